@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/open-policy-agent/opa/compile"
+	"github.com/urfave/cli/v2"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/signatures/budget"
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+)
+
+func benchCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "bench",
+		Usage:     "measure the per-event cost of the signatures against recorded events",
+		ArgsUsage: "<events file>",
+		Description: "The events file holds recorded events (one per line, as output by 'tracee --output json').\n" +
+			"Every signature is replayed the events it selects, and their costs are printed, the costliest first.\n" +
+			"With --budget, the command fails if a signature exceeds the per-event time budget.",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "rules",
+				Usage: "select which rules to load. Specify multiple rules by repeating this flag",
+			},
+			&cli.StringFlag{
+				Name:  "rules-dir",
+				Usage: "directory where to search for rules in OPA (.rego) and Go plugin (.so) formats",
+			},
+			&cli.StringSliceFlag{
+				Name:  "signature-plugins",
+				Usage: "verify the Go plugin rules before loading them. see '--signature-plugins help' for more info",
+			},
+			&cli.BoolFlag{
+				Name:  "rego-partial-eval",
+				Usage: "enable partial evaluation of rego rules",
+			},
+			&cli.BoolFlag{
+				Name:  "rego-aio",
+				Usage: "compile rego signatures altogether as an aggregate policy. By default each signature is compiled separately.",
+			},
+			&cli.DurationFlag{
+				Name:  "budget",
+				Usage: "time a signature may spend handling an event, on average (e.g. 100us). Not enforced if not given",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			logger.Init(logger.NewDefaultLoggingConfig())
+
+			if c.NArg() != 1 {
+				return errfmt.Errorf("a recorded events file is required")
+			}
+
+			var rulesDir []string
+			if c.String("rules-dir") != "" {
+				rulesDir = []string{c.String("rules-dir")}
+			}
+
+			pluginPolicy, err := flags.PrepareSignaturePlugins(c.StringSlice("signature-plugins"))
+			if err != nil {
+				return err
+			}
+
+			sigs, _, err := signature.Find(
+				compile.TargetRego,
+				c.Bool("rego-partial-eval"),
+				rulesDir,
+				c.StringSlice("rules"),
+				c.Bool("rego-aio"),
+				nil,
+				pluginPolicy,
+			)
+			if err != nil {
+				return err
+			}
+
+			events, err := budget.LoadEvents(c.Args().First())
+			if err != nil {
+				return err
+			}
+
+			return printCosts(os.Stdout, budget.Measure(sigs, events), c.Duration("budget"))
+		},
+	}
+}
+
+// printCosts prints the costs of the signatures, failing if one exceeds the budget (if any).
+func printCosts(w io.Writer, costs []budget.Cost, perEventBudget time.Duration) error {
+	exceeding := 0
+
+	fmt.Fprintf(w, "%-10s %-35s %-8s %-12s %s\n", "ID", "NAME", "EVENTS", "PER EVENT", "TOTAL")
+	for _, cost := range costs {
+		mark := ""
+		if perEventBudget > 0 && cost.PerEvent > perEventBudget {
+			mark = " (over budget)"
+			exceeding++
+		}
+		fmt.Fprintf(w, "%-10s %-35s %-8d %-12s %s%s\n", cost.ID, cost.Name, cost.Events, cost.PerEvent, cost.Total, mark)
+	}
+
+	if exceeding > 0 {
+		return errfmt.Errorf("%d signatures exceed the budget of %v per event", exceeding, perEventBudget)
+	}
+
+	return nil
+}
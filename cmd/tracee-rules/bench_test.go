@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/budget"
+)
+
+func Test_printCosts(t *testing.T) {
+	t.Parallel()
+
+	costs := []budget.Cost{
+		{ID: "TST-2", Name: "slow signature", Events: 2, Total: 300 * time.Microsecond, PerEvent: 150 * time.Microsecond},
+		{ID: "TST-1", Name: "fast signature", Events: 4, Total: 8 * time.Microsecond, PerEvent: 2 * time.Microsecond},
+	}
+
+	buf := bytes.Buffer{}
+	assert.NoError(t, printCosts(&buf, costs, 0))
+	assert.Equal(t, `ID         NAME                                EVENTS   PER EVENT    TOTAL
+TST-2      slow signature                      2        150µs        300µs
+TST-1      fast signature                      4        2µs          8µs
+`, buf.String())
+
+	buf.Reset()
+	err := printCosts(&buf, costs, 100*time.Microsecond)
+	assert.ErrorContains(t, err, "1 signatures exceed the budget of 100µs per event")
+	assert.Contains(t, buf.String(), "150µs        300µs (over budget)\n")
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/compile"
+	"github.com/urfave/cli/v2"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/signatures/engine"
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/protocol"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// fixtureFindingsSuffix is the suffix of the expected findings file of a fixture: the fixture
+// <name>.json holds recorded events (one per line, as output by 'tracee --output json'), and
+// <name>.findings.json the number of findings expected from signatures, by signature ID.
+const fixtureFindingsSuffix = ".findings.json"
+
+// fixture is a recorded events file, with the findings expected from replaying it.
+type fixture struct {
+	name     string
+	events   []trace.Event
+	expected map[string]int // findings expected by signature ID
+}
+
+func testCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "test",
+		Usage:     "replay recorded event fixtures against the signatures, asserting their expected findings",
+		ArgsUsage: "<fixture|dir>...",
+		Description: "A fixture <name>.json holds recorded events (one per line, as output by 'tracee --output json').\n" +
+			"The findings expected from replaying it are given in <name>" + fixtureFindingsSuffix + ", as the number\n" +
+			"of findings of each signature to run, by signature ID (e.g. {\"TRC-2\": 1, \"TRC-5\": 0}).\n" +
+			"The directories given are searched for fixtures recursively.",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "rules",
+				Usage: "select which rules to load. Specify multiple rules by repeating this flag",
+			},
+			&cli.StringFlag{
+				Name:  "rules-dir",
+				Usage: "directory where to search for rules in OPA (.rego) and Go plugin (.so) formats",
+			},
+			&cli.StringSliceFlag{
+				Name:  "signature-plugins",
+				Usage: "verify the Go plugin rules before loading them. see '--signature-plugins help' for more info",
+			},
+			&cli.BoolFlag{
+				Name:  "rego-partial-eval",
+				Usage: "enable partial evaluation of rego rules",
+			},
+			&cli.BoolFlag{
+				Name:  "rego-aio",
+				Usage: "compile rego signatures altogether as an aggregate policy. By default each signature is compiled separately.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			logger.Init(logger.NewDefaultLoggingConfig())
+
+			if c.NArg() == 0 {
+				return errfmt.Errorf("no fixtures specified")
+			}
+
+			var rulesDir []string
+			if c.String("rules-dir") != "" {
+				rulesDir = []string{c.String("rules-dir")}
+			}
+
+			pluginPolicy, err := flags.PrepareSignaturePlugins(c.StringSlice("signature-plugins"))
+			if err != nil {
+				return err
+			}
+
+			sigs, _, err := signature.Find(
+				compile.TargetRego,
+				c.Bool("rego-partial-eval"),
+				rulesDir,
+				c.StringSlice("rules"),
+				c.Bool("rego-aio"),
+				nil,
+				pluginPolicy,
+			)
+			if err != nil {
+				return err
+			}
+
+			fixtures, err := findFixtures(c.Args().Slice())
+			if err != nil {
+				return err
+			}
+
+			return testFixtures(c.Context, os.Stdout, sigs, fixtures)
+		},
+	}
+}
+
+// findFixtures returns the fixtures of the given files and directories.
+func findFixtures(paths []string) ([]fixture, error) {
+	var fixtures []fixture
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if !info.IsDir() {
+			fx, err := loadFixture(path)
+			if err != nil {
+				return nil, err
+			}
+			fixtures = append(fixtures, fx)
+			continue
+		}
+
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(p, fixtureFindingsSuffix) {
+				return nil
+			}
+			fx, err := loadFixture(strings.TrimSuffix(p, fixtureFindingsSuffix) + ".json")
+			if err != nil {
+				return err
+			}
+			fixtures = append(fixtures, fx)
+			return nil
+		})
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+
+	return fixtures, nil
+}
+
+// loadFixture loads the fixture of the given recorded events file, and its expected findings.
+func loadFixture(eventsPath string) (fixture, error) {
+	fx := fixture{name: strings.TrimSuffix(eventsPath, ".json")}
+
+	data, err := os.ReadFile(fx.name + fixtureFindingsSuffix)
+	if err != nil {
+		return fixture{}, errfmt.Errorf("fixture %s expected findings: %v", fx.name, err)
+	}
+	if err := json.Unmarshal(data, &fx.expected); err != nil {
+		return fixture{}, errfmt.Errorf("fixture %s expected findings: %v", fx.name, err)
+	}
+
+	f, err := os.Open(eventsPath)
+	if err != nil {
+		return fixture{}, errfmt.Errorf("fixture %s events: %v", fx.name, err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Errorw("Closing file", "error", err)
+		}
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var e trace.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return fixture{}, errfmt.Errorf("fixture %s events, line %d: %v", fx.name, line, err)
+		}
+		fx.events = append(fx.events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return fixture{}, errfmt.Errorf("fixture %s events: %v", fx.name, err)
+	}
+
+	return fx, nil
+}
+
+// testFixtures replays the fixtures against the signatures, reporting the fixtures whose
+// findings aren't the expected ones.
+func testFixtures(ctx context.Context, w io.Writer, sigs []detect.Signature, fixtures []fixture) error {
+	sigsByID := make(map[string]detect.Signature, len(sigs))
+	for _, sig := range sigs {
+		m, err := sig.GetMetadata()
+		if err != nil {
+			continue
+		}
+		sigsByID[m.ID] = sig
+	}
+
+	failed := 0
+	for _, fx := range fixtures {
+		failures, err := testFixture(ctx, sigsByID, fx)
+		if err != nil {
+			return err
+		}
+		if len(failures) == 0 {
+			fmt.Fprintf(w, "PASS %s\n", fx.name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "FAIL %s\n", fx.name)
+		for _, failure := range failures {
+			fmt.Fprintf(w, "    %s\n", failure)
+		}
+	}
+
+	if failed > 0 {
+		return errfmt.Errorf("%d of %d fixtures failed", failed, len(fixtures))
+	}
+
+	return nil
+}
+
+// testFixture replays a fixture against the signatures it expects findings from, and returns
+// the differences with the expected findings.
+func testFixture(ctx context.Context, sigsByID map[string]detect.Signature, fx fixture) ([]string, error) {
+	var failures []string
+
+	ids := make([]string, 0, len(fx.expected))
+	for id := range fx.expected {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var sigs []detect.Signature
+	for _, id := range ids {
+		sig, ok := sigsByID[id]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%s: signature not loaded", id))
+			continue
+		}
+		sigs = append(sigs, sig)
+	}
+
+	findings, err := replay(ctx, sigs, fx.events)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := sigsByID[id]; !ok {
+			continue
+		}
+		if findings[id] != fx.expected[id] {
+			failures = append(failures, fmt.Sprintf("%s: expected %d findings, got %d", id, fx.expected[id], findings[id]))
+		}
+	}
+
+	return failures, nil
+}
+
+// replay runs the signatures on the events with a dedicated engine (the signatures are
+// initialized again, with a fresh state), and returns the number of findings by signature ID.
+func replay(ctx context.Context, sigs []detect.Signature, events []trace.Event) (map[string]int, error) {
+	input := make(chan protocol.Event, len(events))
+	for _, e := range events {
+		input <- e.ToProtocol()
+	}
+	close(input)
+
+	output := make(chan *detect.Finding)
+	config := engine.Config{
+		SignatureBufferSize: 1000,
+		Signatures:          sigs,
+		DataSources:         []detect.DataSource{},
+	}
+	e, err := engine.NewEngine(config, engine.EventSources{Tracee: input}, output)
+	if err != nil {
+		return nil, fmt.Errorf("constructing engine: %w", err)
+	}
+	if err := e.Init(); err != nil {
+		return nil, err
+	}
+
+	go e.Start(ctx)
+
+	// the engine closes the output once all the events are processed
+	findings := make(map[string]int)
+	for {
+		select {
+		case finding, ok := <-output:
+			if !ok {
+				return findings, nil
+			}
+			findings[finding.SigMetadata.ID]++
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
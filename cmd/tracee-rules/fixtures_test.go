@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/protocol"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// newExecSignature returns a signature detecting every execution of the given process.
+func newExecSignature(id string, processName string) detect.Signature {
+	var cb detect.SignatureHandler
+	return &signature.FakeSignature{
+		FakeGetMetadata: func() (detect.SignatureMetadata, error) {
+			return detect.SignatureMetadata{ID: id, Name: id}, nil
+		},
+		FakeGetSelectedEvents: func() ([]detect.SignatureEventSelector, error) {
+			return []detect.SignatureEventSelector{{Source: "tracee", Name: "sched_process_exec"}}, nil
+		},
+		FakeInit: func(ctx detect.SignatureContext) error {
+			cb = ctx.Callback
+			return nil
+		},
+		FakeOnEvent: func(event protocol.Event) error {
+			e, ok := event.Payload.(trace.Event)
+			if ok && e.ProcessName == processName {
+				cb(&detect.Finding{Event: event, SigMetadata: detect.SignatureMetadata{ID: id}})
+			}
+			return nil
+		},
+	}
+}
+
+func writeFixture(t *testing.T, dir, name, events, findings string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), []byte(events), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+fixtureFindingsSuffix), []byte(findings), 0600))
+}
+
+func TestTestFixtures(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	events := `{"timestamp":1,"processName":"nc","eventName":"sched_process_exec"}
+{"timestamp":2,"processName":"nc","eventName":"openat"}
+
+{"timestamp":3,"processName":"ls","eventName":"sched_process_exec"}
+{"timestamp":4,"processName":"nc","eventName":"sched_process_exec"}
+`
+	writeFixture(t, dir, "netcat", events, `{"TST-1": 2, "TST-2": 0}`)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "more"), 0700))
+	writeFixture(t, filepath.Join(dir, "more"), "wrong", events, `{"TST-1": 1, "TST-3": 0}`)
+
+	sigs := []detect.Signature{
+		newExecSignature("TST-1", "nc"),
+		newExecSignature("TST-2", "curl"),
+	}
+
+	fixtures, err := findFixtures([]string{filepath.Join(dir, "netcat.json")})
+	require.NoError(t, err)
+	require.Len(t, fixtures, 1)
+	assert.Len(t, fixtures[0].events, 4)
+
+	var out bytes.Buffer
+	require.NoError(t, testFixtures(context.Background(), &out, sigs, fixtures))
+	assert.Equal(t, "PASS "+filepath.Join(dir, "netcat")+"\n", out.String())
+
+	fixtures, err = findFixtures([]string{dir})
+	require.NoError(t, err)
+	require.Len(t, fixtures, 2)
+
+	out.Reset()
+	err = testFixtures(context.Background(), &out, sigs, fixtures)
+	assert.ErrorContains(t, err, "1 of 2 fixtures failed")
+	assert.Equal(t, "FAIL "+filepath.Join(dir, "more", "wrong")+"\n"+
+		"    TST-3: signature not loaded\n"+
+		"    TST-1: expected 1 findings, got 2\n"+
+		"PASS "+filepath.Join(dir, "netcat")+"\n", out.String())
+}
+
+func TestLoadFixture(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFixture(t, dir, "invalid", "{\"timestamp\":1}\nnot json\n", `{"TST-1": 1}`)
+
+	_, err := loadFixture(filepath.Join(dir, "invalid.json"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = loadFixture(filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "expected findings")
+}
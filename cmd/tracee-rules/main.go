@@ -16,6 +16,7 @@ import (
 	"kernel.org/pub/linux/libs/security/libcap/cap"
 
 	"github.com/aquasecurity/tracee/pkg/capabilities"
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
 	"github.com/aquasecurity/tracee/pkg/cmd/flags/server"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
@@ -59,12 +60,19 @@ func main() {
 				rulesDir = []string{c.String("rules-dir")}
 			}
 
+			pluginPolicy, err := flags.PrepareSignaturePlugins(c.StringSlice("signature-plugins"))
+			if err != nil {
+				return err
+			}
+
 			sigs, _, err := signature.Find(
 				target,
 				c.Bool("rego-partial-eval"),
 				rulesDir,
 				c.StringSlice("rules"),
 				c.Bool("rego-aio"),
+				nil,
+				pluginPolicy,
 			)
 			if err != nil {
 				return err
@@ -176,6 +184,10 @@ func main() {
 
 			return nil
 		},
+		Commands: []*cli.Command{
+			testCommand(),
+			benchCommand(),
+		},
 		Flags: []cli.Flag{
 			&cli.StringSliceFlag{
 				Name:  "rules",
@@ -185,6 +197,10 @@ func main() {
 				Name:  "rules-dir",
 				Usage: "directory where to search for rules in OPA (.rego) and Go plugin (.so) formats",
 			},
+			&cli.StringSliceFlag{
+				Name:  "signature-plugins",
+				Usage: "verify the Go plugin rules before loading them. see '--signature-plugins help' for more info",
+			},
 			&cli.BoolFlag{
 				Name:  "rego-partial-eval",
 				Usage: "enable partial evaluation of rego rules",
@@ -18,6 +18,7 @@ import (
 	"github.com/aquasecurity/tracee/pkg/events"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
 	"github.com/aquasecurity/tracee/pkg/signatures/signature"
 	"github.com/aquasecurity/tracee/types/detect"
 	"github.com/aquasecurity/tracee/types/protocol"
@@ -44,6 +45,13 @@ func init() {
 		"Directory where to search for signatures in OPA (.rego) and Go plugin (.so) formats",
 	)
 
+	// signature-plugins
+	analyze.Flags().StringArray(
+		"signature-plugins",
+		[]string{},
+		"Verify the Go signature plugins before loading them [cosign-key|allow-unsigned]",
+	)
+
 	// rego
 	analyze.Flags().StringArray(
 		"rego",
@@ -76,6 +84,7 @@ tracee analyze --events anti_debugging events.json`,
 		bindViperFlag(cmd, "log")
 		bindViperFlag(cmd, "rego")
 		bindViperFlag(cmd, "signatures-dir")
+		bindViperFlag(cmd, "signature-plugins")
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		logFlags := viper.GetStringSlice("log")
@@ -106,12 +115,28 @@ tracee analyze --events anti_debugging events.json`,
 			signatureEvents = nil
 		}
 
+		pluginPolicy, err := flags.PrepareSignaturePlugins(viper.GetStringSlice("signature-plugins"))
+		if err != nil {
+			logger.Fatalw("Failed to parse signature-plugins flags", "err", err)
+		}
+
+		// the data documents are loaded once: the analysis doesn't refresh them
+		var regoData *regosig.Data
+		if len(rego.Data) > 0 {
+			regoData, err = regosig.NewData(cmd.Context(), rego.Data, 0)
+			if err != nil {
+				logger.Fatalw("Failed to load rego data documents", "err", err)
+			}
+		}
+
 		sigs, _, err := signature.Find(
 			rego.RuntimeTarget,
 			rego.PartialEval,
 			viper.GetStringSlice("signatures-dir"),
 			signatureEvents,
 			rego.AIO,
+			regoData,
+			pluginPolicy,
 		)
 
 		if err != nil {
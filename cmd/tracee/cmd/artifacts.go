@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aquasecurity/tracee/pkg/artifacts"
+)
+
+func init() {
+	rootCmd.AddCommand(artifactsCmd)
+	artifactsCmd.AddCommand(artifactsListCmd, artifactsFetchCmd)
+
+	artifactsCmd.PersistentFlags().String(
+		"server",
+		"localhost:3367",
+		"Address of the tracee data http server (--data-listen-addr)",
+	)
+
+	artifactsListCmd.Flags().String("type", "", "Only list the artifacts of the given capture type (e.g. exec)")
+	artifactsListCmd.Flags().String("container", "", "Only list the artifacts of the given container id")
+	artifactsListCmd.Flags().Bool("json", false, "List the artifacts as JSON")
+
+	artifactsFetchCmd.Flags().StringP("output", "o", "", "File the artifact is written to (default: stdout)")
+}
+
+var artifactsCmd = &cobra.Command{
+	Use:   "artifacts",
+	Short: "List and fetch the artifacts captured by a running tracee",
+	Long: `Artifacts lists and fetches the artifacts captured by a running tracee, indexed with
+their hash, capture type, process and container, through its http server. The running
+tracee must index its captured artifacts (see 'tracee man capture').
+
+eg:
+tracee --capture exec --capture retention-size=1G
+tracee artifacts list --type exec
+tracee artifacts fetch <sha256> -o artifact.bin`,
+}
+
+var artifactsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the captured artifacts, the oldest first",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		artifactType, _ := cmd.Flags().GetString("type")
+		container, _ := cmd.Flags().GetString("container")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		query := url.Values{}
+		if artifactType != "" {
+			query.Set("type", artifactType)
+		}
+		if container != "" {
+			query.Set("container", container)
+		}
+
+		body, err := getArtifacts(cmd, "/artifacts?"+query.Encode())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list artifacts: %v\n", err)
+			os.Exit(1)
+		}
+		defer body.Close()
+
+		if asJSON {
+			if _, err := io.Copy(os.Stdout, body); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read artifacts: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		var list []artifacts.Artifact
+		if err := json.NewDecoder(body).Decode(&list); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read artifacts: %v\n", err)
+			os.Exit(1)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TIME\tTYPE\tSHA256\tSIZE\tPID\tCONTAINER\tPATH")
+		for _, a := range list {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+				a.Time.Format(time.RFC3339), a.Type, a.SHA256, a.Size, a.PID, a.ContainerID, a.Path)
+		}
+		w.Flush()
+	},
+	DisableFlagsInUseLine: true,
+}
+
+var artifactsFetchCmd = &cobra.Command{
+	Use:   "fetch <sha256>",
+	Short: "Fetch the content of a captured artifact, given its hash",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		body, err := getArtifacts(cmd, "/artifacts/"+url.PathEscape(args[0]))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to fetch artifact: %v\n", err)
+			os.Exit(1)
+		}
+		defer body.Close()
+
+		w := io.Writer(os.Stdout)
+		if output != "" {
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0640)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to create %s: %v\n", output, err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if _, err := io.Copy(w, body); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to fetch artifact: %v\n", err)
+			os.Exit(1)
+		}
+	},
+	DisableFlagsInUseLine: true,
+}
+
+// getArtifacts requests an artifacts endpoint of the tracee http server, returning the
+// response body.
+func getArtifacts(cmd *cobra.Command, endpoint string) (io.ReadCloser, error) {
+	server, _ := cmd.Flags().GetString("server")
+	if !strings.Contains(server, "://") {
+		server = "http://" + server
+	}
+
+	client := http.Client{Timeout: 10 * time.Minute}
+	resp, err := client.Get(strings.TrimSuffix(server, "/") + endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return resp.Body, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/aquasecurity/tracee/pkg/cmd"
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
 	"github.com/aquasecurity/tracee/pkg/cmd/initialize"
 	"github.com/aquasecurity/tracee/pkg/events"
 	"github.com/aquasecurity/tracee/pkg/logger"
@@ -26,6 +27,11 @@ func init() {
 		[]string{},
 		"Directories where to search for signatures in OPA (.rego) and Go plugin (.so) formats",
 	)
+	listCmd.Flags().StringArray(
+		"signature-plugins",
+		[]string{},
+		"Verify the Go signature plugins before loading them [cosign-key|allow-unsigned]",
+	)
 }
 
 var listCmd = &cobra.Command{
@@ -41,12 +47,25 @@ var listCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		pluginsFlags, err := c.Flags().GetStringArray("signature-plugins")
+		if err != nil {
+			logger.Fatalw("Failed to get signature-plugins flag", "err", err)
+			os.Exit(1)
+		}
+		pluginPolicy, err := flags.PrepareSignaturePlugins(pluginsFlags)
+		if err != nil {
+			logger.Fatalw("Failed to parse signature-plugins flag", "err", err)
+			os.Exit(1)
+		}
+
 		sigs, _, err := signature.Find(
 			compile.TargetRego,
 			false,
 			sigsDir,
 			nil,
 			false,
+			nil,
+			pluginPolicy,
 		)
 		if err != nil {
 			logger.Fatalw("Failed to find signatures", "err", err)
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aquasecurity/tracee/pkg/ebpf/pinning"
+)
+
+func init() {
+	rootCmd.AddCommand(pinsCmd)
+	pinsCmd.AddCommand(pinsListCmd, pinsCleanupCmd)
+
+	pinsCmd.PersistentFlags().String(
+		"pin-path",
+		"",
+		"Pin namespace (a name, or an absolute bpffs directory) given to tracee with --pin-path",
+	)
+	_ = pinsCmd.MarkPersistentFlagRequired("pin-path")
+}
+
+var pinsCmd = &cobra.Command{
+	Use:   "pins",
+	Short: "List and clean up the eBPF maps pinned by tracee",
+	Long: `Pins lists and cleans up the state eBPF maps pinned to the BPF filesystem by tracee, in a pin
+namespace (see the --pin-path flag). The maps pinned by a tracee are reused by the next tracee of
+the same namespace, until they are cleaned up.
+
+eg:
+tracee --pin-path node-a
+tracee pins list --pin-path node-a
+tracee pins cleanup --pin-path node-a`,
+}
+
+var pinsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the eBPF maps pinned in a pin namespace",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := pinsDir(cmd)
+
+		names, err := pinning.Pinned(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list the pinned eBPF maps: %v\n", err)
+			os.Exit(1)
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	},
+}
+
+var pinsCleanupCmd = &cobra.Command{
+	Use:   "cleanup",
+	Short: "Unpin the eBPF maps of a pin namespace",
+	Long: `Cleanup unpins the eBPF maps of a pin namespace, and removes its directory: the next tracee
+of the namespace starts from empty maps. The maps are freed once no running tracee uses them
+anymore.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := pinsDir(cmd)
+
+		names, err := pinning.Cleanup(dir)
+		for _, name := range names {
+			fmt.Printf("unpinned %s\n", name)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to clean up the pinned eBPF maps: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func pinsDir(cmd *cobra.Command) string {
+	namespace, _ := cmd.Flags().GetString("pin-path")
+
+	dir, err := pinning.Path(namespace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	return dir
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 
 	cmdcobra "github.com/aquasecurity/tracee/pkg/cmd/cobra"
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
 	"github.com/aquasecurity/tracee/pkg/cmd/flags/server"
 	"github.com/aquasecurity/tracee/pkg/cmd/initialize"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
@@ -20,8 +21,9 @@ import (
 )
 
 var (
-	cfgFileFlag string
-	helpFlag    bool
+	cfgFileFlag  string
+	helpFlag     bool
+	validateFlag bool
 
 	rootCmd = &cobra.Command{
 		Use:   "tracee",
@@ -50,6 +52,12 @@ access to hundreds of events that help you understand how your system behaves.`,
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			logger.Init(logger.NewDefaultLoggingConfig())
+
+			if validateFlag {
+				runValidate(cmd)
+				return
+			}
+
 			initialize.SetLibbpfgoCallbacks()
 
 			runner, err := cmdcobra.GetTraceeRunner(cmd, version.GetVersion())
@@ -106,14 +114,51 @@ func initCmd() error {
 		"[name|name.args.pathname...]\tSelect events to trace and event filters",
 	)
 
+	// filter is not bound to viper
+	rootCmd.Flags().StringArray(
+		"filter",
+		[]string{},
+		"<expression>\t\t\tSelect events to trace by a filter expression (a policy)",
+	)
+
 	// policy is not bound to viper
 	rootCmd.Flags().StringArrayP(
 		"policy",
 		"p",
 		[]string{},
-		"[file|dir]\t\t\t\tPath to a policy or directory with policies",
+		"[file|dir|oci]\t\t\tPath to a policy, directory with policies or OCI reference",
 	)
 
+	rootCmd.Flags().StringArray(
+		"policy-quota",
+		[]string{"none"},
+		"[policy|rate|total|sample]\tSample the events of the policies exceeding their quota",
+	)
+	err := viper.BindPFlag("policy-quota", rootCmd.Flags().Lookup("policy-quota"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	rootCmd.Flags().StringArray(
+		"granularity",
+		[]string{"thread"},
+		"[process|window|thread]\tCoalesce the per-thread events of the given events into process-level events",
+	)
+	err = viper.BindPFlag("granularity", rootCmd.Flags().Lookup("granularity"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	rootCmd.Flags().StringArray(
+		"arg-limit",
+		[]string{},
+		"[size|arg=size]\t\tTruncate the events arguments exceeding the given size",
+	)
+	err = viper.BindPFlag("arg-limit", rootCmd.Flags().Lookup("arg-limit"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	// Output flags
 
 	rootCmd.Flags().StringArrayP(
@@ -122,7 +167,17 @@ func initCmd() error {
 		[]string{"table"},
 		"[json|none|webhook...]\t\tControl how and where output is printed",
 	)
-	err := viper.BindPFlag("output", rootCmd.Flags().Lookup("output"))
+	err = viper.BindPFlag("output", rootCmd.Flags().Lookup("output"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	rootCmd.Flags().String(
+		"output-query",
+		"",
+		"<expression>			Select and transform the printed events with a jq expression (table and json outputs)",
+	)
+	err = viper.BindPFlag("output-query", rootCmd.Flags().Lookup("output-query"))
 	if err != nil {
 		return errfmt.WrapError(err)
 	}
@@ -172,13 +227,33 @@ func initCmd() error {
 	rootCmd.Flags().StringArray(
 		"signatures-dir",
 		[]string{},
-		"<dir>\t\t\t\tDirectories where to search for signatures in OPA (.rego) and Go plugin (.so) formats",
+		"<dir|oci>\t\t\t\tDirectories (or OCI references) where to search for signatures in OPA (.rego) and Go plugin (.so) formats",
 	)
 	err = viper.BindPFlag("signatures-dir", rootCmd.Flags().Lookup("signatures-dir"))
 	if err != nil {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().StringArray(
+		"signature-budget",
+		[]string{"none"},
+		"[events|budget|action]\t\tMeasure the signatures against recorded events, enforcing a per-event time budget",
+	)
+	err = viper.BindPFlag("signature-budget", rootCmd.Flags().Lookup("signature-budget"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	rootCmd.Flags().StringArray(
+		"signature-plugins",
+		[]string{},
+		"[cosign-key|allow-unsigned]\t\tVerify the Go signature plugins before loading them",
+	)
+	err = viper.BindPFlag("signature-plugins", rootCmd.Flags().Lookup("signature-plugins"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	rootCmd.Flags().StringArray(
 		"rego",
 		[]string{},
@@ -212,6 +287,16 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().StringArray(
+		"perf-buffer-tuning",
+		[]string{},
+		"[interval|loss-threshold|max-size|stage]\tConfigure the events perf buffer sizing from its loss rate",
+	)
+	err = viper.BindPFlag("perf-buffer-tuning", rootCmd.Flags().Lookup("perf-buffer-tuning"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	rootCmd.Flags().StringArrayP(
 		"cache",
 		"a",
@@ -223,6 +308,40 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().StringArray(
+		"bpf-maps",
+		[]string{},
+		"[stack-addresses|fd-paths|containers|interval|warn-threshold]\tConfigure the eBPF maps sizes and fill level monitoring",
+	)
+	err = viper.BindPFlag("bpf-maps", rootCmd.Flags().Lookup("bpf-maps"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Pin path flags
+
+	rootCmd.Flags().String(
+		"pin-path",
+		"",
+		"<namespace|dir>			Pin the state eBPF maps to the bpffs, reused by the next tracee of the namespace",
+	)
+	err = viper.BindPFlag("pin-path", rootCmd.Flags().Lookup("pin-path"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Stack symbols flags
+
+	rootCmd.Flags().StringArray(
+		"stack-symbols",
+		[]string{"none"},
+		"[symbols|dwarf|cache-size]\t\tSymbolize the events stack addresses, with inlined functions and lines for allowed binaries",
+	)
+	err = viper.BindPFlag("stack-symbols", rootCmd.Flags().Lookup("stack-symbols"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	// Process Tree flags
 
 	rootCmd.Flags().StringArrayP(
@@ -248,6 +367,258 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	// CPU affinity flags
+
+	rootCmd.Flags().StringArray(
+		"cpu-affinity",
+		[]string{"none"},
+		"[cpus|exclude]\t\t\tPin the events pipeline goroutines to CPUs",
+	)
+	err = viper.BindPFlag("cpu-affinity", rootCmd.Flags().Lookup("cpu-affinity"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Event window flags
+
+	rootCmd.Flags().StringArray(
+		"event-window",
+		[]string{"none"},
+		"[duration|max-events|dir]\t\tRetain recent events to be queried through the http server",
+	)
+	err = viper.BindPFlag("event-window", rootCmd.Flags().Lookup("event-window"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Timeline flags
+
+	rootCmd.Flags().StringArray(
+		"timeline",
+		[]string{"none"},
+		"[on-demand|dir|format|max-events]\tRecord per-process timelines exported on exit or on demand",
+	)
+	err = viper.BindPFlag("timeline", rootCmd.Flags().Lookup("timeline"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Flamegraph flags
+
+	rootCmd.Flags().StringArray(
+		"flamegraph",
+		[]string{"none"},
+		"[dir|format|group-by|window]\t\tAggregate the events stack traces into folded stacks or pprof profiles",
+	)
+	err = viper.BindPFlag("flamegraph", rootCmd.Flags().Lookup("flamegraph"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Labels flags
+
+	rootCmd.Flags().StringArray(
+		"labels",
+		[]string{"none"},
+		"[<key>=<value>|cloud]\t\tAttach static labels to every event",
+	)
+	err = viper.BindPFlag("labels", rootCmd.Flags().Lookup("labels"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Suppressions flags
+
+	rootCmd.Flags().StringArray(
+		"suppressions",
+		[]string{"none"},
+		"[file|none]\t\t\tMark the findings matching the allowlist rules of the file as suppressed",
+	)
+	err = viper.BindPFlag("suppressions", rootCmd.Flags().Lookup("suppressions"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Finding context flags
+
+	rootCmd.Flags().StringArray(
+		"finding-context",
+		[]string{"none"},
+		"[before|after|scope|timeout]\tAttach the events preceding and following a finding to it",
+	)
+	err = viper.BindPFlag("finding-context", rootCmd.Flags().Lookup("finding-context"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Finding dedup flags
+
+	rootCmd.Flags().StringArray(
+		"finding-dedup",
+		[]string{"none"},
+		"[window|scope]\t\t\tCollapse the identical findings of a process (or container) within a time window",
+	)
+	err = viper.BindPFlag("finding-dedup", rootCmd.Flags().Lookup("finding-dedup"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Threat intel flags
+
+	rootCmd.Flags().StringArray(
+		"threat-intel",
+		[]string{"none"},
+		"[csv|misp|taxii|refresh]\t\tMatch event indicators against threat intel feeds",
+	)
+	err = viper.BindPFlag("threat-intel", rootCmd.Flags().Lookup("threat-intel"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Vulnerability DB flags
+
+	rootCmd.Flags().StringArray(
+		"vuln-db",
+		[]string{"none"},
+		"[file]\t\t\tMatch the loaded libraries against a vulnerability database snapshot",
+	)
+	err = viper.BindPFlag("vuln-db", rootCmd.Flags().Lookup("vuln-db"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Kubernetes audit logs flags
+
+	rootCmd.Flags().StringArray(
+		"k8s-audit",
+		[]string{"none"},
+		"[file|webhook|window]\t\tCorrelate the Kubernetes audit logs with the pods processes",
+	)
+	err = viper.BindPFlag("k8s-audit", rootCmd.Flags().Lookup("k8s-audit"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Cgroups resource pressure flags
+
+	rootCmd.Flags().StringArray(
+		"cgroup-pressure",
+		[]string{},
+		"[interval|periodic|psi-threshold|throttle-threshold]\tConfigure the containers cgroups resource pressure events",
+	)
+	err = viper.BindPFlag("cgroup-pressure", rootCmd.Flags().Lookup("cgroup-pressure"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Self-limits flags
+
+	rootCmd.Flags().StringArray(
+		"self-limits",
+		[]string{},
+		"[cpu|memory|interval|sample-rate]	Shed load while exceeding the CPU and memory self-limits",
+	)
+	err = viper.BindPFlag("self-limits", rootCmd.Flags().Lookup("self-limits"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Handoff flags
+
+	rootCmd.Flags().StringArray(
+		"handoff",
+		[]string{},
+		"[socket|drain|timeout|max-held]	Hand the events output over from a running tracee, without event loss",
+	)
+	err = viper.BindPFlag("handoff", rootCmd.Flags().Lookup("handoff"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Runtime SBOM flags
+
+	rootCmd.Flags().StringArray(
+		"runtime-sbom",
+		[]string{},
+		"[interval|dir]\tConfigure the containers runtime SBOM events",
+	)
+	err = viper.BindPFlag("runtime-sbom", rootCmd.Flags().Lookup("runtime-sbom"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Network flow stats flags
+
+	rootCmd.Flags().StringArray(
+		"net-flow-stats",
+		[]string{},
+		"[interval]\tConfigure the network flow summary events",
+	)
+	err = viper.BindPFlag("net-flow-stats", rootCmd.Flags().Lookup("net-flow-stats"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Sensitive files flags
+
+	rootCmd.Flags().StringArray(
+		"sensitive-files",
+		[]string{},
+		"[path|defaults]\tConfigure the files matched by the sensitive file access events",
+	)
+	err = viper.BindPFlag("sensitive-files", rootCmd.Flags().Lookup("sensitive-files"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// YARA flags
+
+	rootCmd.Flags().StringArray(
+		"yara",
+		[]string{"none"},
+		"[rules|settle|max-size]\t\tScan the captured artifacts with YARA rules",
+	)
+	err = viper.BindPFlag("yara", rootCmd.Flags().Lookup("yara"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// OCI flags
+
+	rootCmd.Flags().StringArray(
+		"oci",
+		[]string{},
+		"[cosign-key|update-interval|cache-dir]\tConfigure the detection content pulled from OCI registries",
+	)
+	err = viper.BindPFlag("oci", rootCmd.Flags().Lookup("oci"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Control plane flags
+
+	rootCmd.Flags().StringArray(
+		"control-plane",
+		[]string{"none"},
+		"[url|cert|key|ca|agent-id|interval]\tConnect to a central control plane over mutual TLS",
+	)
+	err = viper.BindPFlag("control-plane", rootCmd.Flags().Lookup("control-plane"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Forensic snapshot flags
+
+	rootCmd.Flags().String(
+		"snapshot-dir",
+		"",
+		"<dir>\t\t\t\tEnable on demand forensic snapshots, written as bundles to the given directory",
+	)
+	err = viper.BindPFlag("snapshot-dir", rootCmd.Flags().Lookup("snapshot-dir"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	// Server flags
 
 	rootCmd.Flags().Bool(
@@ -300,6 +671,16 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().String(
+		server.DataListenEndpointFlag,
+		"127.0.0.1:3367",
+		"<url:port>\t\t\t\tListening address of the captured data endpoints server (events, timeline, artifacts and snapshot)",
+	)
+	err = viper.BindPFlag(server.DataListenEndpointFlag, rootCmd.Flags().Lookup(server.DataListenEndpointFlag))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	rootCmd.Flags().String(
 		server.GRPCListenEndpointFlag,
 		"", // disabled by default
@@ -310,8 +691,26 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().StringArray(
+		"split-pipeline",
+		[]string{},
+		"[producer|processor]		Split the events pipeline between the nodes and a remote processor",
+	)
+	err = viper.BindPFlag("split-pipeline", rootCmd.Flags().Lookup("split-pipeline"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	// Other flags
 
+	// validate is not bound to viper
+	rootCmd.Flags().BoolVar(
+		&validateFlag,
+		"validate",
+		false,
+		"\t\t\t\t\tValidate policies and signatures, without attaching probes, and exit",
+	)
+
 	rootCmd.Flags().StringArrayP(
 		"capabilities",
 		"C",
@@ -323,6 +722,26 @@ func initCmd() error {
 		return errfmt.WrapError(err)
 	}
 
+	rootCmd.Flags().StringArray(
+		"btfhub",
+		[]string{"none"},
+		"[archive|mirror|timeout]\t\tFetch the kernel BTF from BTFHub archives or mirrors if missing",
+	)
+	err = viper.BindPFlag("btfhub", rootCmd.Flags().Lookup("btfhub"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	rootCmd.Flags().StringArray(
+		"seccomp",
+		[]string{"none"},
+		"[profile|mode|allow]\t\tConfine tracee to the syscalls it needs with seccomp",
+	)
+	err = viper.BindPFlag("seccomp", rootCmd.Flags().Lookup("seccomp"))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	rootCmd.Flags().String(
 		"install-path",
 		"/tmp/tracee",
@@ -349,6 +768,34 @@ func initCmd() error {
 	return nil
 }
 
+// runValidate validates the given configuration, prints the report and exits with a
+// non-zero status if any error was found.
+func runValidate(cmd *cobra.Command) {
+	logFlags, err := cmdcobra.GetFlagsFromViper("log")
+	if err == nil {
+		var logCfg logger.LoggingConfig
+		logCfg, err = flags.PrepareLogger(logFlags, true)
+		if err == nil {
+			logger.Init(logCfg)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	report, err := cmdcobra.Validate(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+		os.Exit(1)
+	}
+
+	report.Print(os.Stdout)
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
 func checkConfigFlag() {
 	if cfgFileFlag == "" {
 		return
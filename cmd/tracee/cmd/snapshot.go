@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+
+	snapshotCmd.Flags().String(
+		"server",
+		"localhost:3367",
+		"Address of the tracee data http server (--data-listen-addr)",
+	)
+	snapshotCmd.Flags().Bool(
+		"no-bundle",
+		false,
+		"Only emit the snapshot events, don't write an artifact bundle",
+	)
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:     "snapshot",
+	Aliases: []string{"s"},
+	Short:   "Trigger a forensic snapshot on a running tracee",
+	Long: `Snapshot triggers an immediate forensic snapshot on a running tracee: process tree,
+open sockets, loaded kernel modules and namespaces in use.
+
+The snapshot is emitted as snapshot_* events, to all outputs, and written as an artifact bundle
+to the directory given to the running tracee with --snapshot-dir.
+
+eg:
+tracee --snapshot-dir /var/lib/tracee/snapshots --output json:events.json
+tracee snapshot`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		server, _ := cmd.Flags().GetString("server")
+		noBundle, _ := cmd.Flags().GetBool("no-bundle")
+
+		if !strings.Contains(server, "://") {
+			server = "http://" + server
+		}
+		url := fmt.Sprintf("%s/snapshot?bundle=%t", strings.TrimSuffix(server, "/"), !noBundle)
+
+		client := http.Client{Timeout: time.Minute}
+		resp, err := client.Post(url, "", nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to trigger snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read snapshot response: %v\n", err)
+			os.Exit(1)
+		}
+		if resp.StatusCode != http.StatusOK {
+			fmt.Fprintf(os.Stderr, "Failed to trigger snapshot: %s: %s", resp.Status, body)
+			os.Exit(1)
+		}
+
+		fmt.Print(string(body))
+	},
+	DisableFlagsInUseLine: true,
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aquasecurity/tracee/pkg/top"
+)
+
+func init() {
+	rootCmd.AddCommand(topCmd)
+
+	topCmd.Flags().String(
+		"server",
+		"unix:/tmp/tracee.sock",
+		"gRPC server of the running tracee, as given to it with --grpc-listen-addr (tcp:[<host>:]<port> or unix:<path>)",
+	)
+	topCmd.Flags().Duration(
+		"interval",
+		time.Second,
+		"Interval the view is refreshed, and the rates computed, at",
+	)
+	topCmd.Flags().StringArray(
+		"policy",
+		[]string{},
+		"Show the events of the given policy only (repeatable; all the policies by default)",
+	)
+}
+
+var topCmd = &cobra.Command{
+	Use:   "top",
+	Short: "Show the live events of a running tracee",
+	Long: `Top shows, in the terminal, the live events of a running tracee, through its gRPC server (see
+the --grpc-listen-addr flag): the rates of the events, per event and per container, the recent
+findings of the signatures, and the health of the events pipeline (events lost, errors).
+
+The selected event (up/down or k/j keys) is disabled, or enabled back, with the space key: tracee
+stops, or resumes, emitting it. Quit with q.
+
+eg:
+tracee --grpc-listen-addr unix:/tmp/tracee.sock
+tracee top
+tracee top --server tcp:4466 --interval 2s --policy containers`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		server, _ := cmd.Flags().GetString("server")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		policies, _ := cmd.Flags().GetStringArray("policy")
+
+		if interval <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid interval: %s\n", interval)
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		if err := top.Run(ctx, server, interval, policies); err != nil {
+			stop()
+			fmt.Fprintf(os.Stderr, "tracee top: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
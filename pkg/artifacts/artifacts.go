@@ -0,0 +1,362 @@
+// Package artifacts indexes the captured artifacts (their hash, origin and container), and
+// collects them according to a retention policy, so the capture output directory doesn't
+// grow unmanaged. The indexed artifacts can be uploaded to a remote storage, with signed
+// manifests linking them to their origin.
+//
+// The index is a JSON file in the capture output directory rather than an embedded
+// database: it is bounded by the retention policy, fully held in memory to serve lookups,
+// and only rewritten (atomically) when it changed, at most once per settle period.
+package artifacts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+const (
+	// IndexFile is the index file, in the capture output directory.
+	IndexFile = "artifacts.json"
+	// DefaultSettle is the default time an artifact must be left unmodified before it is
+	// indexed, as captured files are written in chunks.
+	DefaultSettle = 2 * time.Second
+	// DefaultCollectInterval is the default interval between two collections of the
+	// artifacts exceeding the retention policy.
+	DefaultCollectInterval = time.Minute
+)
+
+// Config is the artifacts index configuration.
+type Config struct {
+	Enabled  bool
+	MaxAge   time.Duration // artifacts older than this are collected (no limit if 0)
+	MaxSize  int64         // total size of the artifacts, the oldest being collected (no limit if 0)
+	MaxCount int           // number of artifacts, the oldest being collected (no limit if 0)
+	Upload   UploadConfig  // upload of the indexed artifacts (disabled if not enabled)
+}
+
+// ErrInvalidHash is returned when an artifact is looked up by a malformed hash.
+var ErrInvalidHash = errors.New("invalid artifact hash, expected a hex encoded sha256")
+
+// ValidHash returns true if the given hash is a hex encoded sha256, as artifacts are
+// indexed by.
+func ValidHash(hash string) bool {
+	if len(hash) != hex.EncodedLen(sha256.Size) {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Artifact is an indexed captured artifact.
+type Artifact struct {
+	Path        string    `json:"path"`                  // path in the capture output directory
+	Type        string    `json:"type"`                  // capture type, e.g. write, read, exec, mem, module or bpf
+	SHA256      string    `json:"sha256"`                // hash of the content
+	Size        int64     `json:"size"`                  // size of the content, in bytes
+	PID         int       `json:"pid,omitempty"`         // host pid of the process the artifact was captured from, if known
+	ContainerID string    `json:"containerId,omitempty"` // empty for the host
+	Event       string    `json:"event,omitempty"`       // capture event the artifact originates from, if known
+	Time        time.Time `json:"time"`                  // last capture time
+}
+
+// Filter selects artifacts: its empty fields select any artifact.
+type Filter struct {
+	Type        string
+	ContainerID string
+	SHA256      string
+}
+
+func (f Filter) matches(a Artifact) bool {
+	return (f.Type == "" || f.Type == a.Type) &&
+		(f.ContainerID == "" || f.ContainerID == a.ContainerID) &&
+		(f.SHA256 == "" || f.SHA256 == a.SHA256)
+}
+
+// Index indexes the submitted artifacts once they are no longer written to, and persists the
+// index in the capture output directory.
+type Index struct {
+	dir string
+	cfg Config
+
+	mu        sync.Mutex
+	artifacts map[string]Artifact // by path
+	pending   map[string]Artifact // by path, the time being the last submission time
+	dirty     bool                // index changed since it was last saved
+	uploader  *uploader           // nil if the artifacts aren't uploaded
+}
+
+// Open opens the index of the given capture output directory, loading the artifacts indexed
+// by a previous run (the artifacts removed since then are dropped).
+func Open(dir string, cfg Config) (*Index, error) {
+	x := &Index{
+		dir:       dir,
+		cfg:       cfg,
+		artifacts: map[string]Artifact{},
+		pending:   map[string]Artifact{},
+	}
+
+	if cfg.Upload.Enabled() {
+		creds, err := CredentialsFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		x.uploader, err = newUploader(dir, cfg.Upload, creds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, IndexFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return x, nil
+	}
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	var artifacts []Artifact
+	if err := json.Unmarshal(data, &artifacts); err != nil {
+		return nil, errfmt.Errorf("invalid artifacts index %s: %v", filepath.Join(dir, IndexFile), err)
+	}
+	for _, a := range artifacts {
+		if _, err := os.Stat(x.abs(a.Path)); err != nil {
+			x.dirty = true
+			continue
+		}
+		x.artifacts[a.Path] = a
+	}
+
+	return x, nil
+}
+
+func (x *Index) abs(path string) string {
+	return filepath.Join(x.dir, path)
+}
+
+// Submit schedules the indexing of an artifact, given its path in the capture output
+// directory, postponed if it is submitted again (written to) before being indexed.
+func (x *Index) Submit(a Artifact) {
+	a.Time = time.Now()
+
+	x.mu.Lock()
+	x.pending[a.Path] = a
+	x.mu.Unlock()
+}
+
+// Run indexes the settled artifacts and collects the artifacts exceeding the retention
+// policy, saving the index once changed, until the context is done. The indexed artifacts
+// are uploaded if enabled, the ones indexed before the context is done included.
+func (x *Index) Run(ctx context.Context) {
+	ticker := time.NewTicker(DefaultSettle / 2)
+	defer ticker.Stop()
+
+	if x.uploader != nil {
+		go x.uploader.run()
+		defer x.uploader.close()
+	}
+
+	lastCollect := time.Now()
+	for {
+		select {
+		case now := <-ticker.C:
+			x.index(now)
+			if now.Sub(lastCollect) >= DefaultCollectInterval {
+				x.Collect(now)
+				lastCollect = now
+			}
+			if err := x.Save(); err != nil {
+				logger.Warnw("Saving artifacts index", "error", err)
+			}
+		case <-ctx.Done():
+			x.index(time.Now().Add(DefaultSettle))
+			if err := x.Save(); err != nil {
+				logger.Warnw("Saving artifacts index", "error", err)
+			}
+			return
+		}
+	}
+}
+
+// index indexes the pending artifacts not written to since the settle time.
+func (x *Index) index(now time.Time) {
+	x.mu.Lock()
+	var settled []Artifact
+	for path, a := range x.pending {
+		if now.Sub(a.Time) >= DefaultSettle {
+			settled = append(settled, a)
+			delete(x.pending, path)
+		}
+	}
+	x.mu.Unlock()
+
+	for _, a := range settled {
+		size, hash, err := hashFile(x.abs(a.Path))
+		if err != nil {
+			// e.g. renamed once fully captured, the final file being submitted too
+			logger.Debugw("Indexing artifact", "artifact", a.Path, "error", err)
+			continue
+		}
+		a.Size = size
+		a.SHA256 = hash
+
+		x.mu.Lock()
+		x.artifacts[a.Path] = a
+		x.dirty = true
+		x.mu.Unlock()
+
+		if x.uploader != nil {
+			x.uploader.submit(a)
+		}
+	}
+}
+
+func hashFile(path string) (int64, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Errorw("Closing file", "error", err)
+		}
+	}()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Collect removes the artifacts exceeding the retention policy, the oldest first, and
+// returns them.
+func (x *Index) Collect(now time.Time) []Artifact {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	artifacts := x.sorted(Filter{})
+	var size int64
+	for _, a := range artifacts {
+		size += a.Size
+	}
+
+	var collected []Artifact
+	for i, a := range artifacts {
+		kept := len(artifacts) - i
+		if (x.cfg.MaxAge <= 0 || now.Sub(a.Time) <= x.cfg.MaxAge) &&
+			(x.cfg.MaxSize <= 0 || size <= x.cfg.MaxSize) &&
+			(x.cfg.MaxCount <= 0 || kept <= x.cfg.MaxCount) {
+			break
+		}
+		if err := os.Remove(x.abs(a.Path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Warnw("Removing artifact", "artifact", a.Path, "error", err)
+			continue
+		}
+		delete(x.artifacts, a.Path)
+		x.dirty = true
+		size -= a.Size
+		collected = append(collected, a)
+	}
+	if len(collected) > 0 {
+		logger.Debugw("Artifacts collected", "count", len(collected))
+	}
+
+	return collected
+}
+
+// sorted returns the artifacts matching the filter, the oldest first.
+func (x *Index) sorted(f Filter) []Artifact {
+	artifacts := make([]Artifact, 0, len(x.artifacts))
+	for _, a := range x.artifacts {
+		if f.matches(a) {
+			artifacts = append(artifacts, a)
+		}
+	}
+	sort.Slice(artifacts, func(i, j int) bool {
+		if artifacts[i].Time.Equal(artifacts[j].Time) {
+			return artifacts[i].Path < artifacts[j].Path
+		}
+		return artifacts[i].Time.Before(artifacts[j].Time)
+	})
+
+	return artifacts
+}
+
+// List returns the indexed artifacts matching the filter, the oldest first.
+func (x *Index) List(f Filter) []Artifact {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	return x.sorted(f)
+}
+
+// Open opens the content of the latest artifact of the given hash.
+func (x *Index) Open(hash string) (Artifact, *os.File, error) {
+	// an empty hash would match any artifact
+	if !ValidHash(hash) {
+		return Artifact{}, nil, ErrInvalidHash
+	}
+
+	artifacts := x.List(Filter{SHA256: hash})
+	if len(artifacts) == 0 {
+		return Artifact{}, nil, fs.ErrNotExist
+	}
+	a := artifacts[len(artifacts)-1]
+
+	f, err := os.Open(x.abs(a.Path))
+	if err != nil {
+		return Artifact{}, nil, errfmt.WrapError(err)
+	}
+
+	return a, f, nil
+}
+
+// Save writes the index to the capture output directory, if it changed since it was last
+// saved.
+func (x *Index) Save() error {
+	x.mu.Lock()
+	if !x.dirty {
+		x.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(x.sorted(Filter{}), "", "  ")
+	x.dirty = false
+	x.mu.Unlock()
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// written to a temporary file first, so the index is never left partially written
+	tmp := filepath.Join(x.dir, IndexFile+".tmp")
+	err = os.WriteFile(tmp, data, 0640)
+	if err == nil {
+		err = os.Rename(tmp, filepath.Join(x.dir, IndexFile))
+	}
+	if err != nil {
+		x.mu.Lock()
+		x.dirty = true // saved again on the next attempt
+		x.mu.Unlock()
+		return errfmt.WrapError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArtifact(t *testing.T, dir, path, content string) string {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, path)), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, path), []byte(content), 0640))
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func TestIndex(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	x, err := Open(dir, Config{Enabled: true})
+	require.NoError(t, err)
+
+	hash := writeArtifact(t, dir, "abc/exec.ls", "ls")
+	x.Submit(Artifact{Path: "abc/exec.ls", Type: "exec", PID: 42, ContainerID: "abc"})
+	writeArtifact(t, dir, "host/write.dev-1.inode-2", "partial")
+	x.Submit(Artifact{Path: "host/write.dev-1.inode-2", Type: "write"})
+	x.Submit(Artifact{Path: "host/renamed", Type: "module"})
+
+	// the artifacts are indexed once settled
+	x.index(time.Now())
+	assert.Empty(t, x.List(Filter{}))
+
+	// written to again: indexed with the final content
+	writeArtifact(t, dir, "host/write.dev-1.inode-2", "written")
+	x.Submit(Artifact{Path: "host/write.dev-1.inode-2", Type: "write"})
+	x.index(time.Now().Add(DefaultSettle))
+
+	artifacts := x.List(Filter{})
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "abc/exec.ls", artifacts[0].Path)
+	assert.Equal(t, hash, artifacts[0].SHA256)
+	assert.Equal(t, int64(2), artifacts[0].Size)
+	assert.Equal(t, 42, artifacts[0].PID)
+	assert.Equal(t, "host/write.dev-1.inode-2", artifacts[1].Path)
+	assert.Equal(t, int64(len("written")), artifacts[1].Size)
+
+	assert.Len(t, x.List(Filter{ContainerID: "abc"}), 1)
+	assert.Len(t, x.List(Filter{Type: "write"}), 1)
+	assert.Empty(t, x.List(Filter{Type: "mem"}))
+
+	a, f, err := x.Open(hash)
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "ls", string(content))
+	assert.Equal(t, "exec", a.Type)
+
+	_, _, err = x.Open(strings.Repeat("0", 64))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	for _, invalid := range []string{"", "unknown", hash[:63], strings.ToUpper(hash)} {
+		_, _, err = x.Open(invalid)
+		assert.ErrorIs(t, err, ErrInvalidHash, invalid)
+	}
+
+	// the index is persisted, the removed artifacts being dropped when reopened
+	require.NoError(t, x.Save())
+	require.NoError(t, os.Remove(filepath.Join(dir, "abc/exec.ls")))
+	reopened, err := Open(dir, Config{Enabled: true})
+	require.NoError(t, err)
+	artifacts = reopened.List(Filter{})
+	require.Len(t, artifacts, 1)
+	assert.Equal(t, "host/write.dev-1.inode-2", artifacts[0].Path)
+}
+
+func TestIndexCollect(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	testCases := []struct {
+		name      string
+		cfg       Config
+		collected []string
+	}{
+		{
+			name: "no retention policy",
+			cfg:  Config{Enabled: true},
+		},
+		{
+			name:      "max age",
+			cfg:       Config{Enabled: true, MaxAge: 90 * time.Minute},
+			collected: []string{"a", "b"},
+		},
+		{
+			name:      "max size",
+			cfg:       Config{Enabled: true, MaxSize: 5},
+			collected: []string{"a"},
+		},
+		{
+			name:      "max count",
+			cfg:       Config{Enabled: true, MaxCount: 1},
+			collected: []string{"a", "b"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			x, err := Open(dir, tc.cfg)
+			require.NoError(t, err)
+
+			// a: 3 hours old, 3 bytes, b: 2 hours old, 2 bytes, c: 1 hour old, 3 bytes
+			for i, content := range []string{"aaa", "bb", "ccc"} {
+				path := content[:1]
+				hash := writeArtifact(t, dir, path, content)
+				x.artifacts[path] = Artifact{
+					Path:   path,
+					SHA256: hash,
+					Size:   int64(len(content)),
+					Time:   now.Add(-time.Duration(3-i) * time.Hour),
+				}
+			}
+
+			var collected []string
+			for _, a := range x.Collect(now) {
+				collected = append(collected, a.Path)
+				assert.NoFileExists(t, filepath.Join(dir, a.Path))
+			}
+			assert.Equal(t, tc.collected, collected)
+			assert.Len(t, x.List(Filter{}), 3-len(tc.collected))
+		})
+	}
+}
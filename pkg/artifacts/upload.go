@@ -0,0 +1,421 @@
+package artifacts
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+const (
+	// DefaultUploadRegion is the default region of the S3 buckets.
+	DefaultUploadRegion = "us-east-1"
+	// uploadQueueSize is the number of indexed artifacts waiting to be uploaded, the
+	// artifacts indexed while it is full aren't uploaded.
+	uploadQueueSize = 1024
+	// uploadAttempts is the number of attempts to upload an artifact.
+	uploadAttempts = 3
+	// uploadTimeout is the timeout of an upload request.
+	uploadTimeout = 5 * time.Minute
+	// manifestTimeFormat is the time format of the manifests names, sorting them by time.
+	manifestTimeFormat = "20060102T150405.000000000Z"
+)
+
+// UploadConfig is the configuration of the upload of the indexed artifacts to an S3 or GCS
+// bucket, so the evidence collected survives the node termination.
+type UploadConfig struct {
+	URL        string // s3://bucket[/prefix] or gs://bucket[/prefix] (disabled if empty)
+	Region     string // region of the S3 bucket (DefaultUploadRegion if empty)
+	Endpoint   string // S3 compatible endpoint, addressing the bucket in the path (e.g. a MinIO server)
+	SigningKey string // PEM private key signing the manifests (not signed if empty)
+}
+
+// Enabled returns true if the artifacts are uploaded.
+func (c UploadConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// Credentials are the keys signing the upload requests: AWS access keys, or the HMAC keys
+// of the GCS interoperability API.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // temporary credentials only
+}
+
+// CredentialsFromEnv returns the credentials of the standard AWS environment variables.
+func CredentialsFromEnv() (Credentials, error) {
+	creds := Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return Credentials{}, errfmt.Errorf("missing upload credentials: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set (to HMAC keys for GCS)")
+	}
+
+	return creds, nil
+}
+
+// UploadedArtifact is an artifact listed in a manifest, with the object it was uploaded to.
+type UploadedArtifact struct {
+	Artifact
+	Object string `json:"object"` // key of the object in the bucket
+}
+
+// Manifest lists the artifacts uploaded together, linking their objects to the process,
+// container and capture event they originate from. Each manifest is uploaded with its
+// signature (as cosign sign-blob, verified with cosign verify-blob --key).
+type Manifest struct {
+	Host      string             `json:"host"`
+	Time      time.Time          `json:"time"`
+	Artifacts []UploadedArtifact `json:"artifacts"`
+}
+
+// uploader uploads the indexed artifacts, content addressed by their hash, and the
+// manifests of each batch of uploaded artifacts.
+type uploader struct {
+	dir     string
+	client  *http.Client
+	bucket  *url.URL // bucket URL, the objects keys being appended to its path
+	prefix  string   // objects key prefix: the bucket prefix and the host name
+	region  string
+	creds   Credentials
+	key     crypto.Signer // nil if the manifests aren't signed
+	host    string
+	queue   chan Artifact
+	done    chan struct{}
+	retries map[string]int // failed uploads attempts, by artifact path
+}
+
+// newUploader returns an uploader of the artifacts of the given capture output directory.
+func newUploader(dir string, cfg UploadConfig, creds Credentials) (*uploader, error) {
+	target, err := url.Parse(cfg.URL)
+	if err != nil || target.Host == "" {
+		return nil, errfmt.Errorf("invalid artifacts upload url %q, expected s3://bucket[/prefix] or gs://bucket[/prefix]", cfg.URL)
+	}
+
+	region := cfg.Region
+	var bucket string
+	switch target.Scheme {
+	case "s3":
+		if region == "" {
+			region = DefaultUploadRegion
+		}
+		bucket = "https://" + target.Host + ".s3." + region + ".amazonaws.com"
+	case "gs":
+		// the XML API of GCS, interoperable with S3 requests signed with HMAC keys
+		if region == "" {
+			region = "auto"
+		}
+		bucket = "https://storage.googleapis.com/" + target.Host
+	default:
+		return nil, errfmt.Errorf("invalid artifacts upload url %q, expected s3://bucket[/prefix] or gs://bucket[/prefix]", cfg.URL)
+	}
+	if cfg.Endpoint != "" {
+		bucket = strings.TrimSuffix(cfg.Endpoint, "/") + "/" + target.Host
+	}
+	bucketURL, err := url.Parse(bucket)
+	if err != nil {
+		return nil, errfmt.Errorf("invalid artifacts upload endpoint %q: %v", cfg.Endpoint, err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	prefix := strings.Trim(target.Path, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	u := &uploader{
+		dir:     dir,
+		client:  &http.Client{Timeout: uploadTimeout},
+		bucket:  bucketURL,
+		prefix:  prefix + host + "/",
+		region:  region,
+		creds:   creds,
+		host:    host,
+		queue:   make(chan Artifact, uploadQueueSize),
+		done:    make(chan struct{}),
+		retries: map[string]int{},
+	}
+	if cfg.SigningKey != "" {
+		u.key, err = cosign.LoadPrivateKey(cfg.SigningKey)
+		if err != nil {
+			return nil, errfmt.Errorf("error loading the manifests signing key: %v", err)
+		}
+	}
+
+	return u, nil
+}
+
+// submit schedules the upload of an indexed artifact.
+func (u *uploader) submit(a Artifact) {
+	select {
+	case u.queue <- a:
+	default:
+		logger.Warnw("Artifacts upload queue full, artifact not uploaded", "artifact", a.Path)
+	}
+}
+
+// close uploads the artifacts left in the queue and the last manifest, then returns.
+func (u *uploader) close() {
+	close(u.queue)
+	<-u.done
+}
+
+// run uploads the submitted artifacts, and the manifest of the artifacts uploaded since
+// the last one at most once per settle period, until the uploader is closed.
+func (u *uploader) run() {
+	defer close(u.done)
+
+	ticker := time.NewTicker(DefaultSettle)
+	defer ticker.Stop()
+
+	var uploaded []UploadedArtifact
+	var failed []Artifact
+	flush := func(now time.Time) {
+		if len(uploaded) == 0 {
+			return
+		}
+		if err := u.putManifest(uploaded, now); err != nil {
+			logger.Warnw("Uploading artifacts manifest", "error", err)
+			return // uploaded with the next manifest
+		}
+		uploaded = nil
+	}
+	upload := func(a Artifact) {
+		object, err := u.putArtifact(a)
+		if err == nil {
+			delete(u.retries, a.Path)
+			uploaded = append(uploaded, UploadedArtifact{Artifact: a, Object: object})
+			return
+		}
+		u.retries[a.Path]++
+		if u.retries[a.Path] >= uploadAttempts {
+			logger.Warnw("Uploading artifact", "artifact", a.Path, "error", err)
+			delete(u.retries, a.Path)
+			return
+		}
+		logger.Debugw("Uploading artifact, retrying", "artifact", a.Path, "error", err)
+		failed = append(failed, a)
+	}
+
+	for {
+		select {
+		case a, ok := <-u.queue:
+			if !ok {
+				flush(time.Now())
+				return
+			}
+			upload(a)
+		case now := <-ticker.C:
+			retried := failed
+			failed = nil
+			for _, a := range retried {
+				upload(a)
+			}
+			flush(now)
+		}
+	}
+}
+
+// putArtifact uploads an artifact, returning its object key. The content is checked
+// against the indexed hash by the storage, the artifacts modified since they were indexed
+// being rejected.
+func (u *uploader) putArtifact(a Artifact) (string, error) {
+	f, err := os.Open(filepath.Join(u.dir, a.Path))
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Errorw("Closing file", "error", err)
+		}
+	}()
+
+	key := u.prefix + "artifacts/" + a.SHA256
+	if err := u.put(key, f, a.Size, a.SHA256, "application/octet-stream", time.Now()); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// putManifest uploads the manifest of the given uploaded artifacts, and its signature.
+func (u *uploader) putManifest(uploaded []UploadedArtifact, now time.Time) error {
+	data, err := json.MarshalIndent(Manifest{Host: u.host, Time: now.UTC(), Artifacts: uploaded}, "", "  ")
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	key := u.prefix + "manifests/" + now.UTC().Format(manifestTimeFormat) + ".json"
+	if u.key != nil {
+		// the signature is uploaded first, a manifest being never left unsigned
+		signature, err := cosign.SignBase64(u.key, data)
+		if err != nil {
+			return errfmt.Errorf("error signing the artifacts manifest: %v", err)
+		}
+		if err := u.putBytes(key+".sig", signature, "text/plain", now); err != nil {
+			return err
+		}
+	}
+
+	return u.putBytes(key, data, "application/json", now)
+}
+
+func (u *uploader) putBytes(key string, data []byte, contentType string, now time.Time) error {
+	sum := sha256.Sum256(data)
+
+	return u.put(key, bytes.NewReader(data), int64(len(data)), hex.EncodeToString(sum[:]), contentType, now)
+}
+
+// put uploads an object, given the hex encoded sha256 of its content.
+func (u *uploader) put(key string, body io.Reader, size int64, hash, contentType string, now time.Time) error {
+	objectURL, err := url.Parse(u.bucket.String() + "/" + uriEncode(key, false))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	req, err := http.NewRequest(http.MethodPut, objectURL.String(), body)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	signV4(req, hash, u.creds, u.region, "s3", now)
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return errfmt.Errorf("error uploading %s: %v", key, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Errorw("Closing response body", "error", err)
+		}
+	}()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errfmt.Errorf("error uploading %s: %s: %s", key, resp.Status, bytes.TrimSpace(msg))
+	}
+
+	return nil
+}
+
+// signV4 signs a request with the AWS Signature Version 4, given the hex encoded sha256 of
+// its payload: the host and all the headers of the request are signed.
+func signV4(req *http.Request, payloadHash string, creds Credentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if unescaped, err := url.PathUnescape(path); err == nil {
+		path = uriEncode(unescaped, false)
+	}
+
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var params []string
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+
+	return h.Sum(nil)
+}
+
+// uriEncode encodes a string as the AWS Signature Version 4 canonical requests do: all
+// the bytes but the unreserved characters are percent encoded, the slashes too if encodeSlash.
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+
+	return b.String()
+}
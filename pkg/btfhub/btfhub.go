@@ -0,0 +1,266 @@
+// Package btfhub fetches the BTF of the kernels not exposing their own (/sys/kernel/btf/vmlinux),
+// from BTFHub archives or mirrors laid out as the BTFHub archive repository:
+//
+//	<os id>/<os version>/<arch>/<kernel release>.btf[.tar.xz|.tar.gz]
+package btfhub
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// DefaultTimeout is the default timeout of a download from a mirror.
+const DefaultTimeout = 30 * time.Second
+
+const maxBTFSize = 256 << 20 // largest BTF file (or compressed BTF file) read
+
+// extensions are the extensions of the BTF files, by order of preference.
+var extensions = []string{"", ".tar.xz", ".tar.gz"}
+
+var errNotFound = errors.New("BTF not found")
+
+// Config is the BTFHub archives and mirrors the BTF of the kernel is fetched from.
+type Config struct {
+	Archives []string      // local tar archives (optionally gzip compressed) holding BTFHub trees
+	Mirrors  []string      // BTFHub mirrors: http(s) URLs or local directories
+	Timeout  time.Duration // timeout of a download from a mirror
+}
+
+// Enabled returns whether any archive or mirror is configured.
+func (c Config) Enabled() bool {
+	return len(c.Archives) > 0 || len(c.Mirrors) > 0
+}
+
+// Kernel identifies the BTF of a kernel in a BTFHub tree.
+type Kernel struct {
+	OSID      string // e.g. ubuntu
+	OSVersion string // e.g. 20.04
+	Arch      string // uname machine
+	Release   string // uname release
+}
+
+// Path returns the path of the (uncompressed) BTF of the kernel in a BTFHub tree.
+func (k Kernel) Path() string {
+	arch := k.Arch
+	if arch == "aarch64" {
+		arch = "arm64" // BTFHub names
+	}
+
+	return path.Join(k.OSID, k.OSVersion, arch, k.Release+".btf")
+}
+
+// Fetch writes the BTF of a kernel, found in the archives or else in the mirrors, to the output
+// file. It returns the archive or mirror file the BTF was found in.
+func Fetch(cfg Config, kernel Kernel, outFilePath string) (string, error) {
+	if kernel.OSID == "" || kernel.Release == "" {
+		return "", errfmt.Errorf("unknown OS or kernel release")
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	for _, archive := range cfg.Archives {
+		btf, err := fromArchive(archive, kernel.Path())
+		if err != nil {
+			logger.Debugw("BTF: not found in BTFHub archive", "archive", archive, "error", err)
+			continue
+		}
+		return archive, writeFile(outFilePath, btf)
+	}
+
+	for _, mirror := range cfg.Mirrors {
+		for _, ext := range extensions {
+			source, data, err := fromMirror(mirror, kernel.Path()+ext, timeout)
+			if errors.Is(err, errNotFound) {
+				continue
+			}
+			if err == nil {
+				data, err = extract(data, ext)
+			}
+			if err != nil {
+				logger.Debugw("BTF: error fetching from BTFHub mirror", "source", source, "error", err)
+				continue
+			}
+			return source, writeFile(outFilePath, data)
+		}
+	}
+
+	return "", errfmt.Errorf("BTF of kernel %s not found (%s)", kernel.Release, kernel.Path())
+}
+
+// fromArchive reads the BTF at the given path of a BTFHub tree held by a tar archive, which
+// may be below a top directory (e.g. btfhub-archive/).
+func fromArchive(archive string, btfPath string) ([]byte, error) {
+	f, err := os.Open(archive)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Errorw("Closing file", "error", err)
+		}
+	}()
+
+	r, err := decompress(bufio.NewReader(f))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, errNotFound
+		}
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := strings.TrimPrefix(path.Clean(hdr.Name), "/")
+		for _, ext := range extensions {
+			if name == btfPath+ext || strings.HasSuffix(name, "/"+btfPath+ext) {
+				data, err := readAll(tr)
+				if err != nil {
+					return nil, err
+				}
+				return extract(data, ext)
+			}
+		}
+	}
+}
+
+// fromMirror reads a file of a mirror, returning its location.
+func fromMirror(mirror string, filePath string, timeout time.Duration) (string, []byte, error) {
+	if !strings.HasPrefix(mirror, "http://") && !strings.HasPrefix(mirror, "https://") {
+		source := filepath.Join(strings.TrimPrefix(mirror, "file://"), filepath.FromSlash(filePath))
+		data, err := os.ReadFile(source)
+		if errors.Is(err, os.ErrNotExist) {
+			return source, nil, errNotFound
+		}
+		if err != nil {
+			return source, nil, errfmt.WrapError(err)
+		}
+		return source, data, nil
+	}
+
+	source := strings.TrimSuffix(mirror, "/") + "/" + filePath
+	client := http.Client{Timeout: timeout}
+	resp, err := client.Get(source)
+	if err != nil {
+		return source, nil, errfmt.WrapError(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Errorw("Closing response body", "error", err)
+		}
+	}()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return source, nil, errNotFound
+	case resp.StatusCode != http.StatusOK:
+		return source, nil, errfmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	data, err := readAll(resp.Body)
+
+	return source, data, err
+}
+
+// extract returns the BTF of a file with the given extension: the file itself, or the BTF
+// file of a compressed tar archive (as published by BTFHub).
+func extract(data []byte, ext string) ([]byte, error) {
+	var r io.Reader
+	switch ext {
+	case "":
+		return data, nil
+	case ".tar.gz":
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		r = gz
+	case ".tar.xz":
+		// there is no xz decompressor in the standard library
+		xz, err := exec.LookPath("xz")
+		if err != nil {
+			return nil, errfmt.Errorf("xz is required to extract %s files: %v", ext, err)
+		}
+		var out bytes.Buffer
+		cmd := exec.Command(xz, "--decompress", "--stdout")
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stdout = &out
+		if err := cmd.Run(); err != nil {
+			return nil, errfmt.Errorf("error decompressing %s file: %v", ext, err)
+		}
+		r = &out
+	default:
+		return nil, errfmt.Errorf("unknown BTF file extension: %s", ext)
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, errfmt.Errorf("no BTF file in the %s archive", ext)
+		}
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if hdr.Typeflag == tar.TypeReg && strings.HasSuffix(hdr.Name, ".btf") {
+			return readAll(tr)
+		}
+	}
+}
+
+// decompress returns a reader of a file, gzip compressed or not.
+func decompress(r *bufio.Reader) (io.Reader, error) {
+	// a file too short to be compressed is left to the tar reader
+	if magic, _ := r.Peek(2); len(magic) < 2 || magic[0] != 0x1f || magic[1] != 0x8b {
+		return r, nil
+	}
+
+	return gzip.NewReader(r)
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBTFSize+1))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if len(data) > maxBTFSize {
+		return nil, errfmt.Errorf("BTF file larger than %d bytes", maxBTFSize)
+	}
+
+	return data, nil
+}
+
+// writeFile writes the BTF file, replacing any previous one only once complete.
+func writeFile(outFilePath string, btf []byte) error {
+	if err := os.MkdirAll(filepath.Dir(outFilePath), 0755); err != nil {
+		return errfmt.Errorf("could not create BTF directory: %v", err)
+	}
+	tmp := outFilePath + ".tmp"
+	if err := os.WriteFile(tmp, btf, 0644); err != nil {
+		return errfmt.Errorf("could not write BTF file: %v", err)
+	}
+	if err := os.Rename(tmp, outFilePath); err != nil {
+		return errfmt.Errorf("could not write BTF file: %v", err)
+	}
+
+	return nil
+}
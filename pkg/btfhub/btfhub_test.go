@@ -0,0 +1,169 @@
+package btfhub
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKernel = Kernel{OSID: "ubuntu", OSVersion: "20.04", Arch: "aarch64", Release: "5.4.0-42-generic"}
+
+// tarFile returns a tar archive, gzip compressed if asked to, holding the given files.
+func tarFile(t *testing.T, compressed bool, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(&buf)
+	if compressed {
+		tw = tar.NewWriter(gz)
+	}
+	for name, data := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg})
+		require.NoError(t, err)
+		_, err = tw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	if compressed {
+		require.NoError(t, gz.Close())
+	}
+
+	return buf.Bytes()
+}
+
+func writeTestFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestKernelPath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "ubuntu/20.04/arm64/5.4.0-42-generic.btf", testKernel.Path())
+
+	kernel := testKernel
+	kernel.Arch = "x86_64"
+	assert.Equal(t, "ubuntu/20.04/x86_64/5.4.0-42-generic.btf", kernel.Path())
+}
+
+func TestFetchArchive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	btfArchive := tarFile(t, true, map[string][]byte{"5.4.0-42-generic.btf": []byte("packed btf")})
+
+	testCases := []struct {
+		name     string
+		archive  []byte
+		expected string
+	}{
+		{
+			name: "btf file",
+			archive: tarFile(t, false, map[string][]byte{
+				"ubuntu/20.04/arm64/5.4.0-26-generic.btf": []byte("other btf"),
+				"ubuntu/20.04/arm64/5.4.0-42-generic.btf": []byte("btf"),
+			}),
+			expected: "btf",
+		},
+		{
+			name: "compressed btf file below a top directory",
+			archive: tarFile(t, true, map[string][]byte{
+				"btfhub-archive/ubuntu/20.04/arm64/5.4.0-42-generic.btf.tar.gz": btfArchive,
+			}),
+			expected: "packed btf",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			archive := filepath.Join(dir, tc.name+".tar")
+			writeTestFile(t, archive, tc.archive)
+			out := filepath.Join(dir, tc.name, "tracee.btf")
+
+			source, err := Fetch(Config{Archives: []string{archive}}, testKernel, out)
+			require.NoError(t, err)
+			assert.Equal(t, archive, source)
+			btf, err := os.ReadFile(out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(btf))
+		})
+	}
+}
+
+func TestFetchMirrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	emptyMirror := filepath.Join(dir, "empty")
+	require.NoError(t, os.MkdirAll(emptyMirror, 0755))
+	localMirror := filepath.Join(dir, "local")
+	writeTestFile(t, filepath.Join(localMirror, testKernel.Path()), []byte("local btf"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/btfhub/"+testKernel.Path()+".tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(tarFile(t, true, map[string][]byte{"./5.4.0-42-generic.btf": []byte("remote btf")}))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name           string
+		mirrors        []string
+		expectedSource string
+		expected       string
+		expectedError  string
+	}{
+		{
+			name:           "local mirror",
+			mirrors:        []string{emptyMirror, "file://" + localMirror},
+			expectedSource: filepath.Join(localMirror, testKernel.Path()),
+			expected:       "local btf",
+		},
+		{
+			name:           "remote mirror",
+			mirrors:        []string{emptyMirror, server.URL + "/btfhub/"},
+			expectedSource: server.URL + "/btfhub/" + testKernel.Path() + ".tar.gz",
+			expected:       "remote btf",
+		},
+		{
+			name:          "not found",
+			mirrors:       []string{emptyMirror, server.URL},
+			expectedError: "BTF of kernel 5.4.0-42-generic not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			out := filepath.Join(dir, tc.name, "tracee.btf")
+
+			source, err := Fetch(Config{Mirrors: tc.mirrors}, testKernel, out)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				assert.NoFileExists(t, out)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSource, source)
+			btf, err := os.ReadFile(out)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(btf))
+		})
+	}
+}
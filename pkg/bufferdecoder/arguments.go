@@ -0,0 +1,129 @@
+package bufferdecoder
+
+import (
+	"sync"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Arguments holds the raw arguments of an event, decoding them lazily: the buffer is only
+// read as far as needed to return the requested argument, so events dropped before their
+// arguments are needed (e.g. filtered out in userland) never pay for decoding them.
+//
+// Arguments implements trace.ArgsDecoder, and is thread-safe: it may be shared by the copies of
+// the event it is attached to.
+type Arguments struct {
+	mu      sync.Mutex
+	decoder *EbpfDecoder
+	evtDef  events.Definition
+	eventID events.ID
+	decode  argDecoder // generated decoder of the event, nil if it has none
+	argnum  int        // number of arguments in the buffer
+	read    int        // number of arguments read from the buffer so far
+	args    []trace.Argument
+	found   []bool // whether the argument (by param index) was read from the buffer
+}
+
+// NewArguments creates lazily decoded arguments from the remaining buffer of the decoder.
+// It should be called after decoding the argnum with DecodeUint8, and the decoder should
+// not be used by the caller afterwards.
+func NewArguments(decoder *EbpfDecoder, argnum int, evtDef events.Definition, eventID events.ID) *Arguments {
+	params := evtDef.GetParams()
+
+	return &Arguments{
+		decode:  generatedDecoders[eventID],
+		decoder: decoder,
+		evtDef:  evtDef,
+		eventID: eventID,
+		argnum:  argnum,
+		args:    make([]trace.Argument, len(params)),
+		found:   make([]bool, len(params)),
+	}
+}
+
+// readNext reads the next argument from the buffer.
+func (a *Arguments) readNext() (int, error) {
+	a.read++
+
+	var idx uint
+	var arg trace.Argument
+	var err error
+	if a.decode != nil {
+		idx, arg, err = readArgGenerated(a.decode, a.eventID, a.decoder, a.evtDef.GetParams())
+	} else {
+		idx, arg, err = readArgFromBuff(a.eventID, a.decoder, a.evtDef.GetParams())
+	}
+	if err != nil {
+		return -1, errfmt.Errorf("failed to read argument %d of event %s: %v", a.read-1, a.evtDef.GetName(), err)
+	}
+	if a.found[idx] {
+		logger.Warnw("argument overridden from buffer", "error", errfmt.Errorf("read more than one instance of argument %s of event %s. Saved value: %v. New value: %v", arg.Name, a.evtDef.GetName(), a.args[idx].Value, arg.Value))
+	}
+	a.args[idx] = arg
+	a.found[idx] = true
+
+	return int(idx), nil
+}
+
+// Compile-time check to ensure that Arguments implements the trace.ArgsDecoder interface
+var _ trace.ArgsDecoder = &Arguments{}
+
+// Get returns the argument with the given name, decoding the buffer only up to it. The
+// returned boolean is false if the event has no such argument. Like with All, an argument
+// missing from the buffer only has its metadata.
+func (a *Arguments) Get(name string) (trace.Argument, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	params := a.evtDef.GetParams()
+
+	paramIdx := -1
+	for i, p := range params {
+		if p.Name == name {
+			paramIdx = i
+			break
+		}
+	}
+	if paramIdx == -1 {
+		return trace.Argument{}, false
+	}
+
+	for !a.found[paramIdx] && a.read < a.argnum {
+		if _, err := a.readNext(); err != nil {
+			logger.Errorw("error reading argument from buffer", "error", err)
+		}
+	}
+	if !a.found[paramIdx] {
+		return trace.Argument{ArgMeta: params[paramIdx]}, true
+	}
+
+	return a.args[paramIdx], true
+}
+
+// All decodes all the remaining arguments and returns a copy of them, following the order of
+// the event definition params (with metadata filled for arguments missing from the buffer).
+func (a *Arguments) All() []trace.Argument {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for a.read < a.argnum {
+		if _, err := a.readNext(); err != nil {
+			logger.Errorw("error reading argument from buffer", "error", err)
+		}
+	}
+
+	params := a.evtDef.GetParams()
+	for i := range a.args {
+		if !a.found[i] {
+			a.args[i].ArgMeta = params[i]
+		}
+	}
+
+	args := make([]trace.Argument, len(a.args))
+	copy(args, a.args)
+
+	return args
+}
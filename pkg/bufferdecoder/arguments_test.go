@@ -0,0 +1,79 @@
+package bufferdecoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestArguments(t *testing.T) {
+	t.Parallel()
+
+	evtDef := events.NewDefinition(
+		0,
+		events.Sys32Undefined,
+		"fake_event",
+		events.NewVersion(1, 0, 0),
+		"",
+		"",
+		false,
+		false,
+		[]string{},
+		events.Dependencies{},
+		[]trace.ArgMeta{
+			{Type: "int", Name: "dirfd"},
+			{Type: "const char*", Name: "pathname"},
+			{Type: "int", Name: "flags"},
+		},
+		nil,
+	)
+
+	// arguments are submitted out of order, and "flags" is missing
+	buf := new(bytes.Buffer)
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, uint8(1)))
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, uint32(5)))
+	buf.WriteString("/tmp\x00")
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, uint8(0)))
+	require.NoError(t, binary.Write(buf, binary.LittleEndian, int32(-100)))
+
+	args := NewArguments(New(buf.Bytes()), 2, evtDef, 0)
+	event := &trace.Event{}
+	event.SetArgsDecoder(args)
+
+	pathname, err := event.ArgString("pathname")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp", pathname)
+	assert.Equal(t, 1, args.read) // only the first argument was decoded
+
+	dirfd, err := event.ArgInt("dirfd")
+	require.NoError(t, err)
+	assert.Equal(t, int32(-100), dirfd)
+
+	flags, ok := args.Get("flags")
+	assert.True(t, ok) // defined, but missing from the buffer
+	assert.Equal(t, trace.Argument{ArgMeta: trace.ArgMeta{Type: "int", Name: "flags"}}, flags)
+	_, err = event.ArgInt("flags")
+	assert.Error(t, err)
+	_, err = event.ArgUlong("dirfd")
+	assert.Error(t, err)
+	_, ok = args.Get("nonexistent")
+	assert.False(t, ok)
+
+	event.DecodeArgs()
+	require.Len(t, event.Args, 3)
+	assert.Equal(t, int32(-100), event.Args[0].Value)
+	assert.Equal(t, "/tmp", event.Args[1].Value)
+	assert.Equal(t, "flags", event.Args[2].Name)
+	assert.Nil(t, event.Args[2].Value)
+
+	// the decoded arguments are a copy, not modified by the copies of the event
+	event.Args[1].Value = "/etc"
+	arg, _ := args.Get("pathname")
+	assert.Equal(t, "/tmp", arg.Value)
+}
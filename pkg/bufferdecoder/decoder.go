@@ -12,7 +12,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -91,28 +90,7 @@ func (decoder *EbpfDecoder) DecodeContext(eCtx *EventContext) error {
 //
 // Argument array passed should be initialized with the size of len(eventDefinition.Params).
 func (decoder *EbpfDecoder) DecodeArguments(args []trace.Argument, argnum int, evtDef events.Definition, eventId events.ID) error {
-	for i := 0; i < argnum; i++ {
-		idx, arg, err := readArgFromBuff(
-			eventId,
-			decoder,
-			evtDef.GetParams(),
-		)
-		if err != nil {
-			logger.Errorw("error reading argument from buffer", "error", errfmt.Errorf("failed to read argument %d of event %s: %v", i, evtDef.GetName(), err))
-			continue
-		}
-		if args[idx].Value != nil {
-			logger.Warnw("argument overridden from buffer", "error", errfmt.Errorf("read more than one instance of argument %s of event %s. Saved value: %v. New value: %v", arg.Name, evtDef.GetName(), args[idx].Value, arg.Value))
-		}
-		args[idx] = arg
-	}
-	evtParams := evtDef.GetParams()
-	// Fill missing arguments metadata
-	for i := 0; i < len(evtDef.GetParams()); i++ {
-		if args[i].Value == nil {
-			args[i].ArgMeta = evtParams[i]
-		}
-	}
+	copy(args, NewArguments(decoder, argnum, evtDef, eventId).All())
 	return nil
 }
 
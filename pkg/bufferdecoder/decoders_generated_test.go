@@ -0,0 +1,118 @@
+package bufferdecoder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// TestGeneratedDecoders checks that the generated decoders are in sync with the events
+// definitions (run "make decoders-generate" if it fails).
+func TestGeneratedDecoders(t *testing.T) {
+	t.Parallel()
+
+	for id, def := range events.CoreEvents {
+		params := def.GetParams()
+
+		argTypes, ok := generatedParamTypes[id]
+		if len(params) == 0 {
+			assert.False(t, ok, "event %s has no params but has a generated decoder", def.GetName())
+			continue
+		}
+		require.True(t, ok, "event %s has no generated decoder", def.GetName())
+		require.Contains(t, generatedDecoders, id)
+		require.Len(t, argTypes, len(params), "event %s", def.GetName())
+
+		for i, p := range params {
+			assert.Equal(t, GetParamType(p.Type), argTypes[i], "event %s argument %s", def.GetName(), p.Name)
+		}
+	}
+}
+
+func TestReadArgGenerated(t *testing.T) {
+	t.Parallel()
+
+	params := events.Core.GetDefinitionByID(events.Openat).GetParams()
+	input := openatArgsInput
+
+	decode := generatedDecoders[events.Openat]
+	generated := New(input)
+	generic := New(input)
+	for i := 0; i < 2; i++ {
+		idx, arg, err := readArgGenerated(decode, events.Openat, generated, params)
+		require.NoError(t, err)
+		genericIdx, genericArg, err := readArgFromBuff(events.Openat, generic, params)
+		require.NoError(t, err)
+
+		assert.Equal(t, genericIdx, idx)
+		assert.Equal(t, genericArg, arg)
+	}
+	assert.Empty(t, generated.BuffLen()-generated.ReadAmountBytes())
+
+	_, _, err := readArgGenerated(decode, events.Openat, New([]byte{4}), params)
+	assert.ErrorContains(t, err, "invalid arg index 4")
+}
+
+func TestDecodeArgumentsGenerated(t *testing.T) {
+	t.Parallel()
+
+	evtDef := events.Core.GetDefinitionByID(events.Openat)
+	params := evtDef.GetParams()
+
+	generated := make([]trace.Argument, len(params))
+	err := New(openatArgsInput).DecodeArguments(generated, 2, evtDef, events.Openat)
+	require.NoError(t, err)
+
+	generic := make([]trace.Argument, len(params))
+	decoder := New(openatArgsInput)
+	for i := 0; i < 2; i++ {
+		idx, arg, err := readArgFromBuff(events.Openat, decoder, params)
+		require.NoError(t, err)
+		generic[idx] = arg
+	}
+	for i := range generic {
+		if generic[i].Value == nil {
+			generic[i].ArgMeta = params[i]
+		}
+	}
+
+	assert.Equal(t, generic, generated)
+}
+
+// openatArgsInput holds the pathname and mode arguments of an openat event.
+var openatArgsInput = []byte{
+	3,                      // mode
+	0xED, 0x01, 0x00, 0x00, // 0755
+	1, // pathname
+	5, 0, 0, 0, '/', 't', 'm', 'p', 0,
+}
+
+func BenchmarkDecodeArgumentsGenerated(b *testing.B) {
+	evtDef := events.Core.GetDefinitionByID(events.Openat)
+	args := make([]trace.Argument, len(evtDef.GetParams()))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = New(openatArgsInput).DecodeArguments(args, 2, evtDef, events.Openat)
+		args[0].Value, args[1].Value, args[2].Value, args[3].Value = nil, nil, nil, nil
+	}
+}
+
+func BenchmarkDecodeArgumentsReadArgFromBuff(b *testing.B) {
+	evtDef := events.Core.GetDefinitionByID(events.Openat)
+	params := evtDef.GetParams()
+	args := make([]trace.Argument, len(params))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		decoder := New(openatArgsInput)
+		for j := 0; j < 2; j++ {
+			idx, arg, _ := readArgFromBuff(events.Openat, decoder, params)
+			args[idx] = arg
+		}
+	}
+}
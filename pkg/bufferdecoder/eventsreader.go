@@ -49,6 +49,44 @@ const (
 	boolT
 )
 
+//go:generate go run ./gen -events ../events/core.go -out decoders_generated.go
+
+// argDecoder reads the next argument of a specific event from the buffer, returning its
+// index and value. Decoders are generated for each event definition (see
+// decoders_generated.go), removing the per argument type resolution from the hot path.
+type argDecoder func(d *EbpfDecoder, id events.ID) (uint8, interface{}, error)
+
+// readArgGenerated reads the next argument from the buffer with the generated decoder of
+// the event (see DecodeArguments for the fallback to readArgFromBuff).
+// Return the index of the argument and the parsed argument.
+func readArgGenerated(decode argDecoder, id events.ID, ebpfMsgDecoder *EbpfDecoder, params []trace.ArgMeta) (uint, trace.Argument, error) {
+	var arg trace.Argument
+	argIdx, res, err := decode(ebpfMsgDecoder, id)
+	if err != nil {
+		return uint(argIdx), arg, errfmt.WrapError(err)
+	}
+	arg.ArgMeta = params[argIdx]
+	arg.Value = res
+
+	return uint(argIdx), arg, nil
+}
+
+// decodeArgIndex reads the index of the next argument, checking it against the number of
+// the event params.
+func (decoder *EbpfDecoder) decodeArgIndex(paramsNum int) (uint8, error) {
+	var argIdx uint8
+
+	err := decoder.DecodeUint8(&argIdx)
+	if err != nil {
+		return 0, errfmt.Errorf("error reading arg index: %v", err)
+	}
+	if int(argIdx) >= paramsNum {
+		return 0, errfmt.Errorf("invalid arg index %d", argIdx)
+	}
+
+	return argIdx, nil
+}
+
 // readArgFromBuff read the next argument from the buffer.
 // Return the index of the argument and the parsed argument.
 func readArgFromBuff(id events.ID, ebpfMsgDecoder *EbpfDecoder, params []trace.ArgMeta,
@@ -57,135 +95,50 @@ func readArgFromBuff(id events.ID, ebpfMsgDecoder *EbpfDecoder, params []trace.A
 ) {
 	var err error
 	var res interface{}
-	var argIdx uint8
 	var arg trace.Argument
 
-	err = ebpfMsgDecoder.DecodeUint8(&argIdx)
+	argIdx, err := ebpfMsgDecoder.decodeArgIndex(len(params))
 	if err != nil {
-		return 0, arg, errfmt.Errorf("error reading arg index: %v", err)
-	}
-	if int(argIdx) >= len(params) {
-		return 0, arg, errfmt.Errorf("invalid arg index %d", argIdx)
+		return 0, arg, err
 	}
 	arg.ArgMeta = params[argIdx]
 	argType := GetParamType(arg.Type)
 
 	switch argType {
 	case u8T:
-		var data uint8
-		err = ebpfMsgDecoder.DecodeUint8(&data)
-		res = data
+		res, err = readU8Arg(ebpfMsgDecoder)
 	case u16T:
-		var data uint16
-		err = ebpfMsgDecoder.DecodeUint16(&data)
-		res = data
+		res, err = readU16Arg(ebpfMsgDecoder)
 	case intT:
-		var data int32
-		err = ebpfMsgDecoder.DecodeInt32(&data)
-		res = data
+		res, err = readIntArg(ebpfMsgDecoder)
 	case uintT, devT, modeT:
-		var data uint32
-		err = ebpfMsgDecoder.DecodeUint32(&data)
-		res = data
+		res, err = readUintArg(ebpfMsgDecoder)
 	case longT:
-		var data int64
-		err = ebpfMsgDecoder.DecodeInt64(&data)
-		res = data
+		res, err = readLongArg(ebpfMsgDecoder)
 	case ulongT, offT, sizeT:
-		var data uint64
-		err = ebpfMsgDecoder.DecodeUint64(&data)
-		res = data
+		res, err = readUlongArg(ebpfMsgDecoder)
 	case boolT:
-		var data bool
-		err = ebpfMsgDecoder.DecodeBool(&data)
-		res = data
+		res, err = readBoolArg(ebpfMsgDecoder)
 	case pointerT:
-		var data uint64
-		err = ebpfMsgDecoder.DecodeUint64(&data)
-		res = uintptr(data)
+		res, err = readPointerArg(ebpfMsgDecoder)
 	case sockAddrT:
-		res, err = readSockaddrFromBuff(ebpfMsgDecoder)
+		res, err = readSockAddrArg(ebpfMsgDecoder)
 	case credT:
-		var data SlimCred
-		err = ebpfMsgDecoder.DecodeSlimCred(&data)
-		res = trace.SlimCred(data) // here we cast to trace.SlimCred to ensure we send the public interface and not bufferdecoder.SlimCred
+		res, err = readCredArg(ebpfMsgDecoder)
 	case strT:
-		res, err = readStringFromBuff(ebpfMsgDecoder)
+		res, err = readStrArg(ebpfMsgDecoder)
 	case strArrT:
-		// TODO optimization: create slice after getting arrLen
-		var ss []string
-		var arrLen uint8
-		err = ebpfMsgDecoder.DecodeUint8(&arrLen)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading string array number of elements: %v", err)
-		}
-		for i := 0; i < int(arrLen); i++ {
-			s, err := readStringFromBuff(ebpfMsgDecoder)
-			if err != nil {
-				return uint(argIdx), arg, errfmt.Errorf("error reading string element: %v", err)
-			}
-			ss = append(ss, s)
-		}
-		res = ss
+		res, err = readStrArrArg(ebpfMsgDecoder)
 	case argsArrT:
-		var ss []string
-		var arrLen uint32
-		var argNum uint32
-
-		err = ebpfMsgDecoder.DecodeUint32(&arrLen)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading args array length: %v", err)
-		}
-		err = ebpfMsgDecoder.DecodeUint32(&argNum)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading args number: %v", err)
-		}
-		resBytes, err := ReadByteSliceFromBuff(ebpfMsgDecoder, int(arrLen))
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading args array: %v", err)
-		}
-		ss = strings.Split(string(resBytes), "\x00")
-		if ss[len(ss)-1] == "" {
-			ss = ss[:len(ss)-1]
-		}
-		for int(argNum) > len(ss) {
-			ss = append(ss, "?")
-		}
-		res = ss
+		res, err = readArgsArrArg(ebpfMsgDecoder)
 	case bytesT:
-		var size uint32
-		err = ebpfMsgDecoder.DecodeUint32(&size)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading byte array size: %v", err)
-		}
-		// error if byte buffer is too big (and not a network event)
-		if size > 4096 && (id < events.NetPacketBase || id > events.MaxNetID) {
-			return uint(argIdx), arg, errfmt.Errorf("byte array size too big: %d", size)
-		}
-		res, err = ReadByteSliceFromBuff(ebpfMsgDecoder, int(size))
+		res, err = readBytesArg(ebpfMsgDecoder, id)
 	case intArr2T:
-		var intArray [2]int32
-		err = ebpfMsgDecoder.DecodeIntArray(intArray[:], 2)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading int elements: %v", err)
-		}
-		res = intArray
+		res, err = readIntArr2Arg(ebpfMsgDecoder)
 	case uint64ArrT:
-		ulongArray := make([]uint64, 0)
-		err := ebpfMsgDecoder.DecodeUint64Array(&ulongArray)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.Errorf("error reading ulong elements: %v", err)
-		}
-		res = ulongArray
+		res, err = readUint64ArrArg(ebpfMsgDecoder)
 	case timespecT:
-		var sec int64
-		var nsec int64
-		err = ebpfMsgDecoder.DecodeInt64(&sec)
-		if err != nil {
-			return uint(argIdx), arg, errfmt.WrapError(err)
-		}
-		err = ebpfMsgDecoder.DecodeInt64(&nsec)
-		res = float64(sec) + (float64(nsec) / float64(1000000000))
+		res, err = readTimespecArg(ebpfMsgDecoder)
 
 	default:
 		// if we don't recognize the arg type, we can't parse the rest of the buffer
@@ -198,6 +151,160 @@ func readArgFromBuff(id events.ID, ebpfMsgDecoder *EbpfDecoder, params []trace.A
 	return uint(argIdx), arg, nil
 }
 
+//
+// Argument readers, one per argument type (shared by readArgFromBuff and the generated
+// decoders)
+//
+
+func readU8Arg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data uint8
+	err := ebpfMsgDecoder.DecodeUint8(&data)
+	return data, err
+}
+
+func readU16Arg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data uint16
+	err := ebpfMsgDecoder.DecodeUint16(&data)
+	return data, err
+}
+
+func readIntArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data int32
+	err := ebpfMsgDecoder.DecodeInt32(&data)
+	return data, err
+}
+
+func readUintArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data uint32
+	err := ebpfMsgDecoder.DecodeUint32(&data)
+	return data, err
+}
+
+func readLongArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data int64
+	err := ebpfMsgDecoder.DecodeInt64(&data)
+	return data, err
+}
+
+func readUlongArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data uint64
+	err := ebpfMsgDecoder.DecodeUint64(&data)
+	return data, err
+}
+
+func readBoolArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data bool
+	err := ebpfMsgDecoder.DecodeBool(&data)
+	return data, err
+}
+
+func readPointerArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data uint64
+	err := ebpfMsgDecoder.DecodeUint64(&data)
+	return uintptr(data), err
+}
+
+func readSockAddrArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	return readSockaddrFromBuff(ebpfMsgDecoder)
+}
+
+func readCredArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var data SlimCred
+	err := ebpfMsgDecoder.DecodeSlimCred(&data)
+	return trace.SlimCred(data), err // here we cast to trace.SlimCred to ensure we send the public interface and not bufferdecoder.SlimCred
+}
+
+func readStrArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	return readStringFromBuff(ebpfMsgDecoder)
+}
+
+func readStrArrArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	// TODO optimization: create slice after getting arrLen
+	var ss []string
+	var arrLen uint8
+	err := ebpfMsgDecoder.DecodeUint8(&arrLen)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading string array number of elements: %v", err)
+	}
+	for i := 0; i < int(arrLen); i++ {
+		s, err := readStringFromBuff(ebpfMsgDecoder)
+		if err != nil {
+			return nil, errfmt.Errorf("error reading string element: %v", err)
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+func readArgsArrArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var ss []string
+	var arrLen uint32
+	var argNum uint32
+
+	err := ebpfMsgDecoder.DecodeUint32(&arrLen)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading args array length: %v", err)
+	}
+	err = ebpfMsgDecoder.DecodeUint32(&argNum)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading args number: %v", err)
+	}
+	resBytes, err := ReadByteSliceFromBuff(ebpfMsgDecoder, int(arrLen))
+	if err != nil {
+		return nil, errfmt.Errorf("error reading args array: %v", err)
+	}
+	ss = strings.Split(string(resBytes), "\x00")
+	if ss[len(ss)-1] == "" {
+		ss = ss[:len(ss)-1]
+	}
+	for int(argNum) > len(ss) {
+		ss = append(ss, "?")
+	}
+	return ss, nil
+}
+
+func readBytesArg(ebpfMsgDecoder *EbpfDecoder, id events.ID) (interface{}, error) {
+	var size uint32
+	err := ebpfMsgDecoder.DecodeUint32(&size)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading byte array size: %v", err)
+	}
+	// error if byte buffer is too big (and not a network event)
+	if size > 4096 && (id < events.NetPacketBase || id > events.MaxNetID) {
+		return nil, errfmt.Errorf("byte array size too big: %d", size)
+	}
+	return ReadByteSliceFromBuff(ebpfMsgDecoder, int(size))
+}
+
+func readIntArr2Arg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var intArray [2]int32
+	err := ebpfMsgDecoder.DecodeIntArray(intArray[:], 2)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading int elements: %v", err)
+	}
+	return intArray, nil
+}
+
+func readUint64ArrArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	ulongArray := make([]uint64, 0)
+	err := ebpfMsgDecoder.DecodeUint64Array(&ulongArray)
+	if err != nil {
+		return nil, errfmt.Errorf("error reading ulong elements: %v", err)
+	}
+	return ulongArray, nil
+}
+
+func readTimespecArg(ebpfMsgDecoder *EbpfDecoder) (interface{}, error) {
+	var sec int64
+	var nsec int64
+	err := ebpfMsgDecoder.DecodeInt64(&sec)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	err = ebpfMsgDecoder.DecodeInt64(&nsec)
+	return float64(sec) + (float64(nsec) / float64(1000000000)), err
+}
+
 func GetParamType(paramType string) ArgType {
 	switch paramType {
 	case "int", "pid_t", "uid_t", "gid_t", "mqd_t", "clockid_t", "const clockid_t", "key_t", "key_serial_t", "timer_t":
@@ -248,6 +355,30 @@ func GetParamType(paramType string) ArgType {
 	}
 }
 
+// NormalizeCompatArgs normalizes the arguments of a compat (32-bit) syscall to their 32-bit
+// layout. The registers of a compat syscall are read as 64-bit values, zero extended: the
+// signed long arguments are sign extended (e.g. -1 instead of 4294967295), and the unsigned
+// long, size and pointer arguments are truncated to 32 bits. The 64-bit arguments of compat
+// syscalls (e.g. loff_t, u64) are kept as they are.
+func NormalizeCompatArgs(args []trace.Argument) {
+	for i := range args {
+		switch args[i].Type {
+		case "long":
+			if v, ok := args[i].Value.(int64); ok {
+				args[i].Value = int64(int32(v))
+			}
+		case "unsigned long", "size_t":
+			if v, ok := args[i].Value.(uint64); ok {
+				args[i].Value = uint64(uint32(v))
+			}
+		default:
+			if v, ok := args[i].Value.(uintptr); ok { // pointers
+				args[i].Value = uintptr(uint32(v))
+			}
+		}
+	}
+}
+
 func readSockaddrFromBuff(ebpfMsgDecoder *EbpfDecoder) (map[string]string, error) {
 	res := make(map[string]string, 5)
 	var family int16
@@ -269,11 +400,11 @@ func readSockaddrFromBuff(ebpfMsgDecoder *EbpfDecoder) (map[string]string, error
 					char        sun_path[108];  // Pathname
 			};
 		*/
-		sunPath, err := readStringVarFromBuff(ebpfMsgDecoder, 108)
+		sunPath, err := ReadByteSliceFromBuff(ebpfMsgDecoder, 108)
 		if err != nil {
 			return nil, errfmt.Errorf("error parsing sockaddr_un: %v", err)
 		}
-		res["sun_path"] = sunPath
+		res["sun_path"] = parseSunPath(sunPath)
 	case 2: // AF_INET
 		/*
 			http://man7.org/linux/man-pages/man7/ip.7.html
@@ -369,27 +500,24 @@ func readStringFromBuff(ebpfMsgDecoder *EbpfDecoder) (string, error) {
 	return string(res), nil
 }
 
-// readStringVarFromBuff reads a null-terminated string from `buff`
-// max length can be passed as `max` to optimize memory allocation, otherwise pass 0
-func readStringVarFromBuff(decoder *EbpfDecoder, max int) (string, error) {
-	var err error
-	var char int8
-	res := make([]byte, max)
-	err = decoder.DecodeInt8(&char)
-	if err != nil {
-		return "", errfmt.Errorf("error reading null terminated string: %v", err)
+// parseSunPath returns the pathname of the given sockaddr_un sun_path or, if the socket
+// belongs to the abstract namespace (sun_path starting with a null byte), its name
+// prefixed by "@" (as ss and netstat print them).
+func parseSunPath(sunPath []byte) string {
+	prefix := ""
+	if len(sunPath) > 0 && sunPath[0] == 0 {
+		prefix = "@"
+		sunPath = sunPath[1:]
 	}
-	var count int
-	for count = 1; char != 0 && count < max; count++ {
-		res = append(res, byte(char))
-		err = decoder.DecodeInt8(&char)
-		if err != nil {
-			return "", errfmt.Errorf("error reading null terminated string: %v", err)
-		}
+	// the bytes after the name are not always zeroed
+	if i := bytes.IndexByte(sunPath, 0); i >= 0 {
+		sunPath = sunPath[:i]
 	}
-	res = bytes.TrimLeft(res[:], "\000")
-	decoder.cursor += max - count // move cursor to end of buffer
-	return string(res), nil
+	if len(sunPath) == 0 {
+		return "" // unnamed socket
+	}
+
+	return prefix + string(sunPath)
 }
 
 func ReadByteSliceFromBuff(ebpfMsgDecoder *EbpfDecoder, len int) ([]byte, error) {
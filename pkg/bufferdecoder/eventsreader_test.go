@@ -143,6 +143,23 @@ func TestReadArgFromBuff(t *testing.T) {
 			params:      []trace.ArgMeta{{Type: "struct sockaddr*", Name: "sockAddr0"}},
 			expectedArg: map[string]string{"sa_family": "AF_UNIX", "sun_path": "/tmp/socket"},
 		},
+		{
+			name: "sockAddrT - AF_UNIX abstract",
+			input: append([]byte{0,
+				1, 0, // sa_family=AF_UNIX
+				0, 47, 99, 111, 110, 116, 97, 105, 110, 101, 114, 100, // sun_path=\0/containerd
+			}, make([]byte, 108-12)...),
+			params:      []trace.ArgMeta{{Type: "struct sockaddr*", Name: "sockAddr0"}},
+			expectedArg: map[string]string{"sa_family": "AF_UNIX", "sun_path": "@/containerd"},
+		},
+		{
+			name: "sockAddrT - AF_UNIX unnamed",
+			input: append([]byte{0,
+				1, 0, // sa_family=AF_UNIX
+			}, make([]byte, 108)...),
+			params:      []trace.ArgMeta{{Type: "struct sockaddr*", Name: "sockAddr0"}},
+			expectedArg: map[string]string{"sa_family": "AF_UNIX", "sun_path": ""},
+		},
 		{
 			name:          "unknown",
 			input:         []byte{0xDE, 0xAD, 0xBE, 0xEF},
@@ -188,6 +205,35 @@ func TestReadArgFromBuff(t *testing.T) {
 	}
 }
 
+func TestNormalizeCompatArgs(t *testing.T) {
+	t.Parallel()
+
+	arg := func(argType string, value interface{}) trace.Argument {
+		return trace.Argument{ArgMeta: trace.ArgMeta{Name: argType, Type: argType}, Value: value}
+	}
+	args := []trace.Argument{
+		arg("long", int64(4294967295)),
+		arg("unsigned long", uint64(0xffffffff00000010)),
+		arg("size_t", uint64(0x100000020)),
+		arg("void*", uintptr(0x1ffff0000)),
+		arg("loff_t", uint64(0x100000000)),
+		arg("int", int32(-1)),
+		arg("const char*", "/etc/passwd"),
+	}
+
+	NormalizeCompatArgs(args)
+
+	assert.Equal(t, []trace.Argument{
+		arg("long", int64(-1)),
+		arg("unsigned long", uint64(0x10)),
+		arg("size_t", uint64(0x20)),
+		arg("void*", uintptr(0xffff0000)),
+		arg("loff_t", uint64(0x100000000)),
+		arg("int", int32(-1)),
+		arg("const char*", "/etc/passwd"),
+	}, args)
+}
+
 func TestPrintUint32IP(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,306 @@
+// Command gen generates the per event arguments decoders of the bufferdecoder package out
+// of the events definitions (pkg/events/core.go), so decoding the arguments of an event
+// doesn't need to resolve the type of each argument at runtime.
+//
+// Usage (from pkg/bufferdecoder): go generate
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// paramTypes maps the events params types to the bufferdecoder argument types. It must be
+// kept in sync with bufferdecoder.GetParamType (checked by the package tests).
+var paramTypes = map[string]string{
+	"int":                      "intT",
+	"pid_t":                    "intT",
+	"uid_t":                    "intT",
+	"gid_t":                    "intT",
+	"mqd_t":                    "intT",
+	"clockid_t":                "intT",
+	"const clockid_t":          "intT",
+	"key_t":                    "intT",
+	"key_serial_t":             "intT",
+	"timer_t":                  "intT",
+	"unsigned int":             "uintT",
+	"u32":                      "uintT",
+	"long":                     "longT",
+	"unsigned long":            "ulongT",
+	"u64":                      "ulongT",
+	"bool":                     "boolT",
+	"off_t":                    "offT",
+	"loff_t":                   "offT",
+	"mode_t":                   "modeT",
+	"dev_t":                    "devT",
+	"size_t":                   "sizeT",
+	"void*":                    "pointerT",
+	"const void*":              "pointerT",
+	"char*":                    "strT",
+	"const char*":              "strT",
+	"const char*const*":        "strArrT",
+	"const char**":             "argsArrT",
+	"const struct sockaddr*":   "sockAddrT",
+	"struct sockaddr*":         "sockAddrT",
+	"bytes":                    "bytesT",
+	"int[2]":                   "intArr2T",
+	"slim_cred_t":              "credT",
+	"umode_t":                  "u16T",
+	"u8":                       "u8T",
+	"unsigned long[]":          "uint64ArrT",
+	"[]trace.HookedSymbolData": "uint64ArrT",
+	"struct timespec*":         "timespecT",
+	"const struct timespec*":   "timespecT",
+}
+
+// defaultParamType is used for unsupported types (printed as hex).
+const defaultParamType = "pointerT"
+
+// argReaders maps the bufferdecoder argument types to their readers.
+var argReaders = map[string]string{
+	"u8T":        "readU8Arg",
+	"u16T":       "readU16Arg",
+	"intT":       "readIntArg",
+	"uintT":      "readUintArg",
+	"devT":       "readUintArg",
+	"modeT":      "readUintArg",
+	"longT":      "readLongArg",
+	"ulongT":     "readUlongArg",
+	"offT":       "readUlongArg",
+	"sizeT":      "readUlongArg",
+	"boolT":      "readBoolArg",
+	"pointerT":   "readPointerArg",
+	"sockAddrT":  "readSockAddrArg",
+	"credT":      "readCredArg",
+	"strT":       "readStrArg",
+	"strArrT":    "readStrArrArg",
+	"argsArrT":   "readArgsArrArg",
+	"bytesT":     "readBytesArg",
+	"intArr2T":   "readIntArr2Arg",
+	"uint64ArrT": "readUint64ArrArg",
+	"timespecT":  "readTimespecArg",
+}
+
+type eventParams struct {
+	id    string
+	types []string
+}
+
+func main() {
+	eventsFile := flag.String("events", "../events/core.go", "events definitions file")
+	out := flag.String("out", "decoders_generated.go", "output file")
+	testOut := flag.String("test-out", "param_types_generated_test.go", "output file of the params types (used by the tests)")
+	flag.Parse()
+
+	evts, err := parseEvents(*eventsFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := generate(evts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	testSrc, err := generateParamTypes(evts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*testOut, testSrc, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseEvents returns the params types of each event in the CoreEvents map.
+func parseEvents(path string) ([]eventParams, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var coreEvents *ast.CompositeLit
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "CoreEvents" {
+			return true
+		}
+		coreEvents, _ = spec.Values[0].(*ast.CompositeLit)
+		return false
+	})
+	if coreEvents == nil {
+		return nil, fmt.Errorf("CoreEvents not found in %s", path)
+	}
+
+	var evts []eventParams
+	for _, elt := range coreEvents.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		id, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("%s: unexpected event key", fset.Position(kv.Pos()))
+		}
+		def, ok := kv.Value.(*ast.CompositeLit)
+		if !ok {
+			return nil, fmt.Errorf("%s: unexpected event definition", fset.Position(kv.Pos()))
+		}
+
+		evt := eventParams{id: id.Name}
+		for _, field := range def.Elts {
+			fkv, ok := field.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			if name, ok := fkv.Key.(*ast.Ident); !ok || name.Name != "params" {
+				continue
+			}
+			params, ok := fkv.Value.(*ast.CompositeLit)
+			if !ok {
+				return nil, fmt.Errorf("%s: unexpected params", fset.Position(fkv.Pos()))
+			}
+			for _, p := range params.Elts {
+				paramType, err := paramTypeOf(p)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", fset.Position(p.Pos()), err)
+				}
+				evt.types = append(evt.types, paramType)
+			}
+		}
+		evts = append(evts, evt)
+	}
+
+	sort.Slice(evts, func(i, j int) bool { return evts[i].id < evts[j].id })
+
+	return evts, nil
+}
+
+// paramTypeOf returns the Type field of a trace.ArgMeta composite literal.
+func paramTypeOf(p ast.Expr) (string, error) {
+	param, ok := p.(*ast.CompositeLit)
+	if !ok {
+		return "", fmt.Errorf("unexpected param")
+	}
+	for _, field := range param.Elts {
+		kv, ok := field.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if name, ok := kv.Key.(*ast.Ident); !ok || name.Name != "Type" {
+			continue
+		}
+		lit, ok := kv.Value.(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return "", fmt.Errorf("param type is not a string literal")
+		}
+		return strconv.Unquote(lit.Value)
+	}
+
+	return "", fmt.Errorf("param without type")
+}
+
+func generate(evts []eventParams) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString(`// Code generated by pkg/bufferdecoder/gen. DO NOT EDIT.
+
+package bufferdecoder
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+)
+
+`)
+
+	b.WriteString("// generatedDecoders are the per event arguments decoders.\n")
+	b.WriteString("var generatedDecoders = map[events.ID]argDecoder{\n")
+	for _, evt := range evts {
+		if len(evt.types) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\tevents.%s: decode%sArg,\n", evt.id, evt.id)
+	}
+	b.WriteString("}\n")
+
+	for _, evt := range evts {
+		if len(evt.types) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\nfunc decode%sArg(d *EbpfDecoder, id events.ID) (uint8, interface{}, error) {\n", evt.id)
+		fmt.Fprintf(&b, "\tidx, err := d.decodeArgIndex(%d)\n", len(evt.types))
+		b.WriteString("\tif err != nil {\n\t\treturn 0, nil, err\n\t}\n\n")
+		b.WriteString("\tvar v interface{}\n")
+		b.WriteString("\tswitch idx {\n")
+		for i, t := range evt.types {
+			fmt.Fprintf(&b, "\tcase %d:\n", i)
+			if argTypeOf(t) == "bytesT" {
+				fmt.Fprintf(&b, "\t\tv, err = %s(d, id)\n", readerOf(t))
+			} else {
+				fmt.Fprintf(&b, "\t\tv, err = %s(d)\n", readerOf(t))
+			}
+		}
+		b.WriteString("\t}\n\n")
+		b.WriteString("\treturn idx, v, err\n}\n")
+	}
+
+	return format.Source(b.Bytes())
+}
+
+// generateParamTypes generates the argument types each generated decoder reads, so the
+// tests can check them against bufferdecoder.GetParamType.
+func generateParamTypes(evts []eventParams) ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteString(`// Code generated by pkg/bufferdecoder/gen. DO NOT EDIT.
+
+package bufferdecoder
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+)
+
+`)
+
+	b.WriteString("// generatedParamTypes are the argument types each generated decoder reads.\n")
+	b.WriteString("var generatedParamTypes = map[events.ID][]ArgType{\n")
+	for _, evt := range evts {
+		if len(evt.types) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\tevents.%s: {", evt.id)
+		for i, t := range evt.types {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(argTypeOf(t))
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	return format.Source(b.Bytes())
+}
+
+func argTypeOf(paramType string) string {
+	if argType, ok := paramTypes[paramType]; ok {
+		return argType
+	}
+	return defaultParamType
+}
+
+func readerOf(paramType string) string {
+	return argReaders[argTypeOf(paramType)]
+}
@@ -0,0 +1,123 @@
+// Package buildid extracts the identifiers of the executed binaries: their GNU build ID, for
+// symbol servers lookups, and the build information of Go binaries (toolchain, main module and
+// VCS revision), for binary provenance tracking.
+package buildid
+
+import (
+	"debug/buildinfo"
+	"debug/elf"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Info is the identifiers of a binary, empty if unknown.
+type Info struct {
+	BuildID    string // GNU build ID, as hex
+	GoVersion  string // Go toolchain version, Go binaries only
+	GoModule   string // main module path@version, Go binaries only
+	GoRevision string // VCS revision the binary was built from, Go binaries only
+}
+
+// Read reads the identifiers of an ELF binary.
+func Read(path string) (Info, error) {
+	var info Info
+
+	f, err := os.Open(path)
+	if err != nil {
+		return info, errfmt.WrapError(err)
+	}
+	defer f.Close()
+
+	ef, err := elf.NewFile(f)
+	if err != nil {
+		return info, errfmt.WrapError(err)
+	}
+	info.BuildID = FromELF(ef)
+
+	if bi, err := buildinfo.Read(f); err == nil { // not a Go binary otherwise
+		info.GoVersion = bi.GoVersion
+		info.GoModule = bi.Main.Path
+		if bi.Main.Version != "" {
+			info.GoModule += "@" + bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			if setting.Key == "vcs.revision" {
+				info.GoRevision = setting.Value
+			}
+		}
+	}
+
+	return info, nil
+}
+
+// FromELF returns the GNU build ID of an ELF binary, empty if it has none.
+func FromELF(f *elf.File) string {
+	section := f.Section(".note.gnu.build-id")
+	if section == nil {
+		return ""
+	}
+	note, err := section.Data()
+	if err != nil {
+		return ""
+	}
+
+	return parseNote(note, f.ByteOrder)
+}
+
+// parseNote returns the build ID of a build ID ELF note.
+func parseNote(note []byte, order binary.ByteOrder) string {
+	if len(note) < 16 {
+		return ""
+	}
+
+	// namesz, descsz, type, name (aligned to 4 bytes), desc
+	nameSize := order.Uint32(note[0:4])
+	descSize := order.Uint32(note[4:8])
+	descStart := 12 + (uint64(nameSize)+3)&^3
+	if descStart+uint64(descSize) > uint64(len(note)) {
+		return ""
+	}
+
+	return hex.EncodeToString(note[descStart : descStart+uint64(descSize)])
+}
+
+type cacheEntry struct {
+	ctime int64
+	info  Info
+}
+
+// Cache caches the identifiers of binaries, until they change (their ctime).
+type Cache struct {
+	binaries *lru.Cache[string, cacheEntry]
+}
+
+// NewCache creates a cache of the identifiers of size binaries.
+func NewCache(size int) (*Cache, error) {
+	binaries, err := lru.New[string, cacheEntry](size)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Cache{binaries: binaries}, nil
+}
+
+// Get returns the identifiers of a binary, given by a key identifying it (e.g. its container
+// and path) and its ctime, reading them from the given path if not cached.
+func (c *Cache) Get(key string, ctime int64, path string) (Info, error) {
+	if entry, ok := c.binaries.Get(key); ok && entry.ctime == ctime {
+		return entry.info, nil
+	}
+
+	info, err := Read(path)
+	if err != nil {
+		return info, err
+	}
+	c.binaries.Add(key, cacheEntry{ctime: ctime, info: info})
+
+	return info, nil
+}
@@ -0,0 +1,84 @@
+package buildid
+
+import (
+	"encoding/binary"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNote(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		note     []byte
+		expected string
+	}{
+		{
+			name: "gnu build id",
+			note: []byte{
+				4, 0, 0, 0, // namesz
+				8, 0, 0, 0, // descsz
+				3, 0, 0, 0, // NT_GNU_BUILD_ID
+				'G', 'N', 'U', 0,
+				0xde, 0xad, 0xbe, 0xef, 0x01, 0x02, 0x03, 0x04,
+			},
+			expected: "deadbeef01020304",
+		},
+		{
+			name: "truncated",
+			note: []byte{
+				4, 0, 0, 0,
+				20, 0, 0, 0,
+				3, 0, 0, 0,
+				'G', 'N', 'U', 0,
+				0xde, 0xad,
+			},
+			expected: "",
+		},
+		{
+			name:     "empty",
+			note:     nil,
+			expected: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, parseNote(tc.note, binary.LittleEndian))
+		})
+	}
+}
+
+func TestCacheGet(t *testing.T) {
+	t.Parallel()
+
+	executable, err := os.Executable()
+	require.NoError(t, err)
+
+	c, err := NewCache(2)
+	require.NoError(t, err)
+
+	info, err := c.Get("host:test", 1, executable)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+
+	// cached until the binary ctime changes
+	info, err = c.Get("host:test", 1, "/nonexistent")
+	require.NoError(t, err)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+
+	_, err = c.Get("host:test", 2, "/nonexistent")
+	assert.Error(t, err)
+
+	_, err = Read(os.Args[0] + ".nonexistent")
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+// Package canonpath resolves the paths given by the processes (relative to their working
+// directory or to a directory fd, with "..", "." or symbolic link components) to canonical
+// absolute paths, as seen from the mount namespace of the process, so path filters can't be
+// bypassed with equivalent paths (e.g. /tmp/../etc/shadow or a symbolic link to /etc).
+package canonpath
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	// DefaultCacheSize is the default number of resolved paths cached.
+	DefaultCacheSize = 16384
+	// DefaultCacheTTL is the default time a resolved path is cached for: symbolic links may
+	// be changed, so the resolutions are short lived.
+	DefaultCacheTTL = 10 * time.Second
+
+	maxSymlinks = 40 // symbolic links followed in a path, as the kernel does (ELOOP)
+)
+
+// cacheKey is a path in a mount namespace.
+type cacheKey struct {
+	mountNS int
+	path    string
+}
+
+// cacheEntry is the lookup of a path: whether it is a symbolic link, and its target.
+type cacheEntry struct {
+	symlink bool
+	target  string
+	added   time.Time
+}
+
+// Resolver resolves the paths of the processes to canonical absolute paths. The filesystem
+// of a process is accessed through its /proc/<pid>/root, and the resolved paths components are
+// cached per mount namespace (a dentry cache of sorts).
+type Resolver struct {
+	procRoot string // path of the proc filesystem (/proc)
+	ttl      time.Duration
+	cache    *lru.Cache[cacheKey, cacheEntry]
+}
+
+// New creates a resolver caching the given number of resolved paths for the given time.
+func New(cacheSize int, ttl time.Duration) (*Resolver, error) {
+	return newResolver("/proc", cacheSize, ttl)
+}
+
+func newResolver(procRoot string, cacheSize int, ttl time.Duration) (*Resolver, error) {
+	if cacheSize <= 0 {
+		return nil, errfmt.Errorf("invalid canonical paths cache size: %d", cacheSize)
+	}
+	cache, err := lru.New[cacheKey, cacheEntry](cacheSize)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Resolver{procRoot: procRoot, ttl: ttl, cache: cache}, nil
+}
+
+// Resolve returns the canonical absolute path of a path given by a process, relative to the
+// given directory fd (AT_FDCWD, or any negative fd, for its working directory). The last
+// component is only followed if it's a symbolic link and followLast is set, as the syscalls not
+// following it (e.g. unlink or lstat) apply to the link itself. It returns false if a relative
+// path can't be resolved (e.g. the process already exited). The components of a path that don't
+// exist (e.g. a file being created) are resolved lexically.
+func (r *Resolver) Resolve(pid, mountNS int, dirfd int32, p string, followLast bool) (string, bool) {
+	if p == "" {
+		return "", false
+	}
+
+	procDir := r.procRoot + "/" + strconv.Itoa(pid)
+
+	if !path.IsAbs(p) {
+		var base string
+		var err error
+		if dirfd < 0 {
+			base, err = os.Readlink(procDir + "/cwd")
+		} else {
+			base, err = os.Readlink(procDir + "/fd/" + strconv.Itoa(int(dirfd)))
+		}
+		if err != nil || !path.IsAbs(base) {
+			return "", false
+		}
+		p = base + "/" + p
+	}
+
+	return r.resolveSymlinks(procDir+"/root", mountNS, p, followLast), true
+}
+
+// resolveSymlinks walks an absolute path component by component, following the symbolic links
+// within the given root directory.
+func (r *Resolver) resolveSymlinks(root string, mountNS int, p string, followLast bool) string {
+	resolved := "/"
+	remaining := strings.Split(p, "/")
+	followed := 0
+
+	for len(remaining) > 0 {
+		component := remaining[0]
+		remaining = remaining[1:]
+
+		switch component {
+		case "", ".":
+			continue
+		case "..":
+			resolved = path.Dir(resolved)
+			continue
+		}
+
+		candidate := path.Join(resolved, component)
+		if len(remaining) == 0 && !followLast {
+			return candidate // a trailing slash (a remaining empty component) follows it
+		}
+		entry, ok := r.lookup(root, mountNS, candidate)
+		if !ok {
+			// doesn't exist (or isn't accessible): the rest of the path is resolved lexically
+			return path.Join(append([]string{candidate}, remaining...)...)
+		}
+		if !entry.symlink {
+			resolved = candidate
+			continue
+		}
+
+		followed++
+		if followed > maxSymlinks {
+			return path.Join(append([]string{candidate}, remaining...)...)
+		}
+		target := entry.target
+		if !path.IsAbs(target) {
+			target = path.Join(resolved, target)
+		}
+		// the target is walked again: its own components may be symbolic links
+		resolved = "/"
+		remaining = append(strings.Split(target, "/"), remaining...)
+	}
+
+	return resolved
+}
+
+// lookup returns the cached resolution of a path of a mount namespace, or looks it up.
+func (r *Resolver) lookup(root string, mountNS int, p string) (cacheEntry, bool) {
+	key := cacheKey{mountNS: mountNS, path: p}
+	if entry, ok := r.cache.Get(key); ok && time.Since(entry.added) < r.ttl {
+		return entry, true
+	}
+
+	info, err := os.Lstat(root + p)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	entry := cacheEntry{added: time.Now()}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(root + p)
+		if err != nil {
+			return cacheEntry{}, false
+		}
+		entry.symlink = true
+		entry.target = target
+	}
+	r.cache.Add(key, entry)
+
+	return entry, true
+}
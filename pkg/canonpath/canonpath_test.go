@@ -0,0 +1,118 @@
+package canonpath
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProc creates a fake proc filesystem with a process of pid 100, whose root holds:
+//
+//	/etc/shadow
+//	/app/ (working directory, fd 3)
+//	/app/conf -> /etc (absolute symbolic link, within the process root)
+//	/app/up -> .. (relative symbolic link)
+//	/loop -> /loop
+func newProc(t *testing.T) string {
+	t.Helper()
+
+	procRoot := t.TempDir()
+	procDir := filepath.Join(procRoot, "100")
+	root := filepath.Join(procDir, "root")
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "app"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(procDir, "fd"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "etc", "shadow"), nil, 0600))
+	require.NoError(t, os.Symlink("/etc", filepath.Join(root, "app", "conf")))
+	require.NoError(t, os.Symlink("..", filepath.Join(root, "app", "up")))
+	require.NoError(t, os.Symlink("/loop", filepath.Join(root, "loop")))
+	require.NoError(t, os.Symlink("/app", filepath.Join(procDir, "cwd")))
+	require.NoError(t, os.Symlink("/app", filepath.Join(procDir, "fd", "3")))
+	require.NoError(t, os.Symlink("socket:[1234]", filepath.Join(procDir, "fd", "4")))
+
+	return procRoot
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	r, err := newResolver(newProc(t), DefaultCacheSize, DefaultCacheTTL)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		pid      int
+		dirfd    int32
+		path     string
+		noFollow bool
+		expected string
+		resolved bool
+	}{
+		{name: "canonical", pid: 100, dirfd: -100, path: "/etc/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "dot dot", pid: 100, dirfd: -100, path: "/tmp/../etc/./shadow", expected: "/etc/shadow", resolved: true},
+		{name: "dot dot beyond root", pid: 100, dirfd: -100, path: "/../../etc/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "relative to cwd", pid: 100, dirfd: -100, path: "../etc/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "relative to dirfd", pid: 100, dirfd: 3, path: "conf/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "absolute symlink", pid: 100, dirfd: -100, path: "/app/conf/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "relative symlink", pid: 100, dirfd: -100, path: "/app/up/etc/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "dot dot after symlink", pid: 100, dirfd: -100, path: "/app/conf/../app", expected: "/app", resolved: true},
+		{name: "not existing", pid: 100, dirfd: -100, path: "/app/conf/new/../file", expected: "/etc/file", resolved: true},
+		{name: "last symlink not followed", pid: 100, dirfd: -100, path: "/app/conf/../app/conf", noFollow: true, expected: "/app/conf", resolved: true},
+		{name: "last symlink with trailing slash", pid: 100, dirfd: -100, path: "/app/conf/", noFollow: true, expected: "/etc", resolved: true},
+		{name: "last dot dot", pid: 100, dirfd: -100, path: "/app/up/..", noFollow: true, expected: "/", resolved: true},
+		{name: "symlink loop", pid: 100, dirfd: -100, path: "/loop/file", expected: "/loop/file", resolved: true},
+		{name: "dirfd not a directory", pid: 100, dirfd: 4, path: "file", resolved: false},
+		{name: "exited process absolute", pid: 200, dirfd: -100, path: "/tmp/../etc/shadow", expected: "/etc/shadow", resolved: true},
+		{name: "exited process relative", pid: 200, dirfd: -100, path: "etc/shadow", resolved: false},
+		{name: "empty", pid: 100, dirfd: -100, path: "", resolved: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolved, ok := r.Resolve(tc.pid, 4026531840, tc.dirfd, tc.path, !tc.noFollow)
+			require.Equal(t, tc.resolved, ok)
+			assert.Equal(t, tc.expected, resolved)
+		})
+	}
+}
+
+func TestResolveCacheTTL(t *testing.T) {
+	t.Parallel()
+
+	procRoot := newProc(t)
+	r, err := newResolver(procRoot, DefaultCacheSize, time.Hour)
+	require.NoError(t, err)
+
+	resolved, _ := r.Resolve(100, 1, -100, "/app/conf/shadow", true)
+	assert.Equal(t, "/etc/shadow", resolved)
+
+	// the cached resolutions are kept per mount namespace until they expire
+	link := filepath.Join(procRoot, "100", "root", "app", "conf")
+	require.NoError(t, os.Remove(link))
+	require.NoError(t, os.Symlink("/var", link))
+
+	resolved, _ = r.Resolve(100, 1, -100, "/app/conf/shadow", true)
+	assert.Equal(t, "/etc/shadow", resolved)
+	resolved, _ = r.Resolve(100, 2, -100, "/app/conf/shadow", true)
+	assert.Equal(t, "/var/shadow", resolved)
+
+	r.ttl = 0
+	resolved, _ = r.Resolve(100, 1, -100, "/app/conf/shadow", true)
+	assert.Equal(t, "/var/shadow", resolved)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0, DefaultCacheTTL)
+	assert.ErrorContains(t, err, "invalid canonical paths cache size: 0")
+}
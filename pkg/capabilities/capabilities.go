@@ -27,10 +27,11 @@ const (
 )
 
 type Capabilities struct {
-	have   *cap.Set
-	all    map[cap.Value]map[RingType]bool
-	bypass bool
-	lock   *sync.Mutex // big lock to guarantee all threads are on the same ring
+	have         *cap.Set
+	all          map[cap.Value]map[RingType]bool
+	bypass       bool
+	unprivileged bool        // CAP_BPF and CAP_PERFMON instead of CAP_SYS_ADMIN, never raising non permitted ones
+	lock         *sync.Mutex // big lock to guarantee all threads are on the same ring
 }
 
 // Initialize initializes the "caps" instance (singleton).
@@ -45,6 +46,20 @@ func Initialize(bypass bool) error {
 	return errfmt.WrapError(err)
 }
 
+// InitializeUnprivileged initializes the "caps" instance (singleton) for the unprivileged mode:
+// eBPF is used with CAP_BPF and CAP_PERFMON only (never CAP_SYS_ADMIN), and the capabilities
+// not permitted are never raised, the operations needing them failing instead.
+func InitializeUnprivileged() error {
+	var err error
+
+	once.Do(func() {
+		caps = &Capabilities{unprivileged: true}
+		err = caps.initialize(false)
+	})
+
+	return errfmt.WrapError(err)
+}
+
 // GetInstance returns current "caps" instance. It initializes capabilities if
 // needed, bypassing the privilege dropping by default.
 func GetInstance() *Capabilities {
@@ -121,7 +136,22 @@ func (c *Capabilities) initialize(bypass bool) error {
 	}
 
 	hasBPF, _ := c.have.GetFlag(cap.Permitted, cap.BPF)
-	if hasBPF {
+	if c.unprivileged {
+		hasPerfmon, _ := c.have.GetFlag(cap.Permitted, cap.PERFMON)
+		if !hasBPF || !hasPerfmon {
+			return errfmt.WrapError(unprivilegedMissingCaps())
+		}
+		if paranoid > 2 {
+			return errfmt.WrapError(unprivilegedParanoid(paranoid))
+		}
+		err = c.EBPFRingAdd(
+			cap.BPF,
+			cap.PERFMON,
+		)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+	} else if hasBPF {
 		if paranoid < 2 {
 			err = c.EBPFRingAdd(
 				cap.BPF,
@@ -289,6 +319,28 @@ func (c *Capabilities) BaseRingRemove(values ...cap.Value) error {
 	return c.apply(Base)
 }
 
+// Unprivileged returns whether the capabilities are in the unprivileged mode.
+func (c *Capabilities) Unprivileged() bool {
+	return c.unprivileged
+}
+
+// Missing returns the given capabilities not permitted (none if capabilities are bypassed).
+func (c *Capabilities) Missing(values ...cap.Value) []cap.Value {
+	var missing []cap.Value
+
+	if c.bypass {
+		return missing
+	}
+
+	for _, v := range values {
+		if permitted, _ := c.have.GetFlag(cap.Permitted, v); !permitted {
+			missing = append(missing, v)
+		}
+	}
+
+	return missing
+}
+
 // Private Methods
 
 func (c *Capabilities) ringAdd(ring RingType, values ...cap.Value) error {
@@ -366,10 +418,15 @@ func (c *Capabilities) apply(t RingType) error {
 	logger.Debugw("Capabilities change")
 
 	for k, v := range c.all {
-		if v[t] {
+		effective := v[t]
+		if effective && c.unprivileged {
+			// capabilities not permitted can't be raised, the operations needing them fail
+			effective, _ = c.have.GetFlag(cap.Permitted, k)
+		}
+		if effective {
 			logger.Debugw("Enabling cap", "cap", k)
 		}
-		err = c.have.SetFlag(cap.Effective, v[t], k)
+		err = c.have.SetFlag(cap.Effective, effective, k)
 		if err != nil {
 			return errfmt.WrapError(err)
 		}
@@ -390,6 +447,14 @@ func couldNotReadPerfEventParanoid() error {
 	return fmt.Errorf("could not read procfs perf_event_paranoid")
 }
 
+func unprivilegedMissingCaps() error {
+	return fmt.Errorf("unprivileged mode requires CAP_BPF and CAP_PERFMON (kernel 5.8 or newer)")
+}
+
+func unprivilegedParanoid(paranoid int) error {
+	return fmt.Errorf("unprivileged mode requires perf_event_paranoid of 2 or less (is %d)", paranoid)
+}
+
 func couldNotSetProc(e error) error {
 	return fmt.Errorf("could not set capabilities: %v", e)
 }
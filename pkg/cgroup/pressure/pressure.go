@@ -0,0 +1,217 @@
+// Package pressure monitors the resource pressure of cgroup v2 cgroups: memory limits
+// reached and OOM kills (memory.events), CPU throttling (cpu.stat) and stall times (PSI,
+// the cpu, memory and io pressure files).
+package pressure
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between two samples of the cgroups.
+	DefaultInterval = 10 * time.Second
+	// DefaultPSIThreshold is the default share of the interval (percent) some tasks of a
+	// cgroup must have stalled for a resource to report its pressure.
+	DefaultPSIThreshold = 10
+	// DefaultThrottleThreshold is the default share of the CPU periods (percent) a cgroup
+	// must have been throttled in to report its CPU pressure.
+	DefaultThrottleThreshold = 10
+
+	reportsBuffer = 1024
+)
+
+// Controller is a cgroup v2 controller whose pressure is reported.
+type Controller string
+
+const (
+	Memory Controller = "memory"
+	CPU    Controller = "cpu"
+	IO     Controller = "io"
+)
+
+// Config is the resource pressure monitoring configuration.
+type Config struct {
+	Interval          time.Duration // interval between two samples of the cgroups
+	Periodic          bool          // report the pressure of every cgroup at every interval
+	PSIThreshold      float64       // stall share of the interval (percent) reported, if not periodic
+	ThrottleThreshold float64       // throttled share of the CPU periods (percent) reported, if not periodic
+}
+
+// Cgroup is a monitored cgroup.
+type Cgroup struct {
+	ID   uint32 // cgroup id (32 LSB), identifying the cgroup between samples
+	Path string // absolute path of the cgroup directory
+}
+
+// Report is the pressure of a cgroup controller during an interval. Counters are the
+// increases since the previous sample.
+type Report struct {
+	Cgroup     Cgroup
+	Controller Controller
+	Time       time.Time
+	Interval   time.Duration
+
+	// memory.events (memory controller)
+	MemoryHigh    uint64 // times the memory usage exceeded the high boundary (throttled)
+	MemoryMax     uint64 // times the memory usage reached the max limit
+	MemoryOOM     uint64 // times the memory usage reached the max limit, and allocations failed
+	MemoryOOMKill uint64 // processes killed by the OOM killer
+
+	// cpu.stat (cpu controller)
+	CPUPeriods       uint64 // enforcement periods elapsed
+	CPUThrottled     uint64 // periods the cgroup was throttled in
+	CPUThrottledTime time.Duration
+
+	// PSI (all controllers)
+	SomeStall time.Duration // time some tasks stalled on the resource
+	FullStall time.Duration // time all non-idle tasks stalled on the resource
+}
+
+// Monitor samples the resource pressure of the cgroups at every interval.
+type Monitor struct {
+	cfg     Config
+	cgroups func() []Cgroup
+	samples map[uint32]sample // previous sample of the cgroups
+	reports chan Report
+}
+
+// NewMonitor returns a monitor of the cgroups returned by the given function, called at
+// every interval.
+func NewMonitor(cfg Config, cgroups func() []Cgroup) *Monitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	return &Monitor{
+		cfg:     cfg,
+		cgroups: cgroups,
+		samples: map[uint32]sample{},
+		reports: make(chan Report, reportsBuffer),
+	}
+}
+
+// Reports returns the channel of the resource pressure reports.
+func (m *Monitor) Reports() <-chan Report {
+	return m.reports
+}
+
+// Run samples the cgroups until the context is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	m.check(time.Now()) // baseline samples
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, report := range m.check(now) {
+				select {
+				case m.reports <- report:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check samples the cgroups, returning the reports of their pressure since the previous
+// sample. Cgroups sampled for the first time only have a baseline sample.
+func (m *Monitor) check(now time.Time) []Report {
+	var reports []Report
+	current := map[uint32]sample{}
+
+	for _, cgroup := range m.cgroups() {
+		s := readSample(cgroup.Path, now)
+		current[cgroup.ID] = s
+
+		previous, ok := m.samples[cgroup.ID]
+		if !ok || previous.path != cgroup.Path {
+			continue // new cgroup (or id reused)
+		}
+		reports = append(reports, m.compare(cgroup, previous, s)...)
+	}
+	m.samples = current // removed cgroups are dropped
+
+	return reports
+}
+
+// compare returns the reports of the controllers under pressure between two samples.
+func (m *Monitor) compare(cgroup Cgroup, previous, current sample) []Report {
+	interval := current.time.Sub(previous.time)
+	if interval <= 0 {
+		return nil
+	}
+	base := Report{Cgroup: cgroup, Time: current.time, Interval: interval}
+
+	var reports []Report
+
+	if current.memoryEvents != nil && previous.memoryEvents != nil {
+		r := base
+		r.Controller = Memory
+		r.MemoryHigh = delta(previous.memoryEvents["high"], current.memoryEvents["high"])
+		r.MemoryMax = delta(previous.memoryEvents["max"], current.memoryEvents["max"])
+		r.MemoryOOM = delta(previous.memoryEvents["oom"], current.memoryEvents["oom"])
+		r.MemoryOOMKill = delta(previous.memoryEvents["oom_kill"], current.memoryEvents["oom_kill"])
+		r.SomeStall, r.FullStall = stalls(previous.pressure[Memory], current.pressure[Memory])
+		if m.cfg.Periodic || r.MemoryHigh+r.MemoryMax+r.MemoryOOM+r.MemoryOOMKill > 0 || m.stalled(r) {
+			reports = append(reports, r)
+		}
+	}
+
+	if current.cpuStat != nil && previous.cpuStat != nil {
+		r := base
+		r.Controller = CPU
+		r.CPUPeriods = delta(previous.cpuStat["nr_periods"], current.cpuStat["nr_periods"])
+		r.CPUThrottled = delta(previous.cpuStat["nr_throttled"], current.cpuStat["nr_throttled"])
+		r.CPUThrottledTime = usec(delta(previous.cpuStat["throttled_usec"], current.cpuStat["throttled_usec"]))
+		r.SomeStall, r.FullStall = stalls(previous.pressure[CPU], current.pressure[CPU])
+		throttled := r.CPUThrottled > 0 && r.CPUPeriods > 0 &&
+			float64(r.CPUThrottled)*100/float64(r.CPUPeriods) >= m.cfg.ThrottleThreshold
+		if m.cfg.Periodic || throttled || m.stalled(r) {
+			reports = append(reports, r)
+		}
+	}
+
+	if current.pressure[IO] != nil && previous.pressure[IO] != nil {
+		r := base
+		r.Controller = IO
+		r.SomeStall, r.FullStall = stalls(previous.pressure[IO], current.pressure[IO])
+		if m.cfg.Periodic || m.stalled(r) {
+			reports = append(reports, r)
+		}
+	}
+
+	return reports
+}
+
+// stalled returns true if some tasks stalled on the resource for the PSI threshold share
+// of the interval.
+func (m *Monitor) stalled(r Report) bool {
+	return r.SomeStall > 0 && float64(r.SomeStall)*100/float64(r.Interval) >= m.cfg.PSIThreshold
+}
+
+func stalls(previous, current *psi) (some, full time.Duration) {
+	if previous == nil || current == nil {
+		return 0, 0
+	}
+
+	return usec(delta(previous.some, current.some)), usec(delta(previous.full, current.full))
+}
+
+// delta returns the increase of a counter, 0 if it was reset.
+func delta(previous, current uint64) uint64 {
+	if current < previous {
+		return 0
+	}
+
+	return current - previous
+}
+
+func usec(n uint64) time.Duration {
+	return time.Duration(n) * time.Microsecond
+}
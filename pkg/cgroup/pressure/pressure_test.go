@@ -0,0 +1,145 @@
+package pressure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type counters struct {
+	high, max, oom, oomKill         uint64
+	periods, throttled, throttledUs uint64
+	memStall, cpuStall, ioStall     uint64
+}
+
+func writeCgroup(t *testing.T, dir string, c counters) {
+	t.Helper()
+
+	files := map[string]string{
+		"memory.events": fmt.Sprintf("low 0\nhigh %d\nmax %d\noom %d\noom_kill %d\noom_group_kill 0\n",
+			c.high, c.max, c.oom, c.oomKill),
+		"cpu.stat": fmt.Sprintf("usage_usec 1000\nuser_usec 600\nsystem_usec 400\n"+
+			"nr_periods %d\nnr_throttled %d\nthrottled_usec %d\n", c.periods, c.throttled, c.throttledUs),
+		"memory.pressure": psiFile(c.memStall),
+		"cpu.pressure":    psiFile(c.cpuStall),
+		"io.pressure":     psiFile(c.ioStall),
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+}
+
+func psiFile(stall uint64) string {
+	return fmt.Sprintf("some avg10=1.00 avg60=0.50 avg300=0.10 total=%d\n"+
+		"full avg10=0.00 avg60=0.00 avg300=0.00 total=%d\n", stall, stall/2)
+}
+
+func TestMonitorThresholds(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cgroups := []Cgroup{{ID: 1, Path: dir}}
+	m := NewMonitor(Config{Interval: 10 * time.Second, PSIThreshold: 10, ThrottleThreshold: 10},
+		func() []Cgroup { return cgroups })
+
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	writeCgroup(t, dir, counters{oom: 1, periods: 100, throttled: 50, memStall: 1_000_000})
+	assert.Empty(t, m.check(start), "baseline sample")
+
+	// no pressure: 5 throttled periods out of 100 and a 1s stall out of 10s (below 10%)
+	writeCgroup(t, dir, counters{oom: 1, periods: 200, throttled: 55, memStall: 1_500_000, ioStall: 999_999})
+	assert.Empty(t, m.check(start.Add(10*time.Second)))
+
+	// memory limit reached, cpu throttled and io stalled
+	writeCgroup(t, dir, counters{
+		max: 3, oom: 2, oomKill: 1,
+		periods: 300, throttled: 85, throttledUs: 250_000,
+		memStall: 1_500_000, cpuStall: 100, ioStall: 3_999_999,
+	})
+	reports := m.check(start.Add(20 * time.Second))
+	require.Len(t, reports, 3)
+
+	assert.Equal(t, Report{
+		Cgroup:        cgroups[0],
+		Controller:    Memory,
+		Time:          start.Add(20 * time.Second),
+		Interval:      10 * time.Second,
+		MemoryMax:     3,
+		MemoryOOM:     1,
+		MemoryOOMKill: 1,
+	}, reports[0])
+	assert.Equal(t, CPU, reports[1].Controller)
+	assert.Equal(t, uint64(100), reports[1].CPUPeriods)
+	assert.Equal(t, uint64(30), reports[1].CPUThrottled)
+	assert.Equal(t, 250*time.Millisecond, reports[1].CPUThrottledTime)
+	assert.Equal(t, 100*time.Microsecond, reports[1].SomeStall)
+	assert.Equal(t, IO, reports[2].Controller)
+	assert.Equal(t, 3*time.Second, reports[2].SomeStall)
+	assert.Equal(t, 1500*time.Millisecond, reports[2].FullStall)
+
+	// removed cgroup
+	cgroups = nil
+	assert.Empty(t, m.check(start.Add(30*time.Second)))
+	assert.Empty(t, m.samples)
+}
+
+func TestMonitorPeriodic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// only the memory controller enabled, and no PSI support
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "memory.events"), []byte("high 0\nmax 0\noom 0\noom_kill 0\n"), 0o600))
+
+	m := NewMonitor(Config{Periodic: true}, func() []Cgroup { return []Cgroup{{ID: 1, Path: dir}} })
+	assert.Equal(t, DefaultInterval, m.cfg.Interval)
+
+	now := time.Now()
+	assert.Empty(t, m.check(now))
+	reports := m.check(now.Add(m.cfg.Interval))
+	require.Len(t, reports, 1)
+	assert.Equal(t, Memory, reports[0].Controller)
+	assert.Zero(t, reports[0].MemoryOOM)
+	assert.Zero(t, reports[0].SomeStall)
+}
+
+func TestMonitorRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeCgroup(t, dir, counters{})
+	m := NewMonitor(Config{Interval: 50 * time.Millisecond, Periodic: true},
+		func() []Cgroup { return []Cgroup{{ID: 1, Path: dir}} })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Run(ctx)
+
+	select {
+	case report := <-m.Reports():
+		assert.Equal(t, dir, report.Cgroup.Path)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no report received")
+	}
+}
+
+func TestReadPSI(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cpu.pressure")
+
+	// older kernels have no full line in the cpu pressure file
+	require.NoError(t, os.WriteFile(path, []byte("some avg10=0.00 avg60=0.00 avg300=0.00 total=42\n"), 0o600))
+	assert.Equal(t, &psi{some: 42}, readPSI(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid\n"), 0o600))
+	assert.Nil(t, readPSI(path))
+
+	assert.Nil(t, readPSI(filepath.Join(dir, "missing")))
+}
@@ -0,0 +1,117 @@
+package pressure
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sample is the state of the pressure files of a cgroup. The files of the controllers
+// not enabled in the cgroup (or of PSI, if not supported by the kernel) are missing.
+type sample struct {
+	path         string
+	time         time.Time
+	memoryEvents map[string]uint64 // memory.events
+	cpuStat      map[string]uint64 // cpu.stat
+	pressure     map[Controller]*psi
+}
+
+// psi is the total stall time (usec) of a PSI file.
+type psi struct {
+	some uint64
+	full uint64
+}
+
+func readSample(path string, now time.Time) sample {
+	s := sample{
+		path:         path,
+		time:         now,
+		memoryEvents: readFlatKeyed(filepath.Join(path, "memory.events")),
+		cpuStat:      readFlatKeyed(filepath.Join(path, "cpu.stat")),
+		pressure:     map[Controller]*psi{},
+	}
+	for _, controller := range []Controller{Memory, CPU, IO} {
+		if p := readPSI(filepath.Join(path, string(controller)+".pressure")); p != nil {
+			s.pressure[controller] = p
+		}
+	}
+
+	return s
+}
+
+// readFlatKeyed reads a flat keyed cgroup file ("key value" lines), returning nil if it
+// can't be read.
+func readFlatKeyed(path string) map[string]uint64 {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		values[fields[0]] = value
+	}
+
+	return values
+}
+
+// readPSI reads a PSI file, returning nil if it can't be read:
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//
+// The full line is missing from the cpu pressure file of the older kernels.
+func readPSI(path string) *psi {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var p psi
+	found := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		var total uint64
+		ok := false
+		for _, field := range fields[1:] {
+			value, isTotal := strings.CutPrefix(field, "total=")
+			if !isTotal {
+				continue
+			}
+			if total, err = strconv.ParseUint(value, 10, 64); err == nil {
+				ok = true
+			}
+		}
+		if !ok {
+			continue
+		}
+		switch fields[0] {
+		case "some":
+			p.some, found = total, true
+		case "full":
+			p.full, found = total, true
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	return &p
+}
@@ -0,0 +1,67 @@
+// Package clickhouse inserts the events into a ClickHouse table, with the clickhouse-go driver
+// of database/sql over the native protocol (the TCP protocol of clickhouse-client, port 9000).
+package clickhouse
+
+import (
+	"database/sql"
+	"net/url"
+	"strconv"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go" // registers the clickhouse driver
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const driverName = "clickhouse"
+
+// Options are the connection options.
+type Options struct {
+	Addr     string // host:port
+	Database string
+	User     string
+	Password string
+	Secure   bool // connect over TLS
+	Timeout  time.Duration
+}
+
+// DSN returns the data source name of the driver, of the options.
+func (o Options) DSN() string {
+	query := url.Values{}
+	if o.Database != "" {
+		query.Set("database", o.Database)
+	}
+	if o.User != "" {
+		query.Set("username", o.User)
+	}
+	if o.Password != "" {
+		query.Set("password", o.Password)
+	}
+	if o.Secure {
+		query.Set("secure", "true")
+	}
+	if o.Timeout > 0 {
+		// the driver takes the timeouts in seconds
+		timeout := strconv.FormatFloat(o.Timeout.Seconds(), 'f', -1, 64)
+		query.Set("timeout", timeout)
+		query.Set("read_timeout", timeout)
+		query.Set("write_timeout", timeout)
+	}
+
+	dsn := url.URL{Scheme: "tcp", Host: o.Addr, RawQuery: query.Encode()}
+
+	return dsn.String()
+}
+
+// Open returns a handle of the database, connecting lazily (and again, once the connection
+// failed) when used.
+func Open(opts Options) (*sql.DB, error) {
+	db, err := sql.Open(driverName, opts.DSN())
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	// the inserts are sequential
+	db.SetMaxOpenConns(1)
+
+	return db, nil
+}
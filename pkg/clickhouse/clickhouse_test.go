@@ -0,0 +1,174 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestDSN(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		opts     Options
+		expected string
+	}{
+		{
+			name:     "address only",
+			opts:     Options{Addr: "localhost:9000"},
+			expected: "tcp://localhost:9000",
+		},
+		{
+			name: "all options",
+			opts: Options{
+				Addr:     "clickhouse:9440",
+				Database: "security",
+				User:     "tracee",
+				Password: "p@ss&word",
+				Secure:   true,
+				Timeout:  1500 * time.Millisecond,
+			},
+			expected: "tcp://clickhouse:9440?database=security&password=p%40ss%26word&read_timeout=1.5&secure=true&timeout=1.5&username=tracee&write_timeout=1.5",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.opts.DSN())
+		})
+	}
+}
+
+func TestEventsSchema(t *testing.T) {
+	t.Parallel()
+
+	schema := EventsSchema("tracee.events", 0)
+	assert.True(t, strings.HasPrefix(schema, "CREATE TABLE IF NOT EXISTS tracee.events ("))
+	assert.NotContains(t, schema, "TTL")
+	for _, column := range eventsColumns {
+		assert.Contains(t, schema, "\n    "+column+" ")
+	}
+
+	schema = EventsSchema("tracee.events", 30*24*time.Hour)
+	assert.True(t, strings.HasSuffix(schema, "\nTTL toDateTime(timestamp) + INTERVAL 2592000 SECOND"))
+}
+
+func TestEventValues(t *testing.T) {
+	t.Parallel()
+
+	event := trace.Event{
+		Timestamp:       1712345678901234567,
+		EventName:       "security_file_open",
+		EventID:         730,
+		HostName:        "node",
+		HostProcessID:   1234,
+		HostThreadID:    1235,
+		ProcessName:     "cat",
+		ReturnValue:     -2,
+		Container:       trace.Container{ID: "0123456789ab", Name: "nginx", ImageName: "nginx:latest"},
+		Kubernetes:      trace.Kubernetes{PodName: "web", PodNamespace: "default"},
+		MatchedPolicies: []string{"files", "containers"},
+	}
+
+	values, err := eventValues(&event)
+	require.NoError(t, err)
+	require.Len(t, values, len(eventsColumns))
+
+	data := values[len(values)-1]
+	assert.Contains(t, data, `"processName":"cat"`)
+	assert.Equal(t, []any{
+		int64(1712345678901234567), "security_file_open", int64(730), "node", "0123456789ab",
+		"nginx", "nginx:latest", "web", "default", uint32(1234), uint32(1235), uint32(0),
+		uint32(0), "cat", "", int64(-2), []string{"files", "containers"}, data,
+	}, values)
+}
+
+// TestInsertEventsServer inserts events into the ClickHouse server listening on the address of
+// TRACEE_CLICKHOUSE_ADDR (e.g. of a clickhouse/clickhouse-server container), and reads them back.
+func TestInsertEventsServer(t *testing.T) {
+	t.Parallel()
+
+	addr := os.Getenv("TRACEE_CLICKHOUSE_ADDR")
+	if addr == "" {
+		t.Skip("TRACEE_CLICKHOUSE_ADDR isn't set")
+	}
+
+	db, err := Open(Options{Addr: addr, Timeout: 10 * time.Second})
+	require.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	table := fmt.Sprintf("tracee_events_test_%d", time.Now().UnixNano())
+	require.NoError(t, CreateEventsTable(ctx, db, table, time.Hour))
+	defer func() {
+		_, err := db.ExecContext(ctx, "DROP TABLE "+table)
+		assert.NoError(t, err)
+	}()
+	// creating the existing table is a no-op
+	require.NoError(t, CreateEventsTable(ctx, db, table, time.Hour))
+
+	events := []trace.Event{
+		{
+			Timestamp:       1712345678901234567,
+			EventName:       "security_file_open",
+			EventID:         730,
+			HostProcessID:   1234,
+			ProcessName:     "cat",
+			Container:       trace.Container{ID: "0123456789ab", Name: "nginx"},
+			MatchedPolicies: []string{"files", "containers"},
+		},
+		{
+			Timestamp:     1712345678901234568,
+			EventName:     "sched_process_exec",
+			HostProcessID: 1235,
+			ReturnValue:   -2,
+		},
+	}
+	require.NoError(t, InsertEvents(ctx, db, table, events))
+
+	err = InsertEvents(ctx, db, table+"_missing", events)
+	assert.ErrorContains(t, err, "code: 60,") // UNKNOWN_TABLE
+	// the database is usable after an error
+	require.NoError(t, InsertEvents(ctx, db, table, events[:1]))
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf(
+		"SELECT toUnixTimestamp64Nano(timestamp), event_name, pid, return_value, policies, "+
+			"JSONExtractString(data, 'processName') FROM %s ORDER BY timestamp, event_name", table,
+	))
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type row struct {
+		timestamp   int64
+		eventName   string
+		pid         uint32
+		returnValue int64
+		policies    []string
+		processName string
+	}
+	var inserted []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.timestamp, &r.eventName, &r.pid, &r.returnValue, &r.policies, &r.processName))
+		inserted = append(inserted, r)
+	}
+	require.NoError(t, rows.Err())
+
+	assert.Equal(t, []row{
+		{1712345678901234567, "security_file_open", 1234, 0, []string{"files", "containers"}, "cat"},
+		{1712345678901234567, "security_file_open", 1234, 0, []string{"files", "containers"}, "cat"},
+		{1712345678901234568, "sched_process_exec", 1235, -2, []string{}, ""},
+	}, inserted)
+}
@@ -0,0 +1,125 @@
+package clickhouse
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// eventsSchema is the schema of the events table: the columns to select the events by, and the
+// whole event, as JSON, in the data column (to be queried with the JSON functions, e.g.
+// JSONExtractString(data, 'executable', 'path')).
+const eventsSchema = `CREATE TABLE IF NOT EXISTS %s (
+    timestamp DateTime64(9) CODEC(Delta, ZSTD),
+    event_name String,
+    event_id Int64,
+    host_name String,
+    container_id String,
+    container_name String,
+    container_image String,
+    pod_name String,
+    pod_namespace String,
+    pid UInt32,
+    tid UInt32,
+    ppid UInt32,
+    uid UInt32,
+    process_name String,
+    syscall String,
+    return_value Int64,
+    policies Array(String),
+    data String CODEC(ZSTD)
+)
+ENGINE = MergeTree
+PARTITION BY toDate(timestamp)
+ORDER BY (event_name, container_id, timestamp)`
+
+// EventsSchema returns the statement creating the events table, if it doesn't exist. With a
+// ttl, the events are removed by the server once older.
+func EventsSchema(table string, ttl time.Duration) string {
+	schema := fmt.Sprintf(eventsSchema, table)
+	if ttl > 0 {
+		schema += fmt.Sprintf("\nTTL toDateTime(timestamp) + INTERVAL %d SECOND", int64(ttl.Seconds()))
+	}
+
+	return schema
+}
+
+// eventsColumns are the columns of the events table, in the order of eventValues.
+var eventsColumns = []string{
+	"timestamp", "event_name", "event_id", "host_name", "container_id", "container_name",
+	"container_image", "pod_name", "pod_namespace", "pid", "tid", "ppid", "uid", "process_name",
+	"syscall", "return_value", "policies", "data",
+}
+
+// CreateEventsTable creates the events table, if it doesn't exist.
+func CreateEventsTable(ctx context.Context, db *sql.DB, table string, ttl time.Duration) error {
+	_, err := db.ExecContext(ctx, EventsSchema(table, ttl))
+
+	return errfmt.WrapError(err)
+}
+
+// InsertEvents inserts the events into the events table, in a single block.
+func InsertEvents(ctx context.Context, db *sql.DB, table string, events []trace.Event) error {
+	// the driver sends the rows of a transaction as a block, on commit
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(eventsColumns)), ", ")
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf(
+		"INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(eventsColumns, ", "), placeholders,
+	))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer stmt.Close()
+
+	for i := range events {
+		values, err := eventValues(&events[i])
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return errfmt.WrapError(tx.Commit())
+}
+
+// eventValues returns the values of the columns of the events table, of the given event.
+func eventValues(event *trace.Event) ([]any, error) {
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return []any{
+		int64(event.Timestamp),
+		event.EventName,
+		int64(event.EventID),
+		event.HostName,
+		event.Container.ID,
+		event.Container.Name,
+		event.Container.ImageName,
+		event.Kubernetes.PodName,
+		event.Kubernetes.PodNamespace,
+		uint32(event.HostProcessID),
+		uint32(event.HostThreadID),
+		uint32(event.HostParentProcessID),
+		uint32(event.UserID),
+		event.ProcessName,
+		event.Syscall,
+		int64(event.ReturnValue),
+		event.MatchedPolicies,
+		string(eventJSON),
+	}, nil
+}
@@ -0,0 +1,219 @@
+// Package cloud fetches the identity of the cloud instance tracee runs on, from the
+// metadata service of the cloud provider.
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Instance is the identity of a cloud instance.
+type Instance struct {
+	Provider string `json:"provider"` // aws, gcp or azure
+	ID       string `json:"id"`
+	Account  string `json:"account"` // aws account, gcp project or azure subscription
+	Region   string `json:"region"`
+}
+
+// metadataURL is the link-local address of the metadata services (all providers).
+var metadataURL = "http://169.254.169.254"
+
+// requestTimeout is short, the metadata services being local: a host not running in a
+// cloud is detected without delaying tracee startup.
+const requestTimeout = 2 * time.Second
+
+type provider struct {
+	name  string
+	fetch func(ctx context.Context, client *http.Client) (Instance, error)
+}
+
+var providers = []provider{
+	{"aws", fetchAWS},
+	{"gcp", fetchGCP},
+	{"azure", fetchAzure},
+}
+
+// GetInstance returns the identity of the cloud instance tracee runs on. The metadata
+// services of all providers are queried at once, the first answer is returned.
+func GetInstance(ctx context.Context) (Instance, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	client := &http.Client{
+		// metadata services must not be reached through a proxy
+		Transport: &http.Transport{Proxy: nil},
+	}
+
+	type result struct {
+		instance Instance
+		err      error
+	}
+	results := make(chan result, len(providers))
+
+	for _, p := range providers {
+		p := p
+		go func() {
+			instance, err := p.fetch(ctx, client)
+			instance.Provider = p.name
+			results <- result{instance, err}
+		}()
+	}
+
+	for range providers {
+		res := <-results
+		if res.err == nil && res.instance.ID != "" {
+			return res.instance, nil
+		}
+	}
+
+	return Instance{}, errfmt.Errorf("no cloud metadata service found")
+}
+
+// cacheFile is the file caching the instance identity, in the tracee install path.
+const cacheFile = "cloud-instance.json"
+
+// bootIDPath is a variable for tests
+var bootIDPath = "/proc/sys/kernel/random/boot_id"
+
+type cachedInstance struct {
+	BootID   string   `json:"boot_id"`
+	Instance Instance `json:"instance"`
+}
+
+// GetCachedInstance returns the identity of the cloud instance tracee runs on, cached in the
+// given directory: the metadata services are only queried once per boot of the instance (an
+// instance identity can't change while it runs), and not at every restart of tracee.
+func GetCachedInstance(ctx context.Context, dir string) (Instance, error) {
+	path := filepath.Join(dir, cacheFile)
+	bootID, err := os.ReadFile(bootIDPath)
+	if err != nil {
+		return GetInstance(ctx)
+	}
+
+	var cached cachedInstance
+	if data, err := os.ReadFile(path); err == nil {
+		if json.Unmarshal(data, &cached) == nil && cached.BootID == string(bootID) && cached.Instance.ID != "" {
+			return cached.Instance, nil
+		}
+	}
+
+	instance, err := GetInstance(ctx)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	// a cache not written is not an error, the instance is fetched again at the next start
+	data, err := json.Marshal(cachedInstance{BootID: string(bootID), Instance: instance})
+	if err == nil {
+		if err = os.MkdirAll(dir, 0755); err == nil {
+			_ = os.WriteFile(path, data, 0644)
+		}
+	}
+
+	return instance, nil
+}
+
+// fetchAWS uses the instance metadata service v2, which requires a session token. The
+// instance identity document gives the instance, account and region at once.
+func fetchAWS(ctx context.Context, client *http.Client) (Instance, error) {
+	token, err := get(ctx, client, http.MethodPut, "/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "60"})
+	if err != nil {
+		return Instance{}, err
+	}
+	header := map[string]string{"X-aws-ec2-metadata-token": token}
+
+	document, err := get(ctx, client, http.MethodGet, "/latest/dynamic/instance-identity/document", header)
+	if err != nil {
+		return Instance{}, err
+	}
+	var identity struct {
+		InstanceID string `json:"instanceId"`
+		AccountID  string `json:"accountId"`
+		Region     string `json:"region"`
+	}
+	if err := json.Unmarshal([]byte(document), &identity); err != nil {
+		return Instance{}, errfmt.Errorf("invalid instance identity document: %v", err)
+	}
+
+	return Instance{ID: identity.InstanceID, Account: identity.AccountID, Region: identity.Region}, nil
+}
+
+func fetchGCP(ctx context.Context, client *http.Client) (Instance, error) {
+	header := map[string]string{"Metadata-Flavor": "Google"}
+
+	id, err := get(ctx, client, http.MethodGet, "/computeMetadata/v1/instance/id", header)
+	if err != nil {
+		return Instance{}, err
+	}
+	// the zone is given as projects/<project number>/zones/<region>-<zone>
+	zone, err := get(ctx, client, http.MethodGet, "/computeMetadata/v1/instance/zone", header)
+	if err != nil {
+		return Instance{}, err
+	}
+	zone = zone[strings.LastIndex(zone, "/")+1:]
+	region := zone
+	if idx := strings.LastIndex(zone, "-"); idx > 0 {
+		region = zone[:idx]
+	}
+	project, err := get(ctx, client, http.MethodGet, "/computeMetadata/v1/project/project-id", header)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	return Instance{ID: id, Account: project, Region: region}, nil
+}
+
+func fetchAzure(ctx context.Context, client *http.Client) (Instance, error) {
+	header := map[string]string{"Metadata": "true"}
+	const query = "?api-version=2021-02-01&format=text"
+
+	id, err := get(ctx, client, http.MethodGet, "/metadata/instance/compute/vmId"+query, header)
+	if err != nil {
+		return Instance{}, err
+	}
+	region, err := get(ctx, client, http.MethodGet, "/metadata/instance/compute/location"+query, header)
+	if err != nil {
+		return Instance{}, err
+	}
+	subscription, err := get(ctx, client, http.MethodGet, "/metadata/instance/compute/subscriptionId"+query, header)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	return Instance{ID: id, Account: subscription, Region: region}, nil
+}
+
+func get(ctx context.Context, client *http.Client, method, path string, header map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, metadataURL+path, nil)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errfmt.Errorf("%s: %s", path, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
@@ -0,0 +1,175 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMetadataService serves the given paths, if the request has the given header.
+func fakeMetadataService(t *testing.T, headerKey, headerValue string, paths map[string]string) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get(headerKey) != headerValue {
+			http.Error(rw, "missing header", http.StatusBadRequest)
+			return
+		}
+		value, ok := paths[req.Method+" "+req.URL.RequestURI()]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		_, _ = rw.Write([]byte(value))
+	}))
+	t.Cleanup(server.Close)
+
+	orig := metadataURL
+	metadataURL = server.URL
+	t.Cleanup(func() { metadataURL = orig })
+}
+
+// The tests aren't parallel, as they change the metadata service url.
+
+func TestGetInstance(t *testing.T) {
+	testCases := []struct {
+		name             string
+		headerKey        string
+		headerValue      string
+		paths            map[string]string
+		expectedInstance Instance
+		expectedError    string
+	}{
+		{
+			name:        "gcp",
+			headerKey:   "Metadata-Flavor",
+			headerValue: "Google",
+			paths: map[string]string{
+				"GET /computeMetadata/v1/instance/id":        "4520031799277581759\n",
+				"GET /computeMetadata/v1/instance/zone":      "projects/123456789/zones/us-central1-a",
+				"GET /computeMetadata/v1/project/project-id": "prod-project",
+			},
+			expectedInstance: Instance{Provider: "gcp", ID: "4520031799277581759", Account: "prod-project", Region: "us-central1"},
+		},
+		{
+			name:        "azure",
+			headerKey:   "Metadata",
+			headerValue: "true",
+			paths: map[string]string{
+				"GET /metadata/instance/compute/vmId?api-version=2021-02-01&format=text":           "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+				"GET /metadata/instance/compute/location?api-version=2021-02-01&format=text":       "westeurope",
+				"GET /metadata/instance/compute/subscriptionId?api-version=2021-02-01&format=text": "8d10da13-8125-4ba9-a717-bf7490507b3d",
+			},
+			expectedInstance: Instance{
+				Provider: "azure",
+				ID:       "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+				Account:  "8d10da13-8125-4ba9-a717-bf7490507b3d",
+				Region:   "westeurope",
+			},
+		},
+		{
+			name:          "no metadata service",
+			headerKey:     "None",
+			expectedError: "no cloud metadata service found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeMetadataService(t, tc.headerKey, tc.headerValue, tc.paths)
+
+			instance, err := GetInstance(context.Background())
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInstance, instance)
+		})
+	}
+}
+
+func TestGetInstanceAWS(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut && req.URL.Path == "/latest/api/token" {
+			_, _ = rw.Write([]byte("token"))
+			return
+		}
+		if req.Header.Get("X-aws-ec2-metadata-token") != "token" {
+			http.Error(rw, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if req.URL.Path != "/latest/dynamic/instance-identity/document" {
+			http.NotFound(rw, req)
+			return
+		}
+		_, _ = rw.Write([]byte(`{
+  "accountId" : "123456789012",
+  "availabilityZone" : "eu-west-1a",
+  "instanceId" : "i-0123456789abcdef0",
+  "instanceType" : "m5.large",
+  "region" : "eu-west-1"
+}`))
+	}))
+	defer server.Close()
+
+	orig := metadataURL
+	metadataURL = server.URL
+	defer func() { metadataURL = orig }()
+
+	instance, err := GetInstance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Instance{Provider: "aws", ID: "i-0123456789abcdef0", Account: "123456789012", Region: "eu-west-1"}, instance)
+}
+
+func TestGetCachedInstance(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Metadata-Flavor") != "Google" {
+			http.Error(rw, "missing header", http.StatusBadRequest)
+			return
+		}
+		requests.Add(1)
+		switch req.URL.Path {
+		case "/computeMetadata/v1/instance/id":
+			_, _ = rw.Write([]byte("4520031799277581759"))
+		case "/computeMetadata/v1/instance/zone":
+			_, _ = rw.Write([]byte("projects/123456789/zones/us-central1-a"))
+		case "/computeMetadata/v1/project/project-id":
+			_, _ = rw.Write([]byte("prod-project"))
+		}
+	}))
+	defer server.Close()
+
+	origURL, origBootID := metadataURL, bootIDPath
+	defer func() { metadataURL, bootIDPath = origURL, origBootID }()
+	metadataURL = server.URL
+	bootIDPath = filepath.Join(t.TempDir(), "boot_id")
+	require.NoError(t, os.WriteFile(bootIDPath, []byte("boot-1\n"), 0644))
+
+	dir := filepath.Join(t.TempDir(), "tracee")
+	expected := Instance{Provider: "gcp", ID: "4520031799277581759", Account: "prod-project", Region: "us-central1"}
+
+	instance, err := GetCachedInstance(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, expected, instance)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// the cached instance is used in the same boot
+	instance, err = GetCachedInstance(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, expected, instance)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// and fetched again after a reboot
+	require.NoError(t, os.WriteFile(bootIDPath, []byte("boot-2\n"), 0644))
+	instance, err = GetCachedInstance(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, expected, instance)
+	assert.Equal(t, int32(6), requests.Load())
+}
@@ -14,12 +14,20 @@ import (
 	"github.com/aquasecurity/tracee/pkg/cmd/initialize"
 	"github.com/aquasecurity/tracee/pkg/cmd/printer"
 	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/controlplane"
+	"github.com/aquasecurity/tracee/pkg/ebpf/pinning"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/flamegraph"
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+	"github.com/aquasecurity/tracee/pkg/events/window"
 	"github.com/aquasecurity/tracee/pkg/k8s"
 	"github.com/aquasecurity/tracee/pkg/k8s/apis/tracee.aquasec.com/v1beta1"
 	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
 	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/server/http"
+	"github.com/aquasecurity/tracee/pkg/signatures/budget"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
 	"github.com/aquasecurity/tracee/pkg/signatures/signature"
 )
@@ -55,19 +63,76 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 		return runner, err
 	}
 
+	// OCI command line flags: the detection content given as OCI references is pulled
+	// to the cache directory
+
+	ociPuller, err := newOCIPuller()
+	if err != nil {
+		return runner, err
+	}
+
+	// Signature plugins command line flags
+
+	signaturePluginsFlags, err := GetFlagsFromViper("signature-plugins")
+	if err != nil {
+		return runner, err
+	}
+
+	pluginPolicy, err := flags.PrepareSignaturePlugins(signaturePluginsFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	var pluginAudits []signature.PluginAudit
+	pluginPolicy.Audit = func(audit signature.PluginAudit) {
+		pluginAudits = append(pluginAudits, audit)
+	}
+
 	// Signature directory command line flags
 
+	signaturesDirs, err := ociPuller.Resolve(c.Context(), viper.GetStringSlice("signatures-dir"))
+	if err != nil {
+		return runner, err
+	}
+
+	// Rego data documents: refreshed while tracee runs, without reloading the signatures
+
+	regoData, err := newRegoData(c.Context(), rego)
+	if err != nil {
+		return runner, err
+	}
+
 	sigs, dataSources, err := signature.Find(
 		rego.RuntimeTarget,
 		rego.PartialEval,
-		viper.GetStringSlice("signatures-dir"),
+		signaturesDirs,
 		nil,
 		rego.AIO,
+		regoData,
+		pluginPolicy,
 	)
 	if err != nil {
 		return runner, err
 	}
 
+	// Signature budget command line flags: the signatures exceeding their budget aren't loaded
+	// (if refused)
+
+	signatureBudgetFlags, err := GetFlagsFromViper("signature-budget")
+	if err != nil {
+		return runner, err
+	}
+
+	signatureBudget, err := flags.PrepareSignatureBudget(signatureBudgetFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	sigs, err = budget.Enforce(signatureBudget, sigs)
+	if err != nil {
+		return runner, err
+	}
+
 	sigNameToEventId := initialize.CreateEventsFromSignatures(events.StartSignatureID, sigs)
 
 	// Initialize a tracee config structure
@@ -76,6 +141,8 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 		PerfBufferSize:     viper.GetInt("perf-buffer-size"),
 		BlobPerfBufferSize: viper.GetInt("blob-perf-buffer-size"),
 		NoContainersEnrich: viper.GetBool("no-containers"),
+		MetricsEnabled:     viper.GetBool(server.MetricsEndpointFlag),
+		SignaturePlugins:   pluginAudits,
 	}
 
 	// OS release information
@@ -94,6 +161,18 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 
 	cfg.OSInfo = osInfo
 
+	// Split pipeline command line flags
+
+	splitPipelineFlags, err := GetFlagsFromViper("split-pipeline")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.SplitPipeline, err = flags.PrepareSplitPipeline(splitPipelineFlags)
+	if err != nil {
+		return runner, err
+	}
+
 	// Container Runtime command line flags
 
 	if !cfg.NoContainersEnrich {
@@ -125,6 +204,63 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 		logger.Debugw("Cache", "type", cfg.Cache.String())
 	}
 
+	// eBPF maps command line flags
+
+	bpfMapsFlags, err := GetFlagsFromViper("bpf-maps")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.BPFMaps, err = flags.PrepareBPFMaps(bpfMapsFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Pin path command line flags
+
+	if namespace := viper.GetString("pin-path"); namespace != "" {
+		cfg.PinPath, err = pinning.Path(namespace)
+		if err != nil {
+			return runner, err
+		}
+	}
+
+	// Perf buffer tuning command line flags
+
+	perfBufferTuningFlags, err := GetFlagsFromViper("perf-buffer-tuning")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.PerfBufferTuning, err = flags.PreparePerfBufferTuning(perfBufferTuningFlags, viper.GetString("install-path"))
+	if err != nil {
+		return runner, err
+	}
+
+	// the size staged by the tuning of a previous run, unless a size is given
+	if cfg.PerfBufferTuning.StagePath != "" && !viper.IsSet("perf-buffer-size") {
+		staged, err := metrics.LoadStagedPerfBufferSize(cfg.PerfBufferTuning.StagePath)
+		if err != nil {
+			logger.Warnw("Staged perf buffer size not used", "error", err)
+		}
+		if staged > 0 {
+			logger.Infow("Using the staged perf buffer size", "pages", staged, "file", cfg.PerfBufferTuning.StagePath)
+			cfg.PerfBufferSize = staged
+		}
+	}
+
+	// Stack symbols command line flags
+
+	stackSymbolsFlags, err := GetFlagsFromViper("stack-symbols")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.StackSymbols, err = flags.PrepareStackSymbols(stackSymbolsFlags)
+	if err != nil {
+		return runner, err
+	}
+
 	// Process Tree command line flags
 
 	procTreeFlags, err := GetFlagsFromViper("proctree")
@@ -152,6 +288,193 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 
 	cfg.DNSCacheConfig = dnsCache
 
+	// CPU affinity command line flags
+
+	cpuAffinityFlags, err := GetFlagsFromViper("cpu-affinity")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.PipelineCPUs, err = flags.PrepareCPUAffinity(cpuAffinityFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Labels command line flags
+
+	labelsFlags, err := GetFlagsFromViper("labels")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.Labels, err = flags.PrepareLabels(labelsFlags, viper.GetString("install-path"))
+	if err != nil {
+		return runner, err
+	}
+
+	// Suppressions command line flags
+
+	suppressionsFlags, err := GetFlagsFromViper("suppressions")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.SuppressionRules, err = flags.PrepareSuppressions(suppressionsFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Finding context command line flags
+
+	findingContextFlags, err := GetFlagsFromViper("finding-context")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.FindingContext, err = flags.PrepareFindingContext(findingContextFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Finding dedup command line flags
+
+	findingDedupFlags, err := GetFlagsFromViper("finding-dedup")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.FindingDedup, err = flags.PrepareFindingDedup(findingDedupFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Threat intel command line flags
+
+	threatIntelFlags, err := GetFlagsFromViper("threat-intel")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.ThreatIntel, err = flags.PrepareThreatIntel(threatIntelFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Vulnerability DB command line flags
+
+	vulnDBFlags, err := GetFlagsFromViper("vuln-db")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.VulnDB, err = flags.PrepareVulnDB(vulnDBFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Kubernetes audit logs command line flags
+
+	k8sAuditFlags, err := GetFlagsFromViper("k8s-audit")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.K8sAudit, err = flags.PrepareK8sAudit(k8sAuditFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Cgroups resource pressure command line flags
+
+	cgroupPressureFlags, err := GetFlagsFromViper("cgroup-pressure")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.CgroupPressure, err = flags.PrepareCgroupPressure(cgroupPressureFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Self-limits command line flags
+
+	selfLimitsFlags, err := GetFlagsFromViper("self-limits")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.SelfLimits, err = flags.PrepareSelfLimits(selfLimitsFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Handoff command line flags
+
+	handoffFlags, err := GetFlagsFromViper("handoff")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.Handoff, err = flags.PrepareHandoff(handoffFlags)
+	if err != nil {
+		return runner, err
+	}
+	if cfg.Handoff.Enabled() && cfg.SplitPipeline.Mode != "" {
+		return runner, errfmt.Errorf("the events output can't be handed over with a split pipeline")
+	}
+
+	// Runtime SBOM command line flags
+
+	runtimeSBOMFlags, err := GetFlagsFromViper("runtime-sbom")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.RuntimeSBOM, err = flags.PrepareRuntimeSBOM(runtimeSBOMFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Network flow stats command line flags
+
+	netFlowStatsFlags, err := GetFlagsFromViper("net-flow-stats")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.FlowStatsInterval, err = flags.PrepareNetFlowStats(netFlowStatsFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Sensitive files command line flags
+
+	sensitiveFilesFlags, err := GetFlagsFromViper("sensitive-files")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.SensitiveFiles, err = flags.PrepareSensitiveFiles(sensitiveFilesFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// YARA command line flags
+
+	yaraFlags, err := GetFlagsFromViper("yara")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.Yara, err = flags.PrepareYara(yaraFlags)
+	if err != nil {
+		return runner, err
+	}
+	cfg.Yara.Rules, err = ociPuller.Resolve(c.Context(), cfg.Yara.Rules)
+	if err != nil {
+		return runner, err
+	}
+
 	// Capture command line flags - via cobra flag
 
 	captureFlags, err := c.Flags().GetStringArray("capture")
@@ -184,6 +507,10 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 	if err != nil {
 		return runner, err
 	}
+	policyFlags, err = ociPuller.Resolve(c.Context(), policyFlags)
+	if err != nil {
+		return runner, err
+	}
 
 	// Scope command line flags - via cobra flag
 
@@ -205,25 +532,80 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 		return runner, errors.New("policy and event flags cannot be used together")
 	}
 
-	// Try to get policies from kubernetes CRD, policy files and CLI in that order
+	// Filter expressions command line flags - via cobra flag
 
-	var k8sPolicies []v1beta1.PolicyInterface
-	var policies *policy.Policies
+	filterFlags, err := c.Flags().GetStringArray("filter")
+	if err != nil {
+		return runner, err
+	}
+	if len(filterFlags) > 0 && (len(policyFlags) > 0 || len(scopeFlags) > 0 || len(eventFlags) > 0) {
+		return runner, errors.New("filter and policy, scope or event flags cannot be used together")
+	}
 
-	k8sClient, err := k8s.New()
-	if err == nil {
-		k8sPolicies, err = k8sClient.GetPolicy(c.Context())
+	// Control plane command line flags
+
+	controlPlaneFlags, err := GetFlagsFromViper("control-plane")
+	if err != nil {
+		return runner, err
 	}
+
+	controlPlaneCfg, err := flags.PrepareControlPlane(controlPlaneFlags)
 	if err != nil {
-		logger.Debugw("kubernetes cluster", "error", err)
+		return runner, err
 	}
-	if len(k8sPolicies) > 0 {
+
+	var controlPlanePolicies []byte
+
+	if controlPlaneCfg.Enabled {
+		if len(policyFlags) > 0 || len(scopeFlags) > 0 || len(eventFlags) > 0 || len(filterFlags) > 0 {
+			return runner, errors.New("control-plane and policy, scope, event or filter flags cannot be used together")
+		}
+
+		controlPlane, err := controlplane.New(controlPlaneCfg)
+		if err != nil {
+			return runner, err
+		}
+		controlPlanePolicies, err = controlPlane.GetPolicies(c.Context())
+		if err != nil {
+			return runner, err
+		}
+		runner.ControlPlane = controlPlane
+	}
+
+	// Try to get policies from the control plane, kubernetes CRD, policy files and CLI in
+	// that order
+
+	var k8sPolicies []v1beta1.PolicyInterface
+	var policies *policy.Policies
+
+	if !controlPlaneCfg.Enabled {
+		k8sClient, err := k8s.New()
+		if err == nil {
+			k8sPolicies, err = k8sClient.GetPolicy(c.Context())
+		}
+		if err != nil {
+			logger.Debugw("kubernetes cluster", "error", err)
+		}
+	}
+	if controlPlaneCfg.Enabled {
+		logger.Debugw("using policies from the control plane")
+		policies, err = flags.CreatePoliciesFromControlPlane(controlPlanePolicies)
+	} else if len(k8sPolicies) > 0 {
 		logger.Debugw("using policies from kubernetes crd")
 		policies, err = createPoliciesFromK8SPolicy(k8sPolicies)
 	} else if len(policyFlags) > 0 {
 		logger.Debugw("using policies from --policy flag")
 		policies, err = createPoliciesFromPolicyFiles(policyFlags)
+	} else if len(filterFlags) > 0 {
+		logger.Debugw("using policies from --filter flag")
+		policies, err = createPoliciesFromFilterExpressions(filterFlags)
 	} else {
+		// signatures given without events: select the events of the signatures only, enabling
+		// the events they require, instead of the default events
+		if len(eventFlags) == 0 && len(signaturesDirs) > 0 && len(sigs) > 0 {
+			eventFlags = initialize.SignaturesEvents(sigs)
+			logger.Debugw("using the events of the signatures", "signatures", len(eventFlags))
+		}
 		logger.Debugw("using policies from --scope and --events flag")
 		policies, err = createPoliciesFromCLIFlags(scopeFlags, eventFlags)
 	}
@@ -234,6 +616,50 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 	cfg.Policies = policies
 	policy.Snapshots().Store(cfg.Policies)
 
+	// Policy quotas command line flags
+
+	policyQuotaFlags, err := GetFlagsFromViper("policy-quota")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.PolicyQuotas, err = flags.PreparePolicyQuotas(policyQuotaFlags)
+	if err != nil {
+		return runner, err
+	}
+	for _, q := range cfg.PolicyQuotas {
+		if q.Policy == "" {
+			continue
+		}
+		if _, err := policies.LookupByName(q.Policy); err != nil {
+			return runner, errfmt.Errorf("policy-quota: policy %s doesn't exist", q.Policy)
+		}
+	}
+
+	// Events granularity command line flags
+
+	granularityFlags, err := GetFlagsFromViper("granularity")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.Granularity, err = flags.PrepareGranularity(granularityFlags)
+	if err != nil {
+		return runner, err
+	}
+
+	// Arguments size limits command line flags
+
+	argLimitFlags, err := GetFlagsFromViper("arg-limit")
+	if err != nil {
+		return runner, err
+	}
+
+	cfg.ArgLimits, err = flags.PrepareArgLimit(argLimitFlags)
+	if err != nil {
+		return runner, err
+	}
+
 	// Output command line flags
 
 	outputFlags, err := GetFlagsFromViper("output")
@@ -245,6 +671,10 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 	if err != nil {
 		return runner, err
 	}
+	err = flags.PrepareOutputQuery(viper.GetString("output-query"), output)
+	if err != nil {
+		return runner, err
+	}
 	cfg.Output = output.TraceeConfig
 
 	// Create printer
@@ -257,66 +687,216 @@ func GetTraceeRunner(c *cobra.Command, version string) (cmd.Runner, error) {
 		return runner, err
 	}
 
-	// Check kernel lockdown
+	traceeInstallPath := viper.GetString("install-path")
+
+	// The processor of a split pipeline doesn't load eBPF programs
+
+	if cfg.SplitPipeline.Mode != config.SplitPipelineProcessor {
+		// Check kernel lockdown
+
+		lockdown, err := helpers.Lockdown()
+		if err != nil {
+			logger.Debugw("OSInfo", "lockdown", err)
+		}
+		if err == nil && lockdown == helpers.CONFIDENTIALITY {
+			return runner, errfmt.Errorf("kernel lockdown is set to 'confidentiality', can't load eBPF programs")
+		}
+
+		logger.Debugw("OSInfo", "security_lockdown", lockdown)
+
+		// Check if ftrace is enabled
+
+		enabled, err := helpers.FtraceEnabled()
+		if err != nil {
+			return runner, err
+		}
+		if !enabled {
+			logger.Errorw("ftrace_enabled: ftrace is not enabled, kernel events won't be caught, make sure to enable it by executing echo 1 | sudo tee /proc/sys/kernel/ftrace_enabled")
+		}
+
+		// Pick OS information
+
+		kernelConfig, err := initialize.KernelConfig()
+		if err != nil {
+			return runner, err
+		}
+
+		// Decide BTF & BPF files to use (based in the kconfig, release & environment info)
+
+		btfHubFlags, err := GetFlagsFromViper("btfhub")
+		if err != nil {
+			return runner, err
+		}
+
+		cfg.BTFHub, err = flags.PrepareBTFHub(btfHubFlags)
+		if err != nil {
+			return runner, err
+		}
+
+		err = initialize.BpfObject(&cfg, kernelConfig, osInfo, traceeInstallPath, version)
+		if err != nil {
+			return runner, errfmt.Errorf("failed preparing BPF object: %v", err)
+		}
+	}
+
+	// Prepare the server
 
-	lockdown, err := helpers.Lockdown()
+	httpServer, err := server.PrepareHTTPServer(
+		viper.GetString(server.HTTPListenEndpointFlag),
+		viper.GetBool(server.MetricsEndpointFlag),
+		viper.GetBool(server.HealthzEndpointFlag),
+		viper.GetBool(server.PProfEndpointFlag),
+		viper.GetBool(server.PyroscopeAgentFlag),
+	)
 	if err != nil {
-		logger.Debugw("OSInfo", "lockdown", err)
+		return runner, err
 	}
-	if err == nil && lockdown == helpers.CONFIDENTIALITY {
-		return runner, errfmt.Errorf("kernel lockdown is set to 'confidentiality', can't load eBPF programs")
+
+	// dataHTTPServer returns the server of the captured data endpoints, creating it if needed.
+	// It is separate from the http server (metrics, healthz, ...): its endpoints aren't
+	// authenticated, and serve the captured events and artifacts, so it only listens on
+	// localhost unless its listen address is explicitly given.
+	var dataServer *http.Server
+	dataHTTPServer := func() (*http.Server, error) {
+		if dataServer != nil {
+			return dataServer, nil
+		}
+		dataListenAddr := viper.GetString(server.DataListenEndpointFlag)
+		if len(dataListenAddr) == 0 {
+			return nil, errfmt.Errorf("data listen address cannot be empty")
+		}
+		dataServer = http.New(dataListenAddr)
+		return dataServer, nil
 	}
 
-	logger.Debugw("OSInfo", "security_lockdown", lockdown)
+	// Event window command line flags
 
-	// Check if ftrace is enabled
+	eventWindowFlags, err := GetFlagsFromViper("event-window")
+	if err != nil {
+		return runner, err
+	}
 
-	enabled, err := helpers.FtraceEnabled()
+	eventWindowCfg, err := flags.PrepareEventWindow(eventWindowFlags)
 	if err != nil {
 		return runner, err
 	}
-	if !enabled {
-		logger.Errorw("ftrace_enabled: ftrace is not enabled, kernel events won't be caught, make sure to enable it by executing echo 1 | sudo tee /proc/sys/kernel/ftrace_enabled")
+
+	if eventWindowCfg.Enabled {
+		eventWindow, err := window.New(eventWindowCfg)
+		if err != nil {
+			return runner, err
+		}
+
+		// the events window is queried through the data http server, start it if needed
+		srv, err := dataHTTPServer()
+		if err != nil {
+			return runner, err
+		}
+		logger.Debugw("Enabling events query endpoint", "duration", eventWindowCfg.Duration)
+		srv.EnableEventsQueryEndpoint(eventWindow)
+		runner.EventWindow = eventWindow
 	}
 
-	// Pick OS information
+	// Timeline command line flags
 
-	kernelConfig, err := initialize.KernelConfig()
+	timelineFlags, err := GetFlagsFromViper("timeline")
 	if err != nil {
 		return runner, err
 	}
 
-	// Decide BTF & BPF files to use (based in the kconfig, release & environment info)
+	timelineCfg, err := flags.PrepareTimeline(timelineFlags)
+	if err != nil {
+		return runner, err
+	}
 
-	traceeInstallPath := viper.GetString("install-path")
-	err = initialize.BpfObject(&cfg, kernelConfig, osInfo, traceeInstallPath, version)
+	if timelineCfg.Enabled {
+		recorder, err := timeline.New(timelineCfg)
+		if err != nil {
+			return runner, err
+		}
+
+		// the timelines are exported on demand through the data http server, start it if needed
+		srv, err := dataHTTPServer()
+		if err != nil {
+			return runner, err
+		}
+		logger.Debugw("Enabling timeline endpoint", "dir", timelineCfg.Dir)
+		srv.EnableTimelineEndpoint(recorder)
+		runner.Timeline = recorder
+	}
+
+	// Flamegraph command line flags
+
+	flamegraphFlags, err := GetFlagsFromViper("flamegraph")
 	if err != nil {
-		return runner, errfmt.Errorf("failed preparing BPF object: %v", err)
+		return runner, err
 	}
 
-	// Prepare the server
+	flamegraphCfg, err := flags.PrepareFlamegraph(flamegraphFlags)
+	if err != nil {
+		return runner, err
+	}
 
-	httpServer, err := server.PrepareHTTPServer(
-		viper.GetString(server.HTTPListenEndpointFlag),
-		viper.GetBool(server.MetricsEndpointFlag),
-		viper.GetBool(server.HealthzEndpointFlag),
-		viper.GetBool(server.PProfEndpointFlag),
-		viper.GetBool(server.PyroscopeAgentFlag),
-	)
+	if flamegraphCfg.Enabled {
+		aggregator, err := flamegraph.New(flamegraphCfg)
+		if err != nil {
+			return runner, err
+		}
+
+		// the stacks of all the events are collected, unless restricted to selected events
+		if len(cfg.Output.StackAddressesEvents) == 0 {
+			cfg.Output.StackAddresses = true
+		}
+		logger.Debugw("Enabling flamegraph", "dir", flamegraphCfg.Dir, "format", flamegraphCfg.Format)
+		runner.Flamegraph = aggregator
+	}
+
+	// Seccomp command line flags
+
+	seccompFlags, err := GetFlagsFromViper("seccomp")
+	if err != nil {
+		return runner, err
+	}
+
+	runner.Seccomp, err = flags.PrepareSeccomp(seccompFlags)
 	if err != nil {
 		return runner, err
 	}
 
+	// Forensic snapshot command line flags
+
+	cfg.SnapshotDir = viper.GetString("snapshot-dir")
+	if cfg.SnapshotDir != "" {
+		// snapshots are triggered through the data http server (POST /snapshot), start it
+		// if needed (the endpoint is enabled once tracee is created)
+		if _, err := dataHTTPServer(); err != nil {
+			return runner, err
+		}
+	}
+
+	// the indexed artifacts are listed and fetched through the data http server
+	// (GET /artifacts), start it if needed (the endpoint is enabled once tracee is initialized)
+	if cfg.Capture.Index.Enabled {
+		if _, err := dataHTTPServer(); err != nil {
+			return runner, err
+		}
+	}
+
 	grpcServer, err := flags.PrepareGRPCServer(viper.GetString(server.GRPCListenEndpointFlag))
 	if err != nil {
 		return runner, err
 	}
 
 	runner.HTTPServer = httpServer
+	runner.DataHTTPServer = dataServer
 	runner.GRPCServer = grpcServer
 	runner.TraceeConfig = cfg
 	runner.Printer = p
 	runner.InstallPath = traceeInstallPath
+	if ociPuller.Pulled() {
+		runner.OCIPuller = ociPuller
+	}
+	runner.RegoData = regoData
 
 	// parse arguments must be enabled if the rule engine is part of the pipeline
 	runner.TraceeConfig.Output.ParseArguments = true
@@ -2,12 +2,14 @@ package cobra
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events/window"
 )
 
 type cliFlagger interface {
@@ -45,8 +47,16 @@ func GetFlagsFromViper(key string) ([]string, error) {
 		flagger = &OutputConfig{}
 	case "dnscache":
 		flagger = &DnsCacheConfig{}
+	case "cpu-affinity":
+		flagger = &CPUAffinityConfig{}
+	case "event-window":
+		flagger = &EventWindowConfig{}
 	default:
-		return nil, errfmt.Errorf("unrecognized key: %s", key)
+		// keys without a structured config are given as raw cli flags only
+		if _, ok := rawValue.(map[string]interface{}); ok {
+			return nil, errfmt.Errorf("unrecognized key: %s", key)
+		}
+		return getConfigFlags(rawValue, nil, key)
 	}
 
 	return getConfigFlags(rawValue, flagger, key)
@@ -129,8 +139,9 @@ func getCRIConfigFlags(rawValue interface{}) ([]string, error) {
 //
 
 type CacheConfig struct {
-	Type string `mapstructure:"type"`
-	Size int    `mapstructure:"size"`
+	Type     string `mapstructure:"type"`
+	Size     int    `mapstructure:"size"`
+	Overflow string `mapstructure:"overflow"`
 }
 
 func (c *CacheConfig) flags() []string {
@@ -142,6 +153,9 @@ func (c *CacheConfig) flags() []string {
 	if c.Size != 0 {
 		flags = append(flags, fmt.Sprintf("mem-cache-size=%d", c.Size))
 	}
+	if c.Overflow != "" {
+		flags = append(flags, fmt.Sprintf("mem-cache-overflow=%s", c.Overflow))
+	}
 
 	return flags
 }
@@ -151,8 +165,10 @@ func (c *CacheConfig) flags() []string {
 //
 
 type RegoConfig struct {
-	PartialEval bool `mapstructure:"partial-eval"`
-	AIO         bool `mapstructure:"aio"`
+	PartialEval bool              `mapstructure:"partial-eval"`
+	AIO         bool              `mapstructure:"aio"`
+	Data        map[string]string `mapstructure:"data"`
+	DataRefresh string            `mapstructure:"data-refresh"`
 }
 
 func (c *RegoConfig) flags() []string {
@@ -165,6 +181,18 @@ func (c *RegoConfig) flags() []string {
 		flags = append(flags, "aio")
 	}
 
+	names := make([]string, 0, len(c.Data))
+	for name := range c.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		flags = append(flags, fmt.Sprintf("data=%s:%s", name, c.Data[name]))
+	}
+	if c.DataRefresh != "" {
+		flags = append(flags, fmt.Sprintf("data-refresh=%s", c.DataRefresh))
+	}
+
 	return flags
 }
 
@@ -226,6 +254,67 @@ func (c *DnsCacheConfig) flags() []string {
 	return flags
 }
 
+//
+// cpu-affinity flag
+//
+
+type CPUAffinityConfig struct {
+	CPUs    string `mapstructure:"cpus"`
+	Exclude string `mapstructure:"exclude"`
+}
+
+func (c *CPUAffinityConfig) flags() []string {
+	flags := make([]string, 0)
+
+	if c.CPUs != "" {
+		flags = append(flags, fmt.Sprintf("cpus=%s", c.CPUs))
+	}
+	if c.Exclude != "" {
+		flags = append(flags, fmt.Sprintf("exclude=%s", c.Exclude))
+	}
+	if len(flags) == 0 {
+		flags = append(flags, "none")
+	}
+
+	return flags
+}
+
+//
+// event-window flag
+//
+
+type EventWindowConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	Duration  string `mapstructure:"duration"`
+	MaxEvents int    `mapstructure:"max-events"`
+	Dir       string `mapstructure:"dir"`
+}
+
+func (c *EventWindowConfig) flags() []string {
+	flags := make([]string, 0)
+
+	if !c.Enabled {
+		flags = append(flags, "none")
+		return flags
+	}
+
+	// always set, enabling the window even if no other option is given
+	duration := c.Duration
+	if duration == "" {
+		duration = window.DefaultDuration.String()
+	}
+	flags = append(flags, fmt.Sprintf("duration=%s", duration))
+
+	if c.MaxEvents != 0 {
+		flags = append(flags, fmt.Sprintf("max-events=%d", c.MaxEvents))
+	}
+	if c.Dir != "" {
+		flags = append(flags, fmt.Sprintf("dir=%s", c.Dir))
+	}
+
+	return flags
+}
+
 //
 // capabilities flag
 //
@@ -373,13 +462,24 @@ func getLogFilterAttrFlags(filterOut bool, attrs LogFilterAttributes) []string {
 //
 
 type OutputConfig struct {
-	Options      OutputOptsConfig               `mapstructure:"options"`
-	Table        OutputFormatConfig             `mapstructure:"table"`
-	TableVerbose OutputFormatConfig             `mapstructure:"table-verbose"`
-	JSON         OutputFormatConfig             `mapstructure:"json"`
-	GoTemplate   OutputGoTemplateConfig         `mapstructure:"gotemplate"`
-	Forwards     map[string]OutputForwardConfig `mapstructure:"forward"`
-	Webhooks     map[string]OutputWebhookConfig `mapstructure:"webhook"`
+	Options      OutputOptsConfig                  `mapstructure:"options"`
+	Table        OutputFormatConfig                `mapstructure:"table"`
+	TableVerbose OutputFormatConfig                `mapstructure:"table-verbose"`
+	JSON         OutputFormatConfig                `mapstructure:"json"`
+	JSONV2       OutputFormatConfig                `mapstructure:"json-v2"`
+	STIX         OutputFormatConfig                `mapstructure:"stix"`
+	GoTemplate   OutputGoTemplateConfig            `mapstructure:"gotemplate"`
+	GoTemplates  map[string]OutputGoTemplateConfig `mapstructure:"gotemplates"` // a template per sink
+	Forwards     map[string]OutputForwardConfig    `mapstructure:"forward"`
+	Webhooks     map[string]OutputWebhookConfig    `mapstructure:"webhook"`
+	SQLite       map[string]OutputSQLiteConfig     `mapstructure:"sqlite"`
+	ClickHouse   map[string]OutputClickHouseConfig `mapstructure:"clickhouse"`
+	GELF         map[string]OutputGELFConfig       `mapstructure:"gelf"`
+	Redis        map[string]OutputRedisConfig      `mapstructure:"redis"`
+	AMQP         map[string]OutputAMQPConfig       `mapstructure:"amqp"`
+	MQTT         map[string]OutputMQTTConfig       `mapstructure:"mqtt"`
+	PubSub       map[string]OutputPubSubConfig     `mapstructure:"pubsub"`
+	Kinesis      map[string]OutputKinesisConfig    `mapstructure:"kinesis"`
 }
 
 func (c *OutputConfig) flags() []string {
@@ -392,12 +492,30 @@ func (c *OutputConfig) flags() []string {
 	if c.Options.StackAddresses {
 		flags = append(flags, "option:stack-addresses")
 	}
+	for _, event := range c.Options.StackAddressesEvents {
+		flags = append(flags, fmt.Sprintf("option:stack-addresses=%s", event))
+	}
 	if c.Options.ExecEnv {
 		flags = append(flags, "option:exec-env")
 	}
+	if c.Options.ExecBuildID {
+		flags = append(flags, "option:exec-build-id")
+	}
+	if c.Options.CanonicalPaths {
+		flags = append(flags, "option:canonical-paths")
+	}
+	if c.Options.ContainerPaths {
+		flags = append(flags, "option:container-paths")
+	}
 	if c.Options.RelativeTime {
 		flags = append(flags, "option:relative-time")
 	}
+	if c.Options.TimeFormat != "" {
+		flags = append(flags, fmt.Sprintf("option:time-format=%s", c.Options.TimeFormat))
+	}
+	if c.Options.TimeZone != "" {
+		flags = append(flags, fmt.Sprintf("option:time-zone=%s", c.Options.TimeZone))
+	}
 	if c.Options.ExecHash != "" {
 		flags = append(flags, fmt.Sprintf("option:exec-hash=%s", c.Options.ExecHash))
 	}
@@ -410,12 +528,35 @@ func (c *OutputConfig) flags() []string {
 	if c.Options.SortEvents {
 		flags = append(flags, "option:sort-events")
 	}
+	if c.Options.Bytes != "" {
+		flags = append(flags, fmt.Sprintf("option:bytes=%s", c.Options.Bytes))
+	}
+	for kind, format := range c.Options.BytesOutputs {
+		flags = append(flags, fmt.Sprintf("option:bytes.%s=%s", kind, format))
+	}
+	if c.Options.Compress != "" {
+		flags = append(flags, fmt.Sprintf("option:compress=%s", c.Options.Compress))
+	}
+	if c.Options.RotateSize != "" {
+		flags = append(flags, fmt.Sprintf("option:rotate-size=%s", c.Options.RotateSize))
+	}
+	if c.Options.RotateInterval != "" {
+		flags = append(flags, fmt.Sprintf("option:rotate-interval=%s", c.Options.RotateInterval))
+	}
+	if c.Options.RetainFiles != 0 {
+		flags = append(flags, fmt.Sprintf("option:retain-files=%d", c.Options.RetainFiles))
+	}
+	if c.Options.RetainAge != "" {
+		flags = append(flags, fmt.Sprintf("option:retain-age=%s", c.Options.RetainAge))
+	}
 
 	// formats with files
 	formatFilesMap := map[string][]string{
 		"table":         c.Table.Files,
 		"table-verbose": c.TableVerbose.Files,
 		"json":          c.JSON.Files,
+		"json-v2":       c.JSONV2.Files,
+		"stix":          c.STIX.Files,
 	}
 	for format, files := range formatFilesMap {
 		for _, file := range files {
@@ -425,12 +566,10 @@ func (c *OutputConfig) flags() []string {
 
 	// gotemplate
 	if c.GoTemplate.Template != "" {
-		templateFlag := fmt.Sprintf("gotemplate=%s", c.GoTemplate.Template)
-		if len(c.GoTemplate.Files) > 0 {
-			templateFlag += ":" + strings.Join(c.GoTemplate.Files, ",")
-		}
-
-		flags = append(flags, templateFlag)
+		flags = append(flags, c.GoTemplate.flag())
+	}
+	for _, goTemplate := range c.GoTemplates {
+		flags = append(flags, goTemplate.flag())
 	}
 
 	// forward
@@ -444,8 +583,13 @@ func (c *OutputConfig) flags() []string {
 
 		url += fmt.Sprintf("%s:%d", forward.Host, forward.Port)
 
+		delim := "?"
 		if forward.Tag != "" {
-			url += fmt.Sprintf("?tag=%s", forward.Tag)
+			url += fmt.Sprintf("%stag=%s", delim, forward.Tag)
+			delim = "&"
+		}
+		if forward.GoTemplate != "" {
+			url += fmt.Sprintf("%sgotemplate=%s", delim, forward.GoTemplate)
 		}
 
 		flags = append(flags, fmt.Sprintf("forward:%s", url))
@@ -466,23 +610,241 @@ func (c *OutputConfig) flags() []string {
 		}
 		if webhook.ContentType != "" {
 			url += fmt.Sprintf("%scontentType=%s", delim, webhook.ContentType)
+			delim = "&"
+		}
+		if webhook.BatchSize != 0 {
+			url += fmt.Sprintf("%sbatchSize=%d", delim, webhook.BatchSize)
+			delim = "&"
+		}
+		if webhook.BatchBytes != 0 {
+			url += fmt.Sprintf("%sbatchBytes=%d", delim, webhook.BatchBytes)
+			delim = "&"
+		}
+		if webhook.FlushInterval != "" {
+			url += fmt.Sprintf("%sflushInterval=%s", delim, webhook.FlushInterval)
+			delim = "&"
+		}
+		if webhook.QueueSize != 0 {
+			url += fmt.Sprintf("%squeueSize=%d", delim, webhook.QueueSize)
+			delim = "&"
+		}
+		if webhook.Compression != "" {
+			url += fmt.Sprintf("%scompression=%s", delim, webhook.Compression)
+			delim = "&"
+		}
+		if webhook.Framing != "" {
+			url += fmt.Sprintf("%sframing=%s", delim, webhook.Framing)
 		}
 
 		flags = append(flags, fmt.Sprintf("webhook:%s", url))
 	}
 
+	// sqlite
+	for _, sqlite := range c.SQLite {
+		delim := "?"
+		path := sqlite.Path
+		if sqlite.Retention != "" {
+			path += fmt.Sprintf("%sretention=%s", delim, sqlite.Retention)
+			delim = "&"
+		}
+		if sqlite.MaxEvents != 0 {
+			path += fmt.Sprintf("%smaxEvents=%d", delim, sqlite.MaxEvents)
+		}
+
+		flags = append(flags, fmt.Sprintf("sqlite:%s", path))
+	}
+
+	// clickhouse
+	for _, clickhouse := range c.ClickHouse {
+		url := fmt.Sprintf("%s://", clickhouse.Protocol)
+		if clickhouse.User != "" {
+			url += clickhouse.User
+			if clickhouse.Password != "" {
+				url += ":" + clickhouse.Password
+			}
+			url += "@"
+		}
+		url += fmt.Sprintf("%s:%d", clickhouse.Host, clickhouse.Port)
+		if clickhouse.Database != "" {
+			url += "/" + clickhouse.Database
+		}
+
+		delim := "?"
+		if clickhouse.Table != "" {
+			url += fmt.Sprintf("%stable=%s", delim, clickhouse.Table)
+			delim = "&"
+		}
+		if clickhouse.TTL != "" {
+			url += fmt.Sprintf("%sttl=%s", delim, clickhouse.TTL)
+		}
+
+		flags = append(flags, fmt.Sprintf("clickhouse:%s", url))
+	}
+
+	// gelf
+	for _, gelf := range c.GELF {
+		url := fmt.Sprintf("%s://%s:%d", gelf.Protocol, gelf.Host, gelf.Port)
+
+		delim := "?"
+		if gelf.Compression != "" {
+			url += fmt.Sprintf("%scompression=%s", delim, gelf.Compression)
+			delim = "&"
+		}
+		if gelf.ChunkSize != 0 {
+			url += fmt.Sprintf("%schunkSize=%d", delim, gelf.ChunkSize)
+		}
+
+		flags = append(flags, fmt.Sprintf("gelf:%s", url))
+	}
+
+	// redis
+	for _, redis := range c.Redis {
+		url := fmt.Sprintf("%s://", redis.Protocol)
+		if redis.User != "" || redis.Password != "" {
+			url += redis.User
+			if redis.Password != "" {
+				url += ":" + redis.Password
+			}
+			url += "@"
+		}
+		url += fmt.Sprintf("%s:%d", redis.Host, redis.Port)
+		if redis.DB != 0 {
+			url += fmt.Sprintf("/%d", redis.DB)
+		}
+
+		delim := "?"
+		if redis.Stream != "" {
+			url += fmt.Sprintf("%sstream=%s", delim, redis.Stream)
+			delim = "&"
+		}
+		if redis.MaxLen != 0 {
+			url += fmt.Sprintf("%smaxLen=%d", delim, redis.MaxLen)
+		}
+
+		flags = append(flags, fmt.Sprintf("redis:%s", url))
+	}
+
+	// amqp
+	for _, amqp := range c.AMQP {
+		url := fmt.Sprintf("%s://", amqp.Protocol)
+		if amqp.User != "" {
+			url += amqp.User
+			if amqp.Password != "" {
+				url += ":" + amqp.Password
+			}
+			url += "@"
+		}
+		url += fmt.Sprintf("%s:%d/%s", amqp.Host, amqp.Port, amqp.VHost)
+
+		delim := "?"
+		if amqp.Exchange != "" {
+			url += fmt.Sprintf("%sexchange=%s", delim, amqp.Exchange)
+			delim = "&"
+		}
+		if amqp.RoutingKey != "" {
+			url += fmt.Sprintf("%sroutingKey=%s", delim, amqp.RoutingKey)
+			delim = "&"
+		}
+		if amqp.GoTemplate != "" {
+			url += fmt.Sprintf("%sgotemplate=%s", delim, amqp.GoTemplate)
+		}
+
+		flags = append(flags, fmt.Sprintf("amqp:%s", url))
+	}
+
+	// mqtt
+	for _, mqtt := range c.MQTT {
+		url := fmt.Sprintf("%s://", mqtt.Protocol)
+		if mqtt.User != "" {
+			url += mqtt.User
+			if mqtt.Password != "" {
+				url += ":" + mqtt.Password
+			}
+			url += "@"
+		}
+		url += fmt.Sprintf("%s:%d", mqtt.Host, mqtt.Port)
+
+		delim := "?"
+		for _, param := range []struct{ key, value string }{
+			{"topic", mqtt.Topic},
+			{"qos", mqtt.QoS},
+			{"clientId", mqtt.ClientID},
+			{"caFile", mqtt.CAFile},
+			{"certFile", mqtt.CertFile},
+			{"keyFile", mqtt.KeyFile},
+		} {
+			if param.value != "" {
+				url += fmt.Sprintf("%s%s=%s", delim, param.key, param.value)
+				delim = "&"
+			}
+		}
+
+		flags = append(flags, fmt.Sprintf("mqtt:%s", url))
+	}
+
+	// pubsub
+	for _, pubsub := range c.PubSub {
+		path := fmt.Sprintf("projects/%s/topics/%s", pubsub.Project, pubsub.Topic)
+
+		delim := "?"
+		for _, param := range []struct{ key, value string }{
+			{"orderingKey", pubsub.OrderingKey},
+			{"ordering", pubsub.Ordering},
+			{"endpoint", pubsub.Endpoint},
+		} {
+			if param.value != "" {
+				path += fmt.Sprintf("%s%s=%s", delim, param.key, param.value)
+				delim = "&"
+			}
+		}
+
+		flags = append(flags, fmt.Sprintf("pubsub:%s", path))
+	}
+
+	// kinesis
+	for _, kinesis := range c.Kinesis {
+		path := kinesis.Stream
+
+		delim := "?"
+		for _, param := range []struct{ key, value string }{
+			{"region", kinesis.Region},
+			{"partitionKey", kinesis.PartitionKey},
+			{"endpoint", kinesis.Endpoint},
+		} {
+			if param.value != "" {
+				path += fmt.Sprintf("%s%s=%s", delim, param.key, param.value)
+				delim = "&"
+			}
+		}
+
+		flags = append(flags, fmt.Sprintf("kinesis:%s", path))
+	}
+
 	return flags
 }
 
 type OutputOptsConfig struct {
-	None              bool   `mapstructure:"none"`
-	StackAddresses    bool   `mapstructure:"stack-addresses"`
-	ExecEnv           bool   `mapstructure:"exec-env"`
-	RelativeTime      bool   `mapstructure:"relative-time"`
-	ExecHash          string `mapstructure:"exec-hash"`
-	ParseArguments    bool   `mapstructure:"parse-arguments"`
-	ParseArgumentsFDs bool   `mapstructure:"parse-arguments-fds"`
-	SortEvents        bool   `mapstructure:"sort-events"`
+	None                 bool              `mapstructure:"none"`
+	StackAddresses       bool              `mapstructure:"stack-addresses"`
+	StackAddressesEvents []string          `mapstructure:"stack-addresses-events"`
+	ExecEnv              bool              `mapstructure:"exec-env"`
+	ExecBuildID          bool              `mapstructure:"exec-build-id"`
+	CanonicalPaths       bool              `mapstructure:"canonical-paths"`
+	ContainerPaths       bool              `mapstructure:"container-paths"`
+	RelativeTime         bool              `mapstructure:"relative-time"`
+	TimeFormat           string            `mapstructure:"time-format"`
+	TimeZone             string            `mapstructure:"time-zone"`
+	ExecHash             string            `mapstructure:"exec-hash"`
+	ParseArguments       bool              `mapstructure:"parse-arguments"`
+	ParseArgumentsFDs    bool              `mapstructure:"parse-arguments-fds"`
+	SortEvents           bool              `mapstructure:"sort-events"`
+	Bytes                string            `mapstructure:"bytes"`
+	BytesOutputs         map[string]string `mapstructure:"bytes-outputs"`
+	Compress             string            `mapstructure:"compress"`
+	RotateSize           string            `mapstructure:"rotate-size"`
+	RotateInterval       string            `mapstructure:"rotate-interval"`
+	RetainFiles          int               `mapstructure:"retain-files"`
+	RetainAge            string            `mapstructure:"retain-age"`
 }
 
 type OutputFormatConfig struct {
@@ -494,20 +856,113 @@ type OutputGoTemplateConfig struct {
 	Files    []string `mapstructure:"files"`
 }
 
+func (c OutputGoTemplateConfig) flag() string {
+	templateFlag := fmt.Sprintf("gotemplate=%s", c.Template)
+	if len(c.Files) > 0 {
+		templateFlag += ":" + strings.Join(c.Files, ",")
+	}
+
+	return templateFlag
+}
+
 type OutputForwardConfig struct {
+	Protocol   string `mapstructure:"protocol"`
+	User       string `mapstructure:"user"`
+	Password   string `mapstructure:"password"`
+	Host       string `mapstructure:"host"`
+	Port       int    `mapstructure:"port"`
+	Tag        string `mapstructure:"tag"`
+	GoTemplate string `mapstructure:"gotemplate"`
+}
+
+type OutputWebhookConfig struct {
+	Protocol      string `mapstructure:"protocol"`
+	Host          string `mapstructure:"host"`
+	Port          int    `mapstructure:"port"`
+	Timeout       string `mapstructure:"timeout"`
+	GoTemplate    string `mapstructure:"gotemplate"`
+	ContentType   string `mapstructure:"content-type"`
+	BatchSize     int    `mapstructure:"batch-size"`
+	BatchBytes    int    `mapstructure:"batch-bytes"`
+	FlushInterval string `mapstructure:"flush-interval"`
+	QueueSize     int    `mapstructure:"queue-size"`
+	Compression   string `mapstructure:"compression"`
+	Framing       string `mapstructure:"framing"`
+}
+
+type OutputClickHouseConfig struct {
 	Protocol string `mapstructure:"protocol"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
-	Tag      string `mapstructure:"tag"`
+	Database string `mapstructure:"database"`
+	Table    string `mapstructure:"table"`
+	TTL      string `mapstructure:"ttl"`
 }
 
-type OutputWebhookConfig struct {
+type OutputGELFConfig struct {
 	Protocol    string `mapstructure:"protocol"`
 	Host        string `mapstructure:"host"`
 	Port        int    `mapstructure:"port"`
-	Timeout     string `mapstructure:"timeout"`
-	GoTemplate  string `mapstructure:"gotemplate"`
-	ContentType string `mapstructure:"content-type"`
+	Compression string `mapstructure:"compression"`
+	ChunkSize   int    `mapstructure:"chunk-size"`
+}
+
+type OutputMQTTConfig struct {
+	Protocol string `mapstructure:"protocol"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Topic    string `mapstructure:"topic"`
+	QoS      string `mapstructure:"qos"`
+	ClientID string `mapstructure:"client-id"`
+	CAFile   string `mapstructure:"ca-file"`
+	CertFile string `mapstructure:"cert-file"`
+	KeyFile  string `mapstructure:"key-file"`
+}
+
+type OutputPubSubConfig struct {
+	Project     string `mapstructure:"project"`
+	Topic       string `mapstructure:"topic"`
+	OrderingKey string `mapstructure:"ordering-key"`
+	Ordering    string `mapstructure:"ordering"`
+	Endpoint    string `mapstructure:"endpoint"`
+}
+
+type OutputKinesisConfig struct {
+	Stream       string `mapstructure:"stream"`
+	Region       string `mapstructure:"region"`
+	PartitionKey string `mapstructure:"partition-key"`
+	Endpoint     string `mapstructure:"endpoint"`
+}
+
+type OutputAMQPConfig struct {
+	Protocol   string `mapstructure:"protocol"`
+	User       string `mapstructure:"user"`
+	Password   string `mapstructure:"password"`
+	Host       string `mapstructure:"host"`
+	Port       int    `mapstructure:"port"`
+	VHost      string `mapstructure:"vhost"`
+	Exchange   string `mapstructure:"exchange"`
+	RoutingKey string `mapstructure:"routing-key"`
+	GoTemplate string `mapstructure:"gotemplate"`
+}
+
+type OutputRedisConfig struct {
+	Protocol string `mapstructure:"protocol"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	DB       int    `mapstructure:"db"`
+	Stream   string `mapstructure:"stream"`
+	MaxLen   int64  `mapstructure:"max-len"`
+}
+
+type OutputSQLiteConfig struct {
+	Path      string `mapstructure:"path"`
+	Retention string `mapstructure:"retention"`
+	MaxEvents int64  `mapstructure:"max-events"`
 }
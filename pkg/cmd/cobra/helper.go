@@ -1,12 +1,55 @@
 package cobra
 
 import (
+	"context"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+
 	"github.com/aquasecurity/tracee/pkg/cmd/flags"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
 	k8s "github.com/aquasecurity/tracee/pkg/k8s/apis/tracee.aquasec.com/v1beta1"
+	"github.com/aquasecurity/tracee/pkg/oci"
 	"github.com/aquasecurity/tracee/pkg/policy"
 	"github.com/aquasecurity/tracee/pkg/policy/v1beta1"
+	"github.com/aquasecurity/tracee/pkg/signatures/rego"
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
 )
 
+// newOCIPuller returns the puller of the detection content given as OCI references,
+// configured by the oci flags.
+func newOCIPuller() (*oci.Puller, error) {
+	ociFlags, err := GetFlagsFromViper("oci")
+	if err != nil {
+		return nil, err
+	}
+
+	ociCfg, err := flags.PrepareOCI(ociFlags)
+	if err != nil {
+		return nil, err
+	}
+	if ociCfg.CacheDir == "" {
+		ociCfg.CacheDir = filepath.Join(viper.GetString("install-path"), "oci")
+	}
+
+	return oci.NewPuller(ociCfg)
+}
+
+// newRegoData returns the data documents referenced by the rego signatures, configured by
+// the rego flags (nil without data documents).
+func newRegoData(ctx context.Context, cfg rego.Config) (*regosig.Data, error) {
+	if len(cfg.Data) == 0 {
+		return nil, nil
+	}
+
+	data, err := regosig.NewData(ctx, cfg.Data, cfg.DataRefresh)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return data, nil
+}
+
 func createPoliciesFromK8SPolicy(policies []k8s.PolicyInterface) (*policy.Policies, error) {
 	policyScopeMap, policyEventsMap, err := flags.PrepareFilterMapsFromPolicies(policies)
 	if err != nil {
@@ -43,3 +86,12 @@ func createPoliciesFromCLIFlags(scopeFlags, eventFlags []string) (*policy.Polici
 
 	return flags.CreatePolicies(policyScopeMap, policyEventsMap, true)
 }
+
+func createPoliciesFromFilterExpressions(filterFlags []string) (*policy.Policies, error) {
+	policyScopeMap, policyEventsMap, err := flags.PrepareFilterMapsFromExpressions(filterFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	return flags.CreatePolicies(policyScopeMap, policyEventsMap, true)
+}
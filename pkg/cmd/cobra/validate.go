@@ -0,0 +1,277 @@
+package cobra
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/aquasecurity/libbpfgo/helpers"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
+	"github.com/aquasecurity/tracee/pkg/cmd/initialize"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/oci"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/v1beta1"
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+)
+
+// ValidationReport holds the problems found while validating a tracee configuration.
+type ValidationReport struct {
+	Errors   []error
+	Warnings []string
+}
+
+func (r *ValidationReport) addError(err error) {
+	r.Errors = append(r.Errors, err)
+}
+
+func (r *ValidationReport) addWarning(format string, a ...interface{}) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, a...))
+}
+
+// Print writes the report to the given writer.
+func (r *ValidationReport) Print(w io.Writer) {
+	for _, err := range r.Errors {
+		fmt.Fprintf(w, "error: %v\n", err)
+	}
+	for _, warn := range r.Warnings {
+		fmt.Fprintf(w, "warning: %s\n", warn)
+	}
+	fmt.Fprintf(w, "%d error(s), %d warning(s)\n", len(r.Errors), len(r.Warnings))
+}
+
+// Validate parses the given policies (or scope and events flags), compiles their filters,
+// loads the signatures metadata and checks the selected events against the running kernel.
+// It does not load the eBPF object nor attach any probes, and doesn't pull anything over
+// the network (OCI references and remote data documents are only checked for syntax), so
+// it can be used to validate policies and signatures (e.g. in CI) offline, without
+// affecting the host.
+func Validate(c *cobra.Command) (*ValidationReport, error) {
+	report := &ValidationReport{}
+
+	// Signatures
+
+	regoFlags, err := GetFlagsFromViper("rego")
+	if err != nil {
+		return nil, err
+	}
+
+	rego, err := flags.PrepareRego(regoFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	signaturesDirs := localSources("signatures", viper.GetStringSlice("signatures-dir"), report)
+
+	signaturePluginsFlags, err := GetFlagsFromViper("signature-plugins")
+	if err != nil {
+		return nil, err
+	}
+	pluginPolicy, err := flags.PrepareSignaturePlugins(signaturePluginsFlags)
+	if err != nil {
+		return nil, err
+	}
+	pluginPolicy.Audit = func(audit signature.PluginAudit) {
+		if !audit.Loaded {
+			report.addError(fmt.Errorf("signature plugin %s: refused, %s", audit.Path, audit.Reason))
+		}
+	}
+
+	for name, source := range rego.Data {
+		if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+			report.addWarning("rego data document %s: %s not fetched, the document isn't validated", name, source)
+			delete(rego.Data, name)
+		}
+	}
+	regoData, err := newRegoData(c.Context(), rego)
+	if err != nil {
+		report.addError(err)
+	}
+
+	sigs, _, err := signature.Find(
+		rego.RuntimeTarget,
+		rego.PartialEval,
+		signaturesDirs,
+		nil,
+		rego.AIO,
+		regoData,
+		pluginPolicy,
+	)
+	if err != nil {
+		report.addError(fmt.Errorf("signatures: %w", err))
+	}
+
+	sigsEvents := make(map[string]bool)
+	for _, sig := range sigs {
+		meta, err := sig.GetMetadata()
+		if err != nil {
+			report.addError(fmt.Errorf("signatures: failed to get metadata: %w", err))
+			continue
+		}
+		if meta.EventName == "" {
+			report.addError(fmt.Errorf("signature %s: event name cannot be empty", meta.ID))
+			continue
+		}
+		if _, ok := sigsEvents[meta.EventName]; ok {
+			report.addError(fmt.Errorf("signature %s: event %s is duplicated", meta.ID, meta.EventName))
+			continue
+		}
+		sigsEvents[meta.EventName] = true
+	}
+
+	for _, sig := range sigs {
+		meta, err := sig.GetMetadata()
+		if err != nil {
+			continue // already reported
+		}
+		selected, err := sig.GetSelectedEvents()
+		if err != nil {
+			report.addError(fmt.Errorf("signature %s: failed to get selected events: %w", meta.ID, err))
+			continue
+		}
+		for _, s := range selected {
+			if s.Source != "tracee" {
+				continue
+			}
+			if _, ok := events.Core.GetDefinitionIDByName(s.Name); ok || sigsEvents[s.Name] {
+				continue
+			}
+			report.addError(fmt.Errorf("signature %s: selected event %s does not exist", meta.ID, s.Name))
+		}
+	}
+
+	_ = initialize.CreateEventsFromSignatures(events.StartSignatureID, sigs)
+
+	// Policies
+
+	policyFlags, err := c.Flags().GetStringArray("policy")
+	if err != nil {
+		return nil, err
+	}
+	scopeFlags, err := c.Flags().GetStringArray("scope")
+	if err != nil {
+		return nil, err
+	}
+	eventFlags, err := c.Flags().GetStringArray("events")
+	if err != nil {
+		return nil, err
+	}
+	if len(policyFlags) > 0 && (len(scopeFlags) > 0 || len(eventFlags) > 0) {
+		return nil, errors.New("policy flag cannot be used together with scope or event flags")
+	}
+
+	var policies *policy.Policies
+
+	if len(policyFlags) > 0 {
+		policyFiles, fileErrs := v1beta1.ValidatePaths(localSources("policies", policyFlags, report))
+		for _, fileErr := range fileErrs {
+			report.addError(fileErr)
+		}
+		if len(policyFiles) > 0 {
+			policyScopeMap, policyEventsMap, err := flags.PrepareFilterMapsFromPolicies(policyFiles)
+			if err == nil {
+				policies, err = flags.CreatePolicies(policyScopeMap, policyEventsMap, true)
+			}
+			if err != nil {
+				report.addError(fmt.Errorf("policies: %w", err))
+			}
+		}
+	} else {
+		policies, err = createPoliciesFromCLIFlags(scopeFlags, eventFlags)
+		if err != nil {
+			report.addError(fmt.Errorf("flags: %w", err))
+		}
+	}
+
+	// Kernel compatibility
+
+	if policies != nil {
+		validateKernelCompatibility(policies, report)
+	}
+
+	return report, nil
+}
+
+// localSources returns the given sources but the OCI references, whose syntax only is
+// checked as validating doesn't pull them.
+func localSources(kind string, sources []string, report *ValidationReport) []string {
+	local := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if !oci.IsReference(source) {
+			local = append(local, source)
+			continue
+		}
+		if err := oci.ValidateReference(source); err != nil {
+			report.addError(fmt.Errorf("%s: %w", kind, err))
+			continue
+		}
+		report.addWarning("%s %s: OCI reference not pulled, its content isn't validated", kind, oci.Redact(source))
+	}
+
+	return local
+}
+
+// validateKernelCompatibility checks that the kernel symbols required by the selected
+// events, and by their dependencies, are available in the running kernel.
+func validateKernelCompatibility(policies *policy.Policies, report *ValidationReport) {
+	kernelSymbols, err := helpers.NewKernelSymbolTable()
+	if err != nil {
+		report.addWarning("kernel symbols could not be read, skipping kernel compatibility checks: %v", err)
+		return
+	}
+
+	visited := make(map[events.ID]bool)
+	var missingSymbols func(id events.ID) []string
+	missingSymbols = func(id events.ID) []string {
+		if visited[id] || !events.Core.IsDefined(id) {
+			return nil
+		}
+		visited[id] = true
+
+		var missing []string
+		deps := events.Core.GetDefinitionByID(id).GetDependencies()
+		for _, sym := range deps.GetRequiredKSymbols() {
+			// addresses are not checked: they are zeroed when running unprivileged
+			syms, err := kernelSymbols.GetSymbolByName(sym.GetSymbolName())
+			if err != nil || len(syms) == 0 {
+				missing = append(missing, sym.GetSymbolName())
+			}
+		}
+		for _, depID := range deps.GetIDs() {
+			missing = append(missing, missingSymbols(depID)...)
+		}
+		return missing
+	}
+
+	selected := make(map[events.ID]string) // event ID -> policy name
+	for it := policies.CreateAllIterator(); it.HasNext(); {
+		p := it.Next()
+		for id := range p.EventsToTrace {
+			selected[id] = p.Name
+		}
+	}
+
+	ids := make([]events.ID, 0, len(selected))
+	for id := range selected {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		visited = make(map[events.ID]bool)
+		missing := missingSymbols(id)
+		if len(missing) == 0 {
+			continue
+		}
+		report.addWarning(
+			"policy %s: event %s will be disabled in this kernel, missing kernel symbols: %v",
+			selected[id], events.Core.GetDefinitionByID(id).GetName(), missing,
+		)
+	}
+}
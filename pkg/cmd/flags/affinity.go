@@ -0,0 +1,105 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/utils"
+)
+
+func cpuAffinityHelp() string {
+	return `Pin the events pipeline (decode, process and arguments parsing) goroutines to CPUs.
+CPUs are given in the kernel cpu list format (e.g. 0-3,8).
+
+Example:
+  --cpu-affinity cpus=0-3          | run the pipeline only on CPUs 0 to 3.
+  --cpu-affinity exclude=4-7       | run the pipeline on all allowed CPUs but 4 to 7 (e.g. isolated for workloads).
+  --cpu-affinity none              | don't pin the pipeline goroutines (default).
+
+Use the flag multiple times to choose multiple options:
+  --cpu-affinity cpus=0-7 --cpu-affinity exclude=2-3
+`
+}
+
+// PrepareCPUAffinity returns the CPUs the pipeline goroutines should be pinned to, or nil
+// if they shouldn't be pinned. The CPUs must be allowed for the tracee process.
+func PrepareCPUAffinity(affinitySlice []string) ([]int, error) {
+	allowed, err := utils.GetAllowedCPUs()
+	if err != nil {
+		return nil, errfmt.Errorf("failed to get the allowed CPUs: %v", err)
+	}
+
+	return prepareCPUAffinity(affinitySlice, allowed)
+}
+
+func prepareCPUAffinity(affinitySlice []string, allowed []int) ([]int, error) {
+	var cpus, exclude []int
+	var err error
+
+	for _, opt := range affinitySlice {
+		if strings.HasPrefix(opt, "help") {
+			return nil, fmt.Errorf(cpuAffinityHelp())
+		}
+		if opt == "none" {
+			return nil, nil
+		}
+
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return nil, errfmt.Errorf("unrecognized cpu-affinity option format: %s", opt)
+		}
+
+		switch key {
+		case "cpus":
+			cpus, err = utils.ParseCPUList(value)
+		case "exclude":
+			exclude, err = utils.ParseCPUList(value)
+		default:
+			return nil, errfmt.Errorf("unrecognized cpu-affinity option format: %s", opt)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cpus == nil && exclude == nil {
+		return nil, nil
+	}
+
+	if cpus == nil {
+		cpus = allowed
+	}
+
+	isAllowed := make(map[int]bool, len(allowed))
+	for _, cpu := range allowed {
+		isAllowed[cpu] = true
+	}
+	for _, cpu := range cpus {
+		if !isAllowed[cpu] {
+			return nil, errfmt.Errorf("cpu-affinity CPU %d isn't allowed for tracee (allowed: %v)", cpu, allowed)
+		}
+	}
+
+	return cpusExcluding(cpus, exclude)
+}
+
+// cpusExcluding returns the given CPUs but the excluded ones.
+func cpusExcluding(cpus, exclude []int) ([]int, error) {
+	excluded := make(map[int]bool, len(exclude))
+	for _, cpu := range exclude {
+		excluded[cpu] = true
+	}
+
+	res := make([]int, 0, len(cpus))
+	for _, cpu := range cpus {
+		if !excluded[cpu] {
+			res = append(res, cpu)
+		}
+	}
+	if len(res) == 0 {
+		return nil, errfmt.Errorf("cpu-affinity excludes all the selected CPUs")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareCPUAffinity(t *testing.T) {
+	t.Parallel()
+
+	allowed := []int{0, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	testCases := []struct {
+		testName      string
+		affinitySlice []string
+		expectedCPUs  []int
+		expectedError string
+	}{
+		{
+			testName:      "none",
+			affinitySlice: []string{"none"},
+			expectedCPUs:  nil,
+		},
+		{
+			testName:      "cpus",
+			affinitySlice: []string{"cpus=0-3,8"},
+			expectedCPUs:  []int{0, 1, 2, 3, 8},
+		},
+		{
+			testName:      "cpus with exclude",
+			affinitySlice: []string{"cpus=0-7", "exclude=2-3,5"},
+			expectedCPUs:  []int{0, 1, 4, 6, 7},
+		},
+		{
+			testName:      "exclude all cpus",
+			affinitySlice: []string{"cpus=2-3", "exclude=0-3"},
+			expectedError: "cpu-affinity excludes all the selected CPUs",
+		},
+		{
+			testName:      "invalid cpu range",
+			affinitySlice: []string{"cpus=3-1"},
+			expectedError: `invalid cpu range "3-1" in cpu list "3-1"`,
+		},
+		{
+			testName:      "invalid cpu",
+			affinitySlice: []string{"exclude=a"},
+			expectedError: `invalid cpu "a" in cpu list "a"`,
+		},
+		{
+			testName:      "exclude from allowed cpus",
+			affinitySlice: []string{"exclude=0-5"},
+			expectedCPUs:  []int{6, 7, 8},
+		},
+		{
+			testName:      "cpu not allowed",
+			affinitySlice: []string{"cpus=8-9"},
+			expectedError: "cpu-affinity CPU 9 isn't allowed for tracee",
+		},
+		{
+			testName:      "cpu out of range",
+			affinitySlice: []string{"cpus=0-999999999"},
+			expectedError: `cpu 999999999 in cpu list "0-999999999" is out of range`,
+		},
+		{
+			testName:      "invalid option",
+			affinitySlice: []string{"cores=1"},
+			expectedError: "unrecognized cpu-affinity option format: cores=1",
+		},
+	}
+
+	for _, testcase := range testCases {
+		testcase := testcase
+
+		t.Run(testcase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cpus, err := prepareCPUAffinity(testcase.affinitySlice, allowed)
+			if testcase.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testcase.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testcase.expectedCPUs, cpus)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events/truncation"
+)
+
+func argLimitHelp() string {
+	return `Limit the size of the events arguments (e.g. command lines or buffers), so a single event can't
+blow up the size of the output. The string, buffer and string array arguments exceeding their size
+limit are truncated when decoded (after the arguments filters are applied), and marked with
+"truncated": true and their "originalSize", so the consumers know their data was cut.
+
+Possible options:
+  <size>        | limit the size of all the arguments.
+  <arg>=<size>  | limit the size of the arguments of the given name, overriding the limit of all the arguments (0 for no limit).
+
+The sizes are in bytes, with an optional K, M or G suffix (e.g. 4K). The size of a string array is the
+sum of its strings sizes.
+
+Examples:
+  --arg-limit 4K                               | limit the size of all the arguments to 4KB.
+  --arg-limit argv=8K --arg-limit buf=256      | limit the command lines to 8KB and the buffers to 256 bytes.
+  --arg-limit 1K --arg-limit pathname=0        | limit the size of all the arguments to 1KB, but the paths.
+`
+}
+
+// PrepareArgLimit returns the arguments size limits configuration.
+func PrepareArgLimit(argLimitSlice []string) (truncation.Config, error) {
+	var cfg truncation.Config
+
+	for _, opt := range argLimitSlice {
+		if strings.HasPrefix(opt, "help") {
+			return truncation.Config{}, fmt.Errorf(argLimitHelp())
+		}
+
+		name, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			size, err := parseSize(opt)
+			if err != nil {
+				return truncation.Config{}, errfmt.Errorf("invalid arg-limit option: %s, use '--arg-limit help' for more info", opt)
+			}
+			cfg.Default = int(size)
+			continue
+		}
+
+		if name == "" {
+			return truncation.Config{}, errfmt.Errorf("invalid arg-limit option: %s, use '--arg-limit help' for more info", opt)
+		}
+		size := int64(0)
+		if value != "0" {
+			var err error
+			size, err = parseSize(value)
+			if err != nil {
+				return truncation.Config{}, errfmt.Errorf("invalid arg-limit size of %s: %s", name, value)
+			}
+		}
+		if cfg.Args == nil {
+			cfg.Args = make(map[string]int)
+		}
+		cfg.Args[name] = int(size)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events/truncation"
+)
+
+func TestPrepareArgLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		argLimitSlice  []string
+		expectedConfig truncation.Config
+		expectedError  string
+	}{
+		{
+			testName:       "no limit",
+			argLimitSlice:  []string{},
+			expectedConfig: truncation.Config{},
+		},
+		{
+			testName:       "all arguments",
+			argLimitSlice:  []string{"4K"},
+			expectedConfig: truncation.Config{Default: 4096},
+		},
+		{
+			testName:      "arguments",
+			argLimitSlice: []string{"1K", "argv=8K", "buf=256", "pathname=0"},
+			expectedConfig: truncation.Config{
+				Default: 1024,
+				Args:    map[string]int{"argv": 8192, "buf": 256, "pathname": 0},
+			},
+		},
+		{
+			testName:      "invalid size",
+			argLimitSlice: []string{"4X"},
+			expectedError: "invalid arg-limit option: 4X",
+		},
+		{
+			testName:      "invalid argument size",
+			argLimitSlice: []string{"argv=-1"},
+			expectedError: "invalid arg-limit size of argv: -1",
+		},
+		{
+			testName:      "empty argument name",
+			argLimitSlice: []string{"=4K"},
+			expectedError: "invalid arg-limit option: =4K",
+		},
+		{
+			testName:      "help",
+			argLimitSlice: []string{"help"},
+			expectedError: argLimitHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareArgLimit(tc.argLimitSlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
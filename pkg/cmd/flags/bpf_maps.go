@@ -0,0 +1,93 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+// Defaults of the eBPF maps fill level monitoring
+const (
+	defaultBPFMapsInterval      = time.Minute
+	defaultBPFMapsWarnThreshold = 90
+)
+
+// bpfMapsOptions maps the resizable eBPF maps options to the maps names.
+var bpfMapsOptions = map[string]string{
+	"stack-addresses": "stack_addresses",
+	"fd-paths":        "fd_arg_path_map",
+	"containers":      "containers_map",
+}
+
+func bpfMapsHelp() string {
+	return `Configure the size of the eBPF maps holding transient data, and the monitoring of their fill level.
+Maps too small for the workload silently lose data: stack traces aging out of the stack addresses
+map before being read, fd paths evicted before the fd arguments are parsed, or containers missing
+from the containers map. At every interval, the fill level of these maps is checked, and a warning
+is logged for the maps filled over the threshold, or whose entries were missing once looked up
+(evictions). The fill levels and evictions are also exported as metrics (see --metrics).
+
+Possible options:
+  stack-addresses=<size>       | max entries of the stack addresses map (default: 1024).
+  fd-paths=<size>              | max entries of the fd paths map (default: 1024).
+  containers=<size>            | max entries of the containers map (default: 10240).
+  interval=<duration>          | interval between two fill level checks, 0 to disable the monitoring (default: 1m).
+  warn-threshold=<percent>     | fill level a map is reported under pressure from (default: 90).
+
+Examples:
+  --output option:stack-addresses --bpf-maps stack-addresses=8192      | keep more stack traces around.
+  --bpf-maps interval=10s --bpf-maps warn-threshold=75                  | check the maps every 10 seconds.
+`
+}
+
+// PrepareBPFMaps returns the eBPF maps sizes and monitoring configuration of the given
+// options.
+func PrepareBPFMaps(bpfMapsSlice []string) (config.BPFMapsConfig, error) {
+	cfg := config.BPFMapsConfig{
+		Sizes:         map[string]uint32{},
+		Interval:      defaultBPFMapsInterval,
+		WarnThreshold: defaultBPFMapsWarnThreshold,
+	}
+
+	for _, opt := range bpfMapsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(bpfMapsHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid bpf-maps option: %s, use '--bpf-maps help' for more info", opt)
+		}
+
+		if name, ok := bpfMapsOptions[key]; ok {
+			size, err := strconv.ParseUint(value, 10, 32)
+			if err != nil || size == 0 {
+				return cfg, fmt.Errorf("invalid bpf-maps %s size: %s", key, value)
+			}
+			cfg.Sizes[name] = uint32(size)
+			continue
+		}
+
+		switch key {
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || (interval != 0 && interval < time.Second) {
+				return cfg, fmt.Errorf("invalid bpf-maps interval: %s (minimum 1s)", value)
+			}
+			cfg.Interval = interval
+		case "warn-threshold":
+			threshold, err := parsePercent(value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid bpf-maps warn-threshold: %s", value)
+			}
+			cfg.WarnThreshold = threshold
+		default:
+			return cfg, fmt.Errorf("invalid bpf-maps option: %s, use '--bpf-maps help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+func TestPrepareBPFMaps(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		bpfMapsSlice   []string
+		expectedConfig config.BPFMapsConfig
+		expectedError  string
+	}{
+		{
+			testName:     "default",
+			bpfMapsSlice: []string{},
+			expectedConfig: config.BPFMapsConfig{
+				Sizes:         map[string]uint32{},
+				Interval:      time.Minute,
+				WarnThreshold: 90,
+			},
+		},
+		{
+			testName:     "sizes",
+			bpfMapsSlice: []string{"stack-addresses=8192", "fd-paths=4096", "containers=20480"},
+			expectedConfig: config.BPFMapsConfig{
+				Sizes: map[string]uint32{
+					"stack_addresses": 8192,
+					"fd_arg_path_map": 4096,
+					"containers_map":  20480,
+				},
+				Interval:      time.Minute,
+				WarnThreshold: 90,
+			},
+		},
+		{
+			testName:     "monitoring disabled",
+			bpfMapsSlice: []string{"interval=0", "warn-threshold=75"},
+			expectedConfig: config.BPFMapsConfig{
+				Sizes:         map[string]uint32{},
+				WarnThreshold: 75,
+			},
+		},
+		{
+			testName:      "invalid size",
+			bpfMapsSlice:  []string{"stack-addresses=0"},
+			expectedError: "invalid bpf-maps stack-addresses size: 0",
+		},
+		{
+			testName:      "interval too short",
+			bpfMapsSlice:  []string{"interval=100ms"},
+			expectedError: "invalid bpf-maps interval: 100ms (minimum 1s)",
+		},
+		{
+			testName:      "invalid threshold",
+			bpfMapsSlice:  []string{"warn-threshold=120"},
+			expectedError: "invalid bpf-maps warn-threshold: 120",
+		},
+		{
+			testName:      "unknown map",
+			bpfMapsSlice:  []string{"args=1024"},
+			expectedError: "invalid bpf-maps option: args=1024",
+		},
+		{
+			testName:      "help",
+			bpfMapsSlice:  []string{"help"},
+			expectedError: bpfMapsHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareBPFMaps(tc.bpfMapsSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/btfhub"
+)
+
+func btfHubHelp() string {
+	return `Fetch the BTF of the running kernel from BTFHub archives or mirrors when the kernel doesn't
+expose its own (/sys/kernel/btf/vmlinux) and it isn't embedded in tracee, enabling older distribution
+kernels without custom builds. The BTF environment variable TRACEE_BTF_FILE takes precedence.
+
+The archives and mirrors are laid out as the BTFHub archive repository:
+  <os id>/<os version>/<arch>/<kernel release>.btf[.tar.xz|.tar.gz]
+(e.g. ubuntu/20.04/x86_64/5.4.0-42-generic.btf.tar.xz). The .tar.xz files are extracted with the
+xz tool, which must be installed. The archives are searched first, then the mirrors, in order.
+The BTF found is written to the install path (--install-path).
+
+Possible options:
+  archive=<path>         | tar archive (optionally gzip compressed) holding a BTFHub tree (can be repeated).
+  mirror=<url|dir>       | BTFHub mirror: an http(s) URL or a local directory (can be repeated).
+  timeout=<duration>     | timeout of a download from a mirror (default: 30s).
+  none                   | only use the BTF embedded in tracee (default).
+
+Examples:
+  --btfhub archive=/opt/tracee/btfhub.tar.gz
+  --btfhub mirror=https://btfhub.example.com/archive --btfhub mirror=/mnt/btfhub-archive
+`
+}
+
+// PrepareBTFHub returns the BTFHub archives and mirrors configuration of the given options.
+func PrepareBTFHub(btfHubSlice []string) (btfhub.Config, error) {
+	var cfg btfhub.Config
+
+	for _, opt := range btfHubSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(btfHubHelp())
+		}
+		if opt == "none" {
+			return btfhub.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid btfhub option: %s, use '--btfhub help' for more info", opt)
+		}
+
+		switch key {
+		case "archive":
+			cfg.Archives = append(cfg.Archives, value)
+		case "mirror":
+			cfg.Mirrors = append(cfg.Mirrors, value)
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil || timeout <= 0 {
+				return cfg, fmt.Errorf("invalid btfhub timeout: %s", value)
+			}
+			cfg.Timeout = timeout
+		default:
+			return cfg, fmt.Errorf("invalid btfhub option: %s, use '--btfhub help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/btfhub"
+)
+
+func TestPrepareBTFHub(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		btfHubSlice    []string
+		expectedConfig btfhub.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			btfHubSlice:    []string{"none"},
+			expectedConfig: btfhub.Config{},
+		},
+		{
+			testName: "archives and mirrors",
+			btfHubSlice: []string{
+				"archive=/opt/tracee/btfhub.tar.gz",
+				"mirror=https://btfhub.example.com/archive",
+				"mirror=/mnt/btfhub-archive",
+				"timeout=10s",
+			},
+			expectedConfig: btfhub.Config{
+				Archives: []string{"/opt/tracee/btfhub.tar.gz"},
+				Mirrors:  []string{"https://btfhub.example.com/archive", "/mnt/btfhub-archive"},
+				Timeout:  10 * time.Second,
+			},
+		},
+		{
+			testName:      "empty mirror",
+			btfHubSlice:   []string{"mirror="},
+			expectedError: "invalid btfhub option: mirror=",
+		},
+		{
+			testName:      "invalid timeout",
+			btfHubSlice:   []string{"timeout=0s"},
+			expectedError: "invalid btfhub timeout: 0s",
+		},
+		{
+			testName:      "unknown option",
+			btfHubSlice:   []string{"file=/tmp/tracee.btf"},
+			expectedError: "invalid btfhub option: file=/tmp/tracee.btf",
+		},
+		{
+			testName:      "help",
+			btfHubSlice:   []string{"help"},
+			expectedError: btfHubHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareBTFHub(tc.btfHubSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -13,9 +13,15 @@ func cacheHelp() string {
 Possible options:
 cache-type={none,mem}                              pick the appropriate cache type.
 mem-cache-size=256                                 set memory cache size in MB. only works for cache-type=mem.
+mem-cache-overflow={block,sample,drop}             what to do with new events when the memory cache usage is high
+                                                   (above 80% of its size). only works for cache-type=mem:
+                                                   block: wait for the cache to have room when it is full (default).
+                                                   sample: cache 1 of every 10 events, drop new events when full.
+                                                   drop: drop new events until the usage goes below 60%.
 Example:
   --cache cache-type=mem                                   | will cache events in memory using default values.
   --cache cache-type=mem --cache mem-cache-size=1024       | will cache events in memory. will set memory cache size to 1024 MB.
+  --cache cache-type=mem --cache mem-cache-overflow=sample | will cache events in memory. will sample events when the cache is almost full.
   --cache none                                             | no event caching in the pipeline (default).
 Use this flag multiple times to choose multiple output options
 `
@@ -31,6 +37,7 @@ func PrepareCache(cacheSlice []string) (queue.CacheConfig, error) {
 	}
 
 	eventsCacheMemSizeMb := 0
+	overflowPolicy := queue.OverflowBlock
 	for _, o := range cacheSlice {
 		cacheParts := strings.SplitN(o, "=", 2)
 		if len(cacheParts) != 2 {
@@ -55,13 +62,27 @@ func PrepareCache(cacheSlice []string) (queue.CacheConfig, error) {
 			if err != nil {
 				return nil, errfmt.Errorf("could not parse mem-cache-size value: %v", err)
 			}
+		case "mem-cache-overflow":
+			if !cacheTypeMem {
+				return nil, errfmt.Errorf("you need to specify cache-type=mem before setting mem-cache-overflow")
+			}
+			switch value {
+			case "block":
+				overflowPolicy = queue.OverflowBlock
+			case "sample":
+				overflowPolicy = queue.OverflowSample
+			case "drop":
+				overflowPolicy = queue.OverflowDrop
+			default:
+				return nil, errfmt.Errorf("unrecognized mem-cache-overflow option: %s (valid options are: block,sample,drop)", o)
+			}
 
 		default:
 			return nil, errfmt.Errorf("unrecognized cache option format: %s", o)
 		}
 	}
 	if cacheTypeMem {
-		return queue.NewEventQueueMem(eventsCacheMemSizeMb), nil
+		return queue.NewEventQueueMemWithPolicy(eventsCacheMemSizeMb, overflowPolicy), nil
 	}
 
 	return nil, nil
@@ -46,6 +46,23 @@ func TestPrepareCache(t *testing.T) {
 			expectedCache: nil,
 			expectedError: errors.New("you need to specify cache-type=mem before setting mem-cache-size"),
 		},
+		{
+			testName:      "mem-cache-overflow=drop without cache-type=mem",
+			cacheSlice:    []string{"mem-cache-overflow=drop"},
+			expectedCache: nil,
+			expectedError: errors.New("you need to specify cache-type=mem before setting mem-cache-overflow"),
+		},
+		{
+			testName:      "invalid mem-cache-overflow",
+			cacheSlice:    []string{"cache-type=mem", "mem-cache-overflow=bleh"},
+			expectedCache: nil,
+			expectedError: errors.New("unrecognized mem-cache-overflow option: mem-cache-overflow=bleh (valid options are: block,sample,drop)"),
+		},
+		{
+			testName:      "cache-type=mem with mem-cache-overflow=sample",
+			cacheSlice:    []string{"cache-type=mem", "mem-cache-overflow=sample"},
+			expectedCache: queue.NewEventQueueMemWithPolicy(0, queue.OverflowSample),
+		},
 		{
 			testName:      "cache-type=mem with mem-cache-size=512",
 			cacheSlice:    []string{"cache-type=mem", "mem-cache-size=512"},
@@ -16,6 +16,8 @@ Opt out from dropping capabilities by default OR set specific ones.
 
 Possible options:
   --capabilities bypass=[true|false]        | keep all capabilities during execution time.
+  --capabilities unprivileged=[true|false]  | run with CAP_BPF and CAP_PERFMON only (no CAP_SYS_ADMIN, kernel 5.8 or newer),
+                                            | disabling the events needing more capabilities (reported by capability_report).
   --capabilities add=cap_kill,cap_syslog    | add specific capabilities to the "required" capabilities ring.
   --capabilities drop=cap_chown             | drop specific capabilities from the "required" capabilities ring.
 
@@ -37,6 +39,14 @@ func PrepareCapabilities(capsSlice []string) (config.CapabilitiesConfig, error)
 				return capsConfig, errfmt.Errorf("bypass should either be true or false")
 			}
 		}
+		if strings.HasPrefix(slice, "unprivileged=") {
+			u := strings.TrimPrefix(slice, "unprivileged=")
+			if u == "1" || u == "true" {
+				capsConfig.Unprivileged = true
+			} else if u != "0" && u != "false" {
+				return capsConfig, errfmt.Errorf("unprivileged should either be true or false")
+			}
+		}
 		if strings.HasPrefix(slice, "add=") {
 			suffix := strings.TrimPrefix(slice, "add=")
 			if len(suffix) > 0 {
@@ -61,6 +71,10 @@ func PrepareCapabilities(capsSlice []string) (config.CapabilitiesConfig, error)
 		}
 	}
 
+	if capsConfig.BypassCaps && capsConfig.Unprivileged {
+		return capsConfig, errfmt.Errorf("cant bypass capabilities in unprivileged mode")
+	}
+
 	for _, a := range capsConfig.AddCaps {
 		for _, d := range capsConfig.DropCaps {
 			if a == d {
@@ -0,0 +1,71 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+func TestPrepareCapabilities(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		capsSlice      []string
+		expectedConfig config.CapabilitiesConfig
+		expectedError  string
+	}{
+		{
+			testName:       "default",
+			capsSlice:      []string{},
+			expectedConfig: config.CapabilitiesConfig{},
+		},
+		{
+			testName:       "bypass",
+			capsSlice:      []string{"bypass=true"},
+			expectedConfig: config.CapabilitiesConfig{BypassCaps: true},
+		},
+		{
+			testName:  "unprivileged",
+			capsSlice: []string{"unprivileged=1", "add=cap_syslog"},
+			expectedConfig: config.CapabilitiesConfig{
+				Unprivileged: true,
+				AddCaps:      []string{"cap_syslog"},
+			},
+		},
+		{
+			testName:      "invalid unprivileged",
+			capsSlice:     []string{"unprivileged=yes"},
+			expectedError: "unprivileged should either be true or false",
+		},
+		{
+			testName:      "bypass in unprivileged mode",
+			capsSlice:     []string{"bypass=true", "unprivileged=true"},
+			expectedError: "cant bypass capabilities in unprivileged mode",
+		},
+		{
+			testName:      "add and drop",
+			capsSlice:     []string{"add=cap_kill", "drop=cap_kill"},
+			expectedError: "cant add and drop cap_kill at the same time",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			capsConfig, err := PrepareCapabilities(tc.capsSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, capsConfig)
+		})
+	}
+}
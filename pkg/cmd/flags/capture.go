@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/aquasecurity/tracee/pkg/artifacts"
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/logger"
@@ -28,6 +29,19 @@ Possible options:
 dir:/path/to/dir                              path where tracee will save produced artifacts. the artifact will be saved into an 'out' subdirectory. (default: /tmp/tracee).
 clear-dir                                     clear the captured artifacts output dir before starting (default: false).
 
+Artifacts index:
+
+index                                         index the captured artifacts (hash, capture type, process and container) into 'artifacts.json' in the output dir.
+retention-age=<duration>                      remove the indexed artifacts older than the given duration (e.g. 24h). Implies index.
+retention-size=<size>                         remove the oldest indexed artifacts once their total size exceeds the given size (e.g. 1G). Implies index.
+retention-count=<count>                       remove the oldest indexed artifacts once their number exceeds the given count. Implies index.
+upload=<s3://bucket[/prefix]|gs://bucket[/prefix]>
+                                              upload the indexed artifacts to an S3 or GCS bucket, with manifests linking them to their process, container and capture event. Implies index.
+                                              the requests are signed with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY (and AWS_SESSION_TOKEN) environment variables (HMAC keys for GCS).
+upload-region=<region>                        region of the S3 bucket (default: us-east-1).
+upload-endpoint=<url>                         S3 compatible endpoint, the bucket being addressed in the path (e.g. a MinIO server).
+upload-signing-key=<path>                     PEM private key (ECDSA, RSA or Ed25519) signing the uploaded manifests, verified with 'cosign verify-blob'.
+
 Network:
 
 pcap:[single,process,container,command]       capture separate pcap files organized by single file, files per processes, containers and/or commands
@@ -58,6 +72,7 @@ Examples:
   --capture write=/usr/bin/* --capture write=/etc/*        | capture files that were written into anywhere under /usr/bin/ or /etc/
   --capture exec --output none                             | capture executed files into the default output directory not printing the stream of events
   --capture write:type=socket --capture write:fd=stdout    | capture file writes to socket files which are the 'stdout' of the writing process
+  --capture exec --capture retention-size=1G               | capture executed files, keeping the most recent ones up to 1G
 
 Network Examples:
   --capture net (or network)                               | capture network traffic. default: single pcap file containing all packets (traced/filtered or not)
@@ -181,6 +196,16 @@ func PrepareCapture(captureSlice []string, newBinary bool) (config.CaptureConfig
 				amount = (1 << 16) - 1
 			}
 			capture.Net.CaptureLength = uint32(amount) // of packet length to be captured in bytes
+		} else if c == "index" {
+			capture.Index.Enabled = true
+		} else if strings.HasPrefix(c, "retention-") {
+			if err := parseCaptureRetention(c, &capture.Index); err != nil {
+				return config.CaptureConfig{}, err
+			}
+		} else if strings.HasPrefix(c, "upload") {
+			if err := parseCaptureUpload(c, &capture.Index); err != nil {
+				return config.CaptureConfig{}, err
+			}
 		} else if c == "clear-dir" {
 			clearDir = true
 		} else if strings.HasPrefix(c, "dir:") {
@@ -197,6 +222,11 @@ func PrepareCapture(captureSlice []string, newBinary bool) (config.CaptureConfig
 		}
 	}
 
+	upload := capture.Index.Upload
+	if !upload.Enabled() && (upload.Region != "" || upload.Endpoint != "" || upload.SigningKey != "") {
+		return config.CaptureConfig{}, errfmt.Errorf("capture upload options given without an upload url, use '--capture help' for more info")
+	}
+
 	capture.OutputPath = filepath.Join(outDir, "out")
 	if !clearDir {
 		return capture, nil
@@ -209,6 +239,67 @@ func PrepareCapture(captureSlice []string, newBinary bool) (config.CaptureConfig
 	return capture, nil
 }
 
+// parseCaptureRetention parses a retention option of the captured artifacts index, of the
+// format 'retention-<age|size|count>=<value>', enabling the index.
+func parseCaptureRetention(option string, index *artifacts.Config) error {
+	name, value, found := strings.Cut(option, "=")
+	if !found || value == "" {
+		return errfmt.Errorf("invalid capture retention option '%s', use '--capture help' for more info", option)
+	}
+
+	var err error
+	switch name {
+	case "retention-age":
+		index.MaxAge, err = parsePositiveDuration(value)
+	case "retention-size":
+		index.MaxSize, err = parseSize(value)
+	case "retention-count":
+		index.MaxCount, err = strconv.Atoi(value)
+		if err == nil && index.MaxCount < 1 {
+			err = fmt.Errorf("invalid count %q", value)
+		}
+	default:
+		return errfmt.Errorf("invalid capture option specified, use '--capture help' for more info")
+	}
+	if err != nil {
+		return errfmt.Errorf("invalid capture option '%s': %v", option, err)
+	}
+	index.Enabled = true
+
+	return nil
+}
+
+// parseCaptureUpload parses an upload option of the indexed artifacts, of the format
+// 'upload[-<region|endpoint|signing-key>]=<value>', enabling the index.
+func parseCaptureUpload(option string, index *artifacts.Config) error {
+	name, value, found := strings.Cut(option, "=")
+	if !found || value == "" {
+		return errfmt.Errorf("invalid capture upload option '%s', use '--capture help' for more info", option)
+	}
+
+	switch name {
+	case "upload":
+		if !strings.HasPrefix(value, "s3://") && !strings.HasPrefix(value, "gs://") {
+			return errfmt.Errorf("invalid capture option '%s': expected an s3:// or gs:// url", option)
+		}
+		index.Upload.URL = value
+	case "upload-region":
+		index.Upload.Region = value
+	case "upload-endpoint":
+		if !strings.HasPrefix(value, "http://") && !strings.HasPrefix(value, "https://") {
+			return errfmt.Errorf("invalid capture option '%s': expected an http(s):// url", option)
+		}
+		index.Upload.Endpoint = value
+	case "upload-signing-key":
+		index.Upload.SigningKey = value
+	default:
+		return errfmt.Errorf("invalid capture option specified, use '--capture help' for more info")
+	}
+	index.Enabled = true
+
+	return nil
+}
+
 // parseFileCaptureOption parse file capture cmdline argument option of all supported formats.
 func parseFileCaptureOption(arg string, cap string, captureConfig *config.FileCaptureConfig) error {
 	captureConfig.Capture = true
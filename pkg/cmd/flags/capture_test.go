@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aquasecurity/tracee/pkg/artifacts"
 	"github.com/aquasecurity/tracee/pkg/config"
 )
 
@@ -79,6 +81,77 @@ func TestPrepareCapture(t *testing.T) {
 					Exec:       true,
 				},
 			},
+			{
+				testName:     "capture exec with index",
+				captureSlice: []string{"exec", "index"},
+				expectedCapture: config.CaptureConfig{
+					OutputPath: "/tmp/tracee/out",
+					Exec:       true,
+					Index:      artifacts.Config{Enabled: true},
+				},
+			},
+			{
+				testName:     "capture exec with retention",
+				captureSlice: []string{"exec", "retention-age=24h", "retention-size=1G", "retention-count=100"},
+				expectedCapture: config.CaptureConfig{
+					OutputPath: "/tmp/tracee/out",
+					Exec:       true,
+					Index: artifacts.Config{
+						Enabled:  true,
+						MaxAge:   24 * time.Hour,
+						MaxSize:  1 << 30,
+						MaxCount: 100,
+					},
+				},
+			},
+			{
+				testName: "capture exec with upload",
+				captureSlice: []string{
+					"exec",
+					"upload=s3://evidence/tracee",
+					"upload-region=eu-west-1",
+					"upload-endpoint=https://minio:9000",
+					"upload-signing-key=/etc/tracee/signing.key",
+				},
+				expectedCapture: config.CaptureConfig{
+					OutputPath: "/tmp/tracee/out",
+					Exec:       true,
+					Index: artifacts.Config{
+						Enabled: true,
+						Upload: artifacts.UploadConfig{
+							URL:        "s3://evidence/tracee",
+							Region:     "eu-west-1",
+							Endpoint:   "https://minio:9000",
+							SigningKey: "/etc/tracee/signing.key",
+						},
+					},
+				},
+			},
+			{
+				testName:      "invalid capture upload url",
+				captureSlice:  []string{"upload=/tmp/evidence"},
+				expectedError: errors.New("invalid capture option 'upload=/tmp/evidence': expected an s3:// or gs:// url"),
+			},
+			{
+				testName:      "capture upload option without url",
+				captureSlice:  []string{"upload-region=eu-west-1"},
+				expectedError: errors.New("capture upload options given without an upload url"),
+			},
+			{
+				testName:      "invalid capture retention age",
+				captureSlice:  []string{"retention-age=-1h"},
+				expectedError: errors.New("invalid capture option 'retention-age=-1h'"),
+			},
+			{
+				testName:      "invalid capture retention count",
+				captureSlice:  []string{"retention-count=0"},
+				expectedError: errors.New("invalid capture option 'retention-count=0'"),
+			},
+			{
+				testName:      "empty capture retention",
+				captureSlice:  []string{"retention-size="},
+				expectedError: errors.New("invalid capture retention option 'retention-size='"),
+			},
 			{
 				testName:     "capture module",
 				captureSlice: []string{"module"},
@@ -0,0 +1,98 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/cgroup/pressure"
+)
+
+func cgroupPressureHelp() string {
+	return `Configure the resource pressure monitoring of the containers cgroups, which emits the
+cgroup_memory_pressure, cgroup_cpu_pressure and cgroup_io_pressure events. The cgroup v2 files of
+the containers (memory.events, cpu.stat and the memory, cpu and io PSI files) are sampled at every
+interval, and an event is emitted per container and controller under pressure during the interval:
+  memory | the memory usage exceeded the high boundary, reached the max limit, or processes were
+           OOM killed, or tasks stalled on memory for the psi-threshold share of the interval.
+  cpu    | the container was throttled in the throttle-threshold share of the CPU periods, or
+           tasks stalled on CPU for the psi-threshold share of the interval.
+  io     | tasks stalled on IO for the psi-threshold share of the interval.
+The events must be selected (e.g. --events cgroup_memory_pressure) for the cgroups to be monitored.
+Only hosts where cgroup v2 is the default cgroup version are supported.
+
+Possible options:
+  interval=<duration>          | interval between two samples of the cgroups (default: 10s).
+  periodic                     | emit the events of every container at every interval, under pressure or not.
+  psi-threshold=<percent>      | share of the interval tasks stalled for to emit the events (default: 10).
+  throttle-threshold=<percent> | share of the CPU periods the container was throttled in to emit the events (default: 10).
+
+Examples:
+  --events cgroup_memory_pressure --cgroup-pressure interval=5s                          | sample the cgroups every 5 seconds.
+  --scope container=new --events cgroup_cpu_pressure --cgroup-pressure periodic           | emit the cpu usage of the new containers.
+  --events cgroup_io_pressure --cgroup-pressure psi-threshold=50                          | emit the containers stalled on IO half of the time.
+`
+}
+
+// PrepareCgroupPressure returns the cgroups resource pressure monitoring configuration of
+// the given options.
+func PrepareCgroupPressure(cgroupPressureSlice []string) (pressure.Config, error) {
+	cfg := pressure.Config{
+		Interval:          pressure.DefaultInterval,
+		PSIThreshold:      pressure.DefaultPSIThreshold,
+		ThrottleThreshold: pressure.DefaultThrottleThreshold,
+	}
+
+	for _, opt := range cgroupPressureSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(cgroupPressureHelp())
+		}
+		if opt == "periodic" {
+			cfg.Periodic = true
+			continue
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid cgroup-pressure option: %s, use '--cgroup-pressure help' for more info", opt)
+		}
+
+		switch key {
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval < time.Second {
+				return cfg, fmt.Errorf("invalid cgroup-pressure interval: %s (minimum 1s)", value)
+			}
+			cfg.Interval = interval
+		case "psi-threshold":
+			threshold, err := parsePercent(value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid cgroup-pressure psi-threshold: %s", value)
+			}
+			cfg.PSIThreshold = threshold
+		case "throttle-threshold":
+			threshold, err := parsePercent(value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid cgroup-pressure throttle-threshold: %s", value)
+			}
+			cfg.ThrottleThreshold = threshold
+		default:
+			return cfg, fmt.Errorf("invalid cgroup-pressure option: %s, use '--cgroup-pressure help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
+
+func parsePercent(value string) (float64, error) {
+	percent, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if percent <= 0 || percent > 100 {
+		return 0, fmt.Errorf("out of range")
+	}
+
+	return percent, nil
+}
@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cgroup/pressure"
+)
+
+func TestPrepareCgroupPressure(t *testing.T) {
+	t.Parallel()
+
+	defaultConfig := pressure.Config{
+		Interval:          pressure.DefaultInterval,
+		PSIThreshold:      pressure.DefaultPSIThreshold,
+		ThrottleThreshold: pressure.DefaultThrottleThreshold,
+	}
+
+	testCases := []struct {
+		testName            string
+		cgroupPressureSlice []string
+		expectedConfig      pressure.Config
+		expectedError       string
+	}{
+		{
+			testName:            "default",
+			cgroupPressureSlice: []string{},
+			expectedConfig:      defaultConfig,
+		},
+		{
+			testName:            "periodic with interval",
+			cgroupPressureSlice: []string{"interval=30s", "periodic"},
+			expectedConfig: pressure.Config{
+				Interval:          30 * time.Second,
+				Periodic:          true,
+				PSIThreshold:      pressure.DefaultPSIThreshold,
+				ThrottleThreshold: pressure.DefaultThrottleThreshold,
+			},
+		},
+		{
+			testName:            "thresholds",
+			cgroupPressureSlice: []string{"psi-threshold=2.5", "throttle-threshold=50"},
+			expectedConfig: pressure.Config{
+				Interval:          pressure.DefaultInterval,
+				PSIThreshold:      2.5,
+				ThrottleThreshold: 50,
+			},
+		},
+		{
+			testName:            "interval too short",
+			cgroupPressureSlice: []string{"interval=100ms"},
+			expectedError:       "invalid cgroup-pressure interval: 100ms (minimum 1s)",
+		},
+		{
+			testName:            "invalid psi threshold",
+			cgroupPressureSlice: []string{"psi-threshold=101"},
+			expectedError:       "invalid cgroup-pressure psi-threshold: 101",
+		},
+		{
+			testName:            "invalid throttle threshold",
+			cgroupPressureSlice: []string{"throttle-threshold=high"},
+			expectedError:       "invalid cgroup-pressure throttle-threshold: high",
+		},
+		{
+			testName:            "invalid option",
+			cgroupPressureSlice: []string{"controller=memory"},
+			expectedError:       "invalid cgroup-pressure option: controller=memory",
+		},
+		{
+			testName:            "help",
+			cgroupPressureSlice: []string{"help"},
+			expectedError:       cgroupPressureHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareCgroupPressure(tc.cgroupPressureSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/controlplane"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/v1beta1"
+)
+
+func controlPlaneHelp() string {
+	return `Connect out to a central control plane, over mutual TLS, so the agent is managed without
+per-node configuration files: policies are pulled from the control plane when tracee starts,
+and the findings and statistics are pushed to it.
+
+Possible options:
+  url=<https url>        | control plane url (required).
+  cert=<path>            | agent certificate, presented to the control plane (required).
+  key=<path>             | agent certificate key (required).
+  ca=<path>              | certificate authority of the control plane (required).
+  agent-id=<id>          | agent id in the control plane (default: the hostname).
+  interval=<duration>    | interval between two syncs with the control plane (default: 30s).
+  none                   | don't connect to a control plane (default).
+
+Example:
+  --control-plane url=https://tracee-cp:8443 --control-plane cert=/etc/tracee/agent.crt \
+  --control-plane key=/etc/tracee/agent.key --control-plane ca=/etc/tracee/ca.crt
+
+The control plane policies replace the --policy, --scope and --events flags. The filters of the
+policies changed on the control plane are applied while tracee is running, other changes (e.g.
+to the events selected) once tracee is restarted.
+`
+}
+
+// PrepareControlPlane returns the control plane client configuration.
+func PrepareControlPlane(controlPlaneSlice []string) (controlplane.Config, error) {
+	cfg := controlplane.Config{
+		Interval: controlplane.DefaultInterval,
+	}
+
+	for _, opt := range controlPlaneSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(controlPlaneHelp())
+		}
+		if opt == "none" {
+			return controlplane.Config{}, nil
+		}
+
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return controlplane.Config{}, errfmt.Errorf("unrecognized control-plane option format: %s", opt)
+		}
+		if value == "" {
+			return controlplane.Config{}, errfmt.Errorf("control-plane %s cannot be empty", key)
+		}
+
+		switch key {
+		case "url":
+			cfg.URL = value
+		case "cert":
+			cfg.CertFile = value
+		case "key":
+			cfg.KeyFile = value
+		case "ca":
+			cfg.CAFile = value
+		case "agent-id":
+			cfg.AgentID = value
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval <= 0 {
+				return controlplane.Config{}, errfmt.Errorf("invalid control-plane interval: %s", value)
+			}
+			cfg.Interval = interval
+		default:
+			return controlplane.Config{}, errfmt.Errorf("unrecognized control-plane option format: %s", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	if cfg.Enabled && (cfg.URL == "" || cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CAFile == "") {
+		return controlplane.Config{}, errfmt.Errorf("control-plane url, cert, key and ca are required")
+	}
+
+	return cfg, nil
+}
+
+// CreatePoliciesFromControlPlane creates the policies pulled from the control plane, given as
+// a YAML stream.
+func CreatePoliciesFromControlPlane(policiesYAML []byte) (*policy.Policies, error) {
+	policyFiles, err := v1beta1.PoliciesFromYAML(policiesYAML)
+	if err != nil {
+		return nil, errfmt.Errorf("control plane policies: %v", err)
+	}
+	if len(policyFiles) == 0 {
+		return nil, errfmt.Errorf("no policies in the control plane")
+	}
+
+	policyScopeMap, policyEventsMap, err := PrepareFilterMapsFromPolicies(policyFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreatePolicies(policyScopeMap, policyEventsMap, true)
+}
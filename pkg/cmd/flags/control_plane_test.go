@@ -0,0 +1,95 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/controlplane"
+)
+
+func TestPrepareControlPlane(t *testing.T) {
+	t.Parallel()
+
+	required := []string{
+		"url=https://tracee-cp:8443",
+		"cert=/etc/tracee/agent.crt",
+		"key=/etc/tracee/agent.key",
+		"ca=/etc/tracee/ca.crt",
+	}
+
+	testCases := []struct {
+		testName          string
+		controlPlaneSlice []string
+		expectedConfig    controlplane.Config
+		expectedError     string
+	}{
+		{
+			testName:          "none",
+			controlPlaneSlice: []string{"none"},
+			expectedConfig:    controlplane.Config{},
+		},
+		{
+			testName:          "required options",
+			controlPlaneSlice: required,
+			expectedConfig: controlplane.Config{
+				Enabled:  true,
+				URL:      "https://tracee-cp:8443",
+				CertFile: "/etc/tracee/agent.crt",
+				KeyFile:  "/etc/tracee/agent.key",
+				CAFile:   "/etc/tracee/ca.crt",
+				Interval: controlplane.DefaultInterval,
+			},
+		},
+		{
+			testName:          "agent-id and interval",
+			controlPlaneSlice: append([]string{"agent-id=node-1", "interval=1m"}, required...),
+			expectedConfig: controlplane.Config{
+				Enabled:  true,
+				URL:      "https://tracee-cp:8443",
+				AgentID:  "node-1",
+				CertFile: "/etc/tracee/agent.crt",
+				KeyFile:  "/etc/tracee/agent.key",
+				CAFile:   "/etc/tracee/ca.crt",
+				Interval: time.Minute,
+			},
+		},
+		{
+			testName:          "missing options",
+			controlPlaneSlice: []string{"url=https://tracee-cp:8443"},
+			expectedError:     "control-plane url, cert, key and ca are required",
+		},
+		{
+			testName:          "empty value",
+			controlPlaneSlice: []string{"cert="},
+			expectedError:     "control-plane cert cannot be empty",
+		},
+		{
+			testName:          "invalid interval",
+			controlPlaneSlice: append([]string{"interval=0s"}, required...),
+			expectedError:     "invalid control-plane interval: 0s",
+		},
+		{
+			testName:          "invalid option",
+			controlPlaneSlice: []string{"foo"},
+			expectedError:     "unrecognized control-plane option format: foo",
+		},
+	}
+
+	for _, testcase := range testCases {
+		testcase := testcase
+
+		t.Run(testcase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareControlPlane(testcase.controlPlaneSlice)
+			if testcase.expectedError != "" {
+				assert.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedConfig, cfg)
+		})
+	}
+}
@@ -35,3 +35,7 @@ func InvalidFlagOperator(expression string) error {
 func InvalidFlagValue(expression string) error {
 	return fmt.Errorf("invalid flag value: %s", expression)
 }
+
+func InvalidFilterExpression(expression, reason string) error {
+	return fmt.Errorf("invalid filter expression (%s): %s, use '--filter help' for more info", expression, reason)
+}
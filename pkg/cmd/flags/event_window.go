@@ -0,0 +1,85 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events/window"
+)
+
+func eventWindowHelp() string {
+	return `Retain the events of the last minutes so they can be queried from the agent, through
+the data http server (GET /events?filter=<expression>&last=<duration>&limit=<n>), which
+listens on localhost only unless another --data-listen-addr is given.
+
+Possible options:
+  duration=<duration>    | retain the events of the last given duration (default: 10m).
+  max-events=<n>         | retain at most n events in memory (default: 100000).
+  dir=<path>             | retain the events on disk, in the given directory, instead of in memory.
+  none                   | don't retain events (default).
+
+Example:
+  --event-window duration=10m                               | retain the events of the last 10 minutes in memory.
+  --event-window duration=1h --event-window dir=/tmp/events | retain the events of the last hour on disk.
+
+Query example (all execs in container 3f2a... in the last 10 minutes):
+  curl 'http://localhost:3367/events?filter=event=sched_process_exec&filter=container=3f2a*&last=10m'
+
+The filter parameter may be given multiple times, all of its expressions must match. Expressions
+have the syntax of the --scope flag expressions (e.g. comm=bash, uid>0, args.pathname=/etc/*).
+Fields: event, comm, uts, container, containerName, containerImage, podName, podNamespace, podUid,
+syscall, policy, pid, tid, ppid, uid, mntns, pidns, retval, labels.<key> and args.<name>. The limit
+parameter returns the newest events.
+`
+}
+
+// PrepareEventWindow returns the events window configuration.
+func PrepareEventWindow(windowSlice []string) (window.Config, error) {
+	cfg := window.Config{
+		Duration:  window.DefaultDuration,
+		MaxEvents: window.DefaultMaxEvents,
+	}
+
+	for _, opt := range windowSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(eventWindowHelp())
+		}
+		if opt == "none" {
+			return window.Config{}, nil
+		}
+
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok {
+			return window.Config{}, errfmt.Errorf("unrecognized event-window option format: %s", opt)
+		}
+
+		switch key {
+		case "duration":
+			duration, err := time.ParseDuration(value)
+			if err != nil || duration <= 0 {
+				return window.Config{}, errfmt.Errorf("invalid event-window duration: %s", value)
+			}
+			cfg.Duration = duration
+		case "max-events":
+			maxEvents, err := strconv.Atoi(value)
+			if err != nil || maxEvents <= 0 {
+				return window.Config{}, errfmt.Errorf("invalid event-window max-events: %s", value)
+			}
+			cfg.MaxEvents = maxEvents
+		case "dir":
+			if value == "" {
+				return window.Config{}, errfmt.Errorf("event-window dir cannot be empty")
+			}
+			cfg.Dir = value
+		default:
+			return window.Config{}, errfmt.Errorf("unrecognized event-window option format: %s", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/events/window"
+)
+
+func TestPrepareEventWindow(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		windowSlice    []string
+		expectedConfig window.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			windowSlice:    []string{"none"},
+			expectedConfig: window.Config{},
+		},
+		{
+			testName:    "duration",
+			windowSlice: []string{"duration=1h"},
+			expectedConfig: window.Config{
+				Enabled:   true,
+				Duration:  time.Hour,
+				MaxEvents: window.DefaultMaxEvents,
+			},
+		},
+		{
+			testName:    "max-events and dir",
+			windowSlice: []string{"max-events=500", "dir=/tmp/events"},
+			expectedConfig: window.Config{
+				Enabled:   true,
+				Duration:  window.DefaultDuration,
+				MaxEvents: 500,
+				Dir:       "/tmp/events",
+			},
+		},
+		{
+			testName:      "invalid duration",
+			windowSlice:   []string{"duration=-1m"},
+			expectedError: "invalid event-window duration: -1m",
+		},
+		{
+			testName:      "invalid max-events",
+			windowSlice:   []string{"max-events=many"},
+			expectedError: "invalid event-window max-events: many",
+		},
+		{
+			testName:      "invalid option",
+			windowSlice:   []string{"foo"},
+			expectedError: "unrecognized event-window option format: foo",
+		},
+	}
+
+	for _, testcase := range testCases {
+		testcase := testcase
+
+		t.Run(testcase.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareEventWindow(testcase.windowSlice)
+			if testcase.expectedError != "" {
+				assert.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/policy"
+)
+
+func filterExpressionHelp() string {
+	return `Select which events to trace with filter expressions, compiled into policies: each --filter
+expression is a policy (the policies are ORed), and the conditions of an expression are ANDed.
+
+An expression is made of conditions joined by '&&':
+  <field> <operator> <value>  | compares a field to a value.
+  <field> in (<value>, ...)   | compares a field to several values (ORed).
+  <field> not in (<value>, ...) | excludes several values of a field.
+  <field>                     | checks a boolean field.
+  !<field>                    | checks a boolean field is false.
+
+Values are numbers, words or double quoted strings.
+
+Operators:
+  ==, !=                      | equality (!= excludes a value).
+  <, <=, >, >=                | numerical comparison.
+  startsWith, endsWith        | string prefix or suffix.
+  matches                     | string glob, with '*' at its start and/or end (e.g. "nginx*").
+
+Fields:
+  event.name                  | event (or set of events) to trace, with ==, !=, in, not in, matches or startsWith.
+  args.<name>                 | event argument (requires a single event.name).
+  retval                      | event return value (requires a single event.name), also errno names
+                              | (e.g. -EACCES) and the success and failure values of syscalls.
+  process.uid, process.pid    | process user id and process id.
+  process.name                | process command name.
+  process.executable          | process executable path.
+  process.tree                | process ancestor pid.
+  process.mntns, process.pidns | process mount and pid namespaces.
+  process.uts                 | process uts name.
+  container                   | event from a container (boolean).
+  container.id                | container id ("new" for new containers).
+  follow                      | also trace the descendants of the matching processes (boolean).
+  container.name, container.image, container.imageDigest,
+  pod.name, pod.namespace, pod.uid | container and pod fields (require a single event.name).
+  scope.<field>               | event field, by its json name in the trace.Event struct (requires a single event.name).
+
+The process and container id fields are evaluated in the kernel, before submitting the events,
+except for the process.name and process.uts string operators. The event arguments, return value
+and the other fields are evaluated in userspace.
+
+The exclusions (!= and not in) apply the same way to all the fields: a field only excluding
+values matches all the other values (including a missing event argument).
+
+Examples:
+  --filter 'event.name == "openat" && args.pathname startsWith "/etc" && container.image matches "nginx*"'
+  --filter 'event.name in (execve, execveat) && container'
+  --filter 'event.name matches "security_*" && process.uid > 0 && process.uid != 1000'
+  --filter 'event.name == close && retval < 0' --filter 'event.name == openat && process.name == ls'
+
+Note: filter expressions can't be used together with the --policy, --scope and --events flags.
+`
+}
+
+// filterExprScopeFields maps the process fields of the filter expressions to their scope flag
+// names (evaluated in the kernel).
+var filterExprScopeFields = map[string]string{
+	"process.uid":        "uid",
+	"process.pid":        "pid",
+	"process.name":       "comm",
+	"process.executable": "executable",
+	"process.tree":       "tree",
+	"process.mntns":      "mntns",
+	"process.pidns":      "pidns",
+	"process.uts":        "uts",
+	"container.id":       "container",
+	"container":          "container",
+	"follow":             "follow",
+}
+
+// filterExprEventScopeFields maps the container and pod fields of the filter expressions to their
+// event scope filter names (evaluated in userspace).
+var filterExprEventScopeFields = map[string]string{
+	"container.name":        "containerName",
+	"container.image":       "containerImage",
+	"container.imageDigest": "containerImageDigest",
+	"pod.name":              "podName",
+	"pod.namespace":         "podNamespace",
+	"pod.uid":               "podUid",
+}
+
+// filterExprCondition is a condition of a filter expression.
+type filterExprCondition struct {
+	field    string
+	negated  bool // "!<field>"
+	operator string
+	values   []string
+}
+
+// PrepareFilterMapsFromExpressions prepares the scope and events PolicyFilterMap of the given
+// filter expressions, each expression being a policy.
+func PrepareFilterMapsFromExpressions(exprs []string) (PolicyScopeMap, PolicyEventMap, error) {
+	if len(exprs) > policy.PolicyMax {
+		return nil, nil, errfmt.Errorf("too many filters provided, there is a limit of %d filters", policy.PolicyMax)
+	}
+
+	policyScopeMap := make(PolicyScopeMap)
+	policyEventsMap := make(PolicyEventMap)
+
+	for pIdx, expr := range exprs {
+		if strings.HasPrefix(expr, "help") {
+			return nil, nil, fmt.Errorf(filterExpressionHelp())
+		}
+
+		conditions, err := parseFilterExpression(expr)
+		if err != nil {
+			return nil, nil, errfmt.WrapError(err)
+		}
+		scopeFlags, eventFlags, err := compileFilterExpression(expr, conditions)
+		if err != nil {
+			return nil, nil, errfmt.WrapError(err)
+		}
+
+		name := fmt.Sprintf("filter-%d", pIdx)
+		policyScopeMap[pIdx] = policyScopes{policyName: name, scopeFlags: scopeFlags}
+		policyEventsMap[pIdx] = policyEvents{policyName: name, eventFlags: eventFlags}
+	}
+
+	return policyScopeMap, policyEventsMap, nil
+}
+
+// compileFilterExpression compiles the conditions of a filter expression into the scope and
+// event flags of its policy.
+func compileFilterExpression(expr string, conditions []filterExprCondition) ([]scopeFlag, []eventFlag, error) {
+	var scopeFlags []scopeFlag
+	var eventFlags []eventFlag
+	var eventNames []string     // events to trace
+	var eventFilters []string   // filters of the event to trace, e.g. "args.pathname=/etc*"
+	var excludedEvents []string // events not to trace
+	singleEvent := true         // whether the events to trace are a single event (no wildcard)
+
+	for _, cond := range conditions {
+		if cond.field == "event.name" {
+			if cond.negated {
+				return nil, nil, InvalidFilterExpression(expr, "event.name isn't a boolean field")
+			}
+			values, err := filterExprValues(cond)
+			if err != nil {
+				return nil, nil, InvalidFilterExpression(expr, err.Error())
+			}
+			switch cond.operator {
+			case "==", "in", "matches", "startsWith":
+				eventNames = append(eventNames, values...)
+				if len(values) > 1 || strings.Contains(values[0], "*") {
+					singleEvent = false
+				}
+			case "!=", "not in":
+				excludedEvents = append(excludedEvents, values...)
+			default:
+				return nil, nil, InvalidFilterExpression(expr, "invalid event.name operator "+cond.operator)
+			}
+			continue
+		}
+
+		// process fields (scope flags)
+
+		if name, ok := filterExprScopeFields[cond.field]; ok {
+			flag, err := filterExprScopeFlag(name, cond)
+			if err != nil {
+				return nil, nil, InvalidFilterExpression(expr, err.Error())
+			}
+			parsed, err := parseScopeFlag(flag)
+			if err != nil {
+				return nil, nil, errfmt.WrapError(err)
+			}
+			scopeFlags = append(scopeFlags, parsed)
+			continue
+		}
+
+		// event fields (event filter flags)
+
+		var filter string
+		switch {
+		case cond.field == "retval":
+			filter = "retval"
+		case strings.HasPrefix(cond.field, "args."):
+			filter = cond.field
+		case strings.HasPrefix(cond.field, "scope."):
+			filter = cond.field
+		default:
+			name, ok := filterExprEventScopeFields[cond.field]
+			if !ok {
+				return nil, nil, InvalidFilterExpression(expr, "unknown field "+cond.field)
+			}
+			filter = "scope." + name
+		}
+		if cond.negated {
+			return nil, nil, InvalidFilterExpression(expr, "'!' only negates the container field")
+		}
+		if cond.operator == "" { // boolean event field, e.g. "scope.host"
+			if !strings.HasPrefix(filter, "scope.") {
+				return nil, nil, InvalidFilterExpression(expr, cond.field+" isn't a boolean field")
+			}
+			eventFilters = append(eventFilters, filter)
+			continue
+		}
+		operatorAndValues, err := filterExprOperatorAndValues(cond)
+		if err != nil {
+			return nil, nil, InvalidFilterExpression(expr, err.Error())
+		}
+		eventFilters = append(eventFilters, filter+operatorAndValues)
+	}
+
+	if len(eventFilters) > 0 && (len(eventNames) != 1 || !singleEvent) {
+		return nil, nil, InvalidFilterExpression(expr, "event filters require a single event.name")
+	}
+
+	for _, name := range eventNames {
+		parsed, err := parseEventFlag(name)
+		if err != nil {
+			return nil, nil, errfmt.WrapError(err)
+		}
+		eventFlags = append(eventFlags, parsed...)
+	}
+	for _, name := range excludedEvents {
+		parsed, err := parseEventFlag("-" + name)
+		if err != nil {
+			return nil, nil, errfmt.WrapError(err)
+		}
+		eventFlags = append(eventFlags, parsed...)
+	}
+	for _, filter := range eventFilters {
+		parsed, err := parseEventFlag(eventNames[0] + "." + filter)
+		if err != nil {
+			return nil, nil, errfmt.WrapError(err)
+		}
+		eventFlags = append(eventFlags, parsed...)
+	}
+
+	return scopeFlags, eventFlags, nil
+}
+
+// filterExprScopeFlag returns the scope flag of a process field condition.
+func filterExprScopeFlag(name string, cond filterExprCondition) (string, error) {
+	if cond.operator == "" {
+		if name != "container" && name != "follow" {
+			return "", fmt.Errorf("%s isn't a boolean field", cond.field)
+		}
+		if cond.negated {
+			if name == "follow" {
+				return "", fmt.Errorf("'!' only negates the container field")
+			}
+			return "not-" + name, nil
+		}
+		return name, nil
+	}
+	if cond.field == "container" || cond.field == "follow" {
+		return "", fmt.Errorf("%s is a boolean field", cond.field)
+	}
+	switch cond.operator {
+	case "startsWith", "endsWith", "matches":
+		if name != "comm" && name != "uts" { // evaluated in userland
+			return "", fmt.Errorf("%s doesn't support the %s operator", cond.field, cond.operator)
+		}
+	}
+
+	operatorAndValues, err := filterExprOperatorAndValues(cond)
+	if err != nil {
+		return "", err
+	}
+
+	return name + operatorAndValues, nil
+}
+
+// filterExprOperatorAndValues returns the operator and values of a condition, as given to the
+// scope and event flags (e.g. "=/etc*").
+func filterExprOperatorAndValues(cond filterExprCondition) (string, error) {
+	values, err := filterExprValues(cond)
+	if err != nil {
+		return "", err
+	}
+
+	switch cond.operator {
+	case "==", "in", "startsWith", "endsWith", "matches":
+		return "=" + strings.Join(values, ","), nil
+	case "not in":
+		return "!=" + strings.Join(values, ","), nil
+	default: // !=, <, <=, >, >=
+		return cond.operator + strings.Join(values, ","), nil
+	}
+}
+
+// filterExprValues returns the values of a condition, turning the string operators into the
+// wildcards of the scope and event flags.
+func filterExprValues(cond filterExprCondition) ([]string, error) {
+	values := make([]string, 0, len(cond.values))
+
+	for _, value := range cond.values {
+		if value == "" || strings.Contains(value, ",") {
+			return nil, fmt.Errorf("invalid %s value %q", cond.field, value)
+		}
+		switch cond.operator {
+		case "startsWith":
+			value += "*"
+		case "endsWith":
+			value = "*" + value
+		case "matches":
+			if strings.Contains(strings.Trim(value, "*"), "*") {
+				return nil, fmt.Errorf("%q: only '*' at the start or the end of a glob is supported", value)
+			}
+		default:
+			if strings.Contains(value, "*") {
+				return nil, fmt.Errorf("%q: wildcards require the matches operator", value)
+			}
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+//
+// Filter expressions parsing
+//
+
+type filterExprToken struct {
+	kind  string // "word", "string" or "symbol"
+	value string
+}
+
+// filterExprSymbols are the symbols of the filter expressions (longest first).
+var filterExprSymbols = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!", "(", ")", ","}
+
+// filterExprOperators are the operators of the filter expressions conditions.
+var filterExprOperators = map[string]bool{
+	"==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+	"startsWith": true, "endsWith": true, "matches": true, "in": true, "not in": true,
+}
+
+// lexFilterExpression splits a filter expression into its tokens.
+func lexFilterExpression(expr string) ([]filterExprToken, error) {
+	var tokens []filterExprToken
+
+	for i := 0; i < len(expr); {
+		c := rune(expr[i])
+
+		if unicode.IsSpace(c) {
+			i++
+			continue
+		}
+
+		if c == '"' {
+			end := i + 1
+			for ; end < len(expr) && expr[end] != '"'; end++ {
+				if expr[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expr) {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			value, err := strconv.Unquote(expr[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at %d: %v", i, err)
+			}
+			tokens = append(tokens, filterExprToken{kind: "string", value: value})
+			i = end + 1
+			continue
+		}
+
+		symbol := ""
+		for _, s := range filterExprSymbols {
+			if strings.HasPrefix(expr[i:], s) {
+				symbol = s
+				break
+			}
+		}
+		if symbol != "" {
+			tokens = append(tokens, filterExprToken{kind: "symbol", value: symbol})
+			i += len(symbol)
+			continue
+		}
+
+		end := i
+		for ; end < len(expr); end++ {
+			r := rune(expr[end])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("._-*/:", r) {
+				break
+			}
+		}
+		if end == i {
+			return nil, fmt.Errorf("unexpected character %q at %d", expr[i], i)
+		}
+		tokens = append(tokens, filterExprToken{kind: "word", value: expr[i:end]})
+		i = end
+	}
+
+	return tokens, nil
+}
+
+// parseFilterExpression parses a filter expression into its (ANDed) conditions.
+func parseFilterExpression(expr string) ([]filterExprCondition, error) {
+	tokens, err := lexFilterExpression(expr)
+	if err != nil {
+		return nil, InvalidFilterExpression(expr, err.Error())
+	}
+	if len(tokens) == 0 {
+		return nil, InvalidFlagEmpty()
+	}
+
+	var conditions []filterExprCondition
+	pos := 0
+	next := func() (filterExprToken, bool) {
+		if pos >= len(tokens) {
+			return filterExprToken{}, false
+		}
+		pos++
+		return tokens[pos-1], true
+	}
+	value := func() (string, error) {
+		token, ok := next()
+		if !ok {
+			return "", fmt.Errorf("missing value")
+		}
+		if token.kind == "symbol" {
+			return "", fmt.Errorf("unexpected %q instead of a value", token.value)
+		}
+		return token.value, nil
+	}
+
+	for {
+		var cond filterExprCondition
+
+		token, ok := next()
+		if ok && token.kind == "symbol" && token.value == "!" {
+			cond.negated = true
+			token, ok = next()
+		}
+		if !ok || token.kind != "word" {
+			return nil, InvalidFilterExpression(expr, "missing field")
+		}
+		cond.field = token.value
+
+		token, ok = next()
+		if ok && token.kind == "word" && token.value == "not" { // "not in"
+			token, ok = next()
+			if !ok || token.kind != "word" || token.value != "in" {
+				return nil, InvalidFilterExpression(expr, "missing 'in' after not")
+			}
+			token.value = "not in"
+		}
+		if ok && (token.kind == "word" || token.kind == "symbol") && filterExprOperators[token.value] {
+			if cond.negated {
+				return nil, InvalidFilterExpression(expr, "'!' only negates boolean fields")
+			}
+			cond.operator = token.value
+			if cond.operator == "in" || cond.operator == "not in" {
+				cond.values, err = parseFilterExprList(next, value)
+			} else {
+				var v string
+				v, err = value()
+				cond.values = []string{v}
+			}
+			if err != nil {
+				return nil, InvalidFilterExpression(expr, err.Error())
+			}
+			token, ok = next()
+		}
+		conditions = append(conditions, cond)
+
+		if !ok {
+			return conditions, nil
+		}
+		switch {
+		case token.value == "&&":
+			continue
+		case token.value == "||":
+			return nil, InvalidFilterExpression(expr, "'||' isn't supported, give alternatives as separate filters (ORed)")
+		default:
+			return nil, InvalidFilterExpression(expr, fmt.Sprintf("unexpected %q", token.value))
+		}
+	}
+}
+
+// parseFilterExprList parses the values list of the "in" and "not in" operators: "(<value>, ...)".
+func parseFilterExprList(next func() (filterExprToken, bool), value func() (string, error)) ([]string, error) {
+	if token, ok := next(); !ok || token.value != "(" {
+		return nil, fmt.Errorf("missing '(' after in")
+	}
+
+	var values []string
+	for {
+		v, err := value()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		token, ok := next()
+		if !ok {
+			return nil, fmt.Errorf("missing ')'")
+		}
+		switch token.value {
+		case ",":
+			continue
+		case ")":
+			return values, nil
+		default:
+			return nil, fmt.Errorf("unexpected %q in values list", token.value)
+		}
+	}
+}
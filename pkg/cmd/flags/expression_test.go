@@ -0,0 +1,158 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareFilterMapsFromExpressions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		expr           string
+		expectedScopes []string // full scope flags
+		expectedEvents []string // full event flags
+		expectedError  string
+	}{
+		{
+			name:           "event with argument and container image",
+			expr:           `event.name == "openat" && args.pathname startsWith "/etc" && container.image matches "nginx*"`,
+			expectedEvents: []string{"openat", "openat.args.pathname=/etc*", "openat.scope.containerImage=nginx*"},
+		},
+		{
+			name:           "events list in a container",
+			expr:           "event.name in (execve, execveat) && container",
+			expectedScopes: []string{"container"},
+			expectedEvents: []string{"execve", "execveat"},
+		},
+		{
+			name:           "events glob and process uids",
+			expr:           `event.name matches "security_*" && process.uid > 0 && process.uid != 1000`,
+			expectedScopes: []string{"uid>0", "uid!=1000"},
+			expectedEvents: []string{"security_*"},
+		},
+		{
+			name:           "excluded event on the host",
+			expr:           "event.name startsWith open && event.name != openat && !container && follow",
+			expectedScopes: []string{"not-container", "follow"},
+			expectedEvents: []string{"open*", "-openat"},
+		},
+		{
+			name:           "return value and process name",
+			expr:           `event.name == close && retval < 0 && process.name == "ls"`,
+			expectedScopes: []string{"comm=ls"},
+			expectedEvents: []string{"close", "close.retval<0"},
+		},
+		{
+			name:           "return value errno names",
+			expr:           "event.name == openat && retval in (-EACCES, -EPERM)",
+			expectedEvents: []string{"openat", "openat.retval=-EACCES,-EPERM"},
+		},
+		{
+			name:           "new containers and event scope field",
+			expr:           `container.id == new && event.name == "openat" && scope.syscallAbi == i386`,
+			expectedScopes: []string{"container=new"},
+			expectedEvents: []string{"openat", "openat.scope.syscallAbi=i386"},
+		},
+		{
+			name:          "event filter of several events",
+			expr:          "event.name in (open, openat) && args.pathname == /etc/passwd",
+			expectedError: "event filters require a single event.name",
+		},
+		{
+			name:          "event filter without event",
+			expr:          `container.name == "web"`,
+			expectedError: "event filters require a single event.name",
+		},
+		{
+			name:          "or",
+			expr:          "event.name == open || event.name == openat",
+			expectedError: "'||' isn't supported",
+		},
+		{
+			name:          "unknown field",
+			expr:          "event.name == open && process.cwd == /tmp",
+			expectedError: "unknown field process.cwd",
+		},
+		{
+			name:          "negated non boolean field",
+			expr:          "!process.name",
+			expectedError: "process.name isn't a boolean field",
+		},
+		{
+			name:           "excluded events and process name glob",
+			expr:           "event.name not in (open, close) && process.name startsWith sys && process.uid not in (0, 1000)",
+			expectedScopes: []string{"comm=sys*", "uid!=0,1000"},
+			expectedEvents: []string{"-open", "-close"},
+		},
+		{
+			name:           "excluded argument values",
+			expr:           `event.name == openat && args.pathname not in ("/etc/passwd", "/etc/shadow")`,
+			expectedEvents: []string{"openat", "openat.args.pathname!=/etc/passwd,/etc/shadow"},
+		},
+		{
+			name:          "string operator of a process field",
+			expr:          "process.pid startsWith 1",
+			expectedError: "process.pid doesn't support the startsWith operator",
+		},
+		{
+			name:          "not without in",
+			expr:          "event.name not openat",
+			expectedError: "missing 'in' after not",
+		},
+		{
+			name:          "wildcard in the middle of a glob",
+			expr:          `event.name == openat && args.pathname matches "/etc/*/passwd"`,
+			expectedError: "only '*' at the start or the end of a glob is supported",
+		},
+		{
+			name:          "wildcard without matches",
+			expr:          `event.name == "open*"`,
+			expectedError: "wildcards require the matches operator",
+		},
+		{
+			name:          "missing value",
+			expr:          "event.name ==",
+			expectedError: "missing value",
+		},
+		{
+			name:          "unterminated string",
+			expr:          `event.name == "open`,
+			expectedError: "unterminated string",
+		},
+		{
+			name:          "help",
+			expr:          "help",
+			expectedError: filterExpressionHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			scopeMap, eventMap, err := PrepareFilterMapsFromExpressions([]string{tc.expr})
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var scopes, evts []string
+			for _, f := range scopeMap[0].scopeFlags {
+				scopes = append(scopes, f.full)
+			}
+			for _, f := range eventMap[0].eventFlags {
+				evts = append(evts, f.full)
+			}
+			assert.Equal(t, tc.expectedScopes, scopes)
+			assert.Equal(t, tc.expectedEvents, evts)
+			assert.Equal(t, "filter-0", scopeMap[0].policyName)
+		})
+	}
+}
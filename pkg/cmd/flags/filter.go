@@ -29,7 +29,9 @@ Event arguments allow the following operators: '=', '!='.
 Strings can be compared as a prefix if ending with '*' or as suffix if starting with '*'.
 
 Event return value can be accessed using 'event_name.retval' and provide a way to filter an event by its return value.
-Event return value expression has the same syntax as a numerical expression.
+Event return value expression has the same syntax as a numerical expression. Values can also be errno names, negated
+as returned on failure (e.g. 'openat.retval=-EACCES'), and syscalls return values can be compared with '=' and '!=' to
+the 'success' (returning >= 0) and 'failure' (returning -errno) shorthands (e.g. 'openat.retval=failure').
 
 Event scope fields can be accessed using 'event_name.scope.field', this can be used to filter an event by the non arguments
 fields defined in the trace.Event struct.
@@ -41,6 +43,15 @@ Multiple values are ORed if used with equals operator '=', but are ANDed if used
 
 The field 'container' and 'pid' also support the special value 'new' which selects new containers or pids, respectively.
 
+Schedule expressions make a policy only active at some (local) times, and allow the following operators: '=', '!='.
+The 'time' field selects windows of the day (HH:MM-HH:MM, wrapping over midnight if ending before they start),
+and the 'weekday' field days of the week (sun, mon, tue, wed, thu, fri, sat) or ranges of them (e.g. mon-fri).
+Schedule expressions are evaluated in userspace, by the time the events happened at.
+
+Container label expressions select the processes by the labels of their container, as given by the container runtime
+enrichment: 'container.label[<key>]', with the following operators: '=', '!='. The label of a host process, or of a
+container without it, is empty. Container label expressions are evaluated in userspace.
+
 The special 'follow' expression declares that not only processes that match the criteria will be traced, but also their descendants.
 
 The field 'net' specifies which interfaces to monitor when tracing network events.
@@ -70,6 +81,9 @@ Scope examples:
   --scope executable=host:/usr/bin/ls                          | only trace events from /usr/bin/ls executable in the host mount namespace
   --scope executable=4026532448:/usr/bin/ls                    | only trace events from /usr/bin/ls executable in 4026532448 mount namespace
   --scope comm=bash --scope follow                             | trace all events that originated from bash or from one of the processes spawned by bash
+  --scope time!=09:00-18:00                                    | only trace events outside of business hours
+  --scope time=09:00-18:00 --scope weekday=mon-fri             | only trace events during business hours, on weekdays
+  --scope 'container.label[security.scan]!=skip'               | don't trace events from containers labeled security.scan=skip
 
 Event examples:
   --events execve,open                                          | only trace execve and open events
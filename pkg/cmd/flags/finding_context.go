@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/findingcontext"
+)
+
+func findingContextHelp() string {
+	return `Attach windows of interest to the findings: the events preceding and following the event which
+triggered a finding, of the same process (or container). The events are attached to the finding
+metadata, as the "contextBefore" and "contextAfter" properties. A finding is held until its following
+events are observed, or until the timeout expires (with the following events observed so far).
+Only the events selected by the policies (or their dependencies) can be part of a finding context.
+
+Possible options:
+  before=<n>                   | number of events preceding a finding to attach (default: 10).
+  after=<n>                    | number of events following a finding to attach (default: 10).
+  scope=<process|container>    | attach the events of the process, or of the container (default: process).
+  timeout=<duration>           | maximum time a finding is held for its following events (default: 5s).
+  none                         | don't attach events to the findings (default).
+
+Examples:
+  --finding-context before=20                                   | attach the 20 previous and 10 following events of the process.
+  --finding-context scope=container --finding-context after=0   | attach the 10 previous events of the container, without delaying the findings.
+`
+}
+
+// PrepareFindingContext returns the findings context configuration of the given options.
+func PrepareFindingContext(findingContextSlice []string) (findingcontext.Config, error) {
+	cfg := findingcontext.Config{
+		Before:  findingcontext.DefaultBefore,
+		After:   findingcontext.DefaultAfter,
+		Scope:   findingcontext.ScopeProcess,
+		Timeout: findingcontext.DefaultTimeout,
+	}
+
+	for _, opt := range findingContextSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(findingContextHelp())
+		}
+		if opt == "none" {
+			return findingcontext.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid finding-context option: %s, use '--finding-context help' for more info", opt)
+		}
+
+		switch key {
+		case "before", "after":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return cfg, fmt.Errorf("invalid finding-context %s: %s", key, value)
+			}
+			if key == "before" {
+				cfg.Before = n
+			} else {
+				cfg.After = n
+			}
+		case "scope":
+			scope := findingcontext.Scope(value)
+			if scope != findingcontext.ScopeProcess && scope != findingcontext.ScopeContainer {
+				return cfg, fmt.Errorf("invalid finding-context scope: %s (process or container)", value)
+			}
+			cfg.Scope = scope
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil || timeout <= 0 {
+				return cfg, fmt.Errorf("invalid finding-context timeout: %s", value)
+			}
+			cfg.Timeout = timeout
+		default:
+			return cfg, fmt.Errorf("invalid finding-context option: %s, use '--finding-context help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	if cfg.Before == 0 && cfg.After == 0 {
+		cfg.Enabled = false
+	}
+
+	return cfg, nil
+}
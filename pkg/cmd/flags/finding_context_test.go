@@ -0,0 +1,98 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/findingcontext"
+)
+
+func TestPrepareFindingContext(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName            string
+		findingContextSlice []string
+		expectedConfig      findingcontext.Config
+		expectedError       string
+	}{
+		{
+			testName:            "disabled by default",
+			findingContextSlice: []string{},
+			expectedConfig: findingcontext.Config{
+				Before:  findingcontext.DefaultBefore,
+				After:   findingcontext.DefaultAfter,
+				Scope:   findingcontext.ScopeProcess,
+				Timeout: findingcontext.DefaultTimeout,
+			},
+		},
+		{
+			testName:            "none",
+			findingContextSlice: []string{"none"},
+			expectedConfig:      findingcontext.Config{},
+		},
+		{
+			testName:            "all options",
+			findingContextSlice: []string{"before=20", "after=5", "scope=container", "timeout=1s"},
+			expectedConfig: findingcontext.Config{
+				Enabled: true,
+				Before:  20,
+				After:   5,
+				Scope:   findingcontext.ScopeContainer,
+				Timeout: time.Second,
+			},
+		},
+		{
+			testName:            "empty window",
+			findingContextSlice: []string{"before=0", "after=0"},
+			expectedConfig: findingcontext.Config{
+				Scope:   findingcontext.ScopeProcess,
+				Timeout: findingcontext.DefaultTimeout,
+			},
+		},
+		{
+			testName:            "invalid before",
+			findingContextSlice: []string{"before=-1"},
+			expectedError:       "invalid finding-context before: -1",
+		},
+		{
+			testName:            "invalid scope",
+			findingContextSlice: []string{"scope=pod"},
+			expectedError:       "invalid finding-context scope: pod (process or container)",
+		},
+		{
+			testName:            "invalid timeout",
+			findingContextSlice: []string{"timeout=soon"},
+			expectedError:       "invalid finding-context timeout: soon",
+		},
+		{
+			testName:            "invalid option",
+			findingContextSlice: []string{"thread=1"},
+			expectedError:       "invalid finding-context option: thread=1",
+		},
+		{
+			testName:            "help",
+			findingContextSlice: []string{"help"},
+			expectedError:       findingContextHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareFindingContext(tc.findingContextSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/dedup"
+)
+
+func findingDedupHelp() string {
+	return `Collapse identical findings: the findings of the same signature, for the same process (or container),
+within a time window. The first finding of a window is output right away, and the identical findings
+following it are collapsed into the last one, output once the window expires with their number as the
+"count" metadata property. A loop triggering a detection thus outputs two findings per window at most.
+
+Possible options:
+  window=<duration>            | time window identical findings are collapsed in (default: 10s).
+  scope=<process|container>    | collapse the findings of the process, or of the container (default: process).
+  none                         | don't collapse findings (default).
+
+Examples:
+  --finding-dedup window=1m                  | collapse the identical findings of a process within a minute.
+  --finding-dedup scope=container            | collapse the identical findings of a container within 10 seconds.
+`
+}
+
+// PrepareFindingDedup returns the findings deduplication configuration of the given options.
+func PrepareFindingDedup(findingDedupSlice []string) (dedup.Config, error) {
+	cfg := dedup.Config{
+		Window: dedup.DefaultWindow,
+		Scope:  dedup.ScopeProcess,
+	}
+
+	for _, opt := range findingDedupSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(findingDedupHelp())
+		}
+		if opt == "none" {
+			return dedup.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid finding-dedup option: %s, use '--finding-dedup help' for more info", opt)
+		}
+
+		switch key {
+		case "window":
+			window, err := time.ParseDuration(value)
+			if err != nil || window <= 0 {
+				return cfg, fmt.Errorf("invalid finding-dedup window: %s", value)
+			}
+			cfg.Window = window
+		case "scope":
+			scope := dedup.Scope(value)
+			if scope != dedup.ScopeProcess && scope != dedup.ScopeContainer {
+				return cfg, fmt.Errorf("invalid finding-dedup scope: %s (process or container)", value)
+			}
+			cfg.Scope = scope
+		default:
+			return cfg, fmt.Errorf("invalid finding-dedup option: %s, use '--finding-dedup help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	return cfg, nil
+}
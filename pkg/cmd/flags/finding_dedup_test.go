@@ -0,0 +1,90 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/dedup"
+)
+
+func TestPrepareFindingDedup(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName          string
+		findingDedupSlice []string
+		expectedConfig    dedup.Config
+		expectedError     string
+	}{
+		{
+			testName:          "disabled by default",
+			findingDedupSlice: []string{},
+			expectedConfig: dedup.Config{
+				Window: dedup.DefaultWindow,
+				Scope:  dedup.ScopeProcess,
+			},
+		},
+		{
+			testName:          "none",
+			findingDedupSlice: []string{"none"},
+			expectedConfig:    dedup.Config{},
+		},
+		{
+			testName:          "all options",
+			findingDedupSlice: []string{"window=1m", "scope=container"},
+			expectedConfig: dedup.Config{
+				Enabled: true,
+				Window:  time.Minute,
+				Scope:   dedup.ScopeContainer,
+			},
+		},
+		{
+			testName:          "scope only",
+			findingDedupSlice: []string{"scope=process"},
+			expectedConfig: dedup.Config{
+				Enabled: true,
+				Window:  dedup.DefaultWindow,
+				Scope:   dedup.ScopeProcess,
+			},
+		},
+		{
+			testName:          "invalid window",
+			findingDedupSlice: []string{"window=0s"},
+			expectedError:     "invalid finding-dedup window: 0s",
+		},
+		{
+			testName:          "invalid scope",
+			findingDedupSlice: []string{"scope=pod"},
+			expectedError:     "invalid finding-dedup scope: pod (process or container)",
+		},
+		{
+			testName:          "invalid option",
+			findingDedupSlice: []string{"count=1"},
+			expectedError:     "invalid finding-dedup option: count=1",
+		},
+		{
+			testName:          "help",
+			findingDedupSlice: []string{"help"},
+			expectedError:     findingDedupHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareFindingDedup(tc.findingDedupSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
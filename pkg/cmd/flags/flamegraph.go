@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/events/flamegraph"
+)
+
+func flamegraphHelp() string {
+	return `Aggregate the stack traces of the events over time windows, and write them to a directory at the
+end of every window, as folded stacks (for flamegraph.pl, speedscope, ...) or pprof profiles: a
+lightweight always-on profiler. The stacks are rooted at the process name, or at the container and
+then the process name, and their frames are the raw user stack addresses.
+The stack traces of all the events are collected, unless the collection is restricted to selected
+events (--output option:stack-addresses=<event>).
+
+Possible options:
+  dir=<path>                   | directory the profiles are written to (required).
+  format=<folded|pprof>        | format of the profiles (default: folded).
+  group-by=<comm|container>    | root frames of the stacks (default: comm).
+  window=<duration>            | duration a profile aggregates stacks for (default: 1m, minimum: 1s).
+  none                         | don't aggregate stacks (default).
+
+Examples:
+  --flamegraph dir=/var/log/tracee/stacks                                           | folded stacks of every minute.
+  --flamegraph dir=/var/log/tracee/stacks --flamegraph format=pprof --flamegraph window=10m   | pprof profiles of every 10 minutes.
+
+Flamegraph example (the last folded stacks):
+  flamegraph.pl $(ls /var/log/tracee/stacks/*.folded | tail -1) > stacks.svg
+`
+}
+
+// PrepareFlamegraph returns the stacks aggregation configuration of the given options.
+func PrepareFlamegraph(flamegraphSlice []string) (flamegraph.Config, error) {
+	cfg := flamegraph.Config{
+		Format:  flamegraph.DefaultFormat,
+		GroupBy: flamegraph.DefaultGroupBy,
+		Window:  flamegraph.DefaultWindow,
+	}
+
+	for _, opt := range flamegraphSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(flamegraphHelp())
+		}
+		if opt == "none" {
+			return flamegraph.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid flamegraph option: %s, use '--flamegraph help' for more info", opt)
+		}
+
+		switch key {
+		case "dir":
+			cfg.Dir = value
+		case "format":
+			if value != flamegraph.FormatFolded && value != flamegraph.FormatPprof {
+				return cfg, fmt.Errorf("invalid flamegraph format: %s (folded or pprof)", value)
+			}
+			cfg.Format = value
+		case "group-by":
+			if value != flamegraph.GroupByComm && value != flamegraph.GroupByContainer {
+				return cfg, fmt.Errorf("invalid flamegraph group-by: %s (comm or container)", value)
+			}
+			cfg.GroupBy = value
+		case "window":
+			window, err := time.ParseDuration(value)
+			if err != nil || window < time.Second {
+				return cfg, fmt.Errorf("invalid flamegraph window: %s (minimum 1s)", value)
+			}
+			cfg.Window = window
+		default:
+			return cfg, fmt.Errorf("invalid flamegraph option: %s, use '--flamegraph help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	if cfg.Enabled && cfg.Dir == "" {
+		return cfg, fmt.Errorf("flamegraph dir option expected, use '--flamegraph help' for more info")
+	}
+
+	return cfg, nil
+}
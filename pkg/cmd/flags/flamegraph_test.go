@@ -0,0 +1,96 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events/flamegraph"
+)
+
+func TestPrepareFlamegraph(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName        string
+		flamegraphSlice []string
+		expectedConfig  flamegraph.Config
+		expectedError   string
+	}{
+		{
+			testName:        "none",
+			flamegraphSlice: []string{"none"},
+			expectedConfig:  flamegraph.Config{},
+		},
+		{
+			testName:        "dir",
+			flamegraphSlice: []string{"dir=/tmp/stacks"},
+			expectedConfig: flamegraph.Config{
+				Enabled: true,
+				Dir:     "/tmp/stacks",
+				Format:  flamegraph.FormatFolded,
+				GroupBy: flamegraph.GroupByComm,
+				Window:  time.Minute,
+			},
+		},
+		{
+			testName:        "all options",
+			flamegraphSlice: []string{"dir=/tmp/stacks", "format=pprof", "group-by=container", "window=10m"},
+			expectedConfig: flamegraph.Config{
+				Enabled: true,
+				Dir:     "/tmp/stacks",
+				Format:  flamegraph.FormatPprof,
+				GroupBy: flamegraph.GroupByContainer,
+				Window:  10 * time.Minute,
+			},
+		},
+		{
+			testName:        "no dir",
+			flamegraphSlice: []string{"format=pprof"},
+			expectedError:   "flamegraph dir option expected, use '--flamegraph help' for more info",
+		},
+		{
+			testName:        "invalid format",
+			flamegraphSlice: []string{"dir=/tmp/stacks", "format=svg"},
+			expectedError:   "invalid flamegraph format: svg (folded or pprof)",
+		},
+		{
+			testName:        "invalid group-by",
+			flamegraphSlice: []string{"dir=/tmp/stacks", "group-by=pid"},
+			expectedError:   "invalid flamegraph group-by: pid (comm or container)",
+		},
+		{
+			testName:        "window too short",
+			flamegraphSlice: []string{"dir=/tmp/stacks", "window=100ms"},
+			expectedError:   "invalid flamegraph window: 100ms (minimum 1s)",
+		},
+		{
+			testName:        "invalid option",
+			flamegraphSlice: []string{"foo=bar"},
+			expectedError:   "invalid flamegraph option: foo=bar, use '--flamegraph help' for more info",
+		},
+		{
+			testName:        "help",
+			flamegraphSlice: []string{"help"},
+			expectedError:   flamegraphHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareFlamegraph(tc.flamegraphSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
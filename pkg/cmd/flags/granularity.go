@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/granularity"
+)
+
+func granularityHelp() string {
+	return `Control the granularity of the events: per-thread (default) or per-process. The events selected
+for process granularity are reported as events of their process (their thread ids are the process
+ones), and identical events (same arguments and return value) of the threads of a process are
+coalesced within a time window, drastically cutting the volume of high-frequency events of heavily
+threaded workloads.
+
+Possible options:
+  process=<event>[,<event>...] | coalesce the given events at process level.
+  window=<duration>            | time window the identical events of a process are coalesced in (default: 1s).
+  thread                       | all the events are per-thread (default).
+
+Examples:
+  --granularity process=read,write                       | coalesce the read and write events of the threads of a process.
+  --granularity process=openat --granularity window=10s  | emit the same openat event of a process once every 10 seconds at most.
+`
+}
+
+// PrepareGranularity returns the events granularity configuration.
+func PrepareGranularity(granularitySlice []string) (granularity.Config, error) {
+	cfg := granularity.Config{Window: granularity.DefaultWindow}
+
+	for _, opt := range granularitySlice {
+		if strings.HasPrefix(opt, "help") {
+			return granularity.Config{}, fmt.Errorf(granularityHelp())
+		}
+		if opt == "thread" {
+			return granularity.Config{}, nil
+		}
+
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok || value == "" {
+			return granularity.Config{}, errfmt.Errorf("invalid granularity option: %s, use '--granularity help' for more info", opt)
+		}
+
+		switch key {
+		case "process":
+			for _, name := range strings.Split(value, ",") {
+				id, found := events.Core.GetDefinitionIDByName(name)
+				if !found {
+					return granularity.Config{}, errfmt.Errorf("invalid granularity event: %s", name)
+				}
+				cfg.ProcessEvents = append(cfg.ProcessEvents, int(id))
+			}
+		case "window":
+			window, err := time.ParseDuration(value)
+			if err != nil || window <= 0 {
+				return granularity.Config{}, errfmt.Errorf("invalid granularity window: %s", value)
+			}
+			cfg.Window = window
+		default:
+			return granularity.Config{}, errfmt.Errorf("invalid granularity option: %s, use '--granularity help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/granularity"
+)
+
+func TestPrepareGranularity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName         string
+		granularitySlice []string
+		expectedConfig   granularity.Config
+		expectedError    string
+	}{
+		{
+			testName:         "thread",
+			granularitySlice: []string{"thread"},
+			expectedConfig:   granularity.Config{},
+		},
+		{
+			testName:         "process events",
+			granularitySlice: []string{"process=read,write", "process=openat"},
+			expectedConfig: granularity.Config{
+				ProcessEvents: []int{int(events.Read), int(events.Write), int(events.Openat)},
+				Window:        granularity.DefaultWindow,
+			},
+		},
+		{
+			testName:         "window",
+			granularitySlice: []string{"process=read", "window=10s"},
+			expectedConfig: granularity.Config{
+				ProcessEvents: []int{int(events.Read)},
+				Window:        10 * time.Second,
+			},
+		},
+		{
+			testName:         "unknown event",
+			granularitySlice: []string{"process=read,nonexistent"},
+			expectedError:    "invalid granularity event: nonexistent",
+		},
+		{
+			testName:         "invalid window",
+			granularitySlice: []string{"window=-1s"},
+			expectedError:    "invalid granularity window: -1s",
+		},
+		{
+			testName:         "unknown option",
+			granularitySlice: []string{"level=process"},
+			expectedError:    "invalid granularity option: level=process",
+		},
+		{
+			testName:         "empty events",
+			granularitySlice: []string{"process="},
+			expectedError:    "invalid granularity option: process=",
+		},
+		{
+			testName:         "help",
+			granularitySlice: []string{"help"},
+			expectedError:    granularityHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareGranularity(tc.granularitySlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/handoff"
+)
+
+func handoffHelp() string {
+	return `Hand the events output over from a running tracee to a new one started alongside it (e.g. a
+blue/green agent upgrade), without losing nor duplicating events. Both instances must be given the
+same handoff socket, the sink lock: the instance writing the events listens on it.
+
+The new instance holds its events until it gets the lock, asking the running one for it once its
+programs are attached. The running one then only writes the events up to that time, during the
+drain, and exits: it replies with the last event written once its outputs are closed. The new
+instance writes the events it held after it, and numbers its events from the next sequence number
+(sequenceNumber field), so the events output has neither gap nor duplicate across the upgrade.
+
+Possible options:
+  socket=<path>          | unix socket of the sink lock (required).
+  drain=<duration>       | time the running instance writes the events up to the handoff (default: 2s).
+  timeout=<duration>     | time the new instance waits for the running one (default: 2m).
+  max-held=<n>           | events held while waiting for the sink lock, the next are dropped (default: 100000).
+
+Shared file outputs should be opened with the append output option, and the state eBPF maps shared
+with the --pin-path flag.
+
+Examples:
+  --handoff socket=/var/run/tracee/handoff.sock                        | hand the events output over.
+  --handoff socket=/var/run/tracee/handoff.sock --output json:/var/log/tracee.json --output option:append
+  --handoff socket=/var/run/tracee/handoff.sock --handoff drain=5s      | wait longer for the late events.
+`
+}
+
+// PrepareHandoff returns the handoff configuration of the given options.
+func PrepareHandoff(handoffSlice []string) (handoff.Config, error) {
+	cfg := handoff.Config{
+		Drain:   handoff.DefaultDrain,
+		Timeout: handoff.DefaultTimeout,
+		MaxHeld: handoff.DefaultMaxHeld,
+	}
+
+	for _, opt := range handoffSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(handoffHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid handoff option: %s, use '--handoff help' for more info", opt)
+		}
+
+		switch key {
+		case "socket":
+			if !filepath.IsAbs(value) {
+				return cfg, fmt.Errorf("invalid handoff socket: %s (an absolute path)", value)
+			}
+			cfg.Socket = value
+		case "drain":
+			drain, err := time.ParseDuration(value)
+			if err != nil || drain <= 0 {
+				return cfg, fmt.Errorf("invalid handoff drain: %s", value)
+			}
+			cfg.Drain = drain
+		case "timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil || timeout <= 0 {
+				return cfg, fmt.Errorf("invalid handoff timeout: %s", value)
+			}
+			cfg.Timeout = timeout
+		case "max-held":
+			maxHeld, err := strconv.Atoi(value)
+			if err != nil || maxHeld <= 0 {
+				return cfg, fmt.Errorf("invalid handoff max-held: %s", value)
+			}
+			cfg.MaxHeld = maxHeld
+		default:
+			return cfg, fmt.Errorf("invalid handoff option: %s, use '--handoff help' for more info", opt)
+		}
+	}
+
+	if len(handoffSlice) > 0 && !cfg.Enabled() {
+		return cfg, fmt.Errorf("no handoff socket given, use '--handoff help' for more info")
+	}
+
+	return cfg, nil
+}
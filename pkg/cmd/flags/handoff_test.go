@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/handoff"
+)
+
+func TestPrepareHandoff(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		handoffSlice   []string
+		expectedConfig handoff.Config
+		expectedError  string
+	}{
+		{
+			testName:     "default",
+			handoffSlice: []string{},
+			expectedConfig: handoff.Config{
+				Drain:   handoff.DefaultDrain,
+				Timeout: handoff.DefaultTimeout,
+				MaxHeld: handoff.DefaultMaxHeld,
+			},
+		},
+		{
+			testName:     "socket",
+			handoffSlice: []string{"socket=/var/run/tracee/handoff.sock"},
+			expectedConfig: handoff.Config{
+				Socket:  "/var/run/tracee/handoff.sock",
+				Drain:   handoff.DefaultDrain,
+				Timeout: handoff.DefaultTimeout,
+				MaxHeld: handoff.DefaultMaxHeld,
+			},
+		},
+		{
+			testName:     "all options",
+			handoffSlice: []string{"socket=/tmp/handoff.sock", "drain=5s", "timeout=30s", "max-held=1000"},
+			expectedConfig: handoff.Config{
+				Socket:  "/tmp/handoff.sock",
+				Drain:   5 * time.Second,
+				Timeout: 30 * time.Second,
+				MaxHeld: 1000,
+			},
+		},
+		{
+			testName:      "no socket",
+			handoffSlice:  []string{"drain=5s"},
+			expectedError: "no handoff socket given, use '--handoff help' for more info",
+		},
+		{
+			testName:      "relative socket",
+			handoffSlice:  []string{"socket=handoff.sock"},
+			expectedError: "invalid handoff socket: handoff.sock (an absolute path)",
+		},
+		{
+			testName:      "invalid drain",
+			handoffSlice:  []string{"socket=/tmp/handoff.sock", "drain=0s"},
+			expectedError: "invalid handoff drain: 0s",
+		},
+		{
+			testName:      "invalid max-held",
+			handoffSlice:  []string{"socket=/tmp/handoff.sock", "max-held=-1"},
+			expectedError: "invalid handoff max-held: -1",
+		},
+		{
+			testName:      "invalid option",
+			handoffSlice:  []string{"lock=/tmp/handoff.sock"},
+			expectedError: "invalid handoff option: lock=/tmp/handoff.sock, use '--handoff help' for more info",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareHandoff(tc.handoffSlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
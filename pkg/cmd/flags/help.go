@@ -50,16 +50,78 @@ func GetHelpString(key string) string {
 		return configHelp()
 	case "cri":
 		return containersHelp()
+	case "bpf-maps":
+		return bpfMapsHelp()
+	case "perf-buffer-tuning":
+		return perfBufferTuningHelp()
+	case "split-pipeline":
+		return splitPipelineHelp()
+	case "stack-symbols":
+		return stackSymbolsHelp()
 	case "cache":
 		return cacheHelp()
 	case "proctree":
 		return procTreeHelp()
+	case "cpu-affinity":
+		return cpuAffinityHelp()
+	case "event-window":
+		return eventWindowHelp()
+	case "timeline":
+		return timelineHelp()
+	case "flamegraph":
+		return flamegraphHelp()
+	case "control-plane":
+		return controlPlaneHelp()
+	case "labels":
+		return labelsHelp()
+	case "suppressions":
+		return suppressionsHelp()
+	case "finding-context":
+		return findingContextHelp()
+	case "threat-intel":
+		return threatIntelHelp()
+	case "vuln-db":
+		return vulnDBHelp()
+	case "btfhub":
+		return btfHubHelp()
+	case "seccomp":
+		return seccompHelp()
+	case "k8s-audit":
+		return k8sAuditHelp()
+	case "cgroup-pressure":
+		return cgroupPressureHelp()
+	case "self-limits":
+		return selfLimitsHelp()
+	case "handoff":
+		return handoffHelp()
+	case "runtime-sbom":
+		return runtimeSBOMHelp()
+	case "net-flow-stats":
+		return netFlowStatsHelp()
+	case "sensitive-files":
+		return sensitiveFilesHelp()
+	case "yara":
+		return yaraHelp()
+	case "oci":
+		return ociHelp()
+	case "signature-plugins":
+		return signaturePluginsHelp()
 	case "capture":
 		return captureHelp()
 	case "scope", "events":
 		return filterHelp()
+	case "filter":
+		return filterExpressionHelp()
+	case "policy-quota":
+		return policyQuotaHelp()
+	case "granularity":
+		return granularityHelp()
+	case "arg-limit":
+		return argLimitHelp()
 	case "output":
 		return outputHelp()
+	case "output-query":
+		return outputQueryHelp()
 	case "capabilities":
 		return capabilitiesHelp()
 	case "rego":
@@ -0,0 +1,84 @@
+package flags
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
+)
+
+func k8sAuditHelp() string {
+	return `Correlate the Kubernetes audit logs actions on pods (exec, attach, port-forward and debug)
+with the processes they start on the node, emitting a k8s_audit_correlation event per process.
+The audit events are read from the audit log file of the API server log backend, or received from
+its webhook backend. Processes are matched to pods by the container enrichment.
+The k8s_audit_correlation event must be selected (e.g. --events k8s_audit_correlation) for the
+correlations to be emitted.
+
+Possible options:
+  file=<file>            | tail the audit log file (--audit-log-path of the API server, json format).
+  webhook=<addr>         | receive the audit events posted to the listen address (--audit-webhook-config-file
+                           of the API server).
+  webhook-cert=<file>    | serve the webhook over TLS, with the certificate file.
+  webhook-key=<file>     | serve the webhook over TLS, with the key file.
+  window=<duration>      | time after an action during which the pod processes are correlated with it (default: 1m).
+  none                   | don't read the audit logs (default).
+
+Examples:
+  --k8s-audit file=/var/log/kubernetes/audit.log                                     | tail the audit log file.
+  --k8s-audit webhook=:8443 --k8s-audit webhook-cert=tls.crt --k8s-audit webhook-key=tls.key
+  --k8s-audit file=/var/log/kubernetes/audit.log --k8s-audit window=5m               | correlate the processes for 5 minutes.
+`
+}
+
+// PrepareK8sAudit returns the Kubernetes audit logs configuration of the given options.
+func PrepareK8sAudit(k8sAuditSlice []string) (k8saudit.Config, error) {
+	cfg := k8saudit.Config{Window: k8saudit.DefaultWindow}
+
+	for _, opt := range k8sAuditSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(k8sAuditHelp())
+		}
+		if opt == "none" {
+			return k8saudit.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid k8s-audit option: %s, use '--k8s-audit help' for more info", opt)
+		}
+
+		switch key {
+		case "file":
+			cfg.File = value
+		case "webhook":
+			if _, _, err := net.SplitHostPort(value); err != nil {
+				return cfg, fmt.Errorf("invalid k8s-audit webhook address: %s", value)
+			}
+			cfg.Webhook = value
+		case "webhook-cert":
+			cfg.WebhookCert = value
+		case "webhook-key":
+			cfg.WebhookKey = value
+		case "window":
+			window, err := time.ParseDuration(value)
+			if err != nil || window <= 0 {
+				return cfg, fmt.Errorf("invalid k8s-audit window: %s", value)
+			}
+			cfg.Window = window
+		default:
+			return cfg, fmt.Errorf("invalid k8s-audit option: %s, use '--k8s-audit help' for more info", opt)
+		}
+	}
+
+	if (cfg.WebhookCert == "") != (cfg.WebhookKey == "") {
+		return cfg, fmt.Errorf("k8s-audit webhook-cert and webhook-key must be given together")
+	}
+	if cfg.WebhookCert != "" && cfg.Webhook == "" {
+		return cfg, fmt.Errorf("k8s-audit webhook-cert and webhook-key require a webhook address")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
+)
+
+func TestPrepareK8sAudit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		k8sAuditSlice  []string
+		expectedConfig k8saudit.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			k8sAuditSlice:  []string{"none"},
+			expectedConfig: k8saudit.Config{},
+		},
+		{
+			testName:      "file",
+			k8sAuditSlice: []string{"file=/var/log/kubernetes/audit.log"},
+			expectedConfig: k8saudit.Config{
+				File:   "/var/log/kubernetes/audit.log",
+				Window: k8saudit.DefaultWindow,
+			},
+		},
+		{
+			testName:      "tls webhook and window",
+			k8sAuditSlice: []string{"webhook=:8443", "webhook-cert=tls.crt", "webhook-key=tls.key", "window=5m"},
+			expectedConfig: k8saudit.Config{
+				Webhook:     ":8443",
+				WebhookCert: "tls.crt",
+				WebhookKey:  "tls.key",
+				Window:      5 * time.Minute,
+			},
+		},
+		{
+			testName:      "invalid webhook address",
+			k8sAuditSlice: []string{"webhook=8443"},
+			expectedError: "invalid k8s-audit webhook address: 8443",
+		},
+		{
+			testName:      "invalid window",
+			k8sAuditSlice: []string{"window=-1m"},
+			expectedError: "invalid k8s-audit window: -1m",
+		},
+		{
+			testName:      "cert without key",
+			k8sAuditSlice: []string{"webhook=:8443", "webhook-cert=tls.crt"},
+			expectedError: "k8s-audit webhook-cert and webhook-key must be given together",
+		},
+		{
+			testName:      "cert without webhook",
+			k8sAuditSlice: []string{"file=audit.log", "webhook-cert=tls.crt", "webhook-key=tls.key"},
+			expectedError: "k8s-audit webhook-cert and webhook-key require a webhook address",
+		},
+		{
+			testName:      "invalid option",
+			k8sAuditSlice: []string{"dir=/var/log"},
+			expectedError: "invalid k8s-audit option: dir=/var/log",
+		},
+		{
+			testName:      "help",
+			k8sAuditSlice: []string{"help"},
+			expectedError: k8sAuditHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareK8sAudit(tc.k8sAuditSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
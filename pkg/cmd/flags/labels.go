@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/cloud"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+func labelsHelp() string {
+	return `Attach static labels to every event (e.g. the cluster name or the node role), so events
+of multiple clusters can be aggregated without an external enrichment layer.
+
+Possible options:
+  <key>=<value>          | attach the given label.
+  cloud                  | attach the cloud instance labels, fetched from the metadata service of
+                         | the cloud provider (aws, gcp or azure) once per boot: cloud.provider,
+                         | cloud.instance_id, cloud.account_id (aws account, gcp project or azure
+                         | subscription) and cloud.region.
+  none                   | don't attach labels (default).
+
+Example:
+  --labels cluster=prod-eu --labels role=worker  | attach the cluster and role labels.
+  --labels cloud --labels cluster=prod-eu        | attach the cloud instance and cluster labels.
+
+Labels are given in the "labels" field of the events.
+`
+}
+
+// getCloudInstance is a variable for tests
+var getCloudInstance = cloud.GetCachedInstance
+
+// PrepareLabels returns the labels attached to every event. The cloud instance identity is
+// cached in the install path.
+func PrepareLabels(labelsSlice []string, installPath string) (map[string]string, error) {
+	labels := make(map[string]string)
+	cloudLabels := false
+
+	for _, opt := range labelsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return nil, fmt.Errorf(labelsHelp())
+		}
+		if opt == "none" {
+			return nil, nil
+		}
+		if opt == "cloud" {
+			cloudLabels = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return nil, errfmt.Errorf("invalid label: %s, use <key>=<value>", opt)
+		}
+		if _, ok := labels[key]; ok {
+			return nil, errfmt.Errorf("label %s given more than once", key)
+		}
+		labels[key] = value
+	}
+
+	// explicit labels take precedence over the cloud instance ones
+	if cloudLabels {
+		instance, err := getCloudInstance(context.Background(), installPath)
+		if err != nil {
+			logger.Warnw("Cloud instance labels not attached", "error", err)
+		}
+		for key, value := range map[string]string{
+			"cloud.provider":    instance.Provider,
+			"cloud.instance_id": instance.ID,
+			"cloud.account_id":  instance.Account,
+			"cloud.region":      instance.Region,
+		} {
+			if _, ok := labels[key]; !ok && value != "" {
+				labels[key] = value
+			}
+		}
+	}
+
+	if len(labels) == 0 {
+		return nil, nil
+	}
+
+	return labels, nil
+}
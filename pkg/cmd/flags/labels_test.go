@@ -0,0 +1,91 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/cloud"
+)
+
+// Not parallel, as the cloud instance getter is replaced.
+func TestPrepareLabels(t *testing.T) {
+	orig := getCloudInstance
+	defer func() { getCloudInstance = orig }()
+
+	testCases := []struct {
+		testName       string
+		labelsSlice    []string
+		cloudInstance  cloud.Instance
+		cloudError     error
+		expectedLabels map[string]string
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			labelsSlice:    []string{"none"},
+			expectedLabels: nil,
+		},
+		{
+			testName:    "labels",
+			labelsSlice: []string{"cluster=prod-eu", "role=worker", "empty="},
+			expectedLabels: map[string]string{
+				"cluster": "prod-eu",
+				"role":    "worker",
+				"empty":   "",
+			},
+		},
+		{
+			testName:      "cloud",
+			labelsSlice:   []string{"cloud", "cluster=prod-eu", "cloud.region=eu"},
+			cloudInstance: cloud.Instance{Provider: "aws", ID: "i-0123456789abcdef0", Account: "123456789012", Region: "eu-west-1"},
+			expectedLabels: map[string]string{
+				"cluster":           "prod-eu",
+				"cloud.provider":    "aws",
+				"cloud.instance_id": "i-0123456789abcdef0",
+				"cloud.account_id":  "123456789012",
+				"cloud.region":      "eu",
+			},
+		},
+		{
+			testName:       "cloud unavailable",
+			labelsSlice:    []string{"cloud"},
+			cloudError:     errors.New("no cloud metadata service found"),
+			expectedLabels: nil,
+		},
+		{
+			testName:      "invalid label",
+			labelsSlice:   []string{"cluster"},
+			expectedError: "invalid label: cluster, use <key>=<value>",
+		},
+		{
+			testName:      "empty key",
+			labelsSlice:   []string{"=prod"},
+			expectedError: "invalid label: =prod, use <key>=<value>",
+		},
+		{
+			testName:      "duplicate label",
+			labelsSlice:   []string{"role=worker", "role=master"},
+			expectedError: "label role given more than once",
+		},
+	}
+
+	for _, testcase := range testCases {
+		t.Run(testcase.testName, func(t *testing.T) {
+			getCloudInstance = func(ctx context.Context, dir string) (cloud.Instance, error) {
+				assert.Equal(t, "/tmp/tracee", dir)
+				return testcase.cloudInstance, testcase.cloudError
+			}
+
+			labels, err := PrepareLabels(testcase.labelsSlice, "/tmp/tracee")
+			if testcase.expectedError != "" {
+				assert.ErrorContains(t, err, testcase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testcase.expectedLabels, labels)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+func netFlowStatsHelp() string {
+	return `Configure the network flow summary events (net_flow_summary). The TCP flows of the traced
+processes are accounted from the moment they are established, and their summary (5-tuple, duration,
+bytes and packets received and sent) is emitted when they are closed. Long lived flows can also be
+summarized periodically, at most once per interval (while the flow is active).
+The event must be selected (e.g. --events net_flow_summary) for the flows to be accounted.
+
+Possible options:
+  interval=<duration> | minimum interval between two summaries of an established flow (default: on close only).
+
+Examples:
+  --events net_flow_summary                                      | summarize the flows when they are closed.
+  --events net_flow_summary --net-flow-stats interval=30s        | also summarize the established flows every 30 seconds.
+`
+}
+
+// PrepareNetFlowStats returns the interval between two network flow summaries of the
+// given options (0 if the flows are only summarized when closed).
+func PrepareNetFlowStats(netFlowStatsSlice []string) (time.Duration, error) {
+	var interval time.Duration
+
+	for _, opt := range netFlowStatsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return 0, fmt.Errorf(netFlowStatsHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return 0, fmt.Errorf("invalid net-flow-stats option: %s, use '--net-flow-stats help' for more info", opt)
+		}
+
+		switch key {
+		case "interval":
+			var err error
+			interval, err = time.ParseDuration(value)
+			if err != nil || interval < time.Second {
+				return 0, fmt.Errorf("invalid net-flow-stats interval: %s (minimum 1s)", value)
+			}
+		default:
+			return 0, fmt.Errorf("invalid net-flow-stats option: %s, use '--net-flow-stats help' for more info", opt)
+		}
+	}
+
+	return interval, nil
+}
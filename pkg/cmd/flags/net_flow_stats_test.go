@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareNetFlowStats(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName          string
+		netFlowStatsSlice []string
+		expectedInterval  time.Duration
+		expectedError     string
+	}{
+		{
+			testName:          "default",
+			netFlowStatsSlice: []string{},
+			expectedInterval:  0,
+		},
+		{
+			testName:          "interval",
+			netFlowStatsSlice: []string{"interval=30s"},
+			expectedInterval:  30 * time.Second,
+		},
+		{
+			testName:          "interval too short",
+			netFlowStatsSlice: []string{"interval=500ms"},
+			expectedError:     "invalid net-flow-stats interval: 500ms (minimum 1s)",
+		},
+		{
+			testName:          "invalid interval",
+			netFlowStatsSlice: []string{"interval=often"},
+			expectedError:     "invalid net-flow-stats interval: often (minimum 1s)",
+		},
+		{
+			testName:          "invalid option",
+			netFlowStatsSlice: []string{"proto=udp"},
+			expectedError:     "invalid net-flow-stats option: proto=udp",
+		},
+		{
+			testName:          "help",
+			netFlowStatsSlice: []string{"help"},
+			expectedError:     netFlowStatsHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			interval, err := PrepareNetFlowStats(tc.netFlowStatsSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInterval, interval)
+		})
+	}
+}
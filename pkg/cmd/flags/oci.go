@@ -0,0 +1,62 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/oci"
+)
+
+func ociHelp() string {
+	return `Configure the pulling of the detection content published in OCI registries: policies
+(--policy), signatures (--signatures-dir) and YARA rules (--yara rules=) given as OCI references,
+e.g. oci://ghcr.io/org/policies:v1 or oci://ghcr.io/org/policies@sha256:<digest>.
+Basic auth credentials can be given in the reference (user:pass@registry), oci+http:// pulls from a
+plain http registry. The artifacts are extracted to the cache directory, then loaded as local ones.
+
+Possible options:
+  cosign-key=<file>          | verify the cosign signatures of the artifacts with the public key (e.g. cosign.pub).
+                               Tracee fails to start if an artifact isn't signed by the key.
+  update-interval=<duration> | interval between two checks for artifact updates, logged to be applied on restart.
+                               0 disables the checks (default: 1h).
+  cache-dir=<dir>            | directory the artifacts are extracted to (default: <install-path>/oci).
+
+Examples:
+  --policy oci://ghcr.io/org/policies:v1 --oci cosign-key=/etc/tracee/cosign.pub | pull verified policies.
+  --signatures-dir oci://ghcr.io/org/signatures:v1 --oci update-interval=10m     | check for updates every 10 minutes.
+`
+}
+
+// PrepareOCI returns the configuration of the artifacts pulled from OCI registries.
+func PrepareOCI(ociSlice []string) (oci.Config, error) {
+	cfg := oci.Config{UpdateInterval: oci.DefaultUpdateInterval}
+
+	for _, opt := range ociSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(ociHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid oci option: %s, use '--oci help' for more info", opt)
+		}
+
+		switch key {
+		case "cosign-key":
+			cfg.PublicKey = value
+		case "update-interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval < 0 {
+				return cfg, fmt.Errorf("invalid oci update interval: %s", value)
+			}
+			cfg.UpdateInterval = interval
+		case "cache-dir":
+			cfg.CacheDir = value
+		default:
+			return cfg, fmt.Errorf("invalid oci option: %s, use '--oci help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/oci"
+)
+
+func TestPrepareOCI(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		ociSlice       []string
+		expectedConfig oci.Config
+		expectedError  string
+	}{
+		{
+			testName:       "default",
+			ociSlice:       []string{},
+			expectedConfig: oci.Config{UpdateInterval: oci.DefaultUpdateInterval},
+		},
+		{
+			testName: "all options",
+			ociSlice: []string{"cosign-key=/etc/tracee/cosign.pub", "update-interval=10m", "cache-dir=/var/cache/tracee"},
+			expectedConfig: oci.Config{
+				PublicKey:      "/etc/tracee/cosign.pub",
+				UpdateInterval: 10 * time.Minute,
+				CacheDir:       "/var/cache/tracee",
+			},
+		},
+		{
+			testName:       "update checks disabled",
+			ociSlice:       []string{"update-interval=0"},
+			expectedConfig: oci.Config{},
+		},
+		{
+			testName:      "invalid update interval",
+			ociSlice:      []string{"update-interval=-1m"},
+			expectedError: "invalid oci update interval: -1m",
+		},
+		{
+			testName:      "invalid option",
+			ociSlice:      []string{"insecure"},
+			expectedError: "invalid oci option: insecure",
+		},
+		{
+			testName:      "missing value",
+			ociSlice:      []string{"cosign-key="},
+			expectedError: "invalid oci option: cosign-key=",
+		},
+		{
+			testName:      "help",
+			ociSlice:      []string{"help"},
+			expectedError: ociHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareOCI(tc.ociSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
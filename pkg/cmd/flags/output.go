@@ -3,13 +3,19 @@ package flags
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/integrity"
+	"github.com/aquasecurity/tracee/pkg/utils/filewriter"
 )
 
 type PrepareOutputResult struct {
@@ -45,7 +51,7 @@ func PrepareOutput(outputSlice []string, newBinary bool) (PrepareOutputResult, e
 				return outConfig, errors.New("none output does not support path. Use '--output help' for more info")
 			}
 			printerMap["stdout"] = "ignore"
-		case "table", "table-verbose", "json":
+		case "table", "table-verbose", "json", "json-v2", "stix":
 			err := parseFormat(outputParts, printerMap, newBinary)
 			if err != nil {
 				return outConfig, err
@@ -64,6 +70,69 @@ func PrepareOutput(outputSlice []string, newBinary bool) (PrepareOutputResult, e
 			}
 
 			printerMap[outputParts[1]] = "webhook"
+		case "taxii":
+			err := validateURL(outputParts, "taxii", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "taxii"
+		case "pubsub", "kinesis":
+			// the topic (projects/<project>/topics/<topic>) or the stream (name or ARN) isn't an URL
+			if len(outputParts) == 1 || outputParts[1] == "" {
+				if newBinary {
+					return outConfig, fmt.Errorf("%s flag can't be empty, run 'man output' for more info", outputParts[0])
+				}
+
+				return outConfig, fmt.Errorf("%s flag can't be empty, use '--output help' for more info", outputParts[0])
+			}
+
+			printerMap[outputParts[1]] = outputParts[0]
+		case "mqtt":
+			err := validateURL(outputParts, "mqtt", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "mqtt"
+		case "amqp":
+			err := validateURL(outputParts, "amqp", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "amqp"
+		case "redis":
+			err := validateURL(outputParts, "redis", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "redis"
+		case "gelf":
+			err := validateURL(outputParts, "gelf", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "gelf"
+		case "clickhouse":
+			err := validateURL(outputParts, "clickhouse", newBinary)
+			if err != nil {
+				return outConfig, err
+			}
+
+			printerMap[outputParts[1]] = "clickhouse"
+		case "sqlite":
+			if len(outputParts) == 1 || outputParts[1] == "" {
+				if newBinary {
+					return outConfig, errors.New("sqlite flag can't be empty, run 'man output' for more info")
+				}
+
+				return outConfig, errors.New("sqlite flag can't be empty, use '--output help' for more info")
+			}
+
+			printerMap[outputParts[1]] = "sqlite"
 		case "option":
 			err := parseOption(outputParts, traceeConfig, newBinary)
 			if err != nil {
@@ -101,6 +170,12 @@ func setOption(cfg *config.OutputConfig, option string, newBinary bool) error {
 		cfg.StackAddresses = true
 	case "exec-env":
 		cfg.ExecEnv = true
+	case "exec-build-id":
+		cfg.ExecBuildID = true
+	case "canonical-paths":
+		cfg.CanonicalPaths = true
+	case "container-paths":
+		cfg.ContainerPaths = true
 	case "relative-time":
 		cfg.RelativeTime = true
 	case "parse-arguments":
@@ -110,7 +185,71 @@ func setOption(cfg *config.OutputConfig, option string, newBinary bool) error {
 		cfg.ParseArguments = true // no point in parsing file descriptor args only
 	case "sort-events":
 		cfg.EventsSorting = true
+	case "append":
+		cfg.FileWriter.Append = true
 	default:
+		if key, value, ok := strings.Cut(option, "="); ok && isIntegrityOption(key) {
+			if err := setIntegrityOption(&cfg.Integrity, key, value); err != nil {
+				if newBinary {
+					return errfmt.Errorf("invalid output option: %s (%v), run 'man output' for more info", option, err)
+				}
+
+				return errfmt.Errorf("invalid output option: %s (%v), use '--output help' for more info", option, err)
+			}
+
+			return nil
+		}
+
+		if key, value, ok := strings.Cut(option, "="); ok && isFileWriterOption(key) {
+			if err := setFileWriterOption(&cfg.FileWriter, key, value); err != nil {
+				if newBinary {
+					return errfmt.Errorf("invalid output option: %s (%v), run 'man output' for more info", option, err)
+				}
+
+				return errfmt.Errorf("invalid output option: %s (%v), use '--output help' for more info", option, err)
+			}
+
+			return nil
+		}
+
+		if key, value, ok := strings.Cut(option, "="); ok && isTimeOption(key) {
+			if err := setTimeOption(cfg, key, value); err != nil {
+				if newBinary {
+					return errfmt.Errorf("invalid output option: %s (%v), run 'man output' for more info", option, err)
+				}
+
+				return errfmt.Errorf("invalid output option: %s (%v), use '--output help' for more info", option, err)
+			}
+
+			return nil
+		}
+
+		if key, value, ok := strings.Cut(option, "="); ok && isBytesOption(key) {
+			if err := setBytesOption(cfg, key, value); err != nil {
+				if newBinary {
+					return errfmt.Errorf("invalid output option: %s (%v), run 'man output' for more info", option, err)
+				}
+
+				return errfmt.Errorf("invalid output option: %s (%v), use '--output help' for more info", option, err)
+			}
+
+			return nil
+		}
+
+		if value, ok := strings.CutPrefix(option, "stack-addresses="); ok {
+			id, found := events.Core.GetDefinitionIDByName(value)
+			if !found {
+				if newBinary {
+					return errfmt.Errorf("invalid output option: %s (event %q not found), run 'man output' for more info", option, value)
+				}
+
+				return errfmt.Errorf("invalid output option: %s (event %q not found), use '--output help' for more info", option, value)
+			}
+			cfg.StackAddressesEvents = append(cfg.StackAddressesEvents, id)
+
+			return nil
+		}
+
 		if strings.HasPrefix(option, "exec-hash") {
 			hashExecParts := strings.Split(option, "=")
 			if len(hashExecParts) == 1 {
@@ -153,10 +292,193 @@ func setOption(cfg *config.OutputConfig, option string, newBinary bool) error {
 	return nil
 }
 
+// isFileWriterOption returns true if the given option key configures the file outputs.
+func isFileWriterOption(key string) bool {
+	switch key {
+	case "compress", "rotate-size", "rotate-interval", "retain-files", "retain-age":
+		return true
+	}
+
+	return false
+}
+
+// setFileWriterOption sets the given file outputs option in the given config
+func setFileWriterOption(cfg *filewriter.Config, key, value string) error {
+	var err error
+
+	switch key {
+	case "compress":
+		switch value {
+		case filewriter.CompressionGzip, filewriter.CompressionZstd:
+			cfg.Compression = value
+		default:
+			return fmt.Errorf("unsupported compression %q (valid options are: gzip,zstd)", value)
+		}
+	case "rotate-size":
+		cfg.RotateSize, err = parseSize(value)
+	case "rotate-interval":
+		cfg.RotateInterval, err = parsePositiveDuration(value)
+	case "retain-files":
+		cfg.MaxBackups, err = strconv.Atoi(value)
+		if err == nil && cfg.MaxBackups < 1 {
+			err = errors.New("number of files must be positive")
+		}
+	case "retain-age":
+		cfg.MaxAge, err = parsePositiveDuration(value)
+	}
+
+	return err
+}
+
+// isTimeOption returns true if the given option key configures the times of the events.
+func isTimeOption(key string) bool {
+	return key == "time-format" || key == "time-zone"
+}
+
+// setTimeOption sets the given events times option in the given config
+func setTimeOption(cfg *config.OutputConfig, key, value string) error {
+	switch key {
+	case "time-format":
+		switch value {
+		case "nanos":
+			cfg.TimeRFC3339 = false
+		case "rfc3339":
+			cfg.TimeRFC3339 = true
+		default:
+			return fmt.Errorf("unsupported time format %q (valid options are: nanos,rfc3339)", value)
+		}
+	case "time-zone":
+		if value == "" {
+			return errors.New("time zone can't be empty")
+		}
+		loc, err := time.LoadLocation(value)
+		if err != nil {
+			return fmt.Errorf("unknown time zone %q", value)
+		}
+		cfg.TimeZone = loc
+	}
+
+	return nil
+}
+
+// isBytesOption returns true if the given option key configures the rendering of bytes arguments.
+func isBytesOption(key string) bool {
+	return key == "bytes" || strings.HasPrefix(key, "bytes.")
+}
+
+// setBytesOption sets the given bytes arguments rendering option in the given config: of all the
+// outputs (bytes), or of the outputs of a kind (bytes.<kind>).
+func setBytesOption(cfg *config.OutputConfig, key, value string) error {
+	format := config.BytesFormat(value)
+	switch format {
+	case config.BytesFormatBase64, config.BytesFormatHex, config.BytesFormatPreview:
+	default:
+		return fmt.Errorf("unsupported bytes format %q (valid options are: base64,hex,preview)", value)
+	}
+
+	kind, ok := strings.CutPrefix(key, "bytes.")
+	if !ok {
+		cfg.BytesFormat = format
+		return nil
+	}
+	switch kind {
+	case "table", "table-verbose", "json", "json-v2", "gotemplate", "forward", "webhook", "stix", "taxii", "sqlite", "clickhouse", "gelf", "redis", "amqp", "mqtt", "pubsub", "kinesis":
+	default:
+		return fmt.Errorf("unsupported output kind %q", kind)
+	}
+	if cfg.BytesFormats == nil {
+		cfg.BytesFormats = make(map[string]config.BytesFormat)
+	}
+	cfg.BytesFormats[kind] = format
+
+	return nil
+}
+
+// isIntegrityOption returns true if the given option key configures the events signing.
+func isIntegrityOption(key string) bool {
+	return key == "sign" || key == "sign-key"
+}
+
+// setIntegrityOption sets the given events signing option in the given config
+func setIntegrityOption(cfg *integrity.Config, key, value string) error {
+	switch key {
+	case "sign":
+		switch value {
+		case integrity.AlgorithmHMAC, integrity.AlgorithmEd25519:
+			cfg.Algorithm = value
+		default:
+			return fmt.Errorf("unsupported signing algorithm %q (valid options are: %s,%s)",
+				value, integrity.AlgorithmHMAC, integrity.AlgorithmEd25519)
+		}
+	case "sign-key":
+		if value == "" {
+			return errors.New("key file path can't be empty")
+		}
+		cfg.KeyFile = value
+	}
+
+	return nil
+}
+
+// parseSize parses a size in bytes, with an optional K, M or G suffix (e.g. 100M).
+func parseSize(value string) (int64, error) {
+	multiplier := int64(1)
+
+	switch {
+	case strings.HasSuffix(value, "K"):
+		multiplier = 1 << 10
+	case strings.HasSuffix(value, "M"):
+		multiplier = 1 << 20
+	case strings.HasSuffix(value, "G"):
+		multiplier = 1 << 30
+	}
+	if multiplier != 1 {
+		value = value[:len(value)-1]
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size < 1 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+
+	return size * multiplier, nil
+}
+
+func parsePositiveDuration(value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	return d, nil
+}
+
 // getPrinterConfigs returns a slice of printer.Configs based on the given printerMap
 func getPrinterConfigs(printerMap map[string]string, traceeConfig *config.OutputConfig, newBinary bool) ([]config.PrinterConfig, error) {
 	printerConfigs := make([]config.PrinterConfig, 0, len(printerMap))
 
+	var integrityKey *integrity.Key
+	if traceeConfig.Integrity.Enabled() {
+		if !hasPrinterKind(printerMap, "json") && !hasPrinterKind(printerMap, "json-v2") {
+			return nil, errfmt.Errorf("sign output option requires a json output")
+		}
+		var err error
+		integrityKey, err = integrity.LoadKey(traceeConfig.Integrity)
+		if err != nil {
+			return nil, err
+		}
+	} else if traceeConfig.Integrity.KeyFile != "" {
+		return nil, errfmt.Errorf("sign-key output option requires the sign option")
+	}
+
+	if traceeConfig.TimeRFC3339 && traceeConfig.RelativeTime {
+		return nil, errfmt.Errorf("time-format=rfc3339 output option requires wall times, it can't be used with relative-time")
+	}
+
+	if traceeConfig.RelativeTime && hasPrinterKind(printerMap, "sqlite") {
+		return nil, errfmt.Errorf("sqlite output requires wall times (for its retention), it can't be used with relative-time")
+	}
+
 	for outPath, printerKind := range printerMap {
 		if printerKind == "table" {
 			if err := setOption(traceeConfig, "parse-arguments", newBinary); err != nil {
@@ -164,27 +486,70 @@ func getPrinterConfigs(printerMap map[string]string, traceeConfig *config.Output
 			}
 		}
 
-		outFile := os.Stdout
+		var outFile io.WriteCloser = os.Stdout
 		var err error
 
-		if outPath != "stdout" && printerKind != "forward" && printerKind != "webhook" {
-			outFile, err = createFile(outPath)
+		if outPath != "stdout" && !isSinkKind(printerKind) {
+			if traceeConfig.FileWriter.Enabled() {
+				outFile, err = createFileWriter(outPath, traceeConfig.FileWriter)
+			} else {
+				outFile, err = createFile(outPath)
+			}
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		printerConfigs = append(printerConfigs, config.PrinterConfig{
+		printerConfig := config.PrinterConfig{
 			Kind:       printerKind,
 			OutPath:    outPath,
 			OutFile:    outFile,
 			RelativeTS: traceeConfig.RelativeTime,
-		})
+			TimeZone:   traceeConfig.TimeZone,
+		}
+		if printerKind == "json" || printerKind == "json-v2" {
+			printerConfig.IntegrityKey = integrityKey
+		}
+		printerConfig.BytesFormat = bytesFormat(traceeConfig, printerKind)
+
+		printerConfigs = append(printerConfigs, printerConfig)
 	}
 
 	return printerConfigs, nil
 }
 
+// isSinkKind returns true if the outputs of the given kind aren't files, their path being the
+// address of the sink (e.g. an url)
+func isSinkKind(printerKind string) bool {
+	switch printerKind {
+	case "forward", "webhook", "taxii", "sqlite", "clickhouse", "gelf", "redis", "amqp", "mqtt", "pubsub", "kinesis":
+		return true
+	}
+
+	return false
+}
+
+// bytesFormat returns the rendering of the bytes arguments in the outputs of the given kind
+func bytesFormat(traceeConfig *config.OutputConfig, printerKind string) config.BytesFormat {
+	kind, _, _ := strings.Cut(printerKind, "=") // gotemplate=/path/to/template
+	if format, ok := traceeConfig.BytesFormats[kind]; ok {
+		return format
+	}
+
+	return traceeConfig.BytesFormat
+}
+
+// hasPrinterKind returns true if any of the outputs is of the given kind
+func hasPrinterKind(printerMap map[string]string, kind string) bool {
+	for _, printerKind := range printerMap {
+		if printerKind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 // parseFormat parses the given format and sets it in the given printerMap
 func parseFormat(outputParts []string, printerMap map[string]string, newBinary bool) error {
 	// if not file was passed, we use stdout
@@ -253,8 +618,23 @@ func createFile(path string) (*os.File, error) {
 	return file, nil
 }
 
+// creates a compressing and/or rotating writer for the given path
+func createFileWriter(path string, cfg filewriter.Config) (*filewriter.Writer, error) {
+	fileInfo, err := os.Stat(path)
+	if err == nil && fileInfo.IsDir() {
+		return nil, errfmt.Errorf("cannot use a path of existing directory %s", path)
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errfmt.Errorf("failed to create directory: %v", err)
+	}
+
+	return filewriter.New(path, cfg)
+}
+
 // validateURL validates the given URL
-// --output [webhook|forward]:[protocol://user:pass@]host:port[?k=v#f]
+// --output [webhook|forward|taxii|clickhouse|gelf|redis|amqp|mqtt]:[protocol://user:pass@]host:port[?k=v#f]
 func validateURL(outputParts []string, flag string, newBinary bool) error {
 	if len(outputParts) == 1 || outputParts[1] == "" {
 		if newBinary {
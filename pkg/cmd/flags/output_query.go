@@ -0,0 +1,60 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/query"
+)
+
+func outputQueryHelp() string {
+	return `Select and transform the events printed by the table and json outputs with a jq expression,
+evaluated on every event in its json form (json-v2 for the json-v2 outputs), without piping the
+output to an external jq.
+
+An event the expression outputs as is (e.g. select(...)) is printed as usual, the other results are
+printed one per line, in json (the strings as is, in the table outputs). The events the expression
+has no result for are not printed. The other outputs (e.g. webhook, forward) are not queried.
+
+The expression is evaluated by gojq, implementing the whole jq language, but for the functions reading
+other inputs (input, inputs) and modules (import, include). The environment ($ENV, env) is empty.
+
+Examples:
+  --output-query 'select(.processName != "sshd")'
+  --output-query 'select(.eventName == "execve") | .args[] | select(.name == "argv") | .value | join(" ")'
+  --output json --output-query '{time: .timestamp, pid: .processId, event: .eventName, container: .container.name}'
+`
+}
+
+// PrepareOutputQuery parses the output query, and sets it on the table and json printers.
+func PrepareOutputQuery(expression string, output PrepareOutputResult) error {
+	if expression == "" {
+		return nil
+	}
+	if expression == "help" {
+		return fmt.Errorf(outputQueryHelp())
+	}
+
+	q, err := query.Parse(expression)
+	if err != nil {
+		return errfmt.Errorf("invalid output-query: %v, use '--output-query help' for more info", err)
+	}
+
+	if output.TraceeConfig != nil && output.TraceeConfig.Integrity.Enabled() {
+		return errfmt.Errorf("output-query can't be used with the sign output option")
+	}
+
+	queried := false
+	for i := range output.PrinterConfigs {
+		switch output.PrinterConfigs[i].Kind {
+		case "table", "table-verbose", "json", "json-v2":
+			output.PrinterConfigs[i].Query = q
+			queried = true
+		}
+	}
+	if !queried {
+		return errfmt.Errorf("output-query requires a table or json output")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/integrity"
+)
+
+func TestPrepareOutputQuery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName      string
+		expression    string
+		kinds         []string
+		sign          bool
+		expectedKinds []string // kinds of the printers queried
+		expectedError string
+	}{
+		{
+			testName:   "no query",
+			expression: "",
+			kinds:      []string{"table"},
+		},
+		{
+			testName:      "table and json",
+			expression:    `select(.processName != "sshd")`,
+			kinds:         []string{"table", "json", "webhook"},
+			expectedKinds: []string{"table", "json"},
+		},
+		{
+			testName:      "json-v2",
+			expression:    `{pid: .process_id}`,
+			kinds:         []string{"json-v2", "forward"},
+			expectedKinds: []string{"json-v2"},
+		},
+		{
+			testName:      "invalid expression",
+			expression:    `select(.processName`,
+			kinds:         []string{"table"},
+			expectedError: `invalid output-query: expected ")" at 19, use '--output-query help' for more info`,
+		},
+		{
+			testName:      "no table or json output",
+			expression:    `.eventName`,
+			kinds:         []string{"webhook", "gotemplate=/tmp/template.tmpl"},
+			expectedError: "output-query requires a table or json output",
+		},
+		{
+			testName:      "signed output",
+			expression:    `.eventName`,
+			kinds:         []string{"json"},
+			sign:          true,
+			expectedError: "output-query can't be used with the sign output option",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			output := PrepareOutputResult{TraceeConfig: &config.OutputConfig{}}
+			if tc.sign {
+				output.TraceeConfig.Integrity = integrity.Config{Algorithm: integrity.AlgorithmEd25519}
+			}
+			for _, kind := range tc.kinds {
+				output.PrinterConfigs = append(output.PrinterConfigs, config.PrinterConfig{Kind: kind})
+			}
+
+			err := PrepareOutputQuery(tc.expression, output)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var queried []string
+			for _, p := range output.PrinterConfigs {
+				if p.Query != nil {
+					assert.Equal(t, tc.expression, p.Query.String())
+					queried = append(queried, p.Kind)
+				}
+			}
+			assert.Equal(t, tc.expectedKinds, queried)
+		})
+	}
+}
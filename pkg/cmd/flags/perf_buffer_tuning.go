@@ -0,0 +1,98 @@
+package flags
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+// Defaults of the events perf buffer tuning
+const (
+	defaultPerfBufferTuningInterval = 30 * time.Second
+	defaultPerfBufferLossThreshold  = 1
+	defaultPerfBufferMaxSize        = 16384 // 64 MB per cpu
+
+	// perfBufferStageFile is the file staging the recommended perf buffer size, in the
+	// install path.
+	perfBufferStageFile = "perf-buffer-size"
+)
+
+func perfBufferTuningHelp() string {
+	return `Configure the tuning of the events perf buffer, from the rate of the events lost in it.
+A perf buffer too small for the workload loses the events userland didn't read in time. At every
+interval, the loss rate of the buffer is checked: if it exceeds the threshold, the recommended
+size of the buffer is doubled (up to the max size) and a warning is logged. A perf buffer can't be
+resized while it is polled: the recommended size is either applied manually (--perf-buffer-size),
+or staged in the install path and used when tracee starts again without --perf-buffer-size.
+The loss rate, the buffer sizes and the tuning decisions are exported as metrics (see --metrics).
+
+Possible options:
+  interval=<duration>          | interval between two loss rate checks, 0 to disable the tuning (default: 30s).
+  loss-threshold=<percent>     | loss rate growing the recommended size (default: 1).
+  max-size=<pages>             | max recommended size, a power of 2 (default: 16384).
+  stage                        | stage the recommended size for the next start.
+
+Examples:
+  --perf-buffer-tuning stage                                  | size the buffer from the losses of the previous runs.
+  --perf-buffer-tuning interval=10s --perf-buffer-tuning loss-threshold=0.1
+`
+}
+
+// PreparePerfBufferTuning returns the events perf buffer tuning configuration of the given
+// options. The recommended size is staged in the install path.
+func PreparePerfBufferTuning(tuningSlice []string, installPath string) (config.PerfBufferTuningConfig, error) {
+	cfg := config.PerfBufferTuningConfig{
+		Interval:      defaultPerfBufferTuningInterval,
+		LossThreshold: defaultPerfBufferLossThreshold,
+		MaxSize:       defaultPerfBufferMaxSize,
+	}
+
+	for _, opt := range tuningSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(perfBufferTuningHelp())
+		}
+		if opt == "stage" {
+			cfg.StagePath = PerfBufferStagePath(installPath)
+			continue
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid perf-buffer-tuning option: %s, use '--perf-buffer-tuning help' for more info", opt)
+		}
+
+		switch key {
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || (interval != 0 && interval < time.Second) {
+				return cfg, fmt.Errorf("invalid perf-buffer-tuning interval: %s (minimum 1s)", value)
+			}
+			cfg.Interval = interval
+		case "loss-threshold":
+			threshold, err := parsePercent(value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid perf-buffer-tuning loss-threshold: %s", value)
+			}
+			cfg.LossThreshold = threshold
+		case "max-size":
+			size, err := strconv.Atoi(value)
+			if err != nil || size < 1 || size&(size-1) != 0 {
+				return cfg, fmt.Errorf("invalid perf-buffer-tuning max-size: %s (a power of 2)", value)
+			}
+			cfg.MaxSize = size
+		default:
+			return cfg, fmt.Errorf("invalid perf-buffer-tuning option: %s, use '--perf-buffer-tuning help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
+
+// PerfBufferStagePath returns the file staging the recommended perf buffer size.
+func PerfBufferStagePath(installPath string) string {
+	return filepath.Join(installPath, perfBufferStageFile)
+}
@@ -0,0 +1,92 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+func TestPreparePerfBufferTuning(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		tuningSlice    []string
+		expectedConfig config.PerfBufferTuningConfig
+		expectedError  string
+	}{
+		{
+			testName:    "default",
+			tuningSlice: []string{},
+			expectedConfig: config.PerfBufferTuningConfig{
+				Interval:      30 * time.Second,
+				LossThreshold: 1,
+				MaxSize:       16384,
+			},
+		},
+		{
+			testName:    "options",
+			tuningSlice: []string{"interval=10s", "loss-threshold=0.5", "max-size=4096", "stage"},
+			expectedConfig: config.PerfBufferTuningConfig{
+				Interval:      10 * time.Second,
+				LossThreshold: 0.5,
+				MaxSize:       4096,
+				StagePath:     "/tmp/tracee/perf-buffer-size",
+			},
+		},
+		{
+			testName:    "tuning disabled",
+			tuningSlice: []string{"interval=0"},
+			expectedConfig: config.PerfBufferTuningConfig{
+				LossThreshold: 1,
+				MaxSize:       16384,
+			},
+		},
+		{
+			testName:      "invalid interval",
+			tuningSlice:   []string{"interval=100ms"},
+			expectedError: "invalid perf-buffer-tuning interval: 100ms (minimum 1s)",
+		},
+		{
+			testName:      "invalid loss threshold",
+			tuningSlice:   []string{"loss-threshold=120"},
+			expectedError: "invalid perf-buffer-tuning loss-threshold: 120",
+		},
+		{
+			testName:      "invalid max size",
+			tuningSlice:   []string{"max-size=3000"},
+			expectedError: "invalid perf-buffer-tuning max-size: 3000 (a power of 2)",
+		},
+		{
+			testName:      "invalid option",
+			tuningSlice:   []string{"size=4096"},
+			expectedError: "invalid perf-buffer-tuning option: size=4096",
+		},
+		{
+			testName:      "help",
+			tuningSlice:   []string{"help"},
+			expectedError: "Configure the tuning of the events perf buffer",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PreparePerfBufferTuning(tc.tuningSlice, "/tmp/tracee")
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -245,6 +245,22 @@ func CreatePolicies(policyScopeMap PolicyScopeMap, policyEventsMap PolicyEventMa
 				continue
 			}
 
+			if scopeFlag.scopeName == "time" || scopeFlag.scopeName == "weekday" {
+				err := p.ScheduleFilter.Parse(scopeFlag.scopeName, scopeFlag.operatorAndValues)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			if strings.HasPrefix(scopeFlag.scopeName, filters.LabelScopePrefix) {
+				err := p.ContLabelFilter.Parse(scopeFlag.scopeName, scopeFlag.operatorAndValues)
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+
 			if scopeFlag.scopeName == "follow" {
 				p.Follow = true
 				continue
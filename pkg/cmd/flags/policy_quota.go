@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
+)
+
+func policyQuotaHelp() string {
+	return `Limit the events emitted by a policy, protecting the sinks from runaway matches. A policy exceeding its
+maximum event rate, or its total quota, is switched to sampling: only 1 in n of its events is emitted, and a
+policy_quota_exceeded event (matched by the policy) is emitted. A policy sampled because of its rate is back to
+normal after a second within its rate, while a policy exceeding its total quota is sampled until tracee exits.
+
+Each flag is the quota of a policy, made of comma separated options:
+  policy=<name>                | name of the policy (all the policies without a quota of their own if not given).
+  rate=<n>                     | maximum events per second.
+  total=<n>                    | maximum events in total.
+  sample=<n>                   | emit 1 in n events once the quota is exceeded (default: 100).
+  none                         | no quotas (default).
+
+Examples:
+  --policy-quota rate=1000                                      | sample the events of every policy beyond 1000 events per second.
+  --policy-quota policy=noisy,rate=100,total=100000,sample=10   | sample 1 in 10 events of the noisy policy beyond 100 events per second, or 100000 events.
+`
+}
+
+// PreparePolicyQuotas returns the policies quotas of the given flags.
+func PreparePolicyQuotas(policyQuotaSlice []string) ([]quota.Config, error) {
+	var quotas []quota.Config
+
+	for _, flag := range policyQuotaSlice {
+		if strings.HasPrefix(flag, "help") {
+			return nil, fmt.Errorf(policyQuotaHelp())
+		}
+		if flag == "none" {
+			return nil, nil
+		}
+
+		cfg := quota.Config{SampleRate: quota.DefaultSampleRate}
+		for _, opt := range strings.Split(flag, ",") {
+			key, value, found := strings.Cut(opt, "=")
+			if !found || value == "" {
+				return nil, fmt.Errorf("invalid policy-quota option: %s, use '--policy-quota help' for more info", opt)
+			}
+
+			if key == "policy" {
+				cfg.Policy = value
+				continue
+			}
+
+			n, err := strconv.ParseUint(value, 10, 64)
+			if err != nil || n == 0 {
+				return nil, fmt.Errorf("invalid policy-quota %s: %s", key, value)
+			}
+			switch key {
+			case "rate":
+				cfg.Rate = n
+			case "total":
+				cfg.Total = n
+			case "sample":
+				cfg.SampleRate = n
+			default:
+				return nil, fmt.Errorf("invalid policy-quota option: %s, use '--policy-quota help' for more info", opt)
+			}
+		}
+		if cfg.Rate == 0 && cfg.Total == 0 {
+			return nil, fmt.Errorf("invalid policy-quota: %s, a rate or a total is required", flag)
+		}
+
+		quotas = append(quotas, cfg)
+	}
+
+	return quotas, nil
+}
@@ -0,0 +1,88 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
+)
+
+func TestPreparePolicyQuotas(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName         string
+		policyQuotaSlice []string
+		expectedQuotas   []quota.Config
+		expectedError    string
+	}{
+		{
+			testName:         "none",
+			policyQuotaSlice: []string{"none"},
+		},
+		{
+			testName:         "rate of all the policies",
+			policyQuotaSlice: []string{"rate=1000"},
+			expectedQuotas: []quota.Config{
+				{Rate: 1000, SampleRate: quota.DefaultSampleRate},
+			},
+		},
+		{
+			testName:         "all options",
+			policyQuotaSlice: []string{"policy=noisy,rate=100,total=100000,sample=10", "policy=other,total=5"},
+			expectedQuotas: []quota.Config{
+				{Policy: "noisy", Rate: 100, Total: 100000, SampleRate: 10},
+				{Policy: "other", Total: 5, SampleRate: quota.DefaultSampleRate},
+			},
+		},
+		{
+			testName:         "no rate nor total",
+			policyQuotaSlice: []string{"policy=noisy,sample=10"},
+			expectedError:    "invalid policy-quota: policy=noisy,sample=10, a rate or a total is required",
+		},
+		{
+			testName:         "invalid rate",
+			policyQuotaSlice: []string{"rate=0"},
+			expectedError:    "invalid policy-quota rate: 0",
+		},
+		{
+			testName:         "invalid sample",
+			policyQuotaSlice: []string{"rate=10,sample=often"},
+			expectedError:    "invalid policy-quota sample: often",
+		},
+		{
+			testName:         "unknown option",
+			policyQuotaSlice: []string{"rate=10,burst=20"},
+			expectedError:    "invalid policy-quota option: burst=20",
+		},
+		{
+			testName:         "empty option",
+			policyQuotaSlice: []string{"policy="},
+			expectedError:    "invalid policy-quota option: policy=",
+		},
+		{
+			testName:         "help",
+			policyQuotaSlice: []string{"help"},
+			expectedError:    policyQuotaHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			quotas, err := PreparePolicyQuotas(tc.policyQuotaSlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQuotas, quotas)
+		})
+	}
+}
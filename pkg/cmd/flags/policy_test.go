@@ -470,6 +470,87 @@ func TestPrepareFilterMapsFromPolicies(t *testing.T) {
 				},
 			},
 		},
+		{
+			testName: "time!=09:00-18:00 weekday=mon-fri",
+			policy: v1beta1.PolicyFile{
+				Metadata: v1beta1.Metadata{
+					Name: "schedule-scope",
+				},
+				Spec: k8s.PolicySpec{
+					Scope:          []string{"time!=09:00-18:00", "weekday=mon-fri"},
+					DefaultActions: []string{"log"},
+					Rules: []k8s.Rule{
+						{Event: "write"},
+					},
+				},
+			},
+			expPolicyScopeMap: PolicyScopeMap{
+				0: {
+					policyName: "schedule-scope",
+					scopeFlags: []scopeFlag{
+						{
+							full:              "time!=09:00-18:00",
+							scopeName:         "time",
+							operator:          "!=",
+							values:            "09:00-18:00",
+							operatorAndValues: "!=09:00-18:00",
+						},
+						{
+							full:              "weekday=mon-fri",
+							scopeName:         "weekday",
+							operator:          "=",
+							values:            "mon-fri",
+							operatorAndValues: "=mon-fri",
+						},
+					},
+				},
+			},
+			expPolicyEventMap: PolicyEventMap{
+				0: {
+					policyName: "schedule-scope",
+					eventFlags: []eventFlag{
+						writeEvtFlag,
+					},
+				},
+			},
+		},
+		{
+			testName: "container.label[security.scan]!=skip",
+			policy: v1beta1.PolicyFile{
+				Metadata: v1beta1.Metadata{
+					Name: "container-label-scope",
+				},
+				Spec: k8s.PolicySpec{
+					Scope:          []string{"container.label[security.scan]!=skip"},
+					DefaultActions: []string{"log"},
+					Rules: []k8s.Rule{
+						{Event: "write"},
+					},
+				},
+			},
+			expPolicyScopeMap: PolicyScopeMap{
+				0: {
+					policyName: "container-label-scope",
+					scopeFlags: []scopeFlag{
+						{
+							full:              "container.label[security.scan]!=skip",
+							scopeName:         "container.label[security.scan]",
+							operator:          "!=",
+							values:            "skip",
+							operatorAndValues: "!=skip",
+						},
+					},
+				},
+			},
+			expPolicyEventMap: PolicyEventMap{
+				0: {
+					policyName: "container-label-scope",
+					eventFlags: []eventFlag{
+						writeEvtFlag,
+					},
+				},
+			},
+		},
 		{
 			testName: "scope with space",
 			policy: v1beta1.PolicyFile{
@@ -2,11 +2,13 @@ package flags
 
 import (
 	"strings"
+	"time"
 
 	"github.com/open-policy-agent/opa/compile"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/signatures/rego"
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
 )
 
 func regoHelp() string {
@@ -14,9 +16,13 @@ func regoHelp() string {
 possible options:
 partial-eval            enable partial evaluation of rego signatures.
 aio                     compile rego signatures altogether as an aggregate policy. By default each signature is compiled separately.
+data=<name>:<source>    load a data document (JSON or YAML) from a file or an http(s) URL, referenced by signatures as data.documents.<name>.
+data-refresh=<duration> interval between two refreshes of the data documents (default: 1m, 0 disables refreshes).
 Examples:
   --rego partial-eval                               | enable partial evaluation
   --rego partial-eval --rego aio                    | enable partial evaluation, and aggregate policy compilation.
+  --rego data=allowlist:/etc/tracee/allowlist.json  | load the allowlist document, refreshed every minute
+  --rego data=images:https://example.com/images.json --rego data-refresh=10m | load the images document, refreshed every 10 minutes
 Use this flag multiple times to choose multiple output options
 `
 }
@@ -27,6 +33,7 @@ func PrepareRego(regoSlice []string) (rego.Config, error) {
 		PartialEval:   false,
 		AIO:           false,
 	}
+	dataRefresh := regosig.DefaultDataRefresh
 
 	if len(regoSlice) == 0 {
 		return c, nil
@@ -34,11 +41,29 @@ func PrepareRego(regoSlice []string) (rego.Config, error) {
 
 	for _, s := range regoSlice {
 		optValue := strings.TrimSpace(s)
-		switch optValue {
-		case "partial-eval":
+		switch {
+		case optValue == "partial-eval":
 			c.PartialEval = true
-		case "aio":
+		case optValue == "aio":
 			c.AIO = true
+		case strings.HasPrefix(optValue, "data="):
+			name, source, found := strings.Cut(strings.TrimPrefix(optValue, "data="), ":")
+			if !found || name == "" || source == "" {
+				return rego.Config{}, errfmt.Errorf("invalid rego data document '%s', use 'data=<name>:<source>'", optValue)
+			}
+			if c.Data == nil {
+				c.Data = make(map[string]string)
+			}
+			if _, ok := c.Data[name]; ok {
+				return rego.Config{}, errfmt.Errorf("rego data document '%s' specified more than once", name)
+			}
+			c.Data[name] = source
+		case strings.HasPrefix(optValue, "data-refresh="):
+			refresh, err := time.ParseDuration(strings.TrimPrefix(optValue, "data-refresh="))
+			if err != nil || refresh < 0 {
+				return rego.Config{}, errfmt.Errorf("invalid rego data refresh interval '%s'", optValue)
+			}
+			dataRefresh = refresh
 		default:
 			// TODO: build man page
 			// return rego.Config{}, errfmt.Errorf("invalid rego option specified, see 'tracee-rego' man page for more info")
@@ -46,5 +71,9 @@ func PrepareRego(regoSlice []string) (rego.Config, error) {
 		}
 	}
 
+	if len(c.Data) > 0 {
+		c.DataRefresh = dataRefresh
+	}
+
 	return c, nil
 }
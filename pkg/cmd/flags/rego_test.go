@@ -3,6 +3,7 @@ package flags
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +51,49 @@ func TestPrepareRego(t *testing.T) {
 					AIO:           true,
 				},
 			},
+			{
+				testName:  "configure data documents",
+				regoSlice: []string{"data=allowlist:/etc/allowlist.json", "data=images:https://example.com/images.yaml"},
+				expectedRego: rego.Config{
+					RuntimeTarget: "rego",
+					Data: map[string]string{
+						"allowlist": "/etc/allowlist.json",
+						"images":    "https://example.com/images.yaml",
+					},
+					DataRefresh: time.Minute,
+				},
+			},
+			{
+				testName:  "configure data documents refresh",
+				regoSlice: []string{"data-refresh=10m", "data=allowlist:allowlist.json"},
+				expectedRego: rego.Config{
+					RuntimeTarget: "rego",
+					Data:          map[string]string{"allowlist": "allowlist.json"},
+					DataRefresh:   10 * time.Minute,
+				},
+			},
+			{
+				testName:  "data refresh without data documents",
+				regoSlice: []string{"data-refresh=10m"},
+				expectedRego: rego.Config{
+					RuntimeTarget: "rego",
+				},
+			},
+			{
+				testName:      "invalid data document",
+				regoSlice:     []string{"data=allowlist"},
+				expectedError: errors.New("invalid rego data document 'data=allowlist'"),
+			},
+			{
+				testName:      "duplicate data document",
+				regoSlice:     []string{"data=allowlist:a.json", "data=allowlist:b.json"},
+				expectedError: errors.New("rego data document 'allowlist' specified more than once"),
+			},
+			{
+				testName:      "invalid data refresh",
+				regoSlice:     []string{"data-refresh=soon"},
+				expectedError: errors.New("invalid rego data refresh interval 'data-refresh=soon'"),
+			},
 		}
 
 		for _, tc := range testCases {
@@ -0,0 +1,62 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/sbom"
+)
+
+func runtimeSBOMHelp() string {
+	return `Configure the runtime SBOM (software bill of materials) of the containers, emitted by the
+runtime_sbom event. The binaries executed (sched_process_exec) and the shared objects loaded
+(shared_object_loaded) in every container are collected, and at every interval a CycloneDX JSON
+document of what actually ran in the containers whose components changed is emitted. The document
+version is bumped at every report of a container.
+The components are hashed if the hashes calculation is enabled (see --output option:calc-hashes).
+The runtime_sbom event must be selected (e.g. --events runtime_sbom) for the components to be collected.
+
+Possible options:
+  interval=<duration> | interval between two reports of a container (default: 1m).
+  dir=<path>          | also write the documents to the directory, as runtime-sbom-<container id>.cdx.json
+                      | (overwritten at every report of the container).
+
+Examples:
+  --events runtime_sbom --runtime-sbom interval=5m                  | report the runtime SBOM every 5 minutes.
+  --events runtime_sbom --runtime-sbom dir=/var/lib/tracee/sbom     | also write the reports to /var/lib/tracee/sbom.
+`
+}
+
+// PrepareRuntimeSBOM returns the containers runtime SBOM configuration of the given options.
+func PrepareRuntimeSBOM(runtimeSBOMSlice []string) (sbom.Config, error) {
+	cfg := sbom.Config{
+		Interval: sbom.DefaultInterval,
+	}
+
+	for _, opt := range runtimeSBOMSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(runtimeSBOMHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid runtime-sbom option: %s, use '--runtime-sbom help' for more info", opt)
+		}
+
+		switch key {
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval < time.Second {
+				return cfg, fmt.Errorf("invalid runtime-sbom interval: %s (minimum 1s)", value)
+			}
+			cfg.Interval = interval
+		case "dir":
+			cfg.Dir = value
+		default:
+			return cfg, fmt.Errorf("invalid runtime-sbom option: %s, use '--runtime-sbom help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/sbom"
+)
+
+func TestPrepareRuntimeSBOM(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName         string
+		runtimeSBOMSlice []string
+		expectedConfig   sbom.Config
+		expectedError    string
+	}{
+		{
+			testName:         "default",
+			runtimeSBOMSlice: []string{},
+			expectedConfig:   sbom.Config{Interval: sbom.DefaultInterval},
+		},
+		{
+			testName:         "interval and dir",
+			runtimeSBOMSlice: []string{"interval=5m", "dir=/tmp/sbom"},
+			expectedConfig:   sbom.Config{Interval: 5 * time.Minute, Dir: "/tmp/sbom"},
+		},
+		{
+			testName:         "interval too short",
+			runtimeSBOMSlice: []string{"interval=10ms"},
+			expectedError:    "invalid runtime-sbom interval: 10ms (minimum 1s)",
+		},
+		{
+			testName:         "empty dir",
+			runtimeSBOMSlice: []string{"dir="},
+			expectedError:    "invalid runtime-sbom option: dir=",
+		},
+		{
+			testName:         "unknown option",
+			runtimeSBOMSlice: []string{"format=spdx"},
+			expectedError:    "invalid runtime-sbom option: format=spdx",
+		},
+		{
+			testName:         "help",
+			runtimeSBOMSlice: []string{"help"},
+			expectedError:    runtimeSBOMHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareRuntimeSBOM(tc.runtimeSBOMSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
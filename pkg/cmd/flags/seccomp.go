@@ -0,0 +1,72 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/seccomp"
+)
+
+func seccompHelp() string {
+	return `Confine tracee to the syscalls it needs with seccomp, reducing its attack surface once it's
+initialized (the eBPF programs loaded and attached). The seccomp profile of those syscalls can also be
+written, for the container runtimes (docker --security-opt seccomp=<file>, kubernetes localhost profiles).
+AppArmor profiles aren't generated.
+
+Possible options:
+  profile=<file>            | write the seccomp profile of the syscalls tracee needs to the file.
+  mode=[audit|enforce]      | install the seccomp filter on tracee itself: the other syscalls are logged
+                              (audit), to validate the profile, or fail with EPERM (enforce).
+  allow=<syscall>[,...]     | allow syscalls in addition to the ones tracee needs (can be repeated).
+  none                      | don't confine tracee (default).
+
+Examples:
+  --seccomp profile=/etc/tracee/seccomp.json               | write the profile.
+  --seccomp mode=audit                                      | log the syscalls tracee isn't expected to make.
+  --seccomp mode=enforce --seccomp allow=execve             | confine tracee, allowing execve too.
+`
+}
+
+// PrepareSeccomp returns the confinement of tracee to the syscalls it needs.
+func PrepareSeccomp(seccompSlice []string) (seccomp.Config, error) {
+	var cfg seccomp.Config
+
+	for _, opt := range seccompSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(seccompHelp())
+		}
+		if opt == "none" {
+			return seccomp.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid seccomp option: %s, use '--seccomp help' for more info", opt)
+		}
+
+		switch key {
+		case "profile":
+			cfg.ProfilePath = value
+		case "mode":
+			switch value {
+			case "audit":
+				cfg.Mode = seccomp.ModeAudit
+			case "enforce":
+				cfg.Mode = seccomp.ModeEnforce
+			default:
+				return cfg, fmt.Errorf("invalid seccomp mode: %s", value)
+			}
+		case "allow":
+			for _, name := range strings.Split(value, ",") {
+				if name == "" {
+					return cfg, fmt.Errorf("invalid seccomp option: %s, use '--seccomp help' for more info", opt)
+				}
+				cfg.Allow = append(cfg.Allow, name)
+			}
+		default:
+			return cfg, fmt.Errorf("invalid seccomp option: %s, use '--seccomp help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
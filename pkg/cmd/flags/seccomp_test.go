@@ -0,0 +1,77 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/seccomp"
+)
+
+func TestPrepareSeccomp(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		seccompSlice   []string
+		expectedConfig seccomp.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			seccompSlice:   []string{"none"},
+			expectedConfig: seccomp.Config{},
+		},
+		{
+			testName:     "profile and mode",
+			seccompSlice: []string{"profile=/etc/tracee/seccomp.json", "mode=enforce", "allow=execve,wait4", "allow=kcmp"},
+			expectedConfig: seccomp.Config{
+				ProfilePath: "/etc/tracee/seccomp.json",
+				Mode:        seccomp.ModeEnforce,
+				Allow:       []string{"execve", "wait4", "kcmp"},
+			},
+		},
+		{
+			testName:       "audit",
+			seccompSlice:   []string{"mode=audit"},
+			expectedConfig: seccomp.Config{Mode: seccomp.ModeAudit},
+		},
+		{
+			testName:      "invalid mode",
+			seccompSlice:  []string{"mode=kill"},
+			expectedError: "invalid seccomp mode: kill",
+		},
+		{
+			testName:      "empty syscall",
+			seccompSlice:  []string{"allow=execve,"},
+			expectedError: "invalid seccomp option: allow=execve,",
+		},
+		{
+			testName:      "unknown option",
+			seccompSlice:  []string{"apparmor=/tmp/tracee"},
+			expectedError: "invalid seccomp option: apparmor=/tmp/tracee",
+		},
+		{
+			testName:      "help",
+			seccompSlice:  []string{"help"},
+			expectedError: seccompHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareSeccomp(tc.seccompSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
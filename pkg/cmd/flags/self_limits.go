@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/selflimit"
+)
+
+func selfLimitsHelp() string {
+	return `Configure the CPU and memory self-limits of tracee. The CPU time and the resident memory of
+tracee are sampled at every interval, and the load is shed progressively while they exceed a limit,
+one level per interval:
+  sample            | the low priority events are sampled (1 out of the sample rate kept).
+  no-enrichment     | the stack symbols, canonical paths, file hashes and build ids aren't resolved.
+  drop-low-priority | the low priority events are dropped.
+The low priority events are the ones only emitted: the events derived from, or the input of the
+signatures, are never shed. The load shedding steps down, one level per interval, once the usage
+is back below 80% of the limits. The level changes are logged, and emitted as load_shedding events
+if selected.
+
+Possible options:
+  cpu=<cores>          | CPU limit, in cores (e.g. 0.5).
+  memory=<size>        | memory limit, with an optional K, M or G suffix (e.g. 512M).
+  interval=<duration>  | interval between two samples of the usage (default: 5s).
+  sample-rate=<n>      | 1 out of n low priority events kept while sampled (default: 10).
+
+Examples:
+  --self-limits cpu=1 --self-limits memory=1G                 | shed load beyond a core, or 1G of memory.
+  --events load_shedding --self-limits memory=512M             | emit the load shedding level changes.
+  --self-limits cpu=0.5 --self-limits sample-rate=100          | keep 1% of the low priority events while sampled.
+`
+}
+
+// PrepareSelfLimits returns the self-limits configuration of the given options.
+func PrepareSelfLimits(selfLimitsSlice []string) (selflimit.Config, error) {
+	cfg := selflimit.Config{
+		Interval:   selflimit.DefaultInterval,
+		SampleRate: selflimit.DefaultSampleRate,
+	}
+
+	for _, opt := range selfLimitsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(selfLimitsHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid self-limits option: %s, use '--self-limits help' for more info", opt)
+		}
+
+		switch key {
+		case "cpu":
+			cores, err := strconv.ParseFloat(value, 64)
+			if err != nil || cores <= 0 {
+				return cfg, fmt.Errorf("invalid self-limits cpu: %s", value)
+			}
+			cfg.CPU = cores
+		case "memory":
+			size, err := parseSize(value)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid self-limits memory: %s", value)
+			}
+			cfg.Memory = uint64(size)
+		case "interval":
+			interval, err := time.ParseDuration(value)
+			if err != nil || interval < time.Second {
+				return cfg, fmt.Errorf("invalid self-limits interval: %s (minimum 1s)", value)
+			}
+			cfg.Interval = interval
+		case "sample-rate":
+			rate, err := strconv.ParseUint(value, 10, 64)
+			if err != nil || rate < 2 {
+				return cfg, fmt.Errorf("invalid self-limits sample-rate: %s (minimum 2)", value)
+			}
+			cfg.SampleRate = rate
+		default:
+			return cfg, fmt.Errorf("invalid self-limits option: %s, use '--self-limits help' for more info", opt)
+		}
+	}
+
+	if len(selfLimitsSlice) > 0 && !cfg.Enabled() {
+		return cfg, fmt.Errorf("no self-limits given, use '--self-limits help' for more info")
+	}
+
+	return cfg, nil
+}
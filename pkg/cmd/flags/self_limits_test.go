@@ -0,0 +1,97 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/selflimit"
+)
+
+func TestPrepareSelfLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName        string
+		selfLimitsSlice []string
+		expectedConfig  selflimit.Config
+		expectedError   string
+	}{
+		{
+			testName:        "default",
+			selfLimitsSlice: []string{},
+			expectedConfig: selflimit.Config{
+				Interval:   selflimit.DefaultInterval,
+				SampleRate: selflimit.DefaultSampleRate,
+			},
+		},
+		{
+			testName:        "limits",
+			selfLimitsSlice: []string{"cpu=0.5", "memory=512M"},
+			expectedConfig: selflimit.Config{
+				CPU:        0.5,
+				Memory:     512 << 20,
+				Interval:   selflimit.DefaultInterval,
+				SampleRate: selflimit.DefaultSampleRate,
+			},
+		},
+		{
+			testName:        "interval and sample rate",
+			selfLimitsSlice: []string{"memory=1G", "interval=30s", "sample-rate=100"},
+			expectedConfig: selflimit.Config{
+				Memory:     1 << 30,
+				Interval:   30 * time.Second,
+				SampleRate: 100,
+			},
+		},
+		{
+			testName:        "no limits",
+			selfLimitsSlice: []string{"interval=30s"},
+			expectedError:   "no self-limits given, use '--self-limits help' for more info",
+		},
+		{
+			testName:        "invalid cpu",
+			selfLimitsSlice: []string{"cpu=-1"},
+			expectedError:   "invalid self-limits cpu: -1",
+		},
+		{
+			testName:        "invalid memory",
+			selfLimitsSlice: []string{"memory=1T"},
+			expectedError:   "invalid self-limits memory: 1T",
+		},
+		{
+			testName:        "interval too short",
+			selfLimitsSlice: []string{"cpu=1", "interval=100ms"},
+			expectedError:   "invalid self-limits interval: 100ms (minimum 1s)",
+		},
+		{
+			testName:        "invalid sample rate",
+			selfLimitsSlice: []string{"cpu=1", "sample-rate=1"},
+			expectedError:   "invalid self-limits sample-rate: 1 (minimum 2)",
+		},
+		{
+			testName:        "invalid option",
+			selfLimitsSlice: []string{"disk=1G"},
+			expectedError:   "invalid self-limits option: disk=1G, use '--self-limits help' for more info",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareSelfLimits(tc.selfLimitsSlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package flags
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// defaultSensitiveFiles are the credential files matched by sensitive_file_access (unless
+// disabled by defaults=false).
+var defaultSensitiveFiles = []string{
+	// local accounts
+	"/etc/shadow",
+	"/etc/shadow-",
+	"/etc/gshadow",
+	"/etc/gshadow-",
+	"/etc/security/opasswd",
+	// kubernetes
+	"/etc/kubernetes/*.conf",
+	"/etc/kubernetes/pki/*.key",
+	"/var/lib/kubelet/kubeconfig",
+	"/var/lib/kubelet/pki/*.key",
+	"/root/.kube/config",
+	"/home/*/.kube/config",
+	"/var/run/secrets/kubernetes.io/serviceaccount/token",
+	// cloud providers
+	"/root/.aws/credentials",
+	"/home/*/.aws/credentials",
+	"/root/.config/gcloud/credentials.db",
+	"/home/*/.config/gcloud/credentials.db",
+	"/root/.config/gcloud/application_default_credentials.json",
+	"/home/*/.config/gcloud/application_default_credentials.json",
+	"/root/.azure/accessTokens.json",
+	"/home/*/.azure/accessTokens.json",
+	"/root/.docker/config.json",
+	"/home/*/.docker/config.json",
+	// ssh keys
+	"/etc/ssh/ssh_host_*_key",
+	"/root/.ssh/id_*",
+	"/home/*/.ssh/id_*",
+}
+
+func sensitiveFilesHelp() string {
+	return `Configure the files matched by the sensitive_file_access event. Accesses to the matching files
+(opened for reading or writing) are reported with the access mode and the lineage of the process.
+The files are matched with shell patterns ('*' matches any sequence of characters but '/').
+
+By default, the following credential files are matched: the local accounts shadow files,
+kubeconfigs and kubernetes private keys, service account tokens, cloud providers (AWS, GCP, Azure)
+and docker credentials, and SSH keys.
+
+Possible options:
+  path=<pattern>     | match the files of the given pattern (can be given multiple times).
+  defaults=<bool>    | match the default credential files (default: true).
+
+Examples:
+  --events sensitive_file_access                                           | report accesses to the default credential files.
+  --events sensitive_file_access --sensitive-files path=/etc/vault/*.hcl   | also report accesses to the vault configuration files.
+  --sensitive-files defaults=false --sensitive-files path=/srv/secrets/*   | only report accesses to the /srv/secrets files.
+`
+}
+
+// PrepareSensitiveFiles returns the files patterns of the given options matched by the
+// sensitive_file_access event.
+func PrepareSensitiveFiles(sensitiveFilesSlice []string) ([]string, error) {
+	defaults := true
+	var patterns []string
+
+	for _, opt := range sensitiveFilesSlice {
+		if strings.HasPrefix(opt, "help") {
+			return nil, fmt.Errorf(sensitiveFilesHelp())
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return nil, fmt.Errorf("invalid sensitive-files option: %s, use '--sensitive-files help' for more info", opt)
+		}
+
+		switch key {
+		case "path":
+			if !filepath.IsAbs(value) {
+				return nil, fmt.Errorf("invalid sensitive-files path: %s (must be absolute)", value)
+			}
+			if _, err := filepath.Match(value, ""); err != nil {
+				return nil, fmt.Errorf("invalid sensitive-files path: %s (%v)", value, err)
+			}
+			patterns = append(patterns, value)
+		case "defaults":
+			switch value {
+			case "true":
+				defaults = true
+			case "false":
+				defaults = false
+			default:
+				return nil, fmt.Errorf("invalid sensitive-files defaults: %s (must be true or false)", value)
+			}
+		default:
+			return nil, fmt.Errorf("invalid sensitive-files option: %s, use '--sensitive-files help' for more info", opt)
+		}
+	}
+
+	if defaults {
+		patterns = append(append([]string{}, defaultSensitiveFiles...), patterns...)
+	}
+
+	return patterns, nil
+}
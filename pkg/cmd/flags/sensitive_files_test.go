@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareSensitiveFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName            string
+		sensitiveFilesSlice []string
+		expectedPatterns    []string
+		expectedError       string
+	}{
+		{
+			testName:            "default",
+			sensitiveFilesSlice: []string{},
+			expectedPatterns:    defaultSensitiveFiles,
+		},
+		{
+			testName:            "additional path",
+			sensitiveFilesSlice: []string{"path=/etc/vault/*.hcl"},
+			expectedPatterns:    append(append([]string{}, defaultSensitiveFiles...), "/etc/vault/*.hcl"),
+		},
+		{
+			testName:            "without defaults",
+			sensitiveFilesSlice: []string{"path=/srv/secrets/*", "defaults=false"},
+			expectedPatterns:    []string{"/srv/secrets/*"},
+		},
+		{
+			testName:            "relative path",
+			sensitiveFilesSlice: []string{"path=secrets/*"},
+			expectedError:       "invalid sensitive-files path: secrets/* (must be absolute)",
+		},
+		{
+			testName:            "malformed pattern",
+			sensitiveFilesSlice: []string{"path=/srv/[secrets"},
+			expectedError:       "invalid sensitive-files path: /srv/[secrets",
+		},
+		{
+			testName:            "invalid defaults",
+			sensitiveFilesSlice: []string{"defaults=no"},
+			expectedError:       "invalid sensitive-files defaults: no (must be true or false)",
+		},
+		{
+			testName:            "invalid option",
+			sensitiveFilesSlice: []string{"mode=read"},
+			expectedError:       "invalid sensitive-files option: mode=read",
+		},
+		{
+			testName:            "help",
+			sensitiveFilesSlice: []string{"help"},
+			expectedError:       sensitiveFilesHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			patterns, err := PrepareSensitiveFiles(tc.sensitiveFilesSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPatterns, patterns)
+		})
+	}
+}
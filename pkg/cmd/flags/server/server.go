@@ -11,6 +11,7 @@ const (
 	HealthzEndpointFlag    = "healthz"
 	PProfEndpointFlag      = "pprof"
 	HTTPListenEndpointFlag = "http-listen-addr"
+	DataListenEndpointFlag = "data-listen-addr"
 	GRPCListenEndpointFlag = "grpc-listen-addr"
 	PyroscopeAgentFlag     = "pyroscope"
 )
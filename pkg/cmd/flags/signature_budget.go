@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/budget"
+)
+
+func signatureBudgetHelp() string {
+	return `Measure the cost of the signatures against recorded events before loading them, and enforce a
+per-event time budget, so a costly signature can't slow down the events pipeline. Every signature is
+replayed the events it selects (one per line, as output by '--output json'), and the signatures
+spending more than the budget handling an event, on average, are reported (or not loaded).
+
+Possible options:
+  events=<file>                | recorded events file the signatures are measured against (required).
+  budget=<duration>            | time a signature may spend handling an event, on average (default: 100us).
+  action=<warn|refuse>         | warn about the signatures exceeding the budget, or don't load them (default: warn).
+  none                         | don't measure the signatures (default).
+
+Examples:
+  --signature-budget events=events.json                                 | warn about the signatures spending more than 100us per event.
+  --signature-budget events=events.json --signature-budget action=refuse  | don't load the signatures spending more than 100us per event.
+`
+}
+
+// PrepareSignatureBudget returns the signatures budget configuration of the given options.
+func PrepareSignatureBudget(signatureBudgetSlice []string) (budget.Config, error) {
+	cfg := budget.Config{
+		Budget: budget.DefaultBudget,
+		Action: budget.ActionWarn,
+	}
+
+	for _, opt := range signatureBudgetSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(signatureBudgetHelp())
+		}
+		if opt == "none" {
+			return budget.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid signature-budget option: %s, use '--signature-budget help' for more info", opt)
+		}
+
+		switch key {
+		case "events":
+			cfg.Events = value
+		case "budget":
+			b, err := time.ParseDuration(value)
+			if err != nil || b <= 0 {
+				return cfg, fmt.Errorf("invalid signature-budget budget: %s", value)
+			}
+			cfg.Budget = b
+		case "action":
+			action := budget.Action(value)
+			if action != budget.ActionWarn && action != budget.ActionRefuse {
+				return cfg, fmt.Errorf("invalid signature-budget action: %s (warn or refuse)", value)
+			}
+			cfg.Action = action
+		default:
+			return cfg, fmt.Errorf("invalid signature-budget option: %s, use '--signature-budget help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	if cfg.Enabled && cfg.Events == "" {
+		return cfg, fmt.Errorf("signature-budget requires a recorded events file, use '--signature-budget help' for more info")
+	}
+
+	return cfg, nil
+}
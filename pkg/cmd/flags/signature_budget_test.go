@@ -0,0 +1,97 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/budget"
+)
+
+func TestPrepareSignatureBudget(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName             string
+		signatureBudgetSlice []string
+		expectedConfig       budget.Config
+		expectedError        string
+	}{
+		{
+			testName:             "disabled by default",
+			signatureBudgetSlice: []string{},
+			expectedConfig: budget.Config{
+				Budget: budget.DefaultBudget,
+				Action: budget.ActionWarn,
+			},
+		},
+		{
+			testName:             "none",
+			signatureBudgetSlice: []string{"none"},
+			expectedConfig:       budget.Config{},
+		},
+		{
+			testName:             "events only",
+			signatureBudgetSlice: []string{"events=events.json"},
+			expectedConfig: budget.Config{
+				Enabled: true,
+				Events:  "events.json",
+				Budget:  budget.DefaultBudget,
+				Action:  budget.ActionWarn,
+			},
+		},
+		{
+			testName:             "all options",
+			signatureBudgetSlice: []string{"events=events.json", "budget=20us", "action=refuse"},
+			expectedConfig: budget.Config{
+				Enabled: true,
+				Events:  "events.json",
+				Budget:  20 * time.Microsecond,
+				Action:  budget.ActionRefuse,
+			},
+		},
+		{
+			testName:             "missing events",
+			signatureBudgetSlice: []string{"budget=20us"},
+			expectedError:        "signature-budget requires a recorded events file",
+		},
+		{
+			testName:             "invalid budget",
+			signatureBudgetSlice: []string{"events=events.json", "budget=-1s"},
+			expectedError:        "invalid signature-budget budget: -1s",
+		},
+		{
+			testName:             "invalid action",
+			signatureBudgetSlice: []string{"events=events.json", "action=drop"},
+			expectedError:        "invalid signature-budget action: drop (warn or refuse)",
+		},
+		{
+			testName:             "invalid option",
+			signatureBudgetSlice: []string{"rounds=3"},
+			expectedError:        "invalid signature-budget option: rounds=3",
+		},
+		{
+			testName:             "help",
+			signatureBudgetSlice: []string{"help"},
+			expectedError:        signatureBudgetHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareSignatureBudget(tc.signatureBudgetSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
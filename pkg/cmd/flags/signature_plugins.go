@@ -0,0 +1,53 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+)
+
+func signaturePluginsHelp() string {
+	return `Verify the Go signature plugins (.so files of the signatures directories) before loading them.
+A plugin is signed with 'cosign sign-blob --key cosign.key --output-signature <plugin>.so.sig <plugin>.so',
+its signature being next to it. Plugins which aren't verified are refused, unless unsigned plugins are
+allowed. Plugins with an invalid signature are always refused.
+A signature_plugin_load event is emitted for every plugin, loaded or refused.
+
+Possible options:
+  cosign-key=<file>      | verify the plugins signatures with the cosign public key (e.g. cosign.pub).
+  allow-unsigned         | load the plugins which can't be verified (not signed, or no key), with a warning.
+
+Examples:
+  --signature-plugins cosign-key=/etc/tracee/cosign.pub       | load the plugins signed with the key only.
+  --signature-plugins allow-unsigned                          | load all the plugins (not recommended).
+`
+}
+
+// PrepareSignaturePlugins returns the verification policy of the Go signature plugins.
+func PrepareSignaturePlugins(pluginsSlice []string) (signature.PluginPolicy, error) {
+	var policy signature.PluginPolicy
+
+	for _, opt := range pluginsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return policy, fmt.Errorf(signaturePluginsHelp())
+		}
+		if opt == "allow-unsigned" {
+			policy.AllowUnsigned = true
+			continue
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" || key != "cosign-key" {
+			return policy, fmt.Errorf("invalid signature-plugins option: %s, use '--signature-plugins help' for more info", opt)
+		}
+		publicKey, err := cosign.LoadPublicKey(value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid signature-plugins cosign key: %v", err)
+		}
+		policy.PublicKey = publicKey
+	}
+
+	return policy, nil
+}
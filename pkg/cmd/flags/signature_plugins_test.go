@@ -0,0 +1,45 @@
+package flags
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareSignaturePlugins(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	policy, err := PrepareSignaturePlugins(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy.PublicKey)
+	assert.False(t, policy.AllowUnsigned)
+
+	policy, err = PrepareSignaturePlugins([]string{"cosign-key=" + keyFile, "allow-unsigned"})
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(policy.PublicKey))
+	assert.True(t, policy.AllowUnsigned)
+
+	_, err = PrepareSignaturePlugins([]string{"cosign-key=" + filepath.Join(t.TempDir(), "missing.pub")})
+	assert.ErrorContains(t, err, "invalid signature-plugins cosign key")
+
+	_, err = PrepareSignaturePlugins([]string{"allow-all"})
+	assert.ErrorContains(t, err, "invalid signature-plugins option: allow-all")
+
+	_, err = PrepareSignaturePlugins([]string{"help"})
+	assert.EqualError(t, err, signaturePluginsHelp())
+}
@@ -0,0 +1,69 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+func splitPipelineHelp() string {
+	return `Split the events pipeline between the nodes and a remote processor, shrinking the footprint
+of tracee on the nodes. The nodes (producers) decode, process and derive their events, and forward
+them over gRPC to the processor, which runs the signatures engine and the outputs. The events
+stages reading the node state (e.g. the process tree, the containers runtimes, the eBPF maps)
+stay on the nodes. The producers and the processor must be given the same policies and
+signatures, the producers of other policies are rejected by the processor.
+
+Possible options:
+  producer=<protocol:addr>     | forward the events to the processor at the given address.
+  processor=<protocol:addr>    | process the events forwarded to the given address, without eBPF.
+
+Protocols are tcp or unix. The events are dropped while the processor is unreachable.
+
+Examples:
+  --split-pipeline producer=tcp:tracee-processor:4467  | forward the events of the node.
+  --split-pipeline processor=tcp:4467                  | process the events forwarded to port 4467.
+`
+}
+
+// PrepareSplitPipeline returns the split of the events pipeline of the given options.
+func PrepareSplitPipeline(splitSlice []string) (config.SplitPipelineConfig, error) {
+	var cfg config.SplitPipelineConfig
+
+	for _, opt := range splitSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(splitPipelineHelp())
+		}
+
+		mode, endpoint, found := strings.Cut(opt, "=")
+		if !found || (mode != config.SplitPipelineProducer && mode != config.SplitPipelineProcessor) {
+			return cfg, fmt.Errorf("invalid split-pipeline option: %s, use '--split-pipeline help' for more info", opt)
+		}
+		if cfg.Mode != "" {
+			return cfg, fmt.Errorf("split-pipeline can only be given once")
+		}
+
+		protocol, address, _ := strings.Cut(endpoint, ":")
+		if protocol != "tcp" && protocol != "unix" {
+			return cfg, fmt.Errorf("invalid split-pipeline protocol: %s, use tcp or unix (e.g. tcp:4467, unix:/tmp/tracee-pipeline.sock)", endpoint)
+		}
+		if address == "" {
+			return cfg, fmt.Errorf("split-pipeline address cannot be empty")
+		}
+
+		cfg = config.SplitPipelineConfig{Mode: mode, Protocol: protocol, Address: address}
+	}
+
+	// cleanup the processor unix socket if needed, for example if a panic happened
+	if cfg.Mode == config.SplitPipelineProcessor && cfg.Protocol == "unix" {
+		if _, err := os.Stat(cfg.Address); err == nil {
+			if err := os.Remove(cfg.Address); err != nil {
+				return cfg, fmt.Errorf("failed to cleanup split-pipeline listening address (%s): %v", cfg.Address, err)
+			}
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,82 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+)
+
+func TestPrepareSplitPipeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		splitSlice     []string
+		expectedConfig config.SplitPipelineConfig
+		expectedError  string
+	}{
+		{
+			testName:       "not split",
+			splitSlice:     []string{},
+			expectedConfig: config.SplitPipelineConfig{},
+		},
+		{
+			testName:   "producer",
+			splitSlice: []string{"producer=tcp:tracee-processor:4467"},
+			expectedConfig: config.SplitPipelineConfig{
+				Mode:     config.SplitPipelineProducer,
+				Protocol: "tcp",
+				Address:  "tracee-processor:4467",
+			},
+		},
+		{
+			testName:   "processor",
+			splitSlice: []string{"processor=tcp:4467"},
+			expectedConfig: config.SplitPipelineConfig{
+				Mode:     config.SplitPipelineProcessor,
+				Protocol: "tcp",
+				Address:  "4467",
+			},
+		},
+		{
+			testName:      "invalid mode",
+			splitSlice:    []string{"consumer=tcp:4467"},
+			expectedError: "invalid split-pipeline option: consumer=tcp:4467, use '--split-pipeline help' for more info",
+		},
+		{
+			testName:      "invalid protocol",
+			splitSlice:    []string{"producer=udp:4467"},
+			expectedError: "invalid split-pipeline protocol: udp:4467, use tcp or unix (e.g. tcp:4467, unix:/tmp/tracee-pipeline.sock)",
+		},
+		{
+			testName:      "empty address",
+			splitSlice:    []string{"producer=tcp:"},
+			expectedError: "split-pipeline address cannot be empty",
+		},
+		{
+			testName:      "both modes",
+			splitSlice:    []string{"producer=tcp:processor:4467", "processor=tcp:4467"},
+			expectedError: "split-pipeline can only be given once",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareSplitPipeline(tc.splitSlice)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedError, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package flags
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/symbolizer"
+)
+
+func stackSymbolsHelp() string {
+	return `Symbolize the stack addresses of the events (--output option:stack-addresses), adding their
+stack frames to the events: the function of every address, from the symbol tables of the binaries.
+For the binaries allowed to, the debugging information (DWARF) is used as well: the frames get their
+source file and line, and the functions inlined at an address get a frame of their own. Loading the
+debugging information is costly, hence the allowlist. The binaries are cached by build ID.
+The binary path patterns are matched against the paths in the processes mount namespaces, '*'
+not matching '/'.
+
+Possible options:
+  symbols                | symbolize with the symbol tables only.
+  dwarf=<pattern>        | also use the debugging information of the binaries matching the path pattern (can be repeated).
+  cache-size=<n>         | binaries kept loaded (default: 64).
+  none                   | don't symbolize the stack addresses (default).
+
+Examples:
+  --output option:stack-addresses --stack-symbols symbols                                   | functions of the stack addresses.
+  --output option:stack-addresses --stack-symbols dwarf=/usr/bin/* --stack-symbols dwarf=/app/*   | with inlined functions and lines.
+`
+}
+
+// PrepareStackSymbols returns the stack addresses symbolization configuration of the given options.
+func PrepareStackSymbols(stackSymbolsSlice []string) (symbolizer.Config, error) {
+	cfg := symbolizer.Config{
+		CacheSize: symbolizer.DefaultCacheSize,
+	}
+
+	for _, opt := range stackSymbolsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(stackSymbolsHelp())
+		}
+		if opt == "none" {
+			return symbolizer.Config{}, nil
+		}
+		if opt == "symbols" {
+			cfg.Enabled = true
+			continue
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid stack-symbols option: %s, use '--stack-symbols help' for more info", opt)
+		}
+
+		switch key {
+		case "dwarf":
+			if _, err := filepath.Match(value, ""); err != nil || !strings.HasPrefix(value, "/") {
+				return cfg, fmt.Errorf("invalid stack-symbols dwarf pattern: %s (absolute path pattern expected)", value)
+			}
+			cfg.DWARFBinaries = append(cfg.DWARFBinaries, value)
+		case "cache-size":
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return cfg, fmt.Errorf("invalid stack-symbols cache-size: %s", value)
+			}
+			cfg.CacheSize = size
+		default:
+			return cfg, fmt.Errorf("invalid stack-symbols option: %s, use '--stack-symbols help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	return cfg, nil
+}
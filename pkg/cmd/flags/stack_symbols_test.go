@@ -0,0 +1,85 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/symbolizer"
+)
+
+func TestPrepareStackSymbols(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName          string
+		stackSymbolsSlice []string
+		expectedConfig    symbolizer.Config
+		expectedError     string
+	}{
+		{
+			testName:          "none",
+			stackSymbolsSlice: []string{"none"},
+			expectedConfig:    symbolizer.Config{},
+		},
+		{
+			testName:          "symbols",
+			stackSymbolsSlice: []string{"symbols"},
+			expectedConfig: symbolizer.Config{
+				Enabled:   true,
+				CacheSize: symbolizer.DefaultCacheSize,
+			},
+		},
+		{
+			testName:          "dwarf allowlist",
+			stackSymbolsSlice: []string{"dwarf=/usr/bin/*", "dwarf=/app/server", "cache-size=16"},
+			expectedConfig: symbolizer.Config{
+				Enabled:       true,
+				DWARFBinaries: []string{"/usr/bin/*", "/app/server"},
+				CacheSize:     16,
+			},
+		},
+		{
+			testName:          "relative dwarf pattern",
+			stackSymbolsSlice: []string{"dwarf=bin/*"},
+			expectedError:     "invalid stack-symbols dwarf pattern: bin/* (absolute path pattern expected)",
+		},
+		{
+			testName:          "invalid dwarf pattern",
+			stackSymbolsSlice: []string{"dwarf=/usr/bin/["},
+			expectedError:     "invalid stack-symbols dwarf pattern: /usr/bin/[ (absolute path pattern expected)",
+		},
+		{
+			testName:          "invalid cache-size",
+			stackSymbolsSlice: []string{"cache-size=0"},
+			expectedError:     "invalid stack-symbols cache-size: 0",
+		},
+		{
+			testName:          "invalid option",
+			stackSymbolsSlice: []string{"foo"},
+			expectedError:     "invalid stack-symbols option: foo, use '--stack-symbols help' for more info",
+		},
+		{
+			testName:          "help",
+			stackSymbolsSlice: []string{"help"},
+			expectedError:     stackSymbolsHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareStackSymbols(tc.stackSymbolsSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
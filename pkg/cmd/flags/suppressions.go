@@ -0,0 +1,55 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/suppression"
+)
+
+func suppressionsHelp() string {
+	return `Mark the findings matching allowlist rules as suppressed. Suppressed findings aren't dropped:
+they are still output, with the "suppressed" and "suppressedBy" (rule name) metadata properties,
+and counted by the findings_suppressed stats.
+
+Possible options:
+  <file>                 | read the suppression rules of the given YAML file.
+  none                   | don't suppress findings (default).
+
+A finding is suppressed if it matches all the conditions of a rule:
+  signature              | the signature id (e.g. TRC-105).
+  image                  | the container image name.
+  binaryHash             | the sha256 of the process binary.
+  args                   | the finding arguments, or the arguments of the event triggering it.
+Image and argument values can be prefixed and/or suffixed with '*' to match a suffix, a prefix
+or a substring.
+
+Rules file example:
+  rules:
+    - name: package-manager
+      signature: TRC-1022
+      image: docker.io/library/debian*
+      args:
+        pathname: /usr/bin/dpkg*
+
+Example:
+  --suppressions /etc/tracee/suppressions.yaml  | suppress the findings matching the rules of the file.
+`
+}
+
+// PrepareSuppressions returns the suppression rules of the given files.
+func PrepareSuppressions(suppressionsSlice []string) ([]suppression.Rule, error) {
+	var files []string
+
+	for _, opt := range suppressionsSlice {
+		if strings.HasPrefix(opt, "help") {
+			return nil, fmt.Errorf(suppressionsHelp())
+		}
+		if opt == "none" {
+			return nil, nil
+		}
+		files = append(files, opt)
+	}
+
+	return suppression.RulesFromFiles(files)
+}
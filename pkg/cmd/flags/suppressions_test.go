@@ -0,0 +1,67 @@
+package flags
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/suppression"
+)
+
+func TestPrepareSuppressions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "suppressions.yaml")
+	require.NoError(t, os.WriteFile(rulesFile, []byte(`rules:
+  - name: package-manager
+    signature: TRC-1022
+`), 0o600))
+
+	testCases := []struct {
+		testName          string
+		suppressionsSlice []string
+		expectedRules     []suppression.Rule
+		expectedError     string
+	}{
+		{
+			testName:          "none",
+			suppressionsSlice: []string{"none"},
+			expectedRules:     nil,
+		},
+		{
+			testName:          "rules file",
+			suppressionsSlice: []string{rulesFile},
+			expectedRules:     []suppression.Rule{{Name: "package-manager", Signature: "TRC-1022"}},
+		},
+		{
+			testName:          "missing file",
+			suppressionsSlice: []string{filepath.Join(dir, "missing.yaml")},
+			expectedError:     "no such file or directory",
+		},
+		{
+			testName:          "help",
+			suppressionsSlice: []string{"help"},
+			expectedError:     suppressionsHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			rules, err := PrepareSuppressions(tc.suppressionsSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/threatintel"
+)
+
+func TestPrepareThreatIntel(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName         string
+		threatIntelSlice []string
+		expectedConfig   threatintel.Config
+		expectedError    string
+	}{
+		{
+			testName:         "none",
+			threatIntelSlice: []string{"none"},
+			expectedConfig:   threatintel.Config{},
+		},
+		{
+			testName:         "feeds",
+			threatIntelSlice: []string{"csv=/tmp/iocs.csv", "misp=/tmp/misp.json", "taxii=https://taxii.example.com/api/collections/1/objects/"},
+			expectedConfig: threatintel.Config{
+				Feeds: []threatintel.Feed{
+					{Type: threatintel.FeedCSV, Source: "/tmp/iocs.csv"},
+					{Type: threatintel.FeedMISP, Source: "/tmp/misp.json"},
+					{Type: threatintel.FeedTAXII, Source: "https://taxii.example.com/api/collections/1/objects/"},
+				},
+				Refresh: threatintel.DefaultRefresh,
+			},
+		},
+		{
+			testName:         "refresh",
+			threatIntelSlice: []string{"csv=/tmp/iocs.csv", "refresh=10m"},
+			expectedConfig: threatintel.Config{
+				Feeds:   []threatintel.Feed{{Type: threatintel.FeedCSV, Source: "/tmp/iocs.csv"}},
+				Refresh: 10 * time.Minute,
+			},
+		},
+		{
+			testName:         "invalid refresh",
+			threatIntelSlice: []string{"refresh=0s"},
+			expectedError:    "invalid threat-intel refresh interval: 0s",
+		},
+		{
+			testName:         "invalid taxii url",
+			threatIntelSlice: []string{"taxii=collections"},
+			expectedError:    "invalid threat-intel taxii url: collections",
+		},
+		{
+			testName:         "invalid option",
+			threatIntelSlice: []string{"stix=/tmp/bundle.json"},
+			expectedError:    "invalid threat-intel option: stix=/tmp/bundle.json",
+		},
+		{
+			testName:         "missing value",
+			threatIntelSlice: []string{"csv="},
+			expectedError:    "invalid threat-intel option: csv=",
+		},
+		{
+			testName:         "help",
+			threatIntelSlice: []string{"help"},
+			expectedError:    threatIntelHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareThreatIntel(tc.threatIntelSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+)
+
+func timelineHelp() string {
+	return `Record per-process timelines: the output events of every process, grouped by thread and ordered
+by time. A timeline is exported as a JSON or HTML report when its process exits (if a directory is
+given), or on demand, through the data http server (GET /timeline?pid=<host pid>&format=<json|html>),
+which listens on localhost only unless another --data-listen-addr is given.
+The sched_process_exit event must be selected (e.g. --events sched_process_exit) for the reports to be
+written on exit.
+
+Possible options:
+  on-demand              | record the timelines, exported on demand only.
+  dir=<path>             | also write the report of every exited process to the given directory.
+  format=<json|html>     | format of the reports written on exit (default: json).
+  max-events=<n>         | most recent events kept per process (default: 1000).
+  none                   | don't record timelines (default).
+
+Examples:
+  --timeline on-demand                                              | export the timelines through the data http server.
+  --timeline dir=/var/log/tracee/timelines --timeline format=html   | also write a HTML report of every exited process.
+
+Query example (timeline of the process 1234, as HTML):
+  curl 'http://localhost:3367/timeline?pid=1234&format=html'
+`
+}
+
+// PrepareTimeline returns the process timelines configuration of the given options.
+func PrepareTimeline(timelineSlice []string) (timeline.Config, error) {
+	cfg := timeline.Config{
+		Format:    timeline.DefaultFormat,
+		MaxEvents: timeline.DefaultMaxEvents,
+	}
+
+	for _, opt := range timelineSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(timelineHelp())
+		}
+		if opt == "none" {
+			return timeline.Config{}, nil
+		}
+		if opt == "on-demand" {
+			cfg.Enabled = true
+			continue
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid timeline option: %s, use '--timeline help' for more info", opt)
+		}
+
+		switch key {
+		case "dir":
+			cfg.Dir = value
+		case "format":
+			if value != timeline.FormatJSON && value != timeline.FormatHTML {
+				return cfg, fmt.Errorf("invalid timeline format: %s (json or html)", value)
+			}
+			cfg.Format = value
+		case "max-events":
+			maxEvents, err := strconv.Atoi(value)
+			if err != nil || maxEvents <= 0 {
+				return cfg, fmt.Errorf("invalid timeline max-events: %s", value)
+			}
+			cfg.MaxEvents = maxEvents
+		default:
+			return cfg, fmt.Errorf("invalid timeline option: %s, use '--timeline help' for more info", opt)
+		}
+
+		cfg.Enabled = true
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,82 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+)
+
+func TestPrepareTimeline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		timelineSlice  []string
+		expectedConfig timeline.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			timelineSlice:  []string{"none"},
+			expectedConfig: timeline.Config{},
+		},
+		{
+			testName:      "on demand",
+			timelineSlice: []string{"on-demand"},
+			expectedConfig: timeline.Config{
+				Enabled:   true,
+				Format:    timeline.FormatJSON,
+				MaxEvents: timeline.DefaultMaxEvents,
+			},
+		},
+		{
+			testName:      "reports on exit",
+			timelineSlice: []string{"dir=/tmp/timelines", "format=html", "max-events=50"},
+			expectedConfig: timeline.Config{
+				Enabled:   true,
+				Dir:       "/tmp/timelines",
+				Format:    timeline.FormatHTML,
+				MaxEvents: 50,
+			},
+		},
+		{
+			testName:      "invalid format",
+			timelineSlice: []string{"format=pdf"},
+			expectedError: "invalid timeline format: pdf (json or html)",
+		},
+		{
+			testName:      "invalid max-events",
+			timelineSlice: []string{"max-events=0"},
+			expectedError: "invalid timeline max-events: 0",
+		},
+		{
+			testName:      "invalid option",
+			timelineSlice: []string{"pid=1"},
+			expectedError: "invalid timeline option: pid=1",
+		},
+		{
+			testName:      "help",
+			timelineSlice: []string{"help"},
+			expectedError: timelineHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareTimeline(tc.timelineSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
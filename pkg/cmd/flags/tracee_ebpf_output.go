@@ -14,18 +14,27 @@ Possible options:
 [format:]table                                     output events in table format (default)
 [format:]table-verbose                             output events in table format with extra fields per event
 [format:]json                                      output events in json format
+[format:]json-v2                                   output events in json format, with snake_case field names and RFC3339 times (v2 schema)
 [format:]gotemplate=/path/to/template              output events formatted using a given gotemplate file
 out-file:/path/to/file                             write the output to a specified file. create/trim the file if exists (default: stdout)
 none                                               ignore stream of events output, usually used with --capture
 option:{stack-addresses,exec-env,relative-time,exec-hash,parse-arguments,sort-events}
                                                    augment output according to given options (default: none)
   stack-addresses                                  include stack memory addresses for each event
+  stack-addresses=<event>                          include stack memory addresses for the given event only (repeatable)
   exec-env                                         when tracing execve/execveat, show the environment variables that were used for execution
   relative-time                                    use relative timestamp instead of wall timestamp for events
+  time-format=<nanos|rfc3339>                      add the RFC3339 time of the events (time), along with their timestamp in nanoseconds since epoch (default: nanos)
+  time-zone=<zone>                                 time zone of the printed times, e.g. UTC or Europe/Paris (default: local time zone)
   exec-hash                                        when tracing sched_process_exec, show the file hash(sha256) and ctime
+  exec-build-id                                    when tracing sched_process_exec, show the file build id and go build information
+  canonical-paths                                  resolve the relative and symbolic link paths of the syscalls path arguments to canonical absolute paths
+  container-paths                                  add the host paths of the path arguments of the containers events (e.g. host_pathname), under the container rootfs
   parse-arguments                                  do not show raw machine-readable values for event arguments, instead parse into human readable strings
-  parse-arguments-fds                              enable parse-arguments and enrich fd with its file path translation. This can cause pipeline slowdowns.
+  parse-arguments-fds                              enable parse-arguments and enrich fds with their file path, socket or pipe translation. This can cause pipeline slowdowns.
   sort-events                                      enable sorting events before passing to them output. This will decrease the overall program efficiency.
+  bytes=<base64|hex|preview>                       render the bytes arguments as base64, hex or a printable preview (escaping the other bytes)
+  bytes.<kind>=<base64|hex|preview>                render the bytes arguments of the outputs of the given kind (e.g. bytes.table=preview)
 Examples:
   --output json                                            | output as json to stdout
   --output gotemplate=/path/to/my.tmpl                     | output as the provided go template
@@ -71,6 +80,10 @@ func TraceeEbpfPrepareOutput(outputSlice []string, newBinary bool) (PrepareOutpu
 		}
 	}
 
+	if traceeConfig.TimeRFC3339 && traceeConfig.RelativeTime {
+		return outConfig, errfmt.Errorf("time-format=rfc3339 output option requires wall times, it can't be used with relative-time")
+	}
+
 	printerConfigs := make([]config.PrinterConfig, 0)
 
 	if printerKind == "table" {
@@ -81,9 +94,11 @@ func TraceeEbpfPrepareOutput(outputSlice []string, newBinary bool) (PrepareOutpu
 
 	if outPath == "" {
 		stdoutConfig := config.PrinterConfig{
-			Kind:       printerKind,
-			OutFile:    os.Stdout,
-			RelativeTS: traceeConfig.RelativeTime,
+			Kind:        printerKind,
+			OutFile:     os.Stdout,
+			RelativeTS:  traceeConfig.RelativeTime,
+			TimeZone:    traceeConfig.TimeZone,
+			BytesFormat: bytesFormat(traceeConfig, printerKind),
 		}
 
 		printerConfigs = append(printerConfigs, stdoutConfig)
@@ -94,10 +109,12 @@ func TraceeEbpfPrepareOutput(outputSlice []string, newBinary bool) (PrepareOutpu
 		}
 
 		printerConfig := config.PrinterConfig{
-			Kind:       printerKind,
-			OutPath:    outPath,
-			OutFile:    file,
-			RelativeTS: traceeConfig.RelativeTime,
+			Kind:        printerKind,
+			OutPath:     outPath,
+			OutFile:     file,
+			RelativeTS:  traceeConfig.RelativeTime,
+			TimeZone:    traceeConfig.TimeZone,
+			BytesFormat: bytesFormat(traceeConfig, printerKind),
 		}
 
 		printerConfigs = append(printerConfigs, printerConfig)
@@ -113,8 +130,9 @@ func validateFormat(printerKind string) error {
 	if printerKind != "table" &&
 		printerKind != "table-verbose" &&
 		printerKind != "json" &&
+		printerKind != "json-v2" &&
 		!strings.HasPrefix(printerKind, "gotemplate=") {
-		return errfmt.Errorf("unrecognized output format: %s. Valid format values: 'table', 'table-verbose', 'json', or 'gotemplate='. Use '--output help' for more info", printerKind)
+		return errfmt.Errorf("unrecognized output format: %s. Valid format values: 'table', 'table-verbose', 'json', 'json-v2', or 'gotemplate='. Use '--output help' for more info", printerKind)
 	}
 
 	return nil
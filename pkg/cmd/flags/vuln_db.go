@@ -0,0 +1,60 @@
+package flags
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+)
+
+func vulnDBHelp() string {
+	return `Match the loaded libraries against a local vulnerability database snapshot, emitting a
+vulnerable_library_loaded event when a shared object with known vulnerabilities is loaded
+(shared_object_loaded). The library name and version are taken from the shared object file name
+(libname.so.<version> or libname-<version>.so).
+The vulnerable_library_loaded event must be selected (e.g. --events vulnerable_library_loaded) for the
+matches to be emitted.
+
+A snapshot is a JSON file holding a list of vulnerabilities:
+  {"vulnerabilities": [
+    {"id": "CVE-2022-0778", "library": "libcrypto", "affected": [">=1.0.2 <1.1.1n"], "severity": "high"}
+  ]}
+The affected versions are ranges of space separated constraints (<, <=, >, >=, =), any of which
+matches. A vulnerability without affected versions matches every version of the library.
+
+Possible options:
+  file=<path>            | load a vulnerability database snapshot file (can be repeated).
+  none                   | don't load a vulnerability database (default).
+
+Examples:
+  --events vulnerable_library_loaded --vuln-db file=/var/lib/tracee/vulndb.json
+`
+}
+
+// PrepareVulnDB returns the vulnerability database configuration of the given options.
+func PrepareVulnDB(vulnDBSlice []string) (vulndb.Config, error) {
+	var cfg vulndb.Config
+
+	for _, opt := range vulnDBSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(vulnDBHelp())
+		}
+		if opt == "none" {
+			return vulndb.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid vuln-db option: %s, use '--vuln-db help' for more info", opt)
+		}
+
+		switch key {
+		case "file":
+			cfg.Files = append(cfg.Files, value)
+		default:
+			return cfg, fmt.Errorf("invalid vuln-db option: %s, use '--vuln-db help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
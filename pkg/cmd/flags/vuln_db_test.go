@@ -0,0 +1,63 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+)
+
+func TestPrepareVulnDB(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		vulnDBSlice    []string
+		expectedConfig vulndb.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			vulnDBSlice:    []string{"none"},
+			expectedConfig: vulndb.Config{},
+		},
+		{
+			testName:       "files",
+			vulnDBSlice:    []string{"file=/tmp/os.json", "file=/tmp/extra.json"},
+			expectedConfig: vulndb.Config{Files: []string{"/tmp/os.json", "/tmp/extra.json"}},
+		},
+		{
+			testName:      "empty file",
+			vulnDBSlice:   []string{"file="},
+			expectedError: "invalid vuln-db option: file=",
+		},
+		{
+			testName:      "unknown option",
+			vulnDBSlice:   []string{"url=https://example.com/db.json"},
+			expectedError: "invalid vuln-db option: url=https://example.com/db.json",
+		},
+		{
+			testName:      "help",
+			vulnDBSlice:   []string{"help"},
+			expectedError: vulnDBHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareVulnDB(tc.vulnDBSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package flags
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/yara"
+)
+
+func yaraHelp() string {
+	return `Scan the captured artifacts (files, memory dumps, kernel modules and bpf objects, see --capture)
+with YARA rules, emitting a yara_match event per matching rule.
+The yara_match event must be selected (e.g. --events yara_match) for the matches to be emitted.
+The rules are compiled without libyara: only a subset of the YARA language is supported, without modules
+(import), includes, external variables, the xor and base64 modifiers, for loops and arithmetic operators
+(see the yara flag manual for the full list). Rules using them fail to compile.
+
+Possible options:
+  rules=<path>           | load the rules of a file, or of the .yar and .yara files of a directory.
+  rules=oci://<ref>      | load the rules bundled as an OCI artifact, e.g. oci://ghcr.io/org/rules:v1
+                           (see --oci).
+  settle=<duration>      | time an artifact must be left unmodified before it is scanned (default: 2s).
+  max-size=<size>        | maximum size of the scanned artifacts, in megabytes (default: 64).
+  none                   | don't scan the captured artifacts (default).
+
+Examples:
+  --yara rules=/etc/tracee/yara --capture exec                             | scan the executed binaries.
+  --yara rules=oci://ghcr.io/org/rules:v1 --capture mem --yara max-size=16 | scan the memory dumps up to 16MB.
+`
+}
+
+// PrepareYara returns the YARA scanning configuration of the given options.
+func PrepareYara(yaraSlice []string) (yara.Config, error) {
+	cfg := yara.Config{Settle: yara.DefaultSettle, MaxSize: yara.DefaultMaxSize}
+
+	for _, opt := range yaraSlice {
+		if strings.HasPrefix(opt, "help") {
+			return cfg, fmt.Errorf(yaraHelp())
+		}
+		if opt == "none" {
+			return yara.Config{}, nil
+		}
+
+		key, value, found := strings.Cut(opt, "=")
+		if !found || value == "" {
+			return cfg, fmt.Errorf("invalid yara option: %s, use '--yara help' for more info", opt)
+		}
+
+		switch key {
+		case "rules":
+			cfg.Rules = append(cfg.Rules, value)
+		case "settle":
+			settle, err := time.ParseDuration(value)
+			if err != nil || settle <= 0 {
+				return cfg, fmt.Errorf("invalid yara settle time: %s", value)
+			}
+			cfg.Settle = settle
+		case "max-size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size <= 0 {
+				return cfg, fmt.Errorf("invalid yara max size: %s", value)
+			}
+			cfg.MaxSize = size * 1024 * 1024 // MB to bytes
+		default:
+			return cfg, fmt.Errorf("invalid yara option: %s, use '--yara help' for more info", opt)
+		}
+	}
+
+	return cfg, nil
+}
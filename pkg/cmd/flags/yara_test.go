@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/yara"
+)
+
+func TestPrepareYara(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		testName       string
+		yaraSlice      []string
+		expectedConfig yara.Config
+		expectedError  string
+	}{
+		{
+			testName:       "none",
+			yaraSlice:      []string{"none"},
+			expectedConfig: yara.Config{},
+		},
+		{
+			testName:  "rules",
+			yaraSlice: []string{"rules=/etc/tracee/yara", "rules=oci://ghcr.io/org/rules:v1"},
+			expectedConfig: yara.Config{
+				Rules:   []string{"/etc/tracee/yara", "oci://ghcr.io/org/rules:v1"},
+				Settle:  yara.DefaultSettle,
+				MaxSize: yara.DefaultMaxSize,
+			},
+		},
+		{
+			testName:  "settle and max size",
+			yaraSlice: []string{"rules=/tmp/rules.yar", "settle=500ms", "max-size=16"},
+			expectedConfig: yara.Config{
+				Rules:   []string{"/tmp/rules.yar"},
+				Settle:  500 * time.Millisecond,
+				MaxSize: 16 * 1024 * 1024,
+			},
+		},
+		{
+			testName:      "invalid settle",
+			yaraSlice:     []string{"settle=soon"},
+			expectedError: "invalid yara settle time: soon",
+		},
+		{
+			testName:      "invalid max size",
+			yaraSlice:     []string{"max-size=0"},
+			expectedError: "invalid yara max size: 0",
+		},
+		{
+			testName:      "invalid option",
+			yaraSlice:     []string{"/tmp/rules.yar"},
+			expectedError: "invalid yara option: /tmp/rules.yar",
+		},
+		{
+			testName:      "missing value",
+			yaraSlice:     []string{"rules="},
+			expectedError: "invalid yara option: rules=",
+		},
+		{
+			testName:      "help",
+			yaraSlice:     []string{"help"},
+			expectedError: yaraHelp(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := PrepareYara(tc.yaraSlice)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedConfig, cfg)
+		})
+	}
+}
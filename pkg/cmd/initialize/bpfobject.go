@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/libbpfgo/helpers"
 
 	embed "github.com/aquasecurity/tracee"
+	"github.com/aquasecurity/tracee/pkg/btfhub"
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/logger"
@@ -20,7 +21,8 @@ import (
 // helpers.KernelConfig, and helpers.OSInfo structures, as well as an
 // installation path and a version string. The function unpacks the CO-RE eBPF
 // object binary, checks if BTF is enabled, unpacks the BTF file from BTF Hub if
-// necessary, and assigns the kernel configuration and BPF object bytes.
+// necessary (or else fetches it from the configured BTFHub archives and mirrors),
+// and assigns the kernel configuration and BPF object bytes.
 func BpfObject(cfg *config.Config, kConfig *helpers.KernelConfig, osInfo *helpers.OSInfo, installPath string, version string) error {
 	btfFilePath, err := checkEnvPath("TRACEE_BTF_FILE")
 	if btfFilePath == "" && err != nil {
@@ -47,6 +49,7 @@ func BpfObject(cfg *config.Config, kConfig *helpers.KernelConfig, osInfo *helper
 			cfg.BTFObjPath = unpackBTFFile
 		} else {
 			logger.Debugw("BTF: error unpacking embedded BTFHUB file", "error", err)
+			fetchBTFHub(cfg, unpackBTFFile, osInfo)
 		}
 	}
 
@@ -56,6 +59,29 @@ func BpfObject(cfg *config.Config, kConfig *helpers.KernelConfig, osInfo *helper
 	return nil
 }
 
+// fetchBTFHub fetches the BTF of the running kernel from the configured BTFHub archives and
+// mirrors, if any, and saves it to the specified output file path.
+func fetchBTFHub(cfg *config.Config, outFilePath string, osInfo *helpers.OSInfo) {
+	if !cfg.BTFHub.Enabled() {
+		return
+	}
+
+	kernel := btfhub.Kernel{
+		OSID:      osInfo.GetOSReleaseFieldValue(helpers.OS_ID),
+		OSVersion: strings.Replace(osInfo.GetOSReleaseFieldValue(helpers.OS_VERSION_ID), "\"", "", -1),
+		Arch:      osInfo.GetOSReleaseFieldValue(helpers.OS_ARCH),
+		Release:   osInfo.GetOSReleaseFieldValue(helpers.OS_KERNEL_RELEASE),
+	}
+	source, err := btfhub.Fetch(cfg.BTFHub, kernel, outFilePath)
+	if err != nil {
+		logger.Warnw("BTF: kernel BTF not found in the BTFHub archives and mirrors", "error", err)
+		return
+	}
+
+	logger.Infow("BTF: fetched kernel BTF from BTFHub", "source", source, "file", outFilePath)
+	cfg.BTFObjPath = outFilePath
+}
+
 func checkEnvPath(env string) (string, error) {
 	filePath, _ := os.LookupEnv(env)
 	if filePath != "" {
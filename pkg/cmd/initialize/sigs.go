@@ -130,3 +130,18 @@ func allocateEventIdsForSigs(startId events.ID, sigs []detect.Signature) map[str
 	}
 	return namesToIds
 }
+
+// SignaturesEvents returns the names of the events of the given signatures. Selecting them
+// enables the events the signatures require (as their dependencies), and only those.
+func SignaturesEvents(sigs []detect.Signature) []string {
+	names := make([]string, 0, len(sigs))
+	for _, s := range sigs {
+		m, err := s.GetMetadata()
+		if err != nil {
+			continue
+		}
+		names = append(names, m.EventName)
+	}
+
+	return names
+}
@@ -204,3 +204,14 @@ func newFakeSignature(name string, deps []string) detect.Signature {
 		},
 	}
 }
+
+func Test_SignaturesEvents(t *testing.T) {
+	t.Parallel()
+
+	sigs := []detect.Signature{
+		newFakeSignature("fake_event_0", []string{"hooked_syscall"}),
+		newFakeSignature("fake_event_1", []string{"sched_process_exec", "fake_event_0"}),
+	}
+
+	assert.Equal(t, []string{"fake_event_0", "fake_event_1"}, SignaturesEvents(sigs))
+}
@@ -0,0 +1,331 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultAMQPExchange      = "amq.topic"
+	defaultAMQPRoutingKey    = "tracee.{{ .EventName }}"
+	defaultAMQPMaxInFlight   = 256
+	defaultAMQPFlushInterval = time.Second
+)
+
+// amqpPublishing is a message published to an exchange, waiting for its confirmation.
+type amqpPublishing struct {
+	exchange   string
+	routingKey string
+	msg        amqp.Publishing
+	confirm    *amqp.DeferredConfirmation
+}
+
+// amqpEventPrinter publishes the events to an AMQP 0.9.1 broker (e.g. RabbitMQ), to an exchange
+// and with a routing key rendered from the event. With publisher confirms, the messages
+// nacked by the broker (or unconfirmed, the connection failing) are published again once.
+type amqpEventPrinter struct {
+	outPath       string
+	url           string // without the parameters of the sink
+	exchange      *template.Template
+	routingKey    *template.Template
+	templateObj   *template.Template
+	contentType   string
+	persistent    bool
+	confirm       bool
+	maxInFlight   int
+	flushInterval time.Duration
+	timeout       time.Duration
+
+	mu      sync.Mutex
+	conn    *amqp.Connection // nil until connected (connects again on errors)
+	channel *amqp.Channel
+	pending []*amqpPublishing // waiting for their confirmation
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Init parses the url: {amqp,amqps}://[user:password@]host[:port][/vhost]. The exchange and
+// routingKey parameters are Go templates of the events, and the gotemplate, contentType,
+// persistent, confirm, maxInFlight, flushInterval and timeout parameters configure the
+// printer.
+func (p *amqpEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+	if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		return errfmt.Errorf("unsupported protocol for AMQP destination: %s", u.Scheme)
+	}
+	parameters := u.Query()
+
+	p.exchange, err = parseParameterTemplate("exchange", getParameterValue(parameters, "exchange", defaultAMQPExchange))
+	if err != nil {
+		return err
+	}
+	p.routingKey, err = parseParameterTemplate("routingKey", getParameterValue(parameters, "routingKey", defaultAMQPRoutingKey))
+	if err != nil {
+		return err
+	}
+
+	gotemplate := getParameterValue(parameters, "gotemplate", "")
+	if gotemplate != "" {
+		p.templateObj, err = parseTemplate(gotemplate)
+		if err != nil {
+			return err
+		}
+	}
+	p.contentType = getParameterValue(parameters, "contentType", "application/json")
+
+	persistent := getParameterValue(parameters, "persistent", "true")
+	p.persistent, err = strconv.ParseBool(persistent)
+	if err != nil {
+		return errfmt.Errorf("invalid persistent value %q", persistent)
+	}
+
+	confirm := getParameterValue(parameters, "confirm", "true")
+	p.confirm, err = strconv.ParseBool(confirm)
+	if err != nil {
+		return errfmt.Errorf("invalid confirm value %q", confirm)
+	}
+
+	maxInFlight := getParameterValue(parameters, "maxInFlight", strconv.Itoa(defaultAMQPMaxInFlight))
+	p.maxInFlight, err = strconv.Atoi(maxInFlight)
+	if err != nil || p.maxInFlight < 1 {
+		return errfmt.Errorf("invalid maxInFlight value %q", maxInFlight)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultAMQPFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	// the parameters of the sink aren't parameters of the connection (e.g. heartbeat)
+	for _, key := range []string{"exchange", "routingKey", "gotemplate", "contentType", "persistent", "confirm", "maxInFlight", "flushInterval", "timeout"} {
+		parameters.Del(key)
+	}
+	u.RawQuery = parameters.Encode()
+	p.url = u.String()
+
+	logger.Infow("Attempting to connect to AMQP destination", "address", u.Host)
+	if err := p.connect(); err != nil {
+		// The destination may not be available but may appear later so do not return an error here and just connect later.
+		logger.Errorw("Error connecting to AMQP destination", "address", u.Host, "error", err)
+	}
+
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.mu.Lock()
+				p.waitConfirms()
+				p.mu.Unlock()
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// connect opens a channel, in confirm mode, connecting again if the connection was closed.
+func (p *amqpEventPrinter) connect() error {
+	if p.conn == nil || p.conn.IsClosed() {
+		conn, err := amqp.DialConfig(p.url, amqp.Config{
+			Heartbeat:  10 * time.Second,
+			Locale:     "en_US",
+			Properties: amqp.Table{"connection_name": "tracee"},
+			Dial:       amqp.DefaultDial(p.timeout),
+		})
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		p.conn = conn
+	}
+
+	channel, err := p.conn.Channel()
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if p.confirm {
+		if err := channel.Confirm(false); err != nil {
+			_ = channel.Close()
+			return errfmt.WrapError(err)
+		}
+	}
+	p.channel = channel
+
+	return nil
+}
+
+func (p *amqpEventPrinter) Preamble() {}
+
+func (p *amqpEventPrinter) Print(event trace.Event) {
+	publishing, err := p.publishing(&event)
+	if err != nil {
+		logger.Errorw("Error encoding event to AMQP", "error", err)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.publish(publishing); err != nil {
+		logger.Errorw("Error publishing event to AMQP destination", "error", err)
+		return
+	}
+	if p.confirm {
+		p.pending = append(p.pending, publishing)
+		if len(p.pending) >= p.maxInFlight {
+			p.waitConfirms()
+		}
+	}
+}
+
+// publishing renders the exchange, the routing key and the body of an event.
+func (p *amqpEventPrinter) publishing(event *trace.Event) (*amqpPublishing, error) {
+	var exchange, routingKey bytes.Buffer
+	if err := p.exchange.Execute(&exchange, event); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if err := p.routingKey.Execute(&routingKey, event); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	var body []byte
+	if p.templateObj != nil {
+		buf := bytes.Buffer{}
+		if err := p.templateObj.Execute(&buf, event); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		body = buf.Bytes()
+	} else {
+		var err error
+		body, err = json.Marshal(event)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+
+	msg := amqp.Publishing{
+		ContentType: p.contentType,
+		Type:        event.EventName,
+		AppId:       "tracee",
+		Body:        body,
+	}
+	if p.persistent {
+		msg.DeliveryMode = amqp.Persistent
+	}
+
+	return &amqpPublishing{
+		exchange:   strings.TrimSpace(exchange.String()),
+		routingKey: strings.TrimSpace(routingKey.String()),
+		msg:        msg,
+	}, nil
+}
+
+// publish publishes a message, connecting again (once) if the channel was closed (e.g. the
+// exchange doesn't exist, or the connection failed). It is called with the lock held.
+func (p *amqpEventPrinter) publish(publishing *amqpPublishing) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if p.channel == nil || p.channel.IsClosed() {
+			if err = p.connect(); err != nil {
+				continue
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		publishing.confirm, err = p.channel.PublishWithDeferredConfirmWithContext(ctx, publishing.exchange, publishing.routingKey, false, false, publishing.msg)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		_ = p.channel.Close()
+		p.channel = nil
+	}
+
+	return errfmt.WrapError(err)
+}
+
+// waitConfirms waits for the confirmations of the pending messages, and publishes the nacked
+// (or unconfirmed) ones again, once. It is called with the lock held.
+func (p *amqpEventPrinter) waitConfirms() {
+	if len(p.pending) == 0 {
+		return
+	}
+
+	nacked := p.nacked(p.pending)
+	p.pending = p.pending[:0]
+	if len(nacked) == 0 {
+		return
+	}
+
+	republished := make([]*amqpPublishing, 0, len(nacked))
+	for _, publishing := range nacked {
+		if err := p.publish(publishing); err == nil {
+			republished = append(republished, publishing)
+		}
+	}
+	if lost := len(nacked) - len(republished) + len(p.nacked(republished)); lost > 0 {
+		logger.Errorw("Events not confirmed by AMQP destination", "events", lost)
+	}
+}
+
+// nacked waits for the confirmations of messages, and returns the messages nacked, or not
+// confirmed before the timeout.
+func (p *amqpEventPrinter) nacked(publishings []*amqpPublishing) []*amqpPublishing {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	var nacked []*amqpPublishing
+	for _, publishing := range publishings {
+		if ack, err := publishing.confirm.WaitContext(ctx); err != nil || !ack {
+			nacked = append(nacked, publishing)
+		}
+	}
+
+	return nacked
+}
+
+func (p *amqpEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *amqpEventPrinter) Close() {
+	close(p.done)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.waitConfirms()
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+}
@@ -0,0 +1,181 @@
+package printer_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// amqpMessage is a message published to the fake broker.
+type amqpMessage struct {
+	exchange, routingKey, body string
+}
+
+// fakeAMQPBroker is an AMQP 0.9.1 broker of the methods used by the publishers, confirming the
+// messages. It nacks the first message of the nack routing key.
+type fakeAMQPBroker struct {
+	t        *testing.T
+	listener net.Listener
+	nack     string
+
+	mu       sync.Mutex
+	messages []amqpMessage
+}
+
+func newFakeAMQPBroker(t *testing.T, nack string) *fakeAMQPBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	b := &fakeAMQPBroker{t: t, listener: listener, nack: nack}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return b
+}
+
+func (b *fakeAMQPBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return
+	}
+	assert.Equal(b.t, "AMQP\x00\x00\x09\x01", string(header))
+
+	// connection.start: version 0-9, no server properties, PLAIN mechanism and en_US locale
+	b.method(conn, 0, 10, 10, 0, 9, 0, 0, 0, 0, 0, 0, 0, 5, 'P', 'L', 'A', 'I', 'N', 0, 0, 0, 5, 'e', 'n', '_', 'U', 'S')
+
+	var published amqpMessage
+	var size uint64
+	tag := uint64(0)
+	for {
+		typ, channel, payload, err := b.frame(r)
+		if err != nil {
+			return
+		}
+
+		switch typ {
+		case 1: // method
+			class, method := binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:])
+			switch {
+			case class == 10 && method == 11: // connection.start-ok: tune, 128KB frames, no heartbeat
+				b.method(conn, 0, 10, 30, 0, 0, 0, 2, 0, 0, 0, 0)
+			case class == 10 && method == 40: // connection.open
+				b.method(conn, 0, 10, 41, 0)
+			case class == 10 && method == 50: // connection.close
+				b.method(conn, 0, 10, 51)
+				return
+			case class == 20 && method == 10: // channel.open
+				b.method(conn, channel, 20, 11, 0, 0, 0, 0)
+			case class == 20 && method == 40: // channel.close
+				b.method(conn, channel, 20, 41)
+			case class == 85 && method == 10: // confirm.select
+				tag = 0
+				b.method(conn, channel, 85, 11)
+			case class == 60 && method == 40: // basic.publish
+				exchange := payload[7 : 7+payload[6]]
+				key := payload[8+len(exchange) : 8+len(exchange)+int(payload[7+len(exchange)])]
+				published = amqpMessage{exchange: string(exchange), routingKey: string(key)}
+			}
+		case 2: // content header
+			size = binary.BigEndian.Uint64(payload[4:])
+		case 3: // content body
+			published.body += string(payload)
+			if uint64(len(published.body)) < size {
+				continue
+			}
+
+			tag++
+			args := binary.BigEndian.AppendUint64(nil, tag)
+			b.mu.Lock()
+			b.messages = append(b.messages, published)
+			if published.routingKey == b.nack {
+				b.nack = ""
+				b.method(conn, channel, 60, 120, append(args, 0)...) // basic.nack
+			} else {
+				b.method(conn, channel, 60, 80, append(args, 0)...) // basic.ack
+			}
+			b.mu.Unlock()
+		}
+	}
+}
+
+func (b *fakeAMQPBroker) frame(r *bufio.Reader) (byte, uint16, []byte, error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, 0, nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1) // with the frame end
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	require.Equal(b.t, byte(0xce), payload[len(payload)-1])
+
+	return header[0], binary.BigEndian.Uint16(header[1:]), payload[:len(payload)-1], nil
+}
+
+func (b *fakeAMQPBroker) method(conn net.Conn, channel, class, method uint16, args ...byte) {
+	payload := binary.BigEndian.AppendUint16(nil, class)
+	payload = binary.BigEndian.AppendUint16(payload, method)
+	payload = append(payload, args...)
+
+	frame := []byte{1}
+	frame = binary.BigEndian.AppendUint16(frame, channel)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(payload)))
+	frame = append(frame, payload...)
+	_, err := conn.Write(append(frame, 0xce))
+	require.NoError(b.t, err)
+}
+
+func TestAMQPPrinter(t *testing.T) {
+	t.Parallel()
+
+	broker := newFakeAMQPBroker(t, "events.security_file_open")
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "amqp",
+		OutPath: "amqp://" + broker.listener.Addr().String() + "?routingKey={{.EventName",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, "invalid routingKey template")
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "amqp",
+		OutPath: "amqp://guest:guest@" + broker.listener.Addr().String() + "/?exchange=tracee&routingKey=events.{{.EventName}}&maxInFlight=2&flushInterval=1h",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{EventName: "security_file_open", ProcessName: "cat"}) // nacked, then published again
+	p.Print(trace.Event{EventName: "sched_process_exec", ProcessName: "ls"})
+	p.Print(trace.Event{EventName: "sched_process_exit", ProcessName: "ls"}) // confirmed on close
+	p.Close()
+
+	broker.mu.Lock()
+	defer broker.mu.Unlock()
+	require.Len(t, broker.messages, 4)
+	for i, name := range []string{"security_file_open", "sched_process_exec", "security_file_open", "sched_process_exit"} {
+		assert.Equal(t, "tracee", broker.messages[i].exchange)
+		assert.Equal(t, "events."+name, broker.messages[i].routingKey)
+		assert.Contains(t, broker.messages[i].body, `"eventName":"`+name+`"`)
+	}
+}
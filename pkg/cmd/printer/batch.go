@@ -0,0 +1,77 @@
+package printer
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+)
+
+const defaultBatchFlushInterval = time.Second
+
+// batchParameters are the parameters of the batching of a network sink, not sent to its
+// destination.
+var batchParameters = []string{"batchSize", "batchBytes", "flushInterval", "queueSize", "compression", "framing"}
+
+// parseBatchParameters parses the batching parameters of a network sink: the batchSize (events,
+// 1 by default) and batchBytes (0, unlimited by default) thresholds of a batch, the
+// flushInterval (1s by default) a batch is sent after, the queueSize (batches waiting to be
+// sent, beyond which they are dropped), the compression of the batches (none or
+// zstd) and the framing of their events (length or lines).
+func parseBatchParameters(parameters url.Values) (batcher.Config, error) {
+	var cfg batcher.Config
+	var err error
+
+	batchSize := getParameterValue(parameters, "batchSize", "1")
+	cfg.MaxEvents, err = strconv.Atoi(batchSize)
+	if err != nil || cfg.MaxEvents < 1 {
+		return cfg, errfmt.Errorf("invalid batchSize value %q", batchSize)
+	}
+
+	batchBytes := getParameterValue(parameters, "batchBytes", "0")
+	cfg.MaxBytes, err = strconv.Atoi(batchBytes)
+	if err != nil || cfg.MaxBytes < 0 {
+		return cfg, errfmt.Errorf("invalid batchBytes value %q", batchBytes)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultBatchFlushInterval.String())
+	cfg.Interval, err = time.ParseDuration(flushInterval)
+	if err != nil || cfg.Interval <= 0 {
+		return cfg, errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	cfg.QueueSize, err = parseQueueSize(parameters)
+	if err != nil {
+		return cfg, err
+	}
+
+	cfg.Compression = getParameterValue(parameters, "compression", batcher.CompressionNone)
+	switch cfg.Compression {
+	case batcher.CompressionNone, batcher.CompressionZstd:
+	default:
+		return cfg, errfmt.Errorf("unsupported compression %q (valid options are: zstd)", cfg.Compression)
+	}
+
+	cfg.Framing = getParameterValue(parameters, "framing", batcher.FramingLength)
+	switch cfg.Framing {
+	case batcher.FramingLength, batcher.FramingLines:
+	default:
+		return cfg, errfmt.Errorf("unsupported framing %q (valid options are: length,lines)", cfg.Framing)
+	}
+
+	return cfg, nil
+}
+
+// parseQueueSize parses the queueSize parameter of a network sink: the number of batches
+// waiting to be sent, beyond which the batches are dropped.
+func parseQueueSize(parameters url.Values) (int, error) {
+	queueSize := getParameterValue(parameters, "queueSize", strconv.Itoa(batcher.DefaultQueueSize))
+	size, err := strconv.Atoi(queueSize)
+	if err != nil || size < 1 {
+		return 0, errfmt.Errorf("invalid queueSize value %q", queueSize)
+	}
+
+	return size, nil
+}
@@ -0,0 +1,94 @@
+package printer_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestWebhookPrinterBatch(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		query  string
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{r.URL.RawQuery, r.Header, body}
+	}))
+	defer server.Close()
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "webhook",
+		OutPath: server.URL + "?batchSize=10&compression=gzip",
+		OutFile: os.Stdout,
+	})
+	assert.ErrorContains(t, err, `unsupported compression "gzip" (valid options are: zstd)`)
+
+	testCases := []struct {
+		name        string
+		parameters  string
+		compression string
+		framing     string
+		contentType string
+	}{
+		{
+			name:        "zstd length prefixed",
+			parameters:  "batchSize=2&compression=zstd",
+			compression: batcher.CompressionZstd,
+			framing:     batcher.FramingLength,
+			contentType: "application/octet-stream",
+		},
+		{
+			name:        "json lines",
+			parameters:  "batchSize=2&framing=lines&contentType=application/x-ndjson",
+			compression: batcher.CompressionNone,
+			framing:     batcher.FramingLines,
+			contentType: "application/x-ndjson",
+		},
+	}
+
+	for _, tc := range testCases {
+		p, err := printer.New(config.PrinterConfig{
+			Kind:    "webhook",
+			OutPath: server.URL + "?token=abc&flushInterval=1h&" + tc.parameters,
+			OutFile: os.Stdout,
+		})
+		require.NoError(t, err)
+
+		p.Print(trace.Event{EventName: "security_file_open"})
+		p.Print(trace.Event{EventName: "sched_process_exec"})
+		p.Print(trace.Event{EventName: "sched_process_exit"}) // sent on close
+		p.Close()
+
+		for _, expected := range [][]string{{"security_file_open", "sched_process_exec"}, {"sched_process_exit"}} {
+			r := <-requests
+			assert.Equal(t, "token=abc", r.query, tc.name) // the sink parameters aren't sent
+			assert.Equal(t, tc.contentType, r.header.Get("Content-Type"), tc.name)
+			assert.Equal(t, tc.compression, r.header.Get("Content-Encoding"), tc.name)
+			assert.Equal(t, tc.framing, r.header.Get("X-Tracee-Framing"), tc.name)
+
+			events, err := batcher.Decode(r.body, tc.compression, tc.framing)
+			require.NoError(t, err, tc.name)
+			require.Len(t, events, len(expected), tc.name)
+			assert.Equal(t, strconv.Itoa(len(expected)), r.header.Get("X-Tracee-Events"), tc.name)
+			for i, name := range expected {
+				assert.Contains(t, string(events[i]), `"eventName":"`+name+`"`, tc.name)
+			}
+		}
+	}
+}
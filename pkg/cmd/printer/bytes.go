@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// previewSize is the number of bytes shown in a preview of a bytes argument
+const previewSize = 64
+
+// bytesEventPrinter renders the bytes arguments of the events in the given format, before printing
+// them with the wrapped printer.
+type bytesEventPrinter struct {
+	EventPrinter
+	format config.BytesFormat
+}
+
+func (p bytesEventPrinter) Print(event trace.Event) {
+	p.EventPrinter.Print(renderBytesArgs(event, p.format))
+}
+
+// renderBytesArgs returns the event with its bytes arguments rendered as strings in the given
+// format. The arguments of the given event are shared with the other printers, so they are copied
+// (if there is any bytes argument).
+func renderBytesArgs(event trace.Event, format config.BytesFormat) trace.Event {
+	var args []trace.Argument
+
+	for i, arg := range event.Args {
+		data, ok := arg.Value.([]byte)
+		if !ok {
+			continue
+		}
+		if args == nil {
+			args = make([]trace.Argument, len(event.Args))
+			copy(args, event.Args)
+		}
+		args[i].Value = renderBytes(data, format)
+	}
+	if args != nil {
+		event.Args = args
+	}
+
+	return event
+}
+
+// renderBytes renders the given bytes as a string in the given format.
+func renderBytes(data []byte, format config.BytesFormat) interface{} {
+	switch format {
+	case config.BytesFormatBase64:
+		return base64.StdEncoding.EncodeToString(data)
+	case config.BytesFormatHex:
+		return hex.EncodeToString(data)
+	case config.BytesFormatPreview:
+		return preview(data)
+	}
+
+	return data
+}
+
+// preview returns the printable ASCII characters of the first bytes of the given data, escaping
+// the other bytes (\n, \t, \r, \\ or \xNN), followed by "..." if the data is longer.
+func preview(data []byte) string {
+	var b strings.Builder
+
+	size := len(data)
+	if size > previewSize {
+		size = previewSize
+	}
+	for _, c := range data[:size] {
+		switch {
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\\':
+			b.WriteString(`\\`)
+		case c >= 0x20 && c < 0x7f:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		}
+	}
+	if len(data) > size {
+		b.WriteString("...")
+	}
+
+	return b.String()
+}
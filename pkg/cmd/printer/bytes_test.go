@@ -0,0 +1,53 @@
+package printer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestRenderBytesArgs(t *testing.T) {
+	t.Parallel()
+
+	long := make([]byte, previewSize+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	testCases := []struct {
+		name     string
+		format   config.BytesFormat
+		data     []byte
+		expected interface{}
+	}{
+		{name: "default", format: config.BytesFormatDefault, data: []byte{0x7f, 'E'}, expected: []byte{0x7f, 'E'}},
+		{name: "base64", format: config.BytesFormatBase64, data: []byte("tracee"), expected: "dHJhY2Vl"},
+		{name: "hex", format: config.BytesFormatHex, data: []byte{0x7f, 'E', 'L', 'F'}, expected: "7f454c46"},
+		{name: "preview", format: config.BytesFormatPreview, data: []byte("\x7fELF\x02\n\t\\ok"), expected: `\x7fELF\x02\n\t\\ok`},
+		{name: "preview cut", format: config.BytesFormatPreview, data: long, expected: string(long[:previewSize]) + "..."},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			event := trace.Event{
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "fd", Type: "int"}, Value: int32(3)},
+					{ArgMeta: trace.ArgMeta{Name: "buf", Type: "bytes"}, Value: tc.data},
+				},
+			}
+
+			rendered := renderBytesArgs(event, tc.format)
+			assert.Equal(t, int32(3), rendered.Args[0].Value)
+			assert.Equal(t, tc.expected, rendered.Args[1].Value)
+			// the arguments of the event, shared with the other printers, are left as is
+			assert.Equal(t, tc.data, event.Args[1].Value)
+		})
+	}
+}
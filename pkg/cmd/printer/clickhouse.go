@@ -0,0 +1,180 @@
+package printer
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/clickhouse"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultClickHouseTable         = "tracee_events"
+	defaultClickHouseBatchSize     = 10000
+	defaultClickHouseFlushInterval = 5 * time.Second
+)
+
+// clickhouseEventPrinter inserts the events into a ClickHouse table, with the clickhouse-go driver.
+// The events are batched, and the batches are inserted asynchronously: a batch is dropped
+// when the queue of the batches to insert is full (the server being slow or unreachable),
+// instead of slowing the events pipeline down.
+type clickhouseEventPrinter struct {
+	outPath       string
+	opts          clickhouse.Options
+	table         string
+	createTable   bool
+	ttl           time.Duration
+	batchSize     int
+	flushInterval time.Duration
+
+	db      *sql.DB
+	created bool // the table was created, used by the inserting goroutine once initialized
+	queue   *batcher.Queue[trace.Event]
+}
+
+// Init parses the url: {tcp,tls}://[user:password@]host[:port][/database]. The table,
+// createTable, ttl, batchSize, flushInterval, queueSize and timeout parameters configure the
+// printer.
+func (p *clickhouseEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+	parameters := u.Query()
+
+	p.opts.Addr = u.Host
+	switch u.Scheme {
+	case "tcp":
+		if u.Port() == "" {
+			p.opts.Addr += ":9000"
+		}
+	case "tls":
+		if u.Port() == "" {
+			p.opts.Addr += ":9440"
+		}
+		p.opts.Secure = true
+	default:
+		return errfmt.Errorf("unsupported protocol for ClickHouse destination: %s", u.Scheme)
+	}
+	p.opts.Database = strings.TrimPrefix(u.Path, "/")
+	p.opts.User = u.User.Username()
+	p.opts.Password, _ = u.User.Password()
+
+	p.table = getParameterValue(parameters, "table", defaultClickHouseTable)
+
+	createTable := getParameterValue(parameters, "createTable", "true")
+	p.createTable, err = strconv.ParseBool(createTable)
+	if err != nil {
+		return errfmt.Errorf("invalid createTable value %q", createTable)
+	}
+
+	ttl := getParameterValue(parameters, "ttl", "0s")
+	p.ttl, err = time.ParseDuration(ttl)
+	if err != nil || p.ttl < 0 {
+		return errfmt.Errorf("invalid ttl value %q", ttl)
+	}
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultClickHouseBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 {
+		return errfmt.Errorf("invalid batchSize value %q", batchSize)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultClickHouseFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	queueSize, err := parseQueueSize(parameters)
+	if err != nil {
+		return err
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.opts.Timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.opts.Timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	p.db, err = clickhouse.Open(p.opts)
+	if err != nil {
+		return err
+	}
+	logger.Infow("Attempting to connect to ClickHouse destination", "address", p.opts.Addr, "table", p.table)
+	if err := p.prepare(); err != nil {
+		// The destination may not be available but may appear later so do not return an error here and just connect later.
+		logger.Errorw("Error connecting to ClickHouse destination", "address", p.opts.Addr, "error", err)
+	}
+
+	p.queue, err = batcher.NewQueue(batcher.QueueConfig{
+		MaxItems:  p.batchSize,
+		Interval:  p.flushInterval,
+		QueueSize: queueSize,
+	}, nil, p.insert)
+	if err != nil {
+		_ = p.db.Close()
+		return err
+	}
+
+	return nil
+}
+
+// prepare checks the connection to the server, and creates the table if it wasn't yet.
+func (p *clickhouseEventPrinter) prepare() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+	defer cancel()
+
+	if err := p.db.PingContext(ctx); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	return p.create(ctx)
+}
+
+// create creates the table, if it wasn't yet.
+func (p *clickhouseEventPrinter) create(ctx context.Context) error {
+	if !p.createTable || p.created {
+		return nil
+	}
+	if err := clickhouse.CreateEventsTable(ctx, p.db, p.table, p.ttl); err != nil {
+		return err
+	}
+	p.created = true
+
+	return nil
+}
+
+// insert inserts a batch, in the sending goroutine of the queue. The driver connects again when
+// the connection failed.
+func (p *clickhouseEventPrinter) insert(batch []trace.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+	defer cancel()
+
+	if err := p.create(ctx); err != nil {
+		return err
+	}
+
+	return clickhouse.InsertEvents(ctx, p.db, p.table, batch)
+}
+
+func (p *clickhouseEventPrinter) Preamble() {}
+
+func (p *clickhouseEventPrinter) Print(event trace.Event) {
+	p.queue.Add(event)
+}
+
+func (p *clickhouseEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *clickhouseEventPrinter) Close() {
+	p.queue.Close()
+	_ = p.db.Close()
+}
@@ -0,0 +1,49 @@
+package printer_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestClickHousePrinter(t *testing.T) {
+	t.Parallel()
+
+	// an address nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	_, err = printer.New(config.PrinterConfig{
+		Kind:    "clickhouse",
+		OutPath: "http://" + addr,
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, "unsupported protocol for ClickHouse destination: http")
+
+	_, err = printer.New(config.PrinterConfig{
+		Kind:    "clickhouse",
+		OutPath: "tcp://" + addr + "?batchSize=0",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, `invalid batchSize value "0"`)
+
+	// the destination may appear later: the printer starts, and the events are dropped
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "clickhouse",
+		OutPath: "tcp://" + addr + "/security?table=events&batchSize=2&queueSize=1&timeout=100ms",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		p.Print(trace.Event{EventName: "openat"})
+	}
+	p.Close()
+}
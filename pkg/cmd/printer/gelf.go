@@ -0,0 +1,67 @@
+package printer
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/gelf"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// gelfEventPrinter sends the events to Graylog, as GELF messages
+type gelfEventPrinter struct {
+	outPath string
+	writer  *gelf.Writer
+}
+
+// Init parses the url: {udp,tcp,tls}://host:port. The compression (udp only), chunkSize (udp
+// only) and timeout parameters configure the printer.
+func (p *gelfEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+	parameters := u.Query()
+
+	chunkSize := getParameterValue(parameters, "chunkSize", strconv.Itoa(gelf.DefaultChunkSize))
+	size, err := strconv.Atoi(chunkSize)
+	if err != nil {
+		return errfmt.Errorf("invalid chunkSize value %q", chunkSize)
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	t, err := time.ParseDuration(timeout)
+	if err != nil || t <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	p.writer, err = gelf.NewWriter(gelf.Config{
+		Network:     u.Scheme,
+		Address:     u.Host,
+		Compression: gelf.Compression(getParameterValue(parameters, "compression", "")),
+		ChunkSize:   size,
+		Timeout:     t,
+	})
+
+	return err
+}
+
+func (p *gelfEventPrinter) Preamble() {}
+
+func (p *gelfEventPrinter) Print(event trace.Event) {
+	if err := p.writer.Write(gelf.Message(&event)); err != nil {
+		logger.Errorw("Error sending event to GELF destination", "error", err)
+	}
+}
+
+func (p *gelfEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *gelfEventPrinter) Close() {
+	if err := p.writer.Close(); err != nil {
+		logger.Errorw("Error closing GELF destination", "error", err)
+	}
+}
@@ -0,0 +1,66 @@
+package printer_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestGELFPrinter(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	messages := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			message, err := r.ReadString(0)
+			if err != nil {
+				return
+			}
+			messages <- strings.TrimSuffix(message, "\x00")
+		}
+	}()
+
+	_, err = printer.New(config.PrinterConfig{
+		Kind:    "gelf",
+		OutPath: "tcp://" + listener.Addr().String() + "?compression=gzip",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, "gelf compression isn't supported over tcp")
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "gelf",
+		OutPath: "tcp://" + listener.Addr().String(),
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{EventName: "openat", HostName: "node-1", ProcessName: "cat", HostProcessID: 10})
+	p.Print(trace.Event{EventName: "execve", HostName: "node-1", ProcessName: "bash", HostProcessID: 11})
+	p.Close()
+
+	for _, expected := range []string{"openat: cat (pid 10)", "execve: bash (pid 11)"} {
+		var message map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(<-messages), &message))
+		assert.Equal(t, expected, message["short_message"])
+		assert.Equal(t, "node-1", message["host"])
+	}
+}
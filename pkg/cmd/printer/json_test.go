@@ -0,0 +1,91 @@
+package printer_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/integrity"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error { return nil }
+
+func TestJSONPrinterSigned(t *testing.T) {
+	t.Parallel()
+
+	key, err := integrity.LoadKey(integrity.Config{Algorithm: integrity.AlgorithmEd25519})
+	require.NoError(t, err)
+
+	out := &bufferCloser{}
+	p, err := printer.New(config.PrinterConfig{
+		Kind:         "json",
+		OutFile:      out,
+		IntegrityKey: key,
+	})
+	require.NoError(t, err)
+
+	p.Preamble()
+	p.Print(trace.Event{EventName: "openat"})
+	p.Print(trace.Event{EventName: "execve"})
+
+	scanner := bufio.NewScanner(&out.Buffer)
+
+	require.True(t, scanner.Scan())
+	var header struct {
+		Header integrity.Header `json:"integrity_header"`
+	}
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &header))
+	publicKey, err := base64.StdEncoding.DecodeString(key.PublicKey()) // the trusted public key
+	require.NoError(t, err)
+	verifier, err := integrity.NewVerifier(header.Header, nil, publicKey)
+	require.NoError(t, err)
+
+	names := []string{}
+	for scanner.Scan() {
+		var signed struct {
+			Event     json.RawMessage  `json:"event"`
+			Integrity integrity.Record `json:"integrity"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &signed))
+		assert.NoError(t, verifier.Verify(signed.Event, signed.Integrity))
+
+		var evt trace.Event
+		require.NoError(t, json.Unmarshal(signed.Event, &evt))
+		names = append(names, evt.EventName)
+	}
+	assert.Equal(t, []string{"openat", "execve"}, names)
+}
+
+func TestJSONPrinterMarshalError(t *testing.T) {
+	t.Parallel()
+
+	key, err := integrity.LoadKey(integrity.Config{Algorithm: integrity.AlgorithmEd25519})
+	require.NoError(t, err)
+
+	out := &bufferCloser{}
+	p, err := printer.New(config.PrinterConfig{
+		Kind:         "json",
+		OutFile:      out,
+		IntegrityKey: key,
+	})
+	require.NoError(t, err)
+
+	// the event isn't printed (nor signed) if it can't be marshaled
+	p.Print(trace.Event{
+		EventName: "openat",
+		Args:      []trace.Argument{{ArgMeta: trace.ArgMeta{Name: "fd"}, Value: make(chan int)}},
+	})
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,148 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/kinesis"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultKinesisPartitionKey  = "{{ .Container.ID }}"
+	defaultKinesisBatchSize     = kinesis.MaxRecords
+	defaultKinesisFlushInterval = time.Second
+)
+
+// kinesisEventPrinter puts the events to an Amazon Kinesis data stream, partitioned by a key
+// rendered from the event: the events of a container (by default) are ordered in their shard,
+// the events out of containers being partitioned by host. The events are batched, and the
+// batches are put in the background, the records failing (e.g. throttled) being put again once.
+type kinesisEventPrinter struct {
+	outPath       string
+	partitionKey  *template.Template
+	batchSize     int
+	flushInterval time.Duration
+	queueSize     int
+	timeout       time.Duration
+
+	client *kinesis.Client
+	queue  *batcher.Queue[kinesis.Record]
+}
+
+// Init parses the path: <stream name or ARN>[?k=v]. The partitionKey parameter is a Go template
+// of the events, and the region, endpoint, batchSize, flushInterval, queueSize and timeout
+// parameters configure the printer. The AWS credentials are discovered as by the AWS SDKs.
+func (p *kinesisEventPrinter) Init() error {
+	stream, query, _ := strings.Cut(p.outPath, "?")
+	parameters, err := url.ParseQuery(query)
+	if err != nil {
+		return errfmt.Errorf("unable to parse parameters %q: %v", query, err)
+	}
+
+	p.partitionKey, err = parseParameterTemplate("partitionKey", getParameterValue(parameters, "partitionKey", defaultKinesisPartitionKey))
+	if err != nil {
+		return err
+	}
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultKinesisBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 || p.batchSize > kinesis.MaxRecords {
+		return errfmt.Errorf("invalid batchSize value %q (1 to %d)", batchSize, kinesis.MaxRecords)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultKinesisFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	p.queueSize, err = parseQueueSize(parameters)
+	if err != nil {
+		return err
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	p.client, err = kinesis.NewClient(context.Background(), kinesis.Config{
+		Stream:   stream,
+		Region:   getParameterValue(parameters, "region", ""),
+		Endpoint: getParameterValue(parameters, "endpoint", ""),
+		Timeout:  p.timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.queue, err = batcher.NewQueue(batcher.QueueConfig{
+		MaxItems:  p.batchSize,
+		Interval:  p.flushInterval,
+		QueueSize: p.queueSize,
+	}, nil, p.send)
+
+	return err
+}
+
+func (p *kinesisEventPrinter) Preamble() {}
+
+func (p *kinesisEventPrinter) Print(event trace.Event) {
+	var key bytes.Buffer
+	if err := p.partitionKey.Execute(&key, event); err != nil {
+		logger.Errorw("Error rendering Kinesis partition key", "error", err)
+		return
+	}
+	partitionKey := strings.TrimSpace(key.String())
+	if partitionKey == "" {
+		partitionKey = event.HostName // not in a container
+	}
+	if partitionKey == "" {
+		partitionKey = "tracee"
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		logger.Errorw("Error marshalling event", "error", err)
+		return
+	}
+
+	p.queue.Add(kinesis.Record{Data: data, PartitionKey: partitionKey})
+}
+
+// send puts a batch of records, and then the failed ones again (once), in the sending goroutine
+// of the queue.
+func (p *kinesisEventPrinter) send(records []kinesis.Record) error {
+	var err error
+	for attempt := 0; attempt < 2 && len(records) > 0; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		var failed []kinesis.Record
+		failed, err = p.client.PutRecords(ctx, records)
+		cancel()
+		if err == nil {
+			records = nil
+		} else if failed != nil {
+			records = failed // put again the failed records only
+		}
+	}
+
+	return err
+}
+
+func (p *kinesisEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *kinesisEventPrinter) Close() {
+	p.queue.Close()
+}
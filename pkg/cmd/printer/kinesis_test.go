@@ -0,0 +1,92 @@
+package printer_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// kinesisRecord is a record put to the fake Kinesis endpoint.
+type kinesisRecord struct {
+	Data         []byte
+	PartitionKey string
+}
+
+func TestKinesisPrinter(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIAEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	// the first record of the throttled partition key fails
+	var mu sync.Mutex
+	var records []kinesisRecord
+	throttled := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Kinesis_20131202.PutRecords", r.Header.Get("X-Amz-Target"))
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=AKIAEXAMPLE/")
+
+		var request struct {
+			StreamName string
+			Records    []kinesisRecord
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "tracee", request.StreamName)
+
+		mu.Lock()
+		defer mu.Unlock()
+		response := map[string]interface{}{"FailedRecordCount": 0}
+		results := []map[string]string{}
+		for _, record := range request.Records {
+			if record.PartitionKey == "node-1" && throttled {
+				throttled = false
+				response["FailedRecordCount"] = 1
+				results = append(results, map[string]string{"ErrorCode": "ProvisionedThroughputExceededException"})
+				continue
+			}
+			records = append(records, record)
+			results = append(results, map[string]string{"SequenceNumber": "1", "ShardId": "shardId-000000000000"})
+		}
+		response["Records"] = results
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "kinesis",
+		OutPath: "tracee?region=eu-west-1&batchSize=1000",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, `invalid batchSize value "1000" (1 to 500)`)
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "kinesis",
+		OutPath: "tracee?region=eu-west-1&endpoint=" + server.URL + "&batchSize=2&flushInterval=1h",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{EventName: "security_file_open", HostName: "node-1", Container: trace.Container{ID: "abc"}})
+	p.Print(trace.Event{EventName: "sched_process_exec", HostName: "node-1"}) // throttled, then put again
+	p.Print(trace.Event{EventName: "sched_process_exit", HostName: "node-1"}) // put on close
+	p.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, records, 3)
+	for i, expected := range []struct{ name, key string }{
+		{"security_file_open", "abc"},
+		{"sched_process_exec", "node-1"},
+		{"sched_process_exit", "node-1"},
+	} {
+		assert.Equal(t, expected.key, records[i].PartitionKey)
+		assert.Contains(t, string(records[i].Data), `"eventName":"`+expected.name+`"`)
+	}
+}
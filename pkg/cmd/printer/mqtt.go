@@ -0,0 +1,275 @@
+package printer
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultMQTTTopic         = "tracee/{{ .EventName }}"
+	defaultMQTTQoS           = 1
+	defaultMQTTMaxInFlight   = 256
+	defaultMQTTFlushInterval = time.Second
+)
+
+// mqttEventPrinter publishes the events to an MQTT (3.1.1) broker, to a topic rendered from the
+// event. The client connects again after a failure, the messages of QoS 1 and 2 published
+// meanwhile being stored until then.
+type mqttEventPrinter struct {
+	outPath       string
+	topic         *template.Template
+	templateObj   *template.Template
+	qos           byte
+	retain        bool
+	maxInFlight   int
+	flushInterval time.Duration
+	timeout       time.Duration
+
+	client mqtt.Client
+
+	mu      sync.Mutex
+	pending []mqtt.Token // waiting for their completion
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Init parses the url: {tcp,ssl}://[user:password@]host[:port]. The topic parameter is a Go
+// template of the events, and the gotemplate, qos, retain, clientId, caFile, certFile,
+// keyFile, maxInFlight, flushInterval and timeout parameters configure the printer.
+func (p *mqttEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+	parameters := u.Query()
+
+	broker := url.URL{Scheme: u.Scheme, Host: u.Host}
+	var tlsConfig *tls.Config
+	switch u.Scheme {
+	case "tcp", "mqtt":
+		if u.Port() == "" {
+			broker.Host += ":1883"
+		}
+	case "ssl", "tls", "mqtts":
+		if u.Port() == "" {
+			broker.Host += ":8883"
+		}
+		tlsConfig, err = mqttTLSConfig(
+			u.Hostname(),
+			getParameterValue(parameters, "caFile", ""),
+			getParameterValue(parameters, "certFile", ""),
+			getParameterValue(parameters, "keyFile", ""),
+		)
+		if err != nil {
+			return err
+		}
+	default:
+		return errfmt.Errorf("unsupported protocol for MQTT destination: %s", u.Scheme)
+	}
+
+	p.topic, err = parseParameterTemplate("topic", getParameterValue(parameters, "topic", defaultMQTTTopic))
+	if err != nil {
+		return err
+	}
+
+	gotemplate := getParameterValue(parameters, "gotemplate", "")
+	if gotemplate != "" {
+		p.templateObj, err = parseTemplate(gotemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	qos := getParameterValue(parameters, "qos", strconv.Itoa(defaultMQTTQoS))
+	switch qos {
+	case "0", "1", "2":
+		p.qos = qos[0] - '0'
+	default:
+		return errfmt.Errorf("invalid qos value %q, must be 0, 1 or 2", qos)
+	}
+
+	retain := getParameterValue(parameters, "retain", "false")
+	p.retain, err = strconv.ParseBool(retain)
+	if err != nil {
+		return errfmt.Errorf("invalid retain value %q", retain)
+	}
+
+	maxInFlight := getParameterValue(parameters, "maxInFlight", strconv.Itoa(defaultMQTTMaxInFlight))
+	p.maxInFlight, err = strconv.Atoi(maxInFlight)
+	if err != nil || p.maxInFlight < 1 {
+		return errfmt.Errorf("invalid maxInFlight value %q", maxInFlight)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultMQTTFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	clientID := getParameterValue(parameters, "clientId", "")
+	if clientID == "" {
+		hostname, _ := os.Hostname()
+		clientID = "tracee-" + hostname
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(broker.String()).
+		SetClientID(clientID).
+		SetTLSConfig(tlsConfig).
+		SetConnectTimeout(p.timeout).
+		SetWriteTimeout(p.timeout).
+		SetAutoReconnect(true).
+		SetConnectRetry(true) // the messages published before the connection are stored
+	if u.User != nil {
+		password, _ := u.User.Password()
+		opts.SetUsername(u.User.Username()).SetPassword(password)
+	}
+	p.client = mqtt.NewClient(opts)
+
+	logger.Infow("Attempting to connect to MQTT destination", "broker", broker.Host, "clientId", clientID)
+	token := p.client.Connect()
+	if !token.WaitTimeout(p.timeout) || token.Error() != nil {
+		// The destination may not be available but may appear later so do not return an error here and just connect later.
+		logger.Errorw("Error connecting to MQTT destination", "broker", broker.Host, "error", token.Error())
+	}
+
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.mu.Lock()
+				p.waitPending()
+				p.mu.Unlock()
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// mqttTLSConfig returns the TLS configuration of a broker, with the certificate authority
+// verifying its certificate and the client certificate (of mutual TLS), if given.
+func mqttTLSConfig(serverName, caFile, certFile, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		caPEM, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, errfmt.Errorf("loading MQTT certificate authority: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, errfmt.Errorf("no certificate found in %s", caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errfmt.Errorf("loading MQTT client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (p *mqttEventPrinter) Preamble() {}
+
+func (p *mqttEventPrinter) Print(event trace.Event) {
+	var topic bytes.Buffer
+	if err := p.topic.Execute(&topic, event); err != nil {
+		logger.Errorw("Error rendering MQTT topic", "error", err)
+		return
+	}
+
+	var payload []byte
+	if p.templateObj != nil {
+		buf := bytes.Buffer{}
+		if err := p.templateObj.Execute(&buf, event); err != nil {
+			logger.Errorw("error writing to the template", "error", err)
+			return
+		}
+		payload = buf.Bytes()
+	} else {
+		var err error
+		payload, err = json.Marshal(event)
+		if err != nil {
+			logger.Errorw("Error marshalling event", "error", err)
+			return
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending = append(p.pending, p.client.Publish(strings.TrimSpace(topic.String()), p.qos, p.retain, payload))
+	if len(p.pending) >= p.maxInFlight {
+		p.waitPending()
+	}
+}
+
+// waitPending waits for the completion of the pending messages (acknowledged by the broker,
+// for QoS 1 and 2), logging the failures. It is called with the lock held.
+func (p *mqttEventPrinter) waitPending() {
+	failed := 0
+	var err error
+	deadline := time.Now().Add(p.timeout)
+	for _, token := range p.pending {
+		if !token.WaitTimeout(max(time.Until(deadline), time.Millisecond)) {
+			failed++
+			continue
+		}
+		if token.Error() != nil {
+			failed++
+			err = token.Error()
+		}
+	}
+	if failed > 0 {
+		logger.Errorw("Events not published to MQTT destination", "events", failed, "error", err)
+	}
+	p.pending = p.pending[:0]
+}
+
+func (p *mqttEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *mqttEventPrinter) Close() {
+	close(p.done)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.waitPending()
+	p.client.Disconnect(250) // milliseconds, the pending messages being completed
+}
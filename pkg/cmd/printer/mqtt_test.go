@@ -0,0 +1,118 @@
+package printer_test
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// mqttPacket is a control packet sent to an MQTT broker.
+type mqttPacket struct {
+	kind    byte // the high nibble of the fixed header
+	flags   byte // the low nibble
+	payload []byte
+}
+
+func readMQTTPacket(r *bufio.Reader) (mqttPacket, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return mqttPacket{}, err
+	}
+	size, err := binary.ReadUvarint(r) // the remaining length, a base 128 varint
+	if err != nil {
+		return mqttPacket{}, err
+	}
+	payload := make([]byte, size)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return mqttPacket{}, err
+	}
+
+	return mqttPacket{kind: header >> 4, flags: header & 0x0f, payload: payload}, nil
+}
+
+func TestMQTTPrinter(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	type message struct {
+		topic   string
+		qos     byte
+		payload string
+	}
+	connected := make(chan string, 1)
+	messages := make(chan message, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			packet, err := readMQTTPacket(r)
+			if err != nil {
+				return
+			}
+			switch packet.kind {
+			case 1: // CONNECT: the protocol name, level, flags and keep alive, and the client id
+				size := binary.BigEndian.Uint16(packet.payload[10:])
+				connected <- string(packet.payload[12 : 12+size])
+				_, _ = conn.Write([]byte{0x20, 2, 0, 0}) // CONNACK, accepted
+			case 3: // PUBLISH
+				size := binary.BigEndian.Uint16(packet.payload)
+				topic := string(packet.payload[2 : 2+size])
+				qos := packet.flags >> 1 & 3
+				payload := packet.payload[2+size:]
+				if qos > 0 {
+					id := payload[:2]
+					payload = payload[2:]
+					_, _ = conn.Write([]byte{0x40, 2, id[0], id[1]}) // PUBACK
+				}
+				messages <- message{topic: topic, qos: qos, payload: string(payload)}
+			case 12: // PINGREQ
+				_, _ = conn.Write([]byte{0xd0, 0})
+			case 14: // DISCONNECT
+				return
+			}
+		}
+	}()
+
+	_, err = printer.New(config.PrinterConfig{
+		Kind:    "mqtt",
+		OutPath: "tcp://" + listener.Addr().String() + "?qos=3",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, `invalid qos value "3", must be 0, 1 or 2`)
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "mqtt",
+		OutPath: "tcp://" + listener.Addr().String() + "?topic=edge/node-1/{{.EventName}}&clientId=tracee-node-1&maxInFlight=2",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tracee-node-1", <-connected)
+
+	p.Print(trace.Event{EventName: "security_file_open", ProcessName: "cat"})
+	p.Print(trace.Event{EventName: "sched_process_exec", ProcessName: "ls"})
+	p.Print(trace.Event{EventName: "sched_process_exit", ProcessName: "ls"})
+	p.Close()
+
+	for _, name := range []string{"security_file_open", "sched_process_exec", "sched_process_exit"} {
+		m := <-messages
+		assert.Equal(t, "edge/node-1/"+name, m.topic)
+		assert.Equal(t, byte(1), m.qos)
+		assert.Contains(t, m.payload, `"eventName":"`+name+`"`)
+	}
+}
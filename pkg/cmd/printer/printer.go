@@ -7,19 +7,20 @@ import (
 	"io"
 	"net/http"
 	"net/url"
-	"path/filepath"
+	"os"
 	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
 	forward "github.com/IBM/fluent-forward-go/fluent/client"
-	"github.com/Masterminds/sprig/v3"
 
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/integrity"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -53,6 +54,7 @@ func New(cfg config.PrinterConfig) (EventPrinter, error) {
 			verbose:       false,
 			containerMode: cfg.ContainerMode,
 			relativeTS:    cfg.RelativeTS,
+			timeZone:      cfg.TimeZone,
 		}
 	case kind == "table-verbose":
 		res = &tableEventPrinter{
@@ -60,10 +62,18 @@ func New(cfg config.PrinterConfig) (EventPrinter, error) {
 			verbose:       true,
 			containerMode: cfg.ContainerMode,
 			relativeTS:    cfg.RelativeTS,
+			timeZone:      cfg.TimeZone,
 		}
 	case kind == "json":
 		res = &jsonEventPrinter{
-			out: cfg.OutFile,
+			out:          cfg.OutFile,
+			integrityKey: cfg.IntegrityKey,
+		}
+	case kind == "json-v2":
+		res = &jsonEventPrinter{
+			out:          cfg.OutFile,
+			integrityKey: cfg.IntegrityKey,
+			schemaV2:     true,
 		}
 	case kind == "forward":
 		res = &forwardEventPrinter{
@@ -73,6 +83,46 @@ func New(cfg config.PrinterConfig) (EventPrinter, error) {
 		res = &webhookEventPrinter{
 			outPath: cfg.OutPath,
 		}
+	case kind == "pubsub":
+		res = &pubsubEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "kinesis":
+		res = &kinesisEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "mqtt":
+		res = &mqttEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "amqp":
+		res = &amqpEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "redis":
+		res = &redisEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "gelf":
+		res = &gelfEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "clickhouse":
+		res = &clickhouseEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "sqlite":
+		res = &sqliteEventPrinter{
+			outPath: cfg.OutPath,
+		}
+	case kind == "stix":
+		res = &stixEventPrinter{
+			out: cfg.OutFile,
+		}
+	case kind == "taxii":
+		res = &stixEventPrinter{
+			outPath: cfg.OutPath,
+		}
 	case strings.HasPrefix(kind, "gotemplate="):
 		res = &templateEventPrinter{
 			out:          cfg.OutFile,
@@ -83,6 +133,18 @@ func New(cfg config.PrinterConfig) (EventPrinter, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Query != nil {
+		res = queryEventPrinter{
+			EventPrinter: res,
+			query:        cfg.Query,
+			out:          cfg.OutFile,
+			schemaV2:     kind == "json-v2",
+			raw:          kind == "table" || kind == "table-verbose",
+		}
+	}
+	if cfg.BytesFormat != config.BytesFormatDefault {
+		res = bytesEventPrinter{EventPrinter: res, format: cfg.BytesFormat}
+	}
 	return res, nil
 }
 
@@ -91,6 +153,7 @@ type tableEventPrinter struct {
 	verbose       bool
 	containerMode config.ContainerMode
 	relativeTS    bool
+	timeZone      *time.Location // of the wall times (local if nil)
 }
 
 func (p tableEventPrinter) Init() error { return nil }
@@ -201,6 +264,8 @@ func (p tableEventPrinter) Print(event trace.Event) {
 	ut := time.Unix(0, int64(event.Timestamp))
 	if p.relativeTS {
 		ut = ut.UTC()
+	} else if p.timeZone != nil {
+		ut = ut.In(p.timeZone)
 	}
 	timestamp := fmt.Sprintf("%02d:%02d:%02d:%06d", ut.Hour(), ut.Minute(), ut.Second(), ut.Nanosecond()/1000)
 
@@ -346,6 +411,7 @@ func (p tableEventPrinter) Epilogue(stats metrics.Stats) {
 }
 
 func (p tableEventPrinter) Close() {
+	closeOut(p.out)
 }
 
 type templateEventPrinter struct {
@@ -359,9 +425,9 @@ func (p *templateEventPrinter) Init() error {
 	if tmplPath == "" {
 		return errfmt.Errorf("please specify a gotemplate for event-based output")
 	}
-	tmpl, err := template.ParseFiles(tmplPath)
+	tmpl, err := parseTemplate(tmplPath)
 	if err != nil {
-		return errfmt.WrapError(err)
+		return err
 	}
 	p.templateObj = &tmpl
 
@@ -384,27 +450,90 @@ func (p templateEventPrinter) Print(event trace.Event) {
 func (p templateEventPrinter) Epilogue(stats metrics.Stats) {}
 
 func (p templateEventPrinter) Close() {
+	closeOut(p.out)
 }
 
 type jsonEventPrinter struct {
-	out io.WriteCloser
+	out          io.WriteCloser
+	integrityKey *integrity.Key
+	chain        *integrity.Chain // signs the events, if enabled
+	schemaV2     bool             // snake_case field names and RFC3339 times
 }
 
-func (p jsonEventPrinter) Init() error { return nil }
+// signedEvent is a printed signed event.
+type signedEvent struct {
+	Event     json.RawMessage  `json:"event"`
+	Integrity integrity.Record `json:"integrity"`
+}
+
+func (p *jsonEventPrinter) Init() error {
+	if p.integrityKey == nil {
+		return nil
+	}
+
+	chain, err := integrity.NewChain(p.integrityKey)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	p.chain = chain
 
-func (p jsonEventPrinter) Preamble() {}
+	return nil
+}
+
+func (p jsonEventPrinter) Preamble() {
+	if p.chain == nil {
+		return
+	}
+
+	hBytes, err := json.Marshal(map[string]integrity.Header{"integrity_header": p.chain.Header()})
+	if err != nil {
+		logger.Errorw("Error marshaling integrity header to json", "error", err)
+		return
+	}
+	fmt.Fprintln(p.out, string(hBytes))
+}
 
 func (p jsonEventPrinter) Print(event trace.Event) {
-	eBytes, err := json.Marshal(event)
+	var eBytes []byte
+	var err error
+	if p.schemaV2 {
+		eBytes, err = event.MarshalJSONV2()
+	} else {
+		eBytes, err = json.Marshal(event)
+	}
 	if err != nil {
 		logger.Errorw("Error marshaling event to json", "error", err)
+		return
 	}
+
+	if p.chain != nil {
+		eBytes, err = json.Marshal(signedEvent{
+			Event:     eBytes,
+			Integrity: p.chain.Sign(eBytes),
+		})
+		if err != nil {
+			logger.Errorw("Error marshaling signed event to json", "error", err)
+			return
+		}
+	}
+
 	fmt.Fprintln(p.out, string(eBytes))
 }
 
 func (p jsonEventPrinter) Epilogue(stats metrics.Stats) {}
 
 func (p jsonEventPrinter) Close() {
+	closeOut(p.out)
+}
+
+// closeOut closes the output of a printer, unless it is stdout, flushing compressed outputs.
+func closeOut(out io.WriteCloser) {
+	if out == os.Stdout {
+		return
+	}
+	if err := out.Close(); err != nil {
+		logger.Errorw("Closing printer output", "error", err)
+	}
 }
 
 // ignoreEventPrinter ignores events
@@ -428,7 +557,8 @@ type forwardEventPrinter struct {
 	url     *url.URL
 	client  *forward.Client
 	// These parameters can be set up from the URL
-	tag string `default:"tracee"`
+	tag         string             `default:"tracee"`
+	templateObj *template.Template // formats the events, instead of json, if set
 }
 
 func getParameterValue(parameters url.Values, key string, defaultValue string) string {
@@ -454,6 +584,14 @@ func (p *forwardEventPrinter) Init() error {
 	// Check if we have a tag set or default it
 	p.tag = getParameterValue(parameters, "tag", "tracee")
 
+	gotemplate := getParameterValue(parameters, "gotemplate", "")
+	if gotemplate != "" {
+		p.templateObj, err = parseTemplate(gotemplate)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Do we want to enable requireAck?
 	requireAckString := getParameterValue(parameters, "requireAck", "false")
 	requireAck, err := strconv.ParseBool(requireAckString)
@@ -518,10 +656,22 @@ func (p *forwardEventPrinter) Print(event trace.Event) {
 		return
 	}
 
-	// The actual event is marshalled as JSON then sent with the other information (tag, etc.)
-	eBytes, err := json.Marshal(event)
-	if err != nil {
-		logger.Errorw("Error marshaling event to json", "error", err)
+	// The actual event is marshalled as JSON (or formatted with the template) then sent with the
+	// other information (tag, etc.)
+	var eBytes []byte
+	var err error
+	if p.templateObj != nil {
+		buf := bytes.Buffer{}
+		if err := p.templateObj.Execute(&buf, event); err != nil {
+			logger.Errorw("Error executing template", "error", err)
+			return
+		}
+		eBytes = buf.Bytes()
+	} else {
+		eBytes, err = json.Marshal(event)
+		if err != nil {
+			logger.Errorw("Error marshaling event to json", "error", err)
+		}
 	}
 
 	record := map[string]interface{}{
@@ -559,12 +709,16 @@ func (p forwardEventPrinter) Close() {
 	}
 }
 
+// webhookEventPrinter posts the events to a webhook, an event per request, or batched (framed
+// and optionally compressed) if a batchSize, batchBytes or compression parameter is given.
 type webhookEventPrinter struct {
 	outPath     string
 	url         *url.URL
 	timeout     time.Duration
 	templateObj *template.Template
 	contentType string
+	batcher     *batcher.Batcher // nil if not batching
+	batchHeader http.Header      // the headers of the batch requests
 }
 
 func (ws *webhookEventPrinter) Init() error {
@@ -585,19 +739,39 @@ func (ws *webhookEventPrinter) Init() error {
 
 	gotemplate := getParameterValue(parameters, "gotemplate", "")
 	if gotemplate != "" {
-		tmpl, err := template.New(filepath.Base(gotemplate)).
-			Funcs(sprig.TxtFuncMap()).
-			ParseFiles(gotemplate)
-
+		ws.templateObj, err = parseTemplate(gotemplate)
 		if err != nil {
-			return errfmt.WrapError(err)
+			return err
 		}
-		ws.templateObj = tmpl
 	}
 
 	contentType := getParameterValue(parameters, "contentType", "application/json")
 	ws.contentType = contentType
 
+	batchConfig, err := parseBatchParameters(parameters)
+	if err != nil {
+		return err
+	}
+	if batchConfig.MaxEvents > 1 || batchConfig.MaxBytes > 0 || batchConfig.Compression != batcher.CompressionNone {
+		ws.batcher, err = batcher.New(batchConfig, ws.sendBatch)
+		if err != nil {
+			return err
+		}
+		ws.batchHeader = http.Header{"X-Tracee-Framing": {batchConfig.Framing}}
+		if batchConfig.Compression != batcher.CompressionNone {
+			ws.batchHeader.Set("Content-Encoding", batchConfig.Compression)
+		}
+		if batchConfig.Framing == batcher.FramingLength {
+			ws.batchHeader.Set("Content-Type", "application/octet-stream")
+		}
+	}
+
+	// the parameters of the sink aren't sent to the webhook (e.g. a chat incoming webhook)
+	for _, key := range append([]string{"timeout", "gotemplate", "contentType"}, batchParameters...) {
+		parameters.Del(key)
+	}
+	ws.url.RawQuery = parameters.Encode()
+
 	return nil
 }
 
@@ -624,30 +798,57 @@ func (ws *webhookEventPrinter) Print(event trace.Event) {
 		}
 	}
 
+	if ws.batcher != nil {
+		ws.batcher.Add(payload)
+		return
+	}
+
+	if err := ws.post(payload, nil); err != nil {
+		logger.Errorw("Error sending webhook", "error", err)
+	}
+}
+
+// sendBatch posts a batch of events, with its framing, compression and number of events
+// headers.
+func (ws *webhookEventPrinter) sendBatch(batch batcher.Batch) error {
+	header := ws.batchHeader.Clone()
+	header.Set("X-Tracee-Events", strconv.Itoa(batch.Events))
+
+	return ws.post(batch.Data, header)
+}
+
+// post posts a payload to the webhook, with the content type of the sink unless overridden by
+// the given headers.
+func (ws *webhookEventPrinter) post(payload []byte, header http.Header) error {
 	client := http.Client{Timeout: ws.timeout}
 
 	req, err := http.NewRequest(http.MethodPost, ws.url.String(), bytes.NewReader(payload))
 	if err != nil {
-		logger.Errorw("Error creating request", "error", err)
-		return
+		return errfmt.WrapError(err)
 	}
 
 	req.Header.Set("Content-Type", ws.contentType)
+	for key, values := range header {
+		req.Header[key] = values
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
-		logger.Errorw("Error sending webhook", "error", err)
-		return
+		return errfmt.WrapError(err)
 	}
+	_ = resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		logger.Errorw(fmt.Sprintf("Error sending webhook, http status: %d", resp.StatusCode))
+		return errfmt.Errorf("http status: %d", resp.StatusCode)
 	}
 
-	_ = resp.Body.Close()
+	return nil
 }
 
 func (ws *webhookEventPrinter) Epilogue(stats metrics.Stats) {}
 
 func (ws *webhookEventPrinter) Close() {
+	if ws.batcher != nil {
+		ws.batcher.Close()
+	}
 }
@@ -0,0 +1,149 @@
+package printer
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/pubsub"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultPubSubOrderingKey   = "{{ .Container.ID }}"
+	defaultPubSubBatchSize     = 100
+	defaultPubSubFlushInterval = 100 * time.Millisecond
+)
+
+// pubsubEventPrinter publishes the events to a Google Cloud Pub/Sub topic, with an ordering key
+// rendered from the event: the events of a container (by default) are delivered in order to
+// the subscriptions with message ordering enabled. The events are batched, and the batches are
+// published in the background, a batch failing being published again once.
+type pubsubEventPrinter struct {
+	outPath       string
+	orderingKey   *template.Template // nil if ordering is disabled
+	batchSize     int
+	flushInterval time.Duration
+	queueSize     int
+	timeout       time.Duration
+
+	client *pubsub.Client
+	queue  *batcher.Queue[pubsub.Message]
+}
+
+// Init parses the path: projects/<project>/topics/<topic>[?k=v]. The orderingKey parameter is
+// a Go template of the events, and the ordering, endpoint, batchSize, flushInterval and timeout
+// parameters configure the printer. The Google credentials are the application default ones.
+func (p *pubsubEventPrinter) Init() error {
+	topic, query, _ := strings.Cut(p.outPath, "?")
+	parameters, err := url.ParseQuery(query)
+	if err != nil {
+		return errfmt.Errorf("unable to parse parameters %q: %v", query, err)
+	}
+
+	ordering := getParameterValue(parameters, "ordering", "true")
+	ordered, err := strconv.ParseBool(ordering)
+	if err != nil {
+		return errfmt.Errorf("invalid ordering value %q", ordering)
+	}
+	if ordered {
+		p.orderingKey, err = parseParameterTemplate("orderingKey", getParameterValue(parameters, "orderingKey", defaultPubSubOrderingKey))
+		if err != nil {
+			return err
+		}
+	}
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultPubSubBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 || p.batchSize > pubsub.MaxMessages {
+		return errfmt.Errorf("invalid batchSize value %q (1 to %d)", batchSize, pubsub.MaxMessages)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultPubSubFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	p.queueSize, err = parseQueueSize(parameters)
+	if err != nil {
+		return err
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	p.client, err = pubsub.NewClient(ctx, pubsub.Config{
+		Topic:    topic,
+		Endpoint: getParameterValue(parameters, "endpoint", ""),
+		Timeout:  p.timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.queue, err = batcher.NewQueue(batcher.QueueConfig{
+		MaxItems:  p.batchSize,
+		Interval:  p.flushInterval,
+		QueueSize: p.queueSize,
+	}, nil, p.send)
+
+	return err
+}
+
+func (p *pubsubEventPrinter) Preamble() {}
+
+func (p *pubsubEventPrinter) Print(event trace.Event) {
+	orderingKey := ""
+	if p.orderingKey != nil {
+		var key bytes.Buffer
+		if err := p.orderingKey.Execute(&key, event); err != nil {
+			logger.Errorw("Error rendering Pub/Sub ordering key", "error", err)
+			return
+		}
+		orderingKey = strings.TrimSpace(key.String()) // unordered if empty (e.g. not in a container)
+	}
+
+	message, err := pubsub.EventMessage(&event, orderingKey)
+	if err != nil {
+		logger.Errorw("Error encoding event to Pub/Sub", "error", err)
+		return
+	}
+
+	p.queue.Add(message)
+}
+
+// send publishes a batch of messages, again (once) if the publish failed, in the sending
+// goroutine of the queue.
+func (p *pubsubEventPrinter) send(messages []pubsub.Message) error {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+		_, err = p.client.Publish(ctx, messages)
+		cancel()
+		if err == nil {
+			break
+		}
+	}
+
+	return err
+}
+
+func (p *pubsubEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *pubsubEventPrinter) Close() {
+	p.queue.Close()
+}
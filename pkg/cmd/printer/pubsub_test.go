@@ -0,0 +1,88 @@
+package printer_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// pubsubMessage is a message published to the fake Pub/Sub emulator.
+type pubsubMessage struct {
+	Data        []byte
+	OrderingKey string
+	Attributes  map[string]string
+}
+
+func TestPubSubPrinter(t *testing.T) {
+	t.Parallel()
+
+	// the first publish request fails
+	var mu sync.Mutex
+	var messages []pubsubMessage
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/tracee/topics/events:publish", r.URL.Path)
+
+		var request struct {
+			Messages []pubsubMessage
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		messages = append(messages, request.Messages...)
+		ids := []string{}
+		for range request.Messages {
+			ids = append(ids, "1")
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(map[string][]string{"messageIds": ids}))
+	}))
+	defer server.Close()
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "pubsub",
+		OutPath: "tracee/events?endpoint=" + server.URL,
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, `invalid pubsub topic "tracee/events"`)
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "pubsub",
+		OutPath: "projects/tracee/topics/events?endpoint=" + server.URL + "&orderingKey={{.Container.ID}}-{{.ProcessName}}&batchSize=2&flushInterval=1h",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{EventName: "security_file_open", ProcessName: "cat", Container: trace.Container{ID: "abc"}})
+	p.Print(trace.Event{EventName: "sched_process_exec", ProcessName: "ls", Container: trace.Container{ID: "abc"}}) // failed, then published again
+	p.Print(trace.Event{EventName: "sched_process_exit", ProcessName: "ls", Container: trace.Container{ID: "def"}}) // published on close
+	p.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, messages, 3)
+	for i, expected := range []struct{ name, key, container string }{
+		{"security_file_open", "abc-cat", "abc"},
+		{"sched_process_exec", "abc-ls", "abc"},
+		{"sched_process_exit", "def-ls", "def"},
+	} {
+		assert.Equal(t, expected.key, messages[i].OrderingKey)
+		assert.Equal(t, expected.name, messages[i].Attributes["eventName"])
+		assert.Equal(t, expected.container, messages[i].Attributes["containerId"])
+		assert.Contains(t, string(messages[i].Data), `"eventName":"`+expected.name+`"`)
+	}
+}
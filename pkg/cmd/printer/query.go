@@ -0,0 +1,72 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/query"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// queryEventPrinter evaluates a jq query (--output-query) on the events, in their json form,
+// before printing them: an event the query outputs as is (e.g. select(...)) is printed with the
+// wrapped printer, the other results (e.g. {pid: .processId}) are printed one per line, in json
+// (the strings as is, in the table outputs). The events without results are not printed.
+type queryEventPrinter struct {
+	EventPrinter
+	query    *query.Query
+	out      io.Writer
+	schemaV2 bool // the event is queried in its json-v2 form
+	raw      bool // the strings results are printed as is
+}
+
+func (p queryEventPrinter) Print(event trace.Event) {
+	var data []byte
+	var err error
+	if p.schemaV2 {
+		data, err = event.MarshalJSONV2()
+	} else {
+		data, err = json.Marshal(event)
+	}
+	if err != nil {
+		logger.Errorw("Error marshaling event to json", "error", err)
+		return
+	}
+	input, err := query.Decode(data)
+	if err != nil {
+		logger.Errorw("Error decoding event json", "error", err)
+		return
+	}
+
+	err = p.query.Run(input, func(result any) error {
+		if isValue(result, input) {
+			p.EventPrinter.Print(event)
+			return nil
+		}
+		if s, ok := result.(string); ok && p.raw {
+			_, err := fmt.Fprintln(p.out, s)
+			return err
+		}
+		rBytes, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(rBytes))
+		return err
+	})
+	if err != nil {
+		logger.Errorw("Error evaluating output query", "query", p.query.String(), "event", event.EventName, "error", err)
+	}
+}
+
+// isValue returns true if the result of a query is its input object, unchanged.
+func isValue(result, input any) bool {
+	r, ok := result.(map[string]any)
+	if !ok {
+		return false
+	}
+	return reflect.ValueOf(r).UnsafePointer() == reflect.ValueOf(input).UnsafePointer()
+}
@@ -0,0 +1,103 @@
+package printer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/query"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestQueryPrinter(t *testing.T) {
+	t.Parallel()
+
+	events := []trace.Event{
+		{EventName: "openat", ProcessName: "sshd", ProcessID: 1},
+		{
+			EventName:   "execve",
+			ProcessName: "bash",
+			ProcessID:   42,
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/usr/bin/curl"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		kind     string
+		query    string
+		expected []string // lines printed
+	}{
+		{
+			name:     "json select",
+			kind:     "json",
+			query:    `select(.processName != "sshd")`,
+			expected: []string{`"eventName":"execve"`},
+		},
+		{
+			name:     "json transform",
+			kind:     "json",
+			query:    `{pid: .processId, event: .eventName}`,
+			expected: []string{`{"event":"openat","pid":1}`, `{"event":"execve","pid":42}`},
+		},
+		{
+			name:     "json string",
+			kind:     "json",
+			query:    `.args[]? | .value`,
+			expected: []string{`"/usr/bin/curl"`},
+		},
+		{
+			name:     "json-v2",
+			kind:     "json-v2",
+			query:    `.process_id`,
+			expected: []string{`1`, `42`},
+		},
+		{
+			name:     "table select",
+			kind:     "table",
+			query:    `select(.eventName == "execve")`,
+			expected: []string{`bash`},
+		},
+		{
+			name:     "table string",
+			kind:     "table",
+			query:    `.args[]? | select(.name == "pathname") | .value`,
+			expected: []string{`/usr/bin/curl`},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := query.Parse(tc.query)
+			require.NoError(t, err)
+
+			out := &bufferCloser{}
+			p, err := printer.New(config.PrinterConfig{
+				Kind:    tc.kind,
+				OutFile: out,
+				Query:   q,
+			})
+			require.NoError(t, err)
+
+			for _, event := range events {
+				p.Print(event)
+			}
+
+			lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+			require.Len(t, lines, len(tc.expected))
+			for i, expected := range tc.expected {
+				assert.Contains(t, lines[i], expected)
+			}
+		})
+	}
+}
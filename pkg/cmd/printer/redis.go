@@ -0,0 +1,165 @@
+package printer
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/redis"
+	"github.com/aquasecurity/tracee/pkg/utils/batcher"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultRedisStream        = "tracee"
+	defaultRedisMaxLen        = 100000
+	defaultRedisBatchSize     = 100
+	defaultRedisFlushInterval = 100 * time.Millisecond
+)
+
+// redisEventPrinter appends the events to a Redis stream, trimmed to a maximum length. The
+// events are batched, and the batches are sent in the background, each in a pipeline (a round
+// trip).
+type redisEventPrinter struct {
+	outPath       string
+	opts          redis.Options
+	stream        string
+	maxLen        int64
+	approximate   bool
+	batchSize     int
+	flushInterval time.Duration
+	queueSize     int
+
+	client *goredis.Client
+	queue  *batcher.Queue[*goredis.XAddArgs]
+}
+
+// Init parses the url: {redis,rediss}://[[user]:password@]host[:port][/db]. The stream,
+// maxLen, approximate, batchSize, flushInterval, queueSize and timeout parameters configure the printer.
+func (p *redisEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+	parameters := u.Query()
+
+	p.opts.Addr = u.Host
+	if u.Port() == "" {
+		p.opts.Addr += ":6379"
+	}
+	switch u.Scheme {
+	case "redis":
+	case "rediss":
+		p.opts.TLS = &tls.Config{ServerName: u.Hostname()}
+	default:
+		return errfmt.Errorf("unsupported protocol for Redis destination: %s", u.Scheme)
+	}
+	if db := strings.TrimPrefix(u.Path, "/"); db != "" {
+		p.opts.DB, err = strconv.Atoi(db)
+		if err != nil || p.opts.DB < 0 {
+			return errfmt.Errorf("invalid Redis database %q", db)
+		}
+	}
+	p.opts.Username = u.User.Username()
+	p.opts.Password, _ = u.User.Password()
+
+	p.stream = getParameterValue(parameters, "stream", defaultRedisStream)
+
+	maxLen := getParameterValue(parameters, "maxLen", strconv.Itoa(defaultRedisMaxLen))
+	p.maxLen, err = strconv.ParseInt(maxLen, 10, 64)
+	if err != nil || p.maxLen < 0 {
+		return errfmt.Errorf("invalid maxLen value %q", maxLen)
+	}
+
+	approximate := getParameterValue(parameters, "approximate", "true")
+	p.approximate, err = strconv.ParseBool(approximate)
+	if err != nil {
+		return errfmt.Errorf("invalid approximate value %q", approximate)
+	}
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultRedisBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 {
+		return errfmt.Errorf("invalid batchSize value %q", batchSize)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultRedisFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	p.queueSize, err = parseQueueSize(parameters)
+	if err != nil {
+		return err
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	p.opts.Timeout, err = time.ParseDuration(timeout)
+	if err != nil || p.opts.Timeout <= 0 {
+		return errfmt.Errorf("invalid timeout value %q", timeout)
+	}
+
+	p.client = redis.NewClient(p.opts)
+	logger.Infow("Attempting to connect to Redis destination", "address", p.opts.Addr, "stream", p.stream)
+	if err := p.ping(); err != nil {
+		// The destination may not be available but may appear later so do not return an error here and just connect later.
+		logger.Errorw("Error connecting to Redis destination", "address", p.opts.Addr, "error", err)
+	}
+
+	p.queue, err = batcher.NewQueue(batcher.QueueConfig{
+		MaxItems:  p.batchSize,
+		Interval:  p.flushInterval,
+		QueueSize: p.queueSize,
+	}, nil, p.send)
+	if err != nil {
+		_ = p.client.Close()
+		return err
+	}
+
+	return nil
+}
+
+// ping checks the connection to the server.
+func (p *redisEventPrinter) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+	defer cancel()
+
+	return errfmt.WrapError(p.client.Ping(ctx).Err())
+}
+
+func (p *redisEventPrinter) Preamble() {}
+
+func (p *redisEventPrinter) Print(event trace.Event) {
+	fields, err := redis.EventFields(&event)
+	if err != nil {
+		logger.Errorw("Error encoding event to Redis", "error", err)
+		return
+	}
+
+	p.queue.Add(redis.XAddArgs(p.stream, p.maxLen, p.approximate, fields))
+}
+
+// send sends a batch of entries in a pipeline, in the sending goroutine of the queue. The
+// client connects again when the connection failed.
+func (p *redisEventPrinter) send(entries []*goredis.XAddArgs) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.opts.Timeout)
+	defer cancel()
+
+	return redis.XAdd(ctx, p.client, entries)
+}
+
+func (p *redisEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *redisEventPrinter) Close() {
+	p.queue.Close()
+	_ = p.client.Close()
+}
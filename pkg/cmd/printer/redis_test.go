@@ -0,0 +1,49 @@
+package printer_test
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestRedisPrinter(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "redis",
+		OutPath: "http://" + server.Addr(),
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, "unsupported protocol for Redis destination: http")
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "redis",
+		OutPath: "redis://:secret@" + server.Addr() + "/1?stream=events&maxLen=2&batchSize=2&flushInterval=1h",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{EventName: "openat", ProcessName: "cat"})
+	p.Print(trace.Event{EventName: "execve", ProcessName: "bash"}) // a full batch
+	p.Print(trace.Event{EventName: "close", ProcessName: "cat"})   // sent on close
+	p.Close()
+
+	// the stream is trimmed to maxLen entries
+	entries, err := server.DB(1).Stream("events")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for i, name := range []string{"execve", "close"} {
+		require.Len(t, entries[i].Values, 8)
+		assert.Equal(t, []string{"event", name}, entries[i].Values[:2])
+		assert.Contains(t, entries[i].Values[7], `"eventName":"`+name+`"`)
+	}
+}
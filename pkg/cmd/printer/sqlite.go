@@ -0,0 +1,160 @@
+package printer
+
+import (
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/eventstore"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultSQLiteBatchSize     = 1000
+	defaultSQLiteFlushInterval = time.Second
+	sqlitePruneInterval        = time.Minute
+)
+
+// sqliteEventPrinter stores the events in a local SQLite database, in batches, removing the
+// events beyond the retention.
+type sqliteEventPrinter struct {
+	outPath       string // database path, with the sink parameters
+	store         *eventstore.Store
+	batchSize     int
+	flushInterval time.Duration
+	retention     time.Duration // 0 to keep the events
+	maxEvents     int64         // 0 for no limit
+
+	mu     sync.Mutex
+	events []trace.Event
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// Init opens the database. The retention, maxEvents, batchSize and flushInterval parameters of
+// the path configure the printer.
+func (p *sqliteEventPrinter) Init() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse sqlite path %q: %v", p.outPath, err)
+	}
+	parameters := u.Query()
+
+	retention := getParameterValue(parameters, "retention", "0s")
+	p.retention, err = time.ParseDuration(retention)
+	if err != nil || p.retention < 0 {
+		return errfmt.Errorf("invalid retention value %q", retention)
+	}
+
+	maxEvents := getParameterValue(parameters, "maxEvents", "0")
+	p.maxEvents, err = strconv.ParseInt(maxEvents, 10, 64)
+	if err != nil || p.maxEvents < 0 {
+		return errfmt.Errorf("invalid maxEvents value %q", maxEvents)
+	}
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultSQLiteBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 {
+		return errfmt.Errorf("invalid batchSize value %q", batchSize)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultSQLiteFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	p.store, err = eventstore.Open(u.Path)
+	if err != nil {
+		return err
+	}
+	p.prune()
+
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		flushTicker := time.NewTicker(p.flushInterval)
+		defer flushTicker.Stop()
+		pruneTicker := time.NewTicker(sqlitePruneInterval)
+		defer pruneTicker.Stop()
+
+		for {
+			select {
+			case <-flushTicker.C:
+				p.mu.Lock()
+				p.flush()
+				p.mu.Unlock()
+			case <-pruneTicker.C:
+				p.prune()
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (p *sqliteEventPrinter) Preamble() {}
+
+func (p *sqliteEventPrinter) Print(event trace.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, event)
+	if len(p.events) >= p.batchSize {
+		p.flush()
+	}
+}
+
+// flush stores the pending events. It is called with the lock held.
+func (p *sqliteEventPrinter) flush() {
+	if len(p.events) == 0 {
+		return
+	}
+	if err := p.store.Insert(p.events); err != nil {
+		logger.Errorw("Error storing events to sqlite", "events", len(p.events), "error", err)
+	}
+	p.events = p.events[:0]
+}
+
+// prune removes the events beyond the retention.
+func (p *sqliteEventPrinter) prune() {
+	if p.retention == 0 && p.maxEvents == 0 {
+		return
+	}
+
+	var before time.Time
+	if p.retention > 0 {
+		before = time.Now().Add(-p.retention)
+	}
+	removed, err := p.store.Prune(before, p.maxEvents)
+	if err != nil {
+		logger.Errorw("Error pruning sqlite events", "error", err)
+		return
+	}
+	if removed > 0 {
+		logger.Debugw("Pruned sqlite events", "events", removed)
+	}
+}
+
+func (p *sqliteEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *sqliteEventPrinter) Close() {
+	close(p.done)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	p.flush()
+	p.mu.Unlock()
+
+	if err := p.store.Close(); err != nil {
+		logger.Errorw("Error closing sqlite events database", "error", err)
+	}
+}
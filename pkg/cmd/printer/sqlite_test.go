@@ -0,0 +1,56 @@
+package printer_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestSQLitePrinter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.db")
+	now := time.Now()
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "sqlite",
+		OutPath: path + "?retention=1h&batchSize=2",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(trace.Event{Timestamp: int(now.Add(-2 * time.Hour).UnixNano()), EventName: "openat", HostProcessID: 1})
+	p.Print(trace.Event{Timestamp: int(now.UnixNano()), EventName: "execve", HostProcessID: 2}) // batch is full
+	p.Print(trace.Event{Timestamp: int(now.UnixNano()), EventName: "openat", HostProcessID: 3})
+	p.Close()
+
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	// the pending events are stored on close, the old event was stored (retention is pruned
+	// when the printer starts, and then every minute)
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM events WHERE event = 'openat'`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	// reopened, the events beyond the retention are pruned
+	p, err = printer.New(config.PrinterConfig{
+		Kind:    "sqlite",
+		OutPath: path + "?retention=1h",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+	p.Close()
+
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM events`).Scan(&count))
+	assert.Equal(t, 2, count)
+}
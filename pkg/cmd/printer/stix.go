@@ -0,0 +1,202 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/stix"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	defaultSTIXBatchSize     = 100
+	defaultSTIXFlushInterval = 10 * time.Second
+
+	taxiiMediaType = "application/taxii+json;version=2.1"
+)
+
+// stixEventPrinter exports the findings (other events are ignored) as STIX 2.1 bundles,
+// in batches: to a file (one bundle per line), or to a TAXII 2.1 collection.
+type stixEventPrinter struct {
+	out           io.WriteCloser // file output, nil for a taxii output
+	outPath       string         // taxii collection objects url
+	url           *url.URL
+	client        *http.Client
+	batchSize     int
+	flushInterval time.Duration
+
+	mu       sync.Mutex
+	findings []trace.Event
+	done     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func (p *stixEventPrinter) Init() error {
+	p.batchSize = defaultSTIXBatchSize
+	p.flushInterval = defaultSTIXFlushInterval
+
+	if p.out == nil {
+		if err := p.initTAXII(); err != nil {
+			return err
+		}
+	}
+
+	p.done = make(chan struct{})
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.mu.Lock()
+				p.flush()
+				p.mu.Unlock()
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+// initTAXII parses the collection url. The batchSize, flushInterval and timeout
+// parameters configure the printer, and aren't sent to the TAXII server.
+func (p *stixEventPrinter) initTAXII() error {
+	u, err := url.Parse(p.outPath)
+	if err != nil {
+		return errfmt.Errorf("unable to parse URL %q: %v", p.outPath, err)
+	}
+
+	parameters := u.Query()
+
+	batchSize := getParameterValue(parameters, "batchSize", strconv.Itoa(defaultSTIXBatchSize))
+	p.batchSize, err = strconv.Atoi(batchSize)
+	if err != nil || p.batchSize < 1 {
+		return errfmt.Errorf("invalid batchSize value %q", batchSize)
+	}
+
+	flushInterval := getParameterValue(parameters, "flushInterval", defaultSTIXFlushInterval.String())
+	p.flushInterval, err = time.ParseDuration(flushInterval)
+	if err != nil || p.flushInterval <= 0 {
+		return errfmt.Errorf("invalid flushInterval value %q", flushInterval)
+	}
+
+	timeout := getParameterValue(parameters, "timeout", "10s")
+	t, err := time.ParseDuration(timeout)
+	if err != nil {
+		return errfmt.Errorf("unable to convert timeout value %q: %v", timeout, err)
+	}
+	p.client = &http.Client{Timeout: t}
+
+	for _, key := range []string{"batchSize", "flushInterval", "timeout"} {
+		parameters.Del(key)
+	}
+	u.RawQuery = parameters.Encode()
+	p.url = u
+
+	return nil
+}
+
+func (p *stixEventPrinter) Preamble() {}
+
+func (p *stixEventPrinter) Print(event trace.Event) {
+	if event.Metadata == nil { // findings are the only events with signature metadata
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.findings = append(p.findings, event)
+	if len(p.findings) >= p.batchSize {
+		p.flush()
+	}
+}
+
+// flush exports the pending findings, as a bundle. It is called with the lock held.
+func (p *stixEventPrinter) flush() {
+	if len(p.findings) == 0 {
+		return
+	}
+	bundle := stix.NewBundle(p.findings)
+	count := len(p.findings)
+	p.findings = nil
+
+	var err error
+	if p.out != nil {
+		err = p.writeBundle(bundle)
+	} else {
+		err = p.postBundle(bundle)
+	}
+	if err != nil {
+		logger.Errorw("Error exporting findings to STIX", "findings", count, "error", err)
+	}
+}
+
+func (p *stixEventPrinter) writeBundle(bundle stix.Bundle) error {
+	bBytes, err := json.Marshal(bundle)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	_, err = fmt.Fprintln(p.out, string(bBytes))
+
+	return err
+}
+
+// postBundle adds the bundle objects to the TAXII collection, as an envelope.
+func (p *stixEventPrinter) postBundle(bundle stix.Bundle) error {
+	payload, err := json.Marshal(map[string]interface{}{"objects": bundle.Objects})
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url.String(), bytes.NewReader(payload))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	req.Header.Set("Content-Type", taxiiMediaType)
+	req.Header.Set("Accept", taxiiMediaType)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return errfmt.Errorf("taxii server http status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (p *stixEventPrinter) Epilogue(stats metrics.Stats) {}
+
+func (p *stixEventPrinter) Close() {
+	close(p.done)
+	p.wg.Wait()
+
+	p.mu.Lock()
+	p.flush()
+	p.mu.Unlock()
+
+	if p.out != nil {
+		closeOut(p.out)
+	}
+}
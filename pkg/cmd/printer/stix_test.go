@@ -0,0 +1,116 @@
+package printer_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newFindingEvent(signatureID string) trace.Event {
+	return trace.Event{
+		EventName: "fake_signature",
+		Metadata: &trace.Metadata{
+			Properties: map[string]interface{}{"signatureID": signatureID},
+		},
+	}
+}
+
+func TestSTIXPrinter(t *testing.T) {
+	t.Parallel()
+
+	out := &bufferCloser{}
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "stix",
+		OutFile: out,
+	})
+	require.NoError(t, err)
+
+	p.Preamble()
+	p.Print(trace.Event{EventName: "openat"}) // not a finding
+	p.Print(newFindingEvent("TRC-1"))
+	p.Print(newFindingEvent("TRC-2"))
+	p.Close()
+
+	// the findings are flushed on close, as a single bundle
+	scanner := bufio.NewScanner(&out.Buffer)
+	var bundles []map[string]interface{}
+	for scanner.Scan() {
+		var bundle map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &bundle))
+		bundles = append(bundles, bundle)
+	}
+	require.Len(t, bundles, 1)
+	assert.Equal(t, "bundle", bundles[0]["type"])
+	// per finding: an indicator, a process, an observed-data and a sighting
+	assert.Len(t, bundles[0]["objects"], 8)
+}
+
+func TestTAXIIPrinter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu        sync.Mutex
+		envelopes []map[string][]interface{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/api/collections/tracee/objects/", req.URL.Path)
+		assert.Empty(t, req.URL.RawQuery)
+		assert.Equal(t, "application/taxii+json;version=2.1", req.Header.Get("Content-Type"))
+		user, password, ok := req.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", password)
+
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		var envelope map[string][]interface{}
+		require.NoError(t, json.Unmarshal(body, &envelope))
+
+		mu.Lock()
+		envelopes = append(envelopes, envelope)
+		mu.Unlock()
+
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "taxii",
+		OutPath: "http://user:pass@" + server.Listener.Addr().String() + "/api/collections/tracee/objects/?batchSize=2",
+		OutFile: &bufferCloser{},
+	})
+	require.NoError(t, err)
+
+	p.Print(newFindingEvent("TRC-1"))
+	p.Print(newFindingEvent("TRC-2")) // batch is full
+	p.Print(newFindingEvent("TRC-3"))
+	p.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, envelopes, 2)
+	assert.Len(t, envelopes[0]["objects"], 8)
+	assert.Len(t, envelopes[1]["objects"], 4)
+}
+
+func TestTAXIIPrinterInvalidParameters(t *testing.T) {
+	t.Parallel()
+
+	_, err := printer.New(config.PrinterConfig{
+		Kind:    "taxii",
+		OutPath: "http://localhost/api/collections/tracee/objects/?batchSize=0",
+		OutFile: &bufferCloser{},
+	})
+	assert.ErrorContains(t, err, "invalid batchSize")
+}
@@ -0,0 +1,35 @@
+package printer
+
+import (
+	"path/filepath"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// parseTemplate parses a Go template file formatting the events of a sink (gotemplate output, or
+// gotemplate parameter of a forward or webhook output), with the Sprig functions: e.g. toJson, to
+// render the json payload of a Slack or Teams webhook.
+func parseTemplate(path string) (*template.Template, error) {
+	tmpl, err := template.New(filepath.Base(path)).
+		Funcs(sprig.TxtFuncMap()).
+		ParseFiles(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return tmpl, nil
+}
+
+// parseParameterTemplate parses a Go template given as a sink parameter (e.g. the routing key
+// of an amqp output, or the topic of an mqtt output), with the Sprig functions.
+func parseParameterTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(text)
+	if err != nil {
+		return nil, errfmt.Errorf("invalid %s template %q: %v", name, text, err)
+	}
+
+	return tmpl, nil
+}
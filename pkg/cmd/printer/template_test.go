@@ -0,0 +1,119 @@
+package printer_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cmd/printer"
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+var templateEvents = []trace.Event{
+	{
+		EventName:     "anti_debugging",
+		HostName:      "node-a",
+		ProcessName:   "strace",
+		HostProcessID: 42,
+		Container:     trace.Container{Name: "nginx"},
+		Metadata: &trace.Metadata{
+			Description: `A "process" used ptrace`,
+			Severity:    "high",
+			Properties:  map[string]interface{}{"signatureName": "Anti-Debugging detected"},
+		},
+	},
+	{EventName: "execve", HostName: "node-a", ProcessName: "bash"},
+}
+
+func TestTemplatePrinterSprig(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "upper.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("{{ .EventName | upper }} {{ .HostName | quote }}\n"), 0600))
+
+	out := &bufferCloser{}
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "gotemplate=" + path,
+		OutFile: out,
+	})
+	require.NoError(t, err)
+
+	for _, event := range templateEvents {
+		p.Print(event)
+	}
+	assert.Equal(t, "ANTI_DEBUGGING \"node-a\"\nEXECVE \"node-a\"\n", out.String())
+}
+
+// TestChatTemplates checks that the example Slack and Teams templates render valid json payloads.
+func TestChatTemplates(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"slack.tmpl", "teams.tmpl"} {
+		name := name
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			out := &bufferCloser{}
+			p, err := printer.New(config.PrinterConfig{
+				Kind:    "gotemplate=" + filepath.Join("..", "..", "..", "examples", "templates", name),
+				OutFile: out,
+			})
+			require.NoError(t, err)
+
+			for _, event := range templateEvents {
+				out.Reset()
+				p.Print(event)
+
+				var payload map[string]interface{}
+				require.NoError(t, json.Unmarshal(out.Bytes(), &payload), out.String())
+			}
+
+			out.Reset()
+			p.Print(templateEvents[0])
+			assert.Contains(t, out.String(), "Anti-Debugging detected")
+			assert.Contains(t, out.String(), "nginx")
+		})
+	}
+}
+
+func TestWebhookPrinterTemplate(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		query       string
+		contentType string
+		body        string
+	}
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{r.URL.RawQuery, r.Header.Get("Content-Type"), string(body)}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "chat.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"text": {{ .EventName | toJson }}}`), 0600))
+
+	p, err := printer.New(config.PrinterConfig{
+		Kind:    "webhook",
+		OutPath: server.URL + "?token=abc&gotemplate=" + path + "&timeout=5s&contentType=application/vnd.chat%2Bjson",
+		OutFile: os.Stdout,
+	})
+	require.NoError(t, err)
+
+	p.Print(templateEvents[1])
+
+	r := <-requests
+	assert.Equal(t, "token=abc", r.query) // the sink parameters aren't sent
+	assert.Equal(t, "application/vnd.chat+json", r.contentType)
+	assert.Equal(t, `{"text": "execve"}`, r.body)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/seccomp"
+)
+
+// confine writes the seccomp profile of the syscalls tracee needs and installs its seccomp
+// filter on tracee itself, as configured.
+func confine(cfg seccomp.Config) error {
+	syscalls := cfg.Syscalls()
+
+	if cfg.ProfilePath != "" {
+		if err := seccomp.WriteProfile(cfg.ProfilePath, syscalls); err != nil {
+			return errfmt.Errorf("error writing seccomp profile: %v", err)
+		}
+		logger.Infow("Seccomp profile written", "path", cfg.ProfilePath, "syscalls", len(syscalls))
+	}
+
+	if cfg.Mode == seccomp.ModeNone {
+		return nil
+	}
+
+	// the syscall events IDs are the syscall numbers of the architecture
+	nrs := make([]uint32, 0, len(syscalls))
+	for _, name := range syscalls {
+		id, ok := events.Core.GetDefinitionIDByName(name)
+		if !ok || !events.Core.GetDefinitionByID(id).IsSyscall() {
+			return errfmt.Errorf("invalid syscall name: %s", name)
+		}
+		if id >= events.Unsupported {
+			continue // not a syscall of the architecture
+		}
+		nrs = append(nrs, uint32(id))
+	}
+
+	if err := seccomp.Apply(cfg.Mode, nrs); err != nil {
+		return errfmt.WrapError(err)
+	}
+	logger.Infow("Tracee confined with seccomp", "syscalls", len(nrs), "audit", cfg.Mode == seccomp.ModeAudit)
+
+	return nil
+}
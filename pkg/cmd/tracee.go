@@ -6,22 +6,39 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/aquasecurity/tracee/pkg/cmd/flags"
 	"github.com/aquasecurity/tracee/pkg/cmd/printer"
 	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/controlplane"
 	tracee "github.com/aquasecurity/tracee/pkg/ebpf"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events/flamegraph"
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+	"github.com/aquasecurity/tracee/pkg/events/window"
 	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/oci"
+	"github.com/aquasecurity/tracee/pkg/seccomp"
 	"github.com/aquasecurity/tracee/pkg/server/grpc"
 	"github.com/aquasecurity/tracee/pkg/server/http"
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
 	"github.com/aquasecurity/tracee/pkg/utils"
+	"github.com/aquasecurity/tracee/types/trace"
 )
 
 type Runner struct {
-	TraceeConfig config.Config
-	Printer      printer.EventPrinter
-	InstallPath  string
-	HTTPServer   *http.Server
-	GRPCServer   *grpc.Server
+	TraceeConfig   config.Config
+	Printer        printer.EventPrinter
+	InstallPath    string
+	HTTPServer     *http.Server
+	DataHTTPServer *http.Server // captured data endpoints, separate from HTTPServer
+	GRPCServer     *grpc.Server
+	EventWindow    *window.Window
+	Timeline       *timeline.Recorder
+	Flamegraph     *flamegraph.Aggregator
+	ControlPlane   *controlplane.Client
+	OCIPuller      *oci.Puller
+	RegoData       *regosig.Data
+	Seccomp        seccomp.Config
 }
 
 func (r Runner) Run(ctx context.Context) error {
@@ -32,10 +49,25 @@ func (r Runner) Run(ctx context.Context) error {
 		return errfmt.Errorf("error creating Tracee: %v", err)
 	}
 
+	// Forensic snapshots are taken on demand, through the data http server
+
+	if r.DataHTTPServer != nil && r.TraceeConfig.SnapshotDir != "" {
+		logger.Debugw("Enabling snapshot endpoint", "dir", r.TraceeConfig.SnapshotDir)
+		r.DataHTTPServer.EnableSnapshotEndpoint(t)
+	}
+
 	// Readiness Callback: Tracee is ready to receive events
 	t.AddReadyCallback(
 		func(ctx context.Context) {
 			logger.Debugw("Tracee is ready callback")
+			if r.DataHTTPServer != nil {
+				// the artifacts index is opened once tracee is initialized
+				if index := t.Artifacts(); index != nil {
+					logger.Debugw("Enabling artifacts endpoint")
+					r.DataHTTPServer.EnableArtifactsEndpoint(index)
+				}
+				go r.DataHTTPServer.Start(ctx)
+			}
 			if r.HTTPServer != nil {
 				if r.HTTPServer.MetricsEndpointEnabled() {
 					r.TraceeConfig.MetricsEnabled = true // TODO: is this needed ?
@@ -50,6 +82,27 @@ func (r Runner) Run(ctx context.Context) error {
 			if r.GRPCServer != nil {
 				go r.GRPCServer.Start(ctx, t, t.Engine())
 			}
+
+			// push findings and stats to the control plane, if one is configured
+			if r.ControlPlane != nil {
+				go r.ControlPlane.Run(ctx, t, func(policiesYAML []byte) error {
+					policies, err := flags.CreatePoliciesFromControlPlane(policiesYAML)
+					if err != nil {
+						return err
+					}
+					return t.UpdatePolicies(policies)
+				})
+			}
+
+			// check the pulled detection content for updates
+			if r.OCIPuller != nil {
+				go r.OCIPuller.Run(ctx)
+			}
+
+			// refresh the data documents of the rego signatures
+			if r.RegoData != nil {
+				go r.RegoData.Run(ctx)
+			}
 		},
 	)
 
@@ -60,6 +113,19 @@ func (r Runner) Run(ctx context.Context) error {
 		return errfmt.Errorf("error initializing Tracee: %v", err)
 	}
 
+	// Exit once the events output is handed over to a new tracee
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-t.HandedOff():
+			logger.Infow("Events output handed over to a new tracee, exiting")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	// Manage PID file
 
 	if err := os.MkdirAll(r.InstallPath, 0755); err != nil {
@@ -79,11 +145,24 @@ func (r Runner) Run(ctx context.Context) error {
 		return errfmt.WrapError(err)
 	}
 	defer func() {
+		select {
+		case <-t.HandedOff():
+			return // the pid file is the new tracee one
+		default:
+		}
 		if err := removePidFile(installPathDir); err != nil {
 			logger.Warnw("error removing pid file", "error", err)
 		}
 	}()
 
+	// Confine tracee to the syscalls it needs, once initialized
+
+	if r.Seccomp.Enabled() {
+		if err := confine(r.Seccomp); err != nil {
+			return errfmt.Errorf("error confining Tracee: %v", err)
+		}
+	}
+
 	stream := t.SubscribeAll()
 	defer t.Unsubscribe(stream)
 
@@ -97,7 +176,7 @@ func (r Runner) Run(ctx context.Context) error {
 		for {
 			select {
 			case event := <-stream.ReceiveEvents():
-				r.Printer.Print(event)
+				r.handleEvent(event)
 			case <-ctx.Done():
 				return
 			}
@@ -110,16 +189,62 @@ func (r Runner) Run(ctx context.Context) error {
 	// Drain remaininig channel events (sent during shutdown),
 	// the channel is closed by the tracee when it's done
 	for event := range stream.ReceiveEvents() {
-		r.Printer.Print(event)
+		r.handleEvent(event)
 	}
 
 	stats := t.Stats()
 	r.Printer.Epilogue(*stats)
 	r.Printer.Close()
 
+	if r.EventWindow != nil {
+		if err := r.EventWindow.Close(); err != nil {
+			logger.Errorw("Closing events window", "error", err)
+		}
+		if dropped := r.EventWindow.Dropped(); dropped > 0 {
+			logger.Warnw("Events dropped from the events window", "dropped", dropped)
+		}
+	}
+
+	if r.Timeline != nil {
+		if err := r.Timeline.Close(); err != nil {
+			logger.Errorw("Closing process timelines", "error", err)
+		}
+		if dropped := r.Timeline.Dropped(); dropped > 0 {
+			logger.Warnw("Process timeline reports dropped", "dropped", dropped)
+		}
+	}
+
+	if r.Flamegraph != nil {
+		if err := r.Flamegraph.Close(); err != nil {
+			logger.Errorw("Closing flamegraph", "error", err)
+		}
+	}
+
+	// The new tracee writes the events following the last one written, once the outputs are closed
+
+	if err := t.CloseHandoff(); err != nil {
+		logger.Errorw("Replying to the new tracee, events might be duplicated", "error", err)
+	}
+
 	return err
 }
 
+// handleEvent prints the event and retains it in the events window and in the process
+// timelines, and aggregates its stack trace, if enabled.
+func (r Runner) handleEvent(event trace.Event) {
+	r.Printer.Print(event)
+
+	if r.EventWindow != nil {
+		r.EventWindow.Add(event)
+	}
+	if r.Timeline != nil {
+		r.Timeline.Add(event)
+	}
+	if r.Flamegraph != nil {
+		r.Flamegraph.Add(event)
+	}
+}
+
 func GetContainerMode(containerFilterEnabled, noContainersEnrich bool) config.ContainerMode {
 	if !containerFilterEnabled {
 		return config.ContainerModeDisabled
@@ -24,6 +24,7 @@ func GetTraceeRunner(c *cli.Context, version string) (cmd.Runner, error) {
 		PerfBufferSize:     c.Int("perf-buffer-size"),
 		BlobPerfBufferSize: c.Int("blob-perf-buffer-size"),
 		NoContainersEnrich: c.Bool("no-containers"),
+		MetricsEnabled:     c.Bool(server.MetricsEndpointFlag),
 	}
 
 	// Output command line flags
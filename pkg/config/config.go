@@ -2,16 +2,39 @@ package config
 
 import (
 	"io"
+	"time"
 
 	"github.com/aquasecurity/libbpfgo/helpers"
 
+	"github.com/aquasecurity/tracee/pkg/artifacts"
+	"github.com/aquasecurity/tracee/pkg/btfhub"
+	"github.com/aquasecurity/tracee/pkg/cgroup/pressure"
 	"github.com/aquasecurity/tracee/pkg/containers/runtime"
 	"github.com/aquasecurity/tracee/pkg/dnscache"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/granularity"
 	"github.com/aquasecurity/tracee/pkg/events/queue"
+	"github.com/aquasecurity/tracee/pkg/events/truncation"
+	"github.com/aquasecurity/tracee/pkg/handoff"
+	"github.com/aquasecurity/tracee/pkg/integrity"
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
 	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
 	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/pkg/query"
+	"github.com/aquasecurity/tracee/pkg/sbom"
+	"github.com/aquasecurity/tracee/pkg/selflimit"
+	"github.com/aquasecurity/tracee/pkg/signatures/dedup"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
+	"github.com/aquasecurity/tracee/pkg/signatures/findingcontext"
+	"github.com/aquasecurity/tracee/pkg/signatures/signature"
+	"github.com/aquasecurity/tracee/pkg/signatures/suppression"
+	"github.com/aquasecurity/tracee/pkg/symbolizer"
+	"github.com/aquasecurity/tracee/pkg/threatintel"
+	"github.com/aquasecurity/tracee/pkg/utils/filewriter"
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+	"github.com/aquasecurity/tracee/pkg/yara"
 )
 
 // Config is a struct containing user defined configuration of tracee
@@ -26,6 +49,7 @@ type Config struct {
 	BlobPerfBufferSize int
 	MaxPidsCache       int // maximum number of pids to cache per mnt ns (in Tracee.pidsInMntns)
 	BTFObjPath         string
+	BTFHub             btfhub.Config // BTFHub archives and mirrors the missing kernel BTF is fetched from
 	BPFObjBytes        []byte
 	KernelConfig       *helpers.KernelConfig
 	OSInfo             *helpers.OSInfo
@@ -34,6 +58,31 @@ type Config struct {
 	EngineConfig       engine.Config
 	MetricsEnabled     bool
 	DNSCacheConfig     dnscache.Config
+	PipelineCPUs       []int                   // CPUs the pipeline goroutines are pinned to (not pinned if empty)
+	SnapshotDir        string                  // directory of the forensic snapshot bundles (snapshots disabled if empty)
+	Labels             map[string]string       // labels attached to every event
+	SuppressionRules   []suppression.Rule      // allowlist rules of the findings
+	FindingContext     findingcontext.Config   // events attached to the findings (disabled if not enabled)
+	FindingDedup       dedup.Config            // identical findings collapsed (disabled if not enabled)
+	ThreatIntel        threatintel.Config      // threat intel feeds matched by ioc_match (disabled if no feeds)
+	Yara               yara.Config             // YARA scanning of the captured artifacts (disabled if no rules)
+	SignaturePlugins   []signature.PluginAudit // load audits of the Go signature plugins
+	K8sAudit           k8saudit.Config         // kubernetes audit logs correlated by k8s_audit_correlation (disabled if no input)
+	CgroupPressure     pressure.Config         // containers cgroups resource pressure monitoring (cgroup_*_pressure events)
+	FlowStatsInterval  time.Duration           // interval between two net_flow_summary events of a flow (on close only if 0)
+	SensitiveFiles     []string                // files patterns matched by sensitive_file_access
+	RuntimeSBOM        sbom.Config             // containers runtime SBOM reports (runtime_sbom events)
+	VulnDB             vulndb.Config           // vulnerability DB matched by vulnerable_library_loaded (disabled if no files)
+	BPFMaps            BPFMapsConfig           // eBPF maps sizes and fill level monitoring
+	PinPath            string                  // bpffs directory of the state eBPF maps kept across restarts (not pinned if empty)
+	PerfBufferTuning   PerfBufferTuningConfig  // events perf buffer loss rate monitoring and sizing
+	SplitPipeline      SplitPipelineConfig     // events pipeline split between the nodes and a remote processor
+	SelfLimits         selflimit.Config        // CPU and memory self-limits, load shed once exceeded (none if not enabled)
+	Handoff            handoff.Config          // events output handed over from a running tracee (no handoff if not enabled)
+	StackSymbols       symbolizer.Config       // symbolization of the stack addresses (disabled if not enabled)
+	PolicyQuotas       []quota.Config          // events quotas of the policies, sampled once exceeded
+	Granularity        granularity.Config      // events coalesced at process level (all per-thread if not enabled)
+	ArgLimits          truncation.Config       // size limits of the events arguments (none if not enabled)
 }
 
 // Validate does static validation of the configuration
@@ -85,6 +134,7 @@ type CaptureConfig struct {
 	Mem        bool
 	Bpf        bool
 	Net        PcapsConfig
+	Index      artifacts.Config // index and retention of the captured artifacts (disabled if not enabled)
 }
 
 type FileCaptureConfig struct {
@@ -127,9 +177,44 @@ type PcapsConfig struct {
 //
 
 type CapabilitiesConfig struct {
-	BypassCaps bool
-	AddCaps    []string
-	DropCaps   []string
+	BypassCaps   bool
+	Unprivileged bool // CAP_BPF and CAP_PERFMON only, disabling the events needing more privileges
+	AddCaps      []string
+	DropCaps     []string
+}
+
+//
+// eBPF maps
+//
+
+// BPFMapsConfig is the sizing and the fill level monitoring of the eBPF maps.
+type BPFMapsConfig struct {
+	Sizes         map[string]uint32 // max entries of the resized maps, by map name
+	Interval      time.Duration     // interval between two fill level checks (not monitored if 0)
+	WarnThreshold float64           // fill level (percent) a map is reported under pressure from
+}
+
+// PerfBufferTuningConfig is the monitoring of the events perf buffer loss rate, and the
+// sizing of the buffer it recommends.
+type PerfBufferTuningConfig struct {
+	Interval      time.Duration // interval between two loss rate checks (not tuned if 0)
+	LossThreshold float64       // loss rate (percent) doubling the recommended size
+	MaxSize       int           // max recommended size (pages per cpu)
+	StagePath     string        // file staging the recommended size for the next start (not staged if empty)
+}
+
+// Modes of a split events pipeline
+const (
+	SplitPipelineProducer  = "producer"  // decodes, processes and derives the events of the node
+	SplitPipelineProcessor = "processor" // runs the signatures engine and the outputs of the forwarded events
+)
+
+// SplitPipelineConfig is the split of the events pipeline between the nodes producing the
+// events and a remote processor.
+type SplitPipelineConfig struct {
+	Mode     string // SplitPipelineProducer or SplitPipelineProcessor (not split if empty)
+	Protocol string // tcp or unix
+	Address  string // address of the processor (listened by the processor, dialed by the producers)
 }
 
 //
@@ -161,16 +246,38 @@ func (c CalcHashesOption) String() string {
 }
 
 type OutputConfig struct {
-	StackAddresses bool
-	ExecEnv        bool
-	RelativeTime   bool
-	CalcHashes     CalcHashesOption
+	StackAddresses       bool
+	StackAddressesEvents []events.ID // events to capture the stack trace of, if not of all events
+	ExecEnv              bool
+	ExecBuildID          bool // build ID and Go build information of the executed binaries
+	CanonicalPaths       bool // canonical absolute paths of the syscalls path arguments
+	ContainerPaths       bool // host paths of the path arguments of the containers events
+	RelativeTime         bool
+	TimeRFC3339          bool           // RFC3339 time of the events, along with their timestamp in nanoseconds
+	TimeZone             *time.Location // time zone of the printed times (local if nil)
+	CalcHashes           CalcHashesOption
 
 	ParseArguments    bool
 	ParseArgumentsFDs bool
 	EventsSorting     bool
+
+	FileWriter filewriter.Config // compression, rotation and retention of file outputs
+	Integrity  integrity.Config  // signing of the printed events
+
+	BytesFormat  BytesFormat            // rendering of the bytes arguments in all the outputs
+	BytesFormats map[string]BytesFormat // rendering of the bytes arguments, by output kind
 }
 
+// BytesFormat is the rendering of the bytes (raw buffer) arguments in an output.
+type BytesFormat string
+
+const (
+	BytesFormatDefault BytesFormat = ""        // as is: base64 in json, decimal values in table
+	BytesFormatBase64  BytesFormat = "base64"  // base64 string
+	BytesFormatHex     BytesFormat = "hex"     // hex string
+	BytesFormatPreview BytesFormat = "preview" // printable string, escaping the other bytes, cut
+)
+
 type ContainerMode int
 
 const (
@@ -185,4 +292,8 @@ type PrinterConfig struct {
 	OutFile       io.WriteCloser
 	ContainerMode ContainerMode
 	RelativeTS    bool
+	TimeZone      *time.Location
+	IntegrityKey  *integrity.Key // signs the printed events, if set (json only)
+	BytesFormat   BytesFormat
+	Query         *query.Query // selects and transforms the printed events, if set (table and json only)
 }
@@ -86,6 +86,21 @@ func New(
 			logger.Debugw("Enricher", "error", err)
 		}
 
+		// Attempt to register lifecycle events streamers (cri-o has no events stream).
+
+		err = runtimeService.RegisterStreamer(cruntime.Containerd, cruntime.ContainerdEventsStreamer)
+		if err != nil {
+			logger.Debugw("Events streamer", "error", err)
+		}
+		err = runtimeService.RegisterStreamer(cruntime.Docker, cruntime.DockerEventsStreamer)
+		if err != nil {
+			logger.Debugw("Events streamer", "error", err)
+		}
+		err = runtimeService.RegisterStreamer(cruntime.Podman, cruntime.DockerEventsStreamer)
+		if err != nil {
+			logger.Debugw("Events streamer", "error", err)
+		}
+
 		containers.enricher = runtimeService
 	}
 
@@ -376,6 +391,72 @@ func (c *Containers) CgroupMkdir(cgroupId uint64, subPath string, hierarchyID ui
 	return c.cgroupUpdate(cgroupId, subPath, curTime, true)
 }
 
+// StreamLifecycleEvents sends the lifecycle events of the containers runtimes to the
+// channel, until the context is done. The metadata of the started containers is fetched
+// from their runtime (refreshing the one of their cgroups), the one of the stopped
+// containers is taken from their cgroups when not reported by the runtime.
+func (c *Containers) StreamLifecycleEvents(ctx context.Context, out chan<- cruntime.LifecycleEvent) {
+	events := make(chan cruntime.LifecycleEvent)
+	go func() {
+		c.enricher.Stream(ctx, events)
+		close(events)
+	}()
+
+	for event := range events {
+		if event.Action == cruntime.ContainerStarted {
+			c.enrichStartedContainer(ctx, &event)
+		} else if event.Container.Image == "" {
+			event.Container = c.containerMetadata(event.Container)
+		}
+
+		select {
+		case out <- event:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// enrichStartedContainer fetches the metadata of a started container, and updates its
+// cgroups with it, as they might have been created before the metadata was available.
+func (c *Containers) enrichStartedContainer(ctx context.Context, event *cruntime.LifecycleEvent) {
+	containerId := event.Container.ContainerId
+
+	if event.Container.Image == "" {
+		enrichCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		metadata, err := c.enricher.Get(enrichCtx, containerId, event.Runtime)
+		cancel()
+		if err != nil {
+			logger.Debugw("Enriching started container", "container_id", containerId, "error", err)
+			event.Container = c.containerMetadata(event.Container)
+			return
+		}
+		event.Container = metadata
+	}
+
+	c.cgroupsMutex.Lock()
+	defer c.cgroupsMutex.Unlock()
+	for id, info := range c.cgroupsMap {
+		if info.Container.ContainerId == containerId && info.Container.Image == "" {
+			info.Container = event.Container
+			c.cgroupsMap[id] = info
+		}
+	}
+}
+
+// containerMetadata returns the metadata of a container known from its cgroups, or the
+// given one if the container wasn't enriched.
+func (c *Containers) containerMetadata(container cruntime.ContainerMetadata) cruntime.ContainerMetadata {
+	c.cgroupsMutex.RLock()
+	defer c.cgroupsMutex.RUnlock()
+	for _, info := range c.cgroupsMap {
+		if info.Container.ContainerId == container.ContainerId && info.Container.Image != "" {
+			return info.Container
+		}
+	}
+
+	return container
+}
+
 // FindContainerCgroupID32LSB returns the 32 LSB of the Cgroup ID for a given container ID.
 func (c *Containers) FindContainerCgroupID32LSB(containerID string) ([]uint32, error) {
 	var cgroupIDs []uint32
@@ -0,0 +1,184 @@
+package containers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/bucketscache"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// mount is an entry of a mountinfo file: the root of the mounted filesystem (a bind mount may
+// mount a subdirectory of it) and its mount point, for the device of the filesystem.
+type mount struct {
+	device     string // major:minor
+	root       string
+	mountPoint string
+}
+
+// RootfsResolver resolves the root filesystem of the containers (of their mount namespace) to its
+// path in the host mount namespace (e.g. /var/lib/docker/overlay2/<id>/merged), from the mounts of
+// the host and of the containers processes, so it doesn't depend on the storage driver layout.
+// **NOTE**: the mounts are read from /proc/<pid>/mountinfo, requiring CAP_SYS_PTRACE capability.
+type RootfsResolver struct {
+	fs               fs.FS
+	mountNSPIDsCache *bucketscache.BucketsCache
+	rootfs           *lru.Cache[int, string] // host path of the rootfs by mount namespace
+}
+
+// NewRootfsResolver creates a resolver of the containers root filesystems, caching the given
+// number of mount namespaces.
+func NewRootfsResolver(mountNSPIDsCache *bucketscache.BucketsCache, size int) (*RootfsResolver, error) {
+	rootfs, err := lru.New[int, string](size)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &RootfsResolver{
+		fs:               os.DirFS("/"),
+		mountNSPIDsCache: mountNSPIDsCache,
+		rootfs:           rootfs,
+	}, nil
+}
+
+// HostRootfs returns the host path of the root filesystem of a mount namespace, read from the
+// given process (if alive) or from another process of the mount namespace.
+func (r *RootfsResolver) HostRootfs(mountNS int, pid int) (string, error) {
+	if rootfs, ok := r.rootfs.Get(mountNS); ok {
+		return rootfs, nil
+	}
+
+	hostMounts, err := r.readMounts(1)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	pids := []int{pid}
+	for _, p := range r.mountNSPIDsCache.GetBucket(uint32(mountNS)) {
+		pids = append(pids, int(p))
+	}
+	for _, p := range pids {
+		mounts, err := r.readMounts(p)
+		if err != nil {
+			continue // the process is either not alive or not accessible
+		}
+		rootMount, ok := findRootMount(mounts)
+		if !ok {
+			continue
+		}
+		rootfs, ok := hostPath(hostMounts, rootMount)
+		if !ok {
+			return "", ErrRootfsNotMounted
+		}
+		r.rootfs.Add(mountNS, rootfs)
+
+		return rootfs, nil
+	}
+
+	return "", ErrContainerFSUnreachable
+}
+
+// readMounts reads the mounts of the mount namespace of a process.
+func (r *RootfsResolver) readMounts(pid int) ([]mount, error) {
+	file, err := r.fs.Open(fmt.Sprintf("proc/%d/mountinfo", pid))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var mounts []mount
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+		mounts = append(mounts, mount{
+			device:     fields[2],
+			root:       unescapeMountPath(fields[3]),
+			mountPoint: unescapeMountPath(fields[4]),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return mounts, nil
+}
+
+// findRootMount returns the last mount of the root directory (the one on top).
+func findRootMount(mounts []mount) (mount, bool) {
+	var rootMount mount
+	found := false
+	for _, m := range mounts {
+		if m.mountPoint == "/" {
+			rootMount = m
+			found = true
+		}
+	}
+
+	return rootMount, found
+}
+
+// hostPath returns the host path of the root of a mount: the mount point of the same filesystem
+// in the host whose root contains it (an overlay is mounted as is, a directory of a host
+// filesystem is bind mounted). The host mount with the shallowest root is chosen, as it is the
+// mount of the filesystem itself rather than a bind mount of one of its directories.
+func hostPath(hostMounts []mount, m mount) (string, bool) {
+	best := -1
+	for i, hm := range hostMounts {
+		if hm.device != m.device || !isWithin(m.root, hm.root) {
+			continue
+		}
+		if best < 0 || len(hm.root) < len(hostMounts[best].root) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+
+	rel := strings.TrimPrefix(m.root, hostMounts[best].root)
+
+	return path.Join(hostMounts[best].mountPoint, rel), true
+}
+
+// isWithin returns whether a path is the given directory or is under it.
+func isWithin(p, dir string) bool {
+	return dir == "/" || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// unescapeMountPath unescapes the octal escaped characters (space, tab, newline and backslash)
+// of the paths of a mountinfo file.
+func unescapeMountPath(p string) string {
+	if !strings.Contains(p, `\`) {
+		return p
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		if p[i] == '\\' && i+3 < len(p) {
+			if c, err := strconv.ParseUint(p[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(p[i])
+	}
+
+	return b.String()
+}
+
+var ErrRootfsNotMounted = errors.New("container root filesystem is not mounted in the host mount namespace")
@@ -0,0 +1,104 @@
+package containers
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/bucketscache"
+)
+
+const hostMountInfo = `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+23 22 0:21 / /proc rw,nosuid shared:12 - proc proc rw
+24 22 8:2 / /var/lib rw,relatime shared:2 - ext4 /dev/sda2 rw
+410 24 0:52 / /var/lib/docker/overlay2/4f3c/merged rw,relatime - overlay overlay rw,lowerdir=/var/lib/docker/overlay2/l/A,upperdir=/var/lib/docker/overlay2/4f3c/diff
+420 22 8:2 /containers /run/bundles rw,relatime - ext4 /dev/sda2 rw
+430 22 0:60 / /run/with\040space/rootfs rw,relatime - overlay overlay rw
+`
+
+func TestRootfsResolver_HostRootfs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		mountInfo string
+		expected  string
+		expectErr error
+	}{
+		{
+			name: "overlay rootfs",
+			mountInfo: `500 450 0:52 / / rw,relatime - overlay overlay rw,lowerdir=/var/lib/docker/overlay2/l/A
+501 500 0:70 / /proc rw,nosuid - proc proc rw
+`,
+			expected: "/var/lib/docker/overlay2/4f3c/merged",
+		},
+		{
+			name: "directory rootfs",
+			mountInfo: `500 450 8:2 /containers/abc/rootfs / rw,relatime - ext4 /dev/sda2 rw
+`,
+			expected: "/var/lib/containers/abc/rootfs",
+		},
+		{
+			name: "escaped mount point",
+			mountInfo: `500 450 0:60 / / rw,relatime - overlay overlay rw
+`,
+			expected: "/run/with space/rootfs",
+		},
+		{
+			name: "stacked root mounts",
+			mountInfo: `500 450 8:1 / / rw,relatime - ext4 /dev/sda1 rw
+510 500 0:52 / / rw,relatime - overlay overlay rw
+`,
+			expected: "/var/lib/docker/overlay2/4f3c/merged",
+		},
+		{
+			name: "not mounted in the host",
+			mountInfo: `500 450 0:99 / / rw,relatime - overlay overlay rw
+`,
+			expectErr: ErrRootfsNotMounted,
+		},
+		{
+			name:      "unreachable",
+			expectErr: ErrContainerFSUnreachable,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mfs := fstest.MapFS{
+				"proc/1/mountinfo": &fstest.MapFile{Data: []byte(hostMountInfo)},
+			}
+			bucket := bucketscache.BucketsCache{}
+			bucket.Init(20)
+			if tc.mountInfo != "" {
+				// the process of the event exited, another process of the mount namespace is read
+				mfs["proc/200/mountinfo"] = &fstest.MapFile{Data: []byte(tc.mountInfo)}
+				bucket.AddBucketItem(4026532000, 200)
+			}
+
+			resolver, err := NewRootfsResolver(&bucket, 16)
+			require.NoError(t, err)
+			resolver.fs = mfs
+
+			rootfs, err := resolver.HostRootfs(4026532000, 100)
+			if tc.expectErr != nil {
+				assert.ErrorIs(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rootfs)
+
+			// the rootfs of the mount namespace is cached
+			delete(mfs, "proc/200/mountinfo")
+			rootfs, err = resolver.HostRootfs(4026532000, 100)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, rootfs)
+		})
+	}
+}
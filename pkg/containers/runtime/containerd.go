@@ -5,10 +5,13 @@ import (
 	"strings"
 
 	"github.com/containerd/containerd"
+	apievents "github.com/containerd/containerd/api/events"
 	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/events"
 	"github.com/containerd/containerd/namespaces"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 	cri "k8s.io/cri-api/pkg/apis/runtime/v1"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
@@ -89,6 +92,7 @@ func (e *containerdEnricher) Get(ctx context.Context, containerId string) (Conta
 		if container.Labels != nil {
 			labels := container.Labels
 
+			metadata.Labels = labels
 			metadata.Pod = PodMetadata{
 				Name:      labels[PodNameLabel],
 				Namespace: labels[PodNamespaceLabel],
@@ -111,3 +115,105 @@ func (e *containerdEnricher) Get(ctx context.Context, containerId string) (Conta
 func (e *containerdEnricher) isSandbox(labels map[string]string) bool {
 	return labels[ContainerTypeContainerdLabel] == "sandbox"
 }
+
+const (
+	containerdTaskStartTopic = "/tasks/start"
+	containerdTaskExitTopic  = "/tasks/exit"
+	containerdTaskOOMTopic   = "/tasks/oom"
+)
+
+type containerdStreamer struct {
+	client *containerd.Client
+}
+
+// ContainerdEventsStreamer returns a streamer of the containerd containers lifecycle
+// events, in all the containerd namespaces.
+func ContainerdEventsStreamer(socket string) (EventsStreamer, error) {
+	client, err := containerd.New(socket)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &containerdStreamer{client: client}, nil
+}
+
+func (s *containerdStreamer) Stream(ctx context.Context, out chan<- LifecycleEvent) error {
+	envelopes, errs := s.client.Subscribe(ctx,
+		`topic=="`+containerdTaskStartTopic+`"`,
+		`topic=="`+containerdTaskExitTopic+`"`,
+		`topic=="`+containerdTaskOOMTopic+`"`,
+	)
+
+	for {
+		select {
+		case envelope := <-envelopes:
+			event, ok, err := containerdLifecycleEvent(envelope)
+			if err != nil {
+				logger.Debugw("Decoding containerd event", "topic", envelope.Topic, "error", err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		case err := <-errs:
+			if err == nil || ctx.Err() != nil {
+				return nil
+			}
+			return errfmt.WrapError(err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// containerdLifecycleEvent returns the lifecycle event of a containerd task event. Only
+// the container id is known, the exits of the processes executed in the container are
+// skipped.
+func containerdLifecycleEvent(envelope *events.Envelope) (LifecycleEvent, bool, error) {
+	if envelope == nil || envelope.Event == nil {
+		return LifecycleEvent{}, false, nil
+	}
+	event := LifecycleEvent{Time: envelope.Timestamp}
+	value := envelope.Event.GetValue()
+
+	switch envelope.Topic {
+	case containerdTaskStartTopic:
+		var start apievents.TaskStart
+		if err := proto.Unmarshal(value, &start); err != nil {
+			return event, false, errfmt.WrapError(err)
+		}
+		event.Action = ContainerStarted
+		event.Container.ContainerId = start.ContainerID
+		event.Pid = start.Pid
+	case containerdTaskExitTopic:
+		var exit apievents.TaskExit
+		if err := proto.Unmarshal(value, &exit); err != nil {
+			return event, false, errfmt.WrapError(err)
+		}
+		if exit.ID != exit.ContainerID {
+			return event, false, nil // exec process exit
+		}
+		event.Action = ContainerStopped
+		event.Container.ContainerId = exit.ContainerID
+		event.ExitCode = int(exit.ExitStatus)
+		if exit.ExitedAt != nil {
+			event.Time = exit.ExitedAt.AsTime()
+		}
+	case containerdTaskOOMTopic:
+		var oom apievents.TaskOOM
+		if err := proto.Unmarshal(value, &oom); err != nil {
+			return event, false, errfmt.WrapError(err)
+		}
+		event.Action = ContainerOOM
+		event.Container.ContainerId = oom.ContainerID
+	default:
+		return event, false, nil
+	}
+
+	return event, true, nil
+}
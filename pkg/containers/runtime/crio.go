@@ -45,6 +45,7 @@ func (e *crioEnricher) Get(ctx context.Context, containerId string) (ContainerMe
 	// if in k8s we can extract pod info from labels
 	labels := resp.Status.Labels
 	if labels != nil {
+		metadata.Labels = labels
 		metadata.Pod = PodMetadata{
 			Name:      labels[PodNameLabel],
 			Namespace: labels[PodNamespaceLabel],
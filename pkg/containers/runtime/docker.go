@@ -2,9 +2,16 @@ package runtime
 
 import (
 	"context"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
 	docker "github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 )
@@ -50,6 +57,7 @@ func (e *dockerEnricher) Get(ctx context.Context, containerId string) (Container
 		// if in k8s extract pod data from the labels
 		if resp.Config.Labels != nil {
 			labels := resp.Config.Labels
+			metadata.Labels = labels
 			metadata.Pod = PodMetadata{
 				Name:      labels[PodNameLabel],
 				Namespace: labels[PodNamespaceLabel],
@@ -59,6 +67,11 @@ func (e *dockerEnricher) Get(ctx context.Context, containerId string) (Container
 		}
 	}
 
+	// the ports published on the host, to correlate the connections with their exposure
+	if resp.NetworkSettings != nil {
+		metadata.Ports = dockerPortMappings(resp.NetworkSettings.Ports)
+	}
+
 	// attempt to get image name from registry (image from config usually has tag as sha/no tag at all)
 	imageId := container.Image
 	image, _, err := e.client.ImageInspectWithRaw(ctx, imageId)
@@ -84,3 +97,122 @@ func (e *dockerEnricher) Get(ctx context.Context, containerId string) (Container
 func (e *dockerEnricher) isSandbox(labels map[string]string) bool {
 	return labels[ContainerTypeDockerLabel] == "sandbox"
 }
+
+// dockerPortMappings returns the host bindings of the exposed ports of a container, ordered
+// by container port and protocol. The exposed ports without bindings aren't published.
+func dockerPortMappings(ports nat.PortMap) []PortMapping {
+	var mappings []PortMapping
+	for port, bindings := range ports {
+		for _, binding := range bindings {
+			hostPort, err := strconv.ParseUint(binding.HostPort, 10, 16)
+			if err != nil {
+				continue
+			}
+			mappings = append(mappings, PortMapping{
+				ContainerPort: uint16(port.Int()),
+				Protocol:      port.Proto(),
+				HostIP:        binding.HostIP,
+				HostPort:      uint16(hostPort),
+			})
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		a, b := mappings[i], mappings[j]
+		if a.ContainerPort != b.ContainerPort {
+			return a.ContainerPort < b.ContainerPort
+		}
+		if a.Protocol != b.Protocol {
+			return a.Protocol < b.Protocol
+		}
+		return a.HostIP < b.HostIP
+	})
+
+	return mappings
+}
+
+type dockerStreamer struct {
+	client *docker.Client
+}
+
+// DockerEventsStreamer returns a streamer of the docker (or podman) containers lifecycle
+// events.
+func DockerEventsStreamer(socket string) (EventsStreamer, error) {
+	unixSocket := "unix://" + strings.TrimPrefix(socket, "unix://")
+	cli, err := docker.NewClientWithOpts(docker.WithHost(unixSocket), docker.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &dockerStreamer{client: cli}, nil
+}
+
+func (s *dockerStreamer) Stream(ctx context.Context, out chan<- LifecycleEvent) error {
+	messages, errs := s.client.Events(ctx, types.EventsOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("type", string(events.ContainerEventType)),
+			filters.Arg("event", "start"),
+			filters.Arg("event", "die"),
+			filters.Arg("event", "oom"),
+		),
+	})
+
+	for {
+		select {
+		case msg := <-messages:
+			event, ok := dockerLifecycleEvent(msg)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errfmt.WrapError(err)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// dockerLifecycleEvent returns the lifecycle event of a docker container event message,
+// whose attributes hold the container name, image and labels.
+func dockerLifecycleEvent(msg events.Message) (LifecycleEvent, bool) {
+	var action LifecycleAction
+	switch msg.Action {
+	case "start":
+		action = ContainerStarted
+	case "die":
+		action = ContainerStopped
+	case "oom":
+		action = ContainerOOM
+	default:
+		return LifecycleEvent{}, false
+	}
+
+	attributes := msg.Actor.Attributes
+	event := LifecycleEvent{
+		Action: action,
+		Container: ContainerMetadata{
+			ContainerId: msg.Actor.ID,
+			Name:        attributes["name"],
+			Image:       attributes["image"],
+			Pod: PodMetadata{
+				Name:      attributes[PodNameLabel],
+				Namespace: attributes[PodNamespaceLabel],
+				UID:       attributes[PodUIDLabel],
+				Sandbox:   attributes[ContainerTypeDockerLabel] == "sandbox",
+			},
+		},
+		Time: time.Unix(0, msg.TimeNano),
+	}
+	if action == ContainerStopped {
+		event.ExitCode, _ = strconv.Atoi(attributes["exitCode"])
+	}
+
+	return event, true
+}
@@ -0,0 +1,27 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerPortMappings(t *testing.T) {
+	t.Parallel()
+
+	mappings := dockerPortMappings(nat.PortMap{
+		"443/tcp":  {{HostIP: "127.0.0.1", HostPort: "8443"}},
+		"80/tcp":   {{HostIP: "::", HostPort: "8080"}, {HostIP: "0.0.0.0", HostPort: "8080"}},
+		"53/udp":   {{HostPort: "5353"}},
+		"9090/tcp": nil, // exposed, not published
+	})
+
+	assert.Equal(t, []PortMapping{
+		{ContainerPort: 53, Protocol: "udp", HostPort: 5353},
+		{ContainerPort: 80, Protocol: "tcp", HostIP: "0.0.0.0", HostPort: 8080},
+		{ContainerPort: 80, Protocol: "tcp", HostIP: "::", HostPort: 8080},
+		{ContainerPort: 443, Protocol: "tcp", HostIP: "127.0.0.1", HostPort: 8443},
+	}, mappings)
+	assert.Nil(t, dockerPortMappings(nil))
+}
@@ -0,0 +1,32 @@
+package runtime
+
+import (
+	"context"
+	"time"
+)
+
+// LifecycleAction is a container lifecycle change reported by a runtime events stream.
+type LifecycleAction int
+
+const (
+	ContainerStarted LifecycleAction = iota + 1
+	ContainerStopped
+	ContainerOOM
+)
+
+// LifecycleEvent is a container lifecycle change, as reported by its runtime.
+type LifecycleEvent struct {
+	Action    LifecycleAction
+	Runtime   RuntimeId
+	Container ContainerMetadata // only the container id is known for some runtimes
+	Pid       uint32            // host pid of the container init process (started containers, if known)
+	ExitCode  int               // exit code of the container init process (stopped containers)
+	Time      time.Time
+}
+
+// EventsStreamer streams the container lifecycle events of a runtime.
+type EventsStreamer interface {
+	// Stream sends the lifecycle events to the channel, until the context is done (nil
+	// error) or the stream fails.
+	Stream(ctx context.Context, out chan<- LifecycleEvent) error
+}
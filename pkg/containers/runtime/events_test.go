@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/events"
+	dockerevents "github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestDockerLifecycleEvent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, time.Now().UnixNano())
+	message := func(action string, attributes map[string]string) dockerevents.Message {
+		return dockerevents.Message{
+			Type:     dockerevents.ContainerEventType,
+			Action:   action,
+			Actor:    dockerevents.Actor{ID: "abc123", Attributes: attributes},
+			TimeNano: now.UnixNano(),
+		}
+	}
+
+	event, ok := dockerLifecycleEvent(message("start", map[string]string{
+		"name":            "web",
+		"image":           "nginx:1.25",
+		PodNameLabel:      "web-7d9f",
+		PodNamespaceLabel: "default",
+		PodUIDLabel:       "uid",
+	}))
+	require.True(t, ok)
+	assert.Equal(t, LifecycleEvent{
+		Action: ContainerStarted,
+		Container: ContainerMetadata{
+			ContainerId: "abc123",
+			Name:        "web",
+			Image:       "nginx:1.25",
+			Pod:         PodMetadata{Name: "web-7d9f", Namespace: "default", UID: "uid"},
+		},
+		Time: now,
+	}, event)
+
+	event, ok = dockerLifecycleEvent(message("die", map[string]string{"exitCode": "137"}))
+	require.True(t, ok)
+	assert.Equal(t, ContainerStopped, event.Action)
+	assert.Equal(t, 137, event.ExitCode)
+
+	event, ok = dockerLifecycleEvent(message("oom", nil))
+	require.True(t, ok)
+	assert.Equal(t, ContainerOOM, event.Action)
+
+	_, ok = dockerLifecycleEvent(message("exec_start: sh", nil))
+	assert.False(t, ok)
+}
+
+func TestContainerdLifecycleEvent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	envelope := func(topic string, event proto.Message) *events.Envelope {
+		value, err := anypb.New(event)
+		require.NoError(t, err)
+		return &events.Envelope{Timestamp: now, Namespace: "k8s.io", Topic: topic, Event: value}
+	}
+
+	event, ok, err := containerdLifecycleEvent(envelope(containerdTaskStartTopic, &apievents.TaskStart{ContainerID: "abc123", Pid: 42}))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, LifecycleEvent{
+		Action:    ContainerStarted,
+		Container: ContainerMetadata{ContainerId: "abc123"},
+		Pid:       42,
+		Time:      now,
+	}, event)
+
+	exitedAt := now.Add(time.Second)
+	event, ok, err = containerdLifecycleEvent(envelope(containerdTaskExitTopic, &apievents.TaskExit{
+		ContainerID: "abc123",
+		ID:          "abc123",
+		ExitStatus:  1,
+		ExitedAt:    timestamppb.New(exitedAt),
+	}))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, ContainerStopped, event.Action)
+	assert.Equal(t, 1, event.ExitCode)
+	assert.True(t, exitedAt.Equal(event.Time))
+
+	// exit of a process executed in the container
+	_, ok, err = containerdLifecycleEvent(envelope(containerdTaskExitTopic, &apievents.TaskExit{ContainerID: "abc123", ID: "exec-1"}))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	event, ok, err = containerdLifecycleEvent(envelope(containerdTaskOOMTopic, &apievents.TaskOOM{ContainerID: "abc123"}))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, ContainerOOM, event.Action)
+	assert.Equal(t, "abc123", event.Container.ContainerId)
+
+	_, _, err = containerdLifecycleEvent(&events.Envelope{
+		Topic: containerdTaskStartTopic,
+		Event: &anypb.Any{Value: []byte{0xff}},
+	})
+	assert.Error(t, err)
+}
@@ -10,6 +10,8 @@ type ContainerMetadata struct {
 	Image       string
 	ImageDigest string
 	Pod         PodMetadata
+	Ports       []PortMapping
+	Labels      map[string]string // labels of the container, as set in the runtime
 }
 
 type PodMetadata struct {
@@ -19,6 +21,14 @@ type PodMetadata struct {
 	Sandbox   bool
 }
 
+// PortMapping is a port of a container published on a port of the host (docker run -p).
+type PortMapping struct {
+	ContainerPort uint16
+	Protocol      string // tcp, udp or sctp
+	HostIP        string // empty or unspecified (0.0.0.0, ::) for all the host addresses
+	HostPort      uint16
+}
+
 // These labels are injected by kubelet on container creation, we can use them to gather additional data in a k8s context
 const (
 	PodNameLabel                 = "io.kubernetes.pod.name"
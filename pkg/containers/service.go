@@ -2,20 +2,29 @@ package containers
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/aquasecurity/tracee/pkg/containers/runtime"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
 )
 
+// streamRetryInterval is the interval between two subscriptions to a failing runtime
+// events stream (e.g. the runtime was restarted).
+const streamRetryInterval = 5 * time.Second
+
 type runtimeInfoService struct {
 	sockets   runtime.Sockets
 	enrichers map[runtime.RuntimeId]runtime.ContainerEnricher
+	streamers map[runtime.RuntimeId]runtime.EventsStreamer
 }
 
 // RuntimeInfoService initializes a service which can register enrichers for container runtimes
 func RuntimeInfoService(sockets runtime.Sockets) runtimeInfoService {
 	return runtimeInfoService{
 		enrichers: make(map[runtime.RuntimeId]runtime.ContainerEnricher),
+		streamers: make(map[runtime.RuntimeId]runtime.EventsStreamer),
 		sockets:   sockets,
 	}
 }
@@ -34,6 +43,63 @@ func (e *runtimeInfoService) Register(rtime runtime.RuntimeId, enricherBuilder f
 	return nil
 }
 
+// RegisterStreamer associates some EventsStreamer with a runtime, the service can then stream its lifecycle events
+func (e *runtimeInfoService) RegisterStreamer(rtime runtime.RuntimeId, streamerBuilder func(socket string) (runtime.EventsStreamer, error)) error {
+	if !e.sockets.Supports(rtime) {
+		return errfmt.Errorf("error registering events streamer: unsupported runtime %s", rtime.String())
+	}
+	streamer, err := streamerBuilder(e.sockets.Socket(rtime))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	e.streamers[rtime] = streamer
+	return nil
+}
+
+// Stream sends the lifecycle events of all the registered streamers to the channel, until the context is done.
+// Failing streams are subscribed to again.
+func (e *runtimeInfoService) Stream(ctx context.Context, out chan<- runtime.LifecycleEvent) {
+	var wg sync.WaitGroup
+	for rtime, streamer := range e.streamers {
+		rtime, streamer := rtime, streamer
+		events := make(chan runtime.LifecycleEvent)
+
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case event := <-events:
+					event.Runtime = rtime
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for {
+				err := streamer.Stream(ctx, events)
+				if ctx.Err() != nil {
+					return
+				}
+				logger.Debugw("Container runtime events stream", "runtime", rtime.String(), "error", err)
+				select {
+				case <-time.After(streamRetryInterval):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 // Get calls the inner enricher's Get, based on the containerRuntime parameter if a relevant enricher was registered
 // If an unknown runtime is received, enrichment will be attempted through all registered enrichers
 func (e *runtimeInfoService) Get(ctx context.Context, containerId string, containerRuntime runtime.RuntimeId) (runtime.ContainerMetadata, error) {
@@ -0,0 +1,83 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/containers/runtime"
+)
+
+// fakeStreamer sends an event per subscription, the first subscription failing.
+type fakeStreamer struct {
+	subscriptions atomic.Int32
+}
+
+func (s *fakeStreamer) Stream(ctx context.Context, out chan<- runtime.LifecycleEvent) error {
+	n := s.subscriptions.Add(1)
+	select {
+	case out <- runtime.LifecycleEvent{Action: runtime.ContainerStarted, Container: runtime.ContainerMetadata{ContainerId: "abc123"}}:
+	case <-ctx.Done():
+		return nil
+	}
+	if n == 1 {
+		return errors.New("stream closed")
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestRuntimeInfoServiceStream(t *testing.T) {
+	t.Parallel()
+
+	service := RuntimeInfoService(runtime.Sockets{})
+	streamer := &fakeStreamer{}
+	service.streamers[runtime.Containerd] = streamer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan runtime.LifecycleEvent)
+	done := make(chan struct{})
+	go func() {
+		service.Stream(ctx, events)
+		close(done)
+	}()
+
+	receive := func() runtime.LifecycleEvent {
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * streamRetryInterval):
+			require.FailNow(t, "no lifecycle event received")
+		}
+		return runtime.LifecycleEvent{}
+	}
+
+	event := receive()
+	assert.Equal(t, runtime.Containerd, event.Runtime)
+	assert.Equal(t, "abc123", event.Container.ContainerId)
+
+	// subscribed again once the stream failed
+	event = receive()
+	assert.Equal(t, runtime.ContainerStarted, event.Action)
+	assert.Equal(t, int32(2), streamer.subscriptions.Load())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "stream not stopped once the context is done")
+	}
+}
+
+func TestRuntimeInfoServiceRegisterStreamer(t *testing.T) {
+	t.Parallel()
+
+	service := RuntimeInfoService(runtime.Sockets{})
+	err := service.RegisterStreamer(runtime.Docker, runtime.DockerEventsStreamer)
+	assert.ErrorContains(t, err, "unsupported runtime docker")
+}
@@ -0,0 +1,363 @@
+// Package controlplane implements the client of a central control plane, which manages
+// fleets of tracee agents without per-node configuration files.
+//
+// The agent connects out to the control plane over mutual TLS, and speaks the following
+// HTTP API, relative to the control plane URL:
+//
+//	GET  /v1/agents/<agent>/policies  the agent policies, as a YAML stream (one policy per
+//	                                  document), versioned by the ETag header
+//	POST /v1/agents/<agent>/findings  the new findings, as a JSON array of events
+//	POST /v1/agents/<agent>/stats     the agent statistics, as a JSON object
+package controlplane
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/streams"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// DefaultInterval is the default interval between two syncs with the control plane.
+	DefaultInterval = 30 * time.Second
+
+	// maxPendingFindings is the number of findings kept while the control plane is
+	// unreachable: the oldest findings are dropped once it is exceeded.
+	maxPendingFindings = 10000
+
+	requestTimeout = 30 * time.Second
+)
+
+// Config is the control plane client configuration.
+type Config struct {
+	Enabled  bool
+	URL      string        // control plane URL (https)
+	AgentID  string        // agent id in the control plane, the hostname by default
+	CertFile string        // agent certificate, presented to the control plane
+	KeyFile  string        // agent certificate key
+	CAFile   string        // control plane certificate authority
+	Interval time.Duration // interval between two syncs
+}
+
+// Agent is the agent managed by the control plane.
+type Agent interface {
+	SubscribeAll() *streams.Stream
+	Unsubscribe(s *streams.Stream)
+	Stats() *metrics.Stats
+}
+
+// PoliciesApplier applies the policies pulled from the control plane, as a YAML stream,
+// while the agent is running.
+type PoliciesApplier func(policiesYAML []byte) error
+
+// Client is a control plane client.
+type Client struct {
+	cfg     Config
+	baseURL string
+	client  *http.Client
+
+	policiesVersion string
+
+	mu       sync.Mutex
+	findings []trace.Event
+	dropped  uint64
+}
+
+// New creates a control plane client, authenticated with the agent certificate.
+func New(cfg Config) (*Client, error) {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, errfmt.Errorf("invalid control plane url: %v", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return nil, errfmt.Errorf("invalid control plane url %s: an https url is required", cfg.URL)
+	}
+
+	if cfg.AgentID == "" {
+		cfg.AgentID, err = os.Hostname()
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+
+	tlsConfig, err := newTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		cfg:     cfg,
+		baseURL: fmt.Sprintf("%s/v1/agents/%s", u.String(), url.PathEscape(cfg.AgentID)),
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func newTLSConfig(cfg Config) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" || cfg.CAFile == "" {
+		return nil, errfmt.Errorf("control plane certificate, key and certificate authority are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, errfmt.Errorf("loading control plane certificate: %v", err)
+	}
+
+	caPEM, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, errfmt.Errorf("loading control plane certificate authority: %v", err)
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(caPEM) {
+		return nil, errfmt.Errorf("no certificate found in %s", cfg.CAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// GetPolicies pulls the agent policies from the control plane, as a YAML stream.
+func (c *Client) GetPolicies(ctx context.Context) ([]byte, error) {
+	policies, version, err := c.getPolicies(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	c.policiesVersion = version
+
+	return policies, nil
+}
+
+// getPolicies returns the policies and their version, or nil if their version is the
+// given one.
+func (c *Client) getPolicies(ctx context.Context, version string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/policies", nil)
+	if err != nil {
+		return nil, "", errfmt.WrapError(err)
+	}
+	if version != "" {
+		req.Header.Set("If-None-Match", version)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, "", errfmt.Errorf("pulling policies: %v", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, version, nil
+	default:
+		return nil, "", errfmt.Errorf("pulling policies: %s", resp.Status)
+	}
+
+	policies, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", errfmt.Errorf("pulling policies: %v", err)
+	}
+
+	return policies, resp.Header.Get("ETag"), nil
+}
+
+// Run pushes the findings and the statistics of the agent to the control plane, and
+// applies the policies changes, at every interval until the context is done.
+//
+// The policies changes the applier fails to apply (e.g. requiring a restart) are logged,
+// and not pulled again until the policies change again.
+func (c *Client) Run(ctx context.Context, agent Agent, apply PoliciesApplier) {
+	stream := agent.SubscribeAll()
+	defer agent.Unsubscribe(stream)
+
+	// sync apart from the stream reception, not to block the pipeline while the control
+	// plane is slow or unreachable
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(c.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.sync(ctx, agent, apply)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c.receiveFindings(ctx, stream)
+	<-done
+
+	// push the last findings, the context being done
+	pushCtx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	c.pushFindings(pushCtx)
+}
+
+func (c *Client) receiveFindings(ctx context.Context, stream *streams.Stream) {
+	for {
+		select {
+		case evt, ok := <-stream.ReceiveEvents():
+			if !ok {
+				return
+			}
+			if evt.Metadata != nil { // findings are the only events with signature metadata
+				c.addFinding(evt)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) addFinding(evt trace.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.findings) == maxPendingFindings {
+		c.findings = c.findings[1:]
+		c.dropped++
+	}
+	c.findings = append(c.findings, evt)
+}
+
+func (c *Client) sync(ctx context.Context, agent Agent, apply PoliciesApplier) {
+	c.pushFindings(ctx)
+
+	if err := c.post(ctx, "/stats", newStatsReport(agent.Stats())); err != nil {
+		logger.Warnw("Pushing stats to the control plane", "error", err)
+	}
+
+	policiesYAML, version, err := c.getPolicies(ctx, c.policiesVersion)
+	if err != nil {
+		logger.Warnw("Checking control plane policies", "error", err)
+		return
+	}
+	if version == c.policiesVersion {
+		return
+	}
+
+	if err := apply(policiesYAML); err != nil {
+		logger.Warnw("Applying control plane policies", "version", c.policiesVersion, "new_version", version,
+			"error", err)
+	} else {
+		logger.Infow("Control plane policies applied", "version", version)
+	}
+	c.policiesVersion = version
+}
+
+// pushFindings pushes the pending findings. They are kept on failure, to be pushed with
+// the next ones.
+func (c *Client) pushFindings(ctx context.Context) {
+	c.mu.Lock()
+	findings := c.findings
+	dropped := c.dropped
+	c.findings = nil
+	c.dropped = 0
+	c.mu.Unlock()
+
+	if dropped > 0 {
+		logger.Warnw("Findings dropped, the control plane being unreachable", "dropped", dropped)
+	}
+	if len(findings) == 0 {
+		return
+	}
+
+	if err := c.post(ctx, "/findings", findings); err != nil {
+		logger.Warnw("Pushing findings to the control plane", "error", err)
+
+		c.mu.Lock()
+		c.findings = append(findings, c.findings...)
+		if extra := len(c.findings) - maxPendingFindings; extra > 0 {
+			c.findings = c.findings[extra:]
+			c.dropped += uint64(extra)
+		}
+		c.mu.Unlock()
+	}
+}
+
+func (c *Client) post(ctx context.Context, path string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return errfmt.Errorf("%s: %s", path, resp.Status)
+	}
+
+	return nil
+}
+
+// statsReport is the agent statistics pushed to the control plane.
+type statsReport struct {
+	Time                 time.Time `json:"time"`
+	EventCount           uint64    `json:"event_count"`
+	EventsFiltered       uint64    `json:"events_filtered"`
+	NetCapCount          uint64    `json:"net_cap_count"`
+	BPFLogsCount         uint64    `json:"bpf_logs_count"`
+	ErrorCount           uint64    `json:"error_count"`
+	LostEvCount          uint64    `json:"lost_events"`
+	LostWrCount          uint64    `json:"lost_writes"`
+	LostNtCapCount       uint64    `json:"lost_net_cap"`
+	LostBPFLogsCount     uint64    `json:"lost_bpf_logs"`
+	FindingsSuppressed   uint64    `json:"findings_suppressed"`
+	FindingsDeduplicated uint64    `json:"findings_deduplicated"`
+	EventsShed           uint64    `json:"events_shed"`
+}
+
+func newStatsReport(stats *metrics.Stats) statsReport {
+	return statsReport{
+		Time:                 time.Now(),
+		EventCount:           stats.EventCount.Get(),
+		EventsFiltered:       stats.EventsFiltered.Get(),
+		NetCapCount:          stats.NetCapCount.Get(),
+		BPFLogsCount:         stats.BPFLogsCount.Get(),
+		ErrorCount:           stats.ErrorCount.Get(),
+		LostEvCount:          stats.LostEvCount.Get(),
+		LostWrCount:          stats.LostWrCount.Get(),
+		LostNtCapCount:       stats.LostNtCapCount.Get(),
+		LostBPFLogsCount:     stats.LostBPFLogsCount.Get(),
+		FindingsSuppressed:   stats.FindingsSuppressed.Get(),
+		FindingsDeduplicated: stats.FindingsDeduplicated.Get(),
+		EventsShed:           stats.EventsShed.Get(),
+	}
+}
@@ -0,0 +1,317 @@
+package controlplane
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/metrics"
+	"github.com/aquasecurity/tracee/pkg/streams"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// testCA is a certificate authority issuing the test certificates.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+// issue returns a PEM certificate and key, for a server or a client.
+func (ca *testCA) issue(t *testing.T, name string, server bool) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// fakeControlPlane is a control plane requiring client certificates issued by its CA.
+type fakeControlPlane struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	findings []trace.Event
+	stats    []statsReport
+}
+
+func newFakeControlPlane(t *testing.T, ca *testCA) *fakeControlPlane {
+	cp := &fakeControlPlane{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/agents/node-1/policies", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("If-None-Match") == `"v2"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v2"`)
+		_, _ = rw.Write([]byte("kind: Policy\n"))
+	})
+	mux.HandleFunc("/v1/agents/node-1/findings", func(rw http.ResponseWriter, req *http.Request) {
+		var findings []trace.Event
+		if err := json.NewDecoder(req.Body).Decode(&findings); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cp.mu.Lock()
+		cp.findings = append(cp.findings, findings...)
+		cp.mu.Unlock()
+	})
+	mux.HandleFunc("/v1/agents/node-1/stats", func(rw http.ResponseWriter, req *http.Request) {
+		var stats statsReport
+		if err := json.NewDecoder(req.Body).Decode(&stats); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		cp.mu.Lock()
+		cp.stats = append(cp.stats, stats)
+		cp.mu.Unlock()
+	})
+
+	certPEM, keyPEM := ca.issue(t, "control plane", true)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	cp.Server = httptest.NewUnstartedServer(mux)
+	cp.TLS = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	cp.StartTLS()
+	t.Cleanup(cp.Close)
+
+	return cp
+}
+
+// writeClientFiles writes a client certificate, its key and the CA, returning a client
+// configuration using them.
+func writeClientFiles(t *testing.T, url string, ca, clientCA *testCA) Config {
+	dir := t.TempDir()
+	certPEM, keyPEM := clientCA.issue(t, "node-1", false)
+
+	cfg := Config{
+		URL:      url,
+		AgentID:  "node-1",
+		CertFile: filepath.Join(dir, "agent.crt"),
+		KeyFile:  filepath.Join(dir, "agent.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	require.NoError(t, os.WriteFile(cfg.CertFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(cfg.KeyFile, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(cfg.CAFile, ca.pem, 0o600))
+
+	return cfg
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	cfg := writeClientFiles(t, "https://control-plane:8443", ca, ca)
+
+	_, err := New(cfg)
+	require.NoError(t, err)
+
+	invalidURL := cfg
+	invalidURL.URL = "http://control-plane:8443"
+	_, err = New(invalidURL)
+	assert.ErrorContains(t, err, "an https url is required")
+
+	noCert := cfg
+	noCert.CertFile = ""
+	_, err = New(noCert)
+	assert.ErrorContains(t, err, "are required")
+
+	invalidCA := cfg
+	invalidCA.CAFile = cfg.KeyFile
+	_, err = New(invalidCA)
+	assert.ErrorContains(t, err, "no certificate found")
+}
+
+func TestGetPolicies(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	cp := newFakeControlPlane(t, ca)
+
+	client, err := New(writeClientFiles(t, cp.URL, ca, ca))
+	require.NoError(t, err)
+
+	policies, err := client.GetPolicies(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Policy\n", string(policies))
+	assert.Equal(t, `"v2"`, client.policiesVersion)
+
+	policies, version, err := client.getPolicies(context.Background(), `"v2"`)
+	require.NoError(t, err)
+	assert.Nil(t, policies)
+	assert.Equal(t, `"v2"`, version)
+
+	// a client certificate not issued by the control plane CA is rejected
+	untrusted, err := New(writeClientFiles(t, cp.URL, ca, newTestCA(t)))
+	require.NoError(t, err)
+	_, err = untrusted.GetPolicies(context.Background())
+	assert.Error(t, err)
+}
+
+type fakeAgent struct {
+	*streams.StreamsManager
+	stats      *metrics.Stats
+	subscribed chan struct{}
+}
+
+func (a *fakeAgent) SubscribeAll() *streams.Stream {
+	defer close(a.subscribed)
+	return a.Subscribe(^uint64(0), 100)
+}
+
+func (a *fakeAgent) Stats() *metrics.Stats {
+	return a.stats
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	cp := newFakeControlPlane(t, ca)
+
+	cfg := writeClientFiles(t, cp.URL, ca, ca)
+	cfg.Interval = 10 * time.Millisecond
+	client, err := New(cfg)
+	require.NoError(t, err)
+
+	agent := &fakeAgent{
+		StreamsManager: streams.NewStreamsManager(),
+		stats:          &metrics.Stats{},
+		subscribed:     make(chan struct{}),
+	}
+	agent.stats.EventCount.Set(42)
+
+	var appliedMu sync.Mutex
+	var applied []string
+	apply := func(policiesYAML []byte) error {
+		appliedMu.Lock()
+		defer appliedMu.Unlock()
+		applied = append(applied, string(policiesYAML))
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		client.Run(ctx, agent, apply)
+		close(done)
+	}()
+
+	<-agent.subscribed
+	agent.Publish(ctx, trace.Event{EventName: "openat", MatchedPoliciesUser: 1})
+	agent.Publish(ctx, trace.Event{
+		EventName:           "fake_signature",
+		MatchedPoliciesUser: 1,
+		Metadata:            &trace.Metadata{Version: "1"},
+	})
+
+	require.Eventually(t, func() bool {
+		cp.mu.Lock()
+		defer cp.mu.Unlock()
+		return len(cp.findings) == 1 && len(cp.stats) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	assert.Equal(t, "fake_signature", cp.findings[0].EventName)
+	assert.Equal(t, uint64(42), cp.stats[0].EventCount)
+
+	// the changed policies are applied once, not while unchanged
+	appliedMu.Lock()
+	defer appliedMu.Unlock()
+	assert.Equal(t, []string{"kind: Policy\n"}, applied)
+	assert.Equal(t, `"v2"`, client.policiesVersion)
+}
+
+func TestPushFindingsFailure(t *testing.T) {
+	t.Parallel()
+
+	ca := newTestCA(t)
+	cp := newFakeControlPlane(t, ca)
+
+	client, err := New(writeClientFiles(t, cp.URL, ca, ca))
+	require.NoError(t, err)
+
+	for i := 0; i < maxPendingFindings+1; i++ {
+		client.addFinding(trace.Event{EventName: "fake_signature"})
+	}
+	assert.Len(t, client.findings, maxPendingFindings)
+	assert.Equal(t, uint64(1), client.dropped)
+
+	// the findings are kept while the control plane is unreachable
+	cp.Close()
+	client.pushFindings(context.Background())
+	assert.Len(t, client.findings, maxPendingFindings)
+}
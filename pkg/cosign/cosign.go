@@ -0,0 +1,143 @@
+// Package cosign verifies the signatures made by cosign (sigstore) with a key pair: the
+// signatures of OCI artifacts and of blobs (cosign sign-blob). It also signs blobs the same
+// way, for their signatures to be verified with cosign verify-blob.
+//
+// Keyless signatures (Fulcio certificates and Rekor transparency log entries) aren't
+// supported.
+package cosign
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// LoadPublicKey loads a cosign public key: a PEM encoded ECDSA, RSA or Ed25519 public key
+// (e.g. the cosign.pub of cosign generate-key-pair).
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errfmt.Errorf("%s: no PEM encoded public key", path)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errfmt.Errorf("%s: %v", path, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, errfmt.Errorf("%s: unsupported public key type %T", path, key)
+	}
+
+	return key, nil
+}
+
+// Verify verifies a signature of the payload: an ECDSA (ASN.1) or RSA (PKCS #1 v1.5)
+// signature of its sha256, or an Ed25519 signature of the payload.
+func Verify(key crypto.PublicKey, payload, signature []byte) error {
+	hash := sha256.Sum256(payload)
+	valid := false
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		valid = ecdsa.VerifyASN1(k, hash[:], signature)
+	case *rsa.PublicKey:
+		valid = rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		valid = ed25519.Verify(k, payload, signature)
+	default:
+		return errfmt.Errorf("unsupported public key type %T", key)
+	}
+	if !valid {
+		return errfmt.Errorf("invalid signature")
+	}
+
+	return nil
+}
+
+// VerifyBase64 verifies a base64 encoded signature of the payload, as output by cosign
+// sign-blob --output-signature.
+func VerifyBase64(key crypto.PublicKey, payload, signature []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+	if err != nil {
+		return errfmt.Errorf("invalid signature encoding: %v", err)
+	}
+
+	return Verify(key, payload, decoded)
+}
+
+// LoadPrivateKey loads an unencrypted PEM encoded ECDSA, RSA or Ed25519 private key (PKCS #8,
+// or the SEC 1 and PKCS #1 encodings of ECDSA and RSA keys). The encrypted keys of cosign
+// generate-key-pair aren't supported.
+func LoadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errfmt.Errorf("%s: no PEM encoded private key", path)
+	}
+
+	var key interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, errfmt.Errorf("%s: unsupported private key PEM type %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, errfmt.Errorf("%s: %v", path, err)
+	}
+	switch key.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return nil, errfmt.Errorf("%s: unsupported private key type %T", path, key)
+	}
+
+	return key.(crypto.Signer), nil
+}
+
+// Sign signs the payload the way Verify verifies it: an ECDSA (ASN.1) or RSA (PKCS #1 v1.5)
+// signature of its sha256, or an Ed25519 signature of the payload.
+func Sign(key crypto.Signer, payload []byte) ([]byte, error) {
+	if k, ok := key.(ed25519.PrivateKey); ok {
+		return ed25519.Sign(k, payload), nil
+	}
+
+	hash := sha256.Sum256(payload)
+	signature, err := key.Sign(rand.Reader, hash[:], crypto.SHA256)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return signature, nil
+}
+
+// SignBase64 returns the base64 encoded signature of the payload, as output by cosign
+// sign-blob --output-signature.
+func SignBase64(key crypto.Signer, payload []byte) ([]byte, error) {
+	signature, err := Sign(key, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.StdEncoding.EncodeToString(signature)), nil
+}
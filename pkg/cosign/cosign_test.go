@@ -0,0 +1,153 @@
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte("signed payload")
+	hash := sha256.Sum256(payload)
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaSig, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:])
+	require.NoError(t, err)
+
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edSig := ed25519.Sign(edKey, payload)
+
+	testCases := []struct {
+		name      string
+		key       crypto.PublicKey
+		signature []byte
+		valid     bool
+	}{
+		{name: "ecdsa", key: &ecdsaKey.PublicKey, signature: ecdsaSig, valid: true},
+		{name: "rsa", key: &rsaKey.PublicKey, signature: rsaSig, valid: true},
+		{name: "ed25519", key: edPublic, signature: edSig, valid: true},
+		{name: "wrong key", key: &ecdsaKey.PublicKey, signature: rsaSig},
+		{name: "wrong signature", key: edPublic, signature: []byte("signature")},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			key, err := LoadPublicKey(writePublicKey(t, tc.key))
+			require.NoError(t, err)
+
+			err = Verify(key, payload, tc.signature)
+			encodedErr := VerifyBase64(key, payload, []byte(base64.StdEncoding.EncodeToString(tc.signature)+"\n"))
+			if tc.valid {
+				assert.NoError(t, err)
+				assert.NoError(t, encodedErr)
+				assert.ErrorContains(t, Verify(key, []byte("other payload"), tc.signature), "invalid signature")
+				return
+			}
+			assert.ErrorContains(t, err, "invalid signature")
+			assert.ErrorContains(t, encodedErr, "invalid signature")
+		})
+	}
+
+	assert.ErrorContains(t, VerifyBase64(edPublic, payload, []byte("not base64!")), "invalid signature encoding")
+}
+
+func TestLoadPublicKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.pub")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a key"), 0o600))
+	private := filepath.Join(dir, "cosign.key")
+	require.NoError(t, os.WriteFile(private, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("key")}), 0o600))
+
+	_, err := LoadPublicKey(invalid)
+	assert.ErrorContains(t, err, "no PEM encoded public key")
+	_, err = LoadPublicKey(private)
+	assert.ErrorContains(t, err, "no PEM encoded public key")
+	_, err = LoadPublicKey(filepath.Join(dir, "missing.pub"))
+	assert.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte("signed payload")
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecdsaDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name  string
+		block *pem.Block
+	}{
+		{name: "ecdsa", block: &pem.Block{Type: "EC PRIVATE KEY", Bytes: ecdsaDER}},
+		{name: "rsa", block: &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}},
+		{name: "ed25519", block: &pem.Block{Type: "PRIVATE KEY", Bytes: edDER}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "signing.key")
+			require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(tc.block), 0o600))
+			key, err := LoadPrivateKey(path)
+			require.NoError(t, err)
+
+			signature, err := SignBase64(key, payload)
+			require.NoError(t, err)
+			assert.NoError(t, VerifyBase64(key.Public(), payload, signature))
+			assert.Error(t, VerifyBase64(key.Public(), []byte("other payload"), signature))
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "cosign.key")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("key")}), 0o600))
+	_, err = LoadPrivateKey(path)
+	assert.ErrorContains(t, err, "unsupported private key PEM type")
+}
@@ -9,7 +9,6 @@ import (
 
 	lru "github.com/hashicorp/golang-lru/v2"
 
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -48,7 +47,7 @@ func New(config Config) (*DNSCache, error) {
 
 func (nc *DNSCache) Add(event *trace.Event) error {
 	// parse dns argument
-	dns, err := parse.ArgVal[trace.ProtoDNS](event.Args, "proto_dns")
+	dns, err := trace.ArgValue[trace.ProtoDNS](event, "proto_dns")
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,63 @@
+package ebpf
+
+import (
+	"github.com/aquasecurity/tracee/pkg/bufferdecoder"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/chunks"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// addArgChunk keeps a chunk of an argument too big to fit in its event (an arg_chunk event),
+// until the event itself, submitted after its chunks, is decoded.
+func (t *Tracee) addArgChunk(decoder *bufferdecoder.EbpfDecoder, eCtx *bufferdecoder.EventContext, argnum int) {
+	definition := events.Core.GetDefinitionByID(events.ArgChunk)
+	args := make([]trace.Argument, len(definition.GetParams()))
+	if err := decoder.DecodeArguments(args, argnum, definition, events.ArgChunk); err != nil {
+		t.handleError(err)
+		return
+	}
+
+	var chunk chunks.Chunk
+	eventID, err := parse.ArgVal[uint32](args, "event_id")
+	if err == nil {
+		chunk.ArgIndex, err = parse.ArgVal[uint8](args, "arg_index")
+	}
+	if err == nil {
+		chunk.Offset, err = parse.ArgVal[uint32](args, "offset")
+	}
+	if err == nil {
+		chunk.TotalSize, err = parse.ArgVal[uint32](args, "total_size")
+	}
+	if err == nil {
+		chunk.Data, err = parse.ArgVal[[]byte](args, "data")
+	}
+	if err != nil {
+		logger.Debugw("Malformed arg chunk", "error", err)
+		return
+	}
+	chunk.EventID = int32(eventID)
+
+	t.argChunks.Add(chunks.Key{Timestamp: eCtx.Ts, HostTid: eCtx.HostTid}, chunk)
+}
+
+// takeChunkedArgs takes the arguments of an event reassembled from their chunks (if all of them
+// were received), by index.
+func (t *Tracee) takeChunkedArgs(eCtx *bufferdecoder.EventContext) map[uint8][]byte {
+	return t.argChunks.Take(chunks.Key{Timestamp: eCtx.Ts, HostTid: eCtx.HostTid}, int32(eCtx.EventID))
+}
+
+// replaceChunkedArgs replaces the truncated arguments of an event with their value reassembled
+// from their chunks.
+func (t *Tracee) replaceChunkedArgs(reassembled map[uint8][]byte, definition events.Definition, args []trace.Argument) {
+	params := definition.GetParams()
+	for index, data := range reassembled {
+		if int(index) >= len(args) || int(index) >= len(params) {
+			continue
+		}
+		if value, ok := chunks.Value(params[index].Type, data); ok {
+			args[index].Value = value
+		}
+	}
+}
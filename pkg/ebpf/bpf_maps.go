@@ -0,0 +1,93 @@
+package ebpf
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// eBPF maps whose size can be configured, and whose fill level is monitored
+const (
+	stackAddressesMapName = "stack_addresses" // stack traces of the events (if stack addresses are collected)
+	fdArgPathMapName      = "fd_arg_path_map" // paths of the fd arguments (if fd arguments are parsed)
+	containersMapName     = "containers_map"  // containers cgroups status
+)
+
+var monitoredBPFMaps = []string{stackAddressesMapName, fdArgPathMapName, containersMapName}
+
+// resizeBPFMaps sets the max entries of the configured eBPF maps. Maps can only be resized
+// before the eBPF object is loaded.
+func (t *Tracee) resizeBPFMaps() error {
+	for name, size := range t.config.BPFMaps.Sizes {
+		bpfMap, err := t.bpfModule.GetMap(name)
+		if err != nil {
+			return errfmt.Errorf("error getting access to '%s' eBPF Map %v", name, err)
+		}
+		if err := bpfMap.SetMaxEntries(size); err != nil {
+			return errfmt.WrapError(err)
+		}
+		logger.Debugw("eBPF map resized", "map", name, "max_entries", size)
+	}
+
+	return nil
+}
+
+// monitorBPFMaps checks the fill level of the monitored eBPF maps at every interval, until
+// the context is done. A warning is logged for the maps filled over the threshold, or whose
+// entries were missing once looked up (aged out or evicted) during the interval.
+func (t *Tracee) monitorBPFMaps(ctx context.Context) {
+	ticker := time.NewTicker(t.config.BPFMaps.Interval)
+	defer ticker.Stop()
+
+	evictions := make(map[string]uint64) // at the previous check
+
+	for {
+		select {
+		case <-ticker.C:
+			for _, name := range monitoredBPFMaps {
+				entries, maxEntries, err := t.countBPFMapEntries(name)
+				if err != nil {
+					logger.Debugw("Counting eBPF map entries", "map", name, "error", err)
+					continue
+				}
+				t.stats.MapPressure.SetFill(name, entries, maxEntries)
+
+				fill := t.stats.MapPressure.Get(name)
+				evicted := fill.Evictions - evictions[name]
+				evictions[name] = fill.Evictions
+				if fill.Percent() < t.config.BPFMaps.WarnThreshold && evicted == 0 {
+					continue
+				}
+				logger.Warnw("eBPF map under pressure, consider resizing it (see --bpf-maps)",
+					"map", name,
+					"entries", entries,
+					"max_entries", maxEntries,
+					"evictions", evicted,
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// countBPFMapEntries returns the entries in use and the max entries of an eBPF map.
+func (t *Tracee) countBPFMapEntries(name string) (uint32, uint32, error) {
+	bpfMap, err := t.bpfModule.GetMap(name)
+	if err != nil {
+		return 0, 0, errfmt.WrapError(err)
+	}
+
+	var entries uint32
+	iterator := bpfMap.Iterator()
+	for iterator.Next() {
+		entries++
+	}
+	if err := iterator.Err(); err != nil {
+		return 0, 0, errfmt.WrapError(err)
+	}
+
+	return entries, bpfMap.MaxEntries(), nil
+}
@@ -0,0 +1,93 @@
+package ebpf
+
+import (
+	"os"
+	"path/filepath"
+
+	bpf "github.com/aquasecurity/libbpfgo"
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/ebpf/pinning"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// pinnedBPFMaps are the eBPF maps holding the state tracked in the kernel (the processes,
+// threads and containers seen, and the attached programs and modified files), reused by a
+// restarted tracee when pinned.
+var pinnedBPFMaps = []string{
+	"containers_map",
+	"proc_info_map",
+	"task_info_map",
+	"bpf_attach_map",
+	"file_modification_map",
+}
+
+// pinBPFMaps sets the pin path of the state eBPF maps, in the pin namespace: the maps pinned
+// by a previous tracee are reused by libbpf when the object is loaded, the others are pinned
+// once created. The pinned maps incompatible with the object (e.g. resized, or of an older
+// version) are unpinned first. It must be called before the object is loaded.
+func (t *Tracee) pinBPFMaps() error {
+	if t.config.PinPath == "" {
+		return nil
+	}
+
+	if err := pinning.Prepare(t.config.PinPath); err != nil {
+		return errfmt.Errorf("error preparing the pin namespace: %v", err)
+	}
+
+	reused := 0
+	for _, name := range pinnedBPFMaps {
+		bpfMap, err := t.bpfModule.GetMap(name)
+		if err != nil {
+			return errfmt.Errorf("error getting access to '%s' eBPF Map %v", name, err)
+		}
+
+		path := filepath.Join(t.config.PinPath, name)
+		compatible, err := pinnedMapCompatible(bpfMap, path)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		if compatible {
+			reused++
+		} else if _, err := os.Stat(path); err == nil {
+			logger.Warnw("Pinned eBPF map incompatible with the eBPF object, unpinned", "map", name, "path", path)
+			if err := os.Remove(path); err != nil {
+				return errfmt.WrapError(err)
+			}
+		}
+
+		if err := bpfMap.SetPinPath(path); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	logger.Infow("eBPF maps pinned", "path", t.config.PinPath, "reused", reused, "pinned", len(pinnedBPFMaps))
+
+	return nil
+}
+
+// pinnedMapCompatible returns true if a map is pinned at the given path, and libbpf can reuse
+// it for the given map (same type, key and value sizes, max entries and flags).
+func pinnedMapCompatible(bpfMap *bpf.BPFMap, path string) (bool, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil
+	}
+
+	fd, err := pinning.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer unix.Close(fd)
+
+	info, err := bpf.GetMapInfoByFD(fd)
+	if err != nil {
+		return false, err
+	}
+
+	return info.Type == bpfMap.Type() &&
+		info.KeySize == uint32(bpfMap.KeySize()) &&
+		info.ValueSize == uint32(bpfMap.ValueSize()) &&
+		info.MaxEntries == bpfMap.MaxEntries() &&
+		info.MapFlags == uint32(bpfMap.MapFlags()), nil
+}
@@ -19,6 +19,7 @@ statfunc int save_str_arr_to_buf(args_buffer_t *, const char __user *const __use
 statfunc int save_args_str_arr_to_buf(args_buffer_t *, const char *, const char *, int, u8);
 statfunc int save_sockaddr_to_buf(args_buffer_t *, struct socket *, u8);
 statfunc int save_args_to_submit_buf(event_data_t *, args_t *);
+statfunc int submit_arg_chunks(program_data_t *, const void *, u32, u8);
 statfunc int events_perf_submit(program_data_t *, long);
 statfunc int signal_perf_submit(void *, controlplane_signal_t *);
 
@@ -444,6 +445,67 @@ statfunc int save_args_to_submit_buf(event_data_t *event, args_t *args)
     return arg_num;
 }
 
+// Arguments too big to fit in their event (e.g. long command lines) are streamed in follow-up
+// chunks (arg_chunk events), submitted on the same cpu before the event itself, so they are read
+// first. The whole argument is streamed, and reassembled in userspace, keyed by the timestamp and
+// thread of the event: if any chunk is lost, the argument truncated in the event is kept.
+statfunc int submit_arg_chunks(program_data_t *p, const void *ptr, u32 size, u8 index)
+{
+    // Chunk submitted: [context][argnum][0][event id][1][arg index][2][offset][3][total size]
+    //                  [4][chunk size][ ... bytes ... ]
+
+    // Only stream the arguments of the events written to the perf buffer
+    if (!(p->event->config.emit_for_policies & p->event->context.matched_policies))
+        return 0;
+
+    int zero = 0;
+    event_data_t *chunk = bpf_map_lookup_elem(&arg_chunk_data_map, &zero);
+    if (unlikely(chunk == NULL))
+        return 0;
+
+    // the context identifying the event (its timestamp and thread) is set by init_program_data
+    __builtin_memcpy(&chunk->context, &p->event->context, sizeof(event_context_t));
+    chunk->context.eventid = ARG_CHUNK;
+
+    u32 event_id = p->event->context.eventid;
+    if (size > MAX_ARG_CHUNKS * ARG_CHUNK_SIZE)
+        size = MAX_ARG_CHUNKS * ARG_CHUNK_SIZE;
+
+    int submitted = 0;
+#pragma unroll
+    for (int i = 0; i < MAX_ARG_CHUNKS; i++) {
+        u32 offset = i * ARG_CHUNK_SIZE;
+        if (offset >= size)
+            break;
+        u32 len = size - offset;
+        if (len > ARG_CHUNK_SIZE)
+            len = ARG_CHUNK_SIZE;
+
+        chunk->args_buf.offset = 0;
+        chunk->args_buf.argnum = 0;
+        save_to_submit_buf(&chunk->args_buf, &event_id, sizeof(u32), 0);
+        save_to_submit_buf(&chunk->args_buf, &index, sizeof(u8), 1);
+        save_to_submit_buf(&chunk->args_buf, &offset, sizeof(u32), 2);
+        save_to_submit_buf(&chunk->args_buf, &size, sizeof(u32), 3);
+        if (!save_bytes_to_buf(&chunk->args_buf, (void *) ptr + offset, len, 4))
+            break;
+
+        u32 chunk_size = sizeof(event_context_t) + sizeof(u8) + chunk->args_buf.offset;
+
+        // inline bounds check to force compiler to use the register of size
+        asm volatile("if %[size] < %[max_size] goto +1;\n"
+                     "%[size] = %[max_size];\n"
+                     :
+                     : [size] "r"(chunk_size), [max_size] "i"(MAX_EVENT_SIZE));
+
+        if (bpf_perf_event_output(p->ctx, &events, BPF_F_CURRENT_CPU, chunk, chunk_size) != 0)
+            break;
+        submitted++;
+    }
+
+    return submitted;
+}
+
 statfunc int events_perf_submit(program_data_t *p, long ret)
 {
     p->event->context.retval = ret;
@@ -453,8 +515,14 @@ statfunc int events_perf_submit(program_data_t *p, long ret)
     // keep task_info updated
     bpf_probe_read_kernel(&p->task_info->context, sizeof(task_context_t), &p->event->context.task);
 
-    // Get Stack trace
-    if (p->config->options & OPT_CAPTURE_STACK_TRACES) {
+    // Only write the events consumed in userspace by the matched policies to the perf buffer
+    // (dependencies only needed in the kernel aren't)
+    if (!(p->event->config.emit_for_policies & p->event->context.matched_policies))
+        return 0;
+
+    // Get Stack trace (of all events, or of the events selected for it)
+    if (p->config->options & OPT_CAPTURE_STACK_TRACES ||
+        p->event->config.stack_for_policies & p->event->context.matched_policies) {
         int stack_id = bpf_get_stackid(p->ctx, &stack_addresses, BPF_F_USER_STACK);
         if (stack_id >= 0) {
             p->event->context.stack_id = stack_id;
@@ -26,6 +26,8 @@
 #define MAX_PATH_PREF_SIZE    64
 #define MAX_PATH_COMPONENTS   20
 #define MAX_BIN_CHUNKS        110
+#define ARG_CHUNK_SIZE        2048 // arg_chunk: bytes of an argument per chunk (< MAX_BYTES_ARR_SIZE)
+#define MAX_ARG_CHUNKS        16   // arg_chunk: chunks of an argument (bigger ones are cut)
 
 #define CAPTURE_IFACE (1 << 0)
 #define TRACE_IFACE   (1 << 1)
@@ -52,6 +52,18 @@ statfunc int init_task_context(task_context_t *tsk_ctx, struct task_struct *task
     if (is_compat(task))
         tsk_ctx->flags |= IS_COMPAT_FLAG;
 
+    // Task state flags may change during the task lifetime (e.g. setns, capset, seccomp): the
+    // context is kept in task_info, so they are recomputed on each event.
+    tsk_ctx->flags &= ~(INIT_NS_FLAG | PRIVILEGED_FLAG | SECCOMP_FLAG | NO_NEW_PRIVS_FLAG);
+    if (is_task_in_init_ns(task))
+        tsk_ctx->flags |= INIT_NS_FLAG;
+    if (is_task_privileged(task))
+        tsk_ctx->flags |= PRIVILEGED_FLAG;
+    if (is_task_seccomp(task))
+        tsk_ctx->flags |= SECCOMP_FLAG;
+    if (is_task_no_new_privs(task))
+        tsk_ctx->flags |= NO_NEW_PRIVS_FLAG;
+
     // Program name
     bpf_get_current_comm(&tsk_ctx->comm, sizeof(tsk_ctx->comm));
 
@@ -188,12 +200,16 @@ statfunc int init_program_data(program_data_t *p, void *ctx, u32 event_id)
 
     // default to match all policies until an event is selected
     p->event->config.submit_for_policies = ~0ULL;
+    p->event->config.stack_for_policies = 0;
+    p->event->config.emit_for_policies = ~0ULL;
 
     if (event_id != NO_EVENT_SUBMIT) {
         event_config_t *event_config = get_event_config(event_id, p->event->context.policies_version);
         if (event_config != NULL) {
             p->event->config.param_types = event_config->param_types;
             p->event->config.submit_for_policies = event_config->submit_for_policies;
+            p->event->config.stack_for_policies = event_config->stack_for_policies;
+            p->event->config.emit_for_policies = event_config->emit_for_policies;
         }
     }
 
@@ -244,6 +260,7 @@ statfunc bool reset_event(event_data_t *event, u32 event_id)
     event->context.eventid = event_id;
     reset_event_args_buf(event);
     event->config.submit_for_policies = ~0ULL;
+    event->config.emit_for_policies = ~0ULL;
 
     event_config_t *event_config = get_event_config(event_id, event->context.policies_version);
     if (event_config == NULL)
@@ -251,6 +268,7 @@ statfunc bool reset_event(event_data_t *event, u32 event_id)
 
     event->config.param_types = event_config->param_types;
     event->config.submit_for_policies = event_config->submit_for_policies;
+    event->config.emit_for_policies = event_config->emit_for_policies;
     event->context.matched_policies = event_config->submit_for_policies;
 
     return true;
@@ -175,6 +175,78 @@ struct {
     __type(value, u64);                     // ... old sock->socket inode number
 } sockmap SEC(".maps");                     // relate a cloned sock struct with
 
+// network flow stats (accounted by the sock_ops program)
+
+typedef struct netflowstats {
+    u64 start;                              // time the flow was established
+    u64 last_report;                        // time the flow stats were last submitted
+    u8 direction;                           // flow_incoming or flow_outgoing
+} __attribute__((__packed__)) netflowstats_t;
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LRU_HASH);
+    __uint(max_entries, 65535);             // simultaneous established tcp flows
+    __type(key, netflow_t);                 // the network flow (src: local, dst: remote) ...
+    __type(value, netflowstats_t);          // ... linked to its accounting state
+} netflowstatsmap SEC(".maps");
+
+struct {
+    __uint(type, BPF_MAP_TYPE_LRU_HASH);
+    __uint(max_entries, 65535); // 9 MB     // simultaneous tcp flows being traced
+    __type(key, netflow_t);                 // the network flow (src: local, dst: remote) ...
+    __type(value, struct net_task_context); // ... linked to the task which connected or accepted it
+} netflowtaskmap SEC(".maps");
+
+// net_flow_stats_base event, submitted by the sock_ops program (which can't use the
+// per-cpu submit buffer helpers)
+
+typedef struct net_flow_stats_event {
+    event_context_t eventctx;
+    u8 argnum;
+    struct {
+        u8 index0;
+        u8 direction;
+        u8 index1;
+        u32 family;
+        u8 index2;
+        u32 local_addr_size;
+        u8 local_addr[16];
+        u8 index3;
+        u32 remote_addr_size;
+        u8 remote_addr[16];
+        u8 index4;
+        u32 local_port;
+        u8 index5;
+        u32 remote_port;
+        u8 index6;
+        u64 duration;
+        u8 index7;
+        u64 bytes_in;
+        u8 index8;
+        u64 bytes_out;
+        u8 index9;
+        u64 packets_in;
+        u8 index10;
+        u64 packets_out;
+        u8 index11;
+        u8 closed;
+    } __attribute__((__packed__));
+} __attribute__((__packed__)) net_flow_stats_event_t;
+
+// arp header and payload of ethernet/ipv4 arp packets (as in the wire)
+
+typedef struct arp_eth_ipv4 {
+    __be16 hrd;                             // hardware type (ARPHRD_ETHER)
+    __be16 pro;                             // protocol type (ETH_P_IP)
+    u8 hln;                                 // hardware address length
+    u8 pln;                                 // protocol address length
+    __be16 op;                              // ARPOP_REQUEST or ARPOP_REPLY
+    u8 sha[6];                              // sender hardware address
+    __be32 sip;                             // sender ipv4 address
+    u8 tha[6];                              // target hardware address
+    __be32 tip;                             // target ipv4 address
+} __attribute__((__packed__)) arp_eth_ipv4_t;
+
 // entrymap
 
 typedef struct entry {
@@ -5,6 +5,7 @@
 #include <vmlinux_flavors.h>
 
 #include <common/arch.h>
+#include <common/capabilities.h>
 #include <common/namespaces.h>
 
 // PROTOTYPES
@@ -31,6 +32,11 @@ statfunc struct task_struct *get_parent_task(struct task_struct *task);
 statfunc u32 get_task_exit_code(struct task_struct *task);
 statfunc int get_task_parent_flags(struct task_struct *task);
 statfunc const struct cred *get_task_real_cred(struct task_struct *task);
+statfunc u32 get_task_user_ns_id(struct task_struct *task);
+statfunc bool is_task_in_init_ns(struct task_struct *task);
+statfunc bool is_task_privileged(struct task_struct *task);
+statfunc bool is_task_seccomp(struct task_struct *task);
+statfunc bool is_task_no_new_privs(struct task_struct *task);
 
 // FUNCTIONS
 
@@ -190,4 +196,39 @@ statfunc const struct cred *get_task_real_cred(struct task_struct *task)
     return BPF_CORE_READ(task, real_cred);
 }
 
+statfunc u32 get_task_user_ns_id(struct task_struct *task)
+{
+    const struct cred *cred = get_task_real_cred(task);
+    return BPF_CORE_READ(cred, user_ns, ns.inum);
+}
+
+// The initial pid, user, uts, ipc and cgroup namespaces have fixed ids. The initial mount and
+// network namespaces ids are dynamically allocated, so they aren't checked.
+statfunc bool is_task_in_init_ns(struct task_struct *task)
+{
+    return get_task_pid_ns_id(task) == PROC_PID_INIT_INO &&
+           get_task_user_ns_id(task) == PROC_USER_INIT_INO &&
+           get_task_uts_ns_id(task) == PROC_UTS_INIT_INO &&
+           get_task_ipc_ns_id(task) == PROC_IPC_INIT_INO &&
+           get_task_cgroup_ns_id(task) == PROC_CGROUP_INIT_INO;
+}
+
+statfunc bool is_task_privileged(struct task_struct *task)
+{
+    const struct cred *cred = get_task_real_cred(task);
+    u64 cap_effective = credcap_to_slimcap((void *) &cred->cap_effective);
+    return cap_effective & (1ULL << CAP_SYS_ADMIN);
+}
+
+statfunc bool is_task_seccomp(struct task_struct *task)
+{
+    return BPF_CORE_READ(task, seccomp.mode) != SECCOMP_MODE_DISABLED;
+}
+
+statfunc bool is_task_no_new_privs(struct task_struct *task)
+{
+    unsigned long atomic_flags = BPF_CORE_READ(task, atomic_flags);
+    return atomic_flags & (1UL << PFA_NO_NEW_PRIVS);
+}
+
 #endif
@@ -318,6 +318,16 @@ struct signal_data_map {
 
 typedef struct signal_data_map signal_data_map_t;
 
+// arg chunks scratch map
+struct arg_chunk_data_map {
+    __uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+    __uint(max_entries, 1);
+    __type(key, u32);
+    __type(value, event_data_t);
+} arg_chunk_data_map SEC(".maps");
+
+typedef struct arg_chunk_data_map arg_chunk_data_map_t;
+
 // logs count
 struct logs_count {
     __uint(type, BPF_MAP_TYPE_HASH);
@@ -348,6 +358,16 @@ struct file_modification_map {
 
 typedef struct file_modification_map file_modification_map_t;
 
+// hold the OOM killer invocations, by host tgid of the victim, until the victim exits
+struct oom_kill_map {
+    __uint(type, BPF_MAP_TYPE_LRU_HASH);
+    __uint(max_entries, 1024);
+    __type(key, u32);
+    __type(value, oom_kill_info_t);
+} oom_kill_map SEC(".maps");
+
+typedef struct oom_kill_map oom_kill_map_t;
+
 // store cache for IO operations path
 struct io_file_path_cache_map {
     __uint(type, BPF_MAP_TYPE_LRU_HASH);
@@ -1396,6 +1396,9 @@ int sched_process_exec_event_submit_tail(struct bpf_raw_tracepoint_args *ctx)
         invoked_from_kernel = 1;
     }
 
+    // long command lines are streamed in chunks, as they are truncated in the event
+    if (arg_end - arg_start > MAX_ARR_LEN - 1)
+        submit_arg_chunks(&p, (void *) arg_start, arg_end - arg_start, 10);
     save_args_str_arr_to_buf(&p.event->args_buf, (void *) arg_start, (void *) arg_end, argc, 10);
     save_str_to_buf(&p.event->args_buf, (void *) interp, 11);
     save_to_submit_buf(&p.event->args_buf, &stdin_type, sizeof(unsigned short), 12);
@@ -1407,6 +1410,8 @@ int sched_process_exec_event_submit_tail(struct bpf_raw_tracepoint_args *ctx)
         env_end = get_env_end_from_mm(mm);
         int envc = get_envc_from_bprm(bprm);
 
+        if (env_end - env_start > MAX_ARR_LEN - 1)
+            submit_arg_chunks(&p, (void *) env_start, env_end - env_start, 15);
         save_args_str_arr_to_buf(
             &p.event->args_buf, (void *) env_start, (void *) env_end, envc, 15);
     }
@@ -1608,6 +1613,128 @@ int BPF_KPROBE(trace_do_exit)
     return events_perf_submit(&p, code);
 }
 
+// fs/coredump.c: void do_coredump(const kernel_siginfo_t *siginfo), vfs_coredump() since 6.16
+SEC("kprobe/do_coredump")
+int BPF_KPROBE(trace_do_coredump)
+{
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, CORE_DUMP))
+        return 0;
+
+    if (!evaluate_scope_filters(&p))
+        return 0;
+
+    const kernel_siginfo_t *info = (const kernel_siginfo_t *) PT_REGS_PARM1(ctx);
+    int signo = BPF_CORE_READ(info, si_signo);
+    int code = BPF_CORE_READ(info, si_code);
+    // only meaningful for the faults (e.g. SIGSEGV), interpreted in userland
+    void *addr = BPF_CORE_READ(info, _sifields._sigfault._addr);
+
+    save_to_submit_buf(&p.event->args_buf, (void *) &signo, sizeof(int), 0);
+    save_to_submit_buf(&p.event->args_buf, (void *) &code, sizeof(int), 1);
+    save_to_submit_buf(&p.event->args_buf, (void *) &addr, sizeof(void *), 2);
+
+    // the core dump destination: a file name pattern, or a handler program if starting with '|'
+    char core_pattern_sym[13] = "core_pattern";
+    char *core_pattern = (char *) get_symbol_addr(core_pattern_sym);
+    if (core_pattern)
+        save_str_to_buf(&p.event->args_buf, core_pattern, 3);
+
+    return events_perf_submit(&p, 0);
+}
+
+// mm/oom_kill.c: static void oom_kill_process(struct oom_control *oc, const char *message)
+SEC("kprobe/oom_kill_process")
+int BPF_KPROBE(trace_oom_kill_process)
+{
+    // Runs in the context of the task that triggered the OOM killer: the victim is reported
+    // once exiting, in its own context (see trace_exit_oom_victim).
+    struct oom_control *oc = (struct oom_control *) PT_REGS_PARM1(ctx);
+    struct task_struct *victim = BPF_CORE_READ(oc, chosen);
+    if (victim == NULL)
+        return 0;
+
+    oom_kill_info_t info = {0};
+    struct mem_cgroup *memcg = BPF_CORE_READ(oc, memcg);
+    if (memcg != NULL)
+        info.memcg_id = get_cgroup_id(BPF_CORE_READ(memcg, css.cgroup));
+    info.totalpages = BPF_CORE_READ(oc, totalpages);
+    info.points = BPF_CORE_READ(oc, chosen_points);
+    if (bpf_core_field_exists(oc->constraint))
+        info.constraint = BPF_CORE_READ(oc, constraint);
+
+    struct task_struct *task = (struct task_struct *) bpf_get_current_task();
+    info.killer_pid = get_task_host_tgid(task);
+    info.killer_ns_pid = get_task_ns_tgid(task);
+    bpf_get_current_comm(&info.killer_comm, sizeof(info.killer_comm));
+
+    u32 victim_tgid = get_task_host_tgid(victim);
+    bpf_map_update_elem(&oom_kill_map, &victim_tgid, &info, BPF_ANY);
+
+    return 0;
+}
+
+// mm/oom_kill.c: void exit_oom_victim(void), called by the exiting OOM victim (exit_mm)
+SEC("kprobe/exit_oom_victim")
+int BPF_KPROBE(trace_exit_oom_victim)
+{
+    u32 tgid = bpf_get_current_pid_tgid() >> 32;
+    oom_kill_info_t *info = bpf_map_lookup_elem(&oom_kill_map, &tgid);
+    if (info == NULL)
+        return 0; // not killed by the OOM killer (e.g. already exiting when chosen)
+
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, OOM_KILL))
+        goto out;
+
+    if (!evaluate_scope_filters(&p))
+        goto out;
+
+    save_to_submit_buf(&p.event->args_buf, &info->memcg_id, sizeof(u64), 0);
+    // memcg path (1) resolved in userland
+    save_to_submit_buf(&p.event->args_buf, &info->constraint, sizeof(int), 2);
+    save_to_submit_buf(&p.event->args_buf, &info->totalpages, sizeof(unsigned long), 3);
+    save_to_submit_buf(&p.event->args_buf, &info->points, sizeof(long), 4);
+    save_to_submit_buf(&p.event->args_buf, &info->killer_pid, sizeof(int), 5);
+    save_str_to_buf(&p.event->args_buf, info->killer_comm, 6);
+    save_to_submit_buf(&p.event->args_buf, &info->killer_ns_pid, sizeof(int), 7);
+
+    events_perf_submit(&p, 0);
+
+out:
+    bpf_map_delete_elem(&oom_kill_map, &tgid);
+    return 0;
+}
+
+// kernel/cgroup/cgroup.c: static ssize_t cgroup_kill_write(struct kernfs_open_file *of, char
+// *buf, size_t nbytes, loff_t off), writing "1" to cgroup.kill (cgroup v2, since 5.14)
+SEC("kprobe/cgroup_kill_write")
+int BPF_KPROBE(trace_cgroup_kill_write)
+{
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, CGROUP_KILL))
+        return 0;
+
+    if (!evaluate_scope_filters(&p))
+        return 0;
+
+    struct kernfs_open_file *of = (struct kernfs_open_file *) PT_REGS_PARM1(ctx);
+    char *buf = (char *) PT_REGS_PARM2(ctx);
+    char kill = 0;
+    bpf_probe_read_kernel(&kill, sizeof(kill), buf);
+    if (kill != '1')
+        return 0; // "0" is a no-op
+
+    // the cgroup id is the inode number of the cgroup directory, holding the cgroup.kill file
+    struct dentry *dir = BPF_CORE_READ(of, file, f_path.dentry, d_parent);
+    u64 cgroup_id = BPF_CORE_READ(dir, d_inode, i_ino);
+
+    save_to_submit_buf(&p.event->args_buf, &cgroup_id, sizeof(u64), 0);
+    // cgroup path and killed container (1-5) resolved in userland
+
+    return events_perf_submit(&p, 0);
+}
+
 statfunc void syscall_table_check(program_data_t *p)
 {
     char sys_call_table_symbol[15] = "sys_call_table";
@@ -6083,6 +6210,313 @@ int cgroup_skb_egress(struct __sk_buff *ctx)
     return cgroup_skb_generic(ctx, &cgrpctxmap_eg);
 }
 
+//
+// Network Flow Stats (sock_ops)
+//
+
+// TCP flows are accounted by a sock_ops program, attached to the root cgroup, from the
+// moment they are established until they are closed: the byte and segment counters are
+// kept by the kernel TCP stack, and submitted in net_flow_stats_base events when the flow
+// is closed (and periodically, if a flow stats interval is configured).
+//
+// The sock_ops program mostly runs in the softirq context, so the task owning a flow is
+// linked to it when the flow is connected or accepted (in the task context).
+
+statfunc bool get_netflow_from_sock(struct sock *sk, netflow_t *flow)
+{
+    struct inet_sock *inet = inet_sk(sk);
+
+    switch (get_sock_family(sk)) {
+        case PF_INET:
+            flow->src.u6_addr32[0] = get_inet_rcv_saddr(inet);
+            flow->dst.u6_addr32[0] = get_inet_daddr(inet);
+            break;
+        case PF_INET6: {
+            struct in6_addr src = get_sock_v6_rcv_saddr(sk);
+            struct in6_addr dst = get_sock_v6_daddr(sk);
+            __builtin_memcpy(&flow->src, &src, sizeof(struct in6_addr));
+            __builtin_memcpy(&flow->dst, &dst, sizeof(struct in6_addr));
+            break;
+        }
+        default:
+            return false;
+    }
+
+    flow->proto = IPPROTO_TCP;
+    flow->srcport = get_inet_num(inet);              // host byte order
+    flow->dstport = bpf_ntohs(get_inet_dport(inet)); // network to host byte order
+
+    return true;
+}
+
+statfunc bool get_netflow_from_sock_ops(struct bpf_sock_ops *skops, netflow_t *flow)
+{
+    switch (skops->family) {
+        case PF_INET:
+            flow->src.u6_addr32[0] = skops->local_ip4;
+            flow->dst.u6_addr32[0] = skops->remote_ip4;
+            break;
+        case PF_INET6:
+            flow->src.u6_addr32[0] = skops->local_ip6[0];
+            flow->src.u6_addr32[1] = skops->local_ip6[1];
+            flow->src.u6_addr32[2] = skops->local_ip6[2];
+            flow->src.u6_addr32[3] = skops->local_ip6[3];
+            flow->dst.u6_addr32[0] = skops->remote_ip6[0];
+            flow->dst.u6_addr32[1] = skops->remote_ip6[1];
+            flow->dst.u6_addr32[2] = skops->remote_ip6[2];
+            flow->dst.u6_addr32[3] = skops->remote_ip6[3];
+            break;
+        default:
+            return false;
+    }
+
+    flow->proto = IPPROTO_TCP;
+    flow->srcport = skops->local_port;             // host byte order
+    flow->dstport = bpf_ntohl(skops->remote_port); // network byte order, in the upper 16 bits
+
+    return true;
+}
+
+// Link a TCP flow to the task connecting or accepting it.
+statfunc void link_netflow_to_task(program_data_t *p, struct sock *sk)
+{
+    netflow_t flow = {0};
+    if (!get_netflow_from_sock(sk, &flow))
+        return;
+
+    net_task_context_t netctx = {0};
+    set_net_task_context(p->event, &netctx);
+
+    bpf_map_update_elem(&netflowtaskmap, &flow, &netctx, BPF_ANY);
+}
+
+SEC("kprobe/tcp_connect")
+int BPF_KPROBE(trace_tcp_connect)
+{
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, NET_FLOW_STATS_BASE))
+        return 0;
+
+    if (!evaluate_scope_filters(&p))
+        return 0;
+
+    struct sock *sk = (void *) PT_REGS_PARM1(ctx);
+    if (sk == NULL)
+        return 0;
+
+    link_netflow_to_task(&p, sk);
+
+    return 0;
+}
+
+SEC("kretprobe/inet_csk_accept")
+int BPF_KPROBE(trace_ret_inet_csk_accept)
+{
+    struct sock *sk = (void *) PT_REGS_RC(ctx);
+    if (sk == NULL)
+        return 0;
+    if (get_sock_protocol(sk) != IPPROTO_TCP)
+        return 0; // e.g. DCCP
+
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, NET_FLOW_STATS_BASE))
+        return 0;
+
+    if (!evaluate_scope_filters(&p))
+        return 0;
+
+    link_netflow_to_task(&p, sk);
+
+    return 0;
+}
+
+// Submit the stats of a flow, if it is linked to a traced task.
+statfunc void submit_netflow_stats(struct bpf_sock_ops *skops,
+                                   netflow_t *flow,
+                                   netflowstats_t *stats,
+                                   u64 now,
+                                   bool closed)
+{
+    net_task_context_t *netctx = bpf_map_lookup_elem(&netflowtaskmap, flow);
+    if (!netctx)
+        return; // not connected or accepted by a traced task (yet)
+
+    net_flow_stats_event_t evt = {0};
+
+    __builtin_memcpy(&evt.eventctx.task, &netctx->taskctx, sizeof(task_context_t));
+    evt.eventctx.ts = now;
+    evt.eventctx.eventid = NET_FLOW_STATS_BASE;
+    evt.eventctx.syscall = NO_SYSCALL;
+    evt.eventctx.processor_id = (u16) bpf_get_smp_processor_id();
+    evt.eventctx.policies_version = netctx->policies_version;
+    evt.eventctx.matched_policies = netctx->matched_policies;
+
+    evt.argnum = 12;
+    evt.index0 = 0;
+    evt.direction = stats->direction;
+    evt.index1 = 1;
+    evt.family = skops->family;
+    evt.index2 = 2;
+    evt.local_addr_size = sizeof(evt.local_addr);
+    __builtin_memcpy(evt.local_addr, &flow->src, sizeof(evt.local_addr));
+    evt.index3 = 3;
+    evt.remote_addr_size = sizeof(evt.remote_addr);
+    __builtin_memcpy(evt.remote_addr, &flow->dst, sizeof(evt.remote_addr));
+    evt.index4 = 4;
+    evt.local_port = flow->srcport;
+    evt.index5 = 5;
+    evt.remote_port = flow->dstport;
+    evt.index6 = 6;
+    evt.duration = now - stats->start;
+    evt.index7 = 7;
+    evt.bytes_in = skops->bytes_received;
+    evt.index8 = 8;
+    evt.bytes_out = skops->bytes_acked;
+    evt.index9 = 9;
+    evt.packets_in = skops->segs_in;
+    evt.index10 = 10;
+    evt.packets_out = skops->segs_out;
+    evt.index11 = 11;
+    evt.closed = closed;
+
+    bpf_perf_event_output(skops, &events, BPF_F_CURRENT_CPU, &evt, sizeof(evt));
+
+    stats->last_report = now;
+}
+
+SEC("sockops")
+int sock_ops_netflow(struct bpf_sock_ops *skops)
+{
+    // IMPORTANT: runs for EVERY tcp flow of tasks belonging to root cgroup
+
+    netflow_t flow = {0};
+    netflowstats_t *stats;
+    u64 now = bpf_ktime_get_ns();
+    u64 interval = 0;
+    u32 zero = 0;
+
+    netconfig_entry_t *netconfig = bpf_map_lookup_elem(&netconfig_map, &zero);
+    if (netconfig)
+        interval = netconfig->flow_stats_interval;
+
+    switch (skops->op) {
+        case BPF_SOCK_OPS_ACTIVE_ESTABLISHED_CB:
+        case BPF_SOCK_OPS_PASSIVE_ESTABLISHED_CB: {
+            if (!get_netflow_from_sock_ops(skops, &flow))
+                return 1;
+
+            netflowstats_t newstats = {
+                .start = now,
+                .last_report = now,
+                .direction = flow_incoming,
+            };
+            if (skops->op == BPF_SOCK_OPS_ACTIVE_ESTABLISHED_CB) {
+                // outgoing flows are connected before being established: ignore the
+                // ones not connected by traced tasks (accepted flows are only linked to
+                // their task once established)
+                if (!bpf_map_lookup_elem(&netflowtaskmap, &flow))
+                    return 1;
+                newstats.direction = flow_outgoing;
+            }
+            bpf_map_update_elem(&netflowstatsmap, &flow, &newstats, BPF_ANY);
+
+            // get called back on state changes (close), and on every RTT for
+            // periodic flow stats
+            int flags = skops->bpf_sock_ops_cb_flags | BPF_SOCK_OPS_STATE_CB_FLAG;
+            if (interval)
+                flags |= BPF_SOCK_OPS_RTT_CB_FLAG;
+            bpf_sock_ops_cb_flags_set(skops, flags);
+            break;
+        }
+
+        case BPF_SOCK_OPS_RTT_CB:
+            if (!interval)
+                return 1;
+            if (!get_netflow_from_sock_ops(skops, &flow))
+                return 1;
+            stats = bpf_map_lookup_elem(&netflowstatsmap, &flow);
+            if (!stats)
+                return 1;
+            if (now - stats->last_report < interval)
+                return 1;
+
+            submit_netflow_stats(skops, &flow, stats, now, false);
+            break;
+
+        case BPF_SOCK_OPS_STATE_CB:
+            if (skops->args[1] != TCP_CLOSE) // new state
+                return 1;
+            if (!get_netflow_from_sock_ops(skops, &flow))
+                return 1;
+            stats = bpf_map_lookup_elem(&netflowstatsmap, &flow);
+            if (!stats)
+                return 1;
+
+            submit_netflow_stats(skops, &flow, stats, now, true);
+
+            bpf_map_delete_elem(&netflowstatsmap, &flow);
+            bpf_map_delete_elem(&netflowtaskmap, &flow);
+            break;
+    }
+
+    return 1;
+}
+
+//
+// Gratuitous ARP
+//
+
+// trace/events/net.h: TP_PROTO(struct sk_buff *skb)
+SEC("raw_tracepoint/net_dev_queue")
+int tracepoint__net__net_dev_queue(struct bpf_raw_tracepoint_args *ctx)
+{
+    struct sk_buff *skb = (struct sk_buff *) ctx->args[0];
+
+    // IMPORTANT: runs for EVERY packet queued to a device, check the protocol first
+    if (BPF_CORE_READ(skb, protocol) != bpf_htons(ETH_P_ARP))
+        return 0;
+
+    program_data_t p = {};
+    if (!init_program_data(&p, ctx, GRATUITOUS_ARP))
+        return 0;
+
+    if (!evaluate_scope_filters(&p))
+        return 0;
+
+    // ARP packets are sent synchronously by the task sending them (e.g. through a packet
+    // socket) and their network header is the ARP header.
+    arp_eth_ipv4_t arp = {0};
+    unsigned char *head = BPF_CORE_READ(skb, head);
+    u16 network_header = BPF_CORE_READ(skb, network_header);
+    if (bpf_core_read(&arp, sizeof(arp), head + network_header) != 0)
+        return 0;
+
+    if (arp.hrd != bpf_htons(ARPHRD_ETHER) || arp.pro != bpf_htons(ETH_P_IP))
+        return 0;
+    if (arp.hln != sizeof(arp.sha) || arp.pln != sizeof(arp.sip))
+        return 0;
+
+    // gratuitous ARP: the sender announces its own address (requests or replies)
+    if (arp.sip != arp.tip)
+        return 0;
+
+    u16 op = bpf_ntohs(arp.op);
+    if (op != ARPOP_REQUEST && op != ARPOP_REPLY)
+        return 0;
+
+    struct net_device *dev = BPF_CORE_READ(skb, dev);
+
+    save_str_to_buf(&p.event->args_buf, __builtin_preserve_access_index(&dev->name), 0);
+    save_to_submit_buf(&p.event->args_buf, &op, sizeof(u16), 1);
+    save_bytes_to_buf(&p.event->args_buf, arp.sha, sizeof(arp.sha), 2);
+    save_bytes_to_buf(&p.event->args_buf, &arp.sip, sizeof(arp.sip), 3);
+    save_bytes_to_buf(&p.event->args_buf, arp.tha, sizeof(arp.tha), 4);
+
+    events_perf_submit(&p, 0);
+
+    return 0;
+}
+
 //
 // Network Protocol Events Logic
 //
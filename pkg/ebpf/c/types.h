@@ -128,6 +128,12 @@ enum event_id_e
     MODULE_FREE,
     EXECUTE_FINISHED,
     SECURITY_BPRM_CREDS_FOR_EXEC,
+    NET_FLOW_STATS_BASE,
+    GRATUITOUS_ARP,
+    CORE_DUMP,
+    OOM_KILL,
+    CGROUP_KILL,
+    ARG_CHUNK,
     MAX_EVENT_ID,
     NO_EVENT_SUBMIT,
 };
@@ -200,7 +206,11 @@ typedef struct fd_arg_path {
 enum context_flags_e
 {
     CONTAINER_STARTED_FLAG = (1 << 0), // mark the task's container have started
-    IS_COMPAT_FLAG = (1 << 1)          // is the task running in compatible mode
+    IS_COMPAT_FLAG = (1 << 1),         // is the task running in compatible mode
+    INIT_NS_FLAG = (1 << 2),           // is the task in the initial pid, user, uts, ipc and cgroup ns
+    PRIVILEGED_FLAG = (1 << 3),        // does the task have CAP_SYS_ADMIN in its effective set
+    SECCOMP_FLAG = (1 << 4),           // is the task confined by seccomp (strict or filter mode)
+    NO_NEW_PRIVS_FLAG = (1 << 5)       // may the task not gain new privileges (PR_SET_NO_NEW_PRIVS)
 };
 
 enum container_state_e
@@ -339,6 +349,8 @@ typedef struct config_entry {
 typedef struct event_config {
     u64 submit_for_policies;
     u64 param_types;
+    u64 stack_for_policies; // policies requiring the stack trace of this event
+    u64 emit_for_policies;  // policies consuming this event in userspace (written to perf buffer)
 } event_config_t;
 
 enum capture_options_e
@@ -347,8 +359,9 @@ enum capture_options_e
 };
 
 typedef struct netconfig_entry {
-    u32 capture_options; // bitmask of capture options (pcap)
-    u32 capture_length;  // amount of network packet payload to capture (pcap)
+    u32 capture_options;     // bitmask of capture options (pcap)
+    u32 capture_length;      // amount of network packet payload to capture (pcap)
+    u64 flow_stats_interval; // interval (ns) between two flow stats of a flow (0: on close only)
 } netconfig_entry_t;
 
 typedef struct syscall_table_entry {
@@ -538,6 +551,16 @@ typedef struct file_mod_key {
     unsigned long inode;
 } file_mod_key_t;
 
+typedef struct oom_kill_info {
+    u64 memcg_id; // 0 if the whole system is out of memory
+    u64 totalpages;
+    s64 points;
+    u32 constraint;
+    u32 killer_pid;    // in the host pid namespace
+    u32 killer_ns_pid; // in the killer pid namespace
+    char killer_comm[TASK_COMM_LEN];
+} oom_kill_info_t;
+
 enum file_modification_op
 {
     FILE_MODIFICATION_SUBMIT = 0,
@@ -254,6 +254,10 @@ typedef struct {
     uid_t val;
 } kuid_t;
 
+struct seccomp {
+    int mode;
+};
+
 struct task_struct {
     struct thread_info thread_info;
     unsigned int flags;
@@ -274,6 +278,8 @@ struct task_struct {
     struct signal_struct *signal;
     void *stack;
     struct sighand_struct *sighand;
+    unsigned long atomic_flags;
+    struct seccomp seccomp;
 };
 
 typedef struct {
@@ -284,6 +290,21 @@ struct signal_struct {
     atomic_t live;
 };
 
+union __sifields {
+    struct {
+        void *_addr;
+    } _sigfault;
+};
+
+typedef struct kernel_siginfo {
+    struct {
+        int si_signo;
+        int si_errno;
+        int si_code;
+        union __sifields _sifields;
+    };
+} kernel_siginfo_t;
+
 struct vm_area_struct {
     long unsigned int vm_flags;
     struct file *vm_file;
@@ -406,6 +427,31 @@ struct cgroup_root {
     int hierarchy_id;
 };
 
+struct mem_cgroup {
+    struct cgroup_subsys_state css;
+};
+
+enum oom_constraint
+{
+    CONSTRAINT_NONE,
+    CONSTRAINT_CPUSET,
+    CONSTRAINT_MEMORY_POLICY,
+    CONSTRAINT_MEMCG,
+};
+
+struct oom_control {
+    struct mem_cgroup *memcg;
+    long unsigned int totalpages;
+    struct task_struct *chosen;
+    long int chosen_points;
+    enum oom_constraint constraint;
+};
+
+struct kernfs_open_file {
+    struct kernfs_node *kn;
+    struct file *file;
+};
+
 struct fdtable {
     struct file **fd;
 };
@@ -589,6 +635,10 @@ struct msghdr {
 
 typedef s64 ktime_t;
 
+struct net_device {
+    char name[16];
+};
+
 struct sk_buff {
     __u16 network_header;
     union {
@@ -596,6 +646,8 @@ struct sk_buff {
         u64 skb_mstamp_ns;
     };
     unsigned char *head;
+    struct net_device *dev;
+    __be16 protocol;
 };
 
 struct linux_binprm {
@@ -860,6 +912,73 @@ struct __sk_buff {
     __u32 gso_size;
 };
 
+struct bpf_sock_ops {
+    __u32 op;
+    union {
+        __u32 args[4];
+        __u32 reply;
+        __u32 replylong[4];
+    };
+    __u32 family;
+    __u32 remote_ip4;
+    __u32 local_ip4;
+    __u32 remote_ip6[4];
+    __u32 local_ip6[4];
+    __u32 remote_port;
+    __u32 local_port;
+    __u32 is_fullsock;
+    __u32 snd_cwnd;
+    __u32 srtt_us;
+    __u32 bpf_sock_ops_cb_flags;
+    __u32 state;
+    __u32 rtt_min;
+    __u32 snd_ssthresh;
+    __u32 rcv_nxt;
+    __u32 snd_nxt;
+    __u32 snd_una;
+    __u32 mss_cache;
+    __u32 ecn_flags;
+    __u32 rate_delivered;
+    __u32 rate_interval_us;
+    __u32 packets_out;
+    __u32 retrans_out;
+    __u32 total_retrans;
+    __u32 segs_in;
+    __u32 data_segs_in;
+    __u32 segs_out;
+    __u32 data_segs_out;
+    __u32 lost_out;
+    __u32 sacked_out;
+    __u32 sk_txhash;
+    __u64 bytes_received;
+    __u64 bytes_acked;
+};
+
+enum
+{
+    BPF_SOCK_OPS_VOID = 0,
+    BPF_SOCK_OPS_TIMEOUT_INIT = 1,
+    BPF_SOCK_OPS_RWND_INIT = 2,
+    BPF_SOCK_OPS_TCP_CONNECT_CB = 3,
+    BPF_SOCK_OPS_ACTIVE_ESTABLISHED_CB = 4,
+    BPF_SOCK_OPS_PASSIVE_ESTABLISHED_CB = 5,
+    BPF_SOCK_OPS_NEEDS_ECN = 6,
+    BPF_SOCK_OPS_BASE_RTT = 7,
+    BPF_SOCK_OPS_RTO_CB = 8,
+    BPF_SOCK_OPS_RETRANS_CB = 9,
+    BPF_SOCK_OPS_STATE_CB = 10,
+    BPF_SOCK_OPS_TCP_LISTEN_CB = 11,
+    BPF_SOCK_OPS_RTT_CB = 12,
+};
+
+enum
+{
+    BPF_SOCK_OPS_RTO_CB_FLAG = 1,
+    BPF_SOCK_OPS_RETRANS_CB_FLAG = 2,
+    BPF_SOCK_OPS_STATE_CB_FLAG = 4,
+    BPF_SOCK_OPS_RTT_CB_FLAG = 8,
+};
+
 struct ethhdr {
     unsigned char h_dest[6];
     unsigned char h_source[6];
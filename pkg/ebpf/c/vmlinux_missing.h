@@ -50,6 +50,19 @@
 
 #define PF_KTHREAD 0x00200000 /* I am a kernel thread */
 
+#define PFA_NO_NEW_PRIVS 0 /* May not gain new privileges. */
+
+#define SECCOMP_MODE_DISABLED 0 /* seccomp is not in use. */
+
+#define CAP_SYS_ADMIN 21
+
+// fixed inode numbers of the initial namespaces (mnt and net ones are dynamically allocated)
+#define PROC_IPC_INIT_INO    0xEFFFFFFFU
+#define PROC_UTS_INIT_INO    0xEFFFFFFEU
+#define PROC_USER_INIT_INO   0xEFFFFFFDU
+#define PROC_PID_INIT_INO    0xEFFFFFFCU
+#define PROC_CGROUP_INIT_INO 0xEFFFFFFBU
+
 #define TASK_COMM_LEN 16
 
 #define PROC_SUPER_MAGIC 0x9fa0
@@ -155,8 +168,13 @@ enum perf_type_id
 #define TC_ACT_REDIRECT   7
 
 #define ETH_P_IP   0x0800
+#define ETH_P_ARP  0x0806
 #define ETH_P_IPV6 0x86DD
 
+#define ARPHRD_ETHER  1
+#define ARPOP_REQUEST 1
+#define ARPOP_REPLY   2
+
 #define s6_addr   in6_u.u6_addr8
 #define s6_addr16 in6_u.u6_addr16
 #define s6_addr32 in6_u.u6_addr32
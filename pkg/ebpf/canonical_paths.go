@@ -0,0 +1,84 @@
+package ebpf
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// pathArgDirFDs are the path arguments of the syscalls canonicalized, with the names of the
+// directory fd arguments they may be relative to (the working directory if none).
+var pathArgDirFDs = map[string][]string{
+	"pathname": {"dirfd", "dfd"},
+	"path":     nil,
+	"filename": {"dirfd", "dfd"},
+	"oldpath":  {"olddirfd"},
+	"newpath":  {"newdirfd"},
+	"linkpath": {"newdirfd"},
+	"target":   nil, // mount points (the target of a symlink is its content instead)
+}
+
+// noFollowEvents are the syscalls not following the last symbolic link of their paths: they
+// apply to the link itself.
+var noFollowEvents = map[events.ID]struct{}{
+	events.Unlink:       {},
+	events.Unlinkat:     {},
+	events.Rmdir:        {},
+	events.Rename:       {},
+	events.Renameat:     {},
+	events.Renameat2:    {},
+	events.Lstat:        {},
+	events.Readlink:     {},
+	events.Readlinkat:   {},
+	events.Symlink:      {},
+	events.Symlinkat:    {},
+	events.Link:         {},
+	events.Linkat:       {},
+	events.Lchown:       {},
+	events.Lsetxattr:    {},
+	events.Lgetxattr:    {},
+	events.Llistxattr:   {},
+	events.Lremovexattr: {},
+}
+
+// canonicalizePaths replaces the path arguments of an emitted syscall event with their
+// canonical absolute path, within the mount namespace of the process. The paths that can't be
+// resolved (e.g. relative paths of exited processes) are left as is.
+func (t *Tracee) canonicalizePaths(event *trace.Event) {
+	id := events.ID(event.EventID)
+	_, noFollow := noFollowEvents[id]
+	if flags, err := parse.ArgVal[int32](event.Args, "flags"); err == nil {
+		switch id {
+		case events.Open, events.Openat:
+			noFollow = noFollow || flags&unix.O_NOFOLLOW != 0
+		case events.Newfstatat, events.Statx, events.Fchownat, events.Utimensat, events.Fchmodat:
+			noFollow = noFollow || flags&unix.AT_SYMLINK_NOFOLLOW != 0
+		}
+	}
+
+	for i := range event.Args {
+		arg := &event.Args[i]
+		dirFDNames, ok := pathArgDirFDs[arg.Name]
+		if !ok || (arg.Name == "target" && (id == events.Symlink || id == events.Symlinkat)) {
+			continue
+		}
+		p, ok := arg.Value.(string)
+		if !ok || p == "" {
+			continue
+		}
+
+		dirfd := int32(unix.AT_FDCWD)
+		for _, name := range dirFDNames {
+			if fd, err := parse.ArgVal[int32](event.Args, name); err == nil {
+				dirfd = fd
+				break
+			}
+		}
+
+		if canonical, ok := t.pathResolver.Resolve(event.HostProcessID, event.MountNS, dirfd, p, !noFollow); ok {
+			arg.Value = canonical
+		}
+	}
+}
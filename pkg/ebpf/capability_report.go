@@ -0,0 +1,116 @@
+package ebpf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// capabilityReport records what the running kernel lacks for the selected events while tracee
+// initializes: the events canceled (missing kernel symbols, required probes failing to attach),
+// the events missing optional probes, and the probes attached to their fallback hooks.
+// It is reported by the capability_report event.
+type capabilityReport struct {
+	selected  []events.ID            // events selected by the policies, before initializing
+	canceled  map[events.ID]string   // canceled events, with their reason
+	degraded  map[events.ID][]string // events missing optional probes, with their reasons
+	fallbacks map[string]string      // probes attached to their fallback hook
+}
+
+// newCapabilityReport creates a report of the events selected by the policies, by the given
+// events state.
+func newCapabilityReport(eventsState map[events.ID]events.EventState) *capabilityReport {
+	r := &capabilityReport{
+		canceled:  make(map[events.ID]string),
+		degraded:  make(map[events.ID][]string),
+		fallbacks: make(map[string]string),
+	}
+	for id, state := range eventsState {
+		if state.Emit != 0 && events.Core.IsDefined(id) { // emitted to the user (not dependencies)
+			r.selected = append(r.selected, id)
+		}
+	}
+
+	return r
+}
+
+// cancel records the reason an event (and its dependants) is canceled.
+func (r *capabilityReport) cancel(id events.ID, reason string) {
+	r.canceled[id] = reason
+}
+
+// degrade records a reason an event is degraded.
+func (r *capabilityReport) degrade(id events.ID, reason string) {
+	r.degraded[id] = append(r.degraded[id], reason)
+}
+
+// fallback records a probe attached to a fallback hook.
+func (r *capabilityReport) fallback(probe, fallback string) {
+	r.fallbacks[probe] = fallback
+}
+
+// event creates the capability_report event of the selected events, by the events state after
+// initializing.
+func (r *capabilityReport) event(eventsState map[events.ID]events.EventState) trace.Event {
+	var disabled, degraded []string
+	reasons := make(map[string]string)
+
+	for _, id := range r.selected {
+		name := events.Core.GetDefinitionByID(id).GetName()
+		if _, ok := eventsState[id]; !ok {
+			disabled = append(disabled, name)
+			reasons[name] = r.cancelReason(id, make(map[events.ID]bool))
+			continue
+		}
+		if degradedReasons := r.degradedReasons(id, make(map[events.ID]bool)); len(degradedReasons) > 0 {
+			degraded = append(degraded, name)
+			reasons[name] = strings.Join(degradedReasons, "; ")
+		}
+	}
+	sort.Strings(disabled)
+	sort.Strings(degraded)
+
+	return events.CapabilityReportEvent(disabled, degraded, reasons, r.fallbacks)
+}
+
+// cancelReason returns the reason an event was canceled: its own, or the one of the canceled
+// event it depends on.
+func (r *capabilityReport) cancelReason(id events.ID, visited map[events.ID]bool) string {
+	if reason, ok := r.canceled[id]; ok {
+		return reason
+	}
+	visited[id] = true
+
+	for _, depID := range events.Core.GetDefinitionByID(id).GetDependencies().GetIDs() {
+		if visited[depID] {
+			continue
+		}
+		if reason := r.cancelReason(depID, visited); reason != "" {
+			if _, ok := r.canceled[depID]; ok {
+				name := events.Core.GetDefinitionByID(depID).GetName()
+				return fmt.Sprintf("dependency %s canceled: %s", name, reason)
+			}
+			return reason
+		}
+	}
+
+	return ""
+}
+
+// degradedReasons returns the reasons an event is degraded: its own, and the ones of the events
+// it depends on.
+func (r *capabilityReport) degradedReasons(id events.ID, visited map[events.ID]bool) []string {
+	visited[id] = true
+	reasons := append([]string(nil), r.degraded[id]...)
+
+	for _, depID := range events.Core.GetDefinitionByID(id).GetDependencies().GetIDs() {
+		if !visited[depID] {
+			reasons = append(reasons, r.degradedReasons(depID, visited)...)
+		}
+	}
+
+	return reasons
+}
@@ -0,0 +1,54 @@
+package ebpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+)
+
+func TestCapabilityReport(t *testing.T) {
+	t.Parallel()
+
+	selected := events.EventState{Submit: 1, Emit: 1}
+	dependency := events.EventState{Submit: 1}
+	eventsState := map[events.ID]events.EventState{
+		events.Openat:           selected,
+		events.VfsUtimes:        selected,
+		events.ProcessCrashed:   selected,
+		events.HookedSyscall:    selected,
+		events.CoreDump:         dependency,
+		events.SchedProcessExit: dependency,
+	}
+	r := newCapabilityReport(eventsState)
+
+	r.cancel(events.VfsUtimes, "probe vfs_utimes not attached: no symbol")
+	delete(eventsState, events.VfsUtimes)
+	r.cancel(events.SyscallTableCheck, "missing kernel symbols: sys_call_table")
+	delete(eventsState, events.HookedSyscall) // canceled as a dependant of syscall_table_check
+	r.degrade(events.CoreDump, "probe do_coredump not attached: no symbol")
+	r.fallback("__x64_sys_execve", "__arm64_sys_execve")
+
+	event := r.event(eventsState)
+	assert.Equal(t, int(events.CapabilityReport), event.EventID)
+
+	disabled, err := parse.ArgVal[[]string](event.Args, "disabled_events")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hooked_syscall", "vfs_utimes"}, disabled)
+	degraded, err := parse.ArgVal[[]string](event.Args, "degraded_events")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"process_crashed"}, degraded)
+	reasons, err := parse.ArgVal[map[string]string](event.Args, "reasons")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"vfs_utimes":      "probe vfs_utimes not attached: no symbol",
+		"hooked_syscall":  "dependency syscall_table_check canceled: missing kernel symbols: sys_call_table",
+		"process_crashed": "probe do_coredump not attached: no symbol",
+	}, reasons)
+	fallbacks, err := parse.ArgVal[map[string]string](event.Args, "probe_fallbacks")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"__x64_sys_execve": "__arm64_sys_execve"}, fallbacks)
+}
@@ -66,6 +66,7 @@ func (t *Tracee) handleFileCaptures(ctx context.Context) {
 				continue
 			}
 			filename := ""
+			artifactPid := 0
 			metaBuffDecoder := bufferdecoder.New(meta.Metadata[:])
 			var kernelModuleMeta bufferdecoder.KernelModuleMeta
 			var bpfObjectMeta bufferdecoder.BpfObjectMeta
@@ -85,6 +86,7 @@ func (t *Tracee) handleFileCaptures(ctx context.Context) {
 				} else {
 					operation = "write"
 				}
+				artifactPid = int(vfsMeta.Pid)
 				if vfsMeta.Pid == 0 {
 					filename = fmt.Sprintf(
 						"%s.dev-%d.inode-%d",
@@ -117,6 +119,7 @@ func (t *Tracee) handleFileCaptures(ctx context.Context) {
 					mprotectMeta.Ts += t.bootTime
 				}
 				filename = fmt.Sprintf("bin.pid-%d.ts-%d", mprotectMeta.Pid, mprotectMeta.Ts)
+				artifactPid = int(mprotectMeta.Pid)
 			} else if meta.BinType == bufferdecoder.SendKernelModule {
 				err = metaBuffDecoder.DecodeKernelModuleMeta(&kernelModuleMeta)
 				if err != nil {
@@ -202,6 +205,7 @@ func (t *Tracee) handleFileCaptures(ctx context.Context) {
 					t.handleError(err)
 					continue
 				}
+				t.submitArtifact(fullname+"."+fileHash, "module", int(kernelModuleMeta.Pid), containerId)
 			} else if meta.BinType == bufferdecoder.SendBpfObject && (uint32(meta.Size)+uint32(meta.Off)) == bpfObjectMeta.Size {
 				fileHash, _ := t.computeOutFileHash(fullname)
 				// Delete the random int used to differentiate files
@@ -211,6 +215,17 @@ func (t *Tracee) handleFileCaptures(ctx context.Context) {
 					t.handleError(err)
 					continue
 				}
+				t.submitArtifact(fullname[:dotIndex]+"."+fileHash, "bpf", int(bpfObjectMeta.Pid), containerId)
+			}
+
+			// Scan the vfs and memory captures once no longer written to
+			switch meta.BinType {
+			case bufferdecoder.SendVfsWrite:
+				t.submitArtifact(fullname, "write", artifactPid, containerId)
+			case bufferdecoder.SendVfsRead:
+				t.submitArtifact(fullname, "read", artifactPid, containerId)
+			case bufferdecoder.SendMprotect:
+				t.submitArtifact(fullname, "mem", artifactPid, containerId)
 			}
 
 		case lost := <-t.lostCapturesChannel:
@@ -0,0 +1,142 @@
+package ebpf
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/cgroup"
+	"github.com/aquasecurity/tracee/pkg/cgroup/pressure"
+	cruntime "github.com/aquasecurity/tracee/pkg/containers/runtime"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initCgroupPressure enables the resource pressure monitoring of the containers cgroups,
+// if one of the cgroup pressure events is selected.
+func (t *Tracee) initCgroupPressure() {
+	selected := false
+	for _, id := range []events.ID{events.CgroupMemoryPressure, events.CgroupCPUPressure, events.CgroupIOPressure} {
+		if t.eventsState[id].Submit > 0 {
+			selected = true
+		}
+	}
+	if !selected {
+		return
+	}
+	if t.containers.GetCgroupVersion() != cgroup.CgroupVersion2 {
+		logger.Warnw("Cgroup pressure events are read from the cgroup v2 controllers, which is not the default cgroup version")
+		return
+	}
+
+	mountPoint := t.cgroups.GetDefaultCgroup().GetMountPoint()
+	t.cgroupPressure = pressure.NewMonitor(t.config.CgroupPressure, func() []pressure.Cgroup {
+		var cgroups []pressure.Cgroup
+		for id, info := range t.containers.GetContainers() {
+			if info.Dead {
+				continue
+			}
+			cgroups = append(cgroups, pressure.Cgroup{ID: id, Path: filepath.Join(mountPoint, info.Path)})
+		}
+		return cgroups
+	})
+}
+
+// cgroupPressureReports returns the channel of the cgroups resource pressure reports (nil
+// if the cgroup pressure events aren't selected).
+func (t *Tracee) cgroupPressureReports() <-chan pressure.Report {
+	if t.cgroupPressure == nil {
+		return nil
+	}
+
+	return t.cgroupPressure.Reports()
+}
+
+// cgroupPressureEvent returns the cgroup_memory_pressure, cgroup_cpu_pressure or
+// cgroup_io_pressure event of a resource pressure report, or nil if it isn't selected.
+func (t *Tracee) cgroupPressureEvent(report pressure.Report) *trace.Event {
+	var id events.ID
+	var values []interface{}
+	interval := uint64(report.Interval.Microseconds())
+	someStall := uint64(report.SomeStall.Microseconds())
+	fullStall := uint64(report.FullStall.Microseconds())
+
+	switch report.Controller {
+	case pressure.Memory:
+		id = events.CgroupMemoryPressure
+		values = []interface{}{
+			interval,
+			report.MemoryHigh,
+			report.MemoryMax,
+			report.MemoryOOM,
+			report.MemoryOOMKill,
+			someStall,
+			fullStall,
+		}
+	case pressure.CPU:
+		id = events.CgroupCPUPressure
+		values = []interface{}{
+			interval,
+			report.CPUPeriods,
+			report.CPUThrottled,
+			uint64(report.CPUThrottledTime.Microseconds()),
+			someStall,
+			fullStall,
+		}
+	case pressure.IO:
+		id = events.CgroupIOPressure
+		values = []interface{}{interval, someStall, fullStall}
+	default:
+		return nil
+	}
+	if t.eventsState[id].Submit == 0 {
+		return nil
+	}
+
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+
+	// the cgroup might have been removed since it was sampled
+	var container cruntime.ContainerMetadata
+	if t.containers.CgroupExists(uint64(report.Cgroup.ID)) {
+		container = t.containers.GetCgroupInfo(uint64(report.Cgroup.ID)).Container
+	}
+	path := strings.TrimPrefix(report.Cgroup.Path, t.cgroups.GetDefaultCgroup().GetMountPoint())
+	values = append([]interface{}{path}, values...)
+
+	def := events.Core.GetDefinitionByID(id)
+	params := def.GetParams()
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return &trace.Event{
+		Timestamp:   int(t.normalizeSnapshotTime(uint64(report.Time.UnixNano()) - t.bootTime)),
+		CgroupID:    uint(report.Cgroup.ID),
+		ContainerID: container.ContainerId,
+		Container: trace.Container{
+			ID:          container.ContainerId,
+			Name:        container.Name,
+			ImageName:   container.Image,
+			ImageDigest: container.ImageDigest,
+		},
+		Kubernetes: trace.Kubernetes{
+			PodName:      container.Pod.Name,
+			PodNamespace: container.Pod.Namespace,
+			PodUID:       container.Pod.UID,
+			PodSandbox:   container.Pod.Sandbox,
+		},
+		Labels:                t.config.Labels,
+		EventID:               int(id),
+		EventName:             def.GetName(),
+		PoliciesVersion:       policies.Version(),
+		MatchedPoliciesKernel: t.eventsState[id].Submit,
+		ArgsNum:               len(args),
+		Args:                  args,
+	}
+}
@@ -0,0 +1,116 @@
+package ebpf
+
+import (
+	gocontext "context"
+
+	cruntime "github.com/aquasecurity/tracee/pkg/containers/runtime"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const containerLifecycleBuffer = 1024
+
+// initContainerLifecycle enables the containers runtimes events streams, if one of the
+// container lifecycle events is selected.
+func (t *Tracee) initContainerLifecycle() {
+	selected := false
+	for _, id := range []events.ID{events.ContainerStart, events.ContainerStop, events.ContainerOOM} {
+		if t.eventsState[id].Submit > 0 {
+			selected = true
+		}
+	}
+	if !selected {
+		return
+	}
+	if t.config.NoContainersEnrich {
+		logger.Warnw("Container lifecycle events are streamed from the containers runtimes, which requires the container enrichment")
+		return
+	}
+
+	t.containerLifecycle = make(chan cruntime.LifecycleEvent, containerLifecycleBuffer)
+}
+
+// runContainerLifecycle streams the containers runtimes lifecycle events, until the
+// context is done.
+func (t *Tracee) runContainerLifecycle(ctx gocontext.Context) {
+	if t.containerLifecycle == nil {
+		return
+	}
+
+	t.containers.StreamLifecycleEvents(ctx, t.containerLifecycle)
+}
+
+// containerLifecycleEvent returns the container_start, container_stop or container_oom
+// event of a runtime lifecycle event, or nil if it isn't selected.
+func (t *Tracee) containerLifecycleEvent(lifecycle cruntime.LifecycleEvent) *trace.Event {
+	var id events.ID
+	var values []interface{}
+	container := lifecycle.Container
+
+	switch lifecycle.Action {
+	case cruntime.ContainerStarted:
+		id = events.ContainerStart
+		values = []interface{}{
+			lifecycle.Runtime.String(),
+			container.ContainerId,
+			container.Image,
+			container.ImageDigest,
+			container.Name,
+			container.Pod.Name,
+			container.Pod.Namespace,
+			container.Pod.UID,
+			container.Pod.Sandbox,
+			lifecycle.Pid,
+		}
+	case cruntime.ContainerStopped:
+		id = events.ContainerStop
+		values = []interface{}{lifecycle.Runtime.String(), container.ContainerId, int32(lifecycle.ExitCode)}
+	case cruntime.ContainerOOM:
+		id = events.ContainerOOM
+		values = []interface{}{lifecycle.Runtime.String(), container.ContainerId}
+	default:
+		return nil
+	}
+	if t.eventsState[id].Submit == 0 {
+		return nil
+	}
+
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+	def := events.Core.GetDefinitionByID(id)
+	params := def.GetParams()
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return &trace.Event{
+		Timestamp:     int(t.normalizeSnapshotTime(uint64(lifecycle.Time.UnixNano()) - t.bootTime)),
+		HostProcessID: int(lifecycle.Pid),
+		ContainerID:   container.ContainerId,
+		Container: trace.Container{
+			ID:          container.ContainerId,
+			Name:        container.Name,
+			ImageName:   container.Image,
+			ImageDigest: container.ImageDigest,
+		},
+		Kubernetes: trace.Kubernetes{
+			PodName:      container.Pod.Name,
+			PodNamespace: container.Pod.Namespace,
+			PodUID:       container.Pod.UID,
+			PodSandbox:   container.Pod.Sandbox,
+		},
+		Labels:                t.config.Labels,
+		EventID:               int(id),
+		EventName:             def.GetName(),
+		PoliciesVersion:       policies.Version(),
+		MatchedPoliciesKernel: t.eventsState[id].Submit,
+		ArgsNum:               len(args),
+		Args:                  args,
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/aquasecurity/tracee/pkg/containers/runtime"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -113,7 +112,7 @@ func (t *Tracee) enrichContainerEvents(ctx gocontext.Context, in <-chan *trace.E
 						out <- event
 						continue
 					}
-					cgroupId, err = parse.ArgVal[uint64](event.Args, "cgroup_id")
+					cgroupId, err = event.ArgUlong("cgroup_id")
 					if err != nil {
 						logger.Errorw("cgroup_mkdir event failed to trigger enrichment: couldn't get cgroup_id", "error", err, "event_name", event.EventName)
 						out <- event
@@ -205,7 +204,7 @@ func (t *Tracee) enrichContainerEvents(ctx gocontext.Context, in <-chan *trace.E
 			select {
 			case event := <-queueClean:
 				bLock.Lock()
-				cgroupId, err := parse.ArgVal[uint64](event.Args, "cgroup_id")
+				cgroupId, err := event.ArgUlong("cgroup_id")
 				if err != nil {
 					logger.Errorw("cgroup_rmdir event failed to trigger enrich queue clean: couldn't get cgroup_id", "error", err, "event_name", event.EventName)
 					out <- event
@@ -244,6 +243,7 @@ func enrichEvent(evt *trace.Event, enrichData runtime.ContainerMetadata) {
 		ImageName:   enrichData.Image,
 		ImageDigest: enrichData.ImageDigest,
 		Name:        enrichData.Name,
+		Ports:       containerPorts(events.ID(evt.EventID), enrichData.Ports),
 	}
 	evt.Kubernetes = trace.Kubernetes{
 		PodName:      enrichData.Pod.Name,
@@ -252,6 +252,42 @@ func enrichEvent(evt *trace.Event, enrichData runtime.ContainerMetadata) {
 	}
 }
 
+// containerPorts returns the ports a container publishes on the host, given to its network
+// events only: a connection seen on a container port is correlated with its external exposure.
+func containerPorts(eventID events.ID, ports []runtime.PortMapping) []trace.PortMapping {
+	if len(ports) == 0 || !isNetworkEvent(eventID) {
+		return nil
+	}
+
+	mappings := make([]trace.PortMapping, 0, len(ports))
+	for _, port := range ports {
+		mappings = append(mappings, trace.PortMapping{
+			ContainerPort: port.ContainerPort,
+			Protocol:      port.Protocol,
+			HostIP:        port.HostIP,
+			HostPort:      port.HostPort,
+		})
+	}
+
+	return mappings
+}
+
+// isNetworkEvent returns true for the network events: the network packets and flows events,
+// and the sockets events.
+func isNetworkEvent(eventID events.ID) bool {
+	definition := events.Core.GetDefinitionByID(eventID)
+	if definition.IsNetwork() {
+		return true
+	}
+	for _, set := range definition.GetSets() {
+		if set == "network_events" || set == "net" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // isCgroupEventInHid checks if cgroup event is relevant for deriving container event in its hierarchy id.
 // in tracee we only care about containers inside the cpuset controller, as such other hierarchy ids will lead
 // to a failed query.
@@ -259,7 +295,7 @@ func isCgroupEventInHid(event *trace.Event, cts *containers.Containers) (bool, e
 	if cts.GetCgroupVersion() == cgroup.CgroupVersion2 {
 		return true, nil
 	}
-	hierarchyID, err := parse.ArgVal[uint32](event.Args, "hierarchy_id")
+	hierarchyID, err := event.ArgUint("hierarchy_id")
 	if err != nil {
 		return false, errfmt.WrapError(err)
 	}
@@ -0,0 +1,33 @@
+package ebpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/pkg/containers/runtime"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestContainerPorts(t *testing.T) {
+	t.Parallel()
+
+	ports := []runtime.PortMapping{
+		{ContainerPort: 80, Protocol: "tcp", HostIP: "0.0.0.0", HostPort: 8080},
+		{ContainerPort: 53, Protocol: "udp", HostPort: 5353},
+	}
+	expected := []trace.PortMapping{
+		{ContainerPort: 80, Protocol: "tcp", HostIP: "0.0.0.0", HostPort: 8080},
+		{ContainerPort: 53, Protocol: "udp", HostPort: 5353},
+	}
+
+	// network packets, flows and sockets events carry the published ports
+	assert.Equal(t, expected, containerPorts(events.NetPacketBase, ports))
+	assert.Equal(t, expected, containerPorts(events.NetPacketTCP, ports))
+	assert.Equal(t, expected, containerPorts(events.SecuritySocketBind, ports))
+
+	// other events don't
+	assert.Nil(t, containerPorts(events.SchedProcessExec, ports))
+	assert.Nil(t, containerPorts(events.NetPacketTCP, nil))
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"runtime"
 	"strconv"
 	"sync"
+	"time"
 	"unsafe"
 
 	"github.com/aquasecurity/tracee/pkg/bufferdecoder"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/queue"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/policy"
 	"github.com/aquasecurity/tracee/pkg/utils"
@@ -23,6 +26,18 @@ const maxStackDepth int = 20
 // Matches 'NO_SYSCALL' in eBPF code
 const noSyscall int32 = -1
 
+// Max number of raw events passed at once from the perf buffer to the decode stage
+const maxEventsBatchSize = 256
+
+// Min interval between logs of the events cache watermark level changes
+const cacheWatermarkLogInterval = 10 * time.Second
+
+// Arguments parsing stage workers limits
+const (
+	maxParseArgsWorkers    = 4
+	parseArgsJobsPerWorker = 1000
+)
+
 // handleEvents is the main pipeline of tracee. It receives events from the perf buffer
 // and passes them through a series of stages, each stage is a goroutine that performs a
 // specific task on the event. The pipeline is started in a separate goroutine.
@@ -32,9 +47,14 @@ func (t *Tracee) handleEvents(ctx context.Context, initialized chan<- struct{})
 
 	var errcList []<-chan error
 
-	// Decode stage: events are read from the perf buffer and decoded into trace.Event type.
+	// Batch stage: raw events read from the perf buffer are passed in batches to the decode
+	// stage, amortizing the channel synchronization cost on high events rates.
+
+	batchesChan := t.batchEvents(ctx, t.eventsChannel)
 
-	eventsChan, errc := t.decodeEvents(ctx, t.eventsChannel)
+	// Decode stage: events are decoded into trace.Event type.
+
+	eventsChan, errc := t.decodeEvents(ctx, batchesChan)
 	errcList = append(errcList, errc)
 
 	// Cache stage: events go through a caching function.
@@ -68,6 +88,30 @@ func (t *Tracee) handleEvents(ctx context.Context, initialized chan<- struct{})
 	eventsChan, errc = t.deriveEvents(ctx, eventsChan)
 	errcList = append(errcList, errc)
 
+	// Forward stage: in a split pipeline, the events are forwarded to the remote processor,
+	// running the next stages.
+
+	if t.forwarder != nil {
+		errcList = append(errcList, t.forwardEvents(ctx, eventsChan))
+	} else {
+		errcList = append(errcList, t.outputStages(ctx, eventsChan)...)
+	}
+
+	initialized <- struct{}{}
+
+	// Pipeline started. Waiting for pipeline to complete
+
+	if err := t.WaitForPipeline(errcList...); err != nil {
+		logger.Errorw("Pipeline", "error", err)
+	}
+}
+
+// outputStages starts the detection and output stages of the pipeline, run by the remote
+// processor in a split pipeline.
+func (t *Tracee) outputStages(ctx context.Context, eventsChan <-chan *trace.Event) []<-chan error {
+	var errcList []<-chan error
+	var errc <-chan error
+
 	// Engine events stage: events go through the signatures engine for detection.
 
 	if t.config.EngineConfig.Enabled {
@@ -75,18 +119,20 @@ func (t *Tracee) handleEvents(ctx context.Context, initialized chan<- struct{})
 		errcList = append(errcList, errc)
 	}
 
+	// Parse arguments stage: events arguments are parsed into human readable values (done
+	// by the engine stage if it is enabled).
+
+	if !t.config.EngineConfig.Enabled && t.config.Output.ParseArguments {
+		eventsChan, errc = t.parseArgumentsEvents(ctx, eventsChan)
+		errcList = append(errcList, errc)
+	}
+
 	// Sink pipeline stage: events go through printers.
 
 	errc = t.sinkEvents(ctx, eventsChan)
 	errcList = append(errcList, errc)
 
-	initialized <- struct{}{}
-
-	// Pipeline started. Waiting for pipeline to complete
-
-	if err := t.WaitForPipeline(errcList...); err != nil {
-		logger.Errorw("Pipeline", "error", err)
-	}
+	return errcList
 }
 
 // Under some circumstances, tracee-rules might be slower to consume events than
@@ -116,6 +162,32 @@ func (t *Tracee) queueEvents(ctx context.Context, in <-chan *trace.Event) (chan
 	errc := make(chan error, 1)
 	done := make(chan struct{}, 1)
 
+	if q, ok := t.config.Cache.(queue.MemoryBudgeted); ok {
+		// level changes are logged at most once per interval, counting the skipped ones
+		var mutex sync.Mutex
+		var lastLog time.Time
+		changes := 0
+
+		q.OnWatermark(func(level queue.WatermarkLevel, usage, budget int) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			changes++
+			if time.Since(lastLog) < cacheWatermarkLogInterval {
+				return
+			}
+			logger.Warnw("Events cache memory usage changed level",
+				"level", level.String(),
+				"usage", usage,
+				"budget", budget,
+				"dropped", q.Dropped(),
+				"changes", changes,
+			)
+			lastLog = time.Now()
+			changes = 0
+		})
+	}
+
 	// receive and cache events (release pressure in the pipeline)
 	go func() {
 		for {
@@ -125,6 +197,8 @@ func (t *Tracee) queueEvents(ctx context.Context, in <-chan *trace.Event) (chan
 				return
 			case event := <-in:
 				if event != nil {
+					// the memory of the cached events is accounted with their decoded arguments
+					event.DecodeArgs()
 					t.config.Cache.Enqueue(event) // may block if queue is full
 				}
 			}
@@ -152,141 +226,274 @@ func (t *Tracee) queueEvents(ctx context.Context, in <-chan *trace.Event) (chan
 	return out, errc
 }
 
-// decodeEvents is the event decoding pipeline stage. For each received event, it goes
-// through a decoding function that will decode the event from its raw format into a
-// trace.Event type.
-func (t *Tracee) decodeEvents(ctx context.Context, sourceChan chan []byte) (<-chan *trace.Event, <-chan error) {
-	out := make(chan *trace.Event, 10000)
-	errc := make(chan error, 1)
-	sysCompatTranslation := events.Core.IDs32ToIDs()
-	go func() {
-		defer close(out)
-		defer close(errc)
-		for dataRaw := range sourceChan {
-			ebpfMsgDecoder := bufferdecoder.New(dataRaw)
-			var eCtx bufferdecoder.EventContext
-			if err := ebpfMsgDecoder.DecodeContext(&eCtx); err != nil {
-				t.handleError(err)
-				continue
-			}
-			var argnum uint8
-			if err := ebpfMsgDecoder.DecodeUint8(&argnum); err != nil {
-				t.handleError(err)
-				continue
-			}
-			eventId := events.ID(eCtx.EventID)
-			if !events.Core.IsDefined(eventId) {
-				t.handleError(errfmt.Errorf("failed to get configuration of event %d", eventId))
-				continue
-			}
-			eventDefinition := events.Core.GetDefinitionByID(eventId)
-			args := make([]trace.Argument, len(eventDefinition.GetParams()))
-			err := ebpfMsgDecoder.DecodeArguments(args, int(argnum), eventDefinition, eventId)
-			if err != nil {
-				t.handleError(err)
-				continue
-			}
+// eventsBatchPool recycles the raw events batches passed from the batch stage to the
+// decode stage, which puts them back once all of their events are decoded.
+var eventsBatchPool = sync.Pool{
+	New: func() interface{} {
+		batch := make([][]byte, 0, maxEventsBatchSize)
+		return &batch
+	},
+}
 
-			// Add stack trace if needed
-			var stackAddresses []uint64
-			if t.config.Output.StackAddresses {
-				stackAddresses = t.getStackAddresses(eCtx.StackID)
-			}
+// putEventsBatch returns a batch to the pool, not keeping references to its raw events.
+func putEventsBatch(batch *[][]byte) {
+	for i := range *batch {
+		(*batch)[i] = nil
+	}
+	*batch = (*batch)[:0]
+	eventsBatchPool.Put(batch)
+}
 
-			containerInfo := t.containers.GetCgroupInfo(eCtx.CgroupID).Container
-			containerData := trace.Container{
-				ID:          containerInfo.ContainerId,
-				ImageName:   containerInfo.Image,
-				ImageDigest: containerInfo.ImageDigest,
-				Name:        containerInfo.Name,
-			}
-			kubernetesData := trace.Kubernetes{
-				PodName:      containerInfo.Pod.Name,
-				PodNamespace: containerInfo.Pod.Namespace,
-				PodUID:       containerInfo.Pod.UID,
-			}
+// batchEvents is the batching pipeline stage. It reads raw events from the perf buffer
+// channel and, after each blocking read, drains the events already available (up to
+// maxEventsBatchSize) into a single batch. Batches are never delayed waiting for more
+// events, so batching only kicks in when events arrive faster than they are decoded.
+//
+// The perf buffer callback writing to the channel belongs to libbpfgo, which sends the
+// events one by one, so this is the earliest point they can be batched at.
+func (t *Tracee) batchEvents(ctx context.Context, in <-chan []byte) <-chan *[][]byte {
+	out := make(chan *[][]byte, 100)
 
-			flags := parseContextFlags(containerData.ID, eCtx.Flags)
-			syscall := ""
-			if eCtx.Syscall != noSyscall {
-				var err error
-				syscall, err = parseSyscallID(int(eCtx.Syscall), flags.IsCompat, sysCompatTranslation)
-				if err != nil {
-					logger.Debugw("Originated syscall parsing", "error", err)
+	go func() {
+		defer close(out)
+		for dataRaw := range in {
+			batch := eventsBatchPool.Get().(*[][]byte)
+			*batch = append(*batch, dataRaw)
+		drain:
+			for len(*batch) < maxEventsBatchSize {
+				select {
+				case dataRaw, ok := <-in:
+					if !ok {
+						break drain
+					}
+					*batch = append(*batch, dataRaw)
+				default:
+					break drain
 				}
 			}
 
-			// get an event pointer from the pool
-			evt := t.eventsPool.Get().(*trace.Event)
-
-			// populate all the fields of the event used in this stage, and reset the rest
-
-			evt.Timestamp = int(eCtx.Ts)
-			evt.ThreadStartTime = int(eCtx.StartTime)
-			evt.ProcessorID = int(eCtx.ProcessorId)
-			evt.ProcessID = int(eCtx.Pid)
-			evt.ThreadID = int(eCtx.Tid)
-			evt.ParentProcessID = int(eCtx.Ppid)
-			evt.HostProcessID = int(eCtx.HostPid)
-			evt.HostThreadID = int(eCtx.HostTid)
-			evt.HostParentProcessID = int(eCtx.HostPpid)
-			evt.UserID = int(eCtx.Uid)
-			evt.MountNS = int(eCtx.MntID)
-			evt.PIDNS = int(eCtx.PidID)
-			evt.ProcessName = string(bytes.TrimRight(eCtx.Comm[:], "\x00"))
-			evt.HostName = string(bytes.TrimRight(eCtx.UtsName[:], "\x00"))
-			evt.CgroupID = uint(eCtx.CgroupID)
-			evt.ContainerID = containerData.ID
-			evt.Container = containerData
-			evt.Kubernetes = kubernetesData
-			evt.EventID = int(eCtx.EventID)
-			evt.EventName = eventDefinition.GetName()
-			evt.PoliciesVersion = eCtx.PoliciesVersion
-			evt.MatchedPoliciesKernel = eCtx.MatchedPolicies
-			evt.MatchedPoliciesUser = 0
-			evt.MatchedPolicies = []string{}
-			evt.ArgsNum = int(argnum)
-			evt.ReturnValue = int(eCtx.Retval)
-			evt.Args = args
-			evt.StackAddresses = stackAddresses
-			evt.ContextFlags = flags
-			evt.Syscall = syscall
-			evt.Metadata = nil
-			evt.ThreadEntityId = utils.HashTaskID(eCtx.HostTid, eCtx.StartTime)
-			evt.ProcessEntityId = utils.HashTaskID(eCtx.HostPid, eCtx.LeaderStartTime)
-			evt.ParentEntityId = utils.HashTaskID(eCtx.HostPpid, eCtx.ParentStartTime)
-
-			// If there aren't any policies that need filtering in userland, tracee **may** skip
-			// this event, as long as there aren't any derivatives or signatures that depend on it.
-			// Some base events (derivative and signatures) might not have set related policy bit,
-			// thus the need to continue with those within the pipeline.
-			if t.matchPolicies(evt) == 0 {
-				_, hasDerivation := t.eventDerivations[eventId]
-				_, hasSignature := t.eventSignatures[eventId]
-
-				if !hasDerivation && !hasSignature {
-					_ = t.stats.EventsFiltered.Increment()
-					t.eventsPool.Put(evt)
-					continue
-				}
-			}
+			_ = t.stats.EventsReceived.Increment(uint64(len(*batch)))
 
 			select {
-			case out <- evt:
+			case out <- batch:
 			case <-ctx.Done():
 				return
 			}
 		}
 	}()
+
+	return out
+}
+
+// pinPipelineGoroutine pins the calling pipeline stage goroutine to the CPUs given by the
+// cpu-affinity configuration, if any, keeping it away from CPUs isolated for workloads
+// (and on the same NUMA node as the other pinned stages).
+func (t *Tracee) pinPipelineGoroutine(stage string) {
+	if len(t.config.PipelineCPUs) == 0 {
+		return
+	}
+
+	err := utils.PinGoroutineToCPUs(t.config.PipelineCPUs)
+	if err != nil {
+		logger.Warnw("Could not pin pipeline stage to CPUs", "stage", stage, "cpus", t.config.PipelineCPUs, "error", err)
+	}
+}
+
+// decodeEvents is the event decoding pipeline stage. For each event of the received
+// batches, it goes through a decoding function that will decode the event from its raw
+// format into a trace.Event type.
+func (t *Tracee) decodeEvents(ctx context.Context, sourceChan <-chan *[][]byte) (<-chan *trace.Event, <-chan error) {
+	out := make(chan *trace.Event, 10000)
+	errc := make(chan error, 1)
+	sysCompatTranslation := events.Core.IDs32ToIDs()
+	go func() {
+		defer close(out)
+		defer close(errc)
+		t.pinPipelineGoroutine("decode")
+		for batch := range sourceChan {
+			for _, dataRaw := range *batch {
+				evt := t.decodeEvent(dataRaw, sysCompatTranslation)
+				if evt == nil {
+					continue
+				}
+				select {
+				case out <- evt:
+				case <-ctx.Done():
+					return
+				}
+			}
+			putEventsBatch(batch)
+		}
+	}()
 	return out, errc
 }
 
+// decodeEvent decodes a raw event into a trace.Event type, doing its userland filtering.
+// It returns nil if the event couldn't be decoded or if it was filtered out.
+func (t *Tracee) decodeEvent(dataRaw []byte, sysCompatTranslation map[events.ID]events.ID) *trace.Event {
+	ebpfMsgDecoder := bufferdecoder.New(dataRaw)
+	var eCtx bufferdecoder.EventContext
+	if err := ebpfMsgDecoder.DecodeContext(&eCtx); err != nil {
+		t.handleError(err)
+		return nil
+	}
+	var argnum uint8
+	if err := ebpfMsgDecoder.DecodeUint8(&argnum); err != nil {
+		t.handleError(err)
+		return nil
+	}
+	eventId := events.ID(eCtx.EventID)
+	if !events.Core.IsDefined(eventId) {
+		t.handleError(errfmt.Errorf("failed to get configuration of event %d", eventId))
+		return nil
+	}
+	eventDefinition := events.Core.GetDefinitionByID(eventId)
+
+	// the chunks of the arguments too big for their event precede it, and are kept until it comes
+	if eventId == events.ArgChunk {
+		t.addArgChunk(ebpfMsgDecoder, &eCtx, int(argnum))
+		return nil
+	}
+
+	// Add stack trace if needed
+	var stackAddresses []uint64
+	if t.config.Output.StackAddresses || t.eventsState[eventId].Stack&eCtx.MatchedPolicies != 0 {
+		stackAddresses = t.getStackAddresses(eCtx.StackID)
+	}
+	var stackFrames []trace.StackFrame
+	if t.symbolizer != nil && len(stackAddresses) > 0 && t.enrichEvents() {
+		stackFrames = t.symbolizer.Symbolize(int(eCtx.HostPid), stackAddresses)
+	}
+
+	containerInfo := t.containers.GetCgroupInfo(eCtx.CgroupID).Container
+	containerData := trace.Container{
+		ID:          containerInfo.ContainerId,
+		ImageName:   containerInfo.Image,
+		ImageDigest: containerInfo.ImageDigest,
+		Name:        containerInfo.Name,
+		Ports:       containerPorts(eventId, containerInfo.Ports),
+	}
+	kubernetesData := trace.Kubernetes{
+		PodName:      containerInfo.Pod.Name,
+		PodNamespace: containerInfo.Pod.Namespace,
+		PodUID:       containerInfo.Pod.UID,
+	}
+
+	flags := parseContextFlags(containerData.ID, eCtx.Flags)
+	syscall := ""
+	if eCtx.Syscall != noSyscall {
+		var err error
+		syscall, err = parseSyscallID(int(eCtx.Syscall), flags.IsCompat, sysCompatTranslation)
+		if err != nil {
+			logger.Debugw("Originated syscall parsing", "error", err)
+		}
+	}
+
+	// get an event pointer from the pool
+	evt := t.eventsPool.Get().(*trace.Event)
+
+	// populate all the fields of the event used in this stage, and reset the rest
+
+	evt.Timestamp = int(eCtx.Ts)
+	evt.ThreadStartTime = int(eCtx.StartTime)
+	evt.ProcessorID = int(eCtx.ProcessorId)
+	evt.ProcessID = int(eCtx.Pid)
+	evt.ThreadID = int(eCtx.Tid)
+	evt.ParentProcessID = int(eCtx.Ppid)
+	evt.HostProcessID = int(eCtx.HostPid)
+	evt.HostThreadID = int(eCtx.HostTid)
+	evt.HostParentProcessID = int(eCtx.HostPpid)
+	evt.UserID = int(eCtx.Uid)
+	evt.MountNS = int(eCtx.MntID)
+	evt.PIDNS = int(eCtx.PidID)
+	evt.ProcessName = string(bytes.TrimRight(eCtx.Comm[:], "\x00"))
+	evt.HostName = string(bytes.TrimRight(eCtx.UtsName[:], "\x00"))
+	evt.CgroupID = uint(eCtx.CgroupID)
+	evt.ContainerID = containerData.ID
+	evt.Container = containerData
+	evt.Kubernetes = kubernetesData
+	evt.Labels = t.config.Labels
+	evt.EventID = int(eCtx.EventID)
+	evt.EventName = eventDefinition.GetName()
+	evt.PoliciesVersion = eCtx.PoliciesVersion
+	evt.MatchedPoliciesKernel = eCtx.MatchedPolicies
+	evt.MatchedPoliciesUser = 0
+	evt.MatchedPolicies = []string{}
+	evt.ArgsNum = int(argnum)
+	evt.ReturnValue = int(eCtx.Retval)
+	evt.SetArgs(nil)
+	evt.StackAddresses = stackAddresses
+	evt.StackFrames = stackFrames
+	evt.ContextFlags = flags
+	evt.Syscall = syscall
+	evt.SyscallABI = events.SyscallABI(flags.IsCompat)
+	evt.Metadata = nil
+	evt.ThreadEntityId = utils.HashTaskID(eCtx.HostTid, eCtx.StartTime)
+	evt.ProcessEntityId = utils.HashTaskID(eCtx.HostPid, eCtx.LeaderStartTime)
+	evt.ParentEntityId = utils.HashTaskID(eCtx.HostPpid, eCtx.ParentStartTime)
+
+	// If there aren't any policies that need filtering in userland, tracee **may** skip
+	// this event, as long as there aren't any derivatives or signatures that depend on it.
+	// Some base events (derivative and signatures) might not have set related policy bit,
+	// thus the need to continue with those within the pipeline.
+	//
+	// The event context is matched first, so the arguments of events filtered out by
+	// their context are never decoded.
+	_, hasDerivation := t.eventDerivations[eventId]
+	_, hasSignature := t.eventSignatures[eventId]
+
+	bitmap := t.matchPoliciesContext(evt, false)
+	if bitmap == 0 && !hasDerivation && !hasSignature {
+		_ = t.stats.EventsFiltered.Increment()
+		t.eventsPool.Put(evt)
+		return nil
+	}
+
+	// The arguments are decoded lazily, as they are accessed: the argument filters, the
+	// processors and the derivations only decode the arguments they need, and the arguments
+	// of the events dropped before being emitted are never all decoded.
+	evt.SetArgsDecoder(bufferdecoder.NewArguments(ebpfMsgDecoder, int(argnum), eventDefinition, eventId))
+
+	// the arguments rewritten as a whole are decoded eagerly
+	reassembled := t.takeChunkedArgs(&eCtx)
+	isCompatSyscall := flags.IsCompat && eventDefinition.IsSyscall()
+	if len(reassembled) > 0 || isCompatSyscall {
+		evt.DecodeArgs()
+		t.replaceChunkedArgs(reassembled, eventDefinition, evt.Args)
+		if isCompatSyscall {
+			bufferdecoder.NormalizeCompatArgs(evt.Args)
+		}
+	}
+
+	if t.matchPoliciesArgs(evt, bitmap) == 0 && !hasDerivation && !hasSignature {
+		_ = t.stats.EventsFiltered.Increment()
+		t.eventsPool.Put(evt)
+		return nil
+	}
+
+	// the arguments are truncated once filtered: the filters apply to their whole value
+	if t.config.ArgLimits.Enabled() {
+		evt.DecodeArgs()
+		t.config.ArgLimits.Truncate(evt.Args)
+	}
+
+	return evt
+}
+
 // matchPolicies does the userland filtering (policy matching) for events. It iterates through all
 // existing policies, that were set by the kernel in the event bitmap. Some of those policies might
 // not match the event after userland filters are applied. In those cases, the policy bit is cleared
 // (so the event is "filtered" for that policy). This may be called in different stages of the
 // pipeline (decode, derive, engine).
 func (t *Tracee) matchPolicies(event *trace.Event) uint64 {
+	return t.matchPoliciesArgs(event, t.matchPoliciesContext(event, true))
+}
+
+// matchPoliciesContext does the userland filtering of the event context (scope, return value,
+// schedule and global ranges filters), not requiring the event arguments to be decoded. The
+// normalizedTime argument tells whether the event times were already normalized (see
+// normalizeEventCtxTimes).
+func (t *Tracee) matchPoliciesContext(event *trace.Event, normalizedTime bool) uint64 {
 	eventID := events.ID(event.EventID)
 	bitmap := event.MatchedPoliciesKernel
 
@@ -337,8 +544,21 @@ func (t *Tracee) matchPolicies(event *trace.Event) uint64 {
 			continue
 		}
 
-		// 3. event arguments filters
-		if !p.ArgFilter.Filter(eventID, event.Args) {
+		// 3. process scope filters not evaluated in the kernel (with wildcards)
+		if p.UserlandScopeFilters() &&
+			(!p.CommFilter.Filter(event.ProcessName) || !p.UTSFilter.Filter(event.HostName)) {
+			utils.ClearBit(&bitmap, bitOffset)
+			continue
+		}
+
+		// 4. container label filters (from the runtime enrichment)
+		if p.ContLabelFilter.Enabled() && !p.ContLabelFilter.Filter(t.containerLabels(event)) {
+			utils.ClearBit(&bitmap, bitOffset)
+			continue
+		}
+
+		// 5. schedule filters (the policy is only active at some times)
+		if p.ScheduleFilter.Enabled() && !p.ScheduleFilter.Filter(t.eventTime(event, normalizedTime)) {
 			utils.ClearBit(&bitmap, bitOffset)
 			continue
 		}
@@ -388,10 +608,52 @@ func (t *Tracee) matchPolicies(event *trace.Event) uint64 {
 	return bitmap
 }
 
+// matchPoliciesArgs does the userland filtering of the event arguments, for the policies
+// in the given bitmap (as returned by matchPoliciesContext).
+func (t *Tracee) matchPoliciesArgs(event *trace.Event, bitmap uint64) uint64 {
+	eventID := events.ID(event.EventID)
+
+	policies, err := policy.Snapshots().Get(event.PoliciesVersion)
+	if err != nil {
+		t.handleError(err)
+		return 0
+	}
+
+	// Short circuit if there are no policies in userland that need filtering.
+	if bitmap&policies.FilterableInUserland() == 0 {
+		return bitmap
+	}
+
+	for it := policies.CreateUserlandIterator(); it.HasNext(); {
+		p := it.Next()
+		bitOffset := uint(p.ID)
+
+		if !utils.HasBit(bitmap, bitOffset) {
+			continue
+		}
+		if _, ok := p.EventsToTrace[eventID]; !ok {
+			continue
+		}
+
+		// 6. event arguments filters
+		if !p.ArgFilter.FilterEvent(event) {
+			utils.ClearBit(&bitmap, bitOffset)
+		}
+	}
+
+	event.MatchedPoliciesUser = bitmap // store filtered bitmap to be used in sink stage
+
+	return bitmap
+}
+
 func parseContextFlags(containerId string, flags uint32) trace.ContextFlags {
 	const (
 		contStartFlag = 1 << iota
 		IsCompatFlag
+		initNSFlag
+		privilegedFlag
+		seccompFlag
+		noNewPrivsFlag
 	)
 
 	var cflags trace.ContextFlags
@@ -399,6 +661,10 @@ func parseContextFlags(containerId string, flags uint32) trace.ContextFlags {
 	// containerId. See #3251 for more details.
 	cflags.ContainerStarted = (containerId != "") && (flags&contStartFlag) != 0
 	cflags.IsCompat = (flags & IsCompatFlag) != 0
+	cflags.InitNamespaces = (flags & initNSFlag) != 0
+	cflags.Privileged = (flags & privilegedFlag) != 0
+	cflags.Seccomp = (flags & seccompFlag) != 0
+	cflags.NoNewPrivs = (flags & noNewPrivsFlag) != 0
 
 	return cflags
 }
@@ -443,6 +709,7 @@ func (t *Tracee) processEvents(ctx context.Context, in <-chan *trace.Event) (
 	go func() {
 		defer close(out)
 		defer close(errc)
+		t.pinPipelineGoroutine("process")
 
 		for event := range in { // For each received event...
 			if event == nil {
@@ -459,6 +726,21 @@ func (t *Tracee) processEvents(ctx context.Context, in <-chan *trace.Event) (
 				continue
 			}
 
+			// Coalesce the events selected for process granularity (after being processed, so
+			// the process tree and the other processors see the events of all the threads)
+			if t.coalescer != nil && !t.coalescer.Coalesce(event) {
+				t.eventsPool.Put(event)
+				continue
+			}
+
+			// Shed the low priority events while exceeding the self-limits (after being
+			// processed, so the processors state is kept)
+			if t.shedEvent(event) {
+				_ = t.stats.EventsShed.Increment()
+				t.eventsPool.Put(event)
+				continue
+			}
+
 			policies, err := policy.Snapshots().Get(event.PoliciesVersion)
 			if err != nil {
 				t.handleError(err)
@@ -518,12 +800,109 @@ func (t *Tracee) deriveEvents(ctx context.Context, in <-chan *trace.Event) (
 	out := make(chan *trace.Event)
 	errc := make(chan error, 1)
 
+	yaraResults := t.yaraResults()
+	k8sAuditMatches := t.k8sAuditMatches()
+	cgroupPressureReports := t.cgroupPressureReports()
+	runtimeSBOMReports := t.runtimeSBOMReports()
+	progStatsReports := t.progStatsReports()
+	selfLimitReports := t.selfLimitReports()
+
 	go func() {
 		defer close(out)
 		defer close(errc)
 
 		for {
 			select {
+			case result := <-yaraResults:
+				// Artifacts matching YARA rules are scanned asynchronously, their
+				// yara_match events join the pipeline as derived events.
+				evts := t.yaraMatchEvents(result)
+				for i := range evts {
+					event := &evts[i]
+					if t.matchPolicies(event) == 0 {
+						_ = t.stats.EventsFiltered.Increment()
+						continue
+					}
+					t.processEvent(event)
+					out <- event
+				}
+			case lifecycle := <-t.containerLifecycle:
+				// Containers lifecycle events reported by the runtimes join the pipeline as
+				// first-class events.
+				event := t.containerLifecycleEvent(lifecycle)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
+			case report := <-cgroupPressureReports:
+				// Containers cgroups under resource pressure are sampled periodically, their
+				// events join the pipeline as first-class events.
+				event := t.cgroupPressureEvent(report)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
+			case report := <-runtimeSBOMReports:
+				// The runtime SBOM of the containers is reported periodically, its events
+				// join the pipeline as first-class events.
+				event := t.runtimeSBOMEvent(report)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
+			case report := <-progStatsReports:
+				// The eBPF programs statistics are sampled periodically, their events join
+				// the pipeline as first-class events.
+				event := t.progStatsEvent(report)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
+			case report := <-selfLimitReports:
+				// The load shedding level changes join the pipeline as first-class events.
+				event := t.loadSheddingEvent(report)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
+			case match := <-k8sAuditMatches:
+				// Processes observed before the audit log action they follow are
+				// correlated once the action is received.
+				event := t.k8sAuditCorrelationEvent(match)
+				if event == nil {
+					continue
+				}
+				if t.matchPolicies(event) == 0 {
+					_ = t.stats.EventsFiltered.Increment()
+					continue
+				}
+				t.processEvent(event)
+				out <- event
 			case event := <-in:
 				if event == nil {
 					continue // might happen during initialization (ctrl+c seg faults)
@@ -537,6 +916,11 @@ func (t *Tracee) deriveEvents(ctx context.Context, in <-chan *trace.Event) (
 				eventCopy := *event
 				out <- event
 
+				// The recent events of a process are the context of its crash.
+				if t.recentEvents != nil {
+					t.recentEvents.Record(&eventCopy)
+				}
+
 				// Note: event is being derived before any of its args are parsed.
 				derivatives, errors := t.eventDerivations.DeriveEvent(eventCopy)
 
@@ -581,6 +965,113 @@ func (t *Tracee) deriveEvents(ctx context.Context, in <-chan *trace.Event) (
 	return out, errc
 }
 
+// parseArgsJob is an event being parsed by one of the parse arguments stage workers.
+type parseArgsJob struct {
+	event *trace.Event
+	done  chan struct{}
+}
+
+// parseArgumentsEvents is the arguments parsing pipeline stage. Events arguments are
+// parsed by a pool of workers, so the parsing cost isn't paid serially in the sink stage.
+// The original order of the events is kept: events are sent down the pipeline in the
+// same order they were received, as soon as their parsing is done.
+func (t *Tracee) parseArgumentsEvents(ctx context.Context, in <-chan *trace.Event) (
+	<-chan *trace.Event, <-chan error,
+) {
+	out := make(chan *trace.Event, 10000)
+	errc := make(chan error, 1)
+
+	workers := runtime.NumCPU()
+	if workers > maxParseArgsWorkers {
+		workers = maxParseArgsWorkers
+	}
+
+	jobs := make(chan *parseArgsJob, workers*parseArgsJobsPerWorker)
+	ordered := make(chan *parseArgsJob, workers*parseArgsJobsPerWorker)
+
+	jobsPool := sync.Pool{
+		New: func() interface{} {
+			return &parseArgsJob{done: make(chan struct{}, 1)}
+		},
+	}
+
+	// workers: parse the events arguments
+	for i := 0; i < workers; i++ {
+		go func() {
+			t.pinPipelineGoroutine("parse arguments")
+			for job := range jobs {
+				// Only parse events that are going to be emitted by the sink stage.
+				id := events.ID(job.event.EventID)
+				if job.event.MatchedPoliciesUser&t.eventsState[id].Emit != 0 {
+					if err := t.parseArguments(job.event); err != nil {
+						t.handleError(err)
+					}
+				}
+				job.done <- struct{}{}
+			}
+		}()
+	}
+
+	// dispatcher: hand events to the workers, keeping their order
+	go func() {
+		defer close(ordered)
+		defer close(jobs)
+
+		for {
+			var event *trace.Event
+			select {
+			case e, ok := <-in:
+				if !ok {
+					return
+				}
+				event = e
+			case <-ctx.Done():
+				return
+			}
+			if event == nil {
+				continue // might happen during initialization (ctrl+c seg faults)
+			}
+			job := jobsPool.Get().(*parseArgsJob)
+			job.event = event
+			select {
+			case ordered <- job:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// collector: send parsed events down the pipeline, in the original order
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		for job := range ordered {
+			select {
+			case <-job.done:
+			case <-ctx.Done():
+				return
+			}
+			event := job.event
+			job.event = nil
+			jobsPool.Put(job)
+
+			select {
+			case out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
+
 // sinkEvents is the event sink pipeline stage. For each received event, it goes through a
 // series of printers that will print the event to the desired output. It also handles the
 // event pool, returning the event to the pool after it is processed.
@@ -615,25 +1106,41 @@ func (t *Tracee) sinkEvents(ctx context.Context, in <-chan *trace.Event) <-chan
 				t.handleError(err)
 				continue
 			}
+
+			// Policies exceeding their quota are sampled.
+			if t.policyQuotas != nil {
+				event.MatchedPoliciesUser = t.applyPolicyQuotas(ctx, event.MatchedPoliciesUser, policies)
+				if event.MatchedPoliciesUser == 0 {
+					t.eventsPool.Put(event)
+					continue
+				}
+			}
+
 			// Populate the event with the names of the matched policies.
 			event.MatchedPolicies = policies.MatchedNames(event.MatchedPoliciesUser)
 
-			// Parse args here if the rule engine is not enabled (parsed there if it is).
-			if !t.config.EngineConfig.Enabled {
-				err := t.parseArguments(event)
-				if err != nil {
-					t.handleError(err)
-				}
+			// Decode the arguments not decoded yet, only for the events being emitted.
+			event.DecodeArgs()
+
+			// Resolve the path arguments of the emitted syscalls only: it walks the filesystem.
+			if t.pathResolver != nil && events.Core.GetDefinitionByID(id).IsSyscall() && t.enrichEvents() {
+				t.canonicalizePaths(event)
 			}
 
+			// Populate the event with the version of its definition: along with its name, it is the
+			// stable identifier of the event, unlike its id, which can change between releases.
+			event.EventVersion = events.Core.GetDefinitionByID(id).GetVersion().String()
+
 			// Send the event to the streams.
 			select {
 			case <-ctx.Done():
 				return
 			default:
-				t.streamsManager.Publish(ctx, *event)
-				_ = t.stats.EventCount.Increment()
-				t.eventsPool.Put(event)
+				// the kernel clock of the producers isn't the processor one
+				if t.stats.EventLatency != nil && t.receiver == nil {
+					t.observeEventLatency(event)
+				}
+				t.publishEvent(ctx, event)
 			}
 		}
 	}()
@@ -641,6 +1148,13 @@ func (t *Tracee) sinkEvents(ctx context.Context, in <-chan *trace.Event) <-chan
 	return errc
 }
 
+// observeEventLatency records the time elapsed since the kernel timestamp of the event.
+func (t *Tracee) observeEventLatency(event *trace.Event) {
+	now := utils.GetStartTimeNS() // same (monotonic) clock used by the eBPF code
+	latency := time.Duration(now - int64(t.getOrigEvtTimestamp(event)))
+	t.stats.EventLatency.Observe(event.EventName, latency)
+}
+
 // getStackAddresses returns the stack addresses for a given StackID
 func (t *Tracee) getStackAddresses(stackID uint32) []uint64 {
 	stackAddresses := make([]uint64, maxStackDepth)
@@ -652,6 +1166,9 @@ func (t *Tracee) getStackAddresses(stackID uint32) []uint64 {
 	stackBytes, err := t.StackAddressesMap.GetValue(unsafe.Pointer(&stackID))
 	if err != nil {
 		logger.Debugw("failed to get StackAddress", "error", err)
+		if t.stats.MapPressure != nil {
+			t.stats.MapPressure.Evicted(stackAddressesMapName)
+		}
 		return stackAddresses[0:0]
 	}
 
@@ -716,9 +1233,12 @@ func (t *Tracee) handleError(err error) {
 // parseArguments parses the arguments of the event. It must happen before the signatures
 // are evaluated. For the new experience (cmd/tracee), it needs to happen in the the
 // "events_engine" stage of the pipeline. For the old experience (cmd/tracee-ebpf &&
-// cmd/tracee-rules), it happens on the "sink" stage of the pipeline (close to the
-// printers).
+// cmd/tracee-rules), it happens on the "parse arguments" stage of the pipeline (right
+// before the sink stage). The arguments not decoded yet are decoded first, even if they
+// aren't parsed.
 func (t *Tracee) parseArguments(e *trace.Event) error {
+	e.DecodeArgs()
+
 	if t.config.Output.ParseArguments {
 		err := events.ParseArgs(e)
 		if err != nil {
@@ -726,7 +1246,11 @@ func (t *Tracee) parseArguments(e *trace.Event) error {
 		}
 
 		if t.config.Output.ParseArgumentsFDs {
-			return events.ParseArgsFDs(e, uint64(t.getOrigEvtTimestamp(e)), t.FDArgPathMap)
+			err = events.ParseArgsFDs(e, uint64(t.getOrigEvtTimestamp(e)), t.FDArgPathMap, t.fdTable)
+			if err != nil && t.stats.MapPressure != nil {
+				t.stats.MapPressure.Evicted(fdArgPathMapName)
+			}
+			return err
 		}
 	}
 
@@ -0,0 +1,234 @@
+package ebpf
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestBatchEvents(t *testing.T) {
+	t.Parallel()
+
+	numEvents := 2*maxEventsBatchSize + 10
+
+	in := make(chan []byte, numEvents)
+	for i := 0; i < numEvents; i++ {
+		in <- []byte{byte(i)}
+	}
+	close(in)
+
+	trc := &Tracee{}
+	batches := trc.batchEvents(context.Background(), in)
+
+	var received [][]byte
+	numBatches := 0
+	for batch := range batches {
+		require.NotEmpty(t, *batch)
+		require.LessOrEqual(t, len(*batch), maxEventsBatchSize)
+		received = append(received, *batch...)
+		numBatches++
+	}
+
+	// events already available are batched together, keeping their order
+	assert.Equal(t, 3, numBatches)
+	require.Len(t, received, numEvents)
+	for i, dataRaw := range received {
+		assert.Equal(t, []byte{byte(i)}, dataRaw)
+	}
+}
+
+func BenchmarkBatchEvents(b *testing.B) {
+	dataRaw := make([]byte, 128)
+	in := make(chan []byte, 1000)
+	trc := &Tracee{}
+	batches := trc.batchEvents(context.Background(), in)
+
+	go func() {
+		for i := 0; i < b.N; i++ {
+			in <- dataRaw
+		}
+		close(in)
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for batch := range batches {
+		putEventsBatch(batch)
+	}
+}
+
+// newParseArgsTracee returns a tracee parsing the arguments of the openat events emitted
+// by the first policy.
+func newParseArgsTracee() *Tracee {
+	return &Tracee{
+		config: config.Config{
+			Output: &config.OutputConfig{ParseArguments: true},
+		},
+		eventsState: map[events.ID]events.EventState{
+			events.Openat: {Submit: 0b11, Emit: 0b01},
+		},
+	}
+}
+
+func newOpenatEvent(timestamp int, matchedPolicies uint64) *trace.Event {
+	return &trace.Event{
+		Timestamp:           timestamp,
+		EventID:             int(events.Openat),
+		MatchedPoliciesUser: matchedPolicies,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "flags", Type: "int"}, Value: int32(1)},
+		},
+	}
+}
+
+func TestParseArgumentsEvents(t *testing.T) {
+	t.Parallel()
+
+	const numEvents = 5000
+
+	trc := newParseArgsTracee()
+	in := make(chan *trace.Event, numEvents)
+	for i := 0; i < numEvents; i++ {
+		// odd events are only submitted (e.g. for derivations), not emitted
+		matchedPolicies := uint64(0b01)
+		if i%2 == 1 {
+			matchedPolicies = 0b10
+		}
+		in <- newOpenatEvent(i, matchedPolicies)
+	}
+	close(in)
+
+	out, errc := trc.parseArgumentsEvents(context.Background(), in)
+
+	received := 0
+	for event := range out {
+		// the original order is kept
+		require.Equal(t, received, event.Timestamp)
+
+		flags := event.Args[0].Value
+		if received%2 == 0 {
+			assert.Equal(t, "O_WRONLY", flags, "emitted events are parsed")
+		} else {
+			assert.Equal(t, int32(1), flags, "not emitted events aren't parsed")
+		}
+		received++
+	}
+	assert.Equal(t, numEvents, received)
+
+	_, ok := <-errc
+	assert.False(t, ok)
+}
+
+func TestParseArgumentsEventsCancel(t *testing.T) {
+	t.Parallel()
+
+	trc := newParseArgsTracee()
+	in := make(chan *trace.Event) // never closed
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out, _ := trc.parseArgumentsEvents(ctx, in)
+
+	in <- newOpenatEvent(0, 0b01)
+	event := <-out
+	assert.Equal(t, 0, event.Timestamp)
+
+	cancel()
+
+	select {
+	case _, ok := <-out:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stage didn't stop on context cancellation")
+	}
+}
+
+func TestParseArgumentsEventsCancelBackedUp(t *testing.T) {
+	t.Parallel()
+
+	const numEvents = 5000
+
+	trc := newParseArgsTracee()
+	in := make(chan *trace.Event, numEvents)
+	for i := 0; i < numEvents; i++ {
+		in <- newOpenatEvent(i, 0b01)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	out, _ := trc.parseArgumentsEvents(ctx, in)
+
+	// let the stage fill its queues while nothing reads its output
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for range out {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("backed up stage didn't stop on context cancellation")
+	}
+}
+
+func TestParseContextFlags(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		containerId   string
+		flags         uint32
+		expectedFlags trace.ContextFlags
+	}{
+		{
+			name:          "no flags",
+			expectedFlags: trace.ContextFlags{},
+		},
+		{
+			name:          "container started without container id",
+			flags:         1 << 0,
+			expectedFlags: trace.ContextFlags{},
+		},
+		{
+			name:          "container started",
+			containerId:   "abc",
+			flags:         1<<0 | 1<<1,
+			expectedFlags: trace.ContextFlags{ContainerStarted: true, IsCompat: true},
+		},
+		{
+			name:  "task state flags",
+			flags: 1<<2 | 1<<3 | 1<<4 | 1<<5,
+			expectedFlags: trace.ContextFlags{
+				InitNamespaces: true,
+				Privileged:     true,
+				Seccomp:        true,
+				NoNewPrivs:     true,
+			},
+		},
+		{
+			name:          "seccomp without no_new_privs",
+			flags:         1 << 4,
+			expectedFlags: trace.ContextFlags{Seccomp: true},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expectedFlags, parseContextFlags(tc.containerId, tc.flags))
+		})
+	}
+}
@@ -32,6 +32,7 @@ func newEvent(id int, f *detect.Finding, e trace.Event) *trace.Event {
 		EventID:               id,
 		EventName:             f.SigMetadata.EventName,
 		Timestamp:             e.Timestamp,
+		Time:                  e.Time,
 		ThreadStartTime:       e.ThreadStartTime,
 		ProcessorID:           e.ProcessorID,
 		ProcessID:             e.ProcessID,
@@ -50,8 +51,10 @@ func newEvent(id int, f *detect.Finding, e trace.Event) *trace.Event {
 		ContainerID:           e.ContainerID,
 		Container:             e.Container,
 		Kubernetes:            e.Kubernetes,
+		Labels:                e.Labels,
 		ReturnValue:           e.ReturnValue,
 		Syscall:               e.Syscall,
+		SyscallABI:            e.SyscallABI,
 		StackAddresses:        e.StackAddresses,
 		ContextFlags:          e.ContextFlags,
 		ThreadEntityId:        e.ThreadEntityId,
@@ -172,9 +175,10 @@ func getMetadataFromSignatureMetadata(sigMetadata detect.SignatureMetadata) *tra
 	metadata.Description = sigMetadata.Description
 	metadata.Tags = sigMetadata.Tags
 
-	properties := sigMetadata.Properties
-	if sigMetadata.Properties == nil {
-		properties = make(map[string]interface{})
+	// the properties are copied, as they are set per finding (e.g. on suppression)
+	properties := make(map[string]interface{}, len(sigMetadata.Properties)+2)
+	for k, v := range sigMetadata.Properties {
+		properties[k] = v
 	}
 
 	metadata.Properties = properties
@@ -191,5 +195,27 @@ func getMetadataFromSignatureMetadata(sigMetadata detect.SignatureMetadata) *tra
 	metadata.Properties["id"] = sigMetadata.Properties["id"]
 	metadata.Properties["external_id"] = sigMetadata.Properties["external_id"]
 
+	// normalized, so consumers don't have to parse the properties of every signature
+	metadata.Severity = sigMetadata.NormalizedSeverity()
+	metadata.Confidence = sigMetadata.NormalizedConfidence()
+	metadata.Mitre = getMitreAttack(sigMetadata)
+
 	return metadata
 }
+
+// getMitreAttack returns the MITRE ATT&CK mapping of the signature, or nil if it has none.
+func getMitreAttack(sigMetadata detect.SignatureMetadata) *trace.MitreAttack {
+	tactic, _ := sigMetadata.Properties[detect.PropertyCategory].(string)
+	technique, _ := sigMetadata.Properties[detect.PropertyTechnique].(string)
+	techniqueIDs := sigMetadata.TechniqueIDs()
+
+	if tactic == "" && technique == "" && len(techniqueIDs) == 0 {
+		return nil
+	}
+
+	return &trace.MitreAttack{
+		Tactic:       tactic,
+		Technique:    technique,
+		TechniqueIDs: techniqueIDs,
+	}
+}
@@ -41,6 +41,7 @@ func TestFindingToEvent(t *testing.T) {
 			PodNamespace: "namespace",
 			PodUID:       "uid",
 		},
+		Labels:                map[string]string{"cluster": "prod"},
 		ReturnValue:           10,
 		MatchedPoliciesKernel: 1,
 		MatchedPoliciesUser:   1,
@@ -96,6 +97,12 @@ func TestFindingToEvent(t *testing.T) {
 				"id":            "attack-pattern--b21c3b2d-02e6-45b1-980b-e69051040839",
 				"external_id":   "t1000",
 			},
+			Severity: "medium",
+			Mitre: &trace.MitreAttack{
+				Tactic:       "privilege-escalation",
+				Technique:    "Exploitation for Privilege Escalation",
+				TechniqueIDs: []string{"T1000"},
+			},
 		},
 	}
 
@@ -187,6 +194,7 @@ func createFakeEventAndFinding() detect.Finding {
 					PodNamespace: "namespace",
 					PodUID:       "uid",
 				},
+				Labels:                map[string]string{"cluster": "prod"},
 				ReturnValue:           10,
 				MatchedPoliciesKernel: 1,
 				MatchedPoliciesUser:   1,
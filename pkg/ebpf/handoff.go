@@ -0,0 +1,74 @@
+package ebpf
+
+import (
+	"context"
+
+	"github.com/aquasecurity/tracee/pkg/handoff"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/utils"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initHandoff initializes the sink lock the events output is handed over with, if enabled. The
+// events are compared in the monotonic clock of the kernel, the same for all the instances.
+func (t *Tracee) initHandoff() {
+	if !t.config.Handoff.Enabled() {
+		return
+	}
+
+	t.handoff = handoff.New(t.config.Handoff, func() int {
+		return int(utils.GetStartTimeNS()) // same (monotonic) clock used by the eBPF code
+	})
+}
+
+// startHandoff acquires the sink lock, once the events are traced: the running tracee, if
+// any, hands the events output over.
+func (t *Tracee) startHandoff(ctx context.Context) {
+	if t.handoff == nil {
+		return
+	}
+
+	if err := t.handoff.Start(ctx); err != nil {
+		logger.Errorw("Acquiring the sink lock, the events are held", "socket", t.config.Handoff.Socket, "error", err)
+	}
+}
+
+// publishEvent sends an event to the streams: numbered through the sink lock, if the events
+// output is handed over, otherwise at once (and unnumbered).
+func (t *Tracee) publishEvent(ctx context.Context, event *trace.Event) {
+	publish := func(event *trace.Event) {
+		t.streamsManager.Publish(ctx, *event)
+		_ = t.stats.EventCount.Increment()
+		t.eventsPool.Put(event)
+	}
+
+	if t.handoff == nil {
+		publish(event)
+		return
+	}
+
+	t.handoff.Admit(event, t.getOrigEvtTimestamp(event), publish, func(event *trace.Event) {
+		t.eventsPool.Put(event)
+	})
+}
+
+// HandedOff returns a channel closed once the events output is handed over to a new tracee:
+// tracee should then exit, and call CloseHandoff once its outputs are closed (nil if the
+// events output isn't handed over).
+func (t *Tracee) HandedOff() <-chan struct{} {
+	if t.handoff == nil {
+		return nil
+	}
+
+	return t.handoff.Done()
+}
+
+// CloseHandoff releases the sink lock: once handed over, the new tracee is replied the last
+// event written. It must be called once the outputs are closed.
+func (t *Tracee) CloseHandoff() error {
+	if t.handoff == nil {
+		return nil
+	}
+
+	return t.handoff.Close()
+}
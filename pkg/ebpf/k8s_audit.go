@@ -0,0 +1,55 @@
+package ebpf
+
+import (
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/derive"
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initK8sAudit starts the Kubernetes audit logs inputs, correlated with the pod processes.
+func (t *Tracee) initK8sAudit() error {
+	if !t.config.K8sAudit.Enabled() {
+		return nil
+	}
+
+	correlator, err := k8saudit.New(t.config.K8sAudit)
+	if err != nil {
+		return errfmt.Errorf("error initializing kubernetes audit logs input: %v", err)
+	}
+	if t.config.NoContainersEnrich {
+		logger.Warnw("Kubernetes audit logs are correlated with the pods processes, which requires the container enrichment")
+	}
+	t.k8sAudit = correlator
+
+	return nil
+}
+
+// k8sAuditMatches returns the channel of the processes correlated with audit log actions
+// received after them (nil if the audit logs input is disabled).
+func (t *Tracee) k8sAuditMatches() <-chan k8saudit.Match {
+	if t.k8sAudit == nil {
+		return nil
+	}
+
+	return t.k8sAudit.Matches()
+}
+
+// k8sAuditCorrelationEvent returns the k8s_audit_correlation event of a process
+// correlated with an audit log action received after it, or nil if the event isn't
+// selected.
+func (t *Tracee) k8sAuditCorrelationEvent(match k8saudit.Match) *trace.Event {
+	if t.eventsState[events.K8sAuditCorrelation].Submit == 0 {
+		return nil
+	}
+
+	event, err := derive.K8sAuditCorrelationEvent(match)
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+
+	return &event
+}
@@ -0,0 +1,109 @@
+package ebpf
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/libbpfgo/helpers"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// kernelGate is an event not available on the running kernel, by its kernel version.
+type kernelGate struct {
+	reason   string
+	fallback events.ID // event it is derived from instead (events.Undefined if none)
+}
+
+// getKernelGates returns the events not available on the running kernel, with the first of their
+// fallback events available on it (if any).
+func (t *Tracee) getKernelGates() map[events.ID]kernelGate {
+	gates := make(map[events.ID]kernelGate)
+
+	for _, def := range events.Core.GetDefinitions() {
+		kernelVersion := def.GetKernelVersion()
+		if !kernelVersion.IsSet() {
+			continue
+		}
+		satisfied, err := t.isKernelVersionSatisfied(kernelVersion)
+		if err != nil {
+			logger.Debugw("Checking the kernel version of an event", "event", def.GetName(), "error", err)
+			continue // assume the event is available
+		}
+		if !satisfied {
+			release := t.config.OSInfo.GetOSReleaseFieldValue(helpers.OS_KERNEL_RELEASE)
+			gates[def.GetID()] = kernelGate{
+				reason:   fmt.Sprintf("kernel %s not supported (%s)", release, kernelVersion),
+				fallback: events.Undefined,
+			}
+		}
+	}
+
+	// the fallback events are only picked once all unavailable events are known
+	for id, gate := range gates {
+		for _, fallback := range events.Core.GetDefinitionByID(id).GetKernelVersion().GetFallbacks() {
+			if _, ok := gates[fallback]; !ok {
+				gate.fallback = fallback
+				gates[id] = gate
+				break
+			}
+		}
+	}
+
+	return gates
+}
+
+// isKernelVersionSatisfied returns whether the running kernel is in the given range of kernel
+// releases.
+func (t *Tracee) isKernelVersionSatisfied(kernelVersion events.KernelVersion) (bool, error) {
+	if t.config.OSInfo == nil {
+		return true, nil
+	}
+	if min := kernelVersion.GetMin(); min != "" {
+		satisfied, err := t.isAboveSatisfied(min)
+		if err != nil || !satisfied {
+			return false, err
+		}
+	}
+	if max := kernelVersion.GetMax(); max != "" {
+		return t.isBelowSatisfied(max)
+	}
+
+	return true, nil
+}
+
+// getEventDependencies returns the dependencies of an event on the running kernel: its fallback
+// event, if the event isn't available on the kernel.
+func (t *Tracee) getEventDependencies(id events.ID) events.Dependencies {
+	if gate, ok := t.kernelGates[id]; ok && gate.fallback != events.Undefined {
+		return events.NewDependencies([]events.ID{gate.fallback}, nil, nil, nil, events.Capabilities{})
+	}
+
+	return events.Core.GetDefinitionByID(id).GetDependencies()
+}
+
+// validateKernelVersions cancels the selected events not available on the running kernel and
+// without a fallback event, reporting the ones derived from their fallback event as degraded.
+func (t *Tracee) validateKernelVersions() {
+	for id, gate := range t.kernelGates {
+		if _, ok := t.eventsState[id]; !ok {
+			continue
+		}
+		eventName := events.Core.GetDefinitionByID(id).GetName()
+
+		if gate.fallback != events.Undefined {
+			fallbackName := events.Core.GetDefinitionByID(gate.fallback).GetName()
+			logger.Debugw(
+				"Event derived from its fallback event because of the kernel version",
+				"event", eventName, "fallback", fallbackName, "reason", gate.reason,
+			)
+			t.capabilityReport.degrade(id, fmt.Sprintf("%s: derived from %s", gate.reason, fallbackName))
+			continue
+		}
+
+		logger.Debugw("Event canceled because of the kernel version", "event", eventName, "reason", gate.reason)
+		t.capabilityReport.cancel(id, gate.reason)
+		// Cancel the event, it depencies and its dependant events
+		t.eventsDependencies.RemoveEvent(id)
+	}
+}
@@ -0,0 +1,54 @@
+package ebpf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/metrics"
+)
+
+// tunePerfBuffer checks the loss rate of the events perf buffer at every interval, until the
+// context is done. A warning is logged for the intervals losing events over the threshold,
+// with the recommended size of the buffer, staged for the next start if configured.
+//
+// The perf buffer can't be resized while it is polled, and its polling can't be made more
+// frequent: libbpf wakes the poller up for every sample. The events are lost because the
+// pipeline doesn't read them as fast as they are submitted, a bigger buffer absorbs the bursts.
+func (t *Tracee) tunePerfBuffer(ctx context.Context) {
+	ticker := time.NewTicker(t.config.PerfBufferTuning.Interval)
+	defer ticker.Stop()
+
+	tuning := t.stats.PerfBufferTuning
+	tuning.Check(t.stats.EventsReceived.Get(), t.stats.LostEvCount.Get()) // baseline
+
+	for {
+		select {
+		case <-ticker.C:
+			decision, ok := tuning.Check(t.stats.EventsReceived.Get(), t.stats.LostEvCount.Get())
+			if !ok {
+				continue
+			}
+			logger.Warnw("Events perf buffer losing events, consider resizing it (see --perf-buffer-size)",
+				"lost", decision.Lost,
+				"received", decision.Received,
+				"loss_rate", fmt.Sprintf("%.2f%%", decision.LossRate),
+				"size", decision.Size,
+				"recommended_size", decision.Recommended,
+				"action", decision.Action,
+			)
+			if decision.Action != metrics.PerfBufferGrow || t.config.PerfBufferTuning.StagePath == "" {
+				continue
+			}
+			if err := metrics.StagePerfBufferSize(t.config.PerfBufferTuning.StagePath, decision.Recommended); err != nil {
+				logger.Errorw("Staging the recommended perf buffer size", "error", err)
+				continue
+			}
+			logger.Infow("Recommended perf buffer size staged for the next start",
+				"pages", decision.Recommended, "file", t.config.PerfBufferTuning.StagePath)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package pinning manages the eBPF objects tracee pins to the BPF filesystem (bpffs), so a
+// restarted tracee reuses the state kept by the previous one (e.g. across an upgrade) instead
+// of starting from empty maps. The objects of an instance are pinned in its own directory,
+// the pin namespace.
+package pinning
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// DefaultRoot is the bpffs directory of the pin namespaces given by name.
+const DefaultRoot = "/sys/fs/bpf/tracee"
+
+// Path returns the directory of a pin namespace: a namespace is either a name, pinned under
+// DefaultRoot, or an absolute directory of a bpffs.
+func Path(namespace string) (string, error) {
+	if namespace == "" || namespace == "." || namespace == ".." {
+		return "", errfmt.Errorf("invalid pin namespace: %q", namespace)
+	}
+	if filepath.IsAbs(namespace) {
+		return filepath.Clean(namespace), nil
+	}
+	if strings.ContainsRune(namespace, filepath.Separator) {
+		return "", errfmt.Errorf("invalid pin namespace: %q (a name, or an absolute directory)", namespace)
+	}
+
+	return filepath.Join(DefaultRoot, namespace), nil
+}
+
+// Prepare creates the directory of a pin namespace, which must be on a bpffs.
+func Prepare(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	var fs unix.Statfs_t
+	if err := unix.Statfs(dir, &fs); err != nil {
+		return errfmt.WrapError(err)
+	}
+	if fs.Type != unix.BPF_FS_MAGIC {
+		return errfmt.Errorf("%s isn't on a BPF filesystem (mount -t bpf bpf /sys/fs/bpf)", dir)
+	}
+
+	return nil
+}
+
+// Pinned returns the names of the objects pinned in the directory of a pin namespace, sorted.
+func Pinned(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errfmt.WrapError(err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// Cleanup unpins the objects of a pin namespace, and removes its directory. The objects are
+// freed once no running tracee holds them anymore. It returns the names of the unpinned
+// objects.
+func Cleanup(dir string) ([]string, error) {
+	names, err := Pinned(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range names {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return names[:i], errfmt.WrapError(err)
+		}
+	}
+	if err := os.Remove(dir); err != nil && !os.IsNotExist(err) {
+		return names, errfmt.WrapError(err)
+	}
+
+	return names, nil
+}
+
+// Open returns a file descriptor of a pinned object (BPF_OBJ_GET), to be closed by the caller.
+func Open(path string) (int, error) {
+	pathname, err := unix.BytePtrFromString(path)
+	if err != nil {
+		return -1, errfmt.WrapError(err)
+	}
+	attr := struct {
+		Pathname  uint64
+		BPFFD     uint32
+		FileFlags uint32
+	}{
+		Pathname: uint64(uintptr(unsafe.Pointer(pathname))),
+	}
+
+	fd, _, errno := unix.Syscall(unix.SYS_BPF, unix.BPF_OBJ_GET, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr))
+	if errno != 0 {
+		return -1, errfmt.Errorf("opening pinned object %s: %v", path, errno)
+	}
+
+	return int(fd), nil
+}
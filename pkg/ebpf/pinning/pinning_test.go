@@ -0,0 +1,61 @@
+package pinning
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		namespace     string
+		expectedPath  string
+		expectedError string
+	}{
+		{namespace: "node-a", expectedPath: "/sys/fs/bpf/tracee/node-a"},
+		{namespace: "/sys/fs/bpf/custom/", expectedPath: "/sys/fs/bpf/custom"},
+		{namespace: "", expectedError: `invalid pin namespace: ""`},
+		{namespace: "..", expectedError: `invalid pin namespace: ".."`},
+		{namespace: "a/b", expectedError: `invalid pin namespace: "a/b" (a name, or an absolute directory)`},
+	}
+
+	for _, tc := range testCases {
+		path, err := Path(tc.namespace)
+		if tc.expectedError != "" {
+			require.Error(t, err, tc.namespace)
+			assert.Contains(t, err.Error(), tc.expectedError)
+			continue
+		}
+		require.NoError(t, err, tc.namespace)
+		assert.Equal(t, tc.expectedPath, path)
+	}
+}
+
+func TestCleanup(t *testing.T) {
+	t.Parallel()
+
+	dir := filepath.Join(t.TempDir(), "node-a")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	for _, name := range []string{"task_info_map", "proc_info_map"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	names, err := Pinned(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proc_info_map", "task_info_map"}, names)
+
+	names, err = Cleanup(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"proc_info_map", "task_info_map"}, names)
+	assert.NoDirExists(t, dir)
+
+	// nothing pinned
+	names, err = Cleanup(dir)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
@@ -0,0 +1,60 @@
+package ebpf
+
+import (
+	"context"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
+	"github.com/aquasecurity/tracee/pkg/utils"
+)
+
+// applyPolicyQuotas accounts an event in the quotas of the policies it matched, and returns the
+// policies it is still emitted for: the events of the policies exceeding their quota are sampled.
+func (t *Tracee) applyPolicyQuotas(ctx context.Context, matched uint64, policies *policy.Policies) uint64 {
+	now := time.Now()
+
+	for id := 0; id < policy.PolicyMax; id++ {
+		if !utils.HasBit(matched, uint(id)) {
+			continue
+		}
+		p, err := policies.LookupById(id)
+		if err != nil {
+			continue
+		}
+
+		allowed, exceeded := t.policyQuotas.Allow(p.Name, now)
+		if exceeded != nil {
+			t.publishPolicyQuotaExceeded(ctx, exceeded, uint(id), policies)
+		}
+		if !allowed {
+			utils.ClearBit(&matched, uint(id))
+		}
+	}
+
+	return matched
+}
+
+// publishPolicyQuotaExceeded publishes the policy_quota_exceeded event of a policy switched to
+// sampling. The event is matched by the policy, whether it selects the event or not.
+func (t *Tracee) publishPolicyQuotaExceeded(ctx context.Context, exceeded *quota.Exceeded, id uint, policies *policy.Policies) {
+	logger.Warnw("Policy exceeded its quota, sampling its events",
+		"policy", exceeded.Policy, "reason", exceeded.Reason, "limit", exceeded.Limit,
+		"sample_rate", exceeded.SampleRate)
+
+	event := events.PolicyQuotaExceededEvent(
+		exceeded.Policy, string(exceeded.Reason), exceeded.Limit, exceeded.SampleRate,
+	)
+	var matched uint64
+	utils.SetBit(&matched, id)
+	event.PoliciesVersion = policies.Version()
+	event.MatchedPoliciesKernel = matched
+	event.MatchedPoliciesUser = matched
+	event.MatchedPolicies = policies.MatchedNames(matched)
+	event.Labels = t.config.Labels
+
+	t.streamsManager.Publish(ctx, event)
+	_ = t.stats.EventCount.Increment()
+}
@@ -1,6 +1,7 @@
 package probes
 
 import (
+	"fmt"
 	"sync"
 
 	bpf "github.com/aquasecurity/libbpfgo"
@@ -16,11 +17,24 @@ import (
 
 var kernelSymbolTable *helpers.KernelSymbolTable
 
+// fallbackProbes are the alternative attach points of the probes whose hook might be missing
+// in the running kernel (e.g. renamed kernel functions, other architectures), tried in order
+// when the probe fails to attach.
+var fallbackProbes = map[Handle][]Handle{
+	DoCoredump:                 {VfsCoredump},                // kernels >= 6.16
+	VfsUtimes:                  {UtimesCommon},               // kernels < 5.9
+	ExecuteFinishedX86:         {ExecuteFinishedARM},         // arm64
+	ExecuteAtFinishedX86:       {ExecuteAtFinishedARM},       // arm64
+	ExecuteFinishedCompatX86:   {ExecuteFinishedCompatARM},   // arm64
+	ExecuteAtFinishedCompatX86: {ExecuteAtFinishedCompatARM}, // arm64
+}
+
 // ProbeGroup is a collection of probes.
 type ProbeGroup struct {
 	probesLock *sync.Mutex // disallow concurrent access to the probe group
 	module     *bpf.Module
 	probes     map[Handle]Probe
+	fallbacks  map[Handle]Handle // fallback probes attached instead of the probes
 }
 
 // NewProbeGroup creates a new ProbeGroup.
@@ -29,6 +43,7 @@ func NewProbeGroup(m *bpf.Module, p map[Handle]Probe) *ProbeGroup {
 		probesLock: &sync.Mutex{}, // no parallel attaching/detaching of probes
 		probes:     p,
 		module:     m,
+		fallbacks:  make(map[Handle]Handle),
 	}
 }
 
@@ -55,7 +70,34 @@ func (p *ProbeGroup) GetProbeType(handle Handle) string {
 	return ""
 }
 
-// Attach attaches a probe's program to its hook, by given handle.
+// GetProbeName returns the hook of a probe (e.g. kernel function, tracepoint), by its handle.
+func (p *ProbeGroup) GetProbeName(handle Handle) string {
+	p.probesLock.Lock()
+	defer p.probesLock.Unlock()
+
+	switch probe := p.probes[handle].(type) {
+	case *TraceProbe:
+		return probe.GetEventName()
+	case *Uprobe:
+		return probe.GetEventName()
+	case *CgroupProbe:
+		return probe.GetProgramName()
+	}
+
+	return fmt.Sprintf("probe %d", handle)
+}
+
+// GetFallback returns the fallback probe attached instead of a probe, by its handle.
+func (p *ProbeGroup) GetFallback(handle Handle) (Handle, bool) {
+	p.probesLock.Lock()
+	defer p.probesLock.Unlock()
+
+	fallback, ok := p.fallbacks[handle]
+	return fallback, ok
+}
+
+// Attach attaches a probe's program to its hook, by given handle, or else the first of its
+// fallback probes that attaches.
 func (p *ProbeGroup) Attach(handle Handle, args ...interface{}) error {
 	p.probesLock.Lock()
 	defer p.probesLock.Unlock()
@@ -64,10 +106,27 @@ func (p *ProbeGroup) Attach(handle Handle, args ...interface{}) error {
 		return errfmt.Errorf("probe handle (%d) does not exist", handle)
 	}
 
-	return p.probes[handle].attach(p.module, args...)
+	err := p.probes[handle].attach(p.module, args...)
+	if err == nil {
+		return nil
+	}
+	for _, fallback := range fallbackProbes[handle] {
+		probe, ok := p.probes[fallback]
+		if !ok {
+			continue
+		}
+		if fallbackErr := probe.attach(p.module, args...); fallbackErr != nil {
+			logger.Debugw("Failed to attach fallback probe", "probe", handle, "fallback", fallback, "error", fallbackErr)
+			continue
+		}
+		p.fallbacks[handle] = fallback
+		return nil
+	}
+
+	return err
 }
 
-// Detach detaches a probe's program from its hook, by given handle.
+// Detach detaches a probe's program (or its attached fallback) from its hook, by given handle.
 func (p *ProbeGroup) Detach(handle Handle, args ...interface{}) error {
 	p.probesLock.Lock()
 	defer p.probesLock.Unlock()
@@ -76,6 +135,13 @@ func (p *ProbeGroup) Detach(handle Handle, args ...interface{}) error {
 		return errfmt.Errorf("probe handle (%d) does not exist", handle)
 	}
 
+	if fallback, ok := p.fallbacks[handle]; ok {
+		delete(p.fallbacks, handle)
+		if err := p.probes[fallback].detach(args...); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
 	return p.probes[handle].detach(args...)
 }
 
@@ -106,7 +172,7 @@ func (p *ProbeGroup) GetProbeByHandle(handle Handle) Probe {
 }
 
 // NewDefaultProbeGroup initializes the default ProbeGroup (TODO: extensions will use probe groups)
-func NewDefaultProbeGroup(module *bpf.Module, netEnabled bool, kSyms *helpers.KernelSymbolTable) (*ProbeGroup, error) {
+func NewDefaultProbeGroup(module *bpf.Module, netEnabled, netFlowStatsEnabled bool, kSyms *helpers.KernelSymbolTable) (*ProbeGroup, error) {
 	if kSyms == nil {
 		return nil, errfmt.Errorf("kernel symbol table is nil")
 	}
@@ -169,6 +235,11 @@ func NewDefaultProbeGroup(module *bpf.Module, netEnabled bool, kSyms *helpers.Ke
 		RegisterKprobe:             NewTraceProbe(KProbe, "register_kprobe", "trace_register_kprobe"),
 		RegisterKprobeRet:          NewTraceProbe(KretProbe, "register_kprobe", "trace_ret_register_kprobe"),
 		CallUsermodeHelper:         NewTraceProbe(KProbe, "call_usermodehelper", "trace_call_usermodehelper"),
+		DoCoredump:                 NewTraceProbe(KProbe, "do_coredump", "trace_do_coredump"),
+		VfsCoredump:                NewTraceProbe(KProbe, "vfs_coredump", "trace_do_coredump"),
+		OomKillProcess:             NewTraceProbe(KProbe, "oom_kill_process", "trace_oom_kill_process"),
+		ExitOomVictim:              NewTraceProbe(KProbe, "exit_oom_victim", "trace_exit_oom_victim"),
+		CgroupKillWrite:            NewTraceProbe(KProbe, "cgroup_kill_write", "trace_cgroup_kill_write"),
 		DebugfsCreateFile:          NewTraceProbe(KProbe, "debugfs_create_file", "trace_debugfs_create_file"),
 		DebugfsCreateDir:           NewTraceProbe(KProbe, "debugfs_create_dir", "trace_debugfs_create_dir"),
 		DeviceAdd:                  NewTraceProbe(KProbe, "device_add", "trace_device_add"),
@@ -200,6 +271,10 @@ func NewDefaultProbeGroup(module *bpf.Module, netEnabled bool, kSyms *helpers.Ke
 		CgroupBPFRunFilterSKB:      NewTraceProbe(KProbe, "__cgroup_bpf_run_filter_skb", "cgroup_bpf_run_filter_skb"),
 		CgroupSKBIngress:           NewCgroupProbe(bpf.BPFAttachTypeCgroupInetIngress, "cgroup_skb_ingress"),
 		CgroupSKBEgress:            NewCgroupProbe(bpf.BPFAttachTypeCgroupInetEgress, "cgroup_skb_egress"),
+		TCPConnect:                 NewTraceProbe(KProbe, "tcp_connect", "trace_tcp_connect"),
+		InetCskAcceptRet:           NewTraceProbe(KretProbe, "inet_csk_accept", "trace_ret_inet_csk_accept"),
+		SockOpsNetFlow:             NewCgroupProbe(bpf.BPFAttachTypeCgroupSockOps, "sock_ops_netflow"),
+		NetDevQueue:                NewTraceProbe(RawTracepoint, "net:net_dev_queue", "tracepoint__net__net_dev_queue"),
 		DoMmap:                     NewTraceProbe(KProbe, "do_mmap", "trace_do_mmap"),
 		DoMmapRet:                  NewTraceProbe(KretProbe, "do_mmap", "trace_ret_do_mmap"),
 		VfsRead:                    NewTraceProbe(KProbe, "vfs_read", "trace_vfs_read"),
@@ -251,5 +326,12 @@ func NewDefaultProbeGroup(module *bpf.Module, netEnabled bool, kSyms *helpers.Ke
 		}
 	}
 
+	if !netFlowStatsEnabled {
+		// disable the network flow stats sock_ops probe (same as above)
+		if err := allProbes[SockOpsNetFlow].autoload(module, false); err != nil {
+			logger.Errorw("SockOpsNetFlow probe autoload", "error", err)
+		}
+	}
+
 	return NewProbeGroup(module, allProbes), nil
 }
@@ -34,6 +34,11 @@ const (
 	SchedProcessFree
 	SchedSwitch
 	DoExit
+	DoCoredump
+	VfsCoredump
+	OomKillProcess
+	ExitOomVictim
+	CgroupKillWrite
 	CapCapable
 	VfsWrite
 	VfsWriteRet
@@ -106,6 +111,10 @@ const (
 	CgroupBPFRunFilterSKB
 	CgroupSKBIngress
 	CgroupSKBEgress
+	TCPConnect
+	InetCskAcceptRet
+	SockOpsNetFlow
+	NetDevQueue
 	DoMmap
 	DoMmapRet
 	PrintMemDump
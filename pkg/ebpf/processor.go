@@ -122,6 +122,26 @@ func (t *Tracee) registerEventProcessors() {
 	t.RegisterEventProcessor(events.PrintMemDump, t.processTriggeredEvent)
 	t.RegisterEventProcessor(events.PrintMemDump, t.processPrintMemDump)
 	t.RegisterEventProcessor(events.SharedObjectLoaded, t.processSharedObjectLoaded)
+	t.RegisterEventProcessor(events.OomKill, t.processOomKill)
+	t.RegisterEventProcessor(events.CgroupKill, t.processCgroupKill)
+
+	//
+	// FD Table Processors
+	//
+
+	if t.fdTable != nil {
+		for _, id := range fdTableEvents {
+			t.RegisterEventProcessor(id, t.processFDTable)
+		}
+	}
+
+	//
+	// Container Paths Processors
+	//
+
+	if t.rootfsResolver != nil {
+		t.RegisterEventProcessor(events.All, t.processContainerPaths)
+	}
 
 	//
 	// Event Timestamps Normalization Processors
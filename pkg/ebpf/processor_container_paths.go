@@ -0,0 +1,86 @@
+package ebpf
+
+import (
+	"os"
+	"path"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const hostPathArgPrefix = "host_"
+
+// processContainerPaths adds the host view of the path arguments of the events of containers
+// (e.g. host_pathname for pathname): the path of the file in the host mount namespace, under the
+// root filesystem of the container. The path arguments themselves are the view of the container,
+// so both views are given. Relative paths are resolved against the working directory of the
+// process. The host paths are null when unknown (e.g. the process already exited).
+func (t *Tracee) processContainerPaths(event *trace.Event) error {
+	if event.Container.ID == "" {
+		return nil
+	}
+
+	id := events.ID(event.EventID)
+	var rootfs, cwd string
+	var rootfsErr error
+	var hostArgs []trace.Argument
+
+	event.DecodeArgs() // the host paths are added to the arguments
+	for _, arg := range event.Args {
+		dirFDNames, ok := pathArgDirFDs[arg.Name]
+		if !ok || (arg.Name == "target" && (id == events.Symlink || id == events.Symlinkat)) {
+			continue
+		}
+		p, ok := arg.Value.(string)
+		if !ok || p == "" {
+			continue
+		}
+
+		hostArg := trace.Argument{
+			ArgMeta: trace.ArgMeta{Name: hostPathArgPrefix + arg.Name, Type: "const char*"},
+		}
+		hostArgs = append(hostArgs, hostArg)
+
+		if !path.IsAbs(p) {
+			// paths relative to a directory fd are only resolved if it's the working directory
+			relativeToDirFD := false
+			for _, name := range dirFDNames {
+				if dirfd, err := event.ArgInt(name); err == nil && dirfd != unix.AT_FDCWD {
+					relativeToDirFD = true
+				}
+			}
+			if relativeToDirFD {
+				continue
+			}
+			if cwd == "" {
+				// the working directory as seen from the root of the process (the container)
+				dir, err := os.Readlink("/proc/" + strconv.Itoa(event.HostProcessID) + "/cwd")
+				if err != nil || !path.IsAbs(dir) {
+					continue
+				}
+				cwd = dir
+			}
+			p = path.Join(cwd, p)
+		}
+
+		if rootfs == "" && rootfsErr == nil {
+			rootfs, rootfsErr = t.rootfsResolver.HostRootfs(event.MountNS, event.HostProcessID)
+			if rootfsErr != nil {
+				logger.Debugw("Failed to resolve container rootfs", "container", event.Container.ID, "error", rootfsErr)
+			}
+		}
+		if rootfsErr != nil {
+			continue
+		}
+		hostArgs[len(hostArgs)-1].Value = path.Join(rootfs, p)
+	}
+
+	event.Args = append(event.Args, hostArgs...)
+	event.ArgsNum += len(hostArgs)
+
+	return nil
+}
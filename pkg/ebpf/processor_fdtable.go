@@ -0,0 +1,185 @@
+package ebpf
+
+import (
+	"fmt"
+	"path"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/libbpfgo/helpers"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/utils"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const closeRangeCloexec = 1 << 2 // CLOSE_RANGE_CLOEXEC
+
+// fdTableEvents are the events opening, duplicating and closing file descriptors, feeding the
+// processes fd table.
+var fdTableEvents = []events.ID{
+	events.Open,
+	events.Openat,
+	events.Openat2,
+	events.Creat,
+	events.Close,
+	events.CloseRange,
+	events.Dup,
+	events.Dup2,
+	events.Dup3,
+	events.Fcntl,
+	events.Socket,
+	events.Accept,
+	events.Accept4,
+	events.Pipe,
+	events.Pipe2,
+	events.SchedProcessFork,
+	events.SchedProcessExec,
+}
+
+// chooseFDTableEvents selects the events feeding the processes fd table, for the processes in
+// the scope of the policies (the ones whose events fd arguments are resolved).
+func (t *Tracee) chooseFDTableEvents() {
+	var submit uint64
+	for it := t.config.Policies.CreateAllIterator(); it.HasNext(); {
+		p := it.Next()
+		if len(p.EventsToTrace) > 0 {
+			utils.SetBit(&submit, uint(p.ID))
+		}
+	}
+
+	for _, id := range fdTableEvents {
+		t.chooseEvent(id, events.EventState{Submit: submit})
+	}
+}
+
+// processFDTable feeds the processes fd table with the file descriptors opened, duplicated and
+// closed by an event. Malformed events are ignored: the event may also be emitted.
+func (t *Tracee) processFDTable(event *trace.Event) error {
+	id := events.ID(event.EventID)
+	pid := event.HostProcessID
+	ts := uint64(event.Timestamp) // not normalized yet, as the timestamps the table is queried with
+
+	// a descriptor is closed even if close fails (e.g. interrupted)
+	if event.ReturnValue < 0 && id != events.Close {
+		return nil
+	}
+
+	switch id {
+	case events.Open, events.Openat, events.Openat2, events.Creat:
+		pathname, err := event.ArgString("pathname")
+		if err != nil {
+			return nil
+		}
+		if dirfd, err := event.ArgInt("dirfd"); err == nil && !path.IsAbs(pathname) {
+			if dir, ok := t.fdTable.Lookup(pid, dirfd, ts); ok && path.IsAbs(dir) {
+				pathname = path.Join(dir, pathname)
+			}
+		}
+		flags, _ := event.ArgInt("flags")
+		t.fdTable.Open(pid, int32(event.ReturnValue), pathname, flags&unix.O_CLOEXEC != 0, ts)
+
+	case events.Close:
+		fd, err := event.ArgInt("fd")
+		if err != nil {
+			return nil
+		}
+		t.fdTable.Close(pid, fd, ts)
+
+	case events.CloseRange:
+		first, err := event.ArgUint("first")
+		if err != nil {
+			return nil
+		}
+		last, err := event.ArgUint("last")
+		if err != nil {
+			return nil
+		}
+		flags, _ := event.ArgUint("flags")
+		t.fdTable.CloseRange(pid, first, last, flags&closeRangeCloexec != 0, ts)
+
+	case events.Dup, events.Dup2, events.Dup3:
+		oldfd, err := event.ArgInt("oldfd")
+		if err != nil {
+			return nil
+		}
+		flags, _ := event.ArgInt("flags")
+		t.fdTable.Dup(pid, oldfd, int32(event.ReturnValue), flags&unix.O_CLOEXEC != 0, ts)
+
+	case events.Fcntl:
+		cmd, err := event.ArgInt("cmd")
+		if err != nil || (cmd != unix.F_DUPFD && cmd != unix.F_DUPFD_CLOEXEC) {
+			return nil
+		}
+		fd, err := event.ArgInt("fd")
+		if err != nil {
+			return nil
+		}
+		t.fdTable.Dup(pid, fd, int32(event.ReturnValue), cmd == unix.F_DUPFD_CLOEXEC, ts)
+
+	case events.Socket:
+		domain, err := event.ArgInt("domain")
+		if err != nil {
+			return nil
+		}
+		typ, err := event.ArgInt("type")
+		if err != nil {
+			return nil
+		}
+		t.fdTable.Open(pid, int32(event.ReturnValue), socketDescription(domain, typ), typ&unix.SOCK_CLOEXEC != 0, ts)
+
+	case events.Accept, events.Accept4:
+		desc := "socket"
+		if sockfd, err := event.ArgInt("sockfd"); err == nil {
+			if listening, ok := t.fdTable.Lookup(pid, sockfd, ts); ok {
+				desc = listening // same domain and type as the listening socket
+			}
+		}
+		flags, _ := event.ArgInt("flags")
+		t.fdTable.Open(pid, int32(event.ReturnValue), desc, flags&unix.SOCK_CLOEXEC != 0, ts)
+
+	case events.Pipe, events.Pipe2:
+		pipefd, err := trace.ArgValue[[2]int32](event, "pipefd")
+		if err != nil {
+			return nil
+		}
+		flags, _ := event.ArgInt("flags")
+		cloexec := flags&unix.O_CLOEXEC != 0
+		t.fdTable.Open(pid, pipefd[0], fmt.Sprintf("pipe:[%d,%d]", pipefd[0], pipefd[1]), cloexec, ts)
+		t.fdTable.Open(pid, pipefd[1], fmt.Sprintf("pipe:[%d,%d]", pipefd[0], pipefd[1]), cloexec, ts)
+
+	case events.SchedProcessFork:
+		parentPid, err := event.ArgInt("parent_pid")
+		if err != nil {
+			return nil
+		}
+		childTid, err := event.ArgInt("child_tid")
+		if err != nil {
+			return nil
+		}
+		childPid, err := event.ArgInt("child_pid")
+		if err != nil || childTid != childPid {
+			return nil // new threads share the descriptors of their process
+		}
+		t.fdTable.Fork(int(parentPid), int(childPid), ts)
+
+	case events.SchedProcessExec:
+		t.fdTable.Exec(pid, ts)
+	}
+
+	return nil
+}
+
+// socketDescription describes a socket by its domain and type (e.g. socket:[AF_INET,SOCK_STREAM]).
+func socketDescription(domain, typ int32) string {
+	domainName := fmt.Sprint(domain)
+	if d, err := helpers.ParseSocketDomainArgument(uint64(domain)); err == nil {
+		domainName = d.String()
+	}
+	typeName := fmt.Sprint(typ)
+	if t, err := helpers.ParseSocketType(uint64(typ)); err == nil {
+		typeName = t.String()
+	}
+
+	return fmt.Sprintf("socket:[%s,%s]", domainName, typeName)
+}
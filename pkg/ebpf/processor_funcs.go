@@ -7,15 +7,17 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/sys/unix"
 
+	"github.com/aquasecurity/tracee/pkg/buildid"
 	"github.com/aquasecurity/tracee/pkg/capabilities"
+	"github.com/aquasecurity/tracee/pkg/cgroup"
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/containers"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/filehash"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/utils"
@@ -28,7 +30,7 @@ func (t *Tracee) processWriteEvent(event *trace.Event) error {
 	if !t.config.Capture.FileWrite.Capture {
 		return nil
 	}
-	filePath, err := parse.ArgVal[string](event.Args, "pathname")
+	filePath, err := event.ArgString("pathname")
 	if err != nil {
 		return errfmt.Errorf("error parsing vfs_write args: %v", err)
 	}
@@ -36,11 +38,11 @@ func (t *Tracee) processWriteEvent(event *trace.Event) error {
 	if filePath == "" || filePath[0] != '/' {
 		return nil
 	}
-	dev, err := parse.ArgVal[uint32](event.Args, "dev")
+	dev, err := event.ArgUint("dev")
 	if err != nil {
 		return errfmt.Errorf("error parsing vfs_write args: %v", err)
 	}
-	inode, err := parse.ArgVal[uint64](event.Args, "inode")
+	inode, err := event.ArgUlong("inode")
 	if err != nil {
 		return errfmt.Errorf("error parsing vfs_write args: %v", err)
 	}
@@ -67,7 +69,7 @@ func (t *Tracee) processReadEvent(event *trace.Event) error {
 	if !t.config.Capture.FileRead.Capture {
 		return nil
 	}
-	filePath, err := parse.ArgVal[string](event.Args, "pathname")
+	filePath, err := event.ArgString("pathname")
 	if err != nil {
 		return fmt.Errorf("error parsing vfs_read args: %v", err)
 	}
@@ -75,11 +77,11 @@ func (t *Tracee) processReadEvent(event *trace.Event) error {
 	if filePath == "" || filePath[0] != '/' {
 		return nil
 	}
-	dev, err := parse.ArgVal[uint32](event.Args, "dev")
+	dev, err := event.ArgUint("dev")
 	if err != nil {
 		return fmt.Errorf("error parsing vfs_write args: %v", err)
 	}
-	inode, err := parse.ArgVal[uint64](event.Args, "inode")
+	inode, err := event.ArgUlong("inode")
 	if err != nil {
 		return fmt.Errorf("error parsing vfs_write args: %v", err)
 	}
@@ -125,8 +127,8 @@ func (t *Tracee) processSchedProcessExec(event *trace.Event) error {
 	}
 
 	// capture executed files
-	if t.config.Capture.Exec || t.config.Output.CalcHashes != config.CalcHashesNone {
-		filePath, err := parse.ArgVal[string](event.Args, "pathname")
+	if t.config.Capture.Exec || t.config.Output.CalcHashes != config.CalcHashesNone || t.config.Output.ExecBuildID {
+		filePath, err := event.ArgString("pathname")
 		if err != nil {
 			return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 		}
@@ -140,7 +142,7 @@ func (t *Tracee) processSchedProcessExec(event *trace.Event) error {
 		for _, pid := range pids { // will break on success
 			err = nil
 			sourceFilePath := fmt.Sprintf("/proc/%s/root%s", strconv.Itoa(int(pid)), filePath)
-			sourceFileCtime, err := parse.ArgVal[uint64](event.Args, "ctime")
+			sourceFileCtime, err := event.ArgUlong("ctime")
 			if err != nil {
 				return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 			}
@@ -178,15 +180,16 @@ func (t *Tracee) processSchedProcessExec(event *trace.Event) error {
 					}
 					// mark this file as captured
 					t.capturedFiles[capturedFileID] = castedSourceFileCtime
+					t.submitArtifact(destinationFilePath, "exec", event.HostProcessID, containerId)
 				}
 			}
 			// check exec'ed hash ?
 			if t.config.Output.CalcHashes != config.CalcHashesNone {
-				dev, err := parse.ArgVal[uint32](event.Args, "dev")
+				dev, err := event.ArgUint("dev")
 				if err != nil {
 					return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 				}
-				ino, err := parse.ArgVal[uint64](event.Args, "inode")
+				ino, err := event.ArgUlong("inode")
 				if err != nil {
 					return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 				}
@@ -202,6 +205,10 @@ func (t *Tracee) processSchedProcessExec(event *trace.Event) error {
 					return err
 				}
 			}
+			// extract exec'ed build id ?
+			if t.config.Output.ExecBuildID {
+				t.addBuildIDArgs(event, capturedFileID, castedSourceFileCtime, sourceFilePath)
+			}
 			if true { // so loop is conditionally terminated (#SA4044)
 				break
 			}
@@ -265,7 +272,7 @@ const (
 // processHookedProcFops processes a hooked_proc_fops event.
 func (t *Tracee) processHookedProcFops(event *trace.Event) error {
 	const hookedFopsPointersArgName = "hooked_fops_pointers"
-	fopsAddresses, err := parse.ArgVal[[]uint64](event.Args, hookedFopsPointersArgName)
+	fopsAddresses, err := trace.ArgValue[[]uint64](event, hookedFopsPointersArgName)
 	if err != nil || fopsAddresses == nil {
 		return errfmt.Errorf("error parsing hooked_proc_fops args: %v", err)
 	}
@@ -313,7 +320,7 @@ func (t *Tracee) processTriggeredEvent(event *trace.Event) error {
 
 // processPrintSyscallTable processes a print_syscall_table event.
 func (t *Tracee) processPrintMemDump(event *trace.Event) error {
-	address, err := parse.ArgVal[uintptr](event.Args, "address")
+	address, err := trace.ArgValue[uintptr](event, "address")
 	if err != nil || address == 0 {
 		return errfmt.Errorf("error parsing print_mem_dump args: %v", err)
 	}
@@ -341,6 +348,53 @@ func (t *Tracee) processPrintMemDump(event *trace.Event) error {
 	return nil
 }
 
+// processOomKill processes an oom_kill event by resolving the path of the memory cgroup that
+// ran out of memory (if any, the kill being system wide otherwise).
+func (t *Tracee) processOomKill(event *trace.Event) error {
+	memcgID, err := event.ArgUlong("memcg_id")
+	if err != nil {
+		return errfmt.Errorf("error parsing oom_kill args: %v", err)
+	}
+	memcg := ""
+	// the memory controller cgroup ids are the ids of the default hierarchy with cgroup v2 only
+	if memcgID != 0 && t.containers.GetCgroupVersion() == cgroup.CgroupVersion2 {
+		memcg = t.cgroupPath(memcgID)
+	}
+
+	return events.SetArgValue(event, "memcg", memcg)
+}
+
+// processCgroupKill processes a cgroup_kill event by resolving the killed cgroup, and the
+// container and pod it belongs to.
+func (t *Tracee) processCgroupKill(event *trace.Event) error {
+	cgroupID, err := event.ArgUlong("cgroup_id")
+	if err != nil {
+		return errfmt.Errorf("error parsing cgroup_kill args: %v", err)
+	}
+	info := t.containers.GetCgroupInfo(cgroupID)
+
+	for name, value := range map[string]string{
+		"cgroup_path":    t.cgroupPath(cgroupID),
+		"container_id":   info.Container.ContainerId,
+		"container_name": info.Container.Name,
+		"pod_name":       info.Container.Pod.Name,
+		"pod_namespace":  info.Container.Pod.Namespace,
+	} {
+		if err := events.SetArgValue(event, name, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cgroupPath returns the path of a cgroup, relative to the default cgroup mount point.
+func (t *Tracee) cgroupPath(cgroupID uint64) string {
+	path := t.containers.GetCgroupInfo(cgroupID).Path
+
+	return strings.TrimPrefix(path, t.cgroups.GetDefaultCgroup().GetMountPoint())
+}
+
 //
 // Timing related functions
 //
@@ -369,6 +423,14 @@ func (t *Tracee) normalizeEventCtxTimes(event *trace.Event) error {
 		// current ("wall") time: add boot time to timestamp
 		event.Timestamp = event.Timestamp + int(t.bootTime)
 		event.ThreadStartTime = event.ThreadStartTime + int(t.bootTime)
+
+		if t.config.Output.TimeRFC3339 {
+			loc := t.config.Output.TimeZone
+			if loc == nil {
+				loc = time.Local
+			}
+			event.Time = time.Unix(0, int64(event.Timestamp)).In(loc).Format(time.RFC3339Nano)
+		}
 	}
 
 	return nil
@@ -386,6 +448,27 @@ func (t *Tracee) getOrigEvtTimestamp(event *trace.Event) int {
 	return event.Timestamp - int(t.bootTime)
 }
 
+// eventTime returns the ("wall") time the event happened at, whether its timestamp was already
+// normalized via normalizeEventCtxTimes or not.
+func (t *Tracee) eventTime(event *trace.Event, normalized bool) time.Time {
+	timestamp := event.Timestamp
+	if normalized {
+		timestamp = t.getOrigEvtTimestamp(event)
+	}
+
+	return time.Unix(0, int64(timestamp)+int64(t.bootTime))
+}
+
+// containerLabels returns the labels of the container of the event, as given by the container
+// runtime enrichment (nil if the event isn't from a container, or it isn't enriched yet).
+func (t *Tracee) containerLabels(event *trace.Event) map[string]string {
+	if event.Container.ID == "" || t.containers == nil {
+		return nil
+	}
+
+	return t.containers.GetCgroupInfo(uint64(event.CgroupID)).Container.Labels
+}
+
 // processSchedProcessFork processes a sched_process_fork event by normalizing the start time.
 func (t *Tracee) processSchedProcessFork(event *trace.Event) error {
 	return t.normalizeEventArgTime(event, "start_time")
@@ -419,10 +502,18 @@ func (t *Tracee) addHashArg(event *trace.Event, fileKey *filehash.Key) error {
 		return nil
 	}
 
+	event.DecodeArgs() // the hash is added to the arguments
 	hashArg := trace.Argument{
 		ArgMeta: trace.ArgMeta{Name: "sha256", Type: "const char*"},
 	}
 
+	// the files aren't hashed while shedding load (null hash)
+	if !t.enrichEvents() {
+		event.Args = append(event.Args, hashArg)
+		event.ArgsNum++
+		return nil
+	}
+
 	hash, err := t.fileHashes.Get(fileKey)
 	if hash == "" {
 		hashArg.Value = nil
@@ -443,22 +534,56 @@ func (t *Tracee) addHashArg(event *trace.Event, fileKey *filehash.Key) error {
 	return err
 }
 
+// addBuildIDArgs adds the build ID and the Go build information of the executed binary to
+// the event (null if unknown).
+func (t *Tracee) addBuildIDArgs(event *trace.Event, fileID string, ctime int64, path string) {
+	var info buildid.Info
+	// the binaries aren't read while shedding load (null build ids)
+	if t.enrichEvents() {
+		var err error
+		info, err = t.buildIDs.Get(fileID, ctime, path)
+		if err != nil {
+			logger.Debugw("failed to read build id", "error", err, "path", path)
+		}
+	}
+
+	event.DecodeArgs() // the build ids are added to the arguments
+	for _, arg := range []struct {
+		name  string
+		value string
+	}{
+		{"build_id", info.BuildID},
+		{"go_version", info.GoVersion},
+		{"go_module", info.GoModule},
+		{"go_revision", info.GoRevision},
+	} {
+		buildIDArg := trace.Argument{
+			ArgMeta: trace.ArgMeta{Name: arg.name, Type: "const char*"},
+		}
+		if arg.value != "" {
+			buildIDArg.Value = arg.value
+		}
+		event.Args = append(event.Args, buildIDArg)
+		event.ArgsNum++
+	}
+}
+
 func (t *Tracee) processSharedObjectLoaded(event *trace.Event) error {
-	filePath, err := parse.ArgVal[string](event.Args, "pathname")
+	filePath, err := event.ArgString("pathname")
 	if err != nil {
 		logger.Debugw("Error parsing argument", "error", err)
 		return nil
 	}
-	fileCtime, err := parse.ArgVal[uint64](event.Args, "ctime")
+	fileCtime, err := event.ArgUlong("ctime")
 	if err != nil {
 		logger.Debugw("Error parsing argument", "error", err)
 		return nil
 	}
-	dev, err := parse.ArgVal[uint32](event.Args, "dev")
+	dev, err := event.ArgUint("dev")
 	if err != nil {
 		return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 	}
-	ino, err := parse.ArgVal[uint64](event.Args, "inode")
+	ino, err := event.ArgUlong("inode")
 	if err != nil {
 		return errfmt.Errorf("error parsing sched_process_exec args: %v", err)
 	}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/proctree"
 	"github.com/aquasecurity/tracee/pkg/utils"
@@ -26,39 +25,39 @@ func (t *Tracee) procTreeForkProcessor(event *trace.Event) error {
 	// NOTE: The "parent" related arguments can be ignored for process tree purposes.
 
 	// Up Parent (Up in hierarchy until parent is a process and not a lwp)
-	parentTid, err := parse.ArgVal[int32](event.Args, "up_parent_tid")
+	parentTid, err := event.ArgInt("up_parent_tid")
 	errs = append(errs, err)
-	parentNsTid, err := parse.ArgVal[int32](event.Args, "up_parent_ns_tid")
+	parentNsTid, err := event.ArgInt("up_parent_ns_tid")
 	errs = append(errs, err)
-	parentPid, err := parse.ArgVal[int32](event.Args, "up_parent_pid")
+	parentPid, err := event.ArgInt("up_parent_pid")
 	errs = append(errs, err)
-	parentNsPid, err := parse.ArgVal[int32](event.Args, "up_parent_ns_pid")
+	parentNsPid, err := event.ArgInt("up_parent_ns_pid")
 	errs = append(errs, err)
-	parentStartTime, err := parse.ArgVal[uint64](event.Args, "up_parent_start_time")
+	parentStartTime, err := event.ArgUlong("up_parent_start_time")
 	errs = append(errs, err)
 
 	// Thread Group Leader (might be the same as the "child", if "child" is a process)
-	leaderTid, err := parse.ArgVal[int32](event.Args, "leader_tid")
+	leaderTid, err := event.ArgInt("leader_tid")
 	errs = append(errs, err)
-	leaderNsTid, err := parse.ArgVal[int32](event.Args, "leader_ns_tid")
+	leaderNsTid, err := event.ArgInt("leader_ns_tid")
 	errs = append(errs, err)
-	leaderPid, err := parse.ArgVal[int32](event.Args, "leader_pid")
+	leaderPid, err := event.ArgInt("leader_pid")
 	errs = append(errs, err)
-	leaderNsPid, err := parse.ArgVal[int32](event.Args, "leader_ns_pid")
+	leaderNsPid, err := event.ArgInt("leader_ns_pid")
 	errs = append(errs, err)
-	leaderStartTime, err := parse.ArgVal[uint64](event.Args, "leader_start_time")
+	leaderStartTime, err := event.ArgUlong("leader_start_time")
 	errs = append(errs, err)
 
 	// Child (might be a process or a thread)
-	childTid, err := parse.ArgVal[int32](event.Args, "child_tid")
+	childTid, err := event.ArgInt("child_tid")
 	errs = append(errs, err)
-	childNsTid, err := parse.ArgVal[int32](event.Args, "child_ns_tid")
+	childNsTid, err := event.ArgInt("child_ns_tid")
 	errs = append(errs, err)
-	childPid, err := parse.ArgVal[int32](event.Args, "child_pid")
+	childPid, err := event.ArgInt("child_pid")
 	errs = append(errs, err)
-	childNsPid, err := parse.ArgVal[int32](event.Args, "child_ns_pid")
+	childNsPid, err := event.ArgInt("child_ns_pid")
 	errs = append(errs, err)
-	childStartTime, err := parse.ArgVal[uint64](event.Args, "start_time") // child_start_time
+	childStartTime, err := event.ArgUlong("start_time") // child_start_time
 	errs = append(errs, err)
 
 	// Deal with errors
@@ -113,6 +112,7 @@ func (t *Tracee) procTreeForkRemoveArgs(event *trace.Event) error {
 		"leader_start_time",
 	}
 
+	event.DecodeArgs()
 	m := make(map[string]trace.Argument)
 	for _, arg := range event.Args {
 		m[arg.Name] = arg
@@ -146,35 +146,35 @@ func (t *Tracee) procTreeExecProcessor(event *trace.Event) error {
 	taskHash := utils.HashTaskID(uint32(event.HostThreadID), uint64(event.ThreadStartTime))
 
 	// Executable
-	cmdPath, err := parse.ArgVal[string](event.Args, "cmdpath")
+	cmdPath, err := event.ArgString("cmdpath")
 	errs = append(errs, err)
-	pathName, err := parse.ArgVal[string](event.Args, "pathname")
+	pathName, err := event.ArgString("pathname")
 	errs = append(errs, err)
-	dev, err := parse.ArgVal[uint32](event.Args, "dev")
+	dev, err := event.ArgUint("dev")
 	errs = append(errs, err)
-	inode, err := parse.ArgVal[uint64](event.Args, "inode")
+	inode, err := event.ArgUlong("inode")
 	errs = append(errs, err)
-	ctime, err := parse.ArgVal[uint64](event.Args, "ctime")
+	ctime, err := event.ArgUlong("ctime")
 	errs = append(errs, err)
-	inodeMode, err := parse.ArgVal[uint16](event.Args, "inode_mode")
+	inodeMode, err := trace.ArgValue[uint16](event, "inode_mode")
 	errs = append(errs, err)
 
 	// Binary Interpreter (or Loader): might come empty from the kernel
-	interPathName, _ := parse.ArgVal[string](event.Args, "interpreter_pathname")
-	interDev, _ := parse.ArgVal[uint32](event.Args, "interpreter_dev")
-	interInode, _ := parse.ArgVal[uint64](event.Args, "interpreter_inode")
-	interCtime, _ := parse.ArgVal[uint64](event.Args, "interpreter_ctime")
+	interPathName, _ := event.ArgString("interpreter_pathname")
+	interDev, _ := event.ArgUint("interpreter_dev")
+	interInode, _ := event.ArgUlong("interpreter_inode")
+	interCtime, _ := event.ArgUlong("interpreter_ctime")
 
 	// Real Interpreter
-	interp, err := parse.ArgVal[string](event.Args, "interp")
+	interp, err := event.ArgString("interp")
 	errs = append(errs, err)
 
 	// Others
-	stdinType, err := parse.ArgVal[uint16](event.Args, "stdin_type")
+	stdinType, err := trace.ArgValue[uint16](event, "stdin_type")
 	errs = append(errs, err)
-	stdinPath, err := parse.ArgVal[string](event.Args, "stdin_path")
+	stdinPath, err := event.ArgString("stdin_path")
 	errs = append(errs, err)
-	invokedFromKernel, err := parse.ArgVal[int32](event.Args, "invoked_from_kernel")
+	invokedFromKernel, err := event.ArgInt("invoked_from_kernel")
 	errs = append(errs, err)
 
 	// Handle errors
@@ -223,9 +223,9 @@ func (t *Tracee) procTreeExitProcessor(event *trace.Event) error {
 	taskHash := utils.HashTaskID(uint32(event.HostThreadID), uint64(event.ThreadStartTime))
 
 	// Exit logic arguments
-	exitCode, err := parse.ArgVal[int64](event.Args, "exit_code")
+	exitCode, err := event.ArgLong("exit_code")
 	errs = append(errs, err)
-	groupExit, err := parse.ArgVal[bool](event.Args, "process_group_exit")
+	groupExit, err := trace.ArgValue[bool](event, "process_group_exit")
 	errs = append(errs, err)
 
 	// Handle errors
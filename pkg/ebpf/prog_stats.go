@@ -0,0 +1,100 @@
+package ebpf
+
+import (
+	bpf "github.com/aquasecurity/libbpfgo"
+	"kernel.org/pub/linux/libs/security/libcap/cap"
+
+	"github.com/aquasecurity/tracee/pkg/capabilities"
+	"github.com/aquasecurity/tracee/pkg/ebpf/progstats"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initProgStats enables the statistics of the eBPF programs, and their monitoring, if the
+// bpf_prog_stats event is selected. It is called once the eBPF object is loaded.
+func (t *Tracee) initProgStats() {
+	if t.eventsState[events.BPFProgStats].Submit == 0 {
+		return
+	}
+
+	err := capabilities.GetInstance().Specific(
+		func() error {
+			var err error
+			t.progStatsEnabled, err = progstats.EnableStats()
+			return err
+		},
+		cap.SYS_ADMIN,
+	)
+	if err != nil {
+		logger.Warnw("The bpf_prog_stats events aren't emitted", "error", err)
+		return
+	}
+
+	// the programs of the object are loaded once, only the attached ones run
+	var programs []progstats.Program
+	iterator := t.bpfModule.Iterator()
+	for prog := iterator.NextProgram(); prog != nil; prog = iterator.NextProgram() {
+		if prog.FileDescriptor() < 0 {
+			continue // not loaded (autoload disabled)
+		}
+		programs = append(programs, progstats.Program{Name: prog.Name(), FD: prog.FileDescriptor()})
+	}
+
+	t.progStats = progstats.NewMonitor(progstats.DefaultInterval, func() []progstats.Program {
+		return programs
+	})
+}
+
+// progStatsReports returns the channel of the eBPF programs activity reports (nil if the
+// bpf_prog_stats event isn't selected).
+func (t *Tracee) progStatsReports() <-chan progstats.Report {
+	if t.progStats == nil {
+		return nil
+	}
+
+	return t.progStats.Reports()
+}
+
+// progStatsEvent returns the bpf_prog_stats event of an eBPF program activity report.
+func (t *Tracee) progStatsEvent(report progstats.Report) *trace.Event {
+	id := events.BPFProgStats
+	if t.eventsState[id].Submit == 0 {
+		return nil
+	}
+
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+
+	values := []interface{}{
+		report.Program,
+		report.ID,
+		bpf.BPFProgType(report.Type).String(),
+		uint64(report.Interval.Microseconds()),
+		report.RunCount,
+		uint64(report.RunTime.Nanoseconds()),
+		uint64(report.RunTime.Nanoseconds()) / report.RunCount,
+	}
+
+	def := events.Core.GetDefinitionByID(id)
+	params := def.GetParams()
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return &trace.Event{
+		Timestamp:             int(t.normalizeSnapshotTime(uint64(report.Time.UnixNano()) - t.bootTime)),
+		Labels:                t.config.Labels,
+		EventID:               int(id),
+		EventName:             def.GetName(),
+		PoliciesVersion:       policies.Version(),
+		MatchedPoliciesKernel: t.eventsState[id].Submit,
+		ArgsNum:               len(args),
+		Args:                  args,
+	}
+}
@@ -0,0 +1,76 @@
+package progstats
+
+import (
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// statsSysctl enables the BPF statistics of all the programs, while set.
+const statsSysctl = "/proc/sys/kernel/bpf_stats_enabled"
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+// EnableStats enables the BPF statistics until the returned closer is closed (the kernel
+// keeps them enabled while any such request is open, kernel 5.8). The statistics already
+// enabled by the kernel.bpf_stats_enabled sysctl are used as they are.
+func EnableStats() (io.Closer, error) {
+	attr := struct {
+		Type uint32
+	}{Type: unix.BPF_STATS_RUN_TIME}
+
+	fd, _, errno := unix.Syscall(unix.SYS_BPF, unix.BPF_ENABLE_STATS, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr))
+	if errno != 0 {
+		enabled, err := os.ReadFile(statsSysctl)
+		if err == nil && strings.TrimSpace(string(enabled)) == "1" {
+			return nopCloser{}, nil
+		}
+		return nil, errfmt.Errorf("enabling the BPF statistics: %v (set %s to 1 on kernels older than 5.8)", errno, statsSysctl)
+	}
+
+	return os.NewFile(fd, "bpf_stats"), nil
+}
+
+// progInfo is the beginning of struct bpf_prog_info, up to the run statistics: the kernel
+// fills the given length only.
+type progInfo struct {
+	Type      uint32
+	ID        uint32
+	_         [184]byte
+	RunTimeNs uint64
+	RunCnt    uint64
+}
+
+// ReadStats returns the statistics of a loaded eBPF program.
+func ReadStats(fd int) (Stats, error) {
+	var info progInfo
+	attr := struct {
+		FD      uint32
+		InfoLen uint32
+		Info    uint64
+	}{
+		FD:      uint32(fd),
+		InfoLen: uint32(unsafe.Sizeof(info)),
+		Info:    uint64(uintptr(unsafe.Pointer(&info))),
+	}
+
+	_, _, errno := unix.Syscall(unix.SYS_BPF, unix.BPF_OBJ_GET_INFO_BY_FD, uintptr(unsafe.Pointer(&attr)), unsafe.Sizeof(attr))
+	if errno != 0 {
+		return Stats{}, errfmt.Errorf("reading the program info: %v", errno)
+	}
+
+	return Stats{
+		ID:       info.ID,
+		Type:     info.Type,
+		RunCount: info.RunCnt,
+		RunTime:  time.Duration(info.RunTimeNs),
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Package progstats samples the statistics the kernel keeps for the eBPF programs (their
+// run count and run time, while the BPF statistics are enabled), reporting the kernel-side
+// overhead of the programs of the enabled events.
+package progstats
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between two samples of the programs.
+	DefaultInterval = 30 * time.Second
+
+	reportsBuffer = 1024
+)
+
+// Program is a loaded eBPF program.
+type Program struct {
+	Name string
+	FD   int
+}
+
+// Stats are the statistics of an eBPF program, since it was loaded.
+type Stats struct {
+	ID       uint32 // program id, identifying the program between samples
+	Type     uint32 // program type (enum bpf_prog_type)
+	RunCount uint64
+	RunTime  time.Duration
+}
+
+// Report is the activity of an eBPF program during an interval.
+type Report struct {
+	Program  string
+	ID       uint32
+	Type     uint32
+	Time     time.Time
+	Interval time.Duration
+	RunCount uint64        // runs during the interval
+	RunTime  time.Duration // time spent running during the interval
+}
+
+// Monitor samples the statistics of the eBPF programs at every interval.
+type Monitor struct {
+	interval time.Duration
+	programs func() []Program
+	read     func(fd int) (Stats, error)
+	samples  map[uint32]Stats // previous sample of the programs
+	reports  chan Report
+}
+
+// NewMonitor returns a monitor of the programs returned by the given function, called at
+// every interval. The BPF statistics must be enabled (see EnableStats) for the programs
+// runs to be counted.
+func NewMonitor(interval time.Duration, programs func() []Program) *Monitor {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Monitor{
+		interval: interval,
+		programs: programs,
+		read:     ReadStats,
+		samples:  map[uint32]Stats{},
+		reports:  make(chan Report, reportsBuffer),
+	}
+}
+
+// Reports returns the channel of the programs activity reports.
+func (m *Monitor) Reports() <-chan Report {
+	return m.reports
+}
+
+// Run samples the programs until the context is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	m.check(time.Now()) // baseline samples
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, report := range m.check(now) {
+				select {
+				case m.reports <- report:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check samples the programs, returning the reports of the programs that ran since the
+// previous sample. Programs sampled for the first time only have a baseline sample.
+func (m *Monitor) check(now time.Time) []Report {
+	var reports []Report
+	current := map[uint32]Stats{}
+
+	for _, program := range m.programs() {
+		stats, err := m.read(program.FD)
+		if err != nil {
+			continue // unloaded program
+		}
+		current[stats.ID] = stats
+
+		previous, ok := m.samples[stats.ID]
+		if !ok || stats.RunCount <= previous.RunCount {
+			continue // new program, or not run
+		}
+		reports = append(reports, Report{
+			Program:  program.Name,
+			ID:       stats.ID,
+			Type:     stats.Type,
+			Time:     now,
+			Interval: m.interval,
+			RunCount: stats.RunCount - previous.RunCount,
+			RunTime:  stats.RunTime - previous.RunTime,
+		})
+	}
+	m.samples = current // unloaded programs are dropped
+
+	return reports
+}
@@ -0,0 +1,53 @@
+package progstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonitorCheck(t *testing.T) {
+	t.Parallel()
+
+	stats := map[int]Stats{
+		10: {ID: 1, Type: 2, RunCount: 100, RunTime: time.Millisecond},
+		11: {ID: 2, Type: 26, RunCount: 5, RunTime: time.Microsecond},
+	}
+	programs := []Program{{Name: "trace_security_file_open", FD: 10}, {Name: "tracepoint__raw_syscalls__sys_enter", FD: 11}}
+
+	m := NewMonitor(10*time.Second, func() []Program { return programs })
+	m.read = func(fd int) (Stats, error) {
+		s, ok := stats[fd]
+		if !ok {
+			return Stats{}, assert.AnError
+		}
+		return s, nil
+	}
+
+	// baseline
+	start := time.Now()
+	assert.Empty(t, m.check(start))
+
+	// the programs that ran are reported, with their activity during the interval
+	stats[10] = Stats{ID: 1, Type: 2, RunCount: 150, RunTime: 3 * time.Millisecond}
+	now := start.Add(10 * time.Second)
+	reports := m.check(now)
+	require.Len(t, reports, 1)
+	assert.Equal(t, Report{
+		Program:  "trace_security_file_open",
+		ID:       1,
+		Type:     2,
+		Time:     now,
+		Interval: 10 * time.Second,
+		RunCount: 50,
+		RunTime:  2 * time.Millisecond,
+	}, reports[0])
+
+	// programs loaded again (new id) have a new baseline, unloaded ones are dropped
+	stats[10] = Stats{ID: 3, Type: 2, RunCount: 10, RunTime: time.Microsecond}
+	delete(stats, 11)
+	assert.Empty(t, m.check(now.Add(10*time.Second)))
+	assert.Len(t, m.samples, 1)
+}
@@ -0,0 +1,70 @@
+package ebpf
+
+import (
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/sbom"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initRuntimeSBOM enables the collection of the containers runtime SBOM, if the
+// runtime_sbom event is selected.
+func (t *Tracee) initRuntimeSBOM() error {
+	if t.eventsState[events.RuntimeSBOM].Submit == 0 {
+		return nil
+	}
+
+	collector, err := sbom.New(t.config.RuntimeSBOM)
+	if err != nil {
+		return errfmt.Errorf("error initializing runtime sbom collection: %v", err)
+	}
+	t.runtimeSBOM = collector
+
+	return nil
+}
+
+// runtimeSBOMReports returns the channel of the containers runtime SBOM reports (nil if the
+// runtime_sbom event isn't selected).
+func (t *Tracee) runtimeSBOMReports() <-chan sbom.Report {
+	if t.runtimeSBOM == nil {
+		return nil
+	}
+
+	return t.runtimeSBOM.Reports()
+}
+
+// runtimeSBOMEvent returns the runtime_sbom event of a container runtime SBOM report.
+func (t *Tracee) runtimeSBOMEvent(report sbom.Report) *trace.Event {
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+
+	values := []interface{}{
+		report.Executables,
+		report.SharedObjects,
+		string(report.Document),
+	}
+	def := events.Core.GetDefinitionByID(events.RuntimeSBOM)
+	params := def.GetParams()
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return &trace.Event{
+		Timestamp:             int(t.normalizeSnapshotTime(uint64(report.Time.UnixNano()) - t.bootTime)),
+		ContainerID:           report.Container.ID,
+		Container:             report.Container,
+		Kubernetes:            report.Kubernetes,
+		Labels:                t.config.Labels,
+		EventID:               int(events.RuntimeSBOM),
+		EventName:             def.GetName(),
+		PoliciesVersion:       policies.Version(),
+		MatchedPoliciesKernel: t.eventsState[events.RuntimeSBOM].Submit,
+		ArgsNum:               len(args),
+		Args:                  args,
+	}
+}
@@ -0,0 +1,104 @@
+package ebpf
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/selflimit"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initSelfLimits initializes the load shedding keeping tracee within its self-limits, if any.
+func (t *Tracee) initSelfLimits() {
+	if !t.config.SelfLimits.Enabled() {
+		return
+	}
+
+	t.selfLimiter = selflimit.New(t.config.SelfLimits)
+}
+
+// shedEvent returns true if an event is shed at the current load shedding level: only the low
+// priority events are, the ones emitted only (not derived from, nor a signatures input).
+func (t *Tracee) shedEvent(event *trace.Event) bool {
+	if t.selfLimiter == nil || t.selfLimiter.Level() == selflimit.LevelNone {
+		return false
+	}
+
+	id := events.ID(event.EventID)
+	if id == events.LoadShedding || len(t.eventDerivations[id]) > 0 {
+		return false
+	}
+	if eventNode, ok := t.eventsDependencies.GetEvent(id); ok {
+		for _, dependant := range eventNode.GetDependants() {
+			if t.eventSignatures[dependant] {
+				return false
+			}
+		}
+	}
+
+	return !t.selfLimiter.Keep()
+}
+
+// enrichEvents returns true if the expensive enrichments of the events are enabled at the
+// current load shedding level.
+func (t *Tracee) enrichEvents() bool {
+	return t.selfLimiter == nil || t.selfLimiter.Enrich()
+}
+
+// selfLimitReports returns the channel of the load shedding level changes (nil if there are no
+// self-limits).
+func (t *Tracee) selfLimitReports() <-chan selflimit.Report {
+	if t.selfLimiter == nil {
+		return nil
+	}
+
+	return t.selfLimiter.Reports()
+}
+
+// loadSheddingEvent logs a load shedding level change, and returns its load_shedding event
+// (nil if not selected).
+func (t *Tracee) loadSheddingEvent(report selflimit.Report) *trace.Event {
+	cfg := t.config.SelfLimits
+	logger.Warnw("Load shedding level changed", "level", report.Level.String(),
+		"previous", report.Previous.String(), "reason", report.Reason,
+		"cpu", report.Usage.CPU, "memory", report.Usage.Memory)
+
+	id := events.LoadShedding
+	if t.eventsState[id].Submit == 0 {
+		return nil
+	}
+
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+
+	values := []interface{}{
+		report.Level.String(),
+		report.Previous.String(),
+		report.Reason,
+		uint64(report.Usage.CPU * 1000),
+		uint64(cfg.CPU * 1000),
+		report.Usage.Memory,
+		cfg.Memory,
+	}
+
+	def := events.Core.GetDefinitionByID(id)
+	params := def.GetParams()
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return &trace.Event{
+		Timestamp:             int(t.normalizeSnapshotTime(uint64(report.Time.UnixNano()) - t.bootTime)),
+		Labels:                t.config.Labels,
+		EventID:               int(id),
+		EventName:             def.GetName(),
+		PoliciesVersion:       policies.Version(),
+		MatchedPoliciesKernel: t.eventsState[id].Submit,
+		ArgsNum:               len(args),
+		Args:                  args,
+	}
+}
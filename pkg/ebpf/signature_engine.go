@@ -2,13 +2,20 @@ package ebpf
 
 import (
 	"context"
+	"time"
 
 	"github.com/aquasecurity/tracee/pkg/containers"
 	"github.com/aquasecurity/tracee/pkg/dnscache"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/filehash"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/pkg/signatures/dedup"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
+	"github.com/aquasecurity/tracee/pkg/signatures/findingcontext"
+	"github.com/aquasecurity/tracee/pkg/signatures/suppression"
 	"github.com/aquasecurity/tracee/types/detect"
 	"github.com/aquasecurity/tracee/types/protocol"
 	"github.com/aquasecurity/tracee/types/trace"
@@ -53,8 +60,48 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 
 	go t.sigEngine.Start(ctx)
 
+	// Findings matching an allowlist rule are marked as suppressed
+	if len(t.config.SuppressionRules) > 0 {
+		t.suppressor = suppression.New(t.config.SuppressionRules, t.binaryHash)
+		if t.config.MetricsEnabled {
+			if err := t.suppressor.RegisterPrometheus(); err != nil {
+				logger.Errorw("Registering suppression prometheus metrics", "error", err)
+			}
+		}
+	}
+
+	// Findings are held until the events following them are observed (or a timeout expires)
+	var expireTicker *time.Ticker
+	var expireFindings <-chan time.Time // nil (blocking) if the findings aren't held
+	if t.config.FindingContext.Enabled {
+		t.findingContext, err = findingcontext.New(t.config.FindingContext)
+		if err != nil {
+			logger.Fatalw("failed to initialize findings context", "error", err)
+		}
+		expireTicker = time.NewTicker(time.Second)
+		expireFindings = expireTicker.C
+	}
+
+	// Identical findings are collapsed within a time window
+	var dedupTicker *time.Ticker
+	var expireDedup <-chan time.Time // nil (blocking) if the findings aren't collapsed
+	if t.config.FindingDedup.Enabled {
+		t.findingDedup, err = dedup.New(t.config.FindingDedup)
+		if err != nil {
+			logger.Fatalw("failed to initialize findings deduplication", "error", err)
+		}
+		dedupTicker = time.NewTicker(time.Second)
+		expireDedup = dedupTicker.C
+	}
+
 	// Create a function for feeding the engine with an event
-	feedFunc := func(event *trace.Event) {
+	var feedFunc func(event *trace.Event)
+	feedFindings := func(findings []*trace.Event) {
+		for _, finding := range findings {
+			feedFunc(finding)
+		}
+	}
+	feedFunc = func(event *trace.Event) {
 		if event == nil {
 			return // might happen during initialization (ctrl+c seg faults)
 		}
@@ -73,12 +120,22 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 			// This is needed because a later modification of the event (in
 			// particular of the matched policies) can affect engine stage.
 			eventCopy := *event
+
+			// record the (parsed) event for the findings context, before it is printed and
+			// returned to the pool, releasing the held findings it completed
+			var released []*trace.Event
+			if t.findingContext != nil && !events.Core.GetDefinitionByID(id).IsSignature() {
+				released = t.findingContext.Observe(event)
+			}
+
 			// pass the event to the sink stage, if the event is also marked as emit
 			// it will be sent to print by the sink stage
 			out <- event
 
 			// send the event to the rule event
 			engineInput <- eventCopy.ToProtocol()
+
+			feedFindings(released)
 		}
 	}
 
@@ -91,13 +148,22 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 		defer close(errc)
 		defer close(engineInput)
 		defer close(engineOutput)
+		if expireTicker != nil {
+			defer expireTicker.Stop()
+		}
 
 		for {
 			select {
 			case event := <-in:
 				feedFunc(event)
 			case event := <-engineOutputEvents:
+				if t.findingContext != nil {
+					feedFindings(t.findingContext.Hold(event, time.Now()))
+					continue
+				}
 				feedFunc(event)
+			case now := <-expireFindings:
+				feedFindings(t.findingContext.Expire(now))
 			case <-ctx.Done():
 				return
 			}
@@ -105,6 +171,10 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 	}()
 
 	go func() {
+		if dedupTicker != nil {
+			defer dedupTicker.Stop()
+		}
+
 		for {
 			select {
 			case finding := <-engineOutput:
@@ -126,7 +196,29 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 					continue
 				}
 
+				if t.suppressor != nil {
+					trigger := finding.Event.Payload.(trace.Event) // checked by FindingToEvent
+					if t.suppressor.Suppress(event, &trigger) {
+						_ = t.stats.FindingsSuppressed.Increment()
+					}
+				}
+
+				if t.findingDedup != nil {
+					emitted := t.findingDedup.Add(event, time.Now())
+					if len(emitted) == 0 {
+						_ = t.stats.FindingsDeduplicated.Increment()
+					}
+					for _, e := range emitted {
+						engineOutputEvents <- e
+					}
+					continue
+				}
+
 				engineOutputEvents <- event
+			case now := <-expireDedup:
+				for _, e := range t.findingDedup.Expire(now) {
+					engineOutputEvents <- e
+				}
 			case <-ctx.Done():
 				return
 			}
@@ -136,22 +228,46 @@ func (t *Tracee) engineEvents(ctx context.Context, in <-chan *trace.Event) (<-ch
 	return out, errc
 }
 
+// binaryHash returns the sha256 of the binary of the process which triggered a finding:
+// the hash given by the trigger event (exec-hash output option), or the hash of its
+// executable, read through its mount namespace.
+func (t *Tracee) binaryHash(trigger *trace.Event) (string, error) {
+	if hash, err := parse.ArgVal[string](trigger.Args, "sha256"); err == nil && hash != "" {
+		return hash, nil
+	}
+
+	// the binaries of the producers aren't reachable by the processor of a split pipeline
+	if t.contPathResolver == nil {
+		return "", errfmt.Errorf("binary %s not reachable", trigger.Executable.Path)
+	}
+
+	hostPath, err := t.contPathResolver.GetHostAbsPath(trigger.Executable.Path, trigger.MountNS)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	return filehash.ComputeFileHashAtPath(hostPath)
+}
+
 // PrepareBuiltinDataSources returns a list of all data sources tracee makes available built-in
 func (t *Tracee) PrepareBuiltinDataSources() []detect.DataSource {
 	datasources := []detect.DataSource{}
 
+	// The processor of a split pipeline has no containers, DNS cache nor process tree, they are
+	// on the producers.
+
 	// Containers Data Source
-	datasources = append(datasources, containers.NewDataSource(t.containers))
+	if t.containers != nil {
+		datasources = append(datasources, containers.NewDataSource(t.containers))
+	}
 
 	// DNS Data Source
-	if t.config.DNSCacheConfig.Enable {
+	if t.config.DNSCacheConfig.Enable && t.dnsCache != nil {
 		datasources = append(datasources, dnscache.NewDataSource(t.dnsCache))
 	}
 
 	// Process Tree Data Source
-	switch t.config.ProcTree.Source {
-	case proctree.SourceNone:
-	default:
+	if t.config.ProcTree.Source != proctree.SourceNone && t.processTree != nil {
 		datasources = append(datasources, proctree.NewDataSource(t.processTree))
 	}
 
@@ -0,0 +1,142 @@
+package ebpf
+
+import (
+	"context"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/snapshot"
+	"github.com/aquasecurity/tracee/pkg/utils"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// TakeSnapshot takes a forensic snapshot of the host (process tree, open sockets, loaded
+// kernel modules and namespaces in use) on demand. The snapshot is emitted as a batch of
+// snapshot_* events, sent to all policies, and optionally written as an artifact bundle
+// to the snapshots directory.
+func (t *Tracee) TakeSnapshot(ctx context.Context, bundle bool) (*snapshot.Summary, error) {
+	if !t.Running() {
+		return nil, errfmt.Errorf("tracee is not running")
+	}
+
+	s, errs := snapshot.Take("/proc")
+	summary := s.Summary()
+	for _, err := range errs {
+		logger.Warnw("Forensic snapshot", "error", err)
+		summary.Errors = append(summary.Errors, err.Error())
+	}
+
+	// The snapshot was explicitly requested: its events are sent to all policies, even if
+	// not selected by any of them.
+	policies := t.config.Policies
+	matchedNames := policies.MatchedNames(policy.PolicyAll)
+
+	for _, evt := range t.snapshotEvents(s) {
+		evt.PoliciesVersion = policies.Version()
+		evt.MatchedPoliciesKernel = policy.PolicyAll
+		evt.MatchedPoliciesUser = policy.PolicyAll
+		evt.MatchedPolicies = matchedNames
+
+		select {
+		case <-ctx.Done():
+			return summary, ctx.Err()
+		default:
+			t.streamsManager.Publish(ctx, evt)
+			_ = t.stats.EventCount.Increment()
+		}
+	}
+
+	if bundle {
+		if t.config.SnapshotDir == "" {
+			return summary, errfmt.Errorf("snapshots directory not set")
+		}
+		path, err := snapshot.WriteBundle(t.config.SnapshotDir, s)
+		if err != nil {
+			return summary, err
+		}
+		summary.Bundle = path
+	}
+
+	logger.Infow("Forensic snapshot taken",
+		"processes", summary.Processes,
+		"sockets", summary.Sockets,
+		"kernel_modules", summary.Modules,
+		"namespaces", summary.Namespaces,
+		"bundle", summary.Bundle,
+	)
+
+	return summary, nil
+}
+
+// snapshotEvents returns the events describing a forensic snapshot: one event per process,
+// socket, kernel module and namespace. Their times are normalized as the times of the
+// pipeline events (see normalizeEventCtxTimes).
+func (t *Tracee) snapshotEvents(s *snapshot.Snapshot) []trace.Event {
+	evts := make([]trace.Event, 0,
+		len(s.Processes)+len(s.Sockets)+len(s.Modules)+len(s.Namespaces))
+	// the snapshot time is a wall time, while pipeline times are read since boot
+	timestamp := int(t.normalizeSnapshotTime(uint64(s.Time.UnixNano()) - t.bootTime))
+
+	newEvent := func(id events.ID, values ...interface{}) trace.Event {
+		def := events.Core.GetDefinitionByID(id)
+		params := def.GetParams()
+		args := make([]trace.Argument, len(params))
+		for i := range params {
+			args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+		}
+
+		return trace.Event{
+			Timestamp:   timestamp,
+			ProcessName: "tracee",
+			Labels:      t.config.Labels,
+			EventID:     int(id),
+			EventName:   def.GetName(),
+			ArgsNum:     len(args),
+			Args:        args,
+		}
+	}
+
+	for _, p := range s.Processes {
+		startTime := t.normalizeSnapshotTime(utils.ClockTicksToNsSinceBootTime(int64(p.StartTime)))
+		evt := newEvent(events.SnapshotProcess,
+			int32(p.Pid), int32(p.PPid), int32(p.Uid), p.Comm, p.Exe, p.Cmdline, startTime,
+			int32(p.Threads),
+		)
+		// allow filtering the snapshot processes as any other process
+		evt.HostProcessID = p.Pid
+		evt.HostThreadID = p.Pid
+		evt.HostParentProcessID = p.PPid
+		evt.UserID = p.Uid
+		evts = append(evts, evt)
+	}
+	for _, sock := range s.Sockets {
+		evts = append(evts, newEvent(events.SnapshotSocket,
+			sock.Protocol, sock.LocalAddr, sock.LocalPort, sock.RemoteAddr, sock.RemotePort,
+			sock.State, sock.Inode, int32(sock.Pid),
+		))
+	}
+	for _, m := range s.Modules {
+		evts = append(evts, newEvent(events.SnapshotKernelModule,
+			m.Name, m.Size, int32(m.RefCount), m.Dependencies, m.State, m.Address,
+		))
+	}
+	for _, ns := range s.Namespaces {
+		evts = append(evts, newEvent(events.SnapshotNamespace,
+			ns.Type, ns.Inode, int32(ns.Processes), int32(ns.Pid),
+		))
+	}
+
+	return evts
+}
+
+// normalizeSnapshotTime normalizes a time since boot to be relative to tracee start time or
+// current time, as normalizeEventArgTime does.
+func (t *Tracee) normalizeSnapshotTime(sinceBoot uint64) uint64 {
+	if t.config.Output.RelativeTime {
+		return sinceBoot - t.startTime
+	}
+
+	return sinceBoot + t.bootTime
+}
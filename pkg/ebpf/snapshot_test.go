@@ -0,0 +1,83 @@
+package ebpf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/config"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/snapshot"
+	"github.com/aquasecurity/tracee/pkg/utils"
+)
+
+func TestSnapshotEvents(t *testing.T) {
+	t.Parallel()
+
+	const (
+		bootTime  = uint64(1600000000 * time.Second)
+		startTime = uint64(1000 * time.Second) // since boot
+	)
+	s := &snapshot.Snapshot{
+		Time:       time.Unix(0, int64(bootTime+startTime+uint64(5*time.Second))),
+		Processes:  []snapshot.Process{{Pid: 42, PPid: 1, Uid: 101, Comm: "nginx", StartTime: 150000}},
+		Sockets:    []snapshot.Socket{{Protocol: "tcp", LocalAddr: "127.0.0.1", LocalPort: 80, Pid: 42}},
+		Modules:    []snapshot.KernelModule{{Name: "loop", State: "Live"}},
+		Namespaces: []snapshot.Namespace{{Type: "mnt", Inode: 4026531841, Processes: 1, Pid: 1}},
+	}
+	processStart := utils.ClockTicksToNsSinceBootTime(150000)
+
+	testCases := []struct {
+		name              string
+		relativeTime      bool
+		expectedTimestamp int
+		expectedStartTime uint64
+	}{
+		{
+			name:              "wall time",
+			expectedTimestamp: int(s.Time.UnixNano()),
+			expectedStartTime: processStart + bootTime,
+		},
+		{
+			name:              "relative time",
+			relativeTime:      true,
+			expectedTimestamp: int(5 * time.Second),
+			expectedStartTime: processStart - startTime,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			trc := &Tracee{
+				config:    config.Config{Output: &config.OutputConfig{RelativeTime: tc.relativeTime}},
+				bootTime:  bootTime,
+				startTime: startTime,
+			}
+
+			evts := trc.snapshotEvents(s)
+			require.Len(t, evts, 4)
+
+			expectedIDs := []events.ID{
+				events.SnapshotProcess, events.SnapshotSocket, events.SnapshotKernelModule, events.SnapshotNamespace,
+			}
+			for i, evt := range evts {
+				def := events.Core.GetDefinitionByID(expectedIDs[i])
+				assert.Equal(t, int(expectedIDs[i]), evt.EventID)
+				assert.Equal(t, def.GetName(), evt.EventName)
+				assert.Equal(t, tc.expectedTimestamp, evt.Timestamp)
+				assert.Len(t, evt.Args, len(def.GetParams()))
+			}
+
+			assert.Equal(t, 42, evts[0].HostProcessID)
+			assert.Equal(t, "nginx", evts[0].Args[3].Value)
+			assert.Equal(t, tc.expectedStartTime, evts[0].Args[6].Value)
+			assert.Equal(t, uint16(80), evts[1].Args[2].Value)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package ebpf
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
+	"github.com/aquasecurity/tracee/pkg/remote"
+	"github.com/aquasecurity/tracee/pkg/utils"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// In a split pipeline, the nodes (producers) run the pipeline stages reading the node state,
+// up to the derivation stage, and forward their events to a remote processor, running the
+// detection and output stages (see outputStages).
+
+// policiesFingerprint identifies the policies (by id and name) matched by the events of a split
+// pipeline: the matched policies are given as bitmaps, only meaningful to the same policies.
+func policiesFingerprint() string {
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		return ""
+	}
+
+	var ids []string
+	for id := 0; id < policy.PolicyMax; id++ {
+		if p, err := policies.LookupById(id); err == nil {
+			ids = append(ids, strconv.Itoa(id)+":"+p.Name)
+		}
+	}
+
+	return strings.Join(ids, ",")
+}
+
+// initProcessor initializes the processor of a split pipeline: it doesn't load eBPF programs,
+// the events are forwarded by the producers.
+func (t *Tracee) initProcessor() error {
+	var err error
+
+	cfg := t.config.SplitPipeline
+	t.receiver, err = remote.NewReceiver(cfg.Protocol, cfg.Address, policiesFingerprint)
+	if err != nil {
+		return errfmt.Errorf("error listening for the split pipeline producers: %v", err)
+	}
+
+	// Initialize the policies events quotas
+
+	if len(t.config.PolicyQuotas) > 0 {
+		t.policyQuotas, err = quota.New(t.config.PolicyQuotas)
+		if err != nil {
+			return errfmt.Errorf("error initializing policy quotas: %v", err)
+		}
+	}
+
+	// Initialize events pool
+
+	t.eventsPool = &sync.Pool{
+		New: func() interface{} {
+			return &trace.Event{}
+		},
+	}
+
+	// Initialize times
+
+	t.startTime = uint64(utils.GetStartTimeNS())
+	t.bootTime = uint64(utils.GetBootTimeNS())
+
+	return nil
+}
+
+// initForwarder initializes the forwarding of the events of a producer to the processor of a
+// split pipeline.
+func (t *Tracee) initForwarder() error {
+	var err error
+
+	cfg := t.config.SplitPipeline
+	t.forwarder, err = remote.NewForwarder(cfg.Protocol, cfg.Address, policiesFingerprint)
+	if err != nil {
+		return errfmt.Errorf("error initializing the split pipeline forwarder: %v", err)
+	}
+
+	return nil
+}
+
+// runProcessor is Run for the processor of a split pipeline, until the context is done.
+func (t *Tracee) runProcessor(ctx context.Context) error {
+	go t.receiver.Start(ctx)
+
+	pipelineReady := make(chan struct{}, 1)
+	go t.handleForwardedEvents(ctx, pipelineReady)
+
+	<-pipelineReady
+	t.running.Store(true)
+	t.ready(ctx) // executes ready callback, non blocking
+	<-ctx.Done() // block until ctx is cancelled elsewhere
+
+	t.Close()
+
+	return nil
+}
+
+// handleForwardedEvents is the pipeline of the processor of a split pipeline: the events
+// forwarded by the producers go through the detection and output stages.
+func (t *Tracee) handleForwardedEvents(ctx context.Context, initialized chan<- struct{}) {
+	logger.Debugw("Starting handleForwardedEvents goroutine")
+	defer logger.Debugw("Stopped handleForwardedEvents goroutine")
+
+	eventsChan, errc := t.receiveEvents(ctx)
+	errcList := append([]<-chan error{errc}, t.outputStages(ctx, eventsChan)...)
+
+	initialized <- struct{}{}
+
+	if err := t.WaitForPipeline(errcList...); err != nil {
+		logger.Errorw("Pipeline", "error", err)
+	}
+}
+
+// receiveEvents is the receive pipeline stage of the processor. The events forwarded by the
+// producers refer to the version of the policies of their node, they are given the last version
+// of the processor ones (the same policies, checked when a producer connects).
+func (t *Tracee) receiveEvents(ctx context.Context) (<-chan *trace.Event, <-chan error) {
+	out := make(chan *trace.Event, 10000)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		for {
+			select {
+			case event := <-t.receiver.Events():
+				policies, err := policy.Snapshots().GetLast()
+				if err != nil {
+					t.handleError(err)
+					continue
+				}
+				event.PoliciesVersion = policies.Version()
+
+				select {
+				case out <- event:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, errc
+}
+
+// forwardEvents is the forward pipeline stage of the producers. It drains the events already
+// available (up to maxEventsBatchSize) after each blocking read, and forwards them at once to
+// the processor: the events are dropped while the processor is unreachable.
+func (t *Tracee) forwardEvents(ctx context.Context, in <-chan *trace.Event) <-chan error {
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(errc)
+		defer func() {
+			if err := t.forwarder.Close(); err != nil {
+				logger.Debugw("Closing split pipeline forwarder", "error", err)
+			}
+		}()
+
+		batch := make([]*trace.Event, 0, maxEventsBatchSize)
+		for {
+			select {
+			case event, ok := <-in:
+				if !ok {
+					return
+				}
+				if event == nil {
+					continue // might happen during initialization (ctrl+c seg faults)
+				}
+				batch = append(batch, event)
+			case <-ctx.Done():
+				return
+			}
+		drain:
+			for len(batch) < maxEventsBatchSize {
+				select {
+				case event, ok := <-in:
+					if !ok {
+						break drain
+					}
+					if event != nil {
+						batch = append(batch, event)
+					}
+				default:
+					break drain
+				}
+			}
+
+			// the events are forwarded with all their arguments decoded
+			for _, event := range batch {
+				event.DecodeArgs()
+			}
+			t.forwarder.Forward(ctx, batch)
+
+			for i, event := range batch {
+				t.eventsPool.Put(event)
+				batch[i] = nil
+			}
+			batch = batch[:0]
+		}
+	}()
+
+	return errc
+}
@@ -4,6 +4,7 @@ import (
 	gocontext "context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
@@ -16,34 +17,57 @@ import (
 	bpf "github.com/aquasecurity/libbpfgo"
 	"github.com/aquasecurity/libbpfgo/helpers"
 
+	"github.com/aquasecurity/tracee/pkg/artifacts"
 	"github.com/aquasecurity/tracee/pkg/bucketscache"
 	"github.com/aquasecurity/tracee/pkg/bufferdecoder"
+	"github.com/aquasecurity/tracee/pkg/buildid"
+	"github.com/aquasecurity/tracee/pkg/canonpath"
 	"github.com/aquasecurity/tracee/pkg/capabilities"
 	"github.com/aquasecurity/tracee/pkg/cgroup"
+	"github.com/aquasecurity/tracee/pkg/cgroup/pressure"
 	"github.com/aquasecurity/tracee/pkg/config"
 	"github.com/aquasecurity/tracee/pkg/containers"
+	cruntime "github.com/aquasecurity/tracee/pkg/containers/runtime"
 	"github.com/aquasecurity/tracee/pkg/dnscache"
 	"github.com/aquasecurity/tracee/pkg/ebpf/controlplane"
 	"github.com/aquasecurity/tracee/pkg/ebpf/initialization"
 	"github.com/aquasecurity/tracee/pkg/ebpf/probes"
+	"github.com/aquasecurity/tracee/pkg/ebpf/progstats"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/chunks"
 	"github.com/aquasecurity/tracee/pkg/events/dependencies"
 	"github.com/aquasecurity/tracee/pkg/events/derive"
+	"github.com/aquasecurity/tracee/pkg/events/fdtable"
+	"github.com/aquasecurity/tracee/pkg/events/granularity"
+	"github.com/aquasecurity/tracee/pkg/events/recent"
 	"github.com/aquasecurity/tracee/pkg/events/sorting"
 	"github.com/aquasecurity/tracee/pkg/events/trigger"
 	"github.com/aquasecurity/tracee/pkg/filehash"
 	"github.com/aquasecurity/tracee/pkg/filters"
+	"github.com/aquasecurity/tracee/pkg/handoff"
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/metrics"
 	"github.com/aquasecurity/tracee/pkg/pcaps"
 	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/policy/quota"
 	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/pkg/remote"
+	"github.com/aquasecurity/tracee/pkg/sbom"
+	"github.com/aquasecurity/tracee/pkg/selflimit"
+	"github.com/aquasecurity/tracee/pkg/signatures/dedup"
 	"github.com/aquasecurity/tracee/pkg/signatures/engine"
+	"github.com/aquasecurity/tracee/pkg/signatures/findingcontext"
+	"github.com/aquasecurity/tracee/pkg/signatures/suppression"
 	"github.com/aquasecurity/tracee/pkg/streams"
+	"github.com/aquasecurity/tracee/pkg/symbolizer"
+	"github.com/aquasecurity/tracee/pkg/threatintel"
 	"github.com/aquasecurity/tracee/pkg/utils"
 	"github.com/aquasecurity/tracee/pkg/utils/proc"
 	"github.com/aquasecurity/tracee/pkg/utils/sharedobjs"
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+	"github.com/aquasecurity/tracee/pkg/yara"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -62,6 +86,16 @@ type Tracee struct {
 	OutDir    *os.File      // use utils.XXX functions to create or write to this file
 	stats     metrics.Stats
 	sigEngine *engine.Engine
+	// Findings suppression
+	suppressor *suppression.Suppressor
+	// Findings context
+	findingContext *findingcontext.Buffer
+	// Findings deduplication
+	findingDedup *dedup.Deduplicator
+	// Policies events quotas
+	policyQuotas *quota.Quotas
+	// Events coalesced at process level
+	coalescer *granularity.Coalescer
 	// Events States
 	eventsState map[events.ID]events.EventState
 	// Events
@@ -73,6 +107,7 @@ type Tracee struct {
 	eventSignatures  map[events.ID]bool
 	// Artifacts
 	fileHashes     *filehash.Cache
+	buildIDs       *buildid.Cache
 	capturedFiles  map[string]int64
 	writtenFiles   map[string]string
 	netCapturePcap *pcaps.Pcaps
@@ -86,6 +121,9 @@ type Tracee struct {
 	// BPF Maps
 	StackAddressesMap *bpf.BPFMap
 	FDArgPathMap      *bpf.BPFMap
+	fdTable           *fdtable.Table      // processes fd table resolving the fd arguments (if enabled)
+	argChunks         *chunks.Reassembler // arguments streamed in chunks, pending their event
+	pathResolver      *canonpath.Resolver // canonical paths of the syscalls path arguments (if enabled)
 	// Perf Buffers
 	eventsPerfMap  *bpf.PerfBuffer // perf buffer for events
 	fileWrPerfMap  *bpf.PerfBuffer // perf buffer for file writes
@@ -105,13 +143,54 @@ type Tracee struct {
 	cgroups           *cgroup.Cgroups
 	containers        *containers.Containers
 	contPathResolver  *containers.ContainerPathResolver
+	rootfsResolver    *containers.RootfsResolver // host path of the containers rootfs (if enabled)
 	contSymbolsLoader *sharedobjs.ContainersSymbolsLoader
 	// Control Plane
 	controlPlane *controlplane.Controller
+	// Policies updates, applied one at a time
+	policiesUpdateMu sync.Mutex
 	// Process Tree
 	processTree *proctree.ProcessTree
 	// DNS Cache
 	dnsCache *dnscache.DNSCache
+	// Threat Intel
+	threatIntel *threatintel.Store
+	// Vulnerability DB of the loaded libraries
+	vulnDB *vulndb.DB
+	// Stack addresses symbolization (nil if disabled)
+	symbolizer *symbolizer.Symbolizer
+	// YARA scanning of the captured artifacts
+	yaraScanner *yara.Scanner
+	// Index of the captured artifacts (nil if disabled)
+	artifactsIndex *artifacts.Index
+	// Kubernetes audit logs correlation
+	k8sAudit *k8saudit.Correlator
+	// Containers runtimes lifecycle events (nil if not selected)
+	containerLifecycle chan cruntime.LifecycleEvent
+	// Containers cgroups resource pressure monitoring (nil if not selected)
+	cgroupPressure *pressure.Monitor
+	// Containers runtime SBOM collection (nil if not selected)
+	runtimeSBOM *sbom.Collector
+	// eBPF programs statistics monitoring, and the request enabling them (nil if not selected)
+	progStats        *progstats.Monitor
+	progStatsEnabled io.Closer
+	// Load shedding keeping tracee within its self-limits (nil if there are no self-limits)
+	selfLimiter *selflimit.Limiter
+	// Sink lock the events output is handed over with (nil if not enabled)
+	handoff *handoff.Handoff
+	// Split pipeline: forwarding of the events of a producer, or receiving of the events of
+	// the processor (nil if the pipeline isn't split)
+	forwarder *remote.Forwarder
+	receiver  *remote.Receiver
+	// Most recent events of the processes, the context of their crash (nil if not selected)
+	recentEvents *recent.Recorder
+	// Events read in userspace by consumers other than the processors, derivations and
+	// signatures (events.All for any event), see RegisterEventConsumer
+	eventConsumers map[events.ID]bool
+	// Events disabled or degraded by the running kernel, reported by capability_report
+	capabilityReport *capabilityReport
+	// Events not available on the running kernel, by their kernel version
+	kernelGates map[events.ID]kernelGate
 	// Specific Events Needs
 	triggerContexts trigger.Context
 	readyCallback   func(gocontext.Context)
@@ -127,6 +206,11 @@ func (t *Tracee) Stats() *metrics.Stats {
 	return &t.stats
 }
 
+// Artifacts returns the index of the captured artifacts (nil if disabled).
+func (t *Tracee) Artifacts() *artifacts.Index {
+	return t.artifactsIndex
+}
+
 func (t *Tracee) Engine() *engine.Engine {
 	return t.sigEngine
 }
@@ -168,6 +252,8 @@ func (t *Tracee) addEventState(eventID events.ID, chosenState events.EventState)
 	currentState := t.eventsState[eventID]
 	currentState.Submit |= chosenState.Submit
 	currentState.Emit |= chosenState.Emit
+	currentState.Stack |= chosenState.Stack
+	currentState.Consume |= chosenState.Consume
 	t.eventsState[eventID] = currentState
 }
 
@@ -183,6 +269,7 @@ func (t *Tracee) addDependenciesToStateRecursive(eventNode *dependencies.EventNo
 }
 
 func (t *Tracee) chooseEvent(eventID events.ID, chosenState events.EventState) {
+	chosenState.Consume = chosenState.Submit // chosen events are consumed (emitted, captured, ...)
 	t.addEventState(eventID, chosenState)
 	t.eventsDependencies.SelectEvent(eventID)
 	eventNode, ok := t.eventsDependencies.GetEvent(eventID)
@@ -206,6 +293,59 @@ func (t *Tracee) addDependencyEventToState(evtID events.ID, dependantEvts []even
 	}
 }
 
+// setConsumeState sets the policies consuming the dependency events in userspace, the only ones
+// they are written to the perf buffer for: all the policies they're submitted for if they're
+// processed, derived from, read by a registered consumer or signatures input, otherwise the
+// policies emitting them.
+// The dependencies only needed by their dependants in the kernel (e.g. the state kept by their
+// programs) are never written to the perf buffer.
+//
+// Only the changed states are written, the events state being read by the pipeline when the
+// policies are updated at runtime (see UpdatePolicies).
+func (t *Tracee) setConsumeState() {
+	for id, state := range t.eventsState {
+		consume := state.Consume | state.Emit
+		if t.isConsumedInUserspace(id) {
+			consume |= state.Submit
+		}
+		if consume != state.Consume {
+			state.Consume = consume
+			t.eventsState[id] = state
+		}
+	}
+}
+
+// isConsumedInUserspace returns whether an event is consumed in userspace, besides being emitted.
+func (t *Tracee) isConsumedInUserspace(id events.ID) bool {
+	if len(t.eventProcessor[id]) > 0 || len(t.eventDerivations[id]) > 0 ||
+		t.eventConsumers[id] || t.eventConsumers[events.All] {
+		return true
+	}
+	eventNode, ok := t.eventsDependencies.GetEvent(id)
+	if !ok {
+		return true
+	}
+	for _, dependant := range eventNode.GetDependants() {
+		if t.eventSignatures[dependant] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegisterEventConsumer registers an event read in userspace by a consumer other than the
+// processors, derivations and signatures (events.All for any event), so it is written to the
+// perf buffer even if it isn't emitted. The consumers of the emitted events only (reading
+// the pipeline output) don't need to register. It must be called before the eBPF maps are
+// populated.
+func (t *Tracee) RegisterEventConsumer(id events.ID) {
+	if t.eventConsumers == nil {
+		t.eventConsumers = make(map[events.ID]bool)
+	}
+	t.eventConsumers[id] = true
+}
+
 func (t *Tracee) removeEventFromState(evtID events.ID) {
 	logger.Debugw("Cancel event", "event", events.Core.GetDefinitionByID(evtID).GetName())
 	delete(t.eventsState, evtID)
@@ -234,12 +374,13 @@ func New(cfg config.Config) (*Tracee, error) {
 		eventSignatures: make(map[events.ID]bool),
 		streamsManager:  streams.NewStreamsManager(),
 		policyManager:   policyManager,
-		eventsDependencies: dependencies.NewDependenciesManager(
-			func(id events.ID) events.Dependencies {
-				return events.Core.GetDefinitionByID(id).GetDependencies()
-			}),
 	}
 
+	// Events not available on the running kernel depend on their fallback event instead
+
+	t.kernelGates = t.getKernelGates()
+	t.eventsDependencies = dependencies.NewDependenciesManager(t.getEventDependencies)
+
 	t.eventsDependencies.SubscribeAdd(
 		func(node *dependencies.EventNode) {
 			t.addDependencyEventToState(node.GetID(), node.GetDependants())
@@ -249,9 +390,27 @@ func New(cfg config.Config) (*Tracee, error) {
 			t.removeEventFromState(node.GetID())
 		})
 
+	if t.config.MetricsEnabled {
+		t.stats.EventLatency = metrics.NewEventLatency()
+	}
+	if t.config.BPFMaps.Interval > 0 {
+		t.stats.MapPressure = metrics.NewMapPressure()
+	}
+	if t.config.PerfBufferTuning.Interval > 0 {
+		t.stats.PerfBufferTuning = metrics.NewPerfBufferTuning(
+			t.config.PerfBufferSize,
+			t.config.PerfBufferTuning.MaxSize,
+			t.config.PerfBufferTuning.LossThreshold,
+		)
+	}
+
 	// Initialize capabilities rings soon
 
-	err = capabilities.Initialize(t.config.Capabilities.BypassCaps)
+	if t.config.Capabilities.Unprivileged {
+		err = capabilities.InitializeUnprivileged()
+	} else {
+		err = capabilities.Initialize(t.config.Capabilities.BypassCaps)
+	}
 	if err != nil {
 		return t, errfmt.WrapError(err)
 	}
@@ -323,6 +482,57 @@ func New(cfg config.Config) (*Tracee, error) {
 		}
 	}
 
+	// Stack traces of the events selected for it (all events if not restricted)
+
+	for _, id := range t.config.Output.StackAddressesEvents {
+		state, ok := t.eventsState[id]
+		if !ok {
+			logger.Debugw("Stack addresses event not traced", "event", events.Core.GetDefinitionByID(id).GetName())
+			continue
+		}
+		state.Stack = state.Submit
+		t.eventsState[id] = state
+	}
+
+	// Arguments too big for their event, streamed in chunks
+
+	t.argChunks, err = chunks.NewReassembler(chunks.DefaultMaxPending)
+	if err != nil {
+		return t, errfmt.WrapError(err)
+	}
+
+	// Events feeding the processes fd table, resolving the fd arguments of any event
+
+	if t.config.Output.ParseArgumentsFDs {
+		t.fdTable, err = fdtable.New(fdtable.DefaultMaxProcesses)
+		if err != nil {
+			return t, errfmt.WrapError(err)
+		}
+		t.chooseFDTableEvents()
+	}
+
+	// Host paths of the path arguments of the containers events
+
+	if t.config.Output.ContainerPaths {
+		t.rootfsResolver, err = containers.NewRootfsResolver(&t.pidsInMntns, 1024)
+		if err != nil {
+			return t, errfmt.WrapError(err)
+		}
+		// the mounts of the host and of the containers are read from /proc/<pid>/mountinfo
+		err = caps.BaseRingAdd(cap.SYS_PTRACE)
+		if err != nil {
+			return t, errfmt.WrapError(err)
+		}
+	}
+
+	t.capabilityReport = newCapabilityReport(t.eventsState)
+
+	// Disable events needing capabilities not permitted in the unprivileged mode
+
+	if caps.Unprivileged() {
+		t.validateCapabilities(caps)
+	}
+
 	// Update capabilities rings with all events dependencies
 
 	// TODO: extract this to a function to be called from here and from
@@ -384,6 +594,12 @@ func New(cfg config.Config) (*Tracee, error) {
 func (t *Tracee) Init(ctx gocontext.Context) error {
 	var err error
 
+	// The processor of a split pipeline only runs the detection and output stages
+
+	if t.config.SplitPipeline.Mode == config.SplitPipelineProcessor {
+		return t.initProcessor()
+	}
+
 	// Init kernel symbols map
 
 	err = capabilities.GetInstance().Specific(
@@ -398,6 +614,7 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 	}
 
 	t.validateKallsymsDependencies() // disable events w/ missing ksyms dependencies
+	t.validateKernelVersions()       // disable events not available on the running kernel
 
 	// Initialize buckets cache
 
@@ -447,7 +664,7 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 		t.config.NoContainersEnrich,
 		t.cgroups,
 		t.config.Sockets,
-		"containers_map",
+		containersMapName,
 	)
 	if err != nil {
 		return errfmt.Errorf("error initializing containers: %v", err)
@@ -465,6 +682,92 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 		}
 	}
 
+	// Initialize threat intel feeds
+
+	if len(t.config.ThreatIntel.Feeds) > 0 {
+		t.threatIntel, err = threatintel.NewStore(ctx, t.config.ThreatIntel)
+		if err != nil {
+			return errfmt.Errorf("error initializing threat intel: %v", err)
+		}
+	}
+
+	// Initialize the vulnerability DB of the loaded libraries
+
+	if len(t.config.VulnDB.Files) > 0 {
+		t.vulnDB, err = vulndb.Load(t.config.VulnDB.Files)
+		if err != nil {
+			return errfmt.Errorf("error loading vulnerability db: %v", err)
+		}
+		logger.Debugw("Vulnerability DB loaded", "vulnerabilities", t.vulnDB.Len())
+	}
+
+	// Initialize the stack addresses symbolization
+
+	if t.config.StackSymbols.Enabled {
+		t.symbolizer, err = symbolizer.New(t.config.StackSymbols)
+		if err != nil {
+			return errfmt.Errorf("error initializing stack symbolization: %v", err)
+		}
+		// reading the processes memory mappings and binaries requires CAP_SYS_PTRACE
+		if err := capabilities.GetInstance().BaseRingAdd(cap.SYS_PTRACE); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	// Initialize the policies events quotas
+
+	if len(t.config.PolicyQuotas) > 0 {
+		t.policyQuotas, err = quota.New(t.config.PolicyQuotas)
+		if err != nil {
+			return errfmt.Errorf("error initializing policy quotas: %v", err)
+		}
+	}
+
+	// Initialize the events coalesced at process level
+
+	if t.config.Granularity.Enabled() {
+		t.coalescer, err = granularity.New(t.config.Granularity)
+		if err != nil {
+			return errfmt.Errorf("error initializing events granularity: %v", err)
+		}
+	}
+
+	// Initialize YARA scanning of the captured artifacts
+
+	if err := t.initYara(); err != nil {
+		return err
+	}
+
+	// Initialize the Kubernetes audit logs correlation
+
+	if err := t.initK8sAudit(); err != nil {
+		return err
+	}
+
+	// Initialize the containers runtimes lifecycle events streams
+
+	t.initContainerLifecycle()
+
+	// Initialize the containers cgroups resource pressure monitoring
+
+	t.initCgroupPressure()
+
+	// Initialize the containers runtime SBOM collection
+
+	if err := t.initRuntimeSBOM(); err != nil {
+		return err
+	}
+
+	// Initialize the processes recent events recording
+
+	if _, ok := t.eventsState[events.ProcessCrashed]; ok {
+		t.recentEvents, err = recent.New(recent.DefaultMaxEvents)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		t.RegisterEventConsumer(events.All)
+	}
+
 	// Initialize containers related logic
 
 	t.contPathResolver = containers.InitContainerPathResolver(&t.pidsInMntns)
@@ -499,6 +802,18 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 		return errfmt.WrapError(err)
 	}
 
+	// Initialize the eBPF programs statistics monitoring
+
+	t.initProgStats()
+
+	// Initialize the load shedding of the self-limits
+
+	t.initSelfLimits()
+
+	// Initialize the sink lock of the events output handoff
+
+	t.initHandoff()
+
 	// Initialize hashes for files
 
 	t.fileHashes, err = filehash.NewCache(t.config.Output.CalcHashes, t.contPathResolver)
@@ -507,6 +822,31 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 		return errfmt.WrapError(err)
 	}
 
+	// Initialize the canonical paths resolution of the syscalls path arguments
+
+	if t.config.Output.CanonicalPaths {
+		t.pathResolver, err = canonpath.New(canonpath.DefaultCacheSize, canonpath.DefaultCacheTTL)
+		if err != nil {
+			t.Close()
+			return errfmt.WrapError(err)
+		}
+		// the processes filesystems are accessed through /proc/<pid>/root
+		if err := capabilities.GetInstance().BaseRingAdd(cap.SYS_PTRACE); err != nil {
+			t.Close()
+			return errfmt.WrapError(err)
+		}
+	}
+
+	// Initialize build ids of the executed files
+
+	if t.config.Output.ExecBuildID {
+		t.buildIDs, err = buildid.NewCache(1024)
+		if err != nil {
+			t.Close()
+			return errfmt.WrapError(err)
+		}
+	}
+
 	// Initialize capture directory
 
 	if err := os.MkdirAll(t.config.Capture.OutputPath, 0755); err != nil {
@@ -520,6 +860,16 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 		return errfmt.Errorf("error opening out directory: %v", err)
 	}
 
+	// Initialize the index of the captured artifacts
+
+	if t.config.Capture.Index.Enabled {
+		t.artifactsIndex, err = artifacts.Open(t.config.Capture.OutputPath, t.config.Capture.Index)
+		if err != nil {
+			t.Close()
+			return errfmt.Errorf("error opening artifacts index: %v", err)
+		}
+	}
+
 	// Initialize network capture (all needed pcap files)
 
 	t.netCapturePcap, err = pcaps.New(t.config.Capture.Net, t.OutDir)
@@ -530,7 +880,7 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 
 	// Get reference to stack trace addresses map
 
-	stackAddressesMap, err := t.bpfModule.GetMap("stack_addresses")
+	stackAddressesMap, err := t.bpfModule.GetMap(stackAddressesMapName)
 	if err != nil {
 		t.Close()
 		return errfmt.Errorf("error getting access to 'stack_addresses' eBPF Map %v", err)
@@ -539,7 +889,7 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 
 	// Get reference to fd arg path map
 
-	fdArgPathMap, err := t.bpfModule.GetMap("fd_arg_path_map")
+	fdArgPathMap, err := t.bpfModule.GetMap(fdArgPathMapName)
 	if err != nil {
 		t.Close()
 		return errfmt.Errorf("error getting access to 'fd_arg_path_map' eBPF Map %v", err)
@@ -568,6 +918,15 @@ func (t *Tracee) Init(ctx gocontext.Context) error {
 	t.startTime = uint64(utils.GetStartTimeNS())
 	t.bootTime = uint64(utils.GetBootTimeNS())
 
+	// Initialize the forwarding of the events to the processor of a split pipeline
+
+	if t.config.SplitPipeline.Mode == config.SplitPipelineProducer {
+		if err := t.initForwarder(); err != nil {
+			t.Close()
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -633,6 +992,14 @@ func (t *Tracee) initDerivationTable() error {
 		return func() bool { return t.eventsState[id].Submit > 0 }
 	}
 	symbolsCollisions := derive.SymbolsCollision(t.contSymbolsLoader, t.config.Policies)
+	iocMatch := derive.IOCMatch(t.threatIntel)
+	k8sAuditCorrelation := derive.K8sAuditCorrelation(t.k8sAudit)
+	icmpEchoFlood := derive.ICMPEchoFlood()
+	linkerHijack := derive.LinkerHijack(t.contPathResolver)
+	persistenceModification := derive.PersistenceModification(t.processTree)
+	containerReleaseAgentWrite := derive.ContainerReleaseAgentWrite()
+	runtimeSBOM := derive.RuntimeSBOM(t.runtimeSBOM)
+	processCrashed := derive.ProcessCrashed(t.recentEvents)
 
 	executeFailedGen, err := derive.InitProcessExecuteFailedGenerator()
 	if err != nil {
@@ -683,12 +1050,93 @@ func (t *Tracee) initDerivationTable() error {
 				Enabled:        shouldSubmit(events.SymbolsCollision),
 				DeriveFunction: symbolsCollisions,
 			},
+			events.RuntimeSBOM: {
+				Enabled:        shouldSubmit(events.RuntimeSBOM),
+				DeriveFunction: runtimeSBOM,
+			},
+			events.VulnerableLibraryLoaded: {
+				Enabled:        shouldSubmit(events.VulnerableLibraryLoaded),
+				DeriveFunction: derive.VulnerableLibraryLoaded(t.vulnDB),
+			},
 		},
 		events.SchedProcessExec: {
 			events.SymbolsCollision: {
 				Enabled:        shouldSubmit(events.SymbolsCollision),
 				DeriveFunction: symbolsCollisions,
 			},
+			events.IOCMatch: {
+				Enabled:        shouldSubmit(events.IOCMatch),
+				DeriveFunction: iocMatch,
+			},
+			events.K8sAuditCorrelation: {
+				Enabled:        shouldSubmit(events.K8sAuditCorrelation),
+				DeriveFunction: k8sAuditCorrelation,
+			},
+			events.LinkerHijack: {
+				Enabled:        shouldSubmit(events.LinkerHijack),
+				DeriveFunction: linkerHijack,
+			},
+			events.RuntimeSBOM: {
+				Enabled:        shouldSubmit(events.RuntimeSBOM),
+				DeriveFunction: runtimeSBOM,
+			},
+		},
+		events.SecurityFileOpen: {
+			events.LinkerHijack: {
+				Enabled:        shouldSubmit(events.LinkerHijack),
+				DeriveFunction: linkerHijack,
+			},
+			events.PersistenceModification: {
+				Enabled:        shouldSubmit(events.PersistenceModification),
+				DeriveFunction: persistenceModification,
+			},
+			events.SysctlModification: {
+				Enabled:        shouldSubmit(events.SysctlModification),
+				DeriveFunction: derive.SysctlModification(t.contPathResolver),
+			},
+			events.SensitiveFileAccess: {
+				Enabled: shouldSubmit(events.SensitiveFileAccess),
+				DeriveFunction: derive.SensitiveFileAccess(
+					t.config.SensitiveFiles,
+					t.processTree,
+				),
+			},
+			events.ContainerCorePatternWrite: {
+				Enabled:        shouldSubmit(events.ContainerCorePatternWrite),
+				DeriveFunction: derive.ContainerCorePatternWrite(t.contPathResolver),
+			},
+			events.ContainerProcSysKernelWrite: {
+				Enabled:        shouldSubmit(events.ContainerProcSysKernelWrite),
+				DeriveFunction: derive.ContainerProcSysKernelWrite(t.contPathResolver),
+			},
+			events.ContainerNotifyOnReleaseWrite: {
+				Enabled:        shouldSubmit(events.ContainerNotifyOnReleaseWrite),
+				DeriveFunction: derive.ContainerNotifyOnReleaseWrite(),
+			},
+			events.ContainerReleaseAgentWrite: {
+				Enabled:        shouldSubmit(events.ContainerReleaseAgentWrite),
+				DeriveFunction: containerReleaseAgentWrite,
+			},
+		},
+		events.SecurityInodeRename: {
+			events.LinkerHijack: {
+				Enabled:        shouldSubmit(events.LinkerHijack),
+				DeriveFunction: linkerHijack,
+			},
+			events.PersistenceModification: {
+				Enabled:        shouldSubmit(events.PersistenceModification),
+				DeriveFunction: persistenceModification,
+			},
+			events.ContainerReleaseAgentWrite: {
+				Enabled:        shouldSubmit(events.ContainerReleaseAgentWrite),
+				DeriveFunction: containerReleaseAgentWrite,
+			},
+		},
+		events.SecurityInodeMknod: {
+			events.ContainerDeviceMknod: {
+				Enabled:        shouldSubmit(events.ContainerDeviceMknod),
+				DeriveFunction: derive.ContainerDeviceMknod(),
+			},
 		},
 		events.SecuritySocketConnect: {
 			events.NetTCPConnect: {
@@ -697,6 +1145,44 @@ func (t *Tracee) initDerivationTable() error {
 					t.dnsCache,
 				),
 			},
+			events.IOCMatch: {
+				Enabled:        shouldSubmit(events.IOCMatch),
+				DeriveFunction: iocMatch,
+			},
+			events.UnixSocketConnect: {
+				Enabled:        shouldSubmit(events.UnixSocketConnect),
+				DeriveFunction: derive.UnixSocketConnect(),
+			},
+		},
+		events.SecuritySocketListen: {
+			events.UnixSocketListen: {
+				Enabled:        shouldSubmit(events.UnixSocketListen),
+				DeriveFunction: derive.UnixSocketListen(),
+			},
+		},
+		events.Ptrace: {
+			events.ProcessInjection: {
+				Enabled:        shouldSubmit(events.ProcessInjection),
+				DeriveFunction: derive.ProcessInjection(proc.HostPid),
+			},
+		},
+		events.CoreDump: {
+			events.ProcessCrashed: {
+				Enabled:        shouldSubmit(events.ProcessCrashed),
+				DeriveFunction: processCrashed,
+			},
+		},
+		events.SchedProcessExit: {
+			events.ProcessCrashed: {
+				Enabled:        shouldSubmit(events.ProcessCrashed),
+				DeriveFunction: processCrashed,
+			},
+		},
+		events.SecuritySbMount: {
+			events.SensitiveHostMount: {
+				Enabled:        shouldSubmit(events.SensitiveHostMount),
+				DeriveFunction: derive.SensitiveHostMount(),
+			},
 		},
 		events.ExecuteFinished: {
 			events.ProcessExecuteFailed: {
@@ -740,12 +1226,28 @@ func (t *Tracee) initDerivationTable() error {
 				Enabled:        shouldSubmit(events.NetPacketICMP),
 				DeriveFunction: derive.NetPacketICMP(),
 			},
+			events.ICMPEchoFlood: {
+				Enabled:        shouldSubmit(events.ICMPEchoFlood),
+				DeriveFunction: icmpEchoFlood,
+			},
+			events.ICMPLargePayload: {
+				Enabled:        shouldSubmit(events.ICMPLargePayload),
+				DeriveFunction: derive.ICMPLargePayload(),
+			},
 		},
 		events.NetPacketICMPv6Base: {
 			events.NetPacketICMPv6: {
 				Enabled:        shouldSubmit(events.NetPacketICMPv6),
 				DeriveFunction: derive.NetPacketICMPv6(),
 			},
+			events.ICMPEchoFlood: {
+				Enabled:        shouldSubmit(events.ICMPEchoFlood),
+				DeriveFunction: icmpEchoFlood,
+			},
+			events.ICMPLargePayload: {
+				Enabled:        shouldSubmit(events.ICMPLargePayload),
+				DeriveFunction: derive.ICMPLargePayload(),
+			},
 		},
 		events.NetPacketDNSBase: {
 			events.NetPacketDNS: {
@@ -760,6 +1262,10 @@ func (t *Tracee) initDerivationTable() error {
 				Enabled:        shouldSubmit(events.NetPacketDNSResponse),
 				DeriveFunction: derive.NetPacketDNSResponse(),
 			},
+			events.IOCMatch: {
+				Enabled:        shouldSubmit(events.IOCMatch),
+				DeriveFunction: iocMatch,
+			},
 		},
 		events.NetPacketHTTPBase: {
 			events.NetPacketHTTP: {
@@ -774,6 +1280,10 @@ func (t *Tracee) initDerivationTable() error {
 				Enabled:        shouldSubmit(events.NetPacketHTTPResponse),
 				DeriveFunction: derive.NetPacketHTTPResponse(),
 			},
+			events.HTTPRequest: {
+				Enabled:        shouldSubmit(events.HTTPRequest),
+				DeriveFunction: derive.HTTPRequest(),
+			},
 		},
 		//
 		// Network Flow Derivations
@@ -792,6 +1302,26 @@ func (t *Tracee) initDerivationTable() error {
 				),
 			},
 		},
+		events.NetFlowStatsBase: {
+			events.NetFlowSummary: {
+				Enabled: shouldSubmit(events.NetFlowSummary),
+				DeriveFunction: derive.NetFlowSummary(
+					t.dnsCache,
+				),
+			},
+		},
+	}
+
+	// Events not available on the running kernel are derived from their fallback event
+
+	for id, gate := range t.kernelGates {
+		if gate.fallback == events.Undefined {
+			continue
+		}
+		err := t.eventDerivations.Register(gate.fallback, id, shouldSubmit(id), derive.Fallback(id))
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
 	}
 
 	return nil
@@ -814,8 +1344,8 @@ const (
 func (t *Tracee) getOptionsConfig() uint32 {
 	var cOptVal uint32
 
-	if t.config.Output.ExecEnv {
-		cOptVal = cOptVal | optExecEnv
+	if t.config.Output.ExecEnv || t.eventsState[events.LinkerHijack].Submit > 0 {
+		cOptVal = cOptVal | optExecEnv // linker_hijack needs the executions environment
 	}
 	if t.config.Output.StackAddresses {
 		cOptVal = cOptVal | optStackAddresses
@@ -893,6 +1423,39 @@ func (t *Tracee) getUnavKsymsPerEvtID() map[events.ID][]string {
 	return unavSymsPerEvtID
 }
 
+// validateCapabilities cancels the events needing capabilities not permitted (e.g. in the
+// unprivileged mode), with informative error message.
+func (t *Tracee) validateCapabilities(caps *capabilities.Capabilities) {
+	var eventsToCancel []events.ID
+	for id := range t.eventsState {
+		eventsToCancel = append(eventsToCancel, id)
+	}
+
+	for _, id := range eventsToCancel {
+		depsNode, ok := t.eventsDependencies.GetEvent(id)
+		if !ok {
+			continue // already canceled as a dependant of a canceled event
+		}
+		evtCaps := depsNode.GetDependencies().GetCapabilities()
+		missingCaps := append(caps.Missing(evtCaps.GetBase()...), caps.Missing(evtCaps.GetEBPF()...)...)
+		if len(missingCaps) == 0 {
+			continue
+		}
+
+		missing := make([]string, 0, len(missingCaps))
+		for _, c := range missingCaps {
+			missing = append(missing, strings.ToUpper(c.String()))
+		}
+		eventName := events.Core.GetDefinitionByID(id).GetName()
+		logger.Debugw(
+			"Event canceled because of missing capabilities", "missing capabilities", missing, "event", eventName,
+		)
+		t.capabilityReport.cancel(id, fmt.Sprintf("missing capabilities: %s", strings.Join(missing, ", ")))
+		// Cancel the event, it depencies and its dependant events
+		t.eventsDependencies.RemoveEvent(id)
+	}
+}
+
 // validateKallsymsDependencies load all symbols required by events dependencies
 // from the kallsyms file to check for missing symbols. If some symbols are
 // missing, it will cancel their event with informative error message.
@@ -904,6 +1467,9 @@ func (t *Tracee) validateKallsymsDependencies() {
 			"Event canceled because of missing kernel symbol dependency",
 			"missing symbols", missingDepSyms, "event", eventNameToCancel,
 		)
+		t.capabilityReport.cancel(
+			eventToCancel, fmt.Sprintf("missing kernel symbols: %s", strings.Join(missingDepSyms, ", ")),
+		)
 		// Cancel the event, it depencies and its dependant events
 		t.eventsDependencies.RemoveEvent(eventToCancel)
 	}
@@ -949,17 +1515,18 @@ func (t *Tracee) populateBPFMaps() error {
 		}
 	}
 
-	// Initialize the net_packet configuration eBPF map.
-	if pcaps.PcapsEnabled(t.config.Capture.Net) {
+	// Initialize the net_packet and net flow stats configuration eBPF map.
+	if pcaps.PcapsEnabled(t.config.Capture.Net) || t.netFlowStatsEnabled() {
 		bpfNetConfigMap, err := t.bpfModule.GetMap("netconfig_map")
 		if err != nil {
 			return errfmt.WrapError(err)
 		}
 
-		netConfigVal := make([]byte, 8) // u32 capture_options + u32 capture_length
+		netConfigVal := make([]byte, 16) // u32 capture_options + u32 capture_length + u64 flow_stats_interval
 		options := pcaps.GetPcapOptions(t.config.Capture.Net)
 		binary.LittleEndian.PutUint32(netConfigVal[0:4], uint32(options))
 		binary.LittleEndian.PutUint32(netConfigVal[4:8], t.config.Capture.Net.CaptureLength)
+		binary.LittleEndian.PutUint64(netConfigVal[8:16], uint64(t.config.FlowStatsInterval.Nanoseconds()))
 
 		cZero := uint32(0)
 		err = bpfNetConfigMap.Update(unsafe.Pointer(&cZero), unsafe.Pointer(&netConfigVal[0]))
@@ -968,7 +1535,8 @@ func (t *Tracee) populateBPFMaps() error {
 		}
 	}
 
-	// Initialize config and filter maps
+	// Initialize config and filter maps (of the events consumed in userspace)
+	t.setConsumeState()
 	err = t.populateFilterMaps(t.config.Policies, false)
 	if err != nil {
 		return errfmt.WrapError(err)
@@ -1040,6 +1608,34 @@ func (t *Tracee) populateBPFMaps() error {
 	return nil
 }
 
+// UpdatePolicies applies new policies while tracee is running, through a new version of the
+// eBPF filter maps: the events already submitted are matched against the version of the
+// policies they were submitted for. The events selected by the policies must stay the same,
+// as their probes are attached on start, only their filters changing: a restart is needed
+// otherwise. The policies consuming the events in userspace are recomputed for the new
+// version.
+func (t *Tracee) UpdatePolicies(newPolicies *policy.Policies) error {
+	t.policiesUpdateMu.Lock()
+	defer t.policiesUpdateMu.Unlock()
+
+	current, err := policy.Snapshots().GetLast()
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if !current.SameEvents(newPolicies) {
+		return errfmt.Errorf("the policies or the events they select changed, restart tracee to apply them")
+	}
+
+	policy.Snapshots().Store(newPolicies)
+	t.setConsumeState()
+	if err := t.populateFilterMaps(newPolicies, true); err != nil {
+		return errfmt.WrapError(err)
+	}
+	logger.Infow("Policies updated", "version", newPolicies.Version())
+
+	return nil
+}
+
 // populateFilterMaps populates the eBPF maps with the given policies
 func (t *Tracee) populateFilterMaps(newPolicies *policy.Policies, updateProcTree bool) error {
 	polCfg, err := newPolicies.UpdateBPF(
@@ -1086,26 +1682,35 @@ func (t *Tracee) attachProbes() error {
 		}
 	}
 
-	// Attach probes to their respective eBPF programs or cancel events if a required probe is missing.
+	// Attach probes to their respective eBPF programs (or their fallbacks) or cancel events if a
+	// required probe is missing.
 	for probe, evtID := range probesToEvents {
+		probeName := t.probes.GetProbeName(probe.GetHandle())
 		err = t.probes.Attach(probe.GetHandle(), t.cgroups) // attach bpf program to probe
-		if err != nil {
-			for _, evtID := range evtID {
-				evtName := events.Core.GetDefinitionByID(evtID).GetName()
-				if probe.IsRequired() {
-					t.eventsDependencies.RemoveEvent(evtID)
-					logger.Warnw(
-						"Cancelling event and its dependencies because of missing probe",
-						"missing probe", probe.GetHandle(), "event", evtName,
-						"error", err,
-					)
-				} else {
-					logger.Debugw(
-						"Failed to attach non-required probe for event",
-						"event", evtName,
-						"probe", probe.GetHandle(), "error", err,
-					)
-				}
+		if err == nil {
+			if fallback, ok := t.probes.GetFallback(probe.GetHandle()); ok {
+				t.capabilityReport.fallback(probeName, t.probes.GetProbeName(fallback))
+			}
+			continue
+		}
+		reason := fmt.Sprintf("probe %s not attached: %v", probeName, err)
+		for _, evtID := range evtID {
+			evtName := events.Core.GetDefinitionByID(evtID).GetName()
+			if probe.IsRequired() {
+				t.capabilityReport.cancel(evtID, reason)
+				t.eventsDependencies.RemoveEvent(evtID)
+				logger.Warnw(
+					"Cancelling event and its dependencies because of missing probe",
+					"missing probe", probe.GetHandle(), "event", evtName,
+					"error", err,
+				)
+			} else {
+				t.capabilityReport.degrade(evtID, reason)
+				logger.Debugw(
+					"Failed to attach non-required probe for event",
+					"event", evtName,
+					"probe", probe.GetHandle(), "error", err,
+				)
 			}
 		}
 	}
@@ -1132,7 +1737,22 @@ func (t *Tracee) initBPF() error {
 
 	// Initialize probes
 
-	t.probes, err = probes.NewDefaultProbeGroup(t.bpfModule, t.netEnabled(), t.kernelSymbols)
+	t.probes, err = probes.NewDefaultProbeGroup(t.bpfModule, t.netEnabled(), t.netFlowStatsEnabled(), t.kernelSymbols)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Resize the eBPF maps (only possible before loading the object)
+
+	err = t.resizeBPFMaps()
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	// Pin the state eBPF maps, reusing the ones pinned by a previous tracee (only possible
+	// before loading the object, once resized)
+
+	err = t.pinBPFMaps()
 	if err != nil {
 		return errfmt.WrapError(err)
 	}
@@ -1244,6 +1864,10 @@ const pollTimeout int = 300
 
 // Run starts the trace. it will run until ctx is cancelled
 func (t *Tracee) Run(ctx gocontext.Context) error {
+	if t.receiver != nil {
+		return t.runProcessor(ctx)
+	}
+
 	// Some events need initialization before the perf buffers are polled
 
 	go t.hookedSyscallTableRoutine(ctx)
@@ -1260,6 +1884,59 @@ func (t *Tracee) Run(ctx gocontext.Context) error {
 	t.controlPlane.Start()
 	go t.controlPlane.Run(ctx)
 
+	// Refresh threat intel feeds
+	if t.threatIntel != nil {
+		go t.threatIntel.Run(ctx)
+	}
+
+	// Scan the captured artifacts
+	if t.yaraScanner != nil {
+		go t.yaraScanner.Run(ctx)
+	}
+
+	// Index the captured artifacts, collecting them according to the retention policy
+	if t.artifactsIndex != nil {
+		go t.artifactsIndex.Run(ctx)
+	}
+
+	// Correlate the Kubernetes audit logs
+	if t.k8sAudit != nil {
+		go t.k8sAudit.Run(ctx)
+	}
+
+	// Stream the containers runtimes lifecycle events
+	go t.runContainerLifecycle(ctx)
+
+	// Monitor the containers cgroups resource pressure
+	if t.cgroupPressure != nil {
+		go t.cgroupPressure.Run(ctx)
+	}
+
+	// Collect the containers runtime SBOM
+	if t.runtimeSBOM != nil {
+		go t.runtimeSBOM.Run(ctx)
+	}
+
+	// Monitor the eBPF programs statistics
+	if t.progStats != nil {
+		go t.progStats.Run(ctx)
+	}
+
+	// Shed load while exceeding the self-limits
+	if t.selfLimiter != nil {
+		go t.selfLimiter.Run(ctx)
+	}
+
+	// Monitor the eBPF maps fill levels
+	if t.stats.MapPressure != nil {
+		go t.monitorBPFMaps(ctx)
+	}
+
+	// Tune the events perf buffer size from its loss rate
+	if t.stats.PerfBufferTuning != nil {
+		go t.tunePerfBuffer(ctx)
+	}
+
 	// Main event loop (polling events perf buffer)
 
 	t.eventsPerfMap.Poll(pollTimeout)
@@ -1290,6 +1967,7 @@ func (t *Tracee) Run(ctx gocontext.Context) error {
 	// Management
 
 	<-pipelineReady
+	t.startHandoff(ctx)   // the events are traced, the running tracee hands the output over
 	t.running.Store(true) // set running state after writing pid file
 	t.ready(ctx)          // executes ready callback, non blocking
 	<-ctx.Done()          // block until ctx is cancelled elsewhere
@@ -1376,6 +2054,11 @@ func (t *Tracee) Close() {
 			logger.Errorw("failed to detach probes when closing tracee", "err", err)
 		}
 	}
+	if t.progStatsEnabled != nil {
+		if err := t.progStatsEnabled.Close(); err != nil {
+			logger.Errorw("failed to disable the eBPF programs statistics when closing tracee", "err", err)
+		}
+	}
 	if t.bpfModule != nil {
 		t.bpfModule.Close()
 	}
@@ -1385,8 +2068,10 @@ func (t *Tracee) Close() {
 			logger.Errorw("failed to clean containers module when closing tracee", "err", err)
 		}
 	}
-	if err := t.cgroups.Destroy(); err != nil {
-		logger.Errorw("Cgroups destroy", "error", err)
+	if t.cgroups != nil {
+		if err := t.cgroups.Destroy(); err != nil {
+			logger.Errorw("Cgroups destroy", "error", err)
+		}
 	}
 
 	// set 'running' to false and close 'done' channel only after attempting to close all resources
@@ -1444,7 +2129,7 @@ func (t *Tracee) getSelfLoadedPrograms(kprobesOnly bool) map[string]int {
 
 		definition := events.Core.GetDefinitionByID(tr)
 
-		for _, depProbes := range definition.GetDependencies().GetProbes() {
+		for _, depProbes := range t.getEventDependencies(tr).GetProbes() {
 			currProbe := t.probes.GetProbeByHandle(depProbes.GetHandle())
 			name := ""
 			switch p := currProbe.(type) {
@@ -1499,6 +2184,7 @@ func (t *Tracee) invokeInitEvents(out chan *trace.Event) {
 		event.MatchedPoliciesKernel = matchedPolicies
 		event.MatchedPoliciesUser = matchedPolicies
 		event.MatchedPolicies = pols.MatchedNames(matchedPolicies)
+		event.Labels = t.config.Labels
 	}
 
 	policiesMatch := func(state events.EventState) uint64 {
@@ -1528,6 +2214,30 @@ func (t *Tracee) invokeInitEvents(out chan *trace.Event) {
 		}
 	}
 
+	// Signature plugins load audit events (1 event per plugin, loaded or refused)
+
+	matchedPolicies = policiesMatch(t.eventsState[events.SignaturePluginLoad])
+	if matchedPolicies > 0 {
+		for _, audit := range t.config.SignaturePlugins {
+			event := events.SignaturePluginLoadEvent(
+				audit.Path, audit.SHA256, audit.Signed, audit.Verified, audit.Loaded, audit.Reason,
+			)
+			setMatchedPolicies(&event, matchedPolicies, t.config.Policies)
+			out <- &event
+			_ = t.stats.EventCount.Increment()
+		}
+	}
+
+	// Capability report event (events disabled or degraded by the running kernel)
+
+	matchedPolicies = policiesMatch(t.eventsState[events.CapabilityReport])
+	if matchedPolicies > 0 {
+		event := t.capabilityReport.event(t.eventsState)
+		setMatchedPolicies(&event, matchedPolicies, t.config.Policies)
+		out <- &event
+		_ = t.stats.EventCount.Increment()
+	}
+
 	// Ftrace hook event
 
 	matchedPolicies = policiesMatch(t.eventsState[events.FtraceHook])
@@ -1560,6 +2270,12 @@ func (t *Tracee) netEnabled() bool {
 	return pcaps.PcapsEnabled(t.config.Capture.Net)
 }
 
+// netFlowStatsEnabled returns true if the network flow stats are to be accounted
+func (t *Tracee) netFlowStatsEnabled() bool {
+	_, ok := t.eventsState[events.NetFlowStatsBase]
+	return ok
+}
+
 //
 // TODO: move to triggerEvents package
 //
@@ -0,0 +1,66 @@
+package ebpf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/dependencies"
+	"github.com/aquasecurity/tracee/pkg/events/derive"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestSetConsumeState(t *testing.T) {
+	t.Parallel()
+
+	const (
+		selected   = events.ID(1) // emitted by policy 1
+		kernelDep  = events.ID(2) // only needed by selected in the kernel
+		derivedDep = events.ID(3) // selected is derived from it
+		signature  = events.ID(4) // emitted by policy 2
+		sigDep     = events.ID(5) // signature input
+		processed  = events.ID(6) // only needed by selected in the kernel, but processed
+		consumed   = events.ID(7) // only needed by selected in the kernel, but read by a consumer
+	)
+	deps := map[events.ID]events.Dependencies{
+		selected:  events.NewDependencies([]events.ID{kernelDep, derivedDep, processed, consumed}, nil, nil, nil, events.Capabilities{}),
+		signature: events.NewDependencies([]events.ID{sigDep}, nil, nil, nil, events.Capabilities{}),
+	}
+
+	tr := &Tracee{
+		eventsState:      make(map[events.ID]events.EventState),
+		eventSignatures:  make(map[events.ID]bool),
+		eventDerivations: derive.Table{},
+		eventsDependencies: dependencies.NewDependenciesManager(func(id events.ID) events.Dependencies {
+			return deps[id]
+		}),
+	}
+	tr.chooseEvent(selected, events.EventState{Submit: 1, Emit: 1})
+	tr.chooseEvent(signature, events.EventState{Submit: 2, Emit: 2})
+	tr.eventSignatures[signature] = true
+	require.NoError(t, tr.eventDerivations.Register(derivedDep, selected, func() bool { return true }, nil))
+	tr.RegisterEventProcessor(processed, func(*trace.Event) error { return nil })
+	tr.RegisterEventConsumer(consumed)
+
+	tr.setConsumeState()
+
+	expected := map[events.ID]uint64{
+		selected:   1,
+		kernelDep:  0,
+		derivedDep: 1,
+		signature:  2,
+		sigDep:     2,
+		processed:  1,
+		consumed:   1,
+	}
+	for id, consume := range expected {
+		assert.Equal(t, consume, tr.eventsState[id].Consume, "event %d", id)
+	}
+
+	// a consumer of any event, registered later on, is accounted for once recomputed
+	tr.RegisterEventConsumer(events.All)
+	tr.setConsumeState()
+	assert.Equal(t, uint64(1), tr.eventsState[kernelDep].Consume)
+}
@@ -0,0 +1,133 @@
+package ebpf
+
+import (
+	"path/filepath"
+
+	"github.com/aquasecurity/tracee/pkg/artifacts"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/policy"
+	"github.com/aquasecurity/tracee/pkg/yara"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// initYara loads the YARA rules, scanning the captured artifacts.
+func (t *Tracee) initYara() error {
+	if len(t.config.Yara.Rules) == 0 {
+		return nil
+	}
+
+	rules, err := yara.Load(t.config.Yara.Rules)
+	if err != nil {
+		return errfmt.Errorf("error loading yara rules: %v", err)
+	}
+
+	capture := t.config.Capture
+	if !capture.FileWrite.Capture && !capture.FileRead.Capture && !capture.Exec &&
+		!capture.Mem && !capture.Module && !capture.Bpf {
+		logger.Warnw("YARA rules loaded, but no artifacts are captured (see --capture)")
+	}
+
+	t.yaraScanner = yara.NewScanner(rules, t.config.Yara)
+	logger.Debugw("YARA rules loaded", "rules", rules.Len())
+
+	return nil
+}
+
+// captureEvents are the capture events the artifacts originate from, by capture type.
+var captureEvents = map[string]events.ID{
+	"write":  events.CaptureFileWrite,
+	"read":   events.CaptureFileRead,
+	"exec":   events.CaptureExec,
+	"mem":    events.CaptureMem,
+	"module": events.CaptureModule,
+	"bpf":    events.CaptureBpf,
+}
+
+// submitArtifact schedules the indexing and the YARA scan of a captured artifact, given its
+// path in the capture output directory.
+func (t *Tracee) submitArtifact(path string, artifactType string, pid int, containerID string) {
+	if containerID == "host" {
+		containerID = ""
+	}
+
+	if t.artifactsIndex != nil {
+		artifact := artifacts.Artifact{
+			Path:        path,
+			Type:        artifactType,
+			PID:         pid,
+			ContainerID: containerID,
+		}
+		if id, ok := captureEvents[artifactType]; ok {
+			artifact.Event = events.Core.GetDefinitionByID(id).GetName()
+		}
+		t.artifactsIndex.Submit(artifact)
+	}
+
+	if t.yaraScanner == nil {
+		return
+	}
+
+	t.yaraScanner.Submit(yara.Artifact{
+		Path:        filepath.Join(t.config.Capture.OutputPath, path),
+		Type:        artifactType,
+		PID:         pid,
+		ContainerID: containerID,
+	})
+}
+
+// yaraResults returns the channel of the artifacts matching YARA rules (nil if scanning is
+// disabled).
+func (t *Tracee) yaraResults() <-chan yara.Result {
+	if t.yaraScanner == nil {
+		return nil
+	}
+
+	return t.yaraScanner.Results()
+}
+
+// yaraMatchEvents returns the yara_match events of a scan result, one per matching rule.
+// They are matched to the policies selecting the yara_match event.
+func (t *Tracee) yaraMatchEvents(result yara.Result) []trace.Event {
+	policies, err := policy.Snapshots().GetLast()
+	if err != nil {
+		t.handleError(err)
+		return nil
+	}
+	def := events.Core.GetDefinitionByID(events.YaraMatch)
+	params := def.GetParams()
+	timestamp := int(t.normalizeSnapshotTime(uint64(result.Time.UnixNano()) - t.bootTime))
+
+	evts := make([]trace.Event, 0, len(result.Matches))
+	for _, match := range result.Matches {
+		values := []interface{}{
+			match.Rule,
+			match.Tags,
+			match.Strings,
+			match.Meta,
+			result.Path,
+			result.Type,
+		}
+		args := make([]trace.Argument, len(params))
+		for i := range params {
+			args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+		}
+
+		evts = append(evts, trace.Event{
+			Timestamp:             timestamp,
+			HostProcessID:         result.PID,
+			ContainerID:           result.ContainerID,
+			Container:             trace.Container{ID: result.ContainerID},
+			Labels:                t.config.Labels,
+			EventID:               int(events.YaraMatch),
+			EventName:             def.GetName(),
+			PoliciesVersion:       policies.Version(),
+			MatchedPoliciesKernel: t.eventsState[events.YaraMatch].Submit,
+			ArgsNum:               len(args),
+			Args:                  args,
+		})
+	}
+
+	return evts
+}
@@ -0,0 +1,131 @@
+// Package chunks reassembles the arguments too big to fit in their event, streamed by the eBPF
+// code in follow-up chunks (arg_chunk events), submitted before the event itself.
+package chunks
+
+import (
+	"strings"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// DefaultMaxPending is the default number of events whose chunks are pending at once: the chunks
+// of events that are lost or filtered out are evicted.
+const DefaultMaxPending = 1024
+
+// Key identifies an event: the events of a thread are sequenced by their timestamp.
+type Key struct {
+	Timestamp uint64
+	HostTid   uint32
+}
+
+// Chunk is a part of an argument of an event.
+type Chunk struct {
+	EventID   int32
+	ArgIndex  uint8
+	Offset    uint32
+	TotalSize uint32
+	Data      []byte
+}
+
+type pendingArg struct {
+	data     []byte
+	received uint32
+}
+
+type pendingEvent struct {
+	eventID int32
+	args    map[uint8]*pendingArg
+}
+
+// Reassembler reassembles the arguments of the events from their chunks. It isn't thread-safe:
+// it is fed by a single events pipeline stage.
+type Reassembler struct {
+	pending *simplelru.LRU[Key, *pendingEvent]
+}
+
+// NewReassembler creates a reassembler keeping the chunks of the given number of events at most.
+func NewReassembler(maxPending int) (*Reassembler, error) {
+	if maxPending <= 0 {
+		return nil, errfmt.Errorf("invalid arg chunks max pending events: %d", maxPending)
+	}
+	pending, err := simplelru.NewLRU[Key, *pendingEvent](maxPending, nil)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Reassembler{pending: pending}, nil
+}
+
+// Add adds a chunk of an argument of an event. Malformed chunks are ignored.
+func (r *Reassembler) Add(key Key, chunk Chunk) {
+	end := uint64(chunk.Offset) + uint64(len(chunk.Data))
+	if len(chunk.Data) == 0 || end > uint64(chunk.TotalSize) {
+		return
+	}
+
+	event, ok := r.pending.Get(key)
+	if !ok || event.eventID != chunk.EventID {
+		event = &pendingEvent{eventID: chunk.EventID, args: make(map[uint8]*pendingArg)}
+		r.pending.Add(key, event)
+	}
+	arg, ok := event.args[chunk.ArgIndex]
+	if !ok || uint32(len(arg.data)) != chunk.TotalSize {
+		arg = &pendingArg{data: make([]byte, chunk.TotalSize)}
+		event.args[chunk.ArgIndex] = arg
+	}
+
+	copy(arg.data[chunk.Offset:end], chunk.Data)
+	arg.received += uint32(len(chunk.Data))
+}
+
+// Take returns the reassembled arguments of an event, by index, and forgets its chunks. The
+// arguments missing chunks (e.g. lost) aren't returned. The chunks of the events that aren't
+// taken (e.g. filtered out) are eventually evicted.
+func (r *Reassembler) Take(key Key, eventID int32) map[uint8][]byte {
+	if r.pending.Len() == 0 {
+		return nil
+	}
+	event, ok := r.pending.Peek(key)
+	if !ok {
+		return nil
+	}
+	r.pending.Remove(key)
+	if event.eventID != eventID {
+		return nil
+	}
+
+	var args map[uint8][]byte
+	for index, arg := range event.args {
+		if arg.received != uint32(len(arg.data)) {
+			continue
+		}
+		if args == nil {
+			args = make(map[uint8][]byte)
+		}
+		args[index] = arg.data
+	}
+
+	return args
+}
+
+// Value returns the value of an argument of the given type from its reassembled data: the
+// buffers are given as is, the strings are null terminated, and the string arrays are null
+// delimited. It returns false for the other types, which aren't streamed in chunks.
+func Value(argType string, data []byte) (interface{}, bool) {
+	switch argType {
+	case "bytes":
+		return data, true
+	case "const char*":
+		return strings.TrimRight(string(data), "\x00"), true
+	case "const char**":
+		ss := strings.Split(string(data), "\x00")
+		if ss[len(ss)-1] == "" {
+			ss = ss[:len(ss)-1]
+		}
+		return ss, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,72 @@
+package chunks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReassembler(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewReassembler(DefaultMaxPending)
+	require.NoError(t, err)
+
+	key := Key{Timestamp: 1000, HostTid: 42}
+	other := Key{Timestamp: 1000, HostTid: 43}
+
+	// chunks of the argument 10 of event 5, and an incomplete argument 15
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 10, Offset: 0, TotalSize: 10, Data: []byte("ls\x00-l")})
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 10, Offset: 5, TotalSize: 10, Data: []byte("a\x00/t\x00")})
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 15, Offset: 0, TotalSize: 8, Data: []byte("A=1\x00")})
+	// chunk exceeding its argument (ignored)
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 11, Offset: 4, TotalSize: 6, Data: []byte("abc")})
+	// chunk of another thread
+	r.Add(other, Chunk{EventID: 5, ArgIndex: 10, Offset: 0, TotalSize: 2, Data: []byte("ps")})
+
+	assert.Nil(t, r.Take(key, 6), "chunks of another event")
+	assert.Nil(t, r.Take(key, 5), "chunks taken once only")
+
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 10, Offset: 0, TotalSize: 10, Data: []byte("ls\x00-l")})
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 10, Offset: 5, TotalSize: 10, Data: []byte("a\x00/t\x00")})
+	r.Add(key, Chunk{EventID: 5, ArgIndex: 15, Offset: 0, TotalSize: 8, Data: []byte("A=1\x00")})
+	assert.Equal(t, map[uint8][]byte{10: []byte("ls\x00-la\x00/t\x00")}, r.Take(key, 5))
+	assert.Equal(t, map[uint8][]byte{10: []byte("ps")}, r.Take(other, 5))
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		argType  string
+		data     []byte
+		expected interface{}
+		ok       bool
+	}{
+		{name: "bytes", argType: "bytes", data: []byte{1, 2, 3}, expected: []byte{1, 2, 3}, ok: true},
+		{name: "string", argType: "const char*", data: []byte("/bin/ls\x00"), expected: "/bin/ls", ok: true},
+		{name: "string array", argType: "const char**", data: []byte("ls\x00-la\x00/t\x00"), expected: []string{"ls", "-la", "/t"}, ok: true},
+		{name: "unsupported", argType: "int", data: []byte{1, 0, 0, 0}, ok: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, ok := Value(tc.argType, tc.data)
+			require.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestNewReassembler(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewReassembler(0)
+	assert.ErrorContains(t, err, "invalid arg chunks max pending events: 0")
+}
@@ -109,6 +109,12 @@ const (
 	ModuleFree
 	ExecuteFinished
 	SecurityBprmCredsForExec
+	NetFlowStatsBase
+	GratuitousARP
+	CoreDump
+	OomKill
+	CgroupKill
+	ArgChunk
 	MaxCommonID
 )
 
@@ -129,6 +135,10 @@ const (
 	NetFlowEnd
 	NetFlowTCPBegin
 	NetFlowTCPEnd
+	NetFlowSummary
+	HTTPRequest
+	ICMPEchoFlood
+	ICMPLargePayload
 	MaxUserNetID
 	NetTCPConnect
 	InitNamespaces
@@ -141,6 +151,40 @@ const (
 	SymbolsCollision
 	HiddenKernelModule
 	FtraceHook
+	SnapshotProcess
+	SnapshotSocket
+	SnapshotKernelModule
+	SnapshotNamespace
+	IOCMatch
+	YaraMatch
+	SignaturePluginLoad
+	K8sAuditCorrelation
+	ContainerStart
+	ContainerStop
+	ContainerOOM
+	CgroupMemoryPressure
+	CgroupCPUPressure
+	CgroupIOPressure
+	UnixSocketConnect
+	UnixSocketListen
+	SensitiveHostMount
+	ProcessInjection
+	LinkerHijack
+	PersistenceModification
+	SysctlModification
+	SensitiveFileAccess
+	ContainerCorePatternWrite
+	ContainerProcSysKernelWrite
+	ContainerNotifyOnReleaseWrite
+	ContainerReleaseAgentWrite
+	ContainerDeviceMknod
+	RuntimeSBOM
+	VulnerableLibraryLoaded
+	ProcessCrashed
+	CapabilityReport
+	PolicyQuotaExceeded
+	BPFProgStats
+	LoadShedding
 	MaxUserSpace
 )
 
@@ -11271,6 +11315,88 @@ var CoreEvents = map[ID]Definition{
 		sets:   []string{"proc", "proc_life"},
 		params: []trace.ArgMeta{},
 	},
+	CoreDump: {
+		id:       CoreDump,
+		id32Bit:  Sys32Undefined,
+		name:     "core_dump",
+		version:  NewVersion(1, 0, 0),
+		sets:     []string{"proc"},
+		internal: true,
+		dependencies: Dependencies{
+			probes: []Probe{
+				{handle: probes.DoCoredump, required: false}, // vfs_coredump since 6.16
+			},
+			kSymbols: []KSymbol{
+				{symbol: "core_pattern", required: false},
+			},
+		},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "signal"},
+			{Type: "int", Name: "code"},
+			{Type: "void*", Name: "fault_address"},
+			{Type: "const char*", Name: "core_pattern"},
+		},
+	},
+	OomKill: {
+		id:      OomKill,
+		id32Bit: Sys32Undefined,
+		name:    "oom_kill",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			probes: []Probe{
+				{handle: probes.OomKillProcess, required: true},
+				{handle: probes.ExitOomVictim, required: true},
+			},
+		},
+		sets: []string{"proc", "containers"},
+		params: []trace.ArgMeta{
+			{Type: "u64", Name: "memcg_id"},
+			{Type: "const char*", Name: "memcg"},
+			{Type: "int", Name: "constraint"},
+			{Type: "unsigned long", Name: "totalpages"},
+			{Type: "long", Name: "points"},
+			{Type: "int", Name: "killer_pid"},
+			{Type: "const char*", Name: "killer_comm"},
+			{Type: "int", Name: "killer_ns_pid"},
+		},
+	},
+	CgroupKill: {
+		id:      CgroupKill,
+		id32Bit: Sys32Undefined,
+		name:    "cgroup_kill",
+		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min: "5.14", // cgroup.kill
+		},
+		dependencies: Dependencies{
+			probes: []Probe{
+				{handle: probes.CgroupKillWrite, required: true},
+			},
+		},
+		sets: []string{"proc", "containers"},
+		params: []trace.ArgMeta{
+			{Type: "u64", Name: "cgroup_id"},
+			{Type: "const char*", Name: "cgroup_path"},
+			{Type: "const char*", Name: "container_id"},
+			{Type: "const char*", Name: "container_name"},
+			{Type: "const char*", Name: "pod_name"},
+			{Type: "const char*", Name: "pod_namespace"},
+		},
+	},
+	ArgChunk: {
+		id:       ArgChunk,
+		id32Bit:  Sys32Undefined,
+		name:     "arg_chunk",
+		version:  NewVersion(1, 0, 0),
+		internal: true,
+		params: []trace.ArgMeta{
+			{Type: "unsigned int", Name: "event_id"},
+			{Type: "u8", Name: "arg_index"},
+			{Type: "unsigned int", Name: "offset"},
+			{Type: "unsigned int", Name: "total_size"},
+			{Type: "bytes", Name: "data"},
+		},
+	},
 	CapCapable: {
 		id:      CapCapable,
 		id32Bit: Sys32Undefined,
@@ -11626,6 +11752,42 @@ var CoreEvents = map[ID]Definition{
 			{Type: "const char **", Name: "dst_dns"},
 		},
 	},
+	UnixSocketConnect: {
+		id:      UnixSocketConnect,
+		id32Bit: Sys32Undefined,
+		name:    "unix_socket_connect",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecuritySocketConnect,
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "sockfd"},
+			{Type: "int", Name: "type"},
+			{Type: "const char*", Name: "path"},
+			{Type: "bool", Name: "abstract"},
+		},
+	},
+	UnixSocketListen: {
+		id:      UnixSocketListen,
+		id32Bit: Sys32Undefined,
+		name:    "unix_socket_listen",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecuritySocketListen,
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "sockfd"},
+			{Type: "const char*", Name: "path"},
+			{Type: "bool", Name: "abstract"},
+			{Type: "int", Name: "backlog"},
+		},
+	},
 	SecuritySocketAccept: {
 		id:      SecuritySocketAccept,
 		id32Bit: Sys32Undefined,
@@ -11708,6 +11870,24 @@ var CoreEvents = map[ID]Definition{
 			{Type: "unsigned long", Name: "flags"},
 		},
 	},
+	SensitiveHostMount: {
+		id:      SensitiveHostMount,
+		id32Bit: Sys32Undefined,
+		name:    "sensitive_host_mount",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecuritySbMount,
+			},
+		},
+		sets: []string{"fs", "containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "source"},
+			{Type: "const char*", Name: "target"},
+			{Type: "const char*", Name: "container_path"},
+			{Type: "unsigned long", Name: "flags"},
+		},
+	},
 	SecurityBPF: {
 		id:      SecurityBPF,
 		id32Bit: Sys32Undefined,
@@ -12791,8 +12971,7 @@ var CoreEvents = map[ID]Definition{
 		version: NewVersion(1, 0, 0),
 		dependencies: Dependencies{
 			probes: []Probe{
-				{handle: probes.VfsUtimes, required: false},    // this probe exits in kernels >= 5.9
-				{handle: probes.UtimesCommon, required: false}, // this probe exits in kernels < 5.9
+				{handle: probes.VfsUtimes, required: true}, // utimes_common in kernels < 5.9
 			},
 		},
 		sets: []string{},
@@ -12898,14 +13077,11 @@ var CoreEvents = map[ID]Definition{
 		dependencies: Dependencies{
 			probes: []Probe{
 				// TODO: Change all of these probes to tracepoints (requires debugfs)
+				// the arm64 probes are their fallbacks
 				{handle: probes.ExecuteFinishedX86, required: false},
 				{handle: probes.ExecuteAtFinishedX86, required: false},
 				{handle: probes.ExecuteFinishedCompatX86, required: false},
 				{handle: probes.ExecuteAtFinishedCompatX86, required: false},
-				{handle: probes.ExecuteFinishedARM, required: false},
-				{handle: probes.ExecuteAtFinishedARM, required: false},
-				{handle: probes.ExecuteFinishedCompatARM, required: false},
-				{handle: probes.ExecuteAtFinishedCompatARM, required: false},
 			},
 		},
 	},
@@ -12940,6 +13116,58 @@ var CoreEvents = map[ID]Definition{
 			{Type: "const char*const*", Name: "environment"},
 		},
 	},
+	NetFlowStatsBase: {
+		id:       NetFlowStatsBase,
+		id32Bit:  Sys32Undefined,
+		name:     "net_flow_stats_base",
+		version:  NewVersion(1, 0, 0),
+		internal: true,
+		dependencies: Dependencies{
+			capabilities: Capabilities{
+				ebpf: []cap.Value{
+					cap.NET_ADMIN, // needed for BPF_PROG_TYPE_SOCK_OPS
+				},
+			},
+			probes: []Probe{
+				{handle: probes.SockOpsNetFlow, required: true},
+				{handle: probes.TCPConnect, required: true},
+				{handle: probes.InetCskAcceptRet, required: true},
+			},
+		},
+		params: []trace.ArgMeta{
+			{Type: "u8", Name: "direction"},
+			{Type: "unsigned int", Name: "family"},
+			{Type: "bytes", Name: "local_addr"},
+			{Type: "bytes", Name: "remote_addr"},
+			{Type: "unsigned int", Name: "local_port"},
+			{Type: "unsigned int", Name: "remote_port"},
+			{Type: "u64", Name: "duration"},
+			{Type: "u64", Name: "bytes_in"},
+			{Type: "u64", Name: "bytes_out"},
+			{Type: "u64", Name: "packets_in"},
+			{Type: "u64", Name: "packets_out"},
+			{Type: "bool", Name: "closed"},
+		},
+	},
+	GratuitousARP: {
+		id:      GratuitousARP,
+		id32Bit: Sys32Undefined,
+		name:    "gratuitous_arp",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			probes: []Probe{
+				{handle: probes.NetDevQueue, required: true},
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "interface"},
+			{Type: "u16", Name: "operation"},
+			{Type: "bytes", Name: "sender_mac"},
+			{Type: "bytes", Name: "sender_ip"},
+			{Type: "bytes", Name: "target_mac"},
+		},
+	},
 	ProcessExecuteFailed: {
 		id:      ProcessExecuteFailed,
 		id32Bit: Sys32Undefined,
@@ -12992,11 +13220,553 @@ var CoreEvents = map[ID]Definition{
 			{Type: "unsigned long", Name: "count"},
 		},
 	},
+	SnapshotProcess: {
+		id:      SnapshotProcess,
+		id32Bit: Sys32Undefined,
+		name:    "snapshot_process",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"snapshot"},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "pid"},
+			{Type: "int", Name: "ppid"},
+			{Type: "int", Name: "uid"},
+			{Type: "const char*", Name: "comm"},
+			{Type: "const char*", Name: "exe"},
+			{Type: "const char*", Name: "cmdline"},
+			{Type: "unsigned long", Name: "start_time"},
+			{Type: "int", Name: "threads"},
+		},
+	},
+	SnapshotSocket: {
+		id:      SnapshotSocket,
+		id32Bit: Sys32Undefined,
+		name:    "snapshot_socket",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"snapshot"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "protocol"},
+			{Type: "const char*", Name: "local_addr"},
+			{Type: "u16", Name: "local_port"},
+			{Type: "const char*", Name: "remote_addr"},
+			{Type: "u16", Name: "remote_port"},
+			{Type: "const char*", Name: "state"},
+			{Type: "unsigned long", Name: "inode"},
+			{Type: "int", Name: "pid"},
+		},
+	},
+	SnapshotKernelModule: {
+		id:      SnapshotKernelModule,
+		id32Bit: Sys32Undefined,
+		name:    "snapshot_kernel_module",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"snapshot"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "name"},
+			{Type: "unsigned long", Name: "size"},
+			{Type: "int", Name: "refcount"},
+			{Type: "const char*", Name: "dependencies"},
+			{Type: "const char*", Name: "state"},
+			{Type: "unsigned long", Name: "address"},
+		},
+	},
+	SnapshotNamespace: {
+		id:      SnapshotNamespace,
+		id32Bit: Sys32Undefined,
+		name:    "snapshot_namespace",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"snapshot"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "type"},
+			{Type: "u32", Name: "inode"},
+			{Type: "int", Name: "processes"},
+			{Type: "int", Name: "pid"},
+		},
+	},
+	ProcessInjection: {
+		id:      ProcessInjection,
+		id32Bit: Sys32Undefined,
+		name:    "process_injection",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				Ptrace,
+			},
+		},
+		sets: []string{"proc"},
+		params: []trace.ArgMeta{
+			{Type: "pid_t", Name: "target_pid"},
+			{Type: "pid_t", Name: "target_ns_pid"},
+			{Type: "const char*", Name: "technique"},
+			{Type: "u32", Name: "memory_writes"},
+			{Type: "u32", Name: "registers_writes"},
+			{Type: "void*", Name: "first_write_addr"},
+			{Type: "u64", Name: "duration"},
+		},
+	},
+	LinkerHijack: {
+		id:      LinkerHijack,
+		id32Bit: Sys32Undefined,
+		name:    "linker_hijack",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SchedProcessExec,
+				SecurityFileOpen,
+				SecurityInodeRename,
+			},
+		},
+		sets: []string{"proc"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "method"},
+			{Type: "const char*", Name: "source"},
+			{Type: "const char**", Name: "libraries"},
+		},
+	},
+	PersistenceModification: {
+		id:      PersistenceModification,
+		id32Bit: Sys32Undefined,
+		name:    "persistence_modification",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+				SecurityInodeRename,
+			},
+		},
+		sets: []string{"fs"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "technique"},
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "operation"},
+			{Type: "const char**", Name: "lineage"},
+		},
+	},
+	SysctlModification: {
+		id:      SysctlModification,
+		id32Bit: Sys32Undefined,
+		name:    "sysctl_modification",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+			},
+		},
+		sets: []string{"fs"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "name"},
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "value"},
+			{Type: "bool", Name: "sensitive"},
+		},
+	},
+	SensitiveFileAccess: {
+		id:      SensitiveFileAccess,
+		id32Bit: Sys32Undefined,
+		name:    "sensitive_file_access",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+			},
+		},
+		sets: []string{"fs"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "pattern"},
+			{Type: "const char*", Name: "access"},
+			{Type: "int", Name: "flags"},
+			{Type: "const char**", Name: "lineage"},
+		},
+	},
+	ContainerCorePatternWrite: {
+		id:      ContainerCorePatternWrite,
+		id32Bit: Sys32Undefined,
+		name:    "container_core_pattern_write",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+			},
+		},
+		sets: []string{"containers", "container_escape"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "value"},
+		},
+	},
+	ContainerProcSysKernelWrite: {
+		id:      ContainerProcSysKernelWrite,
+		id32Bit: Sys32Undefined,
+		name:    "container_proc_sys_kernel_write",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+			},
+		},
+		sets: []string{"containers", "container_escape"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "name"},
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "value"},
+		},
+	},
+	ContainerNotifyOnReleaseWrite: {
+		id:      ContainerNotifyOnReleaseWrite,
+		id32Bit: Sys32Undefined,
+		name:    "container_notify_on_release_write",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+			},
+		},
+		sets: []string{"containers", "container_escape"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "pathname"},
+			{Type: "int", Name: "flags"},
+		},
+	},
+	ContainerReleaseAgentWrite: {
+		id:      ContainerReleaseAgentWrite,
+		id32Bit: Sys32Undefined,
+		name:    "container_release_agent_write",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityFileOpen,
+				SecurityInodeRename,
+			},
+		},
+		sets: []string{"containers", "container_escape"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "operation"},
+		},
+	},
+	ContainerDeviceMknod: {
+		id:      ContainerDeviceMknod,
+		id32Bit: Sys32Undefined,
+		name:    "container_device_mknod",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecurityInodeMknod,
+			},
+		},
+		sets: []string{"containers", "container_escape"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "file_name"},
+			{Type: "const char*", Name: "device_type"},
+			{Type: "u32", Name: "major"},
+			{Type: "u32", Name: "minor"},
+		},
+	},
+	RuntimeSBOM: {
+		id:      RuntimeSBOM,
+		id32Bit: Sys32Undefined,
+		name:    "runtime_sbom",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SchedProcessExec,
+				SharedObjectLoaded,
+			},
+		},
+		sets: []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "executables"},
+			{Type: "int", Name: "shared_objects"},
+			{Type: "const char*", Name: "sbom"},
+		},
+	},
+	VulnerableLibraryLoaded: {
+		id:      VulnerableLibraryLoaded,
+		id32Bit: Sys32Undefined,
+		name:    "vulnerable_library_loaded",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SharedObjectLoaded,
+			},
+		},
+		sets: []string{"vulnerabilities"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char*", Name: "library"},
+			{Type: "const char*", Name: "version"},
+			{Type: "const char**", Name: "cve_ids"},
+			{Type: "const char*", Name: "severity"},
+		},
+	},
+	ProcessCrashed: {
+		id:      ProcessCrashed,
+		id32Bit: Sys32Undefined,
+		name:    "process_crashed",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				CoreDump,
+				SchedProcessExit,
+			},
+		},
+		sets: []string{"proc"},
+		params: []trace.ArgMeta{
+			{Type: "int", Name: "signal"},
+			{Type: "int", Name: "code"},
+			{Type: "void*", Name: "fault_address"},
+			{Type: "bool", Name: "core_dumped"},
+			{Type: "const char*", Name: "core_pattern"},
+			{Type: "[]trace.RecentEvent", Name: "recent_events"},
+		},
+	},
+	IOCMatch: {
+		id:      IOCMatch,
+		id32Bit: Sys32Undefined,
+		name:    "ioc_match",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SecuritySocketConnect,
+				NetPacketDNSBase,
+				SchedProcessExec,
+			},
+		},
+		sets: []string{"threat_intel"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "indicator_type"},
+			{Type: "const char*", Name: "value"},
+			{Type: "const char*", Name: "indicator"},
+			{Type: "const char*", Name: "feed"},
+			{Type: "const char*", Name: "feed_type"},
+			{Type: "const char*", Name: "description"},
+			{Type: "const char*", Name: "trigger_event"},
+		},
+	},
+	YaraMatch: {
+		id:      YaraMatch,
+		id32Bit: Sys32Undefined,
+		name:    "yara_match",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "rule"},
+			{Type: "const char*const*", Name: "tags"},
+			{Type: "const char*const*", Name: "strings"},
+			{Type: "map[string]string", Name: "meta"},
+			{Type: "const char*", Name: "artifact"},
+			{Type: "const char*", Name: "artifact_type"},
+		},
+	},
+	SignaturePluginLoad: {
+		id:      SignaturePluginLoad,
+		id32Bit: Sys32Undefined,
+		name:    "signature_plugin_load",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "path"},
+			{Type: "const char*", Name: "sha256"},
+			{Type: "bool", Name: "signed"},
+			{Type: "bool", Name: "verified"},
+			{Type: "bool", Name: "loaded"},
+			{Type: "const char*", Name: "reason"},
+		},
+	},
+	CapabilityReport: {
+		id:      CapabilityReport,
+		id32Bit: Sys32Undefined,
+		name:    "capability_report",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char**", Name: "disabled_events"},
+			{Type: "const char**", Name: "degraded_events"},
+			{Type: "map[string]string", Name: "reasons"},
+			{Type: "map[string]string", Name: "probe_fallbacks"},
+		},
+	},
+	PolicyQuotaExceeded: {
+		id:      PolicyQuotaExceeded,
+		id32Bit: Sys32Undefined,
+		name:    "policy_quota_exceeded",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "policy"},
+			{Type: "const char*", Name: "reason"},
+			{Type: "u64", Name: "limit"},
+			{Type: "u64", Name: "sample_rate"},
+		},
+	},
+	BPFProgStats: {
+		id:      BPFProgStats,
+		id32Bit: Sys32Undefined,
+		name:    "bpf_prog_stats",
+		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min: "5.1", // run time and run count of the programs (bpf_prog_stats)
+		},
+		sets: []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "prog_name"},
+			{Type: "u32", Name: "prog_id"},
+			{Type: "const char*", Name: "prog_type"},
+			{Type: "u64", Name: "interval_usec"},
+			{Type: "u64", Name: "run_count"},
+			{Type: "u64", Name: "run_time_nsec"},
+			{Type: "u64", Name: "avg_run_time_nsec"},
+		},
+	},
+	LoadShedding: {
+		id:      LoadShedding,
+		id32Bit: Sys32Undefined,
+		name:    "load_shedding",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "level"},
+			{Type: "const char*", Name: "previous_level"},
+			{Type: "const char*", Name: "reason"},
+			{Type: "u64", Name: "cpu_millicores"},
+			{Type: "u64", Name: "cpu_limit_millicores"},
+			{Type: "u64", Name: "memory_bytes"},
+			{Type: "u64", Name: "memory_limit_bytes"},
+		},
+	},
+	K8sAuditCorrelation: {
+		id:      K8sAuditCorrelation,
+		id32Bit: Sys32Undefined,
+		name:    "k8s_audit_correlation",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				SchedProcessExec,
+			},
+		},
+		sets: []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "audit_id"},
+			{Type: "const char*", Name: "verb"},
+			{Type: "const char*", Name: "user"},
+			{Type: "const char*", Name: "subresource"},
+			{Type: "const char*", Name: "namespace"},
+			{Type: "const char*", Name: "pod"},
+			{Type: "const char*", Name: "container"},
+			{Type: "const char*const*", Name: "command"},
+			{Type: "const char*const*", Name: "source_ips"},
+			{Type: "const char*", Name: "user_agent"},
+			{Type: "unsigned long", Name: "action_time"},
+			{Type: "const char*", Name: "pathname"},
+			{Type: "const char**", Name: "argv"},
+		},
+	},
+	ContainerStart: {
+		id:      ContainerStart,
+		id32Bit: Sys32Undefined,
+		name:    "container_start",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "runtime"},
+			{Type: "const char*", Name: "container_id"},
+			{Type: "const char*", Name: "container_image"},
+			{Type: "const char*", Name: "container_image_digest"},
+			{Type: "const char*", Name: "container_name"},
+			{Type: "const char*", Name: "pod_name"},
+			{Type: "const char*", Name: "pod_namespace"},
+			{Type: "const char*", Name: "pod_uid"},
+			{Type: "bool", Name: "pod_sandbox"},
+			{Type: "u32", Name: "pid"},
+		},
+	},
+	ContainerStop: {
+		id:      ContainerStop,
+		id32Bit: Sys32Undefined,
+		name:    "container_stop",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "runtime"},
+			{Type: "const char*", Name: "container_id"},
+			{Type: "int", Name: "exit_code"},
+		},
+	},
+	ContainerOOM: {
+		id:      ContainerOOM,
+		id32Bit: Sys32Undefined,
+		name:    "container_oom",
+		version: NewVersion(1, 0, 0),
+		sets:    []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "runtime"},
+			{Type: "const char*", Name: "container_id"},
+		},
+	},
+	CgroupMemoryPressure: {
+		id:      CgroupMemoryPressure,
+		id32Bit: Sys32Undefined,
+		name:    "cgroup_memory_pressure",
+		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min: "4.20", // pressure stall information (PSI)
+		},
+		sets: []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "cgroup_path"},
+			{Type: "u64", Name: "interval_usec"},
+			{Type: "u64", Name: "high"},
+			{Type: "u64", Name: "max"},
+			{Type: "u64", Name: "oom"},
+			{Type: "u64", Name: "oom_kill"},
+			{Type: "u64", Name: "some_stall_usec"},
+			{Type: "u64", Name: "full_stall_usec"},
+		},
+	},
+	CgroupCPUPressure: {
+		id:      CgroupCPUPressure,
+		id32Bit: Sys32Undefined,
+		name:    "cgroup_cpu_pressure",
+		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min: "4.20", // pressure stall information (PSI)
+		},
+		sets: []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "cgroup_path"},
+			{Type: "u64", Name: "interval_usec"},
+			{Type: "u64", Name: "periods"},
+			{Type: "u64", Name: "throttled_periods"},
+			{Type: "u64", Name: "throttled_usec"},
+			{Type: "u64", Name: "some_stall_usec"},
+			{Type: "u64", Name: "full_stall_usec"},
+		},
+	},
+	CgroupIOPressure: {
+		id:      CgroupIOPressure,
+		id32Bit: Sys32Undefined,
+		name:    "cgroup_io_pressure",
+		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min: "4.20", // pressure stall information (PSI)
+		},
+		sets: []string{"containers"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "cgroup_path"},
+			{Type: "u64", Name: "interval_usec"},
+			{Type: "u64", Name: "some_stall_usec"},
+			{Type: "u64", Name: "full_stall_usec"},
+		},
+	},
 	SecurityPathNotify: {
 		id:      SecurityPathNotify,
 		id32Bit: Sys32Undefined,
 		name:    "security_path_notify",
 		version: NewVersion(1, 0, 0),
+		kernelVersion: KernelVersion{
+			min:       "5.3", // security_path_notify LSM hook
+			fallbacks: []ID{InotifyWatch},
+		},
 		dependencies: Dependencies{
 			probes: []Probe{
 				{handle: probes.SecurityPathNotify, required: true},
@@ -13512,6 +14282,72 @@ var CoreEvents = map[ID]Definition{
 			{Type: "trace.ProtoHTTPRequest", Name: "http_request"},
 		},
 	},
+	HTTPRequest: {
+		id:      HTTPRequest,
+		id32Bit: Sys32Undefined,
+		name:    "http_request", // flat arguments, preferred event to match web-layer indicators
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				NetPacketHTTPBase,
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "src"},
+			{Type: "const char*", Name: "dst"},
+			{Type: "u16", Name: "src_port"},
+			{Type: "u16", Name: "dst_port"},
+			{Type: "const char*", Name: "method"},
+			{Type: "const char*", Name: "version"},
+			{Type: "const char*", Name: "host"},
+			{Type: "const char*", Name: "path"},
+			{Type: "const char*", Name: "user_agent"},
+			{Type: "const char**", Name: "headers"},
+			{Type: "bool", Name: "truncated"},
+		},
+	},
+	ICMPEchoFlood: {
+		id:      ICMPEchoFlood,
+		id32Bit: Sys32Undefined,
+		name:    "icmp_echo_flood",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				NetPacketICMPBase,
+				NetPacketICMPv6Base,
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "src"},
+			{Type: "const char*", Name: "dst"},
+			{Type: "u32", Name: "count"},
+			{Type: "u64", Name: "window"},
+		},
+	},
+	ICMPLargePayload: {
+		id:      ICMPLargePayload,
+		id32Bit: Sys32Undefined,
+		name:    "icmp_large_payload",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				NetPacketICMPBase,
+				NetPacketICMPv6Base,
+			},
+		},
+		sets: []string{"network_events"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "src"},
+			{Type: "const char*", Name: "dst"},
+			{Type: "trace.PacketMetadata", Name: "metadata"},
+			{Type: "const char*", Name: "type"},
+			{Type: "u16", Name: "id"},
+			{Type: "u16", Name: "seq"},
+			{Type: "u32", Name: "payload_size"},
+		},
+	},
 	NetPacketHTTPResponse: {
 		id:      NetPacketHTTPResponse,
 		id32Bit: Sys32Undefined,
@@ -13613,4 +14449,32 @@ var CoreEvents = map[ID]Definition{
 			{Type: "const char **", Name: "dst_dns"},
 		},
 	},
+	NetFlowSummary: {
+		id:      NetFlowSummary,
+		id32Bit: Sys32Undefined,
+		name:    "net_flow_summary",
+		version: NewVersion(1, 0, 0),
+		dependencies: Dependencies{
+			ids: []ID{
+				NetFlowStatsBase,
+			},
+		},
+		sets: []string{"network_events", "flows"},
+		params: []trace.ArgMeta{
+			{Type: "const char*", Name: "conn_direction"},
+			{Type: "const char*", Name: "proto"},
+			{Type: "const char*", Name: "src"},
+			{Type: "const char*", Name: "dst"},
+			{Type: "u16", Name: "src_port"},
+			{Type: "u16", Name: "dst_port"},
+			{Type: "const char **", Name: "src_dns"},
+			{Type: "const char **", Name: "dst_dns"},
+			{Type: "u64", Name: "duration"},
+			{Type: "u64", Name: "bytes_in"},
+			{Type: "u64", Name: "bytes_out"},
+			{Type: "u64", Name: "packets_in"},
+			{Type: "u64", Name: "packets_out"},
+			{Type: "bool", Name: "closed"},
+		},
+	},
 }
@@ -3,6 +3,12 @@
 
 package events
 
+// Native and compat (32-bit) syscall ABIs of the architecture
+const (
+	NativeSyscallABI = ABIX8664
+	CompatSyscallABI = ABII386
+)
+
 // x86 64bit syscall numbers (used as event IDs for the Syscall Events)
 // https://github.com/torvalds/linux/blob/master/arch/x86/entry/syscalls/syscall_64.tbl
 
@@ -3,6 +3,12 @@
 
 package events
 
+// Native and compat (32-bit) syscall ABIs of the architecture
+const (
+	NativeSyscallABI = ABIAArch64
+	CompatSyscallABI = ABIArm
+)
+
 // arm64 64bit syscall numbers (used as event IDs for the Syscall Events)
 // https://github.com/torvalds/linux/blob/master/include/uapi/asm-generic/unistd.h
 
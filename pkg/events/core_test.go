@@ -16,3 +16,18 @@ func TestAllEventsHaveVersion(t *testing.T) {
 		assert.NoError(t, err, fmt.Sprintf("event %s has invalid version", event.name))
 	}
 }
+
+func TestKernelVersionFallbacksAreDefined(t *testing.T) {
+	t.Parallel()
+
+	for id, event := range CoreEvents {
+		for _, fallback := range event.kernelVersion.GetFallbacks() {
+			assert.NotEqual(t, id, fallback, fmt.Sprintf("event %s falls back to itself", event.name))
+			_, ok := CoreEvents[fallback]
+			assert.True(t, ok, fmt.Sprintf("event %s has an undefined fallback event %d", event.name, fallback))
+		}
+		if len(event.kernelVersion.GetFallbacks()) > 0 {
+			assert.True(t, event.kernelVersion.IsSet(), fmt.Sprintf("event %s has fallbacks but no kernel version", event.name))
+		}
+	}
+}
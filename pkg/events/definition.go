@@ -5,18 +5,19 @@ import (
 )
 
 type Definition struct {
-	id           ID // TODO: use id ?
-	id32Bit      ID
-	name         string
-	version      Version
-	description  string
-	docPath      string // Relative to the 'doc/events' directory
-	internal     bool
-	syscall      bool
-	dependencies Dependencies
-	sets         []string
-	params       []trace.ArgMeta
-	properties   map[string]interface{}
+	id            ID // TODO: use id ?
+	id32Bit       ID
+	name          string
+	version       Version
+	description   string
+	docPath       string // Relative to the 'doc/events' directory
+	internal      bool
+	syscall       bool
+	dependencies  Dependencies
+	kernelVersion KernelVersion // kernel releases the event is available on (all if not set)
+	sets          []string
+	params        []trace.ArgMeta
+	properties    map[string]interface{}
 }
 
 func NewDefinition(
@@ -87,6 +88,10 @@ func (d Definition) GetDependencies() Dependencies {
 	return d.dependencies
 }
 
+func (d Definition) GetKernelVersion() KernelVersion {
+	return d.kernelVersion
+}
+
 func (d Definition) GetSets() []string {
 	return d.sets
 }
@@ -11,8 +11,10 @@ import (
 // TODO: add states to the EventGroup struct (to keep states of events from that group)
 
 type EventState struct {
-	Submit uint64 // should be submitted to userspace (by policies bitmap)
-	Emit   uint64 // should be emitted to the user (by policies bitmap)
+	Submit  uint64 // should be submitted to userspace (by policies bitmap)
+	Emit    uint64 // should be emitted to the user (by policies bitmap)
+	Stack   uint64 // should have its stack trace captured (by policies bitmap)
+	Consume uint64 // should be written to the perf buffer, consumed in userspace (by policies bitmap)
 }
 
 // ATTENTION: the definition group is instantiable (all the rest is immutable)
@@ -0,0 +1,54 @@
+package events
+
+import (
+	"strings"
+)
+
+// KernelVersion is the range of kernel releases an event is available on: from min (inclusive)
+// to max (exclusive), both optional. On kernels out of the range, the event is derived from the
+// first of its fallback events available instead, or canceled if there are none.
+type KernelVersion struct {
+	min       string // e.g. "5.3" for an event available on kernels 5.3 and newer
+	max       string // e.g. "6.2" for an event not available on kernels 6.2 and newer
+	fallbacks []ID   // events to derive the event from, in order, on kernels out of the range
+}
+
+func NewKernelVersion(min, max string, fallbacks []ID) KernelVersion {
+	return KernelVersion{
+		min:       min,
+		max:       max,
+		fallbacks: fallbacks,
+	}
+}
+
+func (k KernelVersion) GetMin() string {
+	return k.min
+}
+
+func (k KernelVersion) GetMax() string {
+	return k.max
+}
+
+func (k KernelVersion) GetFallbacks() []ID {
+	if k.fallbacks == nil {
+		return []ID{}
+	}
+	return k.fallbacks
+}
+
+// IsSet returns whether the event is restricted to a range of kernel releases.
+func (k KernelVersion) IsSet() bool {
+	return k.min != "" || k.max != ""
+}
+
+// String returns the range of kernel releases (e.g. ">= 5.3, < 6.2").
+func (k KernelVersion) String() string {
+	var constraints []string
+	if k.min != "" {
+		constraints = append(constraints, ">= "+k.min)
+	}
+	if k.max != "" {
+		constraints = append(constraints, "< "+k.max)
+	}
+	return strings.Join(constraints, ", ")
+}
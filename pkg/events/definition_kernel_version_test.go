@@ -0,0 +1,49 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKernelVersionString(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		kernelVersion KernelVersion
+		expected      string
+	}{
+		{
+			name:          "not set",
+			kernelVersion: KernelVersion{},
+			expected:      "",
+		},
+		{
+			name:          "min",
+			kernelVersion: NewKernelVersion("5.3", "", []ID{InotifyWatch}),
+			expected:      ">= 5.3",
+		},
+		{
+			name:          "max",
+			kernelVersion: NewKernelVersion("", "6.2", nil),
+			expected:      "< 6.2",
+		},
+		{
+			name:          "range",
+			kernelVersion: NewKernelVersion("4.20", "6.2", nil),
+			expected:      ">= 4.20, < 6.2",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected != "", tc.kernelVersion.IsSet())
+			assert.Equal(t, tc.expected, tc.kernelVersion.String())
+		})
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"github.com/aquasecurity/tracee/pkg/containers"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -22,7 +21,7 @@ func deriveContainerCreateArgs(cts *containers.Containers) func(event trace.Even
 		if check, err := isCgroupEventInHid(&event, cts); !check {
 			return nil, errfmt.WrapError(err)
 		}
-		cgroupId, err := parse.ArgVal[uint64](event.Args, "cgroup_id")
+		cgroupId, err := event.ArgUlong("cgroup_id")
 		if err != nil {
 			return nil, errfmt.WrapError(err)
 		}
@@ -53,7 +52,7 @@ func isCgroupEventInHid(event *trace.Event, cts *containers.Containers) (bool, e
 	if cts.GetCgroupVersion() == cgroup.CgroupVersion2 {
 		return true, nil
 	}
-	hierarchyID, err := parse.ArgVal[uint32](event.Args, "hierarchy_id")
+	hierarchyID, err := event.ArgUint("hierarchy_id")
 	if err != nil {
 		return false, errfmt.WrapError(err)
 	}
@@ -0,0 +1,198 @@
+package derive
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	procSysKernelDir = procSysDir + "kernel/"
+	corePatternFile  = procSysKernelDir + "core_pattern"
+)
+
+// cgroup v1 files running a program, or arming it, once a cgroup becomes empty
+const (
+	notifyOnReleaseFile = "notify_on_release"
+	releaseAgentFile    = "release_agent"
+)
+
+// container_device_mknod device types
+const (
+	deviceTypeChar  = "char"
+	deviceTypeBlock = "block"
+)
+
+// kernel internal dev_t encoding (include/linux/kdev_t.h)
+const (
+	kdevMinorBits = 20
+	kdevMinorMask = (1 << kdevMinorBits) - 1
+)
+
+// ContainerCorePatternWrite derives a container_core_pattern_write event from
+// /proc/sys/kernel/core_pattern being opened for writing from a container. A piped core
+// pattern runs its program, on the host, whenever a process crashes.
+func ContainerCorePatternWrite(resolver pathResolver) DeriveFunction {
+	return deriveSingleEvent(events.ContainerCorePatternWrite,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := containerFileWrite(event)
+			if err != nil || path != corePatternFile {
+				return nil, err
+			}
+
+			return []interface{}{
+				path,
+				readSysctlValue(resolver, path, event.MountNS),
+			}, nil
+		},
+	)
+}
+
+// ContainerProcSysKernelWrite derives a container_proc_sys_kernel_write event from kernel
+// parameters files (under /proc/sys/kernel) being opened for writing from a container. The
+// core pattern has its own event (container_core_pattern_write).
+func ContainerProcSysKernelWrite(resolver pathResolver) DeriveFunction {
+	return deriveSingleEvent(events.ContainerProcSysKernelWrite,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := containerFileWrite(event)
+			if err != nil || !strings.HasPrefix(path, procSysKernelDir) || path == corePatternFile {
+				return nil, err
+			}
+
+			return []interface{}{
+				sysctlName(path),
+				path,
+				readSysctlValue(resolver, path, event.MountNS),
+			}, nil
+		},
+	)
+}
+
+// ContainerNotifyOnReleaseWrite derives a container_notify_on_release_write event from a
+// cgroup notify_on_release file being opened for writing from a container, which arms the
+// release agent of the cgroup hierarchy.
+func ContainerNotifyOnReleaseWrite() DeriveFunction {
+	return deriveSingleEvent(events.ContainerNotifyOnReleaseWrite,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := containerFileWrite(event)
+			if err != nil || filepath.Base(path) != notifyOnReleaseFile {
+				return nil, err
+			}
+			flags, err := event.ArgInt("flags")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			return []interface{}{path, flags}, nil
+		},
+	)
+}
+
+// ContainerReleaseAgentWrite derives a container_release_agent_write event from a cgroup
+// release_agent file being opened for writing, or replaced by a rename, from a container. The
+// release agent is run, on the host, once a cgroup with notify_on_release set becomes empty.
+func ContainerReleaseAgentWrite() DeriveFunction {
+	return deriveSingleEvent(events.ContainerReleaseAgentWrite,
+		func(event trace.Event) ([]interface{}, error) {
+			if !event.ContextFlags.ContainerStarted {
+				return nil, nil
+			}
+
+			var path, operation string
+
+			switch events.ID(event.EventID) {
+			case events.SecurityFileOpen:
+				var err error
+				path, err = containerFileWrite(event)
+				if err != nil {
+					return nil, err
+				}
+				operation = fileOperationWrite
+			case events.SecurityInodeRename:
+				var err error
+				path, err = event.ArgString("new_path")
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+				operation = fileOperationRename
+			default:
+				return nil, nil
+			}
+
+			if filepath.Base(path) != releaseAgentFile {
+				return nil, nil
+			}
+
+			return []interface{}{path, operation}, nil
+		},
+	)
+}
+
+// ContainerDeviceMknod derives a container_device_mknod event from character or block device
+// nodes being created from a container, which may give it access to host devices (e.g. its
+// disks) the container runtime didn't expose.
+func ContainerDeviceMknod() DeriveFunction {
+	return deriveSingleEvent(events.ContainerDeviceMknod,
+		func(event trace.Event) ([]interface{}, error) {
+			if !event.ContextFlags.ContainerStarted {
+				return nil, nil
+			}
+			mode, err := trace.ArgValue[uint16](&event, "mode")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			var deviceType string
+			switch uint32(mode) & unix.S_IFMT {
+			case unix.S_IFCHR:
+				deviceType = deviceTypeChar
+			case unix.S_IFBLK:
+				deviceType = deviceTypeBlock
+			default:
+				return nil, nil // fifos, sockets and regular files
+			}
+
+			fileName, err := event.ArgString("file_name")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			dev, err := event.ArgUint("dev")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			return []interface{}{
+				fileName,
+				deviceType,
+				dev >> kdevMinorBits,
+				dev & kdevMinorMask,
+			}, nil
+		},
+	)
+}
+
+// containerFileWrite returns the path of the file opened (security_file_open) for writing from
+// a container, or an empty string if the event isn't one.
+func containerFileWrite(event trace.Event) (string, error) {
+	if !event.ContextFlags.ContainerStarted || events.ID(event.EventID) != events.SecurityFileOpen {
+		return "", nil
+	}
+	flags, err := event.ArgInt("flags")
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	if flags&(unix.O_WRONLY|unix.O_RDWR) == 0 {
+		return "", nil
+	}
+	path, err := event.ArgString("pathname")
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	return path, nil
+}
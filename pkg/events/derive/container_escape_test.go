@@ -0,0 +1,147 @@
+package derive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func fileOpenEvent(path string, flags int32, inContainer bool) trace.Event {
+	return trace.Event{
+		EventID:      int(events.SecurityFileOpen),
+		ContextFlags: trace.ContextFlags{ContainerStarted: inContainer},
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: path},
+			{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: flags},
+		},
+	}
+}
+
+func TestContainerEscape(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, procSysKernelDir), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, corePatternFile), []byte("|/proc/%P/root/payload\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, procSysKernelDir, "modprobe"), []byte("/tmp/payload\n"), 0o600))
+	resolver := rootPathResolver(root)
+
+	testCases := []struct {
+		name     string
+		deriveFn DeriveFunction
+		event    trace.Event
+		expected []interface{}
+	}{
+		{
+			name:     "core pattern written from a container",
+			deriveFn: ContainerCorePatternWrite(resolver),
+			event:    fileOpenEvent(corePatternFile, int32(os.O_WRONLY|os.O_TRUNC), true),
+			expected: []interface{}{corePatternFile, "|/proc/%P/root/payload"},
+		},
+		{
+			name:     "core pattern written from the host",
+			deriveFn: ContainerCorePatternWrite(resolver),
+			event:    fileOpenEvent(corePatternFile, int32(os.O_WRONLY|os.O_TRUNC), false),
+		},
+		{
+			name:     "core pattern read from a container",
+			deriveFn: ContainerCorePatternWrite(resolver),
+			event:    fileOpenEvent(corePatternFile, int32(os.O_RDONLY), true),
+		},
+		{
+			name:     "kernel parameter written from a container",
+			deriveFn: ContainerProcSysKernelWrite(resolver),
+			event:    fileOpenEvent(procSysKernelDir+"modprobe", int32(os.O_WRONLY), true),
+			expected: []interface{}{"kernel.modprobe", procSysKernelDir + "modprobe", "/tmp/payload"},
+		},
+		{
+			name:     "core pattern isn't a kernel parameter write",
+			deriveFn: ContainerProcSysKernelWrite(resolver),
+			event:    fileOpenEvent(corePatternFile, int32(os.O_WRONLY), true),
+		},
+		{
+			name:     "network parameter written from a container",
+			deriveFn: ContainerProcSysKernelWrite(resolver),
+			event:    fileOpenEvent(procSysDir+"net/ipv4/ip_forward", int32(os.O_WRONLY), true),
+		},
+		{
+			name:     "notify_on_release written from a container",
+			deriveFn: ContainerNotifyOnReleaseWrite(),
+			event:    fileOpenEvent("/tmp/cgrp/x/notify_on_release", int32(os.O_WRONLY), true),
+			expected: []interface{}{"/tmp/cgrp/x/notify_on_release", int32(os.O_WRONLY)},
+		},
+		{
+			name:     "release_agent written from a container",
+			deriveFn: ContainerReleaseAgentWrite(),
+			event:    fileOpenEvent("/tmp/cgrp/release_agent", int32(os.O_RDWR), true),
+			expected: []interface{}{"/tmp/cgrp/release_agent", fileOperationWrite},
+		},
+		{
+			name:     "release_agent replaced from a container",
+			deriveFn: ContainerReleaseAgentWrite(),
+			event: trace.Event{
+				EventID:      int(events.SecurityInodeRename),
+				ContextFlags: trace.ContextFlags{ContainerStarted: true},
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "old_path"}, Value: "/tmp/agent"},
+					{ArgMeta: trace.ArgMeta{Name: "new_path"}, Value: "/tmp/cgrp/release_agent"},
+				},
+			},
+			expected: []interface{}{"/tmp/cgrp/release_agent", fileOperationRename},
+		},
+		{
+			name:     "block device created from a container",
+			deriveFn: ContainerDeviceMknod(),
+			event: trace.Event{
+				EventID:      int(events.SecurityInodeMknod),
+				ContextFlags: trace.ContextFlags{ContainerStarted: true},
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "file_name"}, Value: "sda"},
+					{ArgMeta: trace.ArgMeta{Name: "mode"}, Value: uint16(unix.S_IFBLK | 0o600)},
+					{ArgMeta: trace.ArgMeta{Name: "dev"}, Value: uint32(8<<kdevMinorBits | 1)},
+				},
+			},
+			expected: []interface{}{"sda", deviceTypeBlock, uint32(8), uint32(1)},
+		},
+		{
+			name:     "fifo created from a container",
+			deriveFn: ContainerDeviceMknod(),
+			event: trace.Event{
+				EventID:      int(events.SecurityInodeMknod),
+				ContextFlags: trace.ContextFlags{ContainerStarted: true},
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "file_name"}, Value: "pipe"},
+					{ArgMeta: trace.ArgMeta{Name: "mode"}, Value: uint16(unix.S_IFIFO | 0o600)},
+					{ArgMeta: trace.ArgMeta{Name: "dev"}, Value: uint32(0)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := tc.deriveFn(tc.event)
+			require.Empty(t, errs)
+			if tc.expected == nil {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+			require.Len(t, derived[0].Args, len(tc.expected))
+			for i, expected := range tc.expected {
+				assert.Equal(t, expected, derived[0].Args[i].Value)
+			}
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/aquasecurity/tracee/pkg/containers"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -20,7 +19,7 @@ func deriveContainerRemoveArgs(cts *containers.Containers) deriveArgsFunction {
 		if check, err := isCgroupEventInHid(&event, cts); !check {
 			return nil, errfmt.WrapError(err)
 		}
-		cgroupId, err := parse.ArgVal[uint64](event.Args, "cgroup_id")
+		cgroupId, err := event.ArgUlong("cgroup_id")
 		if err != nil {
 			return nil, errfmt.WrapError(err)
 		}
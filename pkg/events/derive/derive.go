@@ -137,10 +137,11 @@ func buildDerivedEvent(baseEvent *trace.Event, skeleton deriveBase, argsValues [
 	de.EventName = skeleton.Name
 	de.ReturnValue = 0
 	de.StackAddresses = make([]uint64, 1)
-	de.Args = make([]trace.Argument, len(skeleton.Params))
+	args := make([]trace.Argument, len(skeleton.Params))
 	for i, value := range argsValues {
-		de.Args[i] = trace.Argument{ArgMeta: skeleton.Params[i], Value: value}
+		args[i] = trace.Argument{ArgMeta: skeleton.Params[i], Value: value}
 	}
+	de.SetArgs(args) // not decoded from the base event buffer
 	de.ArgsNum = len(de.Args)
 	return de, nil
 }
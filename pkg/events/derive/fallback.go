@@ -0,0 +1,53 @@
+package derive
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Fallback derives an event, on kernels it isn't available on, from the fallback event it depends
+// on instead. The arguments are copied by name from the fallback event, and the arguments it
+// lacks are set to the zero value of their type.
+func Fallback(id events.ID) DeriveFunction {
+	return deriveSingleEvent(id, deriveFallbackArgs(id))
+}
+
+func deriveFallbackArgs(id events.ID) deriveArgsFunction {
+	params := getEventDefinition(id).GetParams()
+
+	return func(event trace.Event) ([]interface{}, error) {
+		args := make([]interface{}, len(params))
+		for i, param := range params {
+			if arg := events.GetArg(&event, param.Name); arg != nil {
+				args[i] = arg.Value
+				continue
+			}
+			args[i] = zeroArgValue(param.Type)
+		}
+		return args, nil
+	}
+}
+
+// zeroArgValue returns the zero value of an argument type, as decoded from the kernel.
+func zeroArgValue(argType string) interface{} {
+	switch argType {
+	case "int", "pid_t", "uid_t", "gid_t":
+		return int32(0)
+	case "unsigned int", "u32", "dev_t", "mode_t":
+		return uint32(0)
+	case "long":
+		return int64(0)
+	case "unsigned long", "u64", "size_t", "off_t", "loff_t":
+		return uint64(0)
+	case "umode_t":
+		return uint16(0)
+	case "u8":
+		return uint8(0)
+	case "bool":
+		return false
+	case "char*", "const char*":
+		return ""
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,42 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	inotifyWatch := trace.Event{
+		EventID:       int(events.InotifyWatch),
+		EventName:     "inotify_watch",
+		HostProcessID: 1234,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Type: "const char*", Name: "pathname"}, Value: "/etc/shadow"},
+			{ArgMeta: trace.ArgMeta{Type: "unsigned long", Name: "inode"}, Value: uint64(42)},
+			{ArgMeta: trace.ArgMeta{Type: "dev_t", Name: "dev"}, Value: uint32(2049)},
+		},
+	}
+
+	derived, errs := Fallback(events.SecurityPathNotify)(inotifyWatch)
+	require.Empty(t, errs)
+	require.Len(t, derived, 1)
+
+	event := derived[0]
+	assert.Equal(t, int(events.SecurityPathNotify), event.EventID)
+	assert.Equal(t, "security_path_notify", event.EventName)
+	assert.Equal(t, 1234, event.HostProcessID)
+	assert.Equal(t, []trace.Argument{
+		{ArgMeta: trace.ArgMeta{Type: "const char*", Name: "pathname"}, Value: "/etc/shadow"},
+		{ArgMeta: trace.ArgMeta{Type: "unsigned long", Name: "inode"}, Value: uint64(42)},
+		{ArgMeta: trace.ArgMeta{Type: "dev_t", Name: "dev"}, Value: uint32(2049)},
+		{ArgMeta: trace.ArgMeta{Type: "u64", Name: "mask"}, Value: uint64(0)},
+		{ArgMeta: trace.ArgMeta{Type: "unsigned int", Name: "obj_type"}, Value: uint32(0)},
+	}, event.Args)
+}
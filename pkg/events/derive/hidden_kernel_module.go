@@ -17,7 +17,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/capabilities"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -53,7 +52,7 @@ func HiddenKernelModule() DeriveFunction {
 
 func deriveHiddenKernelModulesArgs() multiDeriveArgsFunction {
 	return func(event trace.Event) ([][]interface{}, []error) {
-		address, err := parse.ArgVal[uint64](event.Args, "address")
+		address, err := event.ArgUlong("address")
 		if err != nil {
 			return nil, []error{err}
 		}
@@ -62,7 +61,7 @@ func deriveHiddenKernelModulesArgs() multiDeriveArgsFunction {
 			return nil, nil // event in cache: already reported.
 		}
 
-		flags, err := parse.ArgVal[uint32](event.Args, "flags")
+		flags, err := event.ArgUint("flags")
 		if err != nil {
 			return nil, []error{err}
 		}
@@ -98,7 +97,7 @@ func deriveHiddenKernelModulesArgs() multiDeriveArgsFunction {
 		// Add to cache not to report it multiple times
 		foundHiddenKernModsCache.Add(address, struct{}{})
 
-		return [][]interface{}{extractFromEvent(event.Args, address)}, nil
+		return [][]interface{}{extractFromEvent(&event, address)}, nil
 	}
 }
 
@@ -135,7 +134,7 @@ func handleHistoryScanFinished(scanStatus uint64) ([][]interface{}, []error) {
 			break
 		}
 
-		address, err := parse.ArgVal[uint64](e.Args, "address")
+		address, err := e.ArgUlong("address")
 		if err != nil {
 			return nil, []error{err}
 		}
@@ -145,17 +144,17 @@ func handleHistoryScanFinished(scanStatus uint64) ([][]interface{}, []error) {
 		}
 
 		foundHiddenKernModsCache.Add(address, struct{}{})
-		res = append(res, extractFromEvent(e.Args, address)) // Note using the event from LRU and not the event received in the derived event
+		res = append(res, extractFromEvent(e, address)) // Note using the event from LRU and not the event received in the derived event
 	}
 
 	return res, nil // Send all the events
 }
 
-// extractFromEvent extract arguments from the trace.Argument
-func extractFromEvent(args []trace.Argument, address uint64) []interface{} {
+// extractFromEvent extract arguments from the event
+func extractFromEvent(event *trace.Event, address uint64) []interface{} {
 	// Parse module name if possible
 	var name string
-	nameBytes, err := parse.ArgVal[[]byte](args, "name")
+	nameBytes, err := trace.ArgValue[[]byte](event, "name")
 	if err != nil {
 		name = ""
 		// Don't fail hard, submit it without a name!
@@ -167,7 +166,7 @@ func extractFromEvent(args []trace.Argument, address uint64) []interface{} {
 
 	// Parse module srcversion if possible
 	var srcversion string
-	srcversionBytes, err := parse.ArgVal[[]byte](args, "srcversion")
+	srcversionBytes, err := trace.ArgValue[[]byte](event, "srcversion")
 	if err != nil {
 		srcversion = ""
 		// Don't fail hard, submit it without a srcversion!
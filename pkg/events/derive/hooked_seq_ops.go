@@ -5,7 +5,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/utils"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -34,7 +33,7 @@ func HookedSeqOps(kernelSymbols *helpers.KernelSymbolTable) DeriveFunction {
 
 func deriveHookedSeqOpsArgs(kernelSymbols *helpers.KernelSymbolTable) deriveArgsFunction {
 	return func(event trace.Event) ([]interface{}, error) {
-		seqOpsArr, err := parse.ArgVal[[]uint64](event.Args, "net_seq_ops")
+		seqOpsArr, err := trace.ArgValue[[]uint64](&event, "net_seq_ops")
 		if err != nil || len(seqOpsArr) < 1 {
 			return nil, errfmt.WrapError(err)
 		}
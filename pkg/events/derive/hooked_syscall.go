@@ -9,7 +9,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -34,12 +33,12 @@ func DetectHookedSyscall(kernelSymbols *helpers.KernelSymbolTable) DeriveFunctio
 
 func deriveDetectHookedSyscallArgs(kernelSymbols *helpers.KernelSymbolTable) deriveArgsFunction {
 	return func(event trace.Event) ([]interface{}, error) {
-		syscallId, err := parse.ArgVal[int32](event.Args, "syscall_id")
+		syscallId, err := event.ArgInt("syscall_id")
 		if err != nil {
 			return nil, errfmt.Errorf("error parsing syscall_id arg: %v", err)
 		}
 
-		address, err := parse.ArgVal[uint64](event.Args, "syscall_address")
+		address, err := event.ArgUlong("syscall_address")
 		if err != nil {
 			return nil, errfmt.Errorf("error parsing syscall_address arg: %v", err)
 		}
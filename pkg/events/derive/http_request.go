@@ -0,0 +1,206 @@
+package derive
+
+import (
+	"bytes"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	httpRequestMaxLen     = 4096 // bytes of the payload parsed as request line and headers
+	httpRequestMaxHeaders = 64   // headers kept from a request
+)
+
+// httpRequest is an HTTP/1.x request line and headers parsed from a (possibly truncated)
+// payload.
+type httpRequest struct {
+	method    string
+	version   string
+	host      string
+	path      string
+	userAgent string
+	headers   []string // "Name: value", in the order of the payload
+	truncated bool     // the headers were truncated (payload size or headers cap)
+}
+
+// NOTE: Unlike net_packet_http_request, which requires the complete request headers to be
+// held by the packet, http_request parses whatever the (size capped) payload holds.
+
+func HTTPRequest() DeriveFunction {
+	return deriveSingleEvent(events.HTTPRequest,
+		func(event trace.Event) ([]interface{}, error) {
+			if getPacketHTTPDirection(&event) != protoHTTPRequest {
+				return nil, nil
+			}
+			packet, err := createPacketFromEvent(&event)
+			if err != nil {
+				return nil, err
+			}
+			srcIP, dstIP, err := getLayer3SrcDstFromPacket(packet)
+			if err != nil {
+				return nil, err
+			}
+			srcPort, dstPort, err := getLayer4SrcPortDstPortFromPacket(packet)
+			if err != nil {
+				return nil, err
+			}
+			layer7, err := getLayer7FromPacket(packet)
+			if err != nil {
+				return nil, nil // regular tcp/ip packet without payload
+			}
+
+			request := parseHTTPRequest(layer7.Payload())
+			if request == nil {
+				logger.Debugw("not an HTTP/1.x request payload", "id", event.EventID)
+				return nil, nil
+			}
+
+			return []interface{}{
+				srcIP.String(),
+				dstIP.String(),
+				srcPort,
+				dstPort,
+				request.method,
+				request.version,
+				request.host,
+				request.path,
+				request.userAgent,
+				request.headers,
+				request.truncated,
+			}, nil
+		},
+	)
+}
+
+// parseHTTPRequest parses the HTTP/1.x request line and headers of the given payload, up
+// to httpRequestMaxLen bytes. It returns nil if the payload doesn't start with a valid
+// HTTP/1.x request line.
+func parseHTTPRequest(payload []byte) *httpRequest {
+	if len(payload) > httpRequestMaxLen {
+		payload = payload[:httpRequestMaxLen]
+	}
+
+	line, rest, ok := cutHTTPLine(payload)
+	if !ok {
+		return nil // request line truncated
+	}
+	method, target, version, ok := parseHTTPRequestLine(line)
+	if !ok {
+		return nil
+	}
+
+	request := &httpRequest{
+		method:  method,
+		version: version,
+		headers: []string{},
+	}
+
+	for {
+		line, rest, ok = cutHTTPLine(rest)
+		if !ok {
+			request.truncated = true // end of payload before the end of the headers
+			break
+		}
+		if len(line) == 0 {
+			break // end of the headers
+		}
+		name, value, found := strings.Cut(string(line), ":")
+		if !found || !isHTTPToken(name) {
+			continue // malformed (or obsolete folded) header line
+		}
+		if len(request.headers) == httpRequestMaxHeaders {
+			request.truncated = true
+			break
+		}
+		name = textproto.CanonicalMIMEHeaderKey(name)
+		value = strings.TrimSpace(value)
+		request.headers = append(request.headers, name+": "+value)
+
+		switch name {
+		case "Host":
+			request.host = value
+		case "User-Agent":
+			request.userAgent = value
+		}
+	}
+
+	switch {
+	case method == "CONNECT":
+		// authority-form: host:port
+		if request.host == "" {
+			request.host = target
+		}
+	case target == "*":
+		// asterisk-form: server wide OPTIONS
+		request.path = target
+	case strings.HasPrefix(target, "/"):
+		// origin-form: path and query
+		request.path, _, _ = strings.Cut(target, "?")
+	default:
+		// absolute-form: proxied requests
+		uri, err := url.Parse(target)
+		if err != nil {
+			return nil
+		}
+		if request.host == "" {
+			request.host = uri.Host
+		}
+		request.path = uri.Path
+	}
+
+	return request
+}
+
+// parseHTTPRequestLine splits an HTTP/1.x request line into its method, request target
+// and version.
+func parseHTTPRequestLine(line []byte) (string, string, string, bool) {
+	method, rest, ok := strings.Cut(string(line), " ")
+	if !ok || !isHTTPToken(method) {
+		return "", "", "", false
+	}
+	target, version, ok := strings.Cut(rest, " ")
+	if !ok || target == "" {
+		return "", "", "", false
+	}
+	switch version {
+	case "HTTP/1.0", "HTTP/1.1":
+	default:
+		return "", "", "", false
+	}
+
+	return method, target, version, true
+}
+
+// cutHTTPLine returns the first line of the given data (without its CRLF or LF ending) and
+// the data after it. It returns false if the line isn't terminated.
+func cutHTTPLine(data []byte) ([]byte, []byte, bool) {
+	line, rest, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, data, false
+	}
+
+	return bytes.TrimSuffix(line, []byte("\r")), rest, true
+}
+
+// isHTTPToken returns true if the given string is a valid HTTP token (methods and header
+// names).
+func isHTTPToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range []byte(s) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
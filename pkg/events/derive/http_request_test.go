@@ -0,0 +1,112 @@
+package derive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHTTPRequest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		payload  string
+		expected *httpRequest
+	}{
+		{
+			name:    "origin-form request",
+			payload: "GET /index.html?q=1 HTTP/1.1\r\nhost: example.com\r\nUser-Agent: curl/8.0\r\n\r\n",
+			expected: &httpRequest{
+				method:    "GET",
+				version:   "HTTP/1.1",
+				host:      "example.com",
+				path:      "/index.html",
+				userAgent: "curl/8.0",
+				headers:   []string{"Host: example.com", "User-Agent: curl/8.0"},
+			},
+		},
+		{
+			name:    "absolute-form request without host header",
+			payload: "POST http://proxy.example.com:8080/upload HTTP/1.0\n\n",
+			expected: &httpRequest{
+				method:  "POST",
+				version: "HTTP/1.0",
+				host:    "proxy.example.com:8080",
+				path:    "/upload",
+				headers: []string{},
+			},
+		},
+		{
+			name:    "authority-form request",
+			payload: "CONNECT example.com:443 HTTP/1.1\r\n\r\n",
+			expected: &httpRequest{
+				method:  "CONNECT",
+				version: "HTTP/1.1",
+				host:    "example.com:443",
+				headers: []string{},
+			},
+		},
+		{
+			name:    "truncated headers",
+			payload: "GET / HTTP/1.1\r\nHost: example.com\r\nUser-Ag",
+			expected: &httpRequest{
+				method:    "GET",
+				version:   "HTTP/1.1",
+				host:      "example.com",
+				path:      "/",
+				headers:   []string{"Host: example.com"},
+				truncated: true,
+			},
+		},
+		{
+			name:    "malformed header lines are skipped",
+			payload: "DELETE /item/1 HTTP/1.1\r\nno colon\r\nX-Id: 7\r\n\r\n",
+			expected: &httpRequest{
+				method:  "DELETE",
+				version: "HTTP/1.1",
+				path:    "/item/1",
+				headers: []string{"X-Id: 7"},
+			},
+		},
+		{
+			name:    "truncated request line",
+			payload: "GET /index.html HTT",
+		},
+		{
+			name:    "http/2 preface",
+			payload: "PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n",
+		},
+		{
+			name:    "invalid method",
+			payload: "GE(T / HTTP/1.1\r\n\r\n",
+		},
+		{
+			name:    "http response",
+			payload: "HTTP/1.1 200 OK\r\n\r\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, parseHTTPRequest([]byte(tc.payload)))
+		})
+	}
+}
+
+func TestParseHTTPRequestSizeCap(t *testing.T) {
+	t.Parallel()
+
+	payload := "GET / HTTP/1.1\r\nX-Large: " + strings.Repeat("a", httpRequestMaxLen) + "\r\nHost: example.com\r\n\r\n"
+
+	request := parseHTTPRequest([]byte(payload))
+	assert.NotNil(t, request)
+	assert.True(t, request.truncated)
+	assert.Empty(t, request.host)
+	assert.Empty(t, request.headers)
+}
@@ -0,0 +1,83 @@
+package derive
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/threatintel"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// IOCMatch derives an ioc_match event for every threat intel match of the event
+// indicators: the remote IP of security_socket_connect, the domains queried by DNS
+// requests and the sha256 hash of executed binaries (sched_process_exec, when
+// file hashes are computed).
+func IOCMatch(store *threatintel.Store) DeriveFunction {
+	return deriveMultipleEvents(events.IOCMatch,
+		func(event trace.Event) ([][]interface{}, []error) {
+			if store == nil {
+				return nil, nil
+			}
+
+			var results [][]interface{}
+			for _, indicator := range pickIndicators(event) {
+				for _, match := range store.Lookup(indicator.Type, indicator.Value) {
+					results = append(results, []interface{}{
+						string(match.Type),
+						indicator.Value,
+						match.Value,
+						match.Feed,
+						string(match.FeedType),
+						match.Description,
+						event.EventName,
+					})
+				}
+			}
+
+			return results, nil
+		},
+	)
+}
+
+// pickIndicators returns the indicators of the event, which are looked up in the feeds.
+func pickIndicators(event trace.Event) []threatintel.Indicator {
+	switch events.ID(event.EventID) {
+	case events.SecuritySocketConnect:
+		sockaddr, err := trace.ArgValue[map[string]string](&event, "remote_addr")
+		if err != nil {
+			return nil
+		}
+		switch sockaddr["sa_family"] {
+		case "AF_INET":
+			return []threatintel.Indicator{{Type: threatintel.IP, Value: sockaddr["sin_addr"]}}
+		case "AF_INET6":
+			return []threatintel.Indicator{{Type: threatintel.IP, Value: sockaddr["sin6_addr"]}}
+		}
+	case events.NetPacketDNSBase:
+		packet, err := createPacketFromEvent(&event)
+		if err != nil {
+			logger.Debugw("error creating packet", "error", err)
+			return nil
+		}
+		layer7DNS, err := getLayer7DNSFromPacket(packet)
+		if err != nil {
+			return nil
+		}
+		dns := getProtoDNS(layer7DNS)
+		if dns.QR != 0 {
+			return nil // not a DNS request
+		}
+		var indicators []threatintel.Indicator
+		for _, query := range getDNSQueryFromProtoDNS(dns.Questions) {
+			indicators = append(indicators, threatintel.Indicator{Type: threatintel.Domain, Value: query.Query})
+		}
+		return indicators
+	case events.SchedProcessExec:
+		hash, err := event.ArgString("sha256")
+		if err != nil || hash == "" {
+			return nil
+		}
+		return []threatintel.Indicator{{Type: threatintel.SHA256, Value: hash}}
+	}
+
+	return nil
+}
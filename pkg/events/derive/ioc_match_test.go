@@ -0,0 +1,129 @@
+package derive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/threatintel"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestIOCMatch(t *testing.T) {
+	t.Parallel()
+
+	feedPath := filepath.Join(t.TempDir(), "iocs.csv")
+	require.NoError(t, os.WriteFile(feedPath, []byte(`ip,203.0.113.0/24,c2 network
+sha256,e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855,miner
+`), 0o600))
+	store, err := threatintel.NewStore(context.Background(), threatintel.Config{
+		Feeds: []threatintel.Feed{{Type: threatintel.FeedCSV, Source: feedPath}},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name             string
+		event            trace.Event
+		expectedValue    string
+		expectedMatch    string
+		expectedNoEvents bool
+	}{
+		{
+			name: "socket connect",
+			event: trace.Event{
+				EventID:   int(events.SecuritySocketConnect),
+				EventName: "security_socket_connect",
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "remote_addr"}, Value: map[string]string{
+						"sa_family": "AF_INET",
+						"sin_addr":  "203.0.113.7",
+						"sin_port":  "443",
+					}},
+				},
+			},
+			expectedValue: "203.0.113.7",
+			expectedMatch: "203.0.113.0/24",
+		},
+		{
+			name: "exec",
+			event: trace.Event{
+				EventID:   int(events.SchedProcessExec),
+				EventName: "sched_process_exec",
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "sha256"}, Value: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+				},
+			},
+			expectedValue: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expectedMatch: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+		{
+			name: "no match",
+			event: trace.Event{
+				EventID:   int(events.SecuritySocketConnect),
+				EventName: "security_socket_connect",
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "remote_addr"}, Value: map[string]string{
+						"sa_family": "AF_INET6",
+						"sin6_addr": "2001:db8::1",
+						"sin6_port": "443",
+					}},
+				},
+			},
+			expectedNoEvents: true,
+		},
+		{
+			name: "exec without hash",
+			event: trace.Event{
+				EventID:   int(events.SchedProcessExec),
+				EventName: "sched_process_exec",
+			},
+			expectedNoEvents: true,
+		},
+	}
+
+	deriveFn := IOCMatch(store)
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(tc.event)
+			require.Empty(t, errs)
+			if tc.expectedNoEvents {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.IOCMatch), event.EventID)
+			value, err := parse.ArgVal[string](event.Args, "value")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+			indicator, err := parse.ArgVal[string](event.Args, "indicator")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMatch, indicator)
+			feed, err := parse.ArgVal[string](event.Args, "feed")
+			require.NoError(t, err)
+			assert.Equal(t, feedPath, feed)
+			triggerEvent, err := parse.ArgVal[string](event.Args, "trigger_event")
+			require.NoError(t, err)
+			assert.Equal(t, tc.event.EventName, triggerEvent)
+		})
+	}
+}
+
+func TestIOCMatchNoStore(t *testing.T) {
+	t.Parallel()
+
+	derived, errs := IOCMatch(nil)(trace.Event{EventID: int(events.SchedProcessExec)})
+	assert.Empty(t, errs)
+	assert.Empty(t, derived)
+}
@@ -0,0 +1,61 @@
+package derive
+
+import (
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// K8sAuditCorrelation derives a k8s_audit_correlation event for every Kubernetes audit log
+// action (e.g. an exec into the pod) followed by the execution of a pod process. The
+// processes observed before the action is received are correlated by the correlator, and
+// derived with K8sAuditCorrelationEvent.
+func K8sAuditCorrelation(correlator *k8saudit.Correlator) DeriveFunction {
+	return deriveMultipleEvents(events.K8sAuditCorrelation,
+		func(event trace.Event) ([][]interface{}, []error) {
+			if correlator == nil {
+				return nil, nil
+			}
+
+			var results [][]interface{}
+			for _, action := range correlator.Observe(event, time.Now()) {
+				results = append(results, k8sAuditCorrelationArgs(action, event))
+			}
+
+			return results, nil
+		},
+	)
+}
+
+// K8sAuditCorrelationEvent returns the k8s_audit_correlation event of a process correlated
+// with an action received after it.
+func K8sAuditCorrelationEvent(match k8saudit.Match) (trace.Event, error) {
+	return buildDerivedEvent(
+		&match.Event,
+		makeDeriveBase(events.K8sAuditCorrelation),
+		k8sAuditCorrelationArgs(match.Action, match.Event),
+	)
+}
+
+func k8sAuditCorrelationArgs(action k8saudit.Action, event trace.Event) []interface{} {
+	pathname, _ := event.ArgString("pathname")
+	argv, _ := event.ArgStrings("argv")
+
+	return []interface{}{
+		action.AuditID,
+		action.Verb,
+		action.User,
+		action.Subresource,
+		action.Namespace,
+		action.Pod,
+		action.Container,
+		action.Command,
+		action.SourceIPs,
+		action.UserAgent,
+		uint64(action.Time.UnixNano()),
+		pathname,
+		argv,
+	}
+}
@@ -0,0 +1,95 @@
+package derive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/k8saudit"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestK8sAuditCorrelation(t *testing.T) {
+	t.Parallel()
+
+	auditLog := filepath.Join(t.TempDir(), "audit.log")
+	require.NoError(t, os.WriteFile(auditLog, nil, 0o600))
+	correlator, err := k8saudit.New(k8saudit.Config{File: auditLog})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go correlator.Run(ctx)
+
+	exec := func(pod, pathname string) trace.Event {
+		return trace.Event{
+			EventID:    int(events.SchedProcessExec),
+			EventName:  "sched_process_exec",
+			ProcessID:  42,
+			Kubernetes: trace.Kubernetes{PodNamespace: "default", PodName: pod},
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: pathname},
+				{ArgMeta: trace.ArgMeta{Name: "argv"}, Value: []string{pathname, "-c", "id"}},
+			},
+		}
+	}
+
+	// the process executed before the action is received, correlated once it is
+	deriveFn := K8sAuditCorrelation(correlator)
+	derived, errs := deriveFn(exec("web", "/bin/sh"))
+	require.Empty(t, errs)
+	assert.Empty(t, derived)
+
+	audit := `{"auditID":"id-1","stage":"ResponseStarted","verb":"create",` +
+		`"requestURI":"/api/v1/namespaces/default/pods/web/exec?command=sh&container=app",` +
+		`"user":{"username":"alice"},"objectRef":{"resource":"pods","namespace":"default","name":"web","subresource":"exec"},` +
+		`"responseStatus":{"code":101},"requestReceivedTimestamp":"` + time.Now().UTC().Format(time.RFC3339Nano) + `"}` + "\n"
+	time.Sleep(100 * time.Millisecond) // let the audit log be opened before appending to it
+	file, err := os.OpenFile(auditLog, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(audit)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	var match k8saudit.Match
+	select {
+	case match = <-correlator.Matches():
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no correlation of the audit log action")
+	}
+	event, err := K8sAuditCorrelationEvent(match)
+	require.NoError(t, err)
+	assert.Equal(t, int(events.K8sAuditCorrelation), event.EventID)
+	assert.Equal(t, "k8s_audit_correlation", event.EventName)
+	assert.Equal(t, 42, event.ProcessID)
+	user, err := parse.ArgVal[string](event.Args, "user")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", user)
+	pathname, err := parse.ArgVal[string](event.Args, "pathname")
+	require.NoError(t, err)
+	assert.Equal(t, "/bin/sh", pathname)
+
+	// the process executed after the action is received
+	derived, errs = deriveFn(exec("web", "/usr/bin/id"))
+	require.Empty(t, errs)
+	require.Len(t, derived, 1)
+	assert.Equal(t, "k8s_audit_correlation", derived[0].EventName)
+	container, err := parse.ArgVal[string](derived[0].Args, "container")
+	require.NoError(t, err)
+	assert.Equal(t, "app", container)
+	argv, err := parse.ArgVal[[]string](derived[0].Args, "argv")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/usr/bin/id", "-c", "id"}, argv)
+
+	// a process of another pod
+	derived, errs = deriveFn(exec("db", "/bin/sh"))
+	require.Empty(t, errs)
+	assert.Empty(t, derived)
+}
@@ -0,0 +1,148 @@
+package derive
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// linker_hijack methods
+const (
+	linkerHijackEnv         = "environment"
+	linkerHijackPreloadFile = "preload_file"
+)
+
+const ldPreloadFile = "/etc/ld.so.preload"
+
+// linkerHijackEnvs are the dynamic linker environment variables loading libraries (or
+// searching for them) before the ones the executed binary depends on.
+var linkerHijackEnvs = []string{"LD_PRELOAD", "LD_AUDIT", "LD_LIBRARY_PATH"}
+
+type pathResolver interface {
+	GetHostAbsPath(absolutePath string, mountNS int) (string, error)
+}
+
+// LinkerHijack derives linker_hijack events from executions with dynamic linker environment
+// variables set (one event per variable), and from writes to /etc/ld.so.preload (either
+// opened for writing, or replaced by a rename).
+func LinkerHijack(resolver pathResolver) DeriveFunction {
+	return deriveMultipleEvents(events.LinkerHijack,
+		func(event trace.Event) ([][]interface{}, []error) {
+			var args [][]interface{}
+			var err error
+
+			switch events.ID(event.EventID) {
+			case events.SchedProcessExec:
+				args, err = deriveLinkerHijackEnvArgs(event)
+			case events.SecurityFileOpen:
+				args, err = deriveLinkerHijackFileOpenArgs(event, resolver)
+			case events.SecurityInodeRename:
+				args, err = deriveLinkerHijackRenameArgs(event, resolver)
+			}
+			if err != nil {
+				return nil, []error{err}
+			}
+
+			return args, nil
+		},
+	)
+}
+
+func deriveLinkerHijackEnvArgs(event trace.Event) ([][]interface{}, error) {
+	env, err := event.ArgStrings("env")
+	if err != nil {
+		return nil, nil // environment not captured
+	}
+
+	var args [][]interface{}
+	for _, envVar := range env {
+		name, value, found := strings.Cut(envVar, "=")
+		if !found || value == "" {
+			continue
+		}
+		for _, hijackEnv := range linkerHijackEnvs {
+			if name == hijackEnv {
+				args = append(args, []interface{}{linkerHijackEnv, name, splitLinkerList(value)})
+				break
+			}
+		}
+	}
+
+	return args, nil
+}
+
+func deriveLinkerHijackFileOpenArgs(event trace.Event, resolver pathResolver) ([][]interface{}, error) {
+	pathname, err := event.ArgString("pathname")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if pathname != ldPreloadFile {
+		return nil, nil
+	}
+	flags, err := event.ArgInt("flags")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if flags&(unix.O_WRONLY|unix.O_RDWR) == 0 {
+		return nil, nil
+	}
+
+	// NOTE: the file is read once the event reaches the derivation stage, which is (most
+	// often) after the opening process wrote it.
+	libraries := readPreloadFile(resolver, pathname, event.MountNS)
+
+	return [][]interface{}{{linkerHijackPreloadFile, pathname, libraries}}, nil
+}
+
+func deriveLinkerHijackRenameArgs(event trace.Event, resolver pathResolver) ([][]interface{}, error) {
+	newPath, err := event.ArgString("new_path")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if newPath != ldPreloadFile {
+		return nil, nil
+	}
+	oldPath, err := event.ArgString("old_path")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	// the file is being renamed: read it from its old path, or from the new one if already done
+	libraries := readPreloadFile(resolver, oldPath, event.MountNS)
+	if libraries == nil {
+		libraries = readPreloadFile(resolver, newPath, event.MountNS)
+	}
+
+	return [][]interface{}{{linkerHijackPreloadFile, newPath, libraries}}, nil
+}
+
+// readPreloadFile returns the libraries listed in the given preload file, in the given mount
+// namespace, or nil if it can't be read.
+func readPreloadFile(resolver pathResolver, path string, mountNS int) []string {
+	hostPath, err := resolver.GetHostAbsPath(path, mountNS)
+	if err != nil {
+		logger.Debugw("Resolving preload file path", "path", path, "error", err)
+		return nil
+	}
+	content, err := os.ReadFile(hostPath)
+	if err != nil {
+		logger.Debugw("Reading preload file", "path", hostPath, "error", err)
+		return nil
+	}
+
+	return splitLinkerList(string(content))
+}
+
+// splitLinkerList splits a list of libraries (or directories), as the dynamic linker does,
+// on colons and white spaces.
+func splitLinkerList(list string) []string {
+	return strings.FieldsFunc(list, func(r rune) bool {
+		return r == ':' || r == ';' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
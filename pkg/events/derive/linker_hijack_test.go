@@ -0,0 +1,137 @@
+package derive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// rootPathResolver resolves paths under a root directory, whatever the mount namespace.
+type rootPathResolver string
+
+func (r rootPathResolver) GetHostAbsPath(absolutePath string, mountNS int) (string, error) {
+	return filepath.Join(string(r), absolutePath), nil
+}
+
+func TestLinkerHijack(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, ldPreloadFile), []byte("/tmp/evil.so\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "etc", ".preload.tmp"), []byte("/lib/a.so /lib/b.so\n"), 0o600))
+
+	type derivedArgs struct {
+		method    string
+		source    string
+		libraries []string
+	}
+
+	testCases := []struct {
+		name     string
+		event    trace.Event
+		expected []derivedArgs
+	}{
+		{
+			name: "exec with preload and library path",
+			event: trace.Event{
+				EventID: int(events.SchedProcessExec),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "env"}, Value: []string{
+						"HOME=/root",
+						"LD_PRELOAD=/tmp/hook.so:/tmp/other.so",
+						"LD_LIBRARY_PATH=/tmp/libs",
+					}},
+				},
+			},
+			expected: []derivedArgs{
+				{linkerHijackEnv, "LD_PRELOAD", []string{"/tmp/hook.so", "/tmp/other.so"}},
+				{linkerHijackEnv, "LD_LIBRARY_PATH", []string{"/tmp/libs"}},
+			},
+		},
+		{
+			name: "exec with empty preload",
+			event: trace.Event{
+				EventID: int(events.SchedProcessExec),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "env"}, Value: []string{"LD_PRELOAD="}},
+				},
+			},
+		},
+		{
+			name: "exec without environment",
+			event: trace.Event{
+				EventID: int(events.SchedProcessExec),
+			},
+		},
+		{
+			name: "preload file opened for writing",
+			event: trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: ldPreloadFile},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: int32(os.O_WRONLY | os.O_TRUNC)},
+				},
+			},
+			expected: []derivedArgs{
+				{linkerHijackPreloadFile, ldPreloadFile, []string{"/tmp/evil.so"}},
+			},
+		},
+		{
+			name: "preload file opened for reading",
+			event: trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: ldPreloadFile},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: int32(os.O_RDONLY)},
+				},
+			},
+		},
+		{
+			name: "preload file replaced",
+			event: trace.Event{
+				EventID: int(events.SecurityInodeRename),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "old_path"}, Value: "/etc/.preload.tmp"},
+					{ArgMeta: trace.ArgMeta{Name: "new_path"}, Value: ldPreloadFile},
+				},
+			},
+			expected: []derivedArgs{
+				{linkerHijackPreloadFile, ldPreloadFile, []string{"/lib/a.so", "/lib/b.so"}},
+			},
+		},
+	}
+
+	deriveFn := LinkerHijack(rootPathResolver(root))
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(tc.event)
+			require.Empty(t, errs)
+			require.Len(t, derived, len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.Equal(t, int(events.LinkerHijack), derived[i].EventID)
+				method, err := parse.ArgVal[string](derived[i].Args, "method")
+				require.NoError(t, err)
+				assert.Equal(t, expected.method, method)
+				source, err := parse.ArgVal[string](derived[i].Args, "source")
+				require.NoError(t, err)
+				assert.Equal(t, expected.source, source)
+				libraries, err := parse.ArgVal[[]string](derived[i].Args, "libraries")
+				require.NoError(t, err)
+				assert.Equal(t, expected.libraries, libraries)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package derive
 
 import (
+	"net"
+
 	"github.com/aquasecurity/tracee/pkg/dnscache"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
@@ -12,6 +15,18 @@ const (
 	directionIncoming = "incoming"
 )
 
+// flow stats directions (should match defined values in ebpf code)
+const (
+	flowStatsIncoming uint8 = iota + 1
+	flowStatsOutgoing
+)
+
+// socket address families (should match defined values in ebpf code)
+const (
+	afInet  uint32 = 2
+	afInet6 uint32 = 10
+)
+
 func NetFlowTCPBegin(cache *dnscache.DNSCache) DeriveFunction {
 	return deriveSingleEvent(events.NetFlowTCPBegin,
 		func(event trace.Event) ([]interface{}, error) {
@@ -148,6 +163,111 @@ func NetFlowTCPEnd(cache *dnscache.DNSCache) DeriveFunction {
 	)
 }
 
+// NOTE: NetFlowSummary is derived from net_flow_stats_base events, submitted by the sock_ops
+// program, not from net_packet_XXX ones.
+
+func NetFlowSummary(cache *dnscache.DNSCache) DeriveFunction {
+	return deriveSingleEvent(events.NetFlowSummary,
+		func(event trace.Event) ([]interface{}, error) {
+			direction, err := trace.ArgValue[uint8](&event, "direction")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			family, err := event.ArgUint("family")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			localIP, err := pickFlowStatsIP(event, "local_addr", family)
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			remoteIP, err := pickFlowStatsIP(event, "remote_addr", family)
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			localPort, err := event.ArgUint("local_port")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			remotePort, err := event.ArgUint("remote_port")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			// The flow is oriented from the side initiating the connection, the counters
+			// are given from the traced task side (in: received, out: sent).
+			connectionDirection := ""
+			srcIP, dstIP := localIP, remoteIP
+			srcPort, dstPort := uint16(localPort), uint16(remotePort)
+			switch direction {
+			case flowStatsOutgoing:
+				connectionDirection = directionOutgoing
+			case flowStatsIncoming:
+				connectionDirection = directionIncoming
+				srcIP, dstIP, srcPort, dstPort = swapSrcDst(srcIP, dstIP, srcPort, dstPort)
+			default:
+				logger.Debugw("wrong flow direction", "id", event.EventID)
+				return nil, nil
+			}
+
+			var counters [5]uint64
+			for i, name := range []string{"duration", "bytes_in", "bytes_out", "packets_in", "packets_out"} {
+				counters[i], err = event.ArgUlong(name)
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+			}
+			closed, err := trace.ArgValue[bool](&event, "closed")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			// Pick the src and dst IP addresses domain names from the DNS cache
+			srcDomains := getDomainsFromCache(srcIP, cache)
+			dstDomains := getDomainsFromCache(dstIP, cache)
+
+			// Return the derived event arguments.
+			return []interface{}{
+				connectionDirection,
+				"TCP",
+				srcIP,
+				dstIP,
+				srcPort,
+				dstPort,
+				srcDomains,
+				dstDomains,
+				counters[0],
+				counters[1],
+				counters[2],
+				counters[3],
+				counters[4],
+				closed,
+			}, nil
+		},
+	)
+}
+
+// pickFlowStatsIP returns the IP address of the given flow stats address argument, which
+// always holds 16 bytes (IPv4 addresses are held in the first 4 bytes).
+func pickFlowStatsIP(event trace.Event, argName string, family uint32) (net.IP, error) {
+	addr, err := trace.ArgValue[[]byte](&event, argName)
+	if err != nil {
+		return nil, err
+	}
+	if len(addr) != net.IPv6len {
+		return nil, errfmt.Errorf("wrong %s length: %d", argName, len(addr))
+	}
+
+	switch family {
+	case afInet:
+		return net.IPv4(addr[0], addr[1], addr[2], addr[3]), nil
+	case afInet6:
+		return net.IP(addr), nil
+	}
+
+	return nil, errfmt.Errorf("unsupported address family: %d", family)
+}
+
 // func NetFlowUDPBegin(cache *dnscache.DNSCache) DeriveFunction {
 // 	return deriveSingleEvent(events.NetFlowUDPBegin,
 // 		func(event trace.Event) ([]interface{}, error) {
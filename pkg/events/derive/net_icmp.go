@@ -0,0 +1,216 @@
+package derive
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// NOTE: Derived from both net_packet_icmp_base and net_packet_icmpv6_base events.
+
+const (
+	icmpLargePayloadSize  = 128         // echo payloads above this size (ping default: 56 bytes)
+	icmpEchoFloodCount    = 100         // echo requests sent to a destination within a window
+	icmpEchoFloodWindow   = time.Second // window the echo requests are counted in
+	icmpEchoFloodMaxPeers = 4096        // task and destination pairs being counted
+)
+
+// icmpEcho is an ICMP (or ICMPv6) echo request or reply.
+type icmpEcho struct {
+	src         string
+	dst         string
+	kind        string
+	request     bool
+	id          uint16
+	seq         uint16
+	payloadSize uint32
+}
+
+// ICMPLargePayload derives an icmp_large_payload event from ICMP echo requests and
+// replies carrying more data than regular pings do (e.g. ICMP tunneling).
+func ICMPLargePayload() DeriveFunction {
+	return deriveSingleEvent(events.ICMPLargePayload,
+		func(event trace.Event) ([]interface{}, error) {
+			echo, err := getICMPEchoFromEvent(&event)
+			if err != nil {
+				return nil, err
+			}
+			if echo == nil || echo.payloadSize <= icmpLargePayloadSize {
+				return nil, nil
+			}
+
+			return []interface{}{
+				echo.src,
+				echo.dst,
+				trace.PacketMetadata{
+					Direction: getPacketDirection(&event),
+				},
+				echo.kind,
+				echo.id,
+				echo.seq,
+				echo.payloadSize,
+			}, nil
+		},
+	)
+}
+
+type icmpEchoPeer struct {
+	hostPid int
+	src     string
+	dst     string
+}
+
+type icmpEchoWindow struct {
+	start int
+	count uint32
+}
+
+// ICMPEchoFlood derives an icmp_echo_flood event when a task sends icmpEchoFloodCount
+// echo requests to the same destination within icmpEchoFloodWindow (once per window).
+func ICMPEchoFlood() DeriveFunction {
+	windows := make(map[icmpEchoPeer]*icmpEchoWindow)
+
+	return deriveSingleEvent(events.ICMPEchoFlood,
+		func(event trace.Event) ([]interface{}, error) {
+			if getPacketDirection(&event) != trace.PacketEgress {
+				return nil, nil
+			}
+			echo, err := getICMPEchoFromEvent(&event)
+			if err != nil {
+				return nil, err
+			}
+			if echo == nil || !echo.request {
+				return nil, nil
+			}
+
+			peer := icmpEchoPeer{hostPid: event.HostProcessID, src: echo.src, dst: echo.dst}
+			window, ok := windows[peer]
+			if !ok || event.Timestamp-window.start >= int(icmpEchoFloodWindow) {
+				if !ok && len(windows) >= icmpEchoFloodMaxPeers {
+					pruneICMPEchoWindows(windows, event.Timestamp)
+				}
+				window = &icmpEchoWindow{start: event.Timestamp}
+				windows[peer] = window
+			}
+			window.count++
+			if window.count != icmpEchoFloodCount {
+				return nil, nil
+			}
+
+			return []interface{}{
+				echo.src,
+				echo.dst,
+				window.count,
+				uint64(event.Timestamp - window.start),
+			}, nil
+		},
+	)
+}
+
+// pruneICMPEchoWindows removes the expired windows (or all of them, if none expired).
+func pruneICMPEchoWindows(windows map[icmpEchoPeer]*icmpEchoWindow, now int) {
+	for peer, window := range windows {
+		if now-window.start >= int(icmpEchoFloodWindow) {
+			delete(windows, peer)
+		}
+	}
+	if len(windows) >= icmpEchoFloodMaxPeers {
+		clear(windows)
+	}
+}
+
+// getICMPEchoFromEvent returns the ICMP or ICMPv6 echo request or reply of the event
+// packet, or nil if the packet isn't an echo request or reply.
+func getICMPEchoFromEvent(event *trace.Event) (*icmpEcho, error) {
+	packet, err := createPacketFromEvent(event)
+	if err != nil {
+		return nil, err
+	}
+
+	switch events.ID(event.EventID) {
+	case events.NetPacketICMPBase:
+		return getICMPEchoFromPacket(packet)
+	case events.NetPacketICMPv6Base:
+		return getICMPv6EchoFromPacket(packet)
+	}
+
+	return nil, fmt.Errorf("unexpected event: %s", event.EventName)
+}
+
+// getICMPEchoFromPacket returns the ICMP echo request or reply of the packet.
+func getICMPEchoFromPacket(packet gopacket.Packet) (*icmpEcho, error) {
+	layer3IP, err := getLayer3IPv4FromPacket(packet)
+	if err != nil {
+		return nil, err
+	}
+	layerICMP, err := getLayerICMPFromPacket(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	switch layerICMP.TypeCode.Type() {
+	case layers.ICMPv4TypeEchoRequest, layers.ICMPv4TypeEchoReply:
+	default:
+		return nil, nil
+	}
+
+	// the packet payload might be truncated, the IP header holds its actual size
+	payloadSize := int(layer3IP.Length) - int(layer3IP.IHL)*4 - 8 // ICMP echo header
+	if payloadSize < 0 {
+		payloadSize = 0
+	}
+
+	return &icmpEcho{
+		src:         layer3IP.SrcIP.String(),
+		dst:         layer3IP.DstIP.String(),
+		kind:        layerICMP.TypeCode.String(),
+		request:     layerICMP.TypeCode.Type() == layers.ICMPv4TypeEchoRequest,
+		id:          layerICMP.Id,
+		seq:         layerICMP.Seq,
+		payloadSize: uint32(payloadSize),
+	}, nil
+}
+
+// getICMPv6EchoFromPacket returns the ICMPv6 echo request or reply of the packet.
+func getICMPv6EchoFromPacket(packet gopacket.Packet) (*icmpEcho, error) {
+	layer3IP, err := getLayer3IPv6FromPacket(packet)
+	if err != nil {
+		return nil, err
+	}
+	layerICMPv6, err := getLayerICMPv6FromPacket(packet)
+	if err != nil {
+		return nil, err
+	}
+
+	switch layerICMPv6.TypeCode.Type() {
+	case layers.ICMPv6TypeEchoRequest, layers.ICMPv6TypeEchoReply:
+	default:
+		return nil, nil
+	}
+
+	layerEcho, ok := packet.Layer(layers.LayerTypeICMPv6Echo).(*layers.ICMPv6Echo)
+	if !ok {
+		return nil, fmt.Errorf("wrong layer protocol type")
+	}
+
+	// the packet payload might be truncated, the IP header holds its actual size
+	payloadSize := int(layer3IP.Length) - 8 // ICMPv6 echo header
+	if payloadSize < 0 {
+		payloadSize = 0
+	}
+
+	return &icmpEcho{
+		src:         layer3IP.SrcIP.String(),
+		dst:         layer3IP.DstIP.String(),
+		kind:        layerICMPv6.TypeCode.String(),
+		request:     layerICMPv6.TypeCode.Type() == layers.ICMPv6TypeEchoRequest,
+		id:          layerEcho.Identifier,
+		seq:         layerEcho.SeqNumber,
+		payloadSize: uint32(payloadSize),
+	}, nil
+}
@@ -0,0 +1,154 @@
+package derive
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newICMPEchoEvent(t *testing.T, ipv6 bool, request bool, payloadSize int, timestamp int) trace.Event {
+	t.Helper()
+
+	src, dst := net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")
+	if ipv6 {
+		src, dst = net.ParseIP("fd00::1"), net.ParseIP("fd00::2")
+	}
+
+	var toSerialize []gopacket.SerializableLayer
+	event := trace.Event{
+		HostProcessID: 1000,
+		Timestamp:     timestamp,
+		ReturnValue:   packetEgress,
+	}
+
+	if ipv6 {
+		ip := &layers.IPv6{Version: 6, NextHeader: layers.IPProtocolICMPv6, HopLimit: 64, SrcIP: src, DstIP: dst}
+		icmp := &layers.ICMPv6{TypeCode: layers.CreateICMPv6TypeCode(layers.ICMPv6TypeEchoReply, 0)}
+		if request {
+			icmp.TypeCode = layers.CreateICMPv6TypeCode(layers.ICMPv6TypeEchoRequest, 0)
+		}
+		require.NoError(t, icmp.SetNetworkLayerForChecksum(ip))
+		toSerialize = []gopacket.SerializableLayer{ip, icmp, &layers.ICMPv6Echo{Identifier: 7, SeqNumber: 1}}
+		event.EventID = int(events.NetPacketICMPv6Base)
+		event.EventName = "net_packet_icmpv6_base"
+		event.ReturnValue |= familyIPv6
+	} else {
+		ip := &layers.IPv4{Version: 4, IHL: 5, TTL: 64, Protocol: layers.IPProtocolICMPv4, SrcIP: src, DstIP: dst}
+		icmp := &layers.ICMPv4{TypeCode: layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoReply, 0), Id: 7, Seq: 1}
+		if request {
+			icmp.TypeCode = layers.CreateICMPv4TypeCode(layers.ICMPv4TypeEchoRequest, 0)
+		}
+		toSerialize = []gopacket.SerializableLayer{ip, icmp}
+		event.EventID = int(events.NetPacketICMPBase)
+		event.EventName = "net_packet_icmp_base"
+		event.ReturnValue |= familyIPv4
+	}
+	toSerialize = append(toSerialize, gopacket.Payload(make([]byte, payloadSize)))
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{FixLengths: true, ComputeChecksums: true}
+	require.NoError(t, gopacket.SerializeLayers(buf, opts, toSerialize...))
+
+	event.Args = []trace.Argument{
+		{ArgMeta: trace.ArgMeta{Name: "payload", Type: "bytes"}, Value: buf.Bytes()},
+	}
+
+	return event
+}
+
+func TestICMPLargePayload(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		ipv6        bool
+		request     bool
+		payloadSize int
+		expected    []interface{}
+	}{
+		{
+			name:        "regular ping request",
+			request:     true,
+			payloadSize: 56,
+		},
+		{
+			name:        "large echo request",
+			request:     true,
+			payloadSize: 1024,
+			expected:    []interface{}{"10.0.0.1", "10.0.0.2", "EchoRequest", uint16(7), uint16(1), uint32(1024)},
+		},
+		{
+			name:        "large echo reply",
+			payloadSize: 512,
+			expected:    []interface{}{"10.0.0.1", "10.0.0.2", "EchoReply", uint16(7), uint16(1), uint32(512)},
+		},
+		{
+			name:        "large icmpv6 echo request",
+			ipv6:        true,
+			request:     true,
+			payloadSize: 1024,
+			expected:    []interface{}{"fd00::1", "fd00::2", "EchoRequest", uint16(7), uint16(1), uint32(1024)},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := ICMPLargePayload()(newICMPEchoEvent(t, tc.ipv6, tc.request, tc.payloadSize, 0))
+			require.Empty(t, errs)
+			if tc.expected == nil {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			args := derived[0].Args
+			assert.Equal(t, tc.expected[0], args[0].Value) // src
+			assert.Equal(t, tc.expected[1], args[1].Value) // dst
+			assert.Equal(t, trace.PacketEgress, args[2].Value.(trace.PacketMetadata).Direction)
+			assert.Equal(t, tc.expected[2], args[3].Value) // type
+			assert.Equal(t, tc.expected[3], args[4].Value) // id
+			assert.Equal(t, tc.expected[4], args[5].Value) // seq
+			assert.Equal(t, tc.expected[5], args[6].Value) // payload_size
+		})
+	}
+}
+
+func TestICMPEchoFlood(t *testing.T) {
+	t.Parallel()
+
+	deriveFlood := ICMPEchoFlood()
+	step := int(icmpEchoFloodWindow) / (2 * icmpEchoFloodCount) // twice the flood rate
+
+	// echo replies are not counted
+	for i := 0; i < icmpEchoFloodCount; i++ {
+		derived, errs := deriveFlood(newICMPEchoEvent(t, false, false, 56, i*step))
+		require.Empty(t, errs)
+		require.Empty(t, derived)
+	}
+
+	// the flood is reported once per window
+	var reported []trace.Event
+	for i := 0; i < 4*icmpEchoFloodCount; i++ {
+		derived, errs := deriveFlood(newICMPEchoEvent(t, false, true, 56, i*step))
+		require.Empty(t, errs)
+		reported = append(reported, derived...)
+	}
+	require.Len(t, reported, 2)
+
+	args := reported[0].Args
+	assert.Equal(t, "10.0.0.1", args[0].Value)
+	assert.Equal(t, "10.0.0.2", args[1].Value)
+	assert.Equal(t, uint32(icmpEchoFloodCount), args[2].Value)
+	assert.Equal(t, uint64((icmpEchoFloodCount-1)*step), args[3].Value)
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/aquasecurity/tracee/pkg/dnscache"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -58,7 +57,7 @@ func pickIpAndPort(event trace.Event, fieldName string) (string, int, error) {
 	// e.g: sockaddr: map[sa_family:AF_INET sin_addr:10.10.11.2 sin_port:1234]
 
 	// Check if socket is a TCP socket.
-	sType, err := parse.ArgVal[string](event.Args, "type")
+	sType, err := event.ArgString("type")
 	if err != nil {
 		return "", 0, errfmt.WrapError(err)
 	}
@@ -67,7 +66,7 @@ func pickIpAndPort(event trace.Event, fieldName string) (string, int, error) {
 	}
 
 	// Get sockaddr field.
-	sockaddr, err := parse.ArgVal[map[string]string](event.Args, fieldName)
+	sockaddr, err := trace.ArgValue[map[string]string](&event, fieldName)
 	if err != nil {
 		return "", 0, errfmt.WrapError(err)
 	}
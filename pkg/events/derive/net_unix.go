@@ -0,0 +1,88 @@
+package derive
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// NOTE: Derived from security_socket_XXX events, not from net_packet_XXX ones.
+
+func UnixSocketConnect() DeriveFunction {
+	return deriveSingleEvent(events.UnixSocketConnect,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := pickUnixSocketPath(event, "remote_addr")
+			if err != nil {
+				logger.Debugw("error picking address", "error", err)
+				return nil, nil
+			}
+			if path == "" {
+				return nil, nil // not an AF_UNIX (or an unnamed) socket
+			}
+			sockfd, err := event.ArgInt("sockfd")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			sType, err := event.ArgInt("type")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			return []interface{}{
+				sockfd,
+				sType,
+				strings.TrimPrefix(path, "@"),
+				strings.HasPrefix(path, "@"),
+			}, nil
+		},
+	)
+}
+
+func UnixSocketListen() DeriveFunction {
+	return deriveSingleEvent(events.UnixSocketListen,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := pickUnixSocketPath(event, "local_addr")
+			if err != nil {
+				logger.Debugw("error picking address", "error", err)
+				return nil, nil
+			}
+			if path == "" {
+				return nil, nil // not an AF_UNIX (or an unnamed) socket
+			}
+			sockfd, err := event.ArgInt("sockfd")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			backlog, err := event.ArgInt("backlog")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			return []interface{}{
+				sockfd,
+				strings.TrimPrefix(path, "@"),
+				strings.HasPrefix(path, "@"),
+				backlog,
+			}, nil
+		},
+	)
+}
+
+// pickUnixSocketPath returns the sun_path of the event's sockaddr field (abstract socket
+// names are prefixed by "@"), or an empty string if it isn't an AF_UNIX address.
+func pickUnixSocketPath(event trace.Event, fieldName string) (string, error) {
+	// e.g: sockaddr: map[sa_family:AF_UNIX sun_path:/var/run/docker.sock]
+
+	sockaddr, err := trace.ArgValue[map[string]string](&event, fieldName)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	if sockaddr["sa_family"] != "AF_UNIX" {
+		return "", nil
+	}
+
+	return sockaddr["sun_path"], nil
+}
@@ -0,0 +1,87 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newSocketConnectEvent(sockaddr map[string]string) trace.Event {
+	return trace.Event{
+		EventID:   int(events.SecuritySocketConnect),
+		EventName: "security_socket_connect",
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "sockfd", Type: "int"}, Value: int32(3)},
+			{ArgMeta: trace.ArgMeta{Name: "type", Type: "int"}, Value: int32(1)},
+			{ArgMeta: trace.ArgMeta{Name: "remote_addr", Type: "struct sockaddr*"}, Value: sockaddr},
+		},
+	}
+}
+
+func TestUnixSocketConnect(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		sockaddr         map[string]string
+		expectedPath     string
+		expectedAbstract bool
+		expectedNoEvents bool
+	}{
+		{
+			name:         "pathname socket",
+			sockaddr:     map[string]string{"sa_family": "AF_UNIX", "sun_path": "/var/run/docker.sock"},
+			expectedPath: "/var/run/docker.sock",
+		},
+		{
+			name:             "abstract socket",
+			sockaddr:         map[string]string{"sa_family": "AF_UNIX", "sun_path": "@/containerd-shim/k8s.io.sock"},
+			expectedPath:     "/containerd-shim/k8s.io.sock",
+			expectedAbstract: true,
+		},
+		{
+			name:             "unnamed socket",
+			sockaddr:         map[string]string{"sa_family": "AF_UNIX", "sun_path": ""},
+			expectedNoEvents: true,
+		},
+		{
+			name:             "inet socket",
+			sockaddr:         map[string]string{"sa_family": "AF_INET", "sin_addr": "10.0.0.1", "sin_port": "80"},
+			expectedNoEvents: true,
+		},
+	}
+
+	deriveFn := UnixSocketConnect()
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(newSocketConnectEvent(tc.sockaddr))
+			require.Empty(t, errs)
+			if tc.expectedNoEvents {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.UnixSocketConnect), event.EventID)
+			sockType, err := parse.ArgVal[int32](event.Args, "type")
+			require.NoError(t, err)
+			assert.Equal(t, int32(1), sockType)
+			path, err := parse.ArgVal[string](event.Args, "path")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, path)
+			abstract, err := parse.ArgVal[bool](event.Args, "abstract")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAbstract, abstract)
+		})
+	}
+}
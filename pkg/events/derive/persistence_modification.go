@@ -0,0 +1,174 @@
+package derive
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// persistence_modification techniques
+const (
+	persistenceCron         = "cron"
+	persistenceAt           = "at"
+	persistenceSystemd      = "systemd"
+	persistenceShellProfile = "shell_profile"
+)
+
+// file modification operations
+const (
+	fileOperationWrite  = "write"  // opened for writing
+	fileOperationRename = "rename" // replaced by a rename
+)
+
+// persistenceFiles are the files run by a scheduler, or by every new (login) shell.
+var persistenceFiles = map[string]string{
+	"/etc/crontab":      persistenceCron,
+	"/etc/anacrontab":   persistenceCron,
+	"/etc/profile":      persistenceShellProfile,
+	"/etc/bash.bashrc":  persistenceShellProfile,
+	"/etc/bashrc":       persistenceShellProfile,
+	"/etc/zshrc":        persistenceShellProfile,
+	"/etc/zsh/zshrc":    persistenceShellProfile,
+	"/etc/zsh/zprofile": persistenceShellProfile,
+	"/etc/environment":  persistenceShellProfile,
+}
+
+// persistenceDirs are the directories whose files (at any depth) are run by a scheduler, or
+// by every new (login) shell.
+var persistenceDirs = map[string]string{
+	"/etc/cron.d":              persistenceCron,
+	"/etc/cron.hourly":         persistenceCron,
+	"/etc/cron.daily":          persistenceCron,
+	"/etc/cron.weekly":         persistenceCron,
+	"/etc/cron.monthly":        persistenceCron,
+	"/var/spool/cron":          persistenceCron,
+	"/var/spool/anacron":       persistenceCron,
+	"/var/spool/at":            persistenceAt,
+	"/var/spool/cron/atjobs":   persistenceAt,
+	"/etc/systemd/system":      persistenceSystemd,
+	"/etc/systemd/user":        persistenceSystemd,
+	"/run/systemd/system":      persistenceSystemd,
+	"/lib/systemd/system":      persistenceSystemd,
+	"/usr/lib/systemd/system":  persistenceSystemd,
+	"/usr/lib/systemd/user":    persistenceSystemd,
+	"/usr/local/lib/systemd":   persistenceSystemd,
+	"/etc/profile.d":           persistenceShellProfile,
+	"/etc/update-motd.d":       persistenceShellProfile,
+	"/usr/local/etc/profile.d": persistenceShellProfile,
+}
+
+// persistenceHomeFiles are the files, relative to the users home directories, run by every
+// new (login) shell of the user.
+var persistenceHomeFiles = map[string]string{
+	".profile":      persistenceShellProfile,
+	".bashrc":       persistenceShellProfile,
+	".bash_profile": persistenceShellProfile,
+	".bash_login":   persistenceShellProfile,
+	".bash_logout":  persistenceShellProfile,
+	".zshrc":        persistenceShellProfile,
+	".zprofile":     persistenceShellProfile,
+	".zlogin":       persistenceShellProfile,
+}
+
+// persistenceHomeDirs are the directories, relative to the users home directories, whose files
+// are run by the user scheduler.
+var persistenceHomeDirs = map[string]string{
+	".config/systemd/user": persistenceSystemd,
+}
+
+// PersistenceModification derives a persistence_modification event from files run by a
+// scheduler (cron, at, systemd) or by new shells being opened for writing or replaced by a
+// rename, with the lineage of the process modifying them.
+func PersistenceModification(tree *proctree.ProcessTree) DeriveFunction {
+	return deriveSingleEvent(events.PersistenceModification,
+		func(event trace.Event) ([]interface{}, error) {
+			var path, operation string
+
+			switch events.ID(event.EventID) {
+			case events.SecurityFileOpen:
+				flags, err := event.ArgInt("flags")
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+				if flags&(unix.O_WRONLY|unix.O_RDWR) == 0 {
+					return nil, nil
+				}
+				path, err = event.ArgString("pathname")
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+				operation = fileOperationWrite
+			case events.SecurityInodeRename:
+				var err error
+				path, err = event.ArgString("new_path")
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+				operation = fileOperationRename
+			default:
+				return nil, nil
+			}
+
+			technique := classifyPersistencePath(path)
+			if technique == "" {
+				return nil, nil
+			}
+
+			return []interface{}{
+				technique,
+				path,
+				operation,
+				processLineage(tree, &event),
+			}, nil
+		},
+	)
+}
+
+// classifyPersistencePath returns the persistence technique the given file is used by, or an
+// empty string if it isn't a persistence file.
+func classifyPersistencePath(path string) string {
+	if !filepath.IsAbs(path) {
+		return ""
+	}
+	path = filepath.Clean(path)
+
+	if technique, ok := persistenceFiles[path]; ok {
+		return technique
+	}
+	for dir := filepath.Dir(path); dir != "/"; dir = filepath.Dir(dir) {
+		if technique, ok := persistenceDirs[dir]; ok {
+			return technique
+		}
+	}
+
+	// users home directories: /root/<file> and /home/<user>/<file>
+	var homeRel string
+	switch {
+	case strings.HasPrefix(path, "/root/"):
+		homeRel = strings.TrimPrefix(path, "/root/")
+	case strings.HasPrefix(path, "/home/"):
+		_, rel, found := strings.Cut(strings.TrimPrefix(path, "/home/"), "/")
+		if !found {
+			return ""
+		}
+		homeRel = rel
+	default:
+		return ""
+	}
+	if technique, ok := persistenceHomeFiles[homeRel]; ok {
+		return technique
+	}
+	for dir, technique := range persistenceHomeDirs {
+		if strings.HasPrefix(homeRel, dir+"/") {
+			return technique
+		}
+	}
+
+	return ""
+}
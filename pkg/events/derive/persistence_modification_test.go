@@ -0,0 +1,125 @@
+package derive
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestClassifyPersistencePath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path     string
+		expected string
+	}{
+		{"/etc/crontab", persistenceCron},
+		{"/etc/cron.d/backdoor", persistenceCron},
+		{"/var/spool/cron/crontabs/root", persistenceCron},
+		{"/var/spool/cron/atjobs/a0001", persistenceAt},
+		{"/etc/systemd/system/evil.service", persistenceSystemd},
+		{"/etc/systemd/system/multi-user.target.wants/evil.service", persistenceSystemd},
+		{"/home/alice/.config/systemd/user/evil.timer", persistenceSystemd},
+		{"/etc/profile.d/evil.sh", persistenceShellProfile},
+		{"/root/.bashrc", persistenceShellProfile},
+		{"/home/alice/.zshrc", persistenceShellProfile},
+		{"/home/alice/projects/.bashrc", ""},
+		{"/etc/cron.d", ""},
+		{"/etc/passwd", ""},
+		{"relative/.bashrc", ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, classifyPersistencePath(tc.path))
+		})
+	}
+}
+
+func TestPersistenceModification(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		event             trace.Event
+		expectedTechnique string
+		expectedPath      string
+		expectedOperation string
+	}{
+		{
+			name: "crontab opened for writing",
+			event: trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/etc/crontab"},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: int32(os.O_WRONLY | os.O_APPEND)},
+				},
+			},
+			expectedTechnique: persistenceCron,
+			expectedPath:      "/etc/crontab",
+			expectedOperation: fileOperationWrite,
+		},
+		{
+			name: "crontab opened for reading",
+			event: trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/etc/crontab"},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: int32(os.O_RDONLY)},
+				},
+			},
+		},
+		{
+			name: "systemd unit renamed",
+			event: trace.Event{
+				EventID: int(events.SecurityInodeRename),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "old_path"}, Value: "/tmp/evil.service"},
+					{ArgMeta: trace.ArgMeta{Name: "new_path"}, Value: "/etc/systemd/system/evil.service"},
+				},
+			},
+			expectedTechnique: persistenceSystemd,
+			expectedPath:      "/etc/systemd/system/evil.service",
+			expectedOperation: fileOperationRename,
+		},
+	}
+
+	deriveFn := PersistenceModification(nil)
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(tc.event)
+			require.Empty(t, errs)
+			if tc.expectedTechnique == "" {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.PersistenceModification), event.EventID)
+			technique, err := parse.ArgVal[string](event.Args, "technique")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTechnique, technique)
+			path, err := parse.ArgVal[string](event.Args, "pathname")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPath, path)
+			operation, err := parse.ArgVal[string](event.Args, "operation")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOperation, operation)
+		})
+	}
+}
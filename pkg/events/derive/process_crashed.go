@@ -0,0 +1,137 @@
+package derive
+
+import (
+	"syscall"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/recent"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// NOTE: Derived from the core_dump and sched_process_exit events.
+
+const maxPendingCoreDumps = 1024 // core dumps of processes not exited yet
+
+const (
+	exitSignalMask = 0x7f // exit code bits of the signal that killed the process
+	exitCoreDumped = 0x80 // exit code bit of a dumped core
+	siKernel       = 0x80 // si_code of the signals sent by the kernel, without a fault address
+)
+
+// coreDump is the core dump of a crashing process, waiting for the process to exit.
+type coreDump struct {
+	code         int32
+	faultAddress uintptr
+	corePattern  string
+}
+
+// ProcessCrashed derives a process_crashed event from the exit of a process killed by a signal
+// whose default action is to dump core (e.g. SIGSEGV, SIGABRT), even if no core was dumped.
+// The core dump (when the kernel started it) gives the faulting address and the core_pattern
+// the core was handed to, and the recorder the most recent events of the process.
+func ProcessCrashed(recorder *recent.Recorder) DeriveFunction {
+	pending := make(map[int]coreDump)
+
+	return deriveSingleEvent(events.ProcessCrashed,
+		func(event trace.Event) ([]interface{}, error) {
+			switch events.ID(event.EventID) {
+			case events.CoreDump:
+				dump, err := parseCoreDump(event)
+				if err != nil {
+					return nil, errfmt.WrapError(err)
+				}
+				if len(pending) >= maxPendingCoreDumps {
+					clear(pending) // lost exits
+				}
+				pending[event.HostProcessID] = dump
+				return nil, nil
+			case events.SchedProcessExit:
+			default:
+				return nil, nil
+			}
+
+			groupExit, err := trace.ArgValue[bool](&event, "process_group_exit")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			if !groupExit {
+				return nil, nil // a thread exited
+			}
+			exitCode, err := event.ArgLong("exit_code")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			dump, dumped := pending[event.HostProcessID]
+			delete(pending, event.HostProcessID)
+			var recentEvents []trace.RecentEvent
+			if recorder != nil {
+				recentEvents = recorder.Take(&event)
+			}
+
+			signal := syscall.Signal(exitCode & exitSignalMask)
+			if exitCode&^(exitSignalMask|exitCoreDumped) != 0 || !isCoreSignal(signal) {
+				return nil, nil // exited, or killed by a non crashing signal
+			}
+			if !dumped {
+				dump = coreDump{} // the core dump probes aren't available
+			}
+
+			return []interface{}{
+				int32(signal),
+				dump.code,
+				dump.faultAddress,
+				exitCode&exitCoreDumped != 0,
+				dump.corePattern,
+				recentEvents,
+			}, nil
+		},
+	)
+}
+
+// parseCoreDump parses a core_dump event, keeping its fault address only if the signal is a
+// fault raised by the kernel.
+func parseCoreDump(event trace.Event) (coreDump, error) {
+	signal, err := event.ArgInt("signal")
+	if err != nil {
+		return coreDump{}, errfmt.WrapError(err)
+	}
+	code, err := event.ArgInt("code")
+	if err != nil {
+		return coreDump{}, errfmt.WrapError(err)
+	}
+	faultAddress, err := trace.ArgValue[uintptr](&event, "fault_address")
+	if err != nil {
+		return coreDump{}, errfmt.WrapError(err)
+	}
+	corePattern, _ := event.ArgString("core_pattern") // the symbol is optional
+
+	dump := coreDump{code: code, corePattern: corePattern}
+	if isFaultSignal(syscall.Signal(signal)) && code > 0 && code != siKernel {
+		dump.faultAddress = faultAddress
+	}
+
+	return dump, nil
+}
+
+// isCoreSignal returns whether the default action of a signal is to dump core.
+func isCoreSignal(signal syscall.Signal) bool {
+	switch signal {
+	case syscall.SIGQUIT, syscall.SIGILL, syscall.SIGTRAP, syscall.SIGABRT, syscall.SIGBUS,
+		syscall.SIGFPE, syscall.SIGSEGV, syscall.SIGXCPU, syscall.SIGXFSZ, syscall.SIGSYS:
+		return true
+	}
+
+	return false
+}
+
+// isFaultSignal returns whether a signal reports a fault, at an address.
+func isFaultSignal(signal syscall.Signal) bool {
+	switch signal {
+	case syscall.SIGILL, syscall.SIGTRAP, syscall.SIGBUS, syscall.SIGFPE, syscall.SIGSEGV:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,183 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/events/recent"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newCoreDumpEvent(pid int, signal, code int32, addr uintptr) trace.Event {
+	return trace.Event{
+		EventID:         int(events.CoreDump),
+		EventName:       "core_dump",
+		HostProcessID:   pid,
+		ProcessEntityId: uint32(pid),
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "signal", Type: "int"}, Value: signal},
+			{ArgMeta: trace.ArgMeta{Name: "code", Type: "int"}, Value: code},
+			{ArgMeta: trace.ArgMeta{Name: "fault_address", Type: "void*"}, Value: addr},
+			{ArgMeta: trace.ArgMeta{Name: "core_pattern", Type: "const char*"}, Value: "|/usr/lib/systemd/systemd-coredump %P"},
+		},
+	}
+}
+
+func newProcessExitEvent(pid int, exitCode int64, groupExit bool) trace.Event {
+	return trace.Event{
+		EventID:         int(events.SchedProcessExit),
+		EventName:       "sched_process_exit",
+		HostProcessID:   pid,
+		ProcessEntityId: uint32(pid),
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "exit_code", Type: "long"}, Value: exitCode},
+			{ArgMeta: trace.ArgMeta{Name: "process_group_exit", Type: "bool"}, Value: groupExit},
+		},
+	}
+}
+
+func TestProcessCrashed(t *testing.T) {
+	t.Parallel()
+
+	const segvMapErr = 1 // SEGV_MAPERR
+
+	testCases := []struct {
+		name                string
+		events              []trace.Event
+		expectedSignal      int32
+		expectedCode        int32
+		expectedAddress     uintptr
+		expectedCoreDumped  bool
+		expectedCorePattern string
+	}{
+		{
+			name: "segmentation fault with core dumped",
+			events: []trace.Event{
+				newCoreDumpEvent(1000, 11, segvMapErr, 0xdead),
+				newProcessExitEvent(1000, 0x80|11, true),
+			},
+			expectedSignal:      11,
+			expectedCode:        segvMapErr,
+			expectedAddress:     0xdead,
+			expectedCoreDumped:  true,
+			expectedCorePattern: "|/usr/lib/systemd/systemd-coredump %P",
+		},
+		{
+			name: "abort without fault address",
+			events: []trace.Event{
+				newCoreDumpEvent(1000, 6, -6 /* SI_TKILL */, 0x3e8),
+				newProcessExitEvent(1000, 6, true),
+			},
+			expectedSignal:      6,
+			expectedCode:        -6,
+			expectedCorePattern: "|/usr/lib/systemd/systemd-coredump %P",
+		},
+		{
+			name:           "crash without core dump event",
+			events:         []trace.Event{newProcessExitEvent(1000, 7, true)},
+			expectedSignal: 7,
+		},
+		{
+			name: "thread exit",
+			events: []trace.Event{
+				newCoreDumpEvent(1000, 11, segvMapErr, 0xdead),
+				newProcessExitEvent(1000, 0x80|11, false),
+			},
+		},
+		{
+			name:   "killed",
+			events: []trace.Event{newProcessExitEvent(1000, 9, true)},
+		},
+		{
+			name:   "exited",
+			events: []trace.Event{newProcessExitEvent(1000, 11<<8, true)},
+		},
+		{
+			name: "another process exited",
+			events: []trace.Event{
+				newCoreDumpEvent(1000, 11, segvMapErr, 0xdead),
+				newProcessExitEvent(2000, 0, true),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deriveFn := ProcessCrashed(nil)
+
+			var derived []trace.Event
+			for _, event := range tc.events {
+				evts, errs := deriveFn(event)
+				require.Empty(t, errs)
+				derived = append(derived, evts...)
+			}
+
+			if tc.expectedSignal == 0 {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+			evt := derived[0]
+			assert.Equal(t, int(events.ProcessCrashed), evt.EventID)
+
+			signal, err := parse.ArgVal[int32](evt.Args, "signal")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSignal, signal)
+			code, err := parse.ArgVal[int32](evt.Args, "code")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCode, code)
+			addr, err := parse.ArgVal[uintptr](evt.Args, "fault_address")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAddress, addr)
+			coreDumped, err := parse.ArgVal[bool](evt.Args, "core_dumped")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCoreDumped, coreDumped)
+			corePattern, err := parse.ArgVal[string](evt.Args, "core_pattern")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCorePattern, corePattern)
+		})
+	}
+}
+
+func TestProcessCrashedRecentEvents(t *testing.T) {
+	t.Parallel()
+
+	recorder, err := recent.New(recent.DefaultMaxEvents)
+	require.NoError(t, err)
+	deriveFn := ProcessCrashed(recorder)
+
+	var derived []trace.Event
+	for _, event := range []trace.Event{
+		{EventName: "openat", HostProcessID: 1000, ProcessEntityId: 1000},
+		{EventName: "openat", HostProcessID: 2000, ProcessEntityId: 2000},
+		{EventName: "mmap", HostProcessID: 1000, ProcessEntityId: 1000},
+		newCoreDumpEvent(1000, 11, 1, 0xdead),
+		newProcessExitEvent(1000, 11, true),
+	} {
+		event := event
+		recorder.Record(&event) // as recorded by the pipeline, before the derivation
+		evts, errs := deriveFn(event)
+		require.Empty(t, errs)
+		derived = append(derived, evts...)
+	}
+
+	require.Len(t, derived, 1)
+	recentEvents, err := parse.ArgVal[[]trace.RecentEvent](derived[0].Args, "recent_events")
+	require.NoError(t, err)
+	var names []string
+	for _, e := range recentEvents {
+		names = append(names, e.EventName)
+	}
+	assert.Equal(t, []string{"openat", "mmap", "core_dump", "sched_process_exit"}, names)
+
+	// the recent events of an exited process are forgotten
+	assert.Empty(t, recorder.Take(&derived[0]))
+}
@@ -99,6 +99,7 @@ func (gen *ExecFailedGenerator) handleExecBaseEvent(event *trace.Event) (*trace.
 	// We don't have the execution end info - cache current event and wait for it to be received
 	// This is the expected flow, as the execution finished event come chronology after
 	if !ok {
+		event.DecodeArgs() // the arguments of the derived event are those of the cached event
 		gen.baseEvents.Add(event.HostProcessID, event)
 		return nil, nil
 	}
@@ -0,0 +1,166 @@
+package derive
+
+import (
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// NOTE: Derived from ptrace syscall events.
+
+// ptrace requests (defined here since some of them are architecture specific in x/sys/unix)
+const (
+	ptracePokeText  = 4
+	ptracePokeData  = 5
+	ptracePokeUser  = 6
+	ptraceSetRegs   = 13
+	ptraceSetFPRegs = 15
+	ptraceAttach    = 16
+	ptraceDetach    = 17
+	ptraceSetRegSet = 0x4205
+	ptraceSeize     = 0x4206
+)
+
+const ptraceMaxSessions = 4096 // tracer and tracee pairs being followed
+
+// process_injection techniques
+const (
+	injectionShellcode      = "shellcode_injection" // memory written and registers set (e.g. RIP pointed at it)
+	injectionMemoryPatch    = "memory_patching"     // memory written only (e.g. hooked functions)
+	injectionRegisterHijack = "register_hijack"     // registers set only (e.g. calling dlopen from the tracee)
+)
+
+type ptraceSession struct {
+	tracerHostPid int
+	pid           int32
+}
+
+type ptraceSessionState struct {
+	attached   int
+	memWrites  uint32
+	regsWrites uint32
+	firstWrite uintptr
+}
+
+// HostPidFunc translates the ID of a process in the given pid namespace to its ID in the host
+// pid namespace.
+type HostPidFunc func(pidNS int, nsPid int) (int, error)
+
+// ProcessInjection derives a process_injection event from the ptrace requests a tracer makes
+// between attaching to and detaching from a process, when it wrote to the process memory or
+// registers (once per attach and detach sequence). The traced process is given by its pid in
+// the tracer pid namespace, and by its host pid, translated with hostPid.
+func ProcessInjection(hostPid HostPidFunc) DeriveFunction {
+	sessions := make(map[ptraceSession]*ptraceSessionState)
+
+	return deriveSingleEvent(events.ProcessInjection,
+		func(event trace.Event) ([]interface{}, error) {
+			if event.ReturnValue < 0 {
+				return nil, nil // failed request
+			}
+			request, err := event.ArgLong("request")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			pid, err := event.ArgInt("pid")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			session := ptraceSession{tracerHostPid: event.HostProcessID, pid: pid}
+			state, ok := sessions[session]
+
+			switch request {
+			case ptraceAttach, ptraceSeize:
+				if !ok && len(sessions) >= ptraceMaxSessions {
+					prunePtraceSessions(sessions)
+				}
+				sessions[session] = &ptraceSessionState{attached: event.Timestamp}
+				return nil, nil
+			case ptracePokeText, ptracePokeData:
+				if !ok {
+					return nil, nil
+				}
+				if state.memWrites == 0 {
+					addr, err := trace.ArgValue[uintptr](&event, "addr")
+					if err != nil {
+						return nil, errfmt.WrapError(err)
+					}
+					state.firstWrite = addr
+				}
+				state.memWrites++
+				return nil, nil
+			case ptracePokeUser, ptraceSetRegs, ptraceSetFPRegs, ptraceSetRegSet:
+				if ok {
+					state.regsWrites++
+				}
+				return nil, nil
+			case ptraceDetach:
+				if !ok {
+					return nil, nil
+				}
+				delete(sessions, session)
+			default:
+				return nil, nil
+			}
+
+			technique := classifyInjection(state)
+			if technique == "" {
+				return nil, nil // the tracer only read (or debugged) the tracee
+			}
+
+			return []interface{}{
+				targetHostPid(hostPid, event, pid),
+				pid,
+				technique,
+				state.memWrites,
+				state.regsWrites,
+				state.firstWrite,
+				uint64(event.Timestamp - state.attached),
+			}, nil
+		},
+	)
+}
+
+// targetHostPid returns the host pid of a process given by its pid in the pid namespace of
+// the event process, or 0 if it can't be translated (e.g. the process exited).
+func targetHostPid(hostPid HostPidFunc, event trace.Event, nsPid int32) int32 {
+	if event.ProcessID == event.HostProcessID {
+		return nsPid // the event process is in the host pid namespace
+	}
+	pid, err := hostPid(event.PIDNS, int(nsPid))
+	if err != nil {
+		return 0
+	}
+
+	return int32(pid)
+}
+
+// classifyInjection returns the injection technique matching the writes made during a ptrace
+// session, or an empty string if nothing was written.
+func classifyInjection(state *ptraceSessionState) string {
+	switch {
+	case state.memWrites > 0 && state.regsWrites > 0:
+		return injectionShellcode
+	case state.memWrites > 0:
+		return injectionMemoryPatch
+	case state.regsWrites > 0:
+		return injectionRegisterHijack
+	}
+
+	return ""
+}
+
+// prunePtraceSessions removes the sessions attached before the average attach time (e.g.
+// tracers that exited without detaching).
+func prunePtraceSessions(sessions map[ptraceSession]*ptraceSessionState) {
+	var oldest int
+	for _, state := range sessions {
+		oldest += state.attached / len(sessions)
+	}
+	for session, state := range sessions {
+		if state.attached <= oldest {
+			delete(sessions, session)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package derive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newPtraceEvent(tracer int, request int64, pid int32, addr uintptr, timestamp int) trace.Event {
+	return trace.Event{
+		EventID:       int(events.Ptrace),
+		EventName:     "ptrace",
+		ProcessID:     10, // the tracer is in a container
+		HostProcessID: tracer,
+		PIDNS:         4026532000,
+		Timestamp:     timestamp,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "request", Type: "long"}, Value: request},
+			{ArgMeta: trace.ArgMeta{Name: "pid", Type: "pid_t"}, Value: pid},
+			{ArgMeta: trace.ArgMeta{Name: "addr", Type: "void*"}, Value: addr},
+			{ArgMeta: trace.ArgMeta{Name: "data", Type: "void*"}, Value: uintptr(0)},
+		},
+	}
+}
+
+func TestProcessInjection(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		requests          []int64
+		expectedTechnique string
+		expectedMemWrites uint32
+		expectedRegWrites uint32
+	}{
+		{
+			name:              "shellcode injection",
+			requests:          []int64{ptraceAttach, ptracePokeText, ptracePokeText, ptraceSetRegs, ptraceDetach},
+			expectedTechnique: injectionShellcode,
+			expectedMemWrites: 2,
+			expectedRegWrites: 1,
+		},
+		{
+			name:              "memory patching",
+			requests:          []int64{ptraceSeize, ptracePokeData, ptraceDetach},
+			expectedTechnique: injectionMemoryPatch,
+			expectedMemWrites: 1,
+		},
+		{
+			name:              "register hijack",
+			requests:          []int64{ptraceAttach, ptraceSetRegSet, ptraceDetach},
+			expectedTechnique: injectionRegisterHijack,
+			expectedRegWrites: 1,
+		},
+		{
+			name:     "debugging only",
+			requests: []int64{ptraceAttach, 2 /* PTRACE_PEEKDATA */, 7 /* PTRACE_CONT */, ptraceDetach},
+		},
+		{
+			name:     "writes without attach",
+			requests: []int64{ptracePokeText, ptraceSetRegs, ptraceDetach},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			deriveFn := ProcessInjection(func(pidNS int, nsPid int) (int, error) {
+				assert.Equal(t, 4026532000, pidNS)
+				return nsPid + 10000, nil
+			})
+
+			var derived []trace.Event
+			for i, request := range tc.requests {
+				evts, errs := deriveFn(newPtraceEvent(1000, request, 2000, 0x401000, i*1000))
+				require.Empty(t, errs)
+				derived = append(derived, evts...)
+			}
+			if tc.expectedTechnique == "" {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.ProcessInjection), event.EventID)
+			hostPid, err := parse.ArgVal[int32](event.Args, "target_pid")
+			require.NoError(t, err)
+			assert.Equal(t, int32(12000), hostPid)
+			nsPid, err := parse.ArgVal[int32](event.Args, "target_ns_pid")
+			require.NoError(t, err)
+			assert.Equal(t, int32(2000), nsPid)
+			technique, err := parse.ArgVal[string](event.Args, "technique")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTechnique, technique)
+			memWrites, err := parse.ArgVal[uint32](event.Args, "memory_writes")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMemWrites, memWrites)
+			regWrites, err := parse.ArgVal[uint32](event.Args, "registers_writes")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRegWrites, regWrites)
+			duration, err := parse.ArgVal[uint64](event.Args, "duration")
+			require.NoError(t, err)
+			assert.Equal(t, uint64((len(tc.requests)-1)*1000), duration)
+		})
+	}
+}
+
+func TestProcessInjectionTargetHostPid(t *testing.T) {
+	t.Parallel()
+
+	translated := func(int, int) (int, error) { return 12000, nil }
+	untranslated := func(int, int) (int, error) { return 0, errors.New("process not found") }
+
+	event := newPtraceEvent(1000, ptraceDetach, 2000, 0, 0)
+	assert.Equal(t, int32(12000), targetHostPid(translated, event, 2000))
+	assert.Equal(t, int32(0), targetHostPid(untranslated, event, 2000))
+
+	// a tracer of the host pid namespace gives host pids
+	event.ProcessID = event.HostProcessID
+	assert.Equal(t, int32(2000), targetHostPid(untranslated, event, 2000))
+}
@@ -0,0 +1,37 @@
+package derive
+
+import (
+	"strconv"
+
+	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const processLineageMaxDepth = 5 // ancestors given in the derived events lineage arguments
+
+// processLineage returns the ancestors of the event process, from its parent up, as "name[pid]"
+// strings (host pids). It returns nil if the process tree is disabled or doesn't know the process.
+func processLineage(tree *proctree.ProcessTree, event *trace.Event) []string {
+	if tree == nil {
+		return nil
+	}
+	current, found := tree.GetProcessByHash(event.ProcessEntityId)
+	if !found {
+		return nil
+	}
+
+	var lineage []string
+	for depth := 0; depth < processLineageMaxDepth; depth++ {
+		if current.GetInfo().GetNsPid() == 1 {
+			break // init (of the host, or of the container)
+		}
+		current, found = tree.GetProcessByHash(current.GetParentHash())
+		if !found {
+			break
+		}
+		info := current.GetInfo()
+		lineage = append(lineage, info.GetName()+"["+strconv.Itoa(info.GetPid())+"]")
+	}
+
+	return lineage
+}
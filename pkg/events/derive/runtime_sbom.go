@@ -0,0 +1,45 @@
+package derive
+
+import (
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/sbom"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// RuntimeSBOM records the binaries executed (sched_process_exec) and the shared objects
+// loaded (shared_object_loaded) in the containers. It doesn't derive events itself: the
+// runtime_sbom events are reported periodically by the collector.
+func RuntimeSBOM(collector *sbom.Collector) DeriveFunction {
+	return func(event trace.Event) ([]trace.Event, []error) {
+		if collector == nil {
+			return nil, nil
+		}
+
+		var kind sbom.Kind
+		switch events.ID(event.EventID) {
+		case events.SchedProcessExec:
+			kind = sbom.Executable
+		case events.SharedObjectLoaded:
+			kind = sbom.SharedObject
+		default:
+			return nil, nil
+		}
+
+		pathname, err := event.ArgString("pathname")
+		if err != nil {
+			return nil, []error{err}
+		}
+		hash, _ := event.ArgString("sha256") // only if hashes are calculated
+
+		collector.Observe(event.Container, event.Kubernetes, sbom.Component{
+			Path:      pathname,
+			Kind:      kind,
+			SHA256:    hash,
+			FirstSeen: time.Unix(0, int64(event.Timestamp)),
+		})
+
+		return nil, nil
+	}
+}
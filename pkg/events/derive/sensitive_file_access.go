@@ -0,0 +1,79 @@
+package derive
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/proctree"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// sensitive_file_access access modes
+const (
+	fileAccessRead      = "read"
+	fileAccessWrite     = "write"
+	fileAccessReadWrite = "read_write"
+)
+
+// SensitiveFileAccess derives a sensitive_file_access event from files matching one of the
+// given patterns (shell patterns, see filepath.Match) being opened, with the access mode and
+// the lineage of the process opening them.
+func SensitiveFileAccess(patterns []string, tree *proctree.ProcessTree) DeriveFunction {
+	return deriveSingleEvent(events.SensitiveFileAccess,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := event.ArgString("pathname")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			pattern := matchSensitiveFile(patterns, path)
+			if pattern == "" {
+				return nil, nil
+			}
+			flags, err := event.ArgInt("flags")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+
+			return []interface{}{
+				path,
+				pattern,
+				fileAccessMode(flags),
+				flags,
+				processLineage(tree, &event),
+			}, nil
+		},
+	)
+}
+
+// matchSensitiveFile returns the first of the given patterns matching the given path, or an
+// empty string if none does.
+func matchSensitiveFile(patterns []string, path string) string {
+	for _, pattern := range patterns {
+		matched, err := filepath.Match(pattern, path)
+		if err != nil {
+			logger.Debugw("Matching sensitive file", "pattern", pattern, "error", err)
+			continue
+		}
+		if matched {
+			return pattern
+		}
+	}
+
+	return ""
+}
+
+// fileAccessMode returns the access mode of the given open flags.
+func fileAccessMode(flags int32) string {
+	switch flags & unix.O_ACCMODE {
+	case unix.O_WRONLY:
+		return fileAccessWrite
+	case unix.O_RDWR:
+		return fileAccessReadWrite
+	}
+
+	return fileAccessRead
+}
@@ -0,0 +1,97 @@
+package derive
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestSensitiveFileAccess(t *testing.T) {
+	t.Parallel()
+
+	patterns := []string{"/etc/shadow", "/home/*/.ssh/id_*", "/[bad"}
+
+	testCases := []struct {
+		name             string
+		pathname         string
+		flags            int32
+		expectedPattern  string
+		expectedAccess   string
+		expectedNoEvents bool
+	}{
+		{
+			name:            "shadow read",
+			pathname:        "/etc/shadow",
+			flags:           int32(os.O_RDONLY | os.O_CREATE),
+			expectedPattern: "/etc/shadow",
+			expectedAccess:  fileAccessRead,
+		},
+		{
+			name:            "ssh key written",
+			pathname:        "/home/alice/.ssh/id_ed25519",
+			flags:           int32(os.O_WRONLY | os.O_TRUNC),
+			expectedPattern: "/home/*/.ssh/id_*",
+			expectedAccess:  fileAccessWrite,
+		},
+		{
+			name:            "ssh public key read and written",
+			pathname:        "/home/alice/.ssh/id_ed25519.pub",
+			flags:           int32(os.O_RDWR),
+			expectedPattern: "/home/*/.ssh/id_*",
+			expectedAccess:  fileAccessReadWrite,
+		},
+		{
+			name:             "nested home file",
+			pathname:         "/home/alice/backup/.ssh/id_rsa",
+			flags:            int32(os.O_RDONLY),
+			expectedNoEvents: true,
+		},
+		{
+			name:             "other file",
+			pathname:         "/etc/passwd",
+			flags:            int32(os.O_RDONLY),
+			expectedNoEvents: true,
+		},
+	}
+
+	deriveFn := SensitiveFileAccess(patterns, nil)
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: tc.pathname},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: tc.flags},
+				},
+			})
+			require.Empty(t, errs)
+			if tc.expectedNoEvents {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.SensitiveFileAccess), event.EventID)
+			pattern, err := parse.ArgVal[string](event.Args, "pattern")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPattern, pattern)
+			access, err := parse.ArgVal[string](event.Args, "access")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedAccess, access)
+			flags, err := parse.ArgVal[int32](event.Args, "flags")
+			require.NoError(t, err)
+			assert.Equal(t, tc.flags, flags)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package derive
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// sensitiveHostPaths are the host paths giving control over the host, or over its container
+// runtime, to the containers they are bind mounted into.
+var sensitiveHostPaths = map[string]struct{}{
+	"/":                                   {},
+	"/boot":                               {},
+	"/dev":                                {},
+	"/etc":                                {},
+	"/proc":                               {},
+	"/root":                               {},
+	"/sys":                                {},
+	"/var/lib/kubelet":                    {},
+	"/var/log":                            {},
+	"/run/containerd/containerd.sock":     {},
+	"/run/crio/crio.sock":                 {},
+	"/run/docker.sock":                    {},
+	"/var/run/containerd/containerd.sock": {},
+	"/var/run/crio/crio.sock":             {},
+	"/var/run/docker.sock":                {},
+}
+
+// containerRootfsDirs are the directory names the container runtimes mount the containers root
+// filesystems on (e.g. /run/containerd/io.containerd.runtime.v2.task/k8s.io/<id>/rootfs, or
+// /var/lib/docker/overlay2/<id>/merged).
+var containerRootfsDirs = []string{"/rootfs", "/merged"}
+
+func SensitiveHostMount() DeriveFunction {
+	return deriveSingleEvent(events.SensitiveHostMount, deriveSensitiveHostMountArgs)
+}
+
+func deriveSensitiveHostMountArgs(event trace.Event) ([]interface{}, error) {
+	flags, err := event.ArgUlong("flags")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if flags&unix.MS_BIND == 0 || flags&unix.MS_REMOUNT != 0 {
+		return nil, nil // not a new bind mount
+	}
+	source, err := event.ArgString("dev_name")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if !filepath.IsAbs(source) {
+		return nil, nil
+	}
+	if _, ok := sensitiveHostPaths[filepath.Clean(source)]; !ok {
+		return nil, nil
+	}
+	target, err := event.ArgString("path")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	containerPath, ok := resolveContainerPath(target)
+	if !ok {
+		return nil, nil // not mounted into a container root filesystem
+	}
+
+	return []interface{}{source, target, containerPath, flags}, nil
+}
+
+// resolveContainerPath returns the path, as seen from the container, of the given mount target
+// resolved in the mount namespace the container runtime sets up the container in (before it
+// pivots to the container root filesystem).
+func resolveContainerPath(target string) (string, bool) {
+	for _, dir := range containerRootfsDirs {
+		i := strings.Index(target, dir)
+		for i >= 0 {
+			rest := target[i+len(dir):]
+			if rest == "" {
+				return "/", true
+			}
+			if rest[0] == '/' {
+				return rest, true
+			}
+			next := strings.Index(rest, dir)
+			if next < 0 {
+				break
+			}
+			i += len(dir) + next
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,106 @@
+package derive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestSensitiveHostMount(t *testing.T) {
+	t.Parallel()
+
+	const containerdRootfs = "/run/containerd/io.containerd.runtime.v2.task/k8s.io/0123456789ab/rootfs"
+
+	testCases := []struct {
+		name                  string
+		devName               string
+		path                  string
+		flags                 uint64
+		expectedContainerPath string
+		expectedNoEvents      bool
+	}{
+		{
+			name:                  "docker socket into containerd rootfs",
+			devName:               "/var/run/docker.sock",
+			path:                  containerdRootfs + "/var/run/docker.sock",
+			flags:                 0x1000 | 0x4000, // MS_BIND|MS_REC
+			expectedContainerPath: "/var/run/docker.sock",
+		},
+		{
+			name:                  "host root into docker rootfs",
+			devName:               "/",
+			path:                  "/var/lib/docker/overlay2/abcdef/merged/host",
+			flags:                 0x1000, // MS_BIND
+			expectedContainerPath: "/host",
+		},
+		{
+			name:             "regular bind mount into container",
+			devName:          "/var/lib/docker/containers/abcdef/hosts",
+			path:             "/var/lib/docker/overlay2/abcdef/merged/etc/hosts",
+			flags:            0x1000, // MS_BIND
+			expectedNoEvents: true,
+		},
+		{
+			name:             "host bind mount",
+			devName:          "/proc",
+			path:             "/mnt/proc",
+			flags:            0x1000, // MS_BIND
+			expectedNoEvents: true,
+		},
+		{
+			name:             "remount",
+			devName:          "/proc",
+			path:             containerdRootfs + "/proc",
+			flags:            0x1000 | 0x20, // MS_BIND|MS_REMOUNT
+			expectedNoEvents: true,
+		},
+		{
+			name:             "procfs mount",
+			devName:          "proc",
+			path:             containerdRootfs + "/proc",
+			flags:            0x2 | 0x4 | 0x8, // MS_NOSUID|MS_NODEV|MS_NOEXEC
+			expectedNoEvents: true,
+		},
+	}
+
+	deriveFn := SensitiveHostMount()
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			event := trace.Event{
+				EventID:   int(events.SecuritySbMount),
+				EventName: "security_sb_mount",
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "dev_name", Type: "const char*"}, Value: tc.devName},
+					{ArgMeta: trace.ArgMeta{Name: "path", Type: "const char*"}, Value: tc.path},
+					{ArgMeta: trace.ArgMeta{Name: "type", Type: "const char*"}, Value: ""},
+					{ArgMeta: trace.ArgMeta{Name: "flags", Type: "unsigned long"}, Value: tc.flags},
+				},
+			}
+
+			derived, errs := deriveFn(event)
+			require.Empty(t, errs)
+			if tc.expectedNoEvents {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			assert.Equal(t, int(events.SensitiveHostMount), derived[0].EventID)
+			source, err := parse.ArgVal[string](derived[0].Args, "source")
+			require.NoError(t, err)
+			assert.Equal(t, tc.devName, source)
+			containerPath, err := parse.ArgVal[string](derived[0].Args, "container_path")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedContainerPath, containerPath)
+		})
+	}
+}
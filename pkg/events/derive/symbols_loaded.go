@@ -10,7 +10,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/filters"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/policy"
@@ -124,7 +123,7 @@ func (symbsLoadedGen *symbolsLoadedEventGenerator) deriveArgs(
 	matchedSyms, ok := symbsLoadedGen.getSymbolsFromCache(loadingObjectInfo.Id)
 	if ok {
 		if len(matchedSyms) > 0 {
-			hash, _ := parse.ArgVal[string](event.Args, "sha256")
+			hash, _ := event.ArgString("sha256")
 			return []interface{}{loadingObjectInfo.Path, matchedSyms, hash}, nil
 		}
 		return nil, nil
@@ -159,7 +158,7 @@ func (symbsLoadedGen *symbolsLoadedEventGenerator) deriveArgs(
 
 	symbsLoadedGen.libsCache.Add(loadingObjectInfo.Id, exportedWatchSymbols)
 	if len(exportedWatchSymbols) > 0 {
-		hash, _ := parse.ArgVal[string](event.Args, "sha256")
+		hash, _ := event.ArgString("sha256")
 		return []interface{}{loadingObjectInfo.Path, exportedWatchSymbols, hash}, nil
 	}
 
@@ -208,19 +207,19 @@ func (symbsLoadedGen *symbolsLoadedEventGenerator) getSymbolsFromCache(id shared
 func getSharedObjectInfo(event trace.Event) (sharedobjs.ObjInfo, error) {
 	var objInfo sharedobjs.ObjInfo
 
-	loadedObjectInode, err := parse.ArgVal[uint64](event.Args, "inode")
+	loadedObjectInode, err := event.ArgUlong("inode")
 	if err != nil {
 		return objInfo, errfmt.WrapError(err)
 	}
-	loadedObjectDevice, err := parse.ArgVal[uint32](event.Args, "dev")
+	loadedObjectDevice, err := event.ArgUint("dev")
 	if err != nil {
 		return objInfo, errfmt.WrapError(err)
 	}
-	loadedObjectCtime, err := parse.ArgVal[uint64](event.Args, "ctime")
+	loadedObjectCtime, err := event.ArgUlong("ctime")
 	if err != nil {
 		return objInfo, errfmt.WrapError(err)
 	}
-	loadedObjectPath, err := parse.ArgVal[string](event.Args, "pathname")
+	loadedObjectPath, err := event.ArgString("pathname")
 	if err != nil {
 		return objInfo, errfmt.WrapError(err)
 	}
@@ -0,0 +1,104 @@
+package derive
+
+import (
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const procSysDir = "/proc/sys/"
+
+// sensitiveSysctls are the kernel parameters hardening the host, or used by attackers (e.g. to
+// route traffic or to run a program on a crash).
+var sensitiveSysctls = map[string]struct{}{
+	"fs.protected_fifos":                     {},
+	"fs.protected_hardlinks":                 {},
+	"fs.protected_regular":                   {},
+	"fs.protected_symlinks":                  {},
+	"fs.suid_dumpable":                       {},
+	"kernel.core_pattern":                    {},
+	"kernel.dmesg_restrict":                  {},
+	"kernel.kexec_load_disabled":             {},
+	"kernel.kptr_restrict":                   {},
+	"kernel.modprobe":                        {},
+	"kernel.modules_disabled":                {},
+	"kernel.perf_event_paranoid":             {},
+	"kernel.randomize_va_space":              {},
+	"kernel.sysrq":                           {},
+	"kernel.unprivileged_bpf_disabled":       {},
+	"kernel.unprivileged_userns_clone":       {},
+	"kernel.yama.ptrace_scope":               {},
+	"net.core.bpf_jit_harden":                {},
+	"net.ipv4.conf.all.accept_redirects":     {},
+	"net.ipv4.conf.all.rp_filter":            {},
+	"net.ipv4.conf.all.send_redirects":       {},
+	"net.ipv4.conf.default.accept_redirects": {},
+	"net.ipv4.ip_forward":                    {},
+	"net.ipv6.conf.all.forwarding":           {},
+	"user.max_user_namespaces":               {},
+	"vm.mmap_min_addr":                       {},
+	"vm.unprivileged_userfaultfd":            {},
+}
+
+// SysctlModification derives a sysctl_modification event from kernel parameters files (under
+// /proc/sys) being opened for writing, as sysctl(8) and shell redirections do.
+func SysctlModification(resolver pathResolver) DeriveFunction {
+	return deriveSingleEvent(events.SysctlModification,
+		func(event trace.Event) ([]interface{}, error) {
+			path, err := event.ArgString("pathname")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			if !strings.HasPrefix(path, procSysDir) {
+				return nil, nil
+			}
+			flags, err := event.ArgInt("flags")
+			if err != nil {
+				return nil, errfmt.WrapError(err)
+			}
+			if flags&(unix.O_WRONLY|unix.O_RDWR) == 0 {
+				return nil, nil
+			}
+
+			name := sysctlName(path)
+			_, sensitive := sensitiveSysctls[name]
+
+			return []interface{}{
+				name,
+				path,
+				readSysctlValue(resolver, path, event.MountNS),
+				sensitive,
+			}, nil
+		},
+	)
+}
+
+// sysctlName returns the name of the kernel parameter of the given /proc/sys file
+// (e.g. kernel.yama.ptrace_scope for /proc/sys/kernel/yama/ptrace_scope).
+func sysctlName(path string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(path, procSysDir), "/", ".")
+}
+
+// readSysctlValue returns the value of the given kernel parameter file, as seen from the given
+// mount namespace (network parameters are per network namespace), or an empty string if it
+// can't be read.
+func readSysctlValue(resolver pathResolver, path string, mountNS int) string {
+	hostPath, err := resolver.GetHostAbsPath(path, mountNS)
+	if err != nil {
+		logger.Debugw("Resolving sysctl path", "path", path, "error", err)
+		return ""
+	}
+	value, err := os.ReadFile(hostPath)
+	if err != nil {
+		logger.Debugw("Reading sysctl value", "path", hostPath, "error", err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(value))
+}
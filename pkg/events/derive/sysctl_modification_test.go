@@ -0,0 +1,95 @@
+package derive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestSysctlModification(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "proc/sys/kernel/yama"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "proc/sys/kernel/yama/ptrace_scope"), []byte("0\n"), 0o600))
+
+	testCases := []struct {
+		name              string
+		pathname          string
+		flags             int32
+		expectedName      string
+		expectedValue     string
+		expectedSensitive bool
+		expectedNoEvents  bool
+	}{
+		{
+			name:              "ptrace scope written",
+			pathname:          "/proc/sys/kernel/yama/ptrace_scope",
+			flags:             int32(os.O_WRONLY | os.O_TRUNC),
+			expectedName:      "kernel.yama.ptrace_scope",
+			expectedValue:     "0",
+			expectedSensitive: true,
+		},
+		{
+			name:          "unreadable parameter written",
+			pathname:      "/proc/sys/vm/swappiness",
+			flags:         int32(os.O_WRONLY),
+			expectedName:  "vm.swappiness",
+			expectedValue: "",
+		},
+		{
+			name:             "parameter read",
+			pathname:         "/proc/sys/kernel/yama/ptrace_scope",
+			flags:            int32(os.O_RDONLY),
+			expectedNoEvents: true,
+		},
+		{
+			name:             "other file written",
+			pathname:         "/etc/sysctl.conf",
+			flags:            int32(os.O_WRONLY),
+			expectedNoEvents: true,
+		},
+	}
+
+	deriveFn := SysctlModification(rootPathResolver(root))
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			derived, errs := deriveFn(trace.Event{
+				EventID: int(events.SecurityFileOpen),
+				Args: []trace.Argument{
+					{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: tc.pathname},
+					{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: tc.flags},
+				},
+			})
+			require.Empty(t, errs)
+			if tc.expectedNoEvents {
+				assert.Empty(t, derived)
+				return
+			}
+			require.Len(t, derived, 1)
+
+			event := derived[0]
+			assert.Equal(t, int(events.SysctlModification), event.EventID)
+			name, err := parse.ArgVal[string](event.Args, "name")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedName, name)
+			value, err := parse.ArgVal[string](event.Args, "value")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedValue, value)
+			sensitive, err := parse.ArgVal[bool](event.Args, "sensitive")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedSensitive, sensitive)
+		})
+	}
+}
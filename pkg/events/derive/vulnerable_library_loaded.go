@@ -0,0 +1,43 @@
+package derive
+
+import (
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// VulnerableLibraryLoaded derives a vulnerable_library_loaded event from a
+// shared_object_loaded event, if the version of the loaded library (taken from its file
+// name) has vulnerabilities in the vulnerability database.
+func VulnerableLibraryLoaded(db *vulndb.DB) DeriveFunction {
+	return deriveSingleEvent(events.VulnerableLibraryLoaded,
+		func(event trace.Event) ([]interface{}, error) {
+			if db == nil {
+				return nil, nil
+			}
+
+			pathname, err := event.ArgString("pathname")
+			if err != nil {
+				return nil, err
+			}
+			library, version := vulndb.ParseLibrary(pathname)
+			vulns := db.Match(library, version)
+			if len(vulns) == 0 {
+				return nil, nil
+			}
+
+			ids := make([]string, 0, len(vulns))
+			for _, vuln := range vulns {
+				ids = append(ids, vuln.ID)
+			}
+
+			return []interface{}{
+				pathname,
+				library,
+				version,
+				ids,
+				vulndb.HighestSeverity(vulns),
+			}, nil
+		},
+	)
+}
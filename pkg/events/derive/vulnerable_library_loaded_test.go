@@ -0,0 +1,66 @@
+package derive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/vulndb"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestVulnerableLibraryLoaded(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "vulndb.json")
+	require.NoError(t, os.WriteFile(dbPath, []byte(`{"vulnerabilities": [
+  {"id": "CVE-2022-0778", "library": "libcrypto", "affected": ["<1.1.1n"], "severity": "high"},
+  {"id": "CVE-2023-0286", "library": "libcrypto", "affected": ["<1.1.1t"], "severity": "critical"}
+]}`), 0o600))
+	db, err := vulndb.Load([]string{dbPath})
+	require.NoError(t, err)
+
+	loaded := func(pathname string) trace.Event {
+		return trace.Event{
+			EventID:   int(events.SharedObjectLoaded),
+			EventName: "shared_object_loaded",
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: pathname},
+			},
+		}
+	}
+
+	deriveFn := VulnerableLibraryLoaded(db)
+
+	derived, errs := deriveFn(loaded("/usr/lib/libcrypto.so.1.1"))
+	require.Empty(t, errs)
+	require.Len(t, derived, 1)
+	event := derived[0]
+	assert.Equal(t, int(events.VulnerableLibraryLoaded), event.EventID)
+	assert.Equal(t, "vulnerable_library_loaded", event.EventName)
+	library, err := parse.ArgVal[string](event.Args, "library")
+	require.NoError(t, err)
+	assert.Equal(t, "libcrypto", library)
+	version, err := parse.ArgVal[string](event.Args, "version")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1", version)
+	ids, err := parse.ArgVal[[]string](event.Args, "cve_ids")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CVE-2022-0778", "CVE-2023-0286"}, ids)
+	severity, err := parse.ArgVal[string](event.Args, "severity")
+	require.NoError(t, err)
+	assert.Equal(t, "critical", severity)
+
+	derived, errs = deriveFn(loaded("/usr/lib/libcrypto.so.3"))
+	require.Empty(t, errs)
+	assert.Empty(t, derived)
+
+	derived, errs = VulnerableLibraryLoaded(nil)(loaded("/usr/lib/libcrypto.so.1.1"))
+	require.Empty(t, errs)
+	assert.Empty(t, derived)
+}
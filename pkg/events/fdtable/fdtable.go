@@ -0,0 +1,204 @@
+// Package fdtable keeps the file descriptors table of the processes in user space, fed by the
+// events opening, duplicating and closing file descriptors, so the fd arguments of any event can
+// be resolved to the file, socket or pipe they refer to.
+package fdtable
+
+import (
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// DefaultMaxProcesses is the default number of processes whose file descriptors are kept.
+const DefaultMaxProcesses = 8192
+
+// maxHistory is the number of descriptions kept per file descriptor: the events are resolved
+// later in the pipeline than the table is fed, so a reused descriptor is resolved to the
+// description it had at the time of the event.
+const maxHistory = 4
+
+// entry is a description of a file descriptor during its lifetime.
+type entry struct {
+	desc    string
+	opened  uint64 // timestamp the descriptor was opened at
+	closed  uint64 // timestamp the descriptor was closed at (0 if still open)
+	cloexec bool   // closed on exec
+}
+
+func (e *entry) isOpen() bool {
+	return e.closed == 0
+}
+
+// process is the file descriptors of a process (shared by its threads), newest entries last.
+type process struct {
+	fds map[int32][]entry
+}
+
+// Table is the file descriptors table of the processes, by host pid (tgid). It is fed by the
+// events pipeline stage processing the events, and read from the stage parsing their arguments.
+type Table struct {
+	mutex     sync.Mutex
+	processes *lru.Cache[int, *process]
+}
+
+// New creates a file descriptors table keeping the descriptors of the given number of processes.
+func New(maxProcesses int) (*Table, error) {
+	if maxProcesses <= 0 {
+		return nil, errfmt.Errorf("invalid fd table max processes: %d", maxProcesses)
+	}
+	processes, err := lru.New[int, *process](maxProcesses)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Table{processes: processes}, nil
+}
+
+func (t *Table) getProcess(pid int) *process {
+	p, ok := t.processes.Get(pid)
+	if !ok {
+		p = &process{fds: make(map[int32][]entry)}
+		t.processes.Add(pid, p)
+	}
+
+	return p
+}
+
+// Open records a file descriptor opened by a process, closing the one it replaces (if any).
+func (t *Table) Open(pid int, fd int32, desc string, cloexec bool, ts uint64) {
+	if fd < 0 {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.getProcess(pid).open(fd, desc, cloexec, ts)
+}
+
+func (p *process) open(fd int32, desc string, cloexec bool, ts uint64) {
+	p.close(fd, ts)
+
+	history := append(p.fds[fd], entry{desc: desc, opened: ts, cloexec: cloexec})
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	p.fds[fd] = history
+}
+
+// Close records a file descriptor closed by a process.
+func (t *Table) Close(pid int, fd int32, ts uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if p, ok := t.processes.Peek(pid); ok {
+		p.close(fd, ts)
+	}
+}
+
+func (p *process) close(fd int32, ts uint64) {
+	history := p.fds[fd]
+	if len(history) > 0 && history[len(history)-1].isOpen() {
+		history[len(history)-1].closed = ts
+	}
+}
+
+// CloseRange records the file descriptors of the given range closed by a process, or only marked
+// to be closed on exec.
+func (t *Table) CloseRange(pid int, first, last uint32, cloexec bool, ts uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p, ok := t.processes.Peek(pid)
+	if !ok {
+		return
+	}
+	for fd, history := range p.fds {
+		if uint32(fd) < first || uint32(fd) > last {
+			continue
+		}
+		if cloexec {
+			if len(history) > 0 && history[len(history)-1].isOpen() {
+				history[len(history)-1].cloexec = true
+			}
+			continue
+		}
+		p.close(fd, ts)
+	}
+}
+
+// Dup records a file descriptor duplicated by a process into a new descriptor.
+func (t *Table) Dup(pid int, oldfd, newfd int32, cloexec bool, ts uint64) {
+	if newfd < 0 || oldfd == newfd {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p := t.getProcess(pid)
+	desc, ok := p.lookup(oldfd, ts)
+	if !ok {
+		p.close(newfd, ts) // the description of the duplicated descriptor is unknown
+		return
+	}
+	p.open(newfd, desc, cloexec, ts)
+}
+
+// Fork records a process forked by another: the child inherits the open descriptors of its parent.
+func (t *Table) Fork(parentPid, childPid int, ts uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	child := &process{fds: make(map[int32][]entry)}
+	if parent, ok := t.processes.Peek(parentPid); ok {
+		for fd, history := range parent.fds {
+			last := history[len(history)-1]
+			if last.isOpen() {
+				child.fds[fd] = []entry{{desc: last.desc, opened: ts, cloexec: last.cloexec}}
+			}
+		}
+	}
+	t.processes.Add(childPid, child) // replaces the table of a previous process reusing the pid
+}
+
+// Exec records a process executing a new program: its close on exec descriptors are closed.
+func (t *Table) Exec(pid int, ts uint64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p, ok := t.processes.Peek(pid)
+	if !ok {
+		return
+	}
+	for fd, history := range p.fds {
+		if history[len(history)-1].cloexec {
+			p.close(fd, ts)
+		}
+	}
+}
+
+// Lookup returns the description a file descriptor of a process had at the given time.
+func (t *Table) Lookup(pid int, fd int32, ts uint64) (string, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	p, ok := t.processes.Peek(pid)
+	if !ok {
+		return "", false
+	}
+
+	return p.lookup(fd, ts)
+}
+
+func (p *process) lookup(fd int32, ts uint64) (string, bool) {
+	history := p.fds[fd]
+	for i := len(history) - 1; i >= 0; i-- {
+		e := history[i]
+		if e.opened <= ts && (e.isOpen() || ts <= e.closed) {
+			return e.desc, true
+		}
+	}
+
+	return "", false
+}
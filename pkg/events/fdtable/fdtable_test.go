@@ -0,0 +1,119 @@
+package fdtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertLookup(t *testing.T, table *Table, pid int, fd int32, ts uint64, expected string) {
+	t.Helper()
+
+	desc, ok := table.Lookup(pid, fd, ts)
+	if expected == "" {
+		assert.False(t, ok, "fd %d of pid %d at %d resolved to %q", fd, pid, ts, desc)
+		return
+	}
+	assert.True(t, ok, "fd %d of pid %d at %d not resolved", fd, pid, ts)
+	assert.Equal(t, expected, desc)
+}
+
+func TestTableOpenClose(t *testing.T) {
+	t.Parallel()
+
+	table, err := New(DefaultMaxProcesses)
+	require.NoError(t, err)
+
+	table.Open(100, 3, "/etc/passwd", false, 10)
+	table.Close(100, 3, 20)
+	table.Open(100, 3, "socket:[AF_INET,SOCK_STREAM]", false, 30)
+
+	// a reused descriptor is resolved to its description at the time of the event
+	assertLookup(t, table, 100, 3, 5, "")
+	assertLookup(t, table, 100, 3, 15, "/etc/passwd")
+	assertLookup(t, table, 100, 3, 20, "/etc/passwd") // the close event itself
+	assertLookup(t, table, 100, 3, 25, "")
+	assertLookup(t, table, 100, 3, 40, "socket:[AF_INET,SOCK_STREAM]")
+
+	// other processes and descriptors
+	assertLookup(t, table, 200, 3, 40, "")
+	assertLookup(t, table, 100, 4, 40, "")
+
+	// the history of a descriptor is bounded
+	for ts := uint64(50); ts < 50+maxHistory; ts++ {
+		table.Open(100, 3, "/tmp/file", false, ts)
+	}
+	assertLookup(t, table, 100, 3, 15, "")
+	assertLookup(t, table, 100, 3, 60, "/tmp/file")
+}
+
+func TestTableDup(t *testing.T) {
+	t.Parallel()
+
+	table, err := New(DefaultMaxProcesses)
+	require.NoError(t, err)
+
+	table.Open(100, 3, "/var/log/app.log", false, 10)
+	table.Open(100, 1, "/dev/pts/0", false, 10)
+	table.Dup(100, 3, 1, false, 20) // dup2(3, 1)
+	table.Dup(100, 9, 2, false, 20) // unknown old descriptor
+
+	assertLookup(t, table, 100, 1, 15, "/dev/pts/0")
+	assertLookup(t, table, 100, 1, 30, "/var/log/app.log")
+	assertLookup(t, table, 100, 2, 30, "")
+}
+
+func TestTableCloseRange(t *testing.T) {
+	t.Parallel()
+
+	table, err := New(DefaultMaxProcesses)
+	require.NoError(t, err)
+
+	for fd := int32(3); fd < 8; fd++ {
+		table.Open(100, fd, "/tmp/file", false, 10)
+	}
+	table.CloseRange(100, 4, 5, false, 20)
+	table.CloseRange(100, 6, ^uint32(0), true, 20) // CLOSE_RANGE_CLOEXEC
+	table.Exec(100, 30)
+
+	assertLookup(t, table, 100, 3, 40, "/tmp/file")
+	assertLookup(t, table, 100, 4, 40, "")
+	assertLookup(t, table, 100, 5, 40, "")
+	assertLookup(t, table, 100, 6, 25, "/tmp/file")
+	assertLookup(t, table, 100, 6, 40, "")
+	assertLookup(t, table, 100, 7, 40, "")
+}
+
+func TestTableForkExec(t *testing.T) {
+	t.Parallel()
+
+	table, err := New(DefaultMaxProcesses)
+	require.NoError(t, err)
+
+	table.Open(100, 3, "/etc/passwd", false, 10)
+	table.Open(100, 4, "/etc/shadow", true, 10)
+	table.Open(100, 5, "/etc/group", false, 10)
+	table.Close(100, 5, 15)
+	table.Open(200, 3, "/old/process", false, 5) // a previous process reusing the child pid
+
+	table.Fork(100, 200, 20)
+
+	// the child inherits the open descriptors of its parent
+	assertLookup(t, table, 200, 3, 30, "/etc/passwd")
+	assertLookup(t, table, 200, 4, 30, "/etc/shadow")
+	assertLookup(t, table, 200, 5, 30, "")
+
+	// the close on exec descriptors are closed on exec
+	table.Exec(200, 40)
+	assertLookup(t, table, 200, 3, 50, "/etc/passwd")
+	assertLookup(t, table, 200, 4, 50, "")
+	assertLookup(t, table, 100, 4, 50, "/etc/shadow")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0)
+	assert.ErrorContains(t, err, "invalid fd table max processes: 0")
+}
@@ -0,0 +1,314 @@
+// Package flamegraph aggregates the stack traces of the events over time windows, and writes
+// them as folded stacks (flamegraph.pl, speedscope, ...) or pprof profiles, turning the stack
+// traces collection into a lightweight always-on profiler.
+package flamegraph
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Output formats
+const (
+	FormatFolded = "folded"
+	FormatPprof  = "pprof"
+)
+
+// Stacks grouping
+const (
+	GroupByComm      = "comm"      // stacks rooted at the process name
+	GroupByContainer = "container" // stacks rooted at the container, then the process name
+)
+
+// Defaults
+const (
+	DefaultFormat  = FormatFolded
+	DefaultGroupBy = GroupByComm
+	DefaultWindow  = time.Minute
+)
+
+const (
+	maxStacks = 65536 // distinct stacks aggregated per window, the others are dropped
+	hostGroup = "host"
+)
+
+// Config is the configuration of the stacks aggregation.
+type Config struct {
+	Enabled bool
+	Dir     string        // directory the profiles are written to
+	Format  string        // format of the profiles
+	GroupBy string        // root frames of the stacks
+	Window  time.Duration // duration a profile aggregates stacks for
+}
+
+// stackKey identifies an aggregated stack: its root frames and its addresses.
+type stackKey struct {
+	container string
+	comm      string
+	addresses string // addresses, leaf first, encoded as a string to be comparable
+}
+
+// Profile is the stacks aggregated over a window.
+type Profile struct {
+	Start   time.Time
+	End     time.Time
+	GroupBy string
+	Stacks  []Stack // ordered by root frames then addresses
+	Dropped uint64  // stacks over the max distinct stacks
+}
+
+// Stack is an aggregated stack.
+type Stack struct {
+	Container string   // empty unless grouped by container
+	Comm      string   // process name
+	Addresses []uint64 // leaf first
+	Count     uint64
+}
+
+// Aggregator aggregates the stack traces of the events, and writes a profile at the end of
+// every window. It is thread-safe.
+type Aggregator struct {
+	mutex   sync.Mutex
+	cfg     Config
+	start   time.Time
+	stacks  map[stackKey]uint64
+	dropped uint64
+	closed  bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New creates a stacks aggregator, writing a profile to the configured directory at the end
+// of every window.
+func New(cfg Config) (*Aggregator, error) {
+	if cfg.Dir == "" {
+		return nil, errfmt.Errorf("flamegraph directory expected")
+	}
+	if cfg.Format != FormatFolded && cfg.Format != FormatPprof {
+		return nil, errfmt.Errorf("invalid flamegraph format: %s", cfg.Format)
+	}
+	if cfg.GroupBy != GroupByComm && cfg.GroupBy != GroupByContainer {
+		return nil, errfmt.Errorf("invalid flamegraph group by: %s", cfg.GroupBy)
+	}
+	if cfg.Window <= 0 {
+		return nil, errfmt.Errorf("invalid flamegraph window: %v", cfg.Window)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	a := &Aggregator{
+		cfg:    cfg,
+		start:  time.Now(),
+		stacks: make(map[stackKey]uint64),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go a.writeProfiles()
+
+	return a, nil
+}
+
+// Add aggregates the stack trace of an event, if it has one.
+func (a *Aggregator) Add(event trace.Event) {
+	if len(event.StackAddresses) == 0 {
+		return
+	}
+
+	key := stackKey{comm: event.ProcessName}
+	if a.cfg.GroupBy == GroupByContainer {
+		key.container = containerName(&event)
+	}
+	addresses := make([]byte, 0, len(event.StackAddresses)*8)
+	for _, addr := range event.StackAddresses {
+		addresses = binary.LittleEndian.AppendUint64(addresses, addr)
+	}
+	key.addresses = string(addresses)
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if _, ok := a.stacks[key]; !ok && len(a.stacks) >= maxStacks {
+		a.dropped++
+		return
+	}
+	a.stacks[key]++
+}
+
+// Flush returns the profile of the current window, and starts a new window.
+func (a *Aggregator) Flush(now time.Time) *Profile {
+	a.mutex.Lock()
+	stacks, dropped, start := a.stacks, a.dropped, a.start
+	a.stacks = make(map[stackKey]uint64)
+	a.dropped = 0
+	a.start = now
+	a.mutex.Unlock()
+
+	p := &Profile{
+		Start:   start,
+		End:     now,
+		GroupBy: a.cfg.GroupBy,
+		Stacks:  make([]Stack, 0, len(stacks)),
+		Dropped: dropped,
+	}
+	for key, count := range stacks {
+		p.Stacks = append(p.Stacks, Stack{
+			Container: key.container,
+			Comm:      key.comm,
+			Addresses: decodeAddresses(key.addresses),
+			Count:     count,
+		})
+	}
+	sort.Slice(p.Stacks, func(i, j int) bool {
+		si, sj := p.Stacks[i], p.Stacks[j]
+		if si.Container != sj.Container {
+			return si.Container < sj.Container
+		}
+		if si.Comm != sj.Comm {
+			return si.Comm < sj.Comm
+		}
+		for n := 0; n < len(si.Addresses) && n < len(sj.Addresses); n++ {
+			if si.Addresses[n] != sj.Addresses[n] {
+				return si.Addresses[n] < sj.Addresses[n]
+			}
+		}
+		return len(si.Addresses) < len(sj.Addresses)
+	})
+
+	return p
+}
+
+// Close writes the profile of the current window.
+func (a *Aggregator) Close() error {
+	a.mutex.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.stop)
+	}
+	a.mutex.Unlock()
+
+	<-a.done
+
+	return nil
+}
+
+// writeProfiles writes a profile at the end of every window, and a last one once closed.
+func (a *Aggregator) writeProfiles() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.cfg.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			a.write(a.Flush(now))
+		case <-a.stop:
+			a.write(a.Flush(time.Now()))
+			return
+		}
+	}
+}
+
+func (a *Aggregator) write(p *Profile) {
+	if len(p.Stacks) == 0 {
+		return
+	}
+	if p.Dropped > 0 {
+		logger.Warnw("Flamegraph stacks dropped", "dropped", p.Dropped, "max", maxStacks)
+	}
+
+	path := filepath.Join(a.cfg.Dir, p.fileName(a.cfg.Format))
+	if err := p.writeFile(path, a.cfg.Format); err != nil {
+		logger.Errorw("Writing flamegraph profile", "path", path, "error", err)
+	}
+}
+
+// WriteFolded writes the profile as folded stacks: a line per stack, its frames from the root
+// (the container and process name) to the leaf, separated by semicolons, followed by its count.
+func (p *Profile) WriteFolded(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, s := range p.Stacks {
+		if p.GroupBy == GroupByContainer {
+			fmt.Fprintf(bw, "%s;", foldedFrame(s.Container))
+		}
+		bw.WriteString(foldedFrame(s.Comm))
+		for i := len(s.Addresses) - 1; i >= 0; i-- {
+			fmt.Fprintf(bw, ";0x%x", s.Addresses[i])
+		}
+		fmt.Fprintf(bw, " %d\n", s.Count)
+	}
+
+	return errfmt.WrapError(bw.Flush())
+}
+
+// fileName returns the file name of the profile, after the end of its window.
+func (p *Profile) fileName(format string) string {
+	ext := "folded"
+	if format == FormatPprof {
+		ext = "pb.gz"
+	}
+
+	return fmt.Sprintf("stacks-%s.%s", p.End.UTC().Format("20060102T150405"), ext)
+}
+
+func (p *Profile) writeFile(path string, format string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	if format == FormatPprof {
+		err = p.WritePprof(f)
+	} else {
+		err = p.WriteFolded(f)
+	}
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return errfmt.WrapError(f.Close())
+}
+
+// containerName returns the root frame of the container of an event.
+func containerName(event *trace.Event) string {
+	switch {
+	case event.Container.Name != "":
+		return event.Container.Name
+	case event.Container.ID != "":
+		return event.Container.ID
+	default:
+		return hostGroup
+	}
+}
+
+// foldedFrame escapes the separators of the folded format out of a frame.
+func foldedFrame(frame string) string {
+	if frame == "" {
+		return "[unknown]"
+	}
+
+	return strings.NewReplacer(";", "_", " ", "_", "\n", "_").Replace(frame)
+}
+
+func decodeAddresses(s string) []uint64 {
+	addresses := make([]uint64, 0, len(s)/8)
+	for i := 0; i+8 <= len(s); i += 8 {
+		addresses = append(addresses, binary.LittleEndian.Uint64([]byte(s[i:i+8])))
+	}
+
+	return addresses
+}
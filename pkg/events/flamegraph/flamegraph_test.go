@@ -0,0 +1,138 @@
+package flamegraph
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func stackEvent(comm, container string, addresses ...uint64) trace.Event {
+	return trace.Event{
+		ProcessName:    comm,
+		Container:      trace.Container{ID: container},
+		StackAddresses: addresses,
+	}
+}
+
+func TestAggregatorFlush(t *testing.T) {
+	t.Parallel()
+
+	a, err := New(Config{Dir: t.TempDir(), Format: FormatFolded, GroupBy: GroupByContainer, Window: time.Hour})
+	require.NoError(t, err)
+	defer a.Close()
+
+	a.Add(stackEvent("nginx", "abc", 0x30, 0x20, 0x10))
+	a.Add(stackEvent("bash", "", 0x50, 0x10))
+	a.Add(stackEvent("nginx", "abc", 0x30, 0x20, 0x10))
+	a.Add(stackEvent("nginx", "abc", 0x40, 0x10))
+	a.Add(stackEvent("nginx", "abc")) // no stack trace
+
+	start := a.start
+	end := start.Add(time.Minute)
+	p := a.Flush(end)
+
+	assert.Equal(t, start, p.Start)
+	assert.Equal(t, end, p.End)
+	assert.Equal(t, []Stack{
+		{Container: "abc", Comm: "nginx", Addresses: []uint64{0x30, 0x20, 0x10}, Count: 2},
+		{Container: "abc", Comm: "nginx", Addresses: []uint64{0x40, 0x10}, Count: 1},
+		{Container: "host", Comm: "bash", Addresses: []uint64{0x50, 0x10}, Count: 1},
+	}, p.Stacks)
+
+	var folded bytes.Buffer
+	require.NoError(t, p.WriteFolded(&folded))
+	assert.Equal(t, "abc;nginx;0x10;0x20;0x30 2\nabc;nginx;0x10;0x40 1\nhost;bash;0x10;0x50 1\n", folded.String())
+
+	// a new window starts empty
+	p = a.Flush(end.Add(time.Minute))
+	assert.Empty(t, p.Stacks)
+	assert.Equal(t, end, p.Start)
+}
+
+func TestAggregatorFoldedGroupByComm(t *testing.T) {
+	t.Parallel()
+
+	a, err := New(Config{Dir: t.TempDir(), Format: FormatFolded, GroupBy: GroupByComm, Window: time.Hour})
+	require.NoError(t, err)
+	defer a.Close()
+
+	a.Add(stackEvent("my app;1", "abc", 0x20, 0x10))
+	a.Add(stackEvent("my app;1", "def", 0x20, 0x10))
+
+	var folded bytes.Buffer
+	require.NoError(t, a.Flush(time.Now()).WriteFolded(&folded))
+	assert.Equal(t, "my_app_1;0x10;0x20 2\n", folded.String())
+}
+
+func TestAggregatorClose(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	a, err := New(Config{Dir: dir, Format: FormatPprof, GroupBy: GroupByComm, Window: time.Hour})
+	require.NoError(t, err)
+
+	a.Add(stackEvent("nginx", "", 0x20, 0x10))
+	require.NoError(t, a.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "stacks-*.pb.gz"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	f, err := os.Open(files[0])
+	require.NoError(t, err)
+	defer f.Close()
+	gr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gr)
+	require.NoError(t, err)
+
+	var (
+		strs      []string
+		samples   int
+		locations int
+	)
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		require.GreaterOrEqual(t, n, 0)
+		data = data[n:]
+		n = protowire.ConsumeFieldValue(num, typ, data)
+		require.GreaterOrEqual(t, n, 0)
+		switch num {
+		case profileStringTable:
+			s, _ := protowire.ConsumeString(data)
+			strs = append(strs, s)
+		case profileSample:
+			samples++
+		case profileLocation:
+			locations++
+		}
+		data = data[n:]
+	}
+
+	assert.Equal(t, 1, samples)
+	assert.Equal(t, 2, locations)
+	assert.Equal(t, []string{"", "samples", "count", "comm", "nginx"}, strs)
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Format: FormatFolded, GroupBy: GroupByComm, Window: time.Minute})
+	assert.ErrorContains(t, err, "flamegraph directory expected")
+
+	_, err = New(Config{Dir: t.TempDir(), Format: "svg", GroupBy: GroupByComm, Window: time.Minute})
+	assert.ErrorContains(t, err, "invalid flamegraph format: svg")
+
+	_, err = New(Config{Dir: t.TempDir(), Format: FormatFolded, GroupBy: "pid", Window: time.Minute})
+	assert.ErrorContains(t, err, "invalid flamegraph group by: pid")
+}
@@ -0,0 +1,156 @@
+package flamegraph
+
+import (
+	"compress/gzip"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Field numbers of the pprof profile.proto messages.
+const (
+	profileSampleType    = 1
+	profileSample        = 2
+	profileLocation      = 4
+	profileStringTable   = 6
+	profileTimeNanos     = 9
+	profileDurationNanos = 10
+	profilePeriodType    = 11
+	profilePeriod        = 12
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+	sampleLabel      = 3
+
+	labelKey = 1
+	labelStr = 2
+
+	locationID      = 1
+	locationAddress = 3
+)
+
+// WritePprof writes the profile as a gzipped pprof profile. The stacks count is their
+// "samples" value, and their root frames are the "container" and "comm" sample labels (e.g.
+// `go tool pprof -tagroot=comm`).
+func (p *Profile) WritePprof(w io.Writer) error {
+	b := pprofBuilder{strings: map[string]int64{"": 0}, stringTable: []string{""}, locations: map[uint64]uint64{}}
+
+	b.profile = protowire.AppendTag(b.profile, profileSampleType, protowire.BytesType)
+	b.profile = protowire.AppendBytes(b.profile, b.valueType("samples", "count"))
+
+	for _, s := range p.Stacks {
+		b.profile = protowire.AppendTag(b.profile, profileSample, protowire.BytesType)
+		b.profile = protowire.AppendBytes(b.profile, b.sample(s, p.GroupBy))
+	}
+
+	// locations, once all referenced by the samples
+	for _, addr := range b.addresses {
+		var loc []byte
+		loc = protowire.AppendTag(loc, locationID, protowire.VarintType)
+		loc = protowire.AppendVarint(loc, b.locations[addr])
+		loc = protowire.AppendTag(loc, locationAddress, protowire.VarintType)
+		loc = protowire.AppendVarint(loc, addr)
+		b.profile = protowire.AppendTag(b.profile, profileLocation, protowire.BytesType)
+		b.profile = protowire.AppendBytes(b.profile, loc)
+	}
+
+	b.profile = protowire.AppendTag(b.profile, profileTimeNanos, protowire.VarintType)
+	b.profile = protowire.AppendVarint(b.profile, uint64(p.Start.UnixNano()))
+	b.profile = protowire.AppendTag(b.profile, profileDurationNanos, protowire.VarintType)
+	b.profile = protowire.AppendVarint(b.profile, uint64(p.End.Sub(p.Start).Nanoseconds()))
+	b.profile = protowire.AppendTag(b.profile, profilePeriodType, protowire.BytesType)
+	b.profile = protowire.AppendBytes(b.profile, b.valueType("samples", "count"))
+	b.profile = protowire.AppendTag(b.profile, profilePeriod, protowire.VarintType)
+	b.profile = protowire.AppendVarint(b.profile, 1)
+
+	// strings, once all referenced
+	for _, s := range b.stringTable {
+		b.profile = protowire.AppendTag(b.profile, profileStringTable, protowire.BytesType)
+		b.profile = protowire.AppendString(b.profile, s)
+	}
+
+	gw := gzip.NewWriter(w)
+	if _, err := gw.Write(b.profile); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	return errfmt.WrapError(gw.Close())
+}
+
+// pprofBuilder encodes a pprof profile, interning its strings and locations.
+type pprofBuilder struct {
+	profile     []byte
+	strings     map[string]int64
+	stringTable []string
+	locations   map[uint64]uint64 // location id by address
+	addresses   []uint64          // addresses, in order of location id
+}
+
+func (b *pprofBuilder) str(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.stringTable))
+	b.strings[s] = i
+	b.stringTable = append(b.stringTable, s)
+
+	return i
+}
+
+func (b *pprofBuilder) location(addr uint64) uint64 {
+	if id, ok := b.locations[addr]; ok {
+		return id
+	}
+	id := uint64(len(b.addresses) + 1) // ids start at 1
+	b.locations[addr] = id
+	b.addresses = append(b.addresses, addr)
+
+	return id
+}
+
+func (b *pprofBuilder) valueType(typ, unit string) []byte {
+	var vt []byte
+	vt = protowire.AppendTag(vt, valueTypeType, protowire.VarintType)
+	vt = protowire.AppendVarint(vt, uint64(b.str(typ)))
+	vt = protowire.AppendTag(vt, valueTypeUnit, protowire.VarintType)
+	vt = protowire.AppendVarint(vt, uint64(b.str(unit)))
+
+	return vt
+}
+
+func (b *pprofBuilder) label(key, value string) []byte {
+	var l []byte
+	l = protowire.AppendTag(l, labelKey, protowire.VarintType)
+	l = protowire.AppendVarint(l, uint64(b.str(key)))
+	l = protowire.AppendTag(l, labelStr, protowire.VarintType)
+	l = protowire.AppendVarint(l, uint64(b.str(value)))
+
+	return l
+}
+
+func (b *pprofBuilder) sample(s Stack, groupBy string) []byte {
+	// location ids, leaf first (as the stack addresses)
+	var ids []byte
+	for _, addr := range s.Addresses {
+		ids = protowire.AppendVarint(ids, b.location(addr))
+	}
+
+	var sample []byte
+	sample = protowire.AppendTag(sample, sampleLocationID, protowire.BytesType)
+	sample = protowire.AppendBytes(sample, ids)
+	sample = protowire.AppendTag(sample, sampleValue, protowire.BytesType)
+	sample = protowire.AppendBytes(sample, protowire.AppendVarint(nil, s.Count))
+	if groupBy == GroupByContainer {
+		sample = protowire.AppendTag(sample, sampleLabel, protowire.BytesType)
+		sample = protowire.AppendBytes(sample, b.label("container", s.Container))
+	}
+	sample = protowire.AppendTag(sample, sampleLabel, protowire.BytesType)
+	sample = protowire.AppendBytes(sample, b.label("comm", s.Comm))
+
+	return sample
+}
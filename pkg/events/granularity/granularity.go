@@ -0,0 +1,111 @@
+// Package granularity coalesces the per-thread events of high-frequency events into process-level
+// events: the events of the threads of a process are deduplicated by the process (TGID), cutting
+// the events volume of heavily threaded workloads.
+package granularity
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// DefaultWindow is the default time window the identical events of a process are coalesced in.
+const DefaultWindow = time.Second
+
+const maxKeys = 16384 // coalesced events tracked at once
+
+// Config is the events granularity configuration.
+type Config struct {
+	ProcessEvents []int         // events coalesced at process level (all events are per-thread if empty)
+	Window        time.Duration // time window the identical events of a process are coalesced in
+}
+
+// Enabled returns whether events are coalesced at process level.
+func (c Config) Enabled() bool {
+	return len(c.ProcessEvents) > 0
+}
+
+// key identifies the identical events of a process: the host pid alone is reused once a
+// process exits.
+type key struct {
+	eventID  int
+	entityID uint32
+	hostPid  int
+	retval   int
+	args     uint64 // hash of the arguments values
+}
+
+// Coalescer coalesces the events selected for it at process level. It isn't thread-safe: it is
+// fed by a single events pipeline stage.
+type Coalescer struct {
+	events map[int]struct{}
+	window time.Duration
+	seen   *lru.Cache[key, int] // timestamp of the last emitted event, by key
+}
+
+// New creates a coalescer of the given configuration.
+func New(cfg Config) (*Coalescer, error) {
+	if cfg.Window <= 0 {
+		return nil, errfmt.Errorf("invalid granularity window: %v", cfg.Window)
+	}
+	seen, err := lru.New[key, int](maxKeys)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	c := &Coalescer{
+		events: make(map[int]struct{}, len(cfg.ProcessEvents)),
+		window: cfg.Window,
+		seen:   seen,
+	}
+	for _, id := range cfg.ProcessEvents {
+		c.events[id] = struct{}{}
+	}
+
+	return c, nil
+}
+
+// Coalesce returns whether an event is emitted. The events selected for process granularity are
+// reported as events of the process (their thread ids are the process ones), and are dropped if
+// an identical event of the same process (same arguments and return value), from any of its
+// threads, was emitted within the window. Other events are left untouched.
+func (c *Coalescer) Coalesce(event *trace.Event) bool {
+	if _, ok := c.events[event.EventID]; !ok {
+		return true
+	}
+
+	event.ThreadID = event.ProcessID
+	event.HostThreadID = event.HostProcessID
+	event.ThreadEntityId = event.ProcessEntityId
+
+	event.DecodeArgs() // the coalesced events are identified by all their arguments
+	k := key{
+		eventID:  event.EventID,
+		entityID: event.ProcessEntityId,
+		hostPid:  event.HostProcessID,
+		retval:   event.ReturnValue,
+		args:     hashArgs(event.Args),
+	}
+	last, ok := c.seen.Get(k)
+	if ok && event.Timestamp >= last && time.Duration(event.Timestamp-last) < c.window {
+		return false
+	}
+	c.seen.Add(k, event.Timestamp)
+
+	return true
+}
+
+// hashArgs hashes the arguments values of an event.
+func hashArgs(args []trace.Argument) uint64 {
+	h := fnv.New64a()
+	for _, arg := range args {
+		fmt.Fprintf(h, "%s=%v;", arg.Name, arg.Value)
+	}
+
+	return h.Sum64()
+}
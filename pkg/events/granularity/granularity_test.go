@@ -0,0 +1,76 @@
+package granularity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	readID  = 0
+	writeID = 1
+)
+
+func newEvent(ts time.Duration, eventID, pid, tid int, entityID uint32, fd int32) *trace.Event {
+	return &trace.Event{
+		Timestamp:       int(ts),
+		EventID:         eventID,
+		ProcessID:       pid,
+		ThreadID:        tid,
+		HostProcessID:   pid,
+		HostThreadID:    tid,
+		ProcessEntityId: entityID,
+		ThreadEntityId:  entityID + uint32(tid),
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "fd", Type: "int"}, Value: fd},
+		},
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Config{ProcessEvents: []int{readID}, Window: time.Second})
+	require.NoError(t, err)
+
+	// the first event of a process is emitted, as an event of the process
+	event := newEvent(0, readID, 100, 101, 1, 3)
+	assert.True(t, c.Coalesce(event))
+	assert.Equal(t, 100, event.ThreadID)
+	assert.Equal(t, 100, event.HostThreadID)
+	assert.Equal(t, uint32(1), event.ThreadEntityId)
+
+	// identical events of other threads of the process are coalesced within the window
+	assert.False(t, c.Coalesce(newEvent(100*time.Millisecond, readID, 100, 102, 1, 3)))
+	assert.False(t, c.Coalesce(newEvent(200*time.Millisecond, readID, 100, 101, 1, 3)))
+
+	// different arguments, processes or reused pids are not
+	assert.True(t, c.Coalesce(newEvent(300*time.Millisecond, readID, 100, 102, 1, 4)))
+	assert.True(t, c.Coalesce(newEvent(300*time.Millisecond, readID, 200, 201, 2, 3)))
+	assert.True(t, c.Coalesce(newEvent(300*time.Millisecond, readID, 100, 101, 3, 3)))
+
+	// emitted again once the window elapsed
+	assert.True(t, c.Coalesce(newEvent(time.Second, readID, 100, 103, 1, 3)))
+	assert.False(t, c.Coalesce(newEvent(1500*time.Millisecond, readID, 100, 101, 1, 3)))
+
+	// events not selected for process granularity are untouched
+	for i := 0; i < 2; i++ {
+		event := newEvent(2*time.Second, writeID, 100, 101, 1, 3)
+		assert.True(t, c.Coalesce(event))
+		assert.Equal(t, 101, event.ThreadID)
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{ProcessEvents: []int{readID}})
+	assert.ErrorContains(t, err, "invalid granularity window")
+
+	assert.False(t, Config{}.Enabled())
+	assert.True(t, Config{ProcessEvents: []int{readID}, Window: DefaultWindow}.Enabled())
+}
@@ -3,13 +3,18 @@ package events
 import (
 	"bytes"
 	"fmt"
+	"net"
 	"strconv"
+	"strings"
 	"unsafe"
 
+	"golang.org/x/sys/unix"
+
 	bpf "github.com/aquasecurity/libbpfgo"
 	"github.com/aquasecurity/libbpfgo/helpers"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events/fdtable"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -135,7 +140,7 @@ func ParseArgs(event *trace.Event) error {
 				parseOrEmptyString(typeArg, socketTypeArgument, err)
 			}
 		}
-	case SecuritySocketCreate, SecuritySocketConnect:
+	case SecuritySocketCreate, SecuritySocketConnect, UnixSocketConnect:
 		if domArg := GetArg(event, "family"); domArg != nil {
 			if dom, isInt32 := domArg.Value.(int32); isInt32 {
 				socketDomainArgument, err := helpers.ParseSocketDomainArgument(uint64(dom))
@@ -162,7 +167,7 @@ func ParseArgs(event *trace.Event) error {
 				parseOrEmptyString(flagsArg, execFlagArgument, err)
 			}
 		}
-	case Open, Openat, SecurityFileOpen:
+	case Open, Openat, SecurityFileOpen, SensitiveFileAccess, ContainerNotifyOnReleaseWrite:
 		if flagsArg := GetArg(event, "flags"); flagsArg != nil {
 			if flags, isInt32 := flagsArg.Value.(int32); isInt32 {
 				openFlagArgument, err := helpers.ParseOpenFlagArgument(uint64(flags))
@@ -293,29 +298,216 @@ func ParseArgs(event *trace.Event) error {
 				parseOrEmptyString(objTypeArg, objTypeArgument, err)
 			}
 		}
+	case Mount:
+		if flagsArg := GetArg(event, "mountflags"); flagsArg != nil {
+			if flags, isUint64 := flagsArg.Value.(uint64); isUint64 {
+				flagsArg.Type = "string"
+				flagsArg.Value = parseMountFlags(flags)
+			}
+		}
+	case SecuritySbMount, SensitiveHostMount:
+		if flagsArg := GetArg(event, "flags"); flagsArg != nil {
+			if flags, isUint64 := flagsArg.Value.(uint64); isUint64 {
+				flagsArg.Type = "string"
+				flagsArg.Value = parseMountFlags(flags)
+			}
+		}
+	case GratuitousARP:
+		if opArg := GetArg(event, "operation"); opArg != nil {
+			if op, isUint16 := opArg.Value.(uint16); isUint16 {
+				opArg.Type = "string"
+				opArg.Value = parseARPOperation(op)
+			}
+		}
+		for _, name := range []string{"sender_mac", "target_mac"} {
+			if macArg := GetArg(event, name); macArg != nil {
+				if mac, isBytes := macArg.Value.([]byte); isBytes {
+					macArg.Type = "string"
+					macArg.Value = net.HardwareAddr(mac).String()
+				}
+			}
+		}
+		if ipArg := GetArg(event, "sender_ip"); ipArg != nil {
+			if ip, isBytes := ipArg.Value.([]byte); isBytes {
+				ipArg.Type = "string"
+				ipArg.Value = net.IP(ip).String()
+			}
+		}
+	case OomKill:
+		if constraintArg := GetArg(event, "constraint"); constraintArg != nil {
+			if constraint, isInt32 := constraintArg.Value.(int32); isInt32 {
+				constraintArg.Type = "string"
+				constraintArg.Value = parseOOMConstraint(constraint)
+			}
+		}
 	}
 
 	return nil
 }
 
-func ParseArgsFDs(event *trace.Event, origTimestamp uint64, fdArgPathMap *bpf.BPFMap) error {
-	if fdArg := GetArg(event, "fd"); fdArg != nil {
-		if fd, isInt32 := fdArg.Value.(int32); isInt32 {
+// parseARPOperation returns the name of the given ARP operation.
+func parseARPOperation(op uint16) string {
+	switch op {
+	case 1:
+		return "request"
+	case 2:
+		return "reply"
+	}
+
+	return strconv.FormatUint(uint64(op), 10)
+}
+
+// parseOOMConstraint returns the name of the given out of memory constraint (the memory the
+// victim was chosen to free).
+func parseOOMConstraint(constraint int32) string {
+	switch constraint {
+	case 0:
+		return "none"
+	case 1:
+		return "cpuset"
+	case 2:
+		return "memory_policy"
+	case 3:
+		return "memcg"
+	}
+
+	return strconv.FormatInt(int64(constraint), 10)
+}
+
+// mountFlags are the names of the mount(2) flags, by value.
+var mountFlags = []struct {
+	flag uint64
+	name string
+}{
+	{unix.MS_RDONLY, "MS_RDONLY"},
+	{unix.MS_NOSUID, "MS_NOSUID"},
+	{unix.MS_NODEV, "MS_NODEV"},
+	{unix.MS_NOEXEC, "MS_NOEXEC"},
+	{unix.MS_SYNCHRONOUS, "MS_SYNCHRONOUS"},
+	{unix.MS_REMOUNT, "MS_REMOUNT"},
+	{unix.MS_MANDLOCK, "MS_MANDLOCK"},
+	{unix.MS_DIRSYNC, "MS_DIRSYNC"},
+	{unix.MS_NOSYMFOLLOW, "MS_NOSYMFOLLOW"},
+	{unix.MS_NOATIME, "MS_NOATIME"},
+	{unix.MS_NODIRATIME, "MS_NODIRATIME"},
+	{unix.MS_BIND, "MS_BIND"},
+	{unix.MS_MOVE, "MS_MOVE"},
+	{unix.MS_REC, "MS_REC"},
+	{unix.MS_SILENT, "MS_SILENT"},
+	{unix.MS_POSIXACL, "MS_POSIXACL"},
+	{unix.MS_UNBINDABLE, "MS_UNBINDABLE"},
+	{unix.MS_PRIVATE, "MS_PRIVATE"},
+	{unix.MS_SLAVE, "MS_SLAVE"},
+	{unix.MS_SHARED, "MS_SHARED"},
+	{unix.MS_RELATIME, "MS_RELATIME"},
+	{unix.MS_KERNMOUNT, "MS_KERNMOUNT"},
+	{unix.MS_I_VERSION, "MS_I_VERSION"},
+	{unix.MS_STRICTATIME, "MS_STRICTATIME"},
+	{unix.MS_LAZYTIME, "MS_LAZYTIME"},
+}
+
+// parseMountFlags returns the given mount(2) flags as a "|" separated list of their names
+// (e.g. "MS_BIND|MS_REC"). Unknown bits are given as a trailing hexadecimal value.
+func parseMountFlags(flags uint64) string {
+	// the legacy magic number may still be given in the upper 16 bits
+	if flags&unix.MS_MGC_MSK == unix.MS_MGC_VAL {
+		flags &^= unix.MS_MGC_MSK
+	}
+
+	var names []string
+	for _, f := range mountFlags {
+		if flags&f.flag != 0 {
+			names = append(names, f.name)
+			flags &^= f.flag
+		}
+	}
+	if flags != 0 {
+		names = append(names, "0x"+strconv.FormatUint(flags, 16))
+	}
+
+	return strings.Join(names, "|")
+}
+
+// fdArgNames are the names of the arguments holding a file descriptor of the process.
+var fdArgNames = map[string]struct{}{
+	"fd":          {},
+	"sockfd":      {},
+	"dirfd":       {},
+	"olddirfd":    {},
+	"newdirfd":    {},
+	"oldfd":       {},
+	"newfd":       {},
+	"fd_in":       {},
+	"fd_out":      {},
+	"in_fd":       {},
+	"out_fd":      {},
+	"epfd":        {},
+	"dfd":         {},
+	"from_dfd":    {},
+	"to_dfd":      {},
+	"ufd":         {},
+	"pidfd":       {},
+	"fsfd":        {},
+	"mount_fd":    {},
+	"group_fd":    {},
+	"kernel_fd":   {},
+	"initrd_fd":   {},
+	"fanotify_fd": {},
+	"ruleset_fd":  {},
+}
+
+// ParseArgsFDs resolves the file descriptor arguments of an event to the path, socket or pipe they
+// refer to (e.g. fd=3 becomes "3=/etc/passwd"). The fd argument of the syscalls resolved by the
+// kernel is taken from the fd_arg_path_map, the other file descriptors from the processes fd
+// table (if given), as of the time of the event.
+func ParseArgsFDs(event *trace.Event, origTimestamp uint64, fdArgPathMap *bpf.BPFMap, fdTable *fdtable.Table) error {
+	var mapErr error
+
+	for i := range event.Args {
+		arg := &event.Args[i]
+		if _, ok := fdArgNames[arg.Name]; !ok {
+			continue
+		}
+
+		var fd int32
+		switch v := arg.Value.(type) {
+		case int32:
+			fd = v
+		case uint32:
+			fd = int32(v)
+		default:
+			continue
+		}
+
+		if arg.Name == "fd" && fdArgPathMap != nil {
 			ts := origTimestamp
 			bs, err := fdArgPathMap.GetValue(unsafe.Pointer(&ts))
-			if err != nil {
-				return errfmt.WrapError(err)
+			if err == nil {
+				fpath := string(bytes.Trim(bs, "\x00"))
+				arg.Value = fmt.Sprintf("%d=%s", fd, fpath)
+				continue
 			}
+			mapErr = errfmt.WrapError(err)
+		}
 
-			fpath := string(bytes.Trim(bs, "\x00"))
-			fdArg.Value = fmt.Sprintf("%d=%s", fd, fpath)
+		if fdTable == nil || fd < 0 {
+			continue
+		}
+		if desc, ok := fdTable.Lookup(event.HostProcessID, fd, origTimestamp); ok {
+			arg.Value = fmt.Sprintf("%d=%s", fd, desc)
+			if arg.Name == "fd" {
+				mapErr = nil
+			}
 		}
 	}
 
-	return nil
+	return mapErr
 }
 
+// GetArg returns the argument with the given name, to be modified in place: the arguments of
+// the event not decoded yet are decoded first.
 func GetArg(event *trace.Event, argName string) *trace.Argument {
+	event.DecodeArgs()
 	for i := range event.Args {
 		if event.Args[i].Name == argName {
 			return &event.Args[i]
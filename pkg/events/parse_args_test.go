@@ -8,6 +8,7 @@ import (
 
 	"github.com/aquasecurity/libbpfgo/helpers"
 
+	"github.com/aquasecurity/tracee/pkg/events/fdtable"
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
@@ -206,4 +207,138 @@ func TestParseArgs(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("Parse gratuitous arp values", func(t *testing.T) {
+		t.Parallel()
+
+		event := &trace.Event{
+			EventID: int(GratuitousARP),
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "interface", Type: "const char*"}, Value: "eth0"},
+				{ArgMeta: trace.ArgMeta{Name: "operation", Type: "u16"}, Value: uint16(2)},
+				{ArgMeta: trace.ArgMeta{Name: "sender_mac", Type: "bytes"}, Value: []byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02}},
+				{ArgMeta: trace.ArgMeta{Name: "sender_ip", Type: "bytes"}, Value: []byte{172, 17, 0, 2}},
+				{ArgMeta: trace.ArgMeta{Name: "target_mac", Type: "bytes"}, Value: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+			},
+		}
+		err := ParseArgs(event)
+		require.NoError(t, err)
+
+		expectedArgs := []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "interface", Type: "const char*"}, Value: "eth0"},
+			{ArgMeta: trace.ArgMeta{Name: "operation", Type: "string"}, Value: "reply"},
+			{ArgMeta: trace.ArgMeta{Name: "sender_mac", Type: "string"}, Value: "02:42:ac:11:00:02"},
+			{ArgMeta: trace.ArgMeta{Name: "sender_ip", Type: "string"}, Value: "172.17.0.2"},
+			{ArgMeta: trace.ArgMeta{Name: "target_mac", Type: "string"}, Value: "ff:ff:ff:ff:ff:ff"},
+		}
+		assert.Equal(t, expectedArgs, event.Args)
+	})
+
+	t.Run("Parse oom kill constraint", func(t *testing.T) {
+		t.Parallel()
+
+		event := &trace.Event{
+			EventID: int(OomKill),
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "memcg_id", Type: "u64"}, Value: uint64(4242)},
+				{ArgMeta: trace.ArgMeta{Name: "constraint", Type: "int"}, Value: int32(3)},
+			},
+		}
+		err := ParseArgs(event)
+		require.NoError(t, err)
+
+		expectedArgs := []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "memcg_id", Type: "u64"}, Value: uint64(4242)},
+			{ArgMeta: trace.ArgMeta{Name: "constraint", Type: "string"}, Value: "memcg"},
+		}
+		assert.Equal(t, expectedArgs, event.Args)
+	})
+
+	t.Run("Parse mount flags", func(t *testing.T) {
+		t.Parallel()
+
+		testCases := []struct {
+			name          string
+			flags         uint64
+			expectedValue string
+		}{
+			{
+				name:          "recursive private bind",
+				flags:         0x44000 | 0x1000,
+				expectedValue: "MS_BIND|MS_REC|MS_PRIVATE",
+			},
+			{
+				name:          "legacy magic number",
+				flags:         0xc0ed0000 | 0x1,
+				expectedValue: "MS_RDONLY",
+			},
+			{
+				name:          "unknown bits",
+				flags:         0x2 | 0x80000000,
+				expectedValue: "MS_NOSUID|0x80000000",
+			},
+			{
+				name:          "no flags",
+				flags:         0,
+				expectedValue: "",
+			},
+		}
+
+		for _, testCase := range testCases {
+			testCase := testCase
+
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				event := &trace.Event{
+					EventID: int(SecuritySbMount),
+					Args: []trace.Argument{
+						{ArgMeta: trace.ArgMeta{Name: "flags", Type: "unsigned long"}, Value: testCase.flags},
+					},
+				}
+				err := ParseArgs(event)
+				require.NoError(t, err)
+				arg := GetArg(event, "flags")
+				assert.Equal(t, trace.ArgMeta{Name: "flags", Type: "string"}, arg.ArgMeta)
+				assert.Equal(t, testCase.expectedValue, arg.Value)
+			})
+		}
+	})
+}
+
+func TestParseArgsFDs(t *testing.T) {
+	t.Parallel()
+
+	fdTable, err := fdtable.New(fdtable.DefaultMaxProcesses)
+	require.NoError(t, err)
+	fdTable.Open(100, 3, "/etc/passwd", false, 10)
+	fdTable.Open(100, 4, "socket:[AF_INET,SOCK_STREAM]", false, 10)
+
+	event := &trace.Event{
+		EventID:       int(Sendfile),
+		HostProcessID: 100,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "out_fd", Type: "int"}, Value: int32(4)},
+			{ArgMeta: trace.ArgMeta{Name: "in_fd", Type: "int"}, Value: int32(3)},
+			{ArgMeta: trace.ArgMeta{Name: "offset", Type: "off_t*"}, Value: uintptr(0)},
+			{ArgMeta: trace.ArgMeta{Name: "count", Type: "size_t"}, Value: uint64(4096)},
+		},
+	}
+	err = ParseArgsFDs(event, 20, nil, fdTable)
+	require.NoError(t, err)
+	assert.Equal(t, "4=socket:[AF_INET,SOCK_STREAM]", GetArg(event, "out_fd").Value)
+	assert.Equal(t, "3=/etc/passwd", GetArg(event, "in_fd").Value)
+	assert.Equal(t, uint64(4096), GetArg(event, "count").Value)
+
+	// unknown descriptors are left as is
+	event = &trace.Event{
+		EventID:       int(Fstat),
+		HostProcessID: 200,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "fd", Type: "int"}, Value: int32(3)},
+		},
+	}
+	err = ParseArgsFDs(event, 20, nil, fdTable)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), GetArg(event, "fd").Value)
 }
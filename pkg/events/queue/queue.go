@@ -14,3 +14,70 @@ type EventQueue interface {
 	Enqueue(*trace.Event)
 	Dequeue() *trace.Event
 }
+
+// WatermarkLevel is the memory usage level of a queue, relative to its memory budget.
+type WatermarkLevel int
+
+const (
+	WatermarkNormal WatermarkLevel = iota // usage below the high watermark
+	WatermarkHigh                         // usage above the high watermark
+	WatermarkFull                         // memory budget exhausted (until below the high watermark)
+)
+
+func (l WatermarkLevel) String() string {
+	switch l {
+	case WatermarkNormal:
+		return "normal"
+	case WatermarkHigh:
+		return "high"
+	case WatermarkFull:
+		return "full"
+	}
+	return "unknown"
+}
+
+// WatermarkFunc is called whenever the memory usage of a queue changes its watermark level.
+// Usage and budget are given in bytes.
+type WatermarkFunc func(level WatermarkLevel, usage, budget int)
+
+// MemoryBudgeted is implemented by queues enforcing a memory budget.
+type MemoryBudgeted interface {
+	// MemoryUsage returns the estimated memory used by the queued events, and the memory
+	// budget of the queue, in bytes.
+	MemoryUsage() (usage int, budget int)
+	// OnWatermark registers a function called on watermark level changes. It is called
+	// synchronously from Enqueue and Dequeue, so it shouldn't block.
+	OnWatermark(fn WatermarkFunc)
+	// Dropped returns the number of events dropped by the queue overflow policy.
+	Dropped() uint64
+}
+
+// OverflowPolicy defines what a queue does with new events when its memory usage is high.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks enqueuing while the memory budget is exhausted (default).
+	OverflowBlock OverflowPolicy = iota
+	// OverflowSample enqueues only one of every SampleRate events above the high
+	// watermark, and drops new events from the moment the memory budget is exhausted and
+	// until the usage goes below the high watermark.
+	OverflowSample
+	// OverflowDrop drops new events from the moment the high watermark is reached and
+	// until the usage goes below the low watermark.
+	OverflowDrop
+)
+
+// SampleRate is the sampling rate of the OverflowSample policy.
+const SampleRate = 10
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowSample:
+		return "sample"
+	case OverflowDrop:
+		return "drop"
+	}
+	return "unknown"
+}
@@ -8,22 +8,46 @@ import (
 	"github.com/aquasecurity/tracee/types/trace"
 )
 
+// Watermarks, in percentage of the memory budget
+const (
+	highWatermarkPercent = 80
+	lowWatermarkPercent  = 60
+)
+
 // this usecase implements EventQueue interface with a memory stored queue (FIFO)
 type eventQueueMem struct {
 	mutex                *sync.Mutex
 	cond                 *sync.Cond
 	cache                *list.List
-	maxAmountOfEvents    int // max number of cached events possible
+	memBudget            int // max memory used by cached events, in bytes
+	memUsage             int // estimated memory used by cached events, in bytes
+	highWatermark        int
+	lowWatermark         int
+	level                WatermarkLevel
+	watermarkFns         []WatermarkFunc
+	policy               OverflowPolicy
+	sampled              uint64 // events seen while sampling
+	dropped              uint64
 	eventsCacheMemSizeMB int
 	verbose              string
 }
 
 func NewEventQueueMem(queueSizeMb int) CacheConfig {
+	return NewEventQueueMemWithPolicy(queueSizeMb, OverflowBlock)
+}
+
+// NewEventQueueMemWithPolicy creates a memory queue enforcing the given overflow policy
+// when its memory usage goes above the high watermark.
+func NewEventQueueMemWithPolicy(queueSizeMb int, policy OverflowPolicy) CacheConfig {
 	q := &eventQueueMem{
 		eventsCacheMemSizeMB: queueSizeMb,
+		policy:               policy,
 	}
-	q.setup()
+	q.setup(q.getQueueSizeInBytes())
 	q.verbose = fmt.Sprintf("In-Memory Event Queue (Size = %d MB)", q.eventsCacheMemSizeMB)
+	if policy != OverflowBlock {
+		q.verbose = fmt.Sprintf("In-Memory Event Queue (Size = %d MB, Overflow = %s)", q.eventsCacheMemSizeMB, policy)
+	}
 	return q
 }
 
@@ -31,27 +55,51 @@ func (q *eventQueueMem) String() string {
 	return q.verbose
 }
 
-func (q *eventQueueMem) setup() {
+func (q *eventQueueMem) setup(memBudget int) {
 	q.mutex = new(sync.Mutex)
 	q.cond = sync.NewCond(q.mutex)
 
-	// set queue size and init queue
-	q.maxAmountOfEvents = q.getQueueSizeInEvents()
+	// set queue memory budget and init queue
+	q.memBudget = memBudget
+	q.highWatermark = memBudget / 100 * highWatermarkPercent
+	q.lowWatermark = memBudget / 100 * lowWatermarkPercent
 	q.cache = new(list.List)
 }
 
-// Enqueue pushes an event into the queue (may block until queue is available)
+// Enqueue pushes an event into the queue (may block until queue is available, or drop the
+// event, depending on the overflow policy)
 func (q *eventQueueMem) Enqueue(evt *trace.Event) {
+	size := EventSize(evt)
+
 	q.cond.L.Lock()
-	// enqueue waits for de-queuing if cache is full (using >= instead of == to be in the safe side...)
-	for q.cache.Len() >= q.maxAmountOfEvents {
+	if q.shouldDrop(size) {
+		q.dropped++
+		q.cond.L.Unlock()
+		return
+	}
+
+	// enqueue waits for de-queuing if the memory budget is exhausted (an event bigger than
+	// the whole budget is still accepted when the queue is empty)
+	for q.cache.Len() > 0 && q.memUsage+size > q.memBudget {
+		if fns := q.setLevel(WatermarkFull); fns != nil {
+			usage := q.memUsage
+			q.cond.L.Unlock()
+			q.notify(fns, WatermarkFull, usage)
+			q.cond.L.Lock()
+			continue
+		}
 		q.cond.Wait()
 	}
 
-	q.cache.PushBack(*evt)
+	q.cache.PushBack(cachedEvent{event: *evt, size: size})
+	q.memUsage += size
+	level, usage := q.levelFor(q.memUsage), q.memUsage
+	fns := q.setLevel(level)
 	q.cond.L.Unlock()
 	q.cond.Signal() // unblock dequeue if needed
 
+	q.notify(fns, level, usage)
+
 	evt = nil
 }
 
@@ -65,26 +113,118 @@ func (q *eventQueueMem) Dequeue() *trace.Event {
 	}
 
 	e := q.cache.Front()
-	event, ok := e.Value.(trace.Event)
+	cached, ok := e.Value.(cachedEvent)
 	if !ok {
 		q.cond.L.Unlock()
 		return nil
 	}
 	q.cache.Remove(e)
+	q.memUsage -= cached.size
+	level, usage := q.levelFor(q.memUsage), q.memUsage
+	fns := q.setLevel(level)
 	q.cond.L.Unlock()
 	q.cond.Signal() // unblock enqueue if needed
 
-	return &event
+	q.notify(fns, level, usage)
+
+	return &cached.event
 }
 
-// getQueueSizeInEvents returns size of the fifo queue, in # of events, based on
-// the host size
-func (q *eventQueueMem) getQueueSizeInEvents() int {
-	// eventSize is the memory footprint per event in bytes. This is NOT the
-	// size of a single event, but the overall impact in memory consumption to
-	// each cached event (defined by experimentation)
-	eventSize := 1024
+// MemoryUsage returns the estimated memory used by the queued events, and the memory
+// budget of the queue, in bytes.
+func (q *eventQueueMem) MemoryUsage() (int, int) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.memUsage, q.memBudget
+}
+
+// OnWatermark registers a function called on watermark level changes.
+func (q *eventQueueMem) OnWatermark(fn WatermarkFunc) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.watermarkFns = append(q.watermarkFns, fn)
+}
+
+// Dropped returns the number of events dropped by the queue overflow policy.
+func (q *eventQueueMem) Dropped() uint64 {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
 
+	return q.dropped
+}
+
+// cachedEvent is a queued event along with its estimated memory footprint.
+type cachedEvent struct {
+	event trace.Event
+	size  int
+}
+
+// shouldDrop applies the overflow policy to a new event (must be called with the lock held).
+func (q *eventQueueMem) shouldDrop(size int) bool {
+	exhausted := q.cache.Len() > 0 && q.memUsage+size > q.memBudget
+
+	switch q.policy {
+	case OverflowSample:
+		if exhausted || q.level == WatermarkFull {
+			return true
+		}
+		if q.level == WatermarkNormal {
+			return false
+		}
+		q.sampled++
+		return q.sampled%SampleRate != 0
+	case OverflowDrop:
+		return exhausted || q.level != WatermarkNormal
+	}
+
+	return false
+}
+
+// levelFor returns the watermark level of the given memory usage. Once full, the level
+// only goes back to high below the high watermark, and once above the high watermark, it
+// only goes back to normal below the low watermark, so the level doesn't flip on every
+// event around a watermark (must be called with the lock held).
+func (q *eventQueueMem) levelFor(usage int) WatermarkLevel {
+	switch {
+	case usage >= q.memBudget:
+		return WatermarkFull
+	case usage >= q.highWatermark && q.level == WatermarkFull:
+		return WatermarkFull
+	case usage >= q.highWatermark:
+		return WatermarkHigh
+	case usage >= q.lowWatermark && q.level != WatermarkNormal:
+		return WatermarkHigh
+	}
+
+	return WatermarkNormal
+}
+
+// setLevel sets the watermark level, returning the functions to notify if it changed
+// (must be called with the lock held).
+func (q *eventQueueMem) setLevel(level WatermarkLevel) []WatermarkFunc {
+	if level == q.level {
+		return nil
+	}
+	q.level = level
+	if level == WatermarkNormal {
+		q.sampled = 0
+	}
+
+	return q.watermarkFns
+}
+
+// notify calls the given watermark functions (must be called without the lock held).
+func (q *eventQueueMem) notify(fns []WatermarkFunc, level WatermarkLevel, usage int) {
+	for _, fn := range fns {
+		fn(level, usage, q.memBudget)
+	}
+}
+
+// getQueueSizeInBytes returns the memory budget of the fifo queue, in bytes, based on
+// the host size
+func (q *eventQueueMem) getQueueSizeInBytes() int {
 	kbToB := func(amountInKB int) int {
 		return amountInKB * 1024
 	}
@@ -94,26 +234,26 @@ func (q *eventQueueMem) getQueueSizeInEvents() int {
 	gbToMB := func(amountInGB int) int {
 		return amountInGB * 1024
 	}
-	amountOfEvents := func(amountInMB int) int {
-		return mbToKB(kbToB(amountInMB)) / eventSize
+	mbToB := func(amountInMB int) int {
+		return kbToB(mbToKB(amountInMB))
 	}
 
-	// EventsCacheMemSize was provided, return exact amount of events for it
+	// EventsCacheMemSize was provided, return exact budget for it
 	if q.eventsCacheMemSizeMB > 0 {
-		return amountOfEvents(q.eventsCacheMemSizeMB)
+		return mbToB(q.eventsCacheMemSizeMB)
 	}
 
 	switch {
-	case q.eventsCacheMemSizeMB <= gbToMB(1): // up to 1GB, cache = ~256MB in events #
-		return amountOfEvents(256)
-	case q.eventsCacheMemSizeMB <= gbToMB(4): // up to 4GB, cache = ~512MB in events #
-		return amountOfEvents(512)
-	case q.eventsCacheMemSizeMB <= gbToMB(8): // up to 8GB, cache = ~1GB in events #
-		return amountOfEvents(gbToMB(1))
-	case q.eventsCacheMemSizeMB <= gbToMB(16): // up to 16GB, cache = ~2GB in events #
-		return amountOfEvents(gbToMB(2))
-	}
-
-	// bigger hosts, cache = ~4GB in events #
-	return amountOfEvents(gbToMB(4))
+	case q.eventsCacheMemSizeMB <= gbToMB(1): // up to 1GB, cache = ~256MB
+		return mbToB(256)
+	case q.eventsCacheMemSizeMB <= gbToMB(4): // up to 4GB, cache = ~512MB
+		return mbToB(512)
+	case q.eventsCacheMemSizeMB <= gbToMB(8): // up to 8GB, cache = ~1GB
+		return mbToB(gbToMB(1))
+	case q.eventsCacheMemSizeMB <= gbToMB(16): // up to 16GB, cache = ~2GB
+		return mbToB(gbToMB(2))
+	}
+
+	// bigger hosts, cache = ~4GB
+	return mbToB(gbToMB(4))
 }
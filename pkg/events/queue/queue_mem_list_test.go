@@ -35,3 +35,157 @@ func TestEnqueueDequeue(t *testing.T) {
 	}()
 	wg.Wait()
 }
+
+func newTestQueue(eventsBudget int, policy OverflowPolicy) (*eventQueueMem, int) {
+	size := EventSize(&trace.Event{})
+	q := &eventQueueMem{policy: policy}
+	q.setup(eventsBudget * size)
+
+	return q, size
+}
+
+func TestWatermarks(t *testing.T) {
+	t.Parallel()
+
+	q, size := newTestQueue(10, OverflowBlock)
+
+	var levels []WatermarkLevel
+	q.OnWatermark(func(level WatermarkLevel, usage, budget int) {
+		levels = append(levels, level)
+	})
+
+	for i := 0; i < 8; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh}, levels)
+
+	// above the low watermark the level is kept
+	q.Dequeue()
+	q.Dequeue()
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh}, levels)
+
+	q.Dequeue()
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh, WatermarkNormal}, levels)
+
+	usage, budget := q.MemoryUsage()
+	assert.Equal(t, 5*size, usage)
+	assert.Equal(t, 10*size, budget)
+}
+
+func TestWatermarkFullHysteresis(t *testing.T) {
+	t.Parallel()
+
+	q, _ := newTestQueue(10, OverflowBlock)
+
+	var levels []WatermarkLevel
+	q.OnWatermark(func(level WatermarkLevel, usage, budget int) {
+		levels = append(levels, level)
+	})
+
+	for i := 0; i < 10; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh, WatermarkFull}, levels)
+
+	// the level doesn't flip between full and high while blocking around the budget
+	for i := 0; i < 5; i++ {
+		q.Dequeue()
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh, WatermarkFull}, levels)
+
+	// below the high watermark the level goes back to high
+	q.Dequeue()
+	q.Dequeue()
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh, WatermarkFull}, levels)
+	q.Dequeue()
+	assert.DeepEqual(t, []WatermarkLevel{WatermarkHigh, WatermarkFull, WatermarkHigh}, levels)
+}
+
+func TestOverflowDrop(t *testing.T) {
+	t.Parallel()
+
+	q, size := newTestQueue(10, OverflowDrop)
+
+	for i := 0; i < 12; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+
+	usage, _ := q.MemoryUsage()
+	assert.Equal(t, 8*size, usage)
+	assert.Equal(t, uint64(4), q.Dropped())
+}
+
+func TestOverflowSample(t *testing.T) {
+	t.Parallel()
+
+	q, _ := newTestQueue(10, OverflowSample)
+
+	for i := 0; i < 28; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+
+	// 8 events up to the high watermark, then 1 of every SampleRate events
+	assert.Equal(t, 10, q.cache.Len())
+	assert.Equal(t, uint64(18), q.Dropped())
+	for i := 0; i < 8; i++ {
+		assert.Equal(t, i, q.Dequeue().Timestamp)
+	}
+	assert.Equal(t, 17, q.Dequeue().Timestamp)
+	assert.Equal(t, 27, q.Dequeue().Timestamp)
+}
+
+func TestOverflowSampleFull(t *testing.T) {
+	t.Parallel()
+
+	q, _ := newTestQueue(10, OverflowSample)
+
+	for i := 0; i < 28; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.Equal(t, WatermarkFull, q.level)
+
+	// once full, new events are dropped until the usage goes below the high watermark
+	q.Dequeue()
+	for i := 0; i < 2*SampleRate; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.Equal(t, 9, q.cache.Len())
+
+	q.Dequeue()
+	q.Dequeue()
+	assert.Equal(t, WatermarkHigh, q.level)
+	for i := 0; i < SampleRate; i++ {
+		q.Enqueue(&trace.Event{Timestamp: i})
+	}
+	assert.Equal(t, 8, q.cache.Len())
+}
+
+func TestOverflowBlock(t *testing.T) {
+	t.Parallel()
+
+	q, _ := newTestQueue(2, OverflowBlock)
+
+	full := make(chan struct{}, 1)
+	q.OnWatermark(func(level WatermarkLevel, usage, budget int) {
+		if level == WatermarkFull {
+			full <- struct{}{}
+		}
+	})
+
+	q.Enqueue(&trace.Event{Timestamp: 0})
+	q.Enqueue(&trace.Event{Timestamp: 1})
+	<-full
+
+	enqueued := make(chan struct{})
+	go func() {
+		q.Enqueue(&trace.Event{Timestamp: 2}) // blocks until an event is dequeued
+		close(enqueued)
+	}()
+
+	assert.Equal(t, 0, q.Dequeue().Timestamp)
+	<-enqueued
+	assert.Equal(t, uint64(0), q.Dropped())
+	assert.Equal(t, 1, q.Dequeue().Timestamp)
+	assert.Equal(t, 2, q.Dequeue().Timestamp)
+}
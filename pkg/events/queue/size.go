@@ -0,0 +1,74 @@
+package queue
+
+import (
+	"unsafe"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// allocOverheadPercent accounts for the memory allocator overhead (size classes rounding
+// and fragmentation) on top of the allocated data.
+const allocOverheadPercent = 25
+
+var (
+	// eventBaseSize is the memory footprint of a cached event without its dynamic data: the
+	// event itself and the list element holding it.
+	eventBaseSize  = int(unsafe.Sizeof(trace.Event{})) + 64
+	argSize        = int(unsafe.Sizeof(trace.Argument{}))
+	stackFrameSize = int(unsafe.Sizeof(trace.StackFrame{}))
+	pointerSize    = int(unsafe.Sizeof(uintptr(0)))
+)
+
+// EventSize returns an estimation of the memory used by a cached event, in bytes. Strings
+// shared among events (e.g. event and argument names) are not accounted for, only the
+// data allocated per event is (plus the allocator overhead).
+func EventSize(evt *trace.Event) int {
+	size := eventBaseSize
+
+	size += len(evt.ProcessName) + len(evt.HostName)
+	size += len(evt.MatchedPolicies) * int(unsafe.Sizeof(""))
+	size += len(evt.StackAddresses) * 8
+	size += len(evt.StackFrames) * stackFrameSize
+	size += len(evt.Args) * argSize
+	for _, arg := range evt.Args {
+		size += argValueSize(arg.Value)
+	}
+
+	return size + size*allocOverheadPercent/100
+}
+
+// argValueSize returns an estimation of the memory used by an argument value, besides the
+// interface holding it.
+func argValueSize(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	case []string:
+		size := len(v) * int(unsafe.Sizeof(""))
+		for _, s := range v {
+			size += len(s)
+		}
+		return size
+	case []uint64:
+		return len(v) * 8
+	case map[string]string:
+		size := 48 // map header
+		for k, s := range v {
+			size += len(k) + len(s) + 2*int(unsafe.Sizeof(""))
+		}
+		return size
+	case []trace.HookedSymbolData:
+		size := len(v) * int(unsafe.Sizeof(trace.HookedSymbolData{}))
+		for _, s := range v {
+			size += len(s.SymbolName) + len(s.ModuleOwner)
+		}
+		return size
+	default:
+		// scalars (and less common types) are boxed in a small allocation
+		return pointerSize
+	}
+}
@@ -0,0 +1,88 @@
+// Package recent keeps the most recent events of every process, giving the context of what
+// happened to a process when reporting it (e.g. the events preceding its crash).
+package recent
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// DefaultMaxEvents is the default number of most recent events kept per process.
+const DefaultMaxEvents = 32
+
+const maxProcesses = 4096 // processes whose recent events are kept
+
+// processKey identifies a process: the host pid alone is reused once a process exits.
+type processKey struct {
+	entityID uint32
+	hostPid  int
+}
+
+// ring is the most recent events of a process.
+type ring struct {
+	events []trace.RecentEvent
+	next   int
+}
+
+// Recorder records the most recent events of the processes. It isn't thread-safe: it is fed by,
+// and read from, the events pipeline stage deriving the events.
+type Recorder struct {
+	maxEvents int
+	processes *lru.Cache[processKey, *ring]
+}
+
+// New creates a recorder keeping the given number of most recent events per process.
+func New(maxEvents int) (*Recorder, error) {
+	if maxEvents <= 0 {
+		return nil, errfmt.Errorf("invalid recent events max events: %d", maxEvents)
+	}
+	processes, err := lru.New[processKey, *ring](maxProcesses)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Recorder{maxEvents: maxEvents, processes: processes}, nil
+}
+
+// Record records an event of a process, dropping its oldest recorded event if needed.
+func (r *Recorder) Record(event *trace.Event) {
+	key := processKey{entityID: event.ProcessEntityId, hostPid: event.HostProcessID}
+	p, ok := r.processes.Get(key)
+	if !ok {
+		p = &ring{events: make([]trace.RecentEvent, 0, r.maxEvents)}
+		r.processes.Add(key, p)
+	}
+
+	// the arguments are copied: they are parsed in place later in the pipeline
+	event.DecodeArgs()
+	args := make([]trace.Argument, len(event.Args))
+	copy(args, event.Args)
+	recent := trace.RecentEvent{
+		Timestamp:   event.Timestamp,
+		EventName:   event.EventName,
+		ThreadID:    event.ThreadID,
+		ReturnValue: event.ReturnValue,
+		Args:        args,
+	}
+
+	if len(p.events) < r.maxEvents {
+		p.events = append(p.events, recent)
+		return
+	}
+	p.events[p.next] = recent
+	p.next = (p.next + 1) % r.maxEvents
+}
+
+// Take returns the recorded events of the process of an event, oldest first, and forgets them.
+func (r *Recorder) Take(event *trace.Event) []trace.RecentEvent {
+	key := processKey{entityID: event.ProcessEntityId, hostPid: event.HostProcessID}
+	p, ok := r.processes.Peek(key)
+	if !ok {
+		return nil
+	}
+	r.processes.Remove(key)
+
+	return append(p.events[p.next:], p.events[:p.next]...)
+}
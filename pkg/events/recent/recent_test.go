@@ -0,0 +1,80 @@
+package recent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newEvent(ts, pid int, entityID uint32, name string) *trace.Event {
+	return &trace.Event{
+		Timestamp:       ts,
+		HostProcessID:   pid,
+		ThreadID:        pid,
+		ProcessEntityId: entityID,
+		EventName:       name,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/passwd"},
+		},
+	}
+}
+
+func names(recent []trace.RecentEvent) []string {
+	var n []string
+	for _, e := range recent {
+		n = append(n, e.EventName)
+	}
+
+	return n
+}
+
+func TestRecorderTake(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(3)
+	require.NoError(t, err)
+
+	r.Record(newEvent(10, 100, 1, "execve"))
+	r.Record(newEvent(20, 100, 1, "openat"))
+	r.Record(newEvent(15, 200, 2, "execve"))
+	r.Record(newEvent(30, 100, 1, "read"))
+	r.Record(newEvent(40, 100, 1, "write"))  // drops the oldest recorded event (execve)
+	r.Record(newEvent(50, 100, 3, "execve")) // another process, reusing the pid
+
+	recent := r.Take(newEvent(60, 100, 1, "sched_process_exit"))
+	assert.Equal(t, []string{"openat", "read", "write"}, names(recent))
+	assert.Equal(t, 20, recent[0].Timestamp)
+	assert.Equal(t, 100, recent[0].ThreadID)
+	assert.Equal(t, "/etc/passwd", recent[0].Args[0].Value)
+
+	// taken events are forgotten
+	assert.Empty(t, r.Take(newEvent(60, 100, 1, "sched_process_exit")))
+
+	assert.Equal(t, []string{"execve"}, names(r.Take(newEvent(60, 200, 2, "sched_process_exit"))))
+	assert.Equal(t, []string{"execve"}, names(r.Take(newEvent(60, 100, 3, "sched_process_exit"))))
+}
+
+func TestRecorderArgsCopied(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(DefaultMaxEvents)
+	require.NoError(t, err)
+
+	event := newEvent(10, 100, 1, "openat")
+	r.Record(event)
+	event.Args[0].Value = "parsed" // as parsed later in the pipeline
+
+	recent := r.Take(event)
+	require.Len(t, recent, 1)
+	assert.Equal(t, "/etc/passwd", recent[0].Args[0].Value)
+}
+
+func TestNewInvalidMaxEvents(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0)
+	assert.ErrorContains(t, err, "invalid recent events max events: 0")
+}
@@ -0,0 +1,22 @@
+package events
+
+// Syscall ABIs of the events, by the architecture of the syscall table the syscall was made
+// through (the native table of the node, or its 32-bit compat one).
+const (
+	ABIX8664   = "x86_64"
+	ABII386    = "i386"
+	ABIAArch64 = "aarch64"
+	ABIArm     = "arm"
+)
+
+// SyscallABIs are all the syscall ABIs, of every supported architecture.
+var SyscallABIs = []string{ABIX8664, ABII386, ABIAArch64, ABIArm}
+
+// SyscallABI returns the syscall ABI of an event, by whether it originated from a compat
+// (32-bit) syscall.
+func SyscallABI(isCompat bool) string {
+	if isCompat {
+		return CompatSyscallABI
+	}
+	return NativeSyscallABI
+}
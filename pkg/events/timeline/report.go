@@ -0,0 +1,84 @@
+package timeline
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+var htmlReport = template.Must(template.New("timeline").Funcs(template.FuncMap{
+	"time": formatTimestamp,
+	"args": formatArgs,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ProcessName}} [{{.HostProcessID}}] timeline</title>
+<style>
+body { font-family: monospace; margin: 1em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>{{.ProcessName}} [{{.HostProcessID}}]</h1>
+<table>
+<tr><th>Executable</th><td>{{.Executable}}</td></tr>
+<tr><th>Pid (namespace)</th><td>{{.ProcessID}}</td></tr>
+<tr><th>Entity id</th><td>{{.ProcessEntityID}}</td></tr>
+{{- if .ContainerID}}
+<tr><th>Container</th><td>{{.ContainerID}} {{.ContainerImage}}</td></tr>
+{{- end}}
+<tr><th>Start</th><td>{{time .Start}}</td></tr>
+<tr><th>End</th><td>{{time .End}}</td></tr>
+<tr><th>Exited</th><td>{{.Exited}}</td></tr>
+<tr><th>Dropped events</th><td>{{.DroppedEvents}}</td></tr>
+</table>
+{{- range .Threads}}
+<h2>Thread {{.HostThreadID}} ({{.ThreadID}} in namespace)</h2>
+<table>
+<tr><th>Time</th><th>Event</th><th>Syscall</th><th>Return</th><th>Arguments</th></tr>
+{{- range .Events}}
+<tr><td>{{time .Timestamp}}</td><td>{{.EventName}}</td><td>{{.Syscall}}</td><td>{{.ReturnValue}}</td><td>{{args .Args}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
+
+// Write writes the timeline report in the given format.
+func (t *Timeline) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return errfmt.WrapError(json.NewEncoder(w).Encode(t))
+	case FormatHTML:
+		return errfmt.WrapError(htmlReport.Execute(w, t))
+	default:
+		return errfmt.Errorf("invalid timeline format: %s", format)
+	}
+}
+
+// formatTimestamp formats an event timestamp (nanoseconds since the epoch).
+func formatTimestamp(ts int) string {
+	return time.Unix(0, int64(ts)).UTC().Format(time.RFC3339Nano)
+}
+
+func formatArgs(args []trace.Argument) string {
+	var b strings.Builder
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s=%v", arg.Name, arg.Value)
+	}
+
+	return b.String()
+}
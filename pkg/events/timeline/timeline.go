@@ -0,0 +1,298 @@
+// Package timeline materializes per-process timelines: the events of a process, grouped by
+// thread and ordered by time, exported as JSON or HTML reports (e.g. to hand off an incident
+// response) when the process exits, or on demand.
+package timeline
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/events/parse"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Report formats
+const (
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+// Defaults
+const (
+	DefaultMaxEvents = 1000
+	DefaultFormat    = FormatJSON
+)
+
+const (
+	maxProcesses      = 4096 // processes whose timeline is kept, live or exited
+	reportsBufferSize = 256  // reports waiting to be written before new ones are dropped
+)
+
+// Config is the configuration of the process timelines.
+type Config struct {
+	Enabled   bool
+	Dir       string // directory the reports are written to on process exit (on demand only if empty)
+	Format    string // format of the reports written on process exit
+	MaxEvents int    // most recent events kept per process
+}
+
+// Timeline is the timeline of a process.
+type Timeline struct {
+	HostProcessID   int      `json:"hostProcessId"`
+	ProcessID       int      `json:"processId"`
+	ProcessEntityID uint32   `json:"processEntityId"`
+	ProcessName     string   `json:"processName"`
+	Executable      string   `json:"executable"`
+	ContainerID     string   `json:"containerId,omitempty"`
+	ContainerImage  string   `json:"containerImage,omitempty"`
+	Start           int      `json:"start"` // first event timestamp
+	End             int      `json:"end"`   // last event timestamp
+	Exited          bool     `json:"exited"`
+	DroppedEvents   int      `json:"droppedEvents"` // oldest events dropped (over the max events)
+	Threads         []Thread `json:"threads"`       // ordered by first event
+}
+
+// Thread is the timeline of a thread of a process.
+type Thread struct {
+	HostThreadID int           `json:"hostThreadId"`
+	ThreadID     int           `json:"threadId"`
+	Events       []trace.Event `json:"events"` // ordered by time
+}
+
+// processKey identifies a process: the host pid alone is reused once a process exits.
+type processKey struct {
+	entityID uint32
+	hostPid  int
+}
+
+// process is the recorded events of a process, in a ring of the most recent ones.
+type process struct {
+	last    trace.Event // identity fields of the most recent event (e.g. name after an exec)
+	events  []trace.Event
+	next    int
+	dropped int
+	exited  bool
+}
+
+// Recorder records the events of every process, and materializes their timelines. It is
+// thread-safe.
+type Recorder struct {
+	mutex     sync.Mutex
+	cfg       Config
+	processes *lru.Cache[processKey, *process]
+	reports   chan *Timeline
+	closed    bool
+	done      chan struct{}
+	dropped   atomic.Uint64
+}
+
+// New creates a process timelines recorder.
+func New(cfg Config) (*Recorder, error) {
+	if cfg.MaxEvents <= 0 {
+		return nil, errfmt.Errorf("invalid timeline max events: %d", cfg.MaxEvents)
+	}
+	if cfg.Format != FormatJSON && cfg.Format != FormatHTML {
+		return nil, errfmt.Errorf("invalid timeline format: %s", cfg.Format)
+	}
+	if cfg.Dir != "" {
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+
+	processes, err := lru.New[processKey, *process](maxProcesses)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	r := &Recorder{
+		cfg:       cfg,
+		processes: processes,
+		reports:   make(chan *Timeline, reportsBufferSize),
+		done:      make(chan struct{}),
+	}
+	go r.writeReports()
+
+	return r, nil
+}
+
+// Add records an event in the timeline of its process. Once the process exits, its report
+// is written, if a directory is configured. It doesn't block on writing reports: if too many
+// reports are waiting to be written, the report is dropped (see Dropped).
+func (r *Recorder) Add(event trace.Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := processKey{entityID: event.ProcessEntityId, hostPid: event.HostProcessID}
+	p, ok := r.processes.Get(key)
+	if !ok {
+		p = &process{events: make([]trace.Event, 0, min(r.cfg.MaxEvents, 64))}
+		r.processes.Add(key, p)
+	}
+	p.add(event, r.cfg.MaxEvents)
+
+	if !isProcessExit(&event) {
+		return
+	}
+	p.exited = true
+	if r.cfg.Dir == "" || r.closed {
+		return
+	}
+
+	select {
+	case r.reports <- p.timeline():
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// Get returns the timeline of the given host pid: of the live process, or of the most recent
+// exited one.
+func (r *Recorder) Get(hostPid int) (*Timeline, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var found *process
+	for _, key := range r.processes.Keys() {
+		if key.hostPid != hostPid {
+			continue
+		}
+		p, ok := r.processes.Peek(key)
+		if !ok {
+			continue
+		}
+		if found == nil || (found.exited && !p.exited) ||
+			(found.exited == p.exited && p.last.Timestamp > found.last.Timestamp) {
+			found = p
+		}
+	}
+	if found == nil {
+		return nil, false
+	}
+
+	return found.timeline(), true
+}
+
+// Dropped returns the number of reports dropped because too many reports were waiting to be
+// written.
+func (r *Recorder) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Close writes the pending reports.
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.reports)
+	}
+	r.mutex.Unlock()
+
+	<-r.done
+
+	return nil
+}
+
+// writeReports writes the reports of the exited processes.
+func (r *Recorder) writeReports() {
+	defer close(r.done)
+
+	for t := range r.reports {
+		path := filepath.Join(r.cfg.Dir, t.fileName(r.cfg.Format))
+		if err := t.writeFile(path, r.cfg.Format); err != nil {
+			logger.Errorw("Writing process timeline", "path", path, "error", err)
+		}
+	}
+}
+
+// isProcessExit returns whether the event is the exit of a whole process (and not of one of
+// its threads).
+func isProcessExit(event *trace.Event) bool {
+	if events.ID(event.EventID) != events.SchedProcessExit {
+		return false
+	}
+	groupExit, err := parse.ArgVal[bool](event.Args, "process_group_exit")
+
+	return err == nil && groupExit
+}
+
+func (p *process) add(event trace.Event, maxEvents int) {
+	p.last = event
+	if len(p.events) < maxEvents {
+		p.events = append(p.events, event)
+		return
+	}
+	p.events[p.next] = event
+	p.next = (p.next + 1) % maxEvents
+	p.dropped++
+}
+
+// timeline materializes the timeline of the process.
+func (p *process) timeline() *Timeline {
+	ordered := make([]trace.Event, 0, len(p.events))
+	ordered = append(ordered, p.events[p.next:]...)
+	ordered = append(ordered, p.events[:p.next]...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp < ordered[j].Timestamp
+	})
+
+	t := &Timeline{
+		HostProcessID:   p.last.HostProcessID,
+		ProcessID:       p.last.ProcessID,
+		ProcessEntityID: p.last.ProcessEntityId,
+		ProcessName:     p.last.ProcessName,
+		Executable:      p.last.Executable.Path,
+		ContainerID:     p.last.Container.ID,
+		ContainerImage:  p.last.Container.ImageName,
+		Exited:          p.exited,
+		DroppedEvents:   p.dropped,
+		Threads:         make([]Thread, 0),
+	}
+	if len(ordered) > 0 {
+		t.Start = ordered[0].Timestamp
+		t.End = ordered[len(ordered)-1].Timestamp
+	}
+
+	threads := make(map[int]int) // host tid -> index in t.Threads
+	for _, event := range ordered {
+		i, ok := threads[event.HostThreadID]
+		if !ok {
+			i = len(t.Threads)
+			threads[event.HostThreadID] = i
+			t.Threads = append(t.Threads, Thread{
+				HostThreadID: event.HostThreadID,
+				ThreadID:     event.ThreadID,
+			})
+		}
+		t.Threads[i].Events = append(t.Threads[i].Events, event)
+	}
+
+	return t
+}
+
+// fileName returns the report file name of the timeline.
+func (t *Timeline) fileName(format string) string {
+	return fmt.Sprintf("timeline-%d-%d.%s", t.HostProcessID, t.ProcessEntityID, format)
+}
+
+func (t *Timeline) writeFile(path string, format string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if err := t.Write(f, format); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return errfmt.WrapError(f.Close())
+}
@@ -0,0 +1,173 @@
+package timeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newEvent(ts, pid, tid int, entityID uint32, name string) trace.Event {
+	return trace.Event{
+		Timestamp:       ts,
+		HostProcessID:   pid,
+		ProcessID:       pid,
+		HostThreadID:    tid,
+		ThreadID:        tid,
+		ProcessEntityId: entityID,
+		ProcessName:     "bash",
+		EventName:       name,
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/<passwd>"},
+		},
+	}
+}
+
+func exitEvent(ts, pid, tid int, entityID uint32, groupExit bool) trace.Event {
+	return trace.Event{
+		Timestamp:       ts,
+		HostProcessID:   pid,
+		HostThreadID:    tid,
+		ProcessEntityId: entityID,
+		EventID:         int(events.SchedProcessExit),
+		EventName:       "sched_process_exit",
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "exit_code", Type: "long"}, Value: int64(0)},
+			{ArgMeta: trace.ArgMeta{Name: "process_group_exit", Type: "bool"}, Value: groupExit},
+		},
+	}
+}
+
+func TestRecorderGet(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(Config{Enabled: true, Format: FormatJSON, MaxEvents: 3})
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.Add(newEvent(30, 100, 101, 1, "openat"))
+	r.Add(newEvent(10, 100, 100, 1, "execve"))
+	r.Add(newEvent(20, 100, 100, 1, "read"))
+	r.Add(newEvent(40, 100, 100, 1, "write")) // drops the oldest recorded event (openat)
+	r.Add(newEvent(15, 200, 200, 2, "execve"))
+
+	tl, ok := r.Get(100)
+	require.True(t, ok)
+	assert.Equal(t, 100, tl.HostProcessID)
+	assert.Equal(t, 1, tl.DroppedEvents)
+	assert.Equal(t, 10, tl.Start)
+	assert.Equal(t, 40, tl.End)
+	assert.False(t, tl.Exited)
+	require.Len(t, tl.Threads, 1)
+	assert.Equal(t, 100, tl.Threads[0].HostThreadID)
+	require.Len(t, tl.Threads[0].Events, 3)
+	assert.Equal(t, "execve", tl.Threads[0].Events[0].EventName)
+	assert.Equal(t, "read", tl.Threads[0].Events[1].EventName)
+	assert.Equal(t, "write", tl.Threads[0].Events[2].EventName)
+
+	_, ok = r.Get(300)
+	assert.False(t, ok)
+}
+
+func TestRecorderThreads(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(Config{Enabled: true, Format: FormatJSON, MaxEvents: 10})
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.Add(newEvent(20, 100, 101, 1, "openat"))
+	r.Add(newEvent(10, 100, 100, 1, "clone"))
+	r.Add(newEvent(30, 100, 100, 1, "read"))
+	r.Add(exitEvent(40, 100, 101, 1, false)) // thread exit
+
+	tl, ok := r.Get(100)
+	require.True(t, ok)
+	assert.False(t, tl.Exited)
+	require.Len(t, tl.Threads, 2)
+	assert.Equal(t, 100, tl.Threads[0].HostThreadID)
+	assert.Len(t, tl.Threads[0].Events, 2)
+	assert.Equal(t, 101, tl.Threads[1].HostThreadID)
+	assert.Len(t, tl.Threads[1].Events, 2)
+}
+
+func TestRecorderPidReuse(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(Config{Enabled: true, Format: FormatJSON, MaxEvents: 10})
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.Add(newEvent(10, 100, 100, 1, "execve"))
+	r.Add(exitEvent(20, 100, 100, 1, true))
+	r.Add(newEvent(30, 100, 100, 2, "execve"))
+
+	tl, ok := r.Get(100)
+	require.True(t, ok)
+	assert.Equal(t, uint32(2), tl.ProcessEntityID)
+	assert.False(t, tl.Exited)
+}
+
+func TestRecorderReportOnExit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r, err := New(Config{Enabled: true, Dir: dir, Format: FormatJSON, MaxEvents: 10})
+	require.NoError(t, err)
+
+	r.Add(newEvent(10, 100, 100, 7, "execve"))
+	r.Add(exitEvent(20, 100, 100, 7, true))
+	r.Add(newEvent(10, 200, 200, 8, "execve")) // still running: no report
+	require.NoError(t, r.Close())
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "timeline-100-7.json", files[0].Name())
+
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+	var tl Timeline
+	require.NoError(t, json.Unmarshal(data, &tl))
+	assert.True(t, tl.Exited)
+	require.Len(t, tl.Threads, 1)
+	assert.Len(t, tl.Threads[0].Events, 2)
+}
+
+func TestTimelineWriteHTML(t *testing.T) {
+	t.Parallel()
+
+	r, err := New(Config{Enabled: true, Format: FormatHTML, MaxEvents: 10})
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.Add(newEvent(1700000000000000000, 100, 100, 1, "openat"))
+	tl, ok := r.Get(100)
+	require.True(t, ok)
+
+	var buf bytes.Buffer
+	require.NoError(t, tl.Write(&buf, FormatHTML))
+	report := buf.String()
+	assert.Contains(t, report, "<h1>bash [100]</h1>")
+	assert.Contains(t, report, "2023-11-14T22:13:20Z")
+	assert.Contains(t, report, "pathname=/etc/&lt;passwd&gt;")
+
+	assert.Error(t, tl.Write(&buf, "pdf"))
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Enabled: true, Format: FormatJSON})
+	assert.ErrorContains(t, err, "invalid timeline max events: 0")
+
+	_, err = New(Config{Enabled: true, Format: "pdf", MaxEvents: 10})
+	assert.ErrorContains(t, err, "invalid timeline format: pdf")
+}
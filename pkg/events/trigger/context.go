@@ -6,7 +6,6 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/counter"
 	"github.com/aquasecurity/tracee/pkg/errfmt"
-	"github.com/aquasecurity/tracee/pkg/events/parse"
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/types/trace"
 )
@@ -67,7 +66,7 @@ func (store *context) Load(id uint64) (trace.Event, bool) {
 
 // Apply applies an event from the context store to the given event.
 func (store *context) Apply(event trace.Event) (trace.Event, error) {
-	contextID, err := parse.ArgVal[uint64](event.Args, ContextArgName)
+	contextID, err := trace.ArgValue[uint64](&event, ContextArgName)
 	if err != nil {
 		return trace.Event{}, errfmt.Errorf("error parsing caller_context_id arg: %v", err)
 	}
@@ -80,10 +79,11 @@ func (store *context) Apply(event trace.Event) (trace.Event, error) {
 	// "direction" because we only need the uint64, name, etc., from the argument event.
 
 	// same logic as derive.newEvent
+	event.DecodeArgs()
 	invoking.EventName = event.EventName
 	invoking.EventID = event.EventID
 	invoking.ReturnValue = 0
-	invoking.Args = make([]trace.Argument, len(event.Args))
+	invoking.SetArgs(make([]trace.Argument, len(event.Args))) // not those of the invoking event
 	invoking.PoliciesVersion = event.PoliciesVersion
 	invoking.MatchedPoliciesKernel = event.MatchedPoliciesKernel
 	invoking.MatchedPoliciesUser = event.MatchedPoliciesUser
@@ -0,0 +1,107 @@
+// Package truncation caps the size of the events arguments (e.g. command lines or buffers), so a
+// single event can't blow up the size of the output. The truncated arguments are marked as such,
+// with their original size, so the consumers know their data was cut.
+package truncation
+
+import (
+	"unicode/utf8"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Config is the arguments size limits configuration, in bytes (0 for no limit).
+type Config struct {
+	Default int            // limit of all the arguments
+	Args    map[string]int // limits of the arguments of the given names, overriding the default
+}
+
+// Enabled returns whether any argument size is limited.
+func (c Config) Enabled() bool {
+	if c.Default > 0 {
+		return true
+	}
+	for _, limit := range c.Args {
+		if limit > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// limit returns the size limit of an argument.
+func (c Config) limit(name string) int {
+	if limit, ok := c.Args[name]; ok {
+		return limit
+	}
+
+	return c.Default
+}
+
+// Truncate truncates the string, buffer and string array arguments exceeding their size limit,
+// marking them as truncated, with their original size (the sum of the strings sizes of a string
+// array). The other arguments are left as is.
+func (c Config) Truncate(args []trace.Argument) {
+	for i := range args {
+		arg := &args[i]
+		limit := c.limit(arg.Name)
+		if limit <= 0 {
+			continue
+		}
+
+		switch v := arg.Value.(type) {
+		case string:
+			if len(v) > limit {
+				arg.Value = truncateString(v, limit)
+				arg.Truncated = true
+				arg.OriginalSize = len(v)
+			}
+		case []byte:
+			if len(v) > limit {
+				arg.Value = v[:limit]
+				arg.Truncated = true
+				arg.OriginalSize = len(v)
+			}
+		case []string:
+			size := 0
+			for _, s := range v {
+				size += len(s)
+			}
+			if size > limit {
+				arg.Value = truncateStrings(v, limit)
+				arg.Truncated = true
+				arg.OriginalSize = size
+			}
+		}
+	}
+}
+
+// truncateString cuts a string to the given size at most, without splitting a UTF-8 character.
+func truncateString(s string, size int) string {
+	if !utf8.ValidString(s) {
+		return s[:size]
+	}
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+
+	return s[:size]
+}
+
+// truncateStrings cuts a string array to the given total size at most: the strings beyond it are
+// dropped, and the last string kept is cut.
+func truncateStrings(strs []string, size int) []string {
+	truncated := make([]string, 0, len(strs))
+	for _, s := range strs {
+		if len(s) > size {
+			if size > 0 {
+				truncated = append(truncated, truncateString(s, size))
+			}
+			break
+		}
+		truncated = append(truncated, s)
+		size -= len(s)
+	}
+
+	return truncated
+}
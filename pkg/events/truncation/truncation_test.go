@@ -0,0 +1,112 @@
+package truncation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func arg(name string, value interface{}) trace.Argument {
+	return trace.Argument{ArgMeta: trace.ArgMeta{Name: name}, Value: value}
+}
+
+func TestConfigTruncate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   Config
+		args     []trace.Argument
+		expected []trace.Argument
+	}{
+		{
+			name:     "no limit",
+			config:   Config{},
+			args:     []trace.Argument{arg("pathname", "/etc/passwd")},
+			expected: []trace.Argument{arg("pathname", "/etc/passwd")},
+		},
+		{
+			name:   "string",
+			config: Config{Default: 4},
+			args:   []trace.Argument{arg("pathname", "/etc/passwd"), arg("flags", int32(0))},
+			expected: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/etc", Truncated: true, OriginalSize: 11},
+				arg("flags", int32(0)),
+			},
+		},
+		{
+			name:     "string within the limit",
+			config:   Config{Default: 11},
+			args:     []trace.Argument{arg("pathname", "/etc/passwd")},
+			expected: []trace.Argument{arg("pathname", "/etc/passwd")},
+		},
+		{
+			name:   "utf-8 string",
+			config: Config{Default: 3},
+			args:   []trace.Argument{arg("pathname", "/été")},
+			expected: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/é", Truncated: true, OriginalSize: 6},
+			},
+		},
+		{
+			name:   "buffer",
+			config: Config{Default: 2},
+			args:   []trace.Argument{arg("buf", []byte{1, 2, 3})},
+			expected: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "buf"}, Value: []byte{1, 2}, Truncated: true, OriginalSize: 3},
+			},
+		},
+		{
+			name:   "string array",
+			config: Config{Default: 10},
+			args:   []trace.Argument{arg("argv", []string{"bash", "-c", "echo hello"})},
+			expected: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "argv"}, Value: []string{"bash", "-c", "echo"}, Truncated: true, OriginalSize: 16},
+			},
+		},
+		{
+			name:   "string array cut at a string boundary",
+			config: Config{Default: 6},
+			args:   []trace.Argument{arg("argv", []string{"bash", "-c", "echo hello"})},
+			expected: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "argv"}, Value: []string{"bash", "-c"}, Truncated: true, OriginalSize: 16},
+			},
+		},
+		{
+			name:   "argument limit",
+			config: Config{Default: 4, Args: map[string]int{"argv": 0, "buf": 1}},
+			args: []trace.Argument{
+				arg("argv", []string{"bash", "-c", "echo hello"}),
+				arg("buf", []byte{1, 2, 3}),
+				arg("pathname", "/etc/passwd"),
+			},
+			expected: []trace.Argument{
+				arg("argv", []string{"bash", "-c", "echo hello"}),
+				{ArgMeta: trace.ArgMeta{Name: "buf"}, Value: []byte{1}, Truncated: true, OriginalSize: 3},
+				{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/etc", Truncated: true, OriginalSize: 11},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.config.Truncate(tc.args)
+			assert.Equal(t, tc.expected, tc.args)
+		})
+	}
+}
+
+func TestConfigEnabled(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Config{}.Enabled())
+	assert.False(t, Config{Args: map[string]int{"argv": 0}}.Enabled())
+	assert.True(t, Config{Default: 4096}.Enabled())
+	assert.True(t, Config{Args: map[string]int{"argv": 4096}}.Enabled())
+}
@@ -90,6 +90,72 @@ func fetchInitNamespaces() map[string]uint32 {
 	return initNamespacesMap
 }
 
+// SignaturePluginLoadEvent creates an event auditing the load (or refusal) of a Go
+// signature plugin, after its signature verification.
+func SignaturePluginLoadEvent(path, sha256 string, signed, verified, loaded bool, reason string) trace.Event {
+	def := Core.GetDefinitionByID(SignaturePluginLoad)
+	params := def.GetParams()
+	values := []interface{}{path, sha256, signed, verified, loaded, reason}
+
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return trace.Event{
+		Timestamp:   int(time.Now().UnixNano()),
+		ProcessName: "tracee",
+		EventID:     int(SignaturePluginLoad),
+		EventName:   def.GetName(),
+		ArgsNum:     len(args),
+		Args:        args,
+	}
+}
+
+// CapabilityReportEvent creates an event reporting the selected events disabled, or degraded,
+// because of the running kernel, with their reasons, and the probes attached to fallbacks.
+func CapabilityReportEvent(disabled, degraded []string, reasons, fallbacks map[string]string) trace.Event {
+	def := Core.GetDefinitionByID(CapabilityReport)
+	params := def.GetParams()
+	values := []interface{}{disabled, degraded, reasons, fallbacks}
+
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return trace.Event{
+		Timestamp:   int(time.Now().UnixNano()),
+		ProcessName: "tracee",
+		EventID:     int(CapabilityReport),
+		EventName:   def.GetName(),
+		ArgsNum:     len(args),
+		Args:        args,
+	}
+}
+
+// PolicyQuotaExceededEvent creates an event alerting that a policy exceeded its quota (its maximum
+// event rate or its total events), and that its events are sampled from now on.
+func PolicyQuotaExceededEvent(policy, reason string, limit, sampleRate uint64) trace.Event {
+	def := Core.GetDefinitionByID(PolicyQuotaExceeded)
+	params := def.GetParams()
+	values := []interface{}{policy, reason, limit, sampleRate}
+
+	args := make([]trace.Argument, len(params))
+	for i := range params {
+		args[i] = trace.Argument{ArgMeta: params[i], Value: values[i]}
+	}
+
+	return trace.Event{
+		Timestamp:   int(time.Now().UnixNano()),
+		ProcessName: "tracee",
+		EventID:     int(PolicyQuotaExceeded),
+		EventName:   def.GetName(),
+		ArgsNum:     len(args),
+		Args:        args,
+	}
+}
+
 // ExistingContainersEvents returns a list of events for each existing container
 func ExistingContainersEvents(cts *containers.Containers, enrichDisabled bool) []trace.Event {
 	var events []trace.Event
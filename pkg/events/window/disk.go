@@ -0,0 +1,206 @@
+package window
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	segmentSpan   = time.Minute
+	segmentSuffix = ".jsonl"
+)
+
+// diskEntry is a line of a segment file.
+type diskEntry struct {
+	At    int64        `json:"t"`
+	Event *trace.Event `json:"e"`
+}
+
+// diskStore retains events in segment files, one per minute, each holding an event per
+// line. Expired segments are removed as a whole.
+type diskStore struct {
+	dir     string
+	current int64 // start (unix seconds) of the segment being written
+	file    *os.File
+	writer  *bufio.Writer
+	expired int64 // last expiry check, truncated to the segment span
+}
+
+func newDiskStore(dir string) (*diskStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &diskStore{dir: dir}, nil
+}
+
+func (s *diskStore) segmentPath(start int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(start, 10)+segmentSuffix)
+}
+
+// segments returns the start of the existing segments, oldest first.
+func (s *diskStore) segments() ([]int64, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	starts := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		start, err := strconv.ParseInt(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil {
+			continue // not a segment
+		}
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	return starts, nil
+}
+
+func (s *diskStore) add(at time.Time, evt *trace.Event) error {
+	start := at.Truncate(segmentSpan).Unix()
+	if s.file == nil || start != s.current {
+		if err := s.closeSegment(); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(s.segmentPath(start), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		s.current = start
+		s.file = file
+		s.writer = bufio.NewWriter(file)
+	}
+
+	line, err := json.Marshal(diskEntry{At: at.UnixNano(), Event: evt})
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	return nil
+}
+
+func (s *diskStore) query(after time.Time, fn func(evt *trace.Event) bool) error {
+	starts, err := s.segments()
+	if err != nil {
+		return err
+	}
+
+	first := after.Truncate(segmentSpan).Unix()
+	for i := len(starts) - 1; i >= 0 && starts[i] >= first; i-- {
+		evts, err := s.querySegment(starts[i], after.UnixNano())
+		if err != nil {
+			return err
+		}
+		for j := len(evts) - 1; j >= 0; j-- {
+			if !fn(evts[j]) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+// querySegment returns the events of a segment added after the given time, oldest first.
+func (s *diskStore) querySegment(start, after int64) ([]*trace.Event, error) {
+	file, err := os.Open(s.segmentPath(start))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil // expired meanwhile
+		}
+		return nil, errfmt.WrapError(err)
+	}
+	defer file.Close()
+
+	var evts []*trace.Event
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry diskEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue // partially written line
+		}
+		if entry.At < after || entry.Event == nil {
+			continue
+		}
+		evts = append(evts, entry.Event)
+	}
+
+	return evts, errfmt.WrapError(scanner.Err())
+}
+
+func (s *diskStore) expire(before time.Time) error {
+	// segments are removed as a whole, checking once per segment span is enough
+	check := before.Truncate(segmentSpan).Unix()
+	if check == s.expired {
+		return nil
+	}
+	s.expired = check
+
+	starts, err := s.segments()
+	if err != nil {
+		return err
+	}
+
+	// a segment expires once all of its events are older than the given time
+	for _, start := range starts {
+		if time.Unix(start, 0).Add(segmentSpan).After(before) {
+			break
+		}
+		if start == s.current {
+			if err := s.closeSegment(); err != nil {
+				return err
+			}
+		}
+		if err := os.Remove(s.segmentPath(start)); err != nil && !os.IsNotExist(err) {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *diskStore) flush() error {
+	if s.writer == nil {
+		return nil
+	}
+
+	return errfmt.WrapError(s.writer.Flush())
+}
+
+func (s *diskStore) closeSegment() error {
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.writer.Flush()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file = nil
+	s.writer = nil
+
+	return errfmt.WrapError(err)
+}
+
+func (s *diskStore) close() error {
+	return s.closeSegment()
+}
@@ -0,0 +1,199 @@
+package window
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/filters"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Filter matches events against filter expressions, all of which must match.
+//
+// Expressions follow the syntax of the --scope flag, and are parsed by the same filters:
+// field<operator>values, where operator is one of '=', '!=', '<', '<=', '>', '>=' (only
+// '=' and '!=' for strings), and values are separated by ','. Strings are compared as a
+// prefix if ending with '*', as a suffix if starting with '*', or as a substring if both.
+// For example:
+//
+//	event=sched_process_exec
+//	container=3f2a*
+//	uid>0
+//	args.pathname=/etc/*
+//	not-container
+//
+// Supported fields: event, comm (processName), uts (hostName), container (containerId),
+// containerName, containerImage, podName, podNamespace, podUid, syscall, policy, pid (p),
+// tid, ppid, uid, mntns, pidns, retval, labels.<key> and args.<name>. The boolean container and
+// not-container expressions select the events of containers or of the host.
+type Filter struct {
+	matchers []func(evt *trace.Event) bool
+}
+
+// ParseFilter parses filter expressions. No expressions match all events.
+func ParseFilter(exprs []string) (*Filter, error) {
+	f := &Filter{}
+
+	for _, expr := range exprs {
+		if expr == "" {
+			continue
+		}
+		matcher, err := parseExpression(expr)
+		if err != nil {
+			return nil, errfmt.Errorf("invalid events filter %q: %v", expr, err)
+		}
+		f.matchers = append(f.matchers, matcher)
+	}
+
+	return f, nil
+}
+
+// Match returns true if the event matches all the filter expressions.
+func (f *Filter) Match(evt *trace.Event) bool {
+	for _, matcher := range f.matchers {
+		if !matcher(evt) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseExpression(expr string) (func(evt *trace.Event) bool, error) {
+	operatorIdx := strings.IndexAny(expr, "=!<>")
+	if operatorIdx == -1 {
+		switch expr {
+		case "container":
+			return func(evt *trace.Event) bool { return evt.Container.ID != "" }, nil
+		case "not-container":
+			return func(evt *trace.Event) bool { return evt.Container.ID == "" }, nil
+		}
+		return nil, filters.InvalidExpression(expr)
+	}
+
+	field, operatorAndValues := expr[:operatorIdx], expr[operatorIdx:]
+	if err := validateOperatorAndValues(operatorAndValues); err != nil {
+		return nil, err
+	}
+
+	switch field {
+	case "event":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.EventName })
+	case "comm", "processName":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.ProcessName })
+	case "uts", "hostName":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.HostName })
+	case "container", "containerId":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Container.ID })
+	case "containerName":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Container.Name })
+	case "containerImage":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Container.ImageName })
+	case "podName":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Kubernetes.PodName })
+	case "podNamespace":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Kubernetes.PodNamespace })
+	case "podUid":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Kubernetes.PodUID })
+	case "syscall":
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Syscall })
+	case "policy":
+		filter := filters.NewStringFilter(nil)
+		if err := filter.Parse(operatorAndValues); err != nil {
+			return nil, err
+		}
+		return func(evt *trace.Event) bool {
+			for _, policy := range evt.MatchedPolicies {
+				if filter.Filter(policy) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "pid", "p":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.HostProcessID })
+	case "tid":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.HostThreadID })
+	case "ppid":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.HostParentProcessID })
+	case "uid":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.UserID })
+	case "mntns":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.MountNS })
+	case "pidns":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.PIDNS })
+	case "retval":
+		return intMatcher(operatorAndValues, func(evt *trace.Event) int { return evt.ReturnValue })
+	}
+
+	if labelName, isLabel := strings.CutPrefix(field, "labels."); isLabel && labelName != "" {
+		return stringMatcher(operatorAndValues, func(evt *trace.Event) string { return evt.Labels[labelName] })
+	}
+
+	argName, isArg := strings.CutPrefix(field, "args.")
+	if !isArg || argName == "" {
+		return nil, filters.InvalidScopeField(field)
+	}
+
+	// arguments are compared by their string representation
+	filter := filters.NewStringFilter(nil)
+	if err := filter.Parse(operatorAndValues); err != nil {
+		return nil, err
+	}
+	return func(evt *trace.Event) bool {
+		for _, arg := range evt.Args {
+			if arg.Name == argName {
+				return filter.Filter(fmt.Sprint(arg.Value))
+			}
+		}
+		return false
+	}, nil
+}
+
+// validateOperatorAndValues checks the operator is valid and that no value is empty, as
+// the filters expect.
+func validateOperatorAndValues(operatorAndValues string) error {
+	values := ""
+	for _, operator := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if v, ok := strings.CutPrefix(operatorAndValues, operator); ok {
+			values = v
+			break
+		}
+	}
+	for _, value := range strings.Split(values, ",") {
+		if value == "" {
+			return filters.InvalidExpression(operatorAndValues)
+		}
+	}
+
+	return nil
+}
+
+func stringMatcher(operatorAndValues string, value func(evt *trace.Event) string) (func(evt *trace.Event) bool, error) {
+	filter := filters.NewStringFilter(nil)
+	if err := filter.Parse(operatorAndValues); err != nil {
+		return nil, err
+	}
+
+	return func(evt *trace.Event) bool { return filter.Filter(value(evt)) }, nil
+}
+
+func intMatcher(operatorAndValues string, value func(evt *trace.Event) int) (func(evt *trace.Event) bool, error) {
+	// an int filter with only not equal values doesn't match any value (it is meant to
+	// narrow other expressions of the same filter), so it is matched as a negated equality
+	if values, ok := strings.CutPrefix(operatorAndValues, "!="); ok {
+		matcher, err := intMatcher("="+values, value)
+		if err != nil {
+			return nil, err
+		}
+		return func(evt *trace.Event) bool { return !matcher(evt) }, nil
+	}
+
+	filter := filters.NewIntFilter()
+	if err := filter.Parse(operatorAndValues); err != nil {
+		return nil, err
+	}
+
+	return func(evt *trace.Event) bool { return filter.Filter(int64(value(evt))) }, nil
+}
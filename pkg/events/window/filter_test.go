@@ -0,0 +1,79 @@
+package window
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	evt := &trace.Event{
+		EventName:     "sched_process_exec",
+		ProcessName:   "bash",
+		HostProcessID: 1234,
+		Container: trace.Container{
+			ID:   "3f2a1b0c9d8e",
+			Name: "nginx",
+		},
+		MatchedPolicies: []string{"default", "forensics"},
+		Labels:          map[string]string{"cluster": "prod-eu"},
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/usr/bin/bash"},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		exprs   []string
+		matches bool
+	}{
+		{name: "empty", exprs: nil, matches: true},
+		{name: "event", exprs: []string{"event=sched_process_exec"}, matches: true},
+		{name: "event alternatives", exprs: []string{"event=openat,sched_process_exec"}, matches: true},
+		{name: "event mismatch", exprs: []string{"event=openat"}, matches: false},
+		{name: "event prefix", exprs: []string{"event=sched_*"}, matches: true},
+		{name: "negated", exprs: []string{"comm!=bash"}, matches: false},
+		{name: "container", exprs: []string{"container"}, matches: true},
+		{name: "not container", exprs: []string{"not-container"}, matches: false},
+		{name: "container id prefix", exprs: []string{"container=3f2a*"}, matches: true},
+		{name: "container name", exprs: []string{"containerName=nginx"}, matches: true},
+		{name: "pid", exprs: []string{"pid=1234"}, matches: true},
+		{name: "pid not equal", exprs: []string{"pid!=1,2"}, matches: true},
+		{name: "pid not equal mismatch", exprs: []string{"pid!=1234"}, matches: false},
+		{name: "pid range", exprs: []string{"pid>1000", "pid<2000"}, matches: true},
+		{name: "pid out of range", exprs: []string{"pid>=2000"}, matches: false},
+		{name: "policy", exprs: []string{"policy=forensics"}, matches: true},
+		{name: "label", exprs: []string{"labels.cluster=prod-*"}, matches: true},
+		{name: "unknown label", exprs: []string{"labels.role=worker"}, matches: false},
+		{name: "arg suffix", exprs: []string{"args.pathname=*/bash"}, matches: true},
+		{name: "arg contains", exprs: []string{"args.pathname=*bin*"}, matches: true},
+		{name: "unknown arg", exprs: []string{"args.flags=1"}, matches: false},
+		{name: "all expressions", exprs: []string{"event=sched_process_exec", "containerName=nginx", "comm=sh"}, matches: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := ParseFilter(tc.exprs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.matches, f.Match(evt))
+		})
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{"event", "foo=bar", "args.=x", "labels.=x", "comm<bash", "pid=x", "event=a,,b", "comm!bash"} {
+		_, err := ParseFilter([]string{expr})
+		assert.Error(t, err, expr)
+	}
+}
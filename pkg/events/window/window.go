@@ -0,0 +1,296 @@
+// Package window retains the events of the last minutes, in memory or on disk, so they can
+// be queried (e.g. "all execs in container X in the last 10 minutes") from the agent.
+package window
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Defaults
+const (
+	DefaultDuration  = 10 * time.Minute
+	DefaultMaxEvents = 100000
+)
+
+// Config is the configuration of an events window.
+type Config struct {
+	Enabled   bool
+	Duration  time.Duration // how long events are retained
+	MaxEvents int           // max number of events retained in memory
+	Dir       string        // if set, events are retained on disk, in this directory
+}
+
+// ingestBufferSize is the number of events waiting to be retained before new events are
+// dropped (so adding events never blocks the caller).
+const ingestBufferSize = 10000
+
+// store retains events along with the time they were added to the window.
+type store interface {
+	add(at time.Time, evt *trace.Event) error
+	// query calls fn for the events added after the given time, newest first, until fn
+	// returns false.
+	query(after time.Time, fn func(evt *trace.Event) bool) error
+	// expire removes the events added before the given time.
+	expire(before time.Time) error
+	// flush makes the added events visible to queries.
+	flush() error
+	close() error
+}
+
+// entry is an event waiting to be retained.
+type entry struct {
+	at  time.Time
+	evt trace.Event
+}
+
+// Window retains the events added in the last Duration. Events are added asynchronously,
+// by a goroutine writing them to the store, while queries only read the store. Window is
+// thread-safe.
+type Window struct {
+	mutex    sync.RWMutex // guards the store
+	duration time.Duration
+	store    store
+	now      func() time.Time
+	ingest   chan entry
+	flushes  chan chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	dropped  atomic.Uint64
+}
+
+// New creates an events window.
+func New(cfg Config) (*Window, error) {
+	if cfg.Duration <= 0 {
+		return nil, errfmt.Errorf("invalid events window duration: %v", cfg.Duration)
+	}
+	if cfg.MaxEvents <= 0 {
+		return nil, errfmt.Errorf("invalid events window max events: %d", cfg.MaxEvents)
+	}
+
+	w := &Window{
+		duration: cfg.Duration,
+		now:      time.Now,
+		ingest:   make(chan entry, ingestBufferSize),
+		flushes:  make(chan chan struct{}),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	if cfg.Dir != "" {
+		s, err := newDiskStore(cfg.Dir)
+		if err != nil {
+			return nil, err
+		}
+		w.store = s
+	} else {
+		w.store = newMemStore(cfg.MaxEvents)
+	}
+
+	go w.run()
+
+	return w, nil
+}
+
+// Add adds an event to the window. It doesn't block: if too many events are waiting to be
+// retained, the event is dropped (see Dropped).
+func (w *Window) Add(evt trace.Event) {
+	select {
+	case w.ingest <- entry{at: w.now(), evt: evt}:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because too many events were waiting to be
+// retained.
+func (w *Window) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Flush waits for the events added so far to be retained (e.g. to query them right away).
+func (w *Window) Flush() {
+	flushed := make(chan struct{})
+	select {
+	case w.flushes <- flushed:
+		<-flushed
+	case <-w.done:
+	}
+}
+
+// run retains the added events, expiring the events older than the window duration.
+func (w *Window) run() {
+	defer close(w.done)
+
+	for {
+		select {
+		case e := <-w.ingest:
+			w.retain(e)
+		case flushed := <-w.flushes:
+			for len(w.ingest) > 0 {
+				w.retain(<-w.ingest)
+			}
+			close(flushed)
+		case <-w.stop:
+			for len(w.ingest) > 0 {
+				w.retain(<-w.ingest)
+			}
+			return
+		}
+	}
+}
+
+func (w *Window) retain(e entry) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if err := w.store.expire(e.at.Add(-w.duration)); err != nil {
+		logger.Errorw("Expiring events window", "error", err)
+	}
+	if err := w.store.add(e.at, &e.evt); err != nil {
+		logger.Errorw("Adding event to events window", "error", err)
+		return
+	}
+	// make the events visible to queries once there are no more pending events
+	if len(w.ingest) == 0 {
+		if err := w.store.flush(); err != nil {
+			logger.Errorw("Flushing events window", "error", err)
+		}
+	}
+}
+
+// Query returns the events added in the last given duration (or in the whole window if
+// zero) matching the filter, oldest first. If limit isn't zero, only the newest limit
+// events are returned.
+func (w *Window) Query(filter *Filter, last time.Duration, limit int) ([]trace.Event, error) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if last <= 0 || last > w.duration {
+		last = w.duration
+	}
+
+	res := make([]trace.Event, 0)
+	err := w.store.query(w.now().Add(-last), func(evt *trace.Event) bool {
+		if filter.Match(evt) {
+			res = append(res, *evt)
+		}
+		return limit == 0 || len(res) < limit
+	})
+
+	// the store returns the newest events first
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+
+	return res, err
+}
+
+// Close retains the pending events and releases the window resources.
+func (w *Window) Close() error {
+	close(w.stop)
+	<-w.done
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.store.close()
+}
+
+//
+// memory store
+//
+
+// memStoreInitialSize is the initial number of entries of the memory store, which grows
+// up to the max events as needed.
+const memStoreInitialSize = 1024
+
+type memEntry struct {
+	at  time.Time
+	evt trace.Event
+}
+
+// memStore is a ring buffer of the last events.
+type memStore struct {
+	entries   []memEntry
+	maxEvents int
+	head      int // index of the oldest entry
+	size      int
+}
+
+func newMemStore(maxEvents int) *memStore {
+	return &memStore{
+		maxEvents: maxEvents,
+	}
+}
+
+// grow doubles the ring buffer capacity, up to the max events.
+func (s *memStore) grow() {
+	capacity := 2 * len(s.entries)
+	if capacity < memStoreInitialSize {
+		capacity = memStoreInitialSize
+	}
+	if capacity > s.maxEvents {
+		capacity = s.maxEvents
+	}
+
+	entries := make([]memEntry, capacity)
+	for i := 0; i < s.size; i++ {
+		entries[i] = s.entries[(s.head+i)%len(s.entries)]
+	}
+	s.entries = entries
+	s.head = 0
+}
+
+func (s *memStore) add(at time.Time, evt *trace.Event) error {
+	if s.size == len(s.entries) && len(s.entries) < s.maxEvents {
+		s.grow()
+	}
+
+	idx := (s.head + s.size) % len(s.entries)
+	s.entries[idx] = memEntry{at: at, evt: *evt}
+	if s.size < len(s.entries) {
+		s.size++
+	} else {
+		s.head = (s.head + 1) % len(s.entries) // overwrote the oldest entry
+	}
+
+	return nil
+}
+
+func (s *memStore) query(after time.Time, fn func(evt *trace.Event) bool) error {
+	for i := s.size - 1; i >= 0; i-- {
+		entry := &s.entries[(s.head+i)%len(s.entries)]
+		if entry.at.Before(after) {
+			break // older entries are all before
+		}
+		if !fn(&entry.evt) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (s *memStore) expire(before time.Time) error {
+	for s.size > 0 && s.entries[s.head].at.Before(before) {
+		s.entries[s.head] = memEntry{} // release the event memory
+		s.head = (s.head + 1) % len(s.entries)
+		s.size--
+	}
+
+	return nil
+}
+
+func (s *memStore) flush() error {
+	return nil
+}
+
+func (s *memStore) close() error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package window
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// newTestWindow creates a window with a controllable clock.
+func newTestWindow(t *testing.T, cfg Config) (*Window, *time.Time) {
+	w, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { w.Close() })
+
+	now := time.Unix(1700000000, 0)
+	w.now = func() time.Time { return now }
+
+	return w, &now
+}
+
+func eventNames(evts []trace.Event) []string {
+	names := make([]string, 0, len(evts))
+	for _, evt := range evts {
+		names = append(names, evt.EventName)
+	}
+
+	return names
+}
+
+func testWindow(t *testing.T, cfg Config) {
+	w, now := newTestWindow(t, cfg)
+	all, err := ParseFilter(nil)
+	require.NoError(t, err)
+
+	w.Add(trace.Event{EventName: "a"})
+	*now = now.Add(4 * time.Minute)
+	w.Add(trace.Event{EventName: "b"})
+	*now = now.Add(4 * time.Minute)
+	w.Add(trace.Event{EventName: "c"})
+	w.Flush()
+
+	evts, err := w.Query(all, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, eventNames(evts))
+
+	// only the last 5 minutes
+	evts, err = w.Query(all, 5*time.Minute, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, eventNames(evts))
+
+	// limit, keeping the newest events
+	evts, err = w.Query(all, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, eventNames(evts))
+
+	// filter
+	f, err := ParseFilter([]string{"event=b,c"})
+	require.NoError(t, err)
+	evts, err = w.Query(f, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, eventNames(evts))
+
+	// "a" expires
+	*now = now.Add(4 * time.Minute)
+	w.Add(trace.Event{EventName: "d"})
+	w.Flush()
+	evts, err = w.Query(all, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "d"}, eventNames(evts))
+}
+
+func TestWindowMemory(t *testing.T) {
+	t.Parallel()
+
+	testWindow(t, Config{Duration: 10 * time.Minute, MaxEvents: 10})
+}
+
+func TestWindowDisk(t *testing.T) {
+	t.Parallel()
+
+	testWindow(t, Config{Duration: 10 * time.Minute, MaxEvents: 10, Dir: t.TempDir()})
+}
+
+func TestWindowMaxEvents(t *testing.T) {
+	t.Parallel()
+
+	w, _ := newTestWindow(t, Config{Duration: 10 * time.Minute, MaxEvents: 2})
+	all, err := ParseFilter(nil)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		w.Add(trace.Event{EventName: name})
+	}
+	w.Flush()
+
+	evts, err := w.Query(all, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, eventNames(evts))
+}
+
+func TestWindowMemoryGrowth(t *testing.T) {
+	t.Parallel()
+
+	const maxEvents = 3 * memStoreInitialSize
+
+	w, _ := newTestWindow(t, Config{Duration: 10 * time.Minute, MaxEvents: maxEvents})
+	all, err := ParseFilter(nil)
+	require.NoError(t, err)
+
+	s := w.store.(*memStore)
+	assert.Empty(t, s.entries) // nothing is allocated upfront
+
+	for i := 0; i < maxEvents+10; i++ {
+		w.Add(trace.Event{Timestamp: i})
+		if i%memStoreInitialSize == 0 {
+			w.Flush() // don't overflow the ingest buffer
+		}
+	}
+	w.Flush()
+	assert.Len(t, s.entries, maxEvents)
+
+	evts, err := w.Query(all, 0, 0)
+	require.NoError(t, err)
+	require.Len(t, evts, maxEvents)
+	for i, evt := range evts {
+		assert.Equal(t, i+10, evt.Timestamp)
+	}
+}
@@ -0,0 +1,132 @@
+// Package eventstore stores the events in a local SQLite database, for forensic SQL queries on
+// the node, without an external infrastructure.
+//
+// The events are stored in the events table, with indexed columns to select them (timestamp,
+// event, container and pid) and the whole event, as JSON, in the data column (to be queried with
+// the SQLite JSON functions, e.g. json_extract(data, '$.args')).
+package eventstore
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 database/sql driver
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS events (
+	id        INTEGER PRIMARY KEY,
+	timestamp INTEGER NOT NULL,
+	event     TEXT NOT NULL,
+	container TEXT NOT NULL,
+	pid       INTEGER NOT NULL,
+	process   TEXT NOT NULL,
+	data      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_timestamp ON events (timestamp);
+CREATE INDEX IF NOT EXISTS events_event ON events (event, timestamp);
+CREATE INDEX IF NOT EXISTS events_container ON events (container, timestamp);
+CREATE INDEX IF NOT EXISTS events_pid ON events (pid, timestamp);
+`
+
+const insertEvent = `INSERT INTO events (timestamp, event, container, pid, process, data) VALUES (?, ?, ?, ?, ?, ?)`
+
+// Store is a SQLite events database.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the events database at the given path.
+func Open(path string) (*Store, error) {
+	// the write-ahead log lets the database be queried while the events are stored
+	dsn := fmt.Sprintf("file:%s?_journal_mode=WAL&_synchronous=NORMAL&_busy_timeout=5000", path)
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	db.SetMaxOpenConns(1) // a single writer
+
+	if _, err := db.Exec(schema); err != nil {
+		_ = db.Close()
+		return nil, errfmt.Errorf("failed to create the events database %s: %v", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Insert stores the given events, in a single transaction.
+func (s *Store) Insert(events []trace.Event) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer func() { _ = tx.Rollback() }() // no-op once committed
+
+	stmt, err := tx.Prepare(insertEvent)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer stmt.Close()
+
+	for i := range events {
+		event := &events[i]
+		data, err := json.Marshal(event)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		_, err = stmt.Exec(
+			event.Timestamp,
+			event.EventName,
+			event.Container.ID,
+			event.HostProcessID,
+			event.ProcessName,
+			string(data),
+		)
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return errfmt.WrapError(tx.Commit())
+}
+
+// Prune removes the events older than the given time (if not zero), and the oldest events
+// beyond the given maximum number of events (if not zero). It returns the number of events
+// removed.
+func (s *Store) Prune(before time.Time, maxEvents int64) (int64, error) {
+	var removed int64
+
+	if !before.IsZero() {
+		res, err := s.db.Exec(`DELETE FROM events WHERE timestamp < ?`, before.UnixNano())
+		if err != nil {
+			return removed, errfmt.WrapError(err)
+		}
+		n, _ := res.RowsAffected()
+		removed += n
+	}
+
+	if maxEvents > 0 {
+		// the ids grow with the insertions: the oldest events have the lowest ids
+		res, err := s.db.Exec(
+			`DELETE FROM events WHERE id <= (SELECT MAX(id) FROM events) - ?`,
+			maxEvents,
+		)
+		if err != nil {
+			return removed, errfmt.WrapError(err)
+		}
+		n, _ := res.RowsAffected()
+		removed += n
+	}
+
+	return removed, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return errfmt.WrapError(s.db.Close())
+}
@@ -0,0 +1,89 @@
+package eventstore
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.db")
+	store, err := Open(path)
+	require.NoError(t, err)
+
+	now := time.Now()
+	events := []trace.Event{
+		{
+			Timestamp:     int(now.Add(-2 * time.Hour).UnixNano()),
+			EventName:     "sched_process_exec",
+			HostProcessID: 10,
+			ProcessName:   "bash",
+		},
+		{
+			Timestamp:     int(now.Add(-time.Minute).UnixNano()),
+			EventName:     "security_file_open",
+			HostProcessID: 20,
+			ProcessName:   "cat",
+			Container:     trace.Container{ID: "0123456789ab", Name: "nginx"},
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/shadow"},
+			},
+		},
+		{
+			Timestamp:     int(now.UnixNano()),
+			EventName:     "security_file_open",
+			HostProcessID: 30,
+			ProcessName:   "cat",
+		},
+	}
+	require.NoError(t, store.Insert(events))
+	require.NoError(t, store.Close())
+
+	// the database is queried apart, as by an operator
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var pid int
+	var process, pathname string
+	err = db.QueryRow(
+		`SELECT pid, process, json_extract(data, '$.args[0].value') FROM events WHERE container = ? AND event = ?`,
+		"0123456789ab", "security_file_open",
+	).Scan(&pid, &process, &pathname)
+	require.NoError(t, err)
+	assert.Equal(t, 20, pid)
+	assert.Equal(t, "cat", process)
+	assert.Equal(t, "/etc/shadow", pathname)
+
+	// retention
+	store, err = Open(path) // reopened, the events are kept
+	require.NoError(t, err)
+	defer store.Close()
+
+	removed, err := store.Prune(now.Add(-time.Hour), 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	removed, err = store.Prune(time.Time{}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	rows, err := db.Query(`SELECT pid FROM events`)
+	require.NoError(t, err)
+	defer rows.Close()
+	pids := []int{}
+	for rows.Next() {
+		require.NoError(t, rows.Scan(&pid))
+		pids = append(pids, pid)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []int{30}, pids) // the newest event
+}
@@ -33,6 +33,23 @@ func (af *ArgFilter) GetEventFilters(eventID events.ID) map[string]Filter[*Strin
 }
 
 func (af *ArgFilter) Filter(eventID events.ID, args []trace.Argument) bool {
+	return af.filter(eventID, func(name string) (trace.Argument, bool) {
+		for _, arg := range args {
+			if arg.Name == name {
+				return arg, true
+			}
+		}
+		return trace.Argument{}, false
+	})
+}
+
+// FilterEvent filters the arguments of an event, only decoding the filtered ones if the
+// event arguments are decoded lazily.
+func (af *ArgFilter) FilterEvent(event *trace.Event) bool {
+	return af.filter(events.ID(event.EventID), event.GetArg)
+}
+
+func (af *ArgFilter) filter(eventID events.ID, getArg func(name string) (trace.Argument, bool)) bool {
 	if !af.Enabled() {
 		return true
 	}
@@ -47,22 +64,17 @@ func (af *ArgFilter) Filter(eventID events.ID, args []trace.Argument) bool {
 	}
 
 	for argName, f := range af.filters[eventID] {
-		found := false
-		var argVal interface{}
-
-		for _, arg := range args {
-			if arg.Name == argName {
-				found = true
-				argVal = arg.Value
-				break
-			}
-		}
+		arg, found := getArg(argName)
 		if !found {
+			// nothing to exclude from an event missing the argument
+			if ef, ok := f.(interface{ ExclusionsOnly() bool }); ok && ef.ExclusionsOnly() {
+				continue
+			}
 			return false
 		}
 
 		// TODO: use type assertion instead of string conversion
-		argVal = fmt.Sprint(argVal)
+		argVal := fmt.Sprint(arg.Value)
 
 		res := f.Filter(argVal)
 		if !res {
@@ -115,6 +115,28 @@ func TestArgsFilter_Filter(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:                   "Excluded argument value missing from the event",
+			parseFilterName:        "open.args.pathname",
+			parseOperatorAndValues: "!=/etc/passwd",
+			parseEventNamesToID:    events.Core.NamesToIDs(),
+			eventID:                events.Open,
+			args: []trace.Argument{
+				newArgument("flags", "int", 0),
+			},
+			expected: true,
+		},
+		{
+			name:                   "Argument value missing from the event",
+			parseFilterName:        "open.args.pathname",
+			parseOperatorAndValues: "=/etc/passwd",
+			parseEventNamesToID:    events.Core.NamesToIDs(),
+			eventID:                events.Open,
+			args: []trace.Argument{
+				newArgument("flags", "int", 0),
+			},
+			expected: false,
+		},
 
 		// Test cases for syscall argument value of sys_enter and sys_exit events
 		{
@@ -221,6 +243,9 @@ func TestArgsFilter_Filter(t *testing.T) {
 
 			result := filter.Filter(tc.eventID, tc.args)
 			require.Equal(t, tc.expected, result)
+
+			event := &trace.Event{EventID: int(tc.eventID), Args: tc.args}
+			require.Equal(t, tc.expected, filter.FilterEvent(event))
 		})
 	}
 }
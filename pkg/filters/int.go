@@ -90,6 +90,11 @@ func (f *IntFilter[T]) filter(val T) bool {
 	_, inEqual := f.equal[compVal]
 	_, inNotEqual := f.notEqual[compVal]
 
+	// only exclusions: all the values not excluded match
+	if f.enabled && f.ExclusionsOnly() {
+		return !inNotEqual
+	}
+
 	result := !f.enabled || inEqual || compVal > f.min || compVal < f.max
 	if !result && inNotEqual {
 		return false
@@ -98,6 +103,12 @@ func (f *IntFilter[T]) filter(val T) bool {
 	return result
 }
 
+// ExclusionsOnly returns whether the filter only has non equalities, matching all the values
+// not excluded.
+func (f *IntFilter[T]) ExclusionsOnly() bool {
+	return len(f.notEqual) > 0 && len(f.equal) == 0 && f.min == minNotSetInt && f.max == maxNotSetInt
+}
+
 func (f *IntFilter[T]) validate(val int64) bool {
 	if f.is32Bit {
 		return val <= math.MaxInt32 && val >= math.MinInt32
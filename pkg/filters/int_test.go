@@ -44,6 +44,15 @@ func TestIntFilterParse(t *testing.T) {
 			vals:     []int64{50, -2, 8, -4, 51},
 			expected: []bool{false, false, true, true, true},
 		},
+		{
+			name: "only non equalities",
+			expressions: []string{
+				"!=0",
+				"!=-2",
+			},
+			vals:     []int64{0, -2, 8, -4},
+			expected: []bool{false, false, true, true},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,101 @@
+package filters
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/utils"
+)
+
+// LabelScopePrefix prefixes the label scope fields: container.label[<key>].
+const LabelScopePrefix = "container.label["
+
+// LabelFilter filters events by the labels of their container, as given by the container
+// runtime enrichment ("container.label[<key>]" scope fields), e.g. to exclude the trusted
+// infrastructure containers from a policy. Each label is filtered by a string filter: the
+// label of a container without it (or not enriched yet), or of a host process, is empty.
+type LabelFilter struct {
+	labels  map[string]*StringFilter
+	enabled bool
+}
+
+// Compile-time check to ensure that LabelFilter implements the Cloner interface
+var _ utils.Cloner[*LabelFilter] = &LabelFilter{}
+
+func NewLabelFilter() *LabelFilter {
+	return &LabelFilter{
+		labels:  map[string]*StringFilter{},
+		enabled: false,
+	}
+}
+
+func (f *LabelFilter) Enable() {
+	f.enabled = true
+}
+
+func (f *LabelFilter) Disable() {
+	f.enabled = false
+}
+
+func (f *LabelFilter) Enabled() bool {
+	return f.enabled
+}
+
+// Filter returns true if the given container labels match all the label filters.
+func (f *LabelFilter) Filter(labels map[string]string) bool {
+	if !f.enabled {
+		return true
+	}
+
+	for key, filter := range f.labels {
+		if !filter.Filter(labels[key]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Parse parses a label scope field expression:
+//
+//	container.label[security.scan]=skip  (containers with the label, with one of the values)
+//	container.label[security.scan]!=skip (processes without the label, or with another value)
+//
+// The values might be prefixed or suffixed by a wildcard (e.g. team=infra-*).
+func (f *LabelFilter) Parse(field string, operatorAndValues string) error {
+	key, found := strings.CutPrefix(field, LabelScopePrefix)
+	if !found {
+		return InvalidExpression(field + operatorAndValues)
+	}
+	key, found = strings.CutSuffix(key, "]")
+	if !found || key == "" || strings.ContainsAny(key, "[]") {
+		return InvalidExpression(field + operatorAndValues)
+	}
+
+	filter, ok := f.labels[key]
+	if !ok {
+		filter = NewStringFilter(nil)
+	}
+	if err := filter.Parse(operatorAndValues); err != nil {
+		return err
+	}
+	f.labels[key] = filter
+
+	f.Enable()
+
+	return nil
+}
+
+func (f *LabelFilter) Clone() *LabelFilter {
+	if f == nil {
+		return nil
+	}
+
+	n := NewLabelFilter()
+
+	for key, filter := range f.labels {
+		n.labels[key] = filter.Clone()
+	}
+	n.enabled = f.enabled
+
+	return n
+}
@@ -0,0 +1,101 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelFilter(t *testing.T) {
+	t.Parallel()
+
+	type expression struct {
+		field             string
+		operatorAndValues string
+	}
+
+	infra := map[string]string{"security.scan": "skip", "team": "infra-net"}
+	payments := map[string]string{"team": "payments"}
+
+	testCases := []struct {
+		name          string
+		expressions   []expression
+		expectedError bool
+		matching      []map[string]string
+		notMatching   []map[string]string
+	}{
+		{
+			name:        "exclude label",
+			expressions: []expression{{"container.label[security.scan]", "!=skip"}},
+			matching:    []map[string]string{payments, nil},
+			notMatching: []map[string]string{infra},
+		},
+		{
+			name:        "select label values",
+			expressions: []expression{{"container.label[team]", "=payments,checkout"}},
+			matching:    []map[string]string{payments},
+			notMatching: []map[string]string{infra, nil},
+		},
+		{
+			name:        "wildcard",
+			expressions: []expression{{"container.label[team]", "=infra*"}},
+			matching:    []map[string]string{infra},
+			notMatching: []map[string]string{payments},
+		},
+		{
+			name: "labels ANDed",
+			expressions: []expression{
+				{"container.label[team]", "=infra*"},
+				{"container.label[security.scan]", "!=skip"},
+			},
+			notMatching: []map[string]string{infra, payments},
+		},
+		{
+			name:          "no key",
+			expressions:   []expression{{"container.label[]", "=skip"}},
+			expectedError: true,
+		},
+		{
+			name:          "unterminated key",
+			expressions:   []expression{{"container.label[team", "=infra"}},
+			expectedError: true,
+		},
+		{
+			name:          "no value",
+			expressions:   []expression{{"container.label[team]", "="}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewLabelFilter()
+			var err error
+			for _, e := range tc.expressions {
+				if err = filter.Parse(e.field, e.operatorAndValues); err != nil {
+					break
+				}
+			}
+			if tc.expectedError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			clone := filter.Clone()
+			for _, labels := range tc.matching {
+				assert.True(t, filter.Filter(labels), labels)
+				assert.True(t, clone.Filter(labels), labels)
+			}
+			for _, labels := range tc.notMatching {
+				assert.False(t, filter.Filter(labels), labels)
+				assert.False(t, clone.Filter(labels), labels)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package filters
 
 import (
+	"strconv"
 	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
@@ -75,8 +79,13 @@ func (filter *RetFilter) Parse(filterName string, operatorAndValues string, even
 
 	intFilter := filter.filters[id]
 
+	operatorAndValues, err := resolveRetValues(id, operatorAndValues)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	// Treat operatorAndValues as an int filter to avoid code duplication
-	err := intFilter.Parse(operatorAndValues)
+	err = intFilter.Parse(operatorAndValues)
 	if err != nil {
 		return errfmt.WrapError(err)
 	}
@@ -88,6 +97,72 @@ func (filter *RetFilter) Parse(filterName string, operatorAndValues string, even
 	return nil
 }
 
+// errnoValues maps the errno names (e.g. EACCES) to their values.
+var errnoValues = func() map[string]int64 {
+	values := make(map[string]int64)
+	for errno := syscall.Errno(1); errno < 4096; errno++ { // MAX_ERRNO
+		if name := unix.ErrnoName(errno); name != "" {
+			values[name] = int64(errno)
+		}
+	}
+	return values
+}()
+
+// noErrnoSyscalls are the syscalls not returning -errno on failure: they don't return, or they
+// return the restored registers.
+var noErrnoSyscalls = map[events.ID]struct{}{
+	events.Exit:        {},
+	events.ExitGroup:   {},
+	events.RtSigreturn: {},
+	events.Sigreturn:   {},
+}
+
+// resolveRetValues resolves the symbolic values of a return value filter of an event into
+// numeric values: errno names (-EACCES is the negated EACCES), and the success and failure
+// shorthands of syscalls, which fail returning -errno (e.g. "=success" is ">=0", and
+// "!=failure" is ">=0" as well).
+func resolveRetValues(id events.ID, operatorAndValues string) (string, error) {
+	operatorLen := strings.IndexFunc(operatorAndValues, func(r rune) bool {
+		return !strings.ContainsRune("=!<>", r)
+	})
+	if operatorLen <= 0 {
+		return operatorAndValues, nil // left for the int filter to reject
+	}
+	operator := operatorAndValues[:operatorLen]
+	values := strings.Split(operatorAndValues[operatorLen:], ",")
+
+	for i, val := range values {
+		switch val {
+		case "success", "failure":
+			if len(values) != 1 || (operator != "=" && operator != "!=") {
+				return "", InvalidExpression(operatorAndValues)
+			}
+			if !events.Core.IsDefined(id) || !events.Core.GetDefinitionByID(id).IsSyscall() {
+				return "", InvalidValue(val + " (only syscalls have success and failure return values)")
+			}
+			if _, ok := noErrnoSyscalls[id]; ok {
+				return "", InvalidValue(val + " (the syscall doesn't fail returning -errno)")
+			}
+			if (val == "success") == (operator == "=") {
+				return ">=0", nil
+			}
+			return "<0", nil
+		}
+
+		name, negative := strings.CutPrefix(val, "-")
+		errno, ok := errnoValues[name]
+		if !ok {
+			continue // numeric value
+		}
+		if negative {
+			errno = -errno
+		}
+		values[i] = strconv.FormatInt(errno, 10)
+	}
+
+	return operator + strings.Join(values, ","), nil
+}
+
 func (filter *RetFilter) Clone() *RetFilter {
 	if filter == nil {
 		return nil
@@ -0,0 +1,120 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/events"
+)
+
+func TestRetFilterSymbolicValues(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		filterName    string
+		expression    string
+		expectedError bool
+		matching      []int64
+		notMatching   []int64
+	}{
+		{
+			name:        "negated errno name",
+			filterName:  "openat.retval",
+			expression:  "=-EACCES,-EPERM",
+			matching:    []int64{-13, -1},
+			notMatching: []int64{0, 3, 13, -2},
+		},
+		{
+			name:        "excluded errno name",
+			filterName:  "openat.retval",
+			expression:  "!=-ENOENT",
+			matching:    []int64{-13, 0, 3},
+			notMatching: []int64{-2},
+		},
+		{
+			name:        "errno name and number",
+			filterName:  "openat.retval",
+			expression:  "=-ENOENT,5",
+			matching:    []int64{-2, 5},
+			notMatching: []int64{0, 2},
+		},
+		{
+			name:        "success",
+			filterName:  "openat.retval",
+			expression:  "=success",
+			matching:    []int64{0, 3},
+			notMatching: []int64{-1, -13},
+		},
+		{
+			name:        "failure",
+			filterName:  "openat.retval",
+			expression:  "=failure",
+			matching:    []int64{-1, -13},
+			notMatching: []int64{0, 3},
+		},
+		{
+			name:        "not failure",
+			filterName:  "close.retval",
+			expression:  "!=failure",
+			matching:    []int64{0},
+			notMatching: []int64{-9},
+		},
+		{
+			name:          "success of a non syscall event",
+			filterName:    "security_file_open.retval",
+			expression:    "=success",
+			expectedError: true,
+		},
+		{
+			name:          "failure of a syscall not returning errno",
+			filterName:    "rt_sigreturn.retval",
+			expression:    "=failure",
+			expectedError: true,
+		},
+		{
+			name:          "success with a comparison",
+			filterName:    "openat.retval",
+			expression:    ">success",
+			expectedError: true,
+		},
+		{
+			name:          "success with other values",
+			filterName:    "openat.retval",
+			expression:    "=success,-EACCES",
+			expectedError: true,
+		},
+		{
+			name:          "unknown errno name",
+			filterName:    "openat.retval",
+			expression:    "=-EFOO",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewRetFilter()
+			err := filter.Parse(tc.filterName, tc.expression, events.Core.NamesToIDs())
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			id := events.Core.NamesToIDs()[tc.filterName[:len(tc.filterName)-len(".retval")]]
+			for _, val := range tc.matching {
+				assert.True(t, filter.Filter(id, val), "value %d", val)
+			}
+			for _, val := range tc.notMatching {
+				assert.False(t, filter.Filter(id, val), "value %d", val)
+			}
+		})
+	}
+}
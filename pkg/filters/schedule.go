@@ -0,0 +1,240 @@
+package filters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/utils"
+)
+
+const minutesPerDay = 24 * 60
+
+// timeWindow is a window of the day, in minutes since midnight: [start, end). A window
+// ending before its start wraps over midnight.
+type timeWindow struct {
+	start int
+	end   int
+}
+
+func (w timeWindow) contains(minute int) bool {
+	if w.start < w.end {
+		return minute >= w.start && minute < w.end
+	}
+	return minute >= w.start || minute < w.end
+}
+
+// ScheduleFilter filters events by the (local) time they happened at: within windows of the
+// day ("time" scope field) and within days of the week ("weekday" scope field), so that a
+// policy is only active in a schedule.
+type ScheduleFilter struct {
+	windows          []timeWindow
+	excludedWindows  []timeWindow
+	weekdays         uint8 // bitmap of time.Weekday
+	excludedWeekdays uint8 // bitmap of time.Weekday
+	enabled          bool
+}
+
+// Compile-time check to ensure that ScheduleFilter implements the Cloner interface
+var _ utils.Cloner[*ScheduleFilter] = &ScheduleFilter{}
+
+func NewScheduleFilter() *ScheduleFilter {
+	return &ScheduleFilter{
+		windows:         []timeWindow{},
+		excludedWindows: []timeWindow{},
+		enabled:         false,
+	}
+}
+
+func (f *ScheduleFilter) Enable() {
+	f.enabled = true
+}
+
+func (f *ScheduleFilter) Disable() {
+	f.enabled = false
+}
+
+func (f *ScheduleFilter) Enabled() bool {
+	return f.enabled
+}
+
+// Filter returns true if the given time is within the schedule.
+func (f *ScheduleFilter) Filter(t time.Time) bool {
+	if !f.enabled {
+		return true
+	}
+
+	t = t.Local()
+	minute := t.Hour()*60 + t.Minute()
+	day := uint8(1) << uint(t.Weekday())
+
+	if len(f.windows) > 0 && !windowsContain(f.windows, minute) {
+		return false
+	}
+	if windowsContain(f.excludedWindows, minute) {
+		return false
+	}
+	if f.weekdays != 0 && f.weekdays&day == 0 {
+		return false
+	}
+
+	return f.excludedWeekdays&day == 0
+}
+
+func windowsContain(windows []timeWindow, minute int) bool {
+	for _, w := range windows {
+		if w.contains(minute) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse parses a schedule scope field expression:
+//
+//	time=09:00-18:00,20:00-22:00 (windows of the day, wrapping over midnight if ending before they start)
+//	weekday=mon-fri,sun          (days or ranges of days of the week)
+//
+// The '=' operator selects the times within the values, and the '!=' operator the times outside
+// of them.
+func (f *ScheduleFilter) Parse(field string, operatorAndValues string) error {
+	var operator, values string
+	switch {
+	case strings.HasPrefix(operatorAndValues, "!="):
+		operator, values = "!=", operatorAndValues[2:]
+	case strings.HasPrefix(operatorAndValues, "="):
+		operator, values = "=", operatorAndValues[1:]
+	default:
+		return InvalidExpression(field + operatorAndValues)
+	}
+	if values == "" {
+		return InvalidExpression(field + operatorAndValues)
+	}
+
+	for _, val := range strings.Split(values, ",") {
+		switch field {
+		case "time":
+			w, err := parseTimeWindow(val)
+			if err != nil {
+				return err
+			}
+			if operator == "=" {
+				f.windows = append(f.windows, w)
+			} else {
+				f.excludedWindows = append(f.excludedWindows, w)
+			}
+		case "weekday":
+			days, err := parseWeekdays(val)
+			if err != nil {
+				return err
+			}
+			if operator == "=" {
+				f.weekdays |= days
+			} else {
+				f.excludedWeekdays |= days
+			}
+		default:
+			return InvalidExpression(field + operatorAndValues)
+		}
+	}
+
+	f.Enable()
+
+	return nil
+}
+
+// parseTimeWindow parses a window of the day: HH:MM-HH:MM.
+func parseTimeWindow(val string) (timeWindow, error) {
+	start, end, ok := strings.Cut(val, "-")
+	if !ok {
+		return timeWindow{}, InvalidValue(val)
+	}
+
+	startMinute, err := parseTimeOfDay(start)
+	if err != nil {
+		return timeWindow{}, InvalidValue(val)
+	}
+	endMinute, err := parseTimeOfDay(end)
+	if err != nil {
+		return timeWindow{}, InvalidValue(val)
+	}
+	if startMinute%minutesPerDay == endMinute%minutesPerDay {
+		return timeWindow{}, InvalidValue(fmt.Sprintf("%s (empty time window)", val))
+	}
+
+	return timeWindow{start: startMinute % minutesPerDay, end: endMinute % minutesPerDay}, nil
+}
+
+// parseTimeOfDay parses a time of the day (HH:MM, up to 24:00) into minutes since midnight.
+func parseTimeOfDay(val string) (int, error) {
+	hours, minutes, ok := strings.Cut(val, ":")
+	if !ok || len(hours) != 2 || len(minutes) != 2 {
+		return 0, InvalidValue(val)
+	}
+
+	h, err := strconv.Atoi(hours)
+	if err != nil || h < 0 || h > 24 {
+		return 0, InvalidValue(val)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 || m > 59 || (h == 24 && m != 0) {
+		return 0, InvalidValue(val)
+	}
+
+	return h*60 + m, nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseWeekdays parses a day (e.g. mon) or a range of days (e.g. mon-fri, or fri-mon wrapping
+// over the week end) into a bitmap of time.Weekday.
+func parseWeekdays(val string) (uint8, error) {
+	first, last, isRange := strings.Cut(strings.ToLower(val), "-")
+	if !isRange {
+		last = first
+	}
+
+	firstDay, ok := weekdayNames[first]
+	if !ok {
+		return 0, InvalidValue(val)
+	}
+	lastDay, ok := weekdayNames[last]
+	if !ok {
+		return 0, InvalidValue(val)
+	}
+
+	var days uint8
+	for day := firstDay; ; day = (day + 1) % 7 {
+		days |= 1 << uint(day)
+		if day == lastDay {
+			break
+		}
+	}
+
+	return days, nil
+}
+
+func (f *ScheduleFilter) Clone() *ScheduleFilter {
+	if f == nil {
+		return nil
+	}
+
+	n := NewScheduleFilter()
+
+	n.windows = append(n.windows, f.windows...)
+	n.excludedWindows = append(n.excludedWindows, f.excludedWindows...)
+	n.weekdays = f.weekdays
+	n.excludedWeekdays = f.excludedWeekdays
+	n.enabled = f.enabled
+
+	return n
+}
@@ -0,0 +1,121 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleFilter(t *testing.T) {
+	t.Parallel()
+
+	// 2024-01-01 is a monday
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.Local)
+	}
+
+	type expression struct {
+		field             string
+		operatorAndValues string
+	}
+
+	testCases := []struct {
+		name          string
+		expressions   []expression
+		expectedError bool
+		matching      []time.Time
+		notMatching   []time.Time
+	}{
+		{
+			name:        "business hours",
+			expressions: []expression{{"time", "=09:00-18:00"}, {"weekday", "=mon-fri"}},
+			matching:    []time.Time{at(1, 9, 0), at(5, 17, 59)},
+			notMatching: []time.Time{at(1, 8, 59), at(1, 18, 0), at(6, 12, 0), at(7, 12, 0)},
+		},
+		{
+			name:        "outside business hours",
+			expressions: []expression{{"time", "!=09:00-18:00"}},
+			matching:    []time.Time{at(1, 8, 59), at(1, 18, 0), at(6, 23, 0)},
+			notMatching: []time.Time{at(1, 9, 0), at(6, 12, 0)},
+		},
+		{
+			name:        "windows wrapping over midnight",
+			expressions: []expression{{"time", "=22:00-02:00,12:00-13:00"}},
+			matching:    []time.Time{at(1, 23, 0), at(2, 1, 59), at(1, 12, 30)},
+			notMatching: []time.Time{at(1, 2, 0), at(1, 21, 59), at(1, 13, 0)},
+		},
+		{
+			name:        "window until midnight",
+			expressions: []expression{{"time", "=18:00-24:00"}},
+			matching:    []time.Time{at(1, 18, 0), at(1, 23, 59)},
+			notMatching: []time.Time{at(1, 0, 0), at(1, 17, 59)},
+		},
+		{
+			name:        "weekend",
+			expressions: []expression{{"weekday", "=sat,sun"}},
+			matching:    []time.Time{at(6, 0, 0), at(7, 23, 59)},
+			notMatching: []time.Time{at(1, 12, 0), at(5, 23, 59)},
+		},
+		{
+			name:        "excluded days range wrapping over the week end",
+			expressions: []expression{{"weekday", "!=fri-mon"}},
+			matching:    []time.Time{at(2, 12, 0), at(4, 12, 0)},
+			notMatching: []time.Time{at(5, 12, 0), at(6, 12, 0), at(7, 12, 0), at(8, 12, 0)},
+		},
+		{
+			name:          "empty window",
+			expressions:   []expression{{"time", "=09:00-09:00"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid time of day",
+			expressions:   []expression{{"time", "=9:00-18:00"}},
+			expectedError: true,
+		},
+		{
+			name:          "invalid day",
+			expressions:   []expression{{"weekday", "=monday"}},
+			expectedError: true,
+		},
+		{
+			name:          "unsupported operator",
+			expressions:   []expression{{"time", ">09:00"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewScheduleFilter()
+			var err error
+			for _, e := range tc.expressions {
+				if err = filter.Parse(e.field, e.operatorAndValues); err != nil {
+					break
+				}
+			}
+			if tc.expectedError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for _, tm := range tc.matching {
+				assert.True(t, filter.Filter(tm), "time %v", tm)
+			}
+			for _, tm := range tc.notMatching {
+				assert.False(t, filter.Filter(tm), "time %v", tm)
+			}
+
+			clone := filter.Clone()
+			for _, tm := range tc.matching {
+				assert.True(t, clone.Filter(tm), "time %v", tm)
+			}
+		})
+	}
+}
@@ -99,7 +99,8 @@ func (filter *ScopeFilter) Parse(filterName string, operatorAndValues string) er
 			podNSFilter:                NewStringFilter(nil),
 			podUIDFilter:               NewStringFilter(nil),
 			podSandboxFilter:           NewBoolFilter(),
-			syscallFilter:              NewStringFilter(nil),
+			syscallFilter:              NewStringFilter(syscallValueHandler),
+			syscallABIFilter:           NewStringFilter(syscallABIValueHandler),
 		}
 		filter.filters[eventDefID] = eventFilter
 	}
@@ -139,6 +140,7 @@ type eventCtxFilter struct {
 	podUIDFilter               *StringFilter
 	podSandboxFilter           *BoolFilter
 	syscallFilter              *StringFilter
+	syscallABIFilter           *StringFilter
 }
 
 // Compile-time check to ensure that eventCtxFilter implements the Cloner interface
@@ -171,6 +173,7 @@ func (f *eventCtxFilter) Filter(evt trace.Event) bool {
 		f.hostPidFilter.Filter(int64(evt.HostProcessID)) &&
 		f.hostPpidFilter.Filter(int64(evt.HostParentProcessID)) &&
 		f.syscallFilter.Filter(evt.Syscall) &&
+		f.syscallABIFilter.Filter(evt.SyscallABI) &&
 		f.hostTidFilter.Filter(int64(evt.HostThreadID)) &&
 		f.mntNSFilter.Filter(int64(evt.MountNS)) &&
 		f.pidFilter.Filter(int64(evt.ProcessID)) &&
@@ -267,6 +270,9 @@ func (f *eventCtxFilter) Parse(field string, operatorAndValues string) error {
 	case "syscall":
 		filter := f.syscallFilter
 		return filter.Parse(operatorAndValues)
+	case "syscallAbi":
+		filter := f.syscallABIFilter
+		return filter.Parse(operatorAndValues)
 	}
 	return InvalidScopeField(field)
 }
@@ -283,6 +289,29 @@ func addContainer[T any](f *eventCtxFilter, filter Filter[T], operatorAndValues
 	return nil
 }
 
+// syscallValueHandler validates a syscall name. The syscalls missing on the architecture are
+// defined as well, so a policy is valid across architectures (not matching those syscalls).
+func syscallValueHandler(val string) (string, error) {
+	if strings.Contains(val, "*") {
+		return val, nil
+	}
+	id, ok := events.Core.GetDefinitionIDByName(val)
+	if !ok || !events.Core.GetDefinitionByID(id).IsSyscall() {
+		return val, errfmt.Errorf("invalid syscall name: %s", val)
+	}
+	return val, nil
+}
+
+// syscallABIValueHandler validates a syscall ABI, of any of the supported architectures.
+func syscallABIValueHandler(val string) (string, error) {
+	for _, abi := range events.SyscallABIs {
+		if val == abi {
+			return val, nil
+		}
+	}
+	return val, errfmt.Errorf("invalid syscall ABI: %s (%s)", val, strings.Join(events.SyscallABIs, ", "))
+}
+
 func (f *eventCtxFilter) Clone() *eventCtxFilter {
 	if f == nil {
 		return nil
@@ -315,6 +344,7 @@ func (f *eventCtxFilter) Clone() *eventCtxFilter {
 	n.podUIDFilter = f.podUIDFilter.Clone()
 	n.podSandboxFilter = f.podSandboxFilter.Clone()
 	n.syscallFilter = f.syscallFilter.Clone()
+	n.syscallABIFilter = f.syscallABIFilter.Clone()
 
 	return n
 }
@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aquasecurity/tracee/pkg/events"
 	"github.com/aquasecurity/tracee/pkg/filters/sets"
+	"github.com/aquasecurity/tracee/types/trace"
 )
 
 func TestScopeFilterClone(t *testing.T) {
@@ -40,3 +43,83 @@ func TestScopeFilterClone(t *testing.T) {
 		t.Errorf("Changes to copied filter affected the original")
 	}
 }
+
+func TestScopeFilterSyscallABI(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		field         string
+		value         string
+		event         trace.Event
+		expected      bool
+		expectedError string
+	}{
+		{
+			name:     "syscall",
+			field:    "syscall",
+			value:    "=open",
+			event:    trace.Event{Syscall: "open", SyscallABI: events.NativeSyscallABI},
+			expected: true,
+		},
+		{
+			name:     "syscall of a compat syscall",
+			field:    "syscall",
+			value:    "=open",
+			event:    trace.Event{Syscall: "open", SyscallABI: events.CompatSyscallABI},
+			expected: true,
+		},
+		{
+			name:     "syscall prefix",
+			field:    "syscall",
+			value:    "=open*",
+			event:    trace.Event{Syscall: "openat", SyscallABI: events.NativeSyscallABI},
+			expected: true,
+		},
+		{
+			name:          "invalid syscall",
+			field:         "syscall",
+			value:         "=security_file_open",
+			expectedError: "invalid syscall name: security_file_open",
+		},
+		{
+			name:     "native syscall abi",
+			field:    "syscallAbi",
+			value:    "!=" + events.CompatSyscallABI,
+			event:    trace.Event{Syscall: "open", SyscallABI: events.NativeSyscallABI},
+			expected: true,
+		},
+		{
+			name:     "compat syscall abi",
+			field:    "syscallAbi",
+			value:    "=i386,arm",
+			event:    trace.Event{Syscall: "open", SyscallABI: events.NativeSyscallABI},
+			expected: false,
+		},
+		{
+			name:          "invalid syscall abi",
+			field:         "syscallAbi",
+			value:         "=x86",
+			expectedError: "invalid syscall ABI: x86",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewScopeFilter()
+			err := filter.Parse("security_file_open.scope."+tc.field, tc.value)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			tc.event.EventID = int(events.SecurityFileOpen)
+			assert.Equal(t, tc.expected, filter.Filter(tc.event))
+		})
+	}
+}
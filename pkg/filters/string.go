@@ -230,6 +230,19 @@ func (f *StringFilter) NotEqual() []string {
 	return res
 }
 
+// ExclusionsOnly returns whether the filter only has non equalities (including not prefixed,
+// suffixed and contained values), matching all the values not excluded.
+func (f *StringFilter) ExclusionsOnly() bool {
+	return len(f.Equal()) == 0 && len(f.contains) == 0 && (len(f.NotEqual()) > 0 || len(f.notContains) > 0)
+}
+
+// Wildcards returns whether the filter has prefixed, suffixed or contained values, which aren't
+// part of its equalities.
+func (f *StringFilter) Wildcards() bool {
+	return f.prefixes.Length() > 0 || f.suffixes.Length() > 0 || len(f.contains) > 0 ||
+		f.notPrefixes.Length() > 0 || f.notSuffixes.Length() > 0 || len(f.notContains) > 0
+}
+
 func (f *StringFilter) FilterOut() bool {
 	if len(f.Equal()) > 0 && len(f.NotEqual()) == 0 {
 		return false
@@ -199,6 +199,57 @@ func TestStringFilterFilterOut(t *testing.T) {
 	assert.True(t, sf4.FilterOut())
 }
 
+func TestStringFilterExclusions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name              string
+		expressions       []string
+		expectedExclusive bool
+		expectedWildcards bool
+	}{
+		{
+			name:              "equalities",
+			expressions:       []string{"=bash", "!=sh"},
+			expectedExclusive: false,
+			expectedWildcards: false,
+		},
+		{
+			name:              "non equalities",
+			expressions:       []string{"!=bash,sh"},
+			expectedExclusive: true,
+			expectedWildcards: false,
+		},
+		{
+			name:              "not prefixed",
+			expressions:       []string{"!=systemd*"},
+			expectedExclusive: true,
+			expectedWildcards: true,
+		},
+		{
+			name:              "contained",
+			expressions:       []string{"=*http*", "!=curl"},
+			expectedExclusive: false,
+			expectedWildcards: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter := NewStringFilter(nil)
+			for _, expr := range tc.expressions {
+				require.NoError(t, filter.Parse(expr))
+			}
+			assert.Equal(t, tc.expectedExclusive, filter.ExclusionsOnly())
+			assert.Equal(t, tc.expectedWildcards, filter.Wildcards())
+		})
+	}
+}
+
 func TestStringFilterClone(t *testing.T) {
 	t.Parallel()
 
@@ -104,6 +104,11 @@ func (f *UIntFilter[T]) filter(val T) bool {
 	_, inEqual := f.equal[compVal]
 	_, inNotEqual := f.notEqual[compVal]
 
+	// only exclusions: all the values not excluded match
+	if f.enabled && f.ExclusionsOnly() {
+		return !inNotEqual
+	}
+
 	result := !f.enabled || inEqual || compVal > f.min || compVal < f.max
 	if !result && inNotEqual {
 		return false
@@ -111,6 +116,12 @@ func (f *UIntFilter[T]) filter(val T) bool {
 	return result
 }
 
+// ExclusionsOnly returns whether the filter only has non equalities, matching all the values
+// not excluded.
+func (f *UIntFilter[T]) ExclusionsOnly() bool {
+	return len(f.notEqual) > 0 && len(f.equal) == 0 && f.min == MinNotSetUInt && f.max == MaxNotSetUInt
+}
+
 func (f *UIntFilter[T]) validate(val uint64) bool {
 	const maxUIntVal32Bit = math.MaxUint32
 	if f.is32Bit {
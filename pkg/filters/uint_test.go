@@ -52,6 +52,14 @@ func TestUIntFilterParse(t *testing.T) {
 			vals:     []uint64{6, 5, 4, 7},
 			expected: []bool{true, true, true, false},
 		},
+		{
+			name: "only non equalities",
+			expressions: []string{
+				"!=50,8",
+			},
+			vals:     []uint64{50, 149, 7, 8},
+			expected: []bool{false, true, true, false},
+		},
 	}
 
 	for _, tc := range testCases {
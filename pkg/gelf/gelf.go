@@ -0,0 +1,118 @@
+// Package gelf sends the events to Graylog, as GELF (Graylog Extended Log Format) 1.1
+// messages: over UDP, compressed and chunked, or over TCP (with TLS or not), delimited by null
+// bytes.
+package gelf
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const Version = "1.1"
+
+// syslog levels of the messages
+const (
+	levelCritical      = 2
+	levelError         = 3
+	levelWarning       = 4
+	levelNotice        = 5
+	levelInformational = 6
+)
+
+// levels maps the severity of the findings to the syslog levels.
+var levels = map[string]int{
+	"critical": levelCritical,
+	"high":     levelError,
+	"medium":   levelWarning,
+	"low":      levelNotice,
+	"info":     levelInformational,
+}
+
+// invalidFieldChars are the characters not allowed in the names of the additional fields.
+var invalidFieldChars = regexp.MustCompile(`[^\w\.\-]`)
+
+// Message returns the GELF message of an event: its context as additional fields (prefixed
+// with an underscore), and its arguments as _arg_<name> fields.
+func Message(event *trace.Event) map[string]interface{} {
+	shortMessage := fmt.Sprintf("%s: %s (pid %d)", event.EventName, event.ProcessName, event.HostProcessID)
+	level := levelInformational
+	if event.Metadata != nil {
+		if name, ok := event.Metadata.Properties["signatureName"].(string); ok && name != "" {
+			shortMessage = fmt.Sprintf("%s: %s (pid %d)", name, event.ProcessName, event.HostProcessID)
+		}
+		if l, ok := levels[event.Metadata.Severity]; ok {
+			level = l
+		}
+	}
+
+	host := event.HostName
+	if host == "" {
+		host = "tracee"
+	}
+
+	message := map[string]interface{}{
+		"version":       Version,
+		"host":          host,
+		"short_message": shortMessage,
+		"timestamp":     float64(event.Timestamp) / 1e9, // seconds, with decimals
+		"level":         level,
+		"_event_name":   event.EventName,
+		"_event_id":     event.EventID,
+		"_pid":          event.HostProcessID,
+		"_tid":          event.HostThreadID,
+		"_ppid":         event.HostParentProcessID,
+		"_uid":          event.UserID,
+		"_process_name": event.ProcessName,
+		"_return_value": event.ReturnValue,
+	}
+	if event.Metadata != nil && event.Metadata.Description != "" {
+		message["full_message"] = event.Metadata.Description
+	}
+	if event.Syscall != "" {
+		message["_syscall"] = event.Syscall
+	}
+	if event.Container.ID != "" {
+		message["_container_id"] = event.Container.ID
+		message["_container_name"] = event.Container.Name
+		message["_container_image"] = event.Container.ImageName
+	}
+	if event.Kubernetes.PodName != "" {
+		message["_pod_name"] = event.Kubernetes.PodName
+		message["_pod_namespace"] = event.Kubernetes.PodNamespace
+	}
+	if len(event.MatchedPolicies) > 0 {
+		message["_policies"] = strings.Join(event.MatchedPolicies, ",")
+	}
+	if event.Metadata != nil && event.Metadata.Severity != "" {
+		message["_severity"] = event.Metadata.Severity
+	}
+
+	for _, arg := range event.Args {
+		message["_arg_"+invalidFieldChars.ReplaceAllString(arg.Name, "_")] = fieldValue(arg.Value)
+	}
+
+	return message
+}
+
+// fieldValue returns the value of an additional field: GELF fields are strings or numbers.
+func fieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case nil:
+		return ""
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,176 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	event := trace.Event{
+		Timestamp:       1712345678901234567,
+		EventName:       "security_file_open",
+		HostName:        "node-1",
+		HostProcessID:   1234,
+		ProcessName:     "cat",
+		Container:       trace.Container{ID: "0123456789ab", Name: "nginx", ImageName: "nginx:latest"},
+		MatchedPolicies: []string{"files", "containers"},
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname"}, Value: "/etc/shadow"},
+			{ArgMeta: trace.ArgMeta{Name: "flags"}, Value: 0},
+			{ArgMeta: trace.ArgMeta{Name: "argv"}, Value: []string{"cat", "/etc/shadow"}},
+			{ArgMeta: trace.ArgMeta{Name: "dev id"}, Value: nil},
+		},
+	}
+
+	message := Message(&event)
+	assert.Equal(t, "1.1", message["version"])
+	assert.Equal(t, "node-1", message["host"])
+	assert.Equal(t, "security_file_open: cat (pid 1234)", message["short_message"])
+	assert.InDelta(t, 1712345678.901234567, message["timestamp"], 1e-6)
+	assert.Equal(t, levelInformational, message["level"])
+	assert.Equal(t, "0123456789ab", message["_container_id"])
+	assert.Equal(t, "files,containers", message["_policies"])
+	assert.Equal(t, "/etc/shadow", message["_arg_pathname"])
+	assert.Equal(t, 0, message["_arg_flags"])
+	assert.Equal(t, `["cat","/etc/shadow"]`, message["_arg_argv"])
+	assert.Equal(t, "", message["_arg_dev_id"])
+	assert.NotContains(t, message, "full_message")
+	assert.NotContains(t, message, "_pod_name")
+
+	// findings
+	event.Metadata = &trace.Metadata{
+		Description: "A process read the shadow file",
+		Severity:    "high",
+		Properties:  map[string]interface{}{"signatureName": "Shadow file read"},
+	}
+	message = Message(&event)
+	assert.Equal(t, "Shadow file read: cat (pid 1234)", message["short_message"])
+	assert.Equal(t, "A process read the shadow file", message["full_message"])
+	assert.Equal(t, levelError, message["level"])
+	assert.Equal(t, "high", message["_severity"])
+}
+
+func TestWriterUDP(t *testing.T) {
+	t.Parallel()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	read := func() []byte {
+		buf := make([]byte, 65536)
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		return buf[:n]
+	}
+
+	// compressed, in a single datagram
+	w, err := NewWriter(Config{Network: "udp", Address: conn.LocalAddr().String()})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(map[string]interface{}{"version": Version, "short_message": "hello"}))
+
+	zr, err := gzip.NewReader(bytes.NewReader(read()))
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version": "1.1", "short_message": "hello"}`, string(data))
+	require.NoError(t, w.Close())
+
+	// chunked
+	w, err = NewWriter(Config{
+		Network:     "udp",
+		Address:     conn.LocalAddr().String(),
+		Compression: CompressionNone,
+		ChunkSize:   112,
+	})
+	require.NoError(t, err)
+	defer w.Close()
+	long := strings.Repeat("x", 250)
+	require.NoError(t, w.Write(map[string]interface{}{"short_message": long}))
+
+	var messageID []byte
+	var message []byte
+	for i := 0; i < 3; i++ {
+		chunk := read()
+		require.Greater(t, len(chunk), 12)
+		assert.LessOrEqual(t, len(chunk), 112)
+		assert.Equal(t, []byte{0x1e, 0x0f}, chunk[:2])
+		if messageID == nil {
+			messageID = chunk[2:10]
+		}
+		assert.Equal(t, messageID, chunk[2:10])
+		assert.Equal(t, []byte{byte(i), 3}, chunk[10:12])
+		message = append(message, chunk[12:]...)
+	}
+	assert.JSONEq(t, `{"short_message": "`+long+`"}`, string(message))
+
+	// too many chunks
+	err = w.Write(map[string]interface{}{"short_message": strings.Repeat("x", 100*129)})
+	assert.ErrorContains(t, err, "gelf message too large")
+}
+
+func TestWriterTCP(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	messages := make(chan string)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			r := bufio.NewReader(conn)
+			message, err := r.ReadString(0)
+			if err == nil {
+				messages <- strings.TrimSuffix(message, "\x00")
+			}
+			conn.Close() // a message per connection: the writer connects again
+		}
+	}()
+
+	_, err = NewWriter(Config{Network: "tcp", Address: listener.Addr().String(), Compression: CompressionGzip})
+	assert.ErrorContains(t, err, "gelf compression isn't supported over tcp")
+
+	w, err := NewWriter(Config{Network: "tcp", Address: listener.Addr().String()})
+	require.NoError(t, err)
+	defer w.Close()
+
+	event := trace.Event{EventName: "sched_process_exec", ProcessName: "bash", HostProcessID: 1}
+	require.NoError(t, w.Write(Message(&event)))
+	var message map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(<-messages), &message))
+	assert.Equal(t, "sched_process_exec: bash (pid 1)", message["short_message"])
+	assert.Equal(t, "tracee", message["host"])
+
+	// the closed connection fails a write, once noticed, and the next write connects again
+	require.Eventually(t, func() bool {
+		if err := w.Write(Message(&event)); err != nil {
+			return false
+		}
+		select {
+		case <-messages:
+			return true
+		case <-time.After(100 * time.Millisecond):
+			return false
+		}
+	}, 10*time.Second, 10*time.Millisecond)
+}
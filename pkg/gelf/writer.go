@@ -0,0 +1,222 @@
+package gelf
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	// DefaultChunkSize is the default maximum size of the UDP datagrams, fitting the MTU of
+	// most networks.
+	DefaultChunkSize = 1420
+	// maxChunks is the maximum number of chunks of a message, beyond which Graylog drops it.
+	maxChunks = 128
+	// chunkHeaderSize is the size of the header of the chunks: the magic bytes, the message id,
+	// the chunk sequence number and the chunks count.
+	chunkHeaderSize = 12
+)
+
+// Compression is the compression of the UDP messages.
+type Compression string
+
+const (
+	CompressionGzip Compression = "gzip"
+	CompressionZlib Compression = "zlib"
+	CompressionNone Compression = "none"
+)
+
+// Config is the configuration of a writer.
+type Config struct {
+	Network     string      // udp, tcp or tls
+	Address     string      // host:port
+	Compression Compression // of the UDP messages (gzip by default)
+	ChunkSize   int         // maximum size of the UDP datagrams
+	Timeout     time.Duration
+}
+
+// Writer sends GELF messages. It is safe for concurrent use.
+type Writer struct {
+	cfg Config
+
+	mu   sync.Mutex
+	conn net.Conn // nil until connected (TCP reconnects on errors)
+}
+
+// NewWriter returns a writer of the given configuration. The UDP writers resolve the address
+// once, the TCP writers connect on the first message (and again after an error).
+func NewWriter(cfg Config) (*Writer, error) {
+	switch cfg.Network {
+	case "udp", "tcp", "tls":
+	default:
+		return nil, errfmt.Errorf("unsupported gelf protocol %q", cfg.Network)
+	}
+	switch cfg.Compression {
+	case "":
+		cfg.Compression = CompressionNone
+		if cfg.Network == "udp" {
+			cfg.Compression = CompressionGzip
+		}
+	case CompressionGzip, CompressionZlib, CompressionNone:
+	default:
+		return nil, errfmt.Errorf("unsupported gelf compression %q", cfg.Compression)
+	}
+	if cfg.Network != "udp" && cfg.Compression != CompressionNone {
+		return nil, errfmt.Errorf("gelf compression isn't supported over %s", cfg.Network)
+	}
+	if cfg.ChunkSize == 0 {
+		cfg.ChunkSize = DefaultChunkSize
+	}
+	if cfg.ChunkSize <= chunkHeaderSize {
+		return nil, errfmt.Errorf("invalid gelf chunk size %d", cfg.ChunkSize)
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	w := &Writer{cfg: cfg}
+	if cfg.Network == "udp" {
+		if err := w.connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+func (w *Writer) connect() error {
+	dialer := &net.Dialer{Timeout: w.cfg.Timeout}
+
+	var conn net.Conn
+	var err error
+	switch w.cfg.Network {
+	case "tls":
+		tlsDialer := &tls.Dialer{NetDialer: dialer}
+		conn, err = tlsDialer.DialContext(context.Background(), "tcp", w.cfg.Address)
+	default:
+		conn, err = dialer.Dial(w.cfg.Network, w.cfg.Address)
+	}
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	w.conn = conn
+
+	return nil
+}
+
+// Write sends a message.
+func (w *Writer) Write(message map[string]interface{}) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	if w.cfg.Network == "udp" {
+		return w.writeUDP(data)
+	}
+
+	return w.writeTCP(data)
+}
+
+// writeTCP writes a message followed by a null byte, the messages delimiter. The connection
+// is closed on errors, and the next message connects again.
+func (w *Writer) writeTCP(data []byte) error {
+	if w.conn == nil {
+		if err := w.connect(); err != nil {
+			return err
+		}
+	}
+
+	_ = w.conn.SetWriteDeadline(time.Now().Add(w.cfg.Timeout))
+	if _, err := w.conn.Write(append(data, 0)); err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+		return errfmt.WrapError(err)
+	}
+
+	return nil
+}
+
+// writeUDP compresses a message, and sends it in chunks if it doesn't fit in a datagram.
+func (w *Writer) writeUDP(data []byte) error {
+	data, err := w.compress(data)
+	if err != nil {
+		return err
+	}
+
+	if len(data) <= w.cfg.ChunkSize {
+		_, err := w.conn.Write(data)
+		return errfmt.WrapError(err)
+	}
+
+	chunkData := w.cfg.ChunkSize - chunkHeaderSize
+	count := (len(data) + chunkData - 1) / chunkData
+	if count > maxChunks {
+		return errfmt.Errorf("gelf message too large: %d bytes in more than %d chunks", len(data), maxChunks)
+	}
+
+	header := make([]byte, chunkHeaderSize, w.cfg.ChunkSize)
+	header[0], header[1] = 0x1e, 0x0f
+	if _, err := rand.Read(header[2:10]); err != nil { // message id
+		return errfmt.WrapError(err)
+	}
+	header[11] = byte(count)
+	for i := 0; i < count; i++ {
+		header[10] = byte(i)
+		end := min((i+1)*chunkData, len(data))
+		if _, err := w.conn.Write(append(header, data[i*chunkData:end]...)); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+func (w *Writer) compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var zw io.WriteCloser
+
+	switch w.cfg.Compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		zw = gzip.NewWriter(&buf)
+	case CompressionZlib:
+		zw = zlib.NewWriter(&buf)
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Close closes the connection.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+
+	return errfmt.WrapError(err)
+}
@@ -0,0 +1,288 @@
+// Package handoff hands the events output over from a running tracee to a new one started
+// alongside it (e.g. a blue/green agent upgrade), without losing nor duplicating events.
+//
+// The instance writing the events holds the sink lock, a unix socket it listens on. A new
+// instance holds its events until it gets the lock: it asks the running one for it once its
+// own programs are attached. The running one then only writes the events up to the cutoff
+// time of the request, for the drain duration, and exits. Once its outputs are closed, it
+// replies with the cutoff and the sequence number of its last event: the new instance writes
+// the events it held after the cutoff, numbering them from the next sequence number, and
+// listens on the socket in turn.
+package handoff
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// DefaultDrain is the default time the running instance keeps writing the events up to
+	// the cutoff, once asked for the sink lock (the events reach the sink out of order).
+	DefaultDrain = 2 * time.Second
+	// DefaultTimeout is the default time a new instance waits for the running one to reply.
+	DefaultTimeout = 2 * time.Minute
+	// DefaultMaxHeld is the default number of events a new instance holds while waiting for
+	// the sink lock.
+	DefaultMaxHeld = 100000
+)
+
+// Config is the handoff configuration.
+type Config struct {
+	Socket  string        // unix socket of the sink lock (no handoff if empty)
+	Drain   time.Duration // time the events up to the cutoff are written, once asked for the lock
+	Timeout time.Duration // time waited for the running instance to reply
+	MaxHeld int           // events held while waiting for the lock, the next ones are dropped
+}
+
+// Enabled returns true if the handoff is configured.
+func (c Config) Enabled() bool {
+	return c.Socket != ""
+}
+
+// request is sent by the new instance, asking for the sink lock
+type request struct {
+	Since int `json:"since"` // time the new instance traces from
+}
+
+// reply is sent by the running instance, once its outputs are closed
+type reply struct {
+	Cutoff   int    `json:"cutoff"`   // time of the last events written
+	Sequence uint64 `json:"sequence"` // sequence number of the last event written
+}
+
+type state int
+
+const (
+	waiting   state = iota // holding the events, until the lock is acquired
+	holding                // writing the events
+	releasing              // writing the events up to the cutoff, during the drain
+	released               // dropping the events, the lock being handed off
+)
+
+// Handoff is the sink lock of an instance: it decides, with Admit, which events the instance
+// writes, and numbers them.
+type Handoff struct {
+	cfg Config
+	now func() int // current time, in the clock of the events timestamps given to Admit
+
+	mu       sync.Mutex
+	state    state
+	previous int // events up to the previous cutoff were written by the previous instance
+	cutoff   int // events after the cutoff are written by the new instance (releasing)
+	sequence uint64
+	held     []heldEvent
+	dropped  int // held events dropped (over the max)
+
+	listener net.Listener
+	conn     net.Conn // connection of the new instance, replied to on Close
+	done     chan struct{}
+}
+
+// heldEvent is an event held until the sink lock is acquired
+type heldEvent struct {
+	event     *trace.Event
+	timestamp int
+}
+
+// New returns the sink lock of an instance, holding the events until it is acquired with
+// Start. The given function returns the current time, in the clock of the events timestamps
+// given to Admit: a clock shared by the instances (e.g. the monotonic clock of the kernel).
+func New(cfg Config, now func() int) *Handoff {
+	if cfg.Drain <= 0 {
+		cfg.Drain = DefaultDrain
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxHeld <= 0 {
+		cfg.MaxHeld = DefaultMaxHeld
+	}
+
+	return &Handoff{cfg: cfg, now: now, done: make(chan struct{})}
+}
+
+// Done returns a channel closed once the sink lock is handed off to a new instance: the
+// instance should exit, and Close its handoff once its outputs are closed.
+func (h *Handoff) Done() <-chan struct{} {
+	return h.done
+}
+
+// Start acquires the sink lock: at once if no instance holds it, otherwise in the background,
+// once the running instance handed it off. It must be called once the instance traces the
+// events (the running instance is asked to stop writing them).
+func (h *Handoff) Start(ctx context.Context) error {
+	conn, err := net.Dial("unix", h.cfg.Socket)
+	if err != nil {
+		if !errors.Is(err, syscall.ENOENT) && !errors.Is(err, syscall.ECONNREFUSED) {
+			return errfmt.WrapError(err)
+		}
+		// no running instance (a left over socket is removed)
+		logger.Debugw("No running tracee to hand the events output over from", "socket", h.cfg.Socket)
+		return h.acquire(ctx, reply{})
+	}
+
+	since := h.now()
+	if err := json.NewEncoder(conn).Encode(request{Since: since}); err != nil {
+		_ = conn.Close()
+		return errfmt.WrapError(err)
+	}
+	logger.Infow("Handoff requested to the running tracee", "socket", h.cfg.Socket)
+
+	go func() {
+		defer conn.Close()
+
+		var r reply
+		_ = conn.SetReadDeadline(time.Now().Add(h.cfg.Timeout))
+		if err := json.NewDecoder(conn).Decode(&r); err != nil {
+			// the running instance exited without replying, its last events are unknown
+			logger.Warnw("The running tracee didn't hand the events output over, events might be missing",
+				"socket", h.cfg.Socket, "error", err)
+			r = reply{Cutoff: since - 1}
+		}
+		if err := h.acquire(ctx, r); err != nil {
+			logger.Errorw("Handoff", "error", err)
+		}
+	}()
+
+	return nil
+}
+
+// acquire takes the sink lock, given the reply of the previous instance, and listens for the
+// next one.
+func (h *Handoff) acquire(ctx context.Context, r reply) error {
+	_ = os.Remove(h.cfg.Socket)
+	listener, err := net.Listen("unix", h.cfg.Socket)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	h.mu.Lock()
+	h.listener = listener
+	h.state, h.previous, h.sequence = holding, r.Cutoff, r.Sequence
+	h.mu.Unlock()
+	logger.Infow("Sink lock acquired", "socket", h.cfg.Socket, "cutoff", r.Cutoff, "sequence", r.Sequence)
+
+	go h.serve(ctx, listener)
+
+	return nil
+}
+
+// serve waits for a new instance asking for the sink lock.
+func (h *Handoff) serve(ctx context.Context, listener net.Listener) {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return // closed
+		}
+
+		var req request
+		_ = conn.SetReadDeadline(time.Now().Add(h.cfg.Timeout))
+		if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+			logger.Debugw("Invalid handoff request", "error", err)
+			_ = conn.Close()
+			continue
+		}
+		_ = conn.SetReadDeadline(time.Time{})
+
+		cutoff := h.now()
+		h.mu.Lock()
+		h.state, h.cutoff, h.conn = releasing, cutoff, conn
+		h.mu.Unlock()
+		logger.Infow("Handing the events output over to a new tracee", "cutoff", cutoff, "drain", h.cfg.Drain)
+
+		select {
+		case <-time.After(h.cfg.Drain):
+		case <-ctx.Done():
+		}
+
+		h.mu.Lock()
+		h.state = released
+		h.mu.Unlock()
+		_ = listener.Close() // the new instance listens once replied
+		close(h.done)
+
+		return // a single handoff
+	}
+}
+
+// Admit passes an event through the sink lock: the event is either given to write (numbered),
+// held until the lock is acquired, or given to drop. The events held are passed through, in
+// order, by the first call once the lock is acquired. It is not safe for concurrent use.
+func (h *Handoff) Admit(event *trace.Event, timestamp int, write, drop func(*trace.Event)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.state != waiting && h.held != nil {
+		for _, e := range h.held {
+			h.admit(e.event, e.timestamp, write, drop)
+		}
+		if h.dropped > 0 {
+			logger.Warnw("Events dropped while waiting for the sink lock", "dropped", h.dropped, "max", h.cfg.MaxHeld)
+		}
+		h.held, h.dropped = nil, 0
+	}
+
+	h.admit(event, timestamp, write, drop)
+}
+
+func (h *Handoff) admit(event *trace.Event, timestamp int, write, drop func(*trace.Event)) {
+	switch h.state {
+	case waiting:
+		if len(h.held) >= h.cfg.MaxHeld {
+			h.dropped++
+			drop(event)
+			return
+		}
+		h.held = append(h.held, heldEvent{event: event, timestamp: timestamp})
+	case holding, releasing:
+		// the events up to the previous cutoff were written by the previous instance, and the
+		// events after the cutoff are written by the new instance
+		if timestamp <= h.previous || (h.state == releasing && timestamp > h.cutoff) {
+			drop(event)
+			return
+		}
+		h.sequence++
+		event.SequenceNumber = h.sequence
+		write(event)
+	case released:
+		drop(event)
+	}
+}
+
+// Close releases the sink lock: once handed off, it replies to the new instance with the last
+// event written, so it must be called once the outputs are closed.
+func (h *Handoff) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var err error
+	if h.conn != nil {
+		if h.state == released {
+			err = json.NewEncoder(h.conn).Encode(reply{Cutoff: h.cutoff, Sequence: h.sequence})
+		}
+		_ = h.conn.Close()
+		h.conn = nil
+	}
+	if h.listener != nil {
+		_ = h.listener.Close()
+		h.listener = nil
+	}
+
+	return errfmt.WrapError(err)
+}
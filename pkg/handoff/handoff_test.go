@@ -0,0 +1,133 @@
+package handoff
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// sink records the events written and dropped by an instance
+type sink struct {
+	written []*trace.Event
+	dropped []*trace.Event
+}
+
+func (s *sink) admit(h *Handoff, timestamp int) {
+	h.Admit(&trace.Event{Timestamp: timestamp}, timestamp,
+		func(e *trace.Event) { s.written = append(s.written, e) },
+		func(e *trace.Event) { s.dropped = append(s.dropped, e) },
+	)
+}
+
+func timestamps(events []*trace.Event) []int {
+	var ts []int
+	for _, e := range events {
+		ts = append(ts, e.Timestamp)
+	}
+	return ts
+}
+
+func sequences(events []*trace.Event) []uint64 {
+	var seqs []uint64
+	for _, e := range events {
+		seqs = append(seqs, e.SequenceNumber)
+	}
+	return seqs
+}
+
+func stateOf(h *Handoff) state {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.state
+}
+
+func TestHandoff(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var clock atomic.Int64
+	now := func() int { return int(clock.Load()) }
+	cfg := Config{Socket: filepath.Join(t.TempDir(), "handoff.sock"), Drain: 50 * time.Millisecond, MaxHeld: 3}
+
+	// the first instance holds its events until it acquires the lock, at once
+	old, oldSink := New(cfg, now), &sink{}
+	oldSink.admit(old, 5)
+	require.NoError(t, old.Start(ctx))
+	clock.Store(10)
+	oldSink.admit(old, 10)
+	assert.Equal(t, []int{5, 10}, timestamps(oldSink.written))
+
+	// the new instance asks for the lock, the running one writes the events up to the cutoff
+	// during the drain
+	successor, successorSink := New(cfg, now), &sink{}
+	clock.Store(20)
+	require.NoError(t, successor.Start(ctx))
+	successorSink.admit(successor, 19) // traced by both instances
+	successorSink.admit(successor, 25)
+
+	require.Eventually(t, func() bool { return stateOf(old) == releasing }, 5*time.Second, time.Millisecond)
+	oldSink.admit(old, 15) // out of order, before the cutoff
+	oldSink.admit(old, 21) // after the cutoff
+	<-old.Done()
+	assert.Equal(t, []int{5, 10, 15}, timestamps(oldSink.written))
+	assert.Equal(t, []uint64{1, 2, 3}, sequences(oldSink.written))
+
+	// the old instance exits, once its outputs are closed
+	oldSink.admit(old, 30)
+	assert.Equal(t, []int{21, 30}, timestamps(oldSink.dropped))
+	require.NoError(t, old.Close())
+
+	// the new instance writes the events it held after the cutoff, numbered from the last one
+	successorSink.admit(successor, 26)
+	successorSink.admit(successor, 27) // over the max held, until the lock is acquired
+	require.Eventually(t, func() bool { return stateOf(successor) == holding }, 5*time.Second, time.Millisecond)
+	successorSink.admit(successor, 28)
+
+	assert.Equal(t, []int{25, 26, 28}, timestamps(successorSink.written))
+	assert.Equal(t, []uint64{4, 5, 6}, sequences(successorSink.written))
+	assert.ElementsMatch(t, []int{19, 27}, timestamps(successorSink.dropped))
+
+	// the new instance holds the lock in turn
+	third := New(cfg, now)
+	require.NoError(t, third.Start(ctx))
+	<-successor.Done()
+	require.NoError(t, successor.Close())
+	require.Eventually(t, func() bool { return stateOf(third) == holding }, 5*time.Second, time.Millisecond)
+	require.NoError(t, third.Close())
+}
+
+func TestHandoffNoReply(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	now := func() int { return 100 }
+	cfg := Config{Socket: filepath.Join(t.TempDir(), "handoff.sock"), Drain: time.Hour}
+
+	old := New(cfg, now)
+	require.NoError(t, old.Start(ctx))
+
+	// the running instance exits without replying (e.g. killed)
+	successor, successorSink := New(cfg, now), &sink{}
+	require.NoError(t, successor.Start(ctx))
+	successorSink.admit(successor, 99)
+	successorSink.admit(successor, 100)
+	require.Eventually(t, func() bool { return stateOf(old) == releasing }, 5*time.Second, time.Millisecond)
+	require.NoError(t, old.Close())
+
+	// the events since the request are written
+	require.Eventually(t, func() bool { return stateOf(successor) == holding }, 5*time.Second, time.Millisecond)
+	successorSink.admit(successor, 101)
+	assert.Equal(t, []int{100, 101}, timestamps(successorSink.written))
+	assert.Equal(t, []int{99}, timestamps(successorSink.dropped))
+}
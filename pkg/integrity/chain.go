@@ -0,0 +1,231 @@
+package integrity
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Header describes a chain of signed events. It is emitted before the chain records.
+type Header struct {
+	Algorithm string    `json:"algorithm"`
+	BootID    string    `json:"boot_id"`
+	ChainID   string    `json:"chain_id"`
+	KeyID     string    `json:"key_id"`
+	PublicKey string    `json:"public_key,omitempty"` // ed25519 only
+	Time      time.Time `json:"time"`
+}
+
+// Record is the integrity record of a signed event.
+//
+// The record digest is sha256(previous digest | sequence | payload), the first record
+// chaining to the chain id, and the signature is computed over the digest. A missing
+// sequence number reveals a gap, and a modified, removed or reordered event breaks the
+// chain.
+type Record struct {
+	Seq       uint64 `json:"seq"`
+	Prev      string `json:"prev"` // hex digest of the previous record
+	Signature string `json:"sig"`  // base64 signature of the record digest
+}
+
+// Chain signs the events of a stream. Chain isn't thread-safe.
+type Chain struct {
+	key    *Key
+	header Header
+	seq    uint64
+	prev   []byte
+}
+
+// NewChain creates a chain of events signed with the given key.
+func NewChain(key *Key) (*Chain, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Chain{
+		key: key,
+		header: Header{
+			Algorithm: key.Algorithm,
+			BootID:    key.BootID,
+			ChainID:   hex.EncodeToString(id),
+			KeyID:     key.ID(),
+			PublicKey: key.PublicKey(),
+			Time:      time.Now().UTC(),
+		},
+		prev: id,
+	}, nil
+}
+
+// Header returns the chain header.
+func (c *Chain) Header() Header {
+	return c.header
+}
+
+// Sign returns the integrity record of the next event of the chain.
+func (c *Chain) Sign(payload []byte) Record {
+	c.seq++
+	digest := recordDigest(c.prev, c.seq, payload)
+
+	var sig []byte
+	if c.key.Algorithm == AlgorithmEd25519 {
+		sig = ed25519.Sign(c.key.private, digest)
+	} else {
+		mac := hmac.New(sha256.New, c.key.secret)
+		mac.Write(digest)
+		sig = mac.Sum(nil)
+	}
+
+	rec := Record{
+		Seq:       c.seq,
+		Prev:      hex.EncodeToString(c.prev),
+		Signature: base64.StdEncoding.EncodeToString(sig),
+	}
+	c.prev = digest
+
+	return rec
+}
+
+func recordDigest(prev []byte, seq uint64, payload []byte) []byte {
+	var seqBytes [8]byte
+	binary.BigEndian.PutUint64(seqBytes[:], seq)
+
+	h := sha256.New()
+	h.Write(prev)
+	h.Write(seqBytes[:])
+	h.Write(payload)
+
+	return h.Sum(nil)
+}
+
+// Verification errors
+var (
+	ErrSequenceGap  = errors.New("gap in the events sequence")
+	ErrChainBroken  = errors.New("events chain broken")
+	ErrBadSignature = errors.New("bad event signature")
+)
+
+// Verifier verifies the events of a chain, in order. Verifier isn't thread-safe.
+type Verifier struct {
+	header    Header
+	secret    []byte            // hmac-sha256
+	publicKey ed25519.PublicKey // ed25519
+	seq       uint64
+	prev      []byte
+}
+
+// NewVerifier creates a verifier of the chain with the given header. The hmac secret is
+// required for hmac-sha256 chains, and the trusted public key (see TrustedPublicKey) for
+// ed25519 chains: the public key of the header is only checked against it, since anyone
+// rewriting the stream could sign it with their own key.
+func NewVerifier(header Header, secret []byte, publicKey ed25519.PublicKey) (*Verifier, error) {
+	prev, err := hex.DecodeString(header.ChainID)
+	if err != nil {
+		return nil, errfmt.Errorf("invalid chain id: %v", err)
+	}
+
+	v := &Verifier{header: header, prev: prev}
+
+	switch header.Algorithm {
+	case AlgorithmHMAC:
+		if len(secret) == 0 {
+			return nil, errfmt.Errorf("%s verification requires the secret", AlgorithmHMAC)
+		}
+		v.secret = secret
+	case AlgorithmEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, errfmt.Errorf("%s verification requires the trusted public key", AlgorithmEd25519)
+		}
+		if header.PublicKey != base64.StdEncoding.EncodeToString(publicKey) {
+			return nil, errfmt.Errorf("chain header public key doesn't match the trusted public key")
+		}
+		v.publicKey = publicKey
+	default:
+		return nil, errfmt.Errorf("unsupported signing algorithm: %s", header.Algorithm)
+	}
+
+	return v, nil
+}
+
+// Verify verifies the next event of the chain. On a sequence gap, the chain is resumed
+// from the given record (once its signature is verified), so the following events can
+// still be verified.
+func (v *Verifier) Verify(payload []byte, rec Record) error {
+	prev, err := hex.DecodeString(rec.Prev)
+	if err != nil {
+		return fmt.Errorf("%w: invalid previous digest", ErrChainBroken)
+	}
+	sig, err := base64.StdEncoding.DecodeString(rec.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature encoding", ErrBadSignature)
+	}
+
+	digest := recordDigest(prev, rec.Seq, payload)
+	if !v.verifySignature(digest, sig) {
+		return fmt.Errorf("%w: seq %d", ErrBadSignature, rec.Seq)
+	}
+
+	expected := v.seq + 1
+	chained := hmac.Equal(prev, v.prev)
+	v.seq = rec.Seq
+	v.prev = digest
+
+	switch {
+	case rec.Seq > expected:
+		return fmt.Errorf("%w: expected seq %d, got %d", ErrSequenceGap, expected, rec.Seq)
+	case rec.Seq < expected || !chained:
+		return fmt.Errorf("%w: seq %d", ErrChainBroken, rec.Seq)
+	}
+
+	return nil
+}
+
+func (v *Verifier) verifySignature(digest, sig []byte) bool {
+	if v.publicKey != nil {
+		return ed25519.Verify(v.publicKey, digest, sig)
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write(digest)
+
+	return hmac.Equal(mac.Sum(nil), sig)
+}
+
+// Secret returns the hmac secret of a key file, for verification.
+func Secret(keyFile string) ([]byte, error) {
+	key, err := readKeyFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil || key.Algorithm != AlgorithmHMAC {
+		return nil, errfmt.Errorf("no %s key in %s", AlgorithmHMAC, keyFile)
+	}
+
+	return key.secret, nil
+}
+
+// TrustedPublicKey returns the ed25519 public key of a public key file (written next to the key
+// file, with a .pub suffix), for verification.
+func TrustedPublicKey(pubFile string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(pubFile)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, errfmt.Errorf("no %s public key in %s", AlgorithmEd25519, pubFile)
+	}
+
+	return raw, nil
+}
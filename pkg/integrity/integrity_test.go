@@ -0,0 +1,142 @@
+package integrity
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setBootID makes the tests read the given boot id.
+func setBootID(t *testing.T, bootID string) {
+	path := filepath.Join(t.TempDir(), "boot_id")
+	require.NoError(t, os.WriteFile(path, []byte(bootID+"\n"), 0o644))
+	bootIDPath = path
+}
+
+func TestLoadKey(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "tracee.key")
+
+	setBootID(t, "boot-1")
+	key, err := LoadKey(Config{Algorithm: AlgorithmEd25519, KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, "boot-1", key.BootID)
+
+	pub, err := os.ReadFile(keyFile + ".pub")
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey()+"\n", string(pub))
+
+	// same boot: the key is kept
+	again, err := LoadKey(Config{Algorithm: AlgorithmEd25519, KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, key.ID(), again.ID())
+
+	// new boot: the key is replaced
+	setBootID(t, "boot-2")
+	rebooted, err := LoadKey(Config{Algorithm: AlgorithmEd25519, KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.NotEqual(t, key.ID(), rebooted.ID())
+
+	// hmac requires a key file
+	_, err = LoadKey(Config{Algorithm: AlgorithmHMAC})
+	assert.Error(t, err)
+
+	_, err = LoadKey(Config{Algorithm: "md5"})
+	assert.Error(t, err)
+}
+
+func TestChain(t *testing.T) {
+	setBootID(t, "boot-1")
+	keyFile := filepath.Join(t.TempDir(), "tracee.key")
+
+	for _, algorithm := range []string{AlgorithmHMAC, AlgorithmEd25519} {
+		t.Run(algorithm, func(t *testing.T) {
+			key, err := LoadKey(Config{Algorithm: algorithm, KeyFile: keyFile})
+			require.NoError(t, err)
+
+			chain, err := NewChain(key)
+			require.NoError(t, err)
+
+			payloads := [][]byte{[]byte(`{"a":1}`), []byte(`{"b":2}`), []byte(`{"c":3}`), []byte(`{"d":4}`)}
+			records := make([]Record, len(payloads))
+			for i, payload := range payloads {
+				records[i] = chain.Sign(payload)
+			}
+
+			var secret []byte
+			var publicKey ed25519.PublicKey
+			if algorithm == AlgorithmHMAC {
+				secret, err = Secret(keyFile)
+			} else {
+				publicKey, err = TrustedPublicKey(keyFile + ".pub")
+			}
+			require.NoError(t, err)
+
+			newVerifier := func() *Verifier {
+				v, err := NewVerifier(chain.Header(), secret, publicKey)
+				require.NoError(t, err)
+				return v
+			}
+
+			// intact stream
+			v := newVerifier()
+			for i := range payloads {
+				assert.NoError(t, v.Verify(payloads[i], records[i]))
+			}
+
+			// tampered event
+			v = newVerifier()
+			assert.NoError(t, v.Verify(payloads[0], records[0]))
+			err = v.Verify([]byte(`{"b":3}`), records[1])
+			assert.True(t, errors.Is(err, ErrBadSignature), err)
+
+			// missing event: the chain resumes after the gap
+			v = newVerifier()
+			assert.NoError(t, v.Verify(payloads[0], records[0]))
+			err = v.Verify(payloads[2], records[2])
+			assert.True(t, errors.Is(err, ErrSequenceGap), err)
+			assert.NoError(t, v.Verify(payloads[3], records[3]))
+
+			// replayed event
+			v = newVerifier()
+			assert.NoError(t, v.Verify(payloads[0], records[0]))
+			assert.NoError(t, v.Verify(payloads[1], records[1]))
+			err = v.Verify(payloads[1], records[1])
+			assert.True(t, errors.Is(err, ErrChainBroken), err)
+		})
+	}
+}
+
+func TestVerifierTrustedPublicKey(t *testing.T) {
+	setBootID(t, "boot-1")
+
+	trusted, err := LoadKey(Config{Algorithm: AlgorithmEd25519})
+	require.NoError(t, err)
+	trustedPublicKey, err := base64.StdEncoding.DecodeString(trusted.PublicKey())
+	require.NoError(t, err)
+
+	// a stream rewritten and signed with another key, along with its header
+	forger, err := LoadKey(Config{Algorithm: AlgorithmEd25519})
+	require.NoError(t, err)
+	chain, err := NewChain(forger)
+	require.NoError(t, err)
+
+	_, err = NewVerifier(chain.Header(), nil, nil)
+	assert.ErrorContains(t, err, "requires the trusted public key")
+
+	_, err = NewVerifier(chain.Header(), nil, trustedPublicKey)
+	assert.ErrorContains(t, err, "doesn't match the trusted public key")
+
+	// the header public key rewritten as well
+	header := chain.Header()
+	header.PublicKey = trusted.PublicKey()
+	v, err := NewVerifier(header, nil, trustedPublicKey)
+	require.NoError(t, err)
+	err = v.Verify([]byte(`{"a":1}`), chain.Sign([]byte(`{"a":1}`)))
+	assert.True(t, errors.Is(err, ErrBadSignature), err)
+}
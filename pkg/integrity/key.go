@@ -0,0 +1,205 @@
+// Package integrity signs emitted events, chaining them with sequence numbers, so
+// downstream systems can detect tampering or gaps in the events stream.
+package integrity
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Signing algorithms
+const (
+	AlgorithmHMAC    = "hmac-sha256"
+	AlgorithmEd25519 = "ed25519"
+)
+
+const hmacKeySize = 32
+
+// bootIDPath holds the id of the current boot
+var bootIDPath = "/proc/sys/kernel/random/boot_id"
+
+// Config is the configuration of the events signing.
+type Config struct {
+	Algorithm string // signing disabled if empty
+	KeyFile   string // where the per-boot key is kept (required for hmac-sha256)
+}
+
+// Enabled returns true if events signing is enabled.
+func (c Config) Enabled() bool {
+	return c.Algorithm != ""
+}
+
+// Key is a per-boot signing key: it is kept across restarts of tracee in the same boot
+// (when a key file is given), and replaced after a reboot.
+type Key struct {
+	Algorithm string
+	BootID    string
+	secret    []byte             // hmac-sha256 secret
+	private   ed25519.PrivateKey // ed25519 private key
+}
+
+// keyFile is the content of a key file.
+type keyFile struct {
+	Algorithm string `json:"algorithm"`
+	BootID    string `json:"boot_id"`
+	Key       string `json:"key"` // base64 hmac secret or ed25519 seed
+}
+
+// LoadKey returns the signing key of the current boot, generating it if needed. The
+// ed25519 public key is also written next to the key file, with a .pub suffix.
+func LoadKey(cfg Config) (*Key, error) {
+	if cfg.Algorithm != AlgorithmHMAC && cfg.Algorithm != AlgorithmEd25519 {
+		return nil, errfmt.Errorf("unsupported signing algorithm: %s", cfg.Algorithm)
+	}
+	if cfg.Algorithm == AlgorithmHMAC && cfg.KeyFile == "" {
+		return nil, errfmt.Errorf("%s signing requires a key file", AlgorithmHMAC)
+	}
+
+	bootID, err := readBootID()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.KeyFile != "" {
+		key, err := readKeyFile(cfg.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		if key != nil && key.Algorithm == cfg.Algorithm && key.BootID == bootID {
+			return key, nil
+		}
+	}
+
+	key, err := generateKey(cfg.Algorithm, bootID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.KeyFile != "" {
+		if err := writeKeyFile(cfg.KeyFile, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ID identifies the key: a digest of the ed25519 public key, or of the hmac secret.
+func (k *Key) ID() string {
+	var digest [sha256.Size]byte
+	if k.Algorithm == AlgorithmEd25519 {
+		digest = sha256.Sum256(k.private.Public().(ed25519.PublicKey))
+	} else {
+		digest = sha256.Sum256(k.secret)
+	}
+
+	return hex.EncodeToString(digest[:8])
+}
+
+// PublicKey returns the base64 encoded ed25519 public key, or an empty string for hmac.
+func (k *Key) PublicKey() string {
+	if k.Algorithm != AlgorithmEd25519 {
+		return ""
+	}
+
+	return base64.StdEncoding.EncodeToString(k.private.Public().(ed25519.PublicKey))
+}
+
+func readBootID() (string, error) {
+	data, err := os.ReadFile(bootIDPath)
+	if err != nil {
+		return "", errfmt.Errorf("could not read boot id: %v", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func generateKey(algorithm, bootID string) (*Key, error) {
+	key := &Key{Algorithm: algorithm, BootID: bootID}
+
+	if algorithm == AlgorithmEd25519 {
+		_, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		key.private = private
+		return key, nil
+	}
+
+	key.secret = make([]byte, hmacKeySize)
+	if _, err := rand.Read(key.secret); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return key, nil
+}
+
+// readKeyFile reads a key file, returning nil if it doesn't exist.
+func readKeyFile(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errfmt.WrapError(err)
+	}
+
+	var kf keyFile
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return nil, errfmt.Errorf("invalid key file %s: %v", path, err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(kf.Key)
+	if err != nil {
+		return nil, errfmt.Errorf("invalid key file %s: %v", path, err)
+	}
+
+	key := &Key{Algorithm: kf.Algorithm, BootID: kf.BootID}
+	switch kf.Algorithm {
+	case AlgorithmEd25519:
+		if len(raw) != ed25519.SeedSize {
+			return nil, errfmt.Errorf("invalid key file %s: bad key size", path)
+		}
+		key.private = ed25519.NewKeyFromSeed(raw)
+	case AlgorithmHMAC:
+		key.secret = raw
+	default:
+		return nil, errfmt.Errorf("invalid key file %s: unsupported algorithm %s", path, kf.Algorithm)
+	}
+
+	return key, nil
+}
+
+func writeKeyFile(path string, key *Key) error {
+	raw := key.secret
+	if key.Algorithm == AlgorithmEd25519 {
+		raw = key.private.Seed()
+	}
+
+	data, err := json.Marshal(keyFile{
+		Algorithm: key.Algorithm,
+		BootID:    key.BootID,
+		Key:       base64.StdEncoding.EncodeToString(raw),
+	})
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	if key.Algorithm == AlgorithmEd25519 {
+		pub := []byte(key.PublicKey() + "\n")
+		if err := os.WriteFile(path+".pub", pub, 0o644); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
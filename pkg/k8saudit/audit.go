@@ -0,0 +1,140 @@
+// Package k8saudit correlates the actions of the Kubernetes audit logs (e.g. an exec into
+// a pod) with the processes they start on the node.
+package k8saudit
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Action is an API server action on a pod, reported by the audit logs, which starts
+// processes in its containers.
+type Action struct {
+	AuditID     string
+	Verb        string
+	User        string // impersonated user if any, otherwise authenticated user
+	Resource    string // always pods
+	Subresource string // exec, attach, portforward or ephemeralcontainers
+	Namespace   string
+	Pod         string
+	Container   string   // target container, if given in the request
+	Command     []string // exec command, if given in the request
+	SourceIPs   []string
+	UserAgent   string
+	Time        time.Time // time the request was received by the API server
+}
+
+// correlatedSubresources are the pod subresources which actions start processes in the
+// pod (kubectl exec, attach, port-forward and debug).
+var correlatedSubresources = map[string]struct{}{
+	"exec":                {},
+	"attach":              {},
+	"portforward":         {},
+	"ephemeralcontainers": {},
+}
+
+// event is an audit.k8s.io/v1 Event, reduced to the correlated fields.
+type event struct {
+	AuditID    string `json:"auditID"`
+	Stage      string `json:"stage"`
+	RequestURI string `json:"requestURI"`
+	Verb       string `json:"verb"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ImpersonatedUser *struct {
+		Username string `json:"username"`
+	} `json:"impersonatedUser"`
+	SourceIPs []string `json:"sourceIPs"`
+	UserAgent string   `json:"userAgent"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code int `json:"code"`
+	} `json:"responseStatus"`
+	RequestReceivedTimestamp time.Time `json:"requestReceivedTimestamp"`
+}
+
+// eventList is an audit.k8s.io/v1 EventList, as sent by the API server audit webhook.
+type eventList struct {
+	Items []event `json:"items"`
+}
+
+// ParseEvent returns the action of an audit event, as written by the API server audit
+// log backend (one JSON event per line). ok is false if the event isn't a correlated
+// action.
+func ParseEvent(data []byte) (action Action, ok bool, err error) {
+	var e event
+	if err := json.Unmarshal(data, &e); err != nil {
+		return Action{}, false, errfmt.Errorf("invalid audit event: %v", err)
+	}
+
+	action, ok = e.action()
+	return action, ok, nil
+}
+
+// ParseEventList returns the actions of an audit event list, as sent by the API server
+// audit webhook backend.
+func ParseEventList(data []byte) ([]Action, error) {
+	var list eventList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errfmt.Errorf("invalid audit event list: %v", err)
+	}
+
+	var actions []Action
+	for _, e := range list.Items {
+		if action, ok := e.action(); ok {
+			actions = append(actions, action)
+		}
+	}
+
+	return actions, nil
+}
+
+// action returns the action of the event, if it is an allowed request on a correlated pod
+// subresource. Long running requests (exec, attach and port-forward) are reported once
+// started, the other ones once completed.
+func (e *event) action() (Action, bool) {
+	if e.ObjectRef == nil || e.ObjectRef.Resource != "pods" || e.ObjectRef.Name == "" {
+		return Action{}, false
+	}
+	if _, ok := correlatedSubresources[e.ObjectRef.Subresource]; !ok {
+		return Action{}, false
+	}
+	if e.Stage != "ResponseStarted" && e.Stage != "ResponseComplete" {
+		return Action{}, false
+	}
+	if e.ResponseStatus != nil && e.ResponseStatus.Code >= 400 {
+		return Action{}, false // denied or failed request
+	}
+
+	action := Action{
+		AuditID:     e.AuditID,
+		Verb:        e.Verb,
+		User:        e.User.Username,
+		Resource:    e.ObjectRef.Resource,
+		Subresource: e.ObjectRef.Subresource,
+		Namespace:   e.ObjectRef.Namespace,
+		Pod:         e.ObjectRef.Name,
+		SourceIPs:   e.SourceIPs,
+		UserAgent:   e.UserAgent,
+		Time:        e.RequestReceivedTimestamp,
+	}
+	if e.ImpersonatedUser != nil && e.ImpersonatedUser.Username != "" {
+		action.User = e.ImpersonatedUser.Username
+	}
+	if uri, err := url.ParseRequestURI(e.RequestURI); err == nil {
+		query := uri.Query()
+		action.Container = query.Get("container")
+		action.Command = query["command"]
+	}
+
+	return action, true
+}
@@ -0,0 +1,99 @@
+package k8saudit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditEvent returns an audit event of a request on a pod subresource.
+func auditEvent(auditID, stage, subresource, pod string, code int, received time.Time) string {
+	return fmt.Sprintf(`{"kind":"Event","apiVersion":"audit.k8s.io/v1","level":"Metadata",`+
+		`"auditID":%q,"stage":%q,"verb":"create",`+
+		`"requestURI":"/api/v1/namespaces/default/pods/%s/%s?command=sh&command=-c&command=id&container=app&stdin=true",`+
+		`"user":{"username":"alice","groups":["system:authenticated"]},`+
+		`"sourceIPs":["10.0.0.1"],"userAgent":"kubectl/v1.29.0",`+
+		`"objectRef":{"resource":"pods","namespace":"default","name":%q,"apiVersion":"v1","subresource":%q},`+
+		`"responseStatus":{"metadata":{},"code":%d},`+
+		`"requestReceivedTimestamp":%q,"stageTimestamp":%q}`,
+		auditID, stage, pod, subresource, pod, subresource, code,
+		received.Format(time.RFC3339Nano), received.Format(time.RFC3339Nano))
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Parallel()
+
+	received := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	action, ok, err := ParseEvent([]byte(auditEvent("id-1", "ResponseStarted", "exec", "web", 101, received)))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, Action{
+		AuditID:     "id-1",
+		Verb:        "create",
+		User:        "alice",
+		Resource:    "pods",
+		Subresource: "exec",
+		Namespace:   "default",
+		Pod:         "web",
+		Container:   "app",
+		Command:     []string{"sh", "-c", "id"},
+		SourceIPs:   []string{"10.0.0.1"},
+		UserAgent:   "kubectl/v1.29.0",
+		Time:        received,
+	}, action)
+
+	impersonated := `{"auditID":"id-2","stage":"ResponseComplete","verb":"patch",` +
+		`"requestURI":"/api/v1/namespaces/default/pods/web/ephemeralcontainers",` +
+		`"user":{"username":"alice"},"impersonatedUser":{"username":"bob"},` +
+		`"objectRef":{"resource":"pods","namespace":"default","name":"web","subresource":"ephemeralcontainers"},` +
+		`"responseStatus":{"code":200}}`
+	action, ok, err = ParseEvent([]byte(impersonated))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "bob", action.User)
+	assert.Equal(t, "ephemeralcontainers", action.Subresource)
+	assert.Empty(t, action.Container)
+
+	testCases := []struct {
+		name  string
+		event string
+	}{
+		{name: "request received stage", event: auditEvent("id", "RequestReceived", "exec", "web", 0, received)},
+		{name: "forbidden", event: auditEvent("id", "ResponseComplete", "exec", "web", 403, received)},
+		{name: "uncorrelated subresource", event: auditEvent("id", "ResponseComplete", "log", "web", 200, received)},
+		{name: "no object", event: `{"auditID":"id","stage":"ResponseComplete","requestURI":"/healthz"}`},
+	}
+	for _, tc := range testCases {
+		_, ok, err := ParseEvent([]byte(tc.event))
+		require.NoError(t, err, tc.name)
+		assert.False(t, ok, tc.name)
+	}
+
+	_, _, err = ParseEvent([]byte("{"))
+	assert.ErrorContains(t, err, "invalid audit event")
+}
+
+func TestParseEventList(t *testing.T) {
+	t.Parallel()
+
+	received := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	list := fmt.Sprintf(`{"kind":"EventList","apiVersion":"audit.k8s.io/v1","items":[%s,%s,%s]}`,
+		auditEvent("id-1", "RequestReceived", "exec", "web", 0, received),
+		auditEvent("id-1", "ResponseStarted", "exec", "web", 101, received),
+		auditEvent("id-2", "ResponseStarted", "attach", "db", 101, received),
+	)
+
+	actions, err := ParseEventList([]byte(list))
+	require.NoError(t, err)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "id-1", actions[0].AuditID)
+	assert.Equal(t, "id-2", actions[1].AuditID)
+	assert.Equal(t, "attach", actions[1].Subresource)
+
+	_, err = ParseEventList([]byte("[]"))
+	assert.ErrorContains(t, err, "invalid audit event list")
+}
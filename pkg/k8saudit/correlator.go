@@ -0,0 +1,243 @@
+package k8saudit
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// DefaultWindow is the default time after an action during which the processes of its
+	// pod are correlated with it.
+	DefaultWindow = time.Minute
+
+	// clockSkew is the tolerated skew between the API server and the node clocks.
+	clockSkew = 2 * time.Second
+	// maxPodProcesses is the maximum number of processes buffered per pod, waiting for the
+	// audit events received late (e.g. batched by the webhook backend).
+	maxPodProcesses = 1024
+
+	matchesBuffer = 1024
+)
+
+// Config is the audit logs correlation configuration.
+type Config struct {
+	File        string        // audit log file written by the API server log backend
+	Webhook     string        // listen address of the audit webhook receiver
+	WebhookCert string        // TLS certificate of the webhook receiver (plain http if empty)
+	WebhookKey  string        // TLS key of the webhook receiver
+	Window      time.Duration // time after an action during which its pod processes are correlated
+}
+
+// Enabled returns true if an audit logs input is configured.
+func (c Config) Enabled() bool {
+	return c.File != "" || c.Webhook != ""
+}
+
+// Match is a process correlated with an action received after it.
+type Match struct {
+	Action Action
+	Event  trace.Event // the process event
+}
+
+type podKey struct {
+	namespace string
+	name      string
+}
+
+type process struct {
+	event    trace.Event
+	observed time.Time
+}
+
+// Correlator correlates the actions of the audit logs with the processes of the pods
+// they target.
+type Correlator struct {
+	cfg      Config
+	listener net.Listener // webhook receiver listener, nil if disabled
+	matches  chan Match
+
+	mu        sync.Mutex
+	actions   map[podKey][]Action
+	seen      map[string]time.Time // audit ids of the received actions, e.g. reported at several stages
+	processes map[podKey][]process
+}
+
+// New returns a correlator of the configured audit logs inputs, failing if the audit log
+// file doesn't exist or if the webhook receiver can't listen.
+func New(cfg Config) (*Correlator, error) {
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultWindow
+	}
+	c := &Correlator{
+		cfg:       cfg,
+		matches:   make(chan Match, matchesBuffer),
+		actions:   map[podKey][]Action{},
+		seen:      map[string]time.Time{},
+		processes: map[podKey][]process{},
+	}
+
+	if cfg.File != "" {
+		if _, err := os.Stat(cfg.File); err != nil {
+			return nil, errfmt.Errorf("audit log file: %v", err)
+		}
+	}
+	if cfg.Webhook != "" {
+		if cfg.WebhookCert != "" {
+			if _, err := tls.LoadX509KeyPair(cfg.WebhookCert, cfg.WebhookKey); err != nil {
+				return nil, errfmt.Errorf("audit webhook certificate: %v", err)
+			}
+		}
+		listener, err := net.Listen("tcp", cfg.Webhook)
+		if err != nil {
+			return nil, errfmt.Errorf("audit webhook: %v", err)
+		}
+		c.listener = listener
+	}
+
+	return c, nil
+}
+
+// Matches returns the channel of the processes correlated with actions received after
+// them. Processes observed after an action are returned by Observe.
+func (c *Correlator) Matches() <-chan Match {
+	return c.matches
+}
+
+// Run receives the audit events of the configured inputs until the context is done.
+func (c *Correlator) Run(ctx context.Context) {
+	if c.cfg.File != "" {
+		go c.tail(ctx, c.cfg.File)
+	}
+	if c.listener != nil {
+		go c.serve(ctx)
+	}
+
+	ticker := time.NewTicker(c.cfg.Window / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			c.prune(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Observe returns the actions correlated with a process event observed at the given
+// time, and keeps the process to be correlated with the actions received later. Only
+// the processes of pods (known from the container enrichment) are correlated.
+func (c *Correlator) Observe(event trace.Event, observed time.Time) []Action {
+	key := podKey{namespace: event.Kubernetes.PodNamespace, name: event.Kubernetes.PodName}
+	if key.name == "" {
+		return nil
+	}
+	// the arguments are parsed later in the pipeline, keep them as they are now
+	event.DecodeArgs()
+	event.Args = append([]trace.Argument(nil), event.Args...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var actions []Action
+	for _, action := range c.actions[key] {
+		if c.correlated(action, observed) {
+			actions = append(actions, action)
+		}
+	}
+
+	processes := append(c.processes[key], process{event: event, observed: observed})
+	if len(processes) > maxPodProcesses {
+		processes = processes[len(processes)-maxPodProcesses:]
+	}
+	c.processes[key] = processes
+
+	return actions
+}
+
+// add correlates the received actions with the processes observed before them.
+func (c *Correlator) add(ctx context.Context, actions []Action) {
+	var matches []Match
+
+	c.mu.Lock()
+	for _, action := range actions {
+		if action.AuditID != "" {
+			if _, ok := c.seen[action.AuditID]; ok {
+				continue
+			}
+			c.seen[action.AuditID] = action.Time
+		}
+
+		key := podKey{namespace: action.Namespace, name: action.Pod}
+		c.actions[key] = append(c.actions[key], action)
+		for _, p := range c.processes[key] {
+			if c.correlated(action, p.observed) {
+				matches = append(matches, Match{Action: action, Event: p.event})
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	for _, match := range matches {
+		select {
+		case c.matches <- match:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// correlated returns true if a process observed at the given time follows the action
+// within the correlation window.
+func (c *Correlator) correlated(action Action, observed time.Time) bool {
+	return !observed.Before(action.Time.Add(-clockSkew)) &&
+		!observed.After(action.Time.Add(c.cfg.Window))
+}
+
+// prune drops the actions and processes which can no longer be correlated.
+func (c *Correlator) prune(now time.Time) {
+	expired := now.Add(-c.cfg.Window - clockSkew)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, actions := range c.actions {
+		kept := actions[:0]
+		for _, action := range actions {
+			if action.Time.After(expired) {
+				kept = append(kept, action)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.actions, key)
+			continue
+		}
+		c.actions[key] = kept
+	}
+	for id, received := range c.seen {
+		if !received.After(expired) {
+			delete(c.seen, id)
+		}
+	}
+	for key, processes := range c.processes {
+		kept := processes[:0]
+		for _, p := range processes {
+			if p.observed.After(expired) {
+				kept = append(kept, p)
+			}
+		}
+		if len(kept) == 0 {
+			delete(c.processes, key)
+			continue
+		}
+		c.processes[key] = kept
+	}
+}
@@ -0,0 +1,96 @@
+package k8saudit
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func podProcess(namespace, pod, pathname string) trace.Event {
+	return trace.Event{
+		EventName:  "sched_process_exec",
+		Kubernetes: trace.Kubernetes{PodNamespace: namespace, PodName: pod},
+		Args: []trace.Argument{
+			{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: pathname},
+		},
+	}
+}
+
+func TestCorrelator(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	exec := Action{AuditID: "id-1", Subresource: "exec", Namespace: "default", Pod: "web", Time: start}
+
+	c, err := New(Config{Window: 30 * time.Second})
+	require.NoError(t, err)
+
+	// a process of another pod, and a process of the pod observed before the action
+	assert.Empty(t, c.Observe(podProcess("default", "db", "/bin/sh"), start))
+	assert.Empty(t, c.Observe(podProcess("default", "web", "/usr/bin/old"), start.Add(-10*time.Second)))
+	// a process of the pod observed right after the action, which is received late
+	assert.Empty(t, c.Observe(podProcess("default", "web", "/bin/sh"), start.Add(time.Second)))
+	// a host process
+	assert.Empty(t, c.Observe(trace.Event{EventName: "sched_process_exec"}, start))
+
+	c.add(ctx, []Action{exec})
+	require.Len(t, c.Matches(), 1)
+	match := <-c.Matches()
+	assert.Equal(t, exec, match.Action)
+	assert.Equal(t, "/bin/sh", match.Event.Args[0].Value)
+
+	// the same action, reported at another stage
+	c.add(ctx, []Action{exec})
+	assert.Empty(t, c.Matches())
+
+	// processes observed once the action is known
+	actions := c.Observe(podProcess("default", "web", "/usr/bin/id"), start.Add(20*time.Second))
+	assert.Equal(t, []Action{exec}, actions)
+	assert.Empty(t, c.Observe(podProcess("default", "web", "/usr/bin/id"), start.Add(31*time.Second)))
+	assert.Empty(t, c.Observe(podProcess("other", "web", "/usr/bin/id"), start.Add(2*time.Second)))
+
+	c.prune(start.Add(2 * time.Minute))
+	assert.Empty(t, c.actions)
+	assert.Empty(t, c.seen)
+	assert.Empty(t, c.processes)
+}
+
+func TestCorrelatorObserveKeepsArgs(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c, err := New(Config{})
+	require.NoError(t, err)
+
+	event := podProcess("default", "web", "/bin/sh")
+	c.Observe(event, now)
+	event.Args[0].Value = "parsed later"
+
+	c.add(context.Background(), []Action{{Namespace: "default", Pod: "web", Time: now}})
+	match := <-c.Matches()
+	assert.Equal(t, "/bin/sh", match.Event.Args[0].Value)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultWindow, c.cfg.Window)
+
+	_, err = New(Config{File: filepath.Join(t.TempDir(), "missing.log")})
+	assert.ErrorContains(t, err, "audit log file")
+
+	_, err = New(Config{Webhook: "127.0.0.1:0", WebhookCert: "missing.crt", WebhookKey: "missing.key"})
+	assert.ErrorContains(t, err, "audit webhook certificate")
+
+	_, err = New(Config{Webhook: "invalid address"})
+	assert.ErrorContains(t, err, "audit webhook")
+}
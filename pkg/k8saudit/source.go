@@ -0,0 +1,185 @@
+package k8saudit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+const (
+	filePollInterval   = time.Second
+	maxWebhookBodySize = 32 * 1024 * 1024
+)
+
+// tail reads the audit events appended to the audit log file, until the context is done.
+// The file is reopened once rotated or truncated.
+func (c *Correlator) tail(ctx context.Context, path string) {
+	file, err := openTail(path, true)
+	if err != nil {
+		logger.Errorw("Opening audit log file", "file", path, "error", err)
+	}
+
+	ticker := time.NewTicker(filePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if file == nil {
+				// removed while being rotated, reopened once recreated
+				if file, err = openTail(path, false); err != nil {
+					continue
+				}
+			}
+			c.handleLines(ctx, file.lines())
+			if file.rotated() {
+				file.close()
+				if file, err = openTail(path, false); err != nil {
+					logger.Debugw("Reopening audit log file", "file", path, "error", err)
+				}
+			}
+		case <-ctx.Done():
+			if file != nil {
+				file.close()
+			}
+			return
+		}
+	}
+}
+
+func (c *Correlator) handleLines(ctx context.Context, lines [][]byte) {
+	var actions []Action
+	for _, line := range lines {
+		action, ok, err := ParseEvent(line)
+		if err != nil {
+			logger.Debugw("Parsing audit log", "error", err)
+			continue
+		}
+		if ok {
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) > 0 {
+		c.add(ctx, actions)
+	}
+}
+
+// serve receives the audit events of the API server webhook backend, until the context
+// is done.
+func (c *Correlator) serve(ctx context.Context) {
+	server := &http.Server{
+		Handler:           c.webhookHandler(ctx),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	var err error
+	if c.cfg.WebhookCert != "" {
+		err = server.ServeTLS(c.listener, c.cfg.WebhookCert, c.cfg.WebhookKey)
+	} else {
+		err = server.Serve(c.listener)
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logger.Errorw("Audit webhook receiver", "error", err)
+	}
+}
+
+// webhookHandler handles the audit event lists posted by the API server.
+func (c *Correlator) webhookHandler(ctx context.Context) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		actions, err := ParseEventList(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		c.add(ctx, actions)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// fileTail reads the lines appended to a file.
+type fileTail struct {
+	path    string
+	file    *os.File
+	reader  *bufio.Reader
+	offset  int64
+	partial []byte // last line, not fully written yet
+}
+
+// openTail opens a file, to read the lines appended from its end or from its start.
+func openTail(path string, fromEnd bool) (*fileTail, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset int64
+	if fromEnd {
+		if offset, err = file.Seek(0, io.SeekEnd); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+
+	return &fileTail{path: path, file: file, reader: bufio.NewReader(file), offset: offset}, nil
+}
+
+// lines returns the lines fully written since the last call.
+func (t *fileTail) lines() [][]byte {
+	var lines [][]byte
+	for {
+		data, err := t.reader.ReadBytes('\n')
+		t.offset += int64(len(data))
+		t.partial = append(t.partial, data...)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				logger.Debugw("Reading audit log file", "file", t.path, "error", err)
+			}
+			return lines
+		}
+
+		line := bytes.TrimSpace(t.partial)
+		t.partial = nil
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+	}
+}
+
+// rotated returns true if the path refers to another file, or if the file was truncated.
+func (t *fileTail) rotated() bool {
+	info, err := os.Stat(t.path)
+	if err != nil {
+		return false // not recreated yet, the current file might still be written to
+	}
+	current, err := t.file.Stat()
+	if err != nil {
+		return true
+	}
+
+	return !os.SameFile(info, current) || info.Size() < t.offset
+}
+
+func (t *fileTail) close() {
+	_ = t.file.Close()
+}
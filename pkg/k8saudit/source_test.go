@@ -0,0 +1,95 @@
+package k8saudit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTail(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	now := time.Now()
+	old := auditEvent("id-0", "ResponseStarted", "exec", "web", 101, now)
+	require.NoError(t, os.WriteFile(path, []byte(old+"\n"), 0o600))
+
+	c, err := New(Config{File: path})
+	require.NoError(t, err)
+	c.Observe(podProcess("default", "web", "/bin/sh"), now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.Run(ctx)
+
+	appendLines := func(lines string) {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = file.WriteString(lines)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+	receive := func() Match {
+		select {
+		case match := <-c.Matches():
+			return match
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no match received")
+		}
+		return Match{}
+	}
+
+	// wait for the file to be opened, before appending to it
+	time.Sleep(100 * time.Millisecond)
+
+	// the existing events are skipped, and a partially written event is read once complete
+	event := auditEvent("id-1", "ResponseStarted", "exec", "web", 101, now)
+	appendLines("not json\n" + event[:10])
+	time.Sleep(filePollInterval + 100*time.Millisecond)
+	appendLines(event[10:] + "\n")
+	assert.Equal(t, "id-1", receive().Action.AuditID)
+
+	// rotation
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, os.WriteFile(path, []byte(auditEvent("id-2", "ResponseStarted", "attach", "web", 101, now)+"\n"), 0o600))
+	assert.Equal(t, "id-2", receive().Action.AuditID)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	c, err := New(Config{})
+	require.NoError(t, err)
+	c.Observe(podProcess("default", "web", "/bin/sh"), now)
+
+	server := httptest.NewServer(c.webhookHandler(context.Background()))
+	defer server.Close()
+
+	list := fmt.Sprintf(`{"kind":"EventList","items":[%s]}`, auditEvent("id-1", "ResponseStarted", "exec", "web", 101, now))
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader(list))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, c.Matches(), 1)
+	assert.Equal(t, "id-1", (<-c.Matches()).Action.AuditID)
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader("{"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
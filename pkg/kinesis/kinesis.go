@@ -0,0 +1,191 @@
+// Package kinesis implements a minimal Amazon Kinesis Data Streams client, to publish the
+// events with the PutRecords API: requests are signed with the AWS Signature Version 4 signer
+// of the AWS SDK, with the credentials and region of its default configuration.
+package kinesis
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// MaxRecords is the maximum number of records of a PutRecords request.
+const MaxRecords = 500
+
+// Config is the configuration of a client.
+type Config struct {
+	Stream   string // the stream name, or its ARN
+	Region   string // of the ARN, or of the AWS configuration (e.g. AWS_REGION), if empty
+	Endpoint string // https://kinesis.<region>.amazonaws.com if empty
+	Timeout  time.Duration
+}
+
+// Record is a data record, put to the shard of its partition key. The records of a partition
+// key are ordered in their shard.
+type Record struct {
+	Data         []byte
+	PartitionKey string
+}
+
+// Client puts records to a stream. It is safe for concurrent use.
+type Client struct {
+	config      Config
+	client      aws.HTTPClient
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	now         func() time.Time
+}
+
+// NewClient returns a client of a stream. The credentials are discovered as the AWS SDKs do
+// (environment, shared files, web identity, container and instance metadata), and cached.
+func NewClient(ctx context.Context, config Config) (*Client, error) {
+	if config.Stream == "" {
+		return nil, errfmt.Errorf("kinesis stream not specified")
+	}
+	if arn := strings.Split(config.Stream, ":"); config.Region == "" && len(arn) == 6 && arn[0] == "arn" {
+		config.Region = arn[3] // arn:aws:kinesis:<region>:<account>:stream/<name>
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	client := awshttp.NewBuildableClient().WithTimeout(config.Timeout)
+
+	options := []func(*awsconfig.LoadOptions) error{awsconfig.WithHTTPClient(client)}
+	if config.Region != "" {
+		options = append(options, awsconfig.WithRegion(config.Region))
+	}
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx, options...)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	config.Region = awsConfig.Region
+	if config.Region == "" {
+		return nil, errfmt.Errorf("kinesis region not specified (nor configured, e.g. with AWS_REGION)")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://kinesis." + config.Region + ".amazonaws.com"
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+
+	return &Client{
+		config:      config,
+		client:      awsConfig.HTTPClient, // e.g. with the certificates of AWS_CA_BUNDLE
+		credentials: awsConfig.Credentials,
+		signer:      v4.NewSigner(),
+		now:         time.Now,
+	}, nil
+}
+
+type putRecordsEntry struct {
+	Data         []byte `json:"Data"` // base64 encoded
+	PartitionKey string `json:"PartitionKey"`
+}
+
+type putRecordsRequest struct {
+	Records    []putRecordsEntry `json:"Records"`
+	StreamName string            `json:"StreamName,omitempty"`
+	StreamARN  string            `json:"StreamARN,omitempty"`
+}
+
+type putRecordsResponse struct {
+	FailedRecordCount int `json:"FailedRecordCount"`
+	Records           []struct {
+		ErrorCode    string `json:"ErrorCode"`
+		ErrorMessage string `json:"ErrorMessage"`
+	} `json:"Records"`
+}
+
+// PutRecords puts records (at most MaxRecords) to the stream. The records may partially fail,
+// e.g. when a shard is throttled: it returns the failed records, to be put again, and the
+// error of the first one.
+func (c *Client) PutRecords(ctx context.Context, records []Record) ([]Record, error) {
+	if len(records) > MaxRecords {
+		return nil, errfmt.Errorf("too many kinesis records: %d (at most %d)", len(records), MaxRecords)
+	}
+
+	request := putRecordsRequest{Records: make([]putRecordsEntry, 0, len(records))}
+	if strings.HasPrefix(c.config.Stream, "arn:") {
+		request.StreamARN = c.config.Stream
+	} else {
+		request.StreamName = c.config.Stream
+	}
+	for _, record := range records {
+		request.Records = append(request.Records, putRecordsEntry{Data: record.Data, PartitionKey: record.PartitionKey})
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	creds, err := c.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Kinesis_20131202.PutRecords")
+	payloadHash := sha256.Sum256(body)
+	err = c.signer.SignHTTP(ctx, creds, req, hex.EncodeToString(payloadHash[:]), "kinesis", c.config.Region, c.now())
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return nil, errfmt.Errorf("kinesis http status %d: %s %s", resp.StatusCode, apiErr.Type, apiErr.Message)
+	}
+
+	var response putRecordsResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if response.FailedRecordCount == 0 {
+		return nil, nil
+	}
+	if len(response.Records) != len(records) {
+		return nil, errfmt.Errorf("kinesis returned %d results for %d records", len(response.Records), len(records))
+	}
+
+	var failed []Record
+	for i, result := range response.Records {
+		if result.ErrorCode == "" {
+			continue
+		}
+		if failed == nil {
+			err = errfmt.Errorf("%s: %s", result.ErrorCode, result.ErrorMessage)
+		}
+		failed = append(failed, records[i])
+	}
+
+	return failed, err
+}
@@ -0,0 +1,87 @@
+package kinesis
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPutRecords(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Kinesis_20131202.PutRecords", r.Header.Get("X-Amz-Target"))
+		assert.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		assert.Equal(t, "env-token", r.Header.Get("X-Amz-Security-Token"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIAENV/20240102/eu-west-1/kinesis/aws4_request, "+
+				"SignedHeaders=content-length;content-type;host;x-amz-date;x-amz-security-token;x-amz-target, Signature="))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var request putRecordsRequest
+		require.NoError(t, json.Unmarshal(body, &request))
+		assert.Equal(t, "tracee", request.StreamName)
+
+		// the records of the throttled partition key fail
+		response := putRecordsResponse{}
+		for _, record := range request.Records {
+			result := struct {
+				ErrorCode    string `json:"ErrorCode"`
+				ErrorMessage string `json:"ErrorMessage"`
+			}{}
+			if record.PartitionKey == "throttled" {
+				result.ErrorCode = "ProvisionedThroughputExceededException"
+				result.ErrorMessage = "Rate exceeded for shard shardId-000000000001"
+				response.FailedRecordCount++
+			}
+			response.Records = append(response.Records, result)
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(context.Background(), Config{Stream: "tracee", Region: "eu-west-1", Endpoint: server.URL})
+	require.NoError(t, err)
+	client.credentials = aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+		return aws.Credentials{AccessKeyID: "AKIAENV", SecretAccessKey: "env-secret", SessionToken: "env-token"}, nil
+	})
+	client.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	records := []Record{
+		{Data: []byte("1"), PartitionKey: "container-1"},
+		{Data: []byte("2"), PartitionKey: "throttled"},
+		{Data: []byte("3"), PartitionKey: "container-2"},
+	}
+	failed, err := client.PutRecords(context.Background(), records)
+	assert.ErrorContains(t, err, "ProvisionedThroughputExceededException: Rate exceeded")
+	assert.Equal(t, []Record{records[1]}, failed)
+
+	failed, err = client.PutRecords(context.Background(), records[:1])
+	assert.NoError(t, err)
+	assert.Empty(t, failed)
+
+	_, err = client.PutRecords(context.Background(), make([]Record, MaxRecords+1))
+	assert.ErrorContains(t, err, "too many kinesis records")
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(context.Background(), Config{Stream: "arn:aws:kinesis:ap-south-1:123456789012:stream/tracee"})
+	require.NoError(t, err)
+	assert.Equal(t, "ap-south-1", client.config.Region)
+	assert.Equal(t, "https://kinesis.ap-south-1.amazonaws.com", client.config.Endpoint)
+
+	_, err = NewClient(context.Background(), Config{})
+	assert.ErrorContains(t, err, "kinesis stream not specified")
+}
@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// latencyBuckets are the event latency histogram buckets, in seconds: from 10us to ~10s.
+var latencyBuckets = prometheus.ExponentialBuckets(0.00001, 4, 11)
+
+// EventLatency tracks, per event, the latency between the kernel timestamp of an event
+// and its emission by the sink stage of the pipeline. It is a histogram, so percentiles
+// (e.g. p50/p95/p99) are computed with histogram_quantile(), and can be aggregated across
+// agents.
+type EventLatency struct {
+	histogram *prometheus.HistogramVec
+}
+
+// NewEventLatency creates a new EventLatency tracker.
+func NewEventLatency() *EventLatency {
+	return &EventLatency{
+		histogram: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "tracee_ebpf",
+				Name:      "event_latency_seconds",
+				Help:      "latency between the kernel timestamp of an event and its emission, per event",
+				Buckets:   latencyBuckets,
+			},
+			[]string{"event"},
+		),
+	}
+}
+
+// Observe records the latency of an emitted event.
+func (l *EventLatency) Observe(eventName string, latency time.Duration) {
+	if latency < 0 {
+		return // clock skew between the kernel and userland clock readings
+	}
+	l.histogram.WithLabelValues(eventName).Observe(latency.Seconds())
+}
+
+// RegisterPrometheus registers the latency histogram to prometheus metrics exporter
+func (l *EventLatency) RegisterPrometheus() error {
+	return l.register(prometheus.DefaultRegisterer)
+}
+
+func (l *EventLatency) register(registerer prometheus.Registerer) error {
+	return errfmt.WrapError(registerer.Register(l.histogram))
+}
@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLatencyObserve(t *testing.T) {
+	t.Parallel()
+
+	l := NewEventLatency()
+
+	// negative latencies (clock skew) are dropped
+	l.Observe("openat", -time.Millisecond)
+	assert.Equal(t, 0, testutil.CollectAndCount(l.histogram))
+
+	l.Observe("openat", time.Millisecond)
+	l.Observe("openat", 2*time.Millisecond)
+	l.Observe("execve", time.Second)
+	assert.Equal(t, 2, testutil.CollectAndCount(l.histogram)) // one series per event
+}
+
+func TestEventLatencyRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	l := NewEventLatency()
+
+	require.NoError(t, l.register(registry))
+	assert.Error(t, l.register(registry)) // already registered
+
+	l.Observe("openat", time.Millisecond)
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "tracee_ebpf_event_latency_seconds", families[0].GetName())
+	assert.Equal(t, uint64(1), families[0].GetMetric()[0].GetHistogram().GetSampleCount())
+}
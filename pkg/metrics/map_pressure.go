@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// MapFill is the fill level of an eBPF map.
+type MapFill struct {
+	Entries    uint32
+	MaxEntries uint32
+	Evictions  uint64 // entries aged out (or evicted) before userland read them
+}
+
+// Percent returns the share of the map entries in use.
+func (f MapFill) Percent() float64 {
+	if f.MaxEntries == 0 {
+		return 0
+	}
+	return float64(f.Entries) * 100 / float64(f.MaxEntries)
+}
+
+// MapPressure tracks the fill level of eBPF maps, and the entries lost because they were
+// missing once userland looked them up (e.g. stack ids aging out of the stack addresses
+// map). It makes otherwise silent data loss observable.
+type MapPressure struct {
+	mutex sync.Mutex
+	maps  map[string]*MapFill
+
+	entries    *prometheus.GaugeVec
+	maxEntries *prometheus.GaugeVec
+	evictions  *prometheus.CounterVec
+}
+
+// NewMapPressure creates a new MapPressure tracker.
+func NewMapPressure() *MapPressure {
+	return &MapPressure{
+		maps: make(map[string]*MapFill),
+		entries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "bpf_map_entries",
+			Help:      "entries in use in the eBPF map",
+		}, []string{"map"}),
+		maxEntries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "bpf_map_max_entries",
+			Help:      "maximum entries of the eBPF map",
+		}, []string{"map"}),
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "bpf_map_evictions_total",
+			Help:      "eBPF map entries missing when looked up by userland (aged out or evicted)",
+		}, []string{"map"}),
+	}
+}
+
+// SetFill records the entries in use in a map.
+func (p *MapPressure) SetFill(name string, entries, maxEntries uint32) {
+	p.mutex.Lock()
+	fill := p.get(name)
+	fill.Entries = entries
+	fill.MaxEntries = maxEntries
+	p.mutex.Unlock()
+
+	p.entries.WithLabelValues(name).Set(float64(entries))
+	p.maxEntries.WithLabelValues(name).Set(float64(maxEntries))
+}
+
+// Evicted records an entry of a map missing when looked up.
+func (p *MapPressure) Evicted(name string) {
+	p.mutex.Lock()
+	p.get(name).Evictions++
+	p.mutex.Unlock()
+
+	p.evictions.WithLabelValues(name).Inc()
+}
+
+// Get returns the fill level of a map.
+func (p *MapPressure) Get(name string) MapFill {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if fill, ok := p.maps[name]; ok {
+		return *fill
+	}
+	return MapFill{}
+}
+
+func (p *MapPressure) get(name string) *MapFill {
+	fill, ok := p.maps[name]
+	if !ok {
+		fill = &MapFill{}
+		p.maps[name] = fill
+	}
+	return fill
+}
+
+// RegisterPrometheus registers the maps fill levels to prometheus metrics exporter
+func (p *MapPressure) RegisterPrometheus() error {
+	return p.register(prometheus.DefaultRegisterer)
+}
+
+func (p *MapPressure) register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{p.entries, p.maxEntries, p.evictions} {
+		if err := registerer.Register(collector); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapPressure(t *testing.T) {
+	t.Parallel()
+
+	p := NewMapPressure()
+	assert.Equal(t, MapFill{}, p.Get("stack_addresses"))
+
+	p.SetFill("stack_addresses", 512, 1024)
+	p.Evicted("stack_addresses")
+	p.Evicted("stack_addresses")
+	p.SetFill("containers_map", 10, 10240)
+
+	fill := p.Get("stack_addresses")
+	assert.Equal(t, MapFill{Entries: 512, MaxEntries: 1024, Evictions: 2}, fill)
+	assert.Equal(t, 50.0, fill.Percent())
+	assert.Equal(t, 0.0, MapFill{}.Percent())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(p.evictions.WithLabelValues("stack_addresses")))
+	assert.Equal(t, 2, testutil.CollectAndCount(p.entries)) // one series per map
+}
+
+func TestMapPressureRegister(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+	p := NewMapPressure()
+
+	require.NoError(t, p.register(registry))
+	assert.Error(t, p.register(registry)) // already registered
+
+	p.SetFill("fd_arg_path_map", 1, 1024)
+	p.Evicted("fd_arg_path_map")
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 3)
+	assert.Equal(t, "tracee_ebpf_bpf_map_entries", families[0].GetName())
+	assert.Equal(t, "tracee_ebpf_bpf_map_evictions_total", families[1].GetName())
+	assert.Equal(t, "tracee_ebpf_bpf_map_max_entries", families[2].GetName())
+}
@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Perf buffer tuning decisions
+const (
+	PerfBufferGrow   = "grow"   // a bigger buffer is recommended
+	PerfBufferCapped = "capped" // events are lost, but the recommended size is already the max size
+)
+
+// PerfBufferDecision is a decision of the perf buffer tuning, at the end of an interval.
+type PerfBufferDecision struct {
+	Received    uint64  // samples read from the buffer during the interval
+	Lost        uint64  // samples lost during the interval
+	LossRate    float64 // percent of the samples lost during the interval
+	Size        int     // size of the buffer in use (pages per cpu)
+	Recommended int     // recommended size of the buffer (pages per cpu)
+	Action      string  // PerfBufferGrow or PerfBufferCapped
+}
+
+// PerfBufferTuning recommends the size of the events perf buffer from the rate of the samples
+// lost because userland didn't read the buffer fast enough: the recommended size is doubled
+// for every interval losing more than the threshold, up to a max size. A perf buffer can't be
+// resized once polled, the recommended size is applied when tracee starts again.
+type PerfBufferTuning struct {
+	mutex       sync.Mutex
+	threshold   float64 // loss rate (percent) growing the recommended size
+	maxSize     int
+	size        int
+	recommended int
+	received    uint64 // at the previous check
+	lost        uint64 // at the previous check
+
+	lossRate        prometheus.Gauge
+	sizeGauge       prometheus.Gauge
+	recommendedSize prometheus.Gauge
+	decisions       *prometheus.CounterVec
+}
+
+// NewPerfBufferTuning creates the tuning of a perf buffer of the given size (pages per cpu).
+func NewPerfBufferTuning(size, maxSize int, threshold float64) *PerfBufferTuning {
+	t := &PerfBufferTuning{
+		threshold:   threshold,
+		maxSize:     maxSize,
+		size:        size,
+		recommended: size,
+		lossRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "perf_buffer_loss_rate",
+			Help:      "percent of the events perf buffer samples lost during the last tuning interval",
+		}),
+		sizeGauge: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "perf_buffer_size_pages",
+			Help:      "size of the events perf buffer in use, in pages per cpu",
+		}),
+		recommendedSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "perf_buffer_recommended_size_pages",
+			Help:      "recommended size of the events perf buffer, in pages per cpu",
+		}),
+		decisions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tracee_ebpf",
+			Name:      "perf_buffer_tuning_decisions_total",
+			Help:      "decisions of the events perf buffer tuning",
+		}, []string{"action"}),
+	}
+	t.sizeGauge.Set(float64(size))
+	t.recommendedSize.Set(float64(size))
+
+	return t
+}
+
+// Check compares the received and lost samples counters to the previous check, returning the
+// decision if the loss rate of the interval exceeds the threshold.
+func (t *PerfBufferTuning) Check(received, lost uint64) (PerfBufferDecision, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	d := PerfBufferDecision{
+		Received: received - t.received,
+		Lost:     lost - t.lost,
+		Size:     t.size,
+	}
+	t.received, t.lost = received, lost
+	if d.Received+d.Lost > 0 {
+		d.LossRate = float64(d.Lost) * 100 / float64(d.Received+d.Lost)
+	}
+	t.lossRate.Set(d.LossRate)
+
+	if d.Lost == 0 || d.LossRate < t.threshold {
+		return PerfBufferDecision{}, false
+	}
+
+	d.Action = PerfBufferCapped
+	if t.recommended < t.maxSize {
+		d.Action = PerfBufferGrow
+		t.recommended = min(t.recommended*2, t.maxSize)
+		t.recommendedSize.Set(float64(t.recommended))
+	}
+	d.Recommended = t.recommended
+	t.decisions.WithLabelValues(d.Action).Inc()
+
+	return d, true
+}
+
+// Recommended returns the recommended size of the perf buffer (pages per cpu).
+func (t *PerfBufferTuning) Recommended() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.recommended
+}
+
+// RegisterPrometheus registers the perf buffer tuning to prometheus metrics exporter
+func (t *PerfBufferTuning) RegisterPrometheus() error {
+	return t.register(prometheus.DefaultRegisterer)
+}
+
+func (t *PerfBufferTuning) register(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{t.lossRate, t.sizeGauge, t.recommendedSize, t.decisions} {
+		if err := registerer.Register(collector); err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+// StagePerfBufferSize stages the recommended size of the perf buffer (pages per cpu) in the
+// given file, for tracee to use it when it starts again.
+func StagePerfBufferSize(path string, size int) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errfmt.WrapError(err)
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(size)+"\n"), 0644); err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	return nil
+}
+
+// LoadStagedPerfBufferSize returns the size of the perf buffer staged in the given file, or 0
+// if no size was staged.
+func LoadStagedPerfBufferSize(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errfmt.WrapError(err)
+	}
+	size, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || size < 1 || size&(size-1) != 0 {
+		return 0, errfmt.Errorf("invalid perf buffer size staged in %s: %q", path, strings.TrimSpace(string(data)))
+	}
+
+	return size, nil
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPerfBufferTuning(t *testing.T) {
+	t.Parallel()
+
+	tuning := NewPerfBufferTuning(1024, 4096, 1)
+
+	// no loss, or a loss rate under the threshold
+	_, ok := tuning.Check(1000, 0)
+	assert.False(t, ok)
+	_, ok = tuning.Check(2000, 5)
+	assert.False(t, ok)
+	assert.InDelta(t, 0.497, testutil.ToFloat64(tuning.lossRate), 0.001)
+
+	// losses over the threshold double the recommended size, up to the max size
+	d, ok := tuning.Check(2900, 105)
+	require.True(t, ok)
+	assert.Equal(t, PerfBufferDecision{
+		Received: 900, Lost: 100, LossRate: 10, Size: 1024, Recommended: 2048, Action: PerfBufferGrow,
+	}, d)
+	d, ok = tuning.Check(3000, 205)
+	require.True(t, ok)
+	assert.Equal(t, 4096, d.Recommended)
+	d, ok = tuning.Check(3100, 305)
+	require.True(t, ok)
+	assert.Equal(t, PerfBufferCapped, d.Action)
+	assert.Equal(t, 4096, tuning.Recommended())
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(tuning.decisions.WithLabelValues(PerfBufferGrow)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(tuning.decisions.WithLabelValues(PerfBufferCapped)))
+	assert.Equal(t, 4096.0, testutil.ToFloat64(tuning.recommendedSize))
+	assert.Equal(t, 1024.0, testutil.ToFloat64(tuning.sizeGauge))
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, tuning.register(registry))
+	assert.Error(t, tuning.register(registry)) // already registered
+}
+
+func TestStagedPerfBufferSize(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tracee", "perf-buffer-size")
+	size, err := LoadStagedPerfBufferSize(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, size) // nothing staged
+
+	require.NoError(t, StagePerfBufferSize(path, 2048))
+	size, err = LoadStagedPerfBufferSize(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2048, size)
+
+	require.NoError(t, os.WriteFile(path, []byte("1000"), 0644))
+	_, err = LoadStagedPerfBufferSize(path)
+	assert.ErrorContains(t, err, `invalid perf buffer size staged in`)
+}
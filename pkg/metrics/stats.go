@@ -9,15 +9,22 @@ import (
 
 // When updating this struct, please make sure to update the relevant exporting functions
 type Stats struct {
-	EventCount       counter.Counter
-	EventsFiltered   counter.Counter
-	NetCapCount      counter.Counter // network capture events
-	BPFLogsCount     counter.Counter
-	ErrorCount       counter.Counter
-	LostEvCount      counter.Counter
-	LostWrCount      counter.Counter
-	LostNtCapCount   counter.Counter // lost network capture events
-	LostBPFLogsCount counter.Counter
+	EventCount           counter.Counter
+	EventsReceived       counter.Counter // samples read from the events perf buffer
+	EventsFiltered       counter.Counter
+	NetCapCount          counter.Counter // network capture events
+	BPFLogsCount         counter.Counter
+	ErrorCount           counter.Counter
+	LostEvCount          counter.Counter
+	LostWrCount          counter.Counter
+	LostNtCapCount       counter.Counter // lost network capture events
+	LostBPFLogsCount     counter.Counter
+	FindingsSuppressed   counter.Counter   // findings marked as suppressed by an allowlist rule
+	FindingsDeduplicated counter.Counter   // findings collapsed into an identical one
+	EventsShed           counter.Counter   // low priority events shed while exceeding the self-limits
+	EventLatency         *EventLatency     // nil if metrics are disabled
+	MapPressure          *MapPressure      // nil if the eBPF maps aren't monitored
+	PerfBufferTuning     *PerfBufferTuning // nil if the events perf buffer isn't tuned
 }
 
 // Register Stats to prometheus metrics exporter
@@ -52,6 +59,16 @@ func (stats *Stats) RegisterPrometheus() error {
 		return errfmt.WrapError(err)
 	}
 
+	err = prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "tracee_ebpf",
+		Name:      "received_events_total",
+		Help:      "events read from the submission buffer",
+	}, func() float64 { return float64(stats.EventsReceived.Get()) }))
+
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
 	err = prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
 		Namespace: "tracee_ebpf",
 		Name:      "lostevents_total",
@@ -98,5 +115,55 @@ func (stats *Stats) RegisterPrometheus() error {
 		Help:      "errors accumulated by tracee-ebpf",
 	}, func() float64 { return float64(stats.ErrorCount.Get()) }))
 
-	return errfmt.WrapError(err)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	err = prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "tracee_ebpf",
+		Name:      "findings_suppressed_total",
+		Help:      "findings suppressed by the suppression rules",
+	}, func() float64 { return float64(stats.FindingsSuppressed.Get()) }))
+
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	err = prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "tracee_ebpf",
+		Name:      "findings_deduplicated_total",
+		Help:      "findings collapsed into an identical one by the findings deduplication",
+	}, func() float64 { return float64(stats.FindingsDeduplicated.Get()) }))
+
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	err = prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: "tracee_ebpf",
+		Name:      "shed_events_total",
+		Help:      "low priority events shed while exceeding the self-limits",
+	}, func() float64 { return float64(stats.EventsShed.Get()) }))
+
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+
+	if stats.MapPressure != nil {
+		if err := stats.MapPressure.RegisterPrometheus(); err != nil {
+			return err
+		}
+	}
+
+	if stats.PerfBufferTuning != nil {
+		if err := stats.PerfBufferTuning.RegisterPrometheus(); err != nil {
+			return err
+		}
+	}
+
+	if stats.EventLatency != nil {
+		return stats.EventLatency.RegisterPrometheus()
+	}
+
+	return nil
 }
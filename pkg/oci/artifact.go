@@ -0,0 +1,158 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// TitleAnnotation names the file of a layer which isn't an archive.
+const TitleAnnotation = "org.opencontainers.image.title"
+
+// Artifact is an artifact pulled from a registry.
+type Artifact struct {
+	Source string // reference, without credentials
+	Digest string // manifest digest
+	Layers []Layer
+}
+
+// Layer is a pulled artifact layer.
+type Layer struct {
+	Descriptor
+	Data []byte
+}
+
+// Pull pulls an artifact from a registry. Its cosign signature is verified with the given
+// public key, unless nil.
+func Pull(ctx context.Context, source string, key crypto.PublicKey) (*Artifact, error) {
+	ref, err := parseReference(source)
+	if err != nil {
+		return nil, err
+	}
+	c := newClient(ref)
+
+	m, digest, err := c.manifest(ctx, ref.reference)
+	if err != nil {
+		return nil, pullError(source, err)
+	}
+	if key != nil {
+		if err := c.verifySignature(ctx, digest, key); err != nil {
+			return nil, errfmt.Errorf("pulling %s: %v", Redact(source), err)
+		}
+	}
+
+	artifact := &Artifact{Source: Redact(source), Digest: digest}
+	for _, desc := range m.Layers {
+		data, err := c.blob(ctx, desc)
+		if err != nil {
+			return nil, pullError(source, err)
+		}
+		artifact.Layers = append(artifact.Layers, Layer{Descriptor: desc, Data: data})
+	}
+
+	return artifact, nil
+}
+
+// Digest returns the manifest digest the reference currently resolves to.
+func Digest(ctx context.Context, source string) (string, error) {
+	ref, err := parseReference(source)
+	if err != nil {
+		return "", err
+	}
+
+	_, digest, err := newClient(ref).manifest(ctx, ref.reference)
+	if err != nil {
+		return "", pullError(source, err)
+	}
+
+	return digest, nil
+}
+
+func pullError(source string, err error) error {
+	if errors.Is(err, errNotFound) {
+		return errfmt.Errorf("pulling %s: not found in the registry", Redact(source))
+	}
+
+	return errfmt.Errorf("pulling %s: %v", Redact(source), err)
+}
+
+// IsArchive reports whether the layer is a tar (optionally gzipped) archive.
+func (l Layer) IsArchive() bool {
+	return strings.Contains(l.MediaType, "tar")
+}
+
+// Files returns the files of the layer, by name: the regular files of an archive, else the
+// layer itself, named by its title annotation (or its digest).
+func (l Layer) Files() (map[string][]byte, error) {
+	data := l.Data
+	if strings.Contains(l.MediaType, "gzip") {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		defer gz.Close()
+		if data, err = io.ReadAll(io.LimitReader(gz, maxBlobSize)); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+
+	if !l.IsArchive() {
+		name := l.Annotations[TitleAnnotation]
+		if name == "" {
+			_, name, _ = strings.Cut(l.Digest, ":")
+		}
+		return map[string][]byte{name: data}, nil
+	}
+
+	files := map[string][]byte{}
+	archive := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(io.LimitReader(archive, maxBlobSize))
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		files[header.Name] = content
+	}
+}
+
+// Extract writes the files of the artifact layers under dir.
+func (a *Artifact) Extract(dir string) error {
+	for _, layer := range a.Layers {
+		files, err := layer.Files()
+		if err != nil {
+			return errfmt.Errorf("extracting %s: %v", a.Source, err)
+		}
+		for name, content := range files {
+			// rooted, not to escape dir
+			file := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+name)))
+			if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+				return errfmt.WrapError(err)
+			}
+			if err := os.WriteFile(file, content, 0644); err != nil {
+				return errfmt.WrapError(err)
+			}
+		}
+	}
+
+	return nil
+}
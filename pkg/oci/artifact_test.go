@@ -0,0 +1,206 @@
+package oci
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	archive := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := archive.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+// registry is a test registry of the tracee/content repository, requiring a bearer token
+// for the user:pass credentials.
+type registry struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu        sync.Mutex
+	manifests map[string][]byte // by tag and digest
+	blobs     map[string][]byte // by digest
+}
+
+func newRegistry(t *testing.T) *registry {
+	r := &registry{t: t, manifests: map[string][]byte{}, blobs: map[string][]byte{}}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serve))
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+// source returns the reference of a tag or digest of the repository.
+func (r *registry) source(credentials, tagOrDigest string) string {
+	separator := ":"
+	if strings.Contains(tagOrDigest, ":") {
+		separator = "@"
+	}
+
+	return InsecureScheme + credentials + strings.TrimPrefix(r.server.URL, "http://") + "/tracee/content" + separator + tagOrDigest
+}
+
+// push pushes the layers as the manifest of the tag, returning its digest.
+func (r *registry) push(tag string, layers ...Layer) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	m := manifest{}
+	for _, layer := range layers {
+		if layer.Digest == "" {
+			layer.Digest = digestOf(layer.Data)
+		}
+		layer.Size = int64(len(layer.Data))
+		m.Layers = append(m.Layers, layer.Descriptor)
+		r.blobs[layer.Digest] = layer.Data
+	}
+	data, err := json.Marshal(m)
+	require.NoError(r.t, err)
+	digest := digestOf(data)
+	r.manifests[tag] = data
+	r.manifests[digest] = data
+
+	return digest
+}
+
+func (r *registry) serve(rw http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		user, password, _ := req.BasicAuth()
+		if user != "user" || password != "pass" || req.URL.Query().Get("scope") != "repository:tracee/content:pull" {
+			http.Error(rw, "denied", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(map[string]string{"token": "secret"})
+		return
+	}
+
+	if req.Header.Get("Authorization") != "Bearer secret" {
+		rw.Header().Set("WWW-Authenticate", `Bearer realm="`+r.server.URL+`/token",service="registry"`)
+		http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var data []byte
+	var ok bool
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/v2/tracee/content/manifests/"):
+		assert.Contains(r.t, req.Header.Get("Accept"), "application/vnd.oci.image.manifest.v1+json")
+		data, ok = r.manifests[strings.TrimPrefix(req.URL.Path, "/v2/tracee/content/manifests/")]
+	case strings.HasPrefix(req.URL.Path, "/v2/tracee/content/blobs/"):
+		data, ok = r.blobs[strings.TrimPrefix(req.URL.Path, "/v2/tracee/content/blobs/")]
+	}
+	if !ok {
+		http.NotFound(rw, req)
+		return
+	}
+	_, _ = rw.Write(data)
+}
+
+func TestPull(t *testing.T) {
+	t.Parallel()
+
+	policy := []byte("apiVersion: tracee.aquasec.com/v1beta1\nkind: Policy\n")
+	archive := tarGz(t, map[string]string{
+		"signatures/miner.rego":  "package tracee.miner",
+		"../../escape/file.rego": "package tracee.escape",
+	})
+	r := newRegistry(t)
+	digest := r.push("v1",
+		Layer{Descriptor: Descriptor{MediaType: "application/yaml", Annotations: map[string]string{TitleAnnotation: "policy.yaml"}}, Data: policy},
+		Layer{Descriptor: Descriptor{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip"}, Data: archive},
+	)
+
+	artifact, err := Pull(context.Background(), r.source("user:pass@", "v1"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, digest, artifact.Digest)
+	assert.Equal(t, r.source("", "v1"), artifact.Source)
+	require.Len(t, artifact.Layers, 2)
+
+	dir := t.TempDir()
+	require.NoError(t, artifact.Extract(dir))
+	extracted, err := os.ReadFile(filepath.Join(dir, "policy.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, policy, extracted)
+	extracted, err = os.ReadFile(filepath.Join(dir, "signatures", "miner.rego"))
+	require.NoError(t, err)
+	assert.Equal(t, "package tracee.miner", string(extracted))
+	assert.FileExists(t, filepath.Join(dir, "escape", "file.rego")) // rooted in dir
+
+	byDigest, err := Pull(context.Background(), r.source("user:pass@", digest), nil)
+	require.NoError(t, err)
+	assert.Equal(t, digest, byDigest.Digest)
+
+	latest, err := Digest(context.Background(), r.source("user:pass@", "v1"))
+	require.NoError(t, err)
+	assert.Equal(t, digest, latest)
+}
+
+func TestPullErrors(t *testing.T) {
+	t.Parallel()
+
+	r := newRegistry(t)
+	data := []byte("content")
+	r.push("v1", Layer{Descriptor: Descriptor{MediaType: "text/plain"}, Data: data})
+	r.push("tampered", Layer{Descriptor: Descriptor{MediaType: "text/plain", Digest: digestOf([]byte("other"))}, Data: data})
+
+	_, err := Pull(context.Background(), r.source("user:wrong@", "v1"), nil)
+	require.ErrorContains(t, err, "registry token http status: 401")
+	assert.NotContains(t, err.Error(), "wrong")
+
+	_, err = Pull(context.Background(), r.source("user:pass@", "v2"), nil)
+	assert.ErrorContains(t, err, "not found in the registry")
+
+	_, err = Pull(context.Background(), r.source("user:pass@", "tampered"), nil)
+	assert.ErrorContains(t, err, "digest mismatch")
+
+	_, err = Pull(context.Background(), r.source("user:pass@", digestOf([]byte("unknown"))), nil)
+	assert.ErrorContains(t, err, "not found in the registry")
+}
+
+func TestLayerFiles(t *testing.T) {
+	t.Parallel()
+
+	untitled := Layer{Descriptor: Descriptor{MediaType: "text/plain", Digest: "sha256:abc"}, Data: []byte("data")}
+	files, err := untitled.Files()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"abc": []byte("data")}, files)
+
+	var buf bytes.Buffer
+	archive := tar.NewWriter(&buf)
+	require.NoError(t, archive.WriteHeader(&tar.Header{Name: "dir/", Typeflag: tar.TypeDir, Mode: 0o700}))
+	require.NoError(t, archive.WriteHeader(&tar.Header{Name: "dir/a.yaml", Typeflag: tar.TypeReg, Mode: 0o600, Size: 1}))
+	_, err = archive.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, archive.Close())
+
+	tarLayer := Layer{Descriptor: Descriptor{MediaType: "application/vnd.oci.image.layer.v1.tar"}, Data: buf.Bytes()}
+	assert.True(t, tarLayer.IsArchive())
+	files, err = tarLayer.Files()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"dir/a.yaml": []byte("a")}, files)
+}
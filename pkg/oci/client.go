@@ -0,0 +1,228 @@
+package oci
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	manifestTypes = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+	maxBlobSize   = 64 * 1024 * 1024
+	timeout       = time.Minute
+)
+
+// errNotFound is returned when the registry has no such manifest or blob.
+var errNotFound = errors.New("not found")
+
+// Descriptor describes a manifest layer.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type manifest struct {
+	Layers []Descriptor `json:"layers"`
+}
+
+// client is a client of an OCI registry repository.
+type client struct {
+	ref    reference
+	client *http.Client
+	basic  bool   // use the reference credentials
+	token  string // bearer token
+}
+
+func newClient(ref reference) *client {
+	return &client{ref: ref, client: &http.Client{Timeout: timeout}}
+}
+
+// manifest gets the manifest of a tag or digest, returning its digest.
+func (c *client) manifest(ctx context.Context, tagOrDigest string) (manifest, string, error) {
+	var m manifest
+
+	data, err := c.get(ctx, "manifests/"+tagOrDigest, manifestTypes)
+	if err != nil {
+		return m, "", err
+	}
+	digest := digestOf(data)
+	if strings.Contains(tagOrDigest, ":") && digest != tagOrDigest {
+		return m, "", errfmt.Errorf("digest mismatch for %s", tagOrDigest)
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, "", errfmt.Errorf("invalid manifest: %v", err)
+	}
+
+	return m, digest, nil
+}
+
+// blob gets a blob, verifying its digest.
+func (c *client) blob(ctx context.Context, desc Descriptor) ([]byte, error) {
+	data, err := c.get(ctx, "blobs/"+desc.Digest, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyDigest(data, desc.Digest); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func verifyDigest(data []byte, digest string) error {
+	if algorithm, _, _ := strings.Cut(digest, ":"); algorithm != "sha256" {
+		return errfmt.Errorf("unsupported digest %q", digest)
+	}
+	if digestOf(data) != digest {
+		return errfmt.Errorf("digest mismatch for %s", digest)
+	}
+
+	return nil
+}
+
+// get gets a manifest or blob of the repository, authenticating on demand.
+func (c *client) get(ctx context.Context, resource, accept string) ([]byte, error) {
+	resp, err := c.do(ctx, resource, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && !c.basic && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, resource, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errNotFound
+	default:
+		return nil, errfmt.Errorf("registry http status: %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxBlobSize))
+}
+
+func (c *client) do(ctx context.Context, resource, accept string) (*http.Response, error) {
+	u := url.URL{
+		Scheme: c.ref.scheme,
+		Host:   c.ref.registry,
+		Path:   "/v2/" + c.ref.repository + "/" + resource,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case c.basic:
+		password, _ := c.ref.user.Password()
+		req.SetBasicAuth(c.ref.user.Username(), password)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return resp, nil
+}
+
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// authenticate gets a token for the Bearer challenge (anonymously, or with the reference
+// credentials), or uses the reference credentials for a Basic challenge.
+func (c *client) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.ref.user == nil {
+			return errfmt.Errorf("registry requires credentials")
+		}
+		c.basic = true
+		return nil
+	case "bearer":
+	default:
+		return errfmt.Errorf("unsupported registry authentication %q", scheme)
+	}
+
+	values := map[string]string{}
+	for _, param := range challengeParam.FindAllStringSubmatch(params, -1) {
+		values[param[1]] = param[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return errfmt.Errorf("invalid registry authentication realm %q", values["realm"])
+	}
+	query := realm.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + c.ref.repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if c.ref.user != nil {
+		password, _ := c.ref.user.Password()
+		req.SetBasicAuth(c.ref.user.Username(), password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errfmt.Errorf("registry token http status: %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return errfmt.WrapError(err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return errfmt.Errorf("registry returned no token")
+	}
+
+	return nil
+}
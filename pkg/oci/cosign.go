@@ -0,0 +1,88 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignPayloadType         = "cosign container image signature"
+)
+
+// cosignPayload is the simple signing payload signed by cosign.
+type cosignPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// verifySignature verifies the cosign signatures of the manifest digest, stored in the
+// sha256-<hex>.sig tag of the repository: one of them must be valid for the key.
+func (c *client) verifySignature(ctx context.Context, digest string, key crypto.PublicKey) error {
+	tag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	m, _, err := c.manifest(ctx, tag)
+	if errors.Is(err, errNotFound) {
+		return errfmt.Errorf("no cosign signature for %s", digest)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range m.Layers {
+		signature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, err := c.blob(ctx, layer)
+		if err != nil {
+			return err
+		}
+		if c.verifyPayload(payload, signature, digest, key) == nil {
+			return nil
+		}
+	}
+
+	return errfmt.Errorf("no valid cosign signature for %s", digest)
+}
+
+// verifyPayload verifies a cosign signature of the payload, and that the payload signs the
+// manifest digest of the repository.
+func (c *client) verifyPayload(payload []byte, signature, digest string, key crypto.PublicKey) error {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	if err := cosign.Verify(key, payload, sig); err != nil {
+		return err
+	}
+
+	var p cosignPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return errfmt.Errorf("invalid signature payload: %v", err)
+	}
+	if p.Critical.Type != cosignPayloadType {
+		return errfmt.Errorf("unexpected signature type %q", p.Critical.Type)
+	}
+	if p.Critical.Image.DockerManifestDigest != digest {
+		return errfmt.Errorf("signature of %s, not %s", p.Critical.Image.DockerManifestDigest, digest)
+	}
+	if repository := c.ref.registry + "/" + c.ref.repository; p.Critical.Identity.DockerReference != repository {
+		return errfmt.Errorf("signature of %s, not %s", p.Critical.Identity.DockerReference, repository)
+	}
+
+	return nil
+}
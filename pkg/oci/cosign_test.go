@@ -0,0 +1,118 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+)
+
+// writePublicKey writes the PEM encoded public key to a file, returning its path.
+func writePublicKey(t *testing.T, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	return path
+}
+
+// sign pushes a cosign signature of the signed digest as the signature of the digest, as
+// pushed by cosign sign (the digests being the same).
+func (r *registry) sign(t *testing.T, digest, signedDigest, repository string, sign func(payload []byte) []byte) {
+	payload := []byte(fmt.Sprintf(
+		`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`,
+		repository, signedDigest,
+	))
+	r.push(strings.Replace(digest, ":", "-", 1)+".sig", Layer{
+		Descriptor: Descriptor{
+			MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+			Annotations: map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sign(payload))},
+		},
+		Data: payload,
+	})
+}
+
+func TestPullVerified(t *testing.T) {
+	t.Parallel()
+
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signECDSA := func(payload []byte) []byte {
+		hash := sha256.Sum256(payload)
+		sig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, hash[:])
+		require.NoError(t, err)
+		return sig
+	}
+	edPublic, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signEd25519 := func(payload []byte) []byte { return ed25519.Sign(edKey, payload) }
+
+	ecdsaPublic, err := cosign.LoadPublicKey(writePublicKey(t, &ecdsaKey.PublicKey))
+	require.NoError(t, err)
+	ed25519Public, err := cosign.LoadPublicKey(writePublicKey(t, edPublic))
+	require.NoError(t, err)
+
+	r := newRegistry(t)
+	repository := strings.TrimPrefix(r.server.URL, "http://") + "/tracee/content"
+	layer := func(content string) Layer {
+		return Layer{Descriptor: Descriptor{MediaType: "text/plain"}, Data: []byte(content)}
+	}
+
+	signed := r.push("signed", layer("signed"))
+	r.sign(t, signed, signed, repository, signECDSA)
+	edSigned := r.push("ed25519", layer("ed25519"))
+	r.sign(t, edSigned, edSigned, repository, signEd25519)
+	r.push("unsigned", layer("unsigned"))
+	otherRepository := r.push("other-repository", layer("other repository"))
+	r.sign(t, otherRepository, otherRepository, "ghcr.io/other/content", signECDSA)
+	otherDigest := r.push("other-digest", layer("other digest"))
+	r.sign(t, otherDigest, signed, repository, signECDSA) // signature of another artifact
+
+	testCases := []struct {
+		name          string
+		tag           string
+		key           crypto.PublicKey
+		expectedError string
+	}{
+		{name: "ecdsa", tag: "signed", key: ecdsaPublic},
+		{name: "ed25519", tag: "ed25519", key: ed25519Public},
+		{name: "unverified", tag: "unsigned", key: nil},
+		{name: "unsigned", tag: "unsigned", key: ecdsaPublic, expectedError: "no cosign signature"},
+		{name: "wrong key", tag: "signed", key: ed25519Public, expectedError: "no valid cosign signature"},
+		{name: "other repository", tag: "other-repository", key: ecdsaPublic, expectedError: "no valid cosign signature"},
+		{name: "other digest", tag: "other-digest", key: ecdsaPublic, expectedError: "no valid cosign signature"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			artifact, err := Pull(context.Background(), r.source("user:pass@", tc.tag), tc.key)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, artifact.Layers, 1)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package oci
+
+import (
+	"context"
+	"crypto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// DefaultUpdateInterval is the default interval between two update checks of the pulled
+// artifacts.
+const DefaultUpdateInterval = time.Hour
+
+// Config is the configuration of the artifacts pulled from OCI registries.
+type Config struct {
+	CacheDir       string        // directory the artifacts are extracted to
+	PublicKey      string        // cosign public key file (artifacts aren't verified if empty)
+	UpdateInterval time.Duration // interval between two update checks (disabled if 0)
+}
+
+// Puller pulls artifacts into the cache directory, their files being loaded as local ones,
+// and checks them for updates.
+type Puller struct {
+	cfg Config
+	key crypto.PublicKey
+
+	mu      sync.Mutex
+	digests map[string]string // pulled artifacts digests, by reference
+}
+
+// NewPuller returns a puller of the given configuration.
+func NewPuller(cfg Config) (*Puller, error) {
+	p := &Puller{cfg: cfg, digests: map[string]string{}}
+	if cfg.PublicKey != "" {
+		key, err := cosign.LoadPublicKey(cfg.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		p.key = key
+	}
+
+	return p, nil
+}
+
+// Resolve returns the given sources, their OCI references being replaced by the
+// directories their artifacts are extracted to.
+func (p *Puller) Resolve(ctx context.Context, sources []string) ([]string, error) {
+	resolved := make([]string, 0, len(sources))
+	for _, source := range sources {
+		if !IsReference(source) {
+			resolved = append(resolved, source)
+			continue
+		}
+		dir, err := p.pull(ctx, source)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, dir)
+	}
+
+	return resolved, nil
+}
+
+// pull pulls an artifact, returning the directory it is extracted to.
+func (p *Puller) pull(ctx context.Context, source string) (string, error) {
+	if p.key == nil {
+		logger.Warnw("Pulling an OCI artifact without verifying its signature (no cosign key)", "artifact", Redact(source))
+	}
+	artifact, err := Pull(ctx, source, p.key)
+	if err != nil {
+		return "", err
+	}
+
+	_, hex, _ := strings.Cut(artifact.Digest, ":")
+	dir := filepath.Join(p.cfg.CacheDir, hex)
+	// extracted again, not to keep files which were modified or removed since
+	if err := os.RemoveAll(dir); err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", errfmt.WrapError(err)
+	}
+	if err := artifact.Extract(dir); err != nil {
+		return "", err
+	}
+	logger.Debugw("Pulled OCI artifact", "artifact", artifact.Source, "digest", artifact.Digest, "dir", dir)
+
+	p.mu.Lock()
+	p.digests[source] = artifact.Digest
+	p.mu.Unlock()
+
+	return dir, nil
+}
+
+// Pulled reports whether artifacts were pulled.
+func (p *Puller) Pulled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.digests) > 0
+}
+
+// Run checks the pulled artifacts for updates at every update interval, until the context
+// is done.
+//
+// The pulled content can't be changed while tracee is running: an update is logged, and
+// applied once tracee is restarted.
+func (p *Puller) Run(ctx context.Context) {
+	if p.cfg.UpdateInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(p.cfg.UpdateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.checkUpdates(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (p *Puller) checkUpdates(ctx context.Context) {
+	p.mu.Lock()
+	digests := make(map[string]string, len(p.digests))
+	for source, digest := range p.digests {
+		digests[source] = digest
+	}
+	p.mu.Unlock()
+
+	for source, digest := range digests {
+		latest, err := Digest(ctx, source)
+		if err != nil {
+			logger.Warnw("Checking OCI artifact updates", "error", err)
+			continue
+		}
+		if latest == digest {
+			continue
+		}
+		logger.Warnw("OCI artifact updated, restart tracee to apply it",
+			"artifact", Redact(source), "digest", digest, "new_digest", latest)
+
+		p.mu.Lock()
+		p.digests[source] = latest
+		p.mu.Unlock()
+	}
+}
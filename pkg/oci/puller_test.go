@@ -0,0 +1,59 @@
+package oci
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPuller(t *testing.T) {
+	t.Parallel()
+
+	r := newRegistry(t)
+	digest := r.push("v1", Layer{
+		Descriptor: Descriptor{MediaType: "application/yaml", Annotations: map[string]string{TitleAnnotation: "policy.yaml"}},
+		Data:       []byte("v1"),
+	})
+	source := r.source("user:pass@", "v1")
+
+	cacheDir := t.TempDir()
+	puller, err := NewPuller(Config{CacheDir: cacheDir})
+	require.NoError(t, err)
+	assert.False(t, puller.Pulled())
+
+	resolved, err := puller.Resolve(context.Background(), []string{"/etc/tracee/policies", source})
+	require.NoError(t, err)
+	dir := filepath.Join(cacheDir, strings.TrimPrefix(digest, "sha256:"))
+	assert.Equal(t, []string{"/etc/tracee/policies", dir}, resolved)
+	content, err := os.ReadFile(filepath.Join(dir, "policy.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(content))
+	assert.True(t, puller.Pulled())
+
+	// no update
+	puller.checkUpdates(context.Background())
+	assert.Equal(t, map[string]string{source: digest}, puller.digests)
+
+	// v1 pushed again, updated
+	updated := r.push("v1", Layer{
+		Descriptor: Descriptor{MediaType: "application/yaml", Annotations: map[string]string{TitleAnnotation: "policy.yaml"}},
+		Data:       []byte("v1 fixed"),
+	})
+	puller.checkUpdates(context.Background())
+	assert.Equal(t, map[string]string{source: updated}, puller.digests)
+
+	_, err = puller.Resolve(context.Background(), []string{r.source("user:pass@", "v2")})
+	assert.ErrorContains(t, err, "not found in the registry")
+}
+
+func TestNewPuller(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewPuller(Config{PublicKey: filepath.Join(t.TempDir(), "missing.pub")})
+	assert.ErrorContains(t, err, "no such file or directory")
+}
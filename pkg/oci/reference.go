@@ -0,0 +1,95 @@
+// Package oci pulls detection content bundles (policies, signatures, rules, ...) published
+// as artifacts in OCI registries, over the OCI distribution API.
+//
+// Artifacts are optionally verified with cosign signatures (key based, stored as the
+// sha256-<digest>.sig tag of the artifact repository), and can be watched for updates,
+// letting fleets version their detection content centrally.
+package oci
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	// Scheme prefixes the references of artifacts pulled from OCI registries (over https,
+	// or plain http with InsecureScheme).
+	Scheme         = "oci://"
+	InsecureScheme = "oci+http://"
+)
+
+// IsReference reports whether the source is an OCI reference.
+func IsReference(source string) bool {
+	return strings.HasPrefix(source, Scheme) || strings.HasPrefix(source, InsecureScheme)
+}
+
+// ValidateReference checks the syntax of an OCI reference, without pulling it.
+func ValidateReference(source string) error {
+	_, err := parseReference(source)
+	return err
+}
+
+type reference struct {
+	scheme     string // https or http
+	registry   string
+	repository string
+	reference  string // tag or digest
+	user       *url.Userinfo
+}
+
+// parseReference parses oci://[user:password@]registry/repository[:tag|@digest].
+func parseReference(source string) (reference, error) {
+	ref := reference{scheme: "https"}
+	rest := strings.TrimPrefix(source, Scheme)
+	if strings.HasPrefix(source, InsecureScheme) {
+		ref.scheme = "http"
+		rest = strings.TrimPrefix(source, InsecureScheme)
+	}
+
+	if at := strings.LastIndex(rest, "@"); at >= 0 && strings.Contains(rest[:at], "/") {
+		ref.reference = rest[at+1:]
+		rest = rest[:at]
+	}
+	u, err := url.Parse(ref.scheme + "://" + rest)
+	if err != nil || u.Host == "" || len(u.Path) < 2 {
+		return ref, errfmt.Errorf("invalid oci reference %q", Redact(source))
+	}
+	ref.registry = u.Host
+	ref.user = u.User
+	ref.repository = strings.TrimPrefix(u.Path, "/")
+
+	if ref.reference == "" {
+		ref.reference = "latest"
+		if colon := strings.LastIndex(ref.repository, ":"); colon >= 0 {
+			ref.reference = ref.repository[colon+1:]
+			ref.repository = ref.repository[:colon]
+		}
+	}
+
+	return ref, nil
+}
+
+// isDigest reports whether the reference is a digest (rather than a tag).
+func (r reference) isDigest() bool {
+	return strings.Contains(r.reference, ":")
+}
+
+// Redact returns the OCI reference without its credentials.
+func Redact(source string) string {
+	scheme := Scheme
+	if strings.HasPrefix(source, InsecureScheme) {
+		scheme = InsecureScheme
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(source, InsecureScheme), Scheme)
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 {
+		slash = len(rest)
+	}
+	if at := strings.LastIndex(rest[:slash], "@"); at >= 0 {
+		rest = rest[at+1:]
+	}
+
+	return scheme + rest
+}
@@ -180,7 +180,7 @@ func (ps *Policies) createNewEventsMapVersion(
 	ps.bpfInnerMaps[innerMapName] = newInnerMap
 
 	for id, ecfg := range eventsState {
-		eventConfigVal := make([]byte, 16)
+		eventConfigVal := make([]byte, 32)
 
 		// bitmap of policies that require this event to be submitted
 		binary.LittleEndian.PutUint64(eventConfigVal[0:8], ecfg.Submit)
@@ -193,6 +193,12 @@ func (ps *Policies) createNewEventsMapVersion(
 		}
 		binary.LittleEndian.PutUint64(eventConfigVal[8:16], paramTypes)
 
+		// bitmap of policies that require this event stack trace
+		binary.LittleEndian.PutUint64(eventConfigVal[16:24], ecfg.Stack)
+
+		// bitmap of policies that consume this event in userspace
+		binary.LittleEndian.PutUint64(eventConfigVal[24:32], ecfg.Consume)
+
 		err := newInnerMap.Update(unsafe.Pointer(&id), unsafe.Pointer(&eventConfigVal[0]))
 		if err != nil {
 			return errfmt.WrapError(err)
@@ -642,10 +648,10 @@ func (ps *Policies) computePoliciesConfig() *PoliciesConfig {
 		if p.PidNSFilter.Enabled() {
 			cfg.PidNsFilterEnabledScopes |= 1 << offset
 		}
-		if p.UTSFilter.Enabled() {
+		if p.UTSFilter.Enabled() && stringFilterInKernel(p.UTSFilter) {
 			cfg.UtsNsFilterEnabledScopes |= 1 << offset
 		}
-		if p.CommFilter.Enabled() {
+		if p.CommFilter.Enabled() && stringFilterInKernel(p.CommFilter) {
 			cfg.CommFilterEnabledScopes |= 1 << offset
 		}
 		if p.ContIDFilter.Enabled() {
@@ -683,10 +689,10 @@ func (ps *Policies) computePoliciesConfig() *PoliciesConfig {
 		if p.PidNSFilter.FilterOut() {
 			cfg.PidNsFilterOutScopes |= 1 << offset
 		}
-		if p.UTSFilter.FilterOut() {
+		if p.UTSFilter.FilterOut() && stringFilterInKernel(p.UTSFilter) {
 			cfg.UtsNsFilterOutScopes |= 1 << offset
 		}
-		if p.CommFilter.FilterOut() {
+		if p.CommFilter.FilterOut() && stringFilterInKernel(p.CommFilter) {
 			cfg.CommFilterOutScopes |= 1 << offset
 		}
 		if p.ContIDFilter.FilterOut() {
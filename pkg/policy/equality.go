@@ -140,15 +140,19 @@ func (ps *Policies) computeFilterEqualities(
 			fEqs.cgroupIdEqualities[uint64(cgroupIDs[0])] = eq
 		}
 
-		// UTSFilters
-		utsEqualities := p.UTSFilter.Equalities()
-		updateEqualities(fEqs.utsEqualities, utsEqualities.NotEqual, notEqual, policyID)
-		updateEqualities(fEqs.utsEqualities, utsEqualities.Equal, equal, policyID)
-
-		// CommFilters
-		commEqualities := p.CommFilter.Equalities()
-		updateEqualities(fEqs.commEqualities, commEqualities.NotEqual, notEqual, policyID)
-		updateEqualities(fEqs.commEqualities, commEqualities.Equal, equal, policyID)
+		// UTSFilters (the ones with wildcards are evaluated in userland)
+		if stringFilterInKernel(p.UTSFilter) {
+			utsEqualities := p.UTSFilter.Equalities()
+			updateEqualities(fEqs.utsEqualities, utsEqualities.NotEqual, notEqual, policyID)
+			updateEqualities(fEqs.utsEqualities, utsEqualities.Equal, equal, policyID)
+		}
+
+		// CommFilters (the ones with wildcards are evaluated in userland)
+		if stringFilterInKernel(p.CommFilter) {
+			commEqualities := p.CommFilter.Equalities()
+			updateEqualities(fEqs.commEqualities, commEqualities.NotEqual, notEqual, policyID)
+			updateEqualities(fEqs.commEqualities, commEqualities.Equal, equal, policyID)
+		}
 
 		// BinaryFilters
 		binaryEqualities := p.BinaryFilter.Equalities()
@@ -229,6 +229,32 @@ func (ps *Policies) MatchedNames(matched uint64) []string {
 	return names
 }
 
+// SameEvents returns true if both Policies have the same policies (by ID and name), each
+// selecting the same events, their filters possibly differing.
+func (ps *Policies) SameEvents(other *Policies) bool {
+	ps.rwmu.RLock()
+	defer ps.rwmu.RUnlock()
+	other.rwmu.RLock()
+	defer other.rwmu.RUnlock()
+
+	if len(ps.policiesMapByID) != len(other.policiesMapByID) {
+		return false
+	}
+	for id, p := range ps.policiesMapByID {
+		o, ok := other.policiesMapByID[id]
+		if !ok || o.Name != p.Name || len(o.EventsToTrace) != len(p.EventsToTrace) {
+			return false
+		}
+		for e := range p.EventsToTrace {
+			if _, ok := o.EventsToTrace[e]; !ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // Clone returns a deep copy of Policies.
 func (ps *Policies) Clone() *Policies {
 	if ps == nil {
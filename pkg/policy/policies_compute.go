@@ -129,6 +129,9 @@ func (ps *Policies) updateUserlandPolicies() {
 		if p.ArgFilter.Enabled() ||
 			p.RetFilter.Enabled() ||
 			p.ScopeFilter.Enabled() ||
+			p.UserlandScopeFilters() ||
+			p.ScheduleFilter.Enabled() ||
+			p.ContLabelFilter.Enabled() ||
 			(p.UIDFilter.Enabled() && ps.uidFilterableInUserland) ||
 			(p.PIDFilter.Enabled() && ps.pidFilterableInUserland) {
 			// add policy to userland list and set the respective bit
@@ -14,6 +14,32 @@ import (
 	"github.com/aquasecurity/tracee/pkg/filters/sets"
 )
 
+func TestPoliciesSameEvents(t *testing.T) {
+	t.Parallel()
+
+	newPolicies := func(name string, pid string, evts ...events.ID) *Policies {
+		p := NewPolicy()
+		p.Name = name
+		require.NoError(t, p.PIDFilter.Parse(pid))
+		for _, e := range evts {
+			p.EventsToTrace[e] = events.Core.GetDefinitionByID(e).GetName()
+		}
+		ps := NewPolicies()
+		require.NoError(t, ps.Add(p))
+		return ps
+	}
+
+	policies := newPolicies("p1", "=1", events.Openat, events.Execve)
+
+	// filters can differ
+	require.True(t, policies.SameEvents(newPolicies("p1", "=2", events.Execve, events.Openat)))
+
+	require.False(t, policies.SameEvents(newPolicies("p1", "=1", events.Openat)))
+	require.False(t, policies.SameEvents(newPolicies("p1", "=1", events.Openat, events.Close)))
+	require.False(t, policies.SameEvents(newPolicies("p2", "=1", events.Openat, events.Execve)))
+	require.False(t, policies.SameEvents(NewPolicies()))
+}
+
 func TestPoliciesClone(t *testing.T) {
 	t.Parallel()
 
@@ -53,6 +79,8 @@ func TestPoliciesClone(t *testing.T) {
 		filters.ScopeFilter{},
 		filters.ProcessTreeFilter{},
 		filters.BinaryFilter{},
+		filters.ScheduleFilter{},
+		filters.LabelFilter{},
 		sets.PrefixSet{},
 		sets.SuffixSet{},
 	)
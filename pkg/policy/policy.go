@@ -27,6 +27,8 @@ type Policy struct {
 	ScopeFilter       *filters.ScopeFilter
 	ProcessTreeFilter *filters.ProcessTreeFilter
 	BinaryFilter      *filters.BinaryFilter
+	ScheduleFilter    *filters.ScheduleFilter
+	ContLabelFilter   *filters.LabelFilter
 	Follow            bool
 }
 
@@ -53,6 +55,8 @@ func NewPolicy() *Policy {
 		ScopeFilter:       filters.NewScopeFilter(),
 		ProcessTreeFilter: filters.NewProcessTreeFilter(),
 		BinaryFilter:      filters.NewBinaryFilter(),
+		ScheduleFilter:    filters.NewScheduleFilter(),
+		ContLabelFilter:   filters.NewLabelFilter(),
 		Follow:            false,
 	}
 }
@@ -64,6 +68,18 @@ func (p *Policy) ContainerFilterEnabled() bool {
 		p.ContIDFilter.Enabled()
 }
 
+// UserlandScopeFilters returns true if the policy has process scope filters evaluated in userland:
+// the comm and uts filters with wildcards, as the eBPF maps only hold their exact values.
+func (p *Policy) UserlandScopeFilters() bool {
+	return !stringFilterInKernel(p.CommFilter) || !stringFilterInKernel(p.UTSFilter)
+}
+
+// stringFilterInKernel returns true if an enabled string scope filter is evaluated in the kernel
+// (it has no wildcards), or if it is disabled.
+func stringFilterInKernel(f *filters.StringFilter) bool {
+	return !f.Enabled() || !f.Wildcards()
+}
+
 func (p *Policy) Clone() *Policy {
 	if p == nil {
 		return nil
@@ -89,6 +105,8 @@ func (p *Policy) Clone() *Policy {
 	n.ScopeFilter = p.ScopeFilter.Clone()
 	n.ProcessTreeFilter = p.ProcessTreeFilter.Clone()
 	n.BinaryFilter = p.BinaryFilter.Clone()
+	n.ScheduleFilter = p.ScheduleFilter.Clone()
+	n.ContLabelFilter = p.ContLabelFilter.Clone()
 	n.Follow = p.Follow
 
 	return n
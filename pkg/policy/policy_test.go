@@ -28,6 +28,8 @@ func TestPolicyClone(t *testing.T) {
 		filters.ScopeFilter{},
 		filters.ProcessTreeFilter{},
 		filters.BinaryFilter{},
+		filters.ScheduleFilter{},
+		filters.LabelFilter{},
 		sets.PrefixSet{},
 		sets.SuffixSet{},
 	)
@@ -0,0 +1,154 @@
+// Package quota limits the events emitted by the policies: a policy exceeding its maximum
+// event rate, or its total quota, is switched to sampling, protecting the sinks from runaway
+// matches.
+package quota
+
+import (
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// DefaultSampleRate is the default sampling of a policy exceeding its quota: 1 in 100 events.
+const DefaultSampleRate = 100
+
+// Reason is the quota a policy exceeded.
+type Reason string
+
+const (
+	ReasonRate  Reason = "rate"  // maximum events per second
+	ReasonTotal Reason = "total" // maximum events since tracee started
+)
+
+// Config is the quota of a policy.
+type Config struct {
+	Policy     string // policy name (all the policies without a quota of their own if empty)
+	Rate       uint64 // maximum events per second (unlimited if 0)
+	Total      uint64 // maximum events in total (unlimited if 0)
+	SampleRate uint64 // 1 in SampleRate events is emitted once the quota is exceeded
+}
+
+// Exceeded describes a policy switched to sampling.
+type Exceeded struct {
+	Policy     string
+	Reason     Reason
+	Limit      uint64
+	SampleRate uint64
+}
+
+// state is the events accounting of a policy.
+type state struct {
+	cfg         Config
+	windowStart time.Time // start of the current one second window
+	windowCount uint64    // events of the policy in the current window
+	total       uint64    // events of the policy in total
+	sampling    Reason    // quota exceeded, if sampling
+	sampled     uint64    // events of the policy since it is sampling
+}
+
+// Quotas accounts the events emitted by the policies with a quota. It isn't safe for
+// concurrent use: it is fed by the sink pipeline stage.
+type Quotas struct {
+	configs  map[string]Config // by policy name
+	fallback *Config           // quota of the policies without one of their own
+	states   map[string]*state // by policy name
+}
+
+// New creates the policies quotas.
+func New(configs []Config) (*Quotas, error) {
+	q := &Quotas{
+		configs: make(map[string]Config),
+		states:  make(map[string]*state),
+	}
+
+	for _, cfg := range configs {
+		if cfg.Rate == 0 && cfg.Total == 0 {
+			return nil, errfmt.Errorf("policy %q quota has no rate nor total", cfg.Policy)
+		}
+		if cfg.SampleRate == 0 {
+			cfg.SampleRate = DefaultSampleRate
+		}
+		if cfg.Policy == "" {
+			if q.fallback != nil {
+				return nil, errfmt.Errorf("quota of all the policies given more than once")
+			}
+			fallback := cfg
+			q.fallback = &fallback
+			continue
+		}
+		if _, ok := q.configs[cfg.Policy]; ok {
+			return nil, errfmt.Errorf("policy %q quota given more than once", cfg.Policy)
+		}
+		q.configs[cfg.Policy] = cfg
+	}
+
+	return q, nil
+}
+
+// Allow accounts an event matched by the given policy at the given time. It returns whether the
+// event is emitted for the policy, and the quota exceeded if the policy was switched to sampling
+// by this event.
+func (q *Quotas) Allow(policy string, now time.Time) (bool, *Exceeded) {
+	s, ok := q.states[policy]
+	if !ok {
+		cfg, ok := q.configs[policy]
+		if !ok {
+			if q.fallback == nil {
+				return true, nil // policy without a quota
+			}
+			cfg = *q.fallback
+			cfg.Policy = policy
+		}
+		s = &state{cfg: cfg, windowStart: now}
+		q.states[policy] = s
+	}
+
+	if now.Sub(s.windowStart) >= time.Second {
+		// a policy sampled because of its rate is back to normal after a window within its rate
+		if s.sampling == ReasonRate && s.windowCount <= s.cfg.Rate {
+			s.sampling = ""
+		}
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	s.total++
+
+	if s.sampling == "" {
+		switch {
+		case s.cfg.Total > 0 && s.total > s.cfg.Total:
+			s.sampled = 0
+			return false, s.sample(ReasonTotal)
+		case s.cfg.Rate > 0 && s.windowCount > s.cfg.Rate:
+			s.sampled = 0
+			return false, s.sample(ReasonRate)
+		}
+		return true, nil
+	}
+
+	var exceeded *Exceeded
+	if s.sampling == ReasonRate && s.cfg.Total > 0 && s.total > s.cfg.Total {
+		// the total quota is exceeded while sampling because of the rate: sampled for good
+		exceeded = s.sample(ReasonTotal)
+	}
+	s.sampled++
+
+	return s.sampled%s.cfg.SampleRate == 0, exceeded
+}
+
+// sample switches the policy to sampling, because of the given quota.
+func (s *state) sample(reason Reason) *Exceeded {
+	s.sampling = reason
+
+	limit := s.cfg.Rate
+	if reason == ReasonTotal {
+		limit = s.cfg.Total
+	}
+
+	return &Exceeded{
+		Policy:     s.cfg.Policy,
+		Reason:     reason,
+		Limit:      limit,
+		SampleRate: s.cfg.SampleRate,
+	}
+}
@@ -0,0 +1,131 @@
+package quota
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotasRate(t *testing.T) {
+	t.Parallel()
+
+	q, err := New([]Config{{Policy: "noisy", Rate: 3, SampleRate: 2}})
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+
+	// within the rate
+	for i := 0; i < 3; i++ {
+		allowed, exceeded := q.Allow("noisy", start)
+		assert.True(t, allowed)
+		assert.Nil(t, exceeded)
+	}
+
+	// exceeding the rate switches the policy to sampling
+	allowed, exceeded := q.Allow("noisy", start.Add(100*time.Millisecond))
+	assert.False(t, allowed)
+	assert.Equal(t, &Exceeded{Policy: "noisy", Reason: ReasonRate, Limit: 3, SampleRate: 2}, exceeded)
+
+	var sampled []bool
+	for i := 0; i < 4; i++ {
+		allowed, exceeded := q.Allow("noisy", start.Add(200*time.Millisecond))
+		assert.Nil(t, exceeded)
+		sampled = append(sampled, allowed)
+	}
+	assert.Equal(t, []bool{false, true, false, true}, sampled)
+
+	// still sampling after a window over the rate, back to normal after a window within it
+	allowed, _ = q.Allow("noisy", start.Add(time.Second))
+	assert.False(t, allowed)
+	allowed, _ = q.Allow("noisy", start.Add(2*time.Second))
+	assert.True(t, allowed)
+
+	// policies without a quota
+	allowed, exceeded = q.Allow("other", start)
+	assert.True(t, allowed)
+	assert.Nil(t, exceeded)
+}
+
+func TestQuotasTotal(t *testing.T) {
+	t.Parallel()
+
+	q, err := New([]Config{{Rate: 2, Total: 5}}) // all the policies
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	at := func(i int) time.Time {
+		return start.Add(time.Duration(i) * time.Second)
+	}
+
+	// the rate is exceeded first
+	q.Allow("p1", at(0))
+	q.Allow("p1", at(0))
+	_, exceeded := q.Allow("p1", at(0))
+	require.NotNil(t, exceeded)
+	assert.Equal(t, ReasonRate, exceeded.Reason)
+
+	// other policies are accounted separately
+	allowed, exceeded := q.Allow("p2", at(0))
+	assert.True(t, allowed)
+	assert.Nil(t, exceeded)
+
+	// the total is exceeded while sampling because of the rate
+	q.Allow("p1", at(0))
+	q.Allow("p1", at(0))
+	_, exceeded = q.Allow("p1", at(0))
+	assert.Equal(t, &Exceeded{Policy: "p1", Reason: ReasonTotal, Limit: 5, SampleRate: DefaultSampleRate}, exceeded)
+
+	// sampled for good, even within the rate
+	for i := 1; i < 4; i++ {
+		allowed, exceeded := q.Allow("p1", at(i))
+		assert.False(t, allowed)
+		assert.Nil(t, exceeded)
+	}
+}
+
+func TestNewQuotas(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		configs       []Config
+		expectedError string
+	}{
+		{
+			name:          "no limit",
+			configs:       []Config{{Policy: "p1", SampleRate: 10}},
+			expectedError: `policy "p1" quota has no rate nor total`,
+		},
+		{
+			name:          "same policy twice",
+			configs:       []Config{{Policy: "p1", Rate: 1}, {Policy: "p1", Total: 1}},
+			expectedError: `policy "p1" quota given more than once`,
+		},
+		{
+			name:          "all the policies twice",
+			configs:       []Config{{Rate: 1}, {Total: 1}},
+			expectedError: "quota of all the policies given more than once",
+		},
+		{
+			name:    "policy and all the policies",
+			configs: []Config{{Policy: "p1", Rate: 1}, {Total: 1}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tc.configs)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
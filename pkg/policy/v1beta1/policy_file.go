@@ -1,6 +1,9 @@
 package v1beta1
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/errfmt"
 	"github.com/aquasecurity/tracee/pkg/events"
+	"github.com/aquasecurity/tracee/pkg/filters"
 	k8s "github.com/aquasecurity/tracee/pkg/k8s/apis/tracee.aquasec.com/v1beta1"
 )
 
@@ -56,6 +60,22 @@ func (p PolicyFile) GetRules() []k8s.Rule {
 }
 
 func (p PolicyFile) Validate() error {
+	if err := p.validateHeader(); err != nil {
+		return err
+	}
+
+	if err := p.validateDefaultActions(); err != nil {
+		return err
+	}
+
+	if err := p.validateScope(); err != nil {
+		return err
+	}
+
+	return p.validateRules()
+}
+
+func (p PolicyFile) validateHeader() error {
 	if err := validation.IsDNS1123Subdomain(p.GetName()); err != nil {
 		return errfmt.Errorf("policy name %s is invalid: %s", p.GetName(), err)
 	}
@@ -76,15 +96,7 @@ func (p PolicyFile) Validate() error {
 		return errfmt.Errorf("policy %s, rules cannot be empty", p.GetName())
 	}
 
-	if err := p.validateDefaultActions(); err != nil {
-		return err
-	}
-
-	if err := p.validateScope(); err != nil {
-		return err
-	}
-
-	return p.validateRules()
+	return nil
 }
 
 func (p PolicyFile) validateDefaultActions() error {
@@ -109,6 +121,25 @@ func validateActions(policyName string, actions []string) error {
 }
 
 func (p PolicyFile) validateScope() error {
+	for _, scope := range p.GetScope() {
+		scope = strings.ReplaceAll(scope, " ", "")
+
+		if scope == "global" && len(p.GetScope()) > 1 {
+			return errfmt.Errorf("policy %s, global scope must be unique", p.GetName())
+		}
+
+		if scope == "global" {
+			return nil
+		}
+
+		if err := p.validateScopeEntry(scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p PolicyFile) validateScopeEntry(scope string) error {
 	scopes := []string{
 		"uid",
 		"pid",
@@ -120,37 +151,26 @@ func (p PolicyFile) validateScope() error {
 		"not-container",
 		"tree",
 		"exec", "executable", "bin", "binary",
+		"time",
+		"weekday",
 		"follow",
 	}
 
-	for _, scope := range p.GetScope() {
-		scope = strings.ReplaceAll(scope, " ", "")
-
-		if scope == "global" && len(p.GetScope()) > 1 {
-			return errfmt.Errorf("policy %s, global scope must be unique", p.GetName())
-		}
+	scope, err := parseScope(p.GetName(), scope)
+	if err != nil {
+		return err
+	}
 
-		if scope == "global" {
+	for _, s := range scopes {
+		if scope == s {
 			return nil
 		}
-
-		scope, err := parseScope(p.GetName(), scope)
-		if err != nil {
-			return err
-		}
-
-		var found bool
-		for _, s := range scopes {
-			if scope == s {
-				found = true
-			}
-		}
-
-		if !found {
-			return errfmt.Errorf("policy %s, scope %s is not valid", p.GetName(), scope)
-		}
 	}
-	return nil
+	if strings.HasPrefix(scope, filters.LabelScopePrefix) {
+		return nil
+	}
+
+	return errfmt.Errorf("policy %s, scope %s is not valid", p.GetName(), scope)
 }
 
 func parseScope(policyName, scope string) (string, error) {
@@ -175,64 +195,72 @@ func (p PolicyFile) validateRules() error {
 	evts := make(map[string]bool)
 
 	for _, r := range p.GetRules() {
-		// Currently, an event can only be used once in the policy. Support for using the same
-		// event, multiple times, with different filters, shall be implemented in the future.
-		if _, ok := evts[r.Event]; ok {
-			return errfmt.Errorf("policy %s, event %s is duplicated", p.GetName(), r.Event)
+		if err := p.validateRule(r, evts); err != nil {
+			return err
 		}
+	}
 
-		evts[r.Event] = true
+	return nil
+}
 
-		err := validateEvent(p.GetName(), r.Event)
-		if err != nil {
-			return err
-		}
+func (p PolicyFile) validateRule(r k8s.Rule, evts map[string]bool) error {
+	// Currently, an event can only be used once in the policy. Support for using the same
+	// event, multiple times, with different filters, shall be implemented in the future.
+	if _, ok := evts[r.Event]; ok {
+		return errfmt.Errorf("policy %s, event %s is duplicated", p.GetName(), r.Event)
+	}
 
-		err = validateActions(p.GetName(), r.Actions)
-		if err != nil {
-			return err
-		}
+	evts[r.Event] = true
 
-		for _, f := range r.Filters {
-			operatorIdx := strings.IndexAny(f, "=!<>")
+	err := validateEvent(p.GetName(), r.Event)
+	if err != nil {
+		return err
+	}
 
-			if operatorIdx == -1 {
-				return errfmt.Errorf("policy %s, invalid filter operator: %s", p.GetName(), f)
-			}
+	err = validateActions(p.GetName(), r.Actions)
+	if err != nil {
+		return err
+	}
 
-			// args
-			if strings.HasPrefix(f, "args") {
-				s := strings.Split(f, ".")
-				if len(s) == 1 {
-					return errfmt.Errorf("policy %s, arg name can't be empty", p.GetName())
-				}
+	for _, f := range r.Filters {
+		operatorIdx := strings.IndexAny(f, "=!<>")
 
-				err := validateEventArg(p.GetName(), r.Event, s[1])
-				if err != nil {
-					return err
-				}
+		if operatorIdx == -1 {
+			return errfmt.Errorf("policy %s, invalid filter operator: %s", p.GetName(), f)
+		}
 
-				continue
+		// args
+		if strings.HasPrefix(f, "args") {
+			s := strings.Split(f, ".")
+			if len(s) == 1 {
+				return errfmt.Errorf("policy %s, arg name can't be empty", p.GetName())
 			}
 
-			// retval
-			if strings.HasPrefix(f, "retval") {
-				s := strings.Split(f, "=")
-				if len(s) == 1 {
-					return errfmt.Errorf("policy %s, retval must have value: %s", p.GetName(), f)
-				}
+			err := validateEventArg(p.GetName(), r.Event, s[1])
+			if err != nil {
+				return err
+			}
 
-				if s[1] == "" {
-					return errfmt.Errorf("policy %s, retval cannot be empty", p.GetName())
-				}
+			continue
+		}
 
-				_, err := strconv.Atoi(s[1])
-				if err != nil {
-					return errfmt.Errorf("policy %s, retval must be an integer: %s", p.GetName(), s[1])
-				}
+		// retval
+		if strings.HasPrefix(f, "retval") {
+			s := strings.Split(f, "=")
+			if len(s) == 1 {
+				return errfmt.Errorf("policy %s, retval must have value: %s", p.GetName(), f)
+			}
 
-				continue
+			if s[1] == "" {
+				return errfmt.Errorf("policy %s, retval cannot be empty", p.GetName())
 			}
+
+			_, err := strconv.Atoi(s[1])
+			if err != nil {
+				return errfmt.Errorf("policy %s, retval must be an integer: %s", p.GetName(), s[1])
+			}
+
+			continue
 		}
 	}
 
@@ -294,63 +322,107 @@ func PoliciesFromPaths(paths []string) ([]k8s.PolicyInterface, error) {
 	policies := make([]k8s.PolicyInterface, 0)
 
 	for _, path := range paths {
-		if path == "" {
-			return nil, errfmt.Errorf("policy path cannot be empty")
-		}
-
-		path, err := filepath.Abs(path)
+		files, err := policyFilesFromPath(path)
 		if err != nil {
 			return nil, err
 		}
 
-		fileInfo, err := os.Stat(path)
-		if err != nil {
-			return nil, err
-		}
+		policyNames := make(map[string]bool)
 
-		if !fileInfo.IsDir() {
-			p, err := getPoliciesFromFile(path)
+		for _, file := range files {
+			policy, err := getPoliciesFromFile(file)
 			if err != nil {
 				return nil, err
 			}
-			policies = append(policies, p)
 
-			continue
+			// validate policy name is unique
+			if _, ok := policyNames[policy.GetName()]; ok {
+				return nil, errfmt.Errorf("policy %s already exist", policy.GetName())
+			}
+
+			policyNames[policy.GetName()] = true
+
+			policies = append(policies, policy)
 		}
+	}
 
-		files, err := os.ReadDir(path)
+	return policies, nil
+}
+
+// PoliciesFromYAML returns a slice of policies from a YAML stream, holding a policy per
+// document
+func PoliciesFromYAML(data []byte) ([]k8s.PolicyInterface, error) {
+	policies := make([]k8s.PolicyInterface, 0)
+	policyNames := make(map[string]bool)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var policy PolicyFile
+
+		err := decoder.Decode(&policy)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+
+		err = policy.Validate()
 		if err != nil {
 			return nil, err
 		}
 
-		policyNames := make(map[string]bool)
+		// validate policy name is unique
+		if _, ok := policyNames[policy.GetName()]; ok {
+			return nil, errfmt.Errorf("policy %s already exist", policy.GetName())
+		}
 
-		for _, file := range files {
-			if file.IsDir() {
-				continue
-			}
+		policyNames[policy.GetName()] = true
+
+		policies = append(policies, policy)
+	}
 
-			// TODO: support json
-			if strings.HasSuffix(file.Name(), ".yaml") ||
-				strings.HasSuffix(file.Name(), ".yml") {
-				policy, err := getPoliciesFromFile(filepath.Join(path, file.Name()))
-				if err != nil {
-					return nil, err
-				}
+	return policies, nil
+}
 
-				// validate policy name is unique
-				if _, ok := policyNames[policy.GetName()]; ok {
-					return nil, errfmt.Errorf("policy %s already exist", policy.GetName())
-				}
+// policyFilesFromPath returns the policy file paths of a given file or directory path.
+func policyFilesFromPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, errfmt.Errorf("policy path cannot be empty")
+	}
 
-				policyNames[policy.GetName()] = true
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
 
-				policies = append(policies, policy)
-			}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fileInfo.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		// TODO: support json
+		if strings.HasSuffix(entry.Name(), ".yaml") ||
+			strings.HasSuffix(entry.Name(), ".yml") {
+			files = append(files, filepath.Join(path, entry.Name()))
 		}
 	}
 
-	return policies, nil
+	return files, nil
 }
 
 func getPoliciesFromFile(filePath string) (PolicyFile, error) {
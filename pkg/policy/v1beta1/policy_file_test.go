@@ -506,3 +506,69 @@ func TestPolicyValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestPoliciesFromYAML(t *testing.T) {
+	t.Parallel()
+
+	policyYAML := func(name string) string {
+		return `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: ` + name + `
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+`
+	}
+
+	tests := []struct {
+		testName      string
+		data          string
+		expectedNames []string
+		expectedError string
+	}{
+		{
+			testName:      "empty",
+			data:          "",
+			expectedNames: []string{},
+		},
+		{
+			testName:      "policies",
+			data:          policyYAML("first") + "---\n" + policyYAML("second"),
+			expectedNames: []string{"first", "second"},
+		},
+		{
+			testName:      "duplicate policy",
+			data:          policyYAML("first") + "---\n" + policyYAML("first"),
+			expectedError: "policy first already exist",
+		},
+		{
+			testName:      "invalid policy",
+			data:          "kind: Policy\n",
+			expectedError: "is invalid",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.testName, func(t *testing.T) {
+			t.Parallel()
+
+			policies, err := PoliciesFromYAML([]byte(test.data))
+			if test.expectedError != "" {
+				assert.ErrorContains(t, err, test.expectedError)
+				return
+			}
+			assert.NilError(t, err)
+
+			names := make([]string, 0, len(policies))
+			for _, p := range policies {
+				names = append(names, p.GetName())
+			}
+			assert.DeepEqual(t, test.expectedNames, names)
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package v1beta1
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yaml3 "gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	k8s "github.com/aquasecurity/tracee/pkg/k8s/apis/tracee.aquasec.com/v1beta1"
+)
+
+// FileError is a policy validation error with a reference to the file, and to the line and
+// column within the file, where the offending entry was found. Line and Column are 0 when
+// unknown.
+type FileError struct {
+	Path   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e FileError) Error() string {
+	switch {
+	case e.Line == 0:
+		return fmt.Sprintf("%s: %v", e.Path, e.Err)
+	case e.Column == 0:
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %v", e.Path, e.Line, e.Column, e.Err)
+}
+
+func (e FileError) Unwrap() error {
+	return e.Err
+}
+
+// fileError returns a validation error of the given file, referencing the given node (if any).
+func fileError(path string, node *yaml3.Node, err error) FileError {
+	fileErr := FileError{Path: path, Err: err}
+	if node != nil {
+		fileErr.Line, fileErr.Column = node.Line, node.Column
+	}
+
+	return fileErr
+}
+
+// yamlLineRegex matches the line reference in errors produced by the yaml parser, which has
+// no node to reference.
+var yamlLineRegex = regexp.MustCompile(`line (\d+)`)
+
+// ValidatePaths validates all policy files found in the given paths. Unlike
+// PoliciesFromPaths, it does not stop at the first error: every file, scope and rule is
+// checked and all errors are returned with a reference to the line they came from. The
+// policies that passed validation are returned as well.
+func ValidatePaths(paths []string) ([]k8s.PolicyInterface, []FileError) {
+	policies := make([]k8s.PolicyInterface, 0)
+	fileErrs := make([]FileError, 0)
+
+	for _, path := range paths {
+		files, err := policyFilesFromPath(path)
+		if err != nil {
+			fileErrs = append(fileErrs, FileError{Path: path, Err: err})
+			continue
+		}
+
+		// as in PoliciesFromPaths, policy names must be unique within a path
+		policyFiles := make(map[string]string) // policy name -> file path
+
+		for _, file := range files {
+			p, errs := validateFile(file)
+			if len(errs) > 0 {
+				fileErrs = append(fileErrs, errs...)
+				continue
+			}
+
+			if other, ok := policyFiles[p.GetName()]; ok {
+				err := fmt.Errorf("policy %s already exist (%s)", p.GetName(), other)
+				fileErrs = append(fileErrs, fileError(file, p.nodes.name, err))
+				continue
+			}
+			policyFiles[p.GetName()] = file
+
+			policies = append(policies, p.PolicyFile)
+		}
+	}
+
+	return policies, fileErrs
+}
+
+type locatedPolicyFile struct {
+	PolicyFile
+	nodes policyNodes
+}
+
+// validateFile parses and validates a single policy file, collecting all errors found.
+func validateFile(path string) (locatedPolicyFile, []FileError) {
+	var p locatedPolicyFile
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return p, []FileError{{Path: path, Err: err}}
+	}
+
+	// the policy is decoded as for a real run, and its nodes locate the errors
+	var doc yaml3.Node
+	err = yaml3.Unmarshal(data, &doc)
+	if err == nil {
+		err = yaml.Unmarshal(data, &p.PolicyFile)
+	}
+	if err != nil {
+		fileErr := FileError{Path: path, Err: err}
+		if m := yamlLineRegex.FindStringSubmatch(err.Error()); m != nil {
+			fileErr.Line, _ = strconv.Atoi(m[1])
+		}
+		return p, []FileError{fileErr}
+	}
+	p.nodes = newPolicyNodes(&doc)
+
+	var errs []FileError
+
+	if err := p.validateHeader(); err != nil {
+		return p, []FileError{fileError(path, p.nodes.header(p.PolicyFile), err)}
+	}
+
+	if err := p.validateDefaultActions(); err != nil {
+		errs = append(errs, fileError(path, p.nodes.defaultActions, err))
+	}
+
+	if err := p.validateScope(); err != nil {
+		node := p.nodes.scope
+		for i, scope := range p.GetScope() {
+			scope = strings.ReplaceAll(scope, " ", "")
+			if scope == "global" || p.validateScopeEntry(scope) != nil {
+				node = p.nodes.item(p.nodes.scopes, i, node)
+				break
+			}
+		}
+		errs = append(errs, fileError(path, node, err))
+	}
+
+	evts := make(map[string]bool)
+	for i, r := range p.GetRules() {
+		if err := p.validateRule(r, evts); err != nil {
+			errs = append(errs, fileError(path, p.nodes.item(p.nodes.rules, i, p.nodes.rulesKey), err))
+		}
+	}
+
+	return p, errs
+}
+
+// policyNodes are the nodes of the entries of a policy file, referenced by the validation
+// errors (nil if missing).
+type policyNodes struct {
+	apiVersion     *yaml3.Node
+	kind           *yaml3.Node
+	name           *yaml3.Node
+	spec           *yaml3.Node
+	scope          *yaml3.Node
+	defaultActions *yaml3.Node
+	rulesKey       *yaml3.Node
+	scopes         []*yaml3.Node // the scope entries, in order
+	rules          []*yaml3.Node // the event of each rule (or the rule if missing), in order
+}
+
+func newPolicyNodes(doc *yaml3.Node) policyNodes {
+	var n policyNodes
+	if doc.Kind != yaml3.DocumentNode || len(doc.Content) == 0 {
+		return n
+	}
+	root := doc.Content[0]
+
+	n.apiVersion = mappingValue(root, "apiVersion")
+	n.kind = mappingValue(root, "kind")
+	n.name = mappingValue(mappingValue(root, "metadata"), "name")
+	n.spec = mappingKey(root, "spec")
+
+	spec := mappingValue(root, "spec")
+	n.scope = mappingKey(spec, "scope")
+	n.defaultActions = mappingKey(spec, "defaultActions")
+	n.rulesKey = mappingKey(spec, "rules")
+	if scopes := mappingValue(spec, "scope"); scopes != nil && scopes.Kind == yaml3.SequenceNode {
+		n.scopes = scopes.Content
+	}
+	if rules := mappingValue(spec, "rules"); rules != nil && rules.Kind == yaml3.SequenceNode {
+		for _, rule := range rules.Content {
+			if event := mappingValue(rule, "event"); event != nil {
+				rule = event
+			}
+			n.rules = append(n.rules, rule)
+		}
+	}
+
+	return n
+}
+
+// header returns the node of the first header entry failing validation, in the order
+// validateHeader checks them.
+func (n policyNodes) header(p PolicyFile) *yaml3.Node {
+	switch {
+	case len(validation.IsDNS1123Subdomain(p.GetName())) > 0:
+		return n.name
+	case p.APIVersion != "tracee.aquasec.com/v1beta1":
+		return n.apiVersion
+	case p.Kind != "Policy":
+		return n.kind
+	case len(p.GetScope()) == 0:
+		return n.orSpec(n.scope)
+	case len(p.GetRules()) == 0:
+		return n.orSpec(n.rulesKey)
+	}
+
+	return nil
+}
+
+// orSpec returns the given node, or the spec node if missing.
+func (n policyNodes) orSpec(node *yaml3.Node) *yaml3.Node {
+	if node == nil {
+		return n.spec
+	}
+
+	return node
+}
+
+// item returns the i-th node of the given nodes, or the fallback node if missing.
+func (n policyNodes) item(nodes []*yaml3.Node, i int, fallback *yaml3.Node) *yaml3.Node {
+	if i < len(nodes) {
+		return nodes[i]
+	}
+
+	return fallback
+}
+
+// mappingKey returns the key node of the given key of a mapping node, or nil if missing.
+func mappingKey(node *yaml3.Node, key string) *yaml3.Node {
+	if node == nil || node.Kind != yaml3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+
+	return nil
+}
+
+// mappingValue returns the value node of the given key of a mapping node, or nil if missing.
+func mappingValue(node *yaml3.Node, key string) *yaml3.Node {
+	if node == nil || node.Kind != yaml3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,216 @@
+package v1beta1
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePaths(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"valid.yaml": `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: valid
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+`,
+		"invalid-rules.yaml": `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: invalid-rules
+spec:
+  scope:
+    - comm=bash
+  rules:
+    - event: openat
+    - event: not_an_event
+    - event: execve
+      filters:
+        - args.not_an_arg=1
+`,
+		"invalid-scope.yaml": `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: invalid-scope
+spec:
+  scope:
+    - comm=bash
+    - invalid=1
+  rules:
+    - event: openat
+`,
+		"invalid-kind.yaml": `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policies
+metadata:
+  name: invalid-kind
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+`,
+		"invalid-yaml.yaml": `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+	name: invalid-yaml
+`,
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	policies, errs := ValidatePaths([]string{dir})
+
+	require.Len(t, policies, 1)
+	assert.Equal(t, "valid", policies[0].GetName())
+
+	lines := make(map[string][]int)
+	for _, err := range errs {
+		lines[filepath.Base(err.Path)] = append(lines[filepath.Base(err.Path)], err.Line)
+	}
+
+	assert.Equal(t, map[string][]int{
+		"invalid-rules.yaml": {10, 11},
+		"invalid-scope.yaml": {8},
+		"invalid-kind.yaml":  {2},
+		"invalid-yaml.yaml":  {4},
+	}, lines)
+}
+
+func TestValidatePathsDuplicatedName(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	policy := `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: duplicated
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yaml"), []byte(policy), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(policy), 0644))
+
+	policies, errs := ValidatePaths([]string{dir})
+
+	require.Len(t, policies, 1)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "b.yaml", filepath.Base(errs[0].Path))
+	assert.Equal(t, 4, errs[0].Line)
+}
+
+func TestValidatePathsSameNameAcrossPaths(t *testing.T) {
+	t.Parallel()
+
+	policy := `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: same
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+`
+	dir1, dir2 := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir1, "a.yaml"), []byte(policy), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir2, "a.yaml"), []byte(policy), 0644))
+
+	// validation must agree with the policies loaded for a real run
+	loaded, err := PoliciesFromPaths([]string{dir1, dir2})
+	require.NoError(t, err)
+
+	policies, errs := ValidatePaths([]string{dir1, dir2})
+	assert.Empty(t, errs)
+	assert.Len(t, policies, len(loaded))
+}
+
+func TestValidatePathsPositions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   string
+		expected []string // line:column of the errors
+	}{
+		{
+			name: "flow style",
+			policy: `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata: {name: flow-style}
+spec:
+  scope: [comm=bash, invalid=1]
+  rules: [{event: openat}, {event: not_an_event}]
+`,
+			expected: []string{"5:22", "6:36"},
+		},
+		{
+			// the annotation has the same key and value as the invalid rule
+			name: "repeated key and value",
+			policy: `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: repeated
+  annotations:
+    event: not_an_event
+spec:
+  scope:
+    - global
+  rules:
+    - event: openat
+    - event: not_an_event
+    - event: openat
+`,
+			expected: []string{"12:14", "13:14"},
+		},
+		{
+			name: "missing rules",
+			policy: `apiVersion: tracee.aquasec.com/v1beta1
+kind: Policy
+metadata:
+  name: missing-rules
+spec:
+  scope:
+    - global
+`,
+			expected: []string{"5:1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(tc.policy), 0644))
+
+			_, errs := ValidatePaths([]string{dir})
+
+			positions := []string{}
+			for _, err := range errs {
+				positions = append(positions, fmt.Sprintf("%d:%d", err.Line, err.Column))
+			}
+			assert.Equal(t, tc.expected, positions)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Package pubsub implements a minimal Google Cloud Pub/Sub publisher, with the REST API: the
+// messages are published in batches, authenticated with the application default credentials.
+package pubsub
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// MaxMessages is the maximum number of messages of a publish request.
+	MaxMessages = 1000
+
+	defaultEndpoint = "https://pubsub.googleapis.com"
+	scope           = "https://www.googleapis.com/auth/pubsub"
+)
+
+var topicRegexp = regexp.MustCompile(`^projects/[^/]+/topics/[^/]+$`)
+
+// Config is the configuration of a client.
+type Config struct {
+	Topic string // projects/<project>/topics/<topic>
+	// Endpoint is the API endpoint, https://pubsub.googleapis.com if empty (a regional endpoint,
+	// e.g. https://us-east1-pubsub.googleapis.com, keeps the ordered messages in a region). An
+	// http endpoint, or PUBSUB_EMULATOR_HOST, is an emulator, the requests not authenticated.
+	Endpoint string
+	Timeout  time.Duration
+}
+
+// Message is a message published to a topic. The messages of an ordering key are delivered in
+// order to the subscriptions with message ordering enabled.
+type Message struct {
+	Data        []byte            `json:"data"` // base64 encoded
+	OrderingKey string            `json:"orderingKey,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// Client publishes messages to a topic. It is safe for concurrent use.
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient returns a client of a topic. Out of an emulator, the requests are authenticated
+// with the application default credentials: the GOOGLE_APPLICATION_CREDENTIALS file, the gcloud
+// credentials, or the service account of the GCE instance or GKE workload identity.
+func NewClient(ctx context.Context, config Config) (*Client, error) {
+	if !topicRegexp.MatchString(config.Topic) {
+		return nil, errfmt.Errorf("invalid pubsub topic %q, must be projects/<project>/topics/<topic>", config.Topic)
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = defaultEndpoint
+		if emulator := os.Getenv("PUBSUB_EMULATOR_HOST"); emulator != "" {
+			config.Endpoint = "http://" + emulator
+		}
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	client := &http.Client{}
+	if !strings.HasPrefix(config.Endpoint, "http://") {
+		var err error
+		client, err = google.DefaultClient(ctx, scope)
+		if err != nil {
+			return nil, errfmt.Errorf("finding google application default credentials: %v", err)
+		}
+	}
+	client.Timeout = config.Timeout
+
+	return &Client{url: config.Endpoint + "/v1/" + config.Topic + ":publish", client: client}, nil
+}
+
+// Publish publishes messages (at most MaxMessages), and returns their ids.
+func (c *Client) Publish(ctx context.Context, messages []Message) ([]string, error) {
+	if len(messages) > MaxMessages {
+		return nil, errfmt.Errorf("too many pubsub messages: %d (at most %d)", len(messages), MaxMessages)
+	}
+
+	body, err := json.Marshal(struct {
+		Messages []Message `json:"messages"`
+	}{messages})
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Status  string `json:"status"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(respBody, &apiErr)
+		return nil, errfmt.Errorf("pubsub http status %d: %s %s", resp.StatusCode, apiErr.Error.Status, apiErr.Error.Message)
+	}
+
+	var response struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return response.MessageIDs, nil
+}
+
+// EventMessage returns the message of an event: the event as JSON, with its name, container id
+// and host name attributes (for the subscriptions to filter the messages).
+func EventMessage(event *trace.Event, orderingKey string) (Message, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return Message{}, errfmt.WrapError(err)
+	}
+
+	attributes := map[string]string{
+		"eventName": event.EventName,
+		"hostName":  event.HostName,
+	}
+	if event.Container.ID != "" {
+		attributes["containerId"] = event.Container.ID
+	}
+
+	return Message{Data: data, OrderingKey: orderingKey, Attributes: attributes}, nil
+}
@@ -0,0 +1,82 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	var published []Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/projects/tracee/topics/missing:publish" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":{"code":404,"message":"Resource not found (resource=missing).","status":"NOT_FOUND"}}`)
+			return
+		}
+		assert.Equal(t, "/v1/projects/tracee/topics/events:publish", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Empty(t, r.Header.Get("Authorization")) // emulator
+
+		var request struct {
+			Messages []Message `json:"messages"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		published = append(published, request.Messages...)
+
+		response := struct {
+			MessageIDs []string `json:"messageIds"`
+		}{}
+		for i := range request.Messages {
+			response.MessageIDs = append(response.MessageIDs, fmt.Sprint(len(published)-len(request.Messages)+i+1))
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	defer server.Close()
+
+	_, err := NewClient(context.Background(), Config{Topic: "tracee/events", Endpoint: server.URL})
+	assert.ErrorContains(t, err, `invalid pubsub topic "tracee/events"`)
+
+	client, err := NewClient(context.Background(), Config{Topic: "projects/tracee/topics/events", Endpoint: server.URL})
+	require.NoError(t, err)
+
+	var messages []Message
+	for _, event := range []trace.Event{
+		{EventName: "security_file_open", HostName: "node-1", Container: trace.Container{ID: "abc"}},
+		{EventName: "sched_process_exec", HostName: "node-1"},
+	} {
+		event := event
+		message, err := EventMessage(&event, event.Container.ID)
+		require.NoError(t, err)
+		messages = append(messages, message)
+	}
+
+	ids, err := client.Publish(context.Background(), messages)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, ids)
+
+	require.Len(t, published, 2)
+	assert.Equal(t, "abc", published[0].OrderingKey)
+	assert.Equal(t, map[string]string{"eventName": "security_file_open", "hostName": "node-1", "containerId": "abc"}, published[0].Attributes)
+	assert.Contains(t, string(published[0].Data), `"eventName":"security_file_open"`)
+	assert.Empty(t, published[1].OrderingKey)
+	assert.Equal(t, map[string]string{"eventName": "sched_process_exec", "hostName": "node-1"}, published[1].Attributes)
+
+	_, err = client.Publish(context.Background(), make([]Message, MaxMessages+1))
+	assert.ErrorContains(t, err, "too many pubsub messages")
+
+	client, err = NewClient(context.Background(), Config{Topic: "projects/tracee/topics/missing", Endpoint: server.URL})
+	require.NoError(t, err)
+	_, err = client.Publish(context.Background(), messages)
+	assert.ErrorContains(t, err, "pubsub http status 404: NOT_FOUND Resource not found")
+}
@@ -0,0 +1,79 @@
+// Package query evaluates jq expressions, with gojq, to select and transform the events printed by
+// tracee (--output-query) without piping them to an external jq.
+//
+// The queries are evaluated on the JSON values decoded with json.Decoder.UseNumber, so that the
+// numbers keep the precision of the 64 bits integers (e.g. the timestamps): objects
+// (map[string]any), arrays ([]any), strings, numbers (json.Number), booleans and nil. The results
+// are made of the same types, but for the numbers (int, float64 or *big.Int).
+//
+// The whole jq language is supported, but for the functions reading the inputs (input and inputs
+// fail), the environment ($ENV and env are empty) and the modules (import and include fail).
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/itchyny/gojq"
+)
+
+// Query is a compiled query. It is safe for concurrent use.
+type Query struct {
+	expression string
+	code       *gojq.Code
+}
+
+// Parse parses and compiles a jq expression.
+func Parse(expression string) (*Query, error) {
+	parsed, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	code, err := gojq.Compile(parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{expression: expression, code: code}, nil
+}
+
+// String returns the expression of the query.
+func (q *Query) String() string {
+	return q.expression
+}
+
+// Run evaluates the query on the given value, calling emit with every result, in order. The
+// evaluation stops at halt, and at the first error, of the query (e.g. error(...)) or of emit.
+func (q *Query) Run(input any, emit func(result any) error) error {
+	iter := q.code.Run(input)
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := result.(error); ok {
+			var halt *gojq.HaltError
+			if errors.As(err, &halt) && halt.Value() == nil {
+				return nil
+			}
+			return err
+		}
+		if err := emit(result); err != nil {
+			return err
+		}
+	}
+}
+
+// Decode decodes JSON data into the value a query is evaluated on.
+func Decode(data []byte) (any, error) {
+	var value any
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
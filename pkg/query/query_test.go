@@ -0,0 +1,169 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const event = `{
+	"timestamp": 1712345678901234567,
+	"processId": 42,
+	"processName": "curl",
+	"eventName": "security_file_open",
+	"container": {"id": "0123456789ab", "name": "nginx"},
+	"matchedPolicies": ["files", "containers"],
+	"args": [
+		{"name": "pathname", "type": "const char*", "value": "/etc/shadow"},
+		{"name": "flags", "type": "int", "value": 0}
+	]
+}`
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expression string
+		expected   []string // JSON of the results
+	}{
+		{`.`, nil}, // the event itself, checked apart
+		{`.eventName`, []string{`"security_file_open"`}},
+		{`.container.name`, []string{`"nginx"`}},
+		{`."processName"`, []string{`"curl"`}},
+		{`.["processId"]`, []string{`42`}},
+		{`.missing.field`, []string{`null`}},
+		{`.timestamp`, []string{`1712345678901234567`}},
+		{`.matchedPolicies[0], .matchedPolicies[-1], .matchedPolicies[5]`, []string{`"files"`, `"containers"`, `null`}},
+		{`.matchedPolicies[]`, []string{`"files"`, `"containers"`}},
+		{`.args[] | select(.name == "pathname") | .value`, []string{`"/etc/shadow"`}},
+		{`{name: .eventName, pid: .processId, processName}`, []string{`{"name":"security_file_open","pid":42,"processName":"curl"}`}},
+		{`{(.container.name): .processId}`, []string{`{"nginx":42}`}},
+		{`{a: (1, 2)}`, []string{`{"a":1}`, `{"a":2}`}},
+		{`[.args[].name]`, []string{`["pathname","flags"]`}},
+		{`.args | map(.name) | join(",")`, []string{`"pathname,flags"`}},
+		{`select(.processId > 40 and .processName != "bash") | .processId`, []string{`42`}},
+		{`select(.processId < 40 or .container.name == "redis")`, []string{}},
+		{`select(.timestamp == 1712345678901234567) | 1`, []string{`1`}}, // exact 64 bits integers
+		{`select(.timestamp == 1712345678901234568) | 1`, []string{}},
+		{`.missing // "default"`, []string{`"default"`}},
+		{`.processName // "default"`, []string{`"curl"`}},
+		{`.processId + 1, .processId - 50, .processName + "-" + .eventName`, []string{`43`, `-8`, `"curl-security_file_open"`}},
+		{`.args | length`, []string{`2`}},
+		{`.container | keys`, []string{`["id","name"]`}},
+		{`has("args"), (.args | has(5))`, []string{`true`, `false`}},
+		{`.eventName | startswith("security_"), endswith("open"), contains("file"), test("^sec.*_open$")`, []string{`true`, `true`, `true`, `true`}},
+		{`.processId | tostring`, []string{`"42"`}},
+		{`"42" | tonumber`, []string{`42`}},
+		{`.processName | ascii_upcase`, []string{`"CURL"`}},
+		{`.args[1].value | type`, []string{`"number"`}},
+		{`.processName | not`, []string{`false`}},
+		{`.processName.field?`, []string{}},
+		{`[..|.value? // empty]`, []string{`["/etc/shadow",0]`}},
+		{`[.args[] | .value] | .[0]`, []string{`"/etc/shadow"`}},
+		{`.matchedPolicies == ["files", "containers"]`, []string{`true`}},
+		{`[null, false, 1, "a", [], {}] | map(. < 1)`, []string{`[true,true,false,false,false,false]`}},
+		{`-1, empty, []`, []string{`-1`, `[]`}},
+		{`1, halt, 2`, []string{`1`}},
+		{`[.args[] | {(.name): .value}] | add`, []string{`{"flags":0,"pathname":"/etc/shadow"}`}},
+		{`.matchedPolicies | sort | first`, []string{`"containers"`}},
+		{`.timestamp | . / 1000000000 | floor | todate`, []string{`"2024-04-05T19:34:38Z"`}},
+		{`env | length`, []string{`0`}},
+	}
+
+	input, err := Decode([]byte(event))
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.expression, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := Parse(tc.expression)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expression, q.String())
+
+			results := []string{}
+			err = q.Run(input, func(result any) error {
+				if tc.expected == nil {
+					assert.Equal(t, input, result)
+					return nil
+				}
+				data, err := json.Marshal(result)
+				require.NoError(t, err)
+				results = append(results, string(data))
+				return nil
+			})
+			require.NoError(t, err)
+			if tc.expected != nil {
+				assert.Equal(t, tc.expected, results)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	t.Parallel()
+
+	input, err := Decode([]byte(event))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		expression    string
+		expectedError string
+	}{
+		{`.processName.field`, `expected an object but got: string ("curl")`},
+		{`.processId[]`, `cannot iterate over: number (42)`},
+		{`.processName - 1`, `cannot subtract: string ("curl") and number (1)`},
+		{`{(.processId): 1}`, `expected a string for object key but got: number (42)`},
+		{`.eventName | startswith(1)`, `startswith(1) cannot be applied to: string ("security_file_open")`},
+		{`.eventName | test("[")`, "invalid regular expression \"[\": error parsing regexp: missing closing ]: `[`"},
+		{`error("custom")`, `error: custom`},
+	}
+
+	for _, tc := range testCases {
+		q, err := Parse(tc.expression)
+		require.NoError(t, err, tc.expression)
+		err = q.Run(input, func(any) error { return nil })
+		require.Error(t, err, tc.expression)
+		assert.Equal(t, tc.expectedError, err.Error(), tc.expression)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expression    string
+		expectedError string
+	}{
+		{`.a |`, `unexpected EOF`},
+		{`.a b`, `unexpected token "b"`},
+		{`"abc`, `unterminated string literal`},
+		{`.a & .b`, `unexpected token "&"`},
+		{`{(.a)}`, `unexpected token "}"`},
+		{`foo`, `function not defined: foo/0`},
+		{`select`, `function not defined: select/0`},
+		{`input`, `input(s)/0 is not allowed`},
+		{`import "a" as a; .`, `cannot load module: "a"`},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.expression)
+		require.Error(t, err, tc.expression)
+		assert.Equal(t, tc.expectedError, err.Error(), tc.expression)
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	q, err := Parse(`select(.eventName == "security_file_open") | {pid: .processId, path: (.args[] | select(.name == "pathname") | .value)}`)
+	require.NoError(b, err)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		input, _ := Decode([]byte(event))
+		_ = q.Run(input, func(any) error { return nil })
+	}
+}
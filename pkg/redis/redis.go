@@ -0,0 +1,38 @@
+// Package redis publishes the events to Redis Streams, with the go-redis client: the entries
+// of a batch are appended in a pipeline, a round trip per batch.
+package redis
+
+import (
+	"crypto/tls"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Options are the connection options.
+type Options struct {
+	Addr     string // host:port
+	Username string // ACL user, with the password (the default user if empty)
+	Password string
+	DB       int
+	TLS      *tls.Config // nil for a plain connection
+	Timeout  time.Duration
+}
+
+// NewClient returns a client of the server, connecting lazily (and again, once a connection
+// failed) when used.
+func NewClient(opts Options) *goredis.Client {
+	return goredis.NewClient(&goredis.Options{
+		Addr:         opts.Addr,
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DB:           opts.DB,
+		TLSConfig:    opts.TLS,
+		DialTimeout:  opts.Timeout,
+		ReadTimeout:  opts.Timeout,
+		WriteTimeout: opts.Timeout,
+		// the pipelines are sent sequentially
+		PoolSize:        1,
+		DisableIdentity: true,
+	})
+}
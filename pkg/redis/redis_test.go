@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestXAdd(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("tracee", "secret")
+	client := NewClient(Options{
+		Addr:     server.Addr(),
+		Username: "tracee",
+		Password: "secret",
+		DB:       2,
+		Timeout:  5 * time.Second,
+	})
+	defer client.Close()
+
+	ctx := context.Background()
+	events := []trace.Event{
+		{Timestamp: 1712345678901234567, EventName: "security_file_open", ProcessName: "cat", Container: trace.Container{ID: "0123456789ab"}},
+		{Timestamp: 1712345678901234568, EventName: "sched_process_exec", ProcessName: "ls"},
+	}
+	var entries []*goredis.XAddArgs
+	for i := range events {
+		fields, err := EventFields(&events[i])
+		require.NoError(t, err)
+		entries = append(entries, XAddArgs("tracee", 1000, true, fields))
+	}
+	require.NoError(t, XAdd(ctx, client, entries))
+
+	stream, err := server.DB(2).Stream("tracee")
+	require.NoError(t, err)
+	require.Len(t, stream, 2)
+	assert.Equal(t, []string{"event", "security_file_open", "timestamp", "1712345678901234567", "container", "0123456789ab"}, stream[0].Values[:6])
+	assert.Equal(t, "data", stream[0].Values[6])
+	assert.Contains(t, stream[0].Values[7], `"processName":"cat"`)
+	assert.Equal(t, []string{"event", "sched_process_exec", "timestamp", "1712345678901234568", "container", ""}, stream[1].Values[:6])
+
+	// the errors don't break the pipeline, the first one is returned
+	require.NoError(t, server.DB(2).Set("wrongtype", "value"))
+	err = XAdd(ctx, client, []*goredis.XAddArgs{
+		XAddArgs("wrongtype", 0, false, []string{"a", "b"}),
+		XAddArgs("tracee", 2, false, []string{"c", "d"}),
+	})
+	assert.ErrorContains(t, err, "WRONGTYPE Operation against a key holding the wrong kind of value")
+
+	// the stream is trimmed
+	stream, err = server.DB(2).Stream("tracee")
+	require.NoError(t, err)
+	require.Len(t, stream, 2)
+	assert.Equal(t, []string{"c", "d"}, stream[1].Values)
+}
+
+func TestXAddAuthenticationFailed(t *testing.T) {
+	t.Parallel()
+
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	client := NewClient(Options{Addr: server.Addr(), Password: "wrong", Timeout: 5 * time.Second})
+	defer client.Close()
+
+	err := XAdd(context.Background(), client, []*goredis.XAddArgs{
+		XAddArgs("tracee", 0, false, []string{"a", "b"}),
+	})
+	assert.ErrorContains(t, err, "WRONGPASS")
+}
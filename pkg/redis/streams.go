@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// XAddArgs returns the arguments of the XADD command appending an entry to a stream, with an
+// id generated by the server. The stream is trimmed to about maxLen entries (exactly maxLen if
+// not approximate, which is slower), and isn't trimmed if maxLen is 0.
+func XAddArgs(stream string, maxLen int64, approximate bool, fields []string) *goredis.XAddArgs {
+	return &goredis.XAddArgs{
+		Stream: stream,
+		MaxLen: maxLen,
+		Approx: approximate,
+		Values: fields,
+	}
+}
+
+// XAdd appends the entries in a pipeline. It returns the first error of the commands, if any,
+// once all the replies are read.
+func XAdd(ctx context.Context, client *goredis.Client, entries []*goredis.XAddArgs) error {
+	_, err := client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, entry := range entries {
+			pipe.XAdd(ctx, entry)
+		}
+		return nil
+	})
+
+	return errfmt.WrapError(err)
+}
+
+// EventFields returns the fields of the stream entry of an event: the event name, its
+// timestamp and container id (for the consumers to filter the entries without decoding them),
+// and the event as JSON.
+func EventFields(event *trace.Event) ([]string, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return []string{
+		"event", event.EventName,
+		"timestamp", strconv.Itoa(event.Timestamp),
+		"container", event.Container.ID,
+		"data", string(data),
+	}, nil
+}
@@ -0,0 +1,202 @@
+// Package remote splits the events pipeline between the nodes producing the events and a
+// remote processor: the nodes forward their events over gRPC to the processor, which runs the
+// signatures engine and the outputs, shrinking the footprint of tracee on the nodes.
+package remote
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// jsonValue is an argument value of a type not registered to the codec, encoded as JSON and
+// decoded back from the type of its argument (see trace.Argument.UnmarshalJSON).
+type jsonValue []byte
+
+// registered are the types of the argument values encoded as is
+var registered = map[reflect.Type]bool{}
+
+func register(values ...interface{}) {
+	for _, value := range values {
+		gob.Register(value)
+		registered[reflect.TypeOf(value)] = true
+	}
+}
+
+func init() {
+	// gob registers the basic types (and their slices) on its own
+	register(
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0), false, "",
+		[]byte(nil), []int32(nil), []uint64(nil), []string(nil),
+		map[string]string(nil),
+		jsonValue(nil),
+		trace.SlimCred{},
+		trace.ProtoIPv4{}, trace.ProtoIPv6{},
+		trace.ProtoTCP{}, trace.ProtoUDP{},
+		trace.ProtoICMP{}, trace.ProtoICMPv6{},
+		trace.ProtoDNS{},
+		trace.PktMeta{},
+	)
+}
+
+// sanitize replaces the argument values of the types not registered to the codec: gob fails
+// on the interface values of unregistered types, leaving the stream undecodable.
+func sanitize(args []trace.Argument) error {
+	for i := range args {
+		value := args[i].Value
+		if value == nil || registered[reflect.TypeOf(value)] {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return errfmt.Errorf("argument %s: %v", args[i].Name, err)
+		}
+		args[i].Value = jsonValue(data)
+	}
+
+	return nil
+}
+
+// restore decodes the argument values sanitized by the producer
+func restore(args []trace.Argument) error {
+	for i := range args {
+		value, ok := args[i].Value.(jsonValue)
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(struct {
+			trace.ArgMeta
+			Value json.RawMessage `json:"value"`
+		}{args[i].ArgMeta, json.RawMessage(value)})
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+		if err := args[i].UnmarshalJSON(data); err != nil {
+			return errfmt.Errorf("argument %s: %v", args[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Encoder encodes the batches of events forwarded on a stream into frames. The types of the
+// events (and of their arguments) are only described in the first frames of a stream, the
+// next frames only carry the values: the frames of a stream must be decoded in order, by the
+// same Decoder.
+type Encoder struct {
+	buf bytes.Buffer
+	enc *gob.Encoder
+}
+
+// NewEncoder creates the encoder of a stream.
+func NewEncoder() *Encoder {
+	e := &Encoder{}
+	e.enc = gob.NewEncoder(&e.buf)
+
+	return e
+}
+
+// Encode encodes a batch of events into a frame. The arguments values of the types not
+// registered to the codec are replaced in the events.
+func (e *Encoder) Encode(batch []*trace.Event) ([]byte, error) {
+	for _, event := range batch {
+		if err := sanitize(event.Args); err != nil {
+			return nil, errfmt.Errorf("event %s: %v", event.EventName, err)
+		}
+		if event.Metadata != nil {
+			// the properties of the findings are only known at runtime, they are forwarded as
+			// JSON strings if not of a registered type
+			for key, value := range event.Metadata.Properties {
+				if value != nil && !registered[reflect.TypeOf(value)] {
+					data, err := json.Marshal(value)
+					if err != nil {
+						return nil, errfmt.Errorf("event %s: property %s: %v", event.EventName, key, err)
+					}
+					event.Metadata.Properties[key] = string(data)
+				}
+			}
+		}
+	}
+
+	e.buf.Reset()
+	if err := e.enc.Encode(batch); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	frame := make([]byte, e.buf.Len())
+	copy(frame, e.buf.Bytes())
+
+	return frame, nil
+}
+
+// Decoder decodes the frames of a stream, in order.
+type Decoder struct {
+	dec *gob.Decoder
+}
+
+// NewDecoder creates the decoder of a stream, reading its frames from the given function.
+func NewDecoder(next func() ([]byte, error)) *Decoder {
+	return &Decoder{dec: gob.NewDecoder(&frameReader{next: next})}
+}
+
+// Decode decodes the next batch of events of the stream. It returns io.EOF at the end of the
+// stream.
+func (d *Decoder) Decode() ([]*trace.Event, error) {
+	var batch []*trace.Event
+	// the errors of the stream are returned as is (e.g. io.EOF at the end of the stream)
+	if err := d.dec.Decode(&batch); err != nil {
+		return nil, err
+	}
+	for _, event := range batch {
+		if err := restore(event.Args); err != nil {
+			return nil, errfmt.Errorf("event %s: %v", event.EventName, err)
+		}
+	}
+
+	return batch, nil
+}
+
+// frameReader reads the frames of a stream as a continuous stream of bytes. It is a
+// io.ByteReader, so gob doesn't read ahead of the frame it decodes (waiting for the next
+// frame of the stream).
+type frameReader struct {
+	next  func() ([]byte, error)
+	frame []byte
+}
+
+func (r *frameReader) fill() error {
+	for len(r.frame) == 0 {
+		frame, err := r.next()
+		if err != nil {
+			return err
+		}
+		r.frame = frame
+	}
+
+	return nil
+}
+
+func (r *frameReader) Read(p []byte) (int, error) {
+	if err := r.fill(); err != nil {
+		return 0, err
+	}
+	n := copy(p, r.frame)
+	r.frame = r.frame[n:]
+
+	return n, nil
+}
+
+func (r *frameReader) ReadByte() (byte, error) {
+	if err := r.fill(); err != nil {
+		return 0, err
+	}
+	b := r.frame[0]
+	r.frame = r.frame[1:]
+
+	return b, nil
+}
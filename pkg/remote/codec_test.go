@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func testEvents() []*trace.Event {
+	return []*trace.Event{
+		{
+			Timestamp:   1000,
+			ProcessID:   42,
+			ProcessName: "cat",
+			ContainerID: "abcdef",
+			Container:   trace.Container{ID: "abcdef", Name: "web", ImageName: "nginx"},
+			Labels:      map[string]string{"cluster": "prod"},
+			EventID:     257,
+			EventName:   "openat",
+			ArgsNum:     4,
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "dirfd", Type: "int"}, Value: int32(-100)},
+				{ArgMeta: trace.ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/passwd"},
+				{ArgMeta: trace.ArgMeta{Name: "argv", Type: "const char**"}, Value: []string{"cat", "/etc/passwd"}},
+				{ArgMeta: trace.ArgMeta{Name: "empty", Type: "const char*"}, Value: nil},
+			},
+			MatchedPoliciesKernel: 0b101,
+			MatchedPoliciesUser:   0b001,
+		},
+		{
+			Timestamp: 2000,
+			EventID:   2011,
+			EventName: "net_packet_http",
+			ArgsNum:   2,
+			Args: []trace.Argument{
+				{ArgMeta: trace.ArgMeta{Name: "src", Type: "const char*"}, Value: "10.0.0.1"},
+				// not registered to the codec, forwarded as JSON
+				{ArgMeta: trace.ArgMeta{Name: "proto_http", Type: "trace.ProtoHTTP"}, Value: trace.ProtoHTTP{
+					Direction:  "request",
+					Method:     "GET",
+					Protocol:   "HTTP/1.1",
+					Host:       "example.com",
+					URIPath:    "/",
+					StatusCode: 200,
+					Headers:    http.Header{"Accept": []string{"*/*"}},
+				}},
+			},
+		},
+	}
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	var frames [][]byte
+	encoder := NewEncoder()
+	for i := 0; i < 3; i++ {
+		frame, err := encoder.Encode(testEvents())
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+	// the types are only described in the first frame
+	assert.Less(t, len(frames[1]), len(frames[0]))
+
+	decoder := NewDecoder(func() ([]byte, error) {
+		if len(frames) == 0 {
+			return nil, io.EOF
+		}
+		frame := frames[0]
+		frames = frames[1:]
+		return frame, nil
+	})
+	for i := 0; i < 3; i++ {
+		batch, err := decoder.Decode()
+		require.NoError(t, err)
+		assert.Equal(t, testEvents(), batch)
+	}
+	_, err := decoder.Decode()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestCodecSplitFrames(t *testing.T) {
+	t.Parallel()
+
+	frame, err := NewEncoder().Encode(testEvents())
+	require.NoError(t, err)
+
+	// the frames are read as a stream of bytes, whatever their boundaries
+	decoder := NewDecoder(func() ([]byte, error) {
+		if len(frame) == 0 {
+			return nil, io.EOF
+		}
+		b := frame[:1]
+		frame = frame[1:]
+		return b, nil
+	})
+	batch, err := decoder.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, testEvents(), batch)
+}
@@ -0,0 +1,306 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// The pipeline service has a single client streaming method, each message of the stream being a
+// frame of events (see Encoder). It is described by hand, its messages being well known types.
+const (
+	serviceName = "tracee.v1beta1.PipelineService"
+	methodName  = "/" + serviceName + "/ForwardEvents"
+)
+
+// policiesKey is the metadata key of the policies of the producer: the policies matched by the
+// forwarded events are given as bitmaps, only meaningful to the same policies.
+const policiesKey = "tracee-policies"
+
+type pipelineServer interface {
+	forwardEvents(stream grpc.ServerStream) error
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*pipelineServer)(nil),
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ForwardEvents",
+			Handler:       forwardEventsHandler,
+			ClientStreams: true,
+		},
+	},
+}
+
+func forwardEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(pipelineServer).forwardEvents(stream)
+}
+
+//
+// Processor
+//
+
+// Receiver receives the events forwarded by the producers (see Forwarder), on the remote
+// processor.
+type Receiver struct {
+	listener   net.Listener
+	protocol   string
+	listenAddr string
+	server     *grpc.Server
+	policies   func() string
+	events     chan *trace.Event
+}
+
+// NewReceiver listens on the given address for the producers streams. The policies of the
+// producers must match the given ones.
+func NewReceiver(protocol, listenAddr string, policies func() string) (*Receiver, error) {
+	if protocol == "tcp" {
+		listenAddr = ":" + listenAddr
+	}
+
+	lis, err := net.Listen(protocol, listenAddr)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Receiver{
+		listener:   lis,
+		protocol:   protocol,
+		listenAddr: listenAddr,
+		policies:   policies,
+		events:     make(chan *trace.Event, 10000),
+	}, nil
+}
+
+// Events returns the events received from all the producers.
+func (r *Receiver) Events() <-chan *trace.Event {
+	return r.events
+}
+
+// Start serves the producers streams until the context is done.
+func (r *Receiver) Start(ctx context.Context) {
+	srvCtx, srvCancel := context.WithCancel(ctx)
+	defer srvCancel()
+
+	r.server = grpc.NewServer(grpc.KeepaliveParams(keepalive.ServerParameters{
+		Time:    5 * time.Second,
+		Timeout: 1 * time.Second,
+	}))
+	r.server.RegisterService(&serviceDesc, r)
+
+	go func() {
+		logger.Debugw("Starting split pipeline receiver", "protocol", r.protocol, "address", r.listenAddr)
+		if err := r.server.Serve(r.listener); err != nil {
+			logger.Errorw("Split pipeline receiver", "error", err)
+		}
+		srvCancel()
+	}()
+
+	<-srvCtx.Done()
+	// the producers streams never end, they aren't waited for
+	r.server.Stop()
+}
+
+func (r *Receiver) forwardEvents(stream grpc.ServerStream) error {
+	ctx := stream.Context()
+
+	producer := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		producer = p.Addr.String()
+	}
+
+	var policies string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(policiesKey)) > 0 {
+		policies = md.Get(policiesKey)[0]
+	}
+	if expected := r.policies(); policies != expected {
+		logger.Errorw("Split pipeline producer rejected, policies mismatch", "producer", producer,
+			"policies", policies, "expected", expected)
+		return status.Errorf(codes.FailedPrecondition, "policies mismatch: %q, expected %q", policies, expected)
+	}
+
+	logger.Infow("Split pipeline producer connected", "producer", producer)
+	defer logger.Infow("Split pipeline producer disconnected", "producer", producer)
+
+	decoder := NewDecoder(func() ([]byte, error) {
+		frame := &wrapperspb.BytesValue{}
+		if err := stream.RecvMsg(frame); err != nil {
+			return nil, err
+		}
+		return frame.GetValue(), nil
+	})
+
+	for {
+		batch, err := decoder.Decode()
+		if err == io.EOF {
+			return stream.SendMsg(&emptypb.Empty{})
+		}
+		if err != nil {
+			if status.Code(err) == codes.Canceled {
+				return nil
+			}
+			logger.Warnw("Split pipeline producer stream", "producer", producer, "error", err)
+			return status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+
+		for _, event := range batch {
+			select {
+			case r.events <- event:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+//
+// Producer
+//
+
+// Forwarder backoff between two attempts to open a stream to the processor
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// closeTimeout is the max time waited for the processor to acknowledge the end of a stream
+const closeTimeout = 5 * time.Second
+
+// Forwarder forwards the events of a node to the remote processor (see Receiver). The events
+// are dropped while the processor is unreachable, not to hold the node pipeline back.
+type Forwarder struct {
+	conn     *grpc.ClientConn
+	target   string
+	policies func() string
+
+	stream  grpc.ClientStream
+	cancel  context.CancelFunc
+	encoder *Encoder
+	retryAt time.Time
+	backoff time.Duration
+	dropped uint64 // events dropped since the stream was lost
+}
+
+// NewForwarder creates the forwarder of the events to the processor listening on the given
+// address. The connection is established on the first events forwarded.
+func NewForwarder(protocol, address string, policies func() string) (*Forwarder, error) {
+	target := address
+	if protocol == "unix" {
+		target = "unix:" + address
+	}
+
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    10 * time.Second,
+			Timeout: 5 * time.Second,
+		}),
+	)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Forwarder{conn: conn, target: target, policies: policies, backoff: minBackoff}, nil
+}
+
+// Forward forwards a batch of events to the processor, or drops it if the processor is
+// unreachable. It is not safe for concurrent use.
+func (f *Forwarder) Forward(ctx context.Context, batch []*trace.Event) {
+	if f.stream == nil {
+		if time.Now().Before(f.retryAt) {
+			f.dropped += uint64(len(batch))
+			return
+		}
+		if err := f.open(ctx); err != nil {
+			f.fail(err, len(batch))
+			return
+		}
+	}
+
+	frame, err := f.encoder.Encode(batch)
+	if err == nil {
+		err = f.stream.SendMsg(&wrapperspb.BytesValue{Value: frame})
+		if err == io.EOF {
+			// the stream was closed by the processor, its status gives the reason
+			err = f.stream.RecvMsg(&emptypb.Empty{})
+			if err == nil {
+				err = errfmt.Errorf("stream closed by the processor")
+			}
+		}
+	}
+	if err != nil {
+		f.fail(err, len(batch))
+	}
+}
+
+func (f *Forwarder) open(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	ctx = metadata.AppendToOutgoingContext(ctx, policiesKey, f.policies())
+
+	stream, err := f.conn.NewStream(ctx, &serviceDesc.Streams[0], methodName)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	if f.dropped > 0 {
+		logger.Infow("Split pipeline processor reachable again", "processor", f.target, "dropped", f.dropped)
+	} else {
+		logger.Debugw("Split pipeline processor connected", "processor", f.target)
+	}
+	f.stream, f.cancel, f.encoder = stream, cancel, NewEncoder()
+	f.backoff, f.dropped = minBackoff, 0
+
+	return nil
+}
+
+// fail closes the stream (a new stream starts with a new encoder) and drops the batch.
+func (f *Forwarder) fail(err error, batchLen int) {
+	if f.dropped == 0 {
+		logger.Warnw("Split pipeline processor unreachable, dropping the events", "processor", f.target,
+			"retry", f.backoff, "error", err)
+	}
+	f.dropped += uint64(batchLen)
+	f.closeStream()
+	f.retryAt = time.Now().Add(f.backoff)
+	f.backoff = min(f.backoff*2, maxBackoff)
+}
+
+func (f *Forwarder) closeStream() {
+	if f.stream == nil {
+		return
+	}
+	f.cancel()
+	f.stream, f.cancel, f.encoder = nil, nil, nil
+}
+
+// Close ends the stream to the processor and closes the connection.
+func (f *Forwarder) Close() error {
+	if f.stream != nil {
+		// the processor acknowledges the end of the stream, once it received all the events
+		timer := time.AfterFunc(closeTimeout, f.cancel)
+		if err := f.stream.CloseSend(); err == nil {
+			_ = f.stream.RecvMsg(&emptypb.Empty{})
+		}
+		timer.Stop()
+		f.closeStream()
+	}
+
+	return f.conn.Close()
+}
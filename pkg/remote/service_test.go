@@ -0,0 +1,65 @@
+package remote
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestForwarder(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	socket := filepath.Join(t.TempDir(), "pipeline.sock")
+	receiver, err := NewReceiver("unix", socket, func() string { return "0:default" })
+	require.NoError(t, err)
+	go receiver.Start(ctx)
+
+	t.Run("same policies", func(t *testing.T) {
+		forwarder, err := NewForwarder("unix", socket, func() string { return "0:default" })
+		require.NoError(t, err)
+		defer forwarder.Close()
+
+		forwarder.Forward(ctx, testEvents())
+		forwarder.Forward(ctx, testEvents()[:1])
+
+		var received []*trace.Event
+		for len(received) < 3 {
+			select {
+			case event := <-receiver.Events():
+				received = append(received, event)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("received %d events, expected 3", len(received))
+			}
+		}
+		expected := append(testEvents(), testEvents()[:1]...)
+		assert.Equal(t, expected, received)
+		assert.Zero(t, forwarder.dropped)
+	})
+
+	t.Run("other policies", func(t *testing.T) {
+		forwarder, err := NewForwarder("unix", socket, func() string { return "0:other" })
+		require.NoError(t, err)
+		defer forwarder.Close()
+
+		// the rejection is known once the processor answered the stream
+		require.Eventually(t, func() bool {
+			forwarder.Forward(ctx, testEvents())
+			return forwarder.stream == nil && forwarder.dropped > 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		select {
+		case event := <-receiver.Events():
+			t.Fatalf("unexpected event received: %s", event.EventName)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package sbom
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/version"
+)
+
+// SpecVersion is the CycloneDX specification version of the reports.
+const SpecVersion = "1.5"
+
+// CycloneDX document, limited to the fields of a runtime SBOM.
+
+type bom struct {
+	BOMFormat    string      `json:"bomFormat"`
+	SpecVersion  string      `json:"specVersion"`
+	SerialNumber string      `json:"serialNumber"`
+	Version      int         `json:"version"`
+	Metadata     metadata    `json:"metadata"`
+	Components   []component `json:"components"`
+}
+
+type metadata struct {
+	Timestamp  string     `json:"timestamp"`
+	Tools      tools      `json:"tools"`
+	Component  component  `json:"component"`
+	Properties []property `json:"properties,omitempty"`
+}
+
+type tools struct {
+	Components []component `json:"components"`
+}
+
+type component struct {
+	Type       string     `json:"type"`
+	BOMRef     string     `json:"bom-ref,omitempty"`
+	Name       string     `json:"name"`
+	Version    string     `json:"version,omitempty"`
+	Hashes     []hash     `json:"hashes,omitempty"`
+	Properties []property `json:"properties,omitempty"`
+	Evidence   *evidence  `json:"evidence,omitempty"`
+}
+
+type hash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type property struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type evidence struct {
+	Occurrences []occurrence `json:"occurrences"`
+}
+
+type occurrence struct {
+	Location string `json:"location"`
+}
+
+// newDocument returns the CycloneDX JSON document of the components of a container.
+func newDocument(cont *container, components []Component, now time.Time) ([]byte, error) {
+	doc := bom{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  SpecVersion,
+		SerialNumber: cont.serial,
+		Version:      cont.version,
+		Metadata: metadata{
+			Timestamp: now.UTC().Format(time.RFC3339),
+			Tools: tools{
+				Components: []component{
+					{Type: "application", Name: "tracee", Version: version.GetVersion()},
+				},
+			},
+			Component: component{
+				Type:    "container",
+				BOMRef:  cont.info.ID,
+				Name:    cont.info.ImageName,
+				Version: cont.info.ImageDigest,
+				Properties: []property{
+					{Name: "tracee:container_id", Value: cont.info.ID},
+					{Name: "tracee:container_name", Value: cont.info.Name},
+				},
+			},
+		},
+		Components: make([]component, 0, len(components)),
+	}
+	if doc.Metadata.Component.Name == "" {
+		doc.Metadata.Component.Name = cont.info.ID
+	}
+	if cont.dropped > 0 {
+		doc.Metadata.Properties = append(doc.Metadata.Properties,
+			property{Name: "tracee:dropped_components", Value: strconv.Itoa(cont.dropped)},
+		)
+	}
+
+	for _, c := range components {
+		comp := component{
+			Type:   "application",
+			BOMRef: string(c.Kind) + ":" + c.Path,
+			Name:   filepath.Base(c.Path),
+			Properties: []property{
+				{Name: "tracee:kind", Value: string(c.Kind)},
+				{Name: "tracee:first_seen", Value: c.FirstSeen.UTC().Format(time.RFC3339)},
+				{Name: "tracee:count", Value: strconv.FormatUint(c.Count, 10)},
+			},
+			Evidence: &evidence{Occurrences: []occurrence{{Location: c.Path}}},
+		}
+		if c.Kind == SharedObject {
+			comp.Type = "library"
+		}
+		if c.SHA256 != "" {
+			comp.Hashes = []hash{{Alg: "SHA-256", Content: c.SHA256}}
+		}
+		doc.Components = append(doc.Components, comp)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return data, nil
+}
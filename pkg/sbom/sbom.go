@@ -0,0 +1,231 @@
+// Package sbom collects the runtime software bill of materials (SBOM) of the containers:
+// the binaries executed and the shared objects loaded in them. Reports are CycloneDX
+// documents of what actually ran in a container, not of everything its image contains.
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// DefaultInterval is the default interval between two reports of a container.
+	DefaultInterval = time.Minute
+
+	maxContainers = 1024 // containers whose components are tracked
+	maxComponents = 4096 // components tracked per container
+	reportsBuffer = 256
+)
+
+// Kind is the kind of a component.
+type Kind string
+
+const (
+	Executable   Kind = "executable"    // executed binary
+	SharedObject Kind = "shared-object" // loaded shared object
+)
+
+// Config is the runtime SBOM configuration.
+type Config struct {
+	Interval time.Duration // interval between two reports of a container
+	Dir      string        // directory the reports are written to (not written if empty)
+}
+
+// Component is a file which ran in a container.
+type Component struct {
+	Path      string
+	Kind      Kind
+	SHA256    string    // empty if the file wasn't hashed
+	FirstSeen time.Time // first execution or load
+	Count     uint64    // executions or loads
+}
+
+// Report is the runtime SBOM of a container.
+type Report struct {
+	Container     trace.Container
+	Kubernetes    trace.Kubernetes
+	Time          time.Time
+	Executables   int
+	SharedObjects int
+	Document      []byte // CycloneDX JSON document
+}
+
+// container is the components which ran in a container.
+type container struct {
+	info       trace.Container
+	kubernetes trace.Kubernetes
+	serial     string // serial number of the container documents, whose version is bumped
+	version    int
+	components map[string]*Component // by kind and path
+	changed    bool                  // components were added since the last report
+	dropped    int                   // components not tracked (over the max components)
+}
+
+// Collector collects the components which ran in the containers, and reports the SBOM of
+// the containers whose components changed at every interval. It is thread-safe.
+type Collector struct {
+	mutex      sync.Mutex
+	cfg        Config
+	containers *lru.Cache[string, *container]
+	reports    chan Report
+}
+
+// New creates a runtime SBOM collector.
+func New(cfg Config) (*Collector, error) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.Dir != "" {
+		if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	}
+
+	containers, err := lru.New[string, *container](maxContainers)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Collector{
+		cfg:        cfg,
+		containers: containers,
+		reports:    make(chan Report, reportsBuffer),
+	}, nil
+}
+
+// Observe records a component which ran in a container. Host components are ignored.
+func (c *Collector) Observe(info trace.Container, kubernetes trace.Kubernetes, component Component) {
+	if info.ID == "" || component.Path == "" {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cont, ok := c.containers.Get(info.ID)
+	if !ok {
+		cont = &container{
+			serial:     "urn:uuid:" + uuid.NewString(),
+			components: make(map[string]*Component),
+		}
+		c.containers.Add(info.ID, cont)
+	}
+	cont.info = info
+	cont.kubernetes = kubernetes
+
+	key := string(component.Kind) + ":" + component.Path
+	if existing, ok := cont.components[key]; ok {
+		existing.Count++
+		if existing.SHA256 != component.SHA256 && component.SHA256 != "" {
+			existing.SHA256 = component.SHA256 // file replaced since (or first hashed)
+			cont.changed = true
+		}
+		return
+	}
+	if len(cont.components) >= maxComponents {
+		cont.dropped++
+		return
+	}
+
+	component.Count = 1
+	cont.components[key] = &component
+	cont.changed = true
+}
+
+// Reports returns the channel of the runtime SBOM reports.
+func (c *Collector) Reports() <-chan Report {
+	return c.reports
+}
+
+// Run reports the SBOM of the containers whose components changed at every interval, until
+// the context is done.
+func (c *Collector) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, report := range c.collect(now) {
+				if c.cfg.Dir != "" {
+					path := filepath.Join(c.cfg.Dir, fileName(report.Container.ID))
+					if err := os.WriteFile(path, report.Document, 0o640); err != nil {
+						logger.Errorw("Writing runtime SBOM", "path", path, "error", err)
+					}
+				}
+				select {
+				case c.reports <- report:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collect returns the reports of the containers whose components changed since their last
+// report.
+func (c *Collector) collect(now time.Time) []Report {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var reports []Report
+	for _, id := range c.containers.Keys() {
+		cont, ok := c.containers.Peek(id)
+		if !ok || !cont.changed {
+			continue
+		}
+		cont.changed = false
+		cont.version++
+
+		components := make([]Component, 0, len(cont.components))
+		report := Report{
+			Container:  cont.info,
+			Kubernetes: cont.kubernetes,
+			Time:       now,
+		}
+		for _, component := range cont.components {
+			components = append(components, *component)
+			if component.Kind == Executable {
+				report.Executables++
+			} else {
+				report.SharedObjects++
+			}
+		}
+		sort.Slice(components, func(i, j int) bool {
+			if components[i].Kind != components[j].Kind {
+				return components[i].Kind < components[j].Kind
+			}
+			return components[i].Path < components[j].Path
+		})
+
+		document, err := newDocument(cont, components, now)
+		if err != nil {
+			logger.Errorw("Building runtime SBOM", "container", id, "error", err)
+			continue
+		}
+		report.Document = document
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// fileName returns the file name of the reports of a container (overwritten at every report).
+func fileName(containerID string) string {
+	return fmt.Sprintf("runtime-sbom-%s.cdx.json", containerID)
+}
@@ -0,0 +1,93 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+var testContainer = trace.Container{
+	ID:          "abc123",
+	Name:        "web",
+	ImageName:   "nginx:1.25",
+	ImageDigest: "sha256:0123",
+}
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Config{Interval: time.Minute})
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	c.Observe(trace.Container{}, trace.Kubernetes{}, Component{Path: "/usr/bin/ls", Kind: Executable}) // host
+	assert.Empty(t, c.collect(now))
+
+	c.Observe(testContainer, trace.Kubernetes{}, Component{Path: "/usr/sbin/nginx", Kind: Executable, FirstSeen: now})
+	c.Observe(testContainer, trace.Kubernetes{}, Component{Path: "/lib/libc.so.6", Kind: SharedObject, FirstSeen: now, SHA256: "ff00"})
+	c.Observe(testContainer, trace.Kubernetes{}, Component{Path: "/usr/sbin/nginx", Kind: Executable, FirstSeen: now})
+
+	reports := c.collect(now)
+	require.Len(t, reports, 1)
+	report := reports[0]
+	assert.Equal(t, testContainer, report.Container)
+	assert.Equal(t, 1, report.Executables)
+	assert.Equal(t, 1, report.SharedObjects)
+
+	var doc bom
+	require.NoError(t, json.Unmarshal(report.Document, &doc))
+	assert.Equal(t, "CycloneDX", doc.BOMFormat)
+	assert.Equal(t, SpecVersion, doc.SpecVersion)
+	assert.Equal(t, 1, doc.Version)
+	assert.Equal(t, "2023-11-14T22:13:20Z", doc.Metadata.Timestamp)
+	assert.Equal(t, "container", doc.Metadata.Component.Type)
+	assert.Equal(t, "nginx:1.25", doc.Metadata.Component.Name)
+	require.Len(t, doc.Components, 2)
+	assert.Equal(t, "executable:/usr/sbin/nginx", doc.Components[0].BOMRef)
+	assert.Equal(t, "application", doc.Components[0].Type)
+	assert.Contains(t, doc.Components[0].Properties, property{Name: "tracee:count", Value: "2"})
+	assert.Equal(t, "library", doc.Components[1].Type)
+	assert.Equal(t, "libc.so.6", doc.Components[1].Name)
+	assert.Equal(t, []hash{{Alg: "SHA-256", Content: "ff00"}}, doc.Components[1].Hashes)
+	assert.Equal(t, "/lib/libc.so.6", doc.Components[1].Evidence.Occurrences[0].Location)
+
+	// unchanged containers aren't reported again
+	c.Observe(testContainer, trace.Kubernetes{}, Component{Path: "/lib/libc.so.6", Kind: SharedObject, FirstSeen: now})
+	assert.Empty(t, c.collect(now))
+
+	// a new component bumps the document version, keeping its serial number
+	c.Observe(testContainer, trace.Kubernetes{}, Component{Path: "/bin/sh", Kind: Executable, FirstSeen: now})
+	reports = c.collect(now)
+	require.Len(t, reports, 1)
+	var next bom
+	require.NoError(t, json.Unmarshal(reports[0].Document, &next))
+	assert.Equal(t, 2, next.Version)
+	assert.Equal(t, doc.SerialNumber, next.SerialNumber)
+	assert.Len(t, next.Components, 3)
+}
+
+func TestCollectorMaxComponents(t *testing.T) {
+	t.Parallel()
+
+	c, err := New(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultInterval, c.cfg.Interval)
+
+	for i := 0; i <= maxComponents; i++ {
+		c.Observe(testContainer, trace.Kubernetes{}, Component{Path: fmt.Sprintf("/lib/lib%d.so", i), Kind: SharedObject})
+	}
+
+	reports := c.collect(time.Now())
+	require.Len(t, reports, 1)
+	assert.Equal(t, maxComponents, reports[0].SharedObjects)
+
+	var doc bom
+	require.NoError(t, json.Unmarshal(reports[0].Document, &doc))
+	assert.Equal(t, []property{{Name: "tracee:dropped_components", Value: "1"}}, doc.Metadata.Properties)
+}
@@ -0,0 +1,192 @@
+// Package seccomp confines tracee itself: it generates the seccomp profile of the syscalls
+// tracee needs, for the container runtimes (docker, containerd, kubernetes), and installs the
+// seccomp filter allowing them on its own process, once it's initialized.
+package seccomp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// Mode is the action taken on the syscalls tracee doesn't need, once confined.
+type Mode int
+
+const (
+	ModeNone    Mode = iota // not confined
+	ModeAudit               // the syscalls are logged (by the kernel audit)
+	ModeEnforce             // the syscalls fail with EPERM
+)
+
+// Config is the confinement of tracee to the syscalls it needs.
+type Config struct {
+	ProfilePath string   // seccomp profile written to ("" if not written)
+	Mode        Mode     // action taken on the syscalls tracee doesn't need
+	Allow       []string // syscalls allowed in addition to the ones tracee needs
+}
+
+// Enabled returns whether the profile is written or the process confined.
+func (c Config) Enabled() bool {
+	return c.ProfilePath != "" || c.Mode != ModeNone
+}
+
+// Syscalls returns the syscalls tracee needs, with the additional ones allowed, sorted.
+func (c Config) Syscalls() []string {
+	unique := make(map[string]struct{}, len(traceeSyscalls)+len(c.Allow))
+	for _, name := range traceeSyscalls {
+		unique[name] = struct{}{}
+	}
+	for _, name := range c.Allow {
+		unique[name] = struct{}{}
+	}
+
+	syscalls := make([]string, 0, len(unique))
+	for name := range unique {
+		syscalls = append(syscalls, name)
+	}
+	sort.Strings(syscalls)
+
+	return syscalls
+}
+
+// Profile is a seccomp profile of the container runtimes, as in the docker profiles.
+type Profile struct {
+	DefaultAction   string        `json:"defaultAction"`
+	DefaultErrnoRet int           `json:"defaultErrnoRet"`
+	Architectures   []string      `json:"architectures"`
+	Syscalls        []SyscallRule `json:"syscalls"`
+}
+
+// SyscallRule is the action taken on the given syscalls.
+type SyscallRule struct {
+	Names  []string `json:"names"`
+	Action string   `json:"action"`
+}
+
+// NewProfile returns the seccomp profile allowing the given syscalls, failing the others with
+// EPERM. The syscalls not existing on the architecture are ignored by the container runtimes.
+func NewProfile(syscalls []string) Profile {
+	var architectures []string
+	switch runtime.GOARCH {
+	case "amd64":
+		architectures = []string{"SCMP_ARCH_X86_64"}
+	case "arm64":
+		architectures = []string{"SCMP_ARCH_AARCH64"}
+	}
+
+	return Profile{
+		DefaultAction:   "SCMP_ACT_ERRNO",
+		DefaultErrnoRet: int(unix.EPERM),
+		Architectures:   architectures,
+		Syscalls: []SyscallRule{
+			{Names: syscalls, Action: "SCMP_ACT_ALLOW"},
+		},
+	}
+}
+
+// WriteProfile writes the seccomp profile allowing the given syscalls to a file.
+func WriteProfile(path string, syscalls []string) error {
+	data, err := json.MarshalIndent(NewProfile(syscalls), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// offsets in struct seccomp_data
+const (
+	offsetNr   = 0
+	offsetArch = 4
+)
+
+// Filter returns the seccomp filter (classic BPF) allowing the given syscall numbers, of the
+// native architecture, taking the action of the mode on the other syscalls.
+func Filter(mode Mode, nrs []uint32) ([]unix.SockFilter, error) {
+	var arch uint32
+	switch runtime.GOARCH {
+	case "amd64":
+		arch = unix.AUDIT_ARCH_X86_64
+	case "arm64":
+		arch = unix.AUDIT_ARCH_AARCH64
+	default:
+		return nil, fmt.Errorf("architecture %s not supported", runtime.GOARCH)
+	}
+
+	var defaultAction uint32
+	switch mode {
+	case ModeAudit:
+		defaultAction = unix.SECCOMP_RET_LOG
+	case ModeEnforce:
+		defaultAction = unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+	default:
+		return nil, fmt.Errorf("invalid seccomp mode %d", mode)
+	}
+
+	filter := []unix.SockFilter{
+		// syscalls of other architectures (e.g. compat ones) aren't allowed
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, offsetArch),
+		jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, arch, 1, 0),
+		stmt(unix.BPF_RET|unix.BPF_K, defaultAction),
+		stmt(unix.BPF_LD|unix.BPF_W|unix.BPF_ABS, offsetNr),
+	}
+	for _, nr := range nrs {
+		filter = append(filter,
+			jump(unix.BPF_JMP|unix.BPF_JEQ|unix.BPF_K, nr, 0, 1),
+			stmt(unix.BPF_RET|unix.BPF_K, unix.SECCOMP_RET_ALLOW),
+		)
+	}
+	filter = append(filter, stmt(unix.BPF_RET|unix.BPF_K, defaultAction))
+
+	if len(filter) > unix.BPF_MAXINSNS {
+		return nil, fmt.Errorf("seccomp filter of %d syscalls is too long", len(nrs))
+	}
+
+	return filter, nil
+}
+
+func stmt(code uint16, k uint32) unix.SockFilter {
+	return unix.SockFilter{Code: code, K: k}
+}
+
+func jump(code uint16, k uint32, jt, jf uint8) unix.SockFilter {
+	return unix.SockFilter{Code: code, Jt: jt, Jf: jf, K: k}
+}
+
+// Apply confines all the threads of the process (and the ones created afterwards) to the given
+// syscall numbers, taking the action of the mode on the other syscalls. It can't be undone.
+func Apply(mode Mode, nrs []uint32) error {
+	filter, err := Filter(mode, nrs)
+	if err != nil {
+		return err
+	}
+
+	// required to install a filter without CAP_SYS_ADMIN, tracee doesn't execute programs
+	if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+		return fmt.Errorf("could not set no_new_privs: %v", err)
+	}
+
+	prog := unix.SockFprog{
+		Len:    uint16(len(filter)),
+		Filter: &filter[0],
+	}
+	tid, _, errno := unix.Syscall(
+		unix.SYS_SECCOMP,
+		unix.SECCOMP_SET_MODE_FILTER,
+		unix.SECCOMP_FILTER_FLAG_TSYNC,
+		uintptr(unsafe.Pointer(&prog)),
+	)
+	if errno != 0 {
+		return fmt.Errorf("could not install seccomp filter: %v", errno)
+	}
+	if tid != 0 {
+		return fmt.Errorf("could not install seccomp filter: thread %d not synchronized", tid)
+	}
+
+	return nil
+}
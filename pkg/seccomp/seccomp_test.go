@@ -0,0 +1,105 @@
+package seccomp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestConfigSyscalls(t *testing.T) {
+	t.Parallel()
+
+	syscalls := Config{Allow: []string{"execve", "read"}}.Syscalls()
+	assert.True(t, sort.StringsAreSorted(syscalls))
+	assert.Contains(t, syscalls, "execve")
+	assert.Contains(t, syscalls, "bpf")
+	assert.Len(t, syscalls, len(traceeSyscalls)+1) // read is needed by tracee already
+}
+
+func TestWriteProfile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tracee.json")
+	require.NoError(t, WriteProfile(path, []string{"bpf", "read"}))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var profile Profile
+	require.NoError(t, json.Unmarshal(data, &profile))
+	assert.Equal(t, "SCMP_ACT_ERRNO", profile.DefaultAction)
+	assert.Equal(t, 1, profile.DefaultErrnoRet)
+	assert.Equal(t, []SyscallRule{{Names: []string{"bpf", "read"}, Action: "SCMP_ACT_ALLOW"}}, profile.Syscalls)
+}
+
+// runFilter runs a seccomp filter, of the instructions it's built of, on a syscall.
+func runFilter(t *testing.T, filter []unix.SockFilter, arch, nr uint32) uint32 {
+	var acc uint32
+	for pc := 0; pc < len(filter); pc++ {
+		ins := filter[pc]
+		switch ins.Code {
+		case unix.BPF_LD | unix.BPF_W | unix.BPF_ABS:
+			acc = map[uint32]uint32{offsetNr: nr, offsetArch: arch}[ins.K]
+		case unix.BPF_JMP | unix.BPF_JEQ | unix.BPF_K:
+			if acc == ins.K {
+				pc += int(ins.Jt)
+			} else {
+				pc += int(ins.Jf)
+			}
+		case unix.BPF_RET | unix.BPF_K:
+			return ins.K
+		default:
+			t.Fatalf("unexpected instruction %+v", ins)
+		}
+	}
+	t.Fatal("filter without return")
+	return 0
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOARCH != "amd64" && runtime.GOARCH != "arm64" {
+		t.Skip("architecture not supported")
+	}
+	nativeArch := uint32(unix.AUDIT_ARCH_X86_64)
+	if runtime.GOARCH == "arm64" {
+		nativeArch = unix.AUDIT_ARCH_AARCH64
+	}
+	eperm := unix.SECCOMP_RET_ERRNO | uint32(unix.EPERM)
+
+	testCases := []struct {
+		name     string
+		mode     Mode
+		arch     uint32
+		nr       uint32
+		expected uint32
+	}{
+		{name: "allowed", mode: ModeEnforce, arch: nativeArch, nr: 2, expected: unix.SECCOMP_RET_ALLOW},
+		{name: "last allowed", mode: ModeEnforce, arch: nativeArch, nr: 321, expected: unix.SECCOMP_RET_ALLOW},
+		{name: "denied", mode: ModeEnforce, arch: nativeArch, nr: 59, expected: eperm},
+		{name: "other architecture", mode: ModeEnforce, arch: unix.AUDIT_ARCH_I386, nr: 2, expected: eperm},
+		{name: "logged", mode: ModeAudit, arch: nativeArch, nr: 59, expected: unix.SECCOMP_RET_LOG},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := Filter(tc.mode, []uint32{0, 2, 321})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, runFilter(t, filter, tc.arch, tc.nr))
+		})
+	}
+
+	_, err := Filter(ModeNone, []uint32{0})
+	assert.ErrorContains(t, err, "invalid seccomp mode")
+}
@@ -0,0 +1,137 @@
+package seccomp
+
+// traceeSyscalls are the syscalls tracee needs once initialized, of both supported architectures
+// (the ones missing on an architecture are skipped): the ones of the go runtime, of the eBPF
+// programs and maps management, of the events enrichment (procfs, cgroups, containers runtimes)
+// and of the outputs and servers. Programs are never executed once initialized.
+var traceeSyscalls = []string{
+	// go runtime
+	"arch_prctl",
+	"brk",
+	"clock_getres",
+	"clock_gettime",
+	"clock_nanosleep",
+	"clone",
+	"clone3",
+	"epoll_create1",
+	"epoll_ctl",
+	"epoll_pwait",
+	"epoll_wait",
+	"eventfd2",
+	"exit",
+	"exit_group",
+	"futex",
+	"getpid",
+	"getppid",
+	"getrandom",
+	"getrlimit",
+	"gettid",
+	"gettimeofday",
+	"kill",
+	"madvise",
+	"membarrier",
+	"mincore",
+	"mmap",
+	"mprotect",
+	"munmap",
+	"nanosleep",
+	"pipe2",
+	"prlimit64",
+	"restart_syscall",
+	"rseq",
+	"rt_sigaction",
+	"rt_sigprocmask",
+	"rt_sigreturn",
+	"sched_getaffinity",
+	"sched_yield",
+	"set_robust_list",
+	"setitimer",
+	"sigaltstack",
+	"tgkill",
+	"timer_create",
+	"timer_delete",
+	"timer_settime",
+	"uname",
+	// eBPF and perf buffers
+	"bpf",
+	"ioctl",
+	"perf_event_open",
+	"poll",
+	"ppoll",
+	// files (procfs, sysfs, cgroupfs, outputs and captures)
+	"access",
+	"close",
+	"dup",
+	"dup2",
+	"dup3",
+	"faccessat",
+	"faccessat2",
+	"fchmod",
+	"fchmodat",
+	"fchown",
+	"fcntl",
+	"fdatasync",
+	"flock",
+	"fstat",
+	"fstatfs",
+	"fsync",
+	"ftruncate",
+	"getcwd",
+	"getdents64",
+	"inotify_add_watch",
+	"inotify_init1",
+	"inotify_rm_watch",
+	"lseek",
+	"mkdir",
+	"mkdirat",
+	"newfstatat",
+	"open",
+	"openat",
+	"pread64",
+	"pwrite64",
+	"read",
+	"readlink",
+	"readlinkat",
+	"readv",
+	"rename",
+	"renameat",
+	"renameat2",
+	"stat",
+	"statfs",
+	"statx",
+	"umask",
+	"unlink",
+	"unlinkat",
+	"write",
+	"writev",
+	// network (containers runtimes, servers, outputs)
+	"accept",
+	"accept4",
+	"bind",
+	"connect",
+	"getpeername",
+	"getsockname",
+	"getsockopt",
+	"listen",
+	"recvfrom",
+	"recvmmsg",
+	"recvmsg",
+	"sendmmsg",
+	"sendmsg",
+	"sendto",
+	"setsockopt",
+	"shutdown",
+	"socket",
+	"socketpair",
+	// credentials and capabilities (capabilities rings)
+	"capget",
+	"capset",
+	"getegid",
+	"geteuid",
+	"getgid",
+	"getgroups",
+	"getresgid",
+	"getresuid",
+	"getuid",
+	"prctl",
+}
@@ -0,0 +1,267 @@
+// Package selflimit keeps tracee within its CPU and memory self-limits: the resources used by
+// the process are sampled at every interval, and the load is shed progressively while they
+// exceed the limits (sampling the low priority events, disabling the expensive enrichments,
+// and finally dropping the low priority events), instead of tracee getting throttled or OOM
+// killed.
+package selflimit
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	// DefaultInterval is the default interval between two samples of the resources usage.
+	DefaultInterval = 5 * time.Second
+	// DefaultSampleRate is the default share (1 out of the rate) of the low priority events
+	// kept while they are sampled.
+	DefaultSampleRate = 10
+
+	// recoverRatio is the share of the limits the usage must go below for the load shedding
+	// to step down: between it and the limits, the level is kept (no flapping).
+	recoverRatio = 0.8
+
+	reportsBuffer = 16
+)
+
+// Level is a load shedding level, each level shedding the load of the previous ones.
+type Level int32
+
+const (
+	LevelNone         Level = iota // no load shed
+	LevelSample                    // low priority events sampled
+	LevelNoEnrichment              // expensive enrichments disabled
+	LevelDrop                      // low priority events dropped
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelNone:
+		return "none"
+	case LevelSample:
+		return "sample"
+	case LevelNoEnrichment:
+		return "no-enrichment"
+	case LevelDrop:
+		return "drop-low-priority"
+	}
+
+	return "unknown"
+}
+
+// Reasons of a level change
+const (
+	ReasonCPU       = "cpu"       // the CPU usage exceeded its limit
+	ReasonMemory    = "memory"    // the memory usage exceeded its limit
+	ReasonRecovered = "recovered" // the usage went back below the limits
+)
+
+// Config is the self-limits configuration.
+type Config struct {
+	CPU        float64       // CPU limit, in cores (none if 0)
+	Memory     uint64        // memory (resident set size) limit, in bytes (none if 0)
+	Interval   time.Duration // interval between two samples of the resources usage
+	SampleRate uint64        // 1 out of the rate low priority events kept while sampled
+}
+
+// Enabled returns true if any limit is set.
+func (c Config) Enabled() bool {
+	return c.CPU > 0 || c.Memory > 0
+}
+
+// Usage is the resources usage of the process during an interval.
+type Usage struct {
+	CPU    float64 // cores used during the interval
+	Memory uint64  // resident set size, in bytes
+}
+
+// Report is a change of the load shedding level.
+type Report struct {
+	Time     time.Time
+	Level    Level
+	Previous Level
+	Reason   string
+	Usage    Usage
+}
+
+type sample struct {
+	time   time.Time
+	cpu    time.Duration // user and system time used since the process started
+	memory uint64
+}
+
+// Limiter samples the resources usage of the process at every interval, and sets the load
+// shedding level to keep it within the limits.
+type Limiter struct {
+	cfg      Config
+	read     func(now time.Time) (sample, error)
+	previous *sample
+	level    atomic.Int32
+	events   atomic.Uint64 // low priority events seen while sampled
+	reports  chan Report
+}
+
+// New returns the limiter of the given self-limits.
+func New(cfg Config) *Limiter {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultInterval
+	}
+	if cfg.SampleRate == 0 {
+		cfg.SampleRate = DefaultSampleRate
+	}
+
+	return &Limiter{
+		cfg:     cfg,
+		read:    readSample,
+		reports: make(chan Report, reportsBuffer),
+	}
+}
+
+// Reports returns the channel of the load shedding level changes.
+func (l *Limiter) Reports() <-chan Report {
+	return l.reports
+}
+
+// Level returns the current load shedding level.
+func (l *Limiter) Level() Level {
+	return Level(l.level.Load())
+}
+
+// Keep returns true if a low priority event is kept at the current level. It is safe for
+// concurrent use.
+func (l *Limiter) Keep() bool {
+	switch l.Level() {
+	case LevelNone:
+		return true
+	case LevelDrop:
+		return false
+	}
+
+	return l.events.Add(1)%l.cfg.SampleRate == 0
+}
+
+// Enrich returns true if the expensive enrichments of the events are enabled at the current
+// level (stack symbols, canonical paths, file hashes and build ids).
+func (l *Limiter) Enrich() bool {
+	return l.Level() < LevelNoEnrichment
+}
+
+// Run samples the resources usage until the context is done.
+func (l *Limiter) Run(ctx context.Context) {
+	if l.cfg.Memory > 0 {
+		// the garbage is collected more often as the heap gets close to the limit, before
+		// shedding load
+		debug.SetMemoryLimit(int64(float64(l.cfg.Memory) * recoverRatio))
+	}
+
+	ticker := time.NewTicker(l.cfg.Interval)
+	defer ticker.Stop()
+
+	l.check(time.Now()) // baseline sample
+
+	for {
+		select {
+		case now := <-ticker.C:
+			report := l.check(now)
+			if report == nil {
+				continue
+			}
+			select {
+			case l.reports <- *report:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// check samples the resources usage, and moves the load shedding level one step up while
+// a limit is exceeded, or one step down once the usage is back below the recover ratio of
+// the limits. It returns the report of the level change, if any.
+func (l *Limiter) check(now time.Time) *Report {
+	current, err := l.read(now)
+	if err != nil {
+		return nil
+	}
+	previous := l.previous
+	l.previous = &current
+	if previous == nil {
+		return nil // baseline sample
+	}
+
+	interval := current.time.Sub(previous.time)
+	if interval <= 0 {
+		return nil
+	}
+	usage := Usage{
+		CPU:    float64(current.cpu-previous.cpu) / float64(interval),
+		Memory: current.memory,
+	}
+
+	level := l.Level()
+	next, reason := level, ""
+	if reason = l.exceeded(usage, 1); reason != "" {
+		if level < LevelDrop {
+			next = level + 1
+		}
+	} else if l.exceeded(usage, recoverRatio) == "" && level > LevelNone {
+		next, reason = level-1, ReasonRecovered
+	}
+	if next == level {
+		return nil
+	}
+	l.level.Store(int32(next))
+
+	return &Report{Time: now, Level: next, Previous: level, Reason: reason, Usage: usage}
+}
+
+// exceeded returns the reason of the limit exceeded by the usage, given a ratio of the
+// limits, or an empty string.
+func (l *Limiter) exceeded(usage Usage, ratio float64) string {
+	if l.cfg.Memory > 0 && float64(usage.Memory) > float64(l.cfg.Memory)*ratio {
+		return ReasonMemory
+	}
+	if l.cfg.CPU > 0 && usage.CPU > l.cfg.CPU*ratio {
+		return ReasonCPU
+	}
+
+	return ""
+}
+
+// readSample reads the CPU time used by the process (getrusage) and its resident set size
+// (/proc/self/statm).
+func readSample(now time.Time) (sample, error) {
+	var rusage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &rusage); err != nil {
+		return sample{}, errfmt.WrapError(err)
+	}
+
+	statm, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return sample{}, errfmt.WrapError(err)
+	}
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return sample{}, errfmt.Errorf("invalid /proc/self/statm: %q", statm)
+	}
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return sample{}, errfmt.WrapError(err)
+	}
+
+	return sample{
+		time:   now,
+		cpu:    time.Duration(rusage.Utime.Nano() + rusage.Stime.Nano()),
+		memory: pages * uint64(os.Getpagesize()),
+	}, nil
+}
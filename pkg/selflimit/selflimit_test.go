@@ -0,0 +1,92 @@
+package selflimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterLevels(t *testing.T) {
+	t.Parallel()
+
+	const mb = 1 << 20
+	l := New(Config{CPU: 1, Memory: 100 * mb, Interval: 10 * time.Second, SampleRate: 4})
+
+	start := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cpu := time.Duration(0)
+	steps := []struct {
+		name     string
+		cpu      time.Duration // CPU time used during the 10s interval
+		memory   uint64
+		level    Level
+		reason   string
+		reported bool
+	}{
+		{name: "below the limits", cpu: 5 * time.Second, memory: 50 * mb, level: LevelNone},
+		{name: "cpu exceeded", cpu: 15 * time.Second, memory: 50 * mb, level: LevelSample, reason: ReasonCPU, reported: true},
+		{name: "memory exceeded", cpu: 5 * time.Second, memory: 120 * mb, level: LevelNoEnrichment, reason: ReasonMemory, reported: true},
+		{name: "still exceeded", cpu: 5 * time.Second, memory: 120 * mb, level: LevelDrop, reason: ReasonMemory, reported: true},
+		{name: "highest level", cpu: 5 * time.Second, memory: 120 * mb, level: LevelDrop},
+		{name: "between the recover ratio and the limits", cpu: 9 * time.Second, memory: 90 * mb, level: LevelDrop},
+		{name: "recovered", cpu: 5 * time.Second, memory: 50 * mb, level: LevelNoEnrichment, reason: ReasonRecovered, reported: true},
+		{name: "still recovered", cpu: 5 * time.Second, memory: 50 * mb, level: LevelSample, reason: ReasonRecovered, reported: true},
+	}
+
+	var memory uint64
+	l.read = func(now time.Time) (sample, error) {
+		return sample{time: now, cpu: cpu, memory: memory}, nil
+	}
+	assert.Nil(t, l.check(start), "baseline sample")
+
+	for i, step := range steps {
+		cpu += step.cpu
+		memory = step.memory
+		now := start.Add(time.Duration(i+1) * 10 * time.Second)
+
+		report := l.check(now)
+		assert.Equal(t, step.level, l.Level(), step.name)
+		if !step.reported {
+			assert.Nil(t, report, step.name)
+			continue
+		}
+		require.NotNil(t, report, step.name)
+		assert.Equal(t, step.reason, report.Reason, step.name)
+		assert.Equal(t, step.level, report.Level, step.name)
+		assert.Equal(t, now, report.Time, step.name)
+		assert.InDelta(t, step.cpu.Seconds()/10, report.Usage.CPU, 0.001, step.name)
+		assert.Equal(t, step.memory, report.Usage.Memory, step.name)
+	}
+}
+
+func TestLimiterShedding(t *testing.T) {
+	t.Parallel()
+
+	l := New(Config{Memory: 1, SampleRate: 4})
+
+	keep := func() int {
+		kept := 0
+		for i := 0; i < 100; i++ {
+			if l.Keep() {
+				kept++
+			}
+		}
+		return kept
+	}
+
+	assert.Equal(t, 100, keep())
+	assert.True(t, l.Enrich())
+
+	l.level.Store(int32(LevelSample))
+	assert.Equal(t, 25, keep())
+	assert.True(t, l.Enrich())
+
+	l.level.Store(int32(LevelNoEnrichment))
+	assert.Equal(t, 25, keep())
+	assert.False(t, l.Enrich())
+
+	l.level.Store(int32(LevelDrop))
+	assert.Equal(t, 0, keep())
+	assert.False(t, l.Enrich())
+}
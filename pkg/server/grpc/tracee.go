@@ -121,16 +121,10 @@ func getDefinitions(in *pb.GetEventDefinitionsRequest) ([]events.Definition, err
 }
 
 func convertDefinitionToProto(d events.Definition) *pb.EventDefinition {
-	v := &pb.Version{
-		Major: d.GetVersion().Major(),
-		Minor: d.GetVersion().Minor(),
-		Patch: d.GetVersion().Patch(),
-	}
-
 	return &pb.EventDefinition{
 		Id:          int32(d.GetID()),
 		Name:        d.GetName(),
-		Version:     v,
+		Version:     convertVersionToProto(d.GetVersion()),
 		Description: d.GetDescription(),
 		Tags:        d.GetSets(),
 		// threat description is empty because it is the same as the event definition description
@@ -138,6 +132,14 @@ func convertDefinitionToProto(d events.Definition) *pb.EventDefinition {
 	}
 }
 
+func convertVersionToProto(v events.Version) *pb.Version {
+	return &pb.Version{
+		Major: v.Major(),
+		Minor: v.Minor(),
+		Patch: v.Patch(),
+	}
+}
+
 func convertTraceeEventToProto(e trace.Event) (*pb.Event, error) {
 	process := getProcess(e)
 	container := getContainer(e)
@@ -178,6 +180,15 @@ func convertTraceeEventToProto(e trace.Event) (*pb.Event, error) {
 		event.Timestamp = timestamppb.New(time.Unix(0, int64(e.Timestamp)))
 	}
 
+	// the name and version of the event are its stable identifier, unlike its id
+	if e.EventVersion != "" {
+		version, err := events.NewVersionFromString(e.EventVersion)
+		if err != nil {
+			return nil, err
+		}
+		event.Version = convertVersionToProto(version)
+	}
+
 	return event, nil
 }
 
@@ -215,7 +226,7 @@ func getProcess(e trace.Event) *pb.Process {
 			Tid:            wrapperspb.UInt32(uint32(e.HostThreadID)),
 			NamespacedTid:  wrapperspb.UInt32(uint32(e.ThreadID)),
 			Syscall:        e.Syscall,
-			Compat:         e.ContextFlags.ContainerStarted,
+			Compat:         e.ContextFlags.IsCompat,
 			UserStackTrace: userStackTrace,
 		},
 		Parent: &pb.Process{
@@ -28,9 +28,10 @@ func Test_convertEventWithProcessContext(t *testing.T) {
 		ProcessName:         "processTest",
 		EventID:             8,
 		EventName:           "eventTest",
+		EventVersion:        "1.2.3",
 		MatchedPolicies:     []string{"policyTest"},
 		Syscall:             "syscall",
-		ContextFlags:        trace.ContextFlags{ContainerStarted: true},
+		ContextFlags:        trace.ContextFlags{IsCompat: true},
 		ThreadEntityId:      9,
 		ProcessEntityId:     10,
 		ParentEntityId:      11,
@@ -51,6 +52,7 @@ func Test_convertEventWithProcessContext(t *testing.T) {
 	assert.Equal(t, uint32(10), protoEvent.Context.Process.EntityId.Value)
 	assert.Equal(t, uint32(11), protoEvent.Context.Process.Parent.EntityId.Value)
 	assert.Equal(t, "eventTest", protoEvent.Name)
+	assert.Equal(t, &pb.Version{Major: 1, Minor: 2, Patch: 3}, protoEvent.Version)
 	assert.Equal(t, []string{"policyTest"}, protoEvent.Policies.Matched)
 	assert.Equal(t, "processTest", protoEvent.Context.Process.Thread.Name)
 	assert.Equal(t, "syscall", protoEvent.Context.Process.Thread.Syscall)
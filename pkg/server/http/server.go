@@ -2,14 +2,27 @@ package http
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"net/http/pprof"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/grafana/pyroscope-go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/aquasecurity/tracee/pkg/artifacts"
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+	"github.com/aquasecurity/tracee/pkg/events/window"
 	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/snapshot"
 )
 
 // Server represents a http server
@@ -46,6 +59,231 @@ func (s *Server) EnableHealthzEndpoint() {
 	})
 }
 
+// EnableEventsQueryEndpoint enables the endpoint querying the retained events window, with
+// any number of filter expressions (see window.Filter):
+//
+//	GET /events?filter=event=sched_process_exec&filter=container=3f2a*&last=10m&limit=100
+func (s *Server) EnableEventsQueryEndpoint(w *window.Window) {
+	s.mux.HandleFunc("/events", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := req.URL.Query()
+
+		filter, err := window.ParseFilter(query["filter"])
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var last time.Duration
+		if v := query.Get("last"); v != "" {
+			last, err = time.ParseDuration(v)
+			if err != nil || last < 0 {
+				http.Error(rw, fmt.Sprintf("invalid last duration: %s", v), http.StatusBadRequest)
+				return
+			}
+		}
+
+		var limit int
+		if v := query.Get("limit"); v != "" {
+			limit, err = strconv.Atoi(v)
+			if err != nil || limit < 0 {
+				http.Error(rw, fmt.Sprintf("invalid limit: %s", v), http.StatusBadRequest)
+				return
+			}
+		}
+
+		evts, err := w.Query(filter, last, limit)
+		if err != nil {
+			logger.Errorw("Querying events window", "error", err)
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(evts); err != nil {
+			logger.Errorw("Writing events query response", "error", err)
+		}
+	})
+}
+
+// EnableTimelineEndpoint enables the endpoint exporting the timeline of a process (live, or
+// the most recent exited one of the given host pid), as JSON (default) or HTML:
+//
+//	GET /timeline?pid=1234&format=html
+func (s *Server) EnableTimelineEndpoint(r *timeline.Recorder) {
+	s.mux.HandleFunc("/timeline", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := req.URL.Query()
+
+		pid, err := strconv.Atoi(query.Get("pid"))
+		if err != nil || pid <= 0 {
+			http.Error(rw, fmt.Sprintf("invalid pid: %s", query.Get("pid")), http.StatusBadRequest)
+			return
+		}
+
+		format := timeline.FormatJSON
+		if v := query.Get("format"); v != "" {
+			format = v
+		}
+		if format != timeline.FormatJSON && format != timeline.FormatHTML {
+			http.Error(rw, fmt.Sprintf("invalid format: %s", format), http.StatusBadRequest)
+			return
+		}
+
+		t, ok := r.Get(pid)
+		if !ok {
+			http.Error(rw, fmt.Sprintf("no timeline of pid %d", pid), http.StatusNotFound)
+			return
+		}
+
+		if format == timeline.FormatHTML {
+			rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		} else {
+			rw.Header().Set("Content-Type", "application/json")
+		}
+		if err := t.Write(rw, format); err != nil {
+			logger.Errorw("Writing timeline response", "error", err)
+		}
+	})
+}
+
+// ArtifactsIndex lists and opens the indexed captured artifacts
+type ArtifactsIndex interface {
+	List(f artifacts.Filter) []artifacts.Artifact
+	Open(hash string) (artifacts.Artifact, *os.File, error)
+}
+
+// EnableArtifactsEndpoint enables the endpoints listing the indexed captured artifacts, with
+// optional type, container and sha256 filters, and fetching the content of an artifact by
+// its hash:
+//
+//	GET /artifacts?type=exec&container=3f2a
+//	GET /artifacts/<sha256>
+func (s *Server) EnableArtifactsEndpoint(index ArtifactsIndex) {
+	s.mux.HandleFunc("/artifacts", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := req.URL.Query()
+		list := index.List(artifacts.Filter{
+			Type:        query.Get("type"),
+			ContainerID: query.Get("container"),
+			SHA256:      query.Get("sha256"),
+		})
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(list); err != nil {
+			logger.Errorw("Writing artifacts response", "error", err)
+		}
+	})
+
+	s.mux.HandleFunc("/artifacts/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		hash := strings.TrimPrefix(req.URL.Path, "/artifacts/")
+		if !artifacts.ValidHash(hash) {
+			http.Error(rw, fmt.Sprintf("invalid artifact hash %q, expected a hex encoded sha256", hash), http.StatusBadRequest)
+			return
+		}
+		artifact, f, err := index.Open(hash)
+		if errors.Is(err, fs.ErrNotExist) {
+			http.Error(rw, fmt.Sprintf("no artifact of hash %s", hash), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			logger.Errorw("Opening artifact", "error", err)
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				logger.Errorw("Closing artifact", "error", err)
+			}
+		}()
+
+		rw.Header().Set("Content-Type", "application/octet-stream")
+		rw.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", path.Base(artifact.Path)))
+		http.ServeContent(rw, req, "", artifact.Time, f)
+	})
+}
+
+// Snapshotter takes forensic snapshots on demand
+type Snapshotter interface {
+	TakeSnapshot(ctx context.Context, bundle bool) (*snapshot.Summary, error)
+}
+
+// snapshotMinInterval is the minimum interval between two snapshots: taking a snapshot
+// walks the whole procfs, it shouldn't be triggered in a loop.
+const snapshotMinInterval = 10 * time.Second
+
+// EnableSnapshotEndpoint enables the endpoint triggering a forensic snapshot, emitted as
+// events and, unless bundle=false is given, written as an artifact bundle:
+//
+//	POST /snapshot?bundle=false
+//
+// Snapshots are rate limited: a request made while a snapshot is being taken, or less than
+// snapshotMinInterval after the previous one, is rejected (429 Too Many Requests).
+func (s *Server) EnableSnapshotEndpoint(snapshotter Snapshotter) {
+	var (
+		mu           sync.Mutex
+		lastSnapshot time.Time
+	)
+
+	s.mux.HandleFunc("/snapshot", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		bundle := true
+		if v := req.URL.Query().Get("bundle"); v != "" {
+			var err error
+			bundle, err = strconv.ParseBool(v)
+			if err != nil {
+				http.Error(rw, fmt.Sprintf("invalid bundle value: %s", v), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if !mu.TryLock() {
+			http.Error(rw, "a snapshot is already being taken", http.StatusTooManyRequests)
+			return
+		}
+		defer mu.Unlock()
+		if wait := snapshotMinInterval - time.Since(lastSnapshot); wait > 0 {
+			rw.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			http.Error(rw, "too many snapshots, retry later", http.StatusTooManyRequests)
+			return
+		}
+		lastSnapshot = time.Now()
+
+		summary, err := snapshotter.TakeSnapshot(req.Context(), bundle)
+		if err != nil {
+			logger.Errorw("Taking forensic snapshot", "error", err)
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(summary); err != nil {
+			logger.Errorw("Writing snapshot response", "error", err)
+		}
+	})
+}
+
 // Start starts the http server on the listen address
 func (s *Server) Start(ctx context.Context) {
 	srvCtx, srvCancel := context.WithCancel(ctx)
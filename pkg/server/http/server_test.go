@@ -1,12 +1,27 @@
 package http
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/artifacts"
+	"github.com/aquasecurity/tracee/pkg/events/timeline"
+	"github.com/aquasecurity/tracee/pkg/events/window"
+	"github.com/aquasecurity/tracee/pkg/snapshot"
+	"github.com/aquasecurity/tracee/types/trace"
 )
 
 func TestServer(t *testing.T) {
@@ -41,3 +56,240 @@ func TestServer(t *testing.T) {
 		})
 	}
 }
+
+func TestEventsQueryEndpoint(t *testing.T) {
+	t.Parallel()
+
+	w, err := window.New(window.Config{Duration: time.Minute, MaxEvents: 10})
+	require.NoError(t, err)
+	defer w.Close()
+
+	w.Add(trace.Event{EventName: "openat"})
+	w.Add(trace.Event{EventName: "sched_process_exec"})
+	w.Flush()
+
+	httpServer := New("")
+	httpServer.EnableEventsQueryEndpoint(w)
+
+	server := httptest.NewServer(httpServer.mux)
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		events []string
+	}{
+		{name: "all", query: "", status: 200, events: []string{"openat", "sched_process_exec"}},
+		{name: "filter", query: "?filter=event%3Dsched_process_exec", status: 200, events: []string{"sched_process_exec"}},
+		{name: "filters", query: "?filter=event%3Dopenat,sched_process_exec&filter=event!%3Dopenat", status: 200, events: []string{"sched_process_exec"}},
+		{name: "limit", query: "?last=1m&limit=1", status: 200, events: []string{"sched_process_exec"}},
+		{name: "invalid filter", query: "?filter=foo%3Dbar", status: 400},
+		{name: "invalid last", query: "?last=soon", status: 400},
+		{name: "invalid limit", query: "?limit=-1", status: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(fmt.Sprintf("%s/events%s", server.URL, tt.query))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != 200 {
+				return
+			}
+
+			var evts []trace.Event
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&evts))
+
+			names := make([]string, 0, len(evts))
+			for _, evt := range evts {
+				names = append(names, evt.EventName)
+			}
+			assert.Equal(t, tt.events, names)
+		})
+	}
+}
+
+func TestTimelineEndpoint(t *testing.T) {
+	t.Parallel()
+
+	r, err := timeline.New(timeline.Config{Format: timeline.FormatJSON, MaxEvents: 10})
+	require.NoError(t, err)
+	defer r.Close()
+
+	r.Add(trace.Event{EventName: "execve", HostProcessID: 1234, HostThreadID: 1234, ProcessName: "bash"})
+
+	httpServer := New("")
+	httpServer.EnableTimelineEndpoint(r)
+
+	server := httptest.NewServer(httpServer.mux)
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		query       string
+		status      int
+		contentType string
+	}{
+		{name: "json", query: "?pid=1234", status: 200, contentType: "application/json"},
+		{name: "html", query: "?pid=1234&format=html", status: 200, contentType: "text/html; charset=utf-8"},
+		{name: "unknown pid", query: "?pid=4321", status: 404},
+		{name: "invalid pid", query: "?pid=bash", status: 400},
+		{name: "invalid format", query: "?pid=1234&format=pdf", status: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(fmt.Sprintf("%s/timeline%s", server.URL, tt.query))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.status, resp.StatusCode)
+			if tt.status != 200 {
+				return
+			}
+			assert.Equal(t, tt.contentType, resp.Header.Get("Content-Type"))
+		})
+	}
+}
+
+type fakeSnapshotter struct {
+	bundle bool
+}
+
+func (f *fakeSnapshotter) TakeSnapshot(ctx context.Context, bundle bool) (*snapshot.Summary, error) {
+	f.bundle = bundle
+	summary := &snapshot.Summary{Processes: 3}
+	if bundle {
+		summary.Bundle = "/tmp/snapshot.tar.gz"
+	}
+
+	return summary, nil
+}
+
+func TestSnapshotEndpoint(t *testing.T) {
+	t.Parallel()
+
+	snapshotter := &fakeSnapshotter{}
+
+	httpServer := New("")
+	httpServer.EnableSnapshotEndpoint(snapshotter)
+
+	server := httptest.NewServer(httpServer.mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/snapshot")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/snapshot?bundle=maybe", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/snapshot?bundle=false", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.False(t, snapshotter.bundle)
+
+	var summary snapshot.Summary
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	assert.Equal(t, 3, summary.Processes)
+	assert.Empty(t, summary.Bundle)
+
+	// snapshots are rate limited
+	resp, err = http.Post(server.URL+"/snapshot", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+	assert.False(t, snapshotter.bundle)
+}
+
+type fakeArtifactsIndex struct {
+	artifacts []artifacts.Artifact
+	dir       string
+}
+
+func (f *fakeArtifactsIndex) List(filter artifacts.Filter) []artifacts.Artifact {
+	var list []artifacts.Artifact
+	for _, a := range f.artifacts {
+		if filter.Type == "" || filter.Type == a.Type {
+			list = append(list, a)
+		}
+	}
+
+	return list
+}
+
+func (f *fakeArtifactsIndex) Open(hash string) (artifacts.Artifact, *os.File, error) {
+	for _, a := range f.artifacts {
+		if a.SHA256 == hash {
+			file, err := os.Open(filepath.Join(f.dir, a.Path))
+			return a, file, err
+		}
+	}
+
+	return artifacts.Artifact{}, nil, fs.ErrNotExist
+}
+
+func TestArtifactsEndpoint(t *testing.T) {
+	t.Parallel()
+
+	execHash := strings.Repeat("12", 32)
+	writeHash := strings.Repeat("56", 32)
+	index := &fakeArtifactsIndex{
+		dir: t.TempDir(),
+		artifacts: []artifacts.Artifact{
+			{Path: "exec.ls", Type: "exec", SHA256: execHash},
+			{Path: "write.dev-1.inode-2", Type: "write", SHA256: writeHash},
+		},
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(index.dir, "exec.ls"), []byte("ls"), 0600))
+
+	httpServer := New("")
+	httpServer.EnableArtifactsEndpoint(index)
+
+	server := httptest.NewServer(httpServer.mux)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/artifacts?type=exec")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var list []artifacts.Artifact
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "exec.ls", list[0].Path)
+
+	resp, err = http.Get(server.URL + "/artifacts/" + execHash)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ls", string(content))
+	assert.Equal(t, `attachment; filename="exec.ls"`, resp.Header.Get("Content-Disposition"))
+
+	resp, err = http.Get(server.URL + "/artifacts/" + strings.Repeat("0", 64))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// an empty or malformed hash is rejected before the lookup
+	for _, invalid := range []string{"", "1234", strings.ToUpper(execHash), execHash + "00"} {
+		resp, err = http.Get(server.URL + "/artifacts/" + invalid)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, invalid)
+	}
+
+	resp, err = http.Post(server.URL+"/artifacts", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,203 @@
+// Package budget measures the cost of the signatures against a replayed events stream, and
+// enforces a per-event time budget, so a costly signature can't slow down the whole events
+// pipeline in production.
+package budget
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/signatures/state"
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/protocol"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Action is what is done with the signatures exceeding the budget.
+type Action string
+
+const (
+	ActionWarn   Action = "warn"   // load the signature, logging a warning
+	ActionRefuse Action = "refuse" // don't load the signature
+)
+
+// DefaultBudget is the default time a signature may spend handling an event, on average.
+const DefaultBudget = 100 * time.Microsecond
+
+// Config is the signatures budget configuration.
+type Config struct {
+	Enabled bool
+	Events  string        // recorded events file the signatures are measured against
+	Budget  time.Duration // time a signature may spend handling an event, on average
+	Action  Action        // what is done with the signatures exceeding the budget
+}
+
+// Cost is the cost of a signature against an events stream.
+type Cost struct {
+	ID       string
+	Name     string
+	Events   int           // events of the stream the signature selects
+	Total    time.Duration // time spent handling the events
+	PerEvent time.Duration // time spent handling an event, on average
+}
+
+// LoadEvents reads a recorded events file: one event per line, as output by the json output.
+func LoadEvents(path string) ([]protocol.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			logger.Errorw("Closing file", "error", err)
+		}
+	}()
+
+	var events []protocol.Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e trace.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, errfmt.Errorf("events file %s, line %d: %v", path, line, err)
+		}
+		events = append(events, e.ToProtocol())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return events, nil
+}
+
+// Measure replays the events against every signature (initialized for the measure, without
+// data sources), and returns their costs, the costliest first. The signatures failing to
+// initialize aren't measured.
+func Measure(sigs []detect.Signature, events []protocol.Event) []Cost {
+	costs := make([]Cost, 0, len(sigs))
+
+	for _, sig := range sigs {
+		cost, err := measure(sig, events)
+		if err != nil {
+			logger.Debugw("Signature not measured", "error", err)
+			continue
+		}
+		costs = append(costs, cost)
+	}
+
+	sort.SliceStable(costs, func(i, j int) bool { return costs[i].PerEvent > costs[j].PerEvent })
+
+	return costs
+}
+
+func measure(sig detect.Signature, events []protocol.Event) (Cost, error) {
+	m, err := sig.GetMetadata()
+	if err != nil {
+		return Cost{}, errfmt.WrapError(err)
+	}
+	selectors, err := sig.GetSelectedEvents()
+	if err != nil {
+		return Cost{}, errfmt.Errorf("signature %s selected events: %v", m.ID, err)
+	}
+	states, err := state.New(state.DefaultMaxStates)
+	if err != nil {
+		return Cost{}, errfmt.WrapError(err)
+	}
+	err = sig.Init(detect.SignatureContext{
+		Callback: func(*detect.Finding) {},
+		Logger:   logger.Current(),
+		GetDataSource: func(namespace, id string) (detect.DataSource, bool) {
+			return nil, false
+		},
+		State: states,
+	})
+	if err != nil {
+		return Cost{}, errfmt.Errorf("signature %s init: %v", m.ID, err)
+	}
+
+	cost := Cost{ID: m.ID, Name: m.Name}
+	for _, event := range events {
+		if !selects(selectors, event) {
+			continue
+		}
+		start := time.Now()
+		_ = sig.OnEvent(event)
+		cost.Total += time.Since(start)
+		cost.Events++
+	}
+	if cost.Events > 0 {
+		cost.PerEvent = cost.Total / time.Duration(cost.Events)
+	}
+
+	return cost, nil
+}
+
+// selects returns whether the selectors select the event, as the engine dispatches them.
+func selects(selectors []detect.SignatureEventSelector, event protocol.Event) bool {
+	selector := event.Headers.Selector
+	for _, s := range selectors {
+		if s.Source != selector.Source {
+			continue
+		}
+		if s.Name != "" && s.Name != "*" && s.Name != selector.Name {
+			continue
+		}
+		if s.Origin != "" && s.Origin != "*" && s.Origin != selector.Origin {
+			continue
+		}
+		return true
+	}
+
+	return false
+}
+
+// Enforce measures the signatures against the events of the configuration, and returns the
+// signatures to load: all of them, warning about those exceeding the budget, or those within
+// the budget, according to the action.
+func Enforce(cfg Config, sigs []detect.Signature) ([]detect.Signature, error) {
+	if !cfg.Enabled {
+		return sigs, nil
+	}
+
+	events, err := LoadEvents(cfg.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	exceeding := make(map[string]bool)
+	for _, cost := range Measure(sigs, events) {
+		if cost.PerEvent <= cfg.Budget {
+			continue
+		}
+		exceeding[cost.ID] = true
+		logger.Warnw("Signature exceeds its budget",
+			"signature", cost.ID,
+			"name", cost.Name,
+			"per_event", cost.PerEvent,
+			"budget", cfg.Budget,
+			"refused", cfg.Action == ActionRefuse,
+		)
+	}
+	if cfg.Action != ActionRefuse || len(exceeding) == 0 {
+		return sigs, nil
+	}
+
+	kept := make([]detect.Signature, 0, len(sigs))
+	for _, sig := range sigs {
+		m, err := sig.GetMetadata()
+		if err == nil && exceeding[m.ID] {
+			continue
+		}
+		kept = append(kept, sig)
+	}
+
+	return kept, nil
+}
@@ -0,0 +1,121 @@
+package budget
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/protocol"
+)
+
+// sleepingSignature spends the given time handling every event of the given name.
+type sleepingSignature struct {
+	id    string
+	name  string
+	sleep time.Duration
+}
+
+func (s *sleepingSignature) GetMetadata() (detect.SignatureMetadata, error) {
+	return detect.SignatureMetadata{ID: s.id, Name: s.id}, nil
+}
+
+func (s *sleepingSignature) GetSelectedEvents() ([]detect.SignatureEventSelector, error) {
+	return []detect.SignatureEventSelector{{Source: "tracee", Name: s.name, Origin: "*"}}, nil
+}
+
+func (s *sleepingSignature) Init(detect.SignatureContext) error { return nil }
+
+func (s *sleepingSignature) OnEvent(protocol.Event) error {
+	time.Sleep(s.sleep)
+	return nil
+}
+
+func (s *sleepingSignature) OnSignal(detect.Signal) error { return nil }
+
+func (s *sleepingSignature) Close() {}
+
+func writeEvents(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	events := `{"timestamp":1,"eventName":"openat"}
+{"timestamp":2,"eventName":"sched_process_exec"}
+
+{"timestamp":3,"eventName":"openat"}
+`
+	require.NoError(t, os.WriteFile(path, []byte(events), 0600))
+
+	return path
+}
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	events, err := LoadEvents(writeEvents(t))
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	costs := Measure([]detect.Signature{
+		&sleepingSignature{id: "TST-1", name: "sched_process_exec"},
+		&sleepingSignature{id: "TST-2", name: "openat", sleep: 5 * time.Millisecond},
+		&sleepingSignature{id: "TST-3", name: "ptrace", sleep: 5 * time.Millisecond},
+	}, events)
+
+	require.Len(t, costs, 3)
+	assert.Equal(t, "TST-2", costs[0].ID)
+	assert.Equal(t, 2, costs[0].Events)
+	assert.GreaterOrEqual(t, costs[0].PerEvent, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, costs[0].Total, 10*time.Millisecond)
+	assert.Equal(t, 1, costs[1].Events+costs[2].Events, "events of the other signatures")
+}
+
+func TestEnforce(t *testing.T) {
+	t.Parallel()
+
+	path := writeEvents(t)
+	fast := &sleepingSignature{id: "TST-1", name: "openat"}
+	slow := &sleepingSignature{id: "TST-2", name: "openat", sleep: 5 * time.Millisecond}
+	sigs := []detect.Signature{fast, slow}
+
+	testCases := []struct {
+		name     string
+		cfg      Config
+		expected []detect.Signature
+	}{
+		{
+			name:     "disabled",
+			cfg:      Config{Events: path, Budget: time.Millisecond, Action: ActionRefuse},
+			expected: sigs,
+		},
+		{
+			name:     "warn",
+			cfg:      Config{Enabled: true, Events: path, Budget: time.Millisecond, Action: ActionWarn},
+			expected: sigs,
+		},
+		{
+			name:     "refuse",
+			cfg:      Config{Enabled: true, Events: path, Budget: time.Millisecond, Action: ActionRefuse},
+			expected: []detect.Signature{fast},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			kept, err := Enforce(tc.cfg, sigs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, kept)
+		})
+	}
+
+	_, err := Enforce(Config{Enabled: true, Events: filepath.Join(t.TempDir(), "missing.json")}, sigs)
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Package dedup collapses identical findings: the findings of the same signature, for the
+// same process (or container), within a time window, so a loop triggering a detection
+// doesn't flood the outputs with thousands of identical findings.
+package dedup
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// CountProperty is the finding metadata property holding the number of identical
+	// findings a finding was collapsed from.
+	CountProperty = "count"
+
+	// DefaultWindow is the default time window identical findings are collapsed in.
+	DefaultWindow = 10 * time.Second
+
+	maxWindows = 4096 // signatures and processes (or containers) whose findings are collapsed
+)
+
+// Scope is the findings considered identical, for a signature.
+type Scope string
+
+const (
+	ScopeProcess   Scope = "process"   // findings of the same process
+	ScopeContainer Scope = "container" // findings of the same container (or host process)
+)
+
+// Config is the findings deduplication configuration.
+type Config struct {
+	Enabled bool
+	Window  time.Duration // time window identical findings are collapsed in
+	Scope   Scope         // findings considered identical
+}
+
+// window is the time window of the identical findings following an emitted one.
+type window struct {
+	last     *trace.Event // last identical finding, emitted once the window expires
+	count    int          // identical findings collapsed into the last one
+	deadline time.Time
+}
+
+// Deduplicator emits the first finding of a time window, and collapses the identical
+// findings following it into the last one, emitted with their count once the window
+// expires. It isn't safe for concurrent use: it is fed by the engine pipeline stage.
+type Deduplicator struct {
+	cfg     Config
+	windows map[string]*window
+}
+
+// New creates a findings deduplicator.
+func New(cfg Config) (*Deduplicator, error) {
+	if cfg.Window <= 0 {
+		return nil, errfmt.Errorf("invalid finding dedup window: %v", cfg.Window)
+	}
+
+	return &Deduplicator{
+		cfg:     cfg,
+		windows: make(map[string]*window),
+	}, nil
+}
+
+// Add adds a finding, and returns the findings to emit now: the given one if it opens a
+// window (and the last finding of the previous window, if it expired), or none if it is
+// collapsed into the window.
+func (d *Deduplicator) Add(finding *trace.Event, now time.Time) []*trace.Event {
+	key := d.key(finding)
+
+	var emitted []*trace.Event
+	if w, ok := d.windows[key]; ok {
+		if now.Before(w.deadline) {
+			w.last = finding
+			w.count++
+			return nil
+		}
+		delete(d.windows, key)
+		if w.count > 0 {
+			emitted = append(emitted, release(w))
+		}
+	}
+
+	// if too many windows are open, the finding isn't collapsed
+	if len(d.windows) < maxWindows {
+		d.windows[key] = &window{deadline: now.Add(d.cfg.Window)}
+	}
+
+	return append(emitted, finding)
+}
+
+// Expire returns the last findings of the windows which expired, with the number of
+// identical findings collapsed into them.
+func (d *Deduplicator) Expire(now time.Time) []*trace.Event {
+	var emitted []*trace.Event
+
+	for key, w := range d.windows {
+		if now.Before(w.deadline) {
+			continue
+		}
+		delete(d.windows, key)
+		if w.count > 0 {
+			emitted = append(emitted, release(w))
+		}
+	}
+
+	return emitted
+}
+
+// Windows returns the number of open windows.
+func (d *Deduplicator) Windows() int {
+	return len(d.windows)
+}
+
+// key returns the window key of a finding: its signature and its process (or container).
+func (d *Deduplicator) key(finding *trace.Event) string {
+	if d.cfg.Scope == ScopeContainer && finding.Container.ID != "" {
+		return finding.EventName + "/c:" + finding.Container.ID
+	}
+	if finding.ProcessEntityId != 0 {
+		return finding.EventName + "/p:" + strconv.FormatUint(uint64(finding.ProcessEntityId), 10)
+	}
+
+	return finding.EventName + "/h:" + strconv.Itoa(finding.HostProcessID)
+}
+
+// release sets the number of identical findings of a window on its last finding, and
+// returns it.
+func release(w *window) *trace.Event {
+	if w.last.Metadata == nil {
+		w.last.Metadata = &trace.Metadata{}
+	}
+	if w.last.Metadata.Properties == nil {
+		w.last.Metadata.Properties = make(map[string]interface{})
+	}
+	w.last.Metadata.Properties[CountProperty] = w.count
+
+	return w.last
+}
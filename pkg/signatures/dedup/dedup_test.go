@@ -0,0 +1,80 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newFinding(name string, processID uint32, containerID string) *trace.Event {
+	return &trace.Event{
+		EventName:       name,
+		ProcessEntityId: processID,
+		Container:       trace.Container{ID: containerID},
+		Metadata:        &trace.Metadata{Properties: map[string]interface{}{"signatureID": "TRC-1"}},
+	}
+}
+
+func TestDeduplicatorProcessScope(t *testing.T) {
+	t.Parallel()
+
+	d, err := New(Config{Enabled: true, Window: time.Minute, Scope: ScopeProcess})
+	require.NoError(t, err)
+	now := time.Now()
+
+	first := newFinding("finding", 1, "abc")
+	assert.Equal(t, []*trace.Event{first}, d.Add(first, now))
+
+	// identical findings are collapsed, findings of other signatures or processes aren't
+	var last *trace.Event
+	for i := 0; i < 3; i++ {
+		last = newFinding("finding", 1, "abc")
+		assert.Empty(t, d.Add(last, now.Add(time.Second)))
+	}
+	other := newFinding("other", 1, "abc")
+	assert.Equal(t, []*trace.Event{other}, d.Add(other, now))
+	other = newFinding("finding", 2, "abc")
+	assert.Equal(t, []*trace.Event{other}, d.Add(other, now))
+	assert.Equal(t, 3, d.Windows())
+
+	assert.Empty(t, d.Expire(now.Add(time.Second)))
+
+	expired := d.Expire(now.Add(time.Minute))
+	require.Equal(t, []*trace.Event{last}, expired)
+	assert.Equal(t, 3, last.Metadata.Properties[CountProperty])
+	assert.Equal(t, "TRC-1", last.Metadata.Properties["signatureID"])
+	assert.NotContains(t, first.Metadata.Properties, CountProperty)
+	assert.Equal(t, 0, d.Windows())
+}
+
+func TestDeduplicatorContainerScope(t *testing.T) {
+	t.Parallel()
+
+	d, err := New(Config{Enabled: true, Window: time.Minute, Scope: ScopeContainer})
+	require.NoError(t, err)
+	now := time.Now()
+
+	first := newFinding("finding", 1, "abc")
+	assert.Equal(t, []*trace.Event{first}, d.Add(first, now))
+	last := newFinding("finding", 2, "abc")
+	assert.Empty(t, d.Add(last, now))
+
+	// the window expired: its last finding is emitted along with the new one
+	next := newFinding("finding", 3, "abc")
+	assert.Equal(t, []*trace.Event{last, next}, d.Add(next, now.Add(time.Minute)))
+	assert.Equal(t, 1, last.Metadata.Properties[CountProperty])
+
+	// a window without identical findings emits nothing when it expires
+	assert.Empty(t, d.Expire(now.Add(2*time.Minute)))
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Config{Enabled: true})
+	assert.ErrorContains(t, err, "invalid finding dedup window: 0s")
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aquasecurity/tracee/pkg/logger"
 	"github.com/aquasecurity/tracee/pkg/signatures/metrics"
+	"github.com/aquasecurity/tracee/pkg/signatures/state"
 	"github.com/aquasecurity/tracee/types/detect"
 	"github.com/aquasecurity/tracee/types/protocol"
 )
@@ -320,12 +321,17 @@ func (engine *Engine) loadSignature(signature detect.Signature) (string, error)
 		return "", fmt.Errorf("failed to store signature: signature \"%s\" already loaded", metadata.Name)
 	}
 	engine.signaturesMutex.RUnlock()
+	states, err := state.New(state.DefaultMaxStates)
+	if err != nil {
+		return "", fmt.Errorf("error creating states store of signature %s: %w", metadata.Name, err)
+	}
 	signatureCtx := detect.SignatureContext{
 		Callback: engine.matchHandler,
 		Logger:   logger.Current(),
 		GetDataSource: func(namespace, id string) (detect.DataSource, bool) {
 			return engine.GetDataSource(namespace, id)
 		},
+		State: states,
 	}
 	if err := signature.Init(signatureCtx); err != nil {
 		// failed to initialize
@@ -0,0 +1,456 @@
+// Package external runs signatures as external processes, so detections can be written in any
+// language (e.g. Python or Rust) and run as sidecar processes.
+//
+// The events selected by a signature are written to the standard input of its process, one per
+// line, as JSON objects holding a sequence number and the event (in the json output format):
+//
+//	{"seq":1,"event":{"timestamp":1700000000000000000,"eventName":"sched_process_exec",...}}
+//
+// The findings are read from its standard output, one per line, as JSON objects holding the
+// sequence number of the event which triggered the finding, and the finding data:
+//
+//	{"seq":1,"data":{"reason":"reverse shell"}}
+//
+// The standard error of the process is logged. A process which exits is restarted, with a
+// backoff, until the signature is closed.
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"gopkg.in/yaml.v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/protocol"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// ManifestSuffix is the suffix of the manifests of the external signatures, in the signatures
+// directories.
+const ManifestSuffix = ".signature.yaml"
+
+const (
+	// DefaultBuffer is the default number of events queued for a process: the events
+	// exceeding it are dropped, so a slow process doesn't slow down the events pipeline.
+	DefaultBuffer = 1000
+
+	maxBackoff   = 30 * time.Second // maximum time before restarting an exited process
+	closeTimeout = 5 * time.Second  // time a process has to exit once its input is closed
+)
+
+// Manifest describes an external signature: its metadata, the events it selects, and the
+// command of its process.
+type Manifest struct {
+	ID          string                 `yaml:"id"`
+	Name        string                 `yaml:"name"`
+	EventName   string                 `yaml:"eventName"`
+	Version     string                 `yaml:"version"`
+	Description string                 `yaml:"description"`
+	Tags        []string               `yaml:"tags"`
+	Properties  map[string]interface{} `yaml:"properties"`
+	Events      []ManifestEvent        `yaml:"events"`
+	Command     string                 `yaml:"command"`
+	Args        []string               `yaml:"args"`
+	Env         []string               `yaml:"env"`    // added to the environment of tracee
+	Buffer      int                    `yaml:"buffer"` // events queued for the process
+}
+
+// ManifestEvent is an event selected by an external signature.
+type ManifestEvent struct {
+	Name   string `yaml:"name"`
+	Origin string `yaml:"origin"` // container, host or * (default)
+}
+
+// LoadManifest reads the manifest of an external signature. The command of its process is run
+// from the directory of the manifest.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, errfmt.WrapError(err)
+	}
+
+	var m Manifest
+	if err := yaml.UnmarshalStrict(data, &m); err != nil {
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: %v", path, err)
+	}
+	switch {
+	case m.ID == "":
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: id is required", path)
+	case m.EventName == "":
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: eventName is required", path)
+	case m.Command == "":
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: command is required", path)
+	case len(m.Events) == 0:
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: events are required", path)
+	case m.Buffer < 0:
+		return Manifest{}, errfmt.Errorf("external signature manifest %s: invalid buffer: %d", path, m.Buffer)
+	}
+	if m.Name == "" {
+		m.Name = m.ID
+	}
+	if m.Buffer == 0 {
+		m.Buffer = DefaultBuffer
+	}
+
+	return m, nil
+}
+
+// Health is the state of the process of an external signature.
+type Health struct {
+	Running  bool
+	Restarts uint64 // times the process was restarted after exiting
+	Sent     uint64 // events written to the process
+	Dropped  uint64 // events dropped as the process queue was full
+	Findings uint64 // findings read from the process
+}
+
+// request is an event written to a process.
+type request struct {
+	Seq   uint64      `json:"seq"`
+	Event trace.Event `json:"event"`
+}
+
+// response is a finding read from a process.
+type response struct {
+	Seq  uint64                 `json:"seq"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// Signature is an external signature, implementing detect.Signature.
+type Signature struct {
+	manifest Manifest
+	dir      string
+
+	mutex   sync.Mutex // protects the fields below, and the queue from being closed
+	cb      detect.SignatureHandler
+	queue   chan request
+	stop    chan struct{} // closed once the signature is closed
+	done    chan struct{} // closed once the process exited for good
+	seq     uint64
+	pending *simplelru.LRU[uint64, trace.Event] // events written, by sequence number
+	started bool
+
+	running  atomic.Bool
+	restarts atomic.Uint64
+	sent     atomic.Uint64
+	dropped  atomic.Uint64
+	findings atomic.Uint64
+}
+
+// New creates the external signature of a manifest, whose command is run from the given
+// directory.
+func New(manifest Manifest, dir string) *Signature {
+	return &Signature{manifest: manifest, dir: dir}
+}
+
+// NewFromFile creates the external signature of a manifest file.
+func NewFromFile(path string) (*Signature, error) {
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(manifest, filepath.Dir(path)), nil
+}
+
+// GetMetadata implements the detect.Signature interface.
+func (s *Signature) GetMetadata() (detect.SignatureMetadata, error) {
+	return detect.SignatureMetadata{
+		ID:          s.manifest.ID,
+		Version:     s.manifest.Version,
+		Name:        s.manifest.Name,
+		EventName:   s.manifest.EventName,
+		Description: s.manifest.Description,
+		Tags:        s.manifest.Tags,
+		Properties:  s.manifest.Properties,
+	}, nil
+}
+
+// GetSelectedEvents implements the detect.Signature interface.
+func (s *Signature) GetSelectedEvents() ([]detect.SignatureEventSelector, error) {
+	selected := make([]detect.SignatureEventSelector, 0, len(s.manifest.Events))
+	for _, e := range s.manifest.Events {
+		origin := e.Origin
+		if origin == "" {
+			origin = "*"
+		}
+		selected = append(selected, detect.SignatureEventSelector{Source: "tracee", Name: e.Name, Origin: origin})
+	}
+
+	return selected, nil
+}
+
+// Init implements the detect.Signature interface, starting the process of the signature.
+func (s *Signature) Init(ctx detect.SignatureContext) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.cb = ctx.Callback
+	if s.started {
+		return nil
+	}
+
+	pending, err := simplelru.NewLRU[uint64, trace.Event](s.manifest.Buffer*2, nil)
+	if err != nil {
+		return errfmt.WrapError(err)
+	}
+	s.pending = pending
+	s.queue = make(chan request, s.manifest.Buffer)
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	s.started = true
+
+	// the first start is checked, so a signature which can't run isn't loaded
+	p, err := s.startProcess()
+	if err != nil {
+		s.started = false
+		return err
+	}
+	go s.monitor(p)
+
+	return nil
+}
+
+// OnEvent implements the detect.Signature interface, queuing the event for the process. The
+// event is dropped if the queue is full.
+func (s *Signature) OnEvent(event protocol.Event) error {
+	e, ok := event.Payload.(trace.Event)
+	if !ok {
+		return errfmt.Errorf("failed to cast event's payload")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.started {
+		return nil
+	}
+	s.seq++
+	select {
+	case s.queue <- request{Seq: s.seq, Event: e}:
+		s.pending.Add(s.seq, e)
+	default:
+		s.dropped.Add(1)
+	}
+
+	return nil
+}
+
+// OnSignal implements the detect.Signature interface.
+func (s *Signature) OnSignal(signal detect.Signal) error {
+	return nil
+}
+
+// Close implements the detect.Signature interface, closing the input of the process and
+// waiting for it to exit, so its last findings are reported.
+func (s *Signature) Close() {
+	s.mutex.Lock()
+	if !s.started {
+		s.mutex.Unlock()
+		return
+	}
+	s.started = false
+	close(s.stop)
+	close(s.queue)
+	s.mutex.Unlock()
+
+	<-s.done
+}
+
+// Health returns the state of the process of the signature.
+func (s *Signature) Health() Health {
+	return Health{
+		Running:  s.running.Load(),
+		Restarts: s.restarts.Load(),
+		Sent:     s.sent.Load(),
+		Dropped:  s.dropped.Load(),
+		Findings: s.findings.Load(),
+	}
+}
+
+// process is a running process of the signature.
+type process struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout io.ReadCloser
+}
+
+func (s *Signature) startProcess() (*process, error) {
+	cmd := exec.Command(s.manifest.Command, s.manifest.Args...)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(), s.manifest.Env...)
+	cmd.Stderr = &stderrLogger{id: s.manifest.ID}
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errfmt.Errorf("external signature %s: %v", s.manifest.ID, err)
+	}
+	s.running.Store(true)
+
+	return &process{cmd: cmd, stdin: stdin, stdout: stdout}, nil
+}
+
+// monitor runs the process of the signature, restarting it (with a backoff) when it exits,
+// until the signature is closed.
+func (s *Signature) monitor(p *process) {
+	defer close(s.done)
+
+	for {
+		err := s.run(p)
+		s.running.Store(false)
+
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+
+		restarts := s.restarts.Add(1)
+		backoff := time.Duration(restarts) * time.Second
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+		logger.Warnw("External signature process exited, restarting",
+			"signature", s.manifest.ID, "error", err, "restarts", restarts, "backoff", backoff)
+
+		select {
+		case <-s.stop:
+			return
+		case <-time.After(backoff):
+		}
+
+		p, err = s.startProcess()
+		for err != nil {
+			logger.Errorw("Restarting external signature process", "signature", s.manifest.ID, "error", err)
+			select {
+			case <-s.stop:
+				return
+			case <-time.After(maxBackoff):
+			}
+			p, err = s.startProcess()
+		}
+	}
+}
+
+// run writes the queued events to the process, while its findings are read, until it exits
+// (or until the queue is closed).
+func (s *Signature) run(p *process) error {
+	readerDone := make(chan struct{})
+	go func() {
+		defer close(readerDone)
+		s.readFindings(p.stdout)
+	}()
+
+	w := bufio.NewWriter(p.stdin)
+	encoder := json.NewEncoder(w)
+
+	for {
+		select {
+		case req, ok := <-s.queue:
+			if !ok {
+				return s.closeProcess(p, w, readerDone)
+			}
+			if err := encoder.Encode(req); err != nil {
+				// the process exited: its exit status is the error
+				<-readerDone
+				_ = p.stdin.Close()
+				return p.cmd.Wait()
+			}
+			s.sent.Add(1)
+			if len(s.queue) == 0 {
+				_ = w.Flush()
+			}
+		case <-readerDone:
+			_ = p.stdin.Close()
+			return p.cmd.Wait()
+		}
+	}
+}
+
+// closeProcess closes the input of the process, and waits for it to exit (or kills it).
+func (s *Signature) closeProcess(p *process, w *bufio.Writer, readerDone <-chan struct{}) error {
+	_ = w.Flush()
+	_ = p.stdin.Close()
+
+	select {
+	case <-readerDone:
+	case <-time.After(closeTimeout):
+		logger.Warnw("External signature process didn't exit, killing it", "signature", s.manifest.ID)
+		_ = p.cmd.Process.Kill()
+		<-readerDone
+	}
+
+	return p.cmd.Wait()
+}
+
+// readFindings reads the findings of the process, reporting those of the events written to it.
+func (s *Signature) readFindings(stdout io.Reader) {
+	metadata, _ := s.GetMetadata()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var resp response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			logger.Errorw("Invalid external signature finding", "signature", s.manifest.ID, "error", err)
+			continue
+		}
+
+		s.mutex.Lock()
+		trigger, ok := s.pending.Get(resp.Seq)
+		cb := s.cb
+		s.mutex.Unlock()
+		if !ok {
+			logger.Errorw("External signature finding of an unknown event", "signature", s.manifest.ID, "seq", resp.Seq)
+			continue
+		}
+
+		s.findings.Add(1)
+		cb(&detect.Finding{
+			Data:        resp.Data,
+			Event:       trigger.ToProtocol(),
+			SigMetadata: metadata,
+		})
+	}
+}
+
+// stderrLogger logs the standard error of a process, line by line.
+type stderrLogger struct {
+	id  string
+	buf []byte
+}
+
+func (l *stderrLogger) Write(p []byte) (int, error) {
+	l.buf = append(l.buf, p...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		logger.Infow("External signature", "signature", l.id, "stderr", string(l.buf[:i]))
+		l.buf = l.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+var _ detect.Signature = (*Signature)(nil)
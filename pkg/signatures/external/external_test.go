@@ -0,0 +1,211 @@
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// TestHelperProcess isn't a test: it is the external signature process of the tests, reporting
+// a finding for every event of the "nc" process, and exiting on the event of the "exit" process.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("TRACEE_EXTERNAL_SIGNATURE_HELPER") != "1" {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		switch req.Event.ProcessName {
+		case "nc":
+			fmt.Printf(`{"seq":%d,"data":{"binary":"nc"}}`+"\n", req.Seq)
+		case "exit":
+			os.Exit(2)
+		}
+	}
+	os.Exit(0)
+}
+
+func helperManifest(t *testing.T) Manifest {
+	t.Helper()
+
+	exe, err := os.Executable()
+	require.NoError(t, err)
+
+	return Manifest{
+		ID:        "EXT-1",
+		Name:      "helper",
+		EventName: "helper",
+		Events:    []ManifestEvent{{Name: "sched_process_exec"}},
+		Command:   exe,
+		Args:      []string{"-test.run=TestHelperProcess"},
+		Env:       []string{"TRACEE_EXTERNAL_SIGNATURE_HELPER=1"},
+		Buffer:    DefaultBuffer,
+	}
+}
+
+// findings collects the findings of a signature.
+type findings struct {
+	mutex    sync.Mutex
+	findings []*detect.Finding
+}
+
+func (f *findings) add(finding *detect.Finding) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.findings = append(f.findings, finding)
+}
+
+func (f *findings) get() []*detect.Finding {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]*detect.Finding(nil), f.findings...)
+}
+
+func execEvent(ts int, processName string) trace.Event {
+	return trace.Event{Timestamp: ts, EventName: "sched_process_exec", ProcessName: processName}
+}
+
+func TestSignature(t *testing.T) {
+	t.Parallel()
+
+	sig := New(helperManifest(t), t.TempDir())
+	var f findings
+	require.NoError(t, sig.Init(detect.SignatureContext{Callback: f.add}))
+
+	for ts, name := range []string{"ls", "nc", "cat", "nc"} {
+		require.NoError(t, sig.OnEvent(execEvent(ts, name).ToProtocol()))
+	}
+	sig.Close() // the process reports its findings before exiting
+
+	got := f.get()
+	require.Len(t, got, 2)
+	for i, ts := range []int{1, 3} {
+		assert.Equal(t, "EXT-1", got[i].SigMetadata.ID)
+		assert.Equal(t, map[string]interface{}{"binary": "nc"}, got[i].Data)
+		trigger, ok := got[i].Event.Payload.(trace.Event)
+		require.True(t, ok)
+		assert.Equal(t, ts, trigger.Timestamp)
+	}
+
+	health := sig.Health()
+	assert.False(t, health.Running)
+	assert.Equal(t, Health{Sent: 4, Findings: 2}, health)
+}
+
+func TestSignatureRestart(t *testing.T) {
+	t.Parallel()
+
+	sig := New(helperManifest(t), t.TempDir())
+	var f findings
+	require.NoError(t, sig.Init(detect.SignatureContext{Callback: f.add}))
+
+	require.NoError(t, sig.OnEvent(execEvent(1, "exit").ToProtocol()))
+	require.Eventually(t, func() bool { return sig.Health().Restarts == 1 && sig.Health().Running },
+		5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, sig.OnEvent(execEvent(2, "nc").ToProtocol()))
+	require.Eventually(t, func() bool { return len(f.get()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	sig.Close()
+}
+
+func TestSignatureInvalidCommand(t *testing.T) {
+	t.Parallel()
+
+	manifest := helperManifest(t)
+	manifest.Command = filepath.Join(t.TempDir(), "missing")
+	sig := New(manifest, t.TempDir())
+
+	assert.ErrorContains(t, sig.Init(detect.SignatureContext{}), "external signature EXT-1")
+	sig.Close()
+}
+
+func TestLoadManifest(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		manifest      string
+		expected      Manifest
+		expectedError string
+	}{
+		{
+			name: "valid",
+			manifest: `id: EXT-1
+eventName: reverse_shell
+description: reverse shell
+properties:
+  Severity: 3
+events:
+  - name: sched_process_exec
+  - name: security_socket_connect
+    origin: container
+command: python3
+args: [detect.py]
+`,
+			expected: Manifest{
+				ID:          "EXT-1",
+				Name:        "EXT-1",
+				EventName:   "reverse_shell",
+				Description: "reverse shell",
+				Properties:  map[string]interface{}{"Severity": 3},
+				Events: []ManifestEvent{
+					{Name: "sched_process_exec"},
+					{Name: "security_socket_connect", Origin: "container"},
+				},
+				Command: "python3",
+				Args:    []string{"detect.py"},
+				Buffer:  DefaultBuffer,
+			},
+		},
+		{
+			name:          "missing command",
+			manifest:      "id: EXT-1\neventName: e\nevents: [{name: openat}]\n",
+			expectedError: "command is required",
+		},
+		{
+			name:          "missing events",
+			manifest:      "id: EXT-1\neventName: e\ncommand: sh\n",
+			expectedError: "events are required",
+		},
+		{
+			name:          "unknown field",
+			manifest:      "id: EXT-1\neventName: e\ncommand: sh\nevents: [{name: openat}]\nlanguage: python\n",
+			expectedError: "field language not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "test"+ManifestSuffix)
+			require.NoError(t, os.WriteFile(path, []byte(tc.manifest), 0600))
+
+			manifest, err := LoadManifest(path)
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, manifest)
+		})
+	}
+}
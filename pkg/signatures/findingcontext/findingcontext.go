@@ -0,0 +1,282 @@
+// Package findingcontext attaches windows of interest to findings: the events of the
+// process (or container) which triggered a finding, preceding and following it, so a
+// finding can be investigated without querying the full events stream.
+package findingcontext
+
+import (
+	"strconv"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// BeforeProperty is the finding metadata property holding the preceding events.
+	BeforeProperty = "contextBefore"
+	// AfterProperty is the finding metadata property holding the following events.
+	AfterProperty = "contextAfter"
+
+	// DefaultBefore is the default number of events preceding a finding attached to it.
+	DefaultBefore = 10
+	// DefaultAfter is the default number of events following a finding attached to it.
+	DefaultAfter = 10
+	// DefaultTimeout is the default time a finding is held for its following events.
+	DefaultTimeout = 5 * time.Second
+
+	maxRings   = 4096 // processes (or containers) whose recent events are kept
+	maxPending = 1024 // findings held for their following events
+)
+
+// Scope is the events a finding context is made of.
+type Scope string
+
+const (
+	ScopeProcess   Scope = "process"   // events of the process which triggered the finding
+	ScopeContainer Scope = "container" // events of the container (or host process) which triggered the finding
+)
+
+// Config is the findings context configuration.
+type Config struct {
+	Enabled bool
+	Before  int           // events preceding the finding
+	After   int           // events following the finding
+	Scope   Scope         // events the context is made of
+	Timeout time.Duration // maximum time a finding is held for its following events
+}
+
+// pendingFinding is a finding held until its following events are collected.
+type pendingFinding struct {
+	finding  *trace.Event
+	after    []trace.Event
+	deadline time.Time
+}
+
+// Buffer keeps the recent events of every process (or container), and attaches them to
+// the findings. It isn't safe for concurrent use: it is fed by the engine pipeline stage.
+type Buffer struct {
+	cfg      Config
+	rings    *lru.Cache[string, *ring]
+	pending  map[string][]*pendingFinding
+	nPending int
+}
+
+// New creates a findings context buffer.
+func New(cfg Config) (*Buffer, error) {
+	if cfg.Before < 0 || cfg.After < 0 {
+		return nil, errfmt.Errorf("invalid finding context size: %d before, %d after", cfg.Before, cfg.After)
+	}
+
+	rings, err := lru.New[string, *ring](maxRings)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Buffer{
+		cfg:     cfg,
+		rings:   rings,
+		pending: make(map[string][]*pendingFinding),
+	}, nil
+}
+
+// Observe records an event (not a finding), and returns the held findings it completed
+// the context of.
+func (b *Buffer) Observe(event *trace.Event) []*trace.Event {
+	key := b.key(event)
+	evt := copyEvent(event)
+
+	r, ok := b.rings.Get(key)
+	if !ok {
+		// the events following a finding may be observed before the finding is held (the
+		// engine runs concurrently): they are kept in the ring as well.
+		r = newRing(b.cfg.Before + b.cfg.After)
+		b.rings.Add(key, r)
+	}
+	r.add(evt)
+
+	pending, ok := b.pending[key]
+	if !ok {
+		return nil
+	}
+
+	var released []*trace.Event
+	kept := pending[:0]
+	for _, p := range pending {
+		if evt.Timestamp > p.finding.Timestamp {
+			p.after = append(p.after, evt)
+		}
+		if len(p.after) >= b.cfg.After {
+			released = append(released, b.release(p))
+			continue
+		}
+		kept = append(kept, p)
+	}
+	b.setPending(key, kept)
+
+	return released
+}
+
+// Hold attaches the preceding events to a finding, and holds it until its following
+// events are observed (or its timeout expires). It returns the findings to emit now: the
+// given one if it isn't held, or the oldest held one if too many are.
+func (b *Buffer) Hold(finding *trace.Event, now time.Time) []*trace.Event {
+	key := b.key(finding)
+
+	p := &pendingFinding{
+		finding:  finding,
+		deadline: now.Add(b.cfg.Timeout),
+	}
+
+	var before []trace.Event
+	if r, ok := b.rings.Get(key); ok {
+		for _, evt := range r.events() {
+			switch {
+			case evt.Timestamp < finding.Timestamp:
+				before = append(before, evt)
+			case evt.Timestamp > finding.Timestamp && len(p.after) < b.cfg.After:
+				p.after = append(p.after, evt) // observed before the finding was detected
+			}
+		}
+	}
+	if len(before) > b.cfg.Before {
+		before = before[len(before)-b.cfg.Before:]
+	}
+	setProperty(finding, BeforeProperty, before)
+
+	if len(p.after) >= b.cfg.After {
+		return []*trace.Event{b.release(p)}
+	}
+
+	var released []*trace.Event
+	if b.nPending >= maxPending {
+		released = append(released, b.releaseOldest())
+	}
+	b.setPending(key, append(b.pending[key], p))
+
+	return released
+}
+
+// Expire returns the held findings whose timeout expired, with the following events
+// observed so far.
+func (b *Buffer) Expire(now time.Time) []*trace.Event {
+	var released []*trace.Event
+
+	for key, pending := range b.pending {
+		kept := pending[:0]
+		for _, p := range pending {
+			if now.Before(p.deadline) {
+				kept = append(kept, p)
+				continue
+			}
+			released = append(released, b.release(p))
+		}
+		b.setPending(key, kept)
+	}
+
+	return released
+}
+
+// Pending returns the number of held findings.
+func (b *Buffer) Pending() int {
+	return b.nPending
+}
+
+// key returns the ring key of an event, according to the scope.
+func (b *Buffer) key(event *trace.Event) string {
+	if b.cfg.Scope == ScopeContainer && event.Container.ID != "" {
+		return "c:" + event.Container.ID
+	}
+	if event.ProcessEntityId != 0 {
+		return "p:" + strconv.FormatUint(uint64(event.ProcessEntityId), 10)
+	}
+
+	return "h:" + strconv.Itoa(event.HostProcessID)
+}
+
+func (b *Buffer) setPending(key string, pending []*pendingFinding) {
+	b.nPending += len(pending) - len(b.pending[key])
+	if len(pending) == 0 {
+		delete(b.pending, key)
+		return
+	}
+	b.pending[key] = pending
+}
+
+// release attaches the following events to a held finding, and returns it.
+func (b *Buffer) release(p *pendingFinding) *trace.Event {
+	setProperty(p.finding, AfterProperty, p.after)
+	return p.finding
+}
+
+// releaseOldest releases the held finding with the earliest deadline.
+func (b *Buffer) releaseOldest() *trace.Event {
+	var oldestKey string
+	var oldest int
+	for key, pending := range b.pending {
+		for i, p := range pending {
+			if oldestKey == "" || p.deadline.Before(b.pending[oldestKey][oldest].deadline) {
+				oldestKey, oldest = key, i
+			}
+		}
+	}
+
+	pending := b.pending[oldestKey]
+	p := pending[oldest]
+	b.setPending(oldestKey, append(pending[:oldest:oldest], pending[oldest+1:]...))
+
+	return b.release(p)
+}
+
+func setProperty(finding *trace.Event, name string, events []trace.Event) {
+	if finding.Metadata == nil {
+		finding.Metadata = &trace.Metadata{}
+	}
+	if finding.Metadata.Properties == nil {
+		finding.Metadata.Properties = make(map[string]interface{})
+	}
+	if events == nil {
+		events = []trace.Event{}
+	}
+	finding.Metadata.Properties[name] = events
+}
+
+// copyEvent copies an event, as events are returned to a pool once printed. The arguments
+// values are shared: they aren't modified once parsed.
+func copyEvent(event *trace.Event) trace.Event {
+	evt := *event
+	evt.Args = append([]trace.Argument(nil), event.Args...)
+
+	return evt
+}
+
+// ring is a fixed size buffer of the most recent events.
+type ring struct {
+	buf  []trace.Event
+	next int
+	full bool
+}
+
+func newRing(size int) *ring {
+	if size < 1 {
+		size = 1
+	}
+	return &ring{buf: make([]trace.Event, size)}
+}
+
+func (r *ring) add(event trace.Event) {
+	r.buf[r.next] = event
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// events returns the events of the ring, oldest first.
+func (r *ring) events() []trace.Event {
+	if !r.full {
+		return r.buf[:r.next]
+	}
+	return append(append([]trace.Event(nil), r.buf[r.next:]...), r.buf[:r.next]...)
+}
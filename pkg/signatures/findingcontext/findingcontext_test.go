@@ -0,0 +1,150 @@
+package findingcontext
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newEvent(ts int, processID uint32, containerID string) *trace.Event {
+	return &trace.Event{
+		Timestamp:       ts,
+		ProcessEntityId: processID,
+		Container:       trace.Container{ID: containerID},
+		EventName:       "event",
+	}
+}
+
+func newFinding(ts int, processID uint32, containerID string) *trace.Event {
+	finding := newEvent(ts, processID, containerID)
+	finding.EventName = "finding"
+	finding.Metadata = &trace.Metadata{Properties: map[string]interface{}{"signatureID": "TRC-1"}}
+	return finding
+}
+
+func timestamps(t *testing.T, finding *trace.Event, property string) []int {
+	t.Helper()
+
+	events, ok := finding.Metadata.Properties[property].([]trace.Event)
+	require.True(t, ok, "property %s not set", property)
+
+	ts := make([]int, 0, len(events))
+	for _, evt := range events {
+		ts = append(ts, evt.Timestamp)
+	}
+	return ts
+}
+
+func TestBufferProcessScope(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{Enabled: true, Before: 2, After: 2, Scope: ScopeProcess, Timeout: time.Minute})
+	require.NoError(t, err)
+	now := time.Now()
+
+	for ts := 1; ts <= 4; ts++ {
+		assert.Empty(t, b.Observe(newEvent(ts, 1, "")))
+	}
+	assert.Empty(t, b.Observe(newEvent(5, 2, ""))) // another process
+
+	// the finding of the event 4, detected once event 5 of the process is observed
+	assert.Empty(t, b.Observe(newEvent(5, 1, "")))
+	finding := newFinding(4, 1, "")
+	assert.Empty(t, b.Hold(finding, now))
+	assert.Equal(t, 1, b.Pending())
+	assert.Equal(t, []int{2, 3}, timestamps(t, finding, BeforeProperty))
+
+	assert.Empty(t, b.Observe(newEvent(6, 2, "")))
+	released := b.Observe(newEvent(6, 1, ""))
+	require.Equal(t, []*trace.Event{finding}, released)
+	assert.Equal(t, []int{5, 6}, timestamps(t, finding, AfterProperty))
+	assert.Equal(t, 0, b.Pending())
+	assert.Equal(t, "TRC-1", finding.Metadata.Properties["signatureID"])
+}
+
+func TestBufferContainerScope(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{Enabled: true, Before: 3, After: 1, Scope: ScopeContainer, Timeout: time.Minute})
+	require.NoError(t, err)
+
+	b.Observe(newEvent(1, 1, "abc"))
+	b.Observe(newEvent(2, 2, "abc"))
+	b.Observe(newEvent(3, 3, "def"))
+
+	finding := newFinding(4, 2, "abc")
+	assert.Empty(t, b.Hold(finding, time.Now()))
+	assert.Equal(t, []int{1, 2}, timestamps(t, finding, BeforeProperty))
+
+	assert.Empty(t, b.Observe(newEvent(5, 3, "def")))
+	assert.Equal(t, []*trace.Event{finding}, b.Observe(newEvent(5, 1, "abc")))
+	assert.Equal(t, []int{5}, timestamps(t, finding, AfterProperty))
+}
+
+func TestBufferExpire(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{Enabled: true, Before: 1, After: 5, Scope: ScopeProcess, Timeout: time.Second})
+	require.NoError(t, err)
+	now := time.Now()
+
+	finding := newFinding(1, 1, "")
+	assert.Empty(t, b.Hold(finding, now))
+	b.Observe(newEvent(2, 1, ""))
+
+	assert.Empty(t, b.Expire(now.Add(500*time.Millisecond)))
+	assert.Equal(t, []*trace.Event{finding}, b.Expire(now.Add(time.Second)))
+	assert.Equal(t, []int{}, timestamps(t, finding, BeforeProperty))
+	assert.Equal(t, []int{2}, timestamps(t, finding, AfterProperty))
+	assert.Equal(t, 0, b.Pending())
+}
+
+func TestBufferNoAfter(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{Enabled: true, Before: 1, After: 0, Scope: ScopeProcess, Timeout: time.Second})
+	require.NoError(t, err)
+
+	b.Observe(newEvent(1, 1, ""))
+	finding := newFinding(2, 1, "")
+	assert.Equal(t, []*trace.Event{finding}, b.Hold(finding, time.Now()))
+	assert.Equal(t, []int{1}, timestamps(t, finding, BeforeProperty))
+	assert.Equal(t, 0, b.Pending())
+}
+
+func TestBufferMaxPending(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{Enabled: true, Before: 1, After: 1, Scope: ScopeProcess, Timeout: time.Minute})
+	require.NoError(t, err)
+	now := time.Now()
+
+	first := newFinding(1, 1, "")
+	assert.Empty(t, b.Hold(first, now))
+	for i := 1; i < maxPending; i++ {
+		assert.Empty(t, b.Hold(newFinding(1, uint32(i+1), ""), now.Add(time.Duration(i))))
+	}
+	assert.Equal(t, maxPending, b.Pending())
+
+	assert.Equal(t, []*trace.Event{first}, b.Hold(newFinding(1, 1, ""), now.Add(time.Hour)))
+	assert.Equal(t, maxPending, b.Pending())
+}
+
+func TestRing(t *testing.T) {
+	t.Parallel()
+
+	r := newRing(3)
+	assert.Empty(t, r.events())
+
+	for ts := 1; ts <= 5; ts++ {
+		r.add(trace.Event{Timestamp: ts})
+	}
+	events := r.events()
+	require.Len(t, events, 3)
+	assert.Equal(t, 3, events[0].Timestamp)
+	assert.Equal(t, 5, events[2].Timestamp)
+}
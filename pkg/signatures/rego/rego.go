@@ -1,5 +1,7 @@
 package rego
 
+import "time"
+
 // Config represents configurations to the rego engine
 type Config struct {
 	// RuntimeTarget, currently only supports rego
@@ -8,4 +10,8 @@ type Config struct {
 	PartialEval bool
 	// Aggregation Policy complication
 	AIO bool
+	// Data documents referenced by the signatures: their sources (files or http(s) URLs), by name
+	Data map[string]string
+	// Interval between two refreshes of the data documents (never refreshed if 0)
+	DataRefresh time.Duration
 }
@@ -43,6 +43,9 @@ type Options struct {
 	//
 	// https://blog.openpolicyagent.org/partial-evaluation-162750eaf422
 	OPAPartial bool
+
+	// Data optionally specifies the data documents referenced by the modules.
+	Data *Data
 }
 
 type Option func(*Options)
@@ -59,6 +62,12 @@ func OPAPartial(partial bool) Option {
 	}
 }
 
+func OPAData(data *Data) Option {
+	return func(o *Options) {
+		o.Data = data
+	}
+}
+
 func newDefaultOptions() *Options {
 	return &Options{
 		OPATarget:  compile.TargetRego,
@@ -69,8 +78,11 @@ func newDefaultOptions() *Options {
 type aio struct {
 	cb       detect.SignatureHandler
 	metadata detect.SignatureMetadata
+	options  *Options
+	compiler *ast.Compiler
 
 	preparedQuery   rego.PreparedEvalQuery
+	dataVersion     uint64 // version of the data documents snapshot preparedQuery was prepared with
 	sigIDToMetadata map[string]detect.SignatureMetadata
 	selectedEvents  []detect.SignatureEventSelector
 }
@@ -117,31 +129,18 @@ func NewAIO(modules map[string]string, opts ...Option) (detect.Signature, error)
 		return nil, fmt.Errorf("mapping output to selected events: %w", err)
 	}
 
-	var peq rego.PreparedEvalQuery
-
-	if options.OPAPartial {
-		pr, err := rego.New(
-			rego.Compiler(compiler),
-			rego.Query(queryMatchAll),
-		).PartialResult(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("partially evaluating %s query: %w", queryMatchAll, err)
-		}
-		peq, err = pr.Rego(
-			rego.Target(options.OPATarget),
-		).PrepareForEval(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("preparing %s query: %w", queryMatch, err)
-		}
-	} else {
-		peq, err = rego.New(
-			rego.Target(options.OPATarget),
-			rego.Compiler(compiler),
-			rego.Query(queryMatchAll),
-		).PrepareForEval(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("preparing %s query: %w", queryMetadataAll, err)
-		}
+	a := &aio{
+		options:         options,
+		compiler:        compiler,
+		sigIDToMetadata: sigIDToMetadata,
+	}
+	snapshot := options.Data.Snapshot()
+	a.preparedQuery, err = a.prepare(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		a.dataVersion = snapshot.Version
 	}
 
 	var sigIDs []string
@@ -167,12 +166,65 @@ func NewAIO(modules map[string]string, opts ...Option) (detect.Signature, error)
 		Name:    "AIO",
 	}
 
-	return &aio{
-		metadata:        metadata,
-		preparedQuery:   peq,
-		sigIDToMetadata: sigIDToMetadata,
-		selectedEvents:  selectedEvents,
-	}, nil
+	a.metadata = metadata
+	a.selectedEvents = selectedEvents
+
+	return a, nil
+}
+
+// prepare prepares the all in one query, evaluated against the given data documents snapshot.
+func (a *aio) prepare(snapshot *Snapshot) (rego.PreparedEvalQuery, error) {
+	ctx := context.TODO()
+	var store []func(*rego.Rego)
+	if snapshot != nil {
+		store = append(store, rego.Store(snapshot.store))
+	}
+
+	if !a.options.OPAPartial {
+		peq, err := rego.New(append(store,
+			rego.Target(a.options.OPATarget),
+			rego.Compiler(a.compiler),
+			rego.Query(queryMatchAll),
+		)...).PrepareForEval(ctx)
+		if err != nil {
+			return rego.PreparedEvalQuery{}, fmt.Errorf("preparing %s query: %w", queryMetadataAll, err)
+		}
+		return peq, nil
+	}
+
+	pr, err := rego.New(append(store,
+		rego.Compiler(a.compiler),
+		rego.Query(queryMatchAll),
+	)...).PartialResult(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("partially evaluating %s query: %w", queryMatchAll, err)
+	}
+	peq, err := pr.Rego(append(store,
+		rego.Target(a.options.OPATarget),
+	)...).PrepareForEval(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("preparing %s query: %w", queryMatch, err)
+	}
+
+	return peq, nil
+}
+
+// refreshData prepares the query again once the data documents changed.
+func (a *aio) refreshData() error {
+	snapshot := a.options.Data.Snapshot()
+	if snapshot == nil || snapshot.Version == a.dataVersion {
+		return nil
+	}
+
+	// the failing snapshot isn't retried: the previous one is used until the next update
+	a.dataVersion = snapshot.Version
+	peq, err := a.prepare(snapshot)
+	if err != nil {
+		return fmt.Errorf("data documents version %d: %w", snapshot.Version, err)
+	}
+	a.preparedQuery = peq
+
+	return nil
 }
 
 func (a *aio) Init(ctx detect.SignatureContext) error {
@@ -189,6 +241,10 @@ func (a *aio) GetSelectedEvents() ([]detect.SignatureEventSelector, error) {
 }
 
 func (a *aio) OnEvent(event protocol.Event) error {
+	if err := a.refreshData(); err != nil {
+		return err
+	}
+
 	ee, ok := event.Payload.(trace.Event)
 
 	if !ok {
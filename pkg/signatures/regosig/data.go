@@ -0,0 +1,179 @@
+package regosig
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
+	"github.com/open-policy-agent/opa/util"
+
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+const (
+	// DataDocumentsRoot is the root of the data documents in rego: the document <name> is
+	// referenced as data.documents.<name> (e.g. data.documents.allowlist).
+	DataDocumentsRoot = "documents"
+	// DefaultDataRefresh is the default interval between two refreshes of the data documents.
+	DefaultDataRefresh = time.Minute
+
+	maxDataDocumentSize = 64 * 1024 * 1024
+)
+
+// Snapshot is a version of the data documents. A snapshot is never modified: a refresh
+// changing the documents creates a new snapshot, with the next version.
+type Snapshot struct {
+	Version uint64
+	Loaded  time.Time
+
+	store storage.Store
+}
+
+// Data holds the data documents the rego signatures reference (e.g. allowlists of hashes or
+// images), loaded from files or http(s) URLs (as JSON or YAML). The documents are refreshed
+// at runtime, the signatures evaluating the latest snapshot without being reloaded.
+type Data struct {
+	sources  map[string]string // document sources, by document name
+	interval time.Duration     // interval between two refreshes (disabled if 0)
+	client   *http.Client
+
+	snapshot atomic.Pointer[Snapshot]
+	digest   [sha256.Size]byte // digest of the documents of the latest snapshot
+}
+
+// NewData returns the data documents of the given sources (by document name), loading their
+// first snapshot.
+func NewData(ctx context.Context, sources map[string]string, interval time.Duration) (*Data, error) {
+	for name := range sources {
+		if name == "" || strings.ContainsAny(name, "./") {
+			return nil, fmt.Errorf("invalid data document name: %q", name)
+		}
+	}
+
+	d := &Data{
+		sources:  sources,
+		interval: interval,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}
+	if _, err := d.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Snapshot returns the latest snapshot of the data documents (nil without data documents).
+func (d *Data) Snapshot() *Snapshot {
+	if d == nil {
+		return nil
+	}
+
+	return d.snapshot.Load()
+}
+
+// Refresh loads the data documents again, and returns whether they changed, a new snapshot
+// being created then. The current snapshot is kept if a document fails to load.
+func (d *Data) Refresh(ctx context.Context) (bool, error) {
+	names := make([]string, 0, len(d.sources))
+	for name := range d.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	documents := make(map[string]interface{}, len(names))
+	hash := sha256.New()
+	for _, name := range names {
+		raw, err := d.read(ctx, d.sources[name])
+		if err != nil {
+			return false, fmt.Errorf("loading data document %s: %w", name, err)
+		}
+		var document interface{}
+		if err := util.Unmarshal(raw, &document); err != nil {
+			return false, fmt.Errorf("decoding data document %s: %w", name, err)
+		}
+		documents[name] = document
+		fmt.Fprintf(hash, "%s\x00%d\x00", name, len(raw))
+		hash.Write(raw)
+	}
+
+	var digest [sha256.Size]byte
+	copy(digest[:], hash.Sum(nil))
+
+	current := d.snapshot.Load()
+	if current != nil && digest == d.digest {
+		return false, nil
+	}
+
+	next := &Snapshot{
+		Version: 1,
+		Loaded:  time.Now(),
+		store:   inmem.NewFromObject(map[string]interface{}{DataDocumentsRoot: documents}),
+	}
+	if current != nil {
+		next.Version = current.Version + 1
+	}
+	d.digest = digest
+	d.snapshot.Store(next)
+
+	return true, nil
+}
+
+func (d *Data) read(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Errorw("Closing data document response", "error", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxDataDocumentSize))
+}
+
+// Run refreshes the data documents at every refresh interval, until the context is done.
+func (d *Data) Run(ctx context.Context) {
+	if d == nil || d.interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			changed, err := d.Refresh(ctx)
+			if err != nil {
+				logger.Warnw("Refreshing rego data documents", "error", err)
+				continue
+			}
+			if changed {
+				logger.Infow("Rego data documents updated", "version", d.Snapshot().Version)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
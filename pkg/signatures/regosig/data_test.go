@@ -0,0 +1,165 @@
+package regosig_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-policy-agent/opa/compile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
+	"github.com/aquasecurity/tracee/signatures/signaturestest"
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const testRegoCodeAllowlist = `package tracee.TRC_ALLOWLIST
+
+__rego_metadoc__ := {
+	"id": "TRC-ALLOWLIST",
+	"version": "0.1.0",
+	"name": "allowlist",
+}
+
+tracee_selected_events[eventSelector] {
+	eventSelector := {
+		"source": "tracee",
+		"name": "sched_process_exec"
+	}
+}
+
+tracee_match {
+	not data.documents.allowlist.binaries[input.processName]
+}
+`
+
+func writeDocument(t *testing.T, path string, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestData_Refresh(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	writeDocument(t, path, `{"binaries": {"ls": true}}`)
+
+	data, err := regosig.NewData(context.Background(), map[string]string{"allowlist": path}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), data.Snapshot().Version)
+
+	// unchanged documents don't create a new snapshot
+	changed, err := data.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, uint64(1), data.Snapshot().Version)
+
+	writeDocument(t, path, "binaries:\n  ls: true\n  nc: true\n")
+	changed, err = data.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, uint64(2), data.Snapshot().Version)
+
+	// the current snapshot is kept if a document fails to load
+	require.NoError(t, os.Remove(path))
+	_, err = data.Refresh(context.Background())
+	assert.ErrorContains(t, err, "loading data document allowlist")
+	assert.Equal(t, uint64(2), data.Snapshot().Version)
+}
+
+func TestData_HTTP(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/allowlist.json" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"binaries": {"ls": true}}`)
+	}))
+	defer server.Close()
+
+	data, err := regosig.NewData(context.Background(), map[string]string{"allowlist": server.URL + "/allowlist.json"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), data.Snapshot().Version)
+
+	_, err = regosig.NewData(context.Background(), map[string]string{"allowlist": server.URL + "/missing.json"}, 0)
+	assert.ErrorContains(t, err, "unexpected status: 404 Not Found")
+}
+
+func TestNewData_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	_, err := regosig.NewData(context.Background(), map[string]string{"allow.list": "allowlist.json"}, 0)
+	assert.ErrorContains(t, err, `invalid data document name: "allow.list"`)
+}
+
+func TestRegoSignature_Data(t *testing.T) {
+	t.Parallel()
+
+	for _, partial := range []bool{false, true} {
+		partial := partial
+
+		t.Run(fmt.Sprintf("partial=%t", partial), func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "allowlist.json")
+			writeDocument(t, path, `{"binaries": {"ls": true}}`)
+			data, err := regosig.NewData(context.Background(), map[string]string{"allowlist": path}, 0)
+			require.NoError(t, err)
+
+			sig, err := regosig.NewRegoSignatureWithData(compile.TargetRego, partial, data, testRegoCodeAllowlist)
+			require.NoError(t, err)
+			holder := &signaturestest.FindingsHolder{}
+			require.NoError(t, sig.Init(detect.SignatureContext{Callback: holder.OnFinding}))
+
+			nc := trace.Event{EventName: "sched_process_exec", ProcessName: "nc"}.ToProtocol()
+			require.NoError(t, sig.OnEvent(nc))
+			assert.Len(t, holder.GroupBySigID(), 1)
+
+			// the updated allowlist applies without reloading the signature
+			writeDocument(t, path, `{"binaries": {"ls": true, "nc": true}}`)
+			_, err = data.Refresh(context.Background())
+			require.NoError(t, err)
+
+			holder = &signaturestest.FindingsHolder{}
+			require.NoError(t, sig.Init(detect.SignatureContext{Callback: holder.OnFinding}))
+			require.NoError(t, sig.OnEvent(nc))
+			assert.Empty(t, holder.GroupBySigID())
+		})
+	}
+}
+
+func TestAio_Data(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	writeDocument(t, path, `{"binaries": {"ls": true}}`)
+	data, err := regosig.NewData(context.Background(), map[string]string{"allowlist": path}, 0)
+	require.NoError(t, err)
+
+	sig, err := regosig.NewAIO(map[string]string{"allowlist.rego": testRegoCodeAllowlist}, regosig.OPAData(data))
+	require.NoError(t, err)
+	holder := &signaturestest.FindingsHolder{}
+	require.NoError(t, sig.Init(detect.SignatureContext{Callback: holder.OnFinding}))
+
+	nc := trace.Event{EventName: "sched_process_exec", ProcessName: "nc"}.ToProtocol()
+	require.NoError(t, sig.OnEvent(nc))
+	assert.Len(t, holder.GroupBySigID(), 1)
+
+	writeDocument(t, path, `{"binaries": {"ls": true, "nc": true}}`)
+	_, err = data.Refresh(context.Background())
+	require.NoError(t, err)
+
+	holder = &signaturestest.FindingsHolder{}
+	require.NoError(t, sig.Init(detect.SignatureContext{Callback: holder.OnFinding}))
+	require.NoError(t, sig.OnEvent(nc))
+	assert.Empty(t, holder.GroupBySigID())
+}
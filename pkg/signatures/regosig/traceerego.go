@@ -27,6 +27,11 @@ type RegoSignature struct {
 	matchPQ        rego.PreparedEvalQuery
 	metadata       detect.SignatureMetadata
 	selectedEvents []detect.SignatureEventSelector
+	target         string
+	partialEval    bool
+	pkgName        string
+	data           *Data
+	dataVersion    uint64 // version of the data documents snapshot matchPQ was prepared with
 }
 
 const queryMatch string = "data.%s.tracee_match"
@@ -36,8 +41,18 @@ const packageNameRegex string = `package\s.*`
 
 // NewRegoSignature creates a new RegoSignature with the provided rego code string
 func NewRegoSignature(target string, partialEval bool, regoCodes ...string) (detect.Signature, error) {
+	return NewRegoSignatureWithData(target, partialEval, nil, regoCodes...)
+}
+
+// NewRegoSignatureWithData creates a new RegoSignature with the provided rego code string,
+// referencing the given data documents (none if nil)
+func NewRegoSignatureWithData(target string, partialEval bool, data *Data, regoCodes ...string) (detect.Signature, error) {
 	var err error
-	res := RegoSignature{}
+	res := RegoSignature{
+		target:      target,
+		partialEval: partialEval,
+		data:        data,
+	}
 	regoMap := make(map[string]string)
 
 	re := regexp.MustCompile(packageNameRegex)
@@ -55,36 +70,20 @@ func NewRegoSignature(target string, partialEval bool, regoCodes ...string) (det
 		}
 		regoMap[regoModuleName] = regoCode
 	}
+	res.pkgName = pkgName
 
 	res.compiledRego, err = ast.CompileModules(regoMap)
 	if err != nil {
 		return nil, err
 	}
 
-	ctx := context.Background()
-	if partialEval {
-		pr, err := rego.New(
-
-			rego.Compiler(res.compiledRego),
-			rego.Query(fmt.Sprintf(queryMatch, pkgName)),
-		).PartialResult(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		res.matchPQ, err = pr.Rego(rego.Target(target)).PrepareForEval(ctx)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		res.matchPQ, err = rego.New(
-			rego.Target(target),
-			rego.Compiler(res.compiledRego),
-			rego.Query(fmt.Sprintf(queryMatch, pkgName)),
-		).PrepareForEval(ctx)
-		if err != nil {
-			return nil, err
-		}
+	snapshot := data.Snapshot()
+	res.matchPQ, err = res.prepareMatch(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot != nil {
+		res.dataVersion = snapshot.Version
 	}
 
 	res.metadata, err = res.getMetadata(pkgName)
@@ -98,6 +97,53 @@ func NewRegoSignature(target string, partialEval bool, regoCodes ...string) (det
 	return &res, nil
 }
 
+// prepareMatch prepares the tracee_match query, evaluated against the given data documents
+// snapshot (every snapshot having its own store, the query is prepared again for each one)
+func (sig *RegoSignature) prepareMatch(snapshot *Snapshot) (rego.PreparedEvalQuery, error) {
+	ctx := context.Background()
+	var store []func(*rego.Rego)
+	if snapshot != nil {
+		store = append(store, rego.Store(snapshot.store))
+	}
+
+	if !sig.partialEval {
+		return rego.New(append(store,
+			rego.Target(sig.target),
+			rego.Compiler(sig.compiledRego),
+			rego.Query(fmt.Sprintf(queryMatch, sig.pkgName)),
+		)...).PrepareForEval(ctx)
+	}
+
+	pr, err := rego.New(append(store,
+		rego.Compiler(sig.compiledRego),
+		rego.Query(fmt.Sprintf(queryMatch, sig.pkgName)),
+	)...).PartialResult(ctx)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	}
+
+	return pr.Rego(append(store, rego.Target(sig.target))...).PrepareForEval(ctx)
+}
+
+// refreshData prepares the tracee_match query again once the data documents changed, the
+// evaluations using the new snapshot from then on
+func (sig *RegoSignature) refreshData() error {
+	snapshot := sig.data.Snapshot()
+	if snapshot == nil || snapshot.Version == sig.dataVersion {
+		return nil
+	}
+
+	// the failing snapshot isn't retried: the previous one is used until the next update
+	sig.dataVersion = snapshot.Version
+	pq, err := sig.prepareMatch(snapshot)
+	if err != nil {
+		return fmt.Errorf("preparing rego with data documents version %d: %w", snapshot.Version, err)
+	}
+	sig.matchPQ = pq
+
+	return nil
+}
+
 // Init implements the Signature interface by resetting internal state
 func (sig *RegoSignature) Init(ctx detect.SignatureContext) error {
 	sig.cb = ctx.Callback
@@ -158,6 +204,10 @@ func (sig *RegoSignature) getSelectedEvents(pkgName string) ([]detect.SignatureE
 // if bool is "returned", a true evaluation will generate a Finding with no data
 // if document is "returned", any non-empty evaluation will generate a Finding with the document as the Finding's "Data"
 func (sig *RegoSignature) OnEvent(event protocol.Event) error {
+	if err := sig.refreshData(); err != nil {
+		return err
+	}
+
 	input := rego.EvalInput(event.Payload)
 	results, err := sig.matchPQ.Eval(context.TODO(), input)
 	if err != nil {
@@ -0,0 +1,110 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"plugin"
+
+	"github.com/aquasecurity/tracee/pkg/cosign"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// PluginSignatureExt is the extension of the cosign signature of a Go signature plugin,
+// signed with cosign sign-blob --output-signature <plugin>.so.sig.
+const PluginSignatureExt = ".sig"
+
+// PluginPolicy is the verification policy of the Go signature plugins, before they are
+// loaded.
+type PluginPolicy struct {
+	PublicKey     crypto.PublicKey  // cosign public key, verifying the plugins signatures
+	AllowUnsigned bool              // load the plugins which can't be verified (not signed, or no key)
+	Audit         func(PluginAudit) // called for every plugin found, if set
+}
+
+// PluginAudit is the verification outcome of a Go signature plugin.
+type PluginAudit struct {
+	Path     string
+	SHA256   string
+	Signed   bool // a signature was found
+	Verified bool // the signature was verified with the key
+	Loaded   bool
+	Reason   string // why the plugin was loaded or refused
+}
+
+// verifyPlugin verifies the signature of the plugin data.
+func (p PluginPolicy) verifyPlugin(path string, data []byte) PluginAudit {
+	sum := sha256.Sum256(data)
+	audit := PluginAudit{Path: path, SHA256: hex.EncodeToString(sum[:])}
+
+	signature, err := os.ReadFile(path + PluginSignatureExt)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		audit.Reason = "not signed"
+	case err != nil:
+		audit.Reason = "reading signature: " + err.Error()
+	case p.PublicKey == nil:
+		audit.Signed = true
+		audit.Reason = "no public key to verify the signature"
+	default:
+		audit.Signed = true
+		if err := cosign.VerifyBase64(p.PublicKey, data, signature); err != nil {
+			// never loaded, even if unsigned plugins are allowed
+			audit.Reason = "signature verification failed: " + err.Error()
+			return audit
+		}
+		audit.Verified = true
+		audit.Loaded = true
+		audit.Reason = "signature verified"
+		return audit
+	}
+
+	if p.AllowUnsigned {
+		audit.Loaded = true
+		audit.Reason += " (unsigned plugins allowed)"
+	}
+
+	return audit
+}
+
+// openPlugin verifies a plugin, and opens it if it may be loaded. The verified content is
+// opened from a private copy, not to be replaced between its verification and loading.
+func (p PluginPolicy) openPlugin(path string) (*plugin.Plugin, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	audit := p.verifyPlugin(path, data)
+	if p.Audit != nil {
+		p.Audit(audit)
+	}
+	if !audit.Loaded {
+		logger.Errorw("Refusing to load signature plugin", "path", path, "sha256", audit.SHA256, "reason", audit.Reason)
+		return nil, nil
+	}
+	if !audit.Verified {
+		logger.Warnw("Loading unverified signature plugin", "path", path, "sha256", audit.SHA256, "reason", audit.Reason)
+	}
+
+	dir, err := os.MkdirTemp("", "tracee-plugin-")
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warnw("Removing signature plugin copy", "error", err)
+		}
+	}()
+	verified := filepath.Join(dir, filepath.Base(path))
+	if err := os.WriteFile(verified, data, 0500); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return plugin.Open(verified)
+}
@@ -0,0 +1,113 @@
+package signature
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyPlugin(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	data := []byte("plugin")
+	sum := sha256.Sum256(data)
+	sha := hex.EncodeToString(sum[:])
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	signed := filepath.Join(dir, "signed.so")
+	require.NoError(t, os.WriteFile(signed, data, 0o600))
+	require.NoError(t, os.WriteFile(signed+PluginSignatureExt, []byte(base64.StdEncoding.EncodeToString(signature)), 0o600))
+	unsigned := filepath.Join(dir, "unsigned.so")
+	require.NoError(t, os.WriteFile(unsigned, data, 0o600))
+
+	testCases := []struct {
+		name     string
+		policy   PluginPolicy
+		path     string
+		expected PluginAudit
+	}{
+		{
+			name:     "verified",
+			policy:   PluginPolicy{PublicKey: &key.PublicKey},
+			path:     signed,
+			expected: PluginAudit{Path: signed, SHA256: sha, Signed: true, Verified: true, Loaded: true, Reason: "signature verified"},
+		},
+		{
+			name:     "unsigned",
+			policy:   PluginPolicy{PublicKey: &key.PublicKey},
+			path:     unsigned,
+			expected: PluginAudit{Path: unsigned, SHA256: sha, Reason: "not signed"},
+		},
+		{
+			name:     "unsigned allowed",
+			policy:   PluginPolicy{PublicKey: &key.PublicKey, AllowUnsigned: true},
+			path:     unsigned,
+			expected: PluginAudit{Path: unsigned, SHA256: sha, Loaded: true, Reason: "not signed (unsigned plugins allowed)"},
+		},
+		{
+			name:     "no public key",
+			policy:   PluginPolicy{},
+			path:     signed,
+			expected: PluginAudit{Path: signed, SHA256: sha, Signed: true, Reason: "no public key to verify the signature"},
+		},
+		{
+			name:     "no public key, unsigned allowed",
+			policy:   PluginPolicy{AllowUnsigned: true},
+			path:     signed,
+			expected: PluginAudit{Path: signed, SHA256: sha, Signed: true, Loaded: true, Reason: "no public key to verify the signature (unsigned plugins allowed)"},
+		},
+		{
+			name:   "invalid signature, unsigned allowed",
+			policy: PluginPolicy{PublicKey: &otherKey.PublicKey, AllowUnsigned: true},
+			path:   signed,
+			expected: PluginAudit{
+				Path:   signed,
+				SHA256: sha,
+				Signed: true,
+				Reason: "signature verification failed: cosign.Verify: invalid signature",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.policy.verifyPlugin(tc.path, data))
+		})
+	}
+}
+
+func TestOpenPluginRefused(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "unsigned.so")
+	require.NoError(t, os.WriteFile(path, []byte("plugin"), 0o600))
+
+	var audits []PluginAudit
+	policy := PluginPolicy{Audit: func(audit PluginAudit) { audits = append(audits, audit) }}
+
+	p, err := policy.openPlugin(path)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+	require.Len(t, audits, 1)
+	assert.Equal(t, path, audits[0].Path)
+	assert.False(t, audits[0].Loaded)
+}
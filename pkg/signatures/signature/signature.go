@@ -6,16 +6,19 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
-	"plugin"
 	"strings"
 
 	embedded "github.com/aquasecurity/tracee"
 	"github.com/aquasecurity/tracee/pkg/logger"
+	"github.com/aquasecurity/tracee/pkg/signatures/external"
 	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
 	"github.com/aquasecurity/tracee/types/detect"
 )
 
-func Find(target string, partialEval bool, signaturesDir []string, signatures []string, aioEnabled bool) ([]detect.Signature, []detect.DataSource, error) {
+// Find loads the signatures of the given directories, Go plugins being verified with the
+// plugins policy before they are loaded, and rego signatures referencing the given data
+// documents (none if nil).
+func Find(target string, partialEval bool, signaturesDir []string, signatures []string, aioEnabled bool, regoData *regosig.Data, plugins PluginPolicy) ([]detect.Signature, []detect.DataSource, error) {
 	if len(signaturesDir) == 0 {
 		exePath, err := os.Executable()
 		if err != nil {
@@ -31,7 +34,7 @@ func Find(target string, partialEval bool, signaturesDir []string, signatures []
 			continue
 		}
 
-		gosigs, ds, err := findGoSigs(dir)
+		gosigs, ds, err := findGoSigs(dir, plugins)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -39,11 +42,13 @@ func Find(target string, partialEval bool, signaturesDir []string, signatures []
 		sigs = append(sigs, gosigs...)
 		datasources = append(datasources, ds...)
 
-		opasigs, err := findRegoSigs(target, partialEval, dir, aioEnabled)
+		opasigs, err := findRegoSigs(target, partialEval, dir, aioEnabled, regoData)
 		if err != nil {
 			return nil, nil, err
 		}
 		sigs = append(sigs, opasigs...)
+
+		sigs = append(sigs, findExternalSigs(dir)...)
 	}
 
 	var res []detect.Signature
@@ -62,7 +67,7 @@ func Find(target string, partialEval bool, signaturesDir []string, signatures []
 	return res, datasources, nil
 }
 
-func findGoSigs(dir string) ([]detect.Signature, []detect.DataSource, error) {
+func findGoSigs(dir string, plugins PluginPolicy) ([]detect.Signature, []detect.DataSource, error) {
 	var signatures []detect.Signature
 	var datasources []detect.DataSource
 
@@ -82,11 +87,14 @@ func findGoSigs(dir string) ([]detect.Signature, []detect.DataSource, error) {
 				return nil
 			}
 
-			p, err := plugin.Open(path)
+			p, err := plugins.openPlugin(path)
 			if err != nil {
 				logger.Errorw("Opening plugin " + path + ": " + err.Error())
 				return err
 			}
+			if p == nil {
+				return nil // refused
+			}
 			exportSigs, err := p.Lookup("ExportedSignatures")
 			if err != nil {
 				logger.Errorw("Missing Export symbol in plugin " + d.Name())
@@ -117,7 +125,36 @@ func findGoSigs(dir string) ([]detect.Signature, []detect.DataSource, error) {
 	return signatures, datasources, nil
 }
 
-func findRegoSigs(target string, partialEval bool, dir string, aioEnabled bool) ([]detect.Signature, error) {
+// findExternalSigs returns the external signatures of the manifests of the given directory.
+func findExternalSigs(dir string) []detect.Signature {
+	var res []detect.Signature
+
+	errWD := filepath.WalkDir(dir,
+		func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				logger.Errorw("Finding external sigs", "error", err)
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(d.Name(), external.ManifestSuffix) {
+				return nil
+			}
+			sig, err := external.NewFromFile(path)
+			if err != nil {
+				logger.Errorw("Creating external signature", "error", err)
+				return nil
+			}
+			res = append(res, sig)
+			return nil
+		},
+	)
+	if errWD != nil {
+		logger.Errorw("Walking dir", "error", errWD)
+	}
+
+	return res
+}
+
+func findRegoSigs(target string, partialEval bool, dir string, aioEnabled bool, data *regosig.Data) ([]detect.Signature, error) {
 	var res []detect.Signature
 
 	modules := make(map[string]string)
@@ -170,7 +207,7 @@ func findRegoSigs(target string, partialEval bool, dir string, aioEnabled bool)
 			if aioEnabled {
 				return nil
 			}
-			sig, err := regosig.NewRegoSignature(target, partialEval, append(regoHelpers, string(regoCode))...)
+			sig, err := regosig.NewRegoSignatureWithData(target, partialEval, data, append(regoHelpers, string(regoCode))...)
 			if err != nil {
 				newlineOffset := bytes.Index(regoCode, []byte("\n"))
 				if newlineOffset == -1 {
@@ -197,6 +234,7 @@ func findRegoSigs(target string, partialEval bool, dir string, aioEnabled bool)
 			modules,
 			regosig.OPATarget(target),
 			regosig.OPAPartial(partialEval),
+			regosig.OPAData(data),
 		)
 		if err != nil {
 			return nil, err
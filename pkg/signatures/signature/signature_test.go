@@ -1,6 +1,7 @@
 package signature
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,7 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aquasecurity/tracee/pkg/signatures/regosig"
+	"github.com/aquasecurity/tracee/signatures/signaturestest"
 	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/trace"
 )
 
 const (
@@ -22,7 +26,7 @@ const (
 func TestFindByEventName(t *testing.T) {
 	t.Parallel()
 
-	sigs, _, err := Find(compile.TargetRego, false, []string{exampleRulesDir}, []string{"anti_debugging"}, false)
+	sigs, _, err := Find(compile.TargetRego, false, []string{exampleRulesDir}, []string{"anti_debugging"}, false, nil, PluginPolicy{})
 	require.NoError(t, err)
 	require.Equal(t, 1, len(sigs))
 
@@ -45,7 +49,7 @@ func TestFindByEventName(t *testing.T) {
 func TestFindByRuleID(t *testing.T) {
 	t.Parallel()
 
-	sigs, _, err := Find(compile.TargetRego, false, []string{exampleRulesDir}, []string{"TRC-2"}, false)
+	sigs, _, err := Find(compile.TargetRego, false, []string{exampleRulesDir}, []string{"TRC-2"}, false, nil, PluginPolicy{})
 	require.NoError(t, err)
 	require.Equal(t, 1, len(sigs))
 
@@ -111,7 +115,7 @@ func Test_findRegoSigs(t *testing.T) {
 	require.NoError(t, err)
 
 	// find rego signatures
-	sigs, err := findRegoSigs(compile.TargetRego, false, tRoot, false)
+	sigs, err := findRegoSigs(compile.TargetRego, false, tRoot, false, nil)
 	require.NoError(t, err)
 
 	assert.Equal(t, len(sigs), 2)
@@ -133,6 +137,64 @@ func Test_findRegoSigs(t *testing.T) {
 	}
 }
 
+func Test_findRegoSigsWithData(t *testing.T) {
+	t.Parallel()
+
+	const allowlistSig = `package tracee.TRC_ALLOWLIST
+
+__rego_metadoc__ := {
+	"id": "TRC-ALLOWLIST",
+	"version": "0.1.0",
+	"name": "allowlist",
+}
+
+tracee_selected_events[eventSelector] {
+	eventSelector := {
+		"source": "tracee",
+		"name": "sched_process_exec"
+	}
+}
+
+tracee_match {
+	not data.documents.allowlist.binaries[input.processName]
+}
+`
+
+	for _, aio := range []bool{false, true} {
+		aio := aio
+
+		t.Run(fmt.Sprintf("aio=%t", aio), func(t *testing.T) {
+			t.Parallel()
+
+			tRoot := t.TempDir()
+			err := os.WriteFile(filepath.Join(tRoot, "allowlist.rego"), []byte(allowlistSig), 0600)
+			require.NoError(t, err)
+			documentPath := filepath.Join(tRoot, "allowlist.json")
+			err = os.WriteFile(documentPath, []byte(`{"binaries": {"ls": true}}`), 0600)
+			require.NoError(t, err)
+
+			data, err := regosig.NewData(context.Background(), map[string]string{"allowlist": documentPath}, 0)
+			require.NoError(t, err)
+
+			sigs, err := findRegoSigs(compile.TargetRego, false, tRoot, aio, data)
+			require.NoError(t, err)
+			require.Len(t, sigs, 1)
+
+			holder := &signaturestest.FindingsHolder{}
+			require.NoError(t, sigs[0].Init(detect.SignatureContext{Callback: holder.OnFinding}))
+
+			// ls is allowed by the data document, nc isn't
+			ls := trace.Event{EventName: "sched_process_exec", ProcessName: "ls"}.ToProtocol()
+			require.NoError(t, sigs[0].OnEvent(ls))
+			assert.Empty(t, holder.GroupBySigID())
+
+			nc := trace.Event{EventName: "sched_process_exec", ProcessName: "nc"}.ToProtocol()
+			require.NoError(t, sigs[0].OnEvent(nc))
+			assert.Len(t, holder.GroupBySigID(), 1)
+		})
+	}
+}
+
 func copyExampleSig(exampleName, destDir string) error {
 	var exampleDir string
 	extension := filepath.Ext(exampleName)
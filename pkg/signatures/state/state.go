@@ -0,0 +1,112 @@
+// Package state implements the keyed states store of the signatures (see detect.StateStore).
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/detect"
+)
+
+// DefaultMaxStates is the default number of states of a signature
+const DefaultMaxStates = 10000
+
+type state struct {
+	value   interface{}
+	expires time.Time // zero if the state doesn't expire
+}
+
+var _ detect.StateStore = (*Store)(nil)
+
+// Store is the states store of a signature.
+type Store struct {
+	mutex  sync.Mutex
+	states *simplelru.LRU[detect.StateKey, *state]
+	now    func() time.Time
+}
+
+// New creates a states store keeping the given number of states at most.
+func New(maxStates int) (*Store, error) {
+	if maxStates <= 0 {
+		return nil, errfmt.Errorf("invalid signature max states: %d", maxStates)
+	}
+	states, err := simplelru.NewLRU[detect.StateKey, *state](maxStates, nil)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Store{states: states, now: time.Now}, nil
+}
+
+// Get returns the value of the given state, if it exists and didn't expire.
+func (s *Store) Get(key detect.StateKey) (interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	st, ok := s.get(key)
+	if !ok {
+		return nil, false
+	}
+
+	return st.value, true
+}
+
+// Set sets the value of the given state, expiring after the given TTL (0 for no expiration).
+func (s *Store) Set(key detect.StateKey, value interface{}, ttl time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.states.Add(key, &state{value: value, expires: s.expiration(ttl)})
+}
+
+// Increment increments the counter of the given state and returns its value. A new counter
+// expires after the given TTL (0 for no expiration), which isn't extended by the next increments.
+func (s *Store) Increment(key detect.StateKey, ttl time.Duration) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	st, ok := s.get(key)
+	if ok {
+		if count, isCounter := st.value.(int); isCounter {
+			st.value = count + 1
+			return count + 1
+		}
+	}
+	s.states.Add(key, &state{value: 1, expires: s.expiration(ttl)})
+
+	return 1
+}
+
+// Delete deletes the given state.
+func (s *Store) Delete(key detect.StateKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.states.Remove(key)
+}
+
+// get returns the given state, removing it if expired.
+func (s *Store) get(key detect.StateKey) (*state, bool) {
+	st, ok := s.states.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if !st.expires.IsZero() && !s.now().Before(st.expires) {
+		s.states.Remove(key)
+		return nil, false
+	}
+
+	return st, true
+}
+
+// expiration returns the expiration time of a state with the given TTL.
+func (s *Store) expiration(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return s.now().Add(ttl)
+}
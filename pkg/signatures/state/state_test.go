@@ -0,0 +1,52 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store, err := New(2)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	store.now = func() time.Time { return now }
+
+	proc1 := detect.ProcessStateKey(trace.Event{ProcessEntityId: 1}, "failures")
+	proc2 := detect.ProcessStateKey(trace.Event{ProcessEntityId: 2}, "failures")
+	container := detect.ContainerStateKey(trace.Event{Container: trace.Container{ID: "abc"}}, "shell")
+
+	// counters within a time window
+	assert.Equal(t, 1, store.Increment(proc1, time.Minute))
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 2, store.Increment(proc1, time.Minute))
+	assert.Equal(t, 1, store.Increment(proc2, time.Minute))
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 1, store.Increment(proc1, time.Minute), "expired counter")
+
+	// values, the least recently used being evicted
+	store.Set(container, "/bin/sh", 0)
+	value, ok := store.Get(container)
+	require.True(t, ok)
+	assert.Equal(t, "/bin/sh", value)
+	_, ok = store.Get(proc2)
+	assert.False(t, ok, "evicted state")
+
+	store.Delete(container)
+	_, ok = store.Get(container)
+	assert.False(t, ok, "deleted state")
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(0)
+	assert.ErrorContains(t, err, "invalid signature max states: 0")
+}
@@ -0,0 +1,221 @@
+// Package suppression implements allowlist rules for findings: a finding matching a rule
+// is marked as suppressed, instead of being dropped silently, so known benign activity
+// can be told apart from detections without losing the audit trail.
+package suppression
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/aquasecurity/tracee/pkg/counter"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	// SuppressedProperty is the finding metadata property set for suppressed findings.
+	SuppressedProperty = "suppressed"
+	// SuppressedByProperty is the finding metadata property holding the rule name.
+	SuppressedByProperty = "suppressedBy"
+)
+
+// Rule is an allowlist rule. A finding is suppressed if it matches all the given
+// conditions, the conditions not given match every finding.
+//
+// Image and argument values are patterns: an exact value, or a value with a leading
+// and/or a trailing '*' to match a suffix, a prefix or a substring.
+type Rule struct {
+	Name       string            `yaml:"name"`
+	Signature  string            `yaml:"signature"`  // signature id, e.g. TRC-105
+	Image      string            `yaml:"image"`      // container image name pattern
+	BinaryHash string            `yaml:"binaryHash"` // sha256 of the process binary
+	Args       map[string]string `yaml:"args"`       // finding or trigger event argument patterns
+}
+
+// rulesFile is the layout of a suppression rules file.
+type rulesFile struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// RulesFromFiles reads the suppression rules of the given YAML files.
+func RulesFromFiles(paths []string) ([]Rule, error) {
+	var rules []Rule
+	names := make(map[string]bool)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+
+		var file rulesFile
+		if err := yaml.UnmarshalStrict(data, &file); err != nil {
+			return nil, errfmt.Errorf("suppression rules file %s: %v", path, err)
+		}
+
+		for _, rule := range file.Rules {
+			if err := rule.validate(); err != nil {
+				return nil, errfmt.Errorf("suppression rules file %s: %v", path, err)
+			}
+			if names[rule.Name] {
+				return nil, errfmt.Errorf("suppression rules file %s: rule %s defined more than once", path, rule.Name)
+			}
+			names[rule.Name] = true
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return errfmt.Errorf("rule name is required")
+	}
+	if r.Signature == "" && r.Image == "" && r.BinaryHash == "" && len(r.Args) == 0 {
+		return errfmt.Errorf("rule %s has no condition", r.Name)
+	}
+
+	return nil
+}
+
+// BinaryHashFunc returns the sha256 of the binary of the process which triggered a
+// finding. It is only called for the rules with a binary hash condition.
+type BinaryHashFunc func(trigger *trace.Event) (string, error)
+
+// Suppressor marks the findings matching its rules as suppressed.
+type Suppressor struct {
+	rules      []Rule
+	counters   []counter.Counter // suppressed findings, per rule
+	binaryHash BinaryHashFunc
+}
+
+// New creates a suppressor of the given rules.
+func New(rules []Rule, binaryHash BinaryHashFunc) *Suppressor {
+	return &Suppressor{
+		rules:      rules,
+		counters:   make([]counter.Counter, len(rules)),
+		binaryHash: binaryHash,
+	}
+}
+
+// Suppress marks the finding as suppressed if it matches a rule, returning whether it
+// did. The trigger is the event the finding was detected from.
+func (s *Suppressor) Suppress(finding *trace.Event, trigger *trace.Event) bool {
+	if finding.Metadata == nil {
+		return false
+	}
+	signatureID, _ := finding.Metadata.Properties["signatureID"].(string)
+
+	// the binary hash is computed once, and only if needed
+	var hash string
+	hashed := false
+	binaryHash := func() string {
+		if !hashed && s.binaryHash != nil {
+			hash, _ = s.binaryHash(trigger)
+		}
+		hashed = true
+		return hash
+	}
+
+	for i, rule := range s.rules {
+		if rule.Signature != "" && rule.Signature != signatureID {
+			continue
+		}
+		if rule.Image != "" && !matchPattern(rule.Image, finding.Container.ImageName) {
+			continue
+		}
+		if !matchArgs(rule.Args, finding, trigger) {
+			continue
+		}
+		if rule.BinaryHash != "" && !strings.EqualFold(rule.BinaryHash, binaryHash()) {
+			continue
+		}
+
+		finding.Metadata.Properties[SuppressedProperty] = true
+		finding.Metadata.Properties[SuppressedByProperty] = rule.Name
+		_ = s.counters[i].Increment()
+
+		return true
+	}
+
+	return false
+}
+
+// Counts returns the number of suppressed findings, per rule name.
+func (s *Suppressor) Counts() map[string]uint64 {
+	counts := make(map[string]uint64, len(s.rules))
+	for i, rule := range s.rules {
+		counts[rule.Name] = s.counters[i].Get()
+	}
+
+	return counts
+}
+
+// RegisterPrometheus registers the suppressed findings counters, labeled by rule.
+func (s *Suppressor) RegisterPrometheus() error {
+	for i, rule := range s.rules {
+		c := &s.counters[i]
+		err := prometheus.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   "tracee_ebpf",
+			Name:        "findings_suppressed_by_rule_total",
+			Help:        "findings suppressed by a suppression rule",
+			ConstLabels: prometheus.Labels{"rule": rule.Name},
+		}, func() float64 { return float64(c.Get()) }))
+
+		if err != nil {
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return nil
+}
+
+// matchArgs matches the argument patterns against the finding arguments, then the
+// trigger event arguments.
+func matchArgs(patterns map[string]string, finding *trace.Event, trigger *trace.Event) bool {
+	for name, pattern := range patterns {
+		value, ok := argValue(finding.Args, name)
+		if !ok && trigger != nil {
+			value, ok = argValue(trigger.Args, name)
+		}
+		if !ok || !matchPattern(pattern, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func argValue(args []trace.Argument, name string) (string, bool) {
+	for _, arg := range args {
+		if arg.Name == name {
+			return fmt.Sprint(arg.Value), true
+		}
+	}
+
+	return "", false
+}
+
+// matchPattern matches a value against an exact, prefix (foo*), suffix (*foo) or
+// substring (*foo*) pattern.
+func matchPattern(pattern, value string) bool {
+	prefix := strings.HasSuffix(pattern, "*")
+	suffix := strings.HasPrefix(pattern, "*")
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "*"), "*")
+
+	switch {
+	case prefix && suffix:
+		return strings.Contains(value, pattern)
+	case prefix:
+		return strings.HasPrefix(value, pattern)
+	case suffix:
+		return strings.HasSuffix(value, pattern)
+	default:
+		return value == pattern
+	}
+}
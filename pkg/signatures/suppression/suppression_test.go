@@ -0,0 +1,202 @@
+package suppression
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestRulesFromFiles(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		content       string
+		expectedRules []Rule
+		expectedError string
+	}{
+		{
+			name: "valid rules",
+			content: `rules:
+  - name: package-manager
+    signature: TRC-1022
+    image: "docker.io/library/debian*"
+    args:
+      pathname: /usr/bin/dpkg
+  - name: trusted-binary
+    binaryHash: 7f2f1cbbc7e0ea8e5bd5c0ac18d4ca6a8d87f3a5ad1b3a0ee6e5c0b3e8f0f1a2
+`,
+			expectedRules: []Rule{
+				{
+					Name:      "package-manager",
+					Signature: "TRC-1022",
+					Image:     "docker.io/library/debian*",
+					Args:      map[string]string{"pathname": "/usr/bin/dpkg"},
+				},
+				{
+					Name:       "trusted-binary",
+					BinaryHash: "7f2f1cbbc7e0ea8e5bd5c0ac18d4ca6a8d87f3a5ad1b3a0ee6e5c0b3e8f0f1a2",
+				},
+			},
+		},
+		{
+			name:          "unknown field",
+			content:       "rules:\n  - name: r1\n    sig: TRC-1022\n",
+			expectedError: "field sig not found",
+		},
+		{
+			name:          "no name",
+			content:       "rules:\n  - signature: TRC-1022\n",
+			expectedError: "rule name is required",
+		},
+		{
+			name:          "no condition",
+			content:       "rules:\n  - name: r1\n",
+			expectedError: "rule r1 has no condition",
+		},
+		{
+			name:          "duplicate rule",
+			content:       "rules:\n  - name: r1\n    signature: TRC-1\n  - name: r1\n    signature: TRC-2\n",
+			expectedError: "rule r1 defined more than once",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "suppressions.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			rules, err := RulesFromFiles([]string{path})
+			if tc.expectedError != "" {
+				assert.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
+
+func newFinding(signatureID, image string, args ...trace.Argument) *trace.Event {
+	return &trace.Event{
+		EventName: "fake_signature",
+		Container: trace.Container{ImageName: image},
+		Args:      args,
+		Metadata: &trace.Metadata{
+			Properties: map[string]interface{}{"signatureID": signatureID},
+		},
+	}
+}
+
+func stringArg(name, value string) trace.Argument {
+	return trace.Argument{ArgMeta: trace.ArgMeta{Name: name, Type: "const char*"}, Value: value}
+}
+
+func TestSuppress(t *testing.T) {
+	t.Parallel()
+
+	const hash = "7f2f1cbbc7e0ea8e5bd5c0ac18d4ca6a8d87f3a5ad1b3a0ee6e5c0b3e8f0f1a2"
+
+	rules := []Rule{
+		{
+			Name:      "package-manager",
+			Signature: "TRC-1022",
+			Image:     "docker.io/library/debian*",
+			Args:      map[string]string{"pathname": "/usr/bin/dpkg*"},
+		},
+		{
+			Name:       "trusted-binary",
+			Signature:  "TRC-105",
+			BinaryHash: hash,
+		},
+	}
+
+	hashCalls := 0
+	suppressor := New(rules, func(trigger *trace.Event) (string, error) {
+		hashCalls++
+		return hash, nil
+	})
+
+	trigger := &trace.Event{
+		EventName: "security_file_open",
+		Args:      []trace.Argument{stringArg("pathname", "/usr/bin/dpkg-query")},
+	}
+
+	testCases := []struct {
+		name               string
+		finding            *trace.Event
+		expectedSuppressed bool
+		expectedRule       string
+	}{
+		{
+			name:               "trigger argument match",
+			finding:            newFinding("TRC-1022", "docker.io/library/debian:12"),
+			expectedSuppressed: true,
+			expectedRule:       "package-manager",
+		},
+		{
+			name:               "finding argument match",
+			finding:            newFinding("TRC-1022", "docker.io/library/debian:12", stringArg("pathname", "/usr/bin/dpkg")),
+			expectedSuppressed: true,
+			expectedRule:       "package-manager",
+		},
+		{
+			name:    "finding argument mismatch",
+			finding: newFinding("TRC-1022", "docker.io/library/debian:12", stringArg("pathname", "/tmp/dpkg")),
+		},
+		{
+			name:    "image mismatch",
+			finding: newFinding("TRC-1022", "docker.io/library/alpine:3"),
+		},
+		{
+			name:    "signature mismatch",
+			finding: newFinding("TRC-1010", "docker.io/library/debian:12"),
+		},
+		{
+			name:               "binary hash match",
+			finding:            newFinding("TRC-105", ""),
+			expectedSuppressed: true,
+			expectedRule:       "trusted-binary",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			suppressed := suppressor.Suppress(tc.finding, trigger)
+			assert.Equal(t, tc.expectedSuppressed, suppressed)
+
+			properties := tc.finding.Metadata.Properties
+			if !tc.expectedSuppressed {
+				assert.NotContains(t, properties, SuppressedProperty)
+				return
+			}
+			assert.Equal(t, true, properties[SuppressedProperty])
+			assert.Equal(t, tc.expectedRule, properties[SuppressedByProperty])
+		})
+	}
+
+	// the binary hash is only computed for the findings matching the other conditions
+	assert.Equal(t, 1, hashCalls)
+	assert.Equal(t, map[string]uint64{"package-manager": 2, "trusted-binary": 1}, suppressor.Counts())
+}
+
+func TestMatchPattern(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, matchPattern("nginx", "nginx"))
+	assert.False(t, matchPattern("nginx", "nginx:1.25"))
+	assert.True(t, matchPattern("nginx*", "nginx:1.25"))
+	assert.True(t, matchPattern("*:1.25", "nginx:1.25"))
+	assert.True(t, matchPattern("*ginx*", "nginx:1.25"))
+	assert.False(t, matchPattern("*apache*", "nginx:1.25"))
+	assert.True(t, matchPattern("*", ""))
+}
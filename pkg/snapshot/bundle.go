@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// MaxBundles is the number of bundles kept in the snapshots directory: the oldest bundles
+// are removed once it is exceeded, not to fill up the disk.
+const MaxBundles = 20
+
+const (
+	bundlePrefix = "snapshot-"
+	bundleSuffix = ".tar.gz"
+)
+
+// WriteBundle writes the snapshot as an artifact bundle in the given directory: a gzipped
+// tarball holding a JSON file per part of the snapshot. It returns the bundle path. Only
+// the newest MaxBundles bundles are kept in the directory.
+func WriteBundle(dir string, s *Snapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	path := filepath.Join(dir, bundlePrefix+s.Time.UTC().Format("20060102T150405.000")+bundleSuffix)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+	if err != nil {
+		return "", errfmt.WrapError(err)
+	}
+
+	err = writeBundle(file, s)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", errfmt.WrapError(err)
+	}
+
+	if err := pruneBundles(dir, MaxBundles); err != nil {
+		return path, errfmt.WrapError(err)
+	}
+
+	return path, nil
+}
+
+// pruneBundles removes the oldest bundles of the directory, keeping the given number of
+// bundles. Bundle names sort chronologically.
+func pruneBundles(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var bundles []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, bundlePrefix) && strings.HasSuffix(name, bundleSuffix) {
+			bundles = append(bundles, name)
+		}
+	}
+	if len(bundles) <= keep {
+		return nil
+	}
+
+	sort.Strings(bundles)
+	for _, name := range bundles[:len(bundles)-keep] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeBundle(file *os.File, s *Snapshot) error {
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	parts := []struct {
+		name string
+		data interface{}
+	}{
+		{"summary.json", s.Summary()},
+		{"processes.json", s.Processes},
+		{"sockets.json", s.Sockets},
+		{"kernel_modules.json", s.Modules},
+		{"namespaces.json", s.Namespaces},
+	}
+
+	for _, part := range parts {
+		data, err := json.MarshalIndent(part.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = tw.WriteHeader(&tar.Header{
+			Name:    part.name,
+			Mode:    0o640,
+			Size:    int64(len(data)),
+			ModTime: s.Time,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gz.Close()
+}
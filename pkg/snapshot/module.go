@@ -0,0 +1,55 @@
+package snapshot
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// KernelModule is a loaded kernel module.
+type KernelModule struct {
+	Name         string `json:"name"`
+	Size         uint64 `json:"size"`
+	RefCount     int    `json:"refcount"`
+	Dependencies string `json:"dependencies"`
+	State        string `json:"state"`
+	Address      uint64 `json:"address"` // 0 unless read with CAP_SYSLOG
+}
+
+// readKernelModules reads the modules listed in /proc/modules. Hidden modules (e.g.
+// removed from the modules list by a rootkit) are reported by the hidden_kernel_module
+// event instead.
+func readKernelModules(procRoot string) ([]KernelModule, error) {
+	file, err := os.Open(filepath.Join(procRoot, "modules"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var modules []KernelModule
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// name size refcount dependencies state address [taints]
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+		size, _ := strconv.ParseUint(fields[1], 10, 64)
+		refCount, _ := strconv.Atoi(fields[2])
+		address, _ := strconv.ParseUint(strings.TrimPrefix(fields[5], "0x"), 16, 64)
+
+		modules = append(modules, KernelModule{
+			Name:         fields[0],
+			Size:         size,
+			RefCount:     refCount,
+			Dependencies: strings.TrimSuffix(strings.TrimPrefix(fields[3], "-"), ","),
+			State:        fields[4],
+			Address:      address,
+		})
+	}
+
+	return modules, scanner.Err()
+}
@@ -0,0 +1,208 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Process is a process of the process tree.
+type Process struct {
+	Pid       int    `json:"pid"`
+	PPid      int    `json:"ppid"`
+	Uid       int    `json:"uid"`
+	Comm      string `json:"comm"`
+	Exe       string `json:"exe"`
+	Cmdline   string `json:"cmdline"`
+	StartTime uint64 `json:"start_time"` // in clock ticks since boot
+	Threads   int    `json:"threads"`
+}
+
+// Namespace is a namespace in use by at least one process.
+type Namespace struct {
+	Type      string `json:"type"`
+	Inode     uint32 `json:"inode"`
+	Processes int    `json:"processes"`
+	Pid       int    `json:"pid"` // lowest pid of the processes in the namespace
+}
+
+type namespaceKey struct {
+	nsType string
+	inode  uint32
+}
+
+// readProcesses reads all processes, the namespaces they are in and the pids owning each
+// socket (by socket inode).
+func readProcesses(procRoot string) ([]Process, []Namespace, map[uint64]int, error) {
+	entries, err := os.ReadDir(procRoot)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	processes := make([]Process, 0, len(entries))
+	namespaces := make(map[namespaceKey]*Namespace)
+	socketOwners := make(map[uint64]int)
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		pidDir := filepath.Join(procRoot, entry.Name())
+
+		process, err := readProcess(pid, pidDir)
+		if err != nil {
+			continue // process exited meanwhile
+		}
+		processes = append(processes, process)
+
+		readProcessNamespaces(pid, pidDir, namespaces)
+		readProcessSockets(pid, pidDir, socketOwners)
+	}
+
+	sort.Slice(processes, func(i, j int) bool { return processes[i].Pid < processes[j].Pid })
+
+	nsList := make([]Namespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		nsList = append(nsList, *ns)
+	}
+	sort.Slice(nsList, func(i, j int) bool {
+		if nsList[i].Type != nsList[j].Type {
+			return nsList[i].Type < nsList[j].Type
+		}
+		return nsList[i].Inode < nsList[j].Inode
+	})
+
+	return processes, nsList, socketOwners, nil
+}
+
+func readProcess(pid int, pidDir string) (Process, error) {
+	process := Process{Pid: pid}
+
+	status, err := os.Open(filepath.Join(pidDir, "status"))
+	if err != nil {
+		return process, err
+	}
+	defer status.Close()
+
+	scanner := bufio.NewScanner(status)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+		switch key {
+		case "Name":
+			process.Comm = fields[0]
+		case "PPid":
+			process.PPid, _ = strconv.Atoi(fields[0])
+		case "Uid":
+			process.Uid, _ = strconv.Atoi(fields[0])
+		case "Threads":
+			process.Threads, _ = strconv.Atoi(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return process, err
+	}
+
+	// kernel threads have no executable nor command line
+	process.Exe, _ = os.Readlink(filepath.Join(pidDir, "exe"))
+	if cmdline, err := os.ReadFile(filepath.Join(pidDir, "cmdline")); err == nil {
+		process.Cmdline = string(bytes.TrimSpace(bytes.ReplaceAll(cmdline, []byte{0}, []byte{' '})))
+	}
+	if stat, err := os.ReadFile(filepath.Join(pidDir, "stat")); err == nil {
+		process.StartTime = parseStartTime(stat)
+	}
+
+	return process, nil
+}
+
+// parseStartTime returns the start time field of a /proc/<pid>/stat file. The comm field
+// may contain spaces, so fields are counted from its closing parenthesis.
+func parseStartTime(stat []byte) uint64 {
+	idx := bytes.LastIndexByte(stat, ')')
+	if idx < 0 {
+		return 0
+	}
+	fields := strings.Fields(string(stat[idx+1:]))
+	const startTimeIdx = 19 // field 22, counting from field 3 (state)
+	if len(fields) <= startTimeIdx {
+		return 0
+	}
+	startTime, _ := strconv.ParseUint(fields[startTimeIdx], 10, 64)
+
+	return startTime
+}
+
+func readProcessNamespaces(pid int, pidDir string, namespaces map[namespaceKey]*Namespace) {
+	links, err := os.ReadDir(filepath.Join(pidDir, "ns"))
+	if err != nil {
+		return
+	}
+
+	for _, link := range links {
+		target, err := os.Readlink(filepath.Join(pidDir, "ns", link.Name()))
+		if err != nil {
+			continue
+		}
+		nsType, inode, ok := parseInodeLink(target)
+		if !ok {
+			continue
+		}
+
+		key := namespaceKey{nsType: nsType, inode: uint32(inode)}
+		ns, ok := namespaces[key]
+		if !ok {
+			ns = &Namespace{Type: nsType, Inode: uint32(inode), Pid: pid}
+			namespaces[key] = ns
+		}
+		ns.Processes++
+		if pid < ns.Pid {
+			ns.Pid = pid
+		}
+	}
+}
+
+func readProcessSockets(pid int, pidDir string, socketOwners map[uint64]int) {
+	fds, err := os.ReadDir(filepath.Join(pidDir, "fd"))
+	if err != nil {
+		return
+	}
+
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(pidDir, "fd", fd.Name()))
+		if err != nil {
+			continue
+		}
+		kind, inode, ok := parseInodeLink(target)
+		if !ok || kind != "socket" {
+			continue
+		}
+		if _, ok := socketOwners[inode]; !ok {
+			socketOwners[inode] = pid
+		}
+	}
+}
+
+// parseInodeLink parses links in the form kind:[inode] (e.g. mnt:[4026531840]).
+func parseInodeLink(link string) (string, uint64, bool) {
+	kind, rest, ok := strings.Cut(link, ":[")
+	if !ok || !strings.HasSuffix(rest, "]") {
+		return "", 0, false
+	}
+	inode, err := strconv.ParseUint(strings.TrimSuffix(rest, "]"), 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return kind, inode, true
+}
@@ -0,0 +1,67 @@
+// Package snapshot takes forensic snapshots of the host state: the process tree, the open
+// sockets, the loaded kernel modules and the namespaces in use.
+package snapshot
+
+import (
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Snapshot is the host state at a given time.
+type Snapshot struct {
+	Time       time.Time      `json:"time"`
+	Processes  []Process      `json:"processes"`
+	Sockets    []Socket       `json:"sockets"`
+	Modules    []KernelModule `json:"kernel_modules"`
+	Namespaces []Namespace    `json:"namespaces"`
+}
+
+// Summary describes a taken snapshot.
+type Summary struct {
+	Time       time.Time `json:"time"`
+	Processes  int       `json:"processes"`
+	Sockets    int       `json:"sockets"`
+	Modules    int       `json:"kernel_modules"`
+	Namespaces int       `json:"namespaces"`
+	Bundle     string    `json:"bundle,omitempty"`
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+// Take takes a snapshot of the host state from the given procfs mount point. A failure
+// collecting a part of the snapshot doesn't prevent the other parts from being collected:
+// the errors are returned along with the (partial) snapshot.
+func Take(procRoot string) (*Snapshot, []error) {
+	s := &Snapshot{Time: time.Now()}
+	var errs []error
+
+	processes, namespaces, socketOwners, err := readProcesses(procRoot)
+	if err != nil {
+		errs = append(errs, errfmt.Errorf("processes: %v", err))
+	}
+	s.Processes = processes
+	s.Namespaces = namespaces
+
+	s.Sockets, err = readSockets(procRoot, namespaces, socketOwners)
+	if err != nil {
+		errs = append(errs, errfmt.Errorf("sockets: %v", err))
+	}
+
+	s.Modules, err = readKernelModules(procRoot)
+	if err != nil {
+		errs = append(errs, errfmt.Errorf("kernel modules: %v", err))
+	}
+
+	return s, errs
+}
+
+// Summary returns the snapshot summary.
+func (s *Snapshot) Summary() *Summary {
+	return &Summary{
+		Time:       s.Time,
+		Processes:  len(s.Processes),
+		Sockets:    len(s.Sockets),
+		Modules:    len(s.Modules),
+		Namespaces: len(s.Namespaces),
+	}
+}
@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProc creates a procfs tree with two processes sharing namespaces, a tcp socket
+// owned by one of them, a process in another network namespace with an udp socket and a
+// kernel module.
+func fakeProc(t *testing.T) string {
+	root := t.TempDir()
+
+	write := func(path, content string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	link := func(path, target string) {
+		path = filepath.Join(root, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.Symlink(target, path))
+	}
+
+	write("1/status", "Name:\tsystemd\nPPid:\t0\nUid:\t0\t0\t0\t0\nThreads:\t1\n")
+	write("1/cmdline", "/sbin/init\x00splash\x00")
+	write("1/stat", "1 (systemd) S 0 1 1 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 12 0 0")
+	link("1/exe", "/usr/lib/systemd/systemd")
+	link("1/ns/mnt", "mnt:[4026531841]")
+	link("1/ns/net", "net:[4026531840]")
+
+	write("42/status", "Name:\tnginx\nPPid:\t1\nUid:\t101\t101\t101\t101\nThreads:\t4\n")
+	write("42/cmdline", "nginx: master process\x00")
+	write("42/stat", "42 (nginx: master) S 1 42 42 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 4 0 1500 0 0")
+	link("42/exe", "/usr/sbin/nginx")
+	link("42/ns/mnt", "mnt:[4026532000]")
+	link("42/ns/net", "net:[4026531840]")
+	link("42/fd/3", "socket:[31337]")
+	link("42/fd/4", "/dev/null")
+
+	write("43/status", "Name:\tdnsmasq\nPPid:\t1\nUid:\t0\t0\t0\t0\nThreads:\t1\n")
+	write("43/stat", "43 (dnsmasq) S 1 43 43 0 -1 4194560 0 0 0 0 0 0 0 0 20 0 1 0 1600 0 0")
+	link("43/ns/net", "net:[4026532100]")
+	link("43/fd/5", "socket:[4242]")
+	write("43/net/udp", "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops\n"+
+		"   0: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 4242 2 0000000000000000 0\n")
+
+	write("1/net/tcp", "  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"+
+		"   0: 0100007F:0050 00000000:0000 0A 00000000:00000000 00:00000000 00000000   101        0 31337 1 0000000000000000 100 0 0 10 0\n")
+	write("1/net/tcp6", "  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"+
+		"   0: 00000000000000000000000001000000:0016 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 555 1 0000000000000000 100 0 0 10 0\n")
+	write("modules", "nf_tables 249856 2 nft_chain_nat,nft_compat, Live 0xffffffffc0a00000\nloop 32768 0 - Live 0x0000000000000000\n")
+
+	write("self", "") // not a pid
+
+	return root
+}
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	s, errs := Take(fakeProc(t))
+	require.Empty(t, errs)
+
+	assert.Equal(t, []Process{
+		{Pid: 1, PPid: 0, Uid: 0, Comm: "systemd", Exe: "/usr/lib/systemd/systemd", Cmdline: "/sbin/init splash", StartTime: 12, Threads: 1},
+		{Pid: 42, PPid: 1, Uid: 101, Comm: "nginx", Exe: "/usr/sbin/nginx", Cmdline: "nginx: master process", StartTime: 1500, Threads: 4},
+		{Pid: 43, PPid: 1, Uid: 0, Comm: "dnsmasq", StartTime: 1600, Threads: 1},
+	}, s.Processes)
+
+	assert.Equal(t, []Namespace{
+		{Type: "mnt", Inode: 4026531841, Processes: 1, Pid: 1},
+		{Type: "mnt", Inode: 4026532000, Processes: 1, Pid: 42},
+		{Type: "net", Inode: 4026531840, Processes: 2, Pid: 1},
+		{Type: "net", Inode: 4026532100, Processes: 1, Pid: 43},
+	}, s.Namespaces)
+
+	assert.Equal(t, []Socket{
+		{Protocol: "tcp", LocalAddr: "127.0.0.1", LocalPort: 80, RemoteAddr: "0.0.0.0", RemotePort: 0, State: "LISTEN", Inode: 31337, Pid: 42},
+		{Protocol: "tcp6", LocalAddr: "::1", LocalPort: 22, RemoteAddr: "::", RemotePort: 0, State: "LISTEN", Inode: 555, Pid: 0},
+		{Protocol: "udp", LocalAddr: "0.0.0.0", LocalPort: 53, RemoteAddr: "0.0.0.0", RemotePort: 0, State: "CLOSE", Inode: 4242, Pid: 43},
+	}, s.Sockets)
+
+	assert.Equal(t, []KernelModule{
+		{Name: "nf_tables", Size: 249856, RefCount: 2, Dependencies: "nft_chain_nat,nft_compat", State: "Live", Address: 0xffffffffc0a00000},
+		{Name: "loop", Size: 32768, RefCount: 0, Dependencies: "", State: "Live", Address: 0},
+	}, s.Modules)
+}
+
+func TestWriteBundle(t *testing.T) {
+	t.Parallel()
+
+	s, errs := Take(fakeProc(t))
+	require.Empty(t, errs)
+
+	path, err := WriteBundle(t.TempDir(), s)
+	require.NoError(t, err)
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+	}
+	assert.Equal(t, []string{
+		"summary.json", "processes.json", "sockets.json", "kernel_modules.json", "namespaces.json",
+	}, names)
+}
+
+func TestWriteBundlePrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.json"), nil, 0o644))
+
+	start := time.Unix(1700000000, 0)
+	var paths []string
+	for i := 0; i < MaxBundles+2; i++ {
+		path, err := WriteBundle(dir, &Snapshot{Time: start.Add(time.Duration(i) * time.Second)})
+		require.NoError(t, err)
+		paths = append(paths, path)
+	}
+
+	for i, path := range paths {
+		_, err := os.Stat(path)
+		if i < 2 {
+			assert.True(t, os.IsNotExist(err), "oldest bundle %d is removed", i)
+		} else {
+			assert.NoError(t, err, "bundle %d is kept", i)
+		}
+	}
+	assert.FileExists(t, filepath.Join(dir, "other.json"))
+}
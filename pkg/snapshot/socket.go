@@ -0,0 +1,145 @@
+package snapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Socket is an open inet socket.
+type Socket struct {
+	Protocol   string `json:"protocol"`
+	LocalAddr  string `json:"local_addr"`
+	LocalPort  uint16 `json:"local_port"`
+	RemoteAddr string `json:"remote_addr"`
+	RemotePort uint16 `json:"remote_port"`
+	State      string `json:"state"`
+	Inode      uint64 `json:"inode"`
+	Pid        int    `json:"pid"` // 0 if the owner is unknown
+}
+
+// socketTables are the /proc/net tables read, by protocol.
+var socketTables = []string{"tcp", "tcp6", "udp", "udp6"}
+
+// tcpStates are the socket states as listed in /proc/net tables (include/net/tcp_states.h).
+var tcpStates = map[uint64]string{
+	0x01: "ESTABLISHED",
+	0x02: "SYN_SENT",
+	0x03: "SYN_RECV",
+	0x04: "FIN_WAIT1",
+	0x05: "FIN_WAIT2",
+	0x06: "TIME_WAIT",
+	0x07: "CLOSE",
+	0x08: "CLOSE_WAIT",
+	0x09: "LAST_ACK",
+	0x0A: "LISTEN",
+	0x0B: "CLOSING",
+	0x0C: "NEW_SYN_RECV",
+}
+
+// readSockets reads the inet sockets of all the network namespaces in use. The tables of a
+// network namespace are read once, through its lowest pid (/proc/<pid>/net). If no network
+// namespace is known, the tables of the network namespace procfs was mounted in are read.
+func readSockets(procRoot string, namespaces []Namespace, socketOwners map[uint64]int) ([]Socket, error) {
+	var netDirs []string
+	for _, ns := range namespaces {
+		if ns.Type == "net" {
+			netDirs = append(netDirs, filepath.Join(procRoot, strconv.Itoa(ns.Pid), "net"))
+		}
+	}
+	if len(netDirs) == 0 {
+		netDirs = append(netDirs, filepath.Join(procRoot, "net"))
+	}
+
+	var sockets []Socket
+
+	for _, netDir := range netDirs {
+		for _, protocol := range socketTables {
+			table, err := readSocketTable(filepath.Join(netDir, protocol), protocol)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue // e.g. ipv6 disabled, or the process exited meanwhile
+				}
+				return sockets, err
+			}
+			for i := range table {
+				table[i].Pid = socketOwners[table[i].Inode]
+			}
+			sockets = append(sockets, table...)
+		}
+	}
+
+	return sockets, nil
+}
+
+func readSocketTable(path, protocol string) ([]Socket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var sockets []Socket
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 {
+			continue
+		}
+		localAddr, localPort, err := parseSocketAddr(fields[1])
+		if err != nil {
+			continue
+		}
+		remoteAddr, remotePort, err := parseSocketAddr(fields[2])
+		if err != nil {
+			continue
+		}
+		state, _ := strconv.ParseUint(fields[3], 16, 8)
+		inode, _ := strconv.ParseUint(fields[9], 10, 64)
+
+		sockets = append(sockets, Socket{
+			Protocol:   protocol,
+			LocalAddr:  localAddr,
+			LocalPort:  localPort,
+			RemoteAddr: remoteAddr,
+			RemotePort: remotePort,
+			State:      tcpStates[state],
+			Inode:      inode,
+		})
+	}
+
+	return sockets, scanner.Err()
+}
+
+// parseSocketAddr parses a /proc/net address (e.g. 0100007F:0035). Addresses are written
+// as host byte order (little endian) 32 bit words.
+func parseSocketAddr(addr string) (string, uint16, error) {
+	hexIP, hexPort, ok := strings.Cut(addr, ":")
+	if !ok {
+		return "", 0, strconv.ErrSyntax
+	}
+
+	raw, err := hex.DecodeString(hexIP)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return "", 0, strconv.ErrSyntax
+	}
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return ip.String(), uint16(port), nil
+}
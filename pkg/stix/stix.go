@@ -0,0 +1,297 @@
+// Package stix converts findings to STIX 2.1 objects, to be ingested by threat
+// intelligence platforms.
+//
+// Each finding is converted to:
+//   - an indicator, describing the signature (shared by the findings of a signature)
+//   - an observed-data, with the process (and its executable file) the finding was
+//     detected on
+//   - a sighting, relating the observed-data to the indicator
+package stix
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+const (
+	SpecVersion = "2.1"
+
+	// PatternType is the indicators pattern type: their pattern is the tracee signature id.
+	PatternType = "tracee"
+
+	timestampFormat = "2006-01-02T15:04:05.000Z"
+)
+
+var (
+	// indicatorNamespace derives the indicator ids from the signature ids.
+	indicatorNamespace = uuid.MustParse("3cb0e8a6-4c4f-4c5e-9a4b-0a1f1f3f7c2d")
+	// scoNamespace derives the cyber observable ids, as defined by the STIX 2.1 specification.
+	scoNamespace = uuid.MustParse("00abedb4-aa42-466c-9c01-fed23315a9b7")
+)
+
+// confidences maps the findings confidence to the STIX confidence scale (low/med/high).
+var confidences = map[string]int{"low": 15, "medium": 50, "high": 85}
+
+// Bundle is a STIX bundle.
+type Bundle struct {
+	Type    string        `json:"type"`
+	ID      string        `json:"id"`
+	Objects []interface{} `json:"objects"`
+}
+
+type common struct {
+	Type        string `json:"type"`
+	SpecVersion string `json:"spec_version"`
+	ID          string `json:"id"`
+}
+
+// Indicator describes a tracee signature.
+type Indicator struct {
+	common
+	Created            string              `json:"created"`
+	Modified           string              `json:"modified"`
+	Name               string              `json:"name"`
+	Description        string              `json:"description,omitempty"`
+	IndicatorTypes     []string            `json:"indicator_types"`
+	Pattern            string              `json:"pattern"`
+	PatternType        string              `json:"pattern_type"`
+	ValidFrom          string              `json:"valid_from"`
+	KillChainPhases    []KillChainPhase    `json:"kill_chain_phases,omitempty"`
+	ExternalReferences []ExternalReference `json:"external_references,omitempty"`
+}
+
+type KillChainPhase struct {
+	KillChainName string `json:"kill_chain_name"`
+	PhaseName     string `json:"phase_name"`
+}
+
+type ExternalReference struct {
+	SourceName  string `json:"source_name"`
+	ExternalID  string `json:"external_id,omitempty"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// ObservedData holds the cyber observables a finding was detected on.
+type ObservedData struct {
+	common
+	Created        string   `json:"created"`
+	Modified       string   `json:"modified"`
+	FirstObserved  string   `json:"first_observed"`
+	LastObserved   string   `json:"last_observed"`
+	NumberObserved int      `json:"number_observed"`
+	ObjectRefs     []string `json:"object_refs"`
+	HostName       string   `json:"x_tracee_hostname,omitempty"`
+	ContainerID    string   `json:"x_tracee_container_id,omitempty"`
+	ContainerImage string   `json:"x_tracee_container_image,omitempty"`
+	PodName        string   `json:"x_tracee_pod_name,omitempty"`
+	PodNamespace   string   `json:"x_tracee_pod_namespace,omitempty"`
+}
+
+// Sighting is a finding: a sighting of a signature indicator.
+type Sighting struct {
+	common
+	Created          string   `json:"created"`
+	Modified         string   `json:"modified"`
+	FirstSeen        string   `json:"first_seen"`
+	LastSeen         string   `json:"last_seen"`
+	Count            int      `json:"count"`
+	SightingOfRef    string   `json:"sighting_of_ref"`
+	ObservedDataRefs []string `json:"observed_data_refs"`
+	Confidence       int      `json:"confidence,omitempty"`
+	Severity         string   `json:"x_tracee_severity,omitempty"`
+	EventName        string   `json:"x_tracee_event_name"`
+}
+
+// Process is a process cyber observable.
+type Process struct {
+	common
+	PID      int    `json:"pid,omitempty"`
+	ImageRef string `json:"image_ref,omitempty"`
+	Name     string `json:"x_tracee_process_name,omitempty"`
+}
+
+// File is a file cyber observable.
+type File struct {
+	common
+	Name   string            `json:"name"`
+	Path   string            `json:"x_tracee_path,omitempty"`
+	Hashes map[string]string `json:"hashes,omitempty"`
+}
+
+// NewBundle converts the findings to a STIX bundle. The indicators are only included
+// once per bundle.
+func NewBundle(findings []trace.Event) Bundle {
+	bundle := Bundle{
+		Type:    "bundle",
+		ID:      "bundle--" + uuid.NewString(),
+		Objects: []interface{}{},
+	}
+	indicators := make(map[string]bool)
+
+	for _, finding := range findings {
+		indicator := newIndicator(finding)
+		if !indicators[indicator.ID] {
+			indicators[indicator.ID] = true
+			bundle.Objects = append(bundle.Objects, indicator)
+		}
+
+		observables, observedData := newObservedData(finding)
+		bundle.Objects = append(bundle.Objects, observables...)
+		bundle.Objects = append(bundle.Objects, observedData, newSighting(finding, indicator, observedData))
+	}
+
+	return bundle
+}
+
+func newIndicator(finding trace.Event) Indicator {
+	properties := finding.Metadata.Properties
+	signatureID, _ := properties["signatureID"].(string)
+	signatureName, _ := properties["signatureName"].(string)
+	if signatureName == "" {
+		signatureName = finding.EventName
+	}
+	timestamp := formatTime(finding.Timestamp)
+
+	indicator := Indicator{
+		common:         newCommon("indicator", uuid.NewSHA1(indicatorNamespace, []byte(signatureID))),
+		Created:        timestamp,
+		Modified:       timestamp,
+		Name:           signatureName,
+		Description:    finding.Metadata.Description,
+		IndicatorTypes: []string{"malicious-activity"},
+		Pattern:        signatureID,
+		PatternType:    PatternType,
+		ValidFrom:      timestamp,
+	}
+
+	if mitre := finding.Metadata.Mitre; mitre != nil {
+		if mitre.Tactic != "" {
+			indicator.KillChainPhases = []KillChainPhase{
+				{KillChainName: "mitre-attack", PhaseName: mitre.Tactic},
+			}
+		}
+		for _, id := range mitre.TechniqueIDs {
+			indicator.ExternalReferences = append(indicator.ExternalReferences, ExternalReference{
+				SourceName:  "mitre-attack",
+				ExternalID:  id,
+				Description: mitre.Technique,
+				URL:         "https://attack.mitre.org/techniques/" + strings.ReplaceAll(id, ".", "/"),
+			})
+		}
+	}
+
+	return indicator
+}
+
+// newObservedData returns the cyber observables of the finding, and the observed-data
+// referencing them.
+func newObservedData(finding trace.Event) ([]interface{}, ObservedData) {
+	var observables []interface{}
+	var refs []string
+
+	process := Process{
+		common: newCommon("process", uuid.New()),
+		PID:    finding.HostProcessID,
+		Name:   finding.ProcessName,
+	}
+
+	if path := finding.Executable.Path; path != "" {
+		file := File{Name: filepath.Base(path), Path: path}
+		if hash := triggerHash(finding); hash != "" {
+			file.Hashes = map[string]string{"SHA-256": hash}
+		}
+		file.common = newCommon("file", scoID(file.Name, file.Hashes))
+		process.ImageRef = file.ID
+		observables = append(observables, file)
+		refs = append(refs, file.ID)
+	}
+
+	observables = append(observables, process)
+	refs = append(refs, process.ID)
+
+	timestamp := formatTime(finding.Timestamp)
+
+	return observables, ObservedData{
+		common:         newCommon("observed-data", uuid.New()),
+		Created:        timestamp,
+		Modified:       timestamp,
+		FirstObserved:  timestamp,
+		LastObserved:   timestamp,
+		NumberObserved: 1,
+		ObjectRefs:     refs,
+		HostName:       finding.HostName,
+		ContainerID:    finding.Container.ID,
+		ContainerImage: finding.Container.ImageName,
+		PodName:        finding.Kubernetes.PodName,
+		PodNamespace:   finding.Kubernetes.PodNamespace,
+	}
+}
+
+func newSighting(finding trace.Event, indicator Indicator, observedData ObservedData) Sighting {
+	timestamp := formatTime(finding.Timestamp)
+
+	return Sighting{
+		common:           newCommon("sighting", uuid.New()),
+		Created:          timestamp,
+		Modified:         timestamp,
+		FirstSeen:        timestamp,
+		LastSeen:         timestamp,
+		Count:            1,
+		SightingOfRef:    indicator.ID,
+		ObservedDataRefs: []string{observedData.ID},
+		Confidence:       confidences[finding.Metadata.Confidence],
+		Severity:         finding.Metadata.Severity,
+		EventName:        finding.EventName,
+	}
+}
+
+func newCommon(objectType string, id uuid.UUID) common {
+	return common{
+		Type:        objectType,
+		SpecVersion: SpecVersion,
+		ID:          fmt.Sprintf("%s--%s", objectType, id),
+	}
+}
+
+// scoID returns the deterministic id of a file, from its id contributing properties.
+func scoID(name string, hashes map[string]string) uuid.UUID {
+	contributing := map[string]interface{}{"name": name}
+	if len(hashes) > 0 {
+		contributing["hashes"] = hashes
+	}
+	data, _ := json.Marshal(contributing) // keys are sorted
+
+	return uuid.NewSHA1(scoNamespace, data)
+}
+
+// triggerHash returns the executable hash given by the event which triggered the
+// finding (exec-hash output option), if any.
+func triggerHash(finding trace.Event) string {
+	for _, arg := range finding.Args {
+		if arg.Name != "triggeredBy" {
+			continue
+		}
+		triggeredBy, _ := arg.Value.(map[string]interface{})
+		args, _ := triggeredBy["args"].([]trace.Argument)
+		for _, triggerArg := range args {
+			if triggerArg.Name == "sha256" {
+				hash, _ := triggerArg.Value.(string)
+				return hash
+			}
+		}
+	}
+
+	return ""
+}
+
+func formatTime(timestamp int) string {
+	return time.Unix(0, int64(timestamp)).UTC().Format(timestampFormat)
+}
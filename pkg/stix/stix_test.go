@@ -0,0 +1,127 @@
+package stix
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func newFinding(signatureID string, pid int) trace.Event {
+	return trace.Event{
+		Timestamp:     int(time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC).UnixNano()),
+		EventName:     "anti_debugging",
+		HostProcessID: pid,
+		ProcessName:   "strace",
+		HostName:      "node-1",
+		Executable:    trace.File{Path: "/usr/bin/strace"},
+		Container:     trace.Container{ID: "abc123", ImageName: "debian:12"},
+		Args: []trace.Argument{
+			{
+				ArgMeta: trace.ArgMeta{Name: "triggeredBy", Type: "unknown"},
+				Value: map[string]interface{}{
+					"args": []trace.Argument{
+						{ArgMeta: trace.ArgMeta{Name: "sha256", Type: "const char*"}, Value: "deadbeef"},
+					},
+				},
+			},
+		},
+		Metadata: &trace.Metadata{
+			Description: "A process used anti-debugging techniques",
+			Properties: map[string]interface{}{
+				"signatureID":   signatureID,
+				"signatureName": "Anti-Debugging detected",
+			},
+			Severity:   "high",
+			Confidence: "medium",
+			Mitre: &trace.MitreAttack{
+				Tactic:       "defense-evasion",
+				Technique:    "Debugger Evasion",
+				TechniqueIDs: []string{"T1622", "T1055.001"},
+			},
+		},
+	}
+}
+
+func TestNewBundle(t *testing.T) {
+	t.Parallel()
+
+	bundle := NewBundle([]trace.Event{newFinding("TRC-102", 42), newFinding("TRC-102", 43)})
+
+	assert.Equal(t, "bundle", bundle.Type)
+	assert.Regexp(t, "^bundle--[0-9a-f-]{36}$", bundle.ID)
+
+	// 1 indicator (shared), then per finding: a file, a process, an observed-data and a sighting
+	require.Len(t, bundle.Objects, 9)
+
+	indicator, ok := bundle.Objects[0].(Indicator)
+	require.True(t, ok)
+	assert.Equal(t, "TRC-102", indicator.Pattern)
+	assert.Equal(t, PatternType, indicator.PatternType)
+	assert.Equal(t, "Anti-Debugging detected", indicator.Name)
+	assert.Equal(t, "2024-05-01T12:30:00.123Z", indicator.ValidFrom)
+	assert.Equal(t, []KillChainPhase{{KillChainName: "mitre-attack", PhaseName: "defense-evasion"}}, indicator.KillChainPhases)
+	require.Len(t, indicator.ExternalReferences, 2)
+	assert.Equal(t, "https://attack.mitre.org/techniques/T1055/001", indicator.ExternalReferences[1].URL)
+
+	// the indicator id is derived from the signature id
+	assert.Equal(t, indicator.ID, NewBundle([]trace.Event{newFinding("TRC-102", 1)}).Objects[0].(Indicator).ID)
+	assert.NotEqual(t, indicator.ID, NewBundle([]trace.Event{newFinding("TRC-103", 1)}).Objects[0].(Indicator).ID)
+
+	file, ok := bundle.Objects[1].(File)
+	require.True(t, ok)
+	assert.Equal(t, "strace", file.Name)
+	assert.Equal(t, map[string]string{"SHA-256": "deadbeef"}, file.Hashes)
+
+	process, ok := bundle.Objects[2].(Process)
+	require.True(t, ok)
+	assert.Equal(t, 42, process.PID)
+	assert.Equal(t, file.ID, process.ImageRef)
+
+	observedData, ok := bundle.Objects[3].(ObservedData)
+	require.True(t, ok)
+	assert.Equal(t, []string{file.ID, process.ID}, observedData.ObjectRefs)
+	assert.Equal(t, "debian:12", observedData.ContainerImage)
+
+	sighting, ok := bundle.Objects[4].(Sighting)
+	require.True(t, ok)
+	assert.Equal(t, indicator.ID, sighting.SightingOfRef)
+	assert.Equal(t, []string{observedData.ID}, sighting.ObservedDataRefs)
+	assert.Equal(t, 50, sighting.Confidence)
+	assert.Equal(t, "high", sighting.Severity)
+
+	// the same executable is the same file observable
+	assert.Equal(t, file.ID, bundle.Objects[5].(File).ID)
+	assert.NotEqual(t, process.ID, bundle.Objects[6].(Process).ID)
+}
+
+func TestNewBundleJSON(t *testing.T) {
+	t.Parallel()
+
+	finding := newFinding("TRC-102", 42)
+	finding.Executable.Path = ""
+	finding.Metadata.Mitre = nil
+
+	data, err := json.Marshal(NewBundle([]trace.Event{finding}))
+	require.NoError(t, err)
+
+	var bundle struct {
+		Objects []map[string]interface{} `json:"objects"`
+	}
+	require.NoError(t, json.Unmarshal(data, &bundle))
+	require.Len(t, bundle.Objects, 4)
+
+	for _, object := range bundle.Objects {
+		assert.Equal(t, SpecVersion, object["spec_version"])
+		assert.NotContains(t, object, "kill_chain_phases")
+	}
+	assert.Equal(t, "indicator", bundle.Objects[0]["type"])
+	assert.Equal(t, "process", bundle.Objects[1]["type"])
+	assert.NotContains(t, bundle.Objects[1], "image_ref")
+	assert.Equal(t, "observed-data", bundle.Objects[2]["type"])
+	assert.Equal(t, "sighting", bundle.Objects[3]["type"])
+}
@@ -0,0 +1,279 @@
+package symbolizer
+
+import (
+	"debug/dwarf"
+	"debug/elf"
+	"sort"
+)
+
+const maxResolvedPCs = 4096 // resolved addresses cached per binary
+
+// frame is a function of a resolved address.
+type frame struct {
+	function string
+	file     string
+	line     int
+	inlined  bool
+}
+
+// symbol is a function of the symbol tables.
+type symbol struct {
+	name  string
+	value uint64
+	size  uint64
+}
+
+// unit is the address range of a DWARF compilation unit.
+type unit struct {
+	low    uint64
+	high   uint64
+	offset dwarf.Offset
+}
+
+// binary is the symbols (and the debugging information, if loaded) of an ELF binary.
+type binary struct {
+	buildID  string
+	loads    []elf.ProgHeader // loadable segments
+	symbols  []symbol         // ordered by value
+	dwarf    *dwarf.Data      // nil if not loaded
+	units    []unit           // ordered by address
+	resolved map[uint64][]frame
+}
+
+// newBinary loads the symbols of an ELF binary, and its debugging information if asked to.
+func newBinary(f *elf.File, buildID string, withDWARF bool) *binary {
+	b := &binary{
+		buildID:  buildID,
+		resolved: make(map[uint64][]frame),
+	}
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_LOAD {
+			b.loads = append(b.loads, prog.ProgHeader)
+		}
+	}
+
+	syms, _ := f.Symbols() // stripped binaries have none
+	dynSyms, _ := f.DynamicSymbols()
+	for _, sym := range append(syms, dynSyms...) {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC && sym.Value != 0 {
+			b.symbols = append(b.symbols, symbol{name: sym.Name, value: sym.Value, size: sym.Size})
+		}
+	}
+	sort.Slice(b.symbols, func(i, j int) bool {
+		return b.symbols[i].value < b.symbols[j].value
+	})
+
+	if withDWARF {
+		if data, err := f.DWARF(); err == nil {
+			b.dwarf = data
+			b.units = compileUnits(data)
+		}
+	}
+
+	return b
+}
+
+// compileUnits returns the address ranges of the DWARF compilation units.
+func compileUnits(data *dwarf.Data) []unit {
+	var units []unit
+
+	r := data.Reader()
+	for {
+		entry, err := r.Next()
+		if err != nil || entry == nil {
+			break
+		}
+		if entry.Tag == dwarf.TagCompileUnit {
+			ranges, _ := data.Ranges(entry)
+			for _, rng := range ranges {
+				units = append(units, unit{low: rng[0], high: rng[1], offset: entry.Offset})
+			}
+		}
+		r.SkipChildren()
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].low < units[j].low
+	})
+
+	return units
+}
+
+// virtualAddress returns the virtual address (as in the ELF file) of an offset in the file.
+func (b *binary) virtualAddress(fileOffset uint64) (uint64, bool) {
+	for _, load := range b.loads {
+		if fileOffset >= load.Off && fileOffset < load.Off+load.Filesz {
+			return fileOffset - load.Off + load.Vaddr, true
+		}
+	}
+
+	return 0, false
+}
+
+// resolve returns the functions of a virtual address: from its debugging information, with
+// its inlined functions (innermost first), or else from the symbol tables.
+func (b *binary) resolve(pc uint64) []frame {
+	if frames, ok := b.resolved[pc]; ok {
+		return frames
+	}
+
+	frames := b.resolveDWARF(pc)
+	if len(frames) == 0 {
+		if sym, ok := b.symbol(pc); ok {
+			frames = []frame{{function: sym.name}}
+		}
+	}
+
+	if len(b.resolved) >= maxResolvedPCs {
+		b.resolved = make(map[uint64][]frame)
+	}
+	b.resolved[pc] = frames
+
+	return frames
+}
+
+// symbol returns the function symbol of a virtual address.
+func (b *binary) symbol(pc uint64) (symbol, bool) {
+	i := sort.Search(len(b.symbols), func(i int) bool {
+		return b.symbols[i].value > pc
+	})
+	if i == 0 {
+		return symbol{}, false
+	}
+	sym := b.symbols[i-1]
+	if sym.size > 0 && pc >= sym.value+sym.size {
+		return symbol{}, false
+	}
+
+	return sym, true
+}
+
+// resolveDWARF returns the functions of a virtual address from the debugging information: the
+// innermost inlined function at the line of the address, then every function it was inlined
+// in, at the line of the call site, up to the concrete function.
+func (b *binary) resolveDWARF(pc uint64) []frame {
+	if b.dwarf == nil {
+		return nil
+	}
+	i := sort.Search(len(b.units), func(i int) bool {
+		return b.units[i].high > pc
+	})
+	if i == len(b.units) || b.units[i].low > pc {
+		return nil
+	}
+
+	r := b.dwarf.Reader()
+	r.Seek(b.units[i].offset)
+	cu, err := r.Next()
+	if err != nil || cu == nil {
+		return nil
+	}
+	lr, err := b.dwarf.LineReader(cu)
+	if err != nil || lr == nil {
+		return nil
+	}
+
+	// the functions containing the address, from the concrete one to the innermost inlined one
+	var scopes []*dwarf.Entry
+	if cu.Children {
+		scopes = b.scopes(r, pc, nil)
+	}
+	if len(scopes) == 0 {
+		return nil
+	}
+
+	files := lr.Files()
+	innermost := frame{function: b.name(scopes[len(scopes)-1])}
+	var line dwarf.LineEntry
+	if err := lr.SeekPC(pc, &line); err == nil && line.File != nil {
+		innermost.file = line.File.Name
+		innermost.line = line.Line
+	}
+
+	frames := []frame{innermost}
+	for n := len(scopes) - 1; n > 0; n-- {
+		frames[len(frames)-1].inlined = true
+
+		// the caller is at the call site of the inlined function
+		caller := frame{function: b.name(scopes[n-1])}
+		if idx, ok := scopes[n].Val(dwarf.AttrCallFile).(int64); ok && idx >= 0 && int(idx) < len(files) && files[idx] != nil {
+			caller.file = files[idx].Name
+		}
+		if callLine, ok := scopes[n].Val(dwarf.AttrCallLine).(int64); ok {
+			caller.line = int(callLine)
+		}
+		frames = append(frames, caller)
+	}
+
+	return frames
+}
+
+// scopes appends the functions (concrete or inlined) containing the address among the
+// children of the current entry, walking down to the innermost one.
+func (b *binary) scopes(r *dwarf.Reader, pc uint64, scopes []*dwarf.Entry) []*dwarf.Entry {
+	for {
+		entry, err := r.Next()
+		if err != nil || entry == nil || entry.Tag == 0 {
+			return scopes // end of the children
+		}
+
+		switch entry.Tag {
+		case dwarf.TagSubprogram, dwarf.TagInlinedSubroutine, dwarf.TagLexDwarfBlock:
+			if b.contains(entry, pc) {
+				if entry.Tag != dwarf.TagLexDwarfBlock {
+					scopes = append(scopes, entry)
+				}
+				if entry.Children {
+					return b.scopes(r, pc, scopes)
+				}
+				return scopes
+			}
+		case dwarf.TagNamespace, dwarf.TagModule:
+			// functions of namespaces are their children, not at the unit level
+			if entry.Children {
+				if found := b.scopes(r, pc, scopes); len(found) > len(scopes) {
+					return found
+				}
+				continue
+			}
+		}
+		if entry.Children {
+			r.SkipChildren()
+		}
+	}
+}
+
+func (b *binary) contains(entry *dwarf.Entry, pc uint64) bool {
+	ranges, err := b.dwarf.Ranges(entry)
+	if err != nil {
+		return false
+	}
+	for _, rng := range ranges {
+		if pc >= rng[0] && pc < rng[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// name returns the name of a function, following its abstract origin (inlined functions) or
+// its specification (e.g. methods) if needed.
+func (b *binary) name(entry *dwarf.Entry) string {
+	for i := 0; i < 4 && entry != nil; i++ {
+		if name, ok := entry.Val(dwarf.AttrName).(string); ok {
+			return name
+		}
+		offset, ok := entry.Val(dwarf.AttrAbstractOrigin).(dwarf.Offset)
+		if !ok {
+			offset, ok = entry.Val(dwarf.AttrSpecification).(dwarf.Offset)
+		}
+		if !ok {
+			return ""
+		}
+		r := b.dwarf.Reader()
+		r.Seek(offset)
+		entry, _ = r.Next()
+	}
+
+	return ""
+}
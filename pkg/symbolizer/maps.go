@@ -0,0 +1,74 @@
+package symbolizer
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// mapping is a file backed memory mapping of a process.
+type mapping struct {
+	start  uint64
+	end    uint64
+	offset uint64 // offset of the mapping in the file
+	path   string // path of the file, in the mount namespace of the process
+}
+
+// parseMaps parses the file backed mappings of a /proc/<pid>/maps file, ordered by address.
+func parseMaps(r io.Reader) ([]mapping, error) {
+	var mappings []mapping
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// address perms offset dev inode pathname
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || !strings.HasPrefix(fields[5], "/") {
+			continue // anonymous, or pseudo path (e.g. [vdso])
+		}
+		start, end, found := strings.Cut(fields[0], "-")
+		if !found {
+			return nil, errfmt.Errorf("invalid mapping address: %s", fields[0])
+		}
+
+		var m mapping
+		var err error
+		if m.start, err = strconv.ParseUint(start, 16, 64); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if m.end, err = strconv.ParseUint(end, 16, 64); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if m.offset, err = strconv.ParseUint(fields[2], 16, 64); err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		// paths may contain spaces, and deleted files are suffixed
+		m.path = strings.TrimSuffix(strings.Join(fields[5:], " "), " (deleted)")
+
+		mappings = append(mappings, m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].start < mappings[j].start
+	})
+
+	return mappings, nil
+}
+
+// findMapping returns the mapping of an address.
+func findMapping(mappings []mapping, addr uint64) (mapping, bool) {
+	i := sort.Search(len(mappings), func(i int) bool {
+		return mappings[i].end > addr
+	})
+	if i < len(mappings) && mappings[i].start <= addr {
+		return mappings[i], true
+	}
+
+	return mapping{}, false
+}
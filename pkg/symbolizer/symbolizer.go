@@ -0,0 +1,227 @@
+// Package symbolizer resolves the user stack addresses of the events to the functions they
+// belong to, from the symbol tables of the binaries, and from their debugging information
+// (DWARF) for the allowed binaries: with the inlined functions and the source file and line of
+// every frame, making the stack traces actionable.
+package symbolizer
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/aquasecurity/tracee/pkg/buildid"
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// DefaultCacheSize is the default number of binaries kept loaded.
+const DefaultCacheSize = 64
+
+const maxProcesses = 1024 // processes whose memory mappings are kept
+
+// Config is the configuration of the stack addresses symbolization.
+type Config struct {
+	Enabled       bool
+	DWARFBinaries []string // path patterns of the binaries whose debugging information is used
+	CacheSize     int      // binaries kept loaded
+}
+
+// fileKey identifies a binary file, along with the information loaded from it.
+type fileKey struct {
+	dev       uint64
+	ino       uint64
+	mtime     int64
+	withDWARF bool
+}
+
+// buildKey identifies a binary by its build ID, along with the information loaded from it.
+type buildKey struct {
+	buildID   string
+	withDWARF bool
+}
+
+// Symbolizer resolves the stack addresses of processes. The binaries are cached by file and by
+// build ID (the same binary in different containers is loaded once). It is thread-safe.
+type Symbolizer struct {
+	mutex     sync.Mutex
+	cfg       Config
+	procFS    string
+	processes *lru.Cache[int, []mapping]
+	files     *lru.Cache[fileKey, *binary] // nil if the file isn't a supported binary
+	binaries  *lru.Cache[buildKey, *binary]
+}
+
+// New creates a stack addresses symbolizer.
+func New(cfg Config) (*Symbolizer, error) {
+	if cfg.CacheSize <= 0 {
+		return nil, errfmt.Errorf("invalid symbolizer cache size: %d", cfg.CacheSize)
+	}
+	for _, pattern := range cfg.DWARFBinaries {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, errfmt.Errorf("invalid binary pattern %q: %v", pattern, err)
+		}
+	}
+
+	processes, err := lru.New[int, []mapping](maxProcesses)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	files, err := lru.New[fileKey, *binary](cfg.CacheSize)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	binaries, err := lru.New[buildKey, *binary](cfg.CacheSize)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	return &Symbolizer{
+		cfg:       cfg,
+		procFS:    "/proc",
+		processes: processes,
+		files:     files,
+		binaries:  binaries,
+	}, nil
+}
+
+// Symbolize returns the frames of the stack addresses (leaf first) of a process, given by its
+// host pid. The addresses that can't be resolved have a frame with their address only (and the
+// binary mapping them, if known).
+func (s *Symbolizer) Symbolize(pid int, addresses []uint64) []trace.StackFrame {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	mappings, cached := s.processes.Get(pid)
+	if !cached {
+		mappings = s.loadMappings(pid)
+	}
+
+	frames := make([]trace.StackFrame, 0, len(addresses))
+	for i, addr := range addresses {
+		m, found := findMapping(mappings, addr)
+		if !found && cached {
+			// mapped since the mappings were read (e.g. a shared object loaded)
+			mappings, cached = s.loadMappings(pid), false
+			m, found = findMapping(mappings, addr)
+		}
+		if !found {
+			frames = append(frames, trace.StackFrame{Address: addr})
+			continue
+		}
+
+		pc := addr
+		if i > 0 {
+			pc-- // a return address: the call is the previous instruction
+		}
+		frames = append(frames, s.resolve(pid, m, addr, pc)...)
+	}
+
+	return frames
+}
+
+// resolve returns the frames of an address in a mapping.
+func (s *Symbolizer) resolve(pid int, m mapping, addr, pc uint64) []trace.StackFrame {
+	unresolved := []trace.StackFrame{{Address: addr, Binary: m.path}}
+
+	b := s.binary(pid, m.path)
+	if b == nil {
+		return unresolved
+	}
+	unresolved[0].BuildID = b.buildID
+	vaddr, ok := b.virtualAddress(pc - m.start + m.offset)
+	if !ok {
+		return unresolved
+	}
+	resolved := b.resolve(vaddr)
+	if len(resolved) == 0 {
+		return unresolved
+	}
+
+	frames := make([]trace.StackFrame, 0, len(resolved))
+	for _, f := range resolved {
+		frames = append(frames, trace.StackFrame{
+			Address:  addr,
+			Function: f.function,
+			File:     f.file,
+			Line:     f.line,
+			Inlined:  f.inlined,
+			Binary:   m.path,
+			BuildID:  b.buildID,
+		})
+	}
+
+	return frames
+}
+
+// loadMappings reads the file backed memory mappings of a process.
+func (s *Symbolizer) loadMappings(pid int) []mapping {
+	f, err := os.Open(filepath.Join(s.procFS, strconv.Itoa(pid), "maps"))
+	if err != nil {
+		return nil // the process exited
+	}
+	defer f.Close()
+
+	mappings, err := parseMaps(f)
+	if err != nil {
+		return nil
+	}
+	s.processes.Add(pid, mappings)
+
+	return mappings
+}
+
+// binary returns the binary of a path in the mount namespace of a process, nil if it isn't a
+// supported binary.
+func (s *Symbolizer) binary(pid int, path string) *binary {
+	hostPath := filepath.Join(s.procFS, strconv.Itoa(pid), "root", path)
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		return nil
+	}
+	key := fileKey{mtime: info.ModTime().UnixNano(), withDWARF: s.dwarfAllowed(path)}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		key.dev, key.ino = uint64(stat.Dev), stat.Ino
+	}
+	if b, ok := s.files.Get(key); ok {
+		return b
+	}
+
+	f, err := elf.Open(hostPath)
+	if err != nil {
+		s.files.Add(key, nil)
+		return nil
+	}
+	defer f.Close()
+
+	bKey := buildKey{buildID: buildid.FromELF(f), withDWARF: key.withDWARF}
+	if bKey.buildID != "" {
+		if b, ok := s.binaries.Get(bKey); ok {
+			s.files.Add(key, b)
+			return b
+		}
+	}
+
+	b := newBinary(f, bKey.buildID, key.withDWARF)
+	s.files.Add(key, b)
+	if bKey.buildID != "" {
+		s.binaries.Add(bKey, b)
+	}
+
+	return b
+}
+
+// dwarfAllowed returns whether the debugging information of a binary is used.
+func (s *Symbolizer) dwarfAllowed(path string) bool {
+	for _, pattern := range s.cfg.DWARFBinaries {
+		if matched, _ := filepath.Match(pattern, path); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,119 @@
+package symbolizer
+
+import (
+	"debug/elf"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+func TestParseMaps(t *testing.T) {
+	t.Parallel()
+
+	maps := `55d0c4c00000-55d0c4c28000 r--p 00000000 08:01 1048601                    /usr/bin/bash
+55d0c4c28000-55d0c4cd9000 r-xp 00028000 08:01 1048601                    /usr/bin/bash
+55d0c5a8e000-55d0c5c1f000 rw-p 00000000 00:00 0                          [heap]
+7f3a1c600000-7f3a1c628000 r--p 00000000 08:01 1055121                    /tmp/my lib.so (deleted)
+7ffd3b5f2000-7ffd3b5f4000 r-xp 00000000 00:00 0                          [vdso]
+`
+	mappings, err := parseMaps(strings.NewReader(maps))
+	require.NoError(t, err)
+	assert.Equal(t, []mapping{
+		{start: 0x55d0c4c00000, end: 0x55d0c4c28000, offset: 0, path: "/usr/bin/bash"},
+		{start: 0x55d0c4c28000, end: 0x55d0c4cd9000, offset: 0x28000, path: "/usr/bin/bash"},
+		{start: 0x7f3a1c600000, end: 0x7f3a1c628000, offset: 0, path: "/tmp/my lib.so"},
+	}, mappings)
+
+	m, found := findMapping(mappings, 0x55d0c4c30000)
+	assert.True(t, found)
+	assert.Equal(t, uint64(0x28000), m.offset)
+
+	_, found = findMapping(mappings, 0x55d0c4cd9000)
+	assert.False(t, found)
+}
+
+// callerPC returns the address of its call to runtime.Callers. It is inlined in its callers.
+func callerPC() uint64 {
+	var pcs [1]uintptr
+	runtime.Callers(1, pcs[:])
+	return uint64(pcs[0])
+}
+
+func TestSymbolize(t *testing.T) {
+	t.Parallel()
+
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	f, err := elf.Open(executable)
+	require.NoError(t, err)
+	defer f.Close()
+	if f.Section(".debug_info") == nil {
+		t.Skip("test binary without debugging information (e.g. stripped by go test, use go test -c)")
+	}
+
+	testCases := []struct {
+		name      string
+		dwarf     []string
+		assertion func(t *testing.T, frames []trace.StackFrame)
+	}{
+		{
+			name: "symbol tables",
+			assertion: func(t *testing.T, frames []trace.StackFrame) {
+				require.Len(t, frames, 1)
+				assert.True(t, strings.HasPrefix(frames[0].Function, "github.com/aquasecurity/tracee/pkg/symbolizer.TestSymbolize.func"))
+				assert.Empty(t, frames[0].File)
+				assert.False(t, frames[0].Inlined)
+			},
+		},
+		{
+			name:  "debugging information with inlined functions",
+			dwarf: []string{executable},
+			assertion: func(t *testing.T, frames []trace.StackFrame) {
+				require.Len(t, frames, 2)
+				assert.Equal(t, "github.com/aquasecurity/tracee/pkg/symbolizer.callerPC", frames[0].Function)
+				assert.True(t, frames[0].Inlined)
+				assert.True(t, strings.HasSuffix(frames[0].File, "symbolizer_test.go"))
+				assert.Positive(t, frames[0].Line)
+				assert.True(t, strings.HasPrefix(frames[1].Function, "github.com/aquasecurity/tracee/pkg/symbolizer.TestSymbolize.func"))
+				assert.False(t, frames[1].Inlined)
+				assert.True(t, strings.HasSuffix(frames[1].File, "symbolizer_test.go"))
+				assert.Greater(t, frames[1].Line, frames[0].Line)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := New(Config{Enabled: true, DWARFBinaries: tc.dwarf, CacheSize: DefaultCacheSize})
+			require.NoError(t, err)
+
+			addr := callerPC()
+			frames := s.Symbolize(os.Getpid(), []uint64{addr})
+			for _, f := range frames {
+				assert.Equal(t, addr, f.Address)
+				assert.Equal(t, executable, f.Binary)
+			}
+			tc.assertion(t, frames)
+		})
+	}
+}
+
+func TestSymbolizeUnmapped(t *testing.T) {
+	t.Parallel()
+
+	s, err := New(Config{Enabled: true, CacheSize: DefaultCacheSize})
+	require.NoError(t, err)
+
+	frames := s.Symbolize(os.Getpid(), []uint64{0x10})
+	assert.Equal(t, []trace.StackFrame{{Address: 0x10}}, frames)
+}
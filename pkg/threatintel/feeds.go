@@ -0,0 +1,287 @@
+package threatintel
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+const (
+	taxiiMediaType = "application/taxii+json;version=2.1"
+	requestTimeout = 30 * time.Second
+	maxTAXIIPages  = 1000
+)
+
+func loadFeed(ctx context.Context, feed Feed) (*indicators, error) {
+	switch feed.Type {
+	case FeedCSV:
+		return loadFile(feed.Source, parseCSV)
+	case FeedMISP:
+		return loadFile(feed.Source, parseMISP)
+	case FeedTAXII:
+		return loadTAXII(ctx, feed.Source)
+	}
+
+	return nil, errfmt.Errorf("unsupported feed type %q", feed.Type)
+}
+
+func loadFile(path string, parse func(r io.Reader) (*indicators, error)) (*indicators, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	defer f.Close()
+
+	return parse(f)
+}
+
+// csvTypes maps the csv (and misp attributes) indicator types to the indicator types.
+var csvTypes = map[string]IndicatorType{
+	"ip":       IP,
+	"ip-src":   IP,
+	"ip-dst":   IP,
+	"domain":   Domain,
+	"hostname": Domain,
+	"sha256":   SHA256,
+}
+
+// parseCSV parses a csv feed: one indicator per line, as type,value[,description].
+// Empty lines, lines starting with '#' and a type,value header line are skipped.
+func parseCSV(r io.Reader) (*indicators, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	loaded := newIndicators()
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if len(record) < 2 {
+			return nil, errfmt.Errorf("line %d: type,value[,description] expected", line)
+		}
+		if line == 1 && strings.EqualFold(record[0], "type") {
+			continue
+		}
+
+		indicatorType, ok := csvTypes[strings.ToLower(record[0])]
+		if !ok {
+			return nil, errfmt.Errorf("line %d: unsupported indicator type %q", line, record[0])
+		}
+		indicator := Indicator{Type: indicatorType, Value: record[1]}
+		if len(record) > 2 {
+			indicator.Description = record[2]
+		}
+		loaded.add(indicator)
+	}
+
+	return loaded, nil
+}
+
+type mispAttribute struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type mispEvent struct {
+	Info      string          `json:"info"`
+	Attribute []mispAttribute `json:"Attribute"`
+	Object    []struct {
+		Attribute []mispAttribute `json:"Attribute"`
+	} `json:"Object"`
+}
+
+// parseMISP parses a misp json export: an event ({"Event": {...}}), a list of events,
+// or a search response ({"response": [...]}).
+func parseMISP(r io.Reader) (*indicators, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	type wrappedEvent struct {
+		Event mispEvent `json:"Event"`
+	}
+	var wrapped []wrappedEvent
+
+	var single wrappedEvent
+	var response struct {
+		Response []wrappedEvent `json:"response"`
+	}
+	switch {
+	case json.Unmarshal(data, &wrapped) == nil:
+	case json.Unmarshal(data, &response) == nil && response.Response != nil:
+		wrapped = response.Response
+	case json.Unmarshal(data, &single) == nil:
+		wrapped = []wrappedEvent{single}
+	default:
+		return nil, errfmt.Errorf("invalid misp export")
+	}
+
+	loaded := newIndicators()
+	for _, w := range wrapped {
+		attributes := w.Event.Attribute
+		for _, object := range w.Event.Object {
+			attributes = append(attributes, object.Attribute...)
+		}
+		for _, attribute := range attributes {
+			for _, indicator := range mispIndicators(attribute) {
+				indicator.Description = w.Event.Info
+				loaded.add(indicator)
+			}
+		}
+	}
+
+	return loaded, nil
+}
+
+// mispIndicators returns the indicators of a misp attribute, including the composite
+// attributes (e.g. domain|ip or filename|sha256).
+func mispIndicators(attribute mispAttribute) []Indicator {
+	if indicatorType, ok := csvTypes[attribute.Type]; ok {
+		return []Indicator{{Type: indicatorType, Value: attribute.Value}}
+	}
+
+	types := strings.Split(attribute.Type, "|")
+	values := strings.Split(attribute.Value, "|")
+	if len(types) != len(values) {
+		return nil
+	}
+
+	var result []Indicator
+	for i, t := range types {
+		if indicatorType, ok := csvTypes[t]; ok {
+			result = append(result, Indicator{Type: indicatorType, Value: values[i]})
+		}
+	}
+
+	return result
+}
+
+// stixComparison matches the comparisons of stix patterns, holding indicators.
+var stixComparison = regexp.MustCompile(
+	`(ipv4-addr:value|ipv6-addr:value|domain-name:value|file:hashes\.'SHA-256'|file:hashes\.SHA256|file:hashes\.'SHA256')\s*=\s*'([^']*)'`,
+)
+
+var stixTypes = map[string]IndicatorType{
+	"ipv4-addr:value":       IP,
+	"ipv6-addr:value":       IP,
+	"domain-name:value":     Domain,
+	"file:hashes.'SHA-256'": SHA256,
+	"file:hashes.SHA256":    SHA256,
+	"file:hashes.'SHA256'":  SHA256,
+}
+
+type taxiiEnvelope struct {
+	More    bool              `json:"more"`
+	Next    string            `json:"next"`
+	Objects []json.RawMessage `json:"objects"`
+}
+
+type stixIndicator struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`
+	PatternType string `json:"pattern_type"`
+}
+
+// loadTAXII loads the stix indicators of a taxii 2.1 collection, given its objects url.
+// Basic authentication credentials can be given in the url.
+func loadTAXII(ctx context.Context, collectionURL string) (*indicators, error) {
+	u, err := url.Parse(collectionURL)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+	client := &http.Client{Timeout: requestTimeout}
+
+	loaded := newIndicators()
+	next := ""
+	for page := 0; page < maxTAXIIPages; page++ {
+		pageURL := *u
+		query := pageURL.Query()
+		query.Set("match[type]", "indicator")
+		if next != "" {
+			query.Set("next", next)
+		}
+		pageURL.RawQuery = query.Encode()
+
+		envelope, err := getTAXII(ctx, client, pageURL.String())
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range envelope.Objects {
+			var indicator stixIndicator
+			if err := json.Unmarshal(object, &indicator); err != nil || indicator.Type != "indicator" {
+				continue
+			}
+			if indicator.PatternType != "" && indicator.PatternType != "stix" {
+				continue
+			}
+			for _, comparison := range stixComparison.FindAllStringSubmatch(indicator.Pattern, -1) {
+				loaded.add(Indicator{
+					Type:        stixTypes[comparison[1]],
+					Value:       comparison[2],
+					Description: indicator.Name,
+				})
+			}
+		}
+
+		if !envelope.More || envelope.Next == "" {
+			return loaded, nil
+		}
+		next = envelope.Next
+	}
+
+	return loaded, nil
+}
+
+func getTAXII(ctx context.Context, client *http.Client, pageURL string) (taxiiEnvelope, error) {
+	var envelope taxiiEnvelope
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return envelope, errfmt.WrapError(err)
+	}
+	req.Header.Set("Accept", taxiiMediaType)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return envelope, errfmt.WrapError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return envelope, errfmt.Errorf("taxii server http status: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return envelope, errfmt.WrapError(err)
+	}
+
+	return envelope, nil
+}
+
+// redactURL returns the url without its credentials.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.User == nil {
+		return rawURL
+	}
+	u.User = nil
+
+	return u.String()
+}
@@ -0,0 +1,272 @@
+// Package threatintel matches event indicators (remote IPs, domains and file hashes)
+// against locally loaded threat intelligence feeds.
+package threatintel
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// IndicatorType is the type of an indicator of compromise.
+type IndicatorType string
+
+const (
+	IP     IndicatorType = "ip"
+	Domain IndicatorType = "domain"
+	SHA256 IndicatorType = "sha256"
+)
+
+// FeedType is the format of a threat intelligence feed.
+type FeedType string
+
+const (
+	FeedCSV   FeedType = "csv"
+	FeedMISP  FeedType = "misp"
+	FeedTAXII FeedType = "taxii"
+)
+
+// DefaultRefresh is the default interval between two feeds refreshes.
+const DefaultRefresh = time.Hour
+
+// Feed is a threat intelligence feed.
+type Feed struct {
+	Type   FeedType
+	Source string // file path (csv and misp), or collection objects url (taxii)
+}
+
+// Name returns the feed name, used for the matches attribution. Credentials of taxii
+// urls are left out.
+func (f Feed) Name() string {
+	if f.Type == FeedTAXII {
+		return redactURL(f.Source)
+	}
+
+	return f.Source
+}
+
+// Config is the threat intelligence configuration.
+type Config struct {
+	Feeds   []Feed
+	Refresh time.Duration // interval between two feeds refreshes
+}
+
+// Indicator is an indicator of compromise of a feed.
+type Indicator struct {
+	Type        IndicatorType
+	Value       string
+	Description string // e.g. the misp event info, or the stix indicator name
+}
+
+// Match is an indicator found in a feed.
+type Match struct {
+	Indicator
+	Feed     string
+	FeedType FeedType
+}
+
+// indicators are the indicators of a feed, indexed for lookups.
+type indicators struct {
+	values   map[IndicatorType]map[string]Indicator
+	networks []*net.IPNet
+	netInfo  []Indicator // indicators of the networks, by index
+}
+
+func newIndicators() *indicators {
+	return &indicators{
+		values: map[IndicatorType]map[string]Indicator{
+			IP:     {},
+			Domain: {},
+			SHA256: {},
+		},
+	}
+}
+
+// add adds a normalized indicator, ignoring invalid ones.
+func (i *indicators) add(indicator Indicator) {
+	value := normalize(indicator.Type, indicator.Value)
+	if value == "" {
+		return
+	}
+	indicator.Value = value
+
+	if indicator.Type == IP && strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return
+		}
+		i.networks = append(i.networks, network)
+		i.netInfo = append(i.netInfo, indicator)
+		return
+	}
+
+	i.values[indicator.Type][value] = indicator
+}
+
+func (i *indicators) len() int {
+	n := len(i.networks)
+	for _, values := range i.values {
+		n += len(values)
+	}
+
+	return n
+}
+
+// Store holds the indicators of the feeds.
+type Store struct {
+	cfg Config
+
+	mu         sync.RWMutex
+	indicators []*indicators // by feed index, nil until the feed is loaded
+}
+
+// NewStore loads the feeds, failing if one of them can't be loaded.
+func NewStore(ctx context.Context, cfg Config) (*Store, error) {
+	if cfg.Refresh <= 0 {
+		cfg.Refresh = DefaultRefresh
+	}
+	s := &Store{
+		cfg:        cfg,
+		indicators: make([]*indicators, len(cfg.Feeds)),
+	}
+
+	for i, feed := range cfg.Feeds {
+		loaded, err := loadFeed(ctx, feed)
+		if err != nil {
+			return nil, errfmt.Errorf("loading threat intel feed %s: %v", feed.Name(), err)
+		}
+		s.indicators[i] = loaded
+		logger.Debugw("Threat intel feed loaded", "feed", feed.Name(), "indicators", loaded.len())
+	}
+
+	return s, nil
+}
+
+// Run refreshes the feeds at every refresh interval, until the context is done. The
+// indicators of a feed which fails to refresh are kept.
+func (s *Store) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.refresh(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Store) refresh(ctx context.Context) {
+	for i, feed := range s.cfg.Feeds {
+		loaded, err := loadFeed(ctx, feed)
+		if err != nil {
+			logger.Warnw("Refreshing threat intel feed", "feed", feed.Name(), "error", err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.indicators[i] = loaded
+		s.mu.Unlock()
+		logger.Debugw("Threat intel feed refreshed", "feed", feed.Name(), "indicators", loaded.len())
+	}
+}
+
+// Lookup returns the matches of the given value, in all the feeds. Domains also match
+// the indicators of their parent domains, and IPs the indicators of their networks.
+func (s *Store) Lookup(indicatorType IndicatorType, value string) []Match {
+	value = normalize(indicatorType, value)
+	if value == "" {
+		return nil
+	}
+
+	var keys []string
+	var ip net.IP
+	switch indicatorType {
+	case Domain:
+		keys = parentDomains(value)
+	case IP:
+		keys = []string{value}
+		ip = net.ParseIP(value)
+	default:
+		keys = []string{value}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []Match
+	for i, feedIndicators := range s.indicators {
+		if feedIndicators == nil {
+			continue
+		}
+		feed := s.cfg.Feeds[i]
+
+		for _, key := range keys {
+			if indicator, ok := feedIndicators.values[indicatorType][key]; ok {
+				matches = append(matches, Match{Indicator: indicator, Feed: feed.Name(), FeedType: feed.Type})
+			}
+		}
+		if ip == nil {
+			continue
+		}
+		for n, network := range feedIndicators.networks {
+			if network.Contains(ip) {
+				matches = append(matches, Match{Indicator: feedIndicators.netInfo[n], Feed: feed.Name(), FeedType: feed.Type})
+			}
+		}
+	}
+
+	return matches
+}
+
+// normalize returns the canonical form of an indicator value, or an empty string if it
+// is invalid.
+func normalize(indicatorType IndicatorType, value string) string {
+	value = strings.TrimSpace(value)
+
+	switch indicatorType {
+	case IP:
+		if strings.Contains(value, "/") {
+			_, network, err := net.ParseCIDR(value)
+			if err != nil {
+				return ""
+			}
+			return network.String()
+		}
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	case Domain:
+		return strings.TrimSuffix(strings.ToLower(value), ".")
+	case SHA256:
+		value = strings.ToLower(value)
+		if len(value) != 64 {
+			return ""
+		}
+		return value
+	}
+
+	return ""
+}
+
+// parentDomains returns the domain and its parent domains, e.g. a.b.com, b.com and com.
+func parentDomains(domain string) []string {
+	domains := []string{domain}
+	for {
+		idx := strings.Index(domain, ".")
+		if idx < 0 {
+			return domains
+		}
+		domain = domain[idx+1:]
+		domains = append(domains, domain)
+	}
+}
@@ -0,0 +1,215 @@
+package threatintel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testHash = "E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855"
+
+func writeFeed(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestCSVFeed(t *testing.T) {
+	t.Parallel()
+
+	path := writeFeed(t, "feed.csv", `type,value,description
+# comment
+ip,203.0.113.7,c2 server
+ip,198.51.100.0/24,bulletproof hosting
+domain,Evil.example.,phishing
+sha256,`+testHash+`
+`)
+
+	store, err := NewStore(context.Background(), Config{Feeds: []Feed{{Type: FeedCSV, Source: path}}})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		indicatorType IndicatorType
+		value         string
+		expected      []Match
+	}{
+		{
+			name:          "ip",
+			indicatorType: IP,
+			value:         "203.0.113.7",
+			expected: []Match{{
+				Indicator: Indicator{Type: IP, Value: "203.0.113.7", Description: "c2 server"},
+				Feed:      path,
+				FeedType:  FeedCSV,
+			}},
+		},
+		{
+			name:          "ip in network",
+			indicatorType: IP,
+			value:         "198.51.100.12",
+			expected: []Match{{
+				Indicator: Indicator{Type: IP, Value: "198.51.100.0/24", Description: "bulletproof hosting"},
+				Feed:      path,
+				FeedType:  FeedCSV,
+			}},
+		},
+		{
+			name:          "subdomain",
+			indicatorType: Domain,
+			value:         "login.EVIL.example",
+			expected: []Match{{
+				Indicator: Indicator{Type: Domain, Value: "evil.example", Description: "phishing"},
+				Feed:      path,
+				FeedType:  FeedCSV,
+			}},
+		},
+		{
+			name:          "hash",
+			indicatorType: SHA256,
+			value:         strings.ToLower(testHash),
+			expected: []Match{{
+				Indicator: Indicator{Type: SHA256, Value: strings.ToLower(testHash)},
+				Feed:      path,
+				FeedType:  FeedCSV,
+			}},
+		},
+		{name: "no match", indicatorType: IP, value: "192.0.2.1"},
+		{name: "parent domain", indicatorType: Domain, value: "example"},
+		{name: "invalid ip", indicatorType: IP, value: "not an ip"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, store.Lookup(tc.indicatorType, tc.value))
+		})
+	}
+}
+
+func TestCSVFeedInvalid(t *testing.T) {
+	t.Parallel()
+
+	path := writeFeed(t, "feed.csv", "url,http://evil.example\n")
+	_, err := NewStore(context.Background(), Config{Feeds: []Feed{{Type: FeedCSV, Source: path}}})
+	assert.ErrorContains(t, err, `unsupported indicator type "url"`)
+
+	_, err = NewStore(context.Background(), Config{Feeds: []Feed{{Type: FeedCSV, Source: path + ".missing"}}})
+	assert.ErrorContains(t, err, "no such file or directory")
+}
+
+func TestMISPFeed(t *testing.T) {
+	t.Parallel()
+
+	event := `{"Event": {
+		"info": "APT campaign",
+		"Attribute": [
+			{"type": "ip-dst|port", "value": "203.0.113.7|443"},
+			{"type": "domain|ip", "value": "evil.example|198.51.100.1"},
+			{"type": "url", "value": "http://evil.example/payload"}
+		],
+		"Object": [
+			{"Attribute": [{"type": "sha256", "value": "` + testHash + `"}]}
+		]
+	}}`
+
+	for name, content := range map[string]string{
+		"event":    event,
+		"list":     "[" + event + "]",
+		"response": `{"response": [` + event + `]}`,
+	} {
+		path := writeFeed(t, name+".json", content)
+		store, err := NewStore(context.Background(), Config{Feeds: []Feed{{Type: FeedMISP, Source: path}}})
+		require.NoError(t, err, name)
+
+		assert.Len(t, store.Lookup(IP, "203.0.113.7"), 1, name)
+		assert.Empty(t, store.Lookup(Domain, "payload"), name) // url attributes are ignored
+		assert.Len(t, store.Lookup(Domain, "evil.example"), 1, name)
+		assert.Len(t, store.Lookup(SHA256, testHash), 1, name)
+
+		matches := store.Lookup(IP, "198.51.100.1")
+		require.Len(t, matches, 1, name)
+		assert.Equal(t, "APT campaign", matches[0].Description, name)
+		assert.Equal(t, FeedMISP, matches[0].FeedType, name)
+	}
+}
+
+func TestTAXIIFeed(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]taxiiEnvelope{
+		"": {More: true, Next: "2", Objects: []json.RawMessage{
+			json.RawMessage(`{"type": "indicator", "name": "c2", "pattern_type": "stix",
+				"pattern": "[ipv4-addr:value = '203.0.113.7'] OR [domain-name:value = 'evil.example']"}`),
+			json.RawMessage(`{"type": "indicator", "name": "yara rule", "pattern_type": "yara", "pattern": "rule x {}"}`),
+		}},
+		"2": {Objects: []json.RawMessage{
+			json.RawMessage(`{"type": "indicator", "name": "dropper", "pattern_type": "stix",
+				"pattern": "[file:hashes.'SHA-256' = '` + testHash + `']"}`),
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		user, password, _ := req.BasicAuth()
+		if user != "user" || password != "pass" {
+			http.Error(rw, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, taxiiMediaType, req.Header.Get("Accept"))
+		assert.Equal(t, "indicator", req.URL.Query().Get("match[type]"))
+
+		page, ok := pages[req.URL.Query().Get("next")]
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(page)
+	}))
+	defer server.Close()
+
+	feed := Feed{Type: FeedTAXII, Source: "http://user:pass@" + server.Listener.Addr().String() + "/collections/1/objects/"}
+	assert.NotContains(t, feed.Name(), "pass")
+
+	store, err := NewStore(context.Background(), Config{Feeds: []Feed{feed}})
+	require.NoError(t, err)
+
+	matches := store.Lookup(IP, "203.0.113.7")
+	require.Len(t, matches, 1)
+	assert.Equal(t, "c2", matches[0].Description)
+	assert.Equal(t, feed.Name(), matches[0].Feed)
+	assert.Len(t, store.Lookup(Domain, "www.evil.example"), 1)
+	assert.Len(t, store.Lookup(SHA256, testHash), 1)
+
+	unauthorized := Feed{Type: FeedTAXII, Source: server.URL + "/collections/1/objects/"}
+	_, err = NewStore(context.Background(), Config{Feeds: []Feed{unauthorized}})
+	assert.ErrorContains(t, err, "http status: 401")
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	path := writeFeed(t, "feed.csv", "ip,203.0.113.7\n")
+	store, err := NewStore(context.Background(), Config{Feeds: []Feed{{Type: FeedCSV, Source: path}}})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte("ip,203.0.113.8\n"), 0o600))
+	store.refresh(context.Background())
+	assert.Empty(t, store.Lookup(IP, "203.0.113.7"))
+	assert.Len(t, store.Lookup(IP, "203.0.113.8"), 1)
+
+	// the indicators are kept when the feed can't be refreshed
+	require.NoError(t, os.Remove(path))
+	store.refresh(context.Background())
+	assert.Len(t, store.Lookup(IP, "203.0.113.8"), 1)
+}
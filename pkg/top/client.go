@@ -0,0 +1,205 @@
+package top
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/aquasecurity/tracee/api/v1beta1"
+)
+
+// Dial connects to the gRPC server of tracee, as given to --grpc-listen-addr: "tcp:<port>"
+// (localhost), "tcp:<host>:<port>" or "unix:<socket path>".
+func Dial(server string) (*grpc.ClientConn, error) {
+	var target string
+	switch {
+	case strings.HasPrefix(server, "tcp:"):
+		target = strings.TrimPrefix(server, "tcp:")
+		if !strings.Contains(target, ":") {
+			target = "localhost:" + target
+		}
+	case strings.HasPrefix(server, "unix:"):
+		target = "unix://" + strings.TrimPrefix(server, "unix:")
+	default:
+		return nil, fmt.Errorf("invalid server address: %s (tcp:<port>, tcp:<host>:<port> or unix:<path>)", server)
+	}
+
+	return grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// Run runs the view of the tracee listening on the given server in the terminal, until the
+// user quits or the context is done: it streams the events of the given policies (all if
+// none), and polls the pipeline metrics, rendering the view every interval.
+func Run(ctx context.Context, server string, interval time.Duration, policies []string) error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("tracee top needs a terminal")
+	}
+
+	conn, err := Dial(server)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tracee := pb.NewTraceeServiceClient(conn)
+	diagnostic := pb.NewDiagnosticServiceClient(conn)
+
+	stream, err := tracee.StreamEvents(ctx, &pb.StreamEventsRequest{Policies: policies})
+	if err != nil {
+		return fmt.Errorf("streaming the events: %w", err)
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = term.Restore(int(os.Stdin.Fd()), state)
+		fmt.Print("\x1b[H\x1b[2J") // leave a clear terminal
+	}()
+
+	model := NewModel(server)
+	errs := make(chan error, 2)
+
+	// events
+	go func() {
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- fmt.Errorf("streaming the events: %w", err)
+				}
+				return
+			}
+			model.Add(toEvent(res.GetEvent()))
+		}
+	}()
+
+	// keys
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	toggle := func(name string, enable bool) error {
+		if enable {
+			_, err := tracee.EnableEvent(ctx, &pb.EnableEventRequest{Name: name})
+			return err
+		}
+		_, err := tracee.DisableEvent(ctx, &pb.DisableEventRequest{Name: name})
+		return err
+	}
+
+	render := func() {
+		width, height, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			width, height = 120, 40
+		}
+		_ = model.Render(os.Stdout, width, height)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := time.Now()
+	render()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case now := <-ticker.C:
+			metrics, err := diagnostic.GetMetrics(ctx, &pb.GetMetricsRequest{})
+			if err == nil {
+				model.SetMetrics(Metrics{
+					EventCount:     metrics.EventCount,
+					EventsFiltered: metrics.EventsFiltered,
+					ErrorCount:     metrics.ErrorCount,
+					LostEvCount:    metrics.LostEvCount,
+					LostWrCount:    metrics.LostWrCount,
+					LostNtCapCount: metrics.LostNtCapCount,
+				})
+			}
+			model.Tick(now.Sub(last))
+			last = now
+			render()
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch key {
+			case "q", "\x03": // q, ctrl-c
+				return nil
+			case "k", "\x1b[A":
+				model.Move(-1)
+			case "j", "\x1b[B":
+				model.Move(1)
+			case " ":
+				model.Toggle(toggle)
+			default:
+				continue
+			}
+			render()
+		}
+	}
+}
+
+// readKeys sends the keys read from the terminal (in raw mode), until it is closed.
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 16)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		keys <- string(buf[:n])
+	}
+}
+
+// toEvent converts an event streamed by tracee to the view event.
+func toEvent(event *pb.Event) Event {
+	container := event.GetContext().GetContainer()
+	name := container.GetName()
+	if name == "" {
+		name = container.GetId()
+		if len(name) > 12 {
+			name = name[:12]
+		}
+	}
+
+	e := Event{
+		Name:      event.GetName(),
+		Container: name,
+	}
+
+	if threat := event.GetThreat(); threat != nil {
+		process := event.GetContext().GetProcess()
+		e.Finding = &Finding{
+			Time:      event.GetTimestamp().AsTime().Local(),
+			Name:      threat.GetName(),
+			Severity:  threat.GetSeverity().String(),
+			Container: name,
+			Process:   process.GetExecutable().GetPath(),
+		}
+		if e.Finding.Process == "" {
+			e.Finding.Process = process.GetThread().GetName()
+		}
+		if e.Finding.Name == "" {
+			e.Finding.Name = e.Name
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,308 @@
+// Package top implements the interactive terminal view of a running tracee (tracee top): the
+// live rates of the events, per event and per container, the recent findings and the health of
+// the events pipeline, with keybindings to enable and disable the events.
+package top
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxFindings is the number of recent findings kept
+	maxFindings = 10
+	// hostContainer is the name of the container row of the host processes
+	hostContainer = "host"
+)
+
+// Event is an event received from tracee, as counted by the view.
+type Event struct {
+	Name      string
+	Container string   // container name, or id (empty for the host processes)
+	Finding   *Finding // nil if the event isn't a finding
+}
+
+// Finding is a finding received from tracee.
+type Finding struct {
+	Time      time.Time
+	Name      string
+	Severity  string
+	Container string
+	Process   string
+}
+
+// Metrics are the counters of the events pipeline of tracee.
+type Metrics struct {
+	EventCount     uint64
+	EventsFiltered uint64
+	ErrorCount     uint64
+	LostEvCount    uint64
+	LostWrCount    uint64
+	LostNtCapCount uint64
+}
+
+// lost returns the number of events lost, in all the perf buffers.
+func (m Metrics) lost() uint64 {
+	return m.LostEvCount + m.LostWrCount + m.LostNtCapCount
+}
+
+// counter is the number of events of a row: in total, and per second over the last interval
+type counter struct {
+	name   string
+	total  uint64
+	window uint64 // events since the last tick
+	rate   float64
+}
+
+// Model is the state of the view: it counts the events received, and renders them at every
+// tick. It is safe for concurrent use.
+type Model struct {
+	mu         sync.Mutex
+	server     string
+	events     map[string]*counter
+	containers map[string]*counter
+	findings   []Finding // most recent first
+	disabled   map[string]bool
+	selected   string // name of the selected event
+	metrics    Metrics
+	previous   *Metrics // metrics of the previous tick (nil before the first one)
+	rates      rates
+	status     string // last toggle result or error
+}
+
+// rates are the rates of the pipeline metrics over the last interval, per second
+type rates struct {
+	events float64
+	lost   float64
+	errors float64
+}
+
+// NewModel returns an empty view of the tracee listening on the given server.
+func NewModel(server string) *Model {
+	return &Model{
+		server:     server,
+		events:     map[string]*counter{},
+		containers: map[string]*counter{},
+		disabled:   map[string]bool{},
+	}
+}
+
+// Add counts an event received.
+func (m *Model) Add(event Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count(m.events, event.Name)
+	container := event.Container
+	if container == "" {
+		container = hostContainer
+	}
+	count(m.containers, container)
+
+	if event.Finding != nil {
+		m.findings = append([]Finding{*event.Finding}, m.findings...)
+		if len(m.findings) > maxFindings {
+			m.findings = m.findings[:maxFindings]
+		}
+	}
+}
+
+func count(counters map[string]*counter, name string) {
+	c, ok := counters[name]
+	if !ok {
+		c = &counter{name: name}
+		counters[name] = c
+	}
+	c.total++
+	c.window++
+}
+
+// SetMetrics updates the pipeline metrics, polled from tracee.
+func (m *Model) SetMetrics(metrics Metrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.metrics = metrics
+}
+
+// Tick computes the rates of the events received since the previous tick, elapsed ago.
+func (m *Model) Tick(elapsed time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, counters := range []map[string]*counter{m.events, m.containers} {
+		for _, c := range counters {
+			c.rate = float64(c.window) / elapsed.Seconds()
+			c.window = 0
+		}
+	}
+
+	if m.previous != nil {
+		rate := func(current, previous uint64) float64 {
+			if current < previous {
+				return 0 // tracee restarted
+			}
+			return float64(current-previous) / elapsed.Seconds()
+		}
+		m.rates = rates{
+			events: rate(m.metrics.EventCount, m.previous.EventCount),
+			lost:   rate(m.metrics.lost(), m.previous.lost()),
+			errors: rate(m.metrics.ErrorCount, m.previous.ErrorCount),
+		}
+	}
+	previous := m.metrics
+	m.previous = &previous
+}
+
+// Move moves the selection up (negative) or down (positive) the events rows.
+func (m *Model) Move(delta int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rows := m.eventRows()
+	if len(rows) == 0 {
+		return
+	}
+	i := 0
+	for j, row := range rows {
+		if row.name == m.selected {
+			i = j + delta
+		}
+	}
+	i = max(0, min(i, len(rows)-1))
+	m.selected = rows[i].name
+}
+
+// Toggle enables the selected event if disabled, disables it otherwise, with the given
+// function (e.g. through the tracee API).
+func (m *Model) Toggle(toggle func(name string, enable bool) error) {
+	m.mu.Lock()
+	name, enable := m.selected, m.disabled[m.selected]
+	m.mu.Unlock()
+	if name == "" {
+		return
+	}
+
+	err := toggle(name, enable)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case err != nil:
+		m.status = fmt.Sprintf("%s: %v", name, err)
+	case enable:
+		delete(m.disabled, name)
+		m.status = name + " enabled"
+	default:
+		m.disabled[name] = true
+		m.status = name + " disabled"
+	}
+}
+
+// eventRows returns the events rows, by rate (the disabled events last), then name.
+func (m *Model) eventRows() []*counter {
+	rows := sortedRows(m.events)
+	sort.SliceStable(rows, func(i, j int) bool {
+		return !m.disabled[rows[i].name] && m.disabled[rows[j].name]
+	})
+	return rows
+}
+
+func sortedRows(counters map[string]*counter) []*counter {
+	rows := make([]*counter, 0, len(counters))
+	for _, c := range counters {
+		rows = append(rows, c)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].rate != rows[j].rate {
+			return rows[i].rate > rows[j].rate
+		}
+		return rows[i].name < rows[j].name
+	})
+	return rows
+}
+
+// Render writes the view, fitting the given terminal size. The lines end with "\r\n", the
+// terminal being in raw mode.
+func (m *Model) Render(w io.Writer, width, height int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var lines []string
+	add := func(format string, args ...any) {
+		line := fmt.Sprintf(format, args...)
+		if width > 0 && len(line) > width {
+			line = line[:width]
+		}
+		lines = append(lines, line)
+	}
+
+	// header and pipeline health
+	var total float64
+	for _, c := range m.events {
+		total += c.rate
+	}
+	add("tracee top - %s - %s - %.1f events/s", m.server, time.Now().Format("15:04:05"), total)
+	health := "OK"
+	switch {
+	case m.rates.lost > 0:
+		health = "LOSING EVENTS"
+	case m.rates.errors > 0:
+		health = "ERRORS"
+	}
+	add("pipeline: %s - events %d (%.1f/s), filtered %d, lost %d (%.1f/s), errors %d",
+		health, m.metrics.EventCount, m.rates.events, m.metrics.EventsFiltered,
+		m.metrics.lost(), m.rates.lost, m.metrics.ErrorCount)
+	add("")
+
+	// the rows left are shared by the events (half), the containers and the findings
+	findings := min(len(m.findings), 5)
+	containerRows := sortedRows(m.containers)
+	budget := height - len(lines) - 6 - findings // section headers, blank lines and keys
+	eventsBudget := max(budget/2, 1)
+	containersBudget := max(budget-eventsBudget, 1)
+
+	add("%-2s%-40s %12s %12s", "", "EVENT", "RATE/s", "TOTAL")
+	for i, c := range m.eventRows() {
+		if i >= eventsBudget {
+			break
+		}
+		cursor, state := "  ", ""
+		if c.name == m.selected {
+			cursor = "> "
+		}
+		if m.disabled[c.name] {
+			state = " [off]"
+		}
+		add("%-2s%-40s %12.1f %12d", cursor, c.name+state, c.rate, c.total)
+	}
+	add("")
+
+	add("%-2s%-40s %12s %12s", "", "CONTAINER", "RATE/s", "TOTAL")
+	for i, c := range containerRows {
+		if i >= containersBudget {
+			break
+		}
+		add("%-2s%-40s %12.1f %12d", "", c.name, c.rate, c.total)
+	}
+	add("")
+
+	add("%-2sRECENT FINDINGS", "")
+	for _, f := range m.findings[:findings] {
+		container := f.Container
+		if container == "" {
+			container = hostContainer
+		}
+		add("  %s %-8s %-30s %-20s %s", f.Time.Format("15:04:05"), f.Severity, f.Name, container, f.Process)
+	}
+
+	add("")
+	add("keys: up/down (k/j) select, space toggle the event, q quit    %s", m.status)
+
+	_, err := io.WriteString(w, "\x1b[H\x1b[2J"+strings.Join(lines, "\x1b[K\r\n"))
+	return err
+}
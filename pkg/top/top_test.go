@@ -0,0 +1,186 @@
+package top
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/aquasecurity/tracee/api/v1beta1"
+)
+
+func TestModelRates(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel("unix:/tmp/tracee.sock")
+	for i := 0; i < 10; i++ {
+		m.Add(Event{Name: "openat", Container: "nginx"})
+	}
+	for i := 0; i < 4; i++ {
+		m.Add(Event{Name: "execve"})
+	}
+	m.SetMetrics(Metrics{EventCount: 100, LostEvCount: 5})
+	m.Tick(2 * time.Second)
+
+	rows := m.eventRows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "openat", rows[0].name)
+	assert.Equal(t, 5.0, rows[0].rate)
+	assert.Equal(t, "execve", rows[1].name)
+	assert.Equal(t, 2.0, rows[1].rate)
+
+	containers := sortedRows(m.containers)
+	require.Len(t, containers, 2)
+	assert.Equal(t, "nginx", containers[0].name)
+	assert.Equal(t, hostContainer, containers[1].name)
+
+	// no rates of the pipeline metrics before the second tick
+	assert.Equal(t, rates{}, m.rates)
+
+	m.Add(Event{Name: "execve"})
+	m.SetMetrics(Metrics{EventCount: 110, LostEvCount: 5, LostWrCount: 2, ErrorCount: 1})
+	m.Tick(time.Second)
+
+	rows = m.eventRows()
+	assert.Equal(t, "execve", rows[0].name)
+	assert.Equal(t, 1.0, rows[0].rate)
+	assert.Equal(t, uint64(5), rows[0].total)
+	assert.Equal(t, rates{events: 10, lost: 2, errors: 1}, m.rates)
+}
+
+func TestModelFindings(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel("tcp:4466")
+	for i := 0; i < maxFindings+5; i++ {
+		name := fmt.Sprintf("TRC-%d", i)
+		m.Add(Event{Name: name, Finding: &Finding{Name: name}})
+	}
+
+	require.Len(t, m.findings, maxFindings)
+	assert.Equal(t, fmt.Sprintf("TRC-%d", maxFindings+4), m.findings[0].Name)
+}
+
+func TestModelToggle(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel("tcp:4466")
+	m.Add(Event{Name: "openat"})
+	m.Add(Event{Name: "openat"})
+	m.Add(Event{Name: "execve"})
+	m.Tick(time.Second)
+
+	// nothing selected
+	m.Toggle(func(string, bool) error {
+		t.Fatal("toggled without selection")
+		return nil
+	})
+
+	m.Move(1)
+	assert.Equal(t, "openat", m.selected)
+	m.Move(5)
+	assert.Equal(t, "execve", m.selected)
+	m.Move(-5)
+	assert.Equal(t, "openat", m.selected)
+
+	var toggled []string
+	toggle := func(name string, enable bool) error {
+		toggled = append(toggled, fmt.Sprintf("%s=%v", name, enable))
+		return nil
+	}
+
+	// disabled events last
+	m.Toggle(toggle)
+	assert.True(t, m.disabled["openat"])
+	assert.Equal(t, "execve", m.eventRows()[0].name)
+	assert.Equal(t, "openat disabled", m.status)
+
+	m.Toggle(toggle)
+	assert.False(t, m.disabled["openat"])
+	assert.Equal(t, []string{"openat=false", "openat=true"}, toggled)
+
+	m.Toggle(func(string, bool) error { return errors.New("no such event") })
+	assert.False(t, m.disabled["openat"])
+	assert.Equal(t, "openat: no such event", m.status)
+}
+
+func TestModelRender(t *testing.T) {
+	t.Parallel()
+
+	m := NewModel("unix:/tmp/tracee.sock")
+	m.Add(Event{Name: "openat", Container: "nginx"})
+	m.Add(Event{
+		Name:      "TRC-2",
+		Container: "nginx",
+		Finding: &Finding{
+			Name:      "Anti-Debugging",
+			Severity:  "HIGH",
+			Container: "nginx",
+			Process:   "/usr/bin/strace",
+		},
+	})
+	m.SetMetrics(Metrics{EventCount: 10})
+	m.Tick(time.Second)
+	m.SetMetrics(Metrics{EventCount: 20, LostEvCount: 3})
+	m.Tick(time.Second)
+
+	var buf bytes.Buffer
+	require.NoError(t, m.Render(&buf, 120, 40))
+	out := buf.String()
+
+	assert.Contains(t, out, "tracee top - unix:/tmp/tracee.sock")
+	assert.Contains(t, out, "pipeline: LOSING EVENTS - events 20 (10.0/s)")
+	assert.Contains(t, out, "openat")
+	assert.Contains(t, out, "nginx")
+	assert.Contains(t, out, "Anti-Debugging")
+	assert.Contains(t, out, "/usr/bin/strace")
+
+	// the lines fit the width
+	buf.Reset()
+	require.NoError(t, m.Render(&buf, 20, 40))
+	for _, line := range bytes.Split(buf.Bytes(), []byte("\x1b[K\r\n")) {
+		assert.LessOrEqual(t, len(bytes.TrimPrefix(line, []byte("\x1b[H\x1b[2J"))), 20)
+	}
+}
+
+func TestToEvent(t *testing.T) {
+	t.Parallel()
+
+	event := toEvent(&pb.Event{
+		Name: "TRC-2",
+		Context: &pb.Context{
+			Process: &pb.Process{
+				Executable: &pb.Executable{Path: "/usr/bin/strace"},
+			},
+			Container: &pb.Container{Id: "0123456789abcdef"},
+		},
+		Threat: &pb.Threat{Name: "Anti-Debugging", Severity: pb.Severity_HIGH},
+	})
+
+	assert.Equal(t, "TRC-2", event.Name)
+	assert.Equal(t, "0123456789ab", event.Container)
+	require.NotNil(t, event.Finding)
+	assert.Equal(t, "Anti-Debugging", event.Finding.Name)
+	assert.Equal(t, "HIGH", event.Finding.Severity)
+	assert.Equal(t, "/usr/bin/strace", event.Finding.Process)
+
+	event = toEvent(&pb.Event{Name: "openat"})
+	assert.Equal(t, Event{Name: "openat"}, event)
+}
+
+func TestDial(t *testing.T) {
+	t.Parallel()
+
+	for _, server := range []string{"tcp:4466", "tcp:10.0.0.1:4466", "unix:/tmp/tracee.sock"} {
+		conn, err := Dial(server)
+		require.NoError(t, err, server)
+		conn.Close()
+	}
+
+	_, err := Dial("/tmp/tracee.sock")
+	require.Error(t, err)
+}
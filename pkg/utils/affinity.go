@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// MaxCPUs is the number of CPUs that can be set in an affinity mask.
+const MaxCPUs = len(unix.CPUSet{}) * 64
+
+// ParseCPUList parses a list of CPUs in the kernel "cpu list" format (e.g. "0-3,8,10-11",
+// as in /sys/devices/system/cpu/isolated), returning the sorted CPU numbers. CPUs must be
+// lower than MaxCPUs.
+func ParseCPUList(list string) ([]int, error) {
+	cpus := make(map[int]struct{})
+
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		first, last, isRange := strings.Cut(part, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil || start < 0 {
+			return nil, errfmt.Errorf("invalid cpu %q in cpu list %q", first, list)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil || end < start {
+				return nil, errfmt.Errorf("invalid cpu range %q in cpu list %q", part, list)
+			}
+		}
+		if end >= MaxCPUs {
+			return nil, errfmt.Errorf("cpu %d in cpu list %q is out of range (max %d)", end, list, MaxCPUs-1)
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			cpus[cpu] = struct{}{}
+		}
+	}
+
+	if len(cpus) == 0 {
+		return nil, errfmt.Errorf("empty cpu list")
+	}
+
+	res := make([]int, 0, len(cpus))
+	for cpu := range cpus {
+		res = append(res, cpu)
+	}
+	sort.Ints(res)
+
+	return res, nil
+}
+
+// GetAllowedCPUs returns the CPUs the current process is allowed to run on.
+func GetAllowedCPUs() ([]int, error) {
+	var set unix.CPUSet
+
+	if err := unix.SchedGetaffinity(0, &set); err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	cpus := make([]int, 0, set.Count())
+	for cpu := 0; cpu < MaxCPUs; cpu++ {
+		if set.IsSet(cpu) {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpus, nil
+}
+
+// PinGoroutineToCPUs locks the calling goroutine to its current OS thread and restricts
+// that thread to the given CPUs. The goroutine keeps the thread until it exits.
+func PinGoroutineToCPUs(cpus []int) error {
+	var set unix.CPUSet
+
+	for _, cpu := range cpus {
+		set.Set(cpu)
+	}
+
+	runtime.LockOSThread()
+
+	// pid 0 is the calling thread
+	if err := unix.SchedSetaffinity(0, &set); err != nil {
+		runtime.UnlockOSThread()
+		return errfmt.WrapError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package batcher implements the batching of the events of network outputs: the events (or the
+// records built from them) are batched until a size or time threshold, and the batches are then
+// sent in the background (instead of a request per event). The Batcher frames encoded events,
+// and optionally compresses the batches, e.g. for the webhook output.
+package batcher
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// Compression algorithms
+const (
+	CompressionNone = ""
+	CompressionZstd = "zstd"
+)
+
+// Framings of the events in a batch
+const (
+	// FramingLength prefixes each event with its length, a 32 bits big endian integer.
+	FramingLength = "length"
+	// FramingLines ends each event with a newline (e.g. JSON lines).
+	FramingLines = "lines"
+)
+
+// lengthPrefixSize is the size of the length prefix of the events, with FramingLength.
+const lengthPrefixSize = 4
+
+// Config is the configuration of a batcher. The zero value sends each event in its own batch.
+type Config struct {
+	MaxEvents   int           // events after which the batch is sent (1 if 0)
+	MaxBytes    int           // framed bytes after which the batch is sent (0 disables)
+	Interval    time.Duration // maximum duration an event is batched for (0 disables)
+	QueueSize   int           // batches waiting to be sent (DefaultQueueSize if 0)
+	Compression string        // CompressionNone or CompressionZstd
+	Framing     string        // FramingLength (if empty) or FramingLines
+}
+
+// Batch is a batch of framed events, compressed if configured.
+type Batch struct {
+	Data   []byte // valid until the send function returns
+	Events int
+}
+
+// SendFunc sends a batch, e.g. in a request to the destination of an output.
+type SendFunc func(Batch) error
+
+// Batcher batches encoded events, framed and optionally compressed, with a Queue: the batches
+// are sent with its send function in the background, one at a time and in order. Batcher is
+// thread-safe.
+type Batcher struct {
+	cfg     Config
+	send    SendFunc
+	encoder *zstd.Encoder // nil if not compressing
+	queue   *Queue[[]byte]
+	buf     []byte // the framed events, of the sending goroutine
+
+	// sendErr reports the send errors, which don't fail the additions
+	sendErr func(err error, events int)
+}
+
+// New creates a batcher, sending the batches every interval (if any) in the background, until
+// closed.
+func New(cfg Config, send SendFunc) (*Batcher, error) {
+	switch cfg.Framing {
+	case "":
+		cfg.Framing = FramingLength
+	case FramingLength, FramingLines:
+	default:
+		return nil, errfmt.Errorf("unsupported framing: %s", cfg.Framing)
+	}
+
+	b := &Batcher{
+		cfg:     cfg,
+		send:    send,
+		sendErr: logSendError,
+	}
+	switch cfg.Compression {
+	case CompressionNone:
+	case CompressionZstd:
+		var err error
+		b.encoder, err = zstd.NewWriter(nil)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	default:
+		return nil, errfmt.Errorf("unsupported compression: %s", cfg.Compression)
+	}
+
+	framedSize := func(event []byte) int {
+		return frameSize(cfg.Framing, event)
+	}
+	queue, err := NewQueue(QueueConfig{
+		MaxItems:  cfg.MaxEvents,
+		MaxBytes:  cfg.MaxBytes,
+		Interval:  cfg.Interval,
+		QueueSize: cfg.QueueSize,
+	}, framedSize, b.sendEvents)
+	if err != nil {
+		return nil, err
+	}
+	queue.sendErr = func(err error, events int) {
+		b.sendErr(err, events)
+	}
+	b.queue = queue
+
+	return b, nil
+}
+
+func logSendError(err error, events int) {
+	logger.Errorw("Error sending events batch", "events", events, "error", err)
+}
+
+// Add adds an encoded event to the batch, sending the batch first if the event would exceed its
+// maximum size, and then if it reached its maximum number of events or size. The event mustn't
+// be modified once added.
+func (b *Batcher) Add(event []byte) {
+	b.queue.Add(event)
+}
+
+// Flush sends the batch, if not empty.
+func (b *Batcher) Flush() {
+	b.queue.Flush()
+}
+
+// Close stops sending the batches in the background, and sends the last one.
+func (b *Batcher) Close() {
+	b.queue.Close()
+	if b.encoder != nil {
+		_ = b.encoder.Close()
+	}
+}
+
+// sendEvents frames the events of a batch, compresses them if configured, and sends the batch.
+// It is called by the sending goroutine of the queue.
+func (b *Batcher) sendEvents(events [][]byte) error {
+	b.buf = b.buf[:0]
+	for _, event := range events {
+		switch b.cfg.Framing {
+		case FramingLength:
+			b.buf = binary.BigEndian.AppendUint32(b.buf, uint32(len(event)))
+			b.buf = append(b.buf, event...)
+		case FramingLines:
+			b.buf = append(b.buf, event...)
+			b.buf = append(b.buf, '\n')
+		}
+	}
+
+	data := b.buf
+	if b.encoder != nil {
+		data = b.encoder.EncodeAll(b.buf, nil)
+	}
+
+	return b.send(Batch{Data: data, Events: len(events)})
+}
+
+func frameSize(framing string, event []byte) int {
+	if framing == FramingLength {
+		return lengthPrefixSize + len(event)
+	}
+
+	return len(event) + 1
+}
@@ -0,0 +1,190 @@
+package batcher
+
+import (
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the sent batches, decoded.
+type recorder struct {
+	cfg Config
+
+	mu      sync.Mutex
+	batches [][]string
+	raw     [][]byte
+}
+
+func (r *recorder) send(batch Batch) error {
+	events, err := Decode(batch.Data, r.cfg.Compression, r.cfg.Framing)
+	if err != nil {
+		return err
+	}
+	if len(events) != batch.Events {
+		return errors.New("wrong number of events")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	decoded := []string{}
+	for _, event := range events {
+		decoded = append(decoded, string(event))
+	}
+	r.batches = append(r.batches, decoded)
+	r.raw = append(r.raw, append([]byte{}, batch.Data...))
+
+	return nil
+}
+
+func TestBatcherThresholds(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      Config
+		expected [][]string
+	}{
+		{
+			name:     "no batching",
+			cfg:      Config{},
+			expected: [][]string{{"open"}, {"execve"}, {"exit"}, {"clone"}, {"mmap"}},
+		},
+		{
+			name:     "max events",
+			cfg:      Config{MaxEvents: 2},
+			expected: [][]string{{"open", "execve"}, {"exit", "clone"}, {"mmap"}},
+		},
+		{
+			// open and execve are 4+4 and 4+6 bytes framed, exit would make 26 bytes
+			name:     "max bytes",
+			cfg:      Config{MaxEvents: 100, MaxBytes: 20},
+			expected: [][]string{{"open", "execve"}, {"exit", "clone"}, {"mmap"}},
+		},
+		{
+			name:     "max bytes of lines",
+			cfg:      Config{MaxEvents: 100, MaxBytes: 12, Framing: FramingLines},
+			expected: [][]string{{"open", "execve"}, {"exit", "clone"}, {"mmap"}},
+		},
+		{
+			name:     "zstd",
+			cfg:      Config{MaxEvents: 3, Compression: CompressionZstd},
+			expected: [][]string{{"open", "execve", "exit"}, {"clone", "mmap"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &recorder{cfg: tc.cfg}
+			b, err := New(tc.cfg, r.send)
+			require.NoError(t, err)
+			b.sendErr = func(err error, events int) { t.Errorf("sending %d events: %v", events, err) }
+
+			for _, event := range []string{"open", "execve", "exit", "clone", "mmap"} {
+				b.Add([]byte(event))
+			}
+			b.Close() // sends the last batch
+
+			assert.Equal(t, tc.expected, r.batches)
+		})
+	}
+}
+
+func TestBatcherFraming(t *testing.T) {
+	t.Parallel()
+
+	for framing, expected := range map[string]string{
+		FramingLength: "000000026869" + "00000000" + "00000003787878",
+		FramingLines:  "68690a" + "0a" + "7878780a",
+	} {
+		r := &recorder{cfg: Config{Framing: framing}}
+		b, err := New(Config{MaxEvents: 3, Framing: framing}, r.send)
+		require.NoError(t, err)
+
+		b.Add([]byte("hi"))
+		b.Add([]byte{})
+		b.Add([]byte("xxx"))
+		b.Close()
+
+		require.Len(t, r.raw, 1)
+		assert.Equal(t, expected, hex.EncodeToString(r.raw[0]), framing)
+		assert.Equal(t, [][]string{{"hi", "", "xxx"}}, r.batches)
+	}
+}
+
+func TestBatcherInterval(t *testing.T) {
+	t.Parallel()
+
+	sent := make(chan Batch, 1)
+	b, err := New(Config{MaxEvents: 100, Interval: 10 * time.Millisecond}, func(batch Batch) error {
+		sent <- Batch{Data: append([]byte{}, batch.Data...), Events: batch.Events}
+		return nil
+	})
+	require.NoError(t, err)
+	defer b.Close()
+
+	b.Add([]byte("open"))
+	b.Add([]byte("execve"))
+
+	select {
+	case batch := <-sent:
+		assert.Equal(t, 2, batch.Events)
+	case <-time.After(5 * time.Second):
+		t.Fatal("batch not sent after its interval")
+	}
+}
+
+func TestBatcherSendError(t *testing.T) {
+	t.Parallel()
+
+	b, err := New(Config{MaxEvents: 2}, func(batch Batch) error { return errors.New("connection refused") })
+	require.NoError(t, err)
+	var failed []int
+	b.sendErr = func(err error, events int) {
+		assert.EqualError(t, err, "connection refused")
+		failed = append(failed, events)
+	}
+
+	b.Add([]byte("open"))
+	b.Add([]byte("execve"))
+	b.Add([]byte("exit"))
+	b.Close()
+
+	assert.Equal(t, []int{2, 1}, failed) // the failed batch isn't sent again
+}
+
+func TestBatcherInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	send := func(Batch) error { return nil }
+
+	_, err := New(Config{Compression: "lz4"}, send)
+	assert.ErrorContains(t, err, "unsupported compression: lz4")
+
+	_, err = New(Config{Framing: "varint"}, send)
+	assert.ErrorContains(t, err, "unsupported framing: varint")
+
+	_, err = New(Config{MaxBytes: -1}, send)
+	assert.ErrorContains(t, err, "invalid batch thresholds")
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	t.Parallel()
+
+	_, err := Decode([]byte{0, 0, 0, 5, 'o', 'p'}, CompressionNone, FramingLength)
+	assert.ErrorContains(t, err, "truncated event: 2 bytes of 5")
+
+	_, err = Decode([]byte{0, 0}, CompressionNone, FramingLength)
+	assert.ErrorContains(t, err, "truncated length prefix")
+
+	_, err = Decode([]byte("open\nexec"), CompressionNone, FramingLines)
+	assert.ErrorContains(t, err, "no newline")
+}
@@ -0,0 +1,60 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Decode returns the events of a batch, decompressing it and splitting its frames, e.g. for the
+// receivers of the batches of an output.
+func Decode(data []byte, compression, framing string) ([][]byte, error) {
+	switch compression {
+	case CompressionNone:
+	case CompressionZstd:
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		defer decoder.Close()
+		data, err = decoder.DecodeAll(data, nil)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+	default:
+		return nil, errfmt.Errorf("unsupported compression: %s", compression)
+	}
+
+	var events [][]byte
+	switch framing {
+	case "", FramingLength:
+		for len(data) > 0 {
+			if len(data) < lengthPrefixSize {
+				return nil, errfmt.Errorf("truncated length prefix: %d bytes", len(data))
+			}
+			size := int(binary.BigEndian.Uint32(data))
+			data = data[lengthPrefixSize:]
+			if len(data) < size {
+				return nil, errfmt.Errorf("truncated event: %d bytes of %d", len(data), size)
+			}
+			events = append(events, data[:size])
+			data = data[size:]
+		}
+	case FramingLines:
+		for len(data) > 0 {
+			line, rest, found := bytes.Cut(data, []byte{'\n'})
+			if !found {
+				return nil, errfmt.Errorf("truncated event: no newline in the last %d bytes", len(data))
+			}
+			events = append(events, line)
+			data = rest
+		}
+	default:
+		return nil, errfmt.Errorf("unsupported framing: %s", framing)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,179 @@
+package batcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// DefaultQueueSize is the number of batches waiting to be sent, if not configured.
+const DefaultQueueSize = 8
+
+// QueueConfig is the configuration of a queue. The zero value sends each item in its own
+// batch.
+type QueueConfig struct {
+	MaxItems  int           // items after which the batch is sent (1 if 0)
+	MaxBytes  int           // bytes (of the size function) after which the batch is sent (0 disables)
+	Interval  time.Duration // maximum duration an item is batched for (0 disables)
+	QueueSize int           // batches waiting to be sent (DefaultQueueSize if 0)
+}
+
+// Queue batches items (e.g. the records of an output), and hands the full batches through a
+// bounded queue to a goroutine sending them with its send function, one at a time and in
+// order: adding items doesn't wait for the sends. When the queue is full (the destination being
+// slower than the events, or unreachable), the batches are dropped instead of slowing the
+// events pipeline down. Queue is thread-safe.
+type Queue[T any] struct {
+	cfg  QueueConfig
+	size func(T) int // nil without MaxBytes
+	send func([]T) error
+
+	mu      sync.Mutex
+	items   []T
+	bytes   int
+	dropped int // items dropped since the last report
+	batches chan []T
+	done    chan struct{}
+	wg      sync.WaitGroup // flushing goroutine
+	sent    sync.WaitGroup // sending goroutine
+
+	// sendErr reports the send errors, which don't fail the additions
+	sendErr func(err error, items int)
+}
+
+// NewQueue creates a queue, sending the batches every interval (if any) in the background, until
+// closed. The size function returns the size of an item, counted against MaxBytes.
+func NewQueue[T any](cfg QueueConfig, size func(T) int, send func([]T) error) (*Queue[T], error) {
+	if cfg.MaxItems < 0 || cfg.MaxBytes < 0 || cfg.Interval < 0 || cfg.QueueSize < 0 {
+		return nil, errfmt.Errorf("invalid batch thresholds: %d events, %d bytes, %v, %d batches", cfg.MaxItems, cfg.MaxBytes, cfg.Interval, cfg.QueueSize)
+	}
+	if cfg.MaxItems == 0 {
+		cfg.MaxItems = 1
+	}
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = DefaultQueueSize
+	}
+	if cfg.MaxBytes > 0 && size == nil {
+		return nil, errfmt.Errorf("batch bytes threshold without size function")
+	}
+
+	q := &Queue[T]{
+		cfg:     cfg,
+		size:    size,
+		send:    send,
+		batches: make(chan []T, cfg.QueueSize),
+		done:    make(chan struct{}),
+		sendErr: logSendError,
+	}
+
+	q.sent.Add(1)
+	go func() {
+		defer q.sent.Done()
+
+		for batch := range q.batches {
+			if err := q.send(batch); err != nil {
+				q.sendErr(err, len(batch))
+			}
+		}
+	}()
+
+	if cfg.Interval > 0 {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+
+			ticker := time.NewTicker(cfg.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					q.Flush()
+				case <-q.done:
+					return
+				}
+			}
+		}()
+	}
+
+	return q, nil
+}
+
+// Add adds an item to the batch, queuing the batch first if the item would exceed its maximum
+// size, and then if it reached its maximum number of items or size.
+func (q *Queue[T]) Add(item T) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	size := 0
+	if q.cfg.MaxBytes > 0 {
+		size = q.size(item)
+		if len(q.items) > 0 && q.bytes+size > q.cfg.MaxBytes {
+			q.flush()
+		}
+	}
+
+	q.items = append(q.items, item)
+	q.bytes += size
+
+	if len(q.items) >= q.cfg.MaxItems || (q.cfg.MaxBytes > 0 && q.bytes >= q.cfg.MaxBytes) {
+		q.flush()
+	}
+}
+
+// Flush queues the batch, if not empty.
+func (q *Queue[T]) Flush() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.flush()
+}
+
+// Close stops sending the batches in the background, queues the last one (waiting for room in
+// the queue), and waits for the queued batches to be sent.
+func (q *Queue[T]) Close() {
+	close(q.done)
+	q.wg.Wait()
+
+	q.mu.Lock()
+	last := q.items
+	q.items = nil
+	q.mu.Unlock()
+
+	if len(last) > 0 {
+		q.batches <- last
+	}
+	close(q.batches)
+	q.sent.Wait()
+
+	q.mu.Lock()
+	q.reportDropped()
+	q.mu.Unlock()
+}
+
+// flush queues the batch, dropping it if the queue is full. It is called with the lock held.
+func (q *Queue[T]) flush() {
+	if len(q.items) == 0 {
+		return
+	}
+
+	select {
+	case q.batches <- q.items:
+		q.reportDropped()
+	default:
+		q.dropped += len(q.items)
+	}
+
+	q.items = nil // owned by the sending goroutine
+	q.bytes = 0
+}
+
+// reportDropped logs the items dropped since the last report, if any.
+func (q *Queue[T]) reportDropped() {
+	if q.dropped > 0 {
+		logger.Warnw("Dropped events, the destination being slower than the events", "events", q.dropped)
+		q.dropped = 0
+	}
+}
@@ -0,0 +1,81 @@
+package batcher
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueMaxBytes(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var batches [][]string
+	send := func(batch []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+		return nil
+	}
+
+	size := func(s string) int { return len(s) }
+	q, err := NewQueue(QueueConfig{MaxItems: 100, MaxBytes: 10}, size, send)
+	require.NoError(t, err)
+
+	for _, s := range []string{"open", "execve", "exit", "clone", "mmap", "close"} {
+		q.Add(s)
+	}
+	q.Close()
+
+	assert.Equal(t, [][]string{{"open", "execve"}, {"exit", "clone"}, {"mmap", "close"}}, batches)
+}
+
+func TestQueueFull(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var batches [][]int
+	send := func(batch []int) error {
+		if len(batches) == 0 {
+			close(started)
+			<-release
+		}
+		batches = append(batches, batch) // only the sending goroutine
+		return nil
+	}
+
+	q, err := NewQueue(QueueConfig{QueueSize: 1}, nil, send)
+	require.NoError(t, err)
+
+	// the first batch is being sent, the second one is queued, and the next ones are dropped
+	// without waiting for the send
+	q.Add(1)
+	<-started
+	q.Add(2)
+	q.Add(3)
+	q.Add(4)
+
+	q.mu.Lock()
+	assert.Equal(t, 2, q.dropped)
+	q.mu.Unlock()
+
+	close(release)
+	q.Close()
+
+	assert.Equal(t, [][]int{{1}, {2}}, batches)
+}
+
+func TestQueueInvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	send := func([]int) error { return nil }
+
+	_, err := NewQueue(QueueConfig{QueueSize: -1}, nil, send)
+	assert.ErrorContains(t, err, "invalid batch thresholds")
+
+	_, err = NewQueue(QueueConfig{MaxBytes: 100}, nil, send)
+	assert.ErrorContains(t, err, "batch bytes threshold without size function")
+}
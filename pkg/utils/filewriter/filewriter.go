@@ -0,0 +1,289 @@
+// Package filewriter implements a file writer with optional compression, rotation (by size
+// and/or time) and retention of the rotated files, used by file based outputs.
+package filewriter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+// Compression algorithms
+const (
+	CompressionNone = ""
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// rotatedTimeFormat is the format of the timestamp added to the name of rotated files.
+const rotatedTimeFormat = "20060102T150405.000"
+
+// Config is the configuration of a file writer. The zero value writes plain files, never
+// rotated.
+type Config struct {
+	Compression    string        // CompressionNone, CompressionGzip or CompressionZstd
+	RotateSize     int64         // size, in bytes, after which the file is rotated (0 disables)
+	RotateInterval time.Duration // interval after which the file is rotated (0 disables)
+	MaxBackups     int           // max number of rotated files kept (0 keeps all)
+	MaxAge         time.Duration // max age of rotated files kept (0 keeps all)
+	Append         bool          // append to the file if it exists, instead of truncating it
+}
+
+// Enabled returns true if the configuration requires more than writing a plain file.
+func (c Config) Enabled() bool {
+	return c != Config{}
+}
+
+// Writer is an io.WriteCloser writing to a file, compressing and rotating it according to
+// its configuration. Rotated files are renamed after the time of their rotation (e.g.
+// events-20240102T150405.000.json.gz for events.json.gz). Writer is thread-safe.
+type Writer struct {
+	mutex    sync.Mutex
+	path     string
+	cfg      Config
+	file     *os.File
+	counter  *countingWriter
+	out      io.Writer // the compressor, or the counter if not compressing
+	openedAt time.Time
+	now      func() time.Time
+
+	// rotateErr reports the rotation errors, which don't fail the writes
+	rotateErr func(error)
+}
+
+// New creates a writer for the given path, truncating the file if it exists (unless appending).
+func New(path string, cfg Config) (*Writer, error) {
+	switch cfg.Compression {
+	case CompressionNone, CompressionGzip, CompressionZstd:
+	default:
+		return nil, errfmt.Errorf("unsupported compression: %s", cfg.Compression)
+	}
+
+	w := &Writer{
+		path:      path,
+		cfg:       cfg,
+		now:       time.Now,
+		rotateErr: logRotateError,
+	}
+	flag := os.O_TRUNC
+	if cfg.Append {
+		flag = os.O_APPEND
+	}
+	if err := w.open(flag); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Write writes to the current file, rotating it first if needed. If the rotation fails,
+// the error is reported apart (logged by default) and p is appended to the current file.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// the file couldn't be reopened after a failed rotation
+	if w.file == nil {
+		if err := w.open(os.O_APPEND); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate() {
+		if err := w.rotate(); err != nil {
+			w.rotateErr(err)
+			// the current file couldn't be reopened either
+			if w.file == nil {
+				return 0, err
+			}
+		}
+	}
+
+	return w.out.Write(p)
+}
+
+func logRotateError(err error) {
+	logger.Errorw("Rotating file", "error", err)
+}
+
+// Close flushes the compressor, if any, and closes the current file.
+func (w *Writer) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	return w.close()
+}
+
+func (w *Writer) shouldRotate() bool {
+	if w.cfg.RotateSize > 0 && w.counter.written >= w.cfg.RotateSize {
+		return true
+	}
+	if w.cfg.RotateInterval > 0 && w.now().Sub(w.openedAt) >= w.cfg.RotateInterval {
+		return true
+	}
+
+	return false
+}
+
+// open opens the writer path for writing, either truncating it (os.O_TRUNC) or appending
+// to it (os.O_APPEND). Compressed streams appended to a file are concatenated, which both
+// gzip and zstd readers support.
+func (w *Writer) open(flag int) error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|flag, 0666)
+	if err != nil {
+		return errfmt.Errorf("failed to create output path: %v", err)
+	}
+
+	w.file = file
+	w.counter = &countingWriter{w: file}
+	w.openedAt = w.now()
+
+	switch w.cfg.Compression {
+	case CompressionGzip:
+		w.out = gzip.NewWriter(w.counter)
+	case CompressionZstd:
+		w.out, err = zstd.NewWriter(w.counter)
+		if err != nil {
+			_ = file.Close()
+			return errfmt.WrapError(err)
+		}
+	default:
+		w.out = w.counter
+	}
+
+	return nil
+}
+
+func (w *Writer) close() error {
+	if closer, ok := w.out.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			_ = w.file.Close()
+			return errfmt.WrapError(err)
+		}
+	}
+
+	return errfmt.WrapError(w.file.Close())
+}
+
+// rotate closes the current file, renames it and opens a new one, then removes the
+// rotated files not to be retained.
+func (w *Writer) rotate() error {
+	if err := w.close(); err != nil {
+		return w.reopen(err)
+	}
+
+	prefix, ext := w.rotatedNameParts()
+	rotated := prefix + w.now().Format(rotatedTimeFormat) + ext
+	if err := os.Rename(w.path, rotated); err != nil {
+		return w.reopen(errfmt.Errorf("failed to rotate %s: %v", w.path, err))
+	}
+
+	if err := w.open(os.O_TRUNC); err != nil {
+		return w.reopen(err)
+	}
+
+	return w.removeExpired()
+}
+
+// reopen reopens the writer path for appending after a failed rotation, so the writer
+// doesn't keep a closed file, returning the rotation error. If it can't be reopened, the
+// next Write tries again.
+func (w *Writer) reopen(rotateErr error) error {
+	if err := w.open(os.O_APPEND); err != nil {
+		w.file, w.counter, w.out = nil, nil, nil
+		return errfmt.Errorf("%v (reopening %s failed: %v)", rotateErr, w.path, err)
+	}
+
+	return rotateErr
+}
+
+// rotatedNameParts returns the prefix and the extension of the rotated files names.
+func (w *Writer) rotatedNameParts() (string, string) {
+	dir, base := filepath.Split(w.path)
+
+	// keep all extensions (e.g. ".json.gz") after the timestamp
+	name, ext := base, ""
+	if i := strings.Index(base[1:], "."); i != -1 {
+		name, ext = base[:i+1], base[i+1:]
+	}
+
+	return filepath.Join(dir, name) + "-", ext
+}
+
+// removeExpired removes the rotated files beyond MaxBackups or older than MaxAge.
+func (w *Writer) removeExpired() error {
+	if w.cfg.MaxBackups == 0 && w.cfg.MaxAge == 0 {
+		return nil
+	}
+
+	rotated, err := w.rotatedFiles()
+	if err != nil {
+		return err
+	}
+
+	// newest first (timestamps sort lexicographically)
+	sort.Sort(sort.Reverse(sort.StringSlice(rotated)))
+
+	for i, path := range rotated {
+		expired := w.cfg.MaxBackups > 0 && i >= w.cfg.MaxBackups
+		if !expired && w.cfg.MaxAge > 0 {
+			info, err := os.Stat(path)
+			expired = err == nil && w.now().Sub(info.ModTime()) > w.cfg.MaxAge
+		}
+		if !expired {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errfmt.Errorf("failed to remove rotated file %s: %v", path, err)
+		}
+	}
+
+	return nil
+}
+
+// rotatedFiles returns the paths of the files rotated from the writer path.
+func (w *Writer) rotatedFiles() ([]string, error) {
+	prefix, ext := w.rotatedNameParts()
+
+	matches, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, errfmt.WrapError(err)
+	}
+
+	rotated := make([]string, 0, len(matches))
+	for _, path := range matches {
+		ts := strings.TrimSuffix(strings.TrimPrefix(path, prefix), ext)
+		if _, err := time.Parse(rotatedTimeFormat, ts); err != nil {
+			continue // not a rotated file
+		}
+		rotated = append(rotated, path)
+	}
+
+	return rotated, nil
+}
+
+// countingWriter counts the bytes written to the file (after compression).
+type countingWriter struct {
+	w       io.Writer
+	written int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.written += int64(n)
+	return n, err
+}
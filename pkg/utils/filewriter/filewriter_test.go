@@ -0,0 +1,182 @@
+package filewriter
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterCompression(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		compression string
+		reader      func(r io.Reader) (io.Reader, error)
+	}{
+		{
+			compression: CompressionNone,
+			reader:      func(r io.Reader) (io.Reader, error) { return r, nil },
+		},
+		{
+			compression: CompressionGzip,
+			reader:      func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			compression: CompressionZstd,
+			reader:      func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.compression, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "events.json")
+			w, err := New(path, Config{Compression: tc.compression})
+			require.NoError(t, err)
+
+			_, err = w.Write([]byte("{\"eventName\":\"openat\"}\n"))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			file, err := os.Open(path)
+			require.NoError(t, err)
+			defer file.Close()
+
+			r, err := tc.reader(file)
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, "{\"eventName\":\"openat\"}\n", string(data))
+		})
+	}
+}
+
+func TestWriterUnsupportedCompression(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(filepath.Join(t.TempDir(), "events.json"), Config{Compression: "lz4"})
+	assert.ErrorContains(t, err, "unsupported compression: lz4")
+}
+
+func TestWriterAppend(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path, []byte("{\"eventName\":\"openat\"}\n"), 0600))
+
+	w, err := New(path, Config{Append: true})
+	require.NoError(t, err)
+	_, err = w.Write([]byte("{\"eventName\":\"execve\"}\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"eventName\":\"openat\"}\n{\"eventName\":\"execve\"}\n", string(data))
+
+	// truncated otherwise
+	w, err = New(path, Config{})
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
+
+func TestWriterRotation(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.json")
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	w := &Writer{
+		path: path,
+		cfg:  Config{RotateSize: 10, RotateInterval: time.Hour, MaxBackups: 2},
+		now:  func() time.Time { return now },
+	}
+	require.NoError(t, w.open(os.O_TRUNC))
+
+	write := func(s string) {
+		_, err := w.Write([]byte(s))
+		require.NoError(t, err)
+	}
+
+	write("0123456789") // reaches the rotation size
+	now = now.Add(time.Second)
+	write("a") // rotated by size
+	now = now.Add(time.Hour)
+	write("b") // rotated by time
+	now = now.Add(time.Second)
+	write("0123456789")
+	now = now.Add(time.Second)
+	write("c") // rotated by size, the oldest rotated file is removed
+	require.NoError(t, w.Close())
+
+	rotated, err := w.rotatedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "events-20240102T160406.000.json"),
+		filepath.Join(dir, "events-20240102T160408.000.json"),
+	}, rotated)
+
+	data, err := os.ReadFile(rotated[1])
+	require.NoError(t, err)
+	assert.Equal(t, "b0123456789", string(data))
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "c", string(data))
+}
+
+func TestWriterRotationFailure(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.json")
+
+	now := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	var rotateErrs []error
+	w := &Writer{
+		path:      path,
+		cfg:       Config{RotateSize: 1},
+		now:       func() time.Time { return now },
+		rotateErr: func(err error) { rotateErrs = append(rotateErrs, err) },
+	}
+	require.NoError(t, w.open(os.O_TRUNC))
+
+	// a non empty directory at the rotated file path makes the rename fail
+	rotated := filepath.Join(dir, "events-20240102T150405.000.json")
+	require.NoError(t, os.MkdirAll(filepath.Join(rotated, "dir"), 0755))
+
+	_, err := w.Write([]byte("a"))
+	require.NoError(t, err)
+	require.Empty(t, rotateErrs)
+
+	// the rotation error is reported apart, the data isn't dropped
+	n, err := w.Write([]byte("b"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, rotateErrs, 1)
+	assert.ErrorContains(t, rotateErrs[0], "failed to rotate")
+
+	// writing goes on appending to the current file
+	_, err = w.Write([]byte("c"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", string(data))
+}
@@ -179,6 +179,40 @@ func GetProcNS(pid uint, nsName string) (int, error) {
 	return ns, nil
 }
 
+// HostPid translates the ID of a process in the given pid namespace (its innermost one) to
+// its ID in the host pid namespace, e.g. the pid arguments of the syscalls made by a
+// containerized process. To do so, it scans the /proc file system of the host, and requires
+// the CAP_SYS_PTRACE capability.
+func HostPid(pidNS int, nsPid int) (int, error) {
+	if hostNS, err := GetProcNS(1, "pid"); err == nil && hostNS == pidNS {
+		return nsPid, nil
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0, errfmt.WrapError(err)
+	}
+	for _, entry := range entries {
+		pid, err := strconv.ParseUint(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		ns, err := GetProcNS(uint(pid), "pid")
+		if err != nil || ns != pidNS {
+			continue
+		}
+		status, err := NewProcStatus(int(pid))
+		if err != nil {
+			continue // exited
+		}
+		if status.GetNsPid() == nsPid {
+			return int(pid), nil
+		}
+	}
+
+	return 0, errfmt.Errorf("process %d of pid namespace %d not found", nsPid, pidNS)
+}
+
 func extractNSFromLink(link string) (int, error) {
 	nsLinkSplitted := strings.SplitN(link, ":[", 2)
 	if len(nsLinkSplitted) != 2 {
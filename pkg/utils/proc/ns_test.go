@@ -1,6 +1,7 @@
 package proc
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +53,19 @@ func TestExtractNSFromLink(t *testing.T) {
 		})
 	}
 }
+
+func TestHostPid(t *testing.T) {
+	t.Parallel()
+
+	pid := os.Getpid()
+	pidNS, err := GetProcNS(uint(pid), "pid")
+	require.NoError(t, err)
+
+	// the pids of /proc are the ones of the namespace it was mounted for
+	hostPid, err := HostPid(pidNS, pid)
+	require.NoError(t, err)
+	assert.Equal(t, pid, hostPid)
+
+	_, err = HostPid(0, pid)
+	assert.ErrorContains(t, err, "not found")
+}
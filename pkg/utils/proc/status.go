@@ -109,12 +109,18 @@ func (ps ProcStatus) GetPPid() int {
 
 // GetNsTgid returns thread group ID in the namespace of the process.
 func (ps ProcStatus) GetNsTgid() int {
-	return ps.getInt("NStgid")
+	return ps.getInnermostInt("NStgid")
 }
 
 // GetNsPid returns process ID in the namespace of the process.
 func (ps ProcStatus) GetNsPid() int {
-	return ps.getInt("NSpid")
+	return ps.getInnermostInt("NSpid")
+}
+
+// GetNsPids returns the process IDs in the pid namespaces of the process, from the host one
+// to its own one (the last): a single ID for the processes of the host pid namespace.
+func (ps ProcStatus) GetNsPids() []int {
+	return ps.getInts("NSpid")
 }
 
 // GetNsPPid returns parent process ID in the namespace of the process.
@@ -156,6 +162,29 @@ func (ps ProcStatus) getInt(key string) int {
 	return val
 }
 
+// getInts returns the integer values of the given key, a list of the namespaces IDs.
+func (ps ProcStatus) getInts(key string) []int {
+	var vals []int
+	for _, field := range strings.Fields(ps[key]) {
+		val, err := strconv.Atoi(field)
+		if err != nil {
+			return nil
+		}
+		vals = append(vals, val)
+	}
+	return vals
+}
+
+// getInnermostInt returns the last integer value of the given key, the ID in the innermost
+// namespace of a list of the namespaces IDs.
+func (ps ProcStatus) getInnermostInt(key string) int {
+	vals := ps.getInts(key)
+	if len(vals) == 0 {
+		return 0
+	}
+	return vals[len(vals)-1]
+}
+
 // getInt64 returns int64 value of the given key.
 func (ps ProcStatus) getInt64(key string) int64 {
 	val, err := strconv.ParseInt(ps[key], 10, 64)
@@ -0,0 +1,25 @@
+package proc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProcStatusNsPids(t *testing.T) {
+	t.Parallel()
+
+	// a process of a container, nested in the host pid namespace
+	status := ProcStatus{"Pid": "4242", "NStgid": "4242\t7", "NSpid": "4242\t7"}
+	assert.Equal(t, []int{4242, 7}, status.GetNsPids())
+	assert.Equal(t, 7, status.GetNsPid())
+	assert.Equal(t, 7, status.GetNsTgid())
+
+	// a process of the host pid namespace
+	status = ProcStatus{"Pid": "42", "NStgid": "42", "NSpid": "42"}
+	assert.Equal(t, []int{42}, status.GetNsPids())
+	assert.Equal(t, 42, status.GetNsPid())
+
+	assert.Nil(t, ProcStatus{}.GetNsPids())
+	assert.Equal(t, 0, ProcStatus{}.GetNsPid())
+}
@@ -0,0 +1,116 @@
+package vulndb
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// comparator is a version constraint, e.g. >=1.2.
+type comparator struct {
+	op      string // one of <, <=, >, >=, =
+	version string
+}
+
+// versionRange is a conjunction of version constraints, e.g. >=1.0.2 <1.1.1n.
+type versionRange []comparator
+
+// parseRange parses a space separated list of constraints. A version without an operator
+// is an exact version.
+func parseRange(s string) (versionRange, error) {
+	var r versionRange
+
+	for _, field := range strings.Fields(s) {
+		c := comparator{op: "=", version: field}
+		for _, op := range []string{"<=", ">=", "<", ">", "="} {
+			if strings.HasPrefix(field, op) {
+				c = comparator{op: op, version: field[len(op):]}
+				break
+			}
+		}
+		if c.version == "" || strings.ContainsAny(c.version, "<>=") {
+			return nil, errfmt.Errorf("invalid version constraint %q", field)
+		}
+		r = append(r, c)
+	}
+	if len(r) == 0 {
+		return nil, errfmt.Errorf("empty versions range")
+	}
+
+	return r, nil
+}
+
+func (r versionRange) contains(version string) bool {
+	for _, c := range r {
+		cmp := compareVersions(version, c.version)
+		var ok bool
+		switch c.op {
+		case "<":
+			ok = cmp < 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case ">=":
+			ok = cmp >= 0
+		case "=":
+			ok = cmp == 0
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compareVersions compares two dotted versions, segment by segment: the numeric prefix of
+// the segments is compared as a number, and the rest as a string (e.g. 1.1.1n > 1.1.1m >
+// 1.1.1 > 1.1). It returns -1, 0 or 1.
+func compareVersions(a, b string) int {
+	as := strings.Split(a, ".")
+	bs := strings.Split(b, ".")
+
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		if i >= len(as) {
+			return -1
+		}
+		if i >= len(bs) {
+			return 1
+		}
+		if cmp := compareSegments(as[i], bs[i]); cmp != 0 {
+			return cmp
+		}
+	}
+
+	return 0
+}
+
+func compareSegments(a, b string) int {
+	an, arest := splitNumber(a)
+	bn, brest := splitNumber(b)
+
+	switch {
+	case an < bn:
+		return -1
+	case an > bn:
+		return 1
+	}
+
+	return strings.Compare(arest, brest)
+}
+
+// splitNumber splits a version segment into its numeric prefix (-1 if none) and the rest.
+func splitNumber(s string) (int, string) {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	n, err := strconv.Atoi(s[:i])
+	if err != nil {
+		return -1, s
+	}
+
+	return n, s[i:]
+}
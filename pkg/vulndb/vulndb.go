@@ -0,0 +1,164 @@
+// Package vulndb matches the libraries loaded at runtime against a local vulnerability
+// database snapshot, so the vulnerabilities of the libraries actually loaded can be
+// prioritized over the ones of the libraries only shipped in an image.
+package vulndb
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// Config is the vulnerability database configuration.
+type Config struct {
+	Files []string // database snapshot files (matching disabled if empty)
+}
+
+// Vulnerability is a vulnerability of a library.
+type Vulnerability struct {
+	ID       string   `json:"id"`       // e.g. CVE-2022-0778
+	Library  string   `json:"library"`  // library file name, without the .so and version suffixes (e.g. libcrypto)
+	Affected []string `json:"affected"` // affected versions ranges (e.g. ">=1.0.2 <1.1.1n"), all versions if empty
+	Severity string   `json:"severity"` // e.g. critical, high, medium or low
+}
+
+// snapshot is a vulnerability database snapshot file.
+type snapshot struct {
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// entry is a vulnerability, with its affected versions ranges parsed.
+type entry struct {
+	Vulnerability
+	ranges []versionRange
+}
+
+// DB is a vulnerability database, loaded from snapshot files. It is read-only once loaded,
+// and safe for concurrent use.
+type DB struct {
+	libraries map[string][]entry // by library name
+	len       int
+}
+
+// Load loads the vulnerability database snapshot files.
+func Load(files []string) (*DB, error) {
+	db := &DB{libraries: make(map[string][]entry)}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		var snap snapshot
+		if err := json.Unmarshal(data, &snap); err != nil {
+			return nil, errfmt.Errorf("parsing vulnerability database %s: %v", file, err)
+		}
+
+		for i, vuln := range snap.Vulnerabilities {
+			if vuln.ID == "" || vuln.Library == "" {
+				return nil, errfmt.Errorf("vulnerability database %s: vulnerability %d: id and library expected", file, i)
+			}
+			e := entry{Vulnerability: vuln}
+			for _, affected := range vuln.Affected {
+				r, err := parseRange(affected)
+				if err != nil {
+					return nil, errfmt.Errorf("vulnerability database %s: %s: %v", file, vuln.ID, err)
+				}
+				e.ranges = append(e.ranges, r)
+			}
+			name := strings.ToLower(vuln.Library)
+			db.libraries[name] = append(db.libraries[name], e)
+			db.len++
+		}
+	}
+
+	return db, nil
+}
+
+// Len returns the number of vulnerabilities of the database.
+func (db *DB) Len() int {
+	return db.len
+}
+
+// Match returns the vulnerabilities of a library version, ordered by id. Vulnerabilities
+// with affected versions ranges don't match unknown (empty) versions.
+func (db *DB) Match(library, version string) []Vulnerability {
+	var matches []Vulnerability
+	for _, e := range db.libraries[strings.ToLower(library)] {
+		if e.affects(version) {
+			matches = append(matches, e.Vulnerability)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ID < matches[j].ID
+	})
+
+	return matches
+}
+
+func (e *entry) affects(version string) bool {
+	if len(e.ranges) == 0 {
+		return true
+	}
+	if version == "" {
+		return false
+	}
+	for _, r := range e.ranges {
+		if r.contains(version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// versionedName matches a versioned shared object file name: libname-1.2.so.
+var versionedName = regexp.MustCompile(`^(.+?)-([0-9][0-9A-Za-z.]*)\.so$`)
+
+// ParseLibrary returns the library name and version of a shared object path, from its
+// file name: libname.so.1.2 (the version suffix, usually the soname version), or
+// libname-1.2.so. The version is empty if the file name isn't versioned.
+func ParseLibrary(path string) (name, version string) {
+	base := filepath.Base(path)
+
+	if name, version, found := strings.Cut(base, ".so."); found {
+		return name, version
+	}
+	if m := versionedName.FindStringSubmatch(base); m != nil {
+		return m[1], m[2]
+	}
+
+	return strings.TrimSuffix(base, ".so"), ""
+}
+
+// HighestSeverity returns the highest severity of the vulnerabilities.
+func HighestSeverity(vulns []Vulnerability) string {
+	highest := ""
+	for _, vuln := range vulns {
+		if severityRank(vuln.Severity) > severityRank(highest) {
+			highest = strings.ToLower(vuln.Severity)
+		}
+	}
+
+	return highest
+}
+
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case "critical":
+		return 4
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	}
+
+	return 0
+}
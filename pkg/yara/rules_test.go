@@ -0,0 +1,320 @@
+package yara
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func matchedRules(matches []Match) []string {
+	var names []string
+	for _, m := range matches {
+		names = append(names, m.Rule)
+	}
+
+	return names
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	rules, err := Compile(`
+/* test rules */
+rule text_string : tag1 tag2 {
+	meta:
+		description = "text string"
+		severity = 3
+		enabled = true
+	strings:
+		$a = "evil payload" // a comment
+		$b = "unmatched"
+	condition:
+		$a or $b
+}
+
+rule nocase_wide {
+	strings:
+		$a = "MiNeR" nocase wide
+	condition:
+		any of them
+}
+
+rule fullword {
+	strings:
+		$a = "xmrig" fullword
+	condition:
+		$a
+}
+
+rule hex_string {
+	strings:
+		$mz = { 4D 5A }
+		$code = { E8 ?? ?? [2-4] ( C3 | C2 ?0 00 ) }
+	condition:
+		$mz at 0 and $code
+}
+
+rule regexp_string {
+	strings:
+		$url = /https?:\/\/[a-z]+\.onion/i
+	condition:
+		#url >= 2
+}
+
+rule header {
+	condition:
+		uint16(0) == 0x5A4D and uint32be(0) != 0 and filesize < 1KB
+}
+
+private rule base {
+	strings:
+		$a = "evil"
+	condition:
+		$a
+}
+
+rule derived {
+	condition:
+		base and not fullword
+}
+
+rule quantifiers {
+	strings:
+		$s1 = "one"
+		$s2 = "two"
+		$s3 = "three"
+		$x = "never"
+	condition:
+		2 of ($s*) and none of ($x) and all of ($s1, $s2)
+}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, 9, rules.Len())
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{
+			name:     "text",
+			data:     "MZ\xe8\x00\x00\x90\x90\xc2\x10\x00 an evil payload",
+			expected: []string{"text_string", "hex_string", "header", "derived"},
+		},
+		{
+			name:     "nocase wide",
+			data:     "m\x00i\x00n\x00e\x00R\x00",
+			expected: []string{"nocase_wide"},
+		},
+		{
+			name:     "fullword",
+			data:     "run xmrig now",
+			expected: []string{"fullword"},
+		},
+		{
+			name:     "not fullword",
+			data:     "runxmrig2 evil",
+			expected: []string{"derived"},
+		},
+		{
+			name:     "regexp",
+			data:     "HTTP://abc.onion and https://def.ONION",
+			expected: []string{"regexp_string"},
+		},
+		{
+			name:     "single regexp match",
+			data:     "http://abc.onion",
+			expected: nil,
+		},
+		{
+			name:     "quantifiers",
+			data:     "one two",
+			expected: []string{"quantifiers"},
+		},
+		{
+			name:     "hex jump too long",
+			data:     "MZ\xe8\x00\x00\x90\x90\x90\x90\x90\xc3",
+			expected: []string{"header"},
+		},
+		{
+			name:     "empty",
+			data:     "",
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, matchedRules(rules.Scan([]byte(tc.data))))
+		})
+	}
+
+	matches := rules.Scan([]byte("evil payload"))
+	require.Len(t, matches, 2)
+	assert.Equal(t, Match{
+		Rule:    "text_string",
+		Tags:    []string{"tag1", "tag2"},
+		Meta:    map[string]string{"description": "text string", "severity": "3", "enabled": "true"},
+		Strings: []string{"$a"},
+	}, matches[0])
+}
+
+func TestCompileErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			name:     "import",
+			source:   `import "pe"`,
+			expected: "import isn't supported",
+		},
+		{
+			name:     "undefined string",
+			source:   `rule a { strings: $a = "a" condition: $b }`,
+			expected: `undefined string "$b"`,
+		},
+		{
+			name:     "unknown identifier",
+			source:   `rule a { condition: is_dll }`,
+			expected: `unknown identifier "is_dll"`,
+		},
+		{
+			name:     "module",
+			source:   `rule a { condition: pe.is_dll }`,
+			expected: `modules aren't supported ("pe.is_dll")`,
+		},
+		{
+			name:     "unreferenced string",
+			source:   `rule a { strings: $a = "a" $b = "b" condition: $a }`,
+			expected: `unreferenced string "$b"`,
+		},
+		{
+			name:     "for loop",
+			source:   `rule a { strings: $a = "a" condition: for any i in (1..#a): (@a[i] < 10) }`,
+			expected: `"for" loops aren't supported`,
+		},
+		{
+			name:     "text operand",
+			source:   `rule a { condition: "abc" contains "b" }`,
+			expected: "text operands (and their operators) aren't supported",
+		},
+		{
+			name:     "arithmetic",
+			source:   `rule a { condition: filesize - 1 > 10 }`,
+			expected: "arithmetic and bitwise operators aren't supported",
+		},
+		{
+			name:     "string offset",
+			source:   `rule a { strings: $a = "a" condition: $a and @a[1] == 0 }`,
+			expected: "string offsets (@a[i]) aren't supported",
+		},
+		{
+			name:     "string range",
+			source:   `rule a { strings: $a = "a" condition: $a in (0..100) }`,
+			expected: "string ranges ($a in (n..m)) aren't supported",
+		},
+		{
+			name:     "percentage",
+			source:   `rule a { strings: $a = "a" condition: 50% of them }`,
+			expected: "percentages of strings aren't supported",
+		},
+		{
+			name:     "of in range",
+			source:   `rule a { strings: $a = "a" condition: any of them in (0..100) }`,
+			expected: `"in" after a string set isn't supported`,
+		},
+		{
+			name:     "hex not",
+			source:   `rule a { strings: $a = { 4D ~00 } condition: $a }`,
+			expected: "the not operator (~) isn't supported",
+		},
+		{
+			name:     "regexp flags group",
+			source:   `rule a { strings: $a = /(?i)abc/ condition: $a }`,
+			expected: "groups with flags ((?...)) aren't supported",
+		},
+		{
+			name:     "regexp unicode class",
+			source:   `rule a { strings: $a = /\pL+/ condition: $a }`,
+			expected: `the \p escape sequence isn't supported`,
+		},
+		{
+			name:     "unsupported modifier",
+			source:   `rule a { strings: $a = "a" xor condition: $a }`,
+			expected: `string modifier "xor" isn't supported`,
+		},
+		{
+			name:     "invalid hex",
+			source:   `rule a { strings: $a = { 4D 5G } condition: $a }`,
+			expected: "invalid hex string",
+		},
+		{
+			name:     "hex starting with a jump",
+			source:   `rule a { strings: $a = { [2] 4D } condition: $a }`,
+			expected: "starting or ending with a jump",
+		},
+		{
+			name:     "duplicate rule",
+			source:   `rule a { condition: true } rule a { condition: false }`,
+			expected: `duplicate rule "a"`,
+		},
+		{
+			name:     "missing condition",
+			source:   `rule a { strings: $a = "a" }`,
+			expected: "has no condition",
+		},
+		{
+			name:     "unterminated string",
+			source:   "rule a { strings: $a = \"a\n condition: $a }",
+			expected: "line 1: unterminated string",
+		},
+		{
+			name:     "quantifier",
+			source:   `rule a { strings: $a = "a" condition: 2 of them }`,
+			expected: "2 of 1 strings can't match",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Compile(tc.source)
+			assert.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.yar"), []byte(`rule a { condition: true }`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yara"), []byte(`rule b { condition: true }`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte(`not rules`), 0o600))
+	single := filepath.Join(t.TempDir(), "c.rules")
+	require.NoError(t, os.WriteFile(single, []byte(`rule c { condition: true }`), 0o600))
+
+	rules, err := Load([]string{dir, single})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, matchedRules(rules.Scan(nil)))
+
+	duplicate := filepath.Join(t.TempDir(), "a.yar")
+	require.NoError(t, os.WriteFile(duplicate, []byte(`rule a { condition: false }`), 0o600))
+	_, err = Load([]string{dir, duplicate})
+	assert.ErrorContains(t, err, `duplicate rule "a"`)
+
+	_, err = Load([]string{filepath.Join(dir, "missing.yar")})
+	assert.ErrorContains(t, err, "no such file or directory")
+}
@@ -0,0 +1,154 @@
+package yara
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tracee/pkg/logger"
+)
+
+const (
+	// DefaultSettle is the default time an artifact must be left unmodified before it is
+	// scanned, as captured files are written in chunks.
+	DefaultSettle = 2 * time.Second
+	// DefaultMaxSize is the default maximum size of the scanned artifacts.
+	DefaultMaxSize = 64 * 1024 * 1024
+
+	resultsBuffer = 1024
+)
+
+// Artifact is a captured file or memory dump.
+type Artifact struct {
+	Path        string
+	Type        string // capture type, e.g. write, read, exec, mem, module or bpf
+	PID         int    // host pid of the process the artifact was captured from, if known
+	ContainerID string // empty for the host
+}
+
+// Result is an artifact matching rules.
+type Result struct {
+	Artifact
+	Time    time.Time // scan time
+	Matches []Match
+}
+
+// Config is the YARA scanning configuration.
+type Config struct {
+	Rules   []string      // rule files and directories (scanning disabled if empty)
+	Settle  time.Duration // time an artifact must be left unmodified before it is scanned
+	MaxSize int64         // maximum size of the scanned artifacts
+}
+
+// Scanner scans the submitted artifacts, once they are no longer written to.
+type Scanner struct {
+	rules   *Rules
+	cfg     Config
+	results chan Result
+
+	mu      sync.Mutex
+	pending map[string]pendingArtifact // by path
+}
+
+type pendingArtifact struct {
+	artifact Artifact
+	updated  time.Time
+}
+
+// NewScanner returns a scanner of the given rules.
+func NewScanner(rules *Rules, cfg Config) *Scanner {
+	if cfg.Settle <= 0 {
+		cfg.Settle = DefaultSettle
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = DefaultMaxSize
+	}
+
+	return &Scanner{
+		rules:   rules,
+		cfg:     cfg,
+		results: make(chan Result, resultsBuffer),
+		pending: map[string]pendingArtifact{},
+	}
+}
+
+// Results returns the channel of the artifacts matching rules.
+func (s *Scanner) Results() <-chan Result {
+	return s.results
+}
+
+// Submit schedules the scan of an artifact, postponed if it is submitted again (written to)
+// before being scanned.
+func (s *Scanner) Submit(artifact Artifact) {
+	s.mu.Lock()
+	s.pending[artifact.Path] = pendingArtifact{artifact: artifact, updated: time.Now()}
+	s.mu.Unlock()
+}
+
+// Run scans the settled artifacts until the context is done.
+func (s *Scanner) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Settle / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			for _, artifact := range s.settled(now) {
+				result, ok := s.scan(artifact)
+				if !ok {
+					continue
+				}
+				select {
+				case s.results <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// settled returns (and removes) the pending artifacts not written to since the settle time.
+func (s *Scanner) settled(now time.Time) []Artifact {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var artifacts []Artifact
+	for path, p := range s.pending {
+		if now.Sub(p.updated) >= s.cfg.Settle {
+			artifacts = append(artifacts, p.artifact)
+			delete(s.pending, path)
+		}
+	}
+
+	return artifacts
+}
+
+// scan scans an artifact, returning false if it doesn't match any rule.
+func (s *Scanner) scan(artifact Artifact) (Result, bool) {
+	info, err := os.Stat(artifact.Path)
+	if err != nil {
+		// e.g. renamed once fully captured, the final file being submitted too
+		logger.Debugw("YARA scan", "artifact", artifact.Path, "error", err)
+		return Result{}, false
+	}
+	if info.Size() > s.cfg.MaxSize {
+		logger.Debugw("YARA scan skipped, artifact too large", "artifact", artifact.Path, "size", info.Size())
+		return Result{}, false
+	}
+
+	data, err := os.ReadFile(artifact.Path)
+	if err != nil {
+		logger.Warnw("YARA scan", "artifact", artifact.Path, "error", err)
+		return Result{}, false
+	}
+	matches := s.rules.Scan(data)
+	if len(matches) == 0 {
+		return Result{}, false
+	}
+
+	return Result{Artifact: artifact, Time: time.Now(), Matches: matches}, true
+}
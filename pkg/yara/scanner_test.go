@@ -0,0 +1,64 @@
+package yara
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner(t *testing.T) {
+	t.Parallel()
+
+	rules, err := Compile(`rule miner { strings: $a = "stratum+tcp://" condition: $a }`)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	matching := filepath.Join(dir, "write.dev-1.inode-2")
+	require.NoError(t, os.WriteFile(matching, []byte("pool: stratum+tcp://pool.example:3333"), 0o600))
+	clean := filepath.Join(dir, "write.dev-1.inode-3")
+	require.NoError(t, os.WriteFile(clean, []byte("hello"), 0o600))
+	large := filepath.Join(dir, "write.dev-1.inode-4")
+	require.NoError(t, os.WriteFile(large, []byte("stratum+tcp://pool.example:3333 and more data"), 0o600))
+
+	scanner := NewScanner(rules, Config{Settle: 20 * time.Millisecond, MaxSize: 40})
+	for _, path := range []string{matching, clean, large, filepath.Join(dir, "missing")} {
+		scanner.Submit(Artifact{Path: path, Type: "write", PID: 42, ContainerID: "abc"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scanner.Run(ctx)
+
+	select {
+	case result := <-scanner.Results():
+		assert.Equal(t, Artifact{Path: matching, Type: "write", PID: 42, ContainerID: "abc"}, result.Artifact)
+		assert.Equal(t, []string{"miner"}, matchedRules(result.Matches))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no scan result")
+	}
+
+	select {
+	case result := <-scanner.Results():
+		t.Fatalf("unexpected result for %s", result.Path)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestScannerSettle(t *testing.T) {
+	t.Parallel()
+
+	scanner := NewScanner(&Rules{}, Config{Settle: time.Minute})
+	scanner.Submit(Artifact{Path: "a"})
+
+	now := time.Now()
+	assert.Empty(t, scanner.settled(now))
+	scanner.Submit(Artifact{Path: "a", Type: "write"}) // written again, postponed
+	assert.Empty(t, scanner.settled(now.Add(time.Minute-time.Millisecond)))
+	assert.Equal(t, []Artifact{{Path: "a", Type: "write"}}, scanner.settled(now.Add(2*time.Minute)))
+	assert.Empty(t, scanner.settled(now.Add(3*time.Minute)))
+}
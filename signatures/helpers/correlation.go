@@ -0,0 +1,76 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/tracee/types/detect"
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// Correlation correlates an event with a previous event of the same scope (e.g. the same
+// container) within a time window: the "event B within N seconds of event A" pattern most
+// detections are made of. The previous events are kept in the state store of the signature (see
+// detect.StateStore), and expire with the time window.
+type Correlation struct {
+	state  detect.StateStore
+	name   string
+	scope  detect.StateScope
+	window time.Duration
+}
+
+// NewCorrelation creates a correlation of the events of the given scope within the given time
+// window, using the context all signatures are initialized with. Its name identifies it among the
+// correlations and states of the signature.
+func NewCorrelation(
+	ctx detect.SignatureContext,
+	name string,
+	scope detect.StateScope,
+	window time.Duration,
+) (*Correlation, error) {
+	if ctx.State == nil {
+		return nil, fmt.Errorf("signature context has no state store")
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid correlation %s time window: %v", name, window)
+	}
+
+	return &Correlation{
+		state:  ctx.State,
+		name:   name,
+		scope:  scope,
+		window: window,
+	}, nil
+}
+
+// Mark records the given event as the first event (A) of the correlation in its scope, replacing
+// the previous one.
+func (c *Correlation) Mark(event trace.Event) {
+	c.state.Set(detect.ScopedStateKey(c.scope, event, c.name), event, c.window)
+}
+
+// Match returns the first event (A) of the scope of the given event (B), if it happened within the
+// time window before it. The first event is kept until it expires, so it can be matched by the
+// next events too (see Forget).
+func (c *Correlation) Match(event trace.Event) (trace.Event, bool) {
+	value, ok := c.state.Get(detect.ScopedStateKey(c.scope, event, c.name))
+	if !ok {
+		return trace.Event{}, false
+	}
+	first, ok := value.(trace.Event)
+	if !ok {
+		return trace.Event{}, false
+	}
+
+	elapsed := time.Duration(event.Timestamp - first.Timestamp)
+	if elapsed < 0 || elapsed > c.window {
+		return trace.Event{}, false
+	}
+
+	return first, true
+}
+
+// Forget forgets the first event (A) of the scope of the given event, e.g. once matched.
+func (c *Correlation) Forget(event trace.Event) {
+	c.state.Delete(detect.ScopedStateKey(c.scope, event, c.name))
+}
@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"fmt"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// PIDs are the IDs of a process in the host pid namespace and in its own pid namespace (the
+// same for the processes of the host). The pids of the events context and arguments are host
+// pids, unless named as namespace pids (e.g. the ProcessID field, or the "_ns_pid" arguments).
+type PIDs struct {
+	Host int
+	NS   int
+}
+
+// InHostNS returns true if the IDs are the ones of a process of the host pid namespace, as far
+// as they tell: a containerized process given the same IDs is taken for a host process.
+func (p PIDs) InHostNS() bool {
+	return p.Host == p.NS
+}
+
+// GetEventPIDs returns the IDs of the process of the event.
+func GetEventPIDs(event trace.Event) PIDs {
+	return PIDs{Host: event.HostProcessID, NS: event.ProcessID}
+}
+
+// GetEventThreadPIDs returns the IDs of the thread of the event.
+func GetEventThreadPIDs(event trace.Event) PIDs {
+	return PIDs{Host: event.HostThreadID, NS: event.ThreadID}
+}
+
+// GetEventParentPIDs returns the IDs of the parent process of the event process.
+func GetEventParentPIDs(event trace.Event) PIDs {
+	return PIDs{Host: event.HostParentProcessID, NS: event.ParentProcessID}
+}
+
+// GetArgPIDs returns the IDs of a process referenced by the arguments of an event, given their
+// name prefix: "<prefix>_pid" (host pid) and "<prefix>_ns_pid". For example, "parent" and
+// "child" for sched_process_fork, "target" for process_injection or "killer" for oom_kill.
+func GetArgPIDs(event trace.Event, prefix string) (PIDs, error) {
+	host, err := GetTraceeIntArgumentByName(event, prefix+"_pid")
+	if err != nil {
+		return PIDs{}, fmt.Errorf("no %s host pid in event %s: %v", prefix, event.EventName, err)
+	}
+	ns, err := GetTraceeIntArgumentByName(event, prefix+"_ns_pid")
+	if err != nil {
+		return PIDs{}, fmt.Errorf("no %s namespace pid in event %s: %v", prefix, event.EventName, err)
+	}
+
+	return PIDs{Host: host, NS: ns}, nil
+}
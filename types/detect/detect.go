@@ -27,6 +27,7 @@ type SignatureContext struct {
 	Callback      SignatureHandler
 	Logger        Logger
 	GetDataSource func(namespace string, id string) (DataSource, bool)
+	State         StateStore // states of the signature, kept between events
 }
 
 // SignatureMetadata represents information about the signature
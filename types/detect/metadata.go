@@ -0,0 +1,75 @@
+package detect
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Signature metadata properties mapped to the normalized findings metadata.
+const (
+	PropertySeverity     = "Severity"    // 0 (info) to 4 (critical)
+	PropertyConfidence   = "Confidence"  // low, medium or high
+	PropertyCategory     = "Category"    // ATT&CK tactic, e.g. defense-evasion
+	PropertyTechnique    = "Technique"   // ATT&CK technique name
+	PropertyTechniqueIDs = "external_id" // ATT&CK technique ids, comma separated, e.g. T1622
+)
+
+// severities are the severity names, indexed by the Severity property values.
+var severities = []string{"info", "low", "medium", "high", "critical"}
+
+var confidences = map[string]bool{"low": true, "medium": true, "high": true}
+
+// NormalizedSeverity returns the signature severity name (info, low, medium, high or
+// critical), or an empty string if the Severity property is missing or invalid.
+func (m SignatureMetadata) NormalizedSeverity() string {
+	var severity int64
+	switch v := m.Properties[PropertySeverity].(type) {
+	case int:
+		severity = int64(v)
+	case int64:
+		severity = v
+	case float64: // JSON decoded
+		severity = int64(v)
+	case json.Number: // rego signatures
+		n, err := v.Int64()
+		if err != nil {
+			return ""
+		}
+		severity = n
+	default:
+		return ""
+	}
+
+	if severity < 0 || severity >= int64(len(severities)) {
+		return ""
+	}
+
+	return severities[severity]
+}
+
+// NormalizedConfidence returns the signature confidence (low, medium or high), or an
+// empty string if the Confidence property is missing or invalid.
+func (m SignatureMetadata) NormalizedConfidence() string {
+	confidence, _ := m.Properties[PropertyConfidence].(string)
+	confidence = strings.ToLower(strings.TrimSpace(confidence))
+	if !confidences[confidence] {
+		return ""
+	}
+
+	return confidence
+}
+
+// TechniqueIDs returns the ATT&CK technique ids of the signature, in upper case.
+func (m SignatureMetadata) TechniqueIDs() []string {
+	value, _ := m.Properties[PropertyTechniqueIDs].(string)
+
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		id = strings.ToUpper(strings.TrimSpace(id))
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,71 @@
+package detect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizedSeverity(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		severity interface{}
+		expected string
+	}{
+		{name: "int", severity: 0, expected: "info"},
+		{name: "float", severity: float64(3), expected: "high"},
+		{name: "json number", severity: json.Number("4"), expected: "critical"},
+		{name: "out of range", severity: 5, expected: ""},
+		{name: "negative", severity: -1, expected: ""},
+		{name: "invalid json number", severity: json.Number("high"), expected: ""},
+		{name: "string", severity: "2", expected: ""},
+		{name: "missing", severity: nil, expected: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := SignatureMetadata{Properties: map[string]interface{}{}}
+			if tc.severity != nil {
+				m.Properties[PropertySeverity] = tc.severity
+			}
+			assert.Equal(t, tc.expected, m.NormalizedSeverity())
+		})
+	}
+}
+
+func TestNormalizedConfidence(t *testing.T) {
+	t.Parallel()
+
+	m := SignatureMetadata{Properties: map[string]interface{}{PropertyConfidence: " High"}}
+	assert.Equal(t, "high", m.NormalizedConfidence())
+
+	m.Properties[PropertyConfidence] = "certain"
+	assert.Equal(t, "", m.NormalizedConfidence())
+
+	m.Properties[PropertyConfidence] = 90
+	assert.Equal(t, "", m.NormalizedConfidence())
+
+	assert.Equal(t, "", SignatureMetadata{}.NormalizedConfidence())
+}
+
+func TestTechniqueIDs(t *testing.T) {
+	t.Parallel()
+
+	m := SignatureMetadata{Properties: map[string]interface{}{PropertyTechniqueIDs: "T1622"}}
+	assert.Equal(t, []string{"T1622"}, m.TechniqueIDs())
+
+	m.Properties[PropertyTechniqueIDs] = "t1055.001, T1068,"
+	assert.Equal(t, []string{"T1055.001", "T1068"}, m.TechniqueIDs())
+
+	m.Properties[PropertyTechniqueIDs] = ""
+	assert.Nil(t, m.TechniqueIDs())
+
+	assert.Nil(t, SignatureMetadata{}.TechniqueIDs())
+}
@@ -0,0 +1,72 @@
+package detect
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/aquasecurity/tracee/types/trace"
+)
+
+// StateScope is the scope of a state kept by a signature
+type StateScope uint8
+
+const (
+	StateScopeGlobal    StateScope = iota // shared by all the events
+	StateScopeProcess                     // per process
+	StateScopeContainer                   // per container (the host being a container of its own)
+)
+
+// StateKey identifies a state kept by a signature
+type StateKey struct {
+	Scope   StateScope
+	ScopeID string // process entity id or container id (empty for the global scope)
+	Name    string
+}
+
+// GlobalStateKey returns the key of a state shared by all the events
+func GlobalStateKey(name string) StateKey {
+	return StateKey{Scope: StateScopeGlobal, Name: name}
+}
+
+// ProcessStateKey returns the key of a state of the process of the given event
+func ProcessStateKey(event trace.Event, name string) StateKey {
+	return StateKey{
+		Scope:   StateScopeProcess,
+		ScopeID: strconv.FormatUint(uint64(event.ProcessEntityId), 10),
+		Name:    name,
+	}
+}
+
+// ContainerStateKey returns the key of a state of the container of the given event
+func ContainerStateKey(event trace.Event, name string) StateKey {
+	return StateKey{Scope: StateScopeContainer, ScopeID: event.Container.ID, Name: name}
+}
+
+// ScopedStateKey returns the key of a state of the given scope, of the given event
+func ScopedStateKey(scope StateScope, event trace.Event, name string) StateKey {
+	switch scope {
+	case StateScopeProcess:
+		return ProcessStateKey(event, name)
+	case StateScopeContainer:
+		return ContainerStateKey(event, name)
+	}
+
+	return GlobalStateKey(name)
+}
+
+// StateStore is a keyed state store, for the signatures detecting a sequence of events (e.g. a
+// number of failed setuid calls followed by a successful one) without keeping their own maps.
+// Each signature has its own states, which expire after their TTL (0 for no expiration). The
+// least recently used states are evicted when the store is full. It is safe for concurrent use.
+type StateStore interface {
+	// Get returns the value of the given state, if it exists and didn't expire
+	Get(key StateKey) (interface{}, bool)
+	// Set sets the value of the given state, expiring after the given TTL
+	Set(key StateKey, value interface{}, ttl time.Duration)
+	// Increment increments the counter of the given state and returns its value. A new counter
+	// expires after the given TTL: the TTL isn't extended by the next increments, so it counts
+	// the occurrences within a time window.
+	Increment(key StateKey, ttl time.Duration) int
+	// Delete deletes the given state
+	Delete(key StateKey)
+}
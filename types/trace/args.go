@@ -0,0 +1,105 @@
+package trace
+
+import (
+	"fmt"
+)
+
+// ArgsDecoder decodes the arguments of an event lazily, e.g. from the raw buffer the event was
+// read from. An ArgsDecoder attached to an event may be shared by its copies, so it must be
+// thread-safe.
+type ArgsDecoder interface {
+	// Get returns the argument with the given name, decoding the arguments only up to it. The
+	// returned boolean is false if the event has no such argument.
+	Get(name string) (Argument, bool)
+	// All decodes all the arguments, and returns them ordered according to the event
+	// definition. The returned slice is owned by the caller.
+	All() []Argument
+}
+
+// SetArgsDecoder attaches a lazy decoder of the arguments to the event: until DecodeArgs is
+// called, the arguments are only decoded when accessed with GetArg or the typed accessors, and
+// Args is empty.
+func (e *Event) SetArgsDecoder(decoder ArgsDecoder) {
+	e.argsDecoder = decoder
+	e.Args = nil
+}
+
+// SetArgs sets the arguments of the event, detaching its lazy decoder (if any), e.g. for
+// events built from the copy of another event.
+func (e *Event) SetArgs(args []Argument) {
+	e.argsDecoder = nil
+	e.Args = args
+}
+
+// DecodeArgs decodes the arguments not decoded yet into Args, and detaches the lazy decoder.
+// It must be called before accessing Args directly (e.g. before modifying or outputting the
+// arguments), and is a no-op for events without a lazy decoder.
+func (e *Event) DecodeArgs() {
+	if e.argsDecoder == nil {
+		return
+	}
+	e.Args = e.argsDecoder.All()
+	e.argsDecoder = nil
+}
+
+// GetArg returns the argument with the given name, decoding it if needed. The returned boolean
+// is false if the event has no such argument.
+func (e *Event) GetArg(name string) (Argument, bool) {
+	if e.argsDecoder != nil {
+		return e.argsDecoder.Get(name)
+	}
+	for _, arg := range e.Args {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+
+	return Argument{}, false
+}
+
+// ArgValue returns the value of the argument with the given name, decoding it if needed. It
+// fails if the event has no such argument or if its value isn't of type T.
+func ArgValue[T any](e *Event, name string) (T, error) {
+	var zero T
+
+	arg, ok := e.GetArg(name)
+	if !ok {
+		return zero, fmt.Errorf("argument %s not found", name)
+	}
+	val, ok := arg.Value.(T)
+	if !ok {
+		return zero, fmt.Errorf("argument %s is not of type %T, is of type %T", name, zero, arg.Value)
+	}
+
+	return val, nil
+}
+
+// ArgInt returns the value of an int argument (e.g. a file descriptor).
+func (e *Event) ArgInt(name string) (int32, error) {
+	return ArgValue[int32](e, name)
+}
+
+// ArgUint returns the value of an unsigned int argument (e.g. a device).
+func (e *Event) ArgUint(name string) (uint32, error) {
+	return ArgValue[uint32](e, name)
+}
+
+// ArgLong returns the value of a long argument.
+func (e *Event) ArgLong(name string) (int64, error) {
+	return ArgValue[int64](e, name)
+}
+
+// ArgUlong returns the value of an unsigned long argument (e.g. an inode).
+func (e *Event) ArgUlong(name string) (uint64, error) {
+	return ArgValue[uint64](e, name)
+}
+
+// ArgString returns the value of a string argument (e.g. a path).
+func (e *Event) ArgString(name string) (string, error) {
+	return ArgValue[string](e, name)
+}
+
+// ArgStrings returns the value of a string array argument (e.g. the argv of a process).
+func (e *Event) ArgStrings(name string) ([]string, error) {
+	return ArgValue[[]string](e, name)
+}
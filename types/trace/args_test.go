@@ -0,0 +1,102 @@
+package trace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeArgsDecoder records the arguments decoded lazily.
+type fakeArgsDecoder struct {
+	args    []Argument
+	decoded []string
+}
+
+func (d *fakeArgsDecoder) Get(name string) (Argument, bool) {
+	d.decoded = append(d.decoded, name)
+	for _, arg := range d.args {
+		if arg.Name == name {
+			return arg, true
+		}
+	}
+
+	return Argument{}, false
+}
+
+func (d *fakeArgsDecoder) All() []Argument {
+	d.decoded = append(d.decoded, "*")
+	return append([]Argument(nil), d.args...)
+}
+
+func TestEventArgs(t *testing.T) {
+	t.Parallel()
+
+	args := []Argument{
+		{ArgMeta: ArgMeta{Name: "dirfd", Type: "int"}, Value: int32(-100)},
+		{ArgMeta: ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/shadow"},
+		{ArgMeta: ArgMeta{Name: "flags", Type: "int"}},
+	}
+
+	testCases := []struct {
+		name    string
+		event   func() (*Event, *fakeArgsDecoder)
+		decoded []string
+	}{
+		{
+			name: "decoded arguments",
+			event: func() (*Event, *fakeArgsDecoder) {
+				return &Event{Args: args}, nil
+			},
+		},
+		{
+			name: "lazily decoded arguments",
+			event: func() (*Event, *fakeArgsDecoder) {
+				decoder := &fakeArgsDecoder{args: args}
+				event := &Event{Args: []Argument{{ArgMeta: ArgMeta{Name: "stale"}}}}
+				event.SetArgsDecoder(decoder)
+				return event, decoder
+			},
+			decoded: []string{"pathname", "dirfd", "flags", "dirfd", "nonexistent", "*"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			event, decoder := tc.event()
+
+			pathname, err := event.ArgString("pathname")
+			require.NoError(t, err)
+			assert.Equal(t, "/etc/shadow", pathname)
+
+			dirfd, err := event.ArgInt("dirfd")
+			require.NoError(t, err)
+			assert.Equal(t, int32(-100), dirfd)
+
+			_, err = event.ArgInt("flags")
+			assert.EqualError(t, err, "argument flags is not of type int32, is of type <nil>")
+			_, err = event.ArgUlong("dirfd")
+			assert.EqualError(t, err, "argument dirfd is not of type uint64, is of type int32")
+			_, err = event.ArgInt("nonexistent")
+			assert.EqualError(t, err, "argument nonexistent not found")
+
+			// the arguments set detach the decoder, e.g. in the events derived from a copy
+			copied := *event
+			copied.SetArgs([]Argument{{ArgMeta: ArgMeta{Name: "address"}, Value: uint64(1)}})
+			address, err := copied.ArgUlong("address")
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), address)
+
+			event.DecodeArgs()
+			assert.Equal(t, args, event.Args)
+			event.DecodeArgs() // the decoder is detached once decoded
+			if decoder != nil {
+				assert.Equal(t, tc.decoded, decoder.decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// FieldRenamesV2 maps the JSON field names of the legacy events schema to their stable snake_case
+// name in the v2 schema, whatever struct they are in (e.g. the event, its container or its
+// arguments). The fields missing from the map keep their name.
+var FieldRenamesV2 = map[string]string{
+	// Event
+	"threadStartTime":     "thread_start_time",
+	"processorId":         "processor_id",
+	"processId":           "process_id",
+	"cgroupId":            "cgroup_id",
+	"threadId":            "thread_id",
+	"parentProcessId":     "parent_process_id",
+	"hostProcessId":       "host_process_id",
+	"hostThreadId":        "host_thread_id",
+	"hostParentProcessId": "host_parent_process_id",
+	"userId":              "user_id",
+	"mountNamespace":      "mount_namespace",
+	"pidNamespace":        "pid_namespace",
+	"processName":         "process_name",
+	"hostName":            "host_name",
+	"containerId":         "container_id",
+	"eventId":             "event_id",
+	"eventName":           "event_name",
+	"eventVersion":        "event_version",
+	"sequenceNumber":      "sequence_number",
+	"matchedPolicies":     "matched_policies",
+	"argsNum":             "args_num",
+	"returnValue":         "return_value",
+	"syscallAbi":          "syscall_abi",
+	"stackAddresses":      "stack_addresses",
+	"stackFrames":         "stack_frames",
+	"contextFlags":        "context_flags",
+	"threadEntityId":      "thread_entity_id",
+	"processEntityId":     "process_entity_id",
+	"parentEntityId":      "parent_entity_id",
+	// Container
+	"imageDigest": "image_digest",
+	// Kubernetes
+	"podName":      "pod_name",
+	"podNamespace": "pod_namespace",
+	"podUID":       "pod_uid",
+	"podSandbox":   "pod_sandbox",
+	// StackFrame
+	"buildId": "build_id",
+	// ContextFlags
+	"containerStarted": "container_started",
+	"isCompat":         "is_compat",
+	"initNamespaces":   "init_namespaces",
+	"noNewPrivs":       "no_new_privs",
+	// Argument
+	"originalSize": "original_size",
+	// Metadata
+	"Version":      "version",
+	"Description":  "description",
+	"Tags":         "tags",
+	"Properties":   "properties",
+	"Severity":     "severity",
+	"Confidence":   "confidence",
+	"Mitre":        "mitre",
+	"Tactic":       "tactic",
+	"Technique":    "technique",
+	"TechniqueIDs": "technique_ids",
+}
+
+// timestampFieldsV2 are the (v2) fields of the event holding a time in nanoseconds, rendered as an
+// RFC3339 time, along with the time in nanoseconds in a _nanos suffixed field.
+var timestampFieldsV2 = map[string]bool{"timestamp": true, "thread_start_time": true}
+
+// userDataFields are the fields holding user data (labels, arguments values and metadata
+// properties), left as is in the v2 schema.
+var userDataFields = map[string]bool{"labels": true, "value": true, "Properties": true}
+
+// MarshalJSONV2 returns the JSON encoding of the event in the v2 schema: the field names are
+// snake_case (see FieldRenamesV2), the times are RFC3339 times with nanoseconds (e.g. timestamp),
+// along with their value in nanoseconds (e.g. timestamp_nanos), and the event id is a number. The
+// fields holding user data (labels, arguments values and metadata properties) are left as is.
+//
+// The legacy encoding of the event is streamed to the v2 one, rewriting the field names on the
+// fly, so that both schemas are defined by the same struct tags.
+func (e Event) MarshalJSONV2() ([]byte, error) {
+	legacy, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(legacy))
+	d.UseNumber() // keep the precision of the 64 bits integers (e.g. stack addresses)
+	enc := &encoderV2{dec: d}
+	enc.out.Grow(len(legacy) + len(legacy)/8)
+	if err := enc.value(0); err != nil {
+		return nil, err
+	}
+
+	return enc.out.Bytes(), nil
+}
+
+// encoderV2 rewrites the legacy JSON encoding of an event, read from dec, to its v2 encoding.
+type encoderV2 struct {
+	dec *json.Decoder
+	out bytes.Buffer
+}
+
+// value rewrites the next JSON value, at the given depth (the event being at depth 0).
+func (e *encoderV2) value(depth int) error {
+	tok, err := e.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		return e.object(depth)
+	case json.Delim('['):
+		e.out.WriteByte('[')
+		for i := 0; e.dec.More(); i++ {
+			if i > 0 {
+				e.out.WriteByte(',')
+			}
+			if err := e.value(depth + 1); err != nil {
+				return err
+			}
+		}
+		e.out.WriteByte(']')
+		_, err = e.dec.Token()
+		return err
+	}
+
+	return e.literal(tok)
+}
+
+// object rewrites the fields of an object, after its opening brace.
+func (e *encoderV2) object(depth int) error {
+	e.out.WriteByte('{')
+	for i := 0; e.dec.More(); i++ {
+		tok, err := e.dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+		if i > 0 {
+			e.out.WriteByte(',')
+		}
+		newName := name
+		if renamed, ok := FieldRenamesV2[name]; ok {
+			newName = renamed
+		}
+		if err := e.literal(newName); err != nil {
+			return err
+		}
+		e.out.WriteByte(':')
+
+		switch {
+		case userDataFields[name]:
+			var raw json.RawMessage
+			if err := e.dec.Decode(&raw); err != nil {
+				return err
+			}
+			e.out.Write(raw)
+		case depth == 0 && timestampFieldsV2[newName]:
+			err = e.timestamp(newName)
+		case depth == 0 && newName == "event_id":
+			// the legacy event id is a string
+			if tok, err = e.dec.Token(); err == nil {
+				id, _ := tok.(string)
+				err = e.literal(json.Number(id))
+			}
+		default:
+			err = e.value(depth + 1)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	e.out.WriteByte('}')
+	_, err := e.dec.Token()
+
+	return err
+}
+
+// timestamp rewrites a time in nanoseconds as an RFC3339 time, followed by its _nanos field.
+func (e *encoderV2) timestamp(name string) error {
+	tok, err := e.dec.Token()
+	if err != nil {
+		return err
+	}
+	nanos, _ := tok.(json.Number)
+	ns, err := strconv.ParseInt(string(nanos), 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := e.literal(time.Unix(0, ns).UTC().Format(time.RFC3339Nano)); err != nil {
+		return err
+	}
+	e.out.WriteByte(',')
+	if err := e.literal(name + "_nanos"); err != nil {
+		return err
+	}
+	e.out.WriteByte(':')
+
+	return e.literal(nanos)
+}
+
+// literal writes a string, number, boolean or null.
+func (e *encoderV2) literal(tok json.Token) error {
+	b, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	e.out.Write(b)
+
+	return nil
+}
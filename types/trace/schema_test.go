@@ -0,0 +1,105 @@
+package trace
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventMarshalJSONV2(t *testing.T) {
+	t.Parallel()
+
+	event := Event{
+		Timestamp:       1704207845123456789,
+		ThreadStartTime: 1704207800000000001,
+		ProcessID:       12434,
+		ProcessName:     "cat",
+		Container:       Container{ID: "abc", ImageDigest: "sha256:123"},
+		Labels:          map[string]string{"clusterName": "prod"},
+		EventID:         257,
+		EventName:       "openat",
+		StackAddresses:  []uint64{18446744073709551615},
+		ContextFlags:    ContextFlags{ContainerStarted: true},
+		Args: []Argument{
+			{ArgMeta: ArgMeta{Name: "pathname", Type: "const char*"}, Value: "/etc/shadow", Truncated: true, OriginalSize: 20},
+			{ArgMeta: ArgMeta{Name: "data", Type: "map"}, Value: map[string]string{"podName": "x"}},
+		},
+		Metadata: &Metadata{Severity: "high", Properties: map[string]interface{}{"signatureID": "TRC-1"}},
+	}
+
+	b, err := event.MarshalJSONV2()
+	require.NoError(t, err)
+	// the fields are in the order of the struct
+	assert.True(t, strings.HasPrefix(string(b), `{"timestamp":"2024-01-02T15:04:05.123456789Z","timestamp_nanos":1704207845123456789,"thread_start_time":`), string(b))
+
+	var v2 map[string]interface{}
+	d := json.NewDecoder(strings.NewReader(string(b)))
+	d.UseNumber()
+	require.NoError(t, d.Decode(&v2))
+
+	assert.Equal(t, "2024-01-02T15:04:05.123456789Z", v2["timestamp"])
+	assert.Equal(t, json.Number("1704207845123456789"), v2["timestamp_nanos"])
+	assert.Equal(t, "2024-01-02T15:03:20.000000001Z", v2["thread_start_time"])
+	assert.Equal(t, json.Number("1704207800000000001"), v2["thread_start_time_nanos"])
+	assert.Equal(t, json.Number("257"), v2["event_id"])
+	assert.Equal(t, "openat", v2["event_name"])
+	assert.Equal(t, json.Number("12434"), v2["process_id"])
+	assert.Equal(t, "cat", v2["process_name"])
+	assert.Equal(t, []interface{}{json.Number("18446744073709551615")}, v2["stack_addresses"])
+	assert.Equal(t, map[string]interface{}{"id": "abc", "image_digest": "sha256:123"}, v2["container"])
+	assert.Equal(t, true, v2["context_flags"].(map[string]interface{})["container_started"])
+	// user data is left as is
+	assert.Equal(t, map[string]interface{}{"clusterName": "prod"}, v2["labels"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "pathname", "type": "const char*", "value": "/etc/shadow", "truncated": true, "original_size": json.Number("20")},
+		map[string]interface{}{"name": "data", "type": "map", "value": map[string]interface{}{"podName": "x"}},
+	}, v2["args"])
+	metadata := v2["metadata"].(map[string]interface{})
+	assert.Equal(t, "high", metadata["severity"])
+	assert.Equal(t, map[string]interface{}{"signatureID": "TRC-1"}, metadata["properties"])
+
+	for name := range v2 {
+		assert.Equal(t, strings.ToLower(name), name, "field %s isn't snake_case", name)
+	}
+}
+
+// TestFieldRenamesV2 makes sure the new fields of the events get a v2 name.
+func TestFieldRenamesV2(t *testing.T) {
+	t.Parallel()
+
+	types := []reflect.Type{
+		reflect.TypeOf(Event{}),
+		reflect.TypeOf(Container{}),
+		reflect.TypeOf(Kubernetes{}),
+		reflect.TypeOf(StackFrame{}),
+		reflect.TypeOf(ContextFlags{}),
+		reflect.TypeOf(File{}),
+		reflect.TypeOf(Argument{}),
+		reflect.TypeOf(ArgMeta{}),
+		reflect.TypeOf(Metadata{}),
+		reflect.TypeOf(MitreAttack{}),
+	}
+	for _, typ := range types {
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.Anonymous || !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if renamed, ok := FieldRenamesV2[name]; ok {
+				name = renamed
+			}
+			assert.Equal(t, strings.ToLower(name), name, "%s.%s has no v2 name", typ.Name(), field.Name)
+		}
+	}
+}
@@ -14,41 +14,49 @@ import (
 
 // Event is a single result of an ebpf event process. It is used as a payload later delivered to tracee-rules.
 type Event struct {
-	Timestamp             int          `json:"timestamp"`
-	ThreadStartTime       int          `json:"threadStartTime"`
-	ProcessorID           int          `json:"processorId"`
-	ProcessID             int          `json:"processId"`
-	CgroupID              uint         `json:"cgroupId"`
-	ThreadID              int          `json:"threadId"`
-	ParentProcessID       int          `json:"parentProcessId"`
-	HostProcessID         int          `json:"hostProcessId"`
-	HostThreadID          int          `json:"hostThreadId"`
-	HostParentProcessID   int          `json:"hostParentProcessId"`
-	UserID                int          `json:"userId"`
-	MountNS               int          `json:"mountNamespace"`
-	PIDNS                 int          `json:"pidNamespace"`
-	ProcessName           string       `json:"processName"`
-	Executable            File         `json:"executable"`
-	HostName              string       `json:"hostName"`
-	ContainerID           string       `json:"containerId"`
-	Container             Container    `json:"container,omitempty"`
-	Kubernetes            Kubernetes   `json:"kubernetes,omitempty"`
-	EventID               int          `json:"eventId,string"`
-	EventName             string       `json:"eventName"`
-	PoliciesVersion       uint16       `json:"-"`
-	MatchedPoliciesKernel uint64       `json:"-"`
-	MatchedPoliciesUser   uint64       `json:"-"`
-	MatchedPolicies       []string     `json:"matchedPolicies,omitempty"`
-	ArgsNum               int          `json:"argsNum"`
-	ReturnValue           int          `json:"returnValue"`
-	Syscall               string       `json:"syscall"`
-	StackAddresses        []uint64     `json:"stackAddresses"`
-	ContextFlags          ContextFlags `json:"contextFlags"`
-	ThreadEntityId        uint32       `json:"threadEntityId"`  // thread task unique identifier (*)
-	ProcessEntityId       uint32       `json:"processEntityId"` // process unique identifier (*)
-	ParentEntityId        uint32       `json:"parentEntityId"`  // parent process unique identifier (*)
-	Args                  []Argument   `json:"args"`            // args are ordered according their appearance in the original event
-	Metadata              *Metadata    `json:"metadata,omitempty"`
+	Timestamp             int               `json:"timestamp"`
+	Time                  string            `json:"time,omitempty"` // RFC3339 time of the timestamp, if enabled
+	ThreadStartTime       int               `json:"threadStartTime"`
+	ProcessorID           int               `json:"processorId"`
+	ProcessID             int               `json:"processId"`
+	CgroupID              uint              `json:"cgroupId"`
+	ThreadID              int               `json:"threadId"`
+	ParentProcessID       int               `json:"parentProcessId"`
+	HostProcessID         int               `json:"hostProcessId"`
+	HostThreadID          int               `json:"hostThreadId"`
+	HostParentProcessID   int               `json:"hostParentProcessId"`
+	UserID                int               `json:"userId"`
+	MountNS               int               `json:"mountNamespace"`
+	PIDNS                 int               `json:"pidNamespace"`
+	ProcessName           string            `json:"processName"`
+	Executable            File              `json:"executable"`
+	HostName              string            `json:"hostName"`
+	ContainerID           string            `json:"containerId"`
+	Container             Container         `json:"container,omitempty"`
+	Kubernetes            Kubernetes        `json:"kubernetes,omitempty"`
+	Labels                map[string]string `json:"labels,omitempty"` // agent labels, shared by all events (read only)
+	EventID               int               `json:"eventId,string"`
+	EventName             string            `json:"eventName"`
+	EventVersion          string            `json:"eventVersion,omitempty"`   // semantic version of the event definition
+	SequenceNumber        uint64            `json:"sequenceNumber,omitempty"` // order of the event in the output of the agent
+	PoliciesVersion       uint16            `json:"-"`
+	MatchedPoliciesKernel uint64            `json:"-"`
+	MatchedPoliciesUser   uint64            `json:"-"`
+	MatchedPolicies       []string          `json:"matchedPolicies,omitempty"`
+	ArgsNum               int               `json:"argsNum"`
+	ReturnValue           int               `json:"returnValue"`
+	Syscall               string            `json:"syscall"`
+	SyscallABI            string            `json:"syscallAbi,omitempty"` // x86_64, i386, aarch64 or arm
+	StackAddresses        []uint64          `json:"stackAddresses"`
+	StackFrames           []StackFrame      `json:"stackFrames,omitempty"` // symbolized stack addresses, if enabled
+	ContextFlags          ContextFlags      `json:"contextFlags"`
+	ThreadEntityId        uint32            `json:"threadEntityId"`  // thread task unique identifier (*)
+	ProcessEntityId       uint32            `json:"processEntityId"` // process unique identifier (*)
+	ParentEntityId        uint32            `json:"parentEntityId"`  // parent process unique identifier (*)
+	Args                  []Argument        `json:"args"`            // args are ordered according their appearance in the original event
+	Metadata              *Metadata         `json:"metadata,omitempty"`
+
+	argsDecoder ArgsDecoder // decodes the arguments lazily, until DecodeArgs is called
 }
 
 // (*) For an OS task to be uniquely identified, tracee builds a hash consisting of:
@@ -59,10 +67,19 @@ type Event struct {
 // murmur([]byte) where slice of bytes is a concatenation (not a sum) of the 2 values above.
 
 type Container struct {
-	ID          string `json:"id,omitempty"`
-	Name        string `json:"name,omitempty"`
-	ImageName   string `json:"image,omitempty"`
-	ImageDigest string `json:"imageDigest,omitempty"`
+	ID          string        `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	ImageName   string        `json:"image,omitempty"`
+	ImageDigest string        `json:"imageDigest,omitempty"`
+	Ports       []PortMapping `json:"ports,omitempty"` // published ports, of the network events only
+}
+
+// PortMapping is a port of a container published on a port of the host.
+type PortMapping struct {
+	ContainerPort uint16 `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+	HostIP        string `json:"hostIp,omitempty"`
+	HostPort      uint16 `json:"hostPort"`
 }
 
 type Kubernetes struct {
@@ -72,18 +89,45 @@ type Kubernetes struct {
 	PodSandbox   bool   `json:"podSandbox,omitempty"`
 }
 
+// StackFrame is a symbolized stack frame. An address resolving to inlined functions has a
+// frame per function, from the innermost inlined function to the function it was inlined in.
+type StackFrame struct {
+	Address  uint64 `json:"address"`
+	Function string `json:"function,omitempty"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Inlined  bool   `json:"inlined,omitempty"` // inlined in the function of the next frame
+	Binary   string `json:"binary,omitempty"`  // path of the binary mapping the address
+	BuildID  string `json:"buildId,omitempty"`
+}
+
 // Metadata is a struct that holds metadata about an event
 type Metadata struct {
 	Version     string
 	Description string
 	Tags        []string
 	Properties  map[string]interface{}
+	// normalized from the signature properties, for downstream (e.g. SIEM) mapping
+	Severity   string       `json:",omitempty"` // info, low, medium, high or critical
+	Confidence string       `json:",omitempty"` // low, medium or high
+	Mitre      *MitreAttack `json:",omitempty"`
+}
+
+// MitreAttack is the MITRE ATT&CK mapping of a finding
+type MitreAttack struct {
+	Tactic       string   `json:",omitempty"` // e.g. defense-evasion
+	Technique    string   `json:",omitempty"` // e.g. Debugger Evasion
+	TechniqueIDs []string `json:",omitempty"` // e.g. T1622
 }
 
 // ContextFlags are flags representing event context
 type ContextFlags struct {
 	ContainerStarted bool `json:"containerStarted"`
 	IsCompat         bool `json:"isCompat"`
+	InitNamespaces   bool `json:"initNamespaces"` // in the initial pid, user, uts, ipc and cgroup namespaces
+	Privileged       bool `json:"privileged"`     // CAP_SYS_ADMIN in the effective capabilities
+	Seccomp          bool `json:"seccomp"`        // confined by seccomp (strict or filter mode)
+	NoNewPrivs       bool `json:"noNewPrivs"`     // no_new_privs attribute set
 }
 
 type File struct {
@@ -131,7 +175,9 @@ func (e Event) ToProtocol() protocol.Event {
 // Argument holds the information for one argument
 type Argument struct {
 	ArgMeta
-	Value interface{} `json:"value"`
+	Value        interface{} `json:"value"`
+	Truncated    bool        `json:"truncated,omitempty"`    // the value was cut to the argument size limit
+	OriginalSize int         `json:"originalSize,omitempty"` // size of the value before it was cut, in bytes
 }
 
 // ArgMeta describes an argument
@@ -1749,6 +1795,16 @@ type HiddenKernelModule struct {
 	Address string `json:"address"`
 }
 
+// RecentEvent is an event of a process, reported as the context of what happened to it (e.g.
+// the events preceding its crash).
+type RecentEvent struct {
+	Timestamp   int        `json:"timestamp"`
+	EventName   string     `json:"eventName"`
+	ThreadID    int        `json:"threadId"`
+	ReturnValue int        `json:"returnValue"`
+	Args        []Argument `json:"args"`
+}
+
 // MemProtAlert is an enum of possible messages that can be sent by an event to pass some extra information about the event.
 type MemProtAlert uint32
 